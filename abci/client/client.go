@@ -65,6 +65,21 @@ func NewClient(addr, transport string, mustConnect bool) (client Client, err err
 	return
 }
 
+// NewFridayClient returns a new ABCI client of the specified transport type
+// for the friday consensus, which delivers the txs of a block asynchronously.
+// It returns an error if the transport is not "socket" or "grpc"
+func NewFridayClient(addr, transport string, mustConnect bool) (client Client, err error) {
+	switch transport {
+	case "socket":
+		client = NewFridaySocketClient(addr, mustConnect)
+	case "grpc":
+		client = NewFridayGRPCClient(addr, mustConnect)
+	default:
+		err = fmt.Errorf("Unknown abci transport %s", transport)
+	}
+	return
+}
+
 //----------------------------------------
 
 type Callback func(*types.Request, *types.Response)
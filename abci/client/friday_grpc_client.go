@@ -0,0 +1,107 @@
+package abcicli
+
+import (
+	"sync"
+
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+
+	"github.com/hdac-io/tendermint/abci/types"
+	cmn "github.com/hdac-io/tendermint/libs/common"
+)
+
+var _ Client = (*fridayGRPCClient)(nil)
+
+// fridayGRPCClient is a grpcClient for the friday consensus, which delivers
+// the txs of a block asynchronously: the DeliverTx calls run concurrently and
+// their callbacks are called as they complete, in any order (the responses
+// carry the Index of their tx). The calls after them (e.g. EndBlock) wait for
+// all of them, callbacks included.
+type fridayGRPCClient struct {
+	grpcClient
+
+	deliverTxs sync.WaitGroup // DeliverTx calls in flight
+}
+
+func NewFridayGRPCClient(addr string, mustConnect bool) *fridayGRPCClient {
+	cli := &fridayGRPCClient{
+		grpcClient: grpcClient{
+			addr:        addr,
+			mustConnect: mustConnect,
+		},
+	}
+	cli.BaseService = *cmn.NewBaseService(nil, "fridayGRPCClient", cli)
+	return cli
+}
+
+func (cli *fridayGRPCClient) DeliverTxAsync(params types.RequestDeliverTx) *ReqRes {
+	req := types.ToRequestDeliverTx(params)
+	reqres := NewReqRes(req)
+
+	cli.deliverTxs.Add(1)
+	go func() {
+		defer cli.deliverTxs.Done()
+
+		res, err := cli.client.DeliverTx(context.Background(), req.GetDeliverTx(), grpc.WaitForReady(true))
+		if err != nil {
+			cli.StopForError(err)
+		}
+		response := &types.Response{Value: &types.Response_DeliverTx{DeliverTx: res}}
+		reqres.Response = response // Set response
+		reqres.Done()              // Release waiters
+		reqres.SetDone()           // so reqRes.SetCallback will run the callback
+
+		cli.mtx.Lock()
+		defer cli.mtx.Unlock()
+
+		// Notify client listener if set
+		if cli.resCb != nil {
+			cli.resCb(reqres.Request, response)
+		}
+
+		// Notify reqRes listener if set
+		if cb := reqres.GetCallback(); cb != nil {
+			cb(response)
+		}
+	}()
+
+	return reqres
+}
+
+func (cli *fridayGRPCClient) FlushAsync() *ReqRes {
+	cli.deliverTxs.Wait()
+	return cli.grpcClient.FlushAsync()
+}
+
+func (cli *fridayGRPCClient) EndBlockAsync(params types.RequestEndBlock) *ReqRes {
+	cli.deliverTxs.Wait()
+	return cli.grpcClient.EndBlockAsync(params)
+}
+
+func (cli *fridayGRPCClient) CommitAsync() *ReqRes {
+	cli.deliverTxs.Wait()
+	return cli.grpcClient.CommitAsync()
+}
+
+//----------------------------------------
+
+func (cli *fridayGRPCClient) FlushSync() error {
+	cli.deliverTxs.Wait()
+	return nil
+}
+
+func (cli *fridayGRPCClient) DeliverTxSync(params types.RequestDeliverTx) (*types.ResponseDeliverTx, error) {
+	reqres := cli.DeliverTxAsync(params)
+	reqres.Wait()
+	return reqres.Response.GetDeliverTx(), cli.Error()
+}
+
+func (cli *fridayGRPCClient) CommitSync() (*types.ResponseCommit, error) {
+	reqres := cli.CommitAsync()
+	return reqres.Response.GetCommit(), cli.Error()
+}
+
+func (cli *fridayGRPCClient) EndBlockSync(params types.RequestEndBlock) (*types.ResponseEndBlock, error) {
+	reqres := cli.EndBlockAsync(params)
+	return reqres.Response.GetEndBlock(), cli.Error()
+}
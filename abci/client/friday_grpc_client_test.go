@@ -0,0 +1,105 @@
+package abcicli_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	abcicli "github.com/hdac-io/tendermint/abci/client"
+	abciserver "github.com/hdac-io/tendermint/abci/server"
+	"github.com/hdac-io/tendermint/abci/types"
+	"github.com/hdac-io/tendermint/libs/log"
+)
+
+// slowDeliverTxApp delivers the txs of lower index slower, so the DeliverTx
+// calls running concurrently complete in the reverse order.
+type slowDeliverTxApp struct {
+	types.BaseApplication
+
+	numTxs      int32
+	inFlight    int32
+	maxInFlight int32
+	delivered   int32
+	// the txs delivered when EndBlock was called
+	deliveredAtEndBlock int32
+}
+
+func (app *slowDeliverTxApp) DeliverTx(req types.RequestDeliverTx) types.ResponseDeliverTx {
+	inFlight := atomic.AddInt32(&app.inFlight, 1)
+	for {
+		max := atomic.LoadInt32(&app.maxInFlight)
+		if inFlight <= max || atomic.CompareAndSwapInt32(&app.maxInFlight, max, inFlight) {
+			break
+		}
+	}
+	time.Sleep(time.Duration(app.numTxs-req.Index) * 10 * time.Millisecond)
+	atomic.AddInt32(&app.inFlight, -1)
+	atomic.AddInt32(&app.delivered, 1)
+	return types.ResponseDeliverTx{Data: req.Tx, Index: req.Index}
+}
+
+func (app *slowDeliverTxApp) EndBlock(req types.RequestEndBlock) types.ResponseEndBlock {
+	atomic.StoreInt32(&app.deliveredAtEndBlock, atomic.LoadInt32(&app.delivered))
+	return types.ResponseEndBlock{}
+}
+
+func TestFridayGRPCClientDeliverTxConcurrently(t *testing.T) {
+	dir, err := ioutil.TempDir("", "friday_grpc_client")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	addr := "unix://" + filepath.Join(dir, "abci.sock")
+
+	const numTxs = 10
+	app := &slowDeliverTxApp{numTxs: numTxs}
+	server := abciserver.NewGRPCServer(addr, types.NewGRPCApplication(app))
+	server.SetLogger(log.TestingLogger().With("module", "abci-server"))
+	require.NoError(t, server.Start())
+	defer server.Stop()
+
+	c := abcicli.NewFridayGRPCClient(addr, true)
+	c.SetLogger(log.TestingLogger().With("module", "abci-client"))
+	require.NoError(t, c.Start())
+	defer c.Stop()
+
+	var order []int32
+	results := make([][]byte, numTxs)
+	c.SetResponseCallback(func(req *types.Request, res *types.Response) {
+		if r, ok := res.Value.(*types.Response_DeliverTx); ok {
+			order = append(order, r.DeliverTx.Index)
+			results[r.DeliverTx.Index] = r.DeliverTx.Data
+		}
+	})
+	reqreses := make([]*abcicli.ReqRes, numTxs)
+	for i := 0; i < numTxs; i++ {
+		reqreses[i] = c.DeliverTxAsync(types.RequestDeliverTx{Tx: []byte{byte(i)}, Index: int32(i)})
+	}
+	// waits for all the DeliverTx calls and their callbacks
+	_, err = c.EndBlockSync(types.RequestEndBlock{})
+	require.NoError(t, err)
+	assert.EqualValues(t, numTxs, atomic.LoadInt32(&app.deliveredAtEndBlock))
+
+	require.Len(t, order, numTxs)
+	assert.NotEqual(t, int32(0), order[0], "the DeliverTx calls didn't run concurrently")
+	assert.True(t, atomic.LoadInt32(&app.maxInFlight) > 1)
+	for i := 0; i < numTxs; i++ {
+		assert.Equal(t, []byte{byte(i)}, results[i])
+		assert.Equal(t, []byte{byte(i)}, reqreses[i].Response.GetDeliverTx().Data)
+		assert.EqualValues(t, i, reqreses[i].Response.GetDeliverTx().Index)
+	}
+
+	// the sync calls
+	c.SetResponseCallback(nil)
+	res, err := c.DeliverTxSync(types.RequestDeliverTx{Tx: []byte("tx"), Index: numTxs})
+	require.NoError(t, err)
+	assert.Equal(t, []byte("tx"), res.Data)
+	assert.EqualValues(t, numTxs, res.Index)
+	require.NoError(t, c.FlushSync())
+	_, err = c.CommitSync()
+	require.NoError(t, err)
+}
@@ -7,8 +7,20 @@ import (
 	cmn "github.com/hdac-io/tendermint/libs/common"
 )
 
+var _ Client = (*fridayLocalClient)(nil)
+
+// fridayLocalClient is a localClient for the friday consensus, which delivers
+// the txs of a block asynchronously: each DeliverTx request runs in its own
+// goroutine, without the mutex of the app, so the app must handle them
+// concurrently and in any order (see kvstore.FridayKVStoreApplication). They
+// are all answered before EndBlock and Flush.
 type fridayLocalClient struct {
 	localClient
+
+	// the DeliverTx requests in flight
+	deliverTxs sync.WaitGroup
+	// the responses of the DeliverTx requests are called back one at a time
+	cbMtx sync.Mutex
 }
 
 func NewFridayLocalClient(mtx *sync.Mutex, app types.Application) *fridayLocalClient {
@@ -26,8 +38,13 @@ func NewFridayLocalClient(mtx *sync.Mutex, app types.Application) *fridayLocalCl
 }
 
 func (app *fridayLocalClient) DeliverTxAsync(params types.RequestDeliverTx) *ReqRes {
+	app.deliverTxs.Add(1)
 	go func() {
+		defer app.deliverTxs.Done()
 		res := app.Application.DeliverTx(params)
+
+		app.cbMtx.Lock()
+		defer app.cbMtx.Unlock()
 		app.callback(
 			types.ToRequestDeliverTx(params),
 			types.ToResponseDeliverTx(res),
@@ -36,3 +53,23 @@ func (app *fridayLocalClient) DeliverTxAsync(params types.RequestDeliverTx) *Req
 
 	return nil
 }
+
+func (app *fridayLocalClient) FlushAsync() *ReqRes {
+	app.deliverTxs.Wait()
+	return app.localClient.FlushAsync()
+}
+
+func (app *fridayLocalClient) EndBlockAsync(req types.RequestEndBlock) *ReqRes {
+	app.deliverTxs.Wait()
+	return app.localClient.EndBlockAsync(req)
+}
+
+func (app *fridayLocalClient) FlushSync() error {
+	app.deliverTxs.Wait()
+	return app.localClient.FlushSync()
+}
+
+func (app *fridayLocalClient) EndBlockSync(req types.RequestEndBlock) (*types.ResponseEndBlock, error) {
+	app.deliverTxs.Wait()
+	return app.localClient.EndBlockSync(req)
+}
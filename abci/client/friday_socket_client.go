@@ -0,0 +1,51 @@
+package abcicli
+
+import (
+	"container/list"
+
+	"github.com/hdac-io/tendermint/abci/types"
+	cmn "github.com/hdac-io/tendermint/libs/common"
+)
+
+var _ Client = (*fridaySocketClient)(nil)
+
+// fridaySocketClient is a socketClient for the friday consensus, which
+// delivers the txs of a block asynchronously: the application may run the
+// DeliverTx requests of a block concurrently and answer them in any order,
+// as they are matched by their Index, but it must answer all of them before
+// answering the next request (e.g. EndBlock or Flush).
+type fridaySocketClient struct {
+	socketClient
+}
+
+func NewFridaySocketClient(addr string, mustConnect bool) *fridaySocketClient {
+	cli := &fridaySocketClient{
+		socketClient: socketClient{
+			reqQueue:    make(chan *ReqRes, reqQueueSize),
+			flushTimer:  cmn.NewThrottleTimer("fridaySocketClient", flushThrottleMS),
+			mustConnect: mustConnect,
+
+			addr:             addr,
+			reqSent:          list.New(),
+			resCb:            nil,
+			deliverTxByIndex: true,
+		},
+	}
+	cli.BaseService = *cmn.NewBaseService(nil, "fridaySocketClient", cli)
+	return cli
+}
+
+// findDeliverTx returns the element of the DeliverTx request with the given
+// index among the consecutive DeliverTx requests from front, or nil.
+func findDeliverTx(front *list.Element, index int32) *list.Element {
+	for e := front; e != nil; e = e.Next() {
+		req, ok := e.Value.(*ReqRes).Request.Value.(*types.Request_DeliverTx)
+		if !ok {
+			return nil
+		}
+		if req.DeliverTx.Index == index {
+			return e
+		}
+	}
+	return nil
+}
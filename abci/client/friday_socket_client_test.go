@@ -0,0 +1,92 @@
+package abcicli_test
+
+import (
+	"bufio"
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	abcicli "github.com/hdac-io/tendermint/abci/client"
+	"github.com/hdac-io/tendermint/abci/types"
+)
+
+// reverseDeliverTxServer answers the consecutive DeliverTx requests in the
+// reverse order, before answering the next request.
+func reverseDeliverTxServer(ln net.Listener) {
+	conn, err := ln.Accept()
+	if err != nil {
+		return
+	}
+	defer conn.Close()
+
+	r, w := bufio.NewReader(conn), bufio.NewWriter(conn)
+	var deliverTxs []*types.Response
+	for {
+		req := &types.Request{}
+		if err := types.ReadMessage(r, req); err != nil {
+			return
+		}
+		if deliverTx, ok := req.Value.(*types.Request_DeliverTx); ok {
+			deliverTxs = append(deliverTxs, types.ToResponseDeliverTx(types.ResponseDeliverTx{
+				Data:  deliverTx.DeliverTx.Tx,
+				Index: deliverTx.DeliverTx.Index,
+			}))
+			continue
+		}
+		for i := len(deliverTxs) - 1; i >= 0; i-- {
+			if err := types.WriteMessage(deliverTxs[i], w); err != nil {
+				return
+			}
+		}
+		deliverTxs = nil
+
+		var res *types.Response
+		switch req.Value.(type) {
+		case *types.Request_Flush:
+			res = types.ToResponseFlush()
+		case *types.Request_EndBlock:
+			res = types.ToResponseEndBlock(types.ResponseEndBlock{})
+		default:
+			res = types.ToResponseException("unexpected request")
+		}
+		if err := types.WriteMessage(res, w); err != nil {
+			return
+		}
+		if err := w.Flush(); err != nil {
+			return
+		}
+	}
+}
+
+func TestFridaySocketClientDeliverTxOutOfOrder(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer ln.Close()
+	go reverseDeliverTxServer(ln)
+
+	c := abcicli.NewFridaySocketClient(ln.Addr().String(), true)
+	require.NoError(t, c.Start())
+	defer c.Stop()
+
+	const numTxs = 10
+	results := make([][]byte, numTxs)
+	c.SetResponseCallback(func(req *types.Request, res *types.Response) {
+		if r, ok := res.Value.(*types.Response_DeliverTx); ok {
+			results[r.DeliverTx.Index] = r.DeliverTx.Data
+		}
+	})
+	reqreses := make([]*abcicli.ReqRes, numTxs)
+	for i := 0; i < numTxs; i++ {
+		reqreses[i] = c.DeliverTxAsync(types.RequestDeliverTx{Tx: []byte{byte(i)}, Index: int32(i)})
+	}
+	_, err = c.EndBlockSync(types.RequestEndBlock{})
+	require.NoError(t, err)
+
+	for i := 0; i < numTxs; i++ {
+		assert.Equal(t, []byte{byte(i)}, results[i])
+		assert.Equal(t, []byte{byte(i)}, reqreses[i].Response.GetDeliverTx().Data)
+	}
+
+}
@@ -38,6 +38,8 @@ type socketClient struct {
 	reqSent *list.List                            // list of requests sent, waiting for response
 	resCb   func(*types.Request, *types.Response) // called on all requests, if set.
 
+	// match the DeliverTx responses by index, see fridaySocketClient
+	deliverTxByIndex bool
 }
 
 func NewSocketClient(addr string, mustConnect bool) *socketClient {
@@ -192,6 +194,12 @@ func (cli *socketClient) didRecvResponse(res *types.Response) error {
 	if next == nil {
 		return fmt.Errorf("Unexpected result type %v when nothing expected", reflect.TypeOf(res.Value))
 	}
+	if r, ok := res.Value.(*types.Response_DeliverTx); ok && cli.deliverTxByIndex {
+		next = findDeliverTx(next, r.DeliverTx.Index)
+		if next == nil {
+			return fmt.Errorf("Unexpected DeliverTx result with index %d", r.DeliverTx.Index)
+		}
+	}
 	reqres := next.Value.(*ReqRes)
 	if !resMatchesReq(reqres.Request, res) {
 		return fmt.Errorf("Unexpected result type %v when response to %v expected",
@@ -200,7 +208,7 @@ func (cli *socketClient) didRecvResponse(res *types.Response) error {
 
 	reqres.Response = res    // Set response
 	reqres.Done()            // Release waiters
-	cli.reqSent.Remove(next) // Pop first item from linked list
+	cli.reqSent.Remove(next) // Pop item from linked list
 
 	// Notify client listener if set (global callback).
 	if cli.resCb != nil {
@@ -46,20 +46,22 @@ func (app *CounterApplication) DeliverTx(req types.RequestDeliverTx) types.Respo
 	if app.serial {
 		if len(req.Tx) > 8 {
 			return types.ResponseDeliverTx{
-				Code: code.CodeTypeEncodingError,
-				Log:  fmt.Sprintf("Max tx size is 8 bytes, got %d", len(req.Tx))}
+				Code:  code.CodeTypeEncodingError,
+				Log:   fmt.Sprintf("Max tx size is 8 bytes, got %d", len(req.Tx)),
+				Index: req.Index}
 		}
 		tx8 := make([]byte, 8)
 		copy(tx8[len(tx8)-len(req.Tx):], req.Tx)
 		txValue := binary.BigEndian.Uint64(tx8)
 		if txValue != uint64(app.txCount) {
 			return types.ResponseDeliverTx{
-				Code: code.CodeTypeBadNonce,
-				Log:  fmt.Sprintf("Invalid nonce. Expected %v, got %v", app.txCount, txValue)}
+				Code:  code.CodeTypeBadNonce,
+				Log:   fmt.Sprintf("Invalid nonce. Expected %v, got %v", app.txCount, txValue),
+				Index: req.Index}
 		}
 	}
 	app.txCount++
-	return types.ResponseDeliverTx{Code: code.CodeTypeOK}
+	return types.ResponseDeliverTx{Code: code.CodeTypeOK, Index: req.Index}
 }
 
 func (app *CounterApplication) CheckTx(req types.RequestCheckTx) types.ResponseCheckTx {
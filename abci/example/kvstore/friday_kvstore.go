@@ -0,0 +1,196 @@
+package kvstore
+
+import (
+	"bytes"
+	"fmt"
+	"strconv"
+	"sync"
+
+	"github.com/hdac-io/tendermint/abci/example/code"
+	"github.com/hdac-io/tendermint/abci/types"
+	cmn "github.com/hdac-io/tendermint/libs/common"
+	"github.com/hdac-io/tendermint/version"
+)
+
+var _ types.Application = (*FridayKVStoreApplication)(nil)
+
+// FridayKVStoreApplication is a KVStoreApplication for the parallel pipeline
+// of the friday consensus, whose client (e.g. abcicli.NewFridayLocalClient)
+// delivers the txs of a block asynchronously: the DeliverTx requests of a
+// block may run concurrently and arrive in any order, and are matched to the
+// txs by their Index.
+//
+// Each tx is executed speculatively as soon as it is delivered, against the
+// state left by the txs of the block applied so far, recording the values it
+// read. The txs are then applied in the order of the block: a tx whose reads
+// were changed by the txs applied since it was executed conflicts with them,
+// and is executed again before it is applied. The state and the responses are
+// thus the ones of the txs executed one after the other, whatever the order
+// they were delivered in.
+//
+// On top of the `key=value` txs of the KVStoreApplication, which only write,
+// the `key+=n` txs add the integer n to the value of key (0 if missing), so
+// that the txs of a block updating the same key conflict.
+type FridayKVStoreApplication struct {
+	*KVStoreApplication
+
+	mtx  sync.Mutex
+	cond *sync.Cond
+	// the number of txs of the block, and the index of the next one to apply
+	numTxs int64
+	next   int32
+	// the number of txs executed again after a conflict since the start
+	conflicts int64
+}
+
+func NewFridayKVStoreApplication() *FridayKVStoreApplication {
+	app := &FridayKVStoreApplication{KVStoreApplication: NewKVStoreApplication()}
+	app.cond = sync.NewCond(&app.mtx)
+	return app
+}
+
+func (app *FridayKVStoreApplication) Info(req types.RequestInfo) types.ResponseInfo {
+	app.mtx.Lock()
+	defer app.mtx.Unlock()
+	return types.ResponseInfo{
+		Data:       fmt.Sprintf("{\"size\":%v,\"conflicts\":%v}", app.state.Size, app.conflicts),
+		Version:    version.ABCIVersion,
+		AppVersion: ProtocolVersion.Uint64(),
+	}
+}
+
+func (app *FridayKVStoreApplication) CheckTx(req types.RequestCheckTx) types.ResponseCheckTx {
+	if _, _, _, err := parseFridayTx(req.Tx); err != nil {
+		return types.ResponseCheckTx{Code: code.CodeTypeEncodingError, Log: err.Error()}
+	}
+	return types.ResponseCheckTx{Code: code.CodeTypeOK, GasWanted: 1}
+}
+
+func (app *FridayKVStoreApplication) BeginBlock(req types.RequestBeginBlock) types.ResponseBeginBlock {
+	app.mtx.Lock()
+	defer app.mtx.Unlock()
+	app.numTxs = req.Header.NumTxs
+	app.next = 0
+	return types.ResponseBeginBlock{}
+}
+
+// DeliverTx executes the tx speculatively, and applies it once the txs before
+// it in the block are applied.
+func (app *FridayKVStoreApplication) DeliverTx(req types.RequestDeliverTx) types.ResponseDeliverTx {
+	exec := app.execute(req.Tx)
+
+	app.mtx.Lock()
+	defer app.mtx.Unlock()
+	for app.next != req.Index {
+		app.cond.Wait()
+	}
+	if exec.conflicts(app) {
+		app.conflicts++
+		exec = app.execute(req.Tx)
+	}
+	for _, pair := range exec.writes {
+		app.state.db.Set(prefixKey(pair.Key), pair.Value)
+	}
+	if exec.res.Code == code.CodeTypeOK {
+		app.state.Size++
+	}
+	app.next++
+	app.cond.Broadcast()
+
+	exec.res.Index = req.Index
+	return exec.res
+}
+
+// EndBlock waits for the txs of the block to be applied, as they may still be
+// running with an asynchronous client.
+func (app *FridayKVStoreApplication) EndBlock(req types.RequestEndBlock) types.ResponseEndBlock {
+	app.mtx.Lock()
+	defer app.mtx.Unlock()
+	for int64(app.next) < app.numTxs {
+		app.cond.Wait()
+	}
+	return types.ResponseEndBlock{}
+}
+
+func (app *FridayKVStoreApplication) Commit() types.ResponseCommit {
+	app.mtx.Lock()
+	defer app.mtx.Unlock()
+	// for the txs delivered without BeginBlock
+	app.numTxs = 0
+	app.next = 0
+	return app.KVStoreApplication.Commit()
+}
+
+//---------------------------------------------------
+
+// fridayExec is the speculative execution of a tx.
+type fridayExec struct {
+	// the values read, by key (nil if missing)
+	reads  map[string][]byte
+	writes []cmn.KVPair
+	res    types.ResponseDeliverTx
+}
+
+// execute executes tx against the current state, without writing it.
+func (app *FridayKVStoreApplication) execute(tx []byte) *fridayExec {
+	exec := &fridayExec{reads: make(map[string][]byte)}
+	key, value, add, err := parseFridayTx(tx)
+	if err != nil {
+		exec.res = types.ResponseDeliverTx{Code: code.CodeTypeEncodingError, Log: err.Error()}
+		return exec
+	}
+	if add {
+		var sum int64
+		old := app.state.db.Get(prefixKey(key))
+		exec.reads[string(key)] = old
+		if old != nil {
+			if sum, err = strconv.ParseInt(string(old), 10, 64); err != nil {
+				exec.res = types.ResponseDeliverTx{Code: code.CodeTypeUnknownError,
+					Log: fmt.Sprintf("the value of %s is not an integer", key)}
+				return exec
+			}
+		}
+		n, _ := strconv.ParseInt(string(value), 10, 64)
+		value = []byte(strconv.FormatInt(sum+n, 10))
+	}
+	exec.writes = []cmn.KVPair{{Key: key, Value: value}}
+	exec.res = types.ResponseDeliverTx{
+		Code: code.CodeTypeOK,
+		Data: value,
+		Events: []types.Event{
+			{
+				Type: "app",
+				Attributes: []cmn.KVPair{
+					{Key: []byte("creator"), Value: []byte("Cosmoshi Netowoko")},
+					{Key: []byte("key"), Value: key},
+				},
+			},
+		},
+	}
+	return exec
+}
+
+// conflicts returns whether a value read by the execution was changed since.
+func (exec *fridayExec) conflicts(app *FridayKVStoreApplication) bool {
+	for key, value := range exec.reads {
+		if !bytes.Equal(app.state.db.Get(prefixKey([]byte(key))), value) {
+			return true
+		}
+	}
+	return false
+}
+
+// parseFridayTx parses a tx of the FridayKVStoreApplication: `key+=n`, with
+// add true, or a tx of the KVStoreApplication.
+func parseFridayTx(tx []byte) (key, value []byte, add bool, err error) {
+	if parts := bytes.Split(tx, []byte("+=")); len(parts) == 2 {
+		if _, err := strconv.ParseInt(string(parts[1]), 10, 64); err != nil {
+			return nil, nil, false, fmt.Errorf("%q is not an integer", parts[1])
+		}
+		return parts[0], parts[1], true, nil
+	}
+	if parts := bytes.Split(tx, []byte("=")); len(parts) == 2 {
+		return parts[0], parts[1], false, nil
+	}
+	return tx, tx, false, nil
+}
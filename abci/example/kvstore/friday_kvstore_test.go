@@ -0,0 +1,73 @@
+package kvstore
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	abcicli "github.com/hdac-io/tendermint/abci/client"
+	"github.com/hdac-io/tendermint/abci/example/code"
+	"github.com/hdac-io/tendermint/abci/types"
+)
+
+func TestFridayKVStoreParallelDeliverTx(t *testing.T) {
+	app := NewFridayKVStoreApplication()
+	client := abcicli.NewFridayLocalClient(nil, app)
+
+	txs := []string{"a=0", "a+=1", "a+=1", "a+=1", "a=10", "a+=2", "b=x", "b+=1", "c+=y", "a+=-3"}
+	expected := []struct {
+		code uint32
+		data string
+	}{
+		{code.CodeTypeOK, "0"},
+		{code.CodeTypeOK, "1"},
+		{code.CodeTypeOK, "2"},
+		{code.CodeTypeOK, "3"},
+		{code.CodeTypeOK, "10"},
+		{code.CodeTypeOK, "12"},
+		{code.CodeTypeOK, "x"},
+		{code.CodeTypeUnknownError, ""},
+		{code.CodeTypeEncodingError, ""},
+		{code.CodeTypeOK, "9"},
+	}
+
+	for height := int64(1); height <= 3; height++ {
+		var mtx sync.Mutex
+		responses := make(map[int32]*types.ResponseDeliverTx)
+		client.SetResponseCallback(func(req *types.Request, res *types.Response) {
+			r := res.GetDeliverTx()
+			mtx.Lock()
+			responses[r.Index] = r
+			mtx.Unlock()
+		})
+		_, err := client.BeginBlockSync(types.RequestBeginBlock{Header: types.Header{NumTxs: int64(len(txs))}})
+		require.NoError(t, err)
+		// the txs are delivered in the reverse order
+		for i := len(txs) - 1; i >= 0; i-- {
+			client.DeliverTxAsync(types.RequestDeliverTx{Tx: []byte(txs[i]), Index: int32(i)})
+		}
+		_, err = client.EndBlockSync(types.RequestEndBlock{Height: height})
+		require.NoError(t, err)
+		_, err = client.CommitSync()
+		require.NoError(t, err)
+
+		// the responses of the txs executed one after the other
+		require.Len(t, responses, len(txs))
+		for i, exp := range expected {
+			res := responses[int32(i)]
+			assert.Equal(t, exp.code, res.Code, "tx %d %s", i, txs[i])
+			if exp.code == code.CodeTypeOK {
+				assert.Equal(t, exp.data, string(res.Data), "tx %d %s", i, txs[i])
+			}
+		}
+		if t.Failed() {
+			return
+		}
+	}
+
+	res := app.Query(types.RequestQuery{Data: []byte("a")})
+	assert.Equal(t, "9", string(res.Value))
+	assert.EqualValues(t, 24, app.state.Size)
+}
@@ -48,8 +48,10 @@ func saveState(state State) {
 	state.db.Set(stateKey, stateBytes)
 }
 
+// prefixKey returns a new slice, as the keys may be prefixed concurrently
+// (see FridayKVStoreApplication).
 func prefixKey(key []byte) []byte {
-	return append(kvPairPrefixKey, key...)
+	return append(append([]byte{}, kvPairPrefixKey...), key...)
 }
 
 //---------------------------------------------------
@@ -98,7 +100,7 @@ func (app *KVStoreApplication) DeliverTx(req types.RequestDeliverTx) types.Respo
 		},
 	}
 
-	return types.ResponseDeliverTx{Code: code.CodeTypeOK, Events: events}
+	return types.ResponseDeliverTx{Code: code.CodeTypeOK, Events: events, Index: req.Index}
 }
 
 func (app *KVStoreApplication) CheckTx(req types.RequestCheckTx) types.ResponseCheckTx {
@@ -72,7 +72,9 @@ func (app *PersistentKVStoreApplication) DeliverTx(req types.RequestDeliverTx) t
 	if isValidatorTx(req.Tx) {
 		// update validators in the merkle tree
 		// and in app.ValUpdates
-		return app.execValidatorTx(req.Tx)
+		res := app.execValidatorTx(req.Tx)
+		res.Index = req.Index
+		return res
 	}
 
 	// otherwise, update the key-value store
@@ -29,6 +29,7 @@ func TestMarshalJSON(t *testing.T) {
 				},
 			},
 		},
+		ConflictKeys: [][]byte{[]byte("pho")},
 	}
 	b, err = json.Marshal(&r1)
 	assert.Nil(t, err)
@@ -111,3 +112,22 @@ func TestWriteReadMessage2(t *testing.T) {
 		assert.Equal(t, c, msg)
 	}
 }
+
+func TestResponseInfoSupportsLenULB(t *testing.T) {
+	testCases := []struct {
+		min, max, lenULB int64
+		supported        bool
+	}{
+		{0, 0, 3, true},
+		{2, 0, 3, true},
+		{4, 0, 3, false},
+		{0, 3, 3, true},
+		{0, 2, 3, false},
+		{2, 4, 3, true},
+		{4, 5, 3, false},
+	}
+	for _, tc := range testCases {
+		res := ResponseInfo{MinLenUlb: tc.min, MaxLenUlb: tc.max}
+		assert.Equal(t, tc.supported, res.SupportsLenULB(tc.lenULB), "%+v", tc)
+	}
+}
@@ -41,6 +41,13 @@ func (r ResponseQuery) IsErr() bool {
 	return r.Code != CodeTypeOK
 }
 
+// SupportsLenULB returns true if lenULB is within the range of LenULB the app
+// supports, between MinLenUlb and MaxLenUlb, each unbounded if 0.
+func (r ResponseInfo) SupportsLenULB(lenULB int64) bool {
+	return (r.MinLenUlb == 0 || lenULB >= r.MinLenUlb) &&
+		(r.MaxLenUlb == 0 || lenULB <= r.MaxLenUlb)
+}
+
 //---------------------------------------------------------------------------
 // override JSON marshalling so we emit defaults (ie. disable omitempty)
 
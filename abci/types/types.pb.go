@@ -357,6 +357,10 @@ type RequestInfo struct {
 	Version              string   `protobuf:"bytes,1,opt,name=version,proto3" json:"version,omitempty"`
 	BlockVersion         uint64   `protobuf:"varint,2,opt,name=block_version,json=blockVersion,proto3" json:"block_version,omitempty"`
 	P2PVersion           uint64   `protobuf:"varint,3,opt,name=p2p_version,json=p2pVersion,proto3" json:"p2p_version,omitempty"`
+	ConsensusModule      string   `protobuf:"bytes,4,opt,name=consensus_module,json=consensusModule,proto3" json:"consensus_module,omitempty"`
+	LenUlb               int64    `protobuf:"varint,5,opt,name=len_ulb,json=lenUlb,proto3" json:"len_ulb,omitempty"`
+	LastFinalizedHeight  int64    `protobuf:"varint,6,opt,name=last_finalized_height,json=lastFinalizedHeight,proto3" json:"last_finalized_height,omitempty"`
+	LastProposedHeight   int64    `protobuf:"varint,7,opt,name=last_proposed_height,json=lastProposedHeight,proto3" json:"last_proposed_height,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -416,6 +420,34 @@ func (m *RequestInfo) GetP2PVersion() uint64 {
 	return 0
 }
 
+func (m *RequestInfo) GetConsensusModule() string {
+	if m != nil {
+		return m.ConsensusModule
+	}
+	return ""
+}
+
+func (m *RequestInfo) GetLenUlb() int64 {
+	if m != nil {
+		return m.LenUlb
+	}
+	return 0
+}
+
+func (m *RequestInfo) GetLastFinalizedHeight() int64 {
+	if m != nil {
+		return m.LastFinalizedHeight
+	}
+	return 0
+}
+
+func (m *RequestInfo) GetLastProposedHeight() int64 {
+	if m != nil {
+		return m.LastProposedHeight
+	}
+	return 0
+}
+
 // nondeterministic
 type RequestSetOption struct {
 	Key                  string   `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
@@ -1248,6 +1280,8 @@ type ResponseInfo struct {
 	AppVersion           uint64   `protobuf:"varint,3,opt,name=app_version,json=appVersion,proto3" json:"app_version,omitempty"`
 	LastBlockHeight      int64    `protobuf:"varint,4,opt,name=last_block_height,json=lastBlockHeight,proto3" json:"last_block_height,omitempty"`
 	LastBlockAppHash     []byte   `protobuf:"bytes,5,opt,name=last_block_app_hash,json=lastBlockAppHash,proto3" json:"last_block_app_hash,omitempty"`
+	MinLenUlb            int64    `protobuf:"varint,6,opt,name=min_len_ulb,json=minLenUlb,proto3" json:"min_len_ulb,omitempty"`
+	MaxLenUlb            int64    `protobuf:"varint,7,opt,name=max_len_ulb,json=maxLenUlb,proto3" json:"max_len_ulb,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -1321,6 +1355,20 @@ func (m *ResponseInfo) GetLastBlockAppHash() []byte {
 	return nil
 }
 
+func (m *ResponseInfo) GetMinLenUlb() int64 {
+	if m != nil {
+		return m.MinLenUlb
+	}
+	return 0
+}
+
+func (m *ResponseInfo) GetMaxLenUlb() int64 {
+	if m != nil {
+		return m.MaxLenUlb
+	}
+	return 0
+}
+
 // nondeterministic
 type ResponseSetOption struct {
 	Code uint32 `protobuf:"varint,1,opt,name=code,proto3" json:"code,omitempty"`
@@ -1601,14 +1649,28 @@ func (m *ResponseBeginBlock) GetEvents() []Event {
 }
 
 type ResponseCheckTx struct {
-	Code                 uint32   `protobuf:"varint,1,opt,name=code,proto3" json:"code,omitempty"`
-	Data                 []byte   `protobuf:"bytes,2,opt,name=data,proto3" json:"data,omitempty"`
-	Log                  string   `protobuf:"bytes,3,opt,name=log,proto3" json:"log,omitempty"`
-	Info                 string   `protobuf:"bytes,4,opt,name=info,proto3" json:"info,omitempty"`
-	GasWanted            int64    `protobuf:"varint,5,opt,name=gas_wanted,json=gasWanted,proto3" json:"gas_wanted,omitempty"`
-	GasUsed              int64    `protobuf:"varint,6,opt,name=gas_used,json=gasUsed,proto3" json:"gas_used,omitempty"`
-	Events               []Event  `protobuf:"bytes,7,rep,name=events,proto3" json:"events,omitempty"`
-	Codespace            string   `protobuf:"bytes,8,opt,name=codespace,proto3" json:"codespace,omitempty"`
+	Code      uint32  `protobuf:"varint,1,opt,name=code,proto3" json:"code,omitempty"`
+	Data      []byte  `protobuf:"bytes,2,opt,name=data,proto3" json:"data,omitempty"`
+	Log       string  `protobuf:"bytes,3,opt,name=log,proto3" json:"log,omitempty"`
+	Info      string  `protobuf:"bytes,4,opt,name=info,proto3" json:"info,omitempty"`
+	GasWanted int64   `protobuf:"varint,5,opt,name=gas_wanted,json=gasWanted,proto3" json:"gas_wanted,omitempty"`
+	GasUsed   int64   `protobuf:"varint,6,opt,name=gas_used,json=gasUsed,proto3" json:"gas_used,omitempty"`
+	Events    []Event `protobuf:"bytes,7,rep,name=events,proto3" json:"events,omitempty"`
+	Codespace string  `protobuf:"bytes,8,opt,name=codespace,proto3" json:"codespace,omitempty"`
+	// key under which the mempool deduplicates the tx, instead of its hash:
+	// a tx replaces the one in the mempool with the same key
+	DedupKey []byte `protobuf:"bytes,9,opt,name=dedup_key,json=dedupKey,proto3" json:"dedup_key,omitempty"`
+	// sender of the tx and its sequence number, for the mempool to release
+	// the txs of a sender in nonce order
+	Sender string `protobuf:"bytes,10,opt,name=sender,proto3" json:"sender,omitempty"`
+	Nonce  uint64 `protobuf:"varint,11,opt,name=nonce,proto3" json:"nonce,omitempty"`
+	// priority of the tx in the mempool, for the lowest_priority eviction
+	// policy to evict the txs of the lowest priority first when it is full
+	Priority int64 `protobuf:"varint,12,opt,name=priority,proto3" json:"priority,omitempty"`
+	// keys of the state the tx reads or writes: with parallel_deliver_tx, the
+	// txs of a block with no key in common may be delivered concurrently. A tx
+	// without keys conflicts with all the others
+	ConflictKeys         [][]byte `protobuf:"bytes,13,rep,name=conflict_keys,json=conflictKeys,proto3" json:"conflict_keys,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -1703,6 +1765,41 @@ func (m *ResponseCheckTx) GetCodespace() string {
 	return ""
 }
 
+func (m *ResponseCheckTx) GetDedupKey() []byte {
+	if m != nil {
+		return m.DedupKey
+	}
+	return nil
+}
+
+func (m *ResponseCheckTx) GetSender() string {
+	if m != nil {
+		return m.Sender
+	}
+	return ""
+}
+
+func (m *ResponseCheckTx) GetNonce() uint64 {
+	if m != nil {
+		return m.Nonce
+	}
+	return 0
+}
+
+func (m *ResponseCheckTx) GetPriority() int64 {
+	if m != nil {
+		return m.Priority
+	}
+	return 0
+}
+
+func (m *ResponseCheckTx) GetConflictKeys() [][]byte {
+	if m != nil {
+		return m.ConflictKeys
+	}
+	return nil
+}
+
 type ResponseDeliverTx struct {
 	Code                 uint32   `protobuf:"varint,1,opt,name=code,proto3" json:"code,omitempty"`
 	Data                 []byte   `protobuf:"bytes,2,opt,name=data,proto3" json:"data,omitempty"`
@@ -1997,7 +2094,17 @@ type BlockParams struct {
 	// Note: must be greater or equal to -1
 	MaxGas int64 `protobuf:"varint,2,opt,name=max_gas,json=maxGas,proto3" json:"max_gas,omitempty"`
 	// Note: must be greater than 0, it's just only using friday consensus
-	LenUlb               int64    `protobuf:"varint,3,opt,name=len_ulb,json=lenUlb,proto3" json:"len_ulb,omitempty"`
+	LenUlb int64 `protobuf:"varint,3,opt,name=len_ulb,json=lenUlb,proto3" json:"len_ulb,omitempty"`
+	// Note: must be greater or equal to 0, it's just only using friday consensus
+	TimeoutProposeMs int64 `protobuf:"varint,4,opt,name=timeout_propose_ms,json=timeoutProposeMs,proto3" json:"timeout_propose_ms,omitempty"`
+	// Note: must be greater or equal to 0, it's just only using friday consensus
+	TimeoutPrevoteMs int64 `protobuf:"varint,5,opt,name=timeout_prevote_ms,json=timeoutPrevoteMs,proto3" json:"timeout_prevote_ms,omitempty"`
+	// Note: must be greater or equal to 0, it's just only using friday consensus
+	TimeoutPrecommitMs int64 `protobuf:"varint,6,opt,name=timeout_precommit_ms,json=timeoutPrecommitMs,proto3" json:"timeout_precommit_ms,omitempty"`
+	// Note: must be greater or equal to 0, it's just only using friday consensus
+	TimeoutCommitMs int64 `protobuf:"varint,7,opt,name=timeout_commit_ms,json=timeoutCommitMs,proto3" json:"timeout_commit_ms,omitempty"`
+	// Note: must be greater or equal to 0, 0 doesn't limit the size of a tx
+	MaxTxBytes           int64    `protobuf:"varint,8,opt,name=max_tx_bytes,json=maxTxBytes,proto3" json:"max_tx_bytes,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -2057,10 +2164,48 @@ func (m *BlockParams) GetLenUlb() int64 {
 	return 0
 }
 
+func (m *BlockParams) GetTimeoutProposeMs() int64 {
+	if m != nil {
+		return m.TimeoutProposeMs
+	}
+	return 0
+}
+
+func (m *BlockParams) GetTimeoutPrevoteMs() int64 {
+	if m != nil {
+		return m.TimeoutPrevoteMs
+	}
+	return 0
+}
+
+func (m *BlockParams) GetTimeoutPrecommitMs() int64 {
+	if m != nil {
+		return m.TimeoutPrecommitMs
+	}
+	return 0
+}
+
+func (m *BlockParams) GetTimeoutCommitMs() int64 {
+	if m != nil {
+		return m.TimeoutCommitMs
+	}
+	return 0
+}
+
+func (m *BlockParams) GetMaxTxBytes() int64 {
+	if m != nil {
+		return m.MaxTxBytes
+	}
+	return 0
+}
+
 // EvidenceParams contains limits on the evidence.
 type EvidenceParams struct {
 	// Note: must be greater than 0
-	MaxAge               int64    `protobuf:"varint,1,opt,name=max_age,json=maxAge,proto3" json:"max_age,omitempty"`
+	MaxAge int64 `protobuf:"varint,1,opt,name=max_age,json=maxAge,proto3" json:"max_age,omitempty"`
+	// Max age of the evidence in time (in milliseconds), in addition to max_age
+	// in blocks. 0 disables it.
+	MaxAgeDurationMs     int64    `protobuf:"varint,2,opt,name=max_age_duration_ms,json=maxAgeDurationMs,proto3" json:"max_age_duration_ms,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -2106,6 +2251,13 @@ func (m *EvidenceParams) GetMaxAge() int64 {
 	return 0
 }
 
+func (m *EvidenceParams) GetMaxAgeDurationMs() int64 {
+	if m != nil {
+		return m.MaxAgeDurationMs
+	}
+	return 0
+}
+
 // ValidatorParams contains limits on validators.
 type ValidatorParams struct {
 	PubKeyTypes          []string `protobuf:"bytes,1,rep,name=pub_key_types,json=pubKeyTypes,proto3" json:"pub_key_types,omitempty"`
@@ -2155,11 +2307,17 @@ func (m *ValidatorParams) GetPubKeyTypes() []string {
 }
 
 type LastCommitInfo struct {
-	Round                int32      `protobuf:"varint,1,opt,name=round,proto3" json:"round,omitempty"`
-	Votes                []VoteInfo `protobuf:"bytes,2,rep,name=votes,proto3" json:"votes"`
-	XXX_NoUnkeyedLiteral struct{}   `json:"-"`
-	XXX_unrecognized     []byte     `json:"-"`
-	XXX_sizecache        int32      `json:"-"`
+	Round int32      `protobuf:"varint,1,opt,name=round,proto3" json:"round,omitempty"`
+	Votes []VoteInfo `protobuf:"bytes,2,rep,name=votes,proto3" json:"votes"`
+	// height of the commit: the previous height, or the height LenULB below the
+	// block with the friday consensus
+	Height int64 `protobuf:"varint,3,opt,name=height,proto3" json:"height,omitempty"`
+	// bitmap of the votes which signed the commit: the bit i%8 of the byte i/8
+	// is set if the vote i signed it
+	Signers              []byte   `protobuf:"bytes,4,opt,name=signers,proto3" json:"signers,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
 func (m *LastCommitInfo) Reset()         { *m = LastCommitInfo{} }
@@ -2209,6 +2367,20 @@ func (m *LastCommitInfo) GetVotes() []VoteInfo {
 	return nil
 }
 
+func (m *LastCommitInfo) GetHeight() int64 {
+	if m != nil {
+		return m.Height
+	}
+	return 0
+}
+
+func (m *LastCommitInfo) GetSigners() []byte {
+	if m != nil {
+		return m.Signers
+	}
+	return nil
+}
+
 type Event struct {
 	Type                 string          `protobuf:"bytes,1,opt,name=type,proto3" json:"type,omitempty"`
 	Attributes           []common.KVPair `protobuf:"bytes,2,rep,name=attributes,proto3" json:"attributes,omitempty"`
@@ -2662,6 +2834,7 @@ func (m *Validator) GetPower() int64 {
 type ValidatorUpdate struct {
 	PubKey               PubKey   `protobuf:"bytes,1,opt,name=pub_key,json=pubKey,proto3" json:"pub_key"`
 	Power                int64    `protobuf:"varint,2,opt,name=power,proto3" json:"power,omitempty"`
+	ProofOfPossession    []byte   `protobuf:"bytes,3,opt,name=proof_of_possession,json=proofOfPossession,proto3" json:"proof_of_possession,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -2714,10 +2887,19 @@ func (m *ValidatorUpdate) GetPower() int64 {
 	return 0
 }
 
+func (m *ValidatorUpdate) GetProofOfPossession() []byte {
+	if m != nil {
+		return m.ProofOfPossession
+	}
+	return nil
+}
+
 // VoteInfo
 type VoteInfo struct {
-	Validator            Validator `protobuf:"bytes,1,opt,name=validator,proto3" json:"validator"`
-	SignedLastBlock      bool      `protobuf:"varint,2,opt,name=signed_last_block,json=signedLastBlock,proto3" json:"signed_last_block,omitempty"`
+	Validator       Validator `protobuf:"bytes,1,opt,name=validator,proto3" json:"validator"`
+	SignedLastBlock bool      `protobuf:"varint,2,opt,name=signed_last_block,json=signedLastBlock,proto3" json:"signed_last_block,omitempty"`
+	// time of the precommit, or zero if the validator didn't sign the commit
+	Timestamp            time.Time `protobuf:"bytes,3,opt,name=timestamp,proto3,stdtime" json:"timestamp"`
 	XXX_NoUnkeyedLiteral struct{}  `json:"-"`
 	XXX_unrecognized     []byte    `json:"-"`
 	XXX_sizecache        int32     `json:"-"`
@@ -2770,6 +2952,13 @@ func (m *VoteInfo) GetSignedLastBlock() bool {
 	return false
 }
 
+func (m *VoteInfo) GetTimestamp() time.Time {
+	if m != nil {
+		return m.Timestamp
+	}
+	return time.Time{}
+}
+
 type PubKey struct {
 	Type                 string   `protobuf:"bytes,1,opt,name=type,proto3" json:"type,omitempty"`
 	Data                 []byte   `protobuf:"bytes,2,opt,name=data,proto3" json:"data,omitempty"`
@@ -2993,152 +3182,173 @@ func init() { proto.RegisterFile("abci/types/types.proto", fileDescriptor_9f1eaa
 func init() { golang_proto.RegisterFile("abci/types/types.proto", fileDescriptor_9f1eaa49c51fa1ac) }
 
 var fileDescriptor_9f1eaa49c51fa1ac = []byte{
-	// 2315 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xe4, 0x58, 0x4b, 0x73, 0x1b, 0xc7,
-	0xf1, 0xe7, 0x82, 0x78, 0x6d, 0x83, 0x78, 0x68, 0x44, 0x49, 0x10, 0xfe, 0xfa, 0x93, 0xaa, 0x55,
-	0x22, 0x93, 0xb6, 0x04, 0xda, 0x74, 0x94, 0xa2, 0x22, 0xc7, 0x55, 0x84, 0xa4, 0x84, 0x2c, 0x2b,
-	0x0e, 0xb3, 0x92, 0x98, 0x8b, 0xab, 0xb6, 0x06, 0xd8, 0x11, 0xb0, 0x25, 0x60, 0x77, 0xbd, 0x3b,
-	0xa0, 0x80, 0x1c, 0x73, 0x76, 0x55, 0x7c, 0xc8, 0x47, 0xc8, 0x21, 0x1f, 0xc1, 0xc7, 0x9c, 0x52,
-	0x3e, 0xe6, 0x90, 0xb3, 0x92, 0x30, 0x95, 0x4b, 0xaa, 0x72, 0x4e, 0x8e, 0xa9, 0xe9, 0x99, 0x7d,
-	0x72, 0xa1, 0xb2, 0x95, 0xdc, 0x72, 0x21, 0x77, 0xa6, 0x1f, 0x98, 0x9e, 0xe9, 0xee, 0x5f, 0x77,
-	0xc3, 0x55, 0x3a, 0x1c, 0x39, 0x7b, 0x7c, 0xe9, 0xb3, 0x50, 0xfe, 0xed, 0xfb, 0x81, 0xc7, 0x3d,
-	0x52, 0xc1, 0x45, 0xef, 0xee, 0xd8, 0xe1, 0x93, 0xf9, 0xb0, 0x3f, 0xf2, 0x66, 0x7b, 0x63, 0x6f,
-	0xec, 0xed, 0x21, 0x75, 0x38, 0x7f, 0x81, 0x2b, 0x5c, 0xe0, 0x97, 0x94, 0xea, 0x1d, 0xa4, 0xd8,
-	0x27, 0x36, 0x1d, 0xdd, 0x75, 0xbc, 0x3d, 0xce, 0x5c, 0x9b, 0x05, 0x33, 0xc7, 0xe5, 0x7b, 0xa3,
-	0x60, 0xe9, 0x73, 0x6f, 0x6f, 0xc6, 0x82, 0x97, 0x53, 0xa6, 0xfe, 0x29, 0xc9, 0x7b, 0x6f, 0x96,
-	0x9c, 0x3a, 0xc3, 0x70, 0x6f, 0xe4, 0xcd, 0x66, 0x9e, 0x9b, 0x3e, 0x66, 0x6f, 0x7b, 0xec, 0x79,
-	0xe3, 0x29, 0x4b, 0x8e, 0xc5, 0x9d, 0x19, 0x0b, 0x39, 0x9d, 0xf9, 0x92, 0xc1, 0xf8, 0x7d, 0x19,
-	0x6a, 0x26, 0xfb, 0x7c, 0xce, 0x42, 0x4e, 0x76, 0xa0, 0xcc, 0x46, 0x13, 0xaf, 0x5b, 0xba, 0xa9,
-	0xed, 0x34, 0xf6, 0x49, 0x5f, 0x2a, 0x52, 0xd4, 0xc7, 0xa3, 0x89, 0x77, 0xb4, 0x66, 0x22, 0x07,
-	0x79, 0x0f, 0x2a, 0x2f, 0xa6, 0xf3, 0x70, 0xd2, 0x5d, 0x47, 0xd6, 0xcb, 0x59, 0xd6, 0x1f, 0x09,
-	0xd2, 0xd1, 0x9a, 0x29, 0x79, 0x84, 0x5a, 0xc7, 0x7d, 0xe1, 0x75, 0xcb, 0x45, 0x6a, 0x8f, 0xdd,
-	0x17, 0xa8, 0x56, 0x70, 0x90, 0x03, 0x80, 0x90, 0x71, 0xcb, 0xf3, 0xb9, 0xe3, 0xb9, 0xdd, 0x0a,
-	0xf2, 0x5f, 0xcb, 0xf2, 0x3f, 0x65, 0xfc, 0xa7, 0x48, 0x3e, 0x5a, 0x33, 0xf5, 0x30, 0x5a, 0x08,
-	0x49, 0xc7, 0x75, 0xb8, 0x35, 0x9a, 0x50, 0xc7, 0xed, 0x56, 0x8b, 0x24, 0x8f, 0x5d, 0x87, 0x3f,
-	0x14, 0x64, 0x21, 0xe9, 0x44, 0x0b, 0x61, 0xca, 0xe7, 0x73, 0x16, 0x2c, 0xbb, 0xb5, 0x22, 0x53,
-	0x7e, 0x26, 0x48, 0xc2, 0x14, 0xe4, 0x21, 0x0f, 0xa0, 0x31, 0x64, 0x63, 0xc7, 0xb5, 0x86, 0x53,
-	0x6f, 0xf4, 0xb2, 0x5b, 0x47, 0x91, 0x6e, 0x56, 0x64, 0x20, 0x18, 0x06, 0x82, 0x7e, 0xb4, 0x66,
-	0xc2, 0x30, 0x5e, 0x91, 0x7d, 0xa8, 0x8f, 0x26, 0x6c, 0xf4, 0xd2, 0xe2, 0x8b, 0xae, 0x8e, 0x92,
-	0x57, 0xb2, 0x92, 0x0f, 0x05, 0xf5, 0xd9, 0xe2, 0x68, 0xcd, 0xac, 0x8d, 0xe4, 0xa7, 0xb0, 0xcb,
-	0x66, 0x53, 0xe7, 0x8c, 0x05, 0x42, 0xea, 0x72, 0x91, 0x5d, 0x8f, 0x24, 0x1d, 0xe5, 0x74, 0x3b,
-	0x5a, 0x90, 0x7b, 0xa0, 0x33, 0xd7, 0x56, 0x07, 0x6d, 0xa0, 0xe0, 0xd5, 0xdc, 0x8b, 0xba, 0x76,
-	0x74, 0xcc, 0x3a, 0x53, 0xdf, 0xa4, 0x0f, 0x55, 0xe1, 0x46, 0x0e, 0xef, 0x6e, 0xa0, 0xcc, 0x66,
-	0xee, 0x88, 0x48, 0x3b, 0x5a, 0x33, 0x15, 0xd7, 0xa0, 0x06, 0x95, 0x33, 0x3a, 0x9d, 0x33, 0xe3,
-	0x1d, 0x68, 0xa4, 0x3c, 0x85, 0x74, 0xa1, 0x36, 0x63, 0x61, 0x48, 0xc7, 0xac, 0xab, 0xdd, 0xd4,
-	0x76, 0x74, 0x33, 0x5a, 0x1a, 0x2d, 0xd8, 0x48, 0xfb, 0x89, 0x31, 0x8b, 0x05, 0x85, 0x2f, 0x08,
-	0xc1, 0x33, 0x16, 0x84, 0xc2, 0x01, 0x94, 0xa0, 0x5a, 0x92, 0x5b, 0xd0, 0x44, 0x6b, 0xac, 0x88,
-	0x2e, 0xfc, 0xb4, 0x6c, 0x6e, 0xe0, 0xe6, 0xa9, 0x62, 0xda, 0x86, 0x86, 0xbf, 0xef, 0xc7, 0x2c,
-	0xeb, 0xc8, 0x02, 0xfe, 0xbe, 0xaf, 0x18, 0x8c, 0x1f, 0x40, 0x27, 0xef, 0x4a, 0xa4, 0x03, 0xeb,
-	0x2f, 0xd9, 0x52, 0xfd, 0x9e, 0xf8, 0x24, 0x9b, 0xca, 0x2c, 0xfc, 0x0d, 0xdd, 0x54, 0x36, 0x7e,
-	0x59, 0x8a, 0x85, 0x63, 0x6f, 0x22, 0x07, 0x50, 0x16, 0x41, 0x85, 0xd2, 0x8d, 0xfd, 0x5e, 0x5f,
-	0x46, 0x5c, 0x3f, 0x8a, 0xb8, 0xfe, 0xb3, 0x28, 0xe2, 0x06, 0xf5, 0xaf, 0x5f, 0x6f, 0xaf, 0x7d,
-	0xf9, 0xa7, 0x6d, 0xcd, 0x44, 0x09, 0x72, 0x5d, 0x38, 0x04, 0x75, 0x5c, 0xcb, 0xb1, 0xd5, 0xef,
-	0xd4, 0x70, 0x7d, 0x6c, 0x93, 0x43, 0xe8, 0x8c, 0x3c, 0x37, 0x64, 0x6e, 0x38, 0x0f, 0x2d, 0x9f,
-	0x06, 0x74, 0x16, 0xaa, 0x58, 0x8b, 0x1e, 0xf1, 0x61, 0x44, 0x3e, 0x41, 0xaa, 0xd9, 0x1e, 0x65,
-	0x37, 0xc8, 0x47, 0x00, 0x67, 0x74, 0xea, 0xd8, 0x94, 0x7b, 0x41, 0xd8, 0x2d, 0xdf, 0x5c, 0x4f,
-	0x09, 0x9f, 0x46, 0x84, 0xe7, 0xbe, 0x4d, 0x39, 0x1b, 0x94, 0xc5, 0xc9, 0xcc, 0x14, 0x3f, 0xb9,
-	0x0d, 0x6d, 0xea, 0xfb, 0x56, 0xc8, 0x29, 0x67, 0xd6, 0x70, 0xc9, 0x59, 0x88, 0xf1, 0xb8, 0x61,
-	0x36, 0xa9, 0xef, 0x3f, 0x15, 0xbb, 0x03, 0xb1, 0x69, 0xd8, 0xf1, 0x6b, 0x62, 0xa8, 0x10, 0x02,
-	0x65, 0x9b, 0x72, 0x8a, 0xb7, 0xb1, 0x61, 0xe2, 0xb7, 0xd8, 0xf3, 0x29, 0x9f, 0x28, 0x1b, 0xf1,
-	0x9b, 0x5c, 0x85, 0xea, 0x84, 0x39, 0xe3, 0x09, 0x47, 0xb3, 0xd6, 0x4d, 0xb5, 0x12, 0x17, 0xef,
-	0x07, 0xde, 0x19, 0xc3, 0x6c, 0x51, 0x37, 0xe5, 0xc2, 0xf8, 0x9b, 0x06, 0x97, 0x2e, 0x84, 0x97,
-	0xd0, 0x3b, 0xa1, 0xe1, 0x24, 0xfa, 0x2d, 0xf1, 0x4d, 0xde, 0x13, 0x7a, 0xa9, 0xcd, 0x02, 0x95,
-	0xc5, 0x9a, 0xca, 0xe2, 0x23, 0xdc, 0x54, 0x86, 0x2a, 0x16, 0xf2, 0x18, 0x3a, 0x53, 0x1a, 0x72,
-	0x4b, 0xfa, 0xb2, 0x85, 0x59, 0x6a, 0x3d, 0x13, 0x99, 0x4f, 0x68, 0xe4, 0xf3, 0xc2, 0x39, 0x95,
-	0x78, 0x6b, 0x9a, 0xd9, 0x25, 0x47, 0xb0, 0x39, 0x5c, 0xfe, 0x82, 0xba, 0xdc, 0x71, 0x99, 0x75,
-	0xe1, 0xce, 0xdb, 0x4a, 0xd5, 0xe3, 0x33, 0xc7, 0x66, 0xee, 0x28, 0xba, 0xec, 0xcb, 0xb1, 0x48,
-	0xfc, 0x18, 0xa1, 0x71, 0x04, 0xad, 0x6c, 0x2e, 0x20, 0x2d, 0x28, 0xf1, 0x85, 0xb2, 0xb0, 0xc4,
-	0x17, 0xe4, 0x36, 0x94, 0x85, 0x3a, 0xb4, 0xae, 0x15, 0x27, 0x53, 0xc5, 0xfd, 0x6c, 0xe9, 0x33,
-	0x13, 0xe9, 0xc6, 0x41, 0xec, 0xa9, 0x71, 0x7e, 0xb8, 0xa0, 0x6b, 0x13, 0x2a, 0x8e, 0x6b, 0xb3,
-	0x05, 0x2a, 0xab, 0x98, 0x72, 0x61, 0xec, 0x42, 0x3b, 0x97, 0x20, 0x52, 0x8f, 0xa5, 0xa5, 0x1f,
-	0xcb, 0x68, 0x43, 0x33, 0x93, 0x17, 0x8c, 0x2f, 0x2a, 0x50, 0x37, 0x59, 0xe8, 0x0b, 0x57, 0x24,
-	0x07, 0xa0, 0xb3, 0xc5, 0x88, 0xc9, 0x64, 0xae, 0xe5, 0x52, 0xa5, 0xe4, 0x79, 0x1c, 0xd1, 0x45,
-	0xee, 0x8a, 0x99, 0xc9, 0x6e, 0x06, 0x88, 0x2e, 0xe7, 0x85, 0xd2, 0x48, 0x74, 0x27, 0x8b, 0x44,
-	0x9b, 0x39, 0xde, 0x1c, 0x14, 0xed, 0x66, 0xa0, 0x28, 0xaf, 0x38, 0x83, 0x45, 0xf7, 0x0b, 0xb0,
-	0x28, 0x7f, 0xfc, 0x15, 0x60, 0x74, 0xbf, 0x00, 0x8c, 0xba, 0x17, 0x7e, 0xab, 0x10, 0x8d, 0xee,
-	0x64, 0xd1, 0x28, 0x6f, 0x4e, 0x0e, 0x8e, 0x3e, 0x2a, 0x82, 0xa3, 0xeb, 0x39, 0x99, 0x95, 0x78,
-	0xf4, 0xe1, 0x05, 0x3c, 0xba, 0x9a, 0x13, 0x2d, 0x00, 0xa4, 0xfb, 0x19, 0x40, 0x82, 0x42, 0xdb,
-	0x56, 0x20, 0xd2, 0xf7, 0x2f, 0x22, 0xd2, 0xb5, 0xfc, 0xd3, 0x16, 0x41, 0xd2, 0x5e, 0x0e, 0x92,
-	0xae, 0xe4, 0x4f, 0xb9, 0x12, 0x93, 0x76, 0x45, 0xd6, 0xc8, 0x79, 0x9a, 0xf0, 0x7a, 0x16, 0x04,
-	0x5e, 0xa0, 0xd2, 0xbd, 0x5c, 0x18, 0x3b, 0x22, 0x8f, 0x25, 0xfe, 0xf5, 0x06, 0xfc, 0x42, 0xa7,
-	0x4f, 0x79, 0x97, 0xf1, 0x95, 0x96, 0xc8, 0x62, 0x3e, 0x48, 0xe7, 0x40, 0x5d, 0xe5, 0xc0, 0x14,
-	0xac, 0x95, 0xb2, 0xb0, 0xb6, 0x0d, 0x0d, 0x91, 0x69, 0x73, 0x88, 0x45, 0xfd, 0x08, 0xb1, 0xc8,
-	0xbb, 0x70, 0x09, 0xb3, 0x94, 0x04, 0x3f, 0x15, 0x88, 0x65, 0x0c, 0xc4, 0xb6, 0x20, 0xc8, 0x1b,
-	0x93, 0xe9, 0xf3, 0x2e, 0x5c, 0x4e, 0xf1, 0x0a, 0xbd, 0x98, 0x21, 0x65, 0xea, 0xee, 0xc4, 0xdc,
-	0x87, 0xbe, 0x7f, 0x44, 0xc3, 0x89, 0xf1, 0x93, 0xe4, 0x82, 0x12, 0x34, 0x24, 0x50, 0x1e, 0x79,
-	0xb6, 0xb4, 0xbb, 0x69, 0xe2, 0xb7, 0x40, 0xc8, 0xa9, 0x37, 0xc6, 0xc3, 0xe9, 0xa6, 0xf8, 0x14,
-	0x5c, 0x71, 0x28, 0xe9, 0x32, 0x66, 0x8c, 0x5f, 0x6b, 0x89, 0xbe, 0x04, 0x20, 0x8b, 0xb0, 0x4c,
-	0xfb, 0x4f, 0xb0, 0xac, 0xf4, 0xed, 0xb0, 0xcc, 0x38, 0xd7, 0x92, 0x27, 0x8b, 0x51, 0xea, 0xed,
-	0x4c, 0x4c, 0x72, 0x66, 0x05, 0x1f, 0x40, 0x2e, 0xa2, 0x02, 0xa2, 0x8a, 0xd7, 0x9c, 0x2d, 0x20,
-	0x6a, 0xb8, 0x27, 0x17, 0xe4, 0x16, 0xa2, 0x9b, 0xf7, 0x42, 0x85, 0x6a, 0xb3, 0xaf, 0x6a, 0xfc,
-	0x13, 0xb1, 0x69, 0x4a, 0x5a, 0x2a, 0xdb, 0xea, 0x19, 0x68, 0xbc, 0x01, 0xba, 0x38, 0x68, 0xe8,
-	0xd3, 0x11, 0xc3, 0xc8, 0xd3, 0xcd, 0x64, 0xc3, 0x78, 0x06, 0xe4, 0x62, 0xc4, 0x93, 0x8f, 0xa1,
-	0xca, 0xce, 0x98, 0xcb, 0xc5, 0x8d, 0x8b, 0x4b, 0xdb, 0x88, 0xc1, 0x88, 0xb9, 0x7c, 0xd0, 0x15,
-	0x57, 0xf5, 0xf7, 0xd7, 0xdb, 0x1d, 0xc9, 0x73, 0xc7, 0x9b, 0x39, 0x9c, 0xcd, 0x7c, 0xbe, 0x34,
-	0x95, 0x94, 0xf1, 0x4f, 0x4d, 0xa0, 0x41, 0x26, 0x1b, 0x14, 0x5e, 0x5e, 0xe4, 0xf2, 0xa5, 0x14,
-	0xec, 0x7f, 0xb3, 0x0b, 0xfd, 0x7f, 0x80, 0x31, 0x0d, 0xad, 0x57, 0xd4, 0xe5, 0xcc, 0x56, 0xb7,
-	0xaa, 0x8f, 0x69, 0xf8, 0x73, 0xdc, 0x10, 0x35, 0x92, 0x20, 0xcf, 0x43, 0x66, 0xe3, 0xf5, 0xae,
-	0x9b, 0xb5, 0x31, 0x0d, 0x9f, 0x87, 0xcc, 0x4e, 0xd9, 0x56, 0x7b, 0x1b, 0xdb, 0xb2, 0xf7, 0x59,
-	0xcf, 0xdf, 0xe7, 0xaf, 0x4a, 0x89, 0x2f, 0x27, 0x10, 0xfa, 0x3f, 0x61, 0x7b, 0xe2, 0xe4, 0x7a,
-	0xba, 0x30, 0xf8, 0x87, 0x26, 0x6a, 0x8a, 0x6c, 0xa2, 0x26, 0xc7, 0x70, 0x29, 0x8e, 0x34, 0x6b,
-	0x8e, 0x11, 0x18, 0xf9, 0xda, 0x9b, 0x03, 0xb4, 0x73, 0x96, 0xdd, 0x0e, 0xc9, 0xa7, 0x70, 0x2d,
-	0x97, 0x27, 0x62, 0x85, 0xa5, 0x37, 0xa6, 0x8b, 0x2b, 0xd9, 0x74, 0x11, 0xe9, 0x4b, 0xee, 0x68,
-	0xfd, 0xad, 0x7c, 0xff, 0x3b, 0xa2, 0x18, 0x4b, 0x43, 0x4c, 0xd1, 0x4b, 0x1b, 0xbf, 0xd1, 0xa0,
-	0x9d, 0x3b, 0x10, 0xd9, 0x81, 0x8a, 0x44, 0x39, 0x2d, 0xd3, 0xf2, 0xe2, 0x8d, 0xa9, 0x33, 0x4b,
-	0x06, 0xf2, 0x01, 0xd4, 0x99, 0xaa, 0x0b, 0x95, 0x91, 0x57, 0x72, 0xe5, 0xa2, 0xe2, 0x8f, 0xd9,
-	0xc8, 0xf7, 0x40, 0x8f, 0xaf, 0x2e, 0xd7, 0x13, 0xc4, 0x37, 0xad, 0x84, 0x12, 0x46, 0xe3, 0x33,
-	0x68, 0xa4, 0x7e, 0x9e, 0xfc, 0x1f, 0xe8, 0x33, 0xba, 0x50, 0x85, 0xbd, 0x2c, 0xea, 0xea, 0x33,
-	0xba, 0xc0, 0x9a, 0x9e, 0x5c, 0x83, 0x9a, 0x20, 0x8e, 0xa9, 0xbc, 0xf8, 0x75, 0xb3, 0x3a, 0xa3,
-	0x8b, 0x1f, 0x53, 0x24, 0x4c, 0x99, 0x6b, 0xcd, 0xa7, 0xc3, 0xa8, 0x6a, 0x9f, 0x32, 0xf7, 0xf9,
-	0x74, 0x68, 0xec, 0x42, 0x2b, 0x7b, 0xde, 0x48, 0x47, 0x84, 0x9f, 0x52, 0xc7, 0xe1, 0x98, 0x19,
-	0xf7, 0xa0, 0x9d, 0x3b, 0x26, 0x31, 0xa0, 0xe9, 0xcf, 0x87, 0xd6, 0x4b, 0xb6, 0xb4, 0xd0, 0x0e,
-	0xf4, 0x1f, 0xdd, 0x6c, 0xf8, 0xf3, 0xe1, 0x27, 0x6c, 0x29, 0x8a, 0xda, 0xd0, 0x78, 0x0a, 0xad,
-	0x6c, 0x2d, 0x2e, 0x9c, 0x34, 0xf0, 0xe6, 0xae, 0x8d, 0xfa, 0x2b, 0xa6, 0x5c, 0x88, 0x76, 0xfe,
-	0xcc, 0x93, 0x2e, 0x93, 0x2e, 0xbe, 0x4f, 0x3d, 0xce, 0x52, 0x15, 0xbc, 0xe4, 0x31, 0x1c, 0xa8,
-	0xa0, 0x33, 0x88, 0x87, 0xc5, 0xaa, 0x5a, 0x21, 0xb6, 0xf8, 0x26, 0x4f, 0x00, 0x28, 0xe7, 0x81,
-	0x33, 0x9c, 0x27, 0xea, 0x5a, 0x7d, 0x39, 0x63, 0xe9, 0x7f, 0x72, 0x7a, 0x42, 0x9d, 0x60, 0x70,
-	0x43, 0x39, 0xd1, 0x66, 0xc2, 0x99, 0x72, 0xa4, 0x94, 0xbc, 0xf1, 0xcb, 0x0a, 0x54, 0x65, 0x0f,
-	0x42, 0xfa, 0xd9, 0x0e, 0x57, 0x68, 0x55, 0x87, 0x94, 0xbb, 0xea, 0x8c, 0x71, 0x81, 0x70, 0x3b,
-	0xdf, 0x26, 0x0e, 0x1a, 0xe7, 0xaf, 0xb7, 0x6b, 0x08, 0xae, 0xc7, 0x8f, 0x92, 0x9e, 0x71, 0x55,
-	0x4b, 0x15, 0x35, 0xa8, 0xe5, 0x6f, 0xdd, 0xa0, 0x5e, 0x83, 0x9a, 0x3b, 0x9f, 0x59, 0x7c, 0x11,
-	0xaa, 0xe4, 0x54, 0x75, 0xe7, 0xb3, 0x67, 0x0b, 0x74, 0x1f, 0xee, 0x71, 0x3a, 0x45, 0x92, 0x4c,
-	0x4d, 0x75, 0xdc, 0x10, 0xc4, 0x03, 0x68, 0xa6, 0x6a, 0x10, 0xc7, 0x56, 0xb5, 0x6c, 0x2b, 0x1d,
-	0x05, 0xc7, 0x8f, 0x94, 0x95, 0x8d, 0xb8, 0x26, 0x39, 0xb6, 0xc9, 0x4e, 0xb6, 0x1f, 0xc3, 0xd2,
-	0xa5, 0x8e, 0xb1, 0x96, 0x6a, 0xb9, 0x44, 0xe1, 0x22, 0x0e, 0x20, 0xa2, 0x4f, 0xb2, 0xe8, 0xc8,
-	0x52, 0x17, 0x1b, 0x48, 0x7c, 0x07, 0xda, 0x09, 0xfa, 0x4b, 0x16, 0x90, 0x5a, 0x92, 0x6d, 0x64,
-	0x7c, 0x1f, 0x36, 0x5d, 0xb6, 0xe0, 0x56, 0x9e, 0xbb, 0x81, 0xdc, 0x44, 0xd0, 0x4e, 0xb3, 0x12,
-	0xdf, 0x85, 0x56, 0x92, 0xa3, 0x90, 0x77, 0x43, 0x76, 0xc5, 0xf1, 0x2e, 0xb2, 0x5d, 0x87, 0x7a,
-	0x5c, 0x7b, 0x35, 0x91, 0xa1, 0x46, 0x65, 0xc9, 0x15, 0x57, 0x73, 0x01, 0x0b, 0xe7, 0x53, 0xae,
-	0x94, 0xb4, 0x90, 0x07, 0xab, 0x39, 0x53, 0xee, 0x23, 0xef, 0x2d, 0x68, 0x46, 0x61, 0x2f, 0xf9,
-	0xda, 0xc8, 0xb7, 0x11, 0x6d, 0x22, 0xd3, 0x2e, 0x74, 0xfc, 0xc0, 0xf3, 0xbd, 0x90, 0x05, 0x16,
-	0xb5, 0xed, 0x80, 0x85, 0x61, 0xb7, 0x23, 0xf5, 0x45, 0xfb, 0x87, 0x72, 0xdb, 0xf8, 0x00, 0x6a,
-	0x51, 0x51, 0xb9, 0x09, 0x95, 0x41, 0x9c, 0xa2, 0xca, 0xa6, 0x5c, 0x08, 0xd8, 0x3a, 0xf4, 0x7d,
-	0x35, 0x58, 0x11, 0x9f, 0xc6, 0x67, 0x50, 0x53, 0x0f, 0x56, 0xd8, 0x6e, 0xff, 0x10, 0x36, 0x7c,
-	0x1a, 0x08, 0x33, 0xd2, 0x4d, 0x77, 0xd4, 0xb6, 0x9c, 0xd0, 0x80, 0x3f, 0x65, 0x3c, 0xd3, 0x7b,
-	0x37, 0x90, 0x5f, 0x6e, 0x19, 0xf7, 0xa1, 0x99, 0xe1, 0x11, 0xc7, 0x42, 0x3f, 0x8a, 0x82, 0x1a,
-	0x17, 0xf1, 0x2f, 0x97, 0x92, 0x5f, 0x36, 0x1e, 0x80, 0x1e, 0xbf, 0x8d, 0xa8, 0xae, 0x23, 0xd3,
-	0x35, 0x75, 0xdd, 0x72, 0x89, 0xf3, 0x04, 0xef, 0x15, 0x0b, 0x54, 0x4c, 0xc8, 0x85, 0xf1, 0x3c,
-	0x95, 0x84, 0x24, 0x5c, 0x90, 0x3b, 0x50, 0x53, 0x49, 0x48, 0x45, 0x65, 0x34, 0x39, 0x38, 0xc1,
-	0x2c, 0x14, 0x4d, 0x0e, 0x64, 0x4e, 0x4a, 0xd4, 0x96, 0xd2, 0x6a, 0xa7, 0x50, 0x8f, 0x12, 0x4d,
-	0x36, 0x4d, 0x4b, 0x8d, 0x9d, 0x7c, 0x9a, 0x56, 0x4a, 0x13, 0x46, 0xe1, 0x1d, 0xa1, 0x33, 0x76,
-	0x99, 0x6d, 0x25, 0x21, 0x84, 0xbf, 0x51, 0x37, 0xdb, 0x92, 0xf0, 0x24, 0x8a, 0x17, 0xe3, 0x7d,
-	0xa8, 0xca, 0xb3, 0x15, 0xa6, 0xaf, 0x22, 0xac, 0xfa, 0xa3, 0x06, 0xf5, 0x28, 0x4f, 0x17, 0x0a,
-	0x65, 0x0e, 0x5d, 0xfa, 0xa6, 0x87, 0xfe, 0xef, 0x27, 0x9e, 0x3b, 0x40, 0x64, 0x7e, 0x39, 0xf3,
-	0xb8, 0xe3, 0x8e, 0x2d, 0x79, 0xd7, 0x32, 0x07, 0x75, 0x90, 0x72, 0x8a, 0x84, 0x13, 0xb1, 0xff,
-	0xee, 0x2d, 0x68, 0xa4, 0x06, 0x20, 0xa4, 0x06, 0xeb, 0x9f, 0xb2, 0x57, 0x9d, 0x35, 0xd2, 0x80,
-	0x9a, 0xc9, 0xb0, 0x71, 0xed, 0x68, 0xfb, 0x5f, 0x54, 0xa0, 0x7d, 0x38, 0x78, 0x78, 0x7c, 0xe8,
-	0xfb, 0x53, 0x67, 0x44, 0xb1, 0xd3, 0xd9, 0x83, 0x32, 0x36, 0x7b, 0x05, 0xa3, 0xee, 0x5e, 0xd1,
-	0xd4, 0x81, 0xec, 0x43, 0x05, 0x7b, 0x3e, 0x52, 0x34, 0xf1, 0xee, 0x15, 0x0e, 0x1f, 0xc4, 0x8f,
-	0xc8, 0xae, 0xf0, 0xe2, 0xe0, 0xbb, 0x57, 0x34, 0x81, 0x20, 0x1f, 0x83, 0x9e, 0x34, 0x63, 0xab,
-	0xc6, 0xdf, 0xbd, 0x95, 0xb3, 0x08, 0x21, 0x9f, 0x14, 0xac, 0xab, 0x86, 0xc5, 0xbd, 0x95, 0x4d,
-	0x3b, 0x39, 0x80, 0x5a, 0x54, 0xea, 0x17, 0x0f, 0xa8, 0x7b, 0x2b, 0xe6, 0x04, 0xe2, 0x7a, 0x64,
-	0x7f, 0x55, 0x34, 0x45, 0xef, 0x15, 0x0e, 0x33, 0xc8, 0x3d, 0xa8, 0xaa, 0xea, 0xaa, 0x70, 0xd4,
-	0xdc, 0x2b, 0xee, 0xf6, 0x85, 0x91, 0x49, 0x87, 0xb9, 0x6a, 0xd2, 0xdf, 0x5b, 0x39, 0x75, 0x21,
-	0x87, 0x00, 0xa9, 0x36, 0x69, 0xe5, 0x08, 0xbf, 0xb7, 0x7a, 0x9a, 0x42, 0x1e, 0x40, 0x3d, 0x99,
-	0x90, 0x15, 0x8f, 0xd6, 0x7b, 0xab, 0x06, 0x1c, 0x83, 0x1b, 0xff, 0xfa, 0xcb, 0x96, 0xf6, 0xdb,
-	0xf3, 0x2d, 0xed, 0xab, 0xf3, 0x2d, 0xed, 0xeb, 0xf3, 0x2d, 0xed, 0x0f, 0xe7, 0x5b, 0xda, 0x9f,
-	0xcf, 0xb7, 0xb4, 0xdf, 0xfd, 0x75, 0x4b, 0x1b, 0x56, 0x31, 0x46, 0x3e, 0xfc, 0x77, 0x00, 0x00,
-	0x00, 0xff, 0xff, 0x00, 0xdb, 0xe6, 0x59, 0x7e, 0x1a, 0x00, 0x00,
+	// 2642 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xe4, 0x59, 0xcf, 0x6f, 0x1b, 0xc7,
+	0xf5, 0xd7, 0xf2, 0x87, 0xc8, 0x7d, 0x24, 0x45, 0x6a, 0x24, 0xdb, 0x0c, 0xbf, 0xf9, 0x4a, 0xc6,
+	0xba, 0x4d, 0xe4, 0xc4, 0x91, 0x12, 0xa5, 0x2e, 0xe4, 0x26, 0x0d, 0x20, 0xda, 0x4e, 0x25, 0xd8,
+	0x4e, 0xd4, 0x8d, 0xa3, 0xf6, 0x50, 0x60, 0x31, 0xe4, 0x8e, 0xa8, 0x85, 0xc9, 0xdd, 0xcd, 0xee,
+	0x50, 0x21, 0x73, 0x6c, 0xd1, 0x5b, 0x80, 0xe6, 0x50, 0xa0, 0xff, 0x40, 0x0f, 0x3d, 0xf6, 0x98,
+	0x63, 0x4f, 0x45, 0x8e, 0x3d, 0xf4, 0x9c, 0xb6, 0x2a, 0x7a, 0x29, 0xd0, 0x7b, 0x8e, 0xc5, 0xbc,
+	0x99, 0x59, 0xee, 0xae, 0x96, 0x46, 0x92, 0xf6, 0xd6, 0x8b, 0xb4, 0x33, 0xef, 0xf3, 0x86, 0xf3,
+	0xde, 0xcc, 0x7b, 0x9f, 0x99, 0x37, 0x70, 0x9d, 0x0e, 0x86, 0xde, 0x1e, 0x9f, 0x87, 0x2c, 0x96,
+	0x7f, 0x77, 0xc3, 0x28, 0xe0, 0x01, 0xa9, 0x62, 0xa3, 0xf7, 0xda, 0xc8, 0xe3, 0xe7, 0xd3, 0xc1,
+	0xee, 0x30, 0x98, 0xec, 0x8d, 0x82, 0x51, 0xb0, 0x87, 0xd2, 0xc1, 0xf4, 0x0c, 0x5b, 0xd8, 0xc0,
+	0x2f, 0xa9, 0xd5, 0x3b, 0x48, 0xc1, 0xcf, 0x5d, 0x3a, 0x7c, 0xcd, 0x0b, 0xf6, 0x38, 0xf3, 0x5d,
+	0x16, 0x4d, 0x3c, 0x9f, 0xef, 0x0d, 0xa3, 0x79, 0xc8, 0x83, 0xbd, 0x09, 0x8b, 0x9e, 0x8d, 0x99,
+	0xfa, 0xa7, 0x34, 0xef, 0x3e, 0x5f, 0x73, 0xec, 0x0d, 0xe2, 0xbd, 0x61, 0x30, 0x99, 0x04, 0x7e,
+	0x7a, 0x9a, 0xbd, 0xed, 0x51, 0x10, 0x8c, 0xc6, 0x6c, 0x31, 0x2d, 0xee, 0x4d, 0x58, 0xcc, 0xe9,
+	0x24, 0x94, 0x00, 0xeb, 0x8f, 0x15, 0xa8, 0xd9, 0xec, 0xa3, 0x29, 0x8b, 0x39, 0xd9, 0x81, 0x0a,
+	0x1b, 0x9e, 0x07, 0xdd, 0xd2, 0x4d, 0x63, 0xa7, 0xb1, 0x4f, 0x76, 0xe5, 0x40, 0x4a, 0xfa, 0x70,
+	0x78, 0x1e, 0x1c, 0xad, 0xd8, 0x88, 0x20, 0xaf, 0x42, 0xf5, 0x6c, 0x3c, 0x8d, 0xcf, 0xbb, 0x65,
+	0x84, 0x6e, 0x64, 0xa1, 0xef, 0x0a, 0xd1, 0xd1, 0x8a, 0x2d, 0x31, 0x62, 0x58, 0xcf, 0x3f, 0x0b,
+	0xba, 0x95, 0xa2, 0x61, 0x8f, 0xfd, 0x33, 0x1c, 0x56, 0x20, 0xc8, 0x01, 0x40, 0xcc, 0xb8, 0x13,
+	0x84, 0xdc, 0x0b, 0xfc, 0x6e, 0x15, 0xf1, 0x37, 0xb2, 0xf8, 0x0f, 0x18, 0x7f, 0x1f, 0xc5, 0x47,
+	0x2b, 0xb6, 0x19, 0xeb, 0x86, 0xd0, 0xf4, 0x7c, 0x8f, 0x3b, 0xc3, 0x73, 0xea, 0xf9, 0xdd, 0xd5,
+	0x22, 0xcd, 0x63, 0xdf, 0xe3, 0xf7, 0x85, 0x58, 0x68, 0x7a, 0xba, 0x21, 0x4c, 0xf9, 0x68, 0xca,
+	0xa2, 0x79, 0xb7, 0x56, 0x64, 0xca, 0x8f, 0x85, 0x48, 0x98, 0x82, 0x18, 0xf2, 0x16, 0x34, 0x06,
+	0x6c, 0xe4, 0xf9, 0xce, 0x60, 0x1c, 0x0c, 0x9f, 0x75, 0xeb, 0xa8, 0xd2, 0xcd, 0xaa, 0xf4, 0x05,
+	0xa0, 0x2f, 0xe4, 0x47, 0x2b, 0x36, 0x0c, 0x92, 0x16, 0xd9, 0x87, 0xfa, 0xf0, 0x9c, 0x0d, 0x9f,
+	0x39, 0x7c, 0xd6, 0x35, 0x51, 0xf3, 0x5a, 0x56, 0xf3, 0xbe, 0x90, 0x3e, 0x9d, 0x1d, 0xad, 0xd8,
+	0xb5, 0xa1, 0xfc, 0x14, 0x76, 0xb9, 0x6c, 0xec, 0x5d, 0xb0, 0x48, 0x68, 0x6d, 0x14, 0xd9, 0xf5,
+	0x40, 0xca, 0x51, 0xcf, 0x74, 0x75, 0x83, 0xdc, 0x05, 0x93, 0xf9, 0xae, 0x9a, 0x68, 0x03, 0x15,
+	0xaf, 0xe7, 0x56, 0xd4, 0x77, 0xf5, 0x34, 0xeb, 0x4c, 0x7d, 0x93, 0x5d, 0x58, 0x15, 0xdb, 0xc8,
+	0xe3, 0xdd, 0x26, 0xea, 0x6c, 0xe6, 0xa6, 0x88, 0xb2, 0xa3, 0x15, 0x5b, 0xa1, 0xfa, 0x35, 0xa8,
+	0x5e, 0xd0, 0xf1, 0x94, 0x59, 0x2f, 0x43, 0x23, 0xb5, 0x53, 0x48, 0x17, 0x6a, 0x13, 0x16, 0xc7,
+	0x74, 0xc4, 0xba, 0xc6, 0x4d, 0x63, 0xc7, 0xb4, 0x75, 0xd3, 0x5a, 0x83, 0x66, 0x7a, 0x9f, 0x58,
+	0xbf, 0x29, 0x41, 0x23, 0xb5, 0x19, 0x84, 0xe6, 0x05, 0x8b, 0x62, 0xb1, 0x03, 0x94, 0xa6, 0x6a,
+	0x92, 0x5b, 0xd0, 0x42, 0x73, 0x1c, 0x2d, 0x17, 0x1b, 0xb5, 0x62, 0x37, 0xb1, 0xf3, 0x54, 0x81,
+	0xb6, 0xa1, 0x11, 0xee, 0x87, 0x09, 0xa4, 0x8c, 0x10, 0x08, 0xf7, 0x43, 0x0d, 0xb8, 0x0d, 0x9d,
+	0x61, 0xe0, 0xc7, 0xcc, 0x8f, 0xa7, 0xb1, 0x33, 0x09, 0xdc, 0xe9, 0x98, 0xe1, 0xd6, 0x34, 0xed,
+	0x76, 0xd2, 0xff, 0x04, 0xbb, 0xc9, 0x0d, 0xa8, 0x8d, 0x99, 0xef, 0x4c, 0xc7, 0x03, 0xdc, 0x8c,
+	0x65, 0x7b, 0x75, 0xcc, 0xfc, 0x0f, 0xc7, 0x03, 0xb2, 0x0f, 0xd7, 0xc6, 0x34, 0xe6, 0xce, 0x99,
+	0xe7, 0xd3, 0xb1, 0xf7, 0x09, 0x73, 0x9d, 0x73, 0xe6, 0x8d, 0xce, 0x39, 0xee, 0xbc, 0xb2, 0xbd,
+	0x21, 0x84, 0xef, 0x6a, 0xd9, 0x11, 0x8a, 0xc8, 0xeb, 0xb0, 0x89, 0x3a, 0x61, 0x14, 0x84, 0x41,
+	0xbc, 0x50, 0xa9, 0xa1, 0x0a, 0x11, 0xb2, 0x13, 0x25, 0x92, 0x1a, 0xd6, 0x0f, 0xa0, 0x93, 0xdf,
+	0xf5, 0xa4, 0x03, 0xe5, 0x67, 0x6c, 0xae, 0x3c, 0x23, 0x3e, 0xc9, 0xa6, 0x5a, 0x01, 0xf4, 0x86,
+	0x69, 0xab, 0xe5, 0xf8, 0xac, 0x04, 0x9d, 0xfc, 0xc6, 0x27, 0x07, 0x50, 0x11, 0xf1, 0x8f, 0xda,
+	0x8d, 0xfd, 0xde, 0xae, 0x4c, 0x0e, 0xbb, 0x3a, 0x39, 0xec, 0x3e, 0xd5, 0xc9, 0xa1, 0x5f, 0xff,
+	0xe2, 0xcb, 0xed, 0x95, 0xcf, 0xfe, 0xb2, 0x6d, 0xd8, 0xa8, 0x41, 0x5e, 0x10, 0x7b, 0x97, 0x7a,
+	0xbe, 0xe3, 0xb9, 0xea, 0x77, 0x6a, 0xd8, 0x3e, 0x76, 0xc9, 0x61, 0xda, 0x9f, 0x21, 0x8d, 0xe8,
+	0x24, 0xee, 0x96, 0x33, 0xfb, 0xed, 0xbe, 0x16, 0x9f, 0xa0, 0x34, 0xe5, 0x67, 0xd9, 0x41, 0xde,
+	0x06, 0xb8, 0xa0, 0x63, 0xcf, 0xa5, 0x3c, 0x88, 0xe2, 0x6e, 0xe5, 0x66, 0x39, 0xa5, 0x7c, 0xaa,
+	0x05, 0x1f, 0x86, 0x2e, 0xe5, 0xac, 0x5f, 0x11, 0x33, 0xb3, 0x53, 0x78, 0xf2, 0x12, 0xb4, 0x69,
+	0x18, 0x3a, 0x31, 0xa7, 0x9c, 0x39, 0x83, 0x39, 0x67, 0x31, 0xae, 0x56, 0xd3, 0x6e, 0xd1, 0x30,
+	0xfc, 0x40, 0xf4, 0xf6, 0x45, 0xa7, 0xe5, 0x42, 0x33, 0x1d, 0xd5, 0x84, 0x40, 0xc5, 0xa5, 0x9c,
+	0xa2, 0x37, 0x9a, 0x36, 0x7e, 0x8b, 0xbe, 0x90, 0xf2, 0x73, 0x65, 0x23, 0x7e, 0x93, 0xeb, 0xb0,
+	0xaa, 0x96, 0xaa, 0x2c, 0x37, 0x81, 0x6c, 0x09, 0xc7, 0x87, 0x51, 0x70, 0x21, 0x77, 0x4f, 0xdd,
+	0x96, 0x0d, 0xeb, 0x1f, 0x06, 0xac, 0x5f, 0xc9, 0x04, 0x62, 0xdc, 0x73, 0x1a, 0x9f, 0xeb, 0xdf,
+	0x12, 0xdf, 0xe4, 0x55, 0x31, 0x2e, 0x75, 0x59, 0xa4, 0x12, 0x6e, 0x4b, 0x59, 0x7c, 0x84, 0x9d,
+	0xca, 0x50, 0x05, 0x21, 0x0f, 0xa1, 0x83, 0xbb, 0x47, 0x86, 0x9d, 0x83, 0x09, 0xb5, 0x9c, 0x49,
+	0x22, 0x8f, 0xa9, 0x0e, 0x4f, 0x11, 0x46, 0x4a, 0x7d, 0x6d, 0x9c, 0xe9, 0x25, 0x47, 0xb0, 0x39,
+	0x98, 0x7f, 0x42, 0x7d, 0xee, 0xf9, 0xcc, 0xb9, 0xe2, 0xf3, 0xb6, 0x1a, 0xea, 0xe1, 0x85, 0xe7,
+	0x32, 0x7f, 0xa8, 0x9d, 0xbd, 0x91, 0xa8, 0x24, 0x8b, 0x11, 0x5b, 0x47, 0xb0, 0x96, 0x4d, 0x5b,
+	0x64, 0x0d, 0x4a, 0x7c, 0xa6, 0x2c, 0x2c, 0xf1, 0x19, 0x79, 0x09, 0x2a, 0x62, 0x38, 0xb4, 0x6e,
+	0x2d, 0xc9, 0xfb, 0x0a, 0xfd, 0x74, 0x1e, 0x32, 0x1b, 0xe5, 0xd6, 0x01, 0x74, 0xf2, 0xa9, 0xec,
+	0xca, 0x58, 0x9b, 0x50, 0xf5, 0x7c, 0x97, 0xcd, 0x70, 0xb0, 0xaa, 0x2d, 0x1b, 0xd6, 0x6d, 0x68,
+	0xe7, 0x72, 0x59, 0x6a, 0xb1, 0x8c, 0xf4, 0x62, 0x59, 0x6d, 0x68, 0x65, 0x52, 0x98, 0xf5, 0x69,
+	0x15, 0xea, 0x36, 0x8b, 0x43, 0xb1, 0x15, 0xc9, 0x01, 0x98, 0x6c, 0x36, 0x64, 0x92, 0x77, 0x8c,
+	0x5c, 0x56, 0x97, 0x98, 0x87, 0x5a, 0x2e, 0xd2, 0x6c, 0x02, 0x26, 0xb7, 0x33, 0x9c, 0xb9, 0x91,
+	0x57, 0x4a, 0x93, 0xe6, 0x9d, 0x2c, 0x69, 0x6e, 0xe6, 0xb0, 0x39, 0xd6, 0xbc, 0x9d, 0x61, 0xcd,
+	0xfc, 0xc0, 0x19, 0xda, 0xbc, 0x57, 0x40, 0x9b, 0xf9, 0xe9, 0x2f, 0xe1, 0xcd, 0x7b, 0x05, 0xbc,
+	0xd9, 0xbd, 0xf2, 0x5b, 0x85, 0xc4, 0x79, 0x27, 0x4b, 0x9c, 0x79, 0x73, 0x72, 0xcc, 0xf9, 0x76,
+	0x11, 0x73, 0xbe, 0x90, 0xd3, 0x59, 0x4a, 0x9d, 0x6f, 0x5e, 0xa1, 0xce, 0xeb, 0x39, 0xd5, 0x02,
+	0xee, 0xbc, 0x97, 0xe1, 0x4e, 0x28, 0xb4, 0x6d, 0x09, 0x79, 0x7e, 0xff, 0x2a, 0x79, 0xde, 0xc8,
+	0x2f, 0x6d, 0x11, 0x7b, 0xee, 0xe5, 0xd8, 0xf3, 0x5a, 0x7e, 0x96, 0x4b, 0xe9, 0xf3, 0x36, 0xac,
+	0x6b, 0x50, 0xb2, 0xd3, 0xc4, 0xae, 0x67, 0x51, 0x14, 0x44, 0x2a, 0xdd, 0xcb, 0x86, 0xb5, 0x03,
+	0xcd, 0x04, 0xfa, 0x7c, 0xaa, 0xc5, 0x4d, 0x9f, 0xda, 0x5d, 0xd6, 0x57, 0x06, 0x34, 0xd3, 0x5b,
+	0x28, 0x93, 0x03, 0x4d, 0x95, 0x03, 0x53, 0x04, 0x5c, 0xca, 0x12, 0xf0, 0x36, 0x34, 0x44, 0xa6,
+	0xcd, 0x71, 0x2b, 0x0d, 0x13, 0x6e, 0x7d, 0x05, 0xd6, 0x31, 0x4b, 0x49, 0x9a, 0x56, 0x81, 0x58,
+	0xc1, 0x40, 0x6c, 0x0b, 0x81, 0xf4, 0x18, 0x76, 0x93, 0xd7, 0x60, 0x23, 0x85, 0x15, 0xe3, 0x62,
+	0x86, 0x94, 0xa9, 0xbb, 0x93, 0xa0, 0x0f, 0xc3, 0xf0, 0x48, 0x64, 0xcb, 0x2d, 0x68, 0x4c, 0x3c,
+	0xdf, 0xd1, 0x7c, 0x2c, 0x89, 0xd6, 0x9c, 0x78, 0xfe, 0x63, 0x49, 0xc9, 0x42, 0x4e, 0x67, 0x89,
+	0xbc, 0xa6, 0xe4, 0x74, 0x26, 0xe5, 0xd6, 0x13, 0x58, 0xbf, 0x12, 0x0b, 0xc2, 0xfc, 0x61, 0xe0,
+	0x4a, 0xbf, 0xb5, 0x6c, 0xfc, 0x16, 0x0c, 0x3b, 0x0e, 0x46, 0x68, 0x9c, 0x69, 0x8b, 0x4f, 0x81,
+	0x4a, 0x42, 0xd1, 0x94, 0x31, 0x67, 0xfd, 0xda, 0x80, 0xf5, 0x2b, 0x01, 0x52, 0xc8, 0x85, 0xc6,
+	0x7f, 0xc2, 0x85, 0xa5, 0x6f, 0xc6, 0x85, 0xd6, 0xa5, 0x01, 0xad, 0x4c, 0x04, 0x7e, 0x7b, 0x13,
+	0x17, 0x39, 0x57, 0x9e, 0x7d, 0x64, 0x43, 0x1f, 0x40, 0x56, 0x71, 0x99, 0xb2, 0x07, 0x90, 0x1a,
+	0xf6, 0xc9, 0x06, 0xb9, 0x85, 0xec, 0x18, 0x9c, 0xa9, 0x50, 0x6f, 0xed, 0xaa, 0xeb, 0xcc, 0x89,
+	0xe8, 0xb4, 0xa5, 0x2c, 0x95, 0xad, 0xcd, 0x0c, 0xb5, 0xbe, 0x08, 0xa6, 0x98, 0x68, 0x1c, 0xd2,
+	0x21, 0xc3, 0xc8, 0x35, 0xed, 0x45, 0x87, 0xf5, 0x14, 0xc8, 0xd5, 0x8c, 0x41, 0xde, 0x81, 0x55,
+	0x76, 0xc1, 0x7c, 0x2e, 0x3c, 0x2e, 0x9c, 0xd6, 0x4c, 0xc8, 0x8c, 0xf9, 0xbc, 0xdf, 0x15, 0xae,
+	0xfa, 0xe7, 0x97, 0xdb, 0x1d, 0x89, 0xb9, 0x13, 0x4c, 0x3c, 0xce, 0x26, 0x21, 0x9f, 0xdb, 0x4a,
+	0xcb, 0xfa, 0x45, 0x19, 0xda, 0x7a, 0x58, 0x4d, 0x69, 0x45, 0xce, 0xd3, 0x21, 0x53, 0x4a, 0x1d,
+	0x1b, 0xbe, 0x9e, 0x43, 0xff, 0x1f, 0x60, 0x44, 0x63, 0xe7, 0x63, 0xea, 0x73, 0xe6, 0x2a, 0xaf,
+	0x9a, 0x23, 0x1a, 0xff, 0x04, 0x3b, 0xc4, 0x19, 0x4b, 0x88, 0xa7, 0x31, 0x73, 0xd5, 0xf6, 0xae,
+	0x8d, 0x68, 0xfc, 0x61, 0xcc, 0xdc, 0x94, 0x6d, 0xb5, 0x6f, 0x63, 0x5b, 0xd6, 0x9f, 0xf5, 0x9c,
+	0x3f, 0xc9, 0xff, 0x81, 0xe9, 0x32, 0x77, 0x1a, 0x3a, 0x62, 0x61, 0x4d, 0x34, 0xab, 0x8e, 0x1d,
+	0x8f, 0xd8, 0x5c, 0x2c, 0x51, 0x8c, 0xf7, 0x4c, 0xb5, 0x0e, 0xaa, 0x25, 0x56, 0xdd, 0x0f, 0xfc,
+	0x21, 0xc3, 0xf4, 0x58, 0xb1, 0x65, 0x83, 0xf4, 0xa0, 0x1e, 0x46, 0x5e, 0x10, 0x79, 0x7c, 0x8e,
+	0x29, 0xb0, 0x6c, 0x27, 0x6d, 0x71, 0x7c, 0x1f, 0x06, 0xfe, 0xd9, 0xd8, 0x1b, 0x72, 0xf1, 0x4b,
+	0x71, 0xb7, 0x75, 0xb3, 0xbc, 0xd3, 0xb4, 0x9b, 0xba, 0xf3, 0x11, 0x9b, 0xc7, 0xd6, 0xaf, 0x4a,
+	0xb0, 0x7e, 0x25, 0x39, 0xff, 0x8f, 0xac, 0x43, 0x12, 0x70, 0x66, 0xfa, 0x90, 0xf3, 0x2f, 0x03,
+	0x3a, 0xda, 0x23, 0xc9, 0x31, 0xe7, 0x18, 0xd6, 0x93, 0xa8, 0x77, 0xa6, 0x98, 0x0d, 0xf4, 0xbe,
+	0x7f, 0x7e, 0xb2, 0xe8, 0x5c, 0x64, 0xbb, 0x63, 0xf2, 0x1e, 0xdc, 0xc8, 0xe5, 0xac, 0x64, 0xc0,
+	0xd2, 0x73, 0x53, 0xd7, 0xb5, 0x6c, 0xea, 0xd2, 0xe3, 0x2d, 0x7c, 0x54, 0xfe, 0x56, 0x71, 0xf8,
+	0x1d, 0x58, 0xd3, 0xe6, 0x4a, 0xba, 0x2c, 0x5a, 0x69, 0xeb, 0xb7, 0x06, 0xb4, 0x73, 0x13, 0x22,
+	0x3b, 0x50, 0x95, 0x8c, 0x6d, 0x64, 0x2a, 0x0d, 0xe8, 0x31, 0x35, 0x67, 0x09, 0x20, 0x6f, 0x40,
+	0x9d, 0xa9, 0x33, 0x6e, 0xb7, 0x94, 0x61, 0x6a, 0x7d, 0xf4, 0x55, 0xf8, 0x04, 0x46, 0xbe, 0x07,
+	0x66, 0xe2, 0xba, 0xdc, 0xfd, 0x26, 0xf1, 0xb4, 0x52, 0x5a, 0x00, 0xad, 0xcf, 0x4b, 0xd0, 0x48,
+	0xfd, 0xbe, 0x08, 0x35, 0xc1, 0x52, 0xf2, 0x96, 0x22, 0x4f, 0xa8, 0xf5, 0x09, 0x9d, 0xe1, 0x05,
+	0x45, 0x5c, 0x37, 0x85, 0x70, 0x44, 0xa5, 0xe7, 0xcb, 0xf6, 0xea, 0x84, 0xce, 0x7e, 0x44, 0xe3,
+	0xf4, 0x3d, 0xb4, 0x9c, 0xb9, 0x87, 0xde, 0x01, 0x22, 0xae, 0x67, 0xc1, 0x34, 0xb9, 0x56, 0x3a,
+	0x93, 0x58, 0x11, 0x6e, 0x47, 0x49, 0xd4, 0xa5, 0xf2, 0x49, 0x9c, 0x45, 0xb3, 0x8b, 0x80, 0x23,
+	0xba, 0x9a, 0x43, 0xa3, 0xe0, 0x49, 0x2c, 0xee, 0xab, 0x29, 0xb4, 0xba, 0x77, 0x4c, 0x62, 0x15,
+	0x12, 0x64, 0x81, 0x97, 0xa2, 0x27, 0xb1, 0x60, 0x7f, 0xad, 0xb1, 0x80, 0x4b, 0x22, 0x6e, 0x2b,
+	0xc1, 0x7d, 0x8d, 0xbd, 0x09, 0x4d, 0x61, 0x2b, 0xd7, 0xbe, 0xa8, 0x23, 0x0c, 0x26, 0x74, 0xf6,
+	0x54, 0x7a, 0xc3, 0xfa, 0x29, 0xac, 0x65, 0x17, 0x43, 0xfb, 0x47, 0x1f, 0x74, 0xa4, 0x7f, 0x0e,
+	0x47, 0x4c, 0x1c, 0x25, 0x94, 0xc0, 0x71, 0xa7, 0x11, 0x15, 0xd4, 0xee, 0x4c, 0xb4, 0x13, 0x3b,
+	0x12, 0xf4, 0x40, 0x09, 0x9e, 0xc4, 0xd6, 0x5d, 0x68, 0xe7, 0x96, 0x8c, 0x58, 0xd0, 0x0a, 0xa7,
+	0x03, 0x91, 0x96, 0x1c, 0x5c, 0x53, 0x8c, 0x25, 0xd3, 0x6e, 0x84, 0xd3, 0xc1, 0x23, 0x36, 0x17,
+	0x97, 0x95, 0xd8, 0xfa, 0xa5, 0x01, 0x6b, 0xd9, 0x4b, 0x96, 0x88, 0xd8, 0x28, 0x98, 0xfa, 0x2e,
+	0xce, 0xa7, 0x6a, 0xcb, 0x86, 0x28, 0x29, 0x09, 0x1f, 0x6a, 0xf6, 0xd6, 0xb7, 0xaa, 0xd3, 0x80,
+	0xb3, 0xd4, 0xd5, 0x4c, 0x62, 0x96, 0xde, 0x2e, 0xbb, 0x50, 0x8b, 0xbd, 0x91, 0xcf, 0x22, 0xb9,
+	0x9e, 0x4d, 0x5b, 0x37, 0x2d, 0x0f, 0xaa, 0x18, 0x4b, 0x22, 0x2e, 0xc4, 0xc8, 0xfa, 0xf0, 0x26,
+	0xbe, 0xc9, 0x63, 0x00, 0xca, 0x79, 0xe4, 0x0d, 0xa6, 0x8b, 0x09, 0xac, 0xed, 0xca, 0xca, 0xe0,
+	0xee, 0xa3, 0xd3, 0x13, 0xea, 0x45, 0xfd, 0x17, 0x55, 0x0c, 0x6e, 0x2e, 0x90, 0xa9, 0x38, 0x4c,
+	0xe9, 0x5b, 0x3f, 0xaf, 0xc2, 0xaa, 0xbc, 0x8e, 0x92, 0xdd, 0x6c, 0x59, 0x46, 0x8c, 0xaa, 0xcc,
+	0x92, 0xbd, 0xca, 0x2a, 0x0d, 0x22, 0x2f, 0xe5, 0x2b, 0x06, 0xfd, 0xc6, 0xe5, 0x97, 0xdb, 0x35,
+	0x3c, 0x27, 0x1d, 0x3f, 0x58, 0x94, 0x0f, 0x96, 0xd9, 0xaf, 0x6b, 0x15, 0x95, 0x6f, 0x5c, 0xab,
+	0xb8, 0x01, 0x35, 0x7f, 0x3a, 0x71, 0xf8, 0x4c, 0xef, 0xed, 0x55, 0x7f, 0x3a, 0x79, 0x3a, 0xc3,
+	0xe0, 0xe3, 0x01, 0xa7, 0x63, 0x14, 0xc9, 0x6d, 0x5c, 0xc7, 0x0e, 0x21, 0x3c, 0x80, 0x56, 0xea,
+	0x38, 0xea, 0xb9, 0xdd, 0x5a, 0xc6, 0x4a, 0x0c, 0xe2, 0xe3, 0x07, 0xca, 0xca, 0x46, 0x72, 0x3c,
+	0x3d, 0x76, 0xc9, 0x4e, 0xf6, 0x6a, 0x8e, 0xa7, 0xd8, 0x3a, 0x2e, 0x59, 0xea, 0xf6, 0x8d, 0x67,
+	0x58, 0x41, 0xb4, 0x94, 0x53, 0x09, 0xd1, 0x44, 0x4b, 0x39, 0x45, 0xe1, 0xcb, 0xd0, 0x5e, 0x1c,
+	0xe4, 0x24, 0x04, 0xe4, 0x28, 0x8b, 0x6e, 0x04, 0xbe, 0x0e, 0x9b, 0x3e, 0x9b, 0x71, 0x27, 0x8f,
+	0x6e, 0x20, 0x9a, 0x08, 0xd9, 0x69, 0x56, 0xe3, 0xbb, 0xb0, 0xb6, 0x48, 0xf1, 0x88, 0x6d, 0xca,
+	0x02, 0x49, 0xd2, 0x8b, 0xb0, 0x17, 0xa0, 0x9e, 0x1c, 0xc3, 0x5b, 0x72, 0xcf, 0x51, 0x75, 0xfa,
+	0xd6, 0x07, 0xfb, 0x88, 0xc5, 0xd3, 0x31, 0x57, 0x83, 0xac, 0x21, 0x06, 0x0f, 0xf6, 0xb6, 0xec,
+	0x47, 0xec, 0x2d, 0x68, 0xe9, 0xac, 0x29, 0x71, 0x6d, 0xc4, 0x35, 0x75, 0x27, 0x82, 0x6e, 0x43,
+	0x47, 0x65, 0xac, 0xc8, 0xa1, 0xae, 0x1b, 0xb1, 0x38, 0xee, 0x76, 0xe4, 0x78, 0xba, 0xff, 0x50,
+	0x76, 0x5b, 0x6f, 0x40, 0x4d, 0xdf, 0x2f, 0x36, 0xa1, 0xda, 0x4f, 0x32, 0x7c, 0xc5, 0x96, 0x0d,
+	0xc1, 0xfa, 0x87, 0x61, 0xa8, 0xaa, 0x81, 0xe2, 0xd3, 0xfa, 0x19, 0xd4, 0xd4, 0x82, 0x15, 0x56,
+	0x5e, 0x7e, 0x08, 0xcd, 0x90, 0x46, 0xc2, 0x8c, 0x74, 0xfd, 0x45, 0xdf, 0x60, 0x4f, 0x68, 0x24,
+	0x0a, 0x6e, 0x99, 0x32, 0x4c, 0x03, 0xf1, 0xb2, 0xcb, 0xba, 0x07, 0xad, 0x0c, 0x46, 0x4c, 0x0b,
+	0xf7, 0x91, 0x4e, 0x03, 0xd8, 0x48, 0x7e, 0xb9, 0xb4, 0xf8, 0x65, 0xeb, 0x2d, 0x30, 0x93, 0xb5,
+	0x11, 0x21, 0xae, 0x4d, 0x37, 0x94, 0xbb, 0x65, 0x53, 0x0c, 0x18, 0x06, 0x1f, 0xb3, 0x48, 0xc5,
+	0x84, 0x6c, 0x88, 0x04, 0xd4, 0xce, 0xb1, 0x3a, 0xb9, 0x03, 0x35, 0x95, 0xb8, 0xba, 0x46, 0xa6,
+	0x8a, 0x74, 0x82, 0x99, 0x4b, 0x57, 0x91, 0x64, 0x1e, 0x5b, 0x8c, 0x5b, 0x4a, 0x8d, 0x4b, 0x76,
+	0x61, 0x03, 0x8f, 0xe3, 0x4e, 0x70, 0xe6, 0x84, 0x41, 0x1c, 0xb3, 0x38, 0xb9, 0xde, 0x35, 0xed,
+	0x75, 0x14, 0xbd, 0x7f, 0x76, 0x92, 0x08, 0xac, 0xdf, 0x1b, 0x50, 0xd7, 0xc9, 0x2c, 0xcb, 0x8b,
+	0x72, 0x0a, 0x9d, 0x3c, 0x2f, 0xaa, 0x59, 0x2c, 0x80, 0x62, 0x3f, 0x61, 0x3a, 0x73, 0x9d, 0x45,
+	0xd0, 0xe1, 0xa4, 0xea, 0x76, 0x5b, 0x0a, 0x1e, 0xeb, 0x08, 0x23, 0x7d, 0x30, 0x93, 0x57, 0x8b,
+	0x6e, 0xf9, 0x1b, 0xa4, 0x83, 0x85, 0x9a, 0xf5, 0x3a, 0xac, 0x4a, 0x87, 0x14, 0x26, 0xcd, 0xa2,
+	0x03, 0xc6, 0x9f, 0x0d, 0xa8, 0x6b, 0xfe, 0x29, 0x54, 0xca, 0x18, 0x5e, 0xfa, 0xba, 0x86, 0xff,
+	0xf7, 0xd3, 0x9d, 0x60, 0x75, 0xcc, 0x6a, 0x17, 0x01, 0xf7, 0xfc, 0x91, 0x23, 0x17, 0x58, 0xb3,
+	0xba, 0x90, 0x9c, 0xa2, 0xe0, 0x44, 0xf4, 0xbf, 0x72, 0x0b, 0x1a, 0xa9, 0x0a, 0x1c, 0xa9, 0x41,
+	0xf9, 0x3d, 0xf6, 0x71, 0x67, 0x85, 0x34, 0xc4, 0x33, 0x10, 0x56, 0x4e, 0x3a, 0xc6, 0xfe, 0xa7,
+	0x55, 0x68, 0x1f, 0xf6, 0xef, 0x1f, 0x1f, 0x86, 0xe1, 0xd8, 0x1b, 0x22, 0x6d, 0x92, 0x3d, 0xa8,
+	0x60, 0xb5, 0xa1, 0xe0, 0x59, 0xa8, 0x57, 0x54, 0xf6, 0x22, 0xfb, 0x50, 0xc5, 0xa2, 0x03, 0x29,
+	0x7a, 0x1d, 0xea, 0x15, 0x56, 0xbf, 0xc4, 0x8f, 0xc8, 0xb2, 0xc4, 0xd5, 0x47, 0xa2, 0x5e, 0x51,
+	0x09, 0x8c, 0xbc, 0x03, 0xe6, 0xe2, 0x36, 0xbf, 0xec, 0xa9, 0xa8, 0xb7, 0xb4, 0x18, 0x26, 0xf4,
+	0x17, 0xb7, 0x8c, 0x65, 0x0f, 0x2b, 0xbd, 0xa5, 0x55, 0x23, 0x72, 0x00, 0x35, 0x7d, 0x57, 0x2c,
+	0x7e, 0xcc, 0xe9, 0x2d, 0x29, 0x54, 0x09, 0xf7, 0xc8, 0x0b, 0x7a, 0xd1, 0x8b, 0x53, 0xaf, 0xb0,
+	0x9a, 0x46, 0xee, 0xc2, 0xaa, 0x3a, 0x12, 0x17, 0x3e, 0xcb, 0xf4, 0x8a, 0xcb, 0x4d, 0xc2, 0xc8,
+	0x45, 0x89, 0x62, 0xd9, 0xab, 0x58, 0x6f, 0x69, 0xd9, 0x8f, 0x1c, 0x02, 0xa4, 0xee, 0xd9, 0x4b,
+	0x9f, 0xbb, 0x7a, 0xcb, 0xcb, 0x79, 0xe4, 0x2d, 0xa8, 0x2f, 0x4a, 0xb4, 0xc5, 0xcf, 0x50, 0xbd,
+	0x65, 0x15, 0xb6, 0xfe, 0x8b, 0x5f, 0xfd, 0x6d, 0xcb, 0xf8, 0xdd, 0xe5, 0x96, 0xf1, 0xf9, 0xe5,
+	0x96, 0xf1, 0xc5, 0xe5, 0x96, 0xf1, 0xa7, 0xcb, 0x2d, 0xe3, 0xaf, 0x97, 0x5b, 0xc6, 0x1f, 0xfe,
+	0xbe, 0x65, 0x0c, 0x56, 0x31, 0x46, 0xde, 0xfc, 0xf7, 0x00, 0xb8, 0xbc, 0x21, 0x41, 0xaa, 0x1d,
+	0x00, 0x00,
 }
 
 func (this *Request) Equal(that interface{}) bool {
@@ -3517,6 +3727,18 @@ func (this *RequestInfo) Equal(that interface{}) bool {
 	if this.P2PVersion != that1.P2PVersion {
 		return false
 	}
+	if this.ConsensusModule != that1.ConsensusModule {
+		return false
+	}
+	if this.LenUlb != that1.LenUlb {
+		return false
+	}
+	if this.LastFinalizedHeight != that1.LastFinalizedHeight {
+		return false
+	}
+	if this.LastProposedHeight != that1.LastProposedHeight {
+		return false
+	}
 	if !bytes.Equal(this.XXX_unrecognized, that1.XXX_unrecognized) {
 		return false
 	}
@@ -4217,6 +4439,12 @@ func (this *ResponseInfo) Equal(that interface{}) bool {
 	if !bytes.Equal(this.LastBlockAppHash, that1.LastBlockAppHash) {
 		return false
 	}
+	if this.MinLenUlb != that1.MinLenUlb {
+		return false
+	}
+	if this.MaxLenUlb != that1.MaxLenUlb {
+		return false
+	}
 	if !bytes.Equal(this.XXX_unrecognized, that1.XXX_unrecognized) {
 		return false
 	}
@@ -4421,6 +4649,26 @@ func (this *ResponseCheckTx) Equal(that interface{}) bool {
 	if this.Codespace != that1.Codespace {
 		return false
 	}
+	if !bytes.Equal(this.DedupKey, that1.DedupKey) {
+		return false
+	}
+	if this.Sender != that1.Sender {
+		return false
+	}
+	if this.Nonce != that1.Nonce {
+		return false
+	}
+	if this.Priority != that1.Priority {
+		return false
+	}
+	if len(this.ConflictKeys) != len(that1.ConflictKeys) {
+		return false
+	}
+	for i := range this.ConflictKeys {
+		if !bytes.Equal(this.ConflictKeys[i], that1.ConflictKeys[i]) {
+			return false
+		}
+	}
 	if !bytes.Equal(this.XXX_unrecognized, that1.XXX_unrecognized) {
 		return false
 	}
@@ -4613,6 +4861,21 @@ func (this *BlockParams) Equal(that interface{}) bool {
 	if this.LenUlb != that1.LenUlb {
 		return false
 	}
+	if this.TimeoutProposeMs != that1.TimeoutProposeMs {
+		return false
+	}
+	if this.TimeoutPrevoteMs != that1.TimeoutPrevoteMs {
+		return false
+	}
+	if this.TimeoutPrecommitMs != that1.TimeoutPrecommitMs {
+		return false
+	}
+	if this.TimeoutCommitMs != that1.TimeoutCommitMs {
+		return false
+	}
+	if this.MaxTxBytes != that1.MaxTxBytes {
+		return false
+	}
 	if !bytes.Equal(this.XXX_unrecognized, that1.XXX_unrecognized) {
 		return false
 	}
@@ -4640,6 +4903,9 @@ func (this *EvidenceParams) Equal(that interface{}) bool {
 	if this.MaxAge != that1.MaxAge {
 		return false
 	}
+	if this.MaxAgeDurationMs != that1.MaxAgeDurationMs {
+		return false
+	}
 	if !bytes.Equal(this.XXX_unrecognized, that1.XXX_unrecognized) {
 		return false
 	}
@@ -4707,6 +4973,12 @@ func (this *LastCommitInfo) Equal(that interface{}) bool {
 			return false
 		}
 	}
+	if this.Height != that1.Height {
+		return false
+	}
+	if !bytes.Equal(this.Signers, that1.Signers) {
+		return false
+	}
 	if !bytes.Equal(this.XXX_unrecognized, that1.XXX_unrecognized) {
 		return false
 	}
@@ -4964,6 +5236,9 @@ func (this *ValidatorUpdate) Equal(that interface{}) bool {
 	if this.Power != that1.Power {
 		return false
 	}
+	if !bytes.Equal(this.ProofOfPossession, that1.ProofOfPossession) {
+		return false
+	}
 	if !bytes.Equal(this.XXX_unrecognized, that1.XXX_unrecognized) {
 		return false
 	}
@@ -4994,6 +5269,9 @@ func (this *VoteInfo) Equal(that interface{}) bool {
 	if this.SignedLastBlock != that1.SignedLastBlock {
 		return false
 	}
+	if !this.Timestamp.Equal(that1.Timestamp) {
+		return false
+	}
 	if !bytes.Equal(this.XXX_unrecognized, that1.XXX_unrecognized) {
 		return false
 	}
@@ -5863,6 +6141,28 @@ func (m *RequestInfo) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 		i -= len(m.XXX_unrecognized)
 		copy(dAtA[i:], m.XXX_unrecognized)
 	}
+	if m.LastProposedHeight != 0 {
+		i = encodeVarintTypes(dAtA, i, uint64(m.LastProposedHeight))
+		i--
+		dAtA[i] = 0x38
+	}
+	if m.LastFinalizedHeight != 0 {
+		i = encodeVarintTypes(dAtA, i, uint64(m.LastFinalizedHeight))
+		i--
+		dAtA[i] = 0x30
+	}
+	if m.LenUlb != 0 {
+		i = encodeVarintTypes(dAtA, i, uint64(m.LenUlb))
+		i--
+		dAtA[i] = 0x28
+	}
+	if len(m.ConsensusModule) > 0 {
+		i -= len(m.ConsensusModule)
+		copy(dAtA[i:], m.ConsensusModule)
+		i = encodeVarintTypes(dAtA, i, uint64(len(m.ConsensusModule)))
+		i--
+		dAtA[i] = 0x22
+	}
 	if m.P2PVersion != 0 {
 		i = encodeVarintTypes(dAtA, i, uint64(m.P2PVersion))
 		i--
@@ -6667,6 +6967,16 @@ func (m *ResponseInfo) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 		i -= len(m.XXX_unrecognized)
 		copy(dAtA[i:], m.XXX_unrecognized)
 	}
+	if m.MaxLenUlb != 0 {
+		i = encodeVarintTypes(dAtA, i, uint64(m.MaxLenUlb))
+		i--
+		dAtA[i] = 0x38
+	}
+	if m.MinLenUlb != 0 {
+		i = encodeVarintTypes(dAtA, i, uint64(m.MinLenUlb))
+		i--
+		dAtA[i] = 0x30
+	}
 	if len(m.LastBlockAppHash) > 0 {
 		i -= len(m.LastBlockAppHash)
 		copy(dAtA[i:], m.LastBlockAppHash)
@@ -6954,6 +7264,39 @@ func (m *ResponseCheckTx) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 		i -= len(m.XXX_unrecognized)
 		copy(dAtA[i:], m.XXX_unrecognized)
 	}
+	if len(m.ConflictKeys) > 0 {
+		for iNdEx := len(m.ConflictKeys) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.ConflictKeys[iNdEx])
+			copy(dAtA[i:], m.ConflictKeys[iNdEx])
+			i = encodeVarintTypes(dAtA, i, uint64(len(m.ConflictKeys[iNdEx])))
+			i--
+			dAtA[i] = 0x6a
+		}
+	}
+	if m.Priority != 0 {
+		i = encodeVarintTypes(dAtA, i, uint64(m.Priority))
+		i--
+		dAtA[i] = 0x60
+	}
+	if m.Nonce != 0 {
+		i = encodeVarintTypes(dAtA, i, uint64(m.Nonce))
+		i--
+		dAtA[i] = 0x58
+	}
+	if len(m.Sender) > 0 {
+		i -= len(m.Sender)
+		copy(dAtA[i:], m.Sender)
+		i = encodeVarintTypes(dAtA, i, uint64(len(m.Sender)))
+		i--
+		dAtA[i] = 0x52
+	}
+	if len(m.DedupKey) > 0 {
+		i -= len(m.DedupKey)
+		copy(dAtA[i:], m.DedupKey)
+		i = encodeVarintTypes(dAtA, i, uint64(len(m.DedupKey)))
+		i--
+		dAtA[i] = 0x4a
+	}
 	if len(m.Codespace) > 0 {
 		i -= len(m.Codespace)
 		copy(dAtA[i:], m.Codespace)
@@ -7291,6 +7634,31 @@ func (m *BlockParams) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 		i -= len(m.XXX_unrecognized)
 		copy(dAtA[i:], m.XXX_unrecognized)
 	}
+	if m.MaxTxBytes != 0 {
+		i = encodeVarintTypes(dAtA, i, uint64(m.MaxTxBytes))
+		i--
+		dAtA[i] = 0x40
+	}
+	if m.TimeoutCommitMs != 0 {
+		i = encodeVarintTypes(dAtA, i, uint64(m.TimeoutCommitMs))
+		i--
+		dAtA[i] = 0x38
+	}
+	if m.TimeoutPrecommitMs != 0 {
+		i = encodeVarintTypes(dAtA, i, uint64(m.TimeoutPrecommitMs))
+		i--
+		dAtA[i] = 0x30
+	}
+	if m.TimeoutPrevoteMs != 0 {
+		i = encodeVarintTypes(dAtA, i, uint64(m.TimeoutPrevoteMs))
+		i--
+		dAtA[i] = 0x28
+	}
+	if m.TimeoutProposeMs != 0 {
+		i = encodeVarintTypes(dAtA, i, uint64(m.TimeoutProposeMs))
+		i--
+		dAtA[i] = 0x20
+	}
 	if m.LenUlb != 0 {
 		i = encodeVarintTypes(dAtA, i, uint64(m.LenUlb))
 		i--
@@ -7333,6 +7701,11 @@ func (m *EvidenceParams) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 		i -= len(m.XXX_unrecognized)
 		copy(dAtA[i:], m.XXX_unrecognized)
 	}
+	if m.MaxAgeDurationMs != 0 {
+		i = encodeVarintTypes(dAtA, i, uint64(m.MaxAgeDurationMs))
+		i--
+		dAtA[i] = 0x10
+	}
 	if m.MaxAge != 0 {
 		i = encodeVarintTypes(dAtA, i, uint64(m.MaxAge))
 		i--
@@ -7401,6 +7774,18 @@ func (m *LastCommitInfo) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 		i -= len(m.XXX_unrecognized)
 		copy(dAtA[i:], m.XXX_unrecognized)
 	}
+	if len(m.Signers) > 0 {
+		i -= len(m.Signers)
+		copy(dAtA[i:], m.Signers)
+		i = encodeVarintTypes(dAtA, i, uint64(len(m.Signers)))
+		i--
+		dAtA[i] = 0x22
+	}
+	if m.Height != 0 {
+		i = encodeVarintTypes(dAtA, i, uint64(m.Height))
+		i--
+		dAtA[i] = 0x18
+	}
 	if len(m.Votes) > 0 {
 		for iNdEx := len(m.Votes) - 1; iNdEx >= 0; iNdEx-- {
 			{
@@ -7796,6 +8181,13 @@ func (m *ValidatorUpdate) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 		i -= len(m.XXX_unrecognized)
 		copy(dAtA[i:], m.XXX_unrecognized)
 	}
+	if len(m.ProofOfPossession) > 0 {
+		i -= len(m.ProofOfPossession)
+		copy(dAtA[i:], m.ProofOfPossession)
+		i = encodeVarintTypes(dAtA, i, uint64(len(m.ProofOfPossession)))
+		i--
+		dAtA[i] = 0x1a
+	}
 	if m.Power != 0 {
 		i = encodeVarintTypes(dAtA, i, uint64(m.Power))
 		i--
@@ -7838,6 +8230,14 @@ func (m *VoteInfo) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 		i -= len(m.XXX_unrecognized)
 		copy(dAtA[i:], m.XXX_unrecognized)
 	}
+	n39, err39 := github_com_gogo_protobuf_types.StdTimeMarshalTo(m.Timestamp, dAtA[i-github_com_gogo_protobuf_types.SizeOfStdTime(m.Timestamp):])
+	if err39 != nil {
+		return 0, err39
+	}
+	i -= n39
+	i = encodeVarintTypes(dAtA, i, uint64(n39))
+	i--
+	dAtA[i] = 0x1a
 	if m.SignedLastBlock {
 		i--
 		if m.SignedLastBlock {
@@ -7931,12 +8331,12 @@ func (m *Evidence) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 		i--
 		dAtA[i] = 0x28
 	}
-	n40, err40 := github_com_gogo_protobuf_types.StdTimeMarshalTo(m.Time, dAtA[i-github_com_gogo_protobuf_types.SizeOfStdTime(m.Time):])
-	if err40 != nil {
-		return 0, err40
+	n41, err41 := github_com_gogo_protobuf_types.StdTimeMarshalTo(m.Time, dAtA[i-github_com_gogo_protobuf_types.SizeOfStdTime(m.Time):])
+	if err41 != nil {
+		return 0, err41
 	}
-	i -= n40
-	i = encodeVarintTypes(dAtA, i, uint64(n40))
+	i -= n41
+	i = encodeVarintTypes(dAtA, i, uint64(n41))
 	i--
 	dAtA[i] = 0x22
 	if m.Height != 0 {
@@ -8085,8 +8485,21 @@ func NewPopulatedRequestInfo(r randyTypes, easy bool) *RequestInfo {
 	this.Version = string(randStringTypes(r))
 	this.BlockVersion = uint64(uint64(r.Uint32()))
 	this.P2PVersion = uint64(uint64(r.Uint32()))
+	this.ConsensusModule = string(randStringTypes(r))
+	this.LenUlb = int64(r.Int63())
+	if r.Intn(2) == 0 {
+		this.LenUlb *= -1
+	}
+	this.LastFinalizedHeight = int64(r.Int63())
+	if r.Intn(2) == 0 {
+		this.LastFinalizedHeight *= -1
+	}
+	this.LastProposedHeight = int64(r.Int63())
+	if r.Intn(2) == 0 {
+		this.LastProposedHeight *= -1
+	}
 	if !easy && r.Intn(10) != 0 {
-		this.XXX_unrecognized = randUnrecognizedTypes(r, 4)
+		this.XXX_unrecognized = randUnrecognizedTypes(r, 8)
 	}
 	return this
 }
@@ -8358,8 +8771,16 @@ func NewPopulatedResponseInfo(r randyTypes, easy bool) *ResponseInfo {
 	for i := 0; i < v13; i++ {
 		this.LastBlockAppHash[i] = byte(r.Intn(256))
 	}
+	this.MinLenUlb = int64(r.Int63())
+	if r.Intn(2) == 0 {
+		this.MinLenUlb *= -1
+	}
+	this.MaxLenUlb = int64(r.Int63())
+	if r.Intn(2) == 0 {
+		this.MaxLenUlb *= -1
+	}
 	if !easy && r.Intn(10) != 0 {
-		this.XXX_unrecognized = randUnrecognizedTypes(r, 6)
+		this.XXX_unrecognized = randUnrecognizedTypes(r, 8)
 	}
 	return this
 }
@@ -8470,8 +8891,28 @@ func NewPopulatedResponseCheckTx(r randyTypes, easy bool) *ResponseCheckTx {
 		}
 	}
 	this.Codespace = string(randStringTypes(r))
+	v23 := r.Intn(100)
+	this.DedupKey = make([]byte, v23)
+	for i := 0; i < v23; i++ {
+		this.DedupKey[i] = byte(r.Intn(256))
+	}
+	this.Sender = string(randStringTypes(r))
+	this.Nonce = uint64(uint64(r.Uint32()))
+	this.Priority = int64(r.Int63())
+	if r.Intn(2) == 0 {
+		this.Priority *= -1
+	}
+	v24 := r.Intn(10)
+	this.ConflictKeys = make([][]byte, v24)
+	for i := 0; i < v24; i++ {
+		v25 := r.Intn(100)
+		this.ConflictKeys[i] = make([]byte, v25)
+		for j := 0; j < v25; j++ {
+			this.ConflictKeys[i][j] = byte(r.Intn(256))
+		}
+	}
 	if !easy && r.Intn(10) != 0 {
-		this.XXX_unrecognized = randUnrecognizedTypes(r, 9)
+		this.XXX_unrecognized = randUnrecognizedTypes(r, 14)
 	}
 	return this
 }
@@ -8584,20 +9025,44 @@ func NewPopulatedBlockParams(r randyTypes, easy bool) *BlockParams {
 	if r.Intn(2) == 0 {
 		this.LenUlb *= -1
 	}
-	if !easy && r.Intn(10) != 0 {
-		this.XXX_unrecognized = randUnrecognizedTypes(r, 4)
+	this.TimeoutProposeMs = int64(r.Int63())
+	if r.Intn(2) == 0 {
+		this.TimeoutProposeMs *= -1
 	}
-	return this
-}
-
-func NewPopulatedEvidenceParams(r randyTypes, easy bool) *EvidenceParams {
-	this := &EvidenceParams{}
+	this.TimeoutPrevoteMs = int64(r.Int63())
+	if r.Intn(2) == 0 {
+		this.TimeoutPrevoteMs *= -1
+	}
+	this.TimeoutPrecommitMs = int64(r.Int63())
+	if r.Intn(2) == 0 {
+		this.TimeoutPrecommitMs *= -1
+	}
+	this.TimeoutCommitMs = int64(r.Int63())
+	if r.Intn(2) == 0 {
+		this.TimeoutCommitMs *= -1
+	}
+	this.MaxTxBytes = int64(r.Int63())
+	if r.Intn(2) == 0 {
+		this.MaxTxBytes *= -1
+	}
+	if !easy && r.Intn(10) != 0 {
+		this.XXX_unrecognized = randUnrecognizedTypes(r, 9)
+	}
+	return this
+}
+
+func NewPopulatedEvidenceParams(r randyTypes, easy bool) *EvidenceParams {
+	this := &EvidenceParams{}
 	this.MaxAge = int64(r.Int63())
 	if r.Intn(2) == 0 {
 		this.MaxAge *= -1
 	}
+	this.MaxAgeDurationMs = int64(r.Int63())
+	if r.Intn(2) == 0 {
+		this.MaxAgeDurationMs *= -1
+	}
 	if !easy && r.Intn(10) != 0 {
-		this.XXX_unrecognized = randUnrecognizedTypes(r, 2)
+		this.XXX_unrecognized = randUnrecognizedTypes(r, 3)
 	}
 	return this
 }
@@ -8629,8 +9094,17 @@ func NewPopulatedLastCommitInfo(r randyTypes, easy bool) *LastCommitInfo {
 			this.Votes[i] = *v33
 		}
 	}
+	this.Height = int64(r.Int63())
+	if r.Intn(2) == 0 {
+		this.Height *= -1
+	}
+	v34 := r.Intn(100)
+	this.Signers = make([]byte, v34)
+	for i := 0; i < v34; i++ {
+		this.Signers[i] = byte(r.Intn(256))
+	}
 	if !easy && r.Intn(10) != 0 {
-		this.XXX_unrecognized = randUnrecognizedTypes(r, 3)
+		this.XXX_unrecognized = randUnrecognizedTypes(r, 5)
 	}
 	return this
 }
@@ -8639,11 +9113,11 @@ func NewPopulatedEvent(r randyTypes, easy bool) *Event {
 	this := &Event{}
 	this.Type = string(randStringTypes(r))
 	if r.Intn(5) != 0 {
-		v34 := r.Intn(5)
-		this.Attributes = make([]common.KVPair, v34)
-		for i := 0; i < v34; i++ {
-			v35 := common.NewPopulatedKVPair(r, easy)
-			this.Attributes[i] = *v35
+		v35 := r.Intn(5)
+		this.Attributes = make([]common.KVPair, v35)
+		for i := 0; i < v35; i++ {
+			v36 := common.NewPopulatedKVPair(r, easy)
+			this.Attributes[i] = *v36
 		}
 	}
 	if !easy && r.Intn(10) != 0 {
@@ -8654,15 +9128,15 @@ func NewPopulatedEvent(r randyTypes, easy bool) *Event {
 
 func NewPopulatedHeader(r randyTypes, easy bool) *Header {
 	this := &Header{}
-	v36 := NewPopulatedVersion(r, easy)
-	this.Version = *v36
+	v37 := NewPopulatedVersion(r, easy)
+	this.Version = *v37
 	this.ChainID = string(randStringTypes(r))
 	this.Height = int64(r.Int63())
 	if r.Intn(2) == 0 {
 		this.Height *= -1
 	}
-	v37 := github_com_gogo_protobuf_types.NewPopulatedStdTime(r, easy)
-	this.Time = *v37
+	v38 := github_com_gogo_protobuf_types.NewPopulatedStdTime(r, easy)
+	this.Time = *v38
 	this.NumTxs = int64(r.Int63())
 	if r.Intn(2) == 0 {
 		this.NumTxs *= -1
@@ -8671,51 +9145,51 @@ func NewPopulatedHeader(r randyTypes, easy bool) *Header {
 	if r.Intn(2) == 0 {
 		this.TotalTxs *= -1
 	}
-	v38 := NewPopulatedBlockID(r, easy)
-	this.LastBlockId = *v38
-	v39 := r.Intn(100)
-	this.LastCommitHash = make([]byte, v39)
-	for i := 0; i < v39; i++ {
-		this.LastCommitHash[i] = byte(r.Intn(256))
-	}
+	v39 := NewPopulatedBlockID(r, easy)
+	this.LastBlockId = *v39
 	v40 := r.Intn(100)
-	this.DataHash = make([]byte, v40)
+	this.LastCommitHash = make([]byte, v40)
 	for i := 0; i < v40; i++ {
-		this.DataHash[i] = byte(r.Intn(256))
+		this.LastCommitHash[i] = byte(r.Intn(256))
 	}
 	v41 := r.Intn(100)
-	this.ValidatorsHash = make([]byte, v41)
+	this.DataHash = make([]byte, v41)
 	for i := 0; i < v41; i++ {
-		this.ValidatorsHash[i] = byte(r.Intn(256))
+		this.DataHash[i] = byte(r.Intn(256))
 	}
 	v42 := r.Intn(100)
-	this.NextValidatorsHash = make([]byte, v42)
+	this.ValidatorsHash = make([]byte, v42)
 	for i := 0; i < v42; i++ {
-		this.NextValidatorsHash[i] = byte(r.Intn(256))
+		this.ValidatorsHash[i] = byte(r.Intn(256))
 	}
 	v43 := r.Intn(100)
-	this.ConsensusHash = make([]byte, v43)
+	this.NextValidatorsHash = make([]byte, v43)
 	for i := 0; i < v43; i++ {
-		this.ConsensusHash[i] = byte(r.Intn(256))
+		this.NextValidatorsHash[i] = byte(r.Intn(256))
 	}
 	v44 := r.Intn(100)
-	this.AppHash = make([]byte, v44)
+	this.ConsensusHash = make([]byte, v44)
 	for i := 0; i < v44; i++ {
-		this.AppHash[i] = byte(r.Intn(256))
+		this.ConsensusHash[i] = byte(r.Intn(256))
 	}
 	v45 := r.Intn(100)
-	this.LastResultsHash = make([]byte, v45)
+	this.AppHash = make([]byte, v45)
 	for i := 0; i < v45; i++ {
-		this.LastResultsHash[i] = byte(r.Intn(256))
+		this.AppHash[i] = byte(r.Intn(256))
 	}
 	v46 := r.Intn(100)
-	this.EvidenceHash = make([]byte, v46)
+	this.LastResultsHash = make([]byte, v46)
 	for i := 0; i < v46; i++ {
-		this.EvidenceHash[i] = byte(r.Intn(256))
+		this.LastResultsHash[i] = byte(r.Intn(256))
 	}
 	v47 := r.Intn(100)
-	this.ProposerAddress = make([]byte, v47)
+	this.EvidenceHash = make([]byte, v47)
 	for i := 0; i < v47; i++ {
+		this.EvidenceHash[i] = byte(r.Intn(256))
+	}
+	v48 := r.Intn(100)
+	this.ProposerAddress = make([]byte, v48)
+	for i := 0; i < v48; i++ {
 		this.ProposerAddress[i] = byte(r.Intn(256))
 	}
 	if !easy && r.Intn(10) != 0 {
@@ -8736,13 +9210,13 @@ func NewPopulatedVersion(r randyTypes, easy bool) *Version {
 
 func NewPopulatedBlockID(r randyTypes, easy bool) *BlockID {
 	this := &BlockID{}
-	v48 := r.Intn(100)
-	this.Hash = make([]byte, v48)
-	for i := 0; i < v48; i++ {
+	v49 := r.Intn(100)
+	this.Hash = make([]byte, v49)
+	for i := 0; i < v49; i++ {
 		this.Hash[i] = byte(r.Intn(256))
 	}
-	v49 := NewPopulatedPartSetHeader(r, easy)
-	this.PartsHeader = *v49
+	v50 := NewPopulatedPartSetHeader(r, easy)
+	this.PartsHeader = *v50
 	if !easy && r.Intn(10) != 0 {
 		this.XXX_unrecognized = randUnrecognizedTypes(r, 3)
 	}
@@ -8755,9 +9229,9 @@ func NewPopulatedPartSetHeader(r randyTypes, easy bool) *PartSetHeader {
 	if r.Intn(2) == 0 {
 		this.Total *= -1
 	}
-	v50 := r.Intn(100)
-	this.Hash = make([]byte, v50)
-	for i := 0; i < v50; i++ {
+	v51 := r.Intn(100)
+	this.Hash = make([]byte, v51)
+	for i := 0; i < v51; i++ {
 		this.Hash[i] = byte(r.Intn(256))
 	}
 	if !easy && r.Intn(10) != 0 {
@@ -8768,9 +9242,9 @@ func NewPopulatedPartSetHeader(r randyTypes, easy bool) *PartSetHeader {
 
 func NewPopulatedValidator(r randyTypes, easy bool) *Validator {
 	this := &Validator{}
-	v51 := r.Intn(100)
-	this.Address = make([]byte, v51)
-	for i := 0; i < v51; i++ {
+	v52 := r.Intn(100)
+	this.Address = make([]byte, v52)
+	for i := 0; i < v52; i++ {
 		this.Address[i] = byte(r.Intn(256))
 	}
 	this.Power = int64(r.Int63())
@@ -8785,25 +9259,32 @@ func NewPopulatedValidator(r randyTypes, easy bool) *Validator {
 
 func NewPopulatedValidatorUpdate(r randyTypes, easy bool) *ValidatorUpdate {
 	this := &ValidatorUpdate{}
-	v52 := NewPopulatedPubKey(r, easy)
-	this.PubKey = *v52
+	v53 := NewPopulatedPubKey(r, easy)
+	this.PubKey = *v53
 	this.Power = int64(r.Int63())
 	if r.Intn(2) == 0 {
 		this.Power *= -1
 	}
+	v54 := r.Intn(100)
+	this.ProofOfPossession = make([]byte, v54)
+	for i := 0; i < v54; i++ {
+		this.ProofOfPossession[i] = byte(r.Intn(256))
+	}
 	if !easy && r.Intn(10) != 0 {
-		this.XXX_unrecognized = randUnrecognizedTypes(r, 3)
+		this.XXX_unrecognized = randUnrecognizedTypes(r, 4)
 	}
 	return this
 }
 
 func NewPopulatedVoteInfo(r randyTypes, easy bool) *VoteInfo {
 	this := &VoteInfo{}
-	v53 := NewPopulatedValidator(r, easy)
-	this.Validator = *v53
+	v55 := NewPopulatedValidator(r, easy)
+	this.Validator = *v55
 	this.SignedLastBlock = bool(bool(r.Intn(2) == 0))
+	v56 := github_com_gogo_protobuf_types.NewPopulatedStdTime(r, easy)
+	this.Timestamp = *v56
 	if !easy && r.Intn(10) != 0 {
-		this.XXX_unrecognized = randUnrecognizedTypes(r, 3)
+		this.XXX_unrecognized = randUnrecognizedTypes(r, 4)
 	}
 	return this
 }
@@ -8811,9 +9292,9 @@ func NewPopulatedVoteInfo(r randyTypes, easy bool) *VoteInfo {
 func NewPopulatedPubKey(r randyTypes, easy bool) *PubKey {
 	this := &PubKey{}
 	this.Type = string(randStringTypes(r))
-	v54 := r.Intn(100)
-	this.Data = make([]byte, v54)
-	for i := 0; i < v54; i++ {
+	v57 := r.Intn(100)
+	this.Data = make([]byte, v57)
+	for i := 0; i < v57; i++ {
 		this.Data[i] = byte(r.Intn(256))
 	}
 	if !easy && r.Intn(10) != 0 {
@@ -8825,14 +9306,14 @@ func NewPopulatedPubKey(r randyTypes, easy bool) *PubKey {
 func NewPopulatedEvidence(r randyTypes, easy bool) *Evidence {
 	this := &Evidence{}
 	this.Type = string(randStringTypes(r))
-	v55 := NewPopulatedValidator(r, easy)
-	this.Validator = *v55
+	v58 := NewPopulatedValidator(r, easy)
+	this.Validator = *v58
 	this.Height = int64(r.Int63())
 	if r.Intn(2) == 0 {
 		this.Height *= -1
 	}
-	v56 := github_com_gogo_protobuf_types.NewPopulatedStdTime(r, easy)
-	this.Time = *v56
+	v59 := github_com_gogo_protobuf_types.NewPopulatedStdTime(r, easy)
+	this.Time = *v59
 	this.TotalVotingPower = int64(r.Int63())
 	if r.Intn(2) == 0 {
 		this.TotalVotingPower *= -1
@@ -8862,9 +9343,9 @@ func randUTF8RuneTypes(r randyTypes) rune {
 	return rune(ru + 61)
 }
 func randStringTypes(r randyTypes) string {
-	v57 := r.Intn(100)
-	tmps := make([]rune, v57)
-	for i := 0; i < v57; i++ {
+	v59 := r.Intn(100)
+	tmps := make([]rune, v59)
+	for i := 0; i < v59; i++ {
 		tmps[i] = randUTF8RuneTypes(r)
 	}
 	return string(tmps)
@@ -8886,11 +9367,11 @@ func randFieldTypes(dAtA []byte, r randyTypes, fieldNumber int, wire int) []byte
 	switch wire {
 	case 0:
 		dAtA = encodeVarintPopulateTypes(dAtA, uint64(key))
-		v58 := r.Int63()
+		v60 := r.Int63()
 		if r.Intn(2) == 0 {
-			v58 *= -1
+			v60 *= -1
 		}
-		dAtA = encodeVarintPopulateTypes(dAtA, uint64(v58))
+		dAtA = encodeVarintPopulateTypes(dAtA, uint64(v60))
 	case 1:
 		dAtA = encodeVarintPopulateTypes(dAtA, uint64(key))
 		dAtA = append(dAtA, byte(r.Intn(256)), byte(r.Intn(256)), byte(r.Intn(256)), byte(r.Intn(256)), byte(r.Intn(256)), byte(r.Intn(256)), byte(r.Intn(256)), byte(r.Intn(256)))
@@ -9106,6 +9587,19 @@ func (m *RequestInfo) Size() (n int) {
 	if m.P2PVersion != 0 {
 		n += 1 + sovTypes(uint64(m.P2PVersion))
 	}
+	l = len(m.ConsensusModule)
+	if l > 0 {
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	if m.LenUlb != 0 {
+		n += 1 + sovTypes(uint64(m.LenUlb))
+	}
+	if m.LastFinalizedHeight != 0 {
+		n += 1 + sovTypes(uint64(m.LastFinalizedHeight))
+	}
+	if m.LastProposedHeight != 0 {
+		n += 1 + sovTypes(uint64(m.LastProposedHeight))
+	}
 	if m.XXX_unrecognized != nil {
 		n += len(m.XXX_unrecognized)
 	}
@@ -9508,6 +10002,12 @@ func (m *ResponseInfo) Size() (n int) {
 	if l > 0 {
 		n += 1 + l + sovTypes(uint64(l))
 	}
+	if m.MinLenUlb != 0 {
+		n += 1 + sovTypes(uint64(m.MinLenUlb))
+	}
+	if m.MaxLenUlb != 0 {
+		n += 1 + sovTypes(uint64(m.MaxLenUlb))
+	}
 	if m.XXX_unrecognized != nil {
 		n += len(m.XXX_unrecognized)
 	}
@@ -9659,6 +10159,26 @@ func (m *ResponseCheckTx) Size() (n int) {
 	if l > 0 {
 		n += 1 + l + sovTypes(uint64(l))
 	}
+	l = len(m.DedupKey)
+	if l > 0 {
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	l = len(m.Sender)
+	if l > 0 {
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	if m.Nonce != 0 {
+		n += 1 + sovTypes(uint64(m.Nonce))
+	}
+	if m.Priority != 0 {
+		n += 1 + sovTypes(uint64(m.Priority))
+	}
+	if len(m.ConflictKeys) > 0 {
+		for _, b := range m.ConflictKeys {
+			l = len(b)
+			n += 1 + l + sovTypes(uint64(l))
+		}
+	}
 	if m.XXX_unrecognized != nil {
 		n += len(m.XXX_unrecognized)
 	}
@@ -9794,6 +10314,21 @@ func (m *BlockParams) Size() (n int) {
 	if m.LenUlb != 0 {
 		n += 1 + sovTypes(uint64(m.LenUlb))
 	}
+	if m.TimeoutProposeMs != 0 {
+		n += 1 + sovTypes(uint64(m.TimeoutProposeMs))
+	}
+	if m.TimeoutPrevoteMs != 0 {
+		n += 1 + sovTypes(uint64(m.TimeoutPrevoteMs))
+	}
+	if m.TimeoutPrecommitMs != 0 {
+		n += 1 + sovTypes(uint64(m.TimeoutPrecommitMs))
+	}
+	if m.TimeoutCommitMs != 0 {
+		n += 1 + sovTypes(uint64(m.TimeoutCommitMs))
+	}
+	if m.MaxTxBytes != 0 {
+		n += 1 + sovTypes(uint64(m.MaxTxBytes))
+	}
 	if m.XXX_unrecognized != nil {
 		n += len(m.XXX_unrecognized)
 	}
@@ -9809,6 +10344,9 @@ func (m *EvidenceParams) Size() (n int) {
 	if m.MaxAge != 0 {
 		n += 1 + sovTypes(uint64(m.MaxAge))
 	}
+	if m.MaxAgeDurationMs != 0 {
+		n += 1 + sovTypes(uint64(m.MaxAgeDurationMs))
+	}
 	if m.XXX_unrecognized != nil {
 		n += len(m.XXX_unrecognized)
 	}
@@ -9848,6 +10386,13 @@ func (m *LastCommitInfo) Size() (n int) {
 			n += 1 + l + sovTypes(uint64(l))
 		}
 	}
+	if m.Height != 0 {
+		n += 1 + sovTypes(uint64(m.Height))
+	}
+	l = len(m.Signers)
+	if l > 0 {
+		n += 1 + l + sovTypes(uint64(l))
+	}
 	if m.XXX_unrecognized != nil {
 		n += len(m.XXX_unrecognized)
 	}
@@ -10028,6 +10573,10 @@ func (m *ValidatorUpdate) Size() (n int) {
 	if m.Power != 0 {
 		n += 1 + sovTypes(uint64(m.Power))
 	}
+	l = len(m.ProofOfPossession)
+	if l > 0 {
+		n += 1 + l + sovTypes(uint64(l))
+	}
 	if m.XXX_unrecognized != nil {
 		n += len(m.XXX_unrecognized)
 	}
@@ -10045,6 +10594,8 @@ func (m *VoteInfo) Size() (n int) {
 	if m.SignedLastBlock {
 		n += 2
 	}
+	l = github_com_gogo_protobuf_types.SizeOfStdTime(m.Timestamp)
+	n += 1 + l + sovTypes(uint64(l))
 	if m.XXX_unrecognized != nil {
 		n += len(m.XXX_unrecognized)
 	}
@@ -10781,6 +11332,95 @@ func (m *RequestInfo) Unmarshal(dAtA []byte) error {
 					break
 				}
 			}
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ConsensusModule", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ConsensusModule = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 5:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field LenUlb", wireType)
+			}
+			m.LenUlb = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.LenUlb |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 6:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field LastFinalizedHeight", wireType)
+			}
+			m.LastFinalizedHeight = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.LastFinalizedHeight |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 7:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field LastProposedHeight", wireType)
+			}
+			m.LastProposedHeight = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.LastProposedHeight |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
 		default:
 			iNdEx = preIndex
 			skippy, err := skipTypes(dAtA[iNdEx:])
@@ -12700,6 +13340,44 @@ func (m *ResponseInfo) Unmarshal(dAtA []byte) error {
 				m.LastBlockAppHash = []byte{}
 			}
 			iNdEx = postIndex
+		case 6:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MinLenUlb", wireType)
+			}
+			m.MinLenUlb = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.MinLenUlb |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 7:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MaxLenUlb", wireType)
+			}
+			m.MaxLenUlb = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.MaxLenUlb |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
 		default:
 			iNdEx = preIndex
 			skippy, err := skipTypes(dAtA[iNdEx:])
@@ -13635,26 +14313,162 @@ func (m *ResponseCheckTx) Unmarshal(dAtA []byte) error {
 			}
 			m.Codespace = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
-		default:
-			iNdEx = preIndex
-			skippy, err := skipTypes(dAtA[iNdEx:])
-			if err != nil {
-				return err
+		case 9:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field DedupKey", wireType)
 			}
-			if skippy < 0 {
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
 				return ErrInvalidLengthTypes
 			}
-			if (iNdEx + skippy) < 0 {
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
 				return ErrInvalidLengthTypes
 			}
-			if (iNdEx + skippy) > l {
+			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
-			iNdEx += skippy
-		}
-	}
-
+			m.DedupKey = append(m.DedupKey[:0], dAtA[iNdEx:postIndex]...)
+			if m.DedupKey == nil {
+				m.DedupKey = []byte{}
+			}
+			iNdEx = postIndex
+		case 10:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Sender", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Sender = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 11:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Nonce", wireType)
+			}
+			m.Nonce = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Nonce |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 12:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Priority", wireType)
+			}
+			m.Priority = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Priority |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 13:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ConflictKeys", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ConflictKeys = append(m.ConflictKeys, make([]byte, postIndex-iNdEx))
+			copy(m.ConflictKeys[len(m.ConflictKeys)-1], dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipTypes(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
 	if iNdEx > l {
 		return io.ErrUnexpectedEOF
 	}
@@ -14448,6 +15262,101 @@ func (m *BlockParams) Unmarshal(dAtA []byte) error {
 					break
 				}
 			}
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field TimeoutProposeMs", wireType)
+			}
+			m.TimeoutProposeMs = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.TimeoutProposeMs |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 5:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field TimeoutPrevoteMs", wireType)
+			}
+			m.TimeoutPrevoteMs = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.TimeoutPrevoteMs |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 6:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field TimeoutPrecommitMs", wireType)
+			}
+			m.TimeoutPrecommitMs = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.TimeoutPrecommitMs |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 7:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field TimeoutCommitMs", wireType)
+			}
+			m.TimeoutCommitMs = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.TimeoutCommitMs |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 8:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MaxTxBytes", wireType)
+			}
+			m.MaxTxBytes = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.MaxTxBytes |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
 		default:
 			iNdEx = preIndex
 			skippy, err := skipTypes(dAtA[iNdEx:])
@@ -14521,6 +15430,25 @@ func (m *EvidenceParams) Unmarshal(dAtA []byte) error {
 					break
 				}
 			}
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MaxAgeDurationMs", wireType)
+			}
+			m.MaxAgeDurationMs = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.MaxAgeDurationMs |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
 		default:
 			iNdEx = preIndex
 			skippy, err := skipTypes(dAtA[iNdEx:])
@@ -14714,6 +15642,59 @@ func (m *LastCommitInfo) Unmarshal(dAtA []byte) error {
 				return err
 			}
 			iNdEx = postIndex
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Height", wireType)
+			}
+			m.Height = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Height |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Signers", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Signers = append(m.Signers[:0], dAtA[iNdEx:postIndex]...)
+			if m.Signers == nil {
+				m.Signers = []byte{}
+			}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipTypes(dAtA[iNdEx:])
@@ -15915,6 +16896,40 @@ func (m *ValidatorUpdate) Unmarshal(dAtA []byte) error {
 					break
 				}
 			}
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ProofOfPossession", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ProofOfPossession = append(m.ProofOfPossession[:0], dAtA[iNdEx:postIndex]...)
+			if m.ProofOfPossession == nil {
+				m.ProofOfPossession = []byte{}
+			}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipTypes(dAtA[iNdEx:])
@@ -16022,6 +17037,39 @@ func (m *VoteInfo) Unmarshal(dAtA []byte) error {
 				}
 			}
 			m.SignedLastBlock = bool(v != 0)
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Timestamp", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := github_com_gogo_protobuf_types.StdTimeUnmarshal(&m.Timestamp, dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipTypes(dAtA[iNdEx:])
@@ -0,0 +1,306 @@
+package v0
+
+import (
+	"fmt"
+	"reflect"
+	"sync"
+	"time"
+
+	"github.com/hdac-io/tendermint/libs/log"
+	"github.com/hdac-io/tendermint/p2p"
+	sm "github.com/hdac-io/tendermint/state"
+	"github.com/hdac-io/tendermint/store"
+)
+
+const (
+	// number of headers requested ahead of the header store
+	maxPendingHeaderRequests = maxTotalRequesters
+
+	// a request is given to another peer if it isn't answered in time
+	headerRequestTimeout = 15 * time.Second
+)
+
+type headerRequest struct {
+	peerID p2p.ID
+	time   time.Time
+}
+
+type headerResponse struct {
+	peerID p2p.ID
+	msg    *bcHeaderResponseMessage
+}
+
+// HeaderSyncReactor syncs the headers of the chain, with their commits and
+// validator sets, into a HeaderStore, without the block bodies. It replaces
+// the BlockchainReactor on the nodes which only need verified headers, e.g.
+// relayers and light client proxies. The headers are requested from the
+// peers running the BlockchainReactor; the blocks are never executed and the
+// node never switches to consensus.
+//
+// To keep the peers fast syncing from asking it for blocks, it reports a
+// height of 0 to the other nodes.
+type HeaderSyncReactor struct {
+	p2p.BaseReactor
+
+	// the validators of the first heights are trusted from it
+	genState sm.State
+	store    *store.HeaderStore
+
+	mtx       sync.Mutex
+	peers     map[p2p.ID]int64 // reported heights
+	requests  map[int64]headerRequest
+	responses map[int64]headerResponse
+}
+
+// NewHeaderSyncReactor returns a new HeaderSyncReactor syncing the chain of
+// genState into headerStore.
+func NewHeaderSyncReactor(genState sm.State, headerStore *store.HeaderStore) *HeaderSyncReactor {
+	hsR := &HeaderSyncReactor{
+		genState:  genState,
+		store:     headerStore,
+		peers:     make(map[p2p.ID]int64),
+		requests:  make(map[int64]headerRequest),
+		responses: make(map[int64]headerResponse),
+	}
+	hsR.BaseReactor = *p2p.NewBaseReactor("HeaderSyncReactor", hsR)
+	return hsR
+}
+
+// SetLogger implements cmn.Service.
+func (hsR *HeaderSyncReactor) SetLogger(l log.Logger) {
+	hsR.BaseService.Logger = l
+}
+
+// OnStart implements cmn.Service.
+func (hsR *HeaderSyncReactor) OnStart() error {
+	go hsR.syncRoutine()
+	return nil
+}
+
+// GetChannels implements Reactor
+func (hsR *HeaderSyncReactor) GetChannels() []*p2p.ChannelDescriptor {
+	return []*p2p.ChannelDescriptor{
+		{
+			ID:                  BlockchainChannel,
+			Priority:            10,
+			SendQueueCapacity:   1000,
+			RecvBufferCapacity:  50 * 4096,
+			RecvMessageCapacity: maxMsgSize,
+		},
+	}
+}
+
+// AddPeer implements Reactor by asking the peer for its height.
+func (hsR *HeaderSyncReactor) AddPeer(peer p2p.Peer) {
+	msgBytes := encodePeerMsg(hsR.Switch, peer, &bcStatusRequestMessage{hsR.store.Height()})
+	peer.Send(BlockchainChannel, msgBytes)
+}
+
+// RemovePeer implements Reactor by giving the requests of the peer to the
+// other ones.
+func (hsR *HeaderSyncReactor) RemovePeer(peer p2p.Peer, reason interface{}) {
+	hsR.mtx.Lock()
+	defer hsR.mtx.Unlock()
+	hsR.removePeer(peer.ID())
+}
+
+// NOTE: hsR.mtx must be locked
+func (hsR *HeaderSyncReactor) removePeer(peerID p2p.ID) {
+	delete(hsR.peers, peerID)
+	for height, req := range hsR.requests {
+		if req.peerID == peerID {
+			delete(hsR.requests, height)
+		}
+	}
+}
+
+// Receive implements Reactor.
+func (hsR *HeaderSyncReactor) Receive(chID byte, src p2p.Peer, msgBytes []byte) {
+	msg, err := decodePeerMsg(msgBytes, hsR.Switch.ProtoMsgs(src))
+	if err != nil {
+		hsR.Logger.Error("Error decoding message", "src", src, "chId", chID, "msg", msg, "err", err, "bytes", msgBytes)
+		hsR.Switch.StopPeerForError(src, err)
+		return
+	}
+	if err = msg.ValidateBasic(); err != nil {
+		hsR.Logger.Error("Peer sent us invalid msg", "peer", src, "msg", msg, "err", err)
+		hsR.Switch.StopPeerForError(src, err)
+		return
+	}
+
+	hsR.Logger.Debug("Receive", "src", src, "chID", chID, "msg", msg)
+
+	switch msg := msg.(type) {
+	case *bcStatusRequestMessage:
+		msgBytes := encodePeerMsg(hsR.Switch, src, &bcStatusResponseMessage{0})
+		src.TrySend(BlockchainChannel, msgBytes)
+	case *bcBlockRequestMessage:
+		msgBytes := encodePeerMsg(hsR.Switch, src, &bcNoBlockResponseMessage{Height: msg.Height})
+		src.TrySend(BlockchainChannel, msgBytes)
+	case *bcHeaderRequestMessage:
+		hsR.respondToPeer(msg, src)
+	case *bcStatusResponseMessage:
+		hsR.mtx.Lock()
+		hsR.peers[src.ID()] = msg.Height
+		hsR.mtx.Unlock()
+	case *bcHeaderResponseMessage:
+		hsR.mtx.Lock()
+		height := msg.SignedHeader.Height
+		if req, ok := hsR.requests[height]; ok && req.peerID == src.ID() {
+			delete(hsR.requests, height)
+			hsR.responses[height] = headerResponse{src.ID(), msg}
+		}
+		hsR.mtx.Unlock()
+	case *bcNoBlockResponseMessage:
+		hsR.mtx.Lock()
+		if req, ok := hsR.requests[msg.Height]; ok && req.peerID == src.ID() {
+			delete(hsR.requests, msg.Height)
+			// don't ask it again
+			if hsR.peers[src.ID()] >= msg.Height {
+				hsR.peers[src.ID()] = msg.Height - 1
+			}
+		}
+		hsR.mtx.Unlock()
+	default:
+		hsR.Logger.Error(fmt.Sprintf("Unknown message type %v", reflect.TypeOf(msg)))
+	}
+}
+
+// respondToPeer sends a header of the header store to the requesting peer,
+// or says we don't have it.
+func (hsR *HeaderSyncReactor) respondToPeer(msg *bcHeaderRequestMessage, src p2p.Peer) (queued bool) {
+	if sh := hsR.store.LoadSignedHeader(msg.Height); sh != nil {
+		msgBytes := encodePeerMsg(hsR.Switch, src, &bcHeaderResponseMessage{
+			SignedHeader: sh,
+			Validators:   hsR.store.LoadValidators(msg.Height),
+		})
+		return src.TrySend(BlockchainChannel, msgBytes)
+	}
+	msgBytes := encodePeerMsg(hsR.Switch, src, &bcNoBlockResponseMessage{Height: msg.Height})
+	return src.TrySend(BlockchainChannel, msgBytes)
+}
+
+func (hsR *HeaderSyncReactor) syncRoutine() {
+	trySyncTicker := time.NewTicker(trySyncIntervalMS * time.Millisecond)
+	defer trySyncTicker.Stop()
+	statusUpdateTicker := time.NewTicker(statusUpdateIntervalSeconds * time.Second)
+	defer statusUpdateTicker.Stop()
+
+	headersSynced := 0
+	lastHundred := time.Now()
+
+	for {
+		select {
+		case <-hsR.Quit():
+			return
+
+		case <-statusUpdateTicker.C:
+			broadcastMsg(hsR.Switch, &bcStatusRequestMessage{hsR.store.Height()})
+
+		case <-trySyncTicker.C:
+			synced := hsR.saveResponses()
+			hsR.makeRequests()
+
+			for i := 0; i < synced; i++ {
+				headersSynced++
+				if headersSynced%100 == 0 {
+					hsR.Logger.Info("Header Sync Rate", "height", hsR.store.Height(),
+						"headers/s", 100/time.Since(lastHundred).Seconds())
+					lastHundred = time.Now()
+				}
+			}
+		}
+	}
+}
+
+// saveResponses verifies and saves the headers received after the header
+// store, and returns how many were saved. The peers which sent an invalid one
+// are stopped.
+func (hsR *HeaderSyncReactor) saveResponses() (synced int) {
+	for {
+		height := hsR.store.Height() + 1
+		hsR.mtx.Lock()
+		resp, ok := hsR.responses[height]
+		delete(hsR.responses, height)
+		hsR.mtx.Unlock()
+		if !ok {
+			return
+		}
+
+		err := hsR.store.VerifySignedHeader(hsR.genState, resp.msg.SignedHeader, resp.msg.Validators)
+		if err != nil {
+			hsR.Logger.Error("Error in validation", "height", height, "peer", resp.peerID, "err", err)
+			hsR.mtx.Lock()
+			hsR.removePeer(resp.peerID)
+			hsR.mtx.Unlock()
+			if peer := hsR.Switch.Peers().Get(resp.peerID); peer != nil {
+				hsR.Switch.StopPeerForError(peer, fmt.Errorf("HeaderSyncReactor validation error: %v", err))
+			}
+			return
+		}
+		hsR.store.SaveSignedHeader(resp.msg.SignedHeader, resp.msg.Validators)
+		synced++
+	}
+}
+
+// makeRequests requests the missing headers ahead of the header store from
+// the peers which have them, and gives the requests which timed out to other
+// peers.
+func (hsR *HeaderSyncReactor) makeRequests() {
+	hsR.mtx.Lock()
+	defer hsR.mtx.Unlock()
+
+	pendingPerPeer := make(map[p2p.ID]int)
+	for height, req := range hsR.requests {
+		if time.Since(req.time) > headerRequestTimeout {
+			hsR.Logger.Info("Header request timed out", "height", height, "peer", req.peerID)
+			delete(hsR.requests, height)
+			continue
+		}
+		pendingPerPeer[req.peerID]++
+	}
+
+	base := hsR.store.Height()
+	for height := base + 1; height <= base+maxPendingHeaderRequests; height++ {
+		if _, ok := hsR.requests[height]; ok {
+			continue
+		}
+		if _, ok := hsR.responses[height]; ok {
+			continue
+		}
+		peerID, ok := hsR.pickPeer(height, pendingPerPeer)
+		if !ok {
+			return
+		}
+		peer := hsR.Switch.Peers().Get(peerID)
+		if peer == nil {
+			delete(hsR.peers, peerID)
+			continue
+		}
+		msgBytes := encodePeerMsg(hsR.Switch, peer, &bcHeaderRequestMessage{height})
+		if !peer.TrySend(BlockchainChannel, msgBytes) {
+			hsR.Logger.Debug("Send queue is full, drop header request", "peer", peerID, "height", height)
+			return
+		}
+		hsR.requests[height] = headerRequest{peerID, time.Now()}
+		pendingPerPeer[peerID]++
+	}
+}
+
+// pickPeer returns the least busy peer which has the height.
+// NOTE: hsR.mtx must be locked
+func (hsR *HeaderSyncReactor) pickPeer(height int64, pendingPerPeer map[p2p.ID]int) (p2p.ID, bool) {
+	var (
+		picked p2p.ID
+		found  bool
+	)
+	for peerID, peerHeight := range hsR.peers {
+		if peerHeight < height || pendingPerPeer[peerID] >= maxPendingRequestsPerPeer {
+			continue
+		}
+		if !found || pendingPerPeer[peerID] < pendingPerPeer[picked] {
+			picked, found = peerID, true
+		}
+	}
+	return picked, found
+}
@@ -63,6 +63,7 @@ type IBlockPool interface {
 	MaxPeerHeight() int64
 	SetPeerHeight(peerID p2p.ID, height int64)
 	RemovePeer(peerID p2p.ID)
+	ResetHeight(height int64) error
 }
 
 /*
@@ -147,6 +148,40 @@ func (pool *BlockPool) makeRequestersRoutine() {
 	}
 }
 
+// ResetHeight resets the stopped pool to request the blocks from height when
+// it is started again, e.g. to fast sync again after the consensus fell
+// behind. The peers are forgotten until they report their heights again.
+func (pool *BlockPool) ResetHeight(height int64) error {
+	if pool.IsRunning() {
+		return errors.New("can't reset a running pool")
+	}
+	// fails if the pool was never started, and needs no reset then
+	pool.Reset() // nolint: errcheck
+
+	pool.mtx.Lock()
+	defer pool.mtx.Unlock()
+
+	for _, requester := range pool.requesters {
+		requester.Stop()
+	}
+	for _, peer := range pool.peers {
+		if peer.timeout != nil {
+			peer.timeout.Stop()
+		}
+	}
+	pool.requesters = make(map[int64]*bpRequester)
+	pool.height = height
+	pool.peers = make(map[p2p.ID]*bpPeer)
+	pool.maxPeerHeight = 0
+	atomic.StoreInt32(&pool.numPending, 0)
+	return nil
+}
+
+// OnReset implements cmn.Service, see ResetHeight.
+func (pool *BlockPool) OnReset() error {
+	return nil
+}
+
 func (pool *BlockPool) removeTimedoutPeers() {
 	pool.mtx.Lock()
 	defer pool.mtx.Unlock()
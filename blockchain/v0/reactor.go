@@ -6,12 +6,9 @@ import (
 	"reflect"
 	"time"
 
-	amino "github.com/tendermint/go-amino"
-
 	"github.com/hdac-io/tendermint/libs/log"
 	"github.com/hdac-io/tendermint/p2p"
 	sm "github.com/hdac-io/tendermint/state"
-	"github.com/hdac-io/tendermint/store"
 	"github.com/hdac-io/tendermint/types"
 )
 
@@ -63,7 +60,7 @@ type BlockchainReactor struct {
 	latestState  sm.State
 
 	blockExec *sm.BlockExecutor
-	store     *store.BlockStore
+	store     sm.BlockStore
 	pool      IBlockPool
 	fastSync  bool
 
@@ -72,7 +69,7 @@ type BlockchainReactor struct {
 }
 
 // NewBlockchainReactor returns new reactor instance.
-func NewBlockchainReactor(state sm.State, blockExec *sm.BlockExecutor, store *store.BlockStore,
+func NewBlockchainReactor(state sm.State, blockExec *sm.BlockExecutor, store sm.BlockStore,
 	fastSync bool) *BlockchainReactor {
 
 	if state.LastBlockHeight != store.Height() {
@@ -137,7 +134,7 @@ func (bcR *BlockchainReactor) OnStart() error {
 		if err != nil {
 			return err
 		}
-		go bcR.poolRoutine()
+		go bcR.poolRoutine(bcR.initialState)
 	}
 	return nil
 }
@@ -147,6 +144,30 @@ func (bcR *BlockchainReactor) OnStop() {
 	bcR.pool.Stop()
 }
 
+// SwitchToFastSync fast syncs again from state, the state of the consensus
+// which fell behind the peers and was paused, and switches back to the
+// consensus once caught up, like on start.
+func (bcR *BlockchainReactor) SwitchToFastSync(state sm.State) error {
+	if bcR.pool.IsRunning() {
+		return errors.New("already fast syncing")
+	}
+	if state.LastBlockHeight != bcR.store.Height() {
+		return fmt.Errorf("state (%v) and store (%v) height mismatch", state.LastBlockHeight,
+			bcR.store.Height())
+	}
+	if err := bcR.pool.ResetHeight(state.LastBlockHeight + 1); err != nil {
+		return err
+	}
+	bcR.latestState = state
+	if err := bcR.pool.Start(); err != nil {
+		return err
+	}
+	bcR.Logger.Info("Switched to fast sync", "height", state.LastBlockHeight+1)
+	go bcR.BroadcastStatusRequest() // nolint: errcheck
+	go bcR.poolRoutine(state)
+	return nil
+}
+
 // GetChannels implements Reactor
 func (bcR *BlockchainReactor) GetChannels() []*p2p.ChannelDescriptor {
 	return []*p2p.ChannelDescriptor{
@@ -162,7 +183,7 @@ func (bcR *BlockchainReactor) GetChannels() []*p2p.ChannelDescriptor {
 
 // AddPeer implements Reactor by sending our state to peer.
 func (bcR *BlockchainReactor) AddPeer(peer p2p.Peer) {
-	msgBytes := cdc.MustMarshalBinaryBare(&bcStatusResponseMessage{bcR.store.Height()})
+	msgBytes := encodePeerMsg(bcR.Switch, peer, &bcStatusResponseMessage{bcR.store.Height()})
 	peer.Send(BlockchainChannel, msgBytes)
 	// it's OK if send fails. will try later in poolRoutine
 
@@ -184,19 +205,47 @@ func (bcR *BlockchainReactor) respondToPeer(msg *bcBlockRequestMessage,
 
 	block := bcR.store.LoadBlock(msg.Height)
 	if block != nil {
-		msgBytes := cdc.MustMarshalBinaryBare(&bcBlockResponseMessage{Block: block})
+		msgBytes := encodePeerMsg(bcR.Switch, src, &bcBlockResponseMessage{Block: block})
 		return src.TrySend(BlockchainChannel, msgBytes)
 	}
 
 	bcR.Logger.Info("Peer asking for a block we don't have", "src", src, "height", msg.Height)
 
-	msgBytes := cdc.MustMarshalBinaryBare(&bcNoBlockResponseMessage{Height: msg.Height})
+	msgBytes := encodePeerMsg(bcR.Switch, src, &bcNoBlockResponseMessage{Height: msg.Height})
 	return src.TrySend(BlockchainChannel, msgBytes)
 }
 
-// Receive implements Reactor by handling 4 types of messages (look below).
+// respondHeaderToPeer sends the header of a height, with its commit and
+// validator set, to a peer syncing the headers only (see HeaderSyncReactor).
+// If we don't have them, we'll respond saying we don't have the block.
+func (bcR *BlockchainReactor) respondHeaderToPeer(msg *bcHeaderRequestMessage,
+	src p2p.Peer) (queued bool) {
+
+	if meta := bcR.store.LoadBlockMeta(msg.Height); meta != nil {
+		// the canonical commit is only saved with the block of the next ULB height
+		commit := bcR.store.LoadBlockCommit(msg.Height)
+		if commit == nil {
+			commit = bcR.store.LoadSeenCommit(msg.Height)
+		}
+		validators, err := sm.LoadValidators(bcR.blockExec.DB(), msg.Height)
+		if commit != nil && err == nil {
+			msgBytes := encodePeerMsg(bcR.Switch, src, &bcHeaderResponseMessage{
+				SignedHeader: &types.SignedHeader{Header: &meta.Header, Commit: commit},
+				Validators:   validators,
+			})
+			return src.TrySend(BlockchainChannel, msgBytes)
+		}
+	}
+
+	bcR.Logger.Info("Peer asking for a header we don't have", "src", src, "height", msg.Height)
+
+	msgBytes := encodePeerMsg(bcR.Switch, src, &bcNoBlockResponseMessage{Height: msg.Height})
+	return src.TrySend(BlockchainChannel, msgBytes)
+}
+
+// Receive implements Reactor by handling 5 types of messages (look below).
 func (bcR *BlockchainReactor) Receive(chID byte, src p2p.Peer, msgBytes []byte) {
-	msg, err := decodeMsg(msgBytes)
+	msg, err := decodePeerMsg(msgBytes, bcR.Switch.ProtoMsgs(src))
 	if err != nil {
 		bcR.Logger.Error("Error decoding message", "src", src, "chId", chID, "msg", msg, "err", err, "bytes", msgBytes)
 		bcR.Switch.StopPeerForError(src, err)
@@ -223,19 +272,22 @@ func (bcR *BlockchainReactor) Receive(chID byte, src p2p.Peer, msgBytes []byte)
 		bcR.pool.AddBlock(src.ID(), msg.Block, len(msgBytes))
 	case *bcStatusRequestMessage:
 		// Send peer our state.
-		msgBytes := cdc.MustMarshalBinaryBare(&bcStatusResponseMessage{bcR.store.Height()})
+		msgBytes := encodePeerMsg(bcR.Switch, src, &bcStatusResponseMessage{bcR.store.Height()})
 		src.TrySend(BlockchainChannel, msgBytes)
 	case *bcStatusResponseMessage:
 		// Got a peer status. Unverified.
 		bcR.pool.SetPeerHeight(src.ID(), msg.Height)
+	case *bcHeaderRequestMessage:
+		bcR.respondHeaderToPeer(msg, src)
 	default:
 		bcR.Logger.Error(fmt.Sprintf("Unknown message type %v", reflect.TypeOf(msg)))
 	}
 }
 
-// Handle messages from the poolReactor telling the reactor what to do.
+// Handle messages from the poolReactor telling the reactor what to do,
+// syncing the blocks from state.
 // NOTE: Don't sleep in the FOR_LOOP or otherwise slow it down!
-func (bcR *BlockchainReactor) poolRoutine() {
+func (bcR *BlockchainReactor) poolRoutine(state sm.State) {
 
 	trySyncTicker := time.NewTicker(trySyncIntervalMS * time.Millisecond)
 	statusUpdateTicker := time.NewTicker(statusUpdateIntervalSeconds * time.Second)
@@ -243,8 +295,7 @@ func (bcR *BlockchainReactor) poolRoutine() {
 
 	blocksSynced := 0
 
-	chainID := bcR.initialState.ChainID
-	state := bcR.initialState
+	chainID := state.ChainID
 
 	lastHundred := time.Now()
 	lastRate := 0.0
@@ -263,7 +314,7 @@ func (bcR *BlockchainReactor) poolRoutine() {
 				if peer == nil {
 					continue
 				}
-				msgBytes := cdc.MustMarshalBinaryBare(&bcBlockRequestMessage{request.Height})
+				msgBytes := encodePeerMsg(bcR.Switch, peer, &bcBlockRequestMessage{request.Height})
 				queued := peer.TrySend(BlockchainChannel, msgBytes)
 				if !queued {
 					bcR.Logger.Debug("Send queue is full, drop block request", "peer", peer.ID(), "height", request.Height)
@@ -411,8 +462,7 @@ FOR_LOOP:
 
 // BroadcastStatusRequest broadcasts `BlockStore` height.
 func (bcR *BlockchainReactor) BroadcastStatusRequest() error {
-	msgBytes := cdc.MustMarshalBinaryBare(&bcStatusRequestMessage{bcR.store.Height()})
-	bcR.Switch.Broadcast(BlockchainChannel, msgBytes)
+	broadcastMsg(bcR.Switch, &bcStatusRequestMessage{bcR.store.Height()})
 	return nil
 }
 
@@ -425,16 +475,6 @@ type BlockchainMessage interface {
 	ValidateFridayBasic() error
 }
 
-// RegisterBlockchainMessages registers the fast sync messages for amino encoding.
-func RegisterBlockchainMessages(cdc *amino.Codec) {
-	cdc.RegisterInterface((*BlockchainMessage)(nil), nil)
-	cdc.RegisterConcrete(&bcBlockRequestMessage{}, "tendermint/blockchain/BlockRequest", nil)
-	cdc.RegisterConcrete(&bcBlockResponseMessage{}, "tendermint/blockchain/BlockResponse", nil)
-	cdc.RegisterConcrete(&bcNoBlockResponseMessage{}, "tendermint/blockchain/NoBlockResponse", nil)
-	cdc.RegisterConcrete(&bcStatusResponseMessage{}, "tendermint/blockchain/StatusResponse", nil)
-	cdc.RegisterConcrete(&bcStatusRequestMessage{}, "tendermint/blockchain/StatusRequest", nil)
-}
-
 func decodeMsg(bz []byte) (msg BlockchainMessage, err error) {
 	if len(bz) > maxMsgSize {
 		return msg, fmt.Errorf("Msg exceeds max size (%d > %d)", len(bz), maxMsgSize)
@@ -552,3 +592,56 @@ func (m *bcStatusResponseMessage) ValidateFridayBasic() error {
 func (m *bcStatusResponseMessage) String() string {
 	return fmt.Sprintf("[bcStatusResponseMessage %v]", m.Height)
 }
+
+//-------------------------------------
+
+type bcHeaderRequestMessage struct {
+	Height int64
+}
+
+// ValidateBasic performs basic validation.
+func (m *bcHeaderRequestMessage) ValidateBasic() error {
+	if m.Height < 0 {
+		return errors.New("Negative Height")
+	}
+	return nil
+}
+
+// ValidateFridayBasic performs basic validation.
+func (m *bcHeaderRequestMessage) ValidateFridayBasic() error {
+	return m.ValidateBasic()
+}
+
+func (m *bcHeaderRequestMessage) String() string {
+	return fmt.Sprintf("[bcHeaderRequestMessage %v]", m.Height)
+}
+
+//-------------------------------------
+
+type bcHeaderResponseMessage struct {
+	SignedHeader *types.SignedHeader
+	Validators   *types.ValidatorSet
+}
+
+// ValidateBasic performs basic validation.
+func (m *bcHeaderResponseMessage) ValidateBasic() error {
+	if m.SignedHeader == nil || m.SignedHeader.Header == nil || m.SignedHeader.Commit == nil {
+		return errors.New("Incomplete SignedHeader")
+	}
+	if m.Validators == nil || m.Validators.IsNilOrEmpty() {
+		return errors.New("Empty Validators")
+	}
+	return m.SignedHeader.Commit.ValidateBasic()
+}
+
+// ValidateFridayBasic performs basic validation.
+func (m *bcHeaderResponseMessage) ValidateFridayBasic() error {
+	return m.ValidateBasic()
+}
+
+func (m *bcHeaderResponseMessage) String() string {
+	if m.SignedHeader == nil || m.SignedHeader.Header == nil {
+		return "[bcHeaderResponseMessage nil]"
+	}
+	return fmt.Sprintf("[bcHeaderResponseMessage %v]", m.SignedHeader.Height)
+}
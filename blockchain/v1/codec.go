@@ -1,13 +0,0 @@
-package v1
-
-import (
-	amino "github.com/tendermint/go-amino"
-	"github.com/hdac-io/tendermint/types"
-)
-
-var cdc = amino.NewCodec()
-
-func init() {
-	RegisterBlockchainMessages(cdc)
-	types.RegisterBlockAmino(cdc)
-}
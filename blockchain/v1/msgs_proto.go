@@ -0,0 +1,94 @@
+package v1
+
+import (
+	"fmt"
+
+	"github.com/hdac-io/tendermint/p2p"
+	"github.com/hdac-io/tendermint/types"
+	"github.com/hdac-io/tendermint/types/msgs"
+)
+
+// encodePeerMsg encodes msg for peer, with protobuf if the peer uses it, see
+// p2p.Switch.ProtoMsgs, else amino.
+func encodePeerMsg(sw *p2p.Switch, peer p2p.Peer, msg BlockchainMessage) []byte {
+	return encodeMsg(msg, sw.ProtoMsgs(peer))
+}
+
+// broadcastMsg sends msg to all the peers on the blockchain channel, encoded
+// for each of them.
+func broadcastMsg(sw *p2p.Switch, msg BlockchainMessage) {
+	sw.BroadcastMsg(BlockchainChannel, func(proto bool) []byte { return encodeMsg(msg, proto) })
+}
+
+func encodeMsg(msg BlockchainMessage, proto bool) []byte {
+	if !proto {
+		return cdc.MustMarshalBinaryBare(msg)
+	}
+	bz, err := msgToProto(msg).Marshal()
+	if err != nil {
+		panic(err)
+	}
+	return bz
+}
+
+// decodePeerMsg decodes a message encoded with protobuf if proto is true,
+// else amino.
+func decodePeerMsg(bz []byte, proto bool) (BlockchainMessage, error) {
+	if !proto {
+		return decodeMsg(bz)
+	}
+	if len(bz) > maxMsgSize {
+		return nil, fmt.Errorf("Msg exceeds max size (%d > %d)", len(bz), maxMsgSize)
+	}
+	var pb msgs.BlockchainMessage
+	if err := pb.Unmarshal(bz); err != nil {
+		return nil, err
+	}
+	return msgFromProto(&pb)
+}
+
+func msgToProto(msg BlockchainMessage) *msgs.BlockchainMessage {
+	pb := &msgs.BlockchainMessage{}
+	switch msg := msg.(type) {
+	case *bcBlockRequestMessage:
+		pb.Sum = &msgs.BlockchainMessage_BlockRequest{
+			BlockRequest: &msgs.BlockRequestMessage{Height: msg.Height}}
+	case *bcNoBlockResponseMessage:
+		pb.Sum = &msgs.BlockchainMessage_NoBlockResponse{
+			NoBlockResponse: &msgs.NoBlockResponseMessage{Height: msg.Height}}
+	case *bcBlockResponseMessage:
+		pb.Sum = &msgs.BlockchainMessage_BlockResponse{
+			BlockResponse: &msgs.BlockResponseMessage{Block: cdc.MustMarshalBinaryBare(msg.Block)}}
+	case *bcStatusRequestMessage:
+		pb.Sum = &msgs.BlockchainMessage_StatusRequest{
+			StatusRequest: &msgs.StatusRequestMessage{Height: msg.Height}}
+	case *bcStatusResponseMessage:
+		pb.Sum = &msgs.BlockchainMessage_StatusResponse{
+			StatusResponse: &msgs.StatusResponseMessage{Height: msg.Height}}
+	default:
+		panic(fmt.Sprintf("Unknown message type %T", msg))
+	}
+	return pb
+}
+
+func msgFromProto(pb *msgs.BlockchainMessage) (BlockchainMessage, error) {
+	switch sum := pb.Sum.(type) {
+	case *msgs.BlockchainMessage_BlockRequest:
+		return &bcBlockRequestMessage{Height: sum.BlockRequest.Height}, nil
+	case *msgs.BlockchainMessage_NoBlockResponse:
+		return &bcNoBlockResponseMessage{Height: sum.NoBlockResponse.Height}, nil
+	case *msgs.BlockchainMessage_BlockResponse:
+		block := new(types.Block)
+		if err := cdc.UnmarshalBinaryBare(sum.BlockResponse.Block, block); err != nil {
+			return nil, err
+		}
+		return &bcBlockResponseMessage{Block: block}, nil
+	case *msgs.BlockchainMessage_StatusRequest:
+		return &bcStatusRequestMessage{Height: sum.StatusRequest.Height}, nil
+	case *msgs.BlockchainMessage_StatusResponse:
+		return &bcStatusResponseMessage{Height: sum.StatusResponse.Height}, nil
+	default:
+		// the header messages of the v0 reactor aren't supported
+		return nil, fmt.Errorf("unknown message %T", pb.Sum)
+	}
+}
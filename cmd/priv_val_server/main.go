@@ -2,10 +2,12 @@ package main
 
 import (
 	"flag"
+	"net/http"
 	"os"
 	"time"
 
 	"github.com/hdac-io/tendermint/crypto/bls"
+	"github.com/hdac-io/tendermint/crypto/xsalsa20symmetric"
 	cmn "github.com/hdac-io/tendermint/libs/common"
 	"github.com/hdac-io/tendermint/libs/log"
 	"github.com/hdac-io/tendermint/types"
@@ -20,6 +22,13 @@ func main() {
 		privValKeyPath   = flag.String("priv-key", "", "priv val key file path")
 		privValStatePath = flag.String("priv-state", "", "priv val state file path")
 		isFridayPV       = flag.Bool("friday", false, "run for friday")
+		healthAddr       = flag.String("health-addr", "", "Address of the /health and /ready HTTP probes (disabled if empty)")
+		healthMaxIdle    = flag.Duration("health-max-idle", 10*time.Second,
+			"Time without responding to the validator after which /health fails")
+		policyPath = flag.String("policy", "",
+			"JSON file of the signer policy restricting the chain IDs, heights, types and times signed (none if empty)")
+		diskEncryptionKey = flag.String("disk-encryption-key", "",
+			"Secret of the encrypted friday priv val state file, 'env:NAME' or 'file:PATH' (not encrypted if empty)")
 
 		logger = log.NewTMLogger(
 			log.NewSyncWriter(os.Stdout),
@@ -36,9 +45,20 @@ func main() {
 	)
 
 	var pv types.PrivValidator
-	if *isFridayPV {
+	switch {
+	case *isFridayPV && *diskEncryptionKey != "":
+		secret, err := xsalsa20symmetric.LoadSecret(*diskEncryptionKey)
+		if err != nil {
+			logger.Error("Could not load the disk encryption key", "err", err)
+			os.Exit(1)
+		}
+		pv = privval.LoadEncryptedFridayFilePV(*privValKeyPath, *privValStatePath, xsalsa20symmetric.Symmetric{}, secret)
+	case *isFridayPV:
 		pv = privval.LoadFridayFilePV(*privValKeyPath, *privValStatePath)
-	} else {
+	case *diskEncryptionKey != "":
+		logger.Error("Only the friday priv val state file can be encrypted, -disk-encryption-key requires -friday")
+		os.Exit(1)
+	default:
 		pv = privval.LoadFilePV(*privValKeyPath, *privValStatePath)
 	}
 
@@ -57,12 +77,36 @@ func main() {
 
 	sd := privval.NewSignerDialerEndpoint(logger, dialer)
 	ss := privval.NewSignerServer(sd, *chainID, pv)
+	if *policyPath != "" {
+		policy, err := privval.LoadSignerPolicy(*policyPath)
+		if err != nil {
+			logger.Error("Could not load the signer policy", "err", err)
+			os.Exit(1)
+		}
+		if !policy.AllowsChainID(*chainID) {
+			logger.Error("The signer policy doesn't allow the chain ID", "chainID", *chainID, "allowed", policy.ChainIDs)
+			os.Exit(1)
+		}
+		logger.Info("Enforcing the signer policy", "policy", *policyPath)
+		ss.SetPolicy(policy)
+	}
 
 	err := ss.Start()
 	if err != nil {
 		panic(err)
 	}
 
+	if *healthAddr != "" {
+		logger.Info("Starting the health probes", "addr", *healthAddr)
+		go func() {
+			err := http.ListenAndServe(*healthAddr, privval.SignerHealthHandler(ss, *healthMaxIdle))
+			if err != nil {
+				logger.Error("Health probes stopped", "err", err)
+				os.Exit(1)
+			}
+		}()
+	}
+
 	// Stop upon receiving SIGTERM or CTRL-C.
 	cmn.TrapSignal(logger, func() {
 		err := ss.Stop()
@@ -0,0 +1,82 @@
+package commands
+
+import (
+	"encoding/json"
+	"io/ioutil"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	cmn "github.com/hdac-io/tendermint/libs/common"
+	"github.com/hdac-io/tendermint/p2p"
+	"github.com/hdac-io/tendermint/p2p/pex"
+)
+
+// ExportAddrBookCmd writes the addresses of the address book to a file.
+var ExportAddrBookCmd = &cobra.Command{
+	Use:   "export-addrbook [file]",
+	Short: "Export the addresses of the address book to a JSON file",
+	Long: `export-addrbook writes the addresses of the address book to a JSON file, with
+whether the node vetted them, which can be loaded in other nodes with
+import-addrbook. The node must be stopped.`,
+	Args: cobra.ExactArgs(1),
+	RunE: exportAddrBook,
+}
+
+// ImportAddrBookCmd adds the addresses of files to the address book.
+var ImportAddrBookCmd = &cobra.Command{
+	Use:   "import-addrbook [file...]",
+	Short: "Import addresses in the address book, from exports or lists of peers",
+	Long: `import-addrbook adds the addresses of the files to the address book: files
+written by export-addrbook, or lists of ID@host:port addresses, one per line or
+comma separated, with the lines starting with # ignored. The addresses are
+unverified in the book, even if the node they come from vetted them: they are
+vetted once the node connects to them. The addresses already in the book are
+kept as they are. The node must be stopped.
+
+Once the node runs, the crawl_peers RPC endpoint makes it dial them at once.`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: importAddrBook,
+}
+
+func exportAddrBook(cmd *cobra.Command, args []string) error {
+	bookFile := config.P2P.AddrBookFile()
+	if !cmn.FileExists(bookFile) {
+		return errors.Errorf("no address book at %s", bookFile)
+	}
+	book := pex.LoadAddrBook(bookFile, config.P2P.AddrBookStrict)
+	addrs := book.Export()
+	bz, err := json.MarshalIndent(addrs, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := cmn.WriteFile(args[0], bz, 0644); err != nil {
+		return err
+	}
+	logger.Info("Exported address book", "addrs", len(addrs), "file", args[0])
+	return nil
+}
+
+func importAddrBook(cmd *cobra.Command, args []string) error {
+	var addrs []*p2p.NetAddress
+	for _, file := range args {
+		bz, err := ioutil.ReadFile(file)
+		if err != nil {
+			return err
+		}
+		fileAddrs, err := pex.ParseAddrList(bz)
+		if err != nil {
+			return errors.Wrapf(err, "could not read the addresses of %s", file)
+		}
+		addrs = append(addrs, fileAddrs...)
+	}
+
+	bookFile := config.P2P.AddrBookFile()
+	book := pex.LoadAddrBook(bookFile, config.P2P.AddrBookStrict)
+	book.SetLogger(logger.With("book", bookFile))
+	added := book.Import(addrs)
+	book.Save()
+	logger.Info("Imported addresses in the address book", "addrs", len(addrs), "added", added,
+		"size", book.Size())
+	return nil
+}
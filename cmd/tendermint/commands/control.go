@@ -0,0 +1,125 @@
+package commands
+
+import (
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"strconv"
+
+	"github.com/spf13/cobra"
+
+	"github.com/hdac-io/tendermint/control"
+	cmn "github.com/hdac-io/tendermint/libs/common"
+	"github.com/hdac-io/tendermint/p2p"
+)
+
+var (
+	controlKeyFile string
+	controlAddr    string
+)
+
+func init() {
+	ControlCmd.PersistentFlags().StringVar(&controlKeyFile, "key", filepath.Join("config", "control_key.json"),
+		"Key file signing the control requests, relative to the home directory")
+	ControlCmd.PersistentFlags().StringVar(&controlAddr, "addr", "",
+		"Address of the control API (default [control] laddr)")
+	ControlCmd.AddCommand(
+		newControlCmd(control.CommandStatus, "Print the status of the node"),
+		newControlCmd(control.CommandDrain, "Stop proposing blocks, but keep voting"),
+		newControlCmd(control.CommandResume, "Propose blocks again after drain"),
+		newControlCmd(control.CommandRotateLogs, "Move the log file to <log_file>.<time> and start a new one"),
+		controlStopAfterHeightCmd,
+		controlGenKeyCmd,
+	)
+}
+
+// ControlCmd sends signed requests to the control API of a running node.
+var ControlCmd = &cobra.Command{
+	Use:   "control",
+	Short: "Send signed requests to the control API of a running node",
+	Long: `control sends the control API of a running node ([control] laddr) requests
+signed with the key of --key, which must be in the control authorized keys of
+the node ([control] authorized_keys_file). Generate the key with
+"control gen-key", and add the line it prints to the authorized keys.
+
+To stop a validator without missing the heights in progress, drain it, then
+stop it after the pipeline height printed by drain.`,
+}
+
+func newControlCmd(command, short string) *cobra.Command {
+	return &cobra.Command{
+		Use:   command,
+		Short: short,
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return callControl(command, 0)
+		},
+	}
+}
+
+var controlStopAfterHeightCmd = &cobra.Command{
+	Use:   control.CommandStopAfterHeight + " <height>",
+	Short: "Stop the node once the block of height is finalized",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		height, err := strconv.ParseInt(args[0], 10, 64)
+		if err != nil {
+			return fmt.Errorf("invalid height %q: %v", args[0], err)
+		}
+		return callControl(control.CommandStopAfterHeight, height)
+	},
+}
+
+var controlGenKeyCmd = &cobra.Command{
+	Use:   "gen-key",
+	Short: "Generate the key signing the control requests and print its authorized key line",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		keyFile := controlKeyFilePath()
+		if cmn.FileExists(keyFile) {
+			return fmt.Errorf("control key at %s already exists", keyFile)
+		}
+		key, err := p2p.LoadOrGenNodeKey(keyFile)
+		if err != nil {
+			return err
+		}
+		line, err := control.AuthorizedKey(key.PubKey())
+		if err != nil {
+			return err
+		}
+		fmt.Println(line)
+		return nil
+	},
+}
+
+func controlKeyFilePath() string {
+	if filepath.IsAbs(controlKeyFile) {
+		return controlKeyFile
+	}
+	return filepath.Join(config.RootDir, controlKeyFile)
+}
+
+func callControl(command string, height int64) error {
+	key, err := p2p.LoadNodeKey(controlKeyFilePath())
+	if err != nil {
+		return fmt.Errorf("failed to load the control key: %v", err)
+	}
+	addr := controlAddr
+	if addr == "" {
+		addr = config.Control.ListenAddress
+	}
+	if addr == "" {
+		return fmt.Errorf("no control API address: set [control] laddr or --addr")
+	}
+
+	status, err := control.Call(addr, key.PrivKey, command, height)
+	if err != nil {
+		return err
+	}
+	bz, err := json.MarshalIndent(status, "", "  ")
+	if err != nil {
+		return err
+	}
+	fmt.Println(string(bz))
+	return nil
+}
@@ -0,0 +1,190 @@
+package commands
+
+import (
+	"archive/zip"
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	cmn "github.com/hdac-io/tendermint/libs/common"
+)
+
+var (
+	debugRPCAddr    string
+	debugRPCToken   string
+	debugPprofAddr  string
+	debugServerAddr string
+	debugCPUSeconds int
+)
+
+func init() {
+	debugDumpCmd.Flags().StringVar(&debugRPCAddr, "rpc-addr", "",
+		"Address of the RPC server of the node (default [rpc] laddr)")
+	debugDumpCmd.Flags().StringVar(&debugRPCToken, "rpc-token", "",
+		"Admin token of the RPC server, if the consensus endpoints are restricted to the admin clients")
+	debugDumpCmd.Flags().StringVar(&debugPprofAddr, "pprof-addr", "",
+		"Address of the pprof server of the node (default [instrumentation] pprof_listen_addr)")
+	debugDumpCmd.Flags().StringVar(&debugServerAddr, "debug-addr", "",
+		"Address of the debug server of the node (default [instrumentation] debug_listen_addr)")
+	debugDumpCmd.Flags().IntVar(&debugCPUSeconds, "cpu-seconds", 10,
+		"Duration of the CPU profile, in seconds")
+	DebugCmd.AddCommand(debugDumpCmd)
+}
+
+// DebugCmd groups the commands helping to debug a running node.
+var DebugCmd = &cobra.Command{
+	Use:   "debug",
+	Short: "Debug a running node",
+}
+
+var debugDumpCmd = &cobra.Command{
+	Use:   "dump [output.zip]",
+	Short: "Bundle the profiles and the consensus state of a running node in a zip file",
+	Long: `dump captures the pprof profiles (CPU, heap, goroutine, block and mutex) of a
+running node, which must have [instrumentation] pprof on, and snapshots of its
+status and consensus state from the RPC server and the debug server, if any,
+in a zip file to attach to support tickets. The parts which can't be captured
+are listed in errors.txt.
+
+The default output file is debug-<time>.zip.`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runDebugDump,
+}
+
+// debugItem is a file of the bundle, fetched from a URL.
+type debugItem struct {
+	name   string
+	url    string
+	auth   bool
+	client *http.Client
+}
+
+func runDebugDump(cmd *cobra.Command, args []string) error {
+	out := fmt.Sprintf("debug-%s.zip", time.Now().UTC().Format("20060102T150405Z"))
+	if len(args) == 1 {
+		out = args[0]
+	}
+
+	timeout := time.Duration(debugCPUSeconds)*time.Second + 30*time.Second
+	var items []debugItem
+	if rpc, client := debugHTTPClient(debugRPCAddr, config.RPC.ListenAddress, timeout); client != nil {
+		for _, endpoint := range []string{"status", "net_info", "consensus_state", "dump_consensus_state"} {
+			items = append(items, debugItem{endpoint + ".json", rpc + "/" + endpoint, true, client})
+		}
+	}
+	if debug, client := debugHTTPClient(debugServerAddr, config.Instrumentation.DebugListenAddr, timeout); client != nil {
+		items = append(items, debugItem{"debug_consensus.json", debug + "/debug/consensus", false, client})
+	}
+	if pprof, client := debugHTTPClient(debugPprofAddr, config.Instrumentation.PprofListenAddr, timeout); client != nil {
+		items = append(items,
+			debugItem{"goroutine.txt", pprof + "/debug/pprof/goroutine?debug=2", false, client},
+			debugItem{"heap.pprof", pprof + "/debug/pprof/heap", false, client},
+			debugItem{"block.pprof", pprof + "/debug/pprof/block", false, client},
+			debugItem{"mutex.pprof", pprof + "/debug/pprof/mutex", false, client},
+			debugItem{"cpu.pprof", fmt.Sprintf("%s/debug/pprof/profile?seconds=%d", pprof, debugCPUSeconds), false, client},
+		)
+	}
+
+	f, err := os.Create(out)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	zw := zip.NewWriter(f)
+
+	var errs []string
+	for _, item := range items {
+		if item.name == "cpu.pprof" {
+			fmt.Printf("Capturing the CPU profile for %d seconds\n", debugCPUSeconds)
+		}
+		bz, err := fetchDebugItem(item)
+		if err != nil {
+			errs = append(errs, fmt.Sprintf("%s: %v", item.name, err))
+			continue
+		}
+		if err := writeZipFile(zw, item.name, bz); err != nil {
+			return err
+		}
+	}
+	if len(errs) > 0 {
+		if err := writeZipFile(zw, "errors.txt", []byte(strings.Join(errs, "\n")+"\n")); err != nil {
+			return err
+		}
+	}
+	if err := zw.Close(); err != nil {
+		return err
+	}
+
+	fmt.Printf("Wrote %s (%d files, %d errors)\n", out, len(items)-len(errs), len(errs))
+	for _, e := range errs {
+		fmt.Println(e)
+	}
+	return nil
+}
+
+// debugHTTPClient returns the base URL of the HTTP server listening on addr,
+// or on defaultAddr if addr is empty, and a client connecting to it. The
+// client is nil if both addresses are empty.
+func debugHTTPClient(addr, defaultAddr string, timeout time.Duration) (string, *http.Client) {
+	if addr == "" {
+		addr = defaultAddr
+	}
+	if addr == "" {
+		return "", nil
+	}
+	protocol, address := cmn.ProtocolAndAddress(addr)
+	if protocol == "unix" {
+		return "http://unix", &http.Client{
+			Timeout: timeout,
+			Transport: &http.Transport{
+				DialContext: func(ctx context.Context, _, _ string) (net.Conn, error) {
+					return (&net.Dialer{}).DialContext(ctx, "unix", address)
+				},
+			},
+		}
+	}
+	// the servers listening on all the interfaces are reached on the loopback
+	if host, port, err := net.SplitHostPort(address); err == nil && (host == "" || host == "0.0.0.0") {
+		address = net.JoinHostPort("127.0.0.1", port)
+	}
+	return "http://" + address, &http.Client{Timeout: timeout}
+}
+
+func fetchDebugItem(item debugItem) ([]byte, error) {
+	req, err := http.NewRequest(http.MethodGet, item.url, nil)
+	if err != nil {
+		return nil, err
+	}
+	if item.auth && debugRPCToken != "" {
+		req.Header.Set("Authorization", "Bearer "+debugRPCToken)
+	}
+	res, err := item.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+	bz, err := ioutil.ReadAll(res.Body)
+	if err != nil {
+		return nil, err
+	}
+	if res.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("%s: %s", res.Status, strings.TrimSpace(string(bz)))
+	}
+	return bz, nil
+}
+
+func writeZipFile(zw *zip.Writer, name string, bz []byte) error {
+	w, err := zw.CreateHeader(&zip.FileHeader{Name: name, Method: zip.Deflate, Modified: time.Now()})
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(bz)
+	return err
+}
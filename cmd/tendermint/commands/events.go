@@ -0,0 +1,163 @@
+package commands
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"github.com/hdac-io/tendermint/consensus/eventlog"
+	cmn "github.com/hdac-io/tendermint/libs/common"
+	tmpubsub "github.com/hdac-io/tendermint/libs/pubsub"
+	tmquery "github.com/hdac-io/tendermint/libs/pubsub/query"
+	"github.com/hdac-io/tendermint/types"
+)
+
+var (
+	eventsFromHeight int64
+	eventsToHeight   int64
+	eventsOutFile    string
+	eventsRealtime   bool
+	eventsQuery      string
+)
+
+func init() {
+	eventsExportCmd.Flags().Int64Var(&eventsFromHeight, "from", 1, "First height of the events to export")
+	eventsExportCmd.Flags().Int64Var(&eventsToHeight, "to", 0, "Last height of the events to export (0 means the last one recorded)")
+	eventsExportCmd.Flags().StringVar(&eventsOutFile, "out", "", "File to export the events to (default stdout)")
+	eventsReplayCmd.Flags().BoolVar(&eventsRealtime, "realtime", false,
+		"Keep the delays between the events, instead of replaying them at once")
+	eventsReplayCmd.Flags().StringVar(&eventsQuery, "query", "",
+		"Only print the replayed events matching the query, e.g. \"tm.event = 'Vote'\"")
+	EventsCmd.AddCommand(eventsExportCmd, eventsReplayCmd)
+}
+
+// EventsCmd exports the events recorded by the node and replays them.
+var EventsCmd = &cobra.Command{
+	Use:   "events",
+	Short: "Export and replay the events recorded by the node",
+	Long: `The node records the events published at a height on its event bus (the
+consensus events, and the block and tx events) to [consensus] event_log_file,
+if set. "events export" exports the ones of a range of heights, and "events
+replay" publishes exported events again, in the order the node published them,
+into a new event bus, printing the events its subscriber receives.
+
+The heights in progress of the friday consensus interleave their events, so
+exporting a range of heights exports the events of the other heights in
+progress at the same time only if they are in the range too.`,
+}
+
+var eventsExportCmd = &cobra.Command{
+	Use:   "export",
+	Short: "Export the recorded events of a range of heights",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if !config.Consensus.EventLogEnabled() {
+			return errors.New("the events are not recorded, set [consensus] event_log_file")
+		}
+		to := eventsToHeight
+		if to == 0 {
+			to = math.MaxInt64
+		}
+		if eventsFromHeight > to {
+			return fmt.Errorf("--from %d is above --to %d", eventsFromHeight, to)
+		}
+		file := config.Consensus.EventLogFile()
+		if !cmn.FileExists(file) {
+			return fmt.Errorf("no events recorded at %s", file)
+		}
+
+		var w io.Writer = os.Stdout
+		if eventsOutFile != "" {
+			f, err := os.Create(eventsOutFile)
+			if err != nil {
+				return err
+			}
+			defer f.Close()
+			w = f
+		}
+		n, err := eventlog.Export(file, eventsFromHeight, to, w)
+		if err != nil {
+			return err
+		}
+		if eventsOutFile != "" {
+			logger.Info("Exported events", "file", eventsOutFile, "events", n)
+		}
+		return nil
+	},
+}
+
+var eventsReplayCmd = &cobra.Command{
+	Use:   "replay <file>",
+	Short: "Replay exported events into a new event bus, printing them",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		var query tmpubsub.Query = eventlog.Query
+		if eventsQuery != "" {
+			q, err := tmquery.New(eventsQuery)
+			if err != nil {
+				return errors.Wrap(err, "invalid query")
+			}
+			query = q
+		}
+		f, err := os.Open(args[0])
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+
+		eventBus := types.NewEventBus()
+		eventBus.SetLogger(logger.With("module", "events"))
+		if err := eventBus.Start(); err != nil {
+			return err
+		}
+		defer eventBus.Stop()
+		// unbuffered, so the events are printed in order and none is dropped
+		sub, err := eventBus.SubscribeUnbuffered(context.Background(), "replay", query)
+		if err != nil {
+			return err
+		}
+		printed := make(chan error, 1)
+		go func() { printed <- printEvents(sub) }()
+
+		n, err := eventlog.Replay(f, eventBus, eventsRealtime)
+		// unsubscribing once the events replayed are delivered ends printEvents
+		if err := eventBus.UnsubscribeAll(context.Background(), "replay"); err != nil {
+			return err
+		}
+		if perr := <-printed; err == nil {
+			err = perr
+		}
+		if err != nil {
+			return err
+		}
+		logger.Info("Replayed events", "events", n)
+		return nil
+	},
+}
+
+// printEvents prints the events of sub as records, one per line, until it is
+// cancelled.
+func printEvents(sub types.Subscription) error {
+	enc := json.NewEncoder(os.Stdout)
+	for {
+		select {
+		case msg := <-sub.Out():
+			rec, err := eventlog.NewRecord(msg, time.Now())
+			if err != nil {
+				return err
+			}
+			if err := enc.Encode(rec); err != nil {
+				return err
+			}
+		case <-sub.Cancelled():
+			return nil
+		}
+	}
+}
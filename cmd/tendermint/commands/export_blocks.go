@@ -0,0 +1,107 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	nm "github.com/hdac-io/tendermint/node"
+	sm "github.com/hdac-io/tendermint/state"
+	"github.com/hdac-io/tendermint/store"
+)
+
+var (
+	exportFromHeight int64
+	exportToHeight   int64
+)
+
+func init() {
+	ExportBlocksCmd.Flags().Int64Var(&exportFromHeight, "from", 1, "First height to export")
+	ExportBlocksCmd.Flags().Int64Var(&exportToHeight, "to", 0, "Last height to export (defaults to the height of the block store)")
+}
+
+// ExportBlocksCmd writes a height range of the blockchain to a file.
+var ExportBlocksCmd = &cobra.Command{
+	Use:   "export-blocks [file]",
+	Short: "Export a height range of blocks, with their commits and validators, to a file",
+	Long: `export-blocks writes the blocks of a height range, with their seen commits and
+validator sets, to a file which can be loaded in another node with import-blocks,
+e.g. to move a chain to another block store backend. The node must be stopped.`,
+	Args: cobra.ExactArgs(1),
+	RunE: exportBlocks,
+}
+
+// ImportBlocksCmd loads a file written by export-blocks in the block store.
+var ImportBlocksCmd = &cobra.Command{
+	Use:   "import-blocks [file]",
+	Short: "Import the blocks of a file written by export-blocks in the block store",
+	Long: `import-blocks saves the blocks of a file written by export-blocks in the block
+store of the node, after its last height. Every block is verified against the
+previous ones and the genesis file before it is saved: its validator set, its
+seen commit and the commit of the ULB height it carries must be valid. The
+heights already in the block store are skipped, so an interrupted import can be
+run again. The node must be stopped.
+
+The blocks are not executed: the node replays them on start.`,
+	Args: cobra.ExactArgs(1),
+	RunE: importBlocks,
+}
+
+func exportBlocks(cmd *cobra.Command, args []string) error {
+	blockStore, stateDB, err := nm.InitDBs(config, nm.DefaultDBProvider, logger)
+	if err != nil {
+		return err
+	}
+	defer stateDB.Close()
+
+	to := exportToHeight
+	if to == 0 {
+		to = blockStore.Height()
+	}
+
+	f, err := os.Create(args[0])
+	if err != nil {
+		return err
+	}
+	if err := store.ExportBlocks(f, blockStore, stateDB, exportFromHeight, to); err != nil {
+		f.Close() // nolint: errcheck
+		return errors.Wrap(err, "could not export the blocks")
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	logger.Info("Exported blocks", "from", exportFromHeight, "to", to, "file", args[0])
+	return nil
+}
+
+func importBlocks(cmd *cobra.Command, args []string) error {
+	genDoc, err := sm.MakeGenesisDocFromFile(config.GenesisFile())
+	if err != nil {
+		return err
+	}
+	genState, err := sm.MakeGenesisState(genDoc)
+	if err != nil {
+		return err
+	}
+
+	f, err := os.Open(args[0])
+	if err != nil {
+		return err
+	}
+	defer f.Close() // nolint: errcheck
+
+	blockStore, stateDB, err := nm.InitDBs(config, nm.DefaultDBProvider, logger)
+	if err != nil {
+		return err
+	}
+	defer stateDB.Close()
+
+	height, err := store.ImportBlocks(f, blockStore, genState, logger)
+	if err != nil {
+		return fmt.Errorf("imported the blocks up to height %d: %v", height, err)
+	}
+	logger.Info("Imported blocks", "height", height, "file", args[0])
+	return nil
+}
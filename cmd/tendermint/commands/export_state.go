@@ -0,0 +1,60 @@
+package commands
+
+import (
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	nm "github.com/hdac-io/tendermint/node"
+	sm "github.com/hdac-io/tendermint/state"
+)
+
+var exportStateHeight int64
+
+func init() {
+	ExportStateCmd.Flags().Int64Var(&exportStateHeight, "height", 0,
+		"Height to export (defaults to the last height of the state)")
+}
+
+// ExportStateCmd writes the state of the chain at a height to a file, to boot
+// a new chain from it with init --from-export.
+var ExportStateCmd = &cobra.Command{
+	Use:   "export-state [file]",
+	Short: "Export the state of the chain at a height, to boot a new chain continuing from it",
+	Long: `export-state writes the state of the chain at --height to a file: the app hash
+and results hash of the height, and the validators and consensus params
+(including the LenULB) of the next height. Pass it to init --from-export with a
+new chain ID to boot a new chain continuing from that state, e.g. to restart a
+chain after a consensus failure. The node must be stopped, with the app having
+committed the height.
+
+The export fails if a validator update returned by the app before the height
+is still pending, as the new chain starts with a single validator set.
+
+The app state itself is not exported: the app of each validator of the new
+chain must continue from its state at that height, with the exported app hash.`,
+	Args: cobra.ExactArgs(1),
+	RunE: exportState,
+}
+
+func exportState(cmd *cobra.Command, args []string) error {
+	blockStore, stateDB, err := nm.InitDBs(config, nm.DefaultDBProvider, logger)
+	if err != nil {
+		return err
+	}
+	defer stateDB.Close()
+
+	height := exportStateHeight
+	if height == 0 {
+		height = sm.LoadState(stateDB).LastBlockHeight
+	}
+	export, err := sm.ExportState(stateDB, blockStore, height)
+	if err != nil {
+		return errors.Wrap(err, "could not export the state")
+	}
+	if err := export.SaveAs(args[0]); err != nil {
+		return err
+	}
+	logger.Info("Exported state", "chainID", export.ChainID, "height", height,
+		"appHash", export.AppHash, "file", args[0])
+	return nil
+}
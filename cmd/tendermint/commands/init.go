@@ -3,15 +3,35 @@ package commands
 import (
 	"fmt"
 
+	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
 	cfg "github.com/hdac-io/tendermint/config"
 	cmn "github.com/hdac-io/tendermint/libs/common"
 	"github.com/hdac-io/tendermint/p2p"
 	"github.com/hdac-io/tendermint/privval"
+	sm "github.com/hdac-io/tendermint/state"
 	"github.com/hdac-io/tendermint/types"
 	tmtime "github.com/hdac-io/tendermint/types/time"
 )
 
+var (
+	verifyGenesis  bool
+	initFromExport string
+	initNewChainID string
+)
+
+func init() {
+	InitFilesCmd.Flags().BoolVar(&verifyGenesis, "verify-genesis", false,
+		"Verify the genesis file against its attestation signed by the validators (see sign-genesis), "+
+			"instead of generating a genesis file")
+	InitFilesCmd.Flags().StringVar(&genesisAttestationFile, "attestation", "",
+		"Path to the genesis attestation file (defaults to genesis_attestation.json next to the genesis file)")
+	InitFilesCmd.Flags().StringVar(&initFromExport, "from-export", "",
+		"Generate the genesis file of a new chain continuing from the state exported by export-state in this file")
+	InitFilesCmd.Flags().StringVar(&initNewChainID, "chain-id", "",
+		"Chain ID of the new chain, with --from-export")
+}
+
 // InitFilesCmd initialises a fresh Tendermint Core instance.
 var InitFilesCmd = &cobra.Command{
 	Use:   "init",
@@ -20,6 +40,23 @@ var InitFilesCmd = &cobra.Command{
 }
 
 func initFiles(cmd *cobra.Command, args []string) error {
+	if initFromExport != "" {
+		if verifyGenesis {
+			return errors.New("--from-export and --verify-genesis are exclusive")
+		}
+		return initFilesFromExport(config, initFromExport, initNewChainID)
+	}
+	if !verifyGenesis {
+		return initFilesWithConfig(config)
+	}
+
+	genFile := config.GenesisFile()
+	if !cmn.FileExists(genFile) {
+		return fmt.Errorf("genesis file %s to verify does not exist", genFile)
+	}
+	if err := verifyGenesisAttestation(genFile, attestationFile(genFile)); err != nil {
+		return err
+	}
 	return initFilesWithConfig(config)
 }
 
@@ -28,12 +65,16 @@ func initFilesWithConfig(config *cfg.Config) error {
 	privValKeyFile := config.PrivValidatorKeyFile()
 	privValStateFile := config.PrivValidatorStateFile()
 	var pv types.PrivValidator
+	var pvKey privval.FilePVKey
 	if cmn.FileExists(privValKeyFile) {
 		switch config.Consensus.Module {
 		case "tendermint":
-			pv = privval.LoadFilePV(privValKeyFile, privValStateFile)
+			fpv := privval.LoadFilePV(privValKeyFile, privValStateFile)
+			pv, pvKey = fpv, fpv.Key
 		case "friday":
-			pv = privval.LoadFridayFilePV(privValKeyFile, privValStateFile)
+			// only the key is needed here and the state file may be encrypted
+			ffpv := privval.LoadFridayFilePVEmptyState(privValKeyFile, privValStateFile)
+			pv, pvKey = ffpv, ffpv.Key
 		default:
 			return fmt.Errorf("invalid consensus module %s", config.Consensus.Module)
 		}
@@ -44,11 +85,11 @@ func initFilesWithConfig(config *cfg.Config) error {
 		case "tendermint":
 			fpv := privval.GenFilePV(privValKeyFile, privValStateFile)
 			fpv.Save()
-			pv = fpv
+			pv, pvKey = fpv, fpv.Key
 		case "friday":
 			ffpv := privval.GenFridayFilePV(privValKeyFile, privValStateFile)
 			ffpv.Save()
-			pv = ffpv
+			pv, pvKey = ffpv, ffpv.Key
 		default:
 			return fmt.Errorf("invalid consensus module %s", config.Consensus.Module)
 		}
@@ -88,9 +129,10 @@ func initFilesWithConfig(config *cfg.Config) error {
 		}
 		key := pv.GetPubKey()
 		genDoc.Validators = []types.GenesisValidator{{
-			Address: key.Address(),
-			PubKey:  key,
-			Power:   10,
+			Address:           key.Address(),
+			PubKey:            key,
+			Power:             10,
+			ProofOfPossession: pvKey.ProofOfPossession(),
 		}}
 
 		if err := genDoc.SaveAs(genFile); err != nil {
@@ -101,3 +143,35 @@ func initFilesWithConfig(config *cfg.Config) error {
 
 	return nil
 }
+
+// initFilesFromExport generates the genesis file of a new chain with chainID
+// continuing from the state exported in exportFile, then initialises the node
+// like initFilesWithConfig. All the validators of the new chain make the same
+// genesis file from the same export.
+func initFilesFromExport(config *cfg.Config, exportFile, chainID string) error {
+	if chainID == "" {
+		return errors.New("--chain-id of the new chain is required with --from-export")
+	}
+	genFile := config.GenesisFile()
+	if cmn.FileExists(genFile) {
+		return fmt.Errorf("genesis file %s exists: move it away to generate the one of the new chain", genFile)
+	}
+	export, err := sm.StateExportFromFile(exportFile)
+	if err != nil {
+		return err
+	}
+	if export.ConsensusModule != config.Consensus.Module {
+		return fmt.Errorf("the exported chain runs the %s consensus, but consensus.module is %s",
+			export.ConsensusModule, config.Consensus.Module)
+	}
+	genDoc, err := export.GenesisDoc(chainID)
+	if err != nil {
+		return err
+	}
+	if err := genDoc.SaveAs(genFile); err != nil {
+		return err
+	}
+	logger.Info("Generated genesis file from the state export", "path", genFile, "chainID", chainID,
+		"fromChainID", export.ChainID, "fromHeight", export.Height, "appHash", export.AppHash)
+	return initFilesWithConfig(config)
+}
@@ -0,0 +1,175 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	fridaycs "github.com/hdac-io/tendermint/consensus/friday"
+	"github.com/hdac-io/tendermint/crypto/bls"
+	cmn "github.com/hdac-io/tendermint/libs/common"
+	nm "github.com/hdac-io/tendermint/node"
+	"github.com/hdac-io/tendermint/privval"
+	sm "github.com/hdac-io/tendermint/state"
+)
+
+var (
+	migrateChainTo     string
+	migrateChainLenULB int64
+	migrateChainHeight int64
+)
+
+func init() {
+	MigrateChainCmd.Flags().StringVar(&migrateChainTo, "to", "friday", "Consensus module to migrate the chain to (only friday)")
+	MigrateChainCmd.Flags().Int64Var(&migrateChainLenULB, "len-ulb", 0, "LenULB of the friday consensus")
+	MigrateChainCmd.Flags().Int64Var(&migrateChainHeight, "height", 0,
+		"Last height of the tendermint consensus: fails if the node stopped at another height")
+}
+
+// MigrateChainCmd converts the state, the consensus WAL and the private
+// validator of a node of a tendermint chain to run the friday consensus from
+// the next height.
+var MigrateChainCmd = &cobra.Command{
+	Use:   "migrate-chain",
+	Short: "Convert the data of a tendermint chain to run the friday consensus from the next height",
+	Long: `migrate-chain converts the data of a node of a chain run by the tendermint
+consensus for the friday consensus with --len-ulb, which runs the heights above
+the last block: the transition height. The blocks below it stay tendermint
+blocks and the genesis file is kept. The node must be stopped, with the app
+having committed the last block.
+
+All the nodes of the chain must migrate at the same height: halt the chain
+(e.g. with a halt height of the app), and pass the last height with --height
+so that the command fails on a node which stopped at another one.
+
+The state DB is converted: the consensus params get the LenULB, the validators
+of the next LenULB heights are saved, as friday delays the validator updates
+by LenULB heights, and the app hashes of the last LenULB heights, which the
+first friday blocks carry, are saved if missing. The commits of the block store
+are kept as they are. The WAL is moved to a backup directory, as the friday
+consensus can't replay the tendermint one, and the private validator files are
+converted like migrate-privval does, keeping the key of the validator set; they
+are backed up next to them.
+
+Then set consensus.module to "friday" in config.toml, and start the node.`,
+	RunE: migrateChain,
+}
+
+func migrateChain(cmd *cobra.Command, args []string) error {
+	if migrateChainTo != "friday" {
+		return fmt.Errorf("can't migrate a chain to %s: only friday is supported", migrateChainTo)
+	}
+	if migrateChainLenULB < 1 {
+		return errors.New("--len-ulb must be at least 1")
+	}
+
+	blockStore, stateDB, err := nm.InitDBs(config, nm.DefaultDBProvider, logger)
+	if err != nil {
+		return err
+	}
+	defer stateDB.Close()
+
+	lastHeight := sm.LoadState(stateDB).LastBlockHeight
+	if migrateChainHeight != 0 && lastHeight != migrateChainHeight {
+		return fmt.Errorf("the node stopped at height %d, not %d", lastHeight, migrateChainHeight)
+	}
+
+	// converted before the state, to fail before anything is saved
+	pv, err := loadPrivValToMigrate()
+	if err != nil {
+		return err
+	}
+
+	state, err := sm.MigrateToFriday(stateDB, blockStore, migrateChainLenULB)
+	if err != nil {
+		return errors.Wrap(err, "could not migrate the state")
+	}
+	logger.Info("Migrated state to friday", "lastHeight", state.LastBlockHeight,
+		"transitionHeight", state.LastBlockHeight+1, "lenULB", migrateChainLenULB)
+
+	walBackup, err := resetFridayWAL(config.Consensus.WalFile(), state.LastBlockHeight)
+	if err != nil {
+		return err
+	}
+	logger.Info("Reset consensus WAL", "walFile", config.Consensus.WalFile(), "walBackup", walBackup)
+
+	if pv != nil {
+		keyBackup, err := backupKeyFile(config.PrivValidatorKeyFile())
+		if err != nil {
+			return err
+		}
+		stateBackup, err := backupKeyFile(config.PrivValidatorStateFile())
+		if err != nil {
+			return err
+		}
+		pv.Save()
+		logger.Info("Migrated private validator state to friday", "immutableHeight", pv.SignState.ImmutableHeight,
+			"keyBackup", keyBackup, "stateBackup", stateBackup)
+		if _, ok := pv.GetPubKey().(bls.PubKeyBls); !ok {
+			logger.Error("The private validator key is not a BLS key: the friday quorum certificates " +
+				"and commit aggregates need BLS keys, rotate it with rotate-validator-key once running")
+		}
+	}
+
+	logger.Info(`Set consensus.module = "friday" in config.toml before starting the node`)
+	return nil
+}
+
+// loadPrivValToMigrate returns the private validator of the node converted to
+// a FridayFilePV, or nil if the node has no private validator files or they
+// are in the friday format already.
+func loadPrivValToMigrate() (*privval.FridayFilePV, error) {
+	keyFile := config.PrivValidatorKeyFile()
+	stateFile := config.PrivValidatorStateFile()
+	if !cmn.FileExists(keyFile) || !cmn.FileExists(stateFile) {
+		logger.Info("No private validator files to migrate", "keyFile", keyFile, "stateFile", stateFile)
+		return nil, nil
+	}
+	isFriday, err := isFridayPrivValState(stateFile)
+	if err != nil {
+		return nil, err
+	}
+	if isFriday {
+		logger.Info("Private validator state is in the friday format already", "stateFile", stateFile)
+		return nil, nil
+	}
+	secret, err := loadDiskEncryptionSecret()
+	if err != nil {
+		return nil, err
+	}
+	return toFridayPrivVal(keyFile, stateFile, secret), nil
+}
+
+// resetFridayWAL moves the directory of walFile to a backup directory, if it
+// exists, and starts a friday WAL there ending the given height, for the
+// consensus to start above it. It returns the backup directory.
+func resetFridayWAL(walFile string, height int64) (string, error) {
+	walDir := filepath.Dir(walFile)
+	var backupDir string
+	if cmn.FileExists(walDir) {
+		backupDir = fmt.Sprintf("%s.%s.bak", walDir, time.Now().UTC().Format("20060102T150405Z"))
+		if err := os.Rename(walDir, backupDir); err != nil {
+			return "", errors.Wrapf(err, "could not back up %s", walDir)
+		}
+	}
+	if err := cmn.EnsureDir(walDir, 0700); err != nil {
+		return "", err
+	}
+
+	wal, err := fridaycs.NewWAL(walFile)
+	if err != nil {
+		return "", err
+	}
+	if err := wal.Start(); err != nil {
+		return "", err
+	}
+	defer wal.Stop() // nolint: errcheck
+	if err := wal.WriteSync(fridaycs.EndHeightMessage{Height: height}); err != nil {
+		return "", errors.Wrap(err, "could not write the WAL")
+	}
+	return backupDir, nil
+}
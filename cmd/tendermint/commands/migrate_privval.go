@@ -0,0 +1,143 @@
+package commands
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"github.com/hdac-io/tendermint/crypto"
+	"github.com/hdac-io/tendermint/crypto/bls"
+	"github.com/hdac-io/tendermint/crypto/xsalsa20symmetric"
+	cmn "github.com/hdac-io/tendermint/libs/common"
+	"github.com/hdac-io/tendermint/privval"
+)
+
+var migratePrivValTo string
+
+func init() {
+	MigratePrivValCmd.Flags().StringVar(&migratePrivValTo, "to", "",
+		"Consensus module (tendermint or friday) to migrate the private validator to. Defaults to consensus.module")
+}
+
+// MigratePrivValCmd converts the private validator files of this node between
+// the tendermint (FilePV) and the friday (FridayFilePV) formats.
+var MigratePrivValCmd = &cobra.Command{
+	Use:   "migrate-privval",
+	Short: "Convert the private validator files between the tendermint and friday formats",
+	Long: `migrate-privval converts priv_validator_key_file and priv_validator_state_file
+to the format of the given consensus module, and keeps the previous files in
+backup files next to them. The node must be stopped.
+
+The converted sign state never signs at or below what was signed before:
+to friday, the heights below the last signed height become immutable and the
+last height, round and step are kept as the sign state of that height; to
+tendermint, the highest signed height, round and step are kept, or the height
+after the immutable height if nothing was signed since.
+
+The friday consensus signs with a BLS key: when migrating a key of another
+type to friday, a new BLS key is generated, which never signs at or below the
+last signed height, and its public key is printed. The application must then
+replace the previous validator key by the new one in the validator set.`,
+	RunE: migratePrivVal,
+}
+
+func migratePrivVal(cmd *cobra.Command, args []string) error {
+	keyFile := config.PrivValidatorKeyFile()
+	stateFile := config.PrivValidatorStateFile()
+	if !cmn.FileExists(keyFile) {
+		return fmt.Errorf("private validator file %s does not exist", keyFile)
+	}
+	if !cmn.FileExists(stateFile) {
+		return fmt.Errorf("private validator state file %s does not exist", stateFile)
+	}
+
+	to := migratePrivValTo
+	if to == "" {
+		to = config.Consensus.Module
+	}
+	isFriday, err := isFridayPrivValState(stateFile)
+	if err != nil {
+		return err
+	}
+	secret, err := loadDiskEncryptionSecret()
+	if err != nil {
+		return err
+	}
+
+	var (
+		pubKey crypto.PubKey
+		save   func()
+	)
+	switch {
+	case to == "friday" && !isFriday:
+		pv := toFridayPrivVal(keyFile, stateFile, secret)
+		if _, ok := pv.GetPubKey().(bls.PubKeyBls); !ok {
+			oldAddress := pv.GetAddress()
+			pv.RotateKey(bls.GenPrivKey())
+			logger.Info("Generated a BLS private validator key", "oldAddress", oldAddress, "newAddress", pv.GetAddress())
+		}
+		pubKey = pv.GetPubKey()
+		save = pv.Save
+		logger.Info("Migrated private validator state to friday", "immutableHeight", pv.SignState.ImmutableHeight)
+	case to == "tendermint" && isFriday:
+		var fpv *privval.FridayFilePV
+		if secret != nil {
+			fpv = privval.LoadEncryptedFridayFilePV(keyFile, stateFile, xsalsa20symmetric.Symmetric{}, secret)
+		} else {
+			fpv = privval.LoadFridayFilePV(keyFile, stateFile)
+		}
+		pv := fpv.ToFilePV()
+		pubKey = pv.GetPubKey()
+		save = pv.Save
+		logger.Info("Migrated private validator state to tendermint", "height", pv.LastSignState.Height,
+			"round", pv.LastSignState.Round, "step", pv.LastSignState.Step)
+	case to == "friday" || to == "tendermint":
+		return fmt.Errorf("private validator state file %s is already in the %s format", stateFile, to)
+	default:
+		return fmt.Errorf("invalid consensus module %s", to)
+	}
+
+	keyBackup, err := backupKeyFile(keyFile)
+	if err != nil {
+		return err
+	}
+	stateBackup, err := backupKeyFile(stateFile)
+	if err != nil {
+		return err
+	}
+	save()
+	logger.Info("Migrated private validator", "to", to, "keyFile", keyFile, "stateFile", stateFile,
+		"keyBackup", keyBackup, "stateBackup", stateBackup)
+
+	bz, err := cdc.MarshalJSON(pubKey)
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal private validator pubkey")
+	}
+	fmt.Println(string(bz))
+	return nil
+}
+
+// toFridayPrivVal loads the FilePV of keyFile and stateFile as a FridayFilePV,
+// encrypting its state with secret if not nil.
+func toFridayPrivVal(keyFile, stateFile string, secret []byte) *privval.FridayFilePV {
+	pv := privval.LoadFilePV(keyFile, stateFile).ToFridayFilePV()
+	if secret != nil {
+		pv.SetStateEncryption(xsalsa20symmetric.Symmetric{}, secret)
+	}
+	return pv
+}
+
+// isFridayPrivValState returns whether the private validator state file is a
+// FridayFilePV one: either encrypted, which only friday supports, or with an
+// immutable height.
+func isFridayPrivValState(stateFile string) (bool, error) {
+	bz, err := ioutil.ReadFile(stateFile)
+	if err != nil {
+		return false, err
+	}
+	return !json.Valid(bz) || bytes.Contains(bz, []byte(`"immutable_height"`)), nil
+}
@@ -2,12 +2,15 @@ package commands
 
 import (
 	"fmt"
+	"io"
 	"os"
+	"time"
 
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 
 	cfg "github.com/hdac-io/tendermint/config"
+	"github.com/hdac-io/tendermint/libs/autofile"
 	"github.com/hdac-io/tendermint/libs/cli"
 	tmflags "github.com/hdac-io/tendermint/libs/cli/flags"
 	"github.com/hdac-io/tendermint/libs/log"
@@ -16,6 +19,14 @@ import (
 var (
 	config = cfg.DefaultFridayConfig()
 	logger = log.NewTMLogger(log.NewSyncWriter(os.Stdout))
+
+	// the logger of the log format, and the one filtering it by log level,
+	// replaced when the log level is reloaded
+	formatLogger log.Logger
+	levelLogger  *log.SwitchLogger
+
+	// the log file, if the logs aren't written to the standard output
+	logFile *autofile.AutoFile
 )
 
 func init() {
@@ -42,6 +53,22 @@ func ParseConfig() (*cfg.Config, error) {
 	return conf, err
 }
 
+// setLogLevel makes the loggers log with the log level.
+func setLogLevel(logLevel string) error {
+	filtered, err := tmflags.ParseLogLevel(logLevel, formatLogger, cfg.DefaultLogLevel())
+	if err != nil {
+		return err
+	}
+	levelLogger.Set(filtered)
+	return nil
+}
+
+// rotateLogFile moves the log file to <log_file>.<time>, for the next logs to
+// be written to a new log file.
+func rotateLogFile() error {
+	return logFile.Rename(logFile.Path + "." + time.Now().UTC().Format("20060102T150405"))
+}
+
 // RootCmd is the root command for Tendermint core.
 var RootCmd = &cobra.Command{
 	Use:   "tendermint",
@@ -54,13 +81,29 @@ var RootCmd = &cobra.Command{
 		if err != nil {
 			return err
 		}
-		if config.LogFormat == cfg.LogFormatJSON {
-			logger = log.NewTMJSONLogger(log.NewSyncWriter(os.Stdout))
+		var out io.Writer = os.Stdout
+		if config.LogFile != "" {
+			logFile, err = autofile.OpenAutoFile(config.LogFilePath())
+			if err != nil {
+				return err
+			}
+			out = logFile
+		}
+		switch config.LogFormat {
+		case cfg.LogFormatPlain:
+			logger = log.NewTMLogger(log.NewSyncWriter(out))
+		case cfg.LogFormatJSON:
+			logger = log.NewTMJSONLogger(log.NewSyncWriter(out))
+		case cfg.LogFormatStructured:
+			logger = log.NewTMStructuredJSONLogger(log.NewSyncWriter(out))
 		}
-		logger, err = tmflags.ParseLogLevel(config.LogLevel, logger, cfg.DefaultLogLevel())
+		formatLogger = logger
+		filtered, err := tmflags.ParseLogLevel(config.LogLevel, formatLogger, cfg.DefaultLogLevel())
 		if err != nil {
 			return err
 		}
+		levelLogger = log.NewSwitchLogger(filtered)
+		logger = levelLogger
 		if viper.GetBool(cli.TraceFlag) {
 			logger = log.NewTracingLogger(logger)
 		}
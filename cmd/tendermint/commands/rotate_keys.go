@@ -0,0 +1,203 @@
+package commands
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"github.com/hdac-io/tendermint/crypto"
+	"github.com/hdac-io/tendermint/crypto/bls"
+	"github.com/hdac-io/tendermint/crypto/xsalsa20symmetric"
+	cmn "github.com/hdac-io/tendermint/libs/common"
+	"github.com/hdac-io/tendermint/p2p"
+	"github.com/hdac-io/tendermint/privval"
+	"github.com/hdac-io/tendermint/types"
+)
+
+var updateGenesis bool
+
+func init() {
+	RotateValidatorKeyCmd.Flags().BoolVar(&updateGenesis, "update-genesis", false,
+		"Also replace the validator key in the genesis file (only for a chain which has not started yet)")
+}
+
+// RotateNodeKeyCmd replaces the node key, which identifies the node in the
+// p2p network, by a new one. It prints the new node's ID.
+var RotateNodeKeyCmd = &cobra.Command{
+	Use:   "rotate-node-key",
+	Short: "Replace the node key of this node by a new one and print its ID",
+	Long: `rotate-node-key generates a new node key, and keeps the previous one in a
+backup file next to it. The node must be stopped.
+
+The ID of the node changes: the persistent_peers, seeds and unconditional
+peers of the other nodes which refer to it must be updated.
+The validator key is not changed.`,
+	RunE: rotateNodeKey,
+}
+
+// RotateValidatorKeyCmd replaces the private validator key by a new one,
+// keeping the sign state safe to use with it.
+var RotateValidatorKeyCmd = &cobra.Command{
+	Use:   "rotate-validator-key",
+	Short: "Replace the private validator key of this node by a new one",
+	Long: `rotate-validator-key generates a new private validator key, and keeps the
+previous key and sign state in backup files next to them. The node must be stopped.
+
+The new key never signs at or below a height signed with the previous one:
+for friday, the immutable height of the sign state is raised to the highest
+signed height; for tendermint, the last height, round and step are kept.
+
+On a running chain, the application must replace the previous validator key by
+the new one in the validator set (through EndBlock). Before the chain has
+started, --update-genesis replaces it in the genesis file instead.
+The node key is not changed.`,
+	RunE: rotateValidatorKey,
+}
+
+func rotateNodeKey(cmd *cobra.Command, args []string) error {
+	nodeKeyFile := config.NodeKeyFile()
+	if !cmn.FileExists(nodeKeyFile) {
+		return fmt.Errorf("node key at %s does not exist, use gen_node_key", nodeKeyFile)
+	}
+	oldNodeKey, err := p2p.LoadNodeKey(nodeKeyFile)
+	if err != nil {
+		return err
+	}
+
+	backupFile, err := backupKeyFile(nodeKeyFile)
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(nodeKeyFile); err != nil {
+		return err
+	}
+	nodeKey, err := p2p.LoadOrGenNodeKey(nodeKeyFile)
+	if err != nil {
+		// put the previous key back, the node can't start without one
+		if renameErr := os.Rename(backupFile, nodeKeyFile); renameErr != nil {
+			logger.Error("Could not restore the previous node key", "backup", backupFile, "err", renameErr)
+		}
+		return err
+	}
+
+	logger.Info("Rotated node key", "path", nodeKeyFile, "backup", backupFile,
+		"oldID", oldNodeKey.ID(), "newID", nodeKey.ID())
+	fmt.Println(nodeKey.ID())
+	return nil
+}
+
+func rotateValidatorKey(cmd *cobra.Command, args []string) error {
+	keyFile := config.PrivValidatorKeyFile()
+	stateFile := config.PrivValidatorStateFile()
+	if !cmn.FileExists(keyFile) {
+		return fmt.Errorf("private validator file %s does not exist", keyFile)
+	}
+
+	var (
+		oldPubKey crypto.PubKey
+		privKey   = bls.GenPrivKey()
+		save      func()
+	)
+	switch config.Consensus.Module {
+	case "tendermint":
+		pv := privval.LoadFilePV(keyFile, stateFile)
+		oldPubKey = pv.GetPubKey()
+		pv.RotateKey(privKey)
+		save = pv.Save
+	case "friday":
+		secret, err := loadDiskEncryptionSecret()
+		if err != nil {
+			return err
+		}
+		var pv *privval.FridayFilePV
+		if secret != nil {
+			pv = privval.LoadEncryptedFridayFilePV(keyFile, stateFile, xsalsa20symmetric.Symmetric{}, secret)
+		} else {
+			pv = privval.LoadFridayFilePV(keyFile, stateFile)
+		}
+		oldPubKey = pv.GetPubKey()
+		pv.RotateKey(privKey)
+		save = pv.Save
+		logger.Info("Migrated private validator state", "immutableHeight", pv.SignState.ImmutableHeight)
+	default:
+		return fmt.Errorf("invalid consensus module %s", config.Consensus.Module)
+	}
+
+	if updateGenesis {
+		if err := replaceGenesisValidatorKey(config.GenesisFile(), oldPubKey, privKey.PubKey()); err != nil {
+			return err
+		}
+		logger.Info("Replaced validator key in genesis file", "path", config.GenesisFile())
+	}
+
+	keyBackup, err := backupKeyFile(keyFile)
+	if err != nil {
+		return err
+	}
+	stateBackup, err := backupKeyFile(stateFile)
+	if err != nil {
+		return err
+	}
+	save()
+
+	logger.Info("Rotated private validator key", "keyFile", keyFile, "stateFile", stateFile,
+		"keyBackup", keyBackup, "stateBackup", stateBackup,
+		"oldAddress", oldPubKey.Address(), "newAddress", privKey.PubKey().Address())
+	bz, err := cdc.MarshalJSON(privKey.PubKey())
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal private validator pubkey")
+	}
+	fmt.Println(string(bz))
+	return nil
+}
+
+// replaceGenesisValidatorKey replaces oldPubKey by newPubKey among the
+// validators of the genesis file.
+func replaceGenesisValidatorKey(genFile string, oldPubKey, newPubKey crypto.PubKey) error {
+	genDoc, err := types.GenesisDocFromFile(genFile)
+	if err != nil {
+		return err
+	}
+	found := false
+	for i, val := range genDoc.Validators {
+		if val.PubKey.Equals(oldPubKey) {
+			genDoc.Validators[i].PubKey = newPubKey
+			genDoc.Validators[i].Address = newPubKey.Address()
+			found = true
+		}
+	}
+	if !found {
+		return fmt.Errorf("validator %v is not in the genesis file %s", oldPubKey.Address(), genFile)
+	}
+	return genDoc.SaveAs(genFile)
+}
+
+// backupKeyFile copies the file to a new file next to it, whose path is returned.
+func backupKeyFile(file string) (string, error) {
+	bz, err := ioutil.ReadFile(file)
+	if err != nil {
+		return "", err
+	}
+	backupFile := fmt.Sprintf("%s.%s.bak", file, time.Now().UTC().Format("20060102T150405Z"))
+	if err := cmn.WriteFileAtomic(backupFile, bz, 0600); err != nil {
+		return "", errors.Wrapf(err, "could not back up %s", file)
+	}
+	return backupFile, nil
+}
+
+// loadDiskEncryptionSecret loads the secret of disk_encryption_key, like the
+// node does. It returns nil if the encryption at rest is disabled.
+func loadDiskEncryptionSecret() ([]byte, error) {
+	if config.DiskEncryptionKey == "" {
+		return nil, nil
+	}
+	secret, err := xsalsa20symmetric.LoadSecret(config.DiskEncryptionKey)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not load disk_encryption_key")
+	}
+	return secret, nil
+}
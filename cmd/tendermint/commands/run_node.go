@@ -2,9 +2,14 @@ package commands
 
 import (
 	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
 
 	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
 
+	cfg "github.com/hdac-io/tendermint/config"
 	cmn "github.com/hdac-io/tendermint/libs/common"
 	nm "github.com/hdac-io/tendermint/node"
 )
@@ -17,12 +22,14 @@ func AddNodeFlags(cmd *cobra.Command) {
 
 	// priv val flags
 	cmd.Flags().String("priv_validator_laddr", config.PrivValidatorListenAddr, "Socket address to listen on for connections from external priv_validator process")
+	cmd.Flags().Int("priv_validator_threshold", config.PrivValidatorThreshold, "Number of cosigners required to sign, when priv_validator_laddr lists the addresses of cosigners holding shares of the key")
+	cmd.Flags().Bool("priv_validator_failover", config.PrivValidatorFailover, "Fail over from the primary signer to the standby ones, when priv_validator_laddr lists their addresses in order of preference")
 
 	// node flags
 	cmd.Flags().Bool("fast_sync", config.FastSyncMode, "Fast blockchain syncing")
 
 	// abci flags
-	cmd.Flags().String("proxy_app", config.ProxyApp, "Proxy app address, or one of: 'kvstore', 'persistent_kvstore', 'counter', 'counter_serial' or 'noop' for local testing.")
+	cmd.Flags().String("proxy_app", config.ProxyApp, "Proxy app address, or one of: 'kvstore', 'persistent_kvstore', 'friday_kvstore', 'counter', 'counter_serial' or 'noop' for local testing.")
 	cmd.Flags().String("abci", config.ABCI, "Specify abci transport (socket | grpc)")
 
 	// rpc flags
@@ -38,9 +45,13 @@ func AddNodeFlags(cmd *cobra.Command) {
 	cmd.Flags().Bool("p2p.pex", config.P2P.PexReactor, "Enable/disable Peer-Exchange")
 	cmd.Flags().Bool("p2p.seed_mode", config.P2P.SeedMode, "Enable/disable seed mode")
 	cmd.Flags().String("p2p.private_peer_ids", config.P2P.PrivatePeerIDs, "Comma-delimited private peer IDs")
+	cmd.Flags().String("p2p.unconditional_peer_ids", config.P2P.UnconditionalPeerIDs, "Comma-delimited unconditional peer IDs (accepted even if there are enough inbound peers)")
 
 	// consensus flags
 	cmd.Flags().Bool("consensus.create_empty_blocks", config.Consensus.CreateEmptyBlocks, "Set this to false to only produce blocks when there are txs or when the AppHash changes")
+
+	// test harness flags, never read from the config file
+	cmd.Flags().String("simulate-misbehavior", "", "Byzantine behavior produced on purpose to test a private testnet: \"double-vote@<height>\" signs conflicting votes at the height (requires a binary built with -tags misbehavior). NEVER use it on a real network")
 }
 
 // NewRunNodeCmd returns the command that allows the CLI to start a node.
@@ -50,6 +61,13 @@ func NewRunNodeCmd(nodeProvider nm.NodeProvider) *cobra.Command {
 		Use:   "node",
 		Short: "Run the tendermint node",
 		RunE: func(cmd *cobra.Command, args []string) error {
+			if misbehavior, _ := cmd.Flags().GetString("simulate-misbehavior"); misbehavior != "" {
+				config.Consensus.SimulateMisbehavior = misbehavior
+				if err := config.Consensus.ValidateBasic(); err != nil {
+					return fmt.Errorf("Error in --simulate-misbehavior: %v", err)
+				}
+			}
+
 			n, err := nodeProvider(config, logger)
 			if err != nil {
 				return fmt.Errorf("Failed to create node: %v", err)
@@ -62,16 +80,51 @@ func NewRunNodeCmd(nodeProvider nm.NodeProvider) *cobra.Command {
 				}
 			})
 
+			// Reload the config upon receiving SIGHUP.
+			n.SetConfigLoader(reloadConfigFile)
+			hup := make(chan os.Signal, 1)
+			signal.Notify(hup, syscall.SIGHUP)
+			go func() {
+				for range hup {
+					logger.Info("captured SIGHUP, reloading the config")
+					if _, _, err := n.ReloadConfig(); err != nil {
+						logger.Error("Failed to reload the config", "err", err)
+					}
+				}
+			}()
+
+			// Rotate the log file with the rotate-logs control command.
+			if logFile != nil {
+				n.SetLogRotator(rotateLogFile)
+			}
+
 			if err := n.Start(); err != nil {
 				return fmt.Errorf("Failed to start node: %v", err)
 			}
 			logger.Info("Started node", "nodeInfo", n.Switch().NodeInfo())
 
-			// Run forever.
-			select {}
+			// Run until stopped, e.g. by the stop-after-height control command.
+			<-n.Quit()
+			logger.Info("Stopped node")
+			return nil
 		},
 	}
 
 	AddNodeFlags(cmd)
 	return cmd
 }
+
+// reloadConfigFile reads the config file again, and applies its log level.
+func reloadConfigFile() (*cfg.Config, error) {
+	if err := viper.ReadInConfig(); err != nil {
+		return nil, err
+	}
+	newConfig, err := ParseConfig()
+	if err != nil {
+		return nil, err
+	}
+	if err := setLogLevel(newConfig.LogLevel); err != nil {
+		return nil, err
+	}
+	return newConfig, nil
+}
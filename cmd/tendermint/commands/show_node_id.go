@@ -8,6 +8,13 @@ import (
 	"github.com/hdac-io/tendermint/p2p"
 )
 
+var showNodePubKey bool
+
+func init() {
+	ShowNodeIDCmd.Flags().BoolVar(&showNodePubKey, "pubkey", false,
+		"Show the hex encoded (amino) public key of the node instead, for the persistent_peers_pubkeys of its peers")
+}
+
 // ShowNodeIDCmd dumps node's ID to the standard output.
 var ShowNodeIDCmd = &cobra.Command{
 	Use:   "show_node_id",
@@ -21,6 +28,10 @@ func showNodeID(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
+	if showNodePubKey {
+		fmt.Printf("%X\n", nodeKey.PubKey().Bytes())
+		return nil
+	}
 	fmt.Println(nodeKey.ID())
 	return nil
 }
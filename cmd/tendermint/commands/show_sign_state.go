@@ -0,0 +1,155 @@
+package commands
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"github.com/hdac-io/tendermint/crypto/xsalsa20symmetric"
+	cmn "github.com/hdac-io/tendermint/libs/common"
+	"github.com/hdac-io/tendermint/privval"
+)
+
+var showSignStateJSON bool
+
+func init() {
+	ShowSignStateCmd.Flags().BoolVar(&showSignStateJSON, "json", false, "Print the sign state as JSON")
+}
+
+// ShowSignStateCmd prints the friday sign state of this node's private
+// validator.
+var ShowSignStateCmd = &cobra.Command{
+	Use:   "show-sign-state",
+	Short: "Show the friday sign state of this node's private validator",
+	Long: `show-sign-state prints the sign state of priv_validator_state_file of the
+friday consensus: the immutable height, at or below which nothing can be
+signed, and the round, step and signature of each height signed above it.
+
+The private validator signs any height above the immutable height which has
+no sign state, and any higher round or step of a height which has one. After
+restoring the private validator from a backup, the validator is at risk of
+double signing if it signed higher heights, rounds or steps than the ones
+printed before the restore: keep it stopped until the network is past them.
+The problems found in the sign state are printed as warnings.
+
+The schema version of the file is printed too: the files of the first releases
+(version 0) are migrated to the current version the next time the private
+validator saves them, and a file of a newer version than this binary supports
+is refused rather than read partially.`,
+	RunE: showSignState,
+}
+
+type signStateSummary struct {
+	StateFile        string               `json:"state_file"`
+	Version          int                  `json:"version"`
+	ImmutableHeight  int64                `json:"immutable_height"`
+	LastSignedHeight int64                `json:"last_signed_height"`
+	SignStates       []heightSignStateSum `json:"sign_states"`
+	Warnings         []string             `json:"warnings,omitempty"`
+}
+
+type heightSignStateSum struct {
+	Height    int64        `json:"height"`
+	Round     int          `json:"round"`
+	Step      string       `json:"step"`
+	Signature cmn.HexBytes `json:"signature,omitempty"`
+}
+
+func showSignState(cmd *cobra.Command, args []string) error {
+	stateFile := config.PrivValidatorStateFile()
+	if !cmn.FileExists(stateFile) {
+		return fmt.Errorf("private validator state file %s does not exist", stateFile)
+	}
+	isFriday, err := isFridayPrivValState(stateFile)
+	if err != nil {
+		return err
+	}
+	if !isFriday {
+		return fmt.Errorf("private validator state file %s is not in the friday format", stateFile)
+	}
+	secret, err := loadDiskEncryptionSecret()
+	if err != nil {
+		return err
+	}
+	var ss *privval.FridayFilePVSignState
+	if secret != nil {
+		ss, err = privval.LoadFridayFilePVSignState(stateFile, xsalsa20symmetric.Symmetric{}, secret)
+	} else {
+		ss, err = privval.LoadFridayFilePVSignState(stateFile, nil, nil)
+	}
+	if err != nil {
+		return err
+	}
+
+	summary := summarizeSignState(stateFile, ss)
+	if showSignStateJSON {
+		bz, err := json.MarshalIndent(summary, "", "  ")
+		if err != nil {
+			return errors.Wrap(err, "failed to marshal the sign state")
+		}
+		fmt.Println(string(bz))
+		return nil
+	}
+
+	fmt.Printf("State file:         %s\n", summary.StateFile)
+	fmt.Printf("Schema version:     %d\n", summary.Version)
+	fmt.Printf("Immutable height:   %d\n", summary.ImmutableHeight)
+	fmt.Printf("Last signed height: %d\n", summary.LastSignedHeight)
+	if len(summary.SignStates) > 0 {
+		fmt.Printf("\n%-12s %-6s %-10s %s\n", "HEIGHT", "ROUND", "STEP", "SIGNATURE")
+		for _, s := range summary.SignStates {
+			fmt.Printf("%-12d %-6d %-10s %X\n", s.Height, s.Round, s.Step, cmn.Fingerprint(s.Signature))
+		}
+	}
+	for _, warning := range summary.Warnings {
+		fmt.Printf("WARNING: %s\n", warning)
+	}
+	return nil
+}
+
+// summarizeSignState returns the summary of the sign state, with the problems
+// which make the private validator refuse to sign, or sign what it shouldn't.
+func summarizeSignState(stateFile string, ss *privval.FridayFilePVSignState) signStateSummary {
+	summary := signStateSummary{
+		StateFile:        stateFile,
+		Version:          ss.Version(),
+		ImmutableHeight:  ss.ImmutableHeight,
+		LastSignedHeight: ss.ImmutableHeight,
+		SignStates:       []heightSignStateSum{},
+	}
+	for _, s := range ss.SignStates() {
+		summary.SignStates = append(summary.SignStates, heightSignStateSum{
+			Height:    s.Height,
+			Round:     s.Round,
+			Step:      s.StepString(),
+			Signature: s.Signature,
+		})
+		if s.Height > summary.LastSignedHeight {
+			summary.LastSignedHeight = s.Height
+		}
+
+		switch {
+		case s.Height <= ss.ImmutableHeight:
+			summary.Warnings = append(summary.Warnings, fmt.Sprintf(
+				"height %d has a sign state but is not above the immutable height", s.Height))
+		case s.SignBytes != nil && s.Signature == nil:
+			summary.Warnings = append(summary.Warnings, fmt.Sprintf(
+				"height %d has sign bytes but no signature: signing it again will panic", s.Height))
+		case s.SignBytes == nil && s.Signature != nil:
+			summary.Warnings = append(summary.Warnings, fmt.Sprintf(
+				"height %d has a signature but no sign bytes: its round and step can't be signed again", s.Height))
+		}
+	}
+	if ss.Version() < privval.FridaySignStateVersion {
+		summary.Warnings = append(summary.Warnings, fmt.Sprintf(
+			"the state file has the schema version %d: the private validator migrates it to the version %d when it saves it",
+			ss.Version(), privval.FridaySignStateVersion))
+	}
+	if summary.LastSignedHeight == 0 {
+		summary.Warnings = append(summary.Warnings,
+			"nothing was signed: if this validator signed before, it can double sign any height")
+	}
+	return summary
+}
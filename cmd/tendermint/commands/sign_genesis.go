@@ -0,0 +1,138 @@
+package commands
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"github.com/hdac-io/tendermint/crypto"
+	cmn "github.com/hdac-io/tendermint/libs/common"
+	"github.com/hdac-io/tendermint/privval"
+	"github.com/hdac-io/tendermint/types"
+)
+
+var genesisAttestationFile string
+
+func init() {
+	SignGenesisCmd.Flags().StringVar(&genesisAttestationFile, "attestation", "",
+		"Path to the genesis attestation file (defaults to genesis_attestation.json next to the genesis file)")
+}
+
+// SignGenesisCmd adds the signature of the validator of this node to the
+// attestation of the genesis file.
+var SignGenesisCmd = &cobra.Command{
+	Use:   "sign-genesis",
+	Short: "Sign the genesis file with the private validator key of this node",
+	Long: `sign-genesis adds the signature of the validator of this node over the hash of
+the genesis file to its attestation, creating the attestation if it doesn't
+exist. The validator must be in the genesis file.
+
+Once the validators holding more than 2/3 of the voting power signed it, the
+attestation is distributed with the genesis file, and each node checks it has
+the genesis file the validators signed with ` + "`tendermint init --verify-genesis`" + `.`,
+	RunE: signGenesis,
+}
+
+func signGenesis(cmd *cobra.Command, args []string) error {
+	genFile := config.GenesisFile()
+	attFile := attestationFile(genFile)
+
+	jsonBlob, genDoc, err := readGenesisFile(genFile)
+	if err != nil {
+		return err
+	}
+
+	var ga *types.GenesisAttestation
+	if cmn.FileExists(attFile) {
+		if ga, err = types.GenesisAttestationFromFile(attFile); err != nil {
+			return err
+		}
+		if !bytes.Equal(ga.GenesisHash, types.NewGenesisAttestation(jsonBlob, genDoc).GenesisHash) {
+			return fmt.Errorf("the attestation %s is about another genesis file than %s", attFile, genFile)
+		}
+	} else {
+		ga = types.NewGenesisAttestation(jsonBlob, genDoc)
+	}
+
+	privKey, err := loadPrivValidatorKey()
+	if err != nil {
+		return err
+	}
+	if err := ga.Sign(genDoc, privKey); err != nil {
+		return err
+	}
+	if err := ga.SaveAs(attFile); err != nil {
+		return err
+	}
+	logger.Info("Signed genesis file", "genesis", genFile, "attestation", attFile,
+		"hash", ga.GenesisHash, "address", privKey.PubKey().Address(), "signatures", len(ga.Signatures))
+	return nil
+}
+
+// verifyGenesisAttestation verifies the attestation of the genesis file,
+// printing the validators who signed it.
+func verifyGenesisAttestation(genFile, attFile string) error {
+	jsonBlob, genDoc, err := readGenesisFile(genFile)
+	if err != nil {
+		return err
+	}
+	ga, err := types.GenesisAttestationFromFile(attFile)
+	if err != nil {
+		return err
+	}
+
+	for _, val := range genDoc.Validators {
+		signed := "not signed"
+		if ga.Signed(val.Address) {
+			signed = "signed"
+		}
+		fmt.Printf("%v %s (power %d): %s\n", val.Address, val.Name, val.Power, signed)
+	}
+	if err := ga.Verify(jsonBlob, genDoc); err != nil {
+		return errors.Wrap(err, fmt.Sprintf("genesis file %s doesn't match its attestation %s", genFile, attFile))
+	}
+	logger.Info("Verified genesis file", "genesis", genFile, "attestation", attFile, "hash", ga.GenesisHash)
+	return nil
+}
+
+// attestationFile returns the path of the attestation of the genesis file.
+func attestationFile(genFile string) string {
+	if genesisAttestationFile != "" {
+		return genesisAttestationFile
+	}
+	return strings.TrimSuffix(genFile, ".json") + "_attestation.json"
+}
+
+func readGenesisFile(genFile string) ([]byte, *types.GenesisDoc, error) {
+	jsonBlob, err := ioutil.ReadFile(genFile)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "couldn't read genesis file")
+	}
+	genDoc, err := types.GenesisDocFromJSON(jsonBlob)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, fmt.Sprintf("error reading genesis file %s", genFile))
+	}
+	return jsonBlob, genDoc, nil
+}
+
+// loadPrivValidatorKey loads the private validator key of the node.
+func loadPrivValidatorKey() (crypto.PrivKey, error) {
+	keyFile := config.PrivValidatorKeyFile()
+	stateFile := config.PrivValidatorStateFile()
+	if !cmn.FileExists(keyFile) {
+		return nil, fmt.Errorf("private validator file %s does not exist", keyFile)
+	}
+	// only the key is needed and the state file may be encrypted
+	switch config.Consensus.Module {
+	case "tendermint":
+		return privval.LoadFilePVEmptyState(keyFile, stateFile).Key.PrivKey, nil
+	case "friday":
+		return privval.LoadFridayFilePVEmptyState(keyFile, stateFile).Key.PrivKey, nil
+	default:
+		return nil, fmt.Errorf("invalid consensus module %s", config.Consensus.Module)
+	}
+}
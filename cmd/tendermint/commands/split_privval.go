@@ -0,0 +1,108 @@
+package commands
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"github.com/hdac-io/tendermint/crypto/xsalsa20symmetric"
+	cmn "github.com/hdac-io/tendermint/libs/common"
+	"github.com/hdac-io/tendermint/privval"
+)
+
+var (
+	splitPrivValThreshold int
+	splitPrivValShares    int
+	splitPrivValOutput    string
+)
+
+func init() {
+	SplitPrivValCmd.Flags().IntVar(&splitPrivValThreshold, "threshold", 2,
+		"Number of cosigners required to sign")
+	SplitPrivValCmd.Flags().IntVar(&splitPrivValShares, "shares", 3,
+		"Number of cosigners to split the private validator key among")
+	SplitPrivValCmd.Flags().StringVar(&splitPrivValOutput, "output-dir", "",
+		"Directory to write the files of the cosigners to. Defaults to <home>/cosigners")
+}
+
+// SplitPrivValCmd splits the friday private validator key of this node among
+// cosigners, for a node signing with a ThresholdSignerClient.
+var SplitPrivValCmd = &cobra.Command{
+	Use:   "split-privval",
+	Short: "Split the friday private validator key among threshold signing cosigners",
+	Long: `split-privval splits the BLS key of priv_validator_key_file into shares, one per
+cosigner, any --threshold of which sign with the key: the validator public key
+doesn't change. The key and state files of the i-th cosigner are written to
+cosigner-<i> of the output directory, to run with priv_val_server -friday. The
+node must be stopped.
+
+The node then listens for the cosigners on the comma separated addresses of
+priv_validator_laddr, in the order of the cosigners, and signs with
+priv_validator_threshold of them. Each cosigner keeps its own sign state,
+which never signs at or below the heights signed with the key before.
+
+Anyone with --threshold shares, or with priv_validator_key_file, can sign with
+the key: move priv_validator_key_file offline once the cosigners run.`,
+	RunE: splitPrivVal,
+}
+
+func splitPrivVal(cmd *cobra.Command, args []string) error {
+	keyFile := config.PrivValidatorKeyFile()
+	stateFile := config.PrivValidatorStateFile()
+	if !cmn.FileExists(keyFile) {
+		return fmt.Errorf("private validator file %s does not exist", keyFile)
+	}
+	if !cmn.FileExists(stateFile) {
+		return fmt.Errorf("private validator state file %s does not exist", stateFile)
+	}
+	isFriday, err := isFridayPrivValState(stateFile)
+	if err != nil {
+		return err
+	}
+	if !isFriday {
+		return fmt.Errorf("private validator state file %s is not in the friday format: run migrate-privval first", stateFile)
+	}
+	secret, err := loadDiskEncryptionSecret()
+	if err != nil {
+		return err
+	}
+	var pv *privval.FridayFilePV
+	if secret != nil {
+		pv = privval.LoadEncryptedFridayFilePV(keyFile, stateFile, xsalsa20symmetric.Symmetric{}, secret)
+	} else {
+		pv = privval.LoadFridayFilePV(keyFile, stateFile)
+	}
+
+	outputDir := splitPrivValOutput
+	if outputDir == "" {
+		outputDir = filepath.Join(config.RootDir, "cosigners")
+	}
+	keyFiles := make([]string, splitPrivValShares)
+	stateFiles := make([]string, splitPrivValShares)
+	for i := range keyFiles {
+		dir := filepath.Join(outputDir, fmt.Sprintf("cosigner-%d", i+1))
+		if cmn.FileExists(dir) {
+			return fmt.Errorf("cosigner directory %s already exists", dir)
+		}
+		keyFiles[i] = filepath.Join(dir, filepath.Base(keyFile))
+		stateFiles[i] = filepath.Join(dir, filepath.Base(stateFile))
+	}
+
+	sharePVs, err := pv.SplitKey(splitPrivValThreshold, keyFiles, stateFiles)
+	if err != nil {
+		return errors.Wrap(err, "failed to split private validator key")
+	}
+	for i, sharePV := range sharePVs {
+		if err := cmn.EnsureDir(filepath.Dir(keyFiles[i]), 0700); err != nil {
+			return err
+		}
+		sharePV.Save()
+		logger.Info("Saved cosigner", "index", i+1, "keyFile", keyFiles[i], "stateFile", stateFiles[i],
+			"immutableHeight", sharePV.SignState.ImmutableHeight)
+	}
+	logger.Info("Split private validator key", "threshold", splitPrivValThreshold,
+		"shares", splitPrivValShares, "address", pv.GetAddress())
+	return nil
+}
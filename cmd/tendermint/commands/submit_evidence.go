@@ -0,0 +1,72 @@
+package commands
+
+import (
+	"fmt"
+	"io/ioutil"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	rpcclient "github.com/hdac-io/tendermint/rpc/client"
+	"github.com/hdac-io/tendermint/types"
+)
+
+var submitEvidenceNode string
+
+func init() {
+	SubmitEvidenceCmd.Flags().StringVar(&submitEvidenceNode, "node", "tcp://localhost:26657",
+		"Connect to a Tendermint node at this address")
+}
+
+// SubmitEvidenceCmd broadcasts the evidence of two conflicting votes of a
+// validator to a node.
+var SubmitEvidenceCmd = &cobra.Command{
+	Use:   "submit-evidence <vote_a.json> <vote_b.json>",
+	Short: "Submit the evidence of two conflicting votes of a validator to a node",
+	Long: `submit-evidence reads two votes signed by the same validator for the same
+height, round and type but different blocks, e.g. captured by an external
+monitor, as JSON, and broadcasts the evidence of the double sign with
+/broadcast_evidence of the node of --node.
+
+The evidence is verified before it is submitted, with the chain ID of the node
+and its validator set at the height of the votes: the votes must conflict, be
+signed by a validator of the set, and their signatures must be valid.`,
+	Args:         cobra.ExactArgs(2),
+	RunE:         submitEvidence,
+	SilenceUsage: true,
+}
+
+func submitEvidence(cmd *cobra.Command, args []string) error {
+	voteA, err := readVoteFile(args[0])
+	if err != nil {
+		return err
+	}
+	voteB, err := readVoteFile(args[1])
+	if err != nil {
+		return err
+	}
+
+	addr, err := EnsureAddrHasSchemeOrDefaultToTCP(submitEvidenceNode)
+	if err != nil {
+		return err
+	}
+	res, err := rpcclient.SubmitDuplicateVoteEvidence(rpcclient.NewHTTP(addr, "/websocket"), voteA, voteB)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("Submitted evidence %X of validator %X at height %d\n",
+		res.Hash, voteA.ValidatorAddress, voteA.Height)
+	return nil
+}
+
+func readVoteFile(file string) (*types.Vote, error) {
+	jsonBlob, err := ioutil.ReadFile(file)
+	if err != nil {
+		return nil, errors.Wrap(err, "couldn't read the vote file")
+	}
+	vote := new(types.Vote)
+	if err := cdc.UnmarshalJSON(jsonBlob, vote); err != nil {
+		return nil, errors.Wrapf(err, "couldn't parse the vote file %s", file)
+	}
+	return vote, nil
+}
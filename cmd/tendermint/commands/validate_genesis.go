@@ -0,0 +1,62 @@
+package commands
+
+import (
+	"fmt"
+	"io/ioutil"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"github.com/hdac-io/tendermint/types"
+)
+
+var genesisFile string
+
+func init() {
+	ValidateGenesisCmd.Flags().StringVar(&genesisFile, "genesis", "",
+		"Path to the genesis file to validate (defaults to the genesis_file of the config)")
+}
+
+// ValidateGenesisCmd checks a genesis file for problems that would prevent
+// the node (or the network) from starting.
+var ValidateGenesisCmd = &cobra.Command{
+	Use:   "validate-genesis",
+	Short: "Validate the genesis file against the configured consensus module",
+	Long: `validate-genesis checks the genesis file before a node attempts to start with it.
+
+In addition to the checks performed on start-up, it verifies that the consensus
+module matches the one in config.toml, that LenULB is sane for that module,
+that validator keys are valid keys of an allowed type (BLS for friday),
+that the total voting power is within bounds and that app_state is a JSON object.
+All problems found are reported at once.`,
+	RunE: validateGenesis,
+}
+
+func validateGenesis(cmd *cobra.Command, args []string) error {
+	genFile := genesisFile
+	if genFile == "" {
+		genFile = config.GenesisFile()
+	}
+
+	jsonBlob, err := ioutil.ReadFile(genFile)
+	if err != nil {
+		return errors.Wrap(err, "couldn't read genesis file")
+	}
+
+	genDoc := types.GenesisDoc{}
+	if err := cdc.UnmarshalJSON(jsonBlob, &genDoc); err != nil {
+		return errors.Wrap(err, fmt.Sprintf("genesis file %s is not a valid genesis document", genFile))
+	}
+
+	problems := genDoc.ValidateForModule(config.Consensus.Module)
+	if len(problems) == 0 {
+		fmt.Printf("Genesis file %s is valid (chain_id=%s, consensus_module=%s)\n",
+			genFile, genDoc.ChainID, genDoc.ConsensusModule)
+		return nil
+	}
+
+	for _, problem := range problems {
+		fmt.Printf("ERROR: %v\n", problem)
+	}
+	return fmt.Errorf("genesis file %s has %d problem(s)", genFile, len(problems))
+}
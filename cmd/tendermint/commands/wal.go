@@ -0,0 +1,144 @@
+package commands
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/hdac-io/tendermint/consensus/wal"
+	"github.com/hdac-io/tendermint/crypto"
+	"github.com/hdac-io/tendermint/crypto/xsalsa20symmetric"
+)
+
+var (
+	walFromHeight int64
+	walToHeight   int64
+	walOutFile    string
+	walFile       string
+)
+
+func init() {
+	walExportCmd.Flags().Int64Var(&walFromHeight, "from", 0, "First height of the records to export")
+	walExportCmd.Flags().Int64Var(&walToHeight, "to", 0, "Last height of the records to export (0 means the last one)")
+	walExportCmd.Flags().StringVar(&walOutFile, "out", "", "File to export the records to (default stdout)")
+	walImportCmd.Flags().StringVar(&walFile, "wal", "", "WAL file to create (default [consensus] wal_file)")
+	WALCmd.AddCommand(walExportCmd, walImportCmd)
+}
+
+// WALCmd converts the consensus WAL to JSON and back.
+var WALCmd = &cobra.Command{
+	Use:   "wal",
+	Short: "Export the consensus WAL to JSON and import it back",
+	Long: `"wal export" writes the records of the consensus WAL as JSON, one per line,
+and "wal import" rebuilds a WAL file from them, e.g. to repair a corrupted WAL:
+
+  WALFILE=~/.tendermint/data/cs.wal/wal
+  cp $WALFILE ${WALFILE}.bak # backup the file
+  tendermint wal export $WALFILE --out wal.json # stops at the corruption
+  rm $WALFILE # remove the corrupt file
+  tendermint wal import wal.json --wal $WALFILE # rebuild the file without corruption
+
+The WAL is read and written with the consensus module and the
+disk_encryption_key of the config. The node must be stopped.`,
+}
+
+var walExportCmd = &cobra.Command{
+	Use:   "export [wal-file]",
+	Short: "Export the records of the consensus WAL as JSON",
+	Long: `export writes the records of the consensus WAL as JSON, one per line. It reads
+all the files of [consensus] wal_file, from the oldest one, or only the given
+file.
+
+--from and --to select the records of a range of heights, and the end of the
+height below it, from which the replay of the range starts. The heights in
+progress of the friday consensus interleave their records, which are selected
+by their own height. The records after a corrupted one can't be read: export
+writes the ones before it and fails.`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if walToHeight != 0 && walFromHeight > walToHeight {
+			return fmt.Errorf("--from %d is above --to %d", walFromHeight, walToHeight)
+		}
+		sym, secret, err := walEncryption()
+		if err != nil {
+			return err
+		}
+		var r *wal.Reader
+		if len(args) == 1 {
+			f, err := os.Open(args[0])
+			if err != nil {
+				return err
+			}
+			defer f.Close()
+			r, err = wal.NewReader(config.Consensus.Module, f, sym, secret)
+			if err != nil {
+				return err
+			}
+		} else {
+			r, err = wal.Open(config.Consensus.Module, config.Consensus.WalFile(), sym, secret)
+			if err != nil {
+				return err
+			}
+			defer r.Close()
+		}
+
+		var w io.Writer = os.Stdout
+		if walOutFile != "" {
+			f, err := os.Create(walOutFile)
+			if err != nil {
+				return err
+			}
+			defer f.Close()
+			w = f
+		}
+		n, err := wal.Export(r, w, wal.Filter{FromHeight: walFromHeight, ToHeight: walToHeight})
+		if walOutFile != "" {
+			logger.Info("Exported WAL records", "file", walOutFile, "records", n)
+		}
+		return err
+	},
+}
+
+var walImportCmd = &cobra.Command{
+	Use:   "import <json-file>",
+	Short: "Rebuild a WAL file from the records exported as JSON",
+	Long: `import writes the records exported by "wal export" to a new WAL file, by
+default [consensus] wal_file, which must not exist: move the WAL aside first.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		sym, secret, err := walEncryption()
+		if err != nil {
+			return err
+		}
+		file := walFile
+		if file == "" {
+			file = config.Consensus.WalFile()
+		}
+		f, err := os.Open(args[0])
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		n, err := wal.Import(config.Consensus.Module, f, file, sym, secret)
+		if err != nil {
+			return err
+		}
+		logger.Info("Imported WAL records", "file", file, "records", n)
+		return nil
+	},
+}
+
+// walEncryption returns the encryption at rest of the WAL records, like the
+// node does: only the friday consensus encrypts them.
+func walEncryption() (crypto.Symmetric, []byte, error) {
+	if config.Consensus.Module != "friday" {
+		return nil, nil, nil
+	}
+	secret, err := loadDiskEncryptionSecret()
+	if err != nil || secret == nil {
+		return nil, nil, err
+	}
+	return xsalsa20symmetric.Symmetric{}, secret, nil
+}
@@ -23,9 +23,27 @@ func main() {
 		cmd.ResetAllCmd,
 		cmd.ResetPrivValidatorCmd,
 		cmd.ShowValidatorCmd,
+		cmd.ShowSignStateCmd,
 		cmd.TestnetFilesCmd,
 		cmd.ShowNodeIDCmd,
 		cmd.GenNodeKeyCmd,
+		cmd.RotateNodeKeyCmd,
+		cmd.RotateValidatorKeyCmd,
+		cmd.MigratePrivValCmd,
+		cmd.MigrateChainCmd,
+		cmd.SplitPrivValCmd,
+		cmd.ValidateGenesisCmd,
+		cmd.SignGenesisCmd,
+		cmd.SubmitEvidenceCmd,
+		cmd.EventsCmd,
+		cmd.WALCmd,
+		cmd.ExportBlocksCmd,
+		cmd.ImportBlocksCmd,
+		cmd.ExportStateCmd,
+		cmd.ExportAddrBookCmd,
+		cmd.ImportAddrBookCmd,
+		cmd.ControlCmd,
+		cmd.DebugCmd,
 		cmd.VersionCmd)
 
 	// NOTE:
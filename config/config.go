@@ -2,9 +2,13 @@ package config
 
 import (
 	"fmt"
+	"net"
 	"net/http"
+	"net/url"
 	"os"
 	"path/filepath"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/pkg/errors"
@@ -20,6 +24,9 @@ const (
 	LogFormatPlain = "plain"
 	// LogFormatJSON is a format for json output
 	LogFormatJSON = "json"
+	// LogFormatStructured is a format for json output with stable field names
+	// (module, height, round, step, peer)
+	LogFormatStructured = "structured"
 )
 
 // NOTE: Most of the structs & relevant comments + the
@@ -42,6 +49,8 @@ var (
 	defaultNodeKeyName  = "node_key.json"
 	defaultAddrBookName = "addrbook.json"
 
+	defaultControlAuthorizedKeysName = "control_authorized_keys"
+
 	defaultConfigFilePath   = filepath.Join(defaultConfigDir, defaultConfigFileName)
 	defaultGenesisJSONPath  = filepath.Join(defaultConfigDir, defaultGenesisJSONName)
 	defaultPrivValKeyPath   = filepath.Join(defaultConfigDir, defaultPrivValKeyName)
@@ -49,6 +58,8 @@ var (
 
 	defaultNodeKeyPath  = filepath.Join(defaultConfigDir, defaultNodeKeyName)
 	defaultAddrBookPath = filepath.Join(defaultConfigDir, defaultAddrBookName)
+
+	defaultControlAuthorizedKeysPath = filepath.Join(defaultConfigDir, defaultControlAuthorizedKeysName)
 )
 
 var (
@@ -66,9 +77,11 @@ type Config struct {
 	P2P             *P2PConfig             `mapstructure:"p2p"`
 	Mempool         *MempoolConfig         `mapstructure:"mempool"`
 	FastSync        *FastSyncConfig        `mapstructure:"fastsync"`
+	BlockStore      *BlockStoreConfig      `mapstructure:"block_store"`
 	Consensus       *ConsensusConfig       `mapstructure:"consensus"`
 	TxIndex         *TxIndexConfig         `mapstructure:"tx_index"`
 	Instrumentation *InstrumentationConfig `mapstructure:"instrumentation"`
+	Control         *ControlConfig         `mapstructure:"control"`
 }
 
 // DefaultConfig returns a default configuration for a Tendermint node
@@ -79,9 +92,11 @@ func DefaultConfig() *Config {
 		P2P:             DefaultP2PConfig(),
 		Mempool:         DefaultMempoolConfig(),
 		FastSync:        DefaultFastSyncConfig(),
+		BlockStore:      DefaultBlockStoreConfig(),
 		Consensus:       DefaultConsensusConfig(),
 		TxIndex:         DefaultTxIndexConfig(),
 		Instrumentation: DefaultInstrumentationConfig(),
+		Control:         DefaultControlConfig(),
 	}
 }
 
@@ -93,9 +108,11 @@ func DefaultFridayConfig() *Config {
 		P2P:             DefaultP2PConfig(),
 		Mempool:         DefaultMempoolConfig(),
 		FastSync:        DefaultFastSyncConfig(),
+		BlockStore:      DefaultBlockStoreConfig(),
 		Consensus:       DefaultFridayConsensusConfig(),
 		TxIndex:         DefaultTxIndexConfig(),
 		Instrumentation: DefaultInstrumentationConfig(),
+		Control:         DefaultControlConfig(),
 	}
 }
 
@@ -107,9 +124,11 @@ func TestConfig() *Config {
 		P2P:             TestP2PConfig(),
 		Mempool:         TestMempoolConfig(),
 		FastSync:        TestFastSyncConfig(),
+		BlockStore:      TestBlockStoreConfig(),
 		Consensus:       TestConsensusConfig(),
 		TxIndex:         TestTxIndexConfig(),
 		Instrumentation: TestInstrumentationConfig(),
+		Control:         TestControlConfig(),
 	}
 }
 
@@ -121,9 +140,11 @@ func TestFridayConfig() *Config {
 		P2P:             TestP2PConfig(),
 		Mempool:         TestMempoolConfig(),
 		FastSync:        TestFastSyncConfig(),
+		BlockStore:      TestBlockStoreConfig(),
 		Consensus:       TestFridayConsensusConfig(),
 		TxIndex:         TestTxIndexConfig(),
 		Instrumentation: TestInstrumentationConfig(),
+		Control:         TestControlConfig(),
 	}
 }
 
@@ -134,6 +155,7 @@ func (cfg *Config) SetRoot(root string) *Config {
 	cfg.P2P.RootDir = root
 	cfg.Mempool.RootDir = root
 	cfg.Consensus.RootDir = root
+	cfg.Control.RootDir = root
 	return cfg
 }
 
@@ -155,12 +177,22 @@ func (cfg *Config) ValidateBasic() error {
 	if err := cfg.FastSync.ValidateBasic(); err != nil {
 		return errors.Wrap(err, "Error in [fastsync] section")
 	}
+	if err := cfg.BlockStore.ValidateBasic(); err != nil {
+		return errors.Wrap(err, "Error in [block_store] section")
+	}
 	if err := cfg.Consensus.ValidateBasic(); err != nil {
 		return errors.Wrap(err, "Error in [consensus] section")
 	}
+	// the mempool never has txs available to propose
+	if cfg.Mempool.ValidatorLocal() && !cfg.Consensus.CreateEmptyBlocks {
+		return errors.New("Error in [mempool] section: the validator_local mode requires consensus.create_empty_blocks")
+	}
+	if err := cfg.Instrumentation.ValidateBasic(); err != nil {
+		return errors.Wrap(err, "Error in [instrumentation] section")
+	}
 	return errors.Wrap(
-		cfg.Instrumentation.ValidateBasic(),
-		"Error in [instrumentation] section",
+		cfg.Control.ValidateBasic(),
+		"Error in [control] section",
 	)
 }
 
@@ -205,12 +237,21 @@ type BaseConfig struct {
 	// Database directory
 	DBPath string `mapstructure:"db_dir"`
 
+	// Number of heights of which the validators, app hash and results hash
+	// are kept in memory, as they're loaded or saved. 0 disables the cache
+	StateCacheSize int `mapstructure:"state_cache_size"`
+
 	// Output level for logging
 	LogLevel string `mapstructure:"log_level"`
 
-	// Output format: 'plain' (colored text) or 'json'
+	// Output format: 'plain' (colored text), 'json' or 'structured'
 	LogFormat string `mapstructure:"log_format"`
 
+	// Path to the file to write the logs to, instead of the standard output.
+	// The file is reopened if it's moved away, e.g. by logrotate, and is
+	// rotated by the rotate-logs control command.
+	LogFile string `mapstructure:"log_file"`
+
 	// Path to the JSON file containing the initial validator set and other meta data
 	Genesis string `mapstructure:"genesis_file"`
 
@@ -220,16 +261,40 @@ type BaseConfig struct {
 	// Path to the JSON file containing the last sign state of a validator
 	PrivValidatorState string `mapstructure:"priv_validator_state_file"`
 
+	// Where to load the secret used to encrypt the consensus WAL and the
+	// priv_validator_state_file at rest from: "env:NAME" or "file:PATH".
+	// Empty disables the encryption. Only used by the friday consensus.
+	DiskEncryptionKey string `mapstructure:"disk_encryption_key"`
+
 	// TCP or UNIX socket address for Tendermint to listen on for
 	// connections from an external PrivValidator process
 	PrivValidatorListenAddr string `mapstructure:"priv_validator_laddr"`
 
+	// Number of cosigners required to sign with a key split among several
+	// external PrivValidator processes. When above 0, priv_validator_laddr is
+	// a comma separated list of the addresses to listen on for the cosigners,
+	// the i-th of which must hold the i-th share of the key.
+	PrivValidatorThreshold int `mapstructure:"priv_validator_threshold"`
+
+	// Fail over between several external PrivValidator processes holding the
+	// same key. When true, priv_validator_laddr is a comma separated list of the
+	// addresses to listen on for the primary signer, then the standby ones, in
+	// order of preference.
+	PrivValidatorFailover bool `mapstructure:"priv_validator_failover"`
+
 	// A JSON file containing the private key to use for p2p authenticated encryption
 	NodeKey string `mapstructure:"node_key_file"`
 
 	// Mechanism to connect to the ABCI application: socket | grpc
 	ABCI string `mapstructure:"abci"`
 
+	// If true, deliver each tx of a block once the txs before it that it
+	// conflicts with were answered, so that the app can run the txs without
+	// conflict keys in common (see ResponseCheckTx.ConflictKeys) concurrently.
+	// The txs are delivered to the app asynchronously, like with the friday
+	// consensus.
+	ParallelDeliverTx bool `mapstructure:"parallel_deliver_tx"`
+
 	// TCP or UNIX socket address for the profiling server to listen on
 	ProfListenAddress string `mapstructure:"prof_laddr"`
 
@@ -248,6 +313,7 @@ func DefaultBaseConfig() BaseConfig {
 		Moniker:            defaultMoniker,
 		ProxyApp:           "tcp://127.0.0.1:26658",
 		ABCI:               "socket",
+		ParallelDeliverTx:  false,
 		LogLevel:           DefaultPackageLogLevels(),
 		LogFormat:          LogFormatPlain,
 		ProfListenAddress:  "",
@@ -255,6 +321,7 @@ func DefaultBaseConfig() BaseConfig {
 		FilterPeers:        false,
 		DBBackend:          "goleveldb",
 		DBPath:             "data",
+		StateCacheSize:     256,
 	}
 }
 
@@ -298,6 +365,11 @@ func (cfg BaseConfig) NodeKeyFile() string {
 	return rootify(cfg.NodeKey, cfg.RootDir)
 }
 
+// LogFilePath returns the full path to the log file
+func (cfg BaseConfig) LogFilePath() string {
+	return rootify(cfg.LogFile, cfg.RootDir)
+}
+
 // DBDir returns the full path to the database directory
 func (cfg BaseConfig) DBDir() string {
 	return rootify(cfg.DBPath, cfg.RootDir)
@@ -307,9 +379,33 @@ func (cfg BaseConfig) DBDir() string {
 // returns an error if any check fails.
 func (cfg BaseConfig) ValidateBasic() error {
 	switch cfg.LogFormat {
-	case LogFormatPlain, LogFormatJSON:
+	case LogFormatPlain, LogFormatJSON, LogFormatStructured:
 	default:
-		return errors.New("unknown log_format (must be 'plain' or 'json')")
+		return errors.New("unknown log_format (must be 'plain', 'json' or 'structured')")
+	}
+	if cfg.DiskEncryptionKey != "" &&
+		!strings.HasPrefix(cfg.DiskEncryptionKey, "env:") &&
+		!strings.HasPrefix(cfg.DiskEncryptionKey, "file:") {
+		return errors.New("disk_encryption_key must start with 'env:' or 'file:'")
+	}
+	if cfg.StateCacheSize < 0 {
+		return errors.New("state_cache_size can't be negative")
+	}
+	if cfg.PrivValidatorThreshold < 0 {
+		return errors.New("priv_validator_threshold can't be negative")
+	}
+	if cfg.PrivValidatorThreshold > 0 && cfg.PrivValidatorListenAddr == "" {
+		return errors.New("priv_validator_threshold requires the cosigner addresses in priv_validator_laddr")
+	}
+	if cfg.PrivValidatorFailover && cfg.PrivValidatorListenAddr == "" {
+		return errors.New("priv_validator_failover requires the signer addresses in priv_validator_laddr")
+	}
+	if cfg.PrivValidatorFailover && cfg.PrivValidatorThreshold > 0 {
+		return errors.New("priv_validator_failover and priv_validator_threshold can't be used together")
+	}
+	// the node key and the validator key are rotated independently
+	if cfg.NodeKeyFile() == cfg.PrivValidatorKeyFile() {
+		return errors.New("node_key_file and priv_validator_key_file must be different files")
 	}
 	return nil
 }
@@ -348,7 +444,8 @@ type RPCConfig struct {
 	CORSAllowedHeaders []string `mapstructure:"cors_allowed_headers"`
 
 	// TCP or UNIX socket address for the gRPC server to listen on
-	// NOTE: This server only supports /broadcast_tx_commit
+	// NOTE: This server only supports /broadcast_tx_commit, and streams the
+	// finalized blocks and tx results (StreamAPI of rpc/grpc/types.proto)
 	GRPCListenAddress string `mapstructure:"grpc_laddr"`
 
 	// Maximum number of simultaneous connections.
@@ -392,6 +489,21 @@ type RPCConfig struct {
 	// Maximum size of request header, in bytes
 	MaxHeaderBytes int `mapstructure:"max_header_bytes"`
 
+	// /health reports the node unhealthy if no height was finalized for
+	// HealthFinalizeTimeout, or if a committed height has been waiting for
+	// HealthWaitFinalizeTimeout for a lower height to be finalized (friday
+	// consensus only). 0 disables the check.
+	HealthFinalizeTimeout     time.Duration `mapstructure:"health_finalize_timeout"`
+	HealthWaitFinalizeTimeout time.Duration `mapstructure:"health_wait_finalize_timeout"`
+
+	// Maximum number of results of /block, /block_results, /commit and
+	// /validators cached in memory (0 disables the cache). The results of the
+	// heights whose canonical commit was committed never change, so they are
+	// cached until evicted; the ones of the heights above for ResultCacheTTL
+	// (0 means not cached).
+	ResultCacheSize int           `mapstructure:"result_cache_size"`
+	ResultCacheTTL  time.Duration `mapstructure:"result_cache_ttl"`
+
 	// The path to a file containing certificate that is used to create the HTTPS server.
 	// Migth be either absolute path or path related to tendermint's config directory.
 	//
@@ -407,6 +519,48 @@ type RPCConfig struct {
 	//
 	// NOTE: both tls_cert_file and tls_key_file must be present for Tendermint to create HTTPS server. Otherwise, HTTP server is run.
 	TLSKeyFile string `mapstructure:"tls_key_file"`
+
+	// The path to a file containing the admin API tokens, one per line.
+	// Migth be either absolute path or path related to tendermint's config directory.
+	//
+	// If admin_tokens_file or tls_client_ca_file is set, the clients are
+	// authenticated: only the admin clients can call the unsafe endpoints
+	// (dial_seeds, dial_peers, ban_peer, unban_peer, crawl_peers, reload_config,
+	// unsafe_*) and admin_endpoints, and the other clients (public role) can call
+	// all the other endpoints. A client is admin if it sends one of the tokens in the
+	// "Authorization: Bearer <token>" header.
+	// Otherwise, all the clients can call all the endpoints.
+	AdminTokensFile string `mapstructure:"admin_tokens_file"`
+
+	// The path to a file containing the certificate authorities verifying the
+	// TLS client certificates. A client with a verified certificate is admin.
+	// Migth be either absolute path or path related to tendermint's config directory.
+	//
+	// NOTE: requires the HTTPS server (tls_cert_file and tls_key_file).
+	TLSClientCAFile string `mapstructure:"tls_client_ca_file"`
+
+	// Endpoints only the admin clients can call, in addition to the unsafe ones,
+	// if the clients are authenticated (see admin_tokens_file).
+	AdminEndpoints []string `mapstructure:"admin_endpoints"`
+
+	// Maximum number of HTTP requests per second from a remote IP, including
+	// the websocket connection requests (0 - unlimited). The requests above
+	// the rate, once the burst is spent, get a 429 Too Many Requests response.
+	// The admin clients are not limited.
+	RateLimit float64 `mapstructure:"rate_limit"`
+
+	// Maximum number of calls per second on a websocket connection
+	// (0 - unlimited). The calls above the rate get an error response.
+	WSRateLimit float64 `mapstructure:"ws_rate_limit"`
+
+	// Maximum number of subscription events per second sent on a websocket
+	// connection (0 - unlimited). The events above the rate are dropped, like
+	// the ones a slow client doesn't read.
+	WSMaxEventsPerSecond float64 `mapstructure:"ws_max_events_per_second"`
+
+	// Number of requests, calls or events allowed at once above rate_limit,
+	// ws_rate_limit and ws_max_events_per_second.
+	RateLimitBurst int `mapstructure:"rate_limit_burst"`
 }
 
 // DefaultRPCConfig returns a default configuration for the RPC server
@@ -429,8 +583,23 @@ func DefaultRPCConfig() *RPCConfig {
 		MaxBodyBytes:   int64(1000000), // 1MB
 		MaxHeaderBytes: 1 << 20,        // same as the net/http default
 
+		HealthFinalizeTimeout:     60 * time.Second,
+		HealthWaitFinalizeTimeout: 30 * time.Second,
+
+		ResultCacheSize: 256,
+		ResultCacheTTL:  1 * time.Second,
+
 		TLSCertFile: "",
 		TLSKeyFile:  "",
+
+		AdminTokensFile: "",
+		TLSClientCAFile: "",
+		AdminEndpoints:  []string{},
+
+		RateLimit:            0,
+		WSRateLimit:          0,
+		WSMaxEventsPerSecond: 0,
+		RateLimitBurst:       100,
 	}
 }
 
@@ -467,6 +636,36 @@ func (cfg *RPCConfig) ValidateBasic() error {
 	if cfg.MaxHeaderBytes < 0 {
 		return errors.New("max_header_bytes can't be negative")
 	}
+	if cfg.HealthFinalizeTimeout < 0 {
+		return errors.New("health_finalize_timeout can't be negative")
+	}
+	if cfg.HealthWaitFinalizeTimeout < 0 {
+		return errors.New("health_wait_finalize_timeout can't be negative")
+	}
+	if cfg.ResultCacheSize < 0 {
+		return errors.New("result_cache_size can't be negative")
+	}
+	if cfg.ResultCacheTTL < 0 {
+		return errors.New("result_cache_ttl can't be negative")
+	}
+	if cfg.TLSClientCAFile != "" && !cfg.IsTLSEnabled() {
+		return errors.New("tls_client_ca_file requires tls_cert_file and tls_key_file")
+	}
+	if len(cfg.AdminEndpoints) > 0 && !cfg.IsAuthEnabled() {
+		return errors.New("admin_endpoints requires admin_tokens_file or tls_client_ca_file")
+	}
+	if cfg.RateLimit < 0 {
+		return errors.New("rate_limit can't be negative")
+	}
+	if cfg.WSRateLimit < 0 {
+		return errors.New("ws_rate_limit can't be negative")
+	}
+	if cfg.WSMaxEventsPerSecond < 0 {
+		return errors.New("ws_max_events_per_second can't be negative")
+	}
+	if cfg.RateLimitBurst < 1 && cfg.IsRateLimitEnabled() {
+		return errors.New("rate_limit_burst must be positive")
+	}
 	return nil
 }
 
@@ -495,6 +694,34 @@ func (cfg RPCConfig) IsTLSEnabled() bool {
 	return cfg.TLSCertFile != "" && cfg.TLSKeyFile != ""
 }
 
+func (cfg RPCConfig) AdminTokensFilePath() string {
+	path := cfg.AdminTokensFile
+	if filepath.IsAbs(path) {
+		return path
+	}
+	return rootify(filepath.Join(defaultConfigDir, path), cfg.RootDir)
+}
+
+func (cfg RPCConfig) ClientCAFile() string {
+	path := cfg.TLSClientCAFile
+	if filepath.IsAbs(path) {
+		return path
+	}
+	return rootify(filepath.Join(defaultConfigDir, path), cfg.RootDir)
+}
+
+// IsAuthEnabled returns true if the RPC clients are authenticated, and only the
+// admin ones can call the unsafe endpoints and the admin endpoints.
+func (cfg RPCConfig) IsAuthEnabled() bool {
+	return cfg.AdminTokensFile != "" || cfg.TLSClientCAFile != ""
+}
+
+// IsRateLimitEnabled returns true if the requests of a remote IP, or the calls
+// or events of a websocket connection are rate limited.
+func (cfg RPCConfig) IsRateLimitEnabled() bool {
+	return cfg.RateLimit > 0 || cfg.WSRateLimit > 0 || cfg.WSMaxEventsPerSecond > 0
+}
+
 //-----------------------------------------------------------------------------
 // P2PConfig
 
@@ -515,7 +742,38 @@ type P2PConfig struct {
 	// Comma separated list of nodes to keep persistent connections to
 	PersistentPeers string `mapstructure:"persistent_peers"`
 
-	// UPNP port forwarding
+	// Redialing of the disconnected persistent peers: first ReconnectAttempts
+	// dials every ReconnectInterval, then ReconnectBackoffAttempts dials with
+	// an exponential backoff of ReconnectBackoffFactor^n seconds (at most
+	// ReconnectMaxBackoff if not zero), after which the peer is given up.
+	// Every wait is lengthened by a random duration of up to ReconnectJitter.
+	ReconnectAttempts        int           `mapstructure:"reconnect_attempts"`
+	ReconnectInterval        time.Duration `mapstructure:"reconnect_interval"`
+	ReconnectBackoffAttempts int           `mapstructure:"reconnect_backoff_attempts"`
+	ReconnectBackoffFactor   float64       `mapstructure:"reconnect_backoff_factor"`
+	ReconnectMaxBackoff      time.Duration `mapstructure:"reconnect_max_backoff"`
+	ReconnectJitter          time.Duration `mapstructure:"reconnect_jitter"`
+
+	// Comma separated list of peer IDs which are accepted even if the node
+	// already has MaxNumInboundPeers inbound peers
+	UnconditionalPeerIDs string `mapstructure:"unconditional_peer_ids"`
+
+	// Comma separated list of the hex encoded (amino) public keys of
+	// persistent peers (see show_node_id --pubkey), pinned: the connections of
+	// a peer authenticating with another key are refused. Each key must be the
+	// one of the ID of a persistent peer, so a mistyped ID fails on start.
+	// The ID of a peer is the hash of its key, so the expected ID of
+	// persistent_peers already commits to the key: the pinned key is a check
+	// of that ID against the key of the peer, taken out of band.
+	PersistentPeersPubKeys string `mapstructure:"persistent_peers_pubkeys"`
+
+	// Only connect to the persistent peers and the unconditional peers,
+	// inbound or outbound, refusing the other ones (e.g. for a validator only
+	// connected to its sentries)
+	AllowListOnly bool `mapstructure:"allow_list_only"`
+
+	// UPNP port forwarding: map the p2p port on the NAT gateway with UPnP or
+	// NAT-PMP, unless ExternalAddress is set
 	UPNP bool `mapstructure:"upnp"`
 
 	// Path to address book
@@ -543,6 +801,28 @@ type P2PConfig struct {
 	// Rate at which packets can be received, in bytes/second
 	RecvRate int64 `mapstructure:"recv_rate"`
 
+	// Rates at which the packets of the channels of the reactors can be sent
+	// and received on each connection, in bytes/second, so a reactor can't
+	// starve the others (0 for no limit besides send_rate and recv_rate)
+	ConsensusSendRate  int64 `mapstructure:"consensus_send_rate"`
+	ConsensusRecvRate  int64 `mapstructure:"consensus_recv_rate"`
+	BlockchainSendRate int64 `mapstructure:"blockchain_send_rate"`
+	BlockchainRecvRate int64 `mapstructure:"blockchain_recv_rate"`
+	MempoolSendRate    int64 `mapstructure:"mempool_send_rate"`
+	MempoolRecvRate    int64 `mapstructure:"mempool_recv_rate"`
+	EvidenceSendRate   int64 `mapstructure:"evidence_send_rate"`
+	EvidenceRecvRate   int64 `mapstructure:"evidence_recv_rate"`
+
+	// Rotate the keys of the secret connections after the given number of
+	// bytes or time, whichever comes first (0 to never rotate them). The peers
+	// must support the rotation, the older ones drop the connection.
+	SecretConnRekeyBytes    int64         `mapstructure:"secret_conn_rekey_bytes"`
+	SecretConnRekeyInterval time.Duration `mapstructure:"secret_conn_rekey_interval"`
+
+	// Log the key exchange and ciphers of the secret connections, and the
+	// rotations of their keys
+	SecretConnAudit bool `mapstructure:"secret_conn_audit"`
+
 	// Set true to enable the peer-exchange reactor
 	PexReactor bool `mapstructure:"pex"`
 
@@ -559,6 +839,10 @@ type P2PConfig struct {
 	// Toggle to disable guard against peers connecting from the same ip.
 	AllowDuplicateIP bool `mapstructure:"allow_duplicate_ip"`
 
+	// Encode the reactor messages with protobuf for the peers supporting it,
+	// amino is used with the other ones
+	ProtoMsgs bool `mapstructure:"proto_msgs"`
+
 	// Peer connection configuration.
 	HandshakeTimeout time.Duration `mapstructure:"handshake_timeout"`
 	DialTimeout      time.Duration `mapstructure:"dial_timeout"`
@@ -574,25 +858,32 @@ type P2PConfig struct {
 // DefaultP2PConfig returns a default configuration for the peer-to-peer layer
 func DefaultP2PConfig() *P2PConfig {
 	return &P2PConfig{
-		ListenAddress:           "tcp://0.0.0.0:26656",
-		ExternalAddress:         "",
-		UPNP:                    false,
-		AddrBook:                defaultAddrBookPath,
-		AddrBookStrict:          true,
-		MaxNumInboundPeers:      40,
-		MaxNumOutboundPeers:     10,
-		FlushThrottleTimeout:    100 * time.Millisecond,
-		MaxPacketMsgPayloadSize: 1024,    // 1 kB
-		SendRate:                5120000, // 5 mB/s
-		RecvRate:                5120000, // 5 mB/s
-		PexReactor:              true,
-		SeedMode:                false,
-		AllowDuplicateIP:        false,
-		HandshakeTimeout:        20 * time.Second,
-		DialTimeout:             3 * time.Second,
-		TestDialFail:            false,
-		TestFuzz:                false,
-		TestFuzzConfig:          DefaultFuzzConnConfig(),
+		ListenAddress:            "tcp://0.0.0.0:26656",
+		ExternalAddress:          "",
+		ReconnectAttempts:        20,
+		ReconnectInterval:        5 * time.Second,
+		ReconnectBackoffAttempts: 10, // 3**10 seconds = 16hrs
+		ReconnectBackoffFactor:   3,
+		ReconnectMaxBackoff:      0,
+		ReconnectJitter:          3 * time.Second,
+		UPNP:                     false,
+		AddrBook:                 defaultAddrBookPath,
+		AddrBookStrict:           true,
+		MaxNumInboundPeers:       40,
+		MaxNumOutboundPeers:      10,
+		FlushThrottleTimeout:     100 * time.Millisecond,
+		MaxPacketMsgPayloadSize:  1024,    // 1 kB
+		SendRate:                 5120000, // 5 mB/s
+		RecvRate:                 5120000, // 5 mB/s
+		PexReactor:               true,
+		SeedMode:                 false,
+		AllowDuplicateIP:         false,
+		ProtoMsgs:                true,
+		HandshakeTimeout:         20 * time.Second,
+		DialTimeout:              3 * time.Second,
+		TestDialFail:             false,
+		TestFuzz:                 false,
+		TestFuzzConfig:           DefaultFuzzConnConfig(),
 	}
 }
 
@@ -619,6 +910,24 @@ func (cfg *P2PConfig) ValidateBasic() error {
 	if cfg.MaxNumOutboundPeers < 0 {
 		return errors.New("max_num_outbound_peers can't be negative")
 	}
+	if cfg.ReconnectAttempts < 0 {
+		return errors.New("reconnect_attempts can't be negative")
+	}
+	if cfg.ReconnectInterval < 0 {
+		return errors.New("reconnect_interval can't be negative")
+	}
+	if cfg.ReconnectBackoffAttempts < 0 {
+		return errors.New("reconnect_backoff_attempts can't be negative")
+	}
+	if cfg.ReconnectBackoffFactor < 1 {
+		return errors.New("reconnect_backoff_factor can't be less than 1")
+	}
+	if cfg.ReconnectMaxBackoff < 0 {
+		return errors.New("reconnect_max_backoff can't be negative")
+	}
+	if cfg.ReconnectJitter < 0 {
+		return errors.New("reconnect_jitter can't be negative")
+	}
 	if cfg.FlushThrottleTimeout < 0 {
 		return errors.New("flush_throttle_timeout can't be negative")
 	}
@@ -631,6 +940,26 @@ func (cfg *P2PConfig) ValidateBasic() error {
 	if cfg.RecvRate < 0 {
 		return errors.New("recv_rate can't be negative")
 	}
+	for name, rate := range map[string]int64{
+		"consensus_send_rate":  cfg.ConsensusSendRate,
+		"consensus_recv_rate":  cfg.ConsensusRecvRate,
+		"blockchain_send_rate": cfg.BlockchainSendRate,
+		"blockchain_recv_rate": cfg.BlockchainRecvRate,
+		"mempool_send_rate":    cfg.MempoolSendRate,
+		"mempool_recv_rate":    cfg.MempoolRecvRate,
+		"evidence_send_rate":   cfg.EvidenceSendRate,
+		"evidence_recv_rate":   cfg.EvidenceRecvRate,
+	} {
+		if rate < 0 {
+			return fmt.Errorf("%s can't be negative", name)
+		}
+	}
+	if cfg.SecretConnRekeyBytes < 0 {
+		return errors.New("secret_conn_rekey_bytes can't be negative")
+	}
+	if cfg.SecretConnRekeyInterval < 0 {
+		return errors.New("secret_conn_rekey_interval can't be negative")
+	}
 	return nil
 }
 
@@ -667,6 +996,48 @@ type MempoolConfig struct {
 	MaxTxsBytes int64  `mapstructure:"max_txs_bytes"`
 	CacheSize   int    `mapstructure:"cache_size"`
 	MaxTxBytes  int    `mapstructure:"max_tx_bytes"`
+
+	// Gossip the txs in two phases to the peers supporting it: advertise the
+	// hashes of the txs, and send the txs only to the peers requesting them.
+	BroadcastTxHashes bool `mapstructure:"broadcast_tx_hashes"`
+
+	// Release the txs of a sender, set by the app in CheckTx, in nonce order,
+	// holding back the txs after a nonce gap for at most MaxNonceGapBlocks
+	// blocks.
+	SenderNonceOrder  bool  `mapstructure:"sender_nonce_order"`
+	MaxNonceGapBlocks int64 `mapstructure:"max_nonce_gap_blocks"`
+
+	// Txs to evict when the mempool is full to add a new tx:
+	//   1) "none" (default) - the new tx is rejected
+	//   2) "lowest_priority" - the txs of the lowest priority, set by the app in
+	//   CheckTx, below the priority of the new tx
+	//   3) "oldest" - the txs added first
+	//   4) "sender_budget" - the last txs of the sender with the most txs above
+	//   SenderBudget txs
+	// The txs reserved by a proposal block are never evicted.
+	EvictionPolicy string `mapstructure:"eviction_policy"`
+	SenderBudget   int    `mapstructure:"sender_budget"`
+
+	// Number of blocks after which a tx not committed yet expires and is
+	// removed from the mempool, 0 for the txs to never expire.
+	TTLNumBlocks int64 `mapstructure:"ttl_num_blocks"`
+
+	// Mode of the mempool:
+	//   1) "full" (default) - the txs are accepted from the RPC and the peers,
+	//   checked and gossiped
+	//   2) "validator_local" - for the validators behind entry nodes: no tx is
+	//   accepted nor gossiped, the txs of the blocks come from the proposals,
+	//   and the txs of the node's proposals are pulled from the peers of
+	//   PullTxsPeerIDs every PullTxsInterval, waiting at most PullTxsTimeout
+	//   for them, and checked with CheckTx
+	Mode           string        `mapstructure:"mode"`
+	PullTxsTimeout time.Duration `mapstructure:"pull_txs_timeout"`
+
+	// Comma separated IDs of the peers the validator_local mode pulls txs
+	// from, or, in the full mode, of the validator_local peers allowed to pull
+	// txs from the node, at most one pull per half PullTxsInterval
+	PullTxsPeerIDs  string        `mapstructure:"pull_txs_peer_ids"`
+	PullTxsInterval time.Duration `mapstructure:"pull_txs_interval"`
 }
 
 // DefaultMempoolConfig returns a default configuration for the Tendermint mempool
@@ -681,6 +1052,20 @@ func DefaultMempoolConfig() *MempoolConfig {
 		MaxTxsBytes: 1024 * 1024 * 1024, // 1GB
 		CacheSize:   10000,
 		MaxTxBytes:  1024 * 1024, // 1MB
+
+		BroadcastTxHashes: true,
+
+		SenderNonceOrder:  false,
+		MaxNonceGapBlocks: 10,
+
+		EvictionPolicy: "none",
+		SenderBudget:   100,
+		TTLNumBlocks:   0,
+
+		Mode:            "full",
+		PullTxsTimeout:  300 * time.Millisecond,
+		PullTxsPeerIDs:  "",
+		PullTxsInterval: 1 * time.Second,
 	}
 }
 
@@ -701,6 +1086,12 @@ func (cfg *MempoolConfig) WalEnabled() bool {
 	return cfg.WalPath != ""
 }
 
+// ValidatorLocal returns true if the node accepts no tx and pulls the txs of
+// its proposals from the peers.
+func (cfg *MempoolConfig) ValidatorLocal() bool {
+	return cfg.Mode == "validator_local"
+}
+
 // ValidateBasic performs basic validation (checking param bounds, etc.) and
 // returns an error if any check fails.
 func (cfg *MempoolConfig) ValidateBasic() error {
@@ -716,6 +1107,32 @@ func (cfg *MempoolConfig) ValidateBasic() error {
 	if cfg.MaxTxBytes < 0 {
 		return errors.New("max_tx_bytes can't be negative")
 	}
+	if cfg.MaxNonceGapBlocks < 0 {
+		return errors.New("max_nonce_gap_blocks can't be negative")
+	}
+	switch cfg.EvictionPolicy {
+	case "none", "lowest_priority", "oldest":
+	case "sender_budget":
+		if cfg.SenderBudget <= 0 {
+			return errors.New("sender_budget must be positive")
+		}
+	default:
+		return fmt.Errorf("unknown eviction_policy %s", cfg.EvictionPolicy)
+	}
+	if cfg.TTLNumBlocks < 0 {
+		return errors.New("ttl_num_blocks can't be negative")
+	}
+	switch cfg.Mode {
+	case "full", "validator_local":
+	default:
+		return fmt.Errorf("unknown mode %s", cfg.Mode)
+	}
+	if cfg.PullTxsTimeout < 0 {
+		return errors.New("pull_txs_timeout can't be negative")
+	}
+	if cfg.PullTxsInterval <= 0 {
+		return errors.New("pull_txs_interval must be positive")
+	}
 
 	return nil
 }
@@ -725,9 +1142,20 @@ func (cfg *MempoolConfig) ValidateBasic() error {
 
 // FastSyncConfig defines the configuration for the Tendermint fast sync service
 type FastSyncConfig struct {
+	// Fast Sync version to use:
+	//   1) "v0" (default) - the legacy fast sync implementation
+	//   2) "v1" - refactor of v0 version for better testability
+	//   3) "headers" - only the headers, commits and validator sets are synced
+	//   from the peers running v0, without the block bodies, and served by the
+	//   /header RPC. The node never executes the blocks nor switches to consensus.
 	Version string `mapstructure:"version"`
 }
 
+// HeadersOnly returns true if the node only syncs the headers.
+func (cfg *FastSyncConfig) HeadersOnly() bool {
+	return cfg.Version == "headers"
+}
+
 // DefaultFastSyncConfig returns a default configuration for the fast sync service
 func DefaultFastSyncConfig() *FastSyncConfig {
 	return &FastSyncConfig{
@@ -746,6 +1174,7 @@ func (cfg *FastSyncConfig) ValidateBasic() error {
 	switch cfg.Version {
 	case "v0":
 	case "v1":
+	case "headers":
 	default:
 		err = fmt.Errorf("unknown fastsync version %s", cfg.Version)
 	}
@@ -753,6 +1182,65 @@ func (cfg *FastSyncConfig) ValidateBasic() error {
 	return err
 }
 
+//-----------------------------------------------------------------------------
+// BlockStoreConfig
+
+// BlockStoreConfig defines where the blocks are stored
+type BlockStoreConfig struct {
+	// Backend to store the blocks in:
+	//   1) "local" (default) - all the blocks are kept in the local DB
+	//   2) "s3" - only the recent blocks are kept in the local DB, the older
+	//   ones are moved to an S3 compatible object storage and fetched when needed
+	Backend string `mapstructure:"backend"`
+
+	// Number of heights kept in the local DB on top of the friday ULB window
+	KeepRecent int64 `mapstructure:"keep_recent"`
+
+	// S3 compatible object storage. The credentials are read from the
+	// AWS_ACCESS_KEY_ID and AWS_SECRET_ACCESS_KEY environment variables.
+	S3Endpoint string `mapstructure:"s3_endpoint"`
+	S3Region   string `mapstructure:"s3_region"`
+	S3Bucket   string `mapstructure:"s3_bucket"`
+	S3Prefix   string `mapstructure:"s3_prefix"`
+}
+
+// DefaultBlockStoreConfig returns a default configuration for the block store
+func DefaultBlockStoreConfig() *BlockStoreConfig {
+	return &BlockStoreConfig{
+		Backend:    "local",
+		KeepRecent: 10000,
+		S3Endpoint: "https://s3.amazonaws.com",
+		S3Region:   "us-east-1",
+	}
+}
+
+// TestBlockStoreConfig returns a configuration for testing the block store
+func TestBlockStoreConfig() *BlockStoreConfig {
+	return DefaultBlockStoreConfig()
+}
+
+// ValidateBasic performs basic validation.
+func (cfg *BlockStoreConfig) ValidateBasic() error {
+	switch cfg.Backend {
+	case "local":
+		return nil
+	case "s3":
+	default:
+		return fmt.Errorf("unknown block store backend %s", cfg.Backend)
+	}
+
+	if cfg.KeepRecent < 0 {
+		return errors.New("keep_recent can't be negative")
+	}
+	if cfg.S3Endpoint == "" {
+		return errors.New("s3_endpoint is required by the s3 backend")
+	}
+	if cfg.S3Bucket == "" {
+		return errors.New("s3_bucket is required by the s3 backend")
+	}
+	return nil
+}
+
 //-----------------------------------------------------------------------------
 // ConsensusConfig
 
@@ -775,13 +1263,127 @@ type ConsensusConfig struct {
 	TimeoutPreviousFailure      time.Duration `mapstructure:"timeout_previous_failure"`
 	TimeoutPreviousFailureDelta time.Duration `mapstructure:"timeout_previous_failure_delta"`
 
+	// Maximum time the proposer spends preparing the txs of a proposal block:
+	// reaping them from the mempool (or pulling them from the peers) and
+	// handing them to the ProposalTxsPreparer of the app, if any. It is capped
+	// by the propose timeout of the round (0 means the propose timeout).
+	TimeoutPrepareProposal time.Duration `mapstructure:"timeout_prepare_proposal"`
+
+	// Maximum time to wait for the PrivValidator to sign a vote (0 means wait forever).
+	// Only used by the friday consensus.
+	TimeoutSignVote time.Duration `mapstructure:"timeout_sign_vote"`
+
+	// Maximum offset of the local clock from the median of the vote times of
+	// the validators before warning about it (0 means never warn).
+	// Only used by the friday consensus.
+	MaxClockSkew time.Duration `mapstructure:"max_clock_skew"`
+
+	// Directory where a diagnostic bundle is written when the consensus fails
+	// (empty means only keep the last one in memory), and how many times the
+	// consensus is restarted after a failure before halting, waiting
+	// FailureRestartBackoff, doubled on every restart, in between.
+	// Only used by the friday consensus.
+	FailureDumpPath       string        `mapstructure:"failure_dump_dir"`
+	FailureRestarts       int           `mapstructure:"failure_restarts"`
+	FailureRestartBackoff time.Duration `mapstructure:"failure_restart_backoff"`
+
+	// Maximum number of heights progressing at the same time (0 means
+	// LenULB, and it can't exceed LenULB). Only used by the friday consensus.
+	MaxPipelineDepth int `mapstructure:"max_pipeline_depth"`
+
+	// Every CheckpointInterval, the RoundStates of the heights in progress are
+	// written to CheckpointPath, with the votes if CheckpointVotes (else only
+	// the votes of the node), so a restart resumes them from the checkpoint
+	// and the WAL records after it, instead of replaying the WAL of all the
+	// heights in progress. 0 disables the checkpoints. Only used by the
+	// friday consensus.
+	CheckpointPath     string        `mapstructure:"checkpoint_file"`
+	CheckpointInterval time.Duration `mapstructure:"checkpoint_interval"`
+	CheckpointVotes    bool          `mapstructure:"checkpoint_votes"`
+
+	// Every FinalizeWaitTimeout a committed height waits for a lower height
+	// to be finalized, the stall is reported (metric, FinalizeStall event and
+	// RoundState of the lower height in the log), and if FinalizeWaitRerequest
+	// the block parts the lower height misses are requested again from the
+	// peers. 0 disables the watchdog. Only used by the friday consensus.
+	FinalizeWaitTimeout   time.Duration `mapstructure:"finalize_wait_timeout"`
+	FinalizeWaitRerequest bool          `mapstructure:"finalize_wait_rerequest"`
+
+	// Every InvariantCheckInterval, the invariants of the consensus state
+	// (e.g. the block store has the blocks up to the last block height of the
+	// state) are checked in the background, and the violations found by two
+	// consecutive checks are reported (metric, InvariantViolation event and
+	// log). 0 disables the checks. Only used by the friday consensus.
+	InvariantCheckInterval time.Duration `mapstructure:"invariant_check_interval"`
+
+	// When the height reached by more than a third of the peers is more than
+	// FastSyncReentryLag heights above the height in progress of the node, the
+	// consensus is paused and the node fast syncs again up to the peers before
+	// resuming it. 0 disables the re-entry. Only used by the friday consensus,
+	// with the fastsync version "v0".
+	FastSyncReentryLag int64 `mapstructure:"fast_sync_reentry_lag"`
+
+	// The events published at a height on the EventBus (the consensus events,
+	// and the block and tx events) are recorded to EventLogPath, rotated like
+	// the WAL, to export and replay the ones of a range of heights with
+	// `tendermint events`. Empty disables the recording.
+	EventLogPath string `mapstructure:"event_log_file"`
+
+	// In standby, the validator signs nothing until its address enters the
+	// validator set, after a height without it. It then asks the peers for the
+	// highest height they saw signed with its key (the sign watermark), waits
+	// StandbyWatermarkTimeout for their answers, and only signs the heights
+	// above it, unless another node signed with its key since it entered, in
+	// which case it stays in standby. Only used by the friday consensus.
+	Standby                 bool          `mapstructure:"standby"`
+	StandbyWatermarkTimeout time.Duration `mapstructure:"standby_watermark_timeout"`
+
 	// Make progress as soon as we have all the precommits (as if TimeoutCommit = 0)
 	SkipTimeoutCommit bool `mapstructure:"skip_timeout_commit"`
+	// Skip the timeout commit of the heights committed with all the
+	// precommits only, starting the next height of the ULB window without
+	// waiting. Only used by the friday consensus.
+	OptimisticCommit bool `mapstructure:"optimistic_commit"`
 
 	// EmptyBlocks mode and possible interval between empty blocks
 	CreateEmptyBlocks         bool          `mapstructure:"create_empty_blocks"`
 	CreateEmptyBlocksInterval time.Duration `mapstructure:"create_empty_blocks_interval"`
 
+	// Maximum number of heights above the last block height creating empty
+	// blocks (0 means all of them): the proposers of the higher heights wait
+	// for them to get within CreateEmptyBlocksMaxDepth before proposing.
+	// Only used by the friday consensus.
+	CreateEmptyBlocksMaxDepth int `mapstructure:"create_empty_blocks_max_depth"`
+
+	// Number of Reed-Solomon parity parts per 100 proposal block parts gossiped
+	// to the peers missing block parts, so that the proposal is rebuilt from
+	// any of its parts and parity parts as many as its parts (0 disables them).
+	// Only the peers enabling them too get them. Only used by the friday
+	// consensus.
+	BlockPartsParity int `mapstructure:"block_parts_parity"`
+
+	// The proposer of the next round aggregates the BLS signatures of the +2/3
+	// precommits for the block of a round into one CommitAggregate message,
+	// which commits the height, and requires BLS keys registered with a proof of
+	// possession for all the validators.
+	// The precommits are not sent to the peers with the CommitAggregate of
+	// their round. From block protocol 11, the block LenULB heights above
+	// carries the CommitAggregate as its LastCommit, so the precommits it
+	// covers aren't gossiped for the LastCommit either, else they are, after
+	// the votes of the round. Only the peers enabling it too get the
+	// CommitAggregates. Only used by the friday consensus.
+	AggregateCommits bool `mapstructure:"aggregate_commits"`
+
+	// Byzantine behavior the node produces on purpose, "<kind>@<height>", to
+	// test the evidence handling, the slashing of the app and the alerts of a
+	// private testnet end-to-end. The only kind is "double-vote": the
+	// validator signs a conflicting vote for each of its votes at the height.
+	// It is only set by the --simulate-misbehavior flag of the node command,
+	// never by the config file, and requires a local priv_validator and a
+	// binary built with the misbehavior tag. Only used by the friday
+	// consensus. NEVER use it on a real network.
+	SimulateMisbehavior string `mapstructure:"-"`
+
 	// Reactor sleep duration parameters
 	PeerGossipSleepDuration     time.Duration `mapstructure:"peer_gossip_sleep_duration"`
 	PeerQueryMaj23SleepDuration time.Duration `mapstructure:"peer_query_maj23_sleep_duration"`
@@ -801,9 +1403,30 @@ func DefaultConsensusConfig() *ConsensusConfig {
 		TimeoutCommit:               1000 * time.Millisecond,
 		TimeoutPreviousFailure:      2000 * time.Millisecond,
 		TimeoutPreviousFailureDelta: 500 * time.Millisecond,
+		TimeoutPrepareProposal:      1000 * time.Millisecond,
+		TimeoutSignVote:             0 * time.Millisecond,
+		MaxClockSkew:                500 * time.Millisecond,
+		FailureDumpPath:             filepath.Join(defaultDataDir, "cs.failures"),
+		FailureRestarts:             0,
+		FailureRestartBackoff:       1000 * time.Millisecond,
+		MaxPipelineDepth:            0,
+		CheckpointPath:              filepath.Join(defaultDataDir, "cs.checkpoint"),
+		CheckpointInterval:          0,
+		CheckpointVotes:             true,
+		FinalizeWaitTimeout:         60 * time.Second,
+		FinalizeWaitRerequest:       false,
+		InvariantCheckInterval:      10 * time.Second,
+		FastSyncReentryLag:          0,
+		EventLogPath:                "",
+		Standby:                     false,
+		StandbyWatermarkTimeout:     3000 * time.Millisecond,
 		SkipTimeoutCommit:           false,
+		OptimisticCommit:            false,
 		CreateEmptyBlocks:           true,
 		CreateEmptyBlocksInterval:   0 * time.Second,
+		CreateEmptyBlocksMaxDepth:   0,
+		BlockPartsParity:            0,
+		AggregateCommits:            false,
 		PeerGossipSleepDuration:     100 * time.Millisecond,
 		PeerQueryMaj23SleepDuration: 2000 * time.Millisecond,
 	}
@@ -814,6 +1437,7 @@ func DefaultFridayConsensusConfig() *ConsensusConfig {
 	cfg := DefaultConsensusConfig()
 	cfg.Module = "friday"
 	cfg.TimeoutCommit = 800 * time.Millisecond
+	cfg.TimeoutSignVote = 3000 * time.Millisecond
 	return cfg
 }
 
@@ -829,6 +1453,7 @@ func TestConsensusConfig() *ConsensusConfig {
 	cfg.TimeoutCommit = 10 * time.Millisecond
 	cfg.TimeoutPreviousFailure = 20 * time.Millisecond
 	cfg.TimeoutPreviousFailureDelta = 1 * time.Millisecond
+	cfg.TimeoutPrepareProposal = 20 * time.Millisecond
 	cfg.SkipTimeoutCommit = true
 	cfg.PeerGossipSleepDuration = 5 * time.Millisecond
 	cfg.PeerQueryMaj23SleepDuration = 250 * time.Millisecond
@@ -854,6 +1479,16 @@ func (cfg *ConsensusConfig) Propose(round int) time.Duration {
 	) * time.Nanosecond
 }
 
+// PrepareProposal returns the maximum time to prepare the txs of a proposal
+// block at round: TimeoutPrepareProposal, capped by the propose timeout.
+func (cfg *ConsensusConfig) PrepareProposal(round int) time.Duration {
+	propose := cfg.Propose(round)
+	if cfg.TimeoutPrepareProposal > 0 && cfg.TimeoutPrepareProposal < propose {
+		return cfg.TimeoutPrepareProposal
+	}
+	return propose
+}
+
 // Prevote returns the amount of time to wait for straggler votes after receiving any +2/3 prevotes
 func (cfg *ConsensusConfig) Prevote(round int) time.Duration {
 	return time.Duration(
@@ -893,6 +1528,56 @@ func (cfg *ConsensusConfig) SetWalFile(walFile string) {
 	cfg.walFile = walFile
 }
 
+// FailureDumpDir returns the full path to the directory of the failure bundles
+func (cfg *ConsensusConfig) FailureDumpDir() string {
+	return rootify(cfg.FailureDumpPath, cfg.RootDir)
+}
+
+// FailureDumpEnabled returns true if the failure bundles are written to disk.
+func (cfg *ConsensusConfig) FailureDumpEnabled() bool {
+	return cfg.FailureDumpPath != ""
+}
+
+// CheckpointFile returns the full path to the RoundState checkpoint file
+func (cfg *ConsensusConfig) CheckpointFile() string {
+	return rootify(cfg.CheckpointPath, cfg.RootDir)
+}
+
+// EventLogFile returns the full path to the event log file
+func (cfg *ConsensusConfig) EventLogFile() string {
+	return rootify(cfg.EventLogPath, cfg.RootDir)
+}
+
+// EventLogEnabled returns true if the events are recorded.
+func (cfg *ConsensusConfig) EventLogEnabled() bool {
+	return cfg.EventLogPath != ""
+}
+
+// MisbehaviorDoubleVote is the simulated misbehavior of a validator signing a
+// conflicting vote for each of its votes at a height.
+const MisbehaviorDoubleVote = "double-vote"
+
+// Misbehavior returns the kind and the height of the misbehavior simulated by
+// the node, parsed from SimulateMisbehavior. The kind is empty if the node
+// doesn't misbehave.
+func (cfg *ConsensusConfig) Misbehavior() (kind string, height int64, err error) {
+	if cfg.SimulateMisbehavior == "" {
+		return "", 0, nil
+	}
+	parts := strings.SplitN(cfg.SimulateMisbehavior, "@", 2)
+	if len(parts) != 2 {
+		return "", 0, fmt.Errorf("misbehavior %q must be <kind>@<height>", cfg.SimulateMisbehavior)
+	}
+	if parts[0] != MisbehaviorDoubleVote {
+		return "", 0, fmt.Errorf("unknown misbehavior %q", parts[0])
+	}
+	height, err = strconv.ParseInt(parts[1], 10, 64)
+	if err != nil || height < 1 {
+		return "", 0, fmt.Errorf("height of the misbehavior %q must be positive", cfg.SimulateMisbehavior)
+	}
+	return parts[0], height, nil
+}
+
 // ValidateBasic performs basic validation (checking param bounds, etc.) and
 // returns an error if any check fails.
 func (cfg *ConsensusConfig) ValidateBasic() error {
@@ -925,15 +1610,66 @@ func (cfg *ConsensusConfig) ValidateBasic() error {
 	if cfg.TimeoutCommit < 0 {
 		return errors.New("timeout_commit can't be negative")
 	}
+	if cfg.TimeoutPrepareProposal < 0 {
+		return errors.New("timeout_prepare_proposal can't be negative")
+	}
+	if cfg.TimeoutSignVote < 0 {
+		return errors.New("timeout_sign_vote can't be negative")
+	}
+	if cfg.MaxClockSkew < 0 {
+		return errors.New("max_clock_skew can't be negative")
+	}
+	if cfg.FailureRestarts < 0 {
+		return errors.New("failure_restarts can't be negative")
+	}
+	if cfg.FailureRestartBackoff < 0 {
+		return errors.New("failure_restart_backoff can't be negative")
+	}
+	if cfg.MaxPipelineDepth < 0 {
+		return errors.New("max_pipeline_depth can't be negative")
+	}
+	if cfg.CheckpointInterval < 0 {
+		return errors.New("checkpoint_interval can't be negative")
+	}
+	if cfg.CheckpointInterval > 0 && cfg.CheckpointPath == "" {
+		return errors.New("checkpoint_file can't be empty when checkpoint_interval is set")
+	}
+	if cfg.FinalizeWaitTimeout < 0 {
+		return errors.New("finalize_wait_timeout can't be negative")
+	}
+	if cfg.InvariantCheckInterval < 0 {
+		return errors.New("invariant_check_interval can't be negative")
+	}
+	if cfg.FastSyncReentryLag < 0 {
+		return errors.New("fast_sync_reentry_lag can't be negative")
+	}
+	if cfg.StandbyWatermarkTimeout < 0 {
+		return errors.New("standby_watermark_timeout can't be negative")
+	}
 	if cfg.CreateEmptyBlocksInterval < 0 {
 		return errors.New("create_empty_blocks_interval can't be negative")
 	}
+	if cfg.CreateEmptyBlocksMaxDepth < 0 {
+		return errors.New("create_empty_blocks_max_depth can't be negative")
+	}
+	if cfg.BlockPartsParity < 0 {
+		return errors.New("block_parts_parity can't be negative")
+	}
+	if cfg.BlockPartsParity > 100 {
+		return errors.New("block_parts_parity can't be greater than 100")
+	}
 	if cfg.PeerGossipSleepDuration < 0 {
 		return errors.New("peer_gossip_sleep_duration can't be negative")
 	}
 	if cfg.PeerQueryMaj23SleepDuration < 0 {
 		return errors.New("peer_query_maj23_sleep_duration can't be negative")
 	}
+	if _, _, err := cfg.Misbehavior(); err != nil {
+		return err
+	}
+	if cfg.SimulateMisbehavior != "" && cfg.Module != "friday" {
+		return errors.New("the misbehaviors are only simulated by the friday consensus")
+	}
 	return nil
 }
 
@@ -966,6 +1702,16 @@ type TxIndexConfig struct {
 	// precedence over IndexAllTags (i.e. when given both, IndexTags will be
 	// indexed).
 	IndexAllTags bool `mapstructure:"index_all_tags"`
+
+	// Connection string of a PostgreSQL database the blocks, the results of
+	// their transactions and their events are also written to, for SQL
+	// queries. Empty to disable it.
+	//
+	// The tables are created if they don't exist (see state/txindex/psql). The
+	// stock binary links no PostgreSQL driver and refuses to start with it set:
+	// the node must be built with a database/sql driver registered as "postgres",
+	// e.g. github.com/lib/pq. Failed writes are retried.
+	PsqlConn string `mapstructure:"psql_conn"`
 }
 
 // DefaultTxIndexConfig returns a default configuration for the transaction indexer.
@@ -1003,6 +1749,38 @@ type InstrumentationConfig struct {
 
 	// Instrumentation namespace.
 	Namespace string `mapstructure:"namespace"`
+
+	// When true, the spans of the work done for each height (consensus steps,
+	// block execution, mempool and ABCI calls) are exported to an
+	// OpenTelemetry collector with OTLP/HTTP.
+	Tracing bool `mapstructure:"tracing"`
+
+	// Base URL of the OTLP/HTTP endpoint of the collector.
+	// The spans are posted to <TracingOTLPEndpoint>/v1/traces.
+	TracingOTLPEndpoint string `mapstructure:"tracing_otlp_endpoint"`
+
+	// Address to listen for the debug server, which serves a snapshot of
+	// the internals of the friday consensus under /debug/consensus.
+	// It is disabled if empty. It must be a loopback address.
+	DebugListenAddr string `mapstructure:"debug_listen_addr"`
+
+	// When true, the pprof profiles (CPU, heap, goroutine, block, mutex...)
+	// are served under /debug/pprof/ on PprofListenAddr.
+	Pprof bool `mapstructure:"pprof"`
+
+	// Address to listen for the pprof server. It must be a loopback address,
+	// so the profiles are only reachable from the host of the node.
+	PprofListenAddr string `mapstructure:"pprof_listen_addr"`
+
+	// Sampling of the block profile while Pprof is true: one blocking event is
+	// recorded per PprofBlockProfileRate nanoseconds spent blocked. 0 disables
+	// the block profile.
+	PprofBlockProfileRate int `mapstructure:"pprof_block_profile_rate"`
+
+	// Sampling of the mutex profile while Pprof is true: one mutex contention
+	// event out of PprofMutexProfileFraction is recorded. 0 disables the mutex
+	// profile.
+	PprofMutexProfileFraction int `mapstructure:"pprof_mutex_profile_fraction"`
 }
 
 // DefaultInstrumentationConfig returns a default configuration for metrics
@@ -1013,6 +1791,14 @@ func DefaultInstrumentationConfig() *InstrumentationConfig {
 		PrometheusListenAddr: ":26660",
 		MaxOpenConnections:   3,
 		Namespace:            "tendermint",
+		Tracing:              false,
+		TracingOTLPEndpoint:  "http://localhost:4318",
+		DebugListenAddr:      "",
+
+		Pprof:                     false,
+		PprofListenAddr:           "127.0.0.1:26662",
+		PprofBlockProfileRate:     1000000,
+		PprofMutexProfileFraction: 100,
 	}
 }
 
@@ -1028,6 +1814,96 @@ func (cfg *InstrumentationConfig) ValidateBasic() error {
 	if cfg.MaxOpenConnections < 0 {
 		return errors.New("max_open_connections can't be negative")
 	}
+	if cfg.Tracing {
+		u, err := url.Parse(cfg.TracingOTLPEndpoint)
+		if err != nil {
+			return errors.Wrap(err, "invalid tracing_otlp_endpoint")
+		}
+		if u.Scheme != "http" && u.Scheme != "https" {
+			return errors.New("tracing_otlp_endpoint must be an http or https URL")
+		}
+	}
+	if cfg.DebugListenAddr != "" {
+		if err := validateLoopbackAddr(cfg.DebugListenAddr); err != nil {
+			return errors.Wrap(err, "debug_listen_addr must be a loopback address, e.g. localhost:26661")
+		}
+	}
+	if cfg.Pprof {
+		if err := validateLoopbackAddr(cfg.PprofListenAddr); err != nil {
+			return errors.Wrap(err, "pprof_listen_addr must be a loopback address, e.g. 127.0.0.1:26662")
+		}
+	}
+	if cfg.PprofBlockProfileRate < 0 {
+		return errors.New("pprof_block_profile_rate can't be negative")
+	}
+	if cfg.PprofMutexProfileFraction < 0 {
+		return errors.New("pprof_mutex_profile_fraction can't be negative")
+	}
+	return nil
+}
+
+// validateLoopbackAddr returns an error if addr is not a host:port address
+// on a loopback interface.
+func validateLoopbackAddr(addr string) error {
+	host, _, err := net.SplitHostPort(addr)
+	if err != nil {
+		return err
+	}
+	if ip := net.ParseIP(host); host != "localhost" && (ip == nil || !ip.IsLoopback()) {
+		return errors.Errorf("%s is not a loopback address", host)
+	}
+	return nil
+}
+
+//-----------------------------------------------------------------------------
+// ControlConfig
+
+// ControlConfig defines the configuration of the control API, for the
+// orchestration tools to drain and stop the node.
+type ControlConfig struct {
+	RootDir string `mapstructure:"home"`
+
+	// UNIX socket address for the control API to listen on, e.g.
+	// "unix://control.sock". It is disabled if empty.
+	ListenAddress string `mapstructure:"laddr"`
+
+	// Path to the file containing the hex encoded ed25519 public keys allowed
+	// to sign control requests, one per line.
+	AuthorizedKeysFile string `mapstructure:"authorized_keys_file"`
+
+	// Maximum difference between the time of a signed control request and
+	// the local time. Older requests are rejected as replays.
+	MaxRequestAge time.Duration `mapstructure:"max_request_age"`
+}
+
+// DefaultControlConfig returns a default configuration of the control API.
+func DefaultControlConfig() *ControlConfig {
+	return &ControlConfig{
+		ListenAddress:      "",
+		AuthorizedKeysFile: defaultControlAuthorizedKeysPath,
+		MaxRequestAge:      30 * time.Second,
+	}
+}
+
+// TestControlConfig returns a configuration of the control API for testing.
+func TestControlConfig() *ControlConfig {
+	return DefaultControlConfig()
+}
+
+// AuthorizedKeysFilePath returns the full path to the authorized keys file.
+func (cfg *ControlConfig) AuthorizedKeysFilePath() string {
+	return rootify(cfg.AuthorizedKeysFile, cfg.RootDir)
+}
+
+// ValidateBasic performs basic validation (checking param bounds, etc.) and
+// returns an error if any check fails.
+func (cfg *ControlConfig) ValidateBasic() error {
+	if cfg.ListenAddress != "" && !strings.HasPrefix(cfg.ListenAddress, "unix://") {
+		return errors.New("laddr must be a UNIX socket address (unix://)")
+	}
+	if cfg.MaxRequestAge <= 0 {
+		return errors.New("max_request_age must be positive")
+	}
 	return nil
 }
 
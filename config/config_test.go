@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestDefaultConfig(t *testing.T) {
@@ -36,6 +37,14 @@ func TestConfigValidateBasic(t *testing.T) {
 	// tamper with timeout_propose
 	cfg.Consensus.TimeoutPropose = -10 * time.Second
 	assert.Error(t, cfg.ValidateBasic())
+	cfg.Consensus.TimeoutPropose = 3 * time.Second
+
+	// the validator_local mempool never has txs available
+	cfg.Mempool.Mode = "validator_local"
+	cfg.Consensus.CreateEmptyBlocks = false
+	assert.Error(t, cfg.ValidateBasic())
+	cfg.Consensus.CreateEmptyBlocks = true
+	assert.NoError(t, cfg.ValidateBasic())
 }
 
 func TestTLSConfiguration(t *testing.T) {
@@ -54,6 +63,31 @@ func TestTLSConfiguration(t *testing.T) {
 	assert.Equal("/abs/path/to/file.key", cfg.RPC.KeyFile())
 }
 
+func TestRPCAuthConfiguration(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.SetRoot("/home/user")
+	assert.False(t, cfg.RPC.IsAuthEnabled())
+
+	cfg.RPC.AdminTokensFile = "tokens"
+	assert.Equal(t, "/home/user/config/tokens", cfg.RPC.AdminTokensFilePath())
+	assert.True(t, cfg.RPC.IsAuthEnabled())
+	cfg.RPC.AdminEndpoints = []string{"net_info"}
+	assert.NoError(t, cfg.RPC.ValidateBasic())
+
+	// the client certificates require the HTTPS server
+	cfg.RPC.TLSClientCAFile = "/abs/path/to/ca.crt"
+	assert.Equal(t, "/abs/path/to/ca.crt", cfg.RPC.ClientCAFile())
+	assert.Error(t, cfg.RPC.ValidateBasic())
+	cfg.RPC.TLSCertFile = "file.crt"
+	cfg.RPC.TLSKeyFile = "file.key"
+	assert.NoError(t, cfg.RPC.ValidateBasic())
+
+	// the admin endpoints require the authentication
+	cfg.RPC.AdminTokensFile = ""
+	cfg.RPC.TLSClientCAFile = ""
+	assert.Error(t, cfg.RPC.ValidateBasic())
+}
+
 func TestBaseConfigValidateBasic(t *testing.T) {
 	cfg := TestBaseConfig()
 	assert.NoError(t, cfg.ValidateBasic())
@@ -61,6 +95,20 @@ func TestBaseConfigValidateBasic(t *testing.T) {
 	// tamper with log format
 	cfg.LogFormat = "invalid"
 	assert.Error(t, cfg.ValidateBasic())
+	cfg.LogFormat = LogFormatStructured
+	assert.NoError(t, cfg.ValidateBasic())
+	cfg.LogFormat = LogFormatPlain
+
+	// tamper with disk encryption key source
+	cfg.DiskEncryptionKey = "env:TM_DISK_ENCRYPTION_KEY"
+	assert.NoError(t, cfg.ValidateBasic())
+	cfg.DiskEncryptionKey = "0123456789abcdef"
+	assert.Error(t, cfg.ValidateBasic())
+	cfg.DiskEncryptionKey = ""
+
+	// share the node key and the validator key
+	cfg.NodeKey = cfg.PrivValidatorKey
+	assert.Error(t, cfg.ValidateBasic())
 }
 
 func TestRPCConfigValidateBasic(t *testing.T) {
@@ -75,6 +123,10 @@ func TestRPCConfigValidateBasic(t *testing.T) {
 		"TimeoutBroadcastTxCommit",
 		"MaxBodyBytes",
 		"MaxHeaderBytes",
+		"HealthFinalizeTimeout",
+		"HealthWaitFinalizeTimeout",
+		"ResultCacheSize",
+		"ResultCacheTTL",
 	}
 
 	for _, fieldName := range fieldsToTest {
@@ -82,6 +134,18 @@ func TestRPCConfigValidateBasic(t *testing.T) {
 		assert.Error(t, cfg.ValidateBasic())
 		reflect.ValueOf(cfg).Elem().FieldByName(fieldName).SetInt(0)
 	}
+
+	for _, fieldName := range []string{"RateLimit", "WSRateLimit", "WSMaxEventsPerSecond"} {
+		reflect.ValueOf(cfg).Elem().FieldByName(fieldName).SetFloat(-1)
+		assert.Error(t, cfg.ValidateBasic())
+		reflect.ValueOf(cfg).Elem().FieldByName(fieldName).SetFloat(0)
+	}
+
+	// the burst is required by the rate limits only
+	cfg.RateLimitBurst = 0
+	assert.NoError(t, cfg.ValidateBasic())
+	cfg.RateLimit = 10
+	assert.Error(t, cfg.ValidateBasic())
 }
 
 func TestP2PConfigValidateBasic(t *testing.T) {
@@ -91,10 +155,25 @@ func TestP2PConfigValidateBasic(t *testing.T) {
 	fieldsToTest := []string{
 		"MaxNumInboundPeers",
 		"MaxNumOutboundPeers",
+		"ReconnectAttempts",
+		"ReconnectInterval",
+		"ReconnectBackoffAttempts",
+		"ReconnectMaxBackoff",
+		"ReconnectJitter",
 		"FlushThrottleTimeout",
 		"MaxPacketMsgPayloadSize",
 		"SendRate",
 		"RecvRate",
+		"ConsensusSendRate",
+		"ConsensusRecvRate",
+		"BlockchainSendRate",
+		"BlockchainRecvRate",
+		"MempoolSendRate",
+		"MempoolRecvRate",
+		"EvidenceSendRate",
+		"EvidenceRecvRate",
+		"SecretConnRekeyBytes",
+		"SecretConnRekeyInterval",
 	}
 
 	for _, fieldName := range fieldsToTest {
@@ -102,6 +181,9 @@ func TestP2PConfigValidateBasic(t *testing.T) {
 		assert.Error(t, cfg.ValidateBasic())
 		reflect.ValueOf(cfg).Elem().FieldByName(fieldName).SetInt(0)
 	}
+
+	cfg.ReconnectBackoffFactor = 0.5
+	assert.Error(t, cfg.ValidateBasic())
 }
 
 func TestMempoolConfigValidateBasic(t *testing.T) {
@@ -113,6 +195,8 @@ func TestMempoolConfigValidateBasic(t *testing.T) {
 		"MaxTxsBytes",
 		"CacheSize",
 		"MaxTxBytes",
+		"MaxNonceGapBlocks",
+		"TTLNumBlocks",
 	}
 
 	for _, fieldName := range fieldsToTest {
@@ -120,6 +204,25 @@ func TestMempoolConfigValidateBasic(t *testing.T) {
 		assert.Error(t, cfg.ValidateBasic())
 		reflect.ValueOf(cfg).Elem().FieldByName(fieldName).SetInt(0)
 	}
+
+	// tamper with the eviction policy
+	cfg.EvictionPolicy = "sender_budget"
+	assert.NoError(t, cfg.ValidateBasic())
+	cfg.SenderBudget = 0
+	assert.Error(t, cfg.ValidateBasic())
+
+	cfg.EvictionPolicy = "invalid"
+	assert.Error(t, cfg.ValidateBasic())
+	cfg.EvictionPolicy = "none"
+
+	// tamper with the mode
+	cfg.Mode = "validator_local"
+	assert.NoError(t, cfg.ValidateBasic())
+	cfg.PullTxsTimeout = -1
+	assert.Error(t, cfg.ValidateBasic())
+	cfg.PullTxsTimeout = 0
+	cfg.Mode = "invalid"
+	assert.Error(t, cfg.ValidateBasic())
 }
 
 func TestFastSyncConfigValidateBasic(t *testing.T) {
@@ -134,6 +237,24 @@ func TestFastSyncConfigValidateBasic(t *testing.T) {
 	assert.Error(t, cfg.ValidateBasic())
 }
 
+func TestBlockStoreConfigValidateBasic(t *testing.T) {
+	cfg := TestBlockStoreConfig()
+	assert.NoError(t, cfg.ValidateBasic())
+
+	// tamper with backend
+	cfg.Backend = "s3"
+	assert.Error(t, cfg.ValidateBasic()) // no bucket
+
+	cfg.S3Bucket = "blocks"
+	assert.NoError(t, cfg.ValidateBasic())
+
+	cfg.KeepRecent = -1
+	assert.Error(t, cfg.ValidateBasic())
+
+	cfg.Backend = "invalid"
+	assert.Error(t, cfg.ValidateBasic())
+}
+
 func TestConsensusConfigValidateBasic(t *testing.T) {
 	cfg := TestConsensusConfig()
 	assert.NoError(t, cfg.ValidateBasic())
@@ -146,7 +267,20 @@ func TestConsensusConfigValidateBasic(t *testing.T) {
 		"TimeoutPrecommit",
 		"TimeoutPrecommitDelta",
 		"TimeoutCommit",
+		"TimeoutPrepareProposal",
+		"TimeoutSignVote",
+		"MaxClockSkew",
+		"FailureRestarts",
+		"FailureRestartBackoff",
+		"MaxPipelineDepth",
+		"CheckpointInterval",
+		"FinalizeWaitTimeout",
+		"InvariantCheckInterval",
+		"FastSyncReentryLag",
+		"StandbyWatermarkTimeout",
 		"CreateEmptyBlocksInterval",
+		"CreateEmptyBlocksMaxDepth",
+		"BlockPartsParity",
 		"PeerGossipSleepDuration",
 		"PeerQueryMaj23SleepDuration",
 	}
@@ -156,6 +290,20 @@ func TestConsensusConfigValidateBasic(t *testing.T) {
 		assert.Error(t, cfg.ValidateBasic())
 		reflect.ValueOf(cfg).Elem().FieldByName(fieldName).SetInt(0)
 	}
+
+	// the misbehaviors are only simulated by the friday consensus
+	cfg.SimulateMisbehavior = "double-vote@5"
+	assert.Error(t, cfg.ValidateBasic())
+	cfg.Module = "friday"
+	assert.NoError(t, cfg.ValidateBasic())
+	kind, height, err := cfg.Misbehavior()
+	require.NoError(t, err)
+	assert.Equal(t, MisbehaviorDoubleVote, kind)
+	assert.Equal(t, int64(5), height)
+	for _, misbehavior := range []string{"double-vote", "double-vote@0", "double-vote@x", "double-propose@5"} {
+		cfg.SimulateMisbehavior = misbehavior
+		assert.Error(t, cfg.ValidateBasic(), misbehavior)
+	}
 }
 
 func TestInstrumentationConfigValidateBasic(t *testing.T) {
@@ -165,4 +313,54 @@ func TestInstrumentationConfigValidateBasic(t *testing.T) {
 	// tamper with maximum open connections
 	cfg.MaxOpenConnections = -1
 	assert.Error(t, cfg.ValidateBasic())
+
+	// the OTLP endpoint is only checked when tracing
+	cfg = TestInstrumentationConfig()
+	cfg.TracingOTLPEndpoint = "localhost:4318"
+	assert.NoError(t, cfg.ValidateBasic())
+	cfg.Tracing = true
+	assert.Error(t, cfg.ValidateBasic())
+	cfg.TracingOTLPEndpoint = "http://localhost:4318"
+	assert.NoError(t, cfg.ValidateBasic())
+
+	// the debug server only listens on a loopback address
+	cfg = TestInstrumentationConfig()
+	for _, addr := range []string{":26661", "0.0.0.0:26661", "10.0.0.1:26661", "example.com:26661", "localhost"} {
+		cfg.DebugListenAddr = addr
+		assert.Error(t, cfg.ValidateBasic(), addr)
+	}
+	for _, addr := range []string{"", "localhost:26661", "127.0.0.1:26661", "[::1]:26661"} {
+		cfg.DebugListenAddr = addr
+		assert.NoError(t, cfg.ValidateBasic(), addr)
+	}
+
+	// the pprof server only listens on a loopback address
+	cfg = TestInstrumentationConfig()
+	cfg.Pprof = true
+	assert.NoError(t, cfg.ValidateBasic())
+	for _, addr := range []string{":26662", "0.0.0.0:26662", "10.0.0.1:26662", "127.0.0.1"} {
+		cfg.PprofListenAddr = addr
+		assert.Error(t, cfg.ValidateBasic(), addr)
+	}
+	for _, addr := range []string{"localhost:26662", "[::1]:26662"} {
+		cfg.PprofListenAddr = addr
+		assert.NoError(t, cfg.ValidateBasic(), addr)
+	}
+	cfg.PprofBlockProfileRate = -1
+	assert.Error(t, cfg.ValidateBasic())
+}
+
+func TestControlConfigValidateBasic(t *testing.T) {
+	cfg := TestControlConfig()
+	assert.NoError(t, cfg.ValidateBasic())
+
+	// only listens on a UNIX socket
+	cfg.ListenAddress = "tcp://127.0.0.1:26661"
+	assert.Error(t, cfg.ValidateBasic())
+	cfg.ListenAddress = "unix://control.sock"
+	assert.NoError(t, cfg.ValidateBasic())
+
+	// tamper with the max request age
+	cfg.MaxRequestAge = 0
+	assert.Error(t, cfg.ValidateBasic())
 }
@@ -0,0 +1,107 @@
+package config
+
+import (
+	"reflect"
+	"strings"
+)
+
+// ReloadableKeys are the keys of the config fields a running node applies
+// when it reloads its config (SIGHUP or reload_config RPC endpoint). The other
+// fields need a restart.
+var ReloadableKeys = []string{
+	"log_level",
+
+	"rpc.max_subscription_clients",
+	"rpc.max_subscriptions_per_client",
+
+	"mempool.size",
+	"mempool.max_txs_bytes",
+
+	"consensus.timeout_propose",
+	"consensus.timeout_propose_delta",
+	"consensus.timeout_prevote",
+	"consensus.timeout_prevote_delta",
+	"consensus.timeout_precommit",
+	"consensus.timeout_precommit_delta",
+	"consensus.timeout_commit",
+	"consensus.skip_timeout_commit",
+	"consensus.optimistic_commit",
+	"consensus.create_empty_blocks_interval",
+	"consensus.peer_gossip_sleep_duration",
+	"consensus.peer_query_maj23_sleep_duration",
+}
+
+// IsReloadable returns true if the field with key is applied when the config
+// is reloaded.
+func IsReloadable(key string) bool {
+	for _, k := range ReloadableKeys {
+		if k == key {
+			return true
+		}
+	}
+	return false
+}
+
+// Reload returns a copy of cfg with the reloadable fields of newCfg, after
+// validating newCfg, and the keys of the reloadable fields which changed, and
+// of the other fields which changed but need a restart. cfg is not modified:
+// the running services read their config concurrently, so the reloaded fields
+// are applied with their setters (e.g. CListMempool.SetLimits).
+func (cfg *Config) Reload(newCfg *Config) (reloadedCfg *Config, reloaded, ignored []string, err error) {
+	if err := newCfg.ValidateBasic(); err != nil {
+		return nil, nil, nil, err
+	}
+
+	reloadedCfg = cfg.copy()
+	reloadFields("", reflect.ValueOf(reloadedCfg).Elem(), reflect.ValueOf(newCfg).Elem(), func(key string, field, newField reflect.Value) {
+		if reflect.DeepEqual(field.Interface(), newField.Interface()) {
+			return
+		}
+		if !IsReloadable(key) {
+			ignored = append(ignored, key)
+			return
+		}
+		field.Set(newField)
+		reloaded = append(reloaded, key)
+	})
+	return reloadedCfg, reloaded, ignored, nil
+}
+
+// copy returns a copy of cfg with copies of its config structs, for them to
+// be set without modifying the ones of cfg.
+func (cfg *Config) copy() *Config {
+	c := *cfg
+	v := reflect.ValueOf(&c).Elem()
+	for i := 0; i < v.NumField(); i++ {
+		field := v.Field(i)
+		if field.Kind() == reflect.Ptr && !field.IsNil() && field.Elem().Kind() == reflect.Struct {
+			fieldCopy := reflect.New(field.Elem().Type())
+			fieldCopy.Elem().Set(field.Elem())
+			field.Set(fieldCopy)
+		}
+	}
+	return &c
+}
+
+// reloadFields calls reload with the fields of the config structs by key,
+// following the mapstructure tags.
+func reloadFields(prefix string, v, newV reflect.Value, reload func(key string, field, newField reflect.Value)) {
+	for i := 0; i < v.NumField(); i++ {
+		tag := v.Type().Field(i).Tag.Get("mapstructure")
+		if tag == "" {
+			continue
+		}
+		field, newField := v.Field(i), newV.Field(i)
+		switch {
+		case strings.HasSuffix(tag, ",squash"):
+			reloadFields(prefix, field, newField, reload)
+		case field.Kind() == reflect.Ptr && field.Elem().Kind() == reflect.Struct:
+			if field.IsNil() || newField.IsNil() {
+				continue
+			}
+			reloadFields(prefix+tag+".", field.Elem(), newField.Elem(), reload)
+		default:
+			reload(prefix+tag, field, newField)
+		}
+	}
+}
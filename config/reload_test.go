@@ -0,0 +1,55 @@
+package config
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConfigReload(t *testing.T) {
+	cfg := DefaultConfig()
+	mempool := cfg.Mempool
+
+	newCfg := DefaultConfig()
+	newCfg.LogLevel = "*:debug"
+	newCfg.Mempool.Size = 10
+	newCfg.Consensus.TimeoutPropose = 10 * time.Second
+	newCfg.P2P.ListenAddress = "tcp://0.0.0.0:36656"
+	newCfg.Moniker = "other"
+
+	reloadedCfg, reloaded, ignored, err := cfg.Reload(newCfg)
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{"log_level", "mempool.size", "consensus.timeout_propose"}, reloaded)
+	assert.ElementsMatch(t, []string{"p2p.laddr", "moniker"}, ignored)
+
+	// the reloaded fields are set in a copy, the ignored ones are kept
+	assert.Equal(t, "*:debug", reloadedCfg.LogLevel)
+	assert.Equal(t, 10, reloadedCfg.Mempool.Size)
+	assert.Equal(t, 10*time.Second, reloadedCfg.Consensus.TimeoutPropose)
+	assert.Equal(t, DefaultP2PConfig().ListenAddress, reloadedCfg.P2P.ListenAddress)
+	assert.Equal(t, DefaultBaseConfig().Moniker, reloadedCfg.Moniker)
+
+	// the running config isn't modified
+	assert.Equal(t, DefaultConfig(), cfg)
+	assert.Equal(t, DefaultMempoolConfig(), mempool)
+
+	// an invalid config isn't reloaded
+	newCfg.Consensus.TimeoutCommit = -time.Second
+	_, _, _, err = cfg.Reload(newCfg)
+	assert.Error(t, err)
+}
+
+func TestReloadableKeys(t *testing.T) {
+	// all the reloadable keys are config fields
+	var keys []string
+	cfg := DefaultConfig()
+	reloadFields("", reflect.ValueOf(cfg).Elem(), reflect.ValueOf(cfg).Elem(), func(key string, _, _ reflect.Value) {
+		keys = append(keys, key)
+	})
+	for _, key := range ReloadableKeys {
+		assert.Contains(t, keys, key)
+	}
+}
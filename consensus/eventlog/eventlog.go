@@ -0,0 +1,275 @@
+// Package eventlog records the events published at a height on the EventBus:
+// the consensus events, and the block and tx events. The events of a range of
+// heights are exported from the record, and replayed into another EventBus in
+// the order the node published them, e.g. to reproduce with a UI or an indexer
+// an ordering of the pipeline events which occurred on a live network.
+package eventlog
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strconv"
+	"time"
+
+	"github.com/pkg/errors"
+
+	auto "github.com/hdac-io/tendermint/libs/autofile"
+	cmn "github.com/hdac-io/tendermint/libs/common"
+	"github.com/hdac-io/tendermint/libs/log"
+	tmpubsub "github.com/hdac-io/tendermint/libs/pubsub"
+	tmquery "github.com/hdac-io/tendermint/libs/pubsub/query"
+	"github.com/hdac-io/tendermint/types"
+)
+
+const (
+	subscriber = "EventRecorder"
+
+	flushInterval = 2 * time.Second
+)
+
+// Query matches the events the Recorder records, the ones published at a
+// height.
+var Query = tmquery.MustParse(fmt.Sprintf("%s > 0", types.BlockHeightKey))
+
+// Record is an event recorded from the EventBus, written as a line of JSON.
+type Record struct {
+	Time   time.Time           `json:"time"`
+	Height int64               `json:"height"`
+	Type   string              `json:"type"`
+	Events map[string][]string `json:"events"`
+	Data   json.RawMessage     `json:"data"` // amino JSON of the TMEventData
+}
+
+// NewRecord returns the Record of the event msg published on the EventBus at
+// time t.
+func NewRecord(msg tmpubsub.Message, t time.Time) (*Record, error) {
+	events := msg.Events()
+	height, err := lastInt64(events[types.BlockHeightKey])
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid height")
+	}
+	var eventType string
+	// the type of the EventBus is added after the ones of the app, if any
+	if eventTypes := events[types.EventTypeKey]; len(eventTypes) > 0 {
+		eventType = eventTypes[len(eventTypes)-1]
+	}
+	data, err := cdc.MarshalJSON(msg.Data())
+	if err != nil {
+		return nil, err
+	}
+	return &Record{Time: t, Height: height, Type: eventType, Events: events, Data: data}, nil
+}
+
+func lastInt64(values []string) (int64, error) {
+	if len(values) == 0 {
+		return 0, errors.New("no value")
+	}
+	return strconv.ParseInt(values[len(values)-1], 10, 64)
+}
+
+// EventData decodes the data of the event.
+func (rec *Record) EventData() (types.TMEventData, error) {
+	var data types.TMEventData
+	err := cdc.UnmarshalJSON(rec.Data, &data)
+	return data, err
+}
+
+//-----------------------------------------------------------------------------
+
+// Recorder records the events published at a height on an EventBus to a
+// group of files rotated like the consensus WAL. The records are flushed to
+// disk every 2s, on every new block, and once when stopped.
+type Recorder struct {
+	cmn.BaseService
+
+	group    *auto.Group
+	eventBus *types.EventBus
+	sub      types.Subscription
+	quit     chan struct{}
+	done     chan struct{}
+}
+
+// NewRecorder returns a Recorder of the events of eventBus to file, the head
+// of the group of files.
+func NewRecorder(file string, eventBus *types.EventBus, groupOptions ...func(*auto.Group)) (*Recorder, error) {
+	if err := cmn.EnsureDir(filepath.Dir(file), 0700); err != nil {
+		return nil, errors.Wrap(err, "failed to ensure the event log directory is in place")
+	}
+	group, err := auto.OpenGroup(file, groupOptions...)
+	if err != nil {
+		return nil, err
+	}
+	r := &Recorder{
+		group:    group,
+		eventBus: eventBus,
+		quit:     make(chan struct{}),
+		done:     make(chan struct{}),
+	}
+	r.BaseService = *cmn.NewBaseService(nil, "EventRecorder", r)
+	return r, nil
+}
+
+// SetLogger sets the logger of the Recorder and of its group of files.
+func (r *Recorder) SetLogger(l log.Logger) {
+	r.BaseService.Logger = l
+	r.group.SetLogger(l)
+}
+
+// OnStart implements cmn.Service by subscribing to the events.
+func (r *Recorder) OnStart() error {
+	if err := r.group.Start(); err != nil {
+		return err
+	}
+	// unbuffered, so no event is dropped while writing
+	sub, err := r.eventBus.SubscribeUnbuffered(context.Background(), subscriber, Query)
+	if err != nil {
+		r.group.Stop()
+		return err
+	}
+	r.sub = sub
+	go r.recordRoutine()
+	return nil
+}
+
+// OnStop implements cmn.Service by unsubscribing and flushing the records.
+// The EventBus may already be stopped.
+func (r *Recorder) OnStop() {
+	if err := r.eventBus.UnsubscribeAll(context.Background(), subscriber); err != nil {
+		r.Logger.Debug("Failed to unsubscribe", "err", err)
+	}
+	close(r.quit)
+	<-r.done
+	if err := r.group.FlushAndSync(); err != nil {
+		r.Logger.Error("Failed to flush the event log", "err", err)
+	}
+	r.group.Stop()
+	r.group.Close()
+}
+
+func (r *Recorder) recordRoutine() {
+	defer close(r.done)
+
+	flushTicker := time.NewTicker(flushInterval)
+	defer flushTicker.Stop()
+	for {
+		select {
+		case msg := <-r.sub.Out():
+			rec, err := NewRecord(msg, time.Now())
+			if err == nil {
+				err = r.write(rec)
+			}
+			if err != nil {
+				r.Logger.Error("Failed to record event", "events", msg.Events(), "err", err)
+				continue
+			}
+			if rec.Type == types.EventNewBlock {
+				if err := r.group.FlushAndSync(); err != nil {
+					r.Logger.Error("Failed to flush the event log", "err", err)
+				}
+			}
+		case <-flushTicker.C:
+			if err := r.group.FlushAndSync(); err != nil {
+				r.Logger.Error("Periodic event log flush failed", "err", err)
+			}
+		case <-r.sub.Cancelled():
+			return
+		case <-r.quit:
+			return
+		}
+	}
+}
+
+func (r *Recorder) write(rec *Record) error {
+	bz, err := json.Marshal(rec)
+	if err != nil {
+		return err
+	}
+	_, err = r.group.Write(append(bz, '\n'))
+	return err
+}
+
+//-----------------------------------------------------------------------------
+
+// Export writes the records of the heights from to to, included, of the group
+// of files of file, to w, in the order they were recorded. The last record is
+// skipped if it was only partly written. It returns the number of records
+// exported.
+func Export(file string, from, to int64, w io.Writer) (int, error) {
+	group, err := auto.OpenGroup(file)
+	if err != nil {
+		return 0, err
+	}
+	defer group.Close()
+	gr, err := group.NewReader(group.MinIndex())
+	if err != nil {
+		return 0, err
+	}
+	defer gr.Close()
+
+	n := 0
+	err = readRecords(gr, func(rec *Record, line []byte) error {
+		if rec.Height < from || rec.Height > to {
+			return nil
+		}
+		if _, err := w.Write(line); err != nil {
+			return err
+		}
+		n++
+		return nil
+	})
+	return n, err
+}
+
+// Replay publishes the records read from r, e.g. exported, on eventBus, with
+// the events they were published with, in order. If realtime, the delays
+// between the records are kept. It returns the number of records replayed.
+func Replay(r io.Reader, eventBus *types.EventBus, realtime bool) (int, error) {
+	n := 0
+	var last time.Time
+	err := readRecords(r, func(rec *Record, line []byte) error {
+		data, err := rec.EventData()
+		if err != nil {
+			return errors.Wrapf(err, "invalid data of the %s event at height %d", rec.Type, rec.Height)
+		}
+		if realtime && !last.IsZero() && rec.Time.After(last) {
+			time.Sleep(rec.Time.Sub(last))
+		}
+		last = rec.Time
+		if err := eventBus.PublishWithEvents(data, rec.Events); err != nil {
+			return err
+		}
+		n++
+		return nil
+	})
+	return n, err
+}
+
+// readRecords calls fn with each record read from r, and its line. A last line
+// without a newline, only partly written, is skipped.
+func readRecords(r io.Reader, fn func(rec *Record, line []byte) error) error {
+	br := bufio.NewReader(r)
+	for i := 1; ; i++ {
+		line, err := br.ReadBytes('\n')
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if len(bytes.TrimSpace(line)) == 0 {
+			continue
+		}
+		rec := new(Record)
+		if err := json.Unmarshal(line, rec); err != nil {
+			return errors.Wrapf(err, "invalid record on line %d", i)
+		}
+		if err := fn(rec, line); err != nil {
+			return err
+		}
+	}
+}
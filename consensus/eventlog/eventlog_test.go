@@ -0,0 +1,118 @@
+package eventlog
+
+import (
+	"bytes"
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hdac-io/tendermint/libs/log"
+	"github.com/hdac-io/tendermint/types"
+)
+
+func startEventBus(t *testing.T) *types.EventBus {
+	eventBus := types.NewEventBus()
+	eventBus.SetLogger(log.TestingLogger())
+	require.NoError(t, eventBus.Start())
+	return eventBus
+}
+
+func TestRecordExportReplay(t *testing.T) {
+	dir, err := ioutil.TempDir("", "eventlog")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	file := filepath.Join(dir, "events")
+
+	eventBus := startEventBus(t)
+	defer eventBus.Stop()
+	recorder, err := NewRecorder(file, eventBus)
+	require.NoError(t, err)
+	recorder.SetLogger(log.TestingLogger())
+	require.NoError(t, recorder.Start())
+
+	// the heights in progress interleave
+	vote := &types.Vote{Height: 2, Round: 0, Type: types.PrevoteType, ValidatorAddress: []byte("validator")}
+	published := []func() error{
+		func() error {
+			return eventBus.PublishEventNewRoundStep(types.EventDataRoundState{Height: 1, Step: "RoundStepCommit"})
+		},
+		func() error {
+			return eventBus.PublishEventNewRoundStep(types.EventDataRoundState{Height: 2, Step: "RoundStepPrevote"})
+		},
+		// not published at a height: not recorded
+		func() error { return eventBus.PublishEventValidatorSetUpdates(types.EventDataValidatorSetUpdates{}) },
+		func() error { return eventBus.PublishEventVote(types.EventDataVote{Vote: vote}) },
+		func() error {
+			return eventBus.PublishEventNewBlockHeader(types.EventDataNewBlockHeader{Header: types.Header{Height: 1}})
+		},
+		func() error {
+			return eventBus.PublishEventNewRoundStep(types.EventDataRoundState{Height: 3, Step: "RoundStepPropose"})
+		},
+	}
+	for _, publish := range published {
+		require.NoError(t, publish())
+	}
+	require.NoError(t, recorder.Stop())
+
+	var exported bytes.Buffer
+	n, err := Export(file, 2, 3, &exported)
+	require.NoError(t, err)
+	assert.Equal(t, 3, n)
+
+	// a record partly written is skipped
+	exported.WriteString(`{"time":"2019-`)
+
+	replayBus := startEventBus(t)
+	defer replayBus.Stop()
+	sub, err := replayBus.Subscribe(context.Background(), "test", Query, 10)
+	require.NoError(t, err)
+	n, err = Replay(&exported, replayBus, false)
+	require.NoError(t, err)
+	assert.Equal(t, 3, n)
+
+	var replayed []interface{}
+	for i := 0; i < n; i++ {
+		select {
+		case msg := <-sub.Out():
+			replayed = append(replayed, msg.Data())
+			if i == 1 {
+				assert.Equal(t, []string{types.EventVote}, msg.Events()[types.EventTypeKey])
+				assert.Equal(t, []string{types.PipelineStageVote}, msg.Events()[types.PipelineStageKey])
+			}
+		case <-time.After(time.Second):
+			t.Fatal("replayed event not received")
+		}
+	}
+	assert.Equal(t, types.EventDataRoundState{Height: 2, Step: "RoundStepPrevote"}, replayed[0])
+	require.IsType(t, types.EventDataVote{}, replayed[1])
+	assert.Equal(t, vote.ValidatorAddress, replayed[1].(types.EventDataVote).Vote.ValidatorAddress)
+	assert.Equal(t, types.EventDataRoundState{Height: 3, Step: "RoundStepPropose"}, replayed[2])
+}
+
+func TestRecorderStopsAfterEventBus(t *testing.T) {
+	dir, err := ioutil.TempDir("", "eventlog")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	eventBus := startEventBus(t)
+	recorder, err := NewRecorder(filepath.Join(dir, "events"), eventBus)
+	require.NoError(t, err)
+	require.NoError(t, recorder.Start())
+
+	// like the node, which stops the EventBus first
+	require.NoError(t, eventBus.Stop())
+	stopped := make(chan error, 1)
+	go func() { stopped <- recorder.Stop() }()
+	select {
+	case err := <-stopped:
+		assert.NoError(t, err)
+	case <-time.After(time.Second):
+		t.Fatal("recorder didn't stop")
+	}
+}
@@ -0,0 +1,303 @@
+package friday
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+
+	cstypes "github.com/hdac-io/tendermint/consensus/types"
+	cmn "github.com/hdac-io/tendermint/libs/common"
+	"github.com/hdac-io/tendermint/libs/erasure"
+	"github.com/hdac-io/tendermint/p2p"
+	"github.com/hdac-io/tendermint/types"
+)
+
+// The proposal block parts are extended with BlockPartsParity parity parts
+// per 100 parts, Reed-Solomon erasure coded, so that any Total of the parts
+// and parity parts rebuild the parts. The parity parts are gossiped on the
+// DataParityChannel, only registered with BlockPartsParity, so only the peers
+// advertising it in their NodeInfo get them.
+
+// blockPartsParity is the parity parts of the block parts with header.
+type blockPartsParity struct {
+	height int64
+	header types.PartSetHeader
+	// the size of the data of the parts
+	dataSize int
+	shards   [][]byte
+}
+
+// numParityParts returns the number of parity parts of total block parts: 0
+// for a single part, and not more than erasure.MaxShards parts in all.
+func numParityParts(total, parity int) int {
+	if total < 2 || parity <= 0 {
+		return 0
+	}
+	n := (total*parity + 99) / 100
+	if total+n > erasure.MaxShards {
+		n = erasure.MaxShards - total
+	}
+	if n < 0 {
+		return 0
+	}
+	return n
+}
+
+// parityShardSize returns the size of the shards of the parts of dataSize
+// bytes: the size of the first part.
+func parityShardSize(dataSize int) int {
+	if dataSize < types.BlockPartSizeBytes {
+		return dataSize
+	}
+	return types.BlockPartSizeBytes
+}
+
+// makeBlockPartsParity erasure codes the complete parts. It returns nil if
+// the parts get no parity parts.
+func makeBlockPartsParity(height int64, parts *types.PartSet, parity int) (*blockPartsParity, error) {
+	numParity := numParityParts(parts.Total(), parity)
+	if numParity == 0 {
+		return nil, nil
+	}
+
+	dataSize := 0
+	for i := 0; i < parts.Total(); i++ {
+		dataSize += len(parts.GetPart(i).Bytes)
+	}
+	data := make([][]byte, parts.Total())
+	for i := range data {
+		data[i] = paddedShard(parts.GetPart(i).Bytes, parityShardSize(dataSize))
+	}
+	shards, err := erasure.Encode(data, numParity)
+	if err != nil {
+		return nil, err
+	}
+	return &blockPartsParity{
+		height:   height,
+		header:   parts.Header(),
+		dataSize: dataSize,
+		shards:   shards,
+	}, nil
+}
+
+// rebuildBlockParts returns the complete parts rebuilt from the parts
+// received so far and the parity parts, or an error if they don't rebuild
+// parts with the header of parts.
+func rebuildBlockParts(parts *types.PartSet, parity *receivedParity) (*types.PartSet, error) {
+	shardSize := parityShardSize(parity.dataSize)
+	shards := make([][]byte, parts.Total()+parity.maxIndex+1)
+	for i := 0; i < parts.Total(); i++ {
+		if part := parts.GetPart(i); part != nil {
+			shards[i] = paddedShard(part.Bytes, shardSize)
+		}
+	}
+	for index, shard := range parity.shards {
+		shards[parts.Total()+index] = shard
+	}
+	if err := erasure.Reconstruct(shards, parts.Total()); err != nil {
+		return nil, err
+	}
+
+	data := make([]byte, 0, parts.Total()*shardSize)
+	for _, shard := range shards[:parts.Total()] {
+		data = append(data, shard...)
+	}
+	rebuilt := types.NewPartSetFromData(data[:parity.dataSize], types.BlockPartSizeBytes)
+	if !rebuilt.HasHeader(parts.Header()) {
+		return nil, errors.New("parity parts rebuilt parts of another header")
+	}
+	return rebuilt, nil
+}
+
+func paddedShard(bz []byte, size int) []byte {
+	if len(bz) == size {
+		return bz
+	}
+	shard := make([]byte, size)
+	copy(shard, bz)
+	return shard
+}
+
+//-----------------------------------------------------------------------------
+// ConsensusState
+
+// receivedParity is the parity parts received for the proposal block parts
+// of a height.
+type receivedParity struct {
+	header   types.PartSetHeader
+	dataSize int
+	shards   map[int][]byte
+	maxIndex int
+}
+
+// addProposalBlockParity adds the parity part to the ones received for the
+// proposal block parts of its height, and once the parts and parity parts
+// are as many as the parts, adds the missing parts rebuilt from them.
+func (cs *ConsensusState) addProposalBlockParity(msg *BlockPartParityMessage, peerID p2p.ID) (added bool, err error) {
+	heightRound := cs.getRoundState(msg.Height)
+	if heightRound == nil {
+		return false, nil
+	}
+	heightRound.Lock()
+
+	parts := heightRound.ProposalBlockParts
+	if parts == nil || parts.IsComplete() || !parts.HasHeader(msg.PartsHeader) {
+		heightRound.Unlock()
+		return false, nil
+	}
+
+	var parity *receivedParity
+	if v, ok := cs.blockPartsParity.Load(msg.Height); ok {
+		parity = v.(*receivedParity)
+	}
+	if parity == nil || !parity.header.Equals(msg.PartsHeader) || parity.dataSize != msg.DataSize {
+		parity = &receivedParity{
+			header:   msg.PartsHeader,
+			dataSize: msg.DataSize,
+			shards:   make(map[int][]byte),
+		}
+		cs.blockPartsParity.Store(msg.Height, parity)
+	}
+	if _, ok := parity.shards[msg.Index]; ok {
+		heightRound.Unlock()
+		return false, nil
+	}
+	parity.shards[msg.Index] = msg.Bytes
+	if msg.Index > parity.maxIndex {
+		parity.maxIndex = msg.Index
+	}
+	if parts.Count()+len(parity.shards) < parts.Total() {
+		heightRound.Unlock()
+		return true, nil
+	}
+
+	rebuilt, err := rebuildBlockParts(parts, parity)
+	// start over with the next parity parts if the peers sent wrong ones
+	cs.blockPartsParity.Delete(msg.Height)
+	heightRound.Unlock()
+	if err != nil {
+		return true, fmt.Errorf("failed to rebuild the block parts: %v", err)
+	}
+
+	cs.Logger.Info("Rebuilt the block parts from parity parts", "height", msg.Height,
+		"parts", parts.Count(), "parity", len(parity.shards), "total", parts.Total())
+	cs.metrics.BlockPartsRebuilt.Add(float64(rebuilt.Total() - parts.Count()))
+	for i := 0; i < rebuilt.Total(); i++ {
+		part := rebuilt.GetPart(i)
+		if _, err := cs.addProposalBlockPart(&BlockPartMessage{Height: msg.Height, Round: msg.Round, Part: part}, peerID); err != nil {
+			return true, err
+		}
+	}
+	return true, nil
+}
+
+//-----------------------------------------------------------------------------
+// ConsensusReactor
+
+// parityCache is the parity parts of the proposal block parts of the heights
+// in progress, made once to be sent to all the peers.
+type parityCache struct {
+	mtx     sync.Mutex
+	heights map[int64]*blockPartsParity
+}
+
+// get returns the parity parts of the complete parts of height, making them if
+// they're not cached, and prunes the heights up to lastHeight.
+func (pc *parityCache) get(height, lastHeight int64, parts *types.PartSet, parity int) (*blockPartsParity, error) {
+	pc.mtx.Lock()
+	defer pc.mtx.Unlock()
+
+	if pc.heights == nil {
+		pc.heights = make(map[int64]*blockPartsParity)
+	}
+	if p, ok := pc.heights[height]; ok && p.header.Equals(parts.Header()) {
+		return p, nil
+	}
+	for h := range pc.heights {
+		if h <= lastHeight {
+			delete(pc.heights, h)
+		}
+	}
+
+	p, err := makeBlockPartsParity(height, parts, parity)
+	if err != nil || p == nil {
+		return nil, err
+	}
+	pc.heights[height] = p
+	return p, nil
+}
+
+// gossipBlockPartsParity sends the peer the next parity part of the complete
+// proposal block parts of rs it wasn't sent, if it misses parts.
+func (conR *ConsensusReactor) gossipBlockPartsParity(rs *cstypes.RoundState, peer p2p.Peer, ps *PeerState, prs *cstypes.PeerRoundState) {
+	parity := conR.conS.getConfig().BlockPartsParity
+	if parity <= 0 || !rs.ProposalBlockParts.IsComplete() || prs.ProposalBlockParts == nil || prs.ProposalBlockParts.IsFull() {
+		return
+	}
+
+	p, err := conR.parity.get(rs.Height, conR.conS.GetLastHeight(), rs.ProposalBlockParts, parity)
+	if err != nil {
+		conR.Logger.Error("Failed to make the parity parts", "height", rs.Height, "err", err)
+		return
+	}
+	if p == nil {
+		return
+	}
+	index, ok := ps.PickParityIndex(rs.Height, rs.Round, p.header, len(p.shards))
+	if !ok {
+		return
+	}
+	msg := &BlockPartParityMessage{
+		Height:      rs.Height,
+		Round:       rs.Round,
+		PartsHeader: p.header,
+		DataSize:    p.dataSize,
+		Index:       index,
+		Bytes:       p.shards[index],
+	}
+	if peer.Send(DataParityChannel, conR.encodeMsg(peer, msg)) {
+		ps.SetHasParity(rs.Height, rs.Round, p.header, index)
+	}
+}
+
+// peerParity is the parity parts sent to a peer for the proposal block parts
+// with header of a height and round.
+type peerParity struct {
+	round  int
+	header types.PartSetHeader
+	sent   *cmn.BitArray
+}
+
+// PickParityIndex returns a random index of the n parity parts of the
+// proposal block parts with header of height and round the peer wasn't sent.
+func (ps *PeerState) PickParityIndex(height int64, round int, header types.PartSetHeader, n int) (int, bool) {
+	ps.mtx.Lock()
+	defer ps.mtx.Unlock()
+
+	if ps.parity == nil {
+		ps.parity = make(map[int64]*peerParity)
+	}
+	// forget the heights the peer is done with
+	for h := range ps.parity {
+		if _, ok := ps.PRS.Load(h); !ok {
+			delete(ps.parity, h)
+		}
+	}
+	p, ok := ps.parity[height]
+	if !ok || p.round != round || !p.header.Equals(header) || p.sent.Size() != n {
+		p = &peerParity{round: round, header: header, sent: cmn.NewBitArray(n)}
+		ps.parity[height] = p
+	}
+	return p.sent.Not().PickRandom()
+}
+
+// SetHasParity sets the parity part index of the proposal block parts with
+// header of height and round as sent to the peer.
+func (ps *PeerState) SetHasParity(height int64, round int, header types.PartSetHeader, index int) {
+	ps.mtx.Lock()
+	defer ps.mtx.Unlock()
+
+	if p, ok := ps.parity[height]; ok && p.round == round && p.header.Equals(header) {
+		p.sent.SetIndex(index, true)
+	}
+}
@@ -0,0 +1,82 @@
+package friday
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	cmn "github.com/hdac-io/tendermint/libs/common"
+	"github.com/hdac-io/tendermint/types"
+)
+
+func TestRebuildBlockParts(t *testing.T) {
+	data := cmn.RandBytes(10*types.BlockPartSizeBytes + 100)
+	parts := types.NewPartSetFromData(data, types.BlockPartSizeBytes)
+	require.Equal(t, 11, parts.Total())
+
+	parity, err := makeBlockPartsParity(1, parts, 30)
+	require.NoError(t, err)
+	require.Len(t, parity.shards, 4)
+
+	// lose 4 parts
+	received := types.NewPartSetFromHeader(parts.Header())
+	for i := 4; i < parts.Total(); i++ {
+		_, err := received.AddPart(parts.GetPart(i))
+		require.NoError(t, err)
+	}
+	receivedParity := &receivedParity{header: parity.header, dataSize: parity.dataSize, shards: make(map[int][]byte)}
+	for i, shard := range parity.shards {
+		receivedParity.shards[i] = shard
+		receivedParity.maxIndex = i
+	}
+	rebuilt, err := rebuildBlockParts(received, receivedParity)
+	require.NoError(t, err)
+	for i := 0; i < parts.Total(); i++ {
+		assert.Equal(t, parts.GetPart(i).Bytes, rebuilt.GetPart(i).Bytes)
+	}
+
+	// a corrupted parity part doesn't rebuild the parts
+	corrupted := append([]byte{}, parity.shards[0]...)
+	corrupted[0]++
+	receivedParity.shards[0] = corrupted
+	_, err = rebuildBlockParts(received, receivedParity)
+	assert.Error(t, err)
+
+	// a single part gets no parity part
+	single := types.NewPartSetFromData(data[:100], types.BlockPartSizeBytes)
+	parity, err = makeBlockPartsParity(1, single, 30)
+	assert.NoError(t, err)
+	assert.Nil(t, parity)
+}
+
+func TestBlockPartParityMessageValidateBasic(t *testing.T) {
+	parts := types.NewPartSetFromData(cmn.RandBytes(3*types.BlockPartSizeBytes), types.BlockPartSizeBytes)
+	parity, err := makeBlockPartsParity(1, parts, 50)
+	require.NoError(t, err)
+
+	valid := func() *BlockPartParityMessage {
+		return &BlockPartParityMessage{
+			Height:      1,
+			PartsHeader: parity.header,
+			DataSize:    parity.dataSize,
+			Index:       1,
+			Bytes:       parity.shards[1],
+		}
+	}
+	assert.NoError(t, valid().ValidateBasic())
+
+	for _, tc := range []func(*BlockPartParityMessage){
+		func(m *BlockPartParityMessage) { m.Height = -1 },
+		func(m *BlockPartParityMessage) { m.Round = -1 },
+		func(m *BlockPartParityMessage) { m.PartsHeader.Total = 1 },
+		func(m *BlockPartParityMessage) { m.Index = -1 },
+		func(m *BlockPartParityMessage) { m.Index = 254 },
+		func(m *BlockPartParityMessage) { m.DataSize = 2 * types.BlockPartSizeBytes },
+		func(m *BlockPartParityMessage) { m.Bytes = m.Bytes[1:] },
+	} {
+		msg := valid()
+		tc(msg)
+		assert.Error(t, msg.ValidateBasic(), "%v", msg)
+	}
+}
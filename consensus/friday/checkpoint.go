@@ -0,0 +1,488 @@
+package friday
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"runtime/debug"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	cstypes "github.com/hdac-io/tendermint/consensus/types"
+	"github.com/hdac-io/tendermint/crypto"
+	cmn "github.com/hdac-io/tendermint/libs/common"
+	"github.com/hdac-io/tendermint/types"
+)
+
+// Checkpoints of the RoundStates of the heights in progress, to resume them
+// on restart from the checkpoint and the WAL records written after it,
+// instead of replaying the WAL of all of them.
+//
+// A checkpoint first writes a CheckpointMessage to the WAL, then waits for the
+// handlers of the records written before it to return, and saves the
+// RoundStates. They hold the effects of all the records before the
+// CheckpointMessage, and maybe of some after it, which are replayed again on
+// restart: adding a vote, a proposal or a block part again does nothing, and
+// the timeouts of passed steps are ignored.
+
+// the flags of the checkpoint file, before the encoded checkpoint
+const (
+	checkpointPlain     = byte(0)
+	checkpointEncrypted = byte(1)
+)
+
+// roundStateCheckpoint is the content of the checkpoint file.
+type roundStateCheckpoint struct {
+	ChainID string `json:"chain_id"`
+	// Seq of the CheckpointMessage written to the WAL before the checkpoint
+	Seq             int64                  `json:"seq"`
+	LastBlockHeight int64                  `json:"last_block_height"`
+	RoundStates     []checkpointRoundState `json:"round_states"`
+}
+
+// checkpointRoundState is a RoundState without the fields rebuilt from the
+// state, i.e. the validators and the LastCommit.
+type checkpointRoundState struct {
+	Height     int64                 `json:"height"`
+	Round      int                   `json:"round"`
+	Step       cstypes.RoundStepType `json:"step"`
+	StartTime  time.Time             `json:"start_time"`
+	CommitTime time.Time             `json:"commit_time"`
+	Proposal   *types.Proposal       `json:"proposal"`
+
+	// the distinct part sets of the proposal, locked and valid blocks, which
+	// are usually the same, referenced by their index (-1 for none)
+	PartSets           []checkpointPartSet `json:"part_sets"`
+	ProposalBlockParts int                 `json:"proposal_block_parts"`
+	LockedRound        int                 `json:"locked_round"`
+	LockedBlockParts   int                 `json:"locked_block_parts"`
+	ValidRound         int                 `json:"valid_round"`
+	ValidBlockParts    int                 `json:"valid_block_parts"`
+
+	CommitRound               int               `json:"commit_round"`
+	TriggeredTimeoutPrecommit bool              `json:"triggered_timeout_precommit"`
+	CommitAggregate           *types.QuorumCert `json:"commit_aggregate"`
+	Votes                     []*types.Vote     `json:"votes"`
+}
+
+// checkpointPartSet is a PartSet with the parts received so far.
+type checkpointPartSet struct {
+	Header types.PartSetHeader `json:"header"`
+	Parts  []*types.Part       `json:"parts"`
+}
+
+// checkpointC returns the channel of the checkpoint ticks, nil if the
+// checkpoints are disabled.
+func (cs *ConsensusState) checkpointC() <-chan time.Time {
+	if cs.checkpointTicker == nil {
+		return nil
+	}
+	return cs.checkpointTicker.C
+}
+
+// goHandle runs handle in a goroutine, counted among the handlers of the
+// records written to the WAL since the last checkpoint.
+func (cs *ConsensusState) goHandle(handle func()) {
+	cs.goHandleIn(cs.handlers, handle)
+}
+
+// goHandleIn runs handle in a goroutine, counted in handlers and in the
+// running handlers Wait waits for. A panic of handle is a failure of the
+// receiveRoutine, see failHandler.
+func (cs *ConsensusState) goHandleIn(handlers *sync.WaitGroup, handle func()) {
+	handlers.Add(1)
+	cs.runningHandlers.Add(1)
+	go func() {
+		defer cs.runningHandlers.Done()
+		defer handlers.Done()
+		defer func() {
+			if r := recover(); r != nil {
+				cs.failHandler(r, debug.Stack())
+			}
+		}()
+		handle()
+	}()
+}
+
+// startCheckpoint writes a CheckpointMessage to the WAL, and saves the
+// checkpoint once the handlers of the records before it returned. It must be
+// called by the receiveRoutine. It does nothing while the previous checkpoint
+// is waiting for its handlers.
+func (cs *ConsensusState) startCheckpoint() {
+	if !atomic.CompareAndSwapInt32(&cs.checkpointing, 0, 1) {
+		return
+	}
+	// unique across the restarts, for the WAL to be searched for it
+	seq := time.Now().UnixNano()
+	if seq <= cs.checkpointSeq {
+		seq = cs.checkpointSeq + 1
+	}
+	cs.checkpointSeq = seq
+	if err := cs.wal.WriteSync(CheckpointMessage{Seq: seq}); err != nil {
+		cs.Logger.Error("Failed to write the checkpoint to the WAL", "err", err)
+		atomic.StoreInt32(&cs.checkpointing, 0)
+		return
+	}
+
+	handlers := cs.handlers
+	cs.handlers = new(sync.WaitGroup)
+	go func() {
+		defer atomic.StoreInt32(&cs.checkpointing, 0)
+		handlers.Wait()
+
+		cp := cs.makeCheckpoint(seq)
+		if err := saveCheckpoint(cs.getConfig().CheckpointFile(), cp, cs.walSym, cs.walSecret); err != nil {
+			cs.Logger.Error("Failed to save the checkpoint", "err", err)
+			return
+		}
+		cs.Logger.Debug("Saved the checkpoint", "seq", seq, "heights", len(cp.RoundStates))
+	}()
+}
+
+// makeCheckpoint returns the checkpoint of the RoundStates of the heights
+// above the last block height, after the CheckpointMessage seq.
+func (cs *ConsensusState) makeCheckpoint(seq int64) *roundStateCheckpoint {
+	state := cs.GetState()
+	cp := &roundStateCheckpoint{
+		ChainID:         state.ChainID,
+		Seq:             seq,
+		LastBlockHeight: state.LastBlockHeight,
+	}
+
+	// without the votes, only the ones of the node are kept: the peers gossip
+	// theirs again, but the node doesn't sign its own again
+	var voter crypto.Address
+	if !cs.getConfig().CheckpointVotes && cs.privValidator != nil {
+		voter = cs.privValidator.GetPubKey().Address()
+	}
+	for _, height := range cs.roundStateHeights() {
+		if height <= state.LastBlockHeight {
+			continue
+		}
+		rs := cs.getRoundState(height)
+		if rs == nil {
+			continue
+		}
+		rs.RLock()
+		crs := newCheckpointRoundState(rs, cs.getConfig().CheckpointVotes, voter)
+		rs.RUnlock()
+		cp.RoundStates = append(cp.RoundStates, crs)
+	}
+	return cp
+}
+
+// roundStateHeights returns the heights of the RoundStates, in ascending
+// order.
+func (cs *ConsensusState) roundStateHeights() []int64 {
+	var heights []int64
+	cs.roundStates.Range(func(key, value interface{}) bool {
+		heights = append(heights, key.(int64))
+		return true
+	})
+	sort.Slice(heights, func(i, j int) bool { return heights[i] < heights[j] })
+	return heights
+}
+
+// newCheckpointRoundState returns the checkpoint of rs, with all the votes if
+// allVotes, else only the ones of voter.
+func newCheckpointRoundState(rs *cstypes.RoundState, allVotes bool, voter crypto.Address) checkpointRoundState {
+	crs := checkpointRoundState{
+		Height:                    rs.Height,
+		Round:                     rs.Round,
+		Step:                      rs.Step,
+		StartTime:                 rs.StartTime,
+		CommitTime:                rs.CommitTime,
+		Proposal:                  rs.Proposal,
+		LockedRound:               rs.LockedRound,
+		ValidRound:                rs.ValidRound,
+		CommitRound:               rs.CommitRound,
+		TriggeredTimeoutPrecommit: rs.TriggeredTimeoutPrecommit,
+		CommitAggregate:           rs.CommitAggregate,
+	}
+
+	var partSets []*types.PartSet
+	partSetIndex := func(ps *types.PartSet) int {
+		if ps == nil {
+			return -1
+		}
+		for i, other := range partSets {
+			if other == ps {
+				return i
+			}
+		}
+		cps := checkpointPartSet{Header: ps.Header()}
+		for i := 0; i < ps.Total(); i++ {
+			if part := ps.GetPart(i); part != nil {
+				cps.Parts = append(cps.Parts, part)
+			}
+		}
+		partSets = append(partSets, ps)
+		crs.PartSets = append(crs.PartSets, cps)
+		return len(partSets) - 1
+	}
+	crs.ProposalBlockParts = partSetIndex(rs.ProposalBlockParts)
+	crs.LockedBlockParts = partSetIndex(rs.LockedBlockParts)
+	crs.ValidBlockParts = partSetIndex(rs.ValidBlockParts)
+
+	if rs.Votes != nil {
+		for _, vote := range rs.Votes.Votes() {
+			if allVotes || bytes.Equal(vote.ValidatorAddress, voter) {
+				crs.Votes = append(crs.Votes, vote)
+			}
+		}
+	}
+	return crs
+}
+
+// saveCheckpoint writes cp to path, encrypted with sym and secret if sym
+// isn't nil.
+func saveCheckpoint(path string, cp *roundStateCheckpoint, sym crypto.Symmetric, secret []byte) error {
+	bz, err := cdc.MarshalBinaryBare(cp)
+	if err != nil {
+		return err
+	}
+	flag := checkpointPlain
+	if sym != nil {
+		flag = checkpointEncrypted
+		bz = sym.Encrypt(bz, secret)
+	}
+	if err := cmn.EnsureDir(filepath.Dir(path), 0700); err != nil {
+		return err
+	}
+	return cmn.WriteFileAtomic(path, append([]byte{flag}, bz...), 0600)
+}
+
+// loadCheckpoint reads the checkpoint written to path by saveCheckpoint.
+func loadCheckpoint(path string, sym crypto.Symmetric, secret []byte) (*roundStateCheckpoint, error) {
+	bz, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	if len(bz) == 0 {
+		return nil, fmt.Errorf("empty checkpoint file %s", path)
+	}
+	flag, bz := bz[0], bz[1:]
+	switch flag {
+	case checkpointPlain:
+	case checkpointEncrypted:
+		if sym == nil {
+			return nil, fmt.Errorf("checkpoint %s is encrypted, but the WAL encryption is off", path)
+		}
+		if bz, err = sym.Decrypt(bz, secret); err != nil {
+			return nil, fmt.Errorf("failed to decrypt the checkpoint %s: %v", path, err)
+		}
+	default:
+		return nil, fmt.Errorf("unknown checkpoint flag %d", flag)
+	}
+	cp := new(roundStateCheckpoint)
+	if err := cdc.UnmarshalBinaryBare(bz, cp); err != nil {
+		return nil, fmt.Errorf("failed to decode the checkpoint %s: %v", path, err)
+	}
+	return cp, nil
+}
+
+// restoreCheckpoint restores the RoundStates of the checkpoint file, if the
+// checkpoints are enabled, and returns a reader of the WAL records after its
+// CheckpointMessage. It returns false if there is no checkpoint to resume
+// from, and the WAL must be replayed from the first height in progress.
+//
+// CONTRACT: caller must close the reader.
+func (cs *ConsensusState) restoreCheckpoint() (io.ReadCloser, bool) {
+	if cs.getConfig().CheckpointInterval <= 0 {
+		return nil, false
+	}
+	cp, err := loadCheckpoint(cs.getConfig().CheckpointFile(), cs.walSym, cs.walSecret)
+	if os.IsNotExist(err) {
+		return nil, false
+	}
+	if err != nil {
+		cs.Logger.Error("Ignoring the checkpoint", "err", err)
+		return nil, false
+	}
+	if cp.ChainID != cs.state.ChainID || cp.LastBlockHeight > cs.state.LastBlockHeight {
+		cs.Logger.Error("Ignoring the checkpoint of another chain or state", "chainID", cp.ChainID,
+			"lastBlockHeight", cp.LastBlockHeight)
+		return nil, false
+	}
+
+	restored, err := cs.prepareRoundStates(cp)
+	if err != nil {
+		cs.Logger.Error("Ignoring the invalid checkpoint", "err", err)
+		return nil, false
+	}
+	gr, found, err := cs.wal.SearchForCheckpoint(cp.Seq, &WALSearchOptions{IgnoreDataCorruptionErrors: true})
+	if err != nil || !found {
+		cs.Logger.Info("Checkpoint not found in the WAL, replaying the heights in progress", "seq", cp.Seq, "err", err)
+		return nil, false
+	}
+
+	for _, rrs := range restored {
+		cs.restoreRoundState(rrs)
+	}
+	cs.checkpointSeq = cp.Seq
+	cs.Logger.Info("Restored the checkpoint", "seq", cp.Seq, "heights", len(restored))
+	return gr, true
+}
+
+// restoredRoundState is a checkpointRoundState with the part sets and blocks
+// rebuilt.
+type restoredRoundState struct {
+	checkpointRoundState
+	partSets []*types.PartSet
+	blocks   []*types.Block
+}
+
+func (rrs *restoredRoundState) partSet(i int) (*types.PartSet, *types.Block) {
+	if i < 0 {
+		return nil, nil
+	}
+	return rrs.partSets[i], rrs.blocks[i]
+}
+
+// prepareRoundStates rebuilds the part sets and blocks of the RoundStates of
+// cp above the last block height, and verifies the votes, before any is
+// restored.
+func (cs *ConsensusState) prepareRoundStates(cp *roundStateCheckpoint) ([]*restoredRoundState, error) {
+	var restored []*restoredRoundState
+	for _, crs := range cp.RoundStates {
+		if crs.Height <= cs.state.LastBlockHeight {
+			continue
+		}
+		if crs.Proposal != nil && crs.Proposal.Height != crs.Height {
+			return nil, fmt.Errorf("proposal of height %d in the RoundState of height %d", crs.Proposal.Height, crs.Height)
+		}
+		rrs := &restoredRoundState{checkpointRoundState: crs}
+		for _, cps := range crs.PartSets {
+			ps := types.NewPartSetFromHeader(cps.Header)
+			for _, part := range cps.Parts {
+				if _, err := ps.AddPart(part); err != nil {
+					return nil, fmt.Errorf("invalid part of height %d: %v", crs.Height, err)
+				}
+			}
+			var block *types.Block
+			if ps.IsComplete() {
+				_, err := cdc.UnmarshalBinaryLengthPrefixedReader(ps.GetReader(), &block,
+					cs.state.ConsensusParamsAt(crs.Height).Block.MaxBytes)
+				if err != nil {
+					return nil, fmt.Errorf("invalid block of height %d: %v", crs.Height, err)
+				}
+			}
+			rrs.partSets = append(rrs.partSets, ps)
+			rrs.blocks = append(rrs.blocks, block)
+		}
+		for _, i := range []int{crs.ProposalBlockParts, crs.LockedBlockParts, crs.ValidBlockParts} {
+			if i < -1 || i >= len(rrs.partSets) {
+				return nil, fmt.Errorf("invalid part set %d of height %d", i, crs.Height)
+			}
+		}
+		restored = append(restored, rrs)
+	}
+	return restored, nil
+}
+
+// restoreRoundState restores the RoundState of rrs, created if needed. The
+// votes are added like the ones of the WAL, so the invalid ones are skipped.
+func (cs *ConsensusState) restoreRoundState(rrs *restoredRoundState) {
+	rs := cs.getRoundState(rrs.Height)
+	if rs == nil {
+		cs.updateHeight(rrs.Height)
+		rs = cs.getRoundState(rrs.Height)
+	}
+	rs.Lock()
+	defer rs.Unlock()
+
+	rs.Round = rrs.Round
+	rs.Step = rrs.Step
+	rs.StartTime = rrs.StartTime
+	rs.CommitTime = rrs.CommitTime
+	rs.Proposal = rrs.Proposal
+	rs.ProposalBlockParts, rs.ProposalBlock = rrs.partSet(rrs.ProposalBlockParts)
+	rs.LockedRound = rrs.LockedRound
+	rs.LockedBlockParts, rs.LockedBlock = rrs.partSet(rrs.LockedBlockParts)
+	rs.ValidRound = rrs.ValidRound
+	rs.ValidBlockParts, rs.ValidBlock = rrs.partSet(rrs.ValidBlockParts)
+	rs.CommitRound = rrs.CommitRound
+	rs.TriggeredTimeoutPrecommit = rrs.TriggeredTimeoutPrecommit
+	rs.CommitAggregate = rrs.CommitAggregate
+
+	// also track the next round, like enterNewRound
+	if rs.Votes.Round() < rrs.Round+1 {
+		rs.Votes.SetRound(rrs.Round + 1)
+	}
+	if pubKeys, votes := cs.voteKeys(rs, rrs.Votes); len(votes) > 1 {
+		types.BatchVerifyVotes(cs.state.ChainID, votes, pubKeys)
+	}
+	for _, vote := range rrs.Votes {
+		if _, err := rs.Votes.AddVote(vote, ""); err != nil {
+			cs.Logger.Error("Failed to restore a vote", "vote", vote, "err", err)
+		}
+	}
+
+	if rs.ProposalBlock != nil {
+		cs.blockExec.ReserveBlock(cs.state, rs.ProposalBlock)
+	}
+}
+
+// voteKeys returns the public keys of the validators of the votes, for the
+// votes of the validators of rs.
+func (cs *ConsensusState) voteKeys(rs *cstypes.RoundState, votes []*types.Vote) ([]crypto.PubKey, []*types.Vote) {
+	pubKeys := make([]crypto.PubKey, 0, len(votes))
+	known := make([]*types.Vote, 0, len(votes))
+	for _, vote := range votes {
+		_, val := rs.Validators.GetByIndex(vote.ValidatorIndex)
+		if val == nil || !bytes.Equal(val.Address, vote.ValidatorAddress) {
+			continue
+		}
+		pubKeys = append(pubKeys, val.PubKey)
+		known = append(known, vote)
+	}
+	return pubKeys, known
+}
+
+// resumeRestoredHeights schedules, for each height in progress after the
+// checkpoint was restored and the WAL after it replayed, the timeout of its
+// step, or finalizes it if it committed, for the height to go on: the
+// timeouts scheduled before the restart are lost. The heights waiting for
+// +2/3 prevotes or precommits go on with the votes gossiped again.
+func (cs *ConsensusState) resumeRestoredHeights() {
+	for _, height := range cs.roundStateHeights() {
+		if height <= cs.state.LastBlockHeight {
+			continue
+		}
+		rs := cs.getRoundState(height)
+		if rs == nil {
+			continue
+		}
+		rs.RLock()
+		round, step := rs.Round, rs.Step
+		rs.RUnlock()
+
+		timeouts := cs.timeoutConfig(height)
+		switch step {
+		case cstypes.RoundStepNewHeight:
+			// the first height in progress is scheduled by OnStart
+			if height > cs.state.LastBlockHeight+1 {
+				cs.scheduleNewHeightRound0(height)
+			}
+		case cstypes.RoundStepNewRound:
+			cs.scheduleTimeout(0, height, round, cstypes.RoundStepNewRound)
+		case cstypes.RoundStepPropose:
+			cs.scheduleTimeout(timeouts.Propose(round), height, round, cstypes.RoundStepPropose)
+		case cstypes.RoundStepPrevoteWait:
+			cs.scheduleTimeout(timeouts.Prevote(round), height, round, cstypes.RoundStepPrevoteWait)
+		case cstypes.RoundStepPrecommitWait:
+			cs.scheduleTimeout(timeouts.Precommit(round), height, round, cstypes.RoundStepPrecommitWait)
+		case cstypes.RoundStepCommit:
+			go func(height int64) {
+				rs.Lock()
+				defer rs.Unlock()
+				cs.tryFinalizeCommit(height)
+			}(height)
+		}
+	}
+}
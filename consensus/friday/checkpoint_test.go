@@ -0,0 +1,163 @@
+package friday
+
+import (
+	"io/ioutil"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hdac-io/tendermint/crypto/xsalsa20symmetric"
+	"github.com/hdac-io/tendermint/libs/log"
+	sm "github.com/hdac-io/tendermint/state"
+)
+
+func TestCheckpointRoundTrip(t *testing.T) {
+	seed := time.Now().UnixNano()
+	t.Logf("seed %d", seed)
+	h := newFuzzHarness(t, rand.New(rand.NewSource(seed)), 3)
+	defer h.cleanup()
+
+	require.NoError(t, h.cs.Start())
+	for i := 0; i < 300; i++ {
+		h.step()
+	}
+	require.NoError(t, h.cs.Stop())
+	h.cs.Wait()
+
+	cp := h.cs.makeCheckpoint(42)
+	require.NotEmpty(t, cp.RoundStates)
+
+	// saved and loaded, with and without encryption
+	path := filepath.Join(h.dir, "cs.checkpoint")
+	sym := xsalsa20symmetric.Symmetric{}
+	secret := sym.Keygen()
+	for _, encrypted := range []bool{false, true} {
+		if encrypted {
+			require.NoError(t, saveCheckpoint(path, cp, sym, secret))
+			_, err := loadCheckpoint(path, nil, nil)
+			assert.Error(t, err)
+			_, err = loadCheckpoint(path, sym, sym.Keygen())
+			assert.Error(t, err)
+		} else {
+			require.NoError(t, saveCheckpoint(path, cp, nil, nil))
+		}
+		loaded, err := loadCheckpoint(path, sym, secret)
+		require.NoError(t, err)
+		assert.Equal(t, cdc.MustMarshalBinaryBare(cp), cdc.MustMarshalBinaryBare(loaded))
+	}
+
+	// restored in a new ConsensusState
+	cs := NewConsensusState(h.cs.config, h.cs.GetState(), h.cs.blockExec, h.cs.blockStore, h.mempool,
+		sm.MockEvidencePool{})
+	cs.SetLogger(log.TestingLogger())
+	restored, err := cs.prepareRoundStates(cp)
+	require.NoError(t, err)
+	require.Len(t, restored, len(cp.RoundStates))
+	for _, rrs := range restored {
+		cs.restoreRoundState(rrs)
+	}
+	for _, crs := range cp.RoundStates {
+		want := h.cs.GetRoundState(crs.Height)
+		got := cs.GetRoundState(crs.Height)
+		require.NotNil(t, got)
+		assert.Equal(t, want.Round, got.Round, "height %d", crs.Height)
+		assert.Equal(t, want.Step, got.Step, "height %d", crs.Height)
+		assert.Equal(t, want.LockedRound, got.LockedRound, "height %d", crs.Height)
+		assert.Equal(t, want.ValidRound, got.ValidRound, "height %d", crs.Height)
+		assert.Equal(t, want.CommitRound, got.CommitRound, "height %d", crs.Height)
+		assert.Equal(t, want.Proposal, got.Proposal, "height %d", crs.Height)
+		assert.Equal(t, want.ProposalBlock.Hash(), got.ProposalBlock.Hash(), "height %d", crs.Height)
+		assert.Equal(t, want.LockedBlock.Hash(), got.LockedBlock.Hash(), "height %d", crs.Height)
+		assert.Equal(t, len(want.Votes.Votes()), len(got.Votes.Votes()), "height %d", crs.Height)
+	}
+
+	// the part sets are referenced in range
+	cp.RoundStates[0].ProposalBlockParts = len(cp.RoundStates[0].PartSets)
+	_, err = cs.prepareRoundStates(cp)
+	assert.Error(t, err)
+}
+
+func TestCheckpointOwnVotesOnly(t *testing.T) {
+	h := newFuzzHarness(t, rand.New(rand.NewSource(1)), 1)
+	defer h.cleanup()
+
+	require.NoError(t, h.cs.Start())
+	for i := 0; i < 200; i++ {
+		h.step()
+	}
+	require.NoError(t, h.cs.Stop())
+	h.cs.Wait()
+
+	h.cs.config.CheckpointVotes = false
+	address := h.cs.privValidator.GetPubKey().Address()
+	for _, crs := range h.cs.makeCheckpoint(1).RoundStates {
+		for _, vote := range crs.Votes {
+			assert.Equal(t, address, vote.ValidatorAddress)
+		}
+	}
+}
+
+func TestCheckpointTicker(t *testing.T) {
+	h := newFuzzHarness(t, rand.New(rand.NewSource(1)), 2)
+	defer h.cleanup()
+	h.cs.config.CheckpointInterval = 5 * time.Millisecond
+
+	require.NoError(t, h.cs.Start())
+	for i := 0; i < 100; i++ {
+		h.step()
+		time.Sleep(time.Millisecond)
+	}
+	// let the last checkpoint wait for its handlers
+	time.Sleep(50 * time.Millisecond)
+	require.NoError(t, h.cs.Stop())
+	h.cs.Wait()
+
+	cp, err := loadCheckpoint(h.cs.config.CheckpointFile(), nil, nil)
+	require.NoError(t, err)
+	assert.Equal(t, fuzzChainID, cp.ChainID)
+
+	wal, err := NewWAL(h.cs.config.WalFile())
+	require.NoError(t, err)
+	wal.SetLogger(log.TestingLogger())
+	gr, found, err := wal.SearchForCheckpoint(cp.Seq, &WALSearchOptions{})
+	require.NoError(t, err)
+	require.True(t, found)
+	gr.Close()
+}
+
+func TestWALSearchForCheckpoint(t *testing.T) {
+	dir, err := ioutil.TempDir("", "friday_checkpoint")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	wal, err := NewWAL(filepath.Join(dir, "wal"))
+	require.NoError(t, err)
+	wal.SetLogger(log.TestingLogger())
+	require.NoError(t, wal.Start())
+	defer func() {
+		wal.Stop()
+		wal.Wait()
+	}()
+
+	require.NoError(t, wal.WriteSync(EndHeightMessage{1}))
+	require.NoError(t, wal.WriteSync(CheckpointMessage{Seq: 5}))
+	require.NoError(t, wal.WriteSync(timeoutInfo{Height: 2, Round: 1, Step: 3}))
+	require.NoError(t, wal.WriteSync(CheckpointMessage{Seq: 7}))
+
+	gr, found, err := wal.SearchForCheckpoint(5, &WALSearchOptions{})
+	require.NoError(t, err)
+	require.True(t, found)
+	defer gr.Close()
+	msg, err := NewWALDecoder(gr).Decode()
+	require.NoError(t, err)
+	assert.Equal(t, timeoutInfo{Height: 2, Round: 1, Step: 3}, msg.Msg)
+
+	_, found, err = wal.SearchForCheckpoint(6, &WALSearchOptions{})
+	require.NoError(t, err)
+	assert.False(t, found)
+}
@@ -0,0 +1,88 @@
+package friday
+
+import (
+	"sync"
+	"time"
+
+	"github.com/hdac-io/tendermint/types"
+	tmtime "github.com/hdac-io/tendermint/types/time"
+)
+
+// clockSkew keeps the offset of the last vote timestamp of every validator
+// from the local clock, when the vote was received. As the median of the
+// vote timestamps is the time of the next blocks, a local clock far from the
+// median of the offsets makes our votes and proposals late or early for the
+// others. The offsets include the network delay, so they are negative on a
+// network of synchronized clocks.
+type clockSkew struct {
+	mtx     sync.Mutex
+	offsets map[string]time.Duration // by validator address
+	skewed  bool                     // if the last median was above the max
+}
+
+func newClockSkew() *clockSkew {
+	return &clockSkew{offsets: make(map[string]time.Duration)}
+}
+
+func (s *clockSkew) observe(address types.Address, offset time.Duration) {
+	s.mtx.Lock()
+	s.offsets[string(address)] = offset
+	s.mtx.Unlock()
+}
+
+// median returns the median of the offsets of the validators, weighted by
+// their voting power. It returns false if the validators with an offset
+// don't have more than 1/3 of the voting power.
+func (s *clockSkew) median(validators *types.ValidatorSet) (time.Duration, bool) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	var (
+		ref     = time.Unix(0, 0)
+		times   []*tmtime.WeightedTime
+		sampled int64
+	)
+	for _, val := range validators.Validators {
+		if offset, ok := s.offsets[string(val.Address)]; ok {
+			times = append(times, tmtime.NewWeightedTime(ref.Add(offset), val.VotingPower))
+			sampled += val.VotingPower
+		}
+	}
+	if sampled*3 <= validators.TotalVotingPower() {
+		return 0, false
+	}
+	return tmtime.WeightedMedian(times, sampled).Sub(ref), true
+}
+
+// observeVoteTime records the offset of the timestamp of a vote of a peer
+// from our clock.
+func (cs *ConsensusState) observeVoteTime(vote *types.Vote) {
+	cs.clockSkew.observe(vote.ValidatorAddress, vote.Timestamp.Sub(cs.clock.Now()))
+}
+
+// checkClockSkew reports the median offset of the vote timestamps of the
+// validators, and warns when it exceeds MaxClockSkew.
+func (cs *ConsensusState) checkClockSkew(validators *types.ValidatorSet) {
+	skew, ok := cs.clockSkew.median(validators)
+	if !ok {
+		return
+	}
+	cs.metrics.ClockSkewSeconds.Set(skew.Seconds())
+
+	max := cs.getConfig().MaxClockSkew
+	if max == 0 {
+		return
+	}
+	skewed := skew > max || skew < -max
+	cs.clockSkew.mtx.Lock()
+	changed := skewed != cs.clockSkew.skewed
+	cs.clockSkew.skewed = skewed
+	cs.clockSkew.mtx.Unlock()
+	switch {
+	case changed && skewed:
+		cs.Logger.Error("The local clock deviates from the vote times of the validators, check its synchronization (NTP)",
+			"skew", skew, "max", max)
+	case changed:
+		cs.Logger.Info("The local clock is back in line with the vote times of the validators", "skew", skew)
+	}
+}
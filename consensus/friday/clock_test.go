@@ -0,0 +1,105 @@
+package friday
+
+import (
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/go-kit/kit/metrics/generic"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hdac-io/tendermint/types"
+)
+
+// newClockSkewValidators returns the validators of powers, in that order,
+// and their validator set.
+func newClockSkewValidators(powers ...int64) ([]*types.Validator, *types.ValidatorSet) {
+	vals := make([]*types.Validator, len(powers))
+	for i, power := range powers {
+		vals[i] = types.NewValidator(types.NewMockPV().GetPubKey(), power)
+	}
+	return vals, types.NewValidatorSet(vals)
+}
+
+func TestClockSkewMedian(t *testing.T) {
+	ms := time.Millisecond
+	testCases := []struct {
+		name    string
+		powers  []int64
+		offsets []time.Duration // of the validators of powers, none if 0
+		median  time.Duration
+		ok      bool
+	}{
+		{"synchronized", []int64{10, 10, 10, 10}, []time.Duration{-30 * ms, -20 * ms, -10 * ms, -40 * ms}, -30 * ms, true},
+		{"one ahead", []int64{10, 10, 10, 10}, []time.Duration{-30 * ms, -20 * ms, -10 * ms, 2 * time.Second}, -20 * ms, true},
+		{"most ahead", []int64{10, 10, 10, 10}, []time.Duration{-30 * ms, 2 * time.Second, 3 * time.Second, 2 * time.Second}, 2 * time.Second, true},
+		{"weighted", []int64{10, 10, 10, 70}, []time.Duration{-30 * ms, -20 * ms, -10 * ms, 2 * time.Second}, 2 * time.Second, true},
+		{"over 1/3", []int64{10, 10, 10, 10}, []time.Duration{-10 * ms, time.Second, 0, 0}, -10 * ms, true},
+		{"1/3 or less", []int64{10, 10, 10}, []time.Duration{time.Second, 0, 0}, 0, false},
+		{"none", []int64{10, 10, 10, 10}, []time.Duration{0, 0, 0, 0}, 0, false},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			vals, valSet := newClockSkewValidators(tc.powers...)
+			s := newClockSkew()
+			// not a validator
+			s.observe(types.Address("other"), time.Hour)
+			for i, offset := range tc.offsets {
+				if offset != 0 {
+					s.observe(vals[i].Address, offset)
+				}
+			}
+			median, ok := s.median(valSet)
+			assert.Equal(t, tc.ok, ok)
+			assert.Equal(t, tc.median, median)
+		})
+	}
+
+	// only the last vote of a validator counts
+	vals, valSet := newClockSkewValidators(10)
+	s := newClockSkew()
+	s.observe(vals[0].Address, time.Second)
+	s.observe(vals[0].Address, -time.Second)
+	median, ok := s.median(valSet)
+	require.True(t, ok)
+	assert.Equal(t, -time.Second, median)
+}
+
+func TestCheckClockSkew(t *testing.T) {
+	h := newFuzzHarness(t, rand.New(rand.NewSource(1)), 1)
+	defer h.cleanup()
+	cs := h.cs
+	gauge := generic.NewGauge("clock_skew")
+	cs.metrics.ClockSkewSeconds = gauge
+	vals, valSet := newClockSkewValidators(10)
+	addr := vals[0].Address
+
+	testCases := []struct {
+		maxSkew time.Duration
+		skew    time.Duration
+		skewed  bool
+	}{
+		{500 * time.Millisecond, 100 * time.Millisecond, false},
+		{500 * time.Millisecond, 500 * time.Millisecond, false},
+		{500 * time.Millisecond, 600 * time.Millisecond, true},
+		{500 * time.Millisecond, -600 * time.Millisecond, true},
+		{500 * time.Millisecond, -500 * time.Millisecond, false},
+		{time.Second, 600 * time.Millisecond, false},
+		{500 * time.Millisecond, 600 * time.Millisecond, true},
+		// not checked, the last result stays
+		{0, 0, true},
+	}
+
+	for i, tc := range testCases {
+		config := *cs.getConfig()
+		config.MaxClockSkew = tc.maxSkew
+		cs.SetConfig(&config)
+		cs.clockSkew.observe(addr, tc.skew)
+		cs.checkClockSkew(valSet)
+		assert.Equal(t, tc.skewed, cs.clockSkew.skewed, "#%d", i)
+		assert.Equal(t, tc.skew.Seconds(), gauge.Value(), "#%d", i)
+	}
+}
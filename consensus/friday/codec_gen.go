@@ -0,0 +1,44 @@
+// Code generated by scripts/codecgen from its manifest. DO NOT EDIT.
+
+package friday
+
+import (
+	"github.com/hdac-io/tendermint/types"
+	amino "github.com/tendermint/go-amino"
+)
+
+var cdc = amino.NewCodec()
+
+func init() {
+	RegisterConsensusMessages(cdc)
+	RegisterWALMessages(cdc)
+	types.RegisterBlockAmino(cdc)
+}
+
+// RegisterConsensusMessages registers the messages of the consensus reactor.
+func RegisterConsensusMessages(cdc *amino.Codec) {
+	cdc.RegisterInterface((*ConsensusMessage)(nil), nil)
+	cdc.RegisterConcrete(&NewRoundStepMessage{}, "tendermint/NewRoundStepMessage", nil)
+	cdc.RegisterConcrete(&NewValidBlockMessage{}, "tendermint/NewValidBlockMessage", nil)
+	cdc.RegisterConcrete(&ProposalMessage{}, "tendermint/Proposal", nil)
+	cdc.RegisterConcrete(&ProposalPOLMessage{}, "tendermint/ProposalPOL", nil)
+	cdc.RegisterConcrete(&BlockPartMessage{}, "tendermint/BlockPart", nil)
+	cdc.RegisterConcrete(&BlockPartParityMessage{}, "tendermint/BlockPartParity", nil)
+	cdc.RegisterConcrete(&VoteMessage{}, "tendermint/Vote", nil)
+	cdc.RegisterConcrete(&HasVoteMessage{}, "tendermint/HasVote", nil)
+	cdc.RegisterConcrete(&VoteSetMaj23Message{}, "tendermint/VoteSetMaj23", nil)
+	cdc.RegisterConcrete(&VoteSetBitsMessage{}, "tendermint/VoteSetBits", nil)
+	cdc.RegisterConcrete(&SignWatermarkRequestMessage{}, "tendermint/SignWatermarkRequest", nil)
+	cdc.RegisterConcrete(&SignWatermarkMessage{}, "tendermint/SignWatermark", nil)
+	cdc.RegisterConcrete(&CommitAggregateMessage{}, "tendermint/CommitAggregate", nil)
+}
+
+// RegisterWALMessages registers the messages of the WAL.
+func RegisterWALMessages(cdc *amino.Codec) {
+	cdc.RegisterInterface((*WALMessage)(nil), nil)
+	cdc.RegisterConcrete(types.EventDataRoundState{}, "tendermint/wal/EventDataRoundState", nil)
+	cdc.RegisterConcrete(msgInfo{}, "tendermint/wal/MsgInfo", nil)
+	cdc.RegisterConcrete(timeoutInfo{}, "tendermint/wal/TimeoutInfo", nil)
+	cdc.RegisterConcrete(EndHeightMessage{}, "tendermint/wal/EndHeightMessage", nil)
+	cdc.RegisterConcrete(CheckpointMessage{}, "tendermint/wal/CheckpointMessage", nil)
+}
@@ -0,0 +1,202 @@
+package friday
+
+import (
+	"bytes"
+
+	"github.com/pkg/errors"
+
+	cstypes "github.com/hdac-io/tendermint/consensus/types"
+	"github.com/hdac-io/tendermint/p2p"
+	sm "github.com/hdac-io/tendermint/state"
+	"github.com/hdac-io/tendermint/types"
+)
+
+// eventCommitAggregate is fired on the evsw, with the QuorumCert of a
+// CommitAggregate made or added, for the reactor to send it to the peers
+// missing it.
+const eventCommitAggregate = "CommitAggregate"
+
+// commitBlockID returns the block +2/3 precommitted for at round, by the
+// precommits or by the CommitAggregate of the height.
+func commitBlockID(rs *cstypes.RoundState, round int) (types.BlockID, bool) {
+	if blockID, ok := rs.Votes.Precommits(round).TwoThirdsMajority(); ok {
+		return blockID, true
+	}
+	if qc := rs.CommitAggregate; qc != nil && qc.Round == round {
+		return qc.BlockID, true
+	}
+	return types.BlockID{}, false
+}
+
+// isCommitAggregator returns true if the node aggregates the precommits of
+// the round of rs, i.e. it is the proposer of the next round.
+func (cs *ConsensusState) isCommitAggregator(rs *cstypes.RoundState) bool {
+	if cs.privValidator == nil {
+		return false
+	}
+	aggregator := rs.Validators.CopyIncrementProposerPriority(1).GetProposer()
+	return bytes.Equal(aggregator.Address, cs.privValidator.GetPubKey().Address())
+}
+
+// aggregateCommit makes the CommitAggregate of the +2/3 precommits for the
+// block of the round of rs, if the node is the aggregator, for the peers to
+// commit the height on it. The validators must have BLS keys.
+func (cs *ConsensusState) aggregateCommit(rs *cstypes.RoundState) {
+	if !cs.getConfig().AggregateCommits || rs.CommitAggregate != nil || !cs.isCommitAggregator(rs) {
+		return
+	}
+	precommits := rs.Votes.Precommits(rs.Round)
+	if !precommits.HasTwoThirdsMajority() {
+		return
+	}
+	qc, err := types.NewQuorumCert(precommits.MakeCommit(), rs.Validators)
+	if err != nil {
+		cs.Logger.Error("Failed to make the CommitAggregate", "height", rs.Height, "round", rs.Round, "err", err)
+		return
+	}
+	rs.CommitAggregate = qc
+	cs.Logger.Info("Made CommitAggregate", "height", qc.Height, "round", qc.Round, "blockID", qc.BlockID)
+	cs.evsw.FireEvent(eventCommitAggregate, qc)
+}
+
+// addCommitAggregate verifies a CommitAggregate against the validators of its
+// height, and commits the height on it like on +2/3 precommits for its block.
+// The CommitAggregate is ignored if the height has one already.
+func (cs *ConsensusState) addCommitAggregate(qc *types.QuorumCert, peerID p2p.ID) error {
+	heightRound := cs.getRoundState(qc.Height)
+	if heightRound == nil {
+		return nil
+	}
+	heightRound.Lock()
+	defer heightRound.Unlock()
+
+	if heightRound.CommitAggregate != nil {
+		return nil
+	}
+	if err := qc.Verify(cs.state.ChainID, heightRound.Validators); err != nil {
+		return errors.Wrap(err, "invalid CommitAggregate")
+	}
+	heightRound.CommitAggregate = qc
+	cs.Logger.Info("Added CommitAggregate", "height", qc.Height, "round", qc.Round, "blockID", qc.BlockID,
+		"peer", peerID)
+	cs.evsw.FireEvent(eventCommitAggregate, qc)
+
+	// see the +2/3 precommits for a block in addVote
+	cs.enterNewRound(qc.Height, qc.Round)
+	cs.enterPrecommit(qc.Height, qc.Round)
+	cs.enterCommit(qc.Height, qc.Round)
+	return nil
+}
+
+// saveCommitAggregate saves the block committed on the CommitAggregate of rs,
+// with the precommits for it got so far as its seen commit, and the
+// CommitAggregate as its QuorumCert. The other precommits reach the LastCommit
+// of the block LenULB heights above later.
+func (cs *ConsensusState) saveCommitAggregate(rs *cstypes.RoundState, lenULB int64) {
+	precommits := rs.Votes.Precommits(rs.Round)
+	commitSigs := make([]*types.CommitSig, precommits.Size())
+	for idx := range commitSigs {
+		commitSigs[idx] = precommits.GetByIndex(idx).CommitSig()
+	}
+	seenCommit := types.NewCommit(rs.CommitAggregate.BlockID, commitSigs)
+	cs.blockStore.SaveBlock(rs.ProposalBlock, rs.ProposalBlockParts, seenCommit, lenULB)
+	sm.SaveQuorumCert(cs.blockExec.DB(), rs.CommitAggregate)
+}
+
+// verifyCommitAggregate returns true if the QuorumCert saved for height is a
+// valid CommitAggregate for blockID by the validators of the height.
+func (cs *ConsensusState) verifyCommitAggregate(chainID string, blockID types.BlockID, height int64,
+	validators *types.ValidatorSet) bool {
+	qc, err := sm.LoadQuorumCert(cs.blockExec.DB(), height)
+	if err != nil || !qc.BlockID.Equals(blockID) {
+		return false
+	}
+	return qc.Verify(chainID, validators) == nil
+}
+
+// makeLastCommit returns the LastCommit of the block of height, with the
+// validators who signed it: the QuorumCert of the height LenULB below, if the
+// blocks of the chain can carry it, else the +2/3 precommits of the height.
+// Returns a nil commit if the node has neither.
+func (cs *ConsensusState) makeLastCommit(height int64) (*types.Commit, *types.ValidatorSet) {
+	rs := cs.getRoundState(height)
+	if rs == nil {
+		return nil, nil
+	}
+	ulbHeight := height - cs.state.ConsensusParams.Block.LenULB
+	if cs.state.AggregatesLastCommits() {
+		qc, qcErr := sm.LoadQuorumCert(cs.blockExec.DB(), ulbHeight)
+		validators, valsErr := sm.LoadValidators(cs.blockExec.DB(), ulbHeight)
+		if qcErr == nil && valsErr == nil {
+			return qc.Commit(validators), validators
+		}
+	}
+	if !rs.LastCommit.HasTwoThirdsMajority() {
+		return nil, nil
+	}
+	return rs.LastCommit.MakeCommit(), rs.LastValidators
+}
+
+//-------------------------------------
+
+// broadcastCommitAggregate sends the CommitAggregate to the peers missing it.
+func (conR *ConsensusReactor) broadcastCommitAggregate(qc *types.QuorumCert) {
+	for _, peer := range conR.Switch.Peers().List() {
+		if ps, ok := peer.Get(types.PeerStateKey).(*PeerState); ok {
+			conR.sendCommitAggregate(peer, ps, qc)
+		}
+	}
+}
+
+// sendCommitAggregate sends the CommitAggregate to the peer if it's missing
+// it. Returns true if it was sent.
+func (conR *ConsensusReactor) sendCommitAggregate(peer p2p.Peer, ps *PeerState, qc *types.QuorumCert) bool {
+	if ps.HasCommitAggregate(qc.Height, qc.Round) {
+		return false
+	}
+	msg := &CommitAggregateMessage{QuorumCert: qc}
+	if !peer.TrySend(CommitAggregateChannel, conR.encodeMsg(peer, msg)) {
+		return false
+	}
+	ps.SetHasCommitAggregate(qc.Height, qc.Round)
+	return true
+}
+
+// peerHasLastCommitAggregate returns true if the blocks of the chain carry the
+// QuorumCerts of the heights as the LastCommits of the blocks LenULB heights
+// above, and the peer has the CommitAggregate of the LastCommit of rs: it
+// doesn't need its precommits.
+func (conR *ConsensusReactor) peerHasLastCommitAggregate(rs *cstypes.RoundState, ps *PeerState) bool {
+	if rs.LastCommit == nil || !conR.conS.state.AggregatesLastCommits() {
+		return false
+	}
+	return ps.HasCommitAggregate(rs.LastCommit.Height(), rs.LastCommit.Round())
+}
+
+// SetHasCommitAggregate sets the CommitAggregate of height and round as known
+// to the peer.
+func (ps *PeerState) SetHasCommitAggregate(height int64, round int) {
+	ps.mtx.Lock()
+	defer ps.mtx.Unlock()
+
+	if ps.commitAggregates == nil {
+		ps.commitAggregates = make(map[int64]int)
+	}
+	// forget the heights the peer is done with
+	for h := range ps.commitAggregates {
+		if _, ok := ps.PRS.Load(h); !ok {
+			delete(ps.commitAggregates, h)
+		}
+	}
+	ps.commitAggregates[height] = round
+}
+
+// HasCommitAggregate returns true if the peer knows the CommitAggregate of
+// height and round.
+func (ps *PeerState) HasCommitAggregate(height int64, round int) bool {
+	ps.mtx.Lock()
+	defer ps.mtx.Unlock()
+
+	r, ok := ps.commitAggregates[height]
+	return ok && r == round
+}
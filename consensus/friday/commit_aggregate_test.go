@@ -0,0 +1,183 @@
+package friday
+
+import (
+	"math/rand"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	cstypes "github.com/hdac-io/tendermint/consensus/types"
+	"github.com/hdac-io/tendermint/libs/log"
+	"github.com/hdac-io/tendermint/p2p/mock"
+	sm "github.com/hdac-io/tendermint/state"
+	"github.com/hdac-io/tendermint/types"
+	tmtime "github.com/hdac-io/tendermint/types/time"
+	"github.com/hdac-io/tendermint/version"
+)
+
+func TestConsensusStateCommitAggregate(t *testing.T) {
+	h := newFuzzHarness(t, rand.New(rand.NewSource(1)), 1)
+	defer h.cleanup()
+	h.cs.config.AggregateCommits = true
+
+	require.NoError(t, h.cs.Start())
+	defer func() {
+		require.NoError(t, h.cs.Stop())
+		h.cs.Wait()
+	}()
+
+	// get a complete proposal at height 1, without any precommit
+	var blockID types.BlockID
+	var round int
+	for i := 0; i < 1000 && blockID.IsZero(); i++ {
+		h.stubPropose()
+		h.deliver()
+		time.Sleep(time.Millisecond)
+		if rs := h.cs.GetRoundState(1); rs != nil && rs.ProposalBlockParts != nil && rs.ProposalBlockParts.IsComplete() {
+			blockID = types.BlockID{Hash: rs.ProposalBlock.Hash(), PartsHeader: rs.ProposalBlockParts.Header()}
+			round = rs.Round
+		}
+	}
+	require.False(t, blockID.IsZero(), "no complete proposal at height 1")
+
+	// the stubs precommit it, but only the CommitAggregate of their
+	// precommits reaches the ConsensusState
+	precommits := types.NewVoteSet(fuzzChainID, 1, round, types.PrecommitType, h.validators)
+	for _, stub := range h.stubs {
+		vote := &types.Vote{
+			Type:             types.PrecommitType,
+			Height:           1,
+			Round:            round,
+			BlockID:          blockID,
+			Timestamp:        tmtime.Now(),
+			ValidatorAddress: stub.address,
+			ValidatorIndex:   stub.index,
+		}
+		require.NoError(t, stub.privVal.SignVote(fuzzChainID, vote))
+		_, err := precommits.AddVote(vote)
+		require.NoError(t, err)
+	}
+	qc, err := types.NewQuorumCert(precommits.MakeCommit(), h.validators)
+	require.NoError(t, err)
+
+	// a CommitAggregate with a wrong signature is ignored
+	forged := *qc
+	forged.Signature = append([]byte{}, qc.Signature...)
+	forged.Signature[0] ^= 0x01
+	h.cs.peerMsgQueue <- peerMsg(&CommitAggregateMessage{&forged}, "fuzzpeer")
+	time.Sleep(100 * time.Millisecond)
+	assert.Nil(t, h.cs.GetRoundState(1).CommitAggregate)
+	assert.EqualValues(t, 0, h.blockStore.Height())
+
+	h.cs.peerMsgQueue <- peerMsg(&CommitAggregateMessage{qc}, "fuzzpeer")
+	for i := 0; i < 100 && h.blockStore.Height() < 1; i++ {
+		time.Sleep(10 * time.Millisecond)
+	}
+	require.EqualValues(t, 1, h.blockStore.Height(), "height 1 must be committed on the CommitAggregate")
+	assert.Equal(t, blockID.Hash, h.blockStore.LoadBlockMeta(1).BlockID.Hash)
+
+	// the CommitAggregate is the quorum cert of the height, as the seen
+	// commit lacks the precommits of the stubs
+	saved, err := sm.LoadQuorumCert(h.cs.blockExec.DB(), 1)
+	require.NoError(t, err)
+	assert.Equal(t, qc.Signature, saved.Signature)
+	seenCommit := h.blockStore.LoadSeenCommit(1)
+	for _, stub := range h.stubs {
+		assert.Nil(t, seenCommit.Precommits[stub.index])
+	}
+
+	// the block LenULB heights above carries it as its LastCommit
+	ulbHeight := 1 + h.cs.GetState().ConsensusParams.Block.LenULB
+	for i := 0; i < 100 && h.cs.GetRoundState(ulbHeight) == nil; i++ {
+		time.Sleep(10 * time.Millisecond)
+	}
+	lastCommit, validators := h.cs.makeLastCommit(ulbHeight)
+	require.NotNil(t, lastCommit)
+	assert.True(t, lastCommit.IsAggregate())
+	assert.Equal(t, qc.Signature, lastCommit.AggregateSignature)
+	assert.NoError(t, validators.VerifyCommit(fuzzChainID, blockID, 1, lastCommit))
+}
+
+// sendsPeer is a mock peer counting the messages sent to it, by priority.
+type sendsPeer struct {
+	*mock.Peer
+	sent, urgent int
+}
+
+func (p *sendsPeer) Send(chID byte, msgBytes []byte) bool {
+	p.sent++
+	return true
+}
+
+func (p *sendsPeer) SendUrgent(chID byte, msgBytes []byte) bool {
+	p.urgent++
+	return true
+}
+
+func TestGossipVotesCommitAggregate(t *testing.T) {
+	h := newFuzzHarness(t, rand.New(rand.NewSource(1)), 1)
+	defer h.cleanup()
+	h.cs.config.AggregateCommits = true
+	conR := &ConsensusReactor{conS: h.cs}
+
+	// a precommit of a stub for height 1 round 0
+	stub := h.stubs[0]
+	precommit := &types.Vote{
+		Type:             types.PrecommitType,
+		Height:           1,
+		Round:            0,
+		BlockID:          types.BlockID{Hash: make([]byte, 32)},
+		Timestamp:        tmtime.Now(),
+		ValidatorAddress: stub.address,
+		ValidatorIndex:   stub.index,
+	}
+	require.NoError(t, stub.privVal.SignVote(fuzzChainID, precommit))
+	rs := &cstypes.RoundState{Height: 1, Round: 0, Votes: cstypes.NewHeightVoteSet(fuzzChainID, 1, h.validators)}
+	_, err := rs.Votes.AddVote(precommit, "")
+	require.NoError(t, err)
+
+	newPeerState := func() (*sendsPeer, *PeerState, *cstypes.PeerRoundState) {
+		peer := &sendsPeer{Peer: mock.NewPeer(net.IP{127, 0, 0, 1})}
+		ps := NewPeerState(peer, nil)
+		prs := &cstypes.PeerRoundState{Height: 1, Round: 0, Step: cstypes.RoundStepPrecommit,
+			ProposalPOLRound: -1, CatchupCommitRound: -1}
+		ps.PRS.Store(int64(1), prs)
+		return peer, ps, prs
+	}
+	logger := log.TestingLogger()
+
+	// the peers without the CommitAggregate of the round get its precommits
+	peer, ps, prs := newPeerState()
+	assert.True(t, conR.gossipVotesForHeight(logger, rs, prs, ps, true))
+	assert.Equal(t, 1, peer.urgent)
+
+	// the peers with it don't
+	peer, ps, prs = newPeerState()
+	ps.SetHasCommitAggregate(1, 0)
+	assert.False(t, conR.gossipVotesForHeight(logger, rs, prs, ps, true))
+	assert.Equal(t, 0, peer.sent+peer.urgent)
+
+	// the blocks carry the CommitAggregate as the LastCommit of the next
+	// height, so they don't get them with it either
+	lastCommit := types.NewVoteSet(fuzzChainID, 1, 0, types.PrecommitType, h.validators)
+	_, err = lastCommit.AddVote(precommit)
+	require.NoError(t, err)
+	nextRs := &cstypes.RoundState{Height: 2, Round: 0, Votes: cstypes.NewHeightVoteSet(fuzzChainID, 2, h.validators),
+		LastCommit: lastCommit}
+	nextPrs := &cstypes.PeerRoundState{Height: 2, Round: 0, Step: cstypes.RoundStepPrevote,
+		ProposalPOLRound: -1, CatchupCommitRound: -1}
+	ps.PRS.Store(int64(2), nextPrs)
+	require.True(t, h.cs.state.AggregatesLastCommits())
+	assert.False(t, conR.gossipVotesForHeight(logger, nextRs, nextPrs, ps, true))
+	assert.Equal(t, 0, peer.sent+peer.urgent)
+
+	// before block protocol 11, they get them with the LastCommit of the next
+	// height, in the background
+	h.cs.state.Version.Consensus.Block = version.AggregateCommitBlockProtocol - 1
+	assert.True(t, conR.gossipVotesForHeight(logger, nextRs, nextPrs, ps, true))
+	assert.Equal(t, 1, peer.sent)
+	assert.Equal(t, 0, peer.urgent)
+}
@@ -0,0 +1,245 @@
+// +build crashtest
+
+package friday_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"math/rand"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hdac-io/tendermint/abci/example/kvstore"
+	cfg "github.com/hdac-io/tendermint/config"
+	"github.com/hdac-io/tendermint/libs/log"
+	nm "github.com/hdac-io/tendermint/node"
+	"github.com/hdac-io/tendermint/p2p"
+	"github.com/hdac-io/tendermint/privval"
+	sm "github.com/hdac-io/tendermint/state"
+	"github.com/hdac-io/tendermint/store"
+	"github.com/hdac-io/tendermint/types"
+	tmtime "github.com/hdac-io/tendermint/types/time"
+	dbm "github.com/tendermint/tm-db"
+)
+
+// The crash tests kill a friday node at a random libs/fail point (around the
+// WAL WriteSync of the votes, SaveBlock and ApplyBlock), while LenULB heights
+// are in progress, then restart it and check that it recovers:
+//
+//	go test -tags crashtest ./consensus/friday
+//
+// CRASHTEST_RUNS sets the number of crashes (10 by default) and
+// CRASHTEST_SEED the seed of the crash points, printed by each run.
+const (
+	// env of the node process
+	crashTestHomeEnv   = "CRASHTEST_HOME"
+	crashTestHeightEnv = "CRASHTEST_HEIGHT"
+	failTestIndexEnv   = "FAIL_TEST_INDEX"
+
+	crashTestLenULB = 3
+	// the height the node crashes before
+	crashTestHeight = 10
+	// an upper bound of the libs/fail calls per height
+	crashTestFailPointsPerHeight = 20
+
+	crashTestNodeTimeout = 60 * time.Second
+)
+
+func TestCrashRecovery(t *testing.T) {
+	runs := 10
+	if s := os.Getenv("CRASHTEST_RUNS"); s != "" {
+		var err error
+		runs, err = strconv.Atoi(s)
+		require.NoError(t, err)
+	}
+	seed := time.Now().UnixNano()
+	if s := os.Getenv("CRASHTEST_SEED"); s != "" {
+		var err error
+		seed, err = strconv.ParseInt(s, 10, 64)
+		require.NoError(t, err)
+	}
+	t.Logf("CRASHTEST_SEED=%d", seed)
+	rnd := rand.New(rand.NewSource(seed))
+
+	for i := 0; i < runs; i++ {
+		failIndex := rnd.Intn(crashTestFailPointsPerHeight * crashTestHeight)
+		t.Run(fmt.Sprintf("%s=%d", failTestIndexEnv, failIndex), func(t *testing.T) {
+			testCrashRecovery(t, failIndex)
+		})
+	}
+}
+
+func testCrashRecovery(t *testing.T, failIndex int) {
+	home, err := ioutil.TempDir("", "friday_crashtest")
+	require.NoError(t, err)
+	defer os.RemoveAll(home)
+	config := crashTestConfig(home)
+	initCrashTestHome(t, config)
+
+	// crash, or reach crashTestHeight if there are less fail points
+	crashed := runCrashTestNode(t, home, crashTestHeight, failIndex)
+	height := checkCrashInvariants(t, config)
+	t.Logf("crashed: %v, block store height: %d", crashed, height)
+
+	// restart and make progress past the heights in progress at the crash
+	require.False(t, runCrashTestNode(t, home, height+crashTestLenULB+2, -1), "crashed without FAIL_TEST_INDEX")
+	require.True(t, checkCrashInvariants(t, config) >= height+crashTestLenULB+2)
+}
+
+// TestCrashTestNode runs the node of a crash test. It is skipped unless run
+// by TestCrashRecovery.
+func TestCrashTestNode(t *testing.T) {
+	home := os.Getenv(crashTestHomeEnv)
+	if home == "" {
+		t.Skip("run by TestCrashRecovery")
+	}
+	height, err := strconv.ParseInt(os.Getenv(crashTestHeightEnv), 10, 64)
+	require.NoError(t, err)
+
+	config := crashTestConfig(home)
+	logger := log.NewFilter(log.NewTMLogger(log.NewSyncWriter(os.Stdout)), log.AllowInfo())
+	node, err := nm.DefaultNewNode(config, logger)
+	require.NoError(t, err)
+	require.NoError(t, node.Start())
+	defer func() {
+		require.NoError(t, node.Stop())
+		node.Wait()
+	}()
+
+	// txs change the app hash of each height
+	go func() {
+		for i := 0; node.IsRunning(); i++ {
+			_ = node.Mempool().CheckTx(types.Tx(fmt.Sprintf("%d-%d=%d", os.Getpid(), i, i)), nil)
+			time.Sleep(10 * time.Millisecond)
+		}
+	}()
+
+	timeout := time.After(crashTestNodeTimeout)
+	for node.BlockStore().Height() < height {
+		select {
+		case <-timeout:
+			t.Fatalf("timed out at height %d before height %d", node.BlockStore().Height(), height)
+		case <-time.After(50 * time.Millisecond):
+		}
+	}
+}
+
+// runCrashTestNode runs the node of home in a process until height, and
+// returns whether it crashed at the libs/fail call failIndex. A negative
+// failIndex disables the crash.
+func runCrashTestNode(t *testing.T, home string, height int64, failIndex int) bool {
+	cmd := exec.Command(os.Args[0], "-test.run=^TestCrashTestNode$", "-test.v")
+	cmd.Env = append(os.Environ(),
+		crashTestHomeEnv+"="+home,
+		fmt.Sprintf("%s=%d", crashTestHeightEnv, height),
+	)
+	if failIndex >= 0 {
+		cmd.Env = append(cmd.Env, fmt.Sprintf("%s=%d", failTestIndexEnv, failIndex))
+	}
+	out, err := cmd.CombinedOutput()
+	if err == nil {
+		return false
+	}
+	if failIndex >= 0 && bytes.Contains(out, []byte(fmt.Sprintf("*** fail-test %d ***", failIndex))) {
+		return true
+	}
+	t.Fatalf("node failed: %v\n%s", err, out)
+	return false
+}
+
+// checkCrashInvariants checks the invariants which hold after a crash at any
+// point, and returns the block store height.
+func checkCrashInvariants(t *testing.T, config *cfg.Config) int64 {
+	blockStoreDB := dbm.NewDB("blockstore", dbm.DBBackendType(config.DBBackend), config.DBDir())
+	defer blockStoreDB.Close()
+	stateDB := dbm.NewDB("state", dbm.DBBackendType(config.DBBackend), config.DBDir())
+	defer stateDB.Close()
+	blockStore := store.NewBlockStore(blockStoreDB)
+	state := sm.LoadState(stateDB)
+
+	// the block is saved before being applied, and the app commits it before
+	// the state is saved
+	height := blockStore.Height()
+	require.True(t, height == state.LastBlockHeight || height == state.LastBlockHeight+1,
+		"block store height %d, state height %d", height, state.LastBlockHeight)
+	appDB := dbm.NewDB("kvstore", dbm.DBBackendType(config.DBBackend), config.DBDir())
+	defer appDB.Close()
+	var appState kvstore.State
+	if bz := appDB.Get([]byte("stateKey")); bz != nil {
+		require.NoError(t, json.Unmarshal(bz, &appState))
+	}
+	require.True(t, appState.Height == state.LastBlockHeight || appState.Height == state.LastBlockHeight+1,
+		"app height %d, state height %d", appState.Height, state.LastBlockHeight)
+	if appState.Height == state.LastBlockHeight && state.LastBlockHeight > 0 {
+		require.Equal(t, state.AppHash, appState.AppHash)
+	}
+
+	// each saved block is complete, and committed by the validator
+	for h := int64(1); h <= height; h++ {
+		blockMeta := blockStore.LoadBlockMeta(h)
+		require.NotNil(t, blockMeta, "no block meta of height %d", h)
+		block := blockStore.LoadBlock(h)
+		require.NotNil(t, block, "no block of height %d", h)
+		require.Equal(t, h, block.Height)
+		require.Equal(t, blockMeta.BlockID.Hash, block.Hash())
+		seenCommit := blockStore.LoadSeenCommit(h)
+		require.NotNil(t, seenCommit, "no seen commit of height %d", h)
+		validators, err := sm.LoadValidators(stateDB, h)
+		require.NoError(t, err)
+		require.NoError(t, validators.VerifyCommit(state.ChainID, blockMeta.BlockID, h, seenCommit))
+	}
+
+	// the private validator signs nothing at or below a finalized height again
+	signState, err := privval.LoadFridayFilePVSignState(config.PrivValidatorStateFile(), nil, nil)
+	require.NoError(t, err)
+	require.True(t, signState.ImmutableHeight <= height,
+		"immutable height %d above the block store height %d", signState.ImmutableHeight, height)
+	return height
+}
+
+func crashTestConfig(home string) *cfg.Config {
+	config := cfg.TestFridayConfig().SetRoot(home)
+	config.ProxyApp = "persistent_kvstore"
+	config.DBBackend = "goleveldb"
+	config.P2P.ListenAddress = "tcp://127.0.0.1:0"
+	config.RPC.ListenAddress = ""
+	config.RPC.GRPCListenAddress = ""
+	return config
+}
+
+func initCrashTestHome(t *testing.T, config *cfg.Config) {
+	for _, dir := range []string{
+		filepath.Dir(config.GenesisFile()),
+		filepath.Dir(config.PrivValidatorStateFile()),
+		config.DBDir(),
+	} {
+		require.NoError(t, os.MkdirAll(dir, 0700))
+	}
+	pv := privval.GenFridayFilePV(config.PrivValidatorKeyFile(), config.PrivValidatorStateFile())
+	pv.Save()
+	_, err := p2p.LoadOrGenNodeKey(config.NodeKeyFile())
+	require.NoError(t, err)
+
+	params := types.DefaultFridayConsensusParams()
+	params.Block.LenULB = crashTestLenULB
+	genDoc := types.GenesisDoc{
+		ChainID:         "crashtest",
+		ConsensusModule: "friday",
+		GenesisTime:     tmtime.Now(),
+		ConsensusParams: params,
+		Validators: []types.GenesisValidator{{
+			Address: pv.GetAddress(),
+			PubKey:  pv.GetPubKey(),
+			Power:   10,
+		}},
+	}
+	require.NoError(t, genDoc.SaveAs(config.GenesisFile()))
+}
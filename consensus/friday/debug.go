@@ -0,0 +1,166 @@
+package friday
+
+import (
+	"encoding/json"
+	"net/http"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	cstypes "github.com/hdac-io/tendermint/consensus/types"
+	cmn "github.com/hdac-io/tendermint/libs/common"
+)
+
+// how long DebugState waits for a lock: the lock may be held forever
+// by a deadlocked routine, which is what DebugState is used to find out
+const debugLockTimeout = 100 * time.Millisecond
+
+// DebugState is a snapshot of the internals of the ConsensusState, for
+// finding out why the pipeline of heights is stalled.
+type DebugState struct {
+	// -1 if the state of the ConsensusState is locked
+	LastHeight int64 `json:"last_height"`
+
+	// the heights in progress, sorted by height
+	RoundStates []DebugRoundState `json:"round_states"`
+
+	// heights with a TimeoutTicker, sorted
+	TimeoutTickers []int64 `json:"timeout_tickers"`
+
+	// heights scheduled by scheduleNewHeightRound0 which are waiting for
+	// their ULB height to be committed or for the receiveRoutine, sorted
+	NewHeightQueue []int64 `json:"new_height_queue"`
+
+	// true while finalizeCommit waits for the previous height to be finalized
+	WaitFinalize bool `json:"wait_finalize"`
+
+	Queues map[string]DebugQueue `json:"queues"`
+}
+
+// DebugRoundState is a snapshot of the RoundState of a height.
+// Only Height and Locked are set if the RoundState is locked.
+type DebugRoundState struct {
+	Height                    int64           `json:"height"`
+	Locked                    bool            `json:"locked"`
+	Round                     int             `json:"round"`
+	Step                      string          `json:"step"`
+	StartTime                 time.Time       `json:"start_time"`
+	CommitTime                time.Time       `json:"commit_time"`
+	HasProposal               bool            `json:"has_proposal"`
+	ProposalBlockHash         cmn.HexBytes    `json:"proposal_block_hash"`
+	ProposalBlockParts        string          `json:"proposal_block_parts"`
+	LockedRound               int             `json:"locked_round"`
+	LockedBlockHash           cmn.HexBytes    `json:"locked_block_hash"`
+	ValidRound                int             `json:"valid_round"`
+	ValidBlockHash            cmn.HexBytes    `json:"valid_block_hash"`
+	CommitRound               int             `json:"commit_round"`
+	TriggeredTimeoutPrecommit bool            `json:"triggered_timeout_precommit"`
+	Votes                     json.RawMessage `json:"votes"`
+}
+
+// DebugQueue is the length and the capacity of a channel.
+type DebugQueue struct {
+	Len int `json:"len"`
+	Cap int `json:"cap"`
+}
+
+// DebugState returns a snapshot of the internals of the ConsensusState.
+// It is safe to call it concurrently with the receiveRoutine, and it doesn't
+// wait for the locks held for more than debugLockTimeout.
+func (cs *ConsensusState) DebugState() DebugState {
+	ds := DebugState{
+		LastHeight:   -1,
+		WaitFinalize: atomic.LoadInt32(&cs.waitFinalize) == 1,
+		Queues: map[string]DebugQueue{
+			"peer_msg_queue":       {len(cs.peerMsgQueue), cap(cs.peerMsgQueue)},
+			"internal_msg_queue":   {len(cs.internalMsgQueue), cap(cs.internalMsgQueue)},
+			"stats_msg_queue":      {len(cs.statsMsgQueue), cap(cs.statsMsgQueue)},
+			"aggregated_tock_chan": {len(cs.aggregatedTockChan), cap(cs.aggregatedTockChan)},
+		},
+	}
+
+	lastHeightCh := make(chan int64, 1)
+	go func() { lastHeightCh <- cs.GetLastHeight() }()
+	select {
+	case ds.LastHeight = <-lastHeightCh:
+	case <-time.After(debugLockTimeout):
+	}
+
+	cs.roundStates.Range(func(key, value interface{}) bool {
+		ds.RoundStates = append(ds.RoundStates, debugRoundState(key.(int64), value.(*cstypes.RoundState)))
+		return true
+	})
+	sort.Slice(ds.RoundStates, func(i, j int) bool { return ds.RoundStates[i].Height < ds.RoundStates[j].Height })
+
+	ds.TimeoutTickers = sortedHeights(&cs.timeoutTickers)
+	ds.NewHeightQueue = sortedHeights(&cs.scheduledHeights)
+	return ds
+}
+
+func debugRoundState(height int64, rs *cstypes.RoundState) DebugRoundState {
+	drsCh := make(chan DebugRoundState, 1)
+	go func() {
+		rs.RLock()
+		defer rs.RUnlock()
+		drsCh <- snapshotRoundState(rs)
+	}()
+	select {
+	case drs := <-drsCh:
+		return drs
+	case <-time.After(debugLockTimeout):
+		return DebugRoundState{Height: height, Locked: true}
+	}
+}
+
+// NOTE: rs must be locked
+func snapshotRoundState(rs *cstypes.RoundState) DebugRoundState {
+	drs := DebugRoundState{
+		Height:                    rs.Height,
+		Round:                     rs.Round,
+		Step:                      rs.Step.String(),
+		StartTime:                 rs.StartTime,
+		CommitTime:                rs.CommitTime,
+		HasProposal:               rs.Proposal != nil,
+		ProposalBlockHash:         rs.ProposalBlock.Hash(),
+		LockedRound:               rs.LockedRound,
+		LockedBlockHash:           rs.LockedBlock.Hash(),
+		ValidRound:                rs.ValidRound,
+		ValidBlockHash:            rs.ValidBlock.Hash(),
+		CommitRound:               rs.CommitRound,
+		TriggeredTimeoutPrecommit: rs.TriggeredTimeoutPrecommit,
+	}
+	if rs.ProposalBlockParts != nil {
+		drs.ProposalBlockParts = rs.ProposalBlockParts.BitArray().String()
+	}
+	if rs.Votes != nil {
+		if votes, err := rs.Votes.MarshalJSON(); err == nil {
+			drs.Votes = votes
+		}
+	}
+	return drs
+}
+
+// sortedHeights returns the sorted keys of a sync.Map keyed by height.
+func sortedHeights(m *sync.Map) []int64 {
+	heights := []int64{}
+	m.Range(func(key, value interface{}) bool {
+		heights = append(heights, key.(int64))
+		return true
+	})
+	sort.Slice(heights, func(i, j int) bool { return heights[i] < heights[j] })
+	return heights
+}
+
+// DebugHandler returns an http.Handler serving the DebugState as JSON.
+func (cs *ConsensusState) DebugHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		bz, err := json.MarshalIndent(cs.DebugState(), "", "  ")
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write(bz) // nolint: errcheck
+	})
+}
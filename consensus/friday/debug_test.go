@@ -0,0 +1,76 @@
+package friday
+
+import (
+	"encoding/json"
+	"math/rand"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	cstypes "github.com/hdac-io/tendermint/consensus/types"
+)
+
+func getDebugState(t *testing.T, cs *ConsensusState) DebugState {
+	rec := httptest.NewRecorder()
+	cs.DebugHandler().ServeHTTP(rec, httptest.NewRequest("GET", "/debug/consensus", nil))
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "application/json", rec.Header().Get("Content-Type"))
+	var ds DebugState
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &ds))
+	return ds
+}
+
+func TestDebugHandler(t *testing.T) {
+	h := newFuzzHarness(t, rand.New(rand.NewSource(1)), 1)
+	defer h.cleanup()
+	cs := h.cs
+	cs.roundStates.Store(int64(3), &cstypes.RoundState{Height: 3, Round: 2, Step: cstypes.RoundStepPrecommit,
+		LockedRound: 1, ValidRound: 1, CommitRound: -1})
+	cs.timeoutTickers.Store(int64(3), NewTimeoutTicker(cs.aggregatedTockChan))
+	cs.scheduledHeights.Store(int64(4), struct{}{})
+	cs.scheduledHeights.Store(int64(2), struct{}{})
+	cs.internalMsgQueue <- msgInfo{}
+
+	ds := getDebugState(t, cs)
+	assert.Equal(t, int64(0), ds.LastHeight)
+	require.Len(t, ds.RoundStates, 2)
+	assert.Equal(t, int64(1), ds.RoundStates[0].Height)
+	rs := ds.RoundStates[1]
+	assert.Equal(t, int64(3), rs.Height)
+	assert.False(t, rs.Locked)
+	assert.Equal(t, 2, rs.Round)
+	assert.Equal(t, cstypes.RoundStepPrecommit.String(), rs.Step)
+	assert.Equal(t, 1, rs.LockedRound)
+	assert.False(t, rs.HasProposal)
+	assert.Equal(t, []int64{1, 3}, ds.TimeoutTickers)
+	assert.Equal(t, []int64{2, 4}, ds.NewHeightQueue)
+	assert.False(t, ds.WaitFinalize)
+	assert.Equal(t, DebugQueue{1, cap(cs.internalMsgQueue)}, ds.Queues["internal_msg_queue"])
+	assert.Equal(t, DebugQueue{0, cap(cs.peerMsgQueue)}, ds.Queues["peer_msg_queue"])
+}
+
+func TestDebugHandlerLocked(t *testing.T) {
+	h := newFuzzHarness(t, rand.New(rand.NewSource(1)), 1)
+	defer h.cleanup()
+	cs := h.cs
+
+	// a deadlocked routine holds the locks of the state and of the RoundState
+	cs.mtx.Lock()
+	defer cs.mtx.Unlock()
+	rs := cs.getRoundState(1)
+	require.NotNil(t, rs)
+	rs.Lock()
+	defer rs.Unlock()
+
+	start := time.Now()
+	ds := getDebugState(t, cs)
+	assert.True(t, time.Since(start) < 10*debugLockTimeout)
+	assert.Equal(t, int64(-1), ds.LastHeight)
+	require.Len(t, ds.RoundStates, 1)
+	assert.Equal(t, int64(1), ds.RoundStates[0].Height)
+	assert.True(t, ds.RoundStates[0].Locked)
+}
@@ -0,0 +1,187 @@
+package friday
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"path/filepath"
+	"runtime/pprof"
+	"time"
+
+	cmn "github.com/hdac-io/tendermint/libs/common"
+)
+
+// number of the last WAL records kept in a FailureBundle
+const failureWALRecords = 100
+
+// FailureBundle is the diagnostic bundle captured when the receiveRoutine or
+// one of its handlers fails (panics), for finding out what the consensus was
+// doing.
+type FailureBundle struct {
+	Time  time.Time `json:"time"`
+	Error string    `json:"error"`
+	Stack string    `json:"stack"`
+
+	// number of failures since the ConsensusState started, this one included
+	Failures int `json:"failures"`
+	// true if the consensus halted after this failure instead of restarting
+	Halted bool `json:"halted"`
+
+	// the RoundStates of the heights in progress, as by GetRoundStateJSON
+	RoundStates json.RawMessage `json:"round_states"`
+	Debug       DebugState      `json:"debug"`
+
+	// the last records of the WAL, after the EndHeightMessage of the last
+	// block height
+	WALRecords []json.RawMessage `json:"wal_records"`
+	WALError   string            `json:"wal_error,omitempty"`
+
+	// the stacks of all the goroutines
+	Goroutines string `json:"goroutines"`
+}
+
+// handlerFailure is a panic of a handler run by goHandleIn, with its stack.
+type handlerFailure struct {
+	r     interface{}
+	stack []byte
+}
+
+// failHandler hands the panic r of a handler over to the receiveRoutine, which
+// fails with it like with its own panics: the consensus restarts or halts.
+func (cs *ConsensusState) failHandler(r interface{}, stack []byte) {
+	select {
+	case cs.handlerFailures <- handlerFailure{r, stack}:
+	default:
+		// the failure of another handler is pending
+		cs.Logger.Error("CONSENSUS FAILURE!!!", "err", r, "stack", string(stack))
+	}
+}
+
+// handleFailure captures a FailureBundle of the failure of the
+// receiveRoutine, writes it to the failure dump dir, and returns whether the
+// receiveRoutine must be restarted after backoff instead of halting the
+// consensus.
+func (cs *ConsensusState) handleFailure(r interface{}, stack []byte) (backoff time.Duration, restart bool) {
+	cs.failureMtx.Lock()
+	cs.failures++
+	failures := cs.failures
+	cs.failureMtx.Unlock()
+
+	restart = failures <= cs.getConfig().FailureRestarts
+	bundle := cs.captureFailure(r, stack, failures, !restart)
+
+	cs.failureMtx.Lock()
+	cs.lastFailure = bundle
+	cs.failureMtx.Unlock()
+
+	if cs.getConfig().FailureDumpEnabled() {
+		if path, err := cs.writeFailureBundle(bundle); err != nil {
+			cs.Logger.Error("Failed to write the consensus failure bundle", "err", err)
+		} else {
+			cs.Logger.Error("Wrote the consensus failure bundle", "path", path)
+		}
+	}
+
+	if !restart {
+		return 0, false
+	}
+	backoff = cs.getConfig().FailureRestartBackoff << uint(failures-1)
+	cs.Logger.Error("Restarting consensus after failure", "failures", failures,
+		"max", cs.getConfig().FailureRestarts, "backoff", backoff)
+	return backoff, true
+}
+
+func (cs *ConsensusState) captureFailure(r interface{}, stack []byte, failures int, halted bool) *FailureBundle {
+	bundle := &FailureBundle{
+		Time:     cs.clock.Now(),
+		Error:    fmt.Sprintf("%v", r),
+		Stack:    string(stack),
+		Failures: failures,
+		Halted:   halted,
+		Debug:    cs.DebugState(),
+	}
+
+	if bz, err := cs.GetRoundStateJSON(); err == nil {
+		bundle.RoundStates = bz
+	}
+
+	if bundle.Debug.LastHeight < 0 {
+		bundle.WALError = "the state of the ConsensusState is locked"
+	} else {
+		records, err := cs.lastWALRecords(bundle.Debug.LastHeight, failureWALRecords)
+		bundle.WALRecords = records
+		if err != nil {
+			bundle.WALError = err.Error()
+		}
+	}
+
+	var buf bytes.Buffer
+	if err := pprof.Lookup("goroutine").WriteTo(&buf, 2); err == nil {
+		bundle.Goroutines = buf.String()
+	}
+	return bundle
+}
+
+// lastWALRecords returns the last n records of the WAL written after the
+// EndHeightMessage of height.
+func (cs *ConsensusState) lastWALRecords(height int64, n int) ([]json.RawMessage, error) {
+	if err := cs.wal.FlushAndSync(); err != nil {
+		return nil, err
+	}
+	gr, found, err := cs.wal.SearchForEndHeight(height, &WALSearchOptions{IgnoreDataCorruptionErrors: true})
+	if err != nil {
+		return nil, err
+	}
+	if !found {
+		return nil, fmt.Errorf("WAL does not contain #ENDHEIGHT %d", height)
+	}
+	defer gr.Close() // nolint: errcheck
+
+	var records []json.RawMessage
+	dec := NewEncryptedWALDecoder(gr, cs.walSym, cs.walSecret)
+	for {
+		msg, err := dec.Decode()
+		switch {
+		case err == io.EOF:
+			return records, nil
+		case IsDataCorruptionError(err):
+			continue
+		case err != nil:
+			return records, err
+		}
+
+		bz, err := cdc.MarshalJSON(msg)
+		if err != nil {
+			return records, err
+		}
+		records = append(records, bz)
+		if len(records) > n {
+			records = records[1:]
+		}
+	}
+}
+
+// writeFailureBundle writes the bundle as JSON to a new file of the failure
+// dump dir, and returns its path.
+func (cs *ConsensusState) writeFailureBundle(bundle *FailureBundle) (string, error) {
+	dir := cs.getConfig().FailureDumpDir()
+	if err := cmn.EnsureDir(dir, 0700); err != nil {
+		return "", err
+	}
+	bz, err := json.MarshalIndent(bundle, "", "  ")
+	if err != nil {
+		return "", err
+	}
+	path := filepath.Join(dir, fmt.Sprintf("failure-%d-%s.json",
+		bundle.Debug.LastHeight, bundle.Time.UTC().Format("20060102T150405.000")))
+	return path, cmn.WriteFile(path, bz, 0600)
+}
+
+// GetLastFailureJSON returns the FailureBundle of the last failure of the
+// consensus as JSON, or null if it never failed.
+func (cs *ConsensusState) GetLastFailureJSON() ([]byte, error) {
+	cs.failureMtx.Lock()
+	defer cs.failureMtx.Unlock()
+	return json.Marshal(cs.lastFailure)
+}
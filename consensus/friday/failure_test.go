@@ -0,0 +1,138 @@
+package friday
+
+import (
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	cstypes "github.com/hdac-io/tendermint/consensus/types"
+)
+
+// steppingClock advances a second on every call.
+type steppingClock struct {
+	now time.Time
+}
+
+func (c *steppingClock) Now() time.Time {
+	c.now = c.now.Add(time.Second)
+	return c.now
+}
+
+func TestHandleFailure(t *testing.T) {
+	h := newFuzzHarness(t, rand.New(rand.NewSource(1)), 1)
+	defer h.cleanup()
+	cs := h.cs
+	cs.clock = &steppingClock{now: time.Unix(0, 0)}
+	config := *cs.getConfig()
+	config.FailureDumpPath = "failures"
+	config.FailureRestarts = 2
+	config.FailureRestartBackoff = 10 * time.Millisecond
+	cs.SetConfig(&config)
+
+	// restarted with the backoff doubled every time, then halted
+	backoff, restart := cs.handleFailure("first", nil)
+	assert.True(t, restart)
+	assert.Equal(t, 10*time.Millisecond, backoff)
+	backoff, restart = cs.handleFailure("second", nil)
+	assert.True(t, restart)
+	assert.Equal(t, 20*time.Millisecond, backoff)
+	_, restart = cs.handleFailure("third", []byte("goroutine 1 [running]"))
+	assert.False(t, restart)
+
+	bz, err := cs.GetLastFailureJSON()
+	require.NoError(t, err)
+	var bundle FailureBundle
+	require.NoError(t, json.Unmarshal(bz, &bundle))
+	assert.Equal(t, "third", bundle.Error)
+	assert.Equal(t, "goroutine 1 [running]", bundle.Stack)
+	assert.Equal(t, 3, bundle.Failures)
+	assert.True(t, bundle.Halted)
+	assert.NotEmpty(t, bundle.Goroutines)
+
+	// a bundle is written for every failure
+	files, err := ioutil.ReadDir(config.FailureDumpDir())
+	require.NoError(t, err)
+	assert.Len(t, files, 3)
+}
+
+func TestGetLastFailureJSONWithoutFailure(t *testing.T) {
+	h := newFuzzHarness(t, rand.New(rand.NewSource(1)), 1)
+	defer h.cleanup()
+	bz, err := h.cs.GetLastFailureJSON()
+	require.NoError(t, err)
+	assert.Equal(t, "null", string(bz))
+}
+
+type failingSyncWAL struct {
+	nilWAL
+}
+
+func (failingSyncWAL) WriteSync(m WALMessage) error { return errors.New("disk full") }
+
+func TestReceiveRoutineRestartsAfterFailure(t *testing.T) {
+	h := newFuzzHarness(t, rand.New(rand.NewSource(1)), 1)
+	defer h.cleanup()
+	cs := h.cs
+	config := *cs.getConfig()
+	config.FailureRestarts = 1
+	config.FailureRestartBackoff = 50 * time.Millisecond
+	cs.SetConfig(&config)
+	// the receiveRoutine panics on the WAL write of an internal message
+	cs.wal = failingSyncWAL{}
+
+	go cs.receiveRoutine(0)
+	start := time.Now()
+	cs.internalMsgQueue <- msgInfo{Msg: &HasVoteMessage{Height: 1}, internal: true}
+	// read by the receiveRoutine restarted after the backoff
+	cs.internalMsgQueue <- msgInfo{Msg: &HasVoteMessage{Height: 1}, internal: true}
+
+	// halted after the second failure
+	select {
+	case <-cs.done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("the consensus didn't halt")
+	}
+	assert.True(t, time.Since(start) >= config.FailureRestartBackoff)
+	bz, err := cs.GetLastFailureJSON()
+	require.NoError(t, err)
+	var bundle FailureBundle
+	require.NoError(t, json.Unmarshal(bz, &bundle))
+	assert.Equal(t, 2, bundle.Failures)
+	assert.True(t, bundle.Halted)
+}
+
+func TestHandlerFailureHaltsConsensus(t *testing.T) {
+	h := newFuzzHarness(t, rand.New(rand.NewSource(1)), 1)
+	defer h.cleanup()
+	cs := h.cs
+	config := *cs.getConfig()
+	config.FailureRestarts = 0
+	cs.SetConfig(&config)
+
+	go cs.receiveRoutine(0)
+	// handleTimeout panics on the invalid step, in its handler goroutine
+	rs := cs.getRoundState(cs.state.LastBlockHeight + 1)
+	require.NotNil(t, rs)
+	cs.aggregatedTockChan <- timeoutInfo{Height: rs.Height, Round: rs.Round, Step: cstypes.RoundStepCommit}
+
+	select {
+	case <-cs.done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("the consensus didn't halt")
+	}
+	bz, err := cs.GetLastFailureJSON()
+	require.NoError(t, err)
+	var bundle FailureBundle
+	require.NoError(t, json.Unmarshal(bz, &bundle))
+	assert.Contains(t, bundle.Error, "Invalid timeout step")
+	// the stack of the handler, not the one of the receiveRoutine
+	assert.Contains(t, bundle.Stack, "handleTimeout")
+	assert.Equal(t, 1, bundle.Failures)
+	assert.True(t, bundle.Halted)
+}
@@ -0,0 +1,81 @@
+package friday
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/hdac-io/tendermint/types"
+)
+
+// watchFinalizeWait starts the watchdog of finalizeCommit(height) waiting for
+// the lower heights to be finalized, and returns the function stopping it.
+// Every FinalizeWaitTimeout, the stall is reported by reportFinalizeStall.
+func (cs *ConsensusState) watchFinalizeWait(height int64) (stop func()) {
+	timeout := cs.getConfig().FinalizeWaitTimeout
+	if timeout <= 0 {
+		return func() {}
+	}
+
+	done := make(chan struct{})
+	go func() {
+		ticker := time.NewTicker(timeout)
+		defer ticker.Stop()
+		start := time.Now()
+		for {
+			select {
+			case <-ticker.C:
+				cs.reportFinalizeStall(height, time.Since(start))
+			case <-done:
+				return
+			case <-cs.Quit():
+				return
+			}
+		}
+	}()
+	return func() { close(done) }
+}
+
+// reportFinalizeStall reports that height has been waiting for the lower
+// blocking height to be finalized: it counts the stall, logs the RoundState
+// of the blocking height, fires an EventFinalizeStall, and requests the block
+// parts the blocking height misses from the peers if FinalizeWaitRerequest.
+// The state may be locked by the routine the wait is stalled on, so the
+// RoundState is snapshotted like DebugState does.
+func (cs *ConsensusState) reportFinalizeStall(height int64, waited time.Duration) {
+	cs.metrics.FinalizeStalls.Add(1)
+
+	ds := cs.DebugState()
+	if ds.LastHeight < 0 {
+		cs.Logger.Error("Committed height is waiting for too long for a lower height to be finalized",
+			"height", height, "waited", waited, "err", "the state of the ConsensusState is locked")
+		return
+	}
+
+	blockingHeight := ds.LastHeight + 1
+	stall := types.EventDataFinalizeStall{
+		Height:             height,
+		BlockingHeight:     blockingHeight,
+		Waited:             waited,
+		BlockingRoundState: types.EventDataRoundState{Height: blockingHeight},
+	}
+	var drs *DebugRoundState
+	for i := range ds.RoundStates {
+		if ds.RoundStates[i].Height == blockingHeight {
+			drs = &ds.RoundStates[i]
+			stall.BlockingRoundState.Round = drs.Round
+			stall.BlockingRoundState.Step = drs.Step
+		}
+	}
+
+	bz, _ := json.Marshal(drs)
+	cs.Logger.Error("Committed height is waiting for too long for a lower height to be finalized",
+		"height", height, "blockingHeight", blockingHeight, "waited", waited, "roundState", string(bz))
+	cs.eventBus.PublishEventFinalizeStall(stall)
+
+	// the reactor requests the missing block parts
+	if cs.getConfig().FinalizeWaitRerequest && drs != nil && !drs.Locked {
+		if rs := cs.GetRoundState(blockingHeight); rs != nil && rs.ProposalBlockParts != nil {
+			cs.evsw.FireEvent(types.EventFinalizeStall, rs)
+		}
+	}
+}
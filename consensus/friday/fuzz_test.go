@@ -0,0 +1,763 @@
+package friday
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io/ioutil"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	abcicli "github.com/hdac-io/tendermint/abci/client"
+	"github.com/hdac-io/tendermint/abci/example/kvstore"
+	cfg "github.com/hdac-io/tendermint/config"
+	cstypes "github.com/hdac-io/tendermint/consensus/types"
+	tmevents "github.com/hdac-io/tendermint/libs/events"
+	"github.com/hdac-io/tendermint/libs/log"
+	mempl "github.com/hdac-io/tendermint/mempool"
+	"github.com/hdac-io/tendermint/privval"
+	sm "github.com/hdac-io/tendermint/state"
+	"github.com/hdac-io/tendermint/store"
+	"github.com/hdac-io/tendermint/types"
+	tmtime "github.com/hdac-io/tendermint/types/time"
+	dbm "github.com/tendermint/tm-db"
+)
+
+// The fuzz test drives a ConsensusState, one of fuzzValidators validators of
+// equal power, with random proposals, votes and timeouts of the others over
+// multiple heights, then checks that:
+//
+//   - no two different blocks on the same previous block get +2/3 precommits
+//     at a height, and the saved blocks have +2/3 precommits and chain up
+//   - the round and step of each height never go back
+//   - the ConsensusState precommits a block only with +2/3 prevotes for it in
+//     the round, and prevotes another block than its locked one only after
+//     unlocking
+//   - the invariants of the ConsensusState checked in the background hold
+//
+// The other validators don't double sign and lock like the ConsensusState,
+// so a violation is a bug of the ConsensusState. Their messages are delayed,
+// reordered, duplicated or dropped, and the timeouts of the ConsensusState
+// only fire when the test fires them early.
+//
+// FUZZTEST_RUNS sets the number of runs (3 by default), FUZZTEST_STEPS the
+// number of actions per run (500 by default), and FUZZTEST_SEED the seed of
+// the runs, printed by each run.
+const (
+	fuzzValidators = 4
+	fuzzChainID    = "fuzztest"
+)
+
+func TestStateFuzz(t *testing.T) {
+	runs := fuzzTestEnvInt(t, "FUZZTEST_RUNS", 3)
+	steps := fuzzTestEnvInt(t, "FUZZTEST_STEPS", 500)
+	seed := time.Now().UnixNano()
+	if s := os.Getenv("FUZZTEST_SEED"); s != "" {
+		var err error
+		seed, err = strconv.ParseInt(s, 10, 64)
+		require.NoError(t, err)
+	}
+	t.Logf("FUZZTEST_SEED=%d", seed)
+	rnd := rand.New(rand.NewSource(seed))
+
+	for i := 0; i < runs; i++ {
+		runSeed := rnd.Int63()
+		t.Run(fmt.Sprintf("seed=%d", runSeed), func(t *testing.T) {
+			testStateFuzz(t, rand.New(rand.NewSource(runSeed)), steps)
+		})
+	}
+}
+
+func fuzzTestEnvInt(t *testing.T, env string, def int) int {
+	s := os.Getenv(env)
+	if s == "" {
+		return def
+	}
+	n, err := strconv.Atoi(s)
+	require.NoError(t, err)
+	return n
+}
+
+func testStateFuzz(t *testing.T, rnd *rand.Rand, steps int) {
+	lenULB := int64(1 + rnd.Intn(3))
+	h := newFuzzHarness(t, rnd, lenULB)
+	defer h.cleanup()
+
+	require.NoError(t, h.cs.Start())
+	for i := 0; i < steps; i++ {
+		h.step()
+		time.Sleep(time.Duration(rnd.Intn(3)) * time.Millisecond)
+	}
+	require.NoError(t, h.cs.Stop())
+	h.cs.Wait()
+	// let the unlock events already published reach the subscription
+	time.Sleep(100 * time.Millisecond)
+
+	t.Logf("LenULB %d, block store height %d", lenULB, h.blockStore.Height())
+	h.checkInvariants()
+}
+
+//----------------------------------------
+// harness
+
+type fuzzVoteKey struct {
+	height int64
+	round  int
+	type_  types.SignedMsgType
+}
+
+type fuzzHeightRound struct {
+	height int64
+	round  int
+}
+
+// fuzzStub is a validator driven by the test.
+type fuzzStub struct {
+	privVal types.PrivValidator
+	index   int
+	address []byte
+
+	// the rounds of its last votes, of its locked blocks and the votes it
+	// signed, by height
+	rounds       map[int64]int
+	lockedRounds map[int64]int
+	lockedBlocks map[int64]types.BlockID
+	signed       map[fuzzVoteKey]bool
+}
+
+type fuzzHarness struct {
+	t   *testing.T
+	rnd *rand.Rand
+	dir string
+
+	cs         *ConsensusState
+	blockStore *store.BlockStore
+	mempool    *mempl.CListMempool
+	eventBus   *types.EventBus
+	validators *types.ValidatorSet
+	nodeIndex  int
+	stubs      []*fuzzStub
+
+	// the messages of the stubs not delivered yet, each with the ones it
+	// comes with (a proposal and its block parts), and the ones delivered
+	pending   [][]msgInfo
+	delivered []msgInfo
+	proposed  map[fuzzHeightRound]bool
+	// the blocks proposed so far, by hash
+	blocks map[string]*types.Block
+	parts  map[string]*types.PartSet
+
+	mtx sync.Mutex
+	// the votes added by the ConsensusState, by validator index
+	votes map[fuzzVoteKey]map[int]*types.Vote
+	// the unlocks of the ConsensusState, by height
+	unlocks map[int64][]int
+	// the round and step last seen of each height
+	roundSteps map[int64]fuzzRoundStep
+	violations []string
+}
+
+type fuzzRoundStep struct {
+	round int
+	step  cstypes.RoundStepType
+}
+
+func newFuzzHarness(t *testing.T, rnd *rand.Rand, lenULB int64) *fuzzHarness {
+	dir, err := ioutil.TempDir("", "friday_fuzztest")
+	require.NoError(t, err)
+	config := cfg.TestFridayConfig().SetRoot(dir)
+	// the timeouts only fire when the test fires them
+	config.Consensus.TimeoutPropose = time.Hour
+	config.Consensus.TimeoutPrevote = time.Hour
+	config.Consensus.TimeoutPrecommit = time.Hour
+	config.Consensus.SkipTimeoutCommit = false
+
+	nodePV := privval.GenFridayFilePV(filepath.Join(dir, "priv_validator_key.json"),
+		filepath.Join(dir, "priv_validator_state.json"))
+	privVals := []types.PrivValidator{nodePV}
+	pops := [][]byte{nodePV.Key.ProofOfPossession()}
+	for i := 1; i < fuzzValidators; i++ {
+		pv := types.NewMockPV()
+		privVals = append(privVals, pv)
+		pops = append(pops, pv.ProofOfPossession())
+	}
+	params := types.DefaultFridayConsensusParams()
+	params.Block.LenULB = lenULB
+	genDoc := &types.GenesisDoc{
+		ChainID:         fuzzChainID,
+		ConsensusModule: "friday",
+		GenesisTime:     tmtime.Now(),
+		ConsensusParams: params,
+	}
+	for i, pv := range privVals {
+		genDoc.Validators = append(genDoc.Validators, types.GenesisValidator{
+			Address:           pv.GetPubKey().Address(),
+			PubKey:            pv.GetPubKey(),
+			Power:             10,
+			ProofOfPossession: pops[i],
+		})
+	}
+	state, err := sm.MakeGenesisState(genDoc)
+	require.NoError(t, err)
+
+	stateDB := dbm.NewMemDB()
+	sm.SaveState(stateDB, state)
+	blockStore := store.NewBlockStore(dbm.NewMemDB())
+	app := kvstore.NewKVStoreApplication()
+	mtx := new(sync.Mutex)
+	mempool := mempl.NewCListMempool(config.Mempool, abcicli.NewLocalClient(mtx, app), 0)
+	logger := log.TestingLogger()
+	blockExec := sm.NewBlockExecutor(blockStore, stateDB, logger, abcicli.NewLocalClient(mtx, app),
+		mempool, sm.MockEvidencePool{})
+
+	cs := NewConsensusState(config.Consensus, state, blockExec, blockStore, mempool, sm.MockEvidencePool{})
+	cs.SetLogger(logger.With("module", "consensus"))
+	cs.SetPrivValidator(nodePV)
+	eventBus := types.NewEventBus()
+	eventBus.SetLogger(logger.With("module", "events"))
+	require.NoError(t, eventBus.Start())
+	cs.SetEventBus(eventBus)
+
+	h := &fuzzHarness{
+		t:          t,
+		rnd:        rnd,
+		dir:        dir,
+		cs:         cs,
+		blockStore: blockStore,
+		mempool:    mempool,
+		eventBus:   eventBus,
+		validators: state.Validators,
+		proposed:   make(map[fuzzHeightRound]bool),
+		blocks:     make(map[string]*types.Block),
+		parts:      make(map[string]*types.PartSet),
+		votes:      make(map[fuzzVoteKey]map[int]*types.Vote),
+		unlocks:    make(map[int64][]int),
+		roundSteps: make(map[int64]fuzzRoundStep),
+	}
+	h.nodeIndex, _ = state.Validators.GetByAddress(nodePV.GetAddress())
+	for _, pv := range privVals[1:] {
+		address := pv.GetPubKey().Address()
+		index, _ := state.Validators.GetByAddress(address)
+		h.stubs = append(h.stubs, &fuzzStub{
+			privVal:      pv,
+			index:        index,
+			address:      address,
+			rounds:       make(map[int64]int),
+			lockedRounds: make(map[int64]int),
+			lockedBlocks: make(map[int64]types.BlockID),
+			signed:       make(map[fuzzVoteKey]bool),
+		})
+	}
+
+	require.NoError(t, cs.evsw.AddListenerForEvent("fuzztest", types.EventVote, func(data tmevents.EventData) {
+		h.recordVote(data.(*types.Vote))
+	}))
+	require.NoError(t, cs.evsw.AddListenerForEvent("fuzztest", types.EventNewRoundStep, func(data tmevents.EventData) {
+		h.recordRoundStep(data.(*cstypes.RoundState))
+	}))
+	unlockSub, err := eventBus.Subscribe(context.Background(), "fuzztest", types.EventQueryUnlock, 1000)
+	require.NoError(t, err)
+	go func() {
+		for {
+			select {
+			case msg := <-unlockSub.Out():
+				rs := msg.Data().(types.EventDataRoundState)
+				h.mtx.Lock()
+				h.unlocks[rs.Height] = append(h.unlocks[rs.Height], rs.Round)
+				h.mtx.Unlock()
+			case <-unlockSub.Cancelled():
+				return
+			}
+		}
+	}()
+	// nothing reads the stats of the added votes and block parts
+	go func() {
+		for {
+			select {
+			case <-cs.statsMsgQueue:
+			case <-cs.Quit():
+				return
+			}
+		}
+	}()
+	return h
+}
+
+func (h *fuzzHarness) cleanup() {
+	h.eventBus.Stop()
+	os.RemoveAll(h.dir)
+}
+
+// step runs an action picked at random.
+func (h *fuzzHarness) step() {
+	switch n := h.rnd.Intn(100); {
+	case n < 30:
+		h.stubVote()
+	case n < 40:
+		h.stubPropose()
+	case n < 80:
+		h.deliver()
+	case n < 88:
+		h.fireTimeout()
+	case n < 92:
+		h.fireStaleTimeout()
+	case n < 96:
+		h.redeliver()
+	default:
+		_ = h.mempool.CheckTx(types.Tx(fmt.Sprintf("%d=%d", h.rnd.Int63(), h.rnd.Int63())), nil)
+	}
+}
+
+// activeHeights returns the heights in progress.
+func (h *fuzzHarness) activeHeights() []int64 {
+	var heights []int64
+	last := h.cs.GetLastHeight()
+	for height := last + 1; height <= last+h.cs.pipelineDepth(); height++ {
+		if h.cs.getRoundState(height) != nil {
+			heights = append(heights, height)
+		}
+	}
+	return heights
+}
+
+// roundState returns the RoundState of a height in progress picked at
+// random, and remembers its proposal block.
+func (h *fuzzHarness) roundState() *cstypes.RoundState {
+	heights := h.activeHeights()
+	if len(heights) == 0 {
+		return nil
+	}
+	rs := h.cs.GetRoundState(heights[h.rnd.Intn(len(heights))])
+	if rs == nil {
+		return nil
+	}
+	if rs.ProposalBlock != nil && rs.ProposalBlockParts != nil && rs.ProposalBlockParts.IsComplete() {
+		hash := string(rs.ProposalBlock.Hash())
+		if _, ok := h.blocks[hash]; !ok {
+			h.blocks[hash] = rs.ProposalBlock
+			h.parts[hash] = rs.ProposalBlockParts
+		}
+	}
+	return rs
+}
+
+func (h *fuzzHarness) send(msgs ...ConsensusMessage) {
+	mis := make([]msgInfo, len(msgs))
+	for i, msg := range msgs {
+		mis[i] = peerMsg(msg, "fuzzpeer")
+	}
+	h.pending = append(h.pending, mis)
+}
+
+// deliver delivers pending messages picked at random, or drops them.
+func (h *fuzzHarness) deliver() {
+	if len(h.pending) == 0 {
+		return
+	}
+	i := h.rnd.Intn(len(h.pending))
+	mis := h.pending[i]
+	h.pending = append(h.pending[:i], h.pending[i+1:]...)
+	if h.rnd.Intn(20) == 0 {
+		return
+	}
+	// the messages are handled concurrently, so the block parts wait for
+	// another delivery to arrive after their proposal, and are sent again
+	// like the reactor does
+	if _, ok := mis[0].Msg.(*ProposalMessage); ok && len(mis) > 1 {
+		h.pending = append(h.pending, mis[1:])
+		mis = mis[:1]
+	} else if _, ok := mis[0].Msg.(*BlockPartMessage); ok && h.rnd.Intn(2) == 0 {
+		h.pending = append(h.pending, mis)
+	}
+	for _, mi := range mis {
+		h.delivered = append(h.delivered, mi)
+		h.cs.peerMsgQueue <- mi
+	}
+}
+
+// redeliver delivers again a message picked at random.
+func (h *fuzzHarness) redeliver() {
+	if len(h.delivered) == 0 {
+		return
+	}
+	h.cs.peerMsgQueue <- h.delivered[h.rnd.Intn(len(h.delivered))]
+}
+
+// fireTimeout fires the timeout the ConsensusState waits for at a height,
+// but the one of the proposal of its own turn.
+func (h *fuzzHarness) fireTimeout() {
+	rs := h.roundState()
+	if rs == nil {
+		return
+	}
+	var step cstypes.RoundStepType
+	switch {
+	case rs.Step == cstypes.RoundStepPropose && h.proposer(rs) != nil && h.rnd.Intn(4) == 0:
+		step = cstypes.RoundStepPropose
+	case rs.Step == cstypes.RoundStepPrevoteWait:
+		step = cstypes.RoundStepPrevoteWait
+	case rs.TriggeredTimeoutPrecommit:
+		step = cstypes.RoundStepPrecommitWait
+	default:
+		return
+	}
+	h.cs.aggregatedTockChan <- timeoutInfo{0, rs.Height, rs.Round, step}
+}
+
+// fireStaleTimeout fires a timeout of a round or step the ConsensusState is
+// past at a height, which it ignores.
+func (h *fuzzHarness) fireStaleTimeout() {
+	rs := h.roundState()
+	if rs == nil || rs.Round == 0 {
+		return
+	}
+	steps := []cstypes.RoundStepType{
+		cstypes.RoundStepPropose,
+		cstypes.RoundStepPrevoteWait,
+		cstypes.RoundStepPrecommitWait,
+	}
+	h.cs.aggregatedTockChan <- timeoutInfo{0, rs.Height, h.rnd.Intn(rs.Round), steps[h.rnd.Intn(len(steps))]}
+}
+
+// stubPropose makes the stub proposer of the current round of a height
+// propose its locked block, or a new one.
+func (h *fuzzHarness) stubPropose() {
+	rs := h.roundState()
+	if rs == nil || rs.Step < cstypes.RoundStepNewRound || rs.Step >= cstypes.RoundStepCommit {
+		return
+	}
+	hr := fuzzHeightRound{rs.Height, rs.Round}
+	if h.proposed[hr] {
+		return
+	}
+	stub := h.proposer(rs)
+	if stub == nil {
+		return
+	}
+
+	var block *types.Block
+	var parts *types.PartSet
+	polRound := -1
+	if blockID, ok := stub.lockedBlocks[rs.Height]; ok && h.parts[string(blockID.Hash)] != nil {
+		block, parts = h.blocks[string(blockID.Hash)], h.parts[string(blockID.Hash)]
+		polRound = stub.lockedRounds[rs.Height]
+	} else {
+		block, _ = h.cs.createProposalBlock(rs.Height, time.Time{})
+		if block == nil {
+			return
+		}
+		block.ProposerAddress = stub.address
+		parts = block.MakePartSet(types.BlockPartSizeBytes)
+		h.blocks[string(block.Hash())] = block
+		h.parts[string(block.Hash())] = parts
+	}
+
+	proposal := types.NewProposal(rs.Height, rs.Round, polRound, types.BlockID{Hash: block.Hash(), PartsHeader: parts.Header()})
+	require.NoError(h.t, stub.privVal.SignProposal(fuzzChainID, proposal))
+	h.proposed[hr] = true
+	msgs := []ConsensusMessage{&ProposalMessage{proposal}}
+	for i := 0; i < parts.Total(); i++ {
+		msgs = append(msgs, &BlockPartMessage{rs.Height, rs.Round, parts.GetPart(i)})
+	}
+	h.send(msgs...)
+}
+
+// proposer returns the stub proposer of the round, if any.
+func (h *fuzzHarness) proposer(rs *cstypes.RoundState) *fuzzStub {
+	proposer := rs.Validators.Copy().GetProposer().Address
+	for _, stub := range h.stubs {
+		if bytes.Equal(stub.address, proposer) {
+			return stub
+		}
+	}
+	return nil
+}
+
+// stubVote makes a stub prevote, or precommit once it prevoted, in the
+// round of a height it is at, which is the one of the ConsensusState or the
+// next one.
+func (h *fuzzHarness) stubVote() {
+	rs := h.roundState()
+	if rs == nil || rs.Step < cstypes.RoundStepNewRound {
+		return
+	}
+	stub := h.stubs[h.rnd.Intn(len(h.stubs))]
+	round := stub.rounds[rs.Height]
+	if round < rs.Round {
+		round = rs.Round
+	}
+	prevote := fuzzVoteKey{rs.Height, round, types.PrevoteType}
+	precommit := fuzzVoteKey{rs.Height, round, types.PrecommitType}
+	if stub.signed[precommit] && round == rs.Round && h.hasTwoThirdsAny(precommit) && h.rnd.Intn(3) == 0 {
+		// the timeout of the precommits
+		round++
+		prevote.round, precommit.round = round, round
+	}
+	stub.rounds[rs.Height] = round
+
+	h.stubUnlock(stub, rs.Height, round)
+	switch {
+	case !stub.signed[prevote]:
+		var blockID types.BlockID
+		if locked, ok := stub.lockedBlocks[rs.Height]; ok {
+			blockID = locked
+		} else if rs.Round == round && rs.Proposal != nil && h.isValidBlock(rs.Proposal.BlockID) {
+			// prevote nil on an invalid block, or the timeout of the proposal
+			if h.rnd.Intn(10) != 0 {
+				blockID = rs.Proposal.BlockID
+			}
+		} else if h.rnd.Intn(8) != 0 {
+			// wait for the proposal
+			return
+		}
+		h.stubSignVote(stub, prevote, blockID)
+
+	case !stub.signed[precommit]:
+		blockID, ok := h.twoThirdsMajority(prevote)
+		switch {
+		case !ok:
+			// precommit nil on the timeout of the prevotes, or wait
+			if !h.hasTwoThirdsAny(prevote) || h.rnd.Intn(3) != 0 {
+				return
+			}
+		case len(blockID.Hash) == 0:
+			delete(stub.lockedBlocks, rs.Height)
+		case h.rnd.Intn(10) != 0 && h.isValidBlock(blockID):
+			stub.lockedBlocks[rs.Height] = blockID
+			stub.lockedRounds[rs.Height] = round
+		default:
+			blockID = types.BlockID{}
+		}
+		h.stubSignVote(stub, precommit, blockID)
+	}
+}
+
+// stubUnlock unlocks the block of the stub at height once +2/3 prevoted for
+// something else after it locked it, or its previous block is not the one of
+// the previous height anymore, like the ConsensusState does.
+func (h *fuzzHarness) stubUnlock(stub *fuzzStub, height int64, round int) {
+	locked, ok := stub.lockedBlocks[height]
+	if !ok {
+		return
+	}
+	for r := stub.lockedRounds[height] + 1; r <= round; r++ {
+		if blockID, ok := h.twoThirdsMajority(fuzzVoteKey{height, r, types.PrevoteType}); ok && !blockID.Equals(locked) {
+			delete(stub.lockedBlocks, height)
+			return
+		}
+	}
+	if !h.isValidBlock(locked) {
+		delete(stub.lockedBlocks, height)
+	}
+}
+
+// isValidBlock returns whether the block is known, and on the previous block
+// the ConsensusState has at the previous height.
+func (h *fuzzHarness) isValidBlock(blockID types.BlockID) bool {
+	block, ok := h.blocks[string(blockID.Hash)]
+	return ok && h.cs.validatePreviousBlock(block) == nil
+}
+
+func (h *fuzzHarness) stubSignVote(stub *fuzzStub, key fuzzVoteKey, blockID types.BlockID) {
+	vote := &types.Vote{
+		ValidatorAddress: stub.address,
+		ValidatorIndex:   stub.index,
+		Height:           key.height,
+		Round:            key.round,
+		Timestamp:        tmtime.Now(),
+		Type:             key.type_,
+		BlockID:          blockID,
+	}
+	require.NoError(h.t, stub.privVal.SignVote(fuzzChainID, vote))
+	stub.signed[key] = true
+	h.send(&VoteMessage{vote})
+}
+
+//----------------------------------------
+// records
+
+func (h *fuzzHarness) recordVote(vote *types.Vote) {
+	h.mtx.Lock()
+	defer h.mtx.Unlock()
+
+	key := fuzzVoteKey{vote.Height, vote.Round, vote.Type}
+	if h.votes[key] == nil {
+		h.votes[key] = make(map[int]*types.Vote)
+	}
+	h.votes[key][vote.ValidatorIndex] = vote
+}
+
+// recordRoundStep checks that the round and step of the height didn't go
+// back since the last step.
+func (h *fuzzHarness) recordRoundStep(rs *cstypes.RoundState) {
+	h.mtx.Lock()
+	defer h.mtx.Unlock()
+
+	// the round is set before the step, so the step is only compared within
+	// a round
+	current := fuzzRoundStep{rs.Round, rs.Step}
+	last, ok := h.roundSteps[rs.Height]
+	switch {
+	case !ok:
+	case current.round < last.round:
+		h.violations = append(h.violations, fmt.Sprintf("height %d went back from round %d to round %d",
+			rs.Height, last.round, current.round))
+	case current.round > last.round:
+		current.step = cstypes.RoundStepNewHeight
+	case current.step < last.step:
+		h.violations = append(h.violations, fmt.Sprintf("height %d round %d went back from step %v to step %v",
+			rs.Height, current.round, last.step, current.step))
+	}
+	h.roundSteps[rs.Height] = current
+}
+
+// twoThirdsMajority returns the block +2/3 of the votes added by the
+// ConsensusState are for, if any.
+func (h *fuzzHarness) twoThirdsMajority(key fuzzVoteKey) (types.BlockID, bool) {
+	h.mtx.Lock()
+	defer h.mtx.Unlock()
+
+	return h.twoThirdsMajorityLocked(key)
+}
+
+// hasTwoThirdsAny returns whether +2/3 of the validators cast the votes
+// added by the ConsensusState.
+func (h *fuzzHarness) hasTwoThirdsAny(key fuzzVoteKey) bool {
+	h.mtx.Lock()
+	defer h.mtx.Unlock()
+
+	var power int64
+	for index := range h.votes[key] {
+		_, val := h.validators.GetByIndex(index)
+		power += val.VotingPower
+	}
+	return power*3 > h.validators.TotalVotingPower()*2
+}
+
+func (h *fuzzHarness) twoThirdsMajorityLocked(key fuzzVoteKey) (types.BlockID, bool) {
+	power := make(map[string]int64)
+	for index, vote := range h.votes[key] {
+		_, val := h.validators.GetByIndex(index)
+		blockKey := vote.BlockID.Key()
+		power[blockKey] += val.VotingPower
+		if power[blockKey]*3 > h.validators.TotalVotingPower()*2 {
+			return vote.BlockID, true
+		}
+	}
+	return types.BlockID{}, false
+}
+
+//----------------------------------------
+// invariants
+
+func (h *fuzzHarness) checkInvariants() {
+	h.mtx.Lock()
+	defer h.mtx.Unlock()
+
+	for _, violation := range h.violations {
+		h.t.Error(violation)
+	}
+	for _, v := range h.cs.checkInvariants() {
+		h.t.Errorf("invariant %s violated: %s", v.invariant, v.details)
+	}
+	h.checkCommits()
+	h.checkLocks()
+}
+
+// checkCommits checks that the blocks which got +2/3 precommits at a height
+// are on different previous blocks, and the saved blocks are among them.
+func (h *fuzzHarness) checkCommits() {
+	committed := make(map[int64]map[string]types.BlockID)
+	for key := range h.votes {
+		if key.type_ != types.PrecommitType {
+			continue
+		}
+		if blockID, ok := h.twoThirdsMajorityLocked(key); ok && len(blockID.Hash) != 0 {
+			if committed[key.height] == nil {
+				committed[key.height] = make(map[string]types.BlockID)
+			}
+			committed[key.height][blockID.Key()] = blockID
+		}
+	}
+
+	for height, blockIDs := range committed {
+		previous := make(map[string]types.BlockID)
+		for _, blockID := range blockIDs {
+			block, ok := h.blocks[string(blockID.Hash)]
+			if !ok {
+				h.t.Errorf("unknown block %v got +2/3 precommits at height %d", blockID, height)
+				continue
+			}
+			if other, ok := previous[block.LastBlockID.Key()]; ok {
+				h.t.Errorf("blocks %v and %v on the same previous block got +2/3 precommits at height %d",
+					other, blockID, height)
+			}
+			previous[block.LastBlockID.Key()] = blockID
+		}
+	}
+
+	var lastBlockID types.BlockID
+	for height := int64(1); height <= h.blockStore.Height(); height++ {
+		meta := h.blockStore.LoadBlockMeta(height)
+		if _, ok := committed[height][meta.BlockID.Key()]; !ok {
+			h.t.Errorf("block %v saved at height %d without +2/3 precommits", meta.BlockID, height)
+		}
+		if !meta.Header.LastBlockID.Equals(lastBlockID) {
+			h.t.Errorf("block %v saved at height %d on %v, not on the block saved at height %d",
+				meta.BlockID, height, meta.Header.LastBlockID, height-1)
+		}
+		lastBlockID = meta.BlockID
+	}
+}
+
+// checkLocks checks that the ConsensusState precommitted blocks with +2/3
+// prevotes for them, and prevoted another block than the last one it
+// precommitted only after an unlock.
+func (h *fuzzHarness) checkLocks() {
+	for key, votes := range h.votes {
+		vote, ok := votes[h.nodeIndex]
+		if !ok || len(vote.BlockID.Hash) == 0 {
+			continue
+		}
+
+		switch key.type_ {
+		case types.PrecommitType:
+			blockID, ok := h.twoThirdsMajorityLocked(fuzzVoteKey{key.height, key.round, types.PrevoteType})
+			if !ok || !blockID.Equals(vote.BlockID) {
+				h.t.Errorf("precommitted %v at height %d round %d without +2/3 prevotes for it",
+					vote.BlockID, key.height, key.round)
+			}
+
+		case types.PrevoteType:
+			// the block locked by the last precommit before the round
+			lockedRound := -1
+			var locked types.BlockID
+			for r := 0; r < key.round; r++ {
+				precommit, ok := h.votes[fuzzVoteKey{key.height, r, types.PrecommitType}][h.nodeIndex]
+				if ok && len(precommit.BlockID.Hash) != 0 {
+					lockedRound, locked = r, precommit.BlockID
+				}
+			}
+			if lockedRound < 0 || locked.Equals(vote.BlockID) {
+				continue
+			}
+			unlocked := false
+			for _, round := range h.unlocks[key.height] {
+				if lockedRound <= round && round <= key.round {
+					unlocked = true
+				}
+			}
+			if !unlocked {
+				h.t.Errorf("prevoted %v at height %d round %d while locked on %v since round %d",
+					vote.BlockID, key.height, key.round, locked, lockedRound)
+			}
+		}
+	}
+}
@@ -0,0 +1,78 @@
+package friday
+
+import (
+	"fmt"
+
+	cmn "github.com/hdac-io/tendermint/libs/common"
+	sm "github.com/hdac-io/tendermint/state"
+)
+
+// handoffFromFastSync prepares the ConsensusState, before it is started, to
+// take over from the fast sync which synced up to state.
+//
+// The ConsensusState was created at the state the node started with, so its
+// RoundStates are the pipeline slots of heights the fast sync has since
+// saved. The pipeline slots after state.LastBlockHeight are the LenULB next
+// heights, whose LastCommit is the seen commit of the fast synced block LenULB
+// heights below. So the handoff:
+//   - verifies the seen commits of the last LenULB blocks
+//   - drops the RoundStates of the heights saved by the fast sync, which would
+//     be announced to the peers otherwise
+//   - updates to state, and restores the LastCommit and LastValidators of each
+//     pipeline slot
+//
+// The consensus only starts proposing once it is started after the handoff.
+func (cs *ConsensusState) handoffFromFastSync(state sm.State) error {
+	if state.LastBlockHeight < cs.state.LastBlockHeight {
+		return fmt.Errorf("fast synced state height %v is below the consensus state height %v",
+			state.LastBlockHeight, cs.state.LastBlockHeight)
+	}
+	if err := cs.verifyULBSeenCommits(state); err != nil {
+		return err
+	}
+
+	cs.roundStates.Range(func(key, value interface{}) bool {
+		if height := key.(int64); height <= state.LastBlockHeight {
+			cs.deleteRoundState(height)
+		}
+		return true
+	})
+
+	// NOTE: updateHeight causes broadcastNewRoundStepRoutine() to broadcast a
+	// NewRoundStepMessage for each pipeline slot.
+	cs.state = state
+	cs.signalStateChange()
+	cs.updateHeight(state.LastBlockHeight + 1)
+	cs.reconstructLastCommit(state)
+
+	cs.Logger.Info("Handed off from fast sync", "height", state.LastBlockHeight+1,
+		"lenULB", state.ConsensusParams.Block.LenULB)
+	return nil
+}
+
+// verifyULBSeenCommits verifies the seen commits of the last LenULB blocks of
+// state against their validators, as they become the LastCommit of the next
+// pipeline slots. The seen commit of a block committed on a CommitAggregate
+// may lack +2/3 precommits, and its QuorumCert is verified instead.
+func (cs *ConsensusState) verifyULBSeenCommits(state sm.State) error {
+	lenULB := state.ConsensusParams.Block.LenULB
+	for height := cmn.MaxInt64(1, state.LastBlockHeight-lenULB+1); height <= state.LastBlockHeight; height++ {
+		blockMeta := cs.blockStore.LoadBlockMeta(height)
+		if blockMeta == nil {
+			return fmt.Errorf("no block meta of height %v", height)
+		}
+		seenCommit := cs.blockStore.LoadSeenCommit(height)
+		if seenCommit == nil {
+			return fmt.Errorf("no seen commit of height %v", height)
+		}
+		validators, err := sm.LoadValidators(cs.blockExec.DB(), height)
+		if err != nil {
+			return err
+		}
+		err = validators.VerifyCommit(state.ChainID, blockMeta.BlockID, height, seenCommit)
+		if err != nil && !cs.verifyCommitAggregate(state.ChainID, blockMeta.BlockID, height, validators) {
+			return fmt.Errorf("invalid seen commit of height %v: %v", height, err)
+		}
+	}
+	return nil
+}
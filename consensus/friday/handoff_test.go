@@ -0,0 +1,212 @@
+package friday
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	abcicli "github.com/hdac-io/tendermint/abci/client"
+	"github.com/hdac-io/tendermint/abci/example/kvstore"
+	cfg "github.com/hdac-io/tendermint/config"
+	"github.com/hdac-io/tendermint/libs/log"
+	mempl "github.com/hdac-io/tendermint/mempool"
+	"github.com/hdac-io/tendermint/privval"
+	sm "github.com/hdac-io/tendermint/state"
+	"github.com/hdac-io/tendermint/store"
+	"github.com/hdac-io/tendermint/types"
+	tmtime "github.com/hdac-io/tendermint/types/time"
+	dbm "github.com/tendermint/tm-db"
+)
+
+const handoffChainID = "handofftest"
+
+// handoffNode is a friday node of a single validator, so it commits alone.
+type handoffNode struct {
+	cs         *ConsensusState
+	blockStore *store.BlockStore
+	eventBus   *types.EventBus
+	state      sm.State
+}
+
+func newHandoffNode(t *testing.T, dir string, genDoc *types.GenesisDoc, pv types.PrivValidator) *handoffNode {
+	config := cfg.TestFridayConfig().SetRoot(dir)
+	state, err := sm.MakeGenesisState(genDoc)
+	require.NoError(t, err)
+
+	stateDB := dbm.NewMemDB()
+	sm.SaveState(stateDB, state)
+	blockStore := store.NewBlockStore(dbm.NewMemDB())
+	app := kvstore.NewKVStoreApplication()
+	mtx := new(sync.Mutex)
+	mempool := mempl.NewCListMempool(config.Mempool, abcicli.NewLocalClient(mtx, app), 0)
+	logger := log.TestingLogger()
+	blockExec := sm.NewBlockExecutor(blockStore, stateDB, logger, abcicli.NewLocalClient(mtx, app),
+		mempool, sm.MockEvidencePool{})
+
+	cs := NewConsensusState(config.Consensus, state, blockExec, blockStore, mempool, sm.MockEvidencePool{})
+	cs.SetLogger(logger.With("module", "consensus"))
+	cs.SetPrivValidator(pv)
+	eventBus := types.NewEventBus()
+	eventBus.SetLogger(logger.With("module", "events"))
+	require.NoError(t, eventBus.Start())
+	cs.SetEventBus(eventBus)
+	// nothing reads the stats of the added votes and block parts
+	go func() {
+		for {
+			select {
+			case <-cs.statsMsgQueue:
+			case <-cs.Quit():
+				return
+			}
+		}
+	}()
+	return &handoffNode{cs: cs, blockStore: blockStore, eventBus: eventBus, state: state}
+}
+
+// waitForHeight waits for the node to commit up to height. The first heights
+// of a single validator take a few nil rounds, as the heights of the pipeline
+// start before the proposal of the previous one.
+func (n *handoffNode) waitForHeight(t *testing.T, height int64) {
+	for i := 0; i < 6000 && n.blockStore.Height() < height; i++ {
+		time.Sleep(10 * time.Millisecond)
+	}
+	require.True(t, n.blockStore.Height() >= height, "committed up to %d, want %d", n.blockStore.Height(), height)
+}
+
+// fastSync saves and applies the blocks of source up to height like the
+// blockchain reactor, the seen commit of each block being the LastCommit of
+// the block LenULB heights above. seenCommit, if not nil, overrides it.
+func (n *handoffNode) fastSync(t *testing.T, source *store.BlockStore, height int64,
+	seenCommit func(height int64) *types.Commit) {
+	lenULB := n.state.ConsensusParams.Block.LenULB
+	for h := n.blockStore.Height() + 1; h <= height; h++ {
+		block := source.LoadBlock(h)
+		require.NotNil(t, block)
+		commit := source.LoadBlock(h + lenULB).LastCommit
+		if seenCommit != nil {
+			if c := seenCommit(h); c != nil {
+				commit = c
+			}
+		}
+		parts := block.MakePartSet(types.BlockPartSizeBytes)
+		blockID := types.BlockID{Hash: block.Hash(), PartsHeader: parts.Header()}
+		n.blockStore.SaveBlock(block, parts, commit, lenULB)
+		var err error
+		n.state, err = n.cs.blockExec.ApplyBlock(n.state, blockID, block)
+		require.NoError(t, err)
+	}
+}
+
+func (n *handoffNode) stop() {
+	if n.cs.IsRunning() {
+		n.cs.Stop()
+		n.cs.Wait()
+	}
+	n.eventBus.Stop()
+}
+
+func TestHandoffFromFastSync(t *testing.T) {
+	for _, lenULB := range []int64{1, 3} {
+		lenULB := lenULB
+		t.Run(fmt.Sprintf("LenULB=%d", lenULB), func(t *testing.T) {
+			testHandoffFromFastSync(t, lenULB)
+		})
+	}
+}
+
+func testHandoffFromFastSync(t *testing.T, lenULB int64) {
+	dir, err := ioutil.TempDir("", "friday_handofftest")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	// the nodes sign with the same key, each from an empty sign state
+	keyFile := filepath.Join(dir, "priv_validator_key.json")
+	pv := privval.GenFridayFilePV(keyFile, filepath.Join(dir, "source_state.json"))
+	pv.Save()
+	params := types.DefaultFridayConsensusParams()
+	params.Block.LenULB = lenULB
+	genDoc := &types.GenesisDoc{
+		ChainID:         handoffChainID,
+		ConsensusModule: "friday",
+		GenesisTime:     tmtime.Now(),
+		ConsensusParams: params,
+		Validators: []types.GenesisValidator{{
+			Address: pv.GetPubKey().Address(),
+			PubKey:  pv.GetPubKey(),
+			Power:   10,
+		}},
+	}
+
+	// the node the others fast sync from, past LenULB heights
+	syncHeight := lenULB + 2
+	source := newHandoffNode(t, filepath.Join(dir, "source"), genDoc, pv)
+	require.NoError(t, source.cs.Start())
+	source.waitForHeight(t, syncHeight+lenULB)
+	source.stop()
+
+	// the seen commit of a block LenULB heights below a pipeline slot is
+	// invalid, so SwitchToConsensus panics without touching the RoundStates
+	bad := newHandoffNode(t, filepath.Join(dir, "bad"), genDoc,
+		privval.LoadFridayFilePVEmptyState(keyFile, filepath.Join(dir, "bad_state.json")))
+	defer bad.stop()
+	bad.fastSync(t, source.blockStore, syncHeight, func(height int64) *types.Commit {
+		if height == syncHeight-lenULB+1 {
+			return source.blockStore.LoadBlock(height + lenULB - 1).LastCommit
+		}
+		return nil
+	})
+	err = bad.cs.handoffFromFastSync(bad.state)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), fmt.Sprintf("invalid seen commit of height %d", syncHeight-lenULB+1))
+	assert.EqualValues(t, 0, bad.cs.GetLastHeight())
+	assert.NotNil(t, bad.cs.GetRoundState(1))
+	conR := NewConsensusReactor(bad.cs, true)
+	conR.SetLogger(log.TestingLogger())
+	assert.Panics(t, func() { conR.SwitchToConsensus(bad.state, int(syncHeight)) })
+	assert.False(t, bad.cs.IsRunning())
+
+	// a state below the one of the consensus is refused
+	assert.Error(t, source.cs.handoffFromFastSync(bad.state))
+
+	node := newHandoffNode(t, filepath.Join(dir, "node"), genDoc,
+		privval.LoadFridayFilePVEmptyState(keyFile, filepath.Join(dir, "node_state.json")))
+	defer node.stop()
+	node.fastSync(t, source.blockStore, syncHeight, nil)
+	require.NoError(t, node.cs.handoffFromFastSync(node.state))
+	assert.Equal(t, syncHeight, node.cs.GetLastHeight())
+
+	// the RoundStates of the fast synced heights are dropped
+	for height := int64(1); height <= syncHeight; height++ {
+		assert.Nil(t, node.cs.GetRoundState(height), "height %d", height)
+	}
+	// and each pipeline slot has the seen commit and the validators of the
+	// block LenULB heights below
+	for height := syncHeight + 1; height <= syncHeight+lenULB; height++ {
+		rs := node.cs.GetRoundState(height)
+		require.NotNil(t, rs, "height %d", height)
+		ulbHeight := height - lenULB
+		require.True(t, rs.LastCommit.HasTwoThirdsMajority(), "height %d", height)
+		assert.Equal(t, node.blockStore.LoadBlockMeta(ulbHeight).BlockID, rs.LastCommit.MakeCommit().BlockID,
+			"height %d", height)
+		assert.EqualValues(t, ulbHeight, rs.LastCommit.Height(), "height %d", height)
+		validators, err := sm.LoadValidators(node.cs.blockExec.DB(), ulbHeight)
+		require.NoError(t, err)
+		assert.Equal(t, validators.Hash(), rs.LastValidators.Hash(), "height %d", height)
+	}
+
+	// and the node proposes the next height on its restored LastCommit once
+	// started
+	require.NoError(t, node.cs.Start())
+	node.waitForHeight(t, syncHeight+1)
+	block := node.blockStore.LoadBlock(syncHeight + 1)
+	require.NotNil(t, block)
+	assert.Equal(t, pv.GetPubKey().Address(), block.ProposerAddress)
+	assert.Equal(t, node.blockStore.LoadBlockMeta(syncHeight+1-lenULB).BlockID, block.LastCommit.BlockID)
+}
@@ -0,0 +1,89 @@
+package friday
+
+import (
+	"sync"
+	"time"
+)
+
+// Health is the state of the pipeline of heights of the ConsensusState, for
+// the /health RPC to report the conditions stalling it.
+type Health struct {
+	// the last height finalized, and the time it was finalized by the node
+	// (or the time the ConsensusState started, before its first height)
+	LastHeight        int64
+	LastFinalizedTime time.Time
+
+	// the time a committed height started waiting for a lower height to be
+	// finalized, zero if no height is waiting
+	WaitFinalizeSince time.Time
+
+	// the number of consecutive WAL writes which failed, and the last error
+	WALWriteFailures int
+	WALWriteError    error
+
+	// the number of consecutive votes the privValidator failed to sign, and
+	// the last error
+	SignVoteFailures int
+	SignVoteError    error
+}
+
+// healthTracker tracks the Health of the ConsensusState.
+type healthTracker struct {
+	mtx    sync.Mutex
+	health Health
+}
+
+func (ht *healthTracker) get() Health {
+	ht.mtx.Lock()
+	defer ht.mtx.Unlock()
+	return ht.health
+}
+
+func (ht *healthTracker) finalized(height int64, now time.Time) {
+	ht.mtx.Lock()
+	defer ht.mtx.Unlock()
+	ht.health.LastHeight = height
+	ht.health.LastFinalizedTime = now
+}
+
+func (ht *healthTracker) waitFinalize(since time.Time) {
+	ht.mtx.Lock()
+	defer ht.mtx.Unlock()
+	ht.health.WaitFinalizeSince = since
+}
+
+func (ht *healthTracker) walWritten(err error) {
+	ht.mtx.Lock()
+	defer ht.mtx.Unlock()
+	if err == nil {
+		ht.health.WALWriteFailures = 0
+		return
+	}
+	ht.health.WALWriteFailures++
+	ht.health.WALWriteError = err
+}
+
+func (ht *healthTracker) voteSigned(err error) {
+	ht.mtx.Lock()
+	defer ht.mtx.Unlock()
+	if err == nil {
+		ht.health.SignVoteFailures = 0
+		return
+	}
+	ht.health.SignVoteFailures++
+	ht.health.SignVoteError = err
+}
+
+// GetHealth returns the Health of the pipeline of heights. Unlike the
+// RoundStates, it never waits for the state to be unlocked, for the stalls to
+// be reported while a routine holds it.
+func (cs *ConsensusState) GetHealth() Health {
+	return cs.health.get()
+}
+
+// writeWAL writes msg to the WAL without fsync. The messages of the peers and
+// the timeouts are handled even if they couldn't be written, so the failure is
+// only tracked for the health check.
+func (cs *ConsensusState) writeWAL(msg WALMessage) {
+	cs.health.walWritten(cs.wal.Write(msg))
+}
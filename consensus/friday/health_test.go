@@ -0,0 +1,53 @@
+package friday
+
+import (
+	"errors"
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/hdac-io/tendermint/types"
+)
+
+type failingWAL struct {
+	nilWAL
+}
+
+func (failingWAL) Write(m WALMessage) error { return errors.New("disk full") }
+
+type failingPrivValidator struct {
+	types.PrivValidator
+}
+
+func (failingPrivValidator) SignVote(chainID string, vote *types.Vote) error {
+	return errors.New("connection refused")
+}
+
+func TestConsensusStateHealth(t *testing.T) {
+	h := newFuzzHarness(t, rand.New(rand.NewSource(1)), 1)
+	defer h.cleanup()
+	cs := h.cs
+
+	// the WAL writes fail until one succeeds
+	cs.wal = failingWAL{}
+	cs.writeWAL(EndHeightMessage{0})
+	cs.writeWAL(EndHeightMessage{0})
+	health := cs.GetHealth()
+	assert.Equal(t, 2, health.WALWriteFailures)
+	assert.EqualError(t, health.WALWriteError, "disk full")
+	cs.wal = nilWAL{}
+	cs.writeWAL(EndHeightMessage{0})
+	assert.Zero(t, cs.GetHealth().WALWriteFailures)
+
+	// the privValidator fails to sign until it signs a vote
+	privVal := cs.privValidator
+	cs.privValidator = failingPrivValidator{privVal}
+	assert.Nil(t, cs.signAddVote(1, types.PrevoteType, nil, types.PartSetHeader{}))
+	health = cs.GetHealth()
+	assert.Equal(t, 1, health.SignVoteFailures)
+	assert.EqualError(t, health.SignVoteError, "connection refused")
+	cs.privValidator = privVal
+	assert.NotNil(t, cs.signAddVote(1, types.PrevoteType, nil, types.PartSetHeader{}))
+	assert.Zero(t, cs.GetHealth().SignVoteFailures)
+}
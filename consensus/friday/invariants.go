@@ -0,0 +1,127 @@
+package friday
+
+import (
+	"fmt"
+	"sync/atomic"
+	"time"
+
+	"github.com/hdac-io/tendermint/types"
+)
+
+// The invariants of the ConsensusState checked in the background.
+const (
+	// the block store has the blocks up to the last block height of the state
+	invariantBlockStoreHeight = "block_store_height"
+	// the RoundStates are the ones of the heights in progress and of the last
+	// LenULB heights, needed for the LastCommit of the next heights
+	invariantRoundStateHeight = "round_state_height"
+	// the immutable height of the privValidator isn't above the last block height
+	invariantImmutableHeight = "immutable_height"
+	// every RoundState has a TimeoutTicker, and every TimeoutTicker a RoundState
+	invariantTimeoutTickers = "timeout_tickers"
+)
+
+// invariantViolation is a violation of an invariant, about height if it
+// concerns a single height (e.g. its RoundState), else 0.
+type invariantViolation struct {
+	invariant  string
+	height     int64
+	lastHeight int64
+	details    string
+}
+
+func (v invariantViolation) key() string {
+	return fmt.Sprintf("%s/%d", v.invariant, v.height)
+}
+
+// checkInvariantsRoutine checks the invariants every InvariantCheckInterval
+// until the ConsensusState stops. The checks run concurrently with the
+// consensus, which breaks some invariants for a moment (e.g. between the
+// creation of the RoundState of a height and the one of its TimeoutTicker),
+// so only the violations found by two consecutive checks are reported.
+func (cs *ConsensusState) checkInvariantsRoutine(interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	previous := make(map[string]bool)
+	for {
+		select {
+		case <-ticker.C:
+			current := make(map[string]bool)
+			for _, v := range cs.checkInvariants() {
+				current[v.key()] = true
+				if previous[v.key()] {
+					cs.reportInvariantViolation(v)
+				}
+			}
+			previous = current
+		case <-cs.Quit():
+			return
+		}
+	}
+}
+
+// checkInvariants returns the violations of the invariants. It waits for the
+// block being finalized, if any, to be saved and applied.
+func (cs *ConsensusState) checkInvariants() []invariantViolation {
+	cs.finalizeMtx.RLock()
+	defer cs.finalizeMtx.RUnlock()
+
+	lastHeight := cs.state.LastBlockHeight
+	lenULB := cs.state.ConsensusParams.Block.LenULB
+	var violations []invariantViolation
+	violate := func(invariant string, height int64, format string, args ...interface{}) {
+		violations = append(violations, invariantViolation{
+			invariant:  invariant,
+			height:     height,
+			lastHeight: lastHeight,
+			details:    fmt.Sprintf(format, args...),
+		})
+	}
+
+	if height := cs.blockStore.Height(); height != lastHeight {
+		violate(invariantBlockStoreHeight, 0, "block store height %d", height)
+	}
+
+	roundHeights := sortedHeights(&cs.roundStates)
+	roundStates := make(map[int64]bool)
+	for _, height := range roundHeights {
+		roundStates[height] = true
+		if height <= lastHeight-lenULB {
+			violate(invariantRoundStateHeight, height,
+				"RoundState of height %d, finalized more than LenULB (%d) heights ago", height, lenULB)
+		}
+	}
+
+	if height := atomic.LoadInt64(&cs.immutableHeight); height > lastHeight {
+		violate(invariantImmutableHeight, 0, "immutable height %d", height)
+	}
+
+	timeoutTickers := make(map[int64]bool)
+	for _, height := range sortedHeights(&cs.timeoutTickers) {
+		timeoutTickers[height] = true
+		if !roundStates[height] {
+			violate(invariantTimeoutTickers, height, "TimeoutTicker of height %d without a RoundState", height)
+		}
+	}
+	for _, height := range roundHeights {
+		if !timeoutTickers[height] {
+			violate(invariantTimeoutTickers, height, "RoundState of height %d without a TimeoutTicker", height)
+		}
+	}
+	return violations
+}
+
+// reportInvariantViolation counts the violation, logs it and fires an
+// EventInvariantViolation.
+func (cs *ConsensusState) reportInvariantViolation(v invariantViolation) {
+	cs.metrics.InvariantViolations.With("invariant", v.invariant).Add(1)
+	cs.Logger.Error("Consensus invariant violated", "invariant", v.invariant,
+		"lastHeight", v.lastHeight, "details", v.details)
+	cs.eventBus.PublishEventInvariantViolation(types.EventDataInvariantViolation{
+		Invariant:  v.invariant,
+		Height:     v.height,
+		LastHeight: v.lastHeight,
+		Details:    v.details,
+	})
+}
@@ -0,0 +1,38 @@
+package friday
+
+import (
+	"math/rand"
+	"sort"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCheckInvariants(t *testing.T) {
+	h := newFuzzHarness(t, rand.New(rand.NewSource(1)), 2)
+	defer h.cleanup()
+	cs := h.cs
+	assert.Empty(t, cs.checkInvariants())
+
+	// the RoundState of height 1 has its TimeoutTicker
+	cs.state.LastBlockHeight = 3
+	atomic.StoreInt64(&cs.immutableHeight, 4)
+	cs.timeoutTickers.Delete(int64(1))
+	cs.timeoutTickers.Store(int64(5), NewTimeoutTicker(cs.aggregatedTockChan))
+
+	var keys []string
+	for _, v := range cs.checkInvariants() {
+		assert.Equal(t, int64(3), v.lastHeight)
+		assert.NotEmpty(t, v.details)
+		keys = append(keys, v.key())
+	}
+	sort.Strings(keys)
+	assert.Equal(t, []string{
+		"block_store_height/0",
+		"immutable_height/0",
+		"round_state_height/1",
+		"timeout_tickers/1",
+		"timeout_tickers/5",
+	}, keys)
+}
@@ -0,0 +1,42 @@
+package friday
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/hdac-io/tendermint/crypto/tmhash"
+	"github.com/hdac-io/tendermint/types"
+)
+
+// eventConflictingVote is fired on the evsw, with a vote conflicting with a
+// vote of the node, for the reactor to send it to the peers.
+const eventConflictingVote = "ConflictingVote"
+
+// doubleVote signs a vote conflicting with vote, a vote of the node at the
+// height of the simulated double-vote misbehavior (see
+// ConsensusConfig.SimulateMisbehavior), for the peers to catch the two votes
+// as evidence: the nil vote if vote is for a block, else a vote for a made up
+// block. The conflicting vote is sent to the peers, but not added to the votes
+// of the node.
+func (cs *ConsensusState) doubleVote(vote *types.Vote) {
+	signer, ok := cs.privValidator.(types.ConflictingVoteSigner)
+	if !ok {
+		cs.Logger.Error("SIMULATED MISBEHAVIOR: the privValidator can't sign conflicting votes",
+			"type", reflect.TypeOf(cs.privValidator))
+		return
+	}
+
+	conflicting := vote.Copy()
+	if len(vote.BlockID.Hash) == 0 {
+		hash := tmhash.Sum([]byte(fmt.Sprintf("double-vote/%d/%d/%v", vote.Height, vote.Round, vote.Type)))
+		conflicting.BlockID = types.BlockID{Hash: hash, PartsHeader: types.PartSetHeader{Total: 1, Hash: hash}}
+	} else {
+		conflicting.BlockID = types.BlockID{}
+	}
+	if err := signer.SignConflictingVote(cs.state.ChainID, conflicting); err != nil {
+		cs.Logger.Error("SIMULATED MISBEHAVIOR: failed to sign the conflicting vote", "vote", vote, "err", err)
+		return
+	}
+	cs.Logger.Error("SIMULATED MISBEHAVIOR: signed a conflicting vote", "vote", vote, "conflicting", conflicting)
+	cs.evsw.FireEvent(eventConflictingVote, conflicting)
+}
@@ -0,0 +1,77 @@
+package friday
+
+import (
+	"math/rand"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	tmevents "github.com/hdac-io/tendermint/libs/events"
+	"github.com/hdac-io/tendermint/privval"
+	"github.com/hdac-io/tendermint/types"
+)
+
+// conflictingVoteSigner signs the conflicting votes of a FridayFilePV, which
+// only does it itself when built with the misbehavior tag.
+type conflictingVoteSigner struct {
+	*privval.FridayFilePV
+}
+
+func (pv conflictingVoteSigner) SignConflictingVote(chainID string, vote *types.Vote) error {
+	sig, err := pv.Key.PrivKey.Sign(vote.SignBytes(chainID))
+	vote.Signature = sig
+	return err
+}
+
+func TestDoubleVote(t *testing.T) {
+	h := newFuzzHarness(t, rand.New(rand.NewSource(1)), 2)
+	defer h.cleanup()
+	h.cs.doubleVoteHeight = 2
+	h.cs.SetPrivValidator(conflictingVoteSigner{h.cs.privValidator.(*privval.FridayFilePV)})
+
+	var (
+		mtx         sync.Mutex
+		conflicting []*types.Vote
+	)
+	require.NoError(t, h.cs.evsw.AddListenerForEvent("misbehaviortest", eventConflictingVote,
+		func(data tmevents.EventData) {
+			mtx.Lock()
+			conflicting = append(conflicting, data.(*types.Vote))
+			mtx.Unlock()
+		}))
+
+	require.NoError(t, h.cs.Start())
+	for i := 0; i < 300; i++ {
+		h.step()
+		time.Sleep(time.Millisecond)
+	}
+	require.NoError(t, h.cs.Stop())
+	h.cs.Wait()
+
+	mtx.Lock()
+	defer mtx.Unlock()
+	h.mtx.Lock()
+	defer h.mtx.Unlock()
+	require.NotEmpty(t, conflicting)
+	pubKey := h.cs.privValidator.GetPubKey()
+	checked := 0
+	for _, vote := range conflicting {
+		assert.Equal(t, int64(2), vote.Height)
+		// the conflicting vote is signed along with the vote of the node,
+		// which is only recorded once added from the internal queue, so a
+		// vote signed right before the Stop may not be
+		own := h.votes[fuzzVoteKey{vote.Height, vote.Round, vote.Type}][h.nodeIndex]
+		if own == nil {
+			continue
+		}
+		// the peers make evidence of the two votes
+		ev, err := types.NewDuplicateVoteEvidence(fuzzChainID, h.validators, own, vote)
+		require.NoError(t, err)
+		assert.NoError(t, ev.Verify(fuzzChainID, pubKey))
+		checked++
+	}
+	require.NotZero(t, checked, "no vote of the node conflicting with a vote of the %d signed", len(conflicting))
+}
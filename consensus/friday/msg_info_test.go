@@ -0,0 +1,53 @@
+package friday
+
+import (
+	"bytes"
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/hdac-io/tendermint/types"
+)
+
+func TestConsensusStateMsgProvenance(t *testing.T) {
+	h := newFuzzHarness(t, rand.New(rand.NewSource(1)), 1)
+	defer h.cleanup()
+	cs := h.cs
+
+	ourAddr := cs.privValidator.GetPubKey().Address()
+	ourVote := &VoteMessage{&types.Vote{ValidatorAddress: ourAddr}}
+	otherVote := &VoteMessage{&types.Vote{}}
+	for _, val := range h.validators.Validators {
+		if !bytes.Equal(val.Address, ourAddr) {
+			otherVote.Vote.ValidatorAddress = val.Address
+		}
+	}
+
+	// the peer queue only takes the messages of peers
+	assert.True(t, cs.checkPeerMsg(peerMsg(otherVote, "peer")))
+	assert.True(t, cs.checkPeerMsg(peerMsg(ourVote, "peer")), "our votes come back from the peers")
+	assert.False(t, cs.checkPeerMsg(msgInfo{Msg: otherVote}), "no peer")
+	assert.False(t, cs.checkPeerMsg(internalMsg(ourVote)))
+
+	// the internal queue only takes the messages of this node
+	assert.True(t, cs.checkInternalMsg(internalMsg(ourVote)))
+	assert.True(t, cs.checkInternalMsg(internalMsg(&ProposalMessage{&types.Proposal{}})))
+	assert.False(t, cs.checkInternalMsg(internalMsg(otherVote)), "not signed by our privValidator")
+	assert.False(t, cs.checkInternalMsg(peerMsg(ourVote, "peer")))
+	assert.False(t, cs.checkInternalMsg(msgInfo{Msg: ourVote}), "not built by internalMsg")
+}
+
+func TestConsensusStateRejectEmptyPeerID(t *testing.T) {
+	h := newFuzzHarness(t, rand.New(rand.NewSource(1)), 1)
+	defer h.cleanup()
+	cs := h.cs
+
+	// the messages of this node only go through sendInternalMessage
+	_, err := cs.AddVote(&types.Vote{}, "")
+	assert.Equal(t, ErrEmptyPeerID, err)
+	assert.Equal(t, ErrEmptyPeerID, cs.SetProposal(&types.Proposal{}, ""))
+	assert.Equal(t, ErrEmptyPeerID, cs.AddProposalBlockPart(1, 0, &types.Part{}, ""))
+	assert.Equal(t, 0, len(cs.internalMsgQueue))
+	assert.Equal(t, 0, len(cs.peerMsgQueue))
+}
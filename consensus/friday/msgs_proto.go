@@ -0,0 +1,260 @@
+package friday
+
+import (
+	"fmt"
+
+	cstypes "github.com/hdac-io/tendermint/consensus/types"
+	"github.com/hdac-io/tendermint/p2p"
+	"github.com/hdac-io/tendermint/types"
+	"github.com/hdac-io/tendermint/types/msgs"
+)
+
+// encodeMsg encodes msg for peer, with protobuf if the peer uses it, see
+// p2p.Switch.ProtoMsgs, else amino.
+func (conR *ConsensusReactor) encodeMsg(peer p2p.Peer, msg ConsensusMessage) []byte {
+	return encodeMsg(msg, conR.Switch.ProtoMsgs(peer))
+}
+
+// broadcast sends msg to all the peers, encoded for each of them.
+func (conR *ConsensusReactor) broadcast(chID byte, msg ConsensusMessage) {
+	conR.Switch.BroadcastMsg(chID, func(proto bool) []byte { return encodeMsg(msg, proto) })
+}
+
+func encodeMsg(msg ConsensusMessage, proto bool) []byte {
+	if !proto {
+		return cdc.MustMarshalBinaryBare(msg)
+	}
+	bz, err := msgToProto(msg).Marshal()
+	if err != nil {
+		panic(err)
+	}
+	return bz
+}
+
+// decodePeerMsg decodes a message encoded with protobuf if proto is true,
+// else amino.
+func decodePeerMsg(bz []byte, proto bool) (ConsensusMessage, error) {
+	if !proto {
+		return decodeMsg(bz)
+	}
+	if len(bz) > maxMsgSize {
+		return nil, fmt.Errorf("Msg exceeds max size (%d > %d)", len(bz), maxMsgSize)
+	}
+	var pb msgs.ConsensusMessage
+	if err := pb.Unmarshal(bz); err != nil {
+		return nil, err
+	}
+	return msgFromProto(&pb)
+}
+
+func msgToProto(msg ConsensusMessage) *msgs.ConsensusMessage {
+	pb := &msgs.ConsensusMessage{}
+	switch msg := msg.(type) {
+	case *NewRoundStepMessage:
+		pb.Sum = &msgs.ConsensusMessage_NewRoundStep{NewRoundStep: &msgs.NewRoundStepMessage{
+			Height:                msg.Height,
+			Round:                 int64(msg.Round),
+			Step:                  uint32(msg.Step),
+			SecondsSinceStartTime: int64(msg.SecondsSinceStartTime),
+			LastCommitRound:       int64(msg.LastCommitRound),
+		}}
+	case *NewValidBlockMessage:
+		pb.Sum = &msgs.ConsensusMessage_NewValidBlock{NewValidBlock: &msgs.NewValidBlockMessage{
+			Height:           msg.Height,
+			Round:            int64(msg.Round),
+			BlockPartsHeader: msgs.PartSetHeaderToProto(msg.BlockPartsHeader),
+			BlockParts:       msgs.BitArrayToProto(msg.BlockParts),
+			IsCommit:         msg.IsCommit,
+		}}
+	case *ProposalMessage:
+		pb.Sum = &msgs.ConsensusMessage_Proposal{Proposal: &msgs.ProposalMessage{
+			Proposal: msgs.ProposalToProto(msg.Proposal),
+		}}
+	case *ProposalPOLMessage:
+		pb.Sum = &msgs.ConsensusMessage_ProposalPOL{ProposalPOL: &msgs.ProposalPOLMessage{
+			Height:           msg.Height,
+			ProposalPOLRound: int64(msg.ProposalPOLRound),
+			ProposalPOL:      msgs.BitArrayToProto(msg.ProposalPOL),
+		}}
+	case *BlockPartMessage:
+		pb.Sum = &msgs.ConsensusMessage_BlockPart{BlockPart: &msgs.BlockPartMessage{
+			Height: msg.Height,
+			Round:  int64(msg.Round),
+			Part:   msgs.PartToProto(msg.Part),
+		}}
+	case *BlockPartParityMessage:
+		pb.Sum = &msgs.ConsensusMessage_BlockPartParity{BlockPartParity: &msgs.BlockPartParityMessage{
+			Height:      msg.Height,
+			Round:       int64(msg.Round),
+			PartsHeader: msgs.PartSetHeaderToProto(msg.PartsHeader),
+			DataSize:    int64(msg.DataSize),
+			Index:       int64(msg.Index),
+			Bytes:       msg.Bytes,
+		}}
+	case *VoteMessage:
+		pb.Sum = &msgs.ConsensusMessage_Vote{Vote: &msgs.VoteMessage{
+			Vote: msgs.VoteToProto(msg.Vote),
+		}}
+	case *HasVoteMessage:
+		pb.Sum = &msgs.ConsensusMessage_HasVote{HasVote: &msgs.HasVoteMessage{
+			Height: msg.Height,
+			Round:  int64(msg.Round),
+			Type:   uint32(msg.Type),
+			Index:  int64(msg.Index),
+		}}
+	case *VoteSetMaj23Message:
+		pb.Sum = &msgs.ConsensusMessage_VoteSetMaj23{VoteSetMaj23: &msgs.VoteSetMaj23Message{
+			Height:  msg.Height,
+			Round:   int64(msg.Round),
+			Type:    uint32(msg.Type),
+			BlockID: msgs.BlockIDToProto(msg.BlockID),
+		}}
+	case *VoteSetBitsMessage:
+		pb.Sum = &msgs.ConsensusMessage_VoteSetBits{VoteSetBits: &msgs.VoteSetBitsMessage{
+			Height:  msg.Height,
+			Round:   int64(msg.Round),
+			Type:    uint32(msg.Type),
+			BlockID: msgs.BlockIDToProto(msg.BlockID),
+			Votes:   msgs.BitArrayToProto(msg.Votes),
+		}}
+	case *SignWatermarkRequestMessage:
+		pb.Sum = &msgs.ConsensusMessage_SignWatermarkRequest{SignWatermarkRequest: &msgs.SignWatermarkRequestMessage{
+			ValidatorAddress: msg.ValidatorAddress,
+		}}
+	case *SignWatermarkMessage:
+		pb.Sum = &msgs.ConsensusMessage_SignWatermark{SignWatermark: &msgs.SignWatermarkMessage{
+			ValidatorAddress: msg.ValidatorAddress,
+			Height:           msg.Height,
+		}}
+	case *CommitAggregateMessage:
+		qc := msg.QuorumCert
+		pb.Sum = &msgs.ConsensusMessage_CommitAggregate{CommitAggregate: &msgs.CommitAggregateMessage{
+			Height:     qc.Height,
+			Round:      int64(qc.Round),
+			BlockID:    msgs.BlockIDToProto(qc.BlockID),
+			Signers:    msgs.BitArrayToProto(qc.Signers),
+			Timestamps: qc.Timestamps,
+			Signature:  qc.Signature,
+		}}
+	default:
+		panic(fmt.Sprintf("Unknown message type %T", msg))
+	}
+	return pb
+}
+
+func msgFromProto(pb *msgs.ConsensusMessage) (ConsensusMessage, error) {
+	switch sum := pb.Sum.(type) {
+	case *msgs.ConsensusMessage_NewRoundStep:
+		m := sum.NewRoundStep
+		return &NewRoundStepMessage{
+			Height:                m.Height,
+			Round:                 int(m.Round),
+			Step:                  cstypes.RoundStepType(m.Step),
+			SecondsSinceStartTime: int(m.SecondsSinceStartTime),
+			LastCommitRound:       int(m.LastCommitRound),
+		}, nil
+	case *msgs.ConsensusMessage_NewValidBlock:
+		m := sum.NewValidBlock
+		blockParts, err := msgs.BitArrayFromProto(m.BlockParts)
+		if err != nil {
+			return nil, err
+		}
+		return &NewValidBlockMessage{
+			Height:           m.Height,
+			Round:            int(m.Round),
+			BlockPartsHeader: msgs.PartSetHeaderFromProto(m.BlockPartsHeader),
+			BlockParts:       blockParts,
+			IsCommit:         m.IsCommit,
+		}, nil
+	case *msgs.ConsensusMessage_Proposal:
+		proposal, err := msgs.ProposalFromProto(sum.Proposal.Proposal)
+		if err != nil {
+			return nil, err
+		}
+		return &ProposalMessage{Proposal: proposal}, nil
+	case *msgs.ConsensusMessage_ProposalPOL:
+		m := sum.ProposalPOL
+		pol, err := msgs.BitArrayFromProto(m.ProposalPOL)
+		if err != nil {
+			return nil, err
+		}
+		return &ProposalPOLMessage{
+			Height:           m.Height,
+			ProposalPOLRound: int(m.ProposalPOLRound),
+			ProposalPOL:      pol,
+		}, nil
+	case *msgs.ConsensusMessage_BlockPart:
+		m := sum.BlockPart
+		part, err := msgs.PartFromProto(m.Part)
+		if err != nil {
+			return nil, err
+		}
+		return &BlockPartMessage{Height: m.Height, Round: int(m.Round), Part: part}, nil
+	case *msgs.ConsensusMessage_BlockPartParity:
+		m := sum.BlockPartParity
+		return &BlockPartParityMessage{
+			Height:      m.Height,
+			Round:       int(m.Round),
+			PartsHeader: msgs.PartSetHeaderFromProto(m.PartsHeader),
+			DataSize:    int(m.DataSize),
+			Index:       int(m.Index),
+			Bytes:       m.Bytes,
+		}, nil
+	case *msgs.ConsensusMessage_Vote:
+		vote, err := msgs.VoteFromProto(sum.Vote.Vote)
+		if err != nil {
+			return nil, err
+		}
+		return &VoteMessage{Vote: vote}, nil
+	case *msgs.ConsensusMessage_HasVote:
+		m := sum.HasVote
+		return &HasVoteMessage{
+			Height: m.Height,
+			Round:  int(m.Round),
+			Type:   types.SignedMsgType(m.Type),
+			Index:  int(m.Index),
+		}, nil
+	case *msgs.ConsensusMessage_VoteSetMaj23:
+		m := sum.VoteSetMaj23
+		return &VoteSetMaj23Message{
+			Height:  m.Height,
+			Round:   int(m.Round),
+			Type:    types.SignedMsgType(m.Type),
+			BlockID: msgs.BlockIDFromProto(m.BlockID),
+		}, nil
+	case *msgs.ConsensusMessage_VoteSetBits:
+		m := sum.VoteSetBits
+		votes, err := msgs.BitArrayFromProto(m.Votes)
+		if err != nil {
+			return nil, err
+		}
+		return &VoteSetBitsMessage{
+			Height:  m.Height,
+			Round:   int(m.Round),
+			Type:    types.SignedMsgType(m.Type),
+			BlockID: msgs.BlockIDFromProto(m.BlockID),
+			Votes:   votes,
+		}, nil
+	case *msgs.ConsensusMessage_SignWatermarkRequest:
+		return &SignWatermarkRequestMessage{ValidatorAddress: sum.SignWatermarkRequest.ValidatorAddress}, nil
+	case *msgs.ConsensusMessage_SignWatermark:
+		m := sum.SignWatermark
+		return &SignWatermarkMessage{ValidatorAddress: m.ValidatorAddress, Height: m.Height}, nil
+	case *msgs.ConsensusMessage_CommitAggregate:
+		m := sum.CommitAggregate
+		signers, err := msgs.BitArrayFromProto(m.Signers)
+		if err != nil {
+			return nil, err
+		}
+		return &CommitAggregateMessage{QuorumCert: &types.QuorumCert{
+			Height:     m.Height,
+			Round:      int(m.Round),
+			BlockID:    msgs.BlockIDFromProto(m.BlockID),
+			Signers:    signers,
+			Timestamps: m.Timestamps,
+			Signature:  m.Signature,
+		}}, nil
+	default:
+		return nil, fmt.Errorf("unknown message %T", pb.Sum)
+	}
+}
@@ -0,0 +1,82 @@
+package friday
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	cstypes "github.com/hdac-io/tendermint/consensus/types"
+	cmn "github.com/hdac-io/tendermint/libs/common"
+	"github.com/hdac-io/tendermint/types"
+	"github.com/hdac-io/tendermint/types/msgs"
+	tmtime "github.com/hdac-io/tendermint/types/time"
+)
+
+func TestMsgProtoRoundTrip(t *testing.T) {
+	parts := types.NewPartSetFromData(cmn.RandBytes(100), 30)
+	blockID := types.BlockID{Hash: cmn.RandBytes(32), PartsHeader: parts.Header()}
+	bits := cmn.NewBitArray(70)
+	bits.SetIndex(65, true)
+	proposal := types.NewProposal(3, 1, 0, blockID)
+	proposal.Signature = cmn.RandBytes(64)
+	vote := &types.Vote{
+		Type:             types.PrecommitType,
+		Height:           3,
+		Round:            1,
+		BlockID:          blockID,
+		Timestamp:        tmtime.Now(),
+		ValidatorAddress: cmn.RandBytes(20),
+		ValidatorIndex:   2,
+		Signature:        cmn.RandBytes(64),
+	}
+
+	testCases := []ConsensusMessage{
+		&NewRoundStepMessage{Height: 3, Round: 1, Step: cstypes.RoundStepPrevote, SecondsSinceStartTime: 2, LastCommitRound: 0},
+		&NewValidBlockMessage{Height: 3, Round: 1, BlockPartsHeader: parts.Header(), BlockParts: bits, IsCommit: true},
+		&ProposalMessage{Proposal: proposal},
+		&ProposalPOLMessage{Height: 3, ProposalPOLRound: 0, ProposalPOL: bits},
+		&BlockPartMessage{Height: 3, Round: 1, Part: parts.GetPart(1)},
+		&BlockPartParityMessage{Height: 3, Round: 1, PartsHeader: parts.Header(), DataSize: 100, Index: 1, Bytes: cmn.RandBytes(30)},
+		&VoteMessage{Vote: vote},
+		&HasVoteMessage{Height: 3, Round: 1, Type: types.PrevoteType, Index: 2},
+		&VoteSetMaj23Message{Height: 3, Round: 1, Type: types.PrevoteType, BlockID: blockID},
+		&VoteSetBitsMessage{Height: 3, Round: 1, Type: types.PrecommitType, BlockID: blockID, Votes: bits},
+		&SignWatermarkRequestMessage{ValidatorAddress: cmn.RandBytes(20)},
+		&SignWatermarkMessage{ValidatorAddress: cmn.RandBytes(20), Height: 3},
+		&CommitAggregateMessage{QuorumCert: &types.QuorumCert{Height: 3, Round: 1, BlockID: blockID, Signers: bits,
+			Timestamps: []time.Time{vote.Timestamp}, Signature: cmn.RandBytes(96)}},
+	}
+	for _, msg := range testCases {
+		// the message decoded from protobuf is the one decoded from amino
+		fromAmino, err := decodePeerMsg(encodeMsg(msg, false), false)
+		require.NoError(t, err)
+		fromProto, err := decodePeerMsg(encodeMsg(msg, true), true)
+		require.NoError(t, err, "%T", msg)
+		assert.Equal(t, fromAmino, fromProto, "%T", msg)
+	}
+}
+
+func TestMsgProtoInvalid(t *testing.T) {
+	// the bits don't fit in the elems
+	pb := &msgs.ConsensusMessage{Sum: &msgs.ConsensusMessage_ProposalPOL{ProposalPOL: &msgs.ProposalPOLMessage{
+		Height:      1,
+		ProposalPOL: &msgs.BitArray{Bits: 200, Elems: []uint64{1}},
+	}}}
+	bz, err := pb.Marshal()
+	require.NoError(t, err)
+	_, err = decodePeerMsg(bz, true)
+	assert.Error(t, err)
+
+	// no message
+	_, err = decodePeerMsg(nil, true)
+	assert.Error(t, err)
+
+	// no vote
+	pb = &msgs.ConsensusMessage{Sum: &msgs.ConsensusMessage_Vote{Vote: &msgs.VoteMessage{}}}
+	bz, err = pb.Marshal()
+	require.NoError(t, err)
+	_, err = decodePeerMsg(bz, true)
+	assert.Error(t, err)
+}
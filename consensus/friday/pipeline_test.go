@@ -0,0 +1,35 @@
+package friday
+
+import (
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestScheduleBeyondPipelineDepth(t *testing.T) {
+	h := newFuzzHarness(t, rand.New(rand.NewSource(1)), 2)
+	defer h.cleanup()
+	cs := h.cs
+
+	// the height 3 waits for the height 1 to be finalized
+	cs.scheduleNewHeightRound0(3)
+	select {
+	case height := <-cs.newHeightQueue:
+		t.Fatalf("height %d started beyond the pipeline depth", height)
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	// and starts as soon as it is
+	state := cs.state.Copy()
+	state.LastBlockHeight = 1
+	cs.state = state
+	cs.signalStateChange()
+	select {
+	case height := <-cs.newHeightQueue:
+		assert.EqualValues(t, 3, height)
+	case <-time.After(time.Second):
+		t.Fatal("height 3 did not start within the pipeline depth")
+	}
+}
@@ -8,10 +8,11 @@ import (
 
 	"github.com/pkg/errors"
 
-	amino "github.com/tendermint/go-amino"
 	tmcs "github.com/hdac-io/tendermint/consensus"
 	cstypes "github.com/hdac-io/tendermint/consensus/types"
+	"github.com/hdac-io/tendermint/crypto"
 	cmn "github.com/hdac-io/tendermint/libs/common"
+	"github.com/hdac-io/tendermint/libs/erasure"
 	tmevents "github.com/hdac-io/tendermint/libs/events"
 	"github.com/hdac-io/tendermint/libs/log"
 	"github.com/hdac-io/tendermint/p2p"
@@ -21,10 +22,12 @@ import (
 )
 
 const (
-	StateChannel       = byte(0x20)
-	DataChannel        = byte(0x21)
-	VoteChannel        = byte(0x22)
-	VoteSetBitsChannel = byte(0x23)
+	StateChannel           = byte(0x20)
+	DataChannel            = byte(0x21)
+	VoteChannel            = byte(0x22)
+	VoteSetBitsChannel     = byte(0x23)
+	DataParityChannel      = byte(0x24)
+	CommitAggregateChannel = byte(0x25)
 
 	maxMsgSize = 1048576 // 1MB; NOTE/TODO: keep in sync with types.PartSet sizes.
 
@@ -45,6 +48,8 @@ type ConsensusReactor struct {
 	eventBus *types.EventBus
 
 	metrics *tmcs.Metrics
+
+	parity parityCache
 }
 
 type ReactorOption func(*ConsensusReactor)
@@ -77,6 +82,10 @@ func (conR *ConsensusReactor) OnStart() error {
 
 	conR.subscribeToBroadcastEvents()
 
+	if lag := conR.conS.getConfig().FastSyncReentryLag; lag > 0 {
+		go conR.fastSyncReentryRoutine(lag)
+	}
+
 	if !conR.FastSync() {
 		err := conR.conS.Start()
 		if err != nil {
@@ -98,13 +107,15 @@ func (conR *ConsensusReactor) OnStop() {
 }
 
 // SwitchToConsensus switches from fast_sync mode to consensus mode.
-// It resets the state, turns off fast_sync, and starts the consensus state-machine
+// It hands the ULB window off from fast sync, turns off fast_sync, and starts
+// the consensus state-machine
 func (conR *ConsensusReactor) SwitchToConsensus(state sm.State, blocksSynced int) {
 	conR.Logger.Info("SwitchToConsensus")
-	conR.conS.reconstructLastCommit(state)
-	// NOTE: The line below causes broadcastNewRoundStepRoutine() to
-	// broadcast a NewRoundStepMessage.
-	conR.conS.updateToState(state)
+	// The pipeline slots after the fast synced height are restored before
+	// starting the consensus, see handoffFromFastSync.
+	if err := conR.conS.handoffFromFastSync(state); err != nil {
+		panic(fmt.Sprintf("Failed to hand off from fast sync: %v", err))
+	}
 
 	conR.mtx.Lock()
 	conR.fastSync = false
@@ -130,7 +141,7 @@ conR:
 // GetChannels implements Reactor
 func (conR *ConsensusReactor) GetChannels() []*p2p.ChannelDescriptor {
 	// TODO optimize
-	return []*p2p.ChannelDescriptor{
+	channels := []*p2p.ChannelDescriptor{
 		{
 			ID:                  StateChannel,
 			Priority:            5,
@@ -159,6 +170,27 @@ func (conR *ConsensusReactor) GetChannels() []*p2p.ChannelDescriptor {
 			RecvMessageCapacity: maxMsgSize,
 		},
 	}
+	// the peers advertising the channel get the parity parts
+	if conR.conS.getConfig().BlockPartsParity > 0 {
+		channels = append(channels, &p2p.ChannelDescriptor{
+			ID:                  DataParityChannel,
+			Priority:            5,
+			SendQueueCapacity:   100,
+			RecvBufferCapacity:  50 * 4096,
+			RecvMessageCapacity: maxMsgSize,
+		})
+	}
+	// the peers advertising the channel get the CommitAggregates
+	if conR.conS.getConfig().AggregateCommits {
+		channels = append(channels, &p2p.ChannelDescriptor{
+			ID:                  CommitAggregateChannel,
+			Priority:            5,
+			SendQueueCapacity:   10,
+			RecvBufferCapacity:  10 * 4096,
+			RecvMessageCapacity: maxMsgSize,
+		})
+	}
+	return channels
 }
 
 // InitPeer implements Reactor by creating a state for the peer.
@@ -166,7 +198,7 @@ func (conR *ConsensusReactor) InitPeer(peer p2p.Peer) p2p.Peer {
 	peerState := NewPeerState(
 		peer,
 		func() int64 { return conR.conS.state.ConsensusParams.Block.LenULB },
-	).SetLogger(conR.Logger)
+	).SetLogger(conR.Logger).SetProtoMsgs(conR.Switch.ProtoMsgs(peer))
 	peer.Set(types.PeerStateKey, peerState)
 	return peer
 }
@@ -228,7 +260,7 @@ func (conR *ConsensusReactor) Receive(chID byte, src p2p.Peer, msgBytes []byte)
 		return
 	}
 
-	msg, err := decodeMsg(msgBytes)
+	msg, err := decodePeerMsg(msgBytes, conR.Switch.ProtoMsgs(src))
 	if err != nil {
 		conR.Logger.Error("Error decoding message", "src", src, "chId", chID, "msg", msg, "err", err, "bytes", msgBytes)
 		conR.Switch.StopPeerForError(src, err)
@@ -284,13 +316,20 @@ func (conR *ConsensusReactor) Receive(chID byte, src p2p.Peer, msgBytes []byte)
 			default:
 				panic("Bad VoteSetBitsMessage field Type. Forgot to add a check in ValidateBasic?")
 			}
-			src.TrySend(VoteSetBitsChannel, cdc.MustMarshalBinaryBare(&VoteSetBitsMessage{
+			src.TrySend(VoteSetBitsChannel, conR.encodeMsg(src, &VoteSetBitsMessage{
 				Height:  msg.Height,
 				Round:   msg.Round,
 				Type:    msg.Type,
 				BlockID: msg.BlockID,
 				Votes:   ourVotes,
 			}))
+		case *SignWatermarkRequestMessage:
+			src.TrySend(StateChannel, conR.encodeMsg(src, &SignWatermarkMessage{
+				ValidatorAddress: msg.ValidatorAddress,
+				Height:           conR.conS.signWatermark(msg.ValidatorAddress),
+			}))
+		case *SignWatermarkMessage:
+			conR.conS.receiveSignWatermark(msg.ValidatorAddress, msg.Height, src.ID())
 		default:
 			conR.Logger.Error(fmt.Sprintf("Unknown message type %v", reflect.TypeOf(msg)))
 		}
@@ -303,13 +342,28 @@ func (conR *ConsensusReactor) Receive(chID byte, src p2p.Peer, msgBytes []byte)
 		switch msg := msg.(type) {
 		case *ProposalMessage:
 			ps.SetHasProposal(msg.Proposal)
-			conR.conS.peerMsgQueue <- msgInfo{msg, src.ID()}
+			conR.conS.peerMsgQueue <- peerMsg(msg, src.ID())
 		case *ProposalPOLMessage:
 			ps.ApplyProposalPOLMessage(msg)
 		case *BlockPartMessage:
 			ps.SetHasProposalBlockPart(msg.Height, msg.Round, msg.Part.Index)
 			conR.metrics.BlockParts.With("peer_id", string(src.ID())).Add(1)
-			conR.conS.peerMsgQueue <- msgInfo{msg, src.ID()}
+			if conR.isStaleBlockPart(msg) {
+				conR.Switch.MarkPeerAsBad(src)
+			}
+			conR.conS.peerMsgQueue <- peerMsg(msg, src.ID())
+		default:
+			conR.Logger.Error(fmt.Sprintf("Unknown message type %v", reflect.TypeOf(msg)))
+		}
+
+	case DataParityChannel:
+		if conR.FastSync() {
+			conR.Logger.Info("Ignoring message received during fastSync", "msg", msg)
+			return
+		}
+		switch msg := msg.(type) {
+		case *BlockPartParityMessage:
+			conR.conS.peerMsgQueue <- peerMsg(msg, src.ID())
 		default:
 			conR.Logger.Error(fmt.Sprintf("Unknown message type %v", reflect.TypeOf(msg)))
 		}
@@ -334,13 +388,27 @@ func (conR *ConsensusReactor) Receive(chID byte, src p2p.Peer, msgBytes []byte)
 			ps.EnsureVoteBitArrays(height-lenULB, lastCommitSize)
 			ps.SetHasVote(msg.Vote)
 
-			cs.peerMsgQueue <- msgInfo{msg, src.ID()}
+			cs.peerMsgQueue <- peerMsg(msg, src.ID())
 
 		default:
 			// don't punish (leave room for soft upgrades)
 			conR.Logger.Error(fmt.Sprintf("Unknown message type %v", reflect.TypeOf(msg)))
 		}
 
+	case CommitAggregateChannel:
+		if conR.FastSync() {
+			conR.Logger.Info("Ignoring message received during fastSync", "msg", msg)
+			return
+		}
+		switch msg := msg.(type) {
+		case *CommitAggregateMessage:
+			ps.SetHasCommitAggregate(msg.QuorumCert.Height, msg.QuorumCert.Round)
+			conR.conS.peerMsgQueue <- peerMsg(msg, src.ID())
+		default:
+			// don't punish (leave room for soft upgrades)
+			conR.Logger.Error(fmt.Sprintf("Unknown message type %v", reflect.TypeOf(msg)))
+		}
+
 	case VoteSetBitsChannel:
 		if conR.FastSync() {
 			conR.Logger.Info("Ignoring message received during fastSync", "msg", msg)
@@ -395,6 +463,18 @@ func (conR *ConsensusReactor) FastSync() bool {
 
 //--------------------------------------
 
+// isStaleBlockPart returns whether the block part is of a finalized height,
+// or of a round we have passed, so the peer sending it wastes our bandwidth.
+func (conR *ConsensusReactor) isStaleBlockPart(msg *BlockPartMessage) bool {
+	rs := conR.conS.getRoundState(msg.Height)
+	if rs == nil {
+		return msg.Height <= conR.conS.blockStore.Height()
+	}
+	rs.RLock()
+	defer rs.RUnlock()
+	return msg.Round < rs.Round
+}
+
 // subscribeToBroadcastEvents subscribes for new round steps and votes
 // using internal pubsub defined on state to broadcast
 // them to peers upon receiving.
@@ -415,6 +495,31 @@ func (conR *ConsensusReactor) subscribeToBroadcastEvents() {
 			conR.broadcastHasVoteMessage(data.(*types.Vote))
 		})
 
+	// the peers send the block parts we miss again, see reportFinalizeStall
+	conR.conS.evsw.AddListenerForEvent(subscriber, types.EventFinalizeStall,
+		func(data tmevents.EventData) {
+			conR.broadcastNewValidBlockMessage(data.(*cstypes.RoundState))
+		})
+
+	// the peers missing the CommitAggregate get it, see addCommitAggregate
+	conR.conS.evsw.AddListenerForEvent(subscriber, eventCommitAggregate,
+		func(data tmevents.EventData) {
+			conR.broadcastCommitAggregate(data.(*types.QuorumCert))
+		})
+
+	// the peers answer with the sign watermark, see watchStandby
+	conR.conS.evsw.AddListenerForEvent(subscriber, eventSignWatermarkRequest,
+		func(data tmevents.EventData) {
+			msg := &SignWatermarkRequestMessage{ValidatorAddress: data.(crypto.Address)}
+			conR.broadcast(StateChannel, msg)
+		})
+
+	// the misbehavior simulated by the node, see doubleVote
+	conR.conS.evsw.AddListenerForEvent(subscriber, eventConflictingVote,
+		func(data tmevents.EventData) {
+			conR.broadcast(VoteChannel, &VoteMessage{data.(*types.Vote)})
+		})
+
 }
 
 func (conR *ConsensusReactor) unsubscribeFromBroadcastEvents() {
@@ -424,7 +529,7 @@ func (conR *ConsensusReactor) unsubscribeFromBroadcastEvents() {
 
 func (conR *ConsensusReactor) broadcastNewRoundStepMessage(rs *cstypes.RoundState) {
 	nrsMsg := makeRoundStepMessage(rs)
-	conR.Switch.Broadcast(StateChannel, cdc.MustMarshalBinaryBare(nrsMsg))
+	conR.broadcast(StateChannel, nrsMsg)
 }
 
 func (conR *ConsensusReactor) broadcastNewValidBlockMessage(rs *cstypes.RoundState) {
@@ -435,7 +540,7 @@ func (conR *ConsensusReactor) broadcastNewValidBlockMessage(rs *cstypes.RoundSta
 		BlockParts:       rs.ProposalBlockParts.BitArray(),
 		IsCommit:         rs.Step == cstypes.RoundStepCommit,
 	}
-	conR.Switch.Broadcast(StateChannel, cdc.MustMarshalBinaryBare(csMsg))
+	conR.broadcast(StateChannel, csMsg)
 }
 
 // Broadcasts HasVoteMessage to peers that care.
@@ -446,7 +551,7 @@ func (conR *ConsensusReactor) broadcastHasVoteMessage(vote *types.Vote) {
 		Type:   vote.Type,
 		Index:  vote.ValidatorIndex,
 	}
-	conR.Switch.Broadcast(StateChannel, cdc.MustMarshalBinaryBare(msg))
+	conR.broadcast(StateChannel, msg)
 	/*
 		// TODO: Make this broadcast more selective.
 		for _, peer := range conR.Switch.Peers().List() {
@@ -485,7 +590,7 @@ func (conR *ConsensusReactor) sendNewRoundStepMessage(height int64, peer p2p.Pee
 	}
 
 	nrsMsg := makeRoundStepMessage(rs)
-	peer.Send(StateChannel, cdc.MustMarshalBinaryBare(nrsMsg))
+	peer.Send(StateChannel, conR.encodeMsg(peer, nrsMsg))
 }
 
 func (conR *ConsensusReactor) gossipDataRoutine(peer p2p.Peer, ps *PeerState) {
@@ -548,7 +653,7 @@ OUTER_LOOP:
 
 		// Nothing to do. Sleep.
 		if !continuous {
-			time.Sleep(conR.conS.config.PeerGossipSleepDuration)
+			time.Sleep(conR.conS.getConfig().PeerGossipSleepDuration)
 		}
 		continue OUTER_LOOP
 	}
@@ -561,6 +666,14 @@ func (conR *ConsensusReactor) gossipProgressingRound(
 	ps *PeerState,
 	prs *cstypes.PeerRoundState) {
 	logger := conR.Logger.With("peer", peer, "height", height)
+
+	// The lowest uncommitted height holds up the whole pipeline,
+	// so its proposal and block parts are sent ahead of other traffic.
+	send := peer.Send
+	if height == conR.conS.GetLastHeight()+1 {
+		send = peer.SendUrgent
+	}
+
 	//Gossip proposal
 	func(height int64, rs *cstypes.RoundState, prs *cstypes.PeerRoundState) {
 		// Send Proposal && ProposalPOL BitArray?
@@ -569,7 +682,7 @@ func (conR *ConsensusReactor) gossipProgressingRound(
 			{
 				msg := &ProposalMessage{Proposal: rs.Proposal}
 				logger.Debug("Sending proposal", "round", prs.Round)
-				if peer.Send(DataChannel, cdc.MustMarshalBinaryBare(msg)) {
+				if send(DataChannel, conR.encodeMsg(peer, msg)) {
 					// NOTE[ZM]: A peer might have received different proposal msg so this Proposal msg will be rejected!
 					ps.SetHasProposal(rs.Proposal)
 				}
@@ -585,7 +698,7 @@ func (conR *ConsensusReactor) gossipProgressingRound(
 					ProposalPOL:      rs.Votes.Prevotes(rs.Proposal.POLRound).BitArray(),
 				}
 				logger.Debug("Sending POL", "round", prs.Round)
-				peer.Send(DataChannel, cdc.MustMarshalBinaryBare(msg))
+				send(DataChannel, conR.encodeMsg(peer, msg))
 			}
 			return
 		}
@@ -601,6 +714,10 @@ func (conR *ConsensusReactor) gossipProgressingRound(
 		}
 
 		if rs.ProposalBlockParts.HasHeader(prs.ProposalBlockPartsHeader) {
+			// the parity parts rebuild the parts the peer misses from the
+			// parts it gets from any peers
+			conR.gossipBlockPartsParity(rs, peer, ps, prs)
+
 			if index, ok := rs.ProposalBlockParts.BitArray().Sub(prs.ProposalBlockParts.Copy()).PickRandom(); ok {
 				part := rs.ProposalBlockParts.GetPart(index)
 				msg := &BlockPartMessage{
@@ -609,7 +726,7 @@ func (conR *ConsensusReactor) gossipProgressingRound(
 					Part:   part,
 				}
 				logger.Debug("Sending block part", "round", prs.Round)
-				if peer.Send(DataChannel, cdc.MustMarshalBinaryBare(msg)) {
+				if send(DataChannel, conR.encodeMsg(peer, msg)) {
 					ps.SetHasProposalBlockPart(prs.Height, prs.Round, index)
 				}
 				return
@@ -627,12 +744,12 @@ func (conR *ConsensusReactor) gossipDataForCatchupPerPRS(prs *cstypes.PeerRoundS
 		if blockMeta == nil {
 			logger.Error("Failed to load block meta",
 				"targetHeight", prs.Height, "blockstoreHeight", conR.conS.blockStore.Height())
-			time.Sleep(conR.conS.config.PeerGossipSleepDuration)
+			time.Sleep(conR.conS.getConfig().PeerGossipSleepDuration)
 			return
 		} else if !blockMeta.BlockID.PartsHeader.Equals(prs.ProposalBlockPartsHeader) {
 			logger.Info("Peer ProposalBlockPartsHeader mismatch, sleeping",
 				"blockPartsHeader", blockMeta.BlockID.PartsHeader, "peerBlockPartsHeader", prs.ProposalBlockPartsHeader)
-			time.Sleep(conR.conS.config.PeerGossipSleepDuration)
+			time.Sleep(conR.conS.getConfig().PeerGossipSleepDuration)
 			return
 		}
 		// Load the part
@@ -640,7 +757,7 @@ func (conR *ConsensusReactor) gossipDataForCatchupPerPRS(prs *cstypes.PeerRoundS
 		if part == nil {
 			logger.Error("Could not load part", "index", index,
 				"blockPartsHeader", blockMeta.BlockID.PartsHeader, "peerBlockPartsHeader", prs.ProposalBlockPartsHeader)
-			time.Sleep(conR.conS.config.PeerGossipSleepDuration)
+			time.Sleep(conR.conS.getConfig().PeerGossipSleepDuration)
 			return
 		}
 		// Send the part
@@ -650,7 +767,7 @@ func (conR *ConsensusReactor) gossipDataForCatchupPerPRS(prs *cstypes.PeerRoundS
 			Part:   part,
 		}
 		logger.Debug("Sending block part for catchup", "round", prs.Round, "index", index)
-		if peer.Send(DataChannel, cdc.MustMarshalBinaryBare(msg)) {
+		if peer.Send(DataChannel, conR.encodeMsg(peer, msg)) {
 			ps.SetHasProposalBlockPart(prs.Height, prs.Round, index)
 		} else {
 			logger.Debug("Sending block part for catchup failed")
@@ -658,7 +775,7 @@ func (conR *ConsensusReactor) gossipDataForCatchupPerPRS(prs *cstypes.PeerRoundS
 		return
 	}
 	//logger.Info("No parts to send in catch-up, sleeping")
-	time.Sleep(conR.conS.config.PeerGossipSleepDuration)
+	time.Sleep(conR.conS.getConfig().PeerGossipSleepDuration)
 }
 
 func (conR *ConsensusReactor) gossipVotesRoutine(peer p2p.Peer, ps *PeerState) {
@@ -680,7 +797,12 @@ OUTER_LOOP:
 			if height != 0 && height <= commitedHeight {
 				// for Catchup
 				commit := conR.conS.LoadCommit(height)
-				if ps.PickSendVote(commit) {
+				if commit.IsAggregate() {
+					// its precommits have no signatures, the peer commits on it
+					if conR.sendCommitAggregate(peer, ps, commit.QuorumCert()) {
+						logger.Info("Sent Catchup CommitAggregate", "height", height)
+					}
+				} else if ps.PickSendVote(commit, false) {
 					logger.Info("Picked Catchup commit to send", "height", height)
 				}
 				continuous = true
@@ -690,7 +812,9 @@ OUTER_LOOP:
 				// for Progressing rounds
 				if rs, prs := conR.conS.GetRoundState(height), ps.GetRoundState(height); rs != nil && prs != nil {
 					heightLogger := logger.With("height", prs.Height)
-					conR.gossipVotesForHeight(heightLogger, rs, prs, ps)
+					// votes of the lowest uncommitted height hold up the whole pipeline
+					urgent := height == commitedHeight+1
+					conR.gossipVotesForHeight(heightLogger, rs, prs, ps, urgent)
 				}
 				return true
 			}
@@ -699,17 +823,25 @@ OUTER_LOOP:
 		})
 
 		if !continuous {
-			time.Sleep(conR.conS.config.PeerGossipSleepDuration)
+			time.Sleep(conR.conS.getConfig().PeerGossipSleepDuration)
 		}
 		continue OUTER_LOOP
 	}
 }
 
-func (conR *ConsensusReactor) gossipVotesForHeight(logger log.Logger, rs *cstypes.RoundState, prs *cstypes.PeerRoundState, ps *PeerState) bool {
+func (conR *ConsensusReactor) gossipVotesForHeight(
+	logger log.Logger,
+	rs *cstypes.RoundState,
+	prs *cstypes.PeerRoundState,
+	ps *PeerState,
+	urgent bool) bool {
 
 	// If there are lastCommits to send...
-	if prs.Step == cstypes.RoundStepNewHeight {
-		if ps.PickSendVote(rs.LastCommit) {
+	// The peers with the CommitAggregate of the LastCommit don't need its
+	// precommits if the blocks carry it instead.
+	lastCommitAggregated := conR.peerHasLastCommitAggregate(rs, ps)
+	if prs.Step == cstypes.RoundStepNewHeight && !lastCommitAggregated {
+		if ps.PickSendVote(rs.LastCommit, urgent) {
 			logger.Debug("Picked rs.LastCommit to send")
 			return true
 		}
@@ -717,7 +849,7 @@ func (conR *ConsensusReactor) gossipVotesForHeight(logger log.Logger, rs *cstype
 	// If there are POL prevotes to send...
 	if prs.Step <= cstypes.RoundStepPropose && prs.Round != -1 && prs.Round <= rs.Round && prs.ProposalPOLRound != -1 {
 		if polPrevotes := rs.Votes.Prevotes(prs.ProposalPOLRound); polPrevotes != nil {
-			if ps.PickSendVote(polPrevotes) {
+			if ps.PickSendVote(polPrevotes, urgent) {
 				logger.Debug("Picked rs.Prevotes(prs.ProposalPOLRound) to send",
 					"round", prs.ProposalPOLRound)
 				return true
@@ -726,21 +858,25 @@ func (conR *ConsensusReactor) gossipVotesForHeight(logger log.Logger, rs *cstype
 	}
 	// If there are prevotes to send...
 	if prs.Step <= cstypes.RoundStepPrevoteWait && prs.Round != -1 && prs.Round <= rs.Round {
-		if ps.PickSendVote(rs.Votes.Prevotes(prs.Round)) {
+		if ps.PickSendVote(rs.Votes.Prevotes(prs.Round), urgent) {
 			logger.Debug("Picked rs.Prevotes(prs.Round) to send", "round", prs.Round)
 			return true
 		}
 	}
 	// If there are precommits to send...
-	if prs.Step <= cstypes.RoundStepPrecommitWait && prs.Round != -1 && prs.Round <= rs.Round {
-		if ps.PickSendVote(rs.Votes.Precommits(prs.Round)) {
+	// The peers with the CommitAggregate of the round commit the height on it,
+	// they get the precommits with the LastCommit of the block LenULB heights
+	// above.
+	if prs.Step <= cstypes.RoundStepPrecommitWait && prs.Round != -1 && prs.Round <= rs.Round &&
+		!ps.HasCommitAggregate(rs.Height, prs.Round) {
+		if ps.PickSendVote(rs.Votes.Precommits(prs.Round), urgent) {
 			logger.Debug("Picked rs.Precommits(prs.Round) to send", "round", prs.Round)
 			return true
 		}
 	}
 	// If there are prevotes to send...Needed because of validBlock mechanism
 	if prs.Round != -1 && prs.Round <= rs.Round {
-		if ps.PickSendVote(rs.Votes.Prevotes(prs.Round)) {
+		if ps.PickSendVote(rs.Votes.Prevotes(prs.Round), urgent) {
 			logger.Debug("Picked rs.Prevotes(prs.Round) to send", "round", prs.Round)
 			return true
 		}
@@ -748,13 +884,22 @@ func (conR *ConsensusReactor) gossipVotesForHeight(logger log.Logger, rs *cstype
 	// If there are POLPrevotes to send...
 	if prs.ProposalPOLRound != -1 {
 		if polPrevotes := rs.Votes.Prevotes(prs.ProposalPOLRound); polPrevotes != nil {
-			if ps.PickSendVote(polPrevotes) {
+			if ps.PickSendVote(polPrevotes, urgent) {
 				logger.Debug("Picked rs.Prevotes(prs.ProposalPOLRound) to send",
 					"round", prs.ProposalPOLRound)
 				return true
 			}
 		}
 	}
+	// With AggregateCommits, the heights are committed by the CommitAggregates
+	// before the precommits of the LastCommit reach the peer: they are sent in
+	// the background, once the votes of the round are.
+	if prs.Step != cstypes.RoundStepNewHeight && conR.conS.getConfig().AggregateCommits && !lastCommitAggregated {
+		if ps.PickSendVote(rs.LastCommit, false) {
+			logger.Debug("Picked rs.LastCommit to send in the background")
+			return true
+		}
+	}
 
 	return false
 }
@@ -781,13 +926,13 @@ OUTER_LOOP:
 				prs := ps.GetRoundState(prsHeight)
 				if prs != nil && prs.CatchupCommitRound != -1 && 0 < prs.Height && prs.Height <= conR.conS.blockStore.Height() {
 					commit := conR.conS.LoadCommit(prs.Height)
-					peer.TrySend(StateChannel, cdc.MustMarshalBinaryBare(&VoteSetMaj23Message{
+					peer.TrySend(StateChannel, conR.encodeMsg(peer, &VoteSetMaj23Message{
 						Height:  prs.Height,
 						Round:   commit.Round(),
 						Type:    types.PrecommitType,
 						BlockID: commit.BlockID,
 					}))
-					time.Sleep(conR.conS.config.PeerQueryMaj23SleepDuration)
+					time.Sleep(conR.conS.getConfig().PeerQueryMaj23SleepDuration)
 				}
 			} else {
 				rs := conR.conS.GetRoundState(prsHeight)
@@ -799,26 +944,26 @@ OUTER_LOOP:
 				// Maybe send Height/Round/Prevotes
 				{
 					if maj23, ok := rs.Votes.Prevotes(prs.Round).TwoThirdsMajority(); ok {
-						peer.TrySend(StateChannel, cdc.MustMarshalBinaryBare(&VoteSetMaj23Message{
+						peer.TrySend(StateChannel, conR.encodeMsg(peer, &VoteSetMaj23Message{
 							Height:  prs.Height,
 							Round:   prs.Round,
 							Type:    types.PrevoteType,
 							BlockID: maj23,
 						}))
-						time.Sleep(conR.conS.config.PeerQueryMaj23SleepDuration)
+						time.Sleep(conR.conS.getConfig().PeerQueryMaj23SleepDuration)
 					}
 				}
 
 				// Maybe send Height/Round/Precommits
 				{
 					if maj23, ok := rs.Votes.Precommits(prs.Round).TwoThirdsMajority(); ok {
-						peer.TrySend(StateChannel, cdc.MustMarshalBinaryBare(&VoteSetMaj23Message{
+						peer.TrySend(StateChannel, conR.encodeMsg(peer, &VoteSetMaj23Message{
 							Height:  prs.Height,
 							Round:   prs.Round,
 							Type:    types.PrecommitType,
 							BlockID: maj23,
 						}))
-						time.Sleep(conR.conS.config.PeerQueryMaj23SleepDuration)
+						time.Sleep(conR.conS.getConfig().PeerQueryMaj23SleepDuration)
 					}
 				}
 
@@ -826,13 +971,13 @@ OUTER_LOOP:
 				{
 					if prs.ProposalPOLRound >= 0 {
 						if maj23, ok := rs.Votes.Prevotes(prs.ProposalPOLRound).TwoThirdsMajority(); ok {
-							peer.TrySend(StateChannel, cdc.MustMarshalBinaryBare(&VoteSetMaj23Message{
+							peer.TrySend(StateChannel, conR.encodeMsg(peer, &VoteSetMaj23Message{
 								Height:  prs.Height,
 								Round:   prs.ProposalPOLRound,
 								Type:    types.PrevoteType,
 								BlockID: maj23,
 							}))
-							time.Sleep(conR.conS.config.PeerQueryMaj23SleepDuration)
+							time.Sleep(conR.conS.getConfig().PeerQueryMaj23SleepDuration)
 						}
 					}
 				}
@@ -840,7 +985,7 @@ OUTER_LOOP:
 			return true
 		})
 
-		time.Sleep(conR.conS.config.PeerQueryMaj23SleepDuration)
+		time.Sleep(conR.conS.getConfig().PeerQueryMaj23SleepDuration)
 		continue OUTER_LOOP
 	}
 }
@@ -944,11 +1089,17 @@ type PeerState struct {
 	peer       p2p.Peer
 	logger     log.Logger
 	ulbHandler ULBLengthHandler
+	protoMsgs  bool // the messages to the peer are encoded with protobuf
 
 	highestHeight int64
 	mtx           sync.Mutex      // NOTE: Modify below using setters, never directly.
 	PRS           sync.Map        `json:"round_state"` // Exposed.
 	Stats         *peerStateStats `json:"stats"`       // Exposed.
+
+	// the parity parts sent by height
+	parity map[int64]*peerParity
+	// the round of the CommitAggregate the peer has, by height
+	commitAggregates map[int64]int
 }
 
 // peerStateStats holds internal statistics for a peer.
@@ -981,6 +1132,13 @@ func (ps *PeerState) SetLogger(logger log.Logger) *PeerState {
 	return ps
 }
 
+// SetProtoMsgs sets whether the messages to the peer are encoded with
+// protobuf, see p2p.Switch.ProtoMsgs. Returns the peer state itself.
+func (ps *PeerState) SetProtoMsgs(protoMsgs bool) *PeerState {
+	ps.protoMsgs = protoMsgs
+	return ps
+}
+
 // GetRoundState returns an shallow copy of the PeerRoundState.
 // There's no point in mutating it since it won't change PeerState.
 func (ps *PeerState) GetRoundState(height int64) *cstypes.PeerRoundState {
@@ -1086,12 +1244,17 @@ func (ps *PeerState) SetHasProposalBlockPart(height int64, round int, index int)
 }
 
 // PickSendVote picks a vote and sends it to the peer.
+// If urgent, the vote is sent ahead of the other messages queued for the peer.
 // Returns true if vote was sent.
-func (ps *PeerState) PickSendVote(votes types.VoteSetReader) bool {
+func (ps *PeerState) PickSendVote(votes types.VoteSetReader, urgent bool) bool {
 	if vote, ok := ps.PickVoteToSend(votes); ok {
 		msg := &VoteMessage{vote}
-		ps.logger.Debug("Sending vote message", "ps", ps, "vote", vote)
-		if ps.peer.Send(VoteChannel, cdc.MustMarshalBinaryBare(msg)) {
+		ps.logger.Debug("Sending vote message", "ps", ps, "vote", vote, "urgent", urgent)
+		send := ps.peer.Send
+		if urgent {
+			send = ps.peer.SendUrgent
+		}
+		if send(VoteChannel, encodeMsg(msg, ps.protoMsgs)) {
 			ps.SetHasVote(vote)
 			return true
 		}
@@ -1451,19 +1614,6 @@ type ConsensusMessage interface {
 	ValidateBasic() error
 }
 
-func RegisterConsensusMessages(cdc *amino.Codec) {
-	cdc.RegisterInterface((*ConsensusMessage)(nil), nil)
-	cdc.RegisterConcrete(&NewRoundStepMessage{}, "tendermint/NewRoundStepMessage", nil)
-	cdc.RegisterConcrete(&NewValidBlockMessage{}, "tendermint/NewValidBlockMessage", nil)
-	cdc.RegisterConcrete(&ProposalMessage{}, "tendermint/Proposal", nil)
-	cdc.RegisterConcrete(&ProposalPOLMessage{}, "tendermint/ProposalPOL", nil)
-	cdc.RegisterConcrete(&BlockPartMessage{}, "tendermint/BlockPart", nil)
-	cdc.RegisterConcrete(&VoteMessage{}, "tendermint/Vote", nil)
-	cdc.RegisterConcrete(&HasVoteMessage{}, "tendermint/HasVote", nil)
-	cdc.RegisterConcrete(&VoteSetMaj23Message{}, "tendermint/VoteSetMaj23", nil)
-	cdc.RegisterConcrete(&VoteSetBitsMessage{}, "tendermint/VoteSetBits", nil)
-}
-
 func decodeMsg(bz []byte) (msg ConsensusMessage, err error) {
 	if len(bz) > maxMsgSize {
 		return msg, fmt.Errorf("Msg exceeds max size (%d > %d)", len(bz), maxMsgSize)
@@ -1633,6 +1783,51 @@ func (m *BlockPartMessage) String() string {
 
 //-------------------------------------
 
+// BlockPartParityMessage is sent when gossipping a parity part of the
+// proposed block parts.
+type BlockPartParityMessage struct {
+	Height      int64
+	Round       int
+	PartsHeader types.PartSetHeader
+	DataSize    int
+	Index       int
+	Bytes       []byte
+}
+
+// ValidateBasic performs basic validation.
+func (m *BlockPartParityMessage) ValidateBasic() error {
+	if m.Height < 0 {
+		return errors.New("Negative Height")
+	}
+	if m.Round < 0 {
+		return errors.New("Negative Round")
+	}
+	if err := m.PartsHeader.ValidateBasic(); err != nil {
+		return fmt.Errorf("Wrong PartsHeader: %v", err)
+	}
+	if m.PartsHeader.Total < 2 {
+		return errors.New("Parity of a single part")
+	}
+	if m.Index < 0 || m.Index >= erasure.MaxShards-m.PartsHeader.Total {
+		return fmt.Errorf("Wrong Index %d of the parity of %d parts", m.Index, m.PartsHeader.Total)
+	}
+	if m.DataSize <= (m.PartsHeader.Total-1)*types.BlockPartSizeBytes ||
+		m.DataSize > m.PartsHeader.Total*types.BlockPartSizeBytes {
+		return fmt.Errorf("Wrong DataSize %d of %d parts", m.DataSize, m.PartsHeader.Total)
+	}
+	if len(m.Bytes) != parityShardSize(m.DataSize) {
+		return fmt.Errorf("Wrong Bytes size %d, expected %d", len(m.Bytes), parityShardSize(m.DataSize))
+	}
+	return nil
+}
+
+// String returns a string representation.
+func (m *BlockPartParityMessage) String() string {
+	return fmt.Sprintf("[BlockPartParity H:%v R:%v PH:%v I:%v]", m.Height, m.Round, m.PartsHeader, m.Index)
+}
+
+//-------------------------------------
+
 // VoteMessage is sent when voting for a proposal (or lack thereof).
 type VoteMessage struct {
 	Vote *types.Vote
@@ -1750,3 +1945,77 @@ func (m *VoteSetBitsMessage) String() string {
 }
 
 //-------------------------------------
+
+//-------------------------------------
+
+// SignWatermarkRequestMessage is sent by a validator in standby, entering the
+// validator set, to ask for the highest height signed with its key.
+type SignWatermarkRequestMessage struct {
+	ValidatorAddress crypto.Address
+}
+
+// ValidateBasic performs basic validation.
+func (m *SignWatermarkRequestMessage) ValidateBasic() error {
+	if len(m.ValidatorAddress) != crypto.AddressSize {
+		return fmt.Errorf("Expected ValidatorAddress size to be %d bytes, got %d bytes",
+			crypto.AddressSize, len(m.ValidatorAddress))
+	}
+	return nil
+}
+
+// String returns a string representation.
+func (m *SignWatermarkRequestMessage) String() string {
+	return fmt.Sprintf("[SignWatermarkRequest %X]", cmn.Fingerprint(m.ValidatorAddress))
+}
+
+//-------------------------------------
+
+// SignWatermarkMessage is the answer to a SignWatermarkRequestMessage: the
+// highest height of the heights in progress with a vote of the validator, or
+// signed by the node if it is the validator.
+type SignWatermarkMessage struct {
+	ValidatorAddress crypto.Address
+	Height           int64
+}
+
+// ValidateBasic performs basic validation.
+func (m *SignWatermarkMessage) ValidateBasic() error {
+	if len(m.ValidatorAddress) != crypto.AddressSize {
+		return fmt.Errorf("Expected ValidatorAddress size to be %d bytes, got %d bytes",
+			crypto.AddressSize, len(m.ValidatorAddress))
+	}
+	if m.Height < 0 {
+		return errors.New("Negative Height")
+	}
+	return nil
+}
+
+// String returns a string representation.
+func (m *SignWatermarkMessage) String() string {
+	return fmt.Sprintf("[SignWatermark %X H:%v]", cmn.Fingerprint(m.ValidatorAddress), m.Height)
+}
+
+//-------------------------------------
+
+// CommitAggregateMessage is sent when gossipping the CommitAggregate of a
+// height: the +2/3 precommits for its block at a round, with their signatures
+// aggregated into a QuorumCert.
+type CommitAggregateMessage struct {
+	QuorumCert *types.QuorumCert
+}
+
+// ValidateBasic performs basic validation.
+func (m *CommitAggregateMessage) ValidateBasic() error {
+	if m.QuorumCert == nil {
+		return errors.New("Nil QuorumCert")
+	}
+	if err := m.QuorumCert.ValidateBasic(); err != nil {
+		return fmt.Errorf("Wrong QuorumCert: %v", err)
+	}
+	return nil
+}
+
+// String returns a string representation.
+func (m *CommitAggregateMessage) String() string {
+	return fmt.Sprintf("[CommitAggregate %v]", m.QuorumCert)
+}
@@ -0,0 +1,144 @@
+package friday
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/pkg/errors"
+
+	sm "github.com/hdac-io/tendermint/state"
+	"github.com/hdac-io/tendermint/types"
+)
+
+const (
+	// how often the heights of the peers are compared to the height in
+	// progress, and how many consecutive times the node must be behind them
+	// to fast sync again
+	reentryCheckInterval = 1 * time.Second
+	reentryChecks        = 3
+)
+
+// fastSyncReactor is the reactor the consensus hands over to when it fell
+// behind the peers, which switches back to the consensus once caught up.
+type fastSyncReactor interface {
+	SwitchToFastSync(sm.State) error
+}
+
+// fastSyncReentryRoutine pauses the consensus and fast syncs again when the
+// height reached by more than a third of the peers is more than lag heights
+// above the height in progress, reentryChecks times in a row, instead of
+// catching up one height at a time.
+func (conR *ConsensusReactor) fastSyncReentryRoutine(lag int64) {
+	ticker := time.NewTicker(reentryCheckInterval)
+	defer ticker.Stop()
+
+	behind := 0
+	for {
+		select {
+		case <-ticker.C:
+		case <-conR.Quit():
+			return
+		}
+		if conR.FastSync() {
+			behind = 0
+			continue
+		}
+		height, peersHeight := conR.conS.GetPipelineHeight(), conR.peersHeight()
+		if peersHeight-height <= lag {
+			behind = 0
+			continue
+		}
+		if behind++; behind < reentryChecks {
+			continue
+		}
+		behind = 0
+
+		bcR, ok := conR.Switch.Reactor("BLOCKCHAIN").(fastSyncReactor)
+		if !ok {
+			conR.Logger.Error("Fell behind the peers, but the blockchain reactor can't fast sync again",
+				"height", height, "peersHeight", peersHeight)
+			return
+		}
+		conR.Logger.Info("Fell behind the peers, switching to fast sync", "height", height,
+			"peersHeight", peersHeight, "lag", lag)
+		state, err := conR.SwitchToFastSync()
+		if err != nil {
+			conR.Logger.Error("Failed to switch to fast sync", "err", err)
+			continue
+		}
+		if err := bcR.SwitchToFastSync(state); err != nil {
+			conR.Logger.Error("Failed to fast sync, resuming the consensus", "err", err)
+			conR.SwitchToConsensus(state, 0)
+		}
+	}
+}
+
+// peersHeight returns the highest height in progress reached by more than a
+// third of the peers, so that a few peers can't make the node fast sync, or 0
+// without peers.
+func (conR *ConsensusReactor) peersHeight() int64 {
+	var heights []int64
+	for _, peer := range conR.Switch.Peers().List() {
+		if ps, ok := peer.Get(types.PeerStateKey).(*PeerState); ok {
+			heights = append(heights, ps.GetHeight())
+		}
+	}
+	if len(heights) == 0 {
+		return 0
+	}
+	sort.Slice(heights, func(i, j int) bool { return heights[i] > heights[j] })
+	return heights[len(heights)/3]
+}
+
+// SwitchToFastSync stops the consensus, dropping the heights in progress, and
+// returns its state, for the blockchain reactor to fast sync from it and
+// switch back with SwitchToConsensus.
+func (conR *ConsensusReactor) SwitchToFastSync() (sm.State, error) {
+	conR.mtx.Lock()
+	if conR.fastSync {
+		conR.mtx.Unlock()
+		return sm.State{}, errors.New("already fast syncing")
+	}
+	conR.fastSync = true
+	conR.mtx.Unlock()
+	conR.metrics.FastSyncing.Set(1)
+
+	if err := conR.conS.Stop(); err != nil {
+		conR.mtx.Lock()
+		conR.fastSync = false
+		conR.mtx.Unlock()
+		conR.metrics.FastSyncing.Set(0)
+		return sm.State{}, err
+	}
+	conR.conS.Wait()
+	if err := conR.conS.Reset(); err != nil {
+		panic(fmt.Sprintf("Failed to reset consensus state: %v", err))
+	}
+	// stopped with the consensus state
+	go conR.peerStatsRoutine()
+
+	return conR.conS.GetState(), nil
+}
+
+// OnReset implements cmn.Service, for the consensus to be started again after
+// SwitchToFastSync, once handed off from the fast sync. It must be called
+// after Wait, for no handler to run anymore: the handlers waiting for a lower
+// height to be finalized returned when stopped, without finalizing their
+// height. The RoundStates of the heights in progress are then dropped with
+// their stopped tickers, and the state is the one of the last block saved.
+func (cs *ConsensusState) OnReset() error {
+	cs.finalizeMtx.Lock()
+	cs.roundStates.Range(func(key, value interface{}) bool {
+		cs.deleteRoundState(key.(int64))
+		return true
+	})
+	cs.finalizeMtx.Unlock()
+
+	cs.wal = nilWAL{}
+	cs.done = make(chan struct{})
+	cs.failureMtx.Lock()
+	cs.failures = 0
+	cs.failureMtx.Unlock()
+	return cs.evsw.Reset()
+}
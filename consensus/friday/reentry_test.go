@@ -0,0 +1,50 @@
+package friday
+
+import (
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConsensusStateResetAndHandoff(t *testing.T) {
+	h := newFuzzHarness(t, rand.New(rand.NewSource(1)), 2)
+	defer h.cleanup()
+
+	run := func() {
+		require.NoError(t, h.cs.Start())
+		go func() {
+			for {
+				select {
+				case <-h.cs.statsMsgQueue:
+				case <-h.cs.Quit():
+					return
+				}
+			}
+		}()
+		for i := 0; i < 300; i++ {
+			h.step()
+			time.Sleep(time.Millisecond)
+		}
+		require.NoError(t, h.cs.Stop())
+		h.cs.Wait()
+	}
+
+	run()
+	require.NoError(t, h.cs.Reset())
+	heights := 0
+	h.cs.roundStates.Range(func(key, value interface{}) bool {
+		heights++
+		return true
+	})
+	assert.Zero(t, heights, "the heights in progress must be dropped")
+
+	// nothing was fast synced: the consensus resumes above the last block
+	state := h.cs.GetState()
+	require.NoError(t, h.cs.handoffFromFastSync(state))
+	assert.NotNil(t, h.cs.getRoundState(state.LastBlockHeight+1))
+	run()
+	assert.True(t, h.cs.GetLastHeight() >= state.LastBlockHeight)
+}
@@ -44,7 +44,8 @@ var crc32c = crc32.MakeTable(crc32.Castagnoli)
 // NOTE: receiveRoutine should not be running.
 func (cs *ConsensusState) readReplayMessage(msg *TimedWALMessage, newStepSub types.Subscription) error {
 	// Skip meta messages which exist for demarcating boundaries.
-	if _, ok := msg.Msg.(EndHeightMessage); ok {
+	switch msg.Msg.(type) {
+	case EndHeightMessage, CheckpointMessage:
 		return nil
 	}
 
@@ -75,6 +76,9 @@ func (cs *ConsensusState) readReplayMessage(msg *TimedWALMessage, newStepSub typ
 			}
 		}
 	case msgInfo:
+		// the WAL doesn't keep the provenance: its messages without peer are
+		// the ones of this node
+		m.internal = m.PeerID == ""
 		peerID := m.PeerID
 		if peerID == "" {
 			peerID = "local"
@@ -117,10 +121,6 @@ func (cs *ConsensusState) readReplayMessage(msg *TimedWALMessage, newStepSub typ
 // Replay only those messages since the last block.  `timeoutRoutine` should
 // run concurrently to read off tickChan.
 func (cs *ConsensusState) catchupReplay(csHeight int64) error {
-	if csHeight <= 1 {
-		return nil
-	}
-
 	// Set replayMode to true so we don't log signing errors.
 	cs.replayMode = true
 	defer func() { cs.replayMode = false }()
@@ -162,9 +162,10 @@ func (cs *ConsensusState) catchupReplay(csHeight int64) error {
 	// Search for starting height marker
 	// In friday consensus, consensus proceeds in parallel, so it should be noted that the progress is from before the last commited height-ulb.
 	//ex: lastCommitedHeight=5, progressable height = 5+ulb == 6~8, starting height = 6-ulb == 3
+	// The heights up to LenULB start from the beginning of the WAL, #ENDHEIGHT 0.
 	startingHeight := csHeight - cs.state.ConsensusParams.Block.LenULB
-	if startingHeight < 1 {
-		startingHeight = 1
+	if startingHeight < 0 {
+		startingHeight = 0
 	}
 	gr, found, err = cs.wal.SearchForEndHeight(startingHeight, &WALSearchOptions{IgnoreDataCorruptionErrors: true})
 	if err == io.EOF {
@@ -175,12 +176,19 @@ func (cs *ConsensusState) catchupReplay(csHeight int64) error {
 	if !found {
 		return fmt.Errorf("Cannot replay height %d. WAL does not contain #ENDHEIGHT for %d", csHeight, startingHeight)
 	}
+
+	// Resume from the checkpoint, if any, replaying only the messages after it
+	restored := false
+	if cgr, ok := cs.restoreCheckpoint(); ok {
+		gr.Close() // nolint: errcheck
+		gr, restored = cgr, true
+	}
 	defer gr.Close() // nolint: errcheck
 
-	cs.Logger.Info("Catchup by replaying consensus messages", "height", csHeight)
+	cs.Logger.Info("Catchup by replaying consensus messages", "height", csHeight, "fromCheckpoint", restored)
 
 	var msg *TimedWALMessage
-	dec := NewWALDecoder(gr)
+	dec := NewEncryptedWALDecoder(gr, cs.walSym, cs.walSecret)
 
 LOOP:
 	for {
@@ -202,6 +210,9 @@ LOOP:
 			return err
 		}
 	}
+	if restored {
+		cs.resumeRestoredHeights()
+	}
 	cs.Logger.Info("Replay: Done")
 	return nil
 }
@@ -246,6 +257,9 @@ type Handshaker struct {
 	genDoc       *types.GenesisDoc
 	logger       log.Logger
 
+	// deliver the txs of the replayed blocks one at a time
+	parallelDeliverTx bool
+
 	nBlocks int // number of blocks applied to the state
 }
 
@@ -273,6 +287,14 @@ func (h *Handshaker) SetEventBus(eventBus types.BlockEventPublisher) {
 	h.eventBus = eventBus
 }
 
+// SetParallelDeliverTx makes the replayed blocks deliver their txs one at a
+// time, for the apps the node delivers the txs concurrently to (see
+// sm.BlockExecutorWithParallelDeliverTx): the conflict keys of the txs are
+// unknown when replaying.
+func (h *Handshaker) SetParallelDeliverTx() {
+	h.parallelDeliverTx = true
+}
+
 // NBlocks returns the number of blocks applied to the state.
 func (h *Handshaker) NBlocks() int {
 	return h.nBlocks
@@ -282,11 +304,16 @@ func (h *Handshaker) NBlocks() int {
 func (h *Handshaker) Handshake(proxyApp proxy.AppConns) error {
 
 	// Handshake is done via ABCI Info on the query conn.
-	res, err := proxyApp.Query().InfoSync(proxy.RequestInfo)
+	res, err := proxyApp.Query().InfoSync(h.requestInfo())
 	if err != nil {
 		return fmt.Errorf("Error calling Info: %v", err)
 	}
 
+	lenULB := h.initialState.ConsensusParams.Block.LenULB
+	if !res.SupportsLenULB(lenULB) {
+		return sm.ErrAppLenULBUnsupported{LenULB: lenULB, Min: res.MinLenUlb, Max: res.MaxLenUlb}
+	}
+
 	blockHeight := res.LastBlockHeight
 	if blockHeight < 0 {
 		return fmt.Errorf("Got a negative last block height (%d) from the app", blockHeight)
@@ -320,6 +347,20 @@ func (h *Handshaker) Handshake(proxyApp proxy.AppConns) error {
 	return nil
 }
 
+// requestInfo returns the Info request of the handshake, telling the app the
+// consensus module, LenULB and heights of the pipeline: the blocks up to
+// LastFinalizedHeight are committed, and the heights up to LastProposedHeight
+// may have been proposed, ahead of them.
+func (h *Handshaker) requestInfo() abci.RequestInfo {
+	req := proxy.RequestInfo
+	lenULB := h.initialState.ConsensusParams.Block.LenULB
+	req.ConsensusModule = h.initialState.Version.Consensus.Module
+	req.LenUlb = lenULB
+	req.LastFinalizedHeight = h.store.Height()
+	req.LastProposedHeight = h.store.Height() + lenULB
+	return req
+}
+
 // ReplayBlocks replays all blocks since appBlockHeight and ensures the result
 // matches the current state.
 // Returns the final AppHash or an error.
@@ -371,6 +412,9 @@ func (h *Handshaker) ReplayBlocks(
 			if res.ConsensusParams != nil {
 				state.ConsensusParams = state.ConsensusParams.Update(res.ConsensusParams)
 			}
+			if err := state.ConsensusParams.Validator.CheckPowerShare(state.Validators.Validators); err != nil {
+				return nil, fmt.Errorf("Error in InitChain validators: %v", err)
+			}
 			sm.SaveState(h.stateDB, state)
 		}
 	}
@@ -475,7 +519,7 @@ func (h *Handshaker) replayBlocks(state sm.State, proxyApp proxy.AppConns, appBl
 		}
 
 		appHash = appHash[1:]
-		execedAppHash, err := sm.ExecCommitBlock(proxyApp.Consensus(), block, h.logger, h.stateDB, lenULB)
+		execedAppHash, err := sm.ExecCommitBlock(proxyApp.Consensus(), block, h.logger, h.stateDB, lenULB, h.parallelDeliverTx)
 		appHash = append(appHash, execedAppHash)
 		if err != nil {
 			return nil, err
@@ -503,7 +547,12 @@ func (h *Handshaker) replayBlock(state sm.State, height int64, proxyApp proxy.Ap
 	block := h.store.LoadBlock(height)
 	meta := h.store.LoadBlockMeta(height)
 
-	blockExec := sm.NewBlockExecutor(h.store, h.stateDB, h.logger, proxyApp, mock.Mempool{}, sm.MockEvidencePool{})
+	var options []sm.BlockExecutorOption
+	if h.parallelDeliverTx {
+		// the mock mempool knows no conflict key
+		options = append(options, sm.BlockExecutorWithParallelDeliverTx())
+	}
+	blockExec := sm.NewBlockExecutor(h.store, h.stateDB, h.logger, proxyApp, mock.Mempool{}, sm.MockEvidencePool{}, options...)
 	blockExec.SetEventBus(h.eventBus)
 
 	var err error
@@ -0,0 +1,123 @@
+package friday
+
+import (
+	"math/rand"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/go-kit/kit/metrics/generic"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hdac-io/tendermint/types"
+)
+
+// blockingPrivValidator signs the votes once released, one at a time, and
+// counts the signing requests.
+type blockingPrivValidator struct {
+	types.PrivValidator
+	release  chan struct{}
+	mtx      *sync.Mutex
+	requests *int32
+}
+
+func (pv blockingPrivValidator) SignVote(chainID string, vote *types.Vote) error {
+	atomic.AddInt32(pv.requests, 1)
+	<-pv.release
+	pv.mtx.Lock()
+	defer pv.mtx.Unlock()
+	return pv.PrivValidator.SignVote(chainID, vote)
+}
+
+func TestSignVoteWithTimeout(t *testing.T) {
+	h := newFuzzHarness(t, rand.New(rand.NewSource(1)), 1)
+	defer h.cleanup()
+	cs := h.cs
+	config := *cs.getConfig()
+	config.TimeoutSignVote = 50 * time.Millisecond
+	cs.SetConfig(&config)
+	counter := generic.NewCounter("sign_vote_timeouts")
+	cs.metrics.SignVoteTimeouts = counter
+	blocking := blockingPrivValidator{cs.privValidator, make(chan struct{}), new(sync.Mutex), new(int32)}
+	cs.privValidator = blocking
+
+	// the signer misses the deadline
+	start := time.Now()
+	vote, err := cs.signVote(1, types.PrevoteType, nil, types.PartSetHeader{})
+	assert.True(t, time.Since(start) >= config.TimeoutSignVote)
+	assert.Equal(t, ErrSignVoteTimeout, errors.Cause(err))
+	assert.Empty(t, vote.Signature)
+	assert.EqualValues(t, 1, atomic.LoadInt32(&cs.signVoteMisses))
+	assert.EqualValues(t, 1, counter.Value())
+
+	// and the next votes wait for its request, still running, until their
+	// deadline without sending another one
+	for misses := int32(2); misses <= 3; misses++ {
+		start := time.Now()
+		vote, err = cs.signVote(1, types.PrecommitType, nil, types.PartSetHeader{})
+		assert.True(t, time.Since(start) >= config.TimeoutSignVote)
+		assert.Equal(t, ErrSignVoteTimeout, errors.Cause(err))
+		assert.Empty(t, vote.Signature)
+		assert.Equal(t, misses, atomic.LoadInt32(&cs.signVoteMisses))
+		assert.EqualValues(t, misses, counter.Value())
+	}
+	assert.EqualValues(t, 1, atomic.LoadInt32(blocking.requests))
+
+	// the late signature is dropped
+	close(blocking.release)
+	require.Eventually(t, func() bool {
+		return len(cs.signVoteSlot) == 0
+	}, time.Second, time.Millisecond)
+	assert.Empty(t, vote.Signature)
+
+	// a signature in time, now that the signer is released, resets the misses
+	vote, err = cs.signVote(1, types.PrecommitType, nil, types.PartSetHeader{})
+	require.NoError(t, err)
+	assert.NotEmpty(t, vote.Signature)
+	assert.Zero(t, atomic.LoadInt32(&cs.signVoteMisses))
+	assert.EqualValues(t, 2, atomic.LoadInt32(blocking.requests))
+	assert.EqualValues(t, 3, counter.Value())
+}
+
+// delayedPrivValidator signs the votes after a delay.
+type delayedPrivValidator struct {
+	types.PrivValidator
+	delay time.Duration
+}
+
+func (pv delayedPrivValidator) SignVote(chainID string, vote *types.Vote) error {
+	time.Sleep(pv.delay)
+	return pv.PrivValidator.SignVote(chainID, vote)
+}
+
+func TestSignVoteWithTimeoutConcurrent(t *testing.T) {
+	h := newFuzzHarness(t, rand.New(rand.NewSource(1)), 1)
+	defer h.cleanup()
+	cs := h.cs
+	config := *cs.getConfig()
+	config.TimeoutSignVote = time.Second
+	cs.SetConfig(&config)
+	cs.privValidator = delayedPrivValidator{types.NewMockPV(), 20 * time.Millisecond}
+
+	// the votes of the pipelined heights signed at the same time by a fast
+	// signer wait for each other instead of missing
+	votes := make([]*types.Vote, 2)
+	errs := make([]error, 2)
+	var wg sync.WaitGroup
+	for i, type_ := range []types.SignedMsgType{types.PrevoteType, types.PrecommitType} {
+		wg.Add(1)
+		go func(i int, type_ types.SignedMsgType) {
+			defer wg.Done()
+			votes[i], errs[i] = cs.signVote(1, type_, nil, types.PartSetHeader{})
+		}(i, type_)
+	}
+	wg.Wait()
+	for i := range votes {
+		require.NoError(t, errs[i])
+		assert.NotEmpty(t, votes[i].Signature)
+	}
+	assert.Zero(t, atomic.LoadInt32(&cs.signVoteMisses))
+}
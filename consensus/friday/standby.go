@@ -0,0 +1,178 @@
+package friday
+
+import (
+	"bytes"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	cstypes "github.com/hdac-io/tendermint/consensus/types"
+	"github.com/hdac-io/tendermint/p2p"
+	"github.com/hdac-io/tendermint/types"
+)
+
+// eventSignWatermarkRequest is fired on the evsw, with the address of the
+// validator, for the reactor to ask the peers for its sign watermark.
+const eventSignWatermarkRequest = "SignWatermarkRequest"
+
+// standbyWatch keeps a validator in standby, signing nothing, until its
+// address enters the validator set after a height without it. The heights up
+// to the sign watermark, the highest height known to be signed with its key,
+// are never signed, and if the watermark reaches the height it entered at,
+// another node signs with its key and it stays in standby.
+type standbyWatch struct {
+	mtx     sync.Mutex
+	standby bool
+	// true once a height without the validator was seen
+	outside bool
+	// the height the validator entered the validator set at, 0 if it didn't
+	enterHeight int64
+	watermark   int64
+}
+
+func newStandbyWatch(standby bool) *standbyWatch {
+	return &standbyWatch{standby: standby}
+}
+
+// isStandby returns true if the validator signs nothing.
+func (sw *standbyWatch) isStandby() bool {
+	sw.mtx.Lock()
+	defer sw.mtx.Unlock()
+	return sw.standby
+}
+
+// canSign returns true if the validator can sign at height.
+func (sw *standbyWatch) canSign(height int64) bool {
+	sw.mtx.Lock()
+	defer sw.mtx.Unlock()
+	return !sw.standby && height > sw.watermark
+}
+
+// observeValidators records whether the validator is in the validator set of
+// height, and returns true if it entered it at height, for the sign watermark
+// to be exchanged before leaving the standby.
+func (sw *standbyWatch) observeValidators(height int64, isValidator bool) bool {
+	sw.mtx.Lock()
+	defer sw.mtx.Unlock()
+	if !sw.standby || sw.enterHeight > 0 {
+		return false
+	}
+	if !isValidator {
+		sw.outside = true
+		return false
+	}
+	if !sw.outside {
+		return false
+	}
+	sw.enterHeight = height
+	return true
+}
+
+// raiseWatermark raises the sign watermark to height.
+func (sw *standbyWatch) raiseWatermark(height int64) {
+	sw.mtx.Lock()
+	defer sw.mtx.Unlock()
+	if height > sw.watermark {
+		sw.watermark = height
+	}
+}
+
+// leave leaves the standby, unless the watermark reached the height the
+// validator entered at, in which case the validator has to leave the
+// validator set and enter it again. It returns true if it left the standby,
+// with the height it entered at and the watermark.
+func (sw *standbyWatch) leave() (left bool, enterHeight, watermark int64) {
+	sw.mtx.Lock()
+	defer sw.mtx.Unlock()
+	enterHeight, watermark = sw.enterHeight, sw.watermark
+	if !sw.standby || enterHeight == 0 {
+		return false, enterHeight, watermark
+	}
+	if watermark >= enterHeight {
+		sw.outside = false
+		sw.enterHeight = 0
+		return false, enterHeight, watermark
+	}
+	sw.standby = false
+	return true, enterHeight, watermark
+}
+
+// IsStandby returns true if the node waits for its validator to enter the
+// validator set before signing, see config.Standby.
+func (cs *ConsensusState) IsStandby() bool {
+	return cs.standby.isStandby()
+}
+
+// watchStandby checks whether the validator of the node entered the validator
+// set at height, and if so asks the peers for its sign watermark and leaves
+// the standby after config.StandbyWatermarkTimeout.
+func (cs *ConsensusState) watchStandby(height int64, validators *types.ValidatorSet) {
+	if cs.privValidator == nil || validators == nil || !cs.standby.isStandby() {
+		return
+	}
+	address := cs.privValidator.GetPubKey().Address()
+	if !cs.standby.observeValidators(height, validators.HasAddress(address)) {
+		return
+	}
+	cs.Logger.Info("The validator entered the validator set, asking the peers for its sign watermark",
+		"height", height, "timeout", cs.getConfig().StandbyWatermarkTimeout)
+	cs.evsw.FireEvent(eventSignWatermarkRequest, address)
+	time.AfterFunc(cs.getConfig().StandbyWatermarkTimeout, cs.leaveStandby)
+}
+
+// leaveStandby leaves the standby, once the peers answered with the sign
+// watermark of the validator.
+func (cs *ConsensusState) leaveStandby() {
+	cs.standby.raiseWatermark(cs.signWatermark(cs.privValidator.GetPubKey().Address()))
+	left, enterHeight, watermark := cs.standby.leave()
+	if !left {
+		cs.Logger.Error("Another node signed with the validator key since it entered the validator set, "+
+			"staying in standby", "enterHeight", enterHeight, "watermark", watermark)
+		return
+	}
+	cs.Logger.Info("Left standby, signing the heights above the watermark",
+		"enterHeight", enterHeight, "watermark", watermark)
+}
+
+// receiveSignWatermark raises the sign watermark of the validator of the node
+// to the height a peer answered with.
+func (cs *ConsensusState) receiveSignWatermark(address []byte, height int64, peerID p2p.ID) {
+	if cs.privValidator == nil || !bytes.Equal(address, cs.privValidator.GetPubKey().Address()) {
+		return
+	}
+	cs.Logger.Info("Received the sign watermark", "peer", peerID, "height", height)
+	cs.standby.raiseWatermark(height)
+}
+
+// signWatermark returns the highest height of the heights in progress with a
+// vote of address, or signed by the node if it is the validator of address.
+func (cs *ConsensusState) signWatermark(address []byte) int64 {
+	var watermark int64
+	if cs.privValidator != nil && bytes.Equal(address, cs.privValidator.GetPubKey().Address()) {
+		watermark = atomic.LoadInt64(&cs.lastSignHeight)
+	}
+	cs.roundStates.Range(func(key, value interface{}) bool {
+		height := key.(int64)
+		if height <= watermark {
+			return true
+		}
+		for _, vote := range value.(*cstypes.RoundState).Votes.Votes() {
+			if bytes.Equal(vote.ValidatorAddress, address) {
+				watermark = height
+				break
+			}
+		}
+		return true
+	})
+	return watermark
+}
+
+// signed records that the node signed at height.
+func (cs *ConsensusState) signed(height int64) {
+	for {
+		last := atomic.LoadInt64(&cs.lastSignHeight)
+		if height <= last || atomic.CompareAndSwapInt64(&cs.lastSignHeight, last, height) {
+			return
+		}
+	}
+}
@@ -0,0 +1,96 @@
+package friday
+
+import (
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStandbyWatch(t *testing.T) {
+	sw := newStandbyWatch(true)
+	assert.False(t, sw.canSign(1))
+
+	// a validator from the start doesn't enter the validator set
+	assert.False(t, sw.observeValidators(1, true))
+	left, _, _ := sw.leave()
+	assert.False(t, left)
+
+	assert.False(t, sw.observeValidators(2, false))
+	assert.True(t, sw.observeValidators(3, true))
+	assert.False(t, sw.observeValidators(4, true), "entered already")
+
+	// another node signed with the key since the validator entered
+	sw.raiseWatermark(3)
+	left, enterHeight, watermark := sw.leave()
+	assert.False(t, left)
+	assert.Equal(t, int64(3), enterHeight)
+	assert.Equal(t, int64(3), watermark)
+	assert.True(t, sw.isStandby())
+	assert.False(t, sw.observeValidators(5, true), "must leave the validator set again")
+
+	assert.False(t, sw.observeValidators(6, false))
+	assert.True(t, sw.observeValidators(7, true))
+	sw.raiseWatermark(2)
+	left, enterHeight, watermark = sw.leave()
+	assert.True(t, left)
+	assert.Equal(t, int64(7), enterHeight)
+	assert.Equal(t, int64(3), watermark)
+	assert.False(t, sw.isStandby())
+	assert.False(t, sw.canSign(3))
+	assert.True(t, sw.canSign(4))
+
+	// no standby
+	sw = newStandbyWatch(false)
+	assert.True(t, sw.canSign(1))
+	assert.False(t, sw.observeValidators(1, false))
+	assert.False(t, sw.observeValidators(2, true))
+}
+
+func TestStandbySignsNothing(t *testing.T) {
+	h := newFuzzHarness(t, rand.New(rand.NewSource(1)), 2)
+	defer h.cleanup()
+	h.cs.standby = newStandbyWatch(true)
+
+	require.NoError(t, h.cs.Start())
+	for i := 0; i < 300; i++ {
+		h.step()
+		time.Sleep(time.Millisecond)
+	}
+	require.NoError(t, h.cs.Stop())
+	h.cs.Wait()
+
+	h.mtx.Lock()
+	for key, votes := range h.votes {
+		_, ok := votes[h.nodeIndex]
+		assert.False(t, ok, "vote of the node at %v", key)
+	}
+	h.mtx.Unlock()
+	assert.Equal(t, int64(0), h.cs.lastSignHeight)
+
+	address := h.cs.privValidator.GetPubKey().Address()
+	assert.Equal(t, int64(0), h.cs.signWatermark(address))
+	// the highest heights in progress with a vote of the stubs
+	active := make(map[int64]bool)
+	for _, height := range h.activeHeights() {
+		active[height] = true
+	}
+	voted := false
+	for _, stub := range h.stubs {
+		var want int64
+		h.mtx.Lock()
+		for key, votes := range h.votes {
+			if _, ok := votes[stub.index]; ok && active[key.height] && key.height > want {
+				want = key.height
+			}
+		}
+		h.mtx.Unlock()
+		assert.Equal(t, want, h.cs.signWatermark(stub.address))
+		voted = voted || want > 0
+	}
+	assert.True(t, voted)
+	h.cs.receiveSignWatermark(address, 42, "peer")
+	assert.False(t, h.cs.standby.canSign(42))
+}
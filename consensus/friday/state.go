@@ -11,9 +11,11 @@ import (
 
 	"github.com/pkg/errors"
 
+	"github.com/hdac-io/tendermint/crypto"
 	cmn "github.com/hdac-io/tendermint/libs/common"
 	"github.com/hdac-io/tendermint/libs/fail"
 	"github.com/hdac-io/tendermint/libs/log"
+	"github.com/hdac-io/tendermint/libs/trace"
 	tmtime "github.com/hdac-io/tendermint/types/time"
 
 	cfg "github.com/hdac-io/tendermint/config"
@@ -33,6 +35,8 @@ var (
 	ErrInvalidProposalPOLRound  = errors.New("Error invalid proposal POL round")
 	ErrAddingVote               = errors.New("Error adding vote")
 	ErrVoteHeightMismatch       = errors.New("Error vote height mismatch")
+	ErrSignVoteTimeout          = errors.New("Error timed out waiting for the privValidator to sign the vote")
+	ErrEmptyPeerID              = errors.New("Error empty peer ID")
 )
 
 //-----------------------------------------------------------------------------
@@ -45,6 +49,22 @@ var (
 type msgInfo struct {
 	Msg    ConsensusMessage `json:"msg"`
 	PeerID p2p.ID           `json:"peer_key"`
+
+	// internal is set by internalMsg only, for the messages of this node: the
+	// proposals, block parts and votes signed by its privValidator. It isn't
+	// written to the WAL, whose messages without PeerID are the internal ones.
+	internal bool
+}
+
+// internalMsg returns the msgInfo of a message of this node.
+func internalMsg(msg ConsensusMessage) msgInfo {
+	return msgInfo{Msg: msg, internal: true}
+}
+
+// peerMsg returns the msgInfo of a message received from peerID, which must
+// not be empty.
+func peerMsg(msg ConsensusMessage, peerID p2p.ID) msgInfo {
+	return msgInfo{Msg: msg, PeerID: peerID}
 }
 
 // internally generated messages which may update the state
@@ -76,7 +96,8 @@ type evidencePool interface {
 type ConsensusState struct {
 	cmn.BaseService
 
-	// config details
+	// config details, config set again by SetConfig when it is reloaded
+	configMtx     sync.RWMutex
 	config        *cfg.ConsensusConfig
 	privValidator types.PrivValidator // for signing votes
 
@@ -98,6 +119,9 @@ type ConsensusState struct {
 	roundStates sync.Map
 	state       sm.State // State until height-1.
 
+	// the parity parts received for the proposal block parts, by height
+	blockPartsParity sync.Map
+
 	finalizeMtx      sync.RWMutex
 	waitFinalizeCond *sync.Cond
 	waitFinalize     int32
@@ -115,6 +139,12 @@ type ConsensusState struct {
 
 	// signal for scheduling new height, triggered by: scheduleNewHeightRound0
 	newHeightQueue chan int64
+	// closed and replaced when cs.state changes, to wake up the heights
+	// scheduled beyond the pipeline depth
+	stateChanged    chan struct{}
+	stateChangedMtx sync.Mutex
+	// heights scheduled but not received from newHeightQueue yet
+	scheduledHeights sync.Map
 
 	// we use eventBus to trigger msg broadcasts in the reactor,
 	// and to notify external subscribers, eg. through a websocket
@@ -126,6 +156,10 @@ type ConsensusState struct {
 	replayMode   bool // so we don't log signing errors during replay
 	doWALCatchup bool // determines if we even try to do the catchup
 
+	// optional encryption of the WAL at rest
+	walSym    crypto.Symmetric
+	walSecret []byte
+
 	// for tests where we want to limit the number of transitions the state makes
 	nSteps int
 
@@ -143,6 +177,65 @@ type ConsensusState struct {
 
 	// for reporting metrics
 	metrics *tmcs.Metrics
+
+	// number of consecutive votes the privValidator failed to sign in time
+	signVoteMisses int32
+	// held while a signing request of signVoteWithTimeout is running
+	signVoteSlot chan struct{}
+
+	// the conditions stalling the heights, for the /health RPC
+	health healthTracker
+
+	// for tracing the steps of the heights
+	tracer       trace.Tracer
+	traceMtx     sync.Mutex
+	heightTraces map[int64]*heightTrace
+
+	// the source of the vote times and timeouts
+	clock tmtime.Clock
+	// offsets of the vote timestamps of the peers from clock
+	clockSkew *clockSkew
+	// delays of the votes of the peers from our step entries
+	voteDelays *voteDelays
+
+	// failures of the receiveRoutine, see handleFailure, and the pending
+	// failure of its handlers, see failHandler
+	handlerFailures chan handlerFailure
+	failureMtx      sync.Mutex
+	failures        int
+	lastFailure     *FailureBundle
+
+	// 1 if the node must not propose, see SetDraining
+	draining int32
+
+	// the validator signs nothing in standby, see standby.go
+	standby *standbyWatch
+	// the highest height the node signed a vote or proposal at
+	lastSignHeight int64
+	// the last immutable height set on the privValidator, see
+	// cleanupFinalizedRoundState
+	immutableHeight int64
+	// the height the validator signs conflicting votes at, 0 if it doesn't,
+	// see doubleVote
+	doubleVoteHeight int64
+
+	// the handlers of the records written to the WAL since the last
+	// checkpoint, see startCheckpoint
+	handlers         *sync.WaitGroup
+	checkpointTicker *time.Ticker
+	checkpointSeq    int64
+	// 1 while a checkpoint waits for its handlers
+	checkpointing int32
+	// all the handlers running, for Wait to wait for them
+	runningHandlers sync.WaitGroup
+}
+
+// heightTrace holds the spans of a height being decided.
+type heightTrace struct {
+	root     trace.Span
+	step     trace.Span
+	stepName string
+	round    int
 }
 
 // StateOption sets an optional parameter on the ConsensusState.
@@ -169,6 +262,8 @@ func NewConsensusState(
 		aggregatedTockChan: make(chan timeoutInfo, tickTockBufferSize),
 		statsMsgQueue:      make(chan msgInfo, msgQueueSize),
 		newHeightQueue:     make(chan int64),
+		stateChanged:       make(chan struct{}),
+		handlerFailures:    make(chan handlerFailure, 1),
 		done:               make(chan struct{}),
 		doWALCatchup:       true,
 		wal:                nilWAL{},
@@ -176,6 +271,14 @@ func NewConsensusState(
 		evsw:               tmevents.NewEventSwitch(),
 		metrics:            tmcs.NopMetrics(),
 		roundStates:        sync.Map{},
+		tracer:             trace.NopTracer(),
+		heightTraces:       make(map[int64]*heightTrace),
+		clock:              tmtime.SystemClock{},
+		clockSkew:          newClockSkew(),
+		voteDelays:         newVoteDelays(),
+		handlers:           new(sync.WaitGroup),
+		standby:            newStandbyWatch(config.Standby),
+		signVoteSlot:       make(chan struct{}, 1),
 	}
 	// set function defaults (may be overwritten before calling Start)
 	cs.decideProposal = cs.defaultDecideProposal
@@ -184,15 +287,21 @@ func NewConsensusState(
 
 	cs.waitFinalizeCond = sync.NewCond(&cs.finalizeMtx)
 
+	if kind, height, err := config.Misbehavior(); err == nil && kind == cfg.MisbehaviorDoubleVote {
+		cs.doubleVoteHeight = height
+	}
+
+	// before updateToState, which starts the first heights with the clock
+	for _, option := range options {
+		option(cs)
+	}
+
 	cs.updateToState(state)
 
 	// Don't call scheduleRound0 yet.
 	// We do that upon Start().
 	cs.reconstructLastCommit(state)
 	cs.BaseService = *cmn.NewBaseService(nil, "ConsensusState", cs)
-	for _, option := range options {
-		option(cs)
-	}
 	return cs
 }
 
@@ -204,6 +313,23 @@ func (cs *ConsensusState) SetLogger(l log.Logger) {
 	cs.BaseService.Logger = l
 }
 
+// SetConfig sets the config, e.g. when it is reloaded. It is safe to call
+// while the consensus is running; the new timeouts apply from the next ones
+// scheduled.
+func (cs *ConsensusState) SetConfig(config *cfg.ConsensusConfig) {
+	cs.configMtx.Lock()
+	defer cs.configMtx.Unlock()
+	cs.config = config
+}
+
+// getConfig returns the config set by SetConfig. config must not be
+// modified, SetConfig sets a new one.
+func (cs *ConsensusState) getConfig() *cfg.ConsensusConfig {
+	cs.configMtx.RLock()
+	defer cs.configMtx.RUnlock()
+	return cs.config
+}
+
 // SetEventBus sets event bus.
 func (cs *ConsensusState) SetEventBus(b *types.EventBus) {
 	cs.eventBus = b
@@ -215,6 +341,24 @@ func StateMetrics(metrics *tmcs.Metrics) StateOption {
 	return func(cs *ConsensusState) { cs.metrics = metrics }
 }
 
+// StateTracer sets the tracer of the steps of the heights.
+func StateTracer(tracer trace.Tracer) StateOption {
+	return func(cs *ConsensusState) { cs.tracer = tracer }
+}
+
+// StateClock sets the clock of the vote times and timeouts (tmtime.SystemClock by default).
+func StateClock(clock tmtime.Clock) StateOption {
+	return func(cs *ConsensusState) { cs.clock = clock }
+}
+
+// WALEncryption makes the WAL opened on start encrypt its records with sym and secret.
+func WALEncryption(sym crypto.Symmetric, secret []byte) StateOption {
+	return func(cs *ConsensusState) {
+		cs.walSym = sym
+		cs.walSecret = secret
+	}
+}
+
 // String returns a string.
 func (cs *ConsensusState) String() string {
 	// better not to access shared variables
@@ -286,6 +430,35 @@ func (cs *ConsensusState) GetRoundStateSimpleJSON() ([]byte, error) {
 	return cdc.MarshalJSON(simples)
 }
 
+// GetPipelineHeight returns the highest height being decided.
+func (cs *ConsensusState) GetPipelineHeight() int64 {
+	var highest int64
+	cs.roundStates.Range(func(key, value interface{}) bool {
+		if height := key.(int64); height > highest {
+			highest = height
+		}
+		return true
+	})
+	return highest
+}
+
+// SetDraining makes the node stop proposing blocks if draining, while it keeps
+// voting, e.g. before it is stopped, or propose them again otherwise.
+func (cs *ConsensusState) SetDraining(draining bool) {
+	var v int32
+	if draining {
+		v = 1
+	}
+	if atomic.SwapInt32(&cs.draining, v) != v {
+		cs.Logger.Info("Set draining", "draining", draining)
+	}
+}
+
+// IsDraining returns true if the node doesn't propose blocks.
+func (cs *ConsensusState) IsDraining() bool {
+	return atomic.LoadInt32(&cs.draining) == 1
+}
+
 // GetRoundStatesMap returns internal progressing multiple round states
 func (cs *ConsensusState) GetRoundStatesMap() *sync.Map {
 	return &cs.roundStates
@@ -332,7 +505,7 @@ func (cs *ConsensusState) OnStart() error {
 	// we may set the WAL in testing before calling Start,
 	// so only OpenWAL if its still the nilWAL
 	if _, ok := cs.wal.(nilWAL); ok {
-		walFile := cs.config.WalFile()
+		walFile := cs.getConfig().WalFile()
 		wal, err := cs.OpenWAL(walFile)
 		if err != nil {
 			cs.Logger.Error("Error loading ConsensusState wal", "err", err.Error())
@@ -340,6 +513,8 @@ func (cs *ConsensusState) OnStart() error {
 		}
 		cs.wal = wal
 	}
+	// the stalls are measured from the start until a height is finalized
+	cs.health.finalized(cs.state.LastBlockHeight, cs.clock.Now())
 
 	// we need the timeoutRoutine for replay so
 	// we don't block on the tick chan.
@@ -366,9 +541,9 @@ func (cs *ConsensusState) OnStart() error {
 ----
 WALFILE=~/.tendermint/data/cs.wal/wal
 cp $WALFILE ${WALFILE}.bak # backup the file
-go run scripts/wal2json/main.go $WALFILE > wal.json # this will panic, but can be ignored
+tendermint wal export $WALFILE --out wal.json # this will fail at the corruption, but can be ignored
 rm $WALFILE # remove the corrupt file
-go run scripts/json2wal/main.go wal.json $WALFILE # rebuild the file without corruption
+tendermint wal import wal.json --wal $WALFILE # rebuild the file without corruption
 ----`)
 
 				return err
@@ -380,9 +555,17 @@ go run scripts/json2wal/main.go wal.json $WALFILE # rebuild the file without cor
 		}
 	}
 
+	if cs.getConfig().CheckpointInterval > 0 {
+		cs.checkpointTicker = time.NewTicker(cs.getConfig().CheckpointInterval)
+	}
+
 	// now start the receiveRoutine
 	go cs.receiveRoutine(0)
 
+	if cs.getConfig().InvariantCheckInterval > 0 {
+		go cs.checkInvariantsRoutine(cs.getConfig().InvariantCheckInterval)
+	}
+
 	// schedule the first round!
 	// use GetRoundState so we don't race the receiveRoutine for access
 	cs.scheduleNewHeightRound0(height)
@@ -397,14 +580,22 @@ func (cs *ConsensusState) OnStop() {
 		ticker.Stop()
 		return true
 	})
+	if cs.checkpointTicker != nil {
+		cs.checkpointTicker.Stop()
+	}
+	// the handlers waiting for a lower height to be finalized return
+	cs.finalizeMtx.Lock()
+	cs.waitFinalizeCond.Broadcast()
+	cs.finalizeMtx.Unlock()
 	// WAL is stopped in receiveRoutine.
 }
 
-// Wait waits for the the main routine to return.
+// Wait waits for the the main routine and the message handlers to return.
 // NOTE: be sure to Stop() the event switch and drain
 // any event channels or this may deadlock
 func (cs *ConsensusState) Wait() {
 	<-cs.done
+	cs.runningHandlers.Wait()
 }
 
 // OpenWAL opens a file to log all consensus messages and timeouts for deterministic accountability
@@ -415,6 +606,9 @@ func (cs *ConsensusState) OpenWAL(walFile string) (WAL, error) {
 		return nil, err
 	}
 	wal.SetLogger(cs.Logger.With("wal", walFile))
+	if cs.walSym != nil {
+		wal.SetEncryption(cs.walSym, cs.walSecret)
+	}
 	if err := wal.Start(); err != nil {
 		return nil, err
 	}
@@ -422,19 +616,18 @@ func (cs *ConsensusState) OpenWAL(walFile string) (WAL, error) {
 }
 
 //------------------------------------------------------------
-// Public interface for passing messages into the consensus state, possibly causing a state transition.
-// If peerID == "", the msg is considered internal.
-// Messages are added to the appropriate queue (peer or internal).
+// Public interface for passing the messages of peers into the consensus state, possibly causing a state transition.
+// The messages are added to the peer queue, so peerID must not be empty: the
+// messages of this node only go through sendInternalMessage.
 // If the queue is full, the function may block.
 // TODO: should these return anything or let callers just use events?
 
 // AddVote inputs a vote.
 func (cs *ConsensusState) AddVote(vote *types.Vote, peerID p2p.ID) (added bool, err error) {
 	if peerID == "" {
-		cs.internalMsgQueue <- msgInfo{&VoteMessage{vote}, ""}
-	} else {
-		cs.peerMsgQueue <- msgInfo{&VoteMessage{vote}, peerID}
+		return false, ErrEmptyPeerID
 	}
+	cs.peerMsgQueue <- peerMsg(&VoteMessage{vote}, peerID)
 
 	// TODO: wait for event?!
 	return false, nil
@@ -442,12 +635,10 @@ func (cs *ConsensusState) AddVote(vote *types.Vote, peerID p2p.ID) (added bool,
 
 // SetProposal inputs a proposal.
 func (cs *ConsensusState) SetProposal(proposal *types.Proposal, peerID p2p.ID) error {
-
 	if peerID == "" {
-		cs.internalMsgQueue <- msgInfo{&ProposalMessage{proposal}, ""}
-	} else {
-		cs.peerMsgQueue <- msgInfo{&ProposalMessage{proposal}, peerID}
+		return ErrEmptyPeerID
 	}
+	cs.peerMsgQueue <- peerMsg(&ProposalMessage{proposal}, peerID)
 
 	// TODO: wait for event?!
 	return nil
@@ -455,12 +646,10 @@ func (cs *ConsensusState) SetProposal(proposal *types.Proposal, peerID p2p.ID) e
 
 // AddProposalBlockPart inputs a part of the proposal block.
 func (cs *ConsensusState) AddProposalBlockPart(height int64, round int, part *types.Part, peerID p2p.ID) error {
-
 	if peerID == "" {
-		cs.internalMsgQueue <- msgInfo{&BlockPartMessage{height, round, part}, ""}
-	} else {
-		cs.peerMsgQueue <- msgInfo{&BlockPartMessage{height, round, part}, peerID}
+		return ErrEmptyPeerID
 	}
+	cs.peerMsgQueue <- peerMsg(&BlockPartMessage{height, round, part}, peerID)
 
 	// TODO: wait for event?!
 	return nil
@@ -502,7 +691,7 @@ func (cs *ConsensusState) updateNewHeight(height int64) bool {
 		if interfaceULB, hasULBRound := cs.roundStates.Load(ulbHeight); hasULBRound {
 			ulbRound := interfaceULB.(*cstypes.RoundState)
 			if ulbRound.CommitRound > -1 && ulbRound.Votes != nil {
-				if !ulbRound.Votes.Precommits(ulbRound.CommitRound).HasTwoThirdsMajority() {
+				if !ulbRound.Votes.Precommits(ulbRound.CommitRound).HasTwoThirdsMajority() && ulbRound.CommitAggregate == nil {
 					panic("updateToState(state) called but last Precommit round didn't have +2/3")
 				}
 				ulbPrecommits = ulbRound.Votes.Precommits(ulbRound.CommitRound)
@@ -515,7 +704,7 @@ func (cs *ConsensusState) updateNewHeight(height int64) bool {
 		validators, _ = sm.LoadValidators(cs.blockExec.DB(), height)
 	}
 
-	startTime := tmtime.Now()
+	startTime := cs.clock.Now()
 
 	cs.roundStates.LoadOrStore(
 		height,
@@ -548,6 +737,8 @@ func (cs *ConsensusState) updateNewHeight(height int64) bool {
 		ticker.(TimeoutTicker).Start()
 	}
 
+	cs.watchStandby(height, validators)
+
 	// Finally, broadcast RoundState
 	cs.newStep(height)
 
@@ -558,14 +749,23 @@ func (cs *ConsensusState) cleanupFinalizedRoundState(height int64) {
 	if cs.blockStore.Height() < height {
 		panic("Target height finalized not yet")
 	}
+	cs.deleteRoundState(height)
+	if err := cs.privValidator.GetParallelProgressablePV().SetImmutableHeight(height); err != nil {
+		panic(err)
+	}
+	atomic.StoreInt64(&cs.immutableHeight, height)
+}
+
+// deleteRoundState deletes the RoundState of height, and stops its ticker.
+func (cs *ConsensusState) deleteRoundState(height int64) {
 	if ticker, hasTicker := cs.timeoutTickers.Load(height); hasTicker {
 		ticker.(TimeoutTicker).Stop()
 	}
 	cs.roundStates.Delete(height)
 	cs.timeoutTickers.Delete(height)
-	if err := cs.privValidator.GetParallelProgressablePV().SetImmutableHeight(height); err != nil {
-		panic(err)
-	}
+	cs.blockPartsParity.Delete(height)
+	cs.voteDelays.deleteHeight(height)
+	cs.endTrace(height)
 }
 
 func (cs *ConsensusState) updateRoundStep(height int64, round int, step cstypes.RoundStepType) {
@@ -575,6 +775,45 @@ func (cs *ConsensusState) updateRoundStep(height int64, round int, step cstypes.
 	}
 	heightRound.Round = round
 	heightRound.Step = step
+	cs.voteDelays.enterStep(height, round, step, cs.clock.Now())
+	cs.traceStep(height, round, step.String())
+}
+
+// traceStep ends the span of the current step of height and starts the one of step.
+// The root span of height is started with its first step.
+func (cs *ConsensusState) traceStep(height int64, round int, step string) {
+	cs.traceMtx.Lock()
+	defer cs.traceMtx.Unlock()
+
+	ht, ok := cs.heightTraces[height]
+	if !ok {
+		ht = &heightTrace{root: cs.tracer.StartHeight(height)}
+		cs.heightTraces[height] = ht
+	} else if ht.stepName == step && ht.round == round {
+		return
+	}
+	if ht.step != nil {
+		ht.step.End()
+	}
+	ht.step = cs.tracer.StartSpan(height, "consensus."+step, "round", round)
+	ht.stepName = step
+	ht.round = round
+}
+
+// endTrace ends the spans of height, if any.
+func (cs *ConsensusState) endTrace(height int64) {
+	cs.traceMtx.Lock()
+	defer cs.traceMtx.Unlock()
+
+	ht, ok := cs.heightTraces[height]
+	if !ok {
+		return
+	}
+	if ht.step != nil {
+		ht.step.End()
+	}
+	ht.root.End()
+	delete(cs.heightTraces, height)
 }
 
 // Enter : onStart
@@ -582,23 +821,79 @@ func (cs *ConsensusState) updateRoundStep(height int64, round int, step cstypes.
 func (cs *ConsensusState) scheduleNewHeightRound0(height int64) {
 	// ignore commited height
 	if cs.state.LastBlockHeight < height {
+		cs.scheduledHeights.Store(height, struct{}{})
 		go func() {
-			if height > cs.state.ConsensusParams.Block.LenULB {
-				//Waiting for ulb round commit
-				for ulbHeight := height - cs.state.ConsensusParams.Block.LenULB; ulbHeight > cs.state.LastBlockHeight; {
-					time.Sleep(time.Millisecond * 10)
+			if depth := cs.pipelineDepth(); height > depth {
+				//Waiting for ulb round commit, or for the heights beyond the pipeline depth
+				for waitHeight := height - depth; ; {
+					stateChanged := cs.nextStateChange()
+					if waitHeight <= cs.state.LastBlockHeight {
+						break
+					}
+					select {
+					case <-stateChanged:
+					case <-cs.Quit():
+						return
+					}
 				}
 			}
 
 			cs.newHeightQueue <- height
+			cs.scheduledHeights.Delete(height)
 		}()
 	}
 }
 
+// nextStateChange returns a channel closed when cs.state changes next.
+func (cs *ConsensusState) nextStateChange() <-chan struct{} {
+	cs.stateChangedMtx.Lock()
+	defer cs.stateChangedMtx.Unlock()
+	return cs.stateChanged
+}
+
+// signalStateChange wakes up the waiters of nextStateChange, e.g. the heights
+// which just got within the pipeline depth, so they start without delay.
+func (cs *ConsensusState) signalStateChange() {
+	cs.stateChangedMtx.Lock()
+	defer cs.stateChangedMtx.Unlock()
+	close(cs.stateChanged)
+	cs.stateChanged = make(chan struct{})
+}
+
+// pipelineDepth returns how many heights may progress at the same time after
+// the last block height: LenULB, unless MaxPipelineDepth is lower.
+func (cs *ConsensusState) pipelineDepth() int64 {
+	depth := cs.state.ConsensusParams.Block.LenULB
+	if max := int64(cs.getConfig().MaxPipelineDepth); max > 0 && max < depth {
+		return max
+	}
+	return depth
+}
+
+// timeoutConfig returns the consensus config of the height, with the base
+// timeouts set by the consensus params of the height, if any.
+func (cs *ConsensusState) timeoutConfig(height int64) *cfg.ConsensusConfig {
+	params := cs.state.ConsensusParamsAt(height).Block
+	config := *cs.getConfig()
+	if params.TimeoutProposeMs > 0 {
+		config.TimeoutPropose = time.Duration(params.TimeoutProposeMs) * time.Millisecond
+	}
+	if params.TimeoutPrevoteMs > 0 {
+		config.TimeoutPrevote = time.Duration(params.TimeoutPrevoteMs) * time.Millisecond
+	}
+	if params.TimeoutPrecommitMs > 0 {
+		config.TimeoutPrecommit = time.Duration(params.TimeoutPrecommitMs) * time.Millisecond
+	}
+	if params.TimeoutCommitMs > 0 {
+		config.TimeoutCommit = time.Duration(params.TimeoutCommitMs) * time.Millisecond
+	}
+	return &config
+}
+
 // enterNewRound(height, 0) at cs.StartTime.
 func (cs *ConsensusState) scheduleRound0(rs *cstypes.RoundState) {
 	//cs.Logger.Info("scheduleRound0", "now", tmtime.Now(), "startTime", cs.StartTime)
-	sleepDuration := rs.StartTime.Sub(tmtime.Now())
+	sleepDuration := rs.StartTime.Sub(cs.clock.Now())
 	cs.scheduleTimeout(sleepDuration, rs.Height, 0, cstypes.RoundStepNewHeight)
 }
 
@@ -612,7 +907,8 @@ func (cs *ConsensusState) scheduleTimeout(duration time.Duration, height int64,
 }
 
 // send a msg into the receiveRoutine regarding our own proposal, block part, or vote
-func (cs *ConsensusState) sendInternalMessage(mi msgInfo) {
+func (cs *ConsensusState) sendInternalMessage(msg ConsensusMessage) {
+	mi := internalMsg(msg)
 	select {
 	case cs.internalMsgQueue <- mi:
 	default:
@@ -625,6 +921,33 @@ func (cs *ConsensusState) sendInternalMessage(mi msgInfo) {
 	}
 }
 
+// checkPeerMsg returns whether mi, taken from the peer queue, was received
+// from a peer. A message without peer would be handled as one of this node,
+// e.g. without the checks of the vote times, so it is dropped.
+func (cs *ConsensusState) checkPeerMsg(mi msgInfo) bool {
+	if mi.internal || mi.PeerID == "" {
+		cs.Logger.Error("Dropping a message of the peer queue without peer", "msg", mi.Msg)
+		return false
+	}
+	return true
+}
+
+// checkInternalMsg returns whether mi, taken from the internal queue, is a
+// message of this node: the votes must be the ones of its privValidator.
+func (cs *ConsensusState) checkInternalMsg(mi msgInfo) bool {
+	if !mi.internal || mi.PeerID != "" {
+		cs.Logger.Error("Dropping a message of the internal queue from a peer", "msg", mi.Msg, "peer", mi.PeerID)
+		return false
+	}
+	if msg, ok := mi.Msg.(*VoteMessage); ok {
+		if cs.privValidator == nil || !bytes.Equal(msg.Vote.ValidatorAddress, cs.privValidator.GetPubKey().Address()) {
+			cs.Logger.Error("Dropping a vote of the internal queue not signed by our privValidator", "vote", msg.Vote)
+			return false
+		}
+	}
+	return true
+}
+
 // Reconstruct LastCommit from SeenCommit, which we saved along with the block,
 // (which happens even before saving the state)
 func (cs *ConsensusState) reconstructLastCommit(state sm.State) {
@@ -644,9 +967,26 @@ func (cs *ConsensusState) reconstructLastCommit(state sm.State) {
 		}
 
 		seenCommit := cs.blockStore.LoadSeenCommit(height)
-		lastPrecommits := types.CommitToVoteSet(state.ChainID, seenCommit, ulbValidators)
+		var lastPrecommits *types.VoteSet
+		if seenCommit.IsAggregate() {
+			// fast synced with the QuorumCert of the height as its commit
+			if seenCommit.Height() == height {
+				sm.SaveQuorumCert(cs.blockExec.DB(), seenCommit.QuorumCert())
+			}
+		} else if seenCommit.Height() == height {
+			lastPrecommits = types.CommitToVoteSet(state.ChainID, seenCommit, ulbValidators)
+		}
 		if !lastPrecommits.HasTwoThirdsMajority() {
-			panic("Failed to reconstruct LastCommit: Does not have +2/3 maj")
+			// committed on a CommitAggregate, the other precommits come from the peers
+			qc, err := sm.LoadQuorumCert(cs.blockExec.DB(), height)
+			if err != nil || !qc.BlockID.Equals(seenCommit.BlockID) {
+				panic("Failed to reconstruct LastCommit: Does not have +2/3 maj")
+			}
+			if lastPrecommits == nil {
+				lastPrecommits = types.NewVoteSet(state.ChainID, height, qc.Round, types.PrecommitType, ulbValidators)
+			}
+			cs.Logger.Info("Reconstructed LastCommit of a height committed on a CommitAggregate",
+				"height", height, "precommits", lastPrecommits.StringShort())
 		}
 
 		restoreHeight := height + cs.state.ConsensusParams.Block.LenULB
@@ -689,6 +1029,7 @@ func (cs *ConsensusState) updateToState(state sm.State) {
 	}
 
 	cs.state = state
+	cs.signalStateChange()
 
 	// Next desired block height
 	height := state.LastBlockHeight + 1
@@ -700,7 +1041,7 @@ func (cs *ConsensusState) updateToState(state sm.State) {
 func (cs *ConsensusState) newStep(height int64) {
 	rs := cs.getRoundState(height)
 	rsEvent := rs.RoundStateEvent()
-	cs.wal.Write(rsEvent)
+	cs.writeWAL(rsEvent)
 	cs.nSteps++
 	// newStep is called by updateToState in NewConsensusState before the eventBus is set!
 	if cs.eventBus != nil {
@@ -732,7 +1073,11 @@ func (cs *ConsensusState) receiveRoutine(maxSteps int) {
 
 	defer func() {
 		if r := recover(); r != nil {
-			cs.Logger.Error("CONSENSUS FAILURE!!!", "err", r, "stack", string(debug.Stack()))
+			stack := debug.Stack()
+			if f, ok := r.(handlerFailure); ok {
+				r, stack = f.r, f.stack
+			}
+			cs.Logger.Error("CONSENSUS FAILURE!!!", "err", r, "stack", string(stack))
 			// stop gracefully
 			//
 			// NOTE: We most probably shouldn't be running any further when there is
@@ -740,7 +1085,19 @@ func (cs *ConsensusState) receiveRoutine(maxSteps int) {
 			// know if that will result in the validator signing an invalid thing. It
 			// might be worthwhile to explore a mechanism for manual resuming via
 			// some console or secure RPC system, but for now, halting the chain upon
-			// unexpected consensus bugs sounds like the better option.
+			// unexpected consensus bugs sounds like the better option, unless the
+			// operator allows a few restarts (failure_restarts).
+			if backoff, restart := cs.handleFailure(r, stack); restart {
+				go func() {
+					select {
+					case <-time.After(backoff):
+						cs.receiveRoutine(maxSteps)
+					case <-cs.Quit():
+						onExit(cs)
+					}
+				}()
+				return
+			}
 			onExit(cs)
 		}
 	}()
@@ -759,11 +1116,22 @@ func (cs *ConsensusState) receiveRoutine(maxSteps int) {
 		case <-cs.txNotifier.TxsAvailable():
 			go cs.handleTxsAvailable()
 		case mi = <-cs.peerMsgQueue:
-			cs.wal.Write(mi)
+			if !cs.checkPeerMsg(mi) {
+				continue
+			}
+			cs.writeWAL(mi)
+			if _, ok := mi.Msg.(*VoteMessage); ok {
+				// verifies the signatures of the queued votes in a batch
+				cs.receivePeerVotes(mi)
+				continue
+			}
 			// handles proposals, block parts, votes
 			// may generate internal events (votes, complete proposals, 2/3 majorities)
-			go cs.handleMsg(mi)
+			cs.goHandle(func() { cs.handleMsg(mi) })
 		case mi = <-cs.internalMsgQueue:
+			if !cs.checkInternalMsg(mi) {
+				continue
+			}
 			err := cs.wal.WriteSync(mi) // NOTE: fsync
 			if err != nil {
 				panic(fmt.Sprintf("Failed to write %v msg to consensus wal due to %v. Check your FS and restart the node", mi, err))
@@ -778,14 +1146,14 @@ func (cs *ConsensusState) receiveRoutine(maxSteps int) {
 			}
 
 			// handles proposals, block parts, votes
-			go cs.handleMsg(mi)
+			cs.goHandle(func() { cs.handleMsg(mi) })
 		case ti := <-cs.aggregatedTockChan: // tockChan:
 			// TODO: this commit purpose serve to prepare multiple round on TimeoutTicker
 			// so, not handled to each height yet
-			cs.wal.Write(ti)
+			cs.writeWAL(ti)
 			// if the timeout is relevant to the rs
 			// go to the next step
-			go cs.handleTimeout(ti)
+			cs.goHandle(func() { cs.handleTimeout(ti) })
 		case height := <-cs.newHeightQueue:
 			newHeightRound := cs.getRoundState(height)
 			if newHeightRound == nil {
@@ -794,6 +1162,11 @@ func (cs *ConsensusState) receiveRoutine(maxSteps int) {
 			}
 
 			cs.scheduleRound0(newHeightRound)
+		case <-cs.checkpointC():
+			cs.startCheckpoint()
+		case f := <-cs.handlerFailures:
+			// a handler panicked, see goHandleIn
+			panic(f)
 		case <-cs.Quit():
 			onExit(cs)
 			return
@@ -801,6 +1174,15 @@ func (cs *ConsensusState) receiveRoutine(maxSteps int) {
 	}
 }
 
+// sendStats sends mi to the peer stats of the reactor, unless the consensus is
+// stopped, with the reactor routine reading them.
+func (cs *ConsensusState) sendStats(mi msgInfo) {
+	select {
+	case cs.statsMsgQueue <- mi:
+	case <-cs.Quit():
+	}
+}
+
 // state transitions on complete-proposal, 2/3-any, 2/3-one
 func (cs *ConsensusState) handleMsg(mi msgInfo) {
 	var (
@@ -817,19 +1199,25 @@ func (cs *ConsensusState) handleMsg(mi msgInfo) {
 		// if the proposal is complete, we'll enterPrevote or tryFinalizeCommit
 		added, err = cs.addProposalBlockPart(msg, peerID)
 		if added {
-			cs.statsMsgQueue <- mi
+			cs.sendStats(mi)
 		}
 
 		// if err != nil && msg.Round != cs.Round {
 		// 	cs.Logger.Debug("Received block part from wrong round", "height", cs.Height, "csRound", cs.Round, "blockRound", msg.Round)
 		// 	err = nil
 		// }
+	case *BlockPartParityMessage:
+		// the parts rebuilt from the parity parts are added like the block parts
+		_, err = cs.addProposalBlockParity(msg, peerID)
+	case *CommitAggregateMessage:
+		// if the CommitAggregate is valid, we commit the height like on +2/3 precommits
+		err = cs.addCommitAggregate(msg.QuorumCert, peerID)
 	case *VoteMessage:
 		// attempt to add the vote and dupeout the validator if its a duplicate signature
 		// if the vote gives us a 2/3-any or 2/3-one, we transition
-		added, err = cs.tryAddVote(msg.Vote, peerID)
+		added, err = cs.tryAddVote(msg.Vote, peerID, mi.internal)
 		if added {
-			cs.statsMsgQueue <- mi
+			cs.sendStats(mi)
 		}
 
 		// if err == ErrAddingVote {
@@ -916,7 +1304,7 @@ func (cs *ConsensusState) handleTxsAvailable() {
 		}
 
 		// +1ms to ensure RoundStepNewRound timeout always happens after RoundStepNewHeight
-		timeoutCommit := rs.StartTime.Sub(tmtime.Now()) + 1*time.Millisecond
+		timeoutCommit := rs.StartTime.Sub(cs.clock.Now()) + 1*time.Millisecond
 		cs.scheduleTimeout(timeoutCommit, rs.Height, 0, cstypes.RoundStepNewRound)
 	case cstypes.RoundStepNewRound: // after timeoutCommit
 		cs.enterPropose(rs.Height, 0)
@@ -945,7 +1333,7 @@ func (cs *ConsensusState) enterNewRound(height int64, round int) {
 		return
 	}
 
-	if now := tmtime.Now(); heightRound.StartTime.After(now) {
+	if now := cs.clock.Now(); heightRound.StartTime.After(now) {
 		logger.Info("Need to set a buffer and log message here for sanity.", "startTime", heightRound.StartTime, "now", now)
 	}
 
@@ -995,17 +1383,19 @@ func (cs *ConsensusState) enterNewRound(height int64, round int) {
 		// so round number will not dcrease.
 		if ulbRound > 0 && round == 0 {
 			logger.Info(fmt.Sprintf("Wait for cut off to continuous failure. Ulb: %v/%v", ulbHeight, ulbRound))
-			time.Sleep(cs.config.PreviousFailure(ulbRound))
+			time.Sleep(cs.getConfig().PreviousFailure(ulbRound))
 		}
 	}
 
 	// Wait for txs to be available in the mempool
 	// before we enterPropose in round 0. If the last block changed the app hash,
 	// we may need an empty "proof" block, and enterPropose immediately.
-	waitForTxs := cs.config.WaitForTxs() && round == 0 && !cs.needProofBlock(height)
+	// A height above CreateEmptyBlocksMaxDepth waits to get within it.
+	aboveMaxDepth := cs.aboveEmptyBlocksMaxDepth(height)
+	waitForTxs := (cs.getConfig().WaitForTxs() || aboveMaxDepth) && round == 0 && !cs.needProofBlock(height)
 	if waitForTxs {
-		if cs.config.CreateEmptyBlocksInterval > 0 {
-			cs.scheduleTimeout(cs.config.CreateEmptyBlocksInterval, height, round,
+		if cs.getConfig().CreateEmptyBlocksInterval > 0 && !aboveMaxDepth {
+			cs.scheduleTimeout(cs.getConfig().CreateEmptyBlocksInterval, height, round,
 				cstypes.RoundStepNewRound)
 		}
 	} else {
@@ -1014,16 +1404,44 @@ func (cs *ConsensusState) enterNewRound(height int64, round int) {
 }
 
 // needProofBlock returns true on the first height (so the genesis app hash is signed right away)
-// and where the last block (height-1) caused the app hash to change
+// and where the last block (height-1) caused the app hash to change, unless the height
+// is above CreateEmptyBlocksMaxDepth
 func (cs *ConsensusState) needProofBlock(height int64) bool {
 	if height == 1 {
 		return true
 	}
+	if cs.aboveEmptyBlocksMaxDepth(height) {
+		return false
+	}
 
 	lastBlockMeta := cs.blockStore.LoadBlockMeta(height - 1)
 	return !bytes.Equal(cs.state.AppHash, lastBlockMeta.Header.AppHash)
 }
 
+// aboveEmptyBlocksMaxDepth returns true if the height is more than
+// CreateEmptyBlocksMaxDepth heights above the last block height.
+func (cs *ConsensusState) aboveEmptyBlocksMaxDepth(height int64) bool {
+	maxDepth := int64(cs.getConfig().CreateEmptyBlocksMaxDepth)
+	return maxDepth > 0 && height-cs.state.LastBlockHeight > maxDepth
+}
+
+// proposeWithinEmptyBlocksMaxDepth enters the propose step of the height which
+// just got within CreateEmptyBlocksMaxDepth, if it waits in round 0 for it.
+func (cs *ConsensusState) proposeWithinEmptyBlocksMaxDepth() {
+	maxDepth := int64(cs.getConfig().CreateEmptyBlocksMaxDepth)
+	if maxDepth == 0 {
+		return
+	}
+	height := cs.state.LastBlockHeight + maxDepth
+	rs := cs.getRoundState(height)
+	if rs == nil || rs.Round != 0 || rs.Step != cstypes.RoundStepNewRound {
+		return
+	}
+	if !cs.getConfig().WaitForTxs() || cs.needProofBlock(height) {
+		cs.scheduleTimeout(0, height, 0, cstypes.RoundStepNewRound)
+	}
+}
+
 // Enter (CreateEmptyBlocks): from enterNewRound(height,round)
 // Enter (CreateEmptyBlocks, CreateEmptyBlocksInterval > 0 ): after enterNewRound(height,round), after timeout of CreateEmptyBlocksInterval
 // Enter (!CreateEmptyBlocks) : after enterNewRound(height,round), once txs are in the mempool
@@ -1055,7 +1473,7 @@ func (cs *ConsensusState) enterPropose(height int64, round int) {
 	}()
 
 	// If we don't get the proposal and all block parts quick enough, enterPrevote
-	cs.scheduleTimeout(cs.config.Propose(round), height, round, cstypes.RoundStepPropose)
+	cs.scheduleTimeout(cs.timeoutConfig(height).Propose(round), height, round, cstypes.RoundStepPropose)
 
 	// Nothing more to do if we're not a validator
 	if cs.privValidator == nil {
@@ -1071,7 +1489,16 @@ func (cs *ConsensusState) enterPropose(height int64, round int) {
 	}
 	logger.Debug("This node is a validator")
 
+	if !cs.standby.canSign(height) {
+		logger.Debug("This node is in standby or below its sign watermark")
+		return
+	}
+
 	if cs.isProposer(height, address) {
+		if cs.IsDraining() {
+			logger.Info("enterPropose: Our turn to propose, but draining", "proposer", heightRound.Validators.GetProposer().Address)
+			return
+		}
 		logger.Info("enterPropose: Our turn to propose", "proposer", heightRound.Validators.GetProposer().Address, "privValidator", cs.privValidator)
 		cs.decideProposal(height, round)
 	} else {
@@ -1102,7 +1529,8 @@ func (cs *ConsensusState) defaultDecideProposal(height int64, round int) {
 		block, blockParts = heightRound.ValidBlock, heightRound.ValidBlockParts
 	} else {
 		// Create a new proposal block from state/txs from the mempool.
-		block, blockParts = cs.createProposalBlock(height)
+		deadline := time.Now().Add(cs.timeoutConfig(height).PrepareProposal(round))
+		block, blockParts = cs.createProposalBlock(height, deadline)
 		if block == nil { // on error
 			return
 		}
@@ -1115,9 +1543,10 @@ func (cs *ConsensusState) defaultDecideProposal(height int64, round int) {
 	propBlockId := types.BlockID{Hash: block.Hash(), PartsHeader: blockParts.Header()}
 	proposal := types.NewProposal(height, round, heightRound.ValidRound, propBlockId)
 	if err := cs.privValidator.SignProposal(cs.state.ChainID, proposal); err == nil {
+		cs.signed(height)
 
 		// send proposal and block parts on internal msg queue
-		cs.sendInternalMessage(msgInfo{&ProposalMessage{proposal}, ""})
+		cs.sendInternalMessage(&ProposalMessage{proposal})
 		// NOTE: message handlers are executed in parallel(goroutine).
 		// So sometimes the block part message is processed before the proposal message.
 		// There is a slight delay.
@@ -1125,7 +1554,7 @@ func (cs *ConsensusState) defaultDecideProposal(height int64, round int) {
 
 		for i := 0; i < blockParts.Total(); i++ {
 			part := blockParts.GetPart(i)
-			cs.sendInternalMessage(msgInfo{&BlockPartMessage{heightRound.Height, heightRound.Round, part}, ""})
+			cs.sendInternalMessage(&BlockPartMessage{heightRound.Height, heightRound.Round, part})
 		}
 		cs.Logger.Info("Signed proposal", "height", height, "round", round, "proposal", proposal)
 		cs.Logger.Debug(fmt.Sprintf("Signed proposal block: %v", block))
@@ -1159,8 +1588,9 @@ func (cs *ConsensusState) isProposalComplete(height int64) bool {
 // We really only need to return the parts, but the block
 // is returned for convenience so we can log the proposal block.
 // Returns nil block upon error.
+// The txs are prepared before deadline.
 // NOTE: keep it side-effect free for clarity.
-func (cs *ConsensusState) createProposalBlock(height int64) (block *types.Block, blockParts *types.PartSet) {
+func (cs *ConsensusState) createProposalBlock(height int64, deadline time.Time) (block *types.Block, blockParts *types.PartSet) {
 	var ulbCommit *types.Commit
 	var ulbValidators *types.ValidatorSet
 	var validators *types.ValidatorSet
@@ -1179,24 +1609,20 @@ func (cs *ConsensusState) createProposalBlock(height int64) (block *types.Block,
 		ulbCommit = types.NewCommit(types.BlockID{}, nil)
 		ulbValidators = types.NewValidatorSet(nil)
 		ulbNextValidators = types.NewValidatorSet(nil)
-	} else if rs := cs.getRoundState(height); rs != nil && rs.LastCommit.HasTwoThirdsMajority() {
+	} else if ulbCommit, ulbValidators = cs.makeLastCommit(height); ulbCommit != nil {
 		ulbHeight := height - cs.state.ConsensusParams.Block.LenULB
 
-		// Make the commit from ULBCommit
-		ulbCommit = rs.LastCommit.MakeCommit()
-		ulbValidators = rs.LastValidators
-
 		// Getting committed block informations from db
 		var appHashErr error
 		appHash, appHashErr = sm.LoadAppHash(cs.blockExec.DB(), ulbHeight)
 		if appHashErr != nil {
 			panic(fmt.Sprintf("Cannot load ulb AppHash. ulbHeight=%v, LastBlockHeight=%v, error=%v", ulbHeight, cs.state.LastBlockHeight, appHashErr.Error()))
 		}
-		ulbABCIResponses, resErr := sm.LoadABCIResponses(cs.blockExec.DB(), ulbHeight)
+		var resErr error
+		resultsHash, resErr = sm.LoadResultsHash(cs.blockExec.DB(), ulbHeight)
 		if resErr != nil {
 			panic(fmt.Sprintf("Cannot load ulb ABCI responses. ulbHeight=%v, LastBlockHeight=%v, error=%v", ulbHeight, cs.state.LastBlockHeight, resErr.Error()))
 		}
-		resultsHash = ulbABCIResponses.ResultsHash()
 
 		nextValidatorsHeight := ulbHeight + cs.state.ConsensusParams.Block.LenULB + 1
 		var ulbNextVarErr error
@@ -1239,7 +1665,7 @@ func (cs *ConsensusState) createProposalBlock(height int64) (block *types.Block,
 		cs.state,
 		ulbCommit, ulbValidators,
 		validators.Hash(), ulbNextValidators.Hash(), appHash, resultsHash,
-		proposerAddr)
+		proposerAddr, deadline)
 }
 
 func (cs *ConsensusState) validatePreviousBlock(block *types.Block) error {
@@ -1405,7 +1831,7 @@ func (cs *ConsensusState) enterPrevoteWait(height int64, round int) {
 	}()
 
 	// Wait for some more prevotes; enterPrecommit
-	cs.scheduleTimeout(cs.config.Prevote(round), height, round, cstypes.RoundStepPrevoteWait)
+	cs.scheduleTimeout(cs.timeoutConfig(height).Prevote(round), height, round, cstypes.RoundStepPrevoteWait)
 }
 
 // Enter: `timeoutPrevote` after any +2/3 prevotes.
@@ -1572,7 +1998,7 @@ func (cs *ConsensusState) enterPrecommitWait(height int64, round int) {
 	}()
 
 	// Wait for some more precommits; enterNewRound
-	cs.scheduleTimeout(cs.config.Precommit(round), height, round, cstypes.RoundStepPrecommitWait)
+	cs.scheduleTimeout(cs.timeoutConfig(height).Precommit(round), height, round, cstypes.RoundStepPrecommitWait)
 
 }
 
@@ -1600,10 +2026,11 @@ func (cs *ConsensusState) enterCommit(height int64, commitRound int) {
 		cs.tryFinalizeCommit(height)
 	}()
 
-	blockID, ok := heightRound.Votes.Precommits(commitRound).TwoThirdsMajority()
+	blockID, ok := commitBlockID(heightRound, commitRound)
 	if !ok {
 		panic("RunActionCommit() expects +2/3 precommits")
 	}
+	cs.aggregateCommit(heightRound)
 
 	// The Locked* fields no longer matter.
 	// Move them over to ProposalBlock if they match the commit hash,
@@ -1641,7 +2068,7 @@ func (cs *ConsensusState) tryFinalizeCommit(height int64) {
 		return
 	}
 
-	blockID, ok := heightRound.Votes.Precommits(heightRound.Round).TwoThirdsMajority()
+	blockID, ok := commitBlockID(heightRound, heightRound.Round)
 	if !ok || len(blockID.Hash) == 0 {
 		logger.Error("Attempt to finalize failed. There was no +2/3 majority, or +2/3 was for <nil>.")
 		return
@@ -1655,6 +2082,14 @@ func (cs *ConsensusState) tryFinalizeCommit(height int64) {
 
 	//	go
 	cs.finalizeCommit(height)
+
+	// the next height may have been committed first in the WAL, and not wait
+	// for this one, see finalizeCommit
+	if cs.GetLastHeight() == height {
+		if next := cs.getRoundState(height + 1); next != nil && next.Step == cstypes.RoundStepCommit {
+			cs.tryFinalizeCommit(height + 1)
+		}
+	}
 }
 
 // Increment height and goto cstypes.RoundStepNewHeight
@@ -1672,7 +2107,7 @@ func (cs *ConsensusState) finalizeCommit(height int64) {
 		return
 	}
 
-	blockID, ok := heightRound.Votes.Precommits(heightRound.Round).TwoThirdsMajority()
+	blockID, ok := commitBlockID(heightRound, heightRound.Round)
 	block, blockParts := heightRound.ProposalBlock, heightRound.ProposalBlockParts
 
 	if !ok {
@@ -1686,16 +2121,45 @@ func (cs *ConsensusState) finalizeCommit(height int64) {
 	}
 
 	//Wait finalize previous block
+	cs.traceStep(height, heightRound.Round, "WaitPreviousBlock")
+	var stopWatchdog func()
 	for {
 		got, now := height, cs.state.LastBlockHeight
 		wanted := now + 1
 		if got == wanted {
 			break
 		}
+		if got < wanted {
+			// finalized meanwhile, by tryFinalizeCommit of the previous height
+			if stopWatchdog != nil {
+				stopWatchdog()
+			}
+			return
+		}
 
 		cs.Logger.Debug("Previous block is not finalized yet", "Current Finalizing height", got, "Previous finalized height", now)
-		atomic.StoreInt32(&cs.waitFinalize, 1)
+		if cs.replayMode {
+			// the messages of the WAL are replayed one at a time: the
+			// height is finalized after the previous one, by tryFinalizeCommit
+			return
+		}
+		if stopWatchdog == nil {
+			stopWatchdog = cs.watchFinalizeWait(height)
+		}
+		if atomic.CompareAndSwapInt32(&cs.waitFinalize, 0, 1) {
+			cs.health.waitFinalize(cs.clock.Now())
+		}
 		cs.waitFinalizeCond.Wait()
+		if !cs.IsRunning() {
+			// stopped, e.g. to drop to fast sync again, see OnStop
+			if stopWatchdog != nil {
+				stopWatchdog()
+			}
+			return
+		}
+	}
+	if stopWatchdog != nil {
+		stopWatchdog()
 	}
 
 	if err := cs.blockExec.ValidateBlock(cs.state, block); err != nil {
@@ -1715,14 +2179,21 @@ func (cs *ConsensusState) finalizeCommit(height int64) {
 		}
 	}
 
+	cs.traceStep(height, heightRound.Round, "FinalizeCommit")
 	cs.Logger.Info(fmt.Sprintf("Finalizing commit of block with %d txs", block.NumTxs),
 		"height", block.Height, "hash", block.Hash(), "root", block.AppHash)
 	cs.Logger.Info(fmt.Sprintf("%v", block))
 
-	if block.Height > 1 && !cs.config.SkipTimeoutCommit {
+	config := cs.getConfig()
+	if block.Height > 1 && (config.SkipTimeoutCommit || config.OptimisticCommit) &&
+		heightRound.Votes.Precommits(heightRound.Round).HasAll() {
+		// optimistic path: no precommit is missing, so there is no reason to
+		// wait for the timeout commit before the next height of the window
+		cs.metrics.OptimisticCommits.Add(1)
+	} else if block.Height > 1 && !config.SkipTimeoutCommit {
 		if prevRs := cs.getRoundState(block.Height - 1); prevRs != nil {
-			now := tmtime.Now()
-			duration := cs.config.Commit(prevRs.CommitTime).Sub(now)
+			now := cs.clock.Now()
+			duration := cs.timeoutConfig(block.Height).Commit(prevRs.CommitTime).Sub(now)
 			time.Sleep(duration)
 		}
 	}
@@ -1736,8 +2207,13 @@ func (cs *ConsensusState) finalizeCommit(height int64) {
 		// NOTE: the seenCommit is local justification to commit this block,
 		// but may differ from the LastCommit included in the next block
 		precommits := heightRound.Votes.Precommits(heightRound.Round)
-		seenCommit := precommits.MakeCommit()
-		cs.blockStore.SaveBlock(block, blockParts, seenCommit, lenULB)
+		if precommits.HasTwoThirdsMajority() {
+			seenCommit := precommits.MakeCommit()
+			cs.blockStore.SaveBlock(block, blockParts, seenCommit, lenULB)
+			cs.saveQuorumCert(seenCommit, heightRound.Validators)
+		} else {
+			cs.saveCommitAggregate(heightRound, lenULB)
+		}
 	} else {
 		// Happens during replay if we already saved the block but didn't commit
 		cs.Logger.Info("Calling finalizeCommit on already stored block", "height", block.Height)
@@ -1782,15 +2258,18 @@ func (cs *ConsensusState) finalizeCommit(height int64) {
 	}
 
 	heightRound.CommitRound = heightRound.Round
-	heightRound.CommitTime = tmtime.Now()
+	heightRound.CommitTime = cs.clock.Now()
 
 	fail.Fail() // XXX
 
 	// must be called before we update state
 	cs.recordMetrics(height, block)
+	cs.endTrace(height)
 
 	// NewHeightStep!
 	cs.updateToState(stateCopy)
+	cs.health.finalized(height, cs.clock.Now())
+	cs.proposeWithinEmptyBlocksMaxDepth()
 
 	fail.Fail() // XXX
 
@@ -1807,9 +2286,22 @@ func (cs *ConsensusState) finalizeCommit(height int64) {
 	if atomic.LoadInt32(&cs.waitFinalize) == 1 {
 		cs.waitFinalizeCond.Broadcast()
 		atomic.StoreInt32(&cs.waitFinalize, 0)
+		cs.health.waitFinalize(time.Time{})
 	}
 }
 
+// saveQuorumCert saves the QuorumCert of the seenCommit of a finalized height,
+// for external verifiers not to wait for the block carrying its commit. The
+// height is finalized anyway if it can't be made, e.g. without BLS keys.
+func (cs *ConsensusState) saveQuorumCert(seenCommit *types.Commit, validators *types.ValidatorSet) {
+	qc, err := types.NewQuorumCert(seenCommit, validators)
+	if err != nil {
+		cs.Logger.Error("Failed to make the quorum cert", "height", seenCommit.Height(), "err", err)
+		return
+	}
+	sm.SaveQuorumCert(cs.blockExec.DB(), qc)
+}
+
 func (cs *ConsensusState) recordMetrics(height int64, block *types.Block) {
 	heightRound := cs.getRoundState(height)
 	if heightRound == nil {
@@ -1853,6 +2345,7 @@ func (cs *ConsensusState) recordMetrics(height int64, block *types.Block) {
 	cs.metrics.TotalTxs.Set(float64(block.TotalTxs))
 	cs.metrics.CommittedHeight.Set(float64(block.Height))
 
+	cs.checkClockSkew(heightRound.Validators)
 }
 
 //-----------------------------------------------------------------------------
@@ -1866,8 +2359,8 @@ func (cs *ConsensusState) defaultSetProposal(proposal *types.Proposal) error {
 	defer heightRound.Unlock()
 
 	// Already have one
-	// TODO: possibly catch double proposals
 	if heightRound.Proposal != nil {
+		cs.checkDuplicateProposal(heightRound, proposal)
 		return nil
 	}
 
@@ -1898,6 +2391,33 @@ func (cs *ConsensusState) defaultSetProposal(proposal *types.Proposal) error {
 	return nil
 }
 
+// checkDuplicateProposal adds the evidence to the evidence pool if the
+// proposer of the round signed the proposal for another block than the
+// proposal of the round.
+func (cs *ConsensusState) checkDuplicateProposal(heightRound *cstypes.RoundState, proposal *types.Proposal) {
+	existing := heightRound.Proposal
+	if proposal.Height != existing.Height || proposal.Round != existing.Round ||
+		proposal.BlockID.Equals(existing.BlockID) {
+		return
+	}
+
+	proposer := heightRound.Validators.GetProposer()
+	if !proposer.PubKey.VerifyBytes(proposal.SignBytes(cs.state.ChainID), proposal.Signature) {
+		return
+	}
+	if cs.privValidator != nil && bytes.Equal(proposer.Address, cs.privValidator.GetPubKey().Address()) {
+		cs.Logger.Error("Found conflicting proposal from ourselves. Did you unsafe_reset a validator?",
+			"height", proposal.Height, "round", proposal.Round)
+		return
+	}
+
+	ev := types.NewDuplicateProposalEvidence(proposer.PubKey, existing, proposal)
+	cs.Logger.Error("Found conflicting proposal", "evidence", ev)
+	if err := cs.evpool.AddEvidence(ev); err != nil {
+		cs.Logger.Error("Failed to add the duplicate proposal evidence", "err", err)
+	}
+}
+
 // NOTE: block is not necessarily valid.
 // Asynchronously triggers either enterPrevote (before we timeout of propose) or tryFinalizeCommit, once we have the full block.
 func (cs *ConsensusState) addProposalBlockPart(msg *BlockPartMessage, peerID p2p.ID) (added bool, err error) {
@@ -1928,7 +2448,7 @@ func (cs *ConsensusState) addProposalBlockPart(msg *BlockPartMessage, peerID p2p
 		_, err = cdc.UnmarshalBinaryLengthPrefixedReader(
 			heightRound.ProposalBlockParts.GetReader(),
 			&heightRound.ProposalBlock,
-			cs.state.ConsensusParams.Block.MaxBytes,
+			cs.state.ConsensusParamsAt(height).Block.MaxBytes,
 		)
 		if err != nil {
 			return added, err
@@ -1975,8 +2495,8 @@ func (cs *ConsensusState) addProposalBlockPart(msg *BlockPartMessage, peerID p2p
 }
 
 // Attempt to add the vote. if its a duplicate signature, dupeout the validator
-func (cs *ConsensusState) tryAddVote(vote *types.Vote, peerID p2p.ID) (bool, error) {
-	added, err := cs.addVote(vote, peerID)
+func (cs *ConsensusState) tryAddVote(vote *types.Vote, peerID p2p.ID, internal bool) (bool, error) {
+	added, err := cs.addVote(vote, peerID, internal)
 	if err != nil {
 		// If the vote height is off, we'll just ignore it,
 		// But if it's a conflicting sig, add it to the cs.evpool.
@@ -2005,7 +2525,7 @@ func (cs *ConsensusState) tryAddVote(vote *types.Vote, peerID p2p.ID) (bool, err
 
 //-----------------------------------------------------------------------------
 
-func (cs *ConsensusState) addVote(vote *types.Vote, peerID p2p.ID) (added bool, err error) {
+func (cs *ConsensusState) addVote(vote *types.Vote, peerID p2p.ID, internal bool) (added bool, err error) {
 	cs.Logger.Debug("addVote", "voteHeight", vote.Height, "voteType", vote.Type, "valIndex", vote.ValidatorIndex)
 
 	height := vote.Height
@@ -2024,6 +2544,10 @@ func (cs *ConsensusState) addVote(vote *types.Vote, peerID p2p.ID) (added bool,
 
 	cs.eventBus.PublishEventVote(types.EventDataVote{Vote: vote})
 	cs.evsw.FireEvent(types.EventVote, vote)
+	if !internal {
+		cs.observeVoteTime(vote)
+		cs.observeVoteDelay(vote)
+	}
 
 	switch vote.Type {
 	case types.PrevoteType:
@@ -2106,7 +2630,7 @@ func (cs *ConsensusState) addVote(vote *types.Vote, peerID p2p.ID) (added bool,
 			cs.enterPrecommit(height, vote.Round)
 			if len(blockID.Hash) != 0 {
 				cs.enterCommit(height, vote.Round)
-				if cs.config.SkipTimeoutCommit && precommits.HasAll() {
+				if config := cs.getConfig(); (config.SkipTimeoutCommit || config.OptimisticCommit) && precommits.HasAll() {
 					cs.enterNewRound(heightRound.Height+1, 0)
 				}
 			} else {
@@ -2144,17 +2668,75 @@ func (cs *ConsensusState) signVote(height int64, type_ types.SignedMsgType, hash
 		Type:             type_,
 		BlockID:          types.BlockID{Hash: hash, PartsHeader: header},
 	}
-	err := cs.privValidator.SignVote(cs.state.ChainID, vote)
+	err := cs.signVoteWithTimeout(vote)
 	return vote, err
 }
 
+// signVoteWithTimeout asks the privValidator to sign the vote, giving up after
+// config.TimeoutSignVote so a slow or unreachable (remote) signer doesn't
+// block the height forever. A signature arriving after the deadline is dropped.
+// At most one request is sent at a time: the votes of the other heights wait
+// for the previous one to return, within the same deadline, so a hung signer
+// doesn't pile up a goroutine per vote.
+func (cs *ConsensusState) signVoteWithTimeout(vote *types.Vote) error {
+	timeout := cs.getConfig().TimeoutSignVote
+	if timeout <= 0 {
+		return cs.privValidator.SignVote(cs.state.ChainID, vote)
+	}
+
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+	select {
+	case cs.signVoteSlot <- struct{}{}:
+	case <-timer.C:
+		cs.missSignVote(vote, timeout)
+		return errors.Wrapf(ErrSignVoteTimeout, "after %v waiting for the previous request", timeout)
+	case <-cs.Quit():
+		return errors.New("consensus stopped before signing the vote")
+	}
+
+	// sign a copy, so the late signer can't race with the caller
+	toSign := vote.Copy()
+	chainID := cs.state.ChainID
+	errCh := make(chan error, 1)
+	go func() {
+		err := cs.privValidator.SignVote(chainID, toSign)
+		<-cs.signVoteSlot
+		errCh <- err
+	}()
+
+	select {
+	case err := <-errCh:
+		atomic.StoreInt32(&cs.signVoteMisses, 0)
+		if err == nil {
+			vote.Signature = toSign.Signature
+			vote.Timestamp = toSign.Timestamp
+		}
+		return err
+	case <-timer.C:
+		cs.missSignVote(vote, timeout)
+		return errors.Wrapf(ErrSignVoteTimeout, "after %v", timeout)
+	}
+}
+
+// missSignVote counts a vote the privValidator failed to sign in time.
+func (cs *ConsensusState) missSignVote(vote *types.Vote, timeout time.Duration) {
+	cs.metrics.SignVoteTimeouts.Add(1)
+	misses := atomic.AddInt32(&cs.signVoteMisses, 1)
+	if misses > 1 {
+		cs.Logger.Error("PrivValidator keeps missing the signing deadline",
+			"height", vote.Height, "round", vote.Round, "type", vote.Type,
+			"timeout", timeout, "consecutive_misses", misses)
+	}
+}
+
 func (cs *ConsensusState) voteTime(height int64) time.Time {
 	heightRound := cs.getRoundState(height)
 	if heightRound == nil {
 		panic("Must be just initialized height round")
 	}
 
-	now := tmtime.Now()
+	now := cs.clock.Now()
 	minVoteTime := now
 	// TODO: We should remove next line in case we don't vote for v in case cs.ProposalBlock == nil,
 	// even if cs.LockedBlock != nil. See https://github.com/tendermint/spec.
@@ -2183,10 +2765,21 @@ func (cs *ConsensusState) signAddVote(height int64, type_ types.SignedMsgType, h
 	if cs.privValidator == nil || !heightRound.Validators.HasAddress(cs.privValidator.GetPubKey().Address()) {
 		return nil
 	}
+	// nor in standby
+	if !cs.standby.canSign(height) {
+		return nil
+	}
 	vote, err := cs.signVote(height, type_, hash, header)
+	if !cs.replayMode {
+		cs.health.voteSigned(err)
+	}
 	if err == nil {
-		cs.sendInternalMessage(msgInfo{&VoteMessage{vote}, ""})
+		cs.signed(height)
+		cs.sendInternalMessage(&VoteMessage{vote})
 		cs.Logger.Info("Signed and pushed vote", "height", heightRound.Height, "round", heightRound.Round, "vote", vote, "err", err)
+		if height == cs.doubleVoteHeight && !cs.replayMode {
+			cs.doubleVote(vote)
+		}
 		return vote
 	}
 	if !cs.replayMode {
@@ -0,0 +1,78 @@
+package friday
+
+import (
+	"bytes"
+
+	"github.com/hdac-io/tendermint/crypto"
+	"github.com/hdac-io/tendermint/types"
+)
+
+// maxVoteBatch is the maximum number of votes of the peers verified in a
+// batch.
+const maxVoteBatch = 128
+
+// receivePeerVotes handles the vote of a peer mi with the votes queued after
+// it, whose signatures are verified in a batch first. The other messages
+// taken from the queue are handled as usual.
+func (cs *ConsensusState) receivePeerVotes(mi msgInfo) {
+	votes := []msgInfo{mi}
+loop:
+	for len(votes) < maxVoteBatch {
+		select {
+		case next := <-cs.peerMsgQueue:
+			if !cs.checkPeerMsg(next) {
+				continue
+			}
+			cs.writeWAL(next)
+			if _, ok := next.Msg.(*VoteMessage); ok {
+				votes = append(votes, next)
+			} else {
+				cs.goHandle(func() { cs.handleMsg(next) })
+			}
+		default:
+			break loop
+		}
+	}
+
+	// the handlers of the votes count for the checkpoint of their records,
+	// even if it starts before the batch is verified
+	handlers := cs.handlers
+	cs.goHandleIn(handlers, func() {
+		if len(votes) > 1 {
+			cs.verifyVotes(votes)
+		}
+		for _, mi := range votes {
+			mi := mi
+			cs.goHandleIn(handlers, func() { cs.handleMsg(mi) })
+		}
+	})
+}
+
+// verifyVotes verifies the signatures of the votes by their validators in a
+// batch, for addVote not to verify them again. The votes whose validator is
+// unknown are left to addVote.
+func (cs *ConsensusState) verifyVotes(msgs []msgInfo) {
+	votes := make([]*types.Vote, 0, len(msgs))
+	pubKeys := make([]crypto.PubKey, 0, len(msgs))
+	for _, mi := range msgs {
+		vote := mi.Msg.(*VoteMessage).Vote
+		heightRound := cs.getRoundState(vote.Height)
+		if heightRound == nil {
+			continue
+		}
+		heightRound.Lock()
+		var val *types.Validator
+		if heightRound.Validators != nil {
+			_, val = heightRound.Validators.GetByIndex(vote.ValidatorIndex)
+		}
+		heightRound.Unlock()
+		if val == nil || !bytes.Equal(val.Address, vote.ValidatorAddress) {
+			continue
+		}
+		votes = append(votes, vote)
+		pubKeys = append(pubKeys, val.PubKey)
+	}
+	if len(votes) > 1 {
+		types.BatchVerifyVotes(cs.state.ChainID, votes, pubKeys)
+	}
+}
@@ -0,0 +1,169 @@
+package friday
+
+import (
+	"encoding/json"
+	"sort"
+	"sync"
+	"time"
+
+	cstypes "github.com/hdac-io/tendermint/consensus/types"
+	"github.com/hdac-io/tendermint/types"
+)
+
+// voteDelays keeps, by validator address, the delays between our entry in the
+// prevote and precommit steps of a height and round and the arrival of the
+// prevotes and precommits of the validators for it. A vote received before we
+// entered its step has no delay. As all the heights in progress are timed, a
+// validator slow on any of them shows.
+type voteDelays struct {
+	mtx        sync.Mutex
+	stepStarts map[int64]*stepStarts          // by height
+	validators map[string]*ValidatorVoteDelay // by validator address
+}
+
+// stepStarts is the times we entered the prevote and precommit steps of the
+// round of a height.
+type stepStarts struct {
+	round     int
+	prevote   time.Time
+	precommit time.Time
+}
+
+// ValidatorVoteDelay is the summary of the vote delays of a validator.
+type ValidatorVoteDelay struct {
+	Address   types.Address `json:"address"`
+	Prevote   VoteDelay     `json:"prevote"`
+	Precommit VoteDelay     `json:"precommit"`
+}
+
+// VoteDelay is the summary of the delays of the votes of a type.
+type VoteDelay struct {
+	Count int64         `json:"count"`
+	Mean  time.Duration `json:"mean"`
+	Max   time.Duration `json:"max"`
+	Last  time.Duration `json:"last"`
+	total time.Duration
+}
+
+func (d *VoteDelay) observe(delay time.Duration) {
+	d.Count++
+	d.total += delay
+	d.Mean = d.total / time.Duration(d.Count)
+	if delay > d.Max {
+		d.Max = delay
+	}
+	d.Last = delay
+}
+
+func newVoteDelays() *voteDelays {
+	return &voteDelays{
+		stepStarts: make(map[int64]*stepStarts),
+		validators: make(map[string]*ValidatorVoteDelay),
+	}
+}
+
+// enterStep records the time we entered step of the round of height, if it is
+// the prevote or precommit step.
+func (d *voteDelays) enterStep(height int64, round int, step cstypes.RoundStepType, now time.Time) {
+	if step != cstypes.RoundStepPrevote && step != cstypes.RoundStepPrecommit {
+		return
+	}
+	d.mtx.Lock()
+	defer d.mtx.Unlock()
+
+	starts, ok := d.stepStarts[height]
+	if !ok || starts.round != round {
+		starts = &stepStarts{round: round}
+		d.stepStarts[height] = starts
+	}
+	if step == cstypes.RoundStepPrevote {
+		starts.prevote = now
+	} else {
+		starts.precommit = now
+	}
+}
+
+// observe records the delay of the vote received at now, and returns it. It
+// returns false if the vote isn't a prevote or precommit of the round of its
+// height we time.
+func (d *voteDelays) observe(vote *types.Vote, now time.Time) (time.Duration, bool) {
+	d.mtx.Lock()
+	defer d.mtx.Unlock()
+
+	starts, ok := d.stepStarts[vote.Height]
+	if !ok || starts.round != vote.Round {
+		return 0, false
+	}
+	var start time.Time
+	switch vote.Type {
+	case types.PrevoteType:
+		start = starts.prevote
+	case types.PrecommitType:
+		start = starts.precommit
+	default:
+		return 0, false
+	}
+	var delay time.Duration
+	if !start.IsZero() && now.After(start) {
+		delay = now.Sub(start)
+	}
+
+	val, ok := d.validators[string(vote.ValidatorAddress)]
+	if !ok {
+		val = &ValidatorVoteDelay{Address: vote.ValidatorAddress}
+		d.validators[string(vote.ValidatorAddress)] = val
+	}
+	if vote.Type == types.PrevoteType {
+		val.Prevote.observe(delay)
+	} else {
+		val.Precommit.observe(delay)
+	}
+	return delay, true
+}
+
+// deleteHeight forgets the step times of height.
+func (d *voteDelays) deleteHeight(height int64) {
+	d.mtx.Lock()
+	delete(d.stepStarts, height)
+	d.mtx.Unlock()
+}
+
+// summary returns the vote delays of the validators, slowest mean precommit
+// delay first.
+func (d *voteDelays) summary() []ValidatorVoteDelay {
+	d.mtx.Lock()
+	defer d.mtx.Unlock()
+
+	vals := make([]ValidatorVoteDelay, 0, len(d.validators))
+	for _, val := range d.validators {
+		vals = append(vals, *val)
+	}
+	sort.Slice(vals, func(i, j int) bool {
+		if vals[i].Precommit.Mean != vals[j].Precommit.Mean {
+			return vals[i].Precommit.Mean > vals[j].Precommit.Mean
+		}
+		return vals[i].Prevote.Mean > vals[j].Prevote.Mean
+	})
+	return vals
+}
+
+// observeVoteDelay records the delay of a vote of a peer from our entry in
+// its step.
+func (cs *ConsensusState) observeVoteDelay(vote *types.Vote) {
+	delay, ok := cs.voteDelays.observe(vote, cs.clock.Now())
+	if !ok {
+		return
+	}
+	voteType := "prevote"
+	if vote.Type == types.PrecommitType {
+		voteType = "precommit"
+	}
+	cs.metrics.VoteDelaySeconds.With("validator_address", vote.ValidatorAddress.String(),
+		"vote_type", voteType).Observe(delay.Seconds())
+}
+
+// GetVoteDelaysJSON returns the vote delays of the validators, slowest first,
+// as JSON.
+func (cs *ConsensusState) GetVoteDelaysJSON() ([]byte, error) {
+	return json.Marshal(cs.voteDelays.summary())
+}
@@ -0,0 +1,66 @@
+package friday
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	cstypes "github.com/hdac-io/tendermint/consensus/types"
+	"github.com/hdac-io/tendermint/types"
+)
+
+func TestVoteDelays(t *testing.T) {
+	d := newVoteDelays()
+	start := time.Unix(1000, 0)
+	slow, fast := types.Address("slow"), types.Address("fast")
+	vote := func(addr types.Address, height int64, round int, typ types.SignedMsgType) *types.Vote {
+		return &types.Vote{ValidatorAddress: addr, Height: height, Round: round, Type: typ}
+	}
+
+	// not timed before the step of the height and round is entered
+	_, ok := d.observe(vote(slow, 1, 0, types.PrevoteType), start)
+	assert.False(t, ok)
+
+	d.enterStep(1, 0, cstypes.RoundStepPropose, start)
+	d.enterStep(1, 0, cstypes.RoundStepPrevote, start)
+	delay, ok := d.observe(vote(slow, 1, 0, types.PrevoteType), start.Add(300*time.Millisecond))
+	require.True(t, ok)
+	assert.Equal(t, 300*time.Millisecond, delay)
+	delay, ok = d.observe(vote(fast, 1, 0, types.PrevoteType), start.Add(100*time.Millisecond))
+	require.True(t, ok)
+	assert.Equal(t, 100*time.Millisecond, delay)
+
+	// a precommit received before we entered the step has no delay
+	delay, ok = d.observe(vote(fast, 1, 0, types.PrecommitType), start.Add(200*time.Millisecond))
+	require.True(t, ok)
+	assert.Equal(t, time.Duration(0), delay)
+
+	d.enterStep(1, 0, cstypes.RoundStepPrecommit, start.Add(time.Second))
+	_, ok = d.observe(vote(slow, 1, 1, types.PrecommitType), start.Add(2*time.Second))
+	assert.False(t, ok, "other round")
+	_, ok = d.observe(vote(slow, 1, 0, types.PrecommitType), start.Add(2*time.Second))
+	require.True(t, ok)
+	_, ok = d.observe(vote(slow, 1, 0, types.PrecommitType), start.Add(1500*time.Millisecond))
+	require.True(t, ok)
+
+	summary := d.summary()
+	require.Len(t, summary, 2)
+	assert.Equal(t, slow, summary[0].Address)
+	assert.Equal(t, int64(1), summary[0].Prevote.Count)
+	assert.Equal(t, int64(2), summary[0].Precommit.Count)
+	assert.Equal(t, 750*time.Millisecond, summary[0].Precommit.Mean)
+	assert.Equal(t, time.Second, summary[0].Precommit.Max)
+	assert.Equal(t, 500*time.Millisecond, summary[0].Precommit.Last)
+	assert.Equal(t, fast, summary[1].Address)
+
+	// the new round of the height starts over
+	d.enterStep(1, 1, cstypes.RoundStepPrevote, start.Add(3*time.Second))
+	_, ok = d.observe(vote(slow, 1, 0, types.PrecommitType), start.Add(4*time.Second))
+	assert.False(t, ok)
+
+	d.deleteHeight(1)
+	_, ok = d.observe(vote(slow, 1, 1, types.PrevoteType), start.Add(4*time.Second))
+	assert.False(t, ok)
+}
@@ -0,0 +1,96 @@
+package friday
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	cstypes "github.com/hdac-io/tendermint/consensus/types"
+	cmn "github.com/hdac-io/tendermint/libs/common"
+	"github.com/hdac-io/tendermint/types"
+)
+
+// VoteTally is the voting power of the prevotes and precommits received for a
+// round of a height in progress.
+type VoteTally struct {
+	Height           int64     `json:"height"`
+	Round            int       `json:"round"`
+	Step             string    `json:"step"`
+	TotalVotingPower int64     `json:"total_voting_power"`
+	Prevotes         VoteCount `json:"prevotes"`
+	Precommits       VoteCount `json:"precommits"`
+}
+
+// VoteCount is the voting power of the votes of a type, the validators who
+// voted, by index in the validator set, and the block which got +2/3 of the
+// votes, if any.
+type VoteCount struct {
+	VotingPower int64          `json:"voting_power"`
+	Fraction    float64        `json:"fraction"`
+	BitArray    *cmn.BitArray  `json:"bit_array"`
+	Maj23       *types.BlockID `json:"maj23,omitempty"`
+}
+
+// GetVoteTallyJSON returns the vote tallies of the heights in progress, by
+// height, or of the height if it isn't 0. The tally is the one of the round if
+// it isn't negative, else of the current round of the heights.
+func (cs *ConsensusState) GetVoteTallyJSON(height int64, round int) ([]byte, error) {
+	var tallies []VoteTally
+	if height != 0 {
+		rs := cs.getRoundState(height)
+		if rs == nil {
+			return nil, fmt.Errorf("height %d is not in progress", height)
+		}
+		tally, err := voteTally(rs, round)
+		if err != nil {
+			return nil, err
+		}
+		tallies = append(tallies, tally)
+	} else {
+		cs.roundStates.Range(func(key, value interface{}) bool {
+			// a height without votes for the round is skipped
+			if tally, err := voteTally(value.(*cstypes.RoundState), round); err == nil {
+				tallies = append(tallies, tally)
+			}
+			return true
+		})
+		sort.Slice(tallies, func(i, j int) bool { return tallies[i].Height < tallies[j].Height })
+	}
+	return json.Marshal(tallies)
+}
+
+func voteTally(rs *cstypes.RoundState, round int) (VoteTally, error) {
+	rs.RLock()
+	defer rs.RUnlock()
+
+	if round < 0 {
+		round = rs.Round
+	}
+	prevotes, precommits := rs.Votes.Prevotes(round), rs.Votes.Precommits(round)
+	if prevotes == nil || precommits == nil {
+		return VoteTally{}, fmt.Errorf("no votes for round %d of height %d", round, rs.Height)
+	}
+	total := rs.Validators.TotalVotingPower()
+	return VoteTally{
+		Height:           rs.Height,
+		Round:            round,
+		Step:             rs.Step.String(),
+		TotalVotingPower: total,
+		Prevotes:         voteCount(prevotes, total),
+		Precommits:       voteCount(precommits, total),
+	}, nil
+}
+
+func voteCount(voteSet *types.VoteSet, total int64) VoteCount {
+	count := VoteCount{
+		VotingPower: voteSet.Sum(),
+		BitArray:    voteSet.BitArray(),
+	}
+	if total > 0 {
+		count.Fraction = float64(count.VotingPower) / float64(total)
+	}
+	if blockID, ok := voteSet.TwoThirdsMajority(); ok {
+		count.Maj23 = &blockID
+	}
+	return count
+}
@@ -0,0 +1,100 @@
+package friday
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	cstypes "github.com/hdac-io/tendermint/consensus/types"
+	"github.com/hdac-io/tendermint/types"
+)
+
+func TestVoteTally(t *testing.T) {
+	chainID := "vote_tally_chain"
+	valSet, privVals := types.RandValidatorSet(4, 10)
+	blockID := types.BlockID{Hash: []byte("blockhash"), PartsHeader: types.PartSetHeader{Total: 1, Hash: []byte("partshash")}}
+
+	cs := &ConsensusState{}
+	for _, height := range []int64{2, 1} {
+		rs := &cstypes.RoundState{
+			Height:     height,
+			Step:       cstypes.RoundStepPrevote,
+			Validators: valSet,
+			Votes:      cstypes.NewHeightVoteSet(chainID, height, valSet),
+		}
+		cs.roundStates.Store(height, rs)
+	}
+	rs := cs.getRoundState(1)
+	rs.Round = 1
+	rs.Votes.SetRound(1)
+	vote := func(i int, round int, typ types.SignedMsgType) {
+		addr := privVals[i].GetPubKey().Address()
+		idx, _ := valSet.GetByAddress(addr)
+		v := &types.Vote{
+			ValidatorAddress: addr,
+			ValidatorIndex:   idx,
+			Height:           1,
+			Round:            round,
+			Timestamp:        time.Now(),
+			Type:             typ,
+			BlockID:          blockID,
+		}
+		require.NoError(t, privVals[i].SignVote(chainID, v))
+		added, err := rs.Votes.AddVote(v, "peer")
+		require.NoError(t, err)
+		require.True(t, added)
+	}
+	for i := 0; i < 3; i++ {
+		vote(i, 0, types.PrevoteType)
+	}
+	vote(0, 0, types.PrecommitType)
+	vote(0, 1, types.PrevoteType)
+
+	// the current round of all the heights
+	bz, err := cs.GetVoteTallyJSON(0, -1)
+	require.NoError(t, err)
+	var tallies []VoteTally
+	require.NoError(t, json.Unmarshal(bz, &tallies))
+	require.Len(t, tallies, 2)
+	assert.EqualValues(t, 1, tallies[0].Height)
+	assert.EqualValues(t, 2, tallies[1].Height)
+	assert.EqualValues(t, 40, tallies[0].TotalVotingPower)
+	assert.EqualValues(t, 0, tallies[1].Prevotes.VotingPower)
+	assert.Nil(t, tallies[1].Prevotes.Maj23)
+
+	// a round of a height
+	bz, err = cs.GetVoteTallyJSON(1, 0)
+	require.NoError(t, err)
+	require.NoError(t, json.Unmarshal(bz, &tallies))
+	require.Len(t, tallies, 1)
+	tally := tallies[0]
+	assert.Equal(t, 0, tally.Round)
+	assert.Equal(t, cstypes.RoundStepPrevote.String(), tally.Step)
+	assert.EqualValues(t, 30, tally.Prevotes.VotingPower)
+	assert.Equal(t, 0.75, tally.Prevotes.Fraction)
+	voted := 0
+	for i := 0; i < tally.Prevotes.BitArray.Size(); i++ {
+		if tally.Prevotes.BitArray.GetIndex(i) {
+			voted++
+		}
+	}
+	assert.Equal(t, 3, voted)
+	require.NotNil(t, tally.Prevotes.Maj23)
+	assert.True(t, blockID.Equals(*tally.Prevotes.Maj23))
+	assert.EqualValues(t, 10, tally.Precommits.VotingPower)
+	assert.Nil(t, tally.Precommits.Maj23)
+
+	bz, err = cs.GetVoteTallyJSON(1, -1)
+	require.NoError(t, err)
+	require.NoError(t, json.Unmarshal(bz, &tallies))
+	assert.Equal(t, 1, tallies[0].Round)
+	assert.EqualValues(t, 10, tallies[0].Prevotes.VotingPower)
+
+	_, err = cs.GetVoteTallyJSON(3, -1)
+	assert.Error(t, err, "height not in progress")
+	_, err = cs.GetVoteTallyJSON(1, 5)
+	assert.Error(t, err, "round without votes")
+}
@@ -10,12 +10,12 @@ import (
 
 	"github.com/pkg/errors"
 
+	"github.com/hdac-io/tendermint/crypto"
 	auto "github.com/hdac-io/tendermint/libs/autofile"
 	cmn "github.com/hdac-io/tendermint/libs/common"
 	"github.com/hdac-io/tendermint/libs/log"
 	"github.com/hdac-io/tendermint/types"
 	tmtime "github.com/hdac-io/tendermint/types/time"
-	amino "github.com/tendermint/go-amino"
 )
 
 const (
@@ -24,6 +24,12 @@ const (
 
 	// how often the WAL should be sync'd during period sync'ing
 	walDefaultFlushInterval = 2 * time.Second
+
+	// set in the length field of records encrypted by the WALEncoder
+	walEncryptedFlag = uint32(1) << 31
+
+	// upper bound of what the encryption adds to a record (nonce + MAC)
+	walEncryptionOverhead = 64
 )
 
 //--------------------------------------------------------
@@ -36,19 +42,47 @@ type TimedWALMessage struct {
 }
 
 // EndHeightMessage marks the end of the given height inside WAL.
-// @internal used by scripts/wal2json util.
+// @internal used by the consensus/wal package.
 type EndHeightMessage struct {
 	Height int64 `json:"height"`
 }
 
+// CheckpointMessage marks the records handled before the RoundStates of the
+// checkpoint with the given seq were saved.
+type CheckpointMessage struct {
+	Seq int64 `json:"seq"`
+}
+
 type WALMessage interface{}
 
-func RegisterWALMessages(cdc *amino.Codec) {
-	cdc.RegisterInterface((*WALMessage)(nil), nil)
-	cdc.RegisterConcrete(types.EventDataRoundState{}, "tendermint/wal/EventDataRoundState", nil)
-	cdc.RegisterConcrete(msgInfo{}, "tendermint/wal/MsgInfo", nil)
-	cdc.RegisterConcrete(timeoutInfo{}, "tendermint/wal/TimeoutInfo", nil)
-	cdc.RegisterConcrete(EndHeightMessage{}, "tendermint/wal/EndHeightMessage", nil)
+// WALMessageHeight returns the height of a message of the WAL, if it has one.
+// The heights in progress interleave their messages in the WAL, which are
+// told apart by their height. The checkpoints have no height.
+func WALMessageHeight(msg WALMessage) (int64, bool) {
+	switch m := msg.(type) {
+	case types.EventDataRoundState:
+		return m.Height, true
+	case msgInfo:
+		switch cm := m.Msg.(type) {
+		case *ProposalMessage:
+			return cm.Proposal.Height, true
+		case *BlockPartMessage:
+			return cm.Height, true
+		case *BlockPartParityMessage:
+			return cm.Height, true
+		case *VoteMessage:
+			return cm.Vote.Height, true
+		case *CommitAggregateMessage:
+			if cm.QuorumCert != nil {
+				return cm.QuorumCert.Height, true
+			}
+		}
+	case timeoutInfo:
+		return m.Height, true
+	case EndHeightMessage:
+		return m.Height, true
+	}
+	return 0, false
 }
 
 //--------------------------------------------------------
@@ -61,6 +95,7 @@ type WAL interface {
 	FlushAndSync() error
 
 	SearchForEndHeight(height int64, options *WALSearchOptions) (rd io.ReadCloser, found bool, err error)
+	SearchForCheckpoint(seq int64, options *WALSearchOptions) (rd io.ReadCloser, found bool, err error)
 
 	// service methods
 	Start() error
@@ -80,6 +115,10 @@ type baseWAL struct {
 
 	enc *WALEncoder
 
+	// optional encryption of the records at rest
+	sym    crypto.Symmetric
+	secret []byte
+
 	flushTicker   *time.Ticker
 	flushInterval time.Duration
 }
@@ -112,6 +151,15 @@ func (wal *baseWAL) SetFlushInterval(i time.Duration) {
 	wal.flushInterval = i
 }
 
+// SetEncryption makes the WAL encrypt new records with sym and secret.
+// Records already on disk stay readable, whether encrypted or not.
+// Must be called before Start.
+func (wal *baseWAL) SetEncryption(sym crypto.Symmetric, secret []byte) {
+	wal.sym = sym
+	wal.secret = secret
+	wal.enc = NewEncryptedWALEncoder(wal.group, sym, secret)
+}
+
 func (wal *baseWAL) Group() *auto.Group {
 	return wal.group
 }
@@ -238,7 +286,7 @@ func (wal *baseWAL) SearchForEndHeight(height int64, options *WALSearchOptions)
 			return nil, false, err
 		}
 
-		dec := NewWALDecoder(gr)
+		dec := NewEncryptedWALDecoder(gr, wal.sym, wal.secret)
 		for {
 			msg, err = dec.Decode()
 			if err == io.EOF {
@@ -273,18 +321,85 @@ func (wal *baseWAL) SearchForEndHeight(height int64, options *WALSearchOptions)
 	return nil, false, nil
 }
 
+// SearchForCheckpoint searches for the CheckpointMessage with the given seq
+// and returns an auto.GroupReader positioned after it, whenever it was found or
+// not and an error. Group reader will be nil if found equals false.
+//
+// CONTRACT: caller must close group reader.
+func (wal *baseWAL) SearchForCheckpoint(seq int64, options *WALSearchOptions) (rd io.ReadCloser, found bool, err error) {
+	var (
+		msg *TimedWALMessage
+		gr  *auto.GroupReader
+	)
+
+	// NOTE: starting from the last file in the group because the checkpoint
+	// is usually recent
+	min, max := wal.group.MinIndex(), wal.group.MaxIndex()
+	wal.Logger.Info("Searching for checkpoint", "seq", seq, "min", min, "max", max)
+	for index := max; index >= min; index-- {
+		gr, err = wal.group.NewReader(index)
+		if err != nil {
+			return nil, false, err
+		}
+
+		dec := NewEncryptedWALDecoder(gr, wal.sym, wal.secret)
+		lastSeqFound := int64(-1)
+		for {
+			msg, err = dec.Decode()
+			if err == io.EOF {
+				break
+			}
+			if options.IgnoreDataCorruptionErrors && IsDataCorruptionError(err) {
+				wal.Logger.Error("Corrupted entry. Skipping...", "err", err)
+				// do nothing
+				continue
+			} else if err != nil {
+				gr.Close()
+				return nil, false, err
+			}
+
+			if m, ok := msg.Msg.(CheckpointMessage); ok {
+				lastSeqFound = m.Seq
+				if m.Seq == seq { // found
+					wal.Logger.Info("Found", "seq", seq, "index", index)
+					return gr, true, nil
+				}
+			}
+		}
+		gr.Close()
+		// OPTIMISATION: the seqs increase, no need to look in older files
+		if lastSeqFound >= 0 && lastSeqFound < seq {
+			return nil, false, nil
+		}
+	}
+
+	return nil, false, nil
+}
+
 ///////////////////////////////////////////////////////////////////////////////
 
 // A WALEncoder writes custom-encoded WAL messages to an output stream.
 //
 // Format: 4 bytes CRC sum + 4 bytes length + arbitrary-length value (go-amino encoded)
+//
+// If the encoder has a secret, the value is encrypted and the highest bit of
+// the length is set. The CRC sum is computed over the encrypted value.
 type WALEncoder struct {
 	wr io.Writer
+
+	sym    crypto.Symmetric
+	secret []byte
 }
 
 // NewWALEncoder returns a new encoder that writes to wr.
 func NewWALEncoder(wr io.Writer) *WALEncoder {
-	return &WALEncoder{wr}
+	return &WALEncoder{wr: wr}
+}
+
+// NewEncryptedWALEncoder returns a new encoder that writes to wr and encrypts
+// the values with sym and secret. A nil sym disables the encryption.
+func NewEncryptedWALEncoder(wr io.Writer, sym crypto.Symmetric, secret []byte) *WALEncoder {
+	return &WALEncoder{wr: wr, sym: sym, secret: secret}
 }
 
 // Encode writes the custom encoding of v to the stream. It returns an error if
@@ -293,16 +408,24 @@ func NewWALEncoder(wr io.Writer) *WALEncoder {
 func (enc *WALEncoder) Encode(v *TimedWALMessage) error {
 	data := cdc.MustMarshalBinaryBare(v)
 
-	crc := crc32.Checksum(data, crc32c)
 	length := uint32(len(data))
 	if length > maxMsgSizeBytes {
 		return fmt.Errorf("msg is too big: %d bytes, max: %d bytes", length, maxMsgSizeBytes)
 	}
+
+	flag := uint32(0)
+	if enc.sym != nil {
+		data = enc.sym.Encrypt(data, enc.secret)
+		length = uint32(len(data))
+		flag = walEncryptedFlag
+	}
+
+	crc := crc32.Checksum(data, crc32c)
 	totalLength := 8 + int(length)
 
 	msg := make([]byte, totalLength)
 	binary.BigEndian.PutUint32(msg[0:4], crc)
-	binary.BigEndian.PutUint32(msg[4:8], length)
+	binary.BigEndian.PutUint32(msg[4:8], length|flag)
 	copy(msg[8:], data)
 
 	_, err := enc.wr.Write(msg)
@@ -337,11 +460,20 @@ func (e DataCorruptionError) Cause() error {
 // length from the header. If that is not the case, error will be returned.
 type WALDecoder struct {
 	rd io.Reader
+
+	sym    crypto.Symmetric
+	secret []byte
 }
 
 // NewWALDecoder returns a new decoder that reads from rd.
 func NewWALDecoder(rd io.Reader) *WALDecoder {
-	return &WALDecoder{rd}
+	return &WALDecoder{rd: rd}
+}
+
+// NewEncryptedWALDecoder returns a new decoder that reads from rd and is able
+// to decrypt the values encrypted by an encoder with the same sym and secret.
+func NewEncryptedWALDecoder(rd io.Reader, sym crypto.Symmetric, secret []byte) *WALDecoder {
+	return &WALDecoder{rd: rd, sym: sym, secret: secret}
 }
 
 // Decode reads the next custom-encoded value from its reader and returns it.
@@ -363,9 +495,15 @@ func (dec *WALDecoder) Decode() (*TimedWALMessage, error) {
 		return nil, DataCorruptionError{fmt.Errorf("failed to read length: %v", err)}
 	}
 	length := binary.BigEndian.Uint32(b)
+	encrypted := length&walEncryptedFlag != 0
+	length &^= walEncryptedFlag
 
-	if length > maxMsgSizeBytes {
-		return nil, DataCorruptionError{fmt.Errorf("length %d exceeded maximum possible value of %d bytes", length, maxMsgSizeBytes)}
+	maxLength := uint32(maxMsgSizeBytes)
+	if encrypted {
+		maxLength += walEncryptionOverhead
+	}
+	if length > maxLength {
+		return nil, DataCorruptionError{fmt.Errorf("length %d exceeded maximum possible value of %d bytes", length, maxLength)}
 	}
 
 	data := make([]byte, length)
@@ -380,6 +518,17 @@ func (dec *WALDecoder) Decode() (*TimedWALMessage, error) {
 		return nil, DataCorruptionError{fmt.Errorf("checksums do not match: read: %v, actual: %v", crc, actualCRC)}
 	}
 
+	if encrypted {
+		// not a DataCorruptionError, so it isn't skipped during the replay
+		if dec.sym == nil {
+			return nil, errors.New("WAL record is encrypted but no disk_encryption_key is configured")
+		}
+		data, err = dec.sym.Decrypt(data, dec.secret)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to decrypt WAL record")
+		}
+	}
+
 	var res = new(TimedWALMessage) // nolint: gosimple
 	err = cdc.UnmarshalBinaryBare(data, res)
 	if err != nil {
@@ -399,6 +548,9 @@ func (nilWAL) FlushAndSync() error          { return nil }
 func (nilWAL) SearchForEndHeight(height int64, options *WALSearchOptions) (rd io.ReadCloser, found bool, err error) {
 	return nil, false, nil
 }
+func (nilWAL) SearchForCheckpoint(seq int64, options *WALSearchOptions) (rd io.ReadCloser, found bool, err error) {
+	return nil, false, nil
+}
 func (nilWAL) Start() error { return nil }
 func (nilWAL) Stop() error  { return nil }
 func (nilWAL) Wait()        {}
@@ -0,0 +1,94 @@
+package friday
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hdac-io/tendermint/consensus/types"
+	"github.com/hdac-io/tendermint/crypto/xsalsa20symmetric"
+	"github.com/hdac-io/tendermint/libs/log"
+	tmtime "github.com/hdac-io/tendermint/types/time"
+)
+
+func TestWALEncryptedEncoderDecoder(t *testing.T) {
+	sym := xsalsa20symmetric.Symmetric{}
+	secret := sym.Keygen()
+	msg := TimedWALMessage{Time: tmtime.Now(), Msg: timeoutInfo{Duration: time.Second, Height: 1, Round: 1, Step: types.RoundStepPropose}}
+	plain := new(bytes.Buffer)
+	require.NoError(t, NewWALEncoder(plain).Encode(&msg))
+
+	b := new(bytes.Buffer)
+	require.NoError(t, NewEncryptedWALEncoder(b, sym, secret).Encode(&msg))
+	encrypted := b.Bytes()
+	assert.False(t, bytes.Contains(encrypted, plain.Bytes()[8:]), "the record is written in plaintext")
+
+	decoded, err := NewEncryptedWALDecoder(bytes.NewReader(encrypted), sym, secret).Decode()
+	require.NoError(t, err)
+	assert.Equal(t, msg.Time.UTC(), decoded.Time)
+	assert.Equal(t, msg.Msg, decoded.Msg)
+
+	// the records written before the encryption was turned on stay readable
+	decoded, err = NewEncryptedWALDecoder(bytes.NewReader(plain.Bytes()), sym, secret).Decode()
+	require.NoError(t, err)
+	assert.Equal(t, msg.Msg, decoded.Msg)
+
+	// not data corruption, so the replay doesn't skip the records
+	_, err = NewEncryptedWALDecoder(bytes.NewReader(encrypted), sym, sym.Keygen()).Decode()
+	require.Error(t, err)
+	assert.False(t, IsDataCorruptionError(err))
+	_, err = NewWALDecoder(bytes.NewReader(encrypted)).Decode()
+	require.Error(t, err)
+	assert.False(t, IsDataCorruptionError(err))
+}
+
+func TestWALEncryptionReopen(t *testing.T) {
+	walDir, err := ioutil.TempDir("", "wal")
+	require.NoError(t, err)
+	defer os.RemoveAll(walDir)
+	walFile := filepath.Join(walDir, "wal")
+	sym := xsalsa20symmetric.Symmetric{}
+	secret := sym.Keygen()
+
+	openWAL := func(secret []byte) *baseWAL {
+		wal, err := NewWAL(walFile)
+		require.NoError(t, err)
+		wal.SetLogger(log.TestingLogger())
+		if secret != nil {
+			wal.SetEncryption(sym, secret)
+		}
+		return wal
+	}
+
+	msg := timeoutInfo{Duration: time.Second, Height: 2, Round: 1, Step: types.RoundStepPropose}
+	wal := openWAL(secret)
+	require.NoError(t, wal.Start())
+	require.NoError(t, wal.Write(EndHeightMessage{1}))
+	require.NoError(t, wal.WriteSync(msg))
+	require.NoError(t, wal.Stop())
+	wal.Wait()
+
+	// reopened with the key
+	wal = openWAL(secret)
+	gr, found, err := wal.SearchForEndHeight(1, &WALSearchOptions{})
+	require.NoError(t, err)
+	require.True(t, found)
+	decoded, err := NewEncryptedWALDecoder(gr, sym, secret).Decode()
+	gr.Close()
+	require.NoError(t, err)
+	assert.Equal(t, msg, decoded.Msg)
+
+	// reopened with another key, or none
+	for _, secret := range [][]byte{sym.Keygen(), nil} {
+		wal = openWAL(secret)
+		_, found, err = wal.SearchForEndHeight(1, &WALSearchOptions{IgnoreDataCorruptionErrors: true})
+		assert.Error(t, err)
+		assert.False(t, found)
+	}
+}
@@ -51,6 +51,31 @@ type Metrics struct {
 
 	// Number of blockparts transmitted by peer.
 	BlockParts metrics.Counter
+
+	// Number of votes the PrivValidator failed to sign in time.
+	SignVoteTimeouts metrics.Counter
+
+	// Median offset of the vote timestamps of the validators from the local
+	// clock, when the votes are received.
+	ClockSkewSeconds metrics.Gauge
+
+	// Number of times a committed height waited longer than
+	// finalize_wait_timeout for a lower height to be finalized.
+	FinalizeStalls metrics.Counter
+
+	// Number of violations of the consensus state invariants, by invariant.
+	InvariantViolations metrics.Counter
+
+	// Number of proposal block parts rebuilt from parity parts.
+	BlockPartsRebuilt metrics.Counter
+
+	// Number of heights committed with the precommits of all the validators,
+	// which skipped the timeout commit (see skip_timeout_commit).
+	OptimisticCommits metrics.Counter
+
+	// Delay between the local entry in the prevote or precommit step and the
+	// arrival of the vote of a validator.
+	VoteDelaySeconds metrics.Histogram
 }
 
 // PrometheusMetrics returns Metrics build using Prometheus client library.
@@ -155,6 +180,49 @@ func PrometheusMetrics(namespace string, labelsAndValues ...string) *Metrics {
 			Name:      "block_parts",
 			Help:      "Number of blockparts transmitted by peer.",
 		}, append(labels, "peer_id")).With(labelsAndValues...),
+		SignVoteTimeouts: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "sign_vote_timeouts",
+			Help:      "Number of votes the PrivValidator failed to sign in time.",
+		}, labels).With(labelsAndValues...),
+		ClockSkewSeconds: prometheus.NewGaugeFrom(stdprometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "clock_skew_seconds",
+			Help:      "Median offset of the vote timestamps of the validators from the local clock.",
+		}, labels).With(labelsAndValues...),
+		FinalizeStalls: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "finalize_stalls",
+			Help:      "Number of times a committed height waited too long for a lower height to be finalized.",
+		}, labels).With(labelsAndValues...),
+		InvariantViolations: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "invariant_violations",
+			Help:      "Number of violations of the consensus state invariants.",
+		}, append(labels, "invariant")).With(labelsAndValues...),
+		BlockPartsRebuilt: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "block_parts_rebuilt",
+			Help:      "Number of proposal block parts rebuilt from parity parts.",
+		}, labels).With(labelsAndValues...),
+		OptimisticCommits: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "optimistic_commits",
+			Help:      "Number of heights committed with all the precommits, without waiting for the timeout commit.",
+		}, labels).With(labelsAndValues...),
+		VoteDelaySeconds: prometheus.NewHistogramFrom(stdprometheus.HistogramOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "vote_delay_seconds",
+			Help:      "Delay between the local entry in the vote step and the arrival of the vote of a validator.",
+			Buckets:   stdprometheus.ExponentialBuckets(0.01, 2, 12),
+		}, append(labels, "validator_address", "vote_type")).With(labelsAndValues...),
 	}
 }
 
@@ -180,5 +248,19 @@ func NopMetrics() *Metrics {
 		CommittedHeight: discard.NewGauge(),
 		FastSyncing:     discard.NewGauge(),
 		BlockParts:      discard.NewCounter(),
+
+		SignVoteTimeouts: discard.NewCounter(),
+
+		ClockSkewSeconds: discard.NewGauge(),
+
+		FinalizeStalls: discard.NewCounter(),
+
+		InvariantViolations: discard.NewCounter(),
+
+		BlockPartsRebuilt: discard.NewCounter(),
+
+		OptimisticCommits: discard.NewCounter(),
+
+		VoteDelaySeconds: discard.NewHistogram(),
 	}
 }
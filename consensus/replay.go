@@ -205,6 +205,9 @@ type Handshaker struct {
 	genDoc       *types.GenesisDoc
 	logger       log.Logger
 
+	// deliver the txs of the replayed blocks one at a time
+	parallelDeliverTx bool
+
 	nBlocks int // number of blocks applied to the state
 }
 
@@ -232,6 +235,14 @@ func (h *Handshaker) SetEventBus(eventBus types.BlockEventPublisher) {
 	h.eventBus = eventBus
 }
 
+// SetParallelDeliverTx makes the replayed blocks deliver their txs one at a
+// time, for the apps the node delivers the txs concurrently to (see
+// sm.BlockExecutorWithParallelDeliverTx): the conflict keys of the txs are
+// unknown when replaying.
+func (h *Handshaker) SetParallelDeliverTx() {
+	h.parallelDeliverTx = true
+}
+
 // NBlocks returns the number of blocks applied to the state.
 func (h *Handshaker) NBlocks() int {
 	return h.nBlocks
@@ -330,6 +341,9 @@ func (h *Handshaker) ReplayBlocks(
 			if res.ConsensusParams != nil {
 				state.ConsensusParams = state.ConsensusParams.Update(res.ConsensusParams)
 			}
+			if err := state.ConsensusParams.Validator.CheckPowerShare(state.Validators.Validators); err != nil {
+				return nil, fmt.Errorf("Error in InitChain validators: %v", err)
+			}
 			sm.SaveState(h.stateDB, state)
 		}
 	}
@@ -430,7 +444,7 @@ func (h *Handshaker) replayBlocks(state sm.State, proxyApp proxy.AppConns, appBl
 			assertAppHashEqualsOneFromBlock(appHash, block)
 		}
 
-		appHash, err = sm.ExecCommitBlock(proxyApp.Consensus(), block, h.logger, h.stateDB, 1)
+		appHash, err = sm.ExecCommitBlock(proxyApp.Consensus(), block, h.logger, h.stateDB, 1, h.parallelDeliverTx)
 		if err != nil {
 			return nil, err
 		}
@@ -456,7 +470,12 @@ func (h *Handshaker) replayBlock(state sm.State, height int64, proxyApp proxy.Ap
 	block := h.store.LoadBlock(height)
 	meta := h.store.LoadBlockMeta(height)
 
-	blockExec := sm.NewBlockExecutor(h.store, h.stateDB, h.logger, proxyApp, mock.Mempool{}, sm.MockEvidencePool{})
+	var options []sm.BlockExecutorOption
+	if h.parallelDeliverTx {
+		// the mock mempool knows no conflict key
+		options = append(options, sm.BlockExecutorWithParallelDeliverTx())
+	}
+	blockExec := sm.NewBlockExecutor(h.store, h.stateDB, h.logger, proxyApp, mock.Mempool{}, sm.MockEvidencePool{}, options...)
 	blockExec.SetEventBus(h.eventBus)
 
 	var err error
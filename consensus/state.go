@@ -79,6 +79,7 @@ type IConsensusState interface {
 	GetRoundStateSimpleJSON() ([]byte, error)
 
 	SetEventBus(b *types.EventBus)
+	SetConfig(config *cfg.ConsensusConfig)
 }
 
 // ConsensusState handles execution of the consensus algorithm.
@@ -88,7 +89,8 @@ type IConsensusState interface {
 type ConsensusState struct {
 	cmn.BaseService
 
-	// config details
+	// config details, config set again by SetConfig when it is reloaded
+	configMtx     sync.RWMutex
 	config        *cfg.ConsensusConfig
 	privValidator types.PrivValidator // for signing votes
 
@@ -204,6 +206,23 @@ func (cs *ConsensusState) SetLogger(l log.Logger) {
 	cs.timeoutTicker.SetLogger(l)
 }
 
+// SetConfig sets the config, e.g. when it is reloaded. It is safe to call
+// while the consensus is running; the new timeouts apply from the next ones
+// scheduled.
+func (cs *ConsensusState) SetConfig(config *cfg.ConsensusConfig) {
+	cs.configMtx.Lock()
+	defer cs.configMtx.Unlock()
+	cs.config = config
+}
+
+// getConfig returns the config set by SetConfig. config must not be
+// modified, SetConfig sets a new one.
+func (cs *ConsensusState) getConfig() *cfg.ConsensusConfig {
+	cs.configMtx.RLock()
+	defer cs.configMtx.RUnlock()
+	return cs.config
+}
+
 // SetEventBus sets event bus.
 func (cs *ConsensusState) SetEventBus(b *types.EventBus) {
 	cs.eventBus = b
@@ -299,7 +318,7 @@ func (cs *ConsensusState) OnStart() error {
 	// we may set the WAL in testing before calling Start,
 	// so only OpenWAL if its still the nilWAL
 	if _, ok := cs.wal.(nilWAL); ok {
-		walFile := cs.config.WalFile()
+		walFile := cs.getConfig().WalFile()
 		wal, err := cs.OpenWAL(walFile)
 		if err != nil {
 			cs.Logger.Error("Error loading ConsensusState wal", "err", err.Error())
@@ -330,9 +349,9 @@ func (cs *ConsensusState) OnStart() error {
 ----
 WALFILE=~/.tendermint/data/cs.wal/wal
 cp $WALFILE ${WALFILE}.bak # backup the file
-go run scripts/wal2json/main.go $WALFILE > wal.json # this will panic, but can be ignored
+tendermint wal export $WALFILE --out wal.json # this will fail at the corruption, but can be ignored
 rm $WALFILE # remove the corrupt file
-go run scripts/json2wal/main.go wal.json $WALFILE # rebuild the file without corruption
+tendermint wal import wal.json --wal $WALFILE # rebuild the file without corruption
 ----`)
 
 				return err
@@ -552,9 +571,9 @@ func (cs *ConsensusState) updateToState(state sm.State) {
 		// to be gathered for the first block.
 		// And alternative solution that relies on clocks:
 		// cs.StartTime = state.LastBlockTime.Add(timeoutCommit)
-		cs.StartTime = cs.config.Commit(tmtime.Now())
+		cs.StartTime = cs.getConfig().Commit(tmtime.Now())
 	} else {
-		cs.StartTime = cs.config.Commit(cs.CommitTime)
+		cs.StartTime = cs.getConfig().Commit(cs.CommitTime)
 	}
 
 	cs.Validators = validators
@@ -849,10 +868,10 @@ func (cs *ConsensusState) enterNewRound(height int64, round int) {
 	// Wait for txs to be available in the mempool
 	// before we enterPropose in round 0. If the last block changed the app hash,
 	// we may need an empty "proof" block, and enterPropose immediately.
-	waitForTxs := cs.config.WaitForTxs() && round == 0 && !cs.needProofBlock(height)
+	waitForTxs := cs.getConfig().WaitForTxs() && round == 0 && !cs.needProofBlock(height)
 	if waitForTxs {
-		if cs.config.CreateEmptyBlocksInterval > 0 {
-			cs.scheduleTimeout(cs.config.CreateEmptyBlocksInterval, height, round,
+		if cs.getConfig().CreateEmptyBlocksInterval > 0 {
+			cs.scheduleTimeout(cs.getConfig().CreateEmptyBlocksInterval, height, round,
 				cstypes.RoundStepNewRound)
 		}
 	} else {
@@ -897,7 +916,7 @@ func (cs *ConsensusState) enterPropose(height int64, round int) {
 	}()
 
 	// If we don't get the proposal and all block parts quick enough, enterPrevote
-	cs.scheduleTimeout(cs.config.Propose(round), height, round, cstypes.RoundStepPropose)
+	cs.scheduleTimeout(cs.getConfig().Propose(round), height, round, cstypes.RoundStepPropose)
 
 	// Nothing more to do if we're not a validator
 	if cs.privValidator == nil {
@@ -935,7 +954,7 @@ func (cs *ConsensusState) defaultDecideProposal(height int64, round int) {
 		block, blockParts = cs.ValidBlock, cs.ValidBlockParts
 	} else {
 		// Create a new proposal block from state/txs from the mempool.
-		block, blockParts = cs.createProposalBlock()
+		block, blockParts = cs.createProposalBlock(time.Now().Add(cs.getConfig().PrepareProposal(round)))
 		if block == nil { // on error
 			return
 		}
@@ -982,8 +1001,9 @@ func (cs *ConsensusState) isProposalComplete() bool {
 // We really only need to return the parts, but the block
 // is returned for convenience so we can log the proposal block.
 // Returns nil block upon error.
+// The txs are prepared before deadline.
 // NOTE: keep it side-effect free for clarity.
-func (cs *ConsensusState) createProposalBlock() (block *types.Block, blockParts *types.PartSet) {
+func (cs *ConsensusState) createProposalBlock(deadline time.Time) (block *types.Block, blockParts *types.PartSet) {
 	var commit *types.Commit
 	switch {
 	case cs.Height == 1:
@@ -1000,7 +1020,7 @@ func (cs *ConsensusState) createProposalBlock() (block *types.Block, blockParts
 	}
 
 	proposerAddr := cs.privValidator.GetPubKey().Address()
-	return cs.blockExec.CreateProposalBlock(cs.Height, cs.state, commit, proposerAddr)
+	return cs.blockExec.CreateProposalBlock(cs.Height, cs.state, commit, proposerAddr, deadline)
 }
 
 // Enter: `timeoutPropose` after entering Propose.
@@ -1081,7 +1101,7 @@ func (cs *ConsensusState) enterPrevoteWait(height int64, round int) {
 	}()
 
 	// Wait for some more prevotes; enterPrecommit
-	cs.scheduleTimeout(cs.config.Prevote(round), height, round, cstypes.RoundStepPrevoteWait)
+	cs.scheduleTimeout(cs.getConfig().Prevote(round), height, round, cstypes.RoundStepPrevoteWait)
 }
 
 // Enter: `timeoutPrevote` after any +2/3 prevotes.
@@ -1209,7 +1229,7 @@ func (cs *ConsensusState) enterPrecommitWait(height int64, round int) {
 	}()
 
 	// Wait for some more precommits; enterNewRound
-	cs.scheduleTimeout(cs.config.Precommit(round), height, round, cstypes.RoundStepPrecommitWait)
+	cs.scheduleTimeout(cs.getConfig().Precommit(round), height, round, cstypes.RoundStepPrecommitWait)
 
 }
 
@@ -1433,8 +1453,8 @@ func (cs *ConsensusState) recordMetrics(height int64, block *types.Block) {
 
 func (cs *ConsensusState) defaultSetProposal(proposal *types.Proposal) error {
 	// Already have one
-	// TODO: possibly catch double proposals
 	if cs.Proposal != nil {
+		cs.checkDuplicateProposal(proposal)
 		return nil
 	}
 
@@ -1465,6 +1485,32 @@ func (cs *ConsensusState) defaultSetProposal(proposal *types.Proposal) error {
 	return nil
 }
 
+// checkDuplicateProposal adds the evidence to the evidence pool if the
+// proposer of the round signed the proposal for another block than the
+// proposal of the round.
+func (cs *ConsensusState) checkDuplicateProposal(proposal *types.Proposal) {
+	if proposal.Height != cs.Proposal.Height || proposal.Round != cs.Proposal.Round ||
+		proposal.BlockID.Equals(cs.Proposal.BlockID) {
+		return
+	}
+
+	proposer := cs.Validators.GetProposer()
+	if !proposer.PubKey.VerifyBytes(proposal.SignBytes(cs.state.ChainID), proposal.Signature) {
+		return
+	}
+	if cs.privValidator != nil && bytes.Equal(proposer.Address, cs.privValidator.GetPubKey().Address()) {
+		cs.Logger.Error("Found conflicting proposal from ourselves. Did you unsafe_reset a validator?",
+			"height", proposal.Height, "round", proposal.Round)
+		return
+	}
+
+	ev := types.NewDuplicateProposalEvidence(proposer.PubKey, cs.Proposal, proposal)
+	cs.Logger.Error("Found conflicting proposal", "evidence", ev)
+	if err := cs.evpool.AddEvidence(ev); err != nil {
+		cs.Logger.Error("Failed to add the duplicate proposal evidence", "err", err)
+	}
+}
+
 // NOTE: block is not necessarily valid.
 // Asynchronously triggers either enterPrevote (before we timeout of propose) or tryFinalizeCommit, once we have the full block.
 func (cs *ConsensusState) addProposalBlockPart(msg *BlockPartMessage, peerID p2p.ID) (added bool, err error) {
@@ -1588,7 +1634,7 @@ func (cs *ConsensusState) addVote(vote *types.Vote, peerID p2p.ID) (added bool,
 		cs.evsw.FireEvent(types.EventVote, vote)
 
 		// if we can skip timeoutCommit and have all the votes now,
-		if cs.config.SkipTimeoutCommit && cs.LastCommit.HasAll() {
+		if cs.getConfig().SkipTimeoutCommit && cs.LastCommit.HasAll() {
 			// go straight to new round (skip timeout commit)
 			// cs.scheduleTimeout(time.Duration(0), cs.Height, 0, cstypes.RoundStepNewHeight)
 			cs.enterNewRound(cs.Height, 0)
@@ -1696,7 +1742,7 @@ func (cs *ConsensusState) addVote(vote *types.Vote, peerID p2p.ID) (added bool,
 			cs.enterPrecommit(height, vote.Round)
 			if len(blockID.Hash) != 0 {
 				cs.enterCommit(height, vote.Round)
-				if cs.config.SkipTimeoutCommit && precommits.HasAll() {
+				if cs.getConfig().SkipTimeoutCommit && precommits.HasAll() {
 					cs.enterNewRound(cs.Height, 0)
 				}
 			} else {
@@ -3,6 +3,7 @@ package types
 import (
 	"errors"
 	"fmt"
+	"sort"
 	"strings"
 	"sync"
 
@@ -157,6 +158,31 @@ func (hvs *HeightVoteSet) POLInfo() (polRound int, polBlockID types.BlockID) {
 	return -1, types.BlockID{}
 }
 
+// Votes returns the votes of all the rounds, including the peer catchup
+// rounds, by round, prevotes first, and by validator index.
+func (hvs *HeightVoteSet) Votes() []*types.Vote {
+	hvs.mtx.Lock()
+	defer hvs.mtx.Unlock()
+
+	rounds := make([]int, 0, len(hvs.roundVoteSets))
+	for round := range hvs.roundVoteSets {
+		rounds = append(rounds, round)
+	}
+	sort.Ints(rounds)
+	var votes []*types.Vote
+	for _, round := range rounds {
+		rvs := hvs.roundVoteSets[round]
+		for _, voteSet := range []*types.VoteSet{rvs.Prevotes, rvs.Precommits} {
+			for i := 0; i < voteSet.Size(); i++ {
+				if vote := voteSet.GetByIndex(i); vote != nil {
+					votes = append(votes, vote)
+				}
+			}
+		}
+	}
+	return votes
+}
+
 func (hvs *HeightVoteSet) getVoteSet(round int, type_ types.SignedMsgType) *types.VoteSet {
 	rvs, ok := hvs.roundVoteSets[round]
 	if !ok {
@@ -52,6 +52,36 @@ func TestPeerCatchupRounds(t *testing.T) {
 
 }
 
+func TestHeightVoteSetVotes(t *testing.T) {
+	valSet, privVals := types.RandValidatorSet(10, 1)
+
+	hvs := NewHeightVoteSet(config.ChainID(), 1, valSet)
+	if votes := hvs.Votes(); len(votes) != 0 {
+		t.Fatalf("Expected no votes, got %v", votes)
+	}
+
+	expected := []*types.Vote{
+		makeVoteHR(t, 1, 0, privVals, 2),
+		makeVoteHR(t, 1, 0, privVals, 5),
+		makeVoteHR(t, 1, 999, privVals, 0),
+	}
+	for _, i := range []int{2, 0, 1} {
+		if added, err := hvs.AddVote(expected[i], "peer1"); !added || err != nil {
+			t.Fatal("Expected to successfully add vote from peer", added, err)
+		}
+	}
+
+	votes := hvs.Votes()
+	if len(votes) != len(expected) {
+		t.Fatalf("Expected %d votes, got %d", len(expected), len(votes))
+	}
+	for i, vote := range votes {
+		if vote != expected[i] {
+			t.Errorf("Expected vote %v at %d, got %v", expected[i], i, vote)
+		}
+	}
+}
+
 func makeVoteHR(t *testing.T, height int64, round int, privVals []types.PrivValidator, valIndex int) *types.Vote {
 	privVal := privVals[valIndex]
 	addr := privVal.GetPubKey().Address()
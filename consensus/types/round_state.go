@@ -87,6 +87,10 @@ type RoundState struct {
 	LastCommit                *types.VoteSet      `json:"last_commit"`  // Last precommits at Height-1
 	LastValidators            *types.ValidatorSet `json:"last_validators"`
 	TriggeredTimeoutPrecommit bool                `json:"triggered_timeout_precommit"`
+
+	// The CommitAggregate of the height, standing for +2/3 precommits for its
+	// block at its round. Only used by the friday consensus.
+	CommitAggregate *types.QuorumCert `json:"commit_aggregate"`
 }
 
 // Compressed version of the RoundState for use in RPC
@@ -10,7 +10,6 @@ import (
 
 	"github.com/pkg/errors"
 
-	amino "github.com/tendermint/go-amino"
 	auto "github.com/hdac-io/tendermint/libs/autofile"
 	cmn "github.com/hdac-io/tendermint/libs/common"
 	"github.com/hdac-io/tendermint/libs/log"
@@ -36,19 +35,33 @@ type TimedWALMessage struct {
 }
 
 // EndHeightMessage marks the end of the given height inside WAL.
-// @internal used by scripts/wal2json util.
+// @internal used by the consensus/wal package.
 type EndHeightMessage struct {
 	Height int64 `json:"height"`
 }
 
 type WALMessage interface{}
 
-func RegisterWALMessages(cdc *amino.Codec) {
-	cdc.RegisterInterface((*WALMessage)(nil), nil)
-	cdc.RegisterConcrete(types.EventDataRoundState{}, "tendermint/wal/EventDataRoundState", nil)
-	cdc.RegisterConcrete(msgInfo{}, "tendermint/wal/MsgInfo", nil)
-	cdc.RegisterConcrete(timeoutInfo{}, "tendermint/wal/TimeoutInfo", nil)
-	cdc.RegisterConcrete(EndHeightMessage{}, "tendermint/wal/EndHeightMessage", nil)
+// WALMessageHeight returns the height of a message of the WAL, if it has one.
+func WALMessageHeight(msg WALMessage) (int64, bool) {
+	switch m := msg.(type) {
+	case types.EventDataRoundState:
+		return m.Height, true
+	case msgInfo:
+		switch cm := m.Msg.(type) {
+		case *ProposalMessage:
+			return cm.Proposal.Height, true
+		case *BlockPartMessage:
+			return cm.Height, true
+		case *VoteMessage:
+			return cm.Vote.Height, true
+		}
+	case timeoutInfo:
+		return m.Height, true
+	case EndHeightMessage:
+		return m.Height, true
+	}
+	return 0, false
 }
 
 //--------------------------------------------------------
@@ -0,0 +1,27 @@
+// Code generated by scripts/codecgen from its manifest. DO NOT EDIT.
+
+package wal
+
+import (
+	"github.com/hdac-io/tendermint/consensus"
+	"github.com/hdac-io/tendermint/consensus/friday"
+	"github.com/hdac-io/tendermint/types"
+	amino "github.com/tendermint/go-amino"
+)
+
+var (
+	// the messages of the two consensus modules are registered under the same
+	// names, so each one has its codec
+	tendermintCdc = amino.NewCodec()
+	fridayCdc     = amino.NewCodec()
+)
+
+func init() {
+	consensus.RegisterConsensusMessages(tendermintCdc)
+	consensus.RegisterWALMessages(tendermintCdc)
+	types.RegisterBlockAmino(tendermintCdc)
+
+	friday.RegisterConsensusMessages(fridayCdc)
+	friday.RegisterWALMessages(fridayCdc)
+	types.RegisterBlockAmino(fridayCdc)
+}
@@ -0,0 +1,277 @@
+// Package wal reads the consensus WAL of the tendermint and friday consensus
+// modules, including the records of the friday WAL encrypted at rest, and
+// converts it to JSON and back, e.g. to inspect a WAL or to repair a corrupted
+// one. The records are tagged with the height of their message: the heights in
+// progress of the friday consensus interleave their records in the WAL, so a
+// range of heights is filtered by the height of each record rather than by the
+// EndHeightMessages.
+package wal
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/pkg/errors"
+
+	cs "github.com/hdac-io/tendermint/consensus"
+	"github.com/hdac-io/tendermint/consensus/friday"
+	"github.com/hdac-io/tendermint/crypto"
+	auto "github.com/hdac-io/tendermint/libs/autofile"
+	cmn "github.com/hdac-io/tendermint/libs/common"
+)
+
+// endHeightPrefix starts the lines which follow the EndHeightMessages in the
+// JSON, to find the ends of the heights at a glance. They are skipped when
+// reading the JSON back.
+const endHeightPrefix = "ENDHEIGHT"
+
+// Record is a record of the WAL: a message and the time it was written.
+type Record struct {
+	Time time.Time
+	// cs.WALMessage or friday.WALMessage, depending on the consensus module
+	Msg interface{}
+	// height of the message, if HasHeight
+	Height    int64
+	HasHeight bool
+}
+
+// decoder decodes the records of the WAL of a consensus module.
+type decoder interface {
+	decode() (*Record, error)
+}
+
+type tendermintDecoder struct{ dec *cs.WALDecoder }
+
+func (d tendermintDecoder) decode() (*Record, error) {
+	msg, err := d.dec.Decode()
+	if err != nil {
+		return nil, err
+	}
+	height, ok := cs.WALMessageHeight(msg.Msg)
+	return &Record{Time: msg.Time, Msg: msg.Msg, Height: height, HasHeight: ok}, nil
+}
+
+type fridayDecoder struct{ dec *friday.WALDecoder }
+
+func (d fridayDecoder) decode() (*Record, error) {
+	msg, err := d.dec.Decode()
+	if err != nil {
+		return nil, err
+	}
+	height, ok := friday.WALMessageHeight(msg.Msg)
+	return &Record{Time: msg.Time, Msg: msg.Msg, Height: height, HasHeight: ok}, nil
+}
+
+// Reader reads the records of a WAL one by one.
+type Reader struct {
+	module string
+	dec    decoder
+	closer io.Closer
+}
+
+// NewReader returns a Reader of the records of the WAL of the consensus
+// module read from rd. The records encrypted at rest by the friday consensus
+// are decrypted with sym and secret, if set.
+func NewReader(module string, rd io.Reader, sym crypto.Symmetric, secret []byte) (*Reader, error) {
+	r := &Reader{module: module}
+	switch module {
+	case "tendermint":
+		r.dec = tendermintDecoder{cs.NewWALDecoder(rd)}
+	case "friday":
+		r.dec = fridayDecoder{friday.NewEncryptedWALDecoder(rd, sym, secret)}
+	default:
+		return nil, fmt.Errorf("unknown consensus module %q", module)
+	}
+	return r, nil
+}
+
+// Open returns a Reader of all the files of the WAL at walFile, from the
+// oldest one to the head. It must be closed.
+func Open(module, walFile string, sym crypto.Symmetric, secret []byte) (*Reader, error) {
+	if !cmn.FileExists(walFile) {
+		return nil, fmt.Errorf("no WAL at %s", walFile)
+	}
+	group, err := auto.OpenGroup(walFile)
+	if err != nil {
+		return nil, err
+	}
+	gr, err := group.NewReader(group.MinIndex())
+	if err != nil {
+		group.Close()
+		return nil, err
+	}
+	r, err := NewReader(module, gr, sym, secret)
+	if err != nil {
+		gr.Close()
+		group.Close()
+		return nil, err
+	}
+	r.closer = groupCloser{group, gr}
+	return r, nil
+}
+
+type groupCloser struct {
+	group *auto.Group
+	gr    *auto.GroupReader
+}
+
+func (c groupCloser) Close() error {
+	err := c.gr.Close()
+	c.group.Close()
+	return err
+}
+
+// Read returns the next record, or io.EOF at the end of the WAL. A corrupted
+// record returns an error for which cs.IsDataCorruptionError or
+// friday.IsDataCorruptionError is true: the records after it can't be read.
+func (r *Reader) Read() (*Record, error) {
+	return r.dec.decode()
+}
+
+// Close closes the files opened by Open.
+func (r *Reader) Close() error {
+	if r.closer == nil {
+		return nil
+	}
+	return r.closer.Close()
+}
+
+// Filter selects the records of a range of heights.
+type Filter struct {
+	FromHeight int64
+	// 0 means no upper bound
+	ToHeight int64
+}
+
+// Match returns whether the filter selects the record. The records without a
+// height (the checkpoints of the friday consensus) are always selected, as well
+// as the EndHeightMessage of the height below the range, from which the
+// replay of the range starts.
+func (f Filter) Match(rec *Record) bool {
+	if !rec.HasHeight {
+		return true
+	}
+	if rec.Height < f.FromHeight {
+		return rec.Height == f.FromHeight-1 && isEndHeight(rec.Msg)
+	}
+	return f.ToHeight == 0 || rec.Height <= f.ToHeight
+}
+
+func isEndHeight(msg interface{}) bool {
+	switch msg.(type) {
+	case cs.EndHeightMessage, friday.EndHeightMessage:
+		return true
+	}
+	return false
+}
+
+// Export writes the records of r selected by filter to w as JSON, one record
+// per line, each EndHeightMessage followed by a line "ENDHEIGHT <height>". It
+// returns the number of records written, including the ones written before an
+// error: the records before a corrupted one are exported.
+func Export(r *Reader, w io.Writer, filter Filter) (int, error) {
+	n := 0
+	for {
+		rec, err := r.Read()
+		if err == io.EOF {
+			return n, nil
+		} else if err != nil {
+			return n, errors.Wrapf(err, "failed to read the record after %d records", n)
+		}
+		if !filter.Match(rec) {
+			continue
+		}
+
+		bz, err := marshalJSON(r.module, rec)
+		if err != nil {
+			return n, err
+		}
+		bz = append(bz, '\n')
+		switch msg := rec.Msg.(type) {
+		case cs.EndHeightMessage:
+			bz = append(bz, fmt.Sprintf("%s %d\n", endHeightPrefix, msg.Height)...)
+		case friday.EndHeightMessage:
+			bz = append(bz, fmt.Sprintf("%s %d\n", endHeightPrefix, msg.Height)...)
+		}
+		if _, err := w.Write(bz); err != nil {
+			return n, err
+		}
+		n++
+	}
+}
+
+// Import reads the records written by Export from r, and writes them to the
+// WAL file walFile of the consensus module, which must not exist. The records
+// are encrypted at rest with sym and secret, if set, like the friday consensus
+// does. It returns the number of records written.
+func Import(module string, r io.Reader, walFile string, sym crypto.Symmetric, secret []byte) (int, error) {
+	if module != "tendermint" && module != "friday" {
+		return 0, fmt.Errorf("unknown consensus module %q", module)
+	}
+	if err := cmn.EnsureDir(filepath.Dir(walFile), 0700); err != nil {
+		return 0, errors.Wrap(err, "failed to ensure WAL directory is in place")
+	}
+	f, err := os.OpenFile(walFile, os.O_EXCL|os.O_WRONLY|os.O_CREATE, 0600)
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to create the WAL file")
+	}
+	defer f.Close()
+	var encode func(line []byte) error
+	switch module {
+	case "tendermint":
+		enc := cs.NewWALEncoder(f)
+		encode = func(line []byte) error {
+			var msg cs.TimedWALMessage
+			if err := tendermintCdc.UnmarshalJSON(line, &msg); err != nil {
+				return err
+			}
+			return enc.Encode(&msg)
+		}
+	case "friday":
+		enc := friday.NewWALEncoder(f)
+		if sym != nil {
+			enc = friday.NewEncryptedWALEncoder(f, sym, secret)
+		}
+		encode = func(line []byte) error {
+			var msg friday.TimedWALMessage
+			if err := fridayCdc.UnmarshalJSON(line, &msg); err != nil {
+				return err
+			}
+			return enc.Encode(&msg)
+		}
+	}
+
+	// the lines of the block parts are longer than the default buffer of a
+	// bufio.Scanner, so the lines are read whole whatever their length
+	br := bufio.NewReader(r)
+	n := 0
+	for lineNum := 1; ; lineNum++ {
+		line, err := br.ReadBytes('\n')
+		if err != nil && err != io.EOF {
+			return n, err
+		}
+		line = bytes.TrimSpace(line)
+		if len(line) > 0 && !bytes.HasPrefix(line, []byte(endHeightPrefix)) {
+			if err := encode(line); err != nil {
+				return n, errors.Wrapf(err, "failed to import line %d", lineNum)
+			}
+			n++
+		}
+		if err == io.EOF {
+			break
+		}
+	}
+	return n, f.Sync()
+}
+
+func marshalJSON(module string, rec *Record) ([]byte, error) {
+	if module == "tendermint" {
+		return tendermintCdc.MarshalJSON(&cs.TimedWALMessage{Time: rec.Time, Msg: rec.Msg})
+	}
+	return fridayCdc.MarshalJSON(&friday.TimedWALMessage{Time: rec.Time, Msg: rec.Msg})
+}
@@ -0,0 +1,122 @@
+package wal
+
+import (
+	"bytes"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hdac-io/tendermint/consensus/friday"
+	"github.com/hdac-io/tendermint/crypto"
+	"github.com/hdac-io/tendermint/crypto/xsalsa20symmetric"
+	"github.com/hdac-io/tendermint/types"
+)
+
+// fridayWAL returns a friday WAL whose heights 1 and 2 interleave their
+// records.
+func fridayWAL(t *testing.T, sym crypto.Symmetric, secret []byte) []byte {
+	msgs := []friday.WALMessage{
+		friday.EndHeightMessage{Height: 0},
+		types.EventDataRoundState{Height: 1, Step: "RoundStepPropose"},
+		types.EventDataRoundState{Height: 2, Step: "RoundStepPropose"},
+		friday.EndHeightMessage{Height: 1},
+		friday.CheckpointMessage{Seq: 1},
+		types.EventDataRoundState{Height: 2, Step: "RoundStepCommit"},
+		types.EventDataRoundState{Height: 3, Step: "RoundStepPropose"},
+		friday.EndHeightMessage{Height: 2},
+	}
+	buf := new(bytes.Buffer)
+	enc := friday.NewEncryptedWALEncoder(buf, sym, secret)
+	now := time.Now().UTC()
+	for _, msg := range msgs {
+		require.NoError(t, enc.Encode(&friday.TimedWALMessage{Time: now, Msg: msg}))
+	}
+	return buf.Bytes()
+}
+
+func readAll(t *testing.T, r *Reader) []interface{} {
+	var msgs []interface{}
+	for {
+		rec, err := r.Read()
+		if err == io.EOF {
+			return msgs
+		}
+		require.NoError(t, err)
+		msgs = append(msgs, rec.Msg)
+	}
+}
+
+func TestExportImport(t *testing.T) {
+	dir, err := ioutil.TempDir("", "wal")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	sym := xsalsa20symmetric.Symmetric{}
+	secret := bytes.Repeat([]byte{1}, 32)
+
+	r, err := NewReader("friday", bytes.NewReader(fridayWAL(t, sym, secret)), sym, secret)
+	require.NoError(t, err)
+	out := new(bytes.Buffer)
+	n, err := Export(r, out, Filter{FromHeight: 2, ToHeight: 2})
+	require.NoError(t, err)
+	// the end of the height 1, the records of the height 2 and the checkpoint
+	assert.Equal(t, 5, n)
+	assert.True(t, strings.HasSuffix(out.String(), "ENDHEIGHT 2\n"), out.String())
+
+	walFile := filepath.Join(dir, "cs.wal", "wal")
+	n, err = Import("friday", bytes.NewReader(out.Bytes()), walFile, sym, secret)
+	require.NoError(t, err)
+	assert.Equal(t, 5, n)
+	_, err = Import("friday", bytes.NewReader(out.Bytes()), walFile, sym, secret)
+	assert.Error(t, err, "the WAL file exists")
+
+	// the imported records are encrypted
+	r = mustOpen(t, walFile, nil, nil)
+	_, err = Export(r, ioutil.Discard, Filter{})
+	assert.Error(t, err)
+	r.Close()
+	r = mustOpen(t, walFile, sym, secret)
+	defer r.Close()
+	assert.Equal(t, []interface{}{
+		types.EventDataRoundState{Height: 2, Step: "RoundStepPropose"},
+		friday.EndHeightMessage{Height: 1},
+		friday.CheckpointMessage{Seq: 1},
+		types.EventDataRoundState{Height: 2, Step: "RoundStepCommit"},
+		friday.EndHeightMessage{Height: 2},
+	}, readAll(t, r))
+}
+
+func TestExportCorrupted(t *testing.T) {
+	bz := fridayWAL(t, nil, nil)
+	// corrupt the last record
+	bz[len(bz)-1] ^= 0xff
+
+	r, err := NewReader("friday", bytes.NewReader(bz), nil, nil)
+	require.NoError(t, err)
+	out := new(bytes.Buffer)
+	n, err := Export(r, out, Filter{})
+	require.Error(t, err)
+	// the records before the corrupted one are exported, and the ends of the
+	// heights 0 and 1
+	assert.Equal(t, 7, n)
+	assert.Equal(t, 7+2, strings.Count(out.String(), "\n"))
+
+	r, err = NewReader("friday", bytes.NewReader(bz), nil, nil)
+	require.NoError(t, err)
+	for err == nil {
+		_, err = r.Read()
+	}
+	assert.True(t, friday.IsDataCorruptionError(err), err)
+}
+
+func mustOpen(t *testing.T, walFile string, sym crypto.Symmetric, secret []byte) *Reader {
+	r, err := Open("friday", walFile, sym, secret)
+	require.NoError(t, err)
+	return r
+}
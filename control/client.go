@@ -0,0 +1,51 @@
+package control
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/hdac-io/tendermint/crypto"
+	cmn "github.com/hdac-io/tendermint/libs/common"
+	tmtime "github.com/hdac-io/tendermint/types/time"
+)
+
+// Call sends the control API listening on addr the command, signed with
+// privKey, and returns the status of the node after it was carried out.
+func Call(addr string, privKey crypto.PrivKey, command string, height int64) (*Status, error) {
+	req, err := NewRequest(command, height, tmtime.Now(), privKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign the request: %v", err)
+	}
+	if err := req.ValidateBasic(); err != nil {
+		return nil, err
+	}
+	bz, err := cdc.MarshalJSON(req)
+	if err != nil {
+		return nil, err
+	}
+
+	conn, err := cmn.Connect(addr)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+	conn.SetDeadline(time.Now().Add(connTimeout)) // nolint: errcheck
+
+	if _, err := conn.Write(append(bz, '\n')); err != nil {
+		return nil, err
+	}
+	line, err := bufio.NewReader(conn).ReadBytes('\n')
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the response: %v", err)
+	}
+	var res Response
+	if err := cdc.UnmarshalJSON(line, &res); err != nil {
+		return nil, fmt.Errorf("failed to parse the response: %v", err)
+	}
+	if res.Error != "" {
+		return nil, errors.New(res.Error)
+	}
+	return res.Status, nil
+}
@@ -0,0 +1,14 @@
+// Code generated by scripts/codecgen from its manifest. DO NOT EDIT.
+
+package control
+
+import (
+	cryptoAmino "github.com/hdac-io/tendermint/crypto/encoding/amino"
+	amino "github.com/tendermint/go-amino"
+)
+
+var cdc = amino.NewCodec()
+
+func init() {
+	cryptoAmino.RegisterAmino(cdc)
+}
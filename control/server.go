@@ -0,0 +1,215 @@
+package control
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"sync"
+	"time"
+
+	cfg "github.com/hdac-io/tendermint/config"
+	"github.com/hdac-io/tendermint/crypto"
+	cmn "github.com/hdac-io/tendermint/libs/common"
+	"github.com/hdac-io/tendermint/libs/log"
+	tmtime "github.com/hdac-io/tendermint/types/time"
+)
+
+// connTimeout is the time a client has to send its request and read the
+// response.
+const connTimeout = 10 * time.Second
+
+// Handler carries out the control commands on the node.
+type Handler interface {
+	ControlStatus() Status
+	// Drain makes the node stop proposing blocks if drain, or propose them
+	// again otherwise.
+	Drain(drain bool) error
+	// StopAfterHeight makes the node stop once the block of height is
+	// finalized.
+	StopAfterHeight(height int64) error
+	RotateLogs() error
+}
+
+// Server serves the control API on a UNIX socket. It carries out the
+// requests signed by the authorized keys with its Handler, one request per
+// connection.
+type Server struct {
+	cmn.BaseService
+
+	config  *cfg.ControlConfig
+	handler Handler
+	clock   tmtime.Clock
+
+	authorizedKeys []crypto.PubKey
+	listener       net.Listener
+
+	// the signatures of the requests received within MaxRequestAge, by
+	// request time, to reject the replays
+	mtx  sync.Mutex
+	seen map[string]time.Time
+}
+
+// NewServer returns a Server of the control API with config, carrying out the
+// commands with handler.
+func NewServer(config *cfg.ControlConfig, handler Handler, logger log.Logger) *Server {
+	s := &Server{
+		config:  config,
+		handler: handler,
+		clock:   tmtime.SystemClock{},
+		seen:    make(map[string]time.Time),
+	}
+	s.BaseService = *cmn.NewBaseService(logger, "ControlServer", s)
+	return s
+}
+
+// OnStart implements cmn.Service. It loads the authorized keys and listens on
+// the UNIX socket, which only the user of the node can connect to.
+func (s *Server) OnStart() error {
+	keys, err := LoadAuthorizedKeys(s.config.AuthorizedKeysFilePath())
+	if err != nil {
+		return fmt.Errorf("failed to load the control authorized keys: %v", err)
+	}
+	if len(keys) == 0 {
+		return errors.New("no control authorized keys")
+	}
+	s.authorizedKeys = keys
+
+	_, path := cmn.ProtocolAndAddress(s.config.ListenAddress)
+	// remove the socket left by a previous run
+	if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	listener, err := net.Listen("unix", path)
+	if err != nil {
+		return err
+	}
+	if err := os.Chmod(path, 0600); err != nil {
+		listener.Close()
+		return err
+	}
+	s.listener = listener
+
+	go s.acceptRoutine()
+	return nil
+}
+
+// OnStop implements cmn.Service.
+func (s *Server) OnStop() {
+	if err := s.listener.Close(); err != nil {
+		s.Logger.Error("Error closing the control listener", "err", err)
+	}
+}
+
+func (s *Server) acceptRoutine() {
+	for {
+		conn, err := s.listener.Accept()
+		if err != nil {
+			if !s.IsRunning() {
+				return
+			}
+			s.Logger.Error("Failed to accept a control connection", "err", err)
+			continue
+		}
+		go s.handleConn(conn)
+	}
+}
+
+func (s *Server) handleConn(conn net.Conn) {
+	defer conn.Close()
+	conn.SetDeadline(time.Now().Add(connTimeout)) // nolint: errcheck
+
+	var res Response
+	status, err := s.handleRequest(conn)
+	if err != nil {
+		res.Error = err.Error()
+	} else {
+		res.Status = &status
+	}
+	bz, err := cdc.MarshalJSON(res)
+	if err != nil {
+		s.Logger.Error("Failed to marshal the control response", "err", err)
+		return
+	}
+	if _, err := conn.Write(append(bz, '\n')); err != nil {
+		s.Logger.Error("Failed to write the control response", "err", err)
+	}
+}
+
+// handleRequest reads the request of conn, verifies it and carries it out.
+func (s *Server) handleRequest(conn net.Conn) (Status, error) {
+	reader := bufio.NewReaderSize(conn, maxRequestBytes)
+	line, isPrefix, err := reader.ReadLine()
+	if err != nil {
+		return Status{}, fmt.Errorf("failed to read the request: %v", err)
+	}
+	if isPrefix {
+		return Status{}, fmt.Errorf("request larger than %d bytes", maxRequestBytes)
+	}
+	var req Request
+	if err := cdc.UnmarshalJSON(line, &req); err != nil {
+		return Status{}, fmt.Errorf("failed to parse the request: %v", err)
+	}
+	if err := s.verifyRequest(&req); err != nil {
+		s.Logger.Error("Rejected a control request", "command", req.Command, "err", err)
+		return Status{}, err
+	}
+
+	s.Logger.Info("Control request", "command", req.Command, "height", req.Height,
+		"pubKey", req.PubKey)
+	switch req.Command {
+	case CommandDrain:
+		err = s.handler.Drain(true)
+	case CommandResume:
+		err = s.handler.Drain(false)
+	case CommandStopAfterHeight:
+		err = s.handler.StopAfterHeight(req.Height)
+	case CommandRotateLogs:
+		err = s.handler.RotateLogs()
+	}
+	if err != nil {
+		return Status{}, err
+	}
+	return s.handler.ControlStatus(), nil
+}
+
+// verifyRequest returns an error if req isn't signed by an authorized key,
+// was signed more than MaxRequestAge from now, or was already received.
+func (s *Server) verifyRequest(req *Request) error {
+	if err := req.ValidateBasic(); err != nil {
+		return err
+	}
+	authorized := false
+	for _, key := range s.authorizedKeys {
+		if key.Equals(req.PubKey) {
+			authorized = true
+			break
+		}
+	}
+	if !authorized {
+		return errors.New("unauthorized key")
+	}
+	if !req.PubKey.VerifyBytes(req.SignBytes(), req.Signature) {
+		return errors.New("invalid signature")
+	}
+
+	now := s.clock.Now()
+	maxAge := s.config.MaxRequestAge
+	if age := now.Sub(req.Time); age > maxAge || age < -maxAge {
+		return fmt.Errorf("request time %v too far from the node time %v", req.Time, now)
+	}
+
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	for sig, t := range s.seen {
+		if now.Sub(t) > maxAge {
+			delete(s.seen, sig)
+		}
+	}
+	if _, ok := s.seen[string(req.Signature)]; ok {
+		return errors.New("replayed request")
+	}
+	s.seen[string(req.Signature)] = req.Time
+	return nil
+}
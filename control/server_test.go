@@ -0,0 +1,181 @@
+package control
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	cfg "github.com/hdac-io/tendermint/config"
+	"github.com/hdac-io/tendermint/crypto/ed25519"
+	"github.com/hdac-io/tendermint/libs/log"
+)
+
+type fixedClock time.Time
+
+func (c fixedClock) Now() time.Time {
+	return time.Time(c)
+}
+
+type mockHandler struct {
+	status Status
+}
+
+func (h *mockHandler) ControlStatus() Status {
+	return h.status
+}
+
+func (h *mockHandler) Drain(drain bool) error {
+	h.status.Draining = drain
+	return nil
+}
+
+func (h *mockHandler) StopAfterHeight(height int64) error {
+	if height <= h.status.LastHeight {
+		return fmt.Errorf("height %d is already finalized", height)
+	}
+	h.status.StopHeight = height
+	return nil
+}
+
+func (h *mockHandler) RotateLogs() error {
+	return fmt.Errorf("no log file")
+}
+
+func startServer(t *testing.T, handler Handler, keys ...ed25519.PrivKeyEd25519) (*Server, string, func()) {
+	dir, err := ioutil.TempDir("", "control_test")
+	require.NoError(t, err)
+
+	var authorized string
+	for _, key := range keys {
+		line, err := AuthorizedKey(key.PubKey())
+		require.NoError(t, err)
+		authorized += "# operator\n" + line + "\n"
+	}
+	config := cfg.TestControlConfig()
+	config.RootDir = dir
+	config.AuthorizedKeysFile = "authorized_keys"
+	config.ListenAddress = "unix://" + filepath.Join(dir, "control.sock")
+	require.NoError(t, ioutil.WriteFile(config.AuthorizedKeysFilePath(), []byte(authorized), 0600))
+
+	s := NewServer(config, handler, log.TestingLogger())
+	require.NoError(t, s.Start())
+	return s, config.ListenAddress, func() {
+		s.Stop()
+		os.RemoveAll(dir)
+	}
+}
+
+func TestServerCommands(t *testing.T) {
+	key := ed25519.GenPrivKey()
+	handler := &mockHandler{status: Status{LastHeight: 10, PipelineHeight: 12}}
+	_, addr, stop := startServer(t, handler, key)
+	defer stop()
+
+	status, err := Call(addr, key, CommandStatus, 0)
+	require.NoError(t, err)
+	assert.Equal(t, handler.status, *status)
+
+	status, err = Call(addr, key, CommandDrain, 0)
+	require.NoError(t, err)
+	assert.True(t, status.Draining)
+
+	status, err = Call(addr, key, CommandStopAfterHeight, 12)
+	require.NoError(t, err)
+	assert.Equal(t, int64(12), status.StopHeight)
+
+	status, err = Call(addr, key, CommandResume, 0)
+	require.NoError(t, err)
+	assert.False(t, status.Draining)
+
+	// the handler errors are returned
+	_, err = Call(addr, key, CommandStopAfterHeight, 9)
+	assert.Error(t, err)
+	_, err = Call(addr, key, CommandRotateLogs, 0)
+	assert.Error(t, err)
+
+	_, err = Call(addr, key, CommandStopAfterHeight, 0)
+	assert.Error(t, err)
+	_, err = Call(addr, key, "restart", 0)
+	assert.Error(t, err)
+}
+
+func TestServerRejectsRequests(t *testing.T) {
+	key := ed25519.GenPrivKey()
+	handler := &mockHandler{}
+	s, addr, stop := startServer(t, handler, key)
+	defer stop()
+	now := time.Now()
+	s.clock = fixedClock(now)
+
+	send := func(req *Request) error {
+		conn, err := net.Dial("unix", addr[len("unix://"):])
+		require.NoError(t, err)
+		defer conn.Close()
+		_, err = conn.Write(append(cdc.MustMarshalJSON(req), '\n'))
+		require.NoError(t, err)
+		bz, err := ioutil.ReadAll(conn)
+		require.NoError(t, err)
+		var res Response
+		require.NoError(t, cdc.UnmarshalJSON(bz, &res))
+		if res.Error != "" {
+			return errors.New(res.Error)
+		}
+		return nil
+	}
+
+	// signed by an unauthorized key
+	req, err := NewRequest(CommandDrain, 0, now, ed25519.GenPrivKey())
+	require.NoError(t, err)
+	assert.EqualError(t, send(req), "unauthorized key")
+
+	// tampered with
+	req, err = NewRequest(CommandStatus, 0, now, key)
+	require.NoError(t, err)
+	req.Command = CommandDrain
+	assert.EqualError(t, send(req), "invalid signature")
+
+	// too old or too far in the future
+	req, err = NewRequest(CommandDrain, 0, now.Add(-time.Minute), key)
+	require.NoError(t, err)
+	assert.Error(t, send(req))
+	req, err = NewRequest(CommandDrain, 0, now.Add(time.Minute), key)
+	require.NoError(t, err)
+	assert.Error(t, send(req))
+	assert.False(t, handler.status.Draining)
+
+	// replayed
+	req, err = NewRequest(CommandDrain, 0, now, key)
+	require.NoError(t, err)
+	assert.NoError(t, send(req))
+	assert.EqualError(t, send(req), "replayed request")
+}
+
+func TestLoadAuthorizedKeys(t *testing.T) {
+	f, err := ioutil.TempFile("", "authorized_keys")
+	require.NoError(t, err)
+	defer os.Remove(f.Name())
+
+	key := ed25519.GenPrivKey().PubKey()
+	line, err := AuthorizedKey(key)
+	require.NoError(t, err)
+	_, err = f.WriteString("# comment\n\n  " + line + "  \n")
+	require.NoError(t, err)
+	require.NoError(t, f.Close())
+
+	keys, err := LoadAuthorizedKeys(f.Name())
+	require.NoError(t, err)
+	require.Len(t, keys, 1)
+	assert.True(t, key.Equals(keys[0]))
+
+	require.NoError(t, ioutil.WriteFile(f.Name(), []byte(line[2:]+"\n"), 0600))
+	_, err = LoadAuthorizedKeys(f.Name())
+	assert.Error(t, err)
+}
@@ -0,0 +1,147 @@
+package control
+
+import (
+	"bufio"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/hdac-io/tendermint/crypto"
+	"github.com/hdac-io/tendermint/crypto/ed25519"
+)
+
+// The commands of the control API.
+const (
+	// CommandStatus returns the status of the node.
+	CommandStatus = "status"
+	// CommandDrain makes the node stop proposing blocks, but keep voting.
+	CommandDrain = "drain"
+	// CommandResume makes a drained node propose blocks again.
+	CommandResume = "resume"
+	// CommandStopAfterHeight makes the node stop once the block of the
+	// request height is finalized.
+	CommandStopAfterHeight = "stop-after-height"
+	// CommandRotateLogs moves the log file away and starts a new one.
+	CommandRotateLogs = "rotate-logs"
+)
+
+// maxRequestBytes is the maximum size of a request on the wire.
+const maxRequestBytes = 64 * 1024
+
+// Request is a control request, signed by one of the authorized keys.
+type Request struct {
+	Command string `json:"command"`
+	// the height of CommandStopAfterHeight
+	Height int64 `json:"height"`
+	// the time the request was signed, to reject replays
+	Time time.Time `json:"time"`
+
+	PubKey    crypto.PubKey `json:"pub_key"`
+	Signature []byte        `json:"signature"`
+}
+
+// NewRequest returns the request of command at now, signed with privKey.
+func NewRequest(command string, height int64, now time.Time, privKey crypto.PrivKey) (*Request, error) {
+	req := &Request{
+		Command: command,
+		Height:  height,
+		Time:    now,
+		PubKey:  privKey.PubKey(),
+	}
+	sig, err := privKey.Sign(req.SignBytes())
+	if err != nil {
+		return nil, err
+	}
+	req.Signature = sig
+	return req, nil
+}
+
+// SignBytes returns the bytes of the request signed by its key.
+func (req *Request) SignBytes() []byte {
+	return cdc.MustMarshalBinaryBare(Request{
+		Command: req.Command,
+		Height:  req.Height,
+		Time:    req.Time,
+		PubKey:  req.PubKey,
+	})
+}
+
+// ValidateBasic performs basic validation.
+func (req *Request) ValidateBasic() error {
+	switch req.Command {
+	case CommandStatus, CommandDrain, CommandResume, CommandRotateLogs:
+	case CommandStopAfterHeight:
+		if req.Height <= 0 {
+			return errors.New("stop-after-height requires a positive height")
+		}
+	default:
+		return fmt.Errorf("unknown command %q", req.Command)
+	}
+	if req.PubKey == nil {
+		return errors.New("missing pub_key")
+	}
+	if len(req.Signature) == 0 {
+		return errors.New("missing signature")
+	}
+	return nil
+}
+
+// Status is the status of the node returned by every command.
+type Status struct {
+	// height of the last finalized block
+	LastHeight int64 `json:"last_height"`
+	// highest height the consensus is deciding
+	PipelineHeight int64 `json:"pipeline_height"`
+	// true if the node doesn't propose blocks
+	Draining bool `json:"draining"`
+	// true if the validator waits in standby to enter the validator set
+	Standby bool `json:"standby"`
+	// height after which the node stops, 0 if none
+	StopHeight int64 `json:"stop_height"`
+}
+
+// Response is the response to a control request.
+type Response struct {
+	Status *Status `json:"status,omitempty"`
+	Error  string  `json:"error,omitempty"`
+}
+
+// LoadAuthorizedKeys reads the hex encoded ed25519 public keys of the file,
+// one per line. Empty lines and lines starting with # are skipped.
+func LoadAuthorizedKeys(path string) ([]crypto.PubKey, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	var keys []crypto.PubKey
+	scanner := bufio.NewScanner(file)
+	for lineNum := 1; scanner.Scan(); lineNum++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		bz, err := hex.DecodeString(line)
+		if err != nil || len(bz) != ed25519.PubKeyEd25519Size {
+			return nil, fmt.Errorf("%s:%d: not a hex encoded ed25519 public key", path, lineNum)
+		}
+		var pubKey ed25519.PubKeyEd25519
+		copy(pubKey[:], bz)
+		keys = append(keys, pubKey)
+	}
+	return keys, scanner.Err()
+}
+
+// AuthorizedKey returns the line of the ed25519 pubKey in the authorized keys
+// file.
+func AuthorizedKey(pubKey crypto.PubKey) (string, error) {
+	edPubKey, ok := pubKey.(ed25519.PubKeyEd25519)
+	if !ok {
+		return "", errors.New("not an ed25519 public key")
+	}
+	return fmt.Sprintf("%X", edPubKey[:]), nil
+}
@@ -0,0 +1,28 @@
+// Package batch creates the batch verifiers of the key types which support
+// them.
+package batch
+
+import (
+	"github.com/hdac-io/tendermint/crypto"
+	"github.com/hdac-io/tendermint/crypto/bls"
+	"github.com/hdac-io/tendermint/crypto/ed25519"
+)
+
+// CreateBatchVerifier returns a new batch verifier of the signatures of the
+// type of pk, and false if the type doesn't support batch verification.
+func CreateBatchVerifier(pk crypto.PubKey) (crypto.BatchVerifier, bool) {
+	switch pk.(type) {
+	case ed25519.PubKeyEd25519:
+		return ed25519.NewBatchVerifier(), true
+	case bls.PubKeyBls:
+		return bls.NewBatchVerifier(), true
+	}
+	return nil, false
+}
+
+// SupportsBatchVerifier returns true if the type of pk supports batch
+// verification.
+func SupportsBatchVerifier(pk crypto.PubKey) bool {
+	_, ok := CreateBatchVerifier(pk)
+	return ok
+}
@@ -0,0 +1,76 @@
+package batch
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hdac-io/tendermint/crypto"
+	"github.com/hdac-io/tendermint/crypto/bls"
+	"github.com/hdac-io/tendermint/crypto/ed25519"
+	"github.com/hdac-io/tendermint/crypto/secp256k1"
+)
+
+func TestBatchVerifier(t *testing.T) {
+	testCases := map[string]func() crypto.PrivKey{
+		"ed25519": func() crypto.PrivKey { return ed25519.GenPrivKey() },
+		"bls":     func() crypto.PrivKey { return bls.GenPrivKey() },
+	}
+	for name, genPrivKey := range testCases {
+		t.Run(name, func(t *testing.T) {
+			var (
+				keys []crypto.PubKey
+				msgs [][]byte
+				sigs [][]byte
+			)
+			for i := 0; i < 8; i++ {
+				privKey := genPrivKey()
+				// messages of several lengths
+				msg := crypto.CRandBytes(64 + i%3)
+				sig, err := privKey.Sign(msg)
+				require.NoError(t, err)
+				keys = append(keys, privKey.PubKey())
+				msgs = append(msgs, msg)
+				sigs = append(sigs, sig)
+			}
+
+			verify := func() (bool, []bool) {
+				bv, ok := CreateBatchVerifier(keys[0])
+				require.True(t, ok)
+				for i := range keys {
+					require.NoError(t, bv.Add(keys[i], msgs[i], sigs[i]))
+				}
+				return bv.Verify()
+			}
+
+			ok, valid := verify()
+			assert.True(t, ok)
+			assert.Equal(t, []bool{true, true, true, true, true, true, true, true}, valid)
+
+			// sign another message with the 2nd and 6th signatures
+			sig, err := genPrivKey().Sign(msgs[1])
+			require.NoError(t, err)
+			sigs[1] = sig
+			msgs[5] = crypto.CRandBytes(len(msgs[5]))
+
+			ok, valid = verify()
+			assert.False(t, ok)
+			assert.Equal(t, []bool{true, false, true, true, true, false, true, true}, valid)
+
+			bv, _ := CreateBatchVerifier(keys[0])
+			ok, valid = bv.Verify()
+			assert.False(t, ok, "empty batch")
+			assert.Empty(t, valid)
+		})
+	}
+}
+
+func TestSupportsBatchVerifier(t *testing.T) {
+	assert.True(t, SupportsBatchVerifier(ed25519.GenPrivKey().PubKey()))
+	assert.True(t, SupportsBatchVerifier(bls.GenPrivKey().PubKey()))
+	assert.False(t, SupportsBatchVerifier(secp256k1.GenPrivKey().PubKey()))
+
+	bv, _ := CreateBatchVerifier(ed25519.GenPrivKey().PubKey())
+	assert.Error(t, bv.Add(bls.GenPrivKey().PubKey(), []byte("msg"), make([]byte, 48)))
+}
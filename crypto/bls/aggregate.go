@@ -0,0 +1,78 @@
+package bls
+
+import (
+	"fmt"
+
+	herumi "github.com/hdac-io/bls-go-binary/bls"
+)
+
+// AggregateSignatures returns the aggregate of the signatures, verified with
+// VerifyAggregateSignature.
+func AggregateSignatures(sigs [][]byte) ([]byte, error) {
+	if len(sigs) == 0 {
+		return nil, fmt.Errorf("no signatures")
+	}
+	var aggregate herumi.Sign
+	for i, sig := range sigs {
+		var s herumi.Sign
+		if err := s.Deserialize(sig); err != nil {
+			return nil, fmt.Errorf("invalid signature %d: %v", i, err)
+		}
+		if i == 0 {
+			aggregate = s
+		} else {
+			aggregate.Add(&s)
+		}
+	}
+	return aggregate.Serialize(), nil
+}
+
+// VerifyAggregateSignature returns true if sig is the aggregate of the
+// signatures of msgs by pubKeys, in order. Like for VerifyBytes, the message
+// signed is mapped from its first bytes only, the size of a field element, so
+// the messages may have different lengths but must be at least that long.
+//
+// The aggregate of the signatures of the same message can be forged with a
+// pubkey made from the others (rogue key): the pubkeys must have a valid
+// proof of possession, see VerifyProofOfPossession.
+func VerifyAggregateSignature(pubKeys []PubKeyBls, msgs [][]byte, sig []byte) bool {
+	if len(pubKeys) == 0 || len(pubKeys) != len(msgs) {
+		return false
+	}
+	size := herumi.GetFpByteSize()
+	pubVec := make([]herumi.PublicKey, len(pubKeys))
+	hashes := make([][]byte, len(msgs))
+	for i, msg := range msgs {
+		if len(msg) < size {
+			return false
+		}
+		pubVec[i] = pubKeys[i].PublicKey
+		hashes[i] = msg[:size]
+	}
+	var aggregate herumi.Sign
+	if err := aggregate.Deserialize(sig); err != nil {
+		return false
+	}
+	return aggregate.VerifyAggregateHashes(pubVec, hashes)
+}
+
+// ProofOfPossession returns the proof that the holder of the pubkey of
+// privKey has privKey, its signature of the pubkey, to register the pubkey
+// with.
+func (privKey PrivKeyBls) ProofOfPossession() []byte {
+	return privKey.GetPop().Serialize()
+}
+
+// VerifyProofOfPossession returns true if pop proves the possession of the
+// private key of pubKey, which then can't be a rogue key made from others.
+func (pubKey PubKeyBls) VerifyProofOfPossession(pop []byte) bool {
+	// herumi panics deserializing an empty signature
+	if len(pop) == 0 {
+		return false
+	}
+	var sig herumi.Sign
+	if err := sig.Deserialize(pop); err != nil {
+		return false
+	}
+	return sig.VerifyPop(&pubKey.PublicKey)
+}
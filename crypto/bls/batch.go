@@ -0,0 +1,113 @@
+package bls
+
+import (
+	"fmt"
+
+	herumi "github.com/hdac-io/bls-go-binary/bls"
+
+	"github.com/hdac-io/tendermint/crypto"
+)
+
+var _ crypto.BatchVerifier = &BatchVerifier{}
+
+// BatchVerifier verifies BLS signatures by aggregating them. Each signature
+// and its pubkey are multiplied by a random scalar, so that invalid
+// signatures can't cancel each other out in the aggregate. As herumi
+// aggregates messages of the same length only, the signatures are aggregated
+// by length of message. If an aggregate is invalid, its signatures are
+// verified one by one to find the invalid ones.
+type BatchVerifier struct {
+	keys []PubKeyBls
+	msgs [][]byte
+	sigs []herumi.G1
+}
+
+// NewBatchVerifier returns an empty BatchVerifier.
+func NewBatchVerifier() *BatchVerifier {
+	return &BatchVerifier{}
+}
+
+// Add implements crypto.BatchVerifier.
+func (b *BatchVerifier) Add(key crypto.PubKey, msg, sig []byte) error {
+	pubKey, ok := key.(PubKeyBls)
+	if !ok {
+		return fmt.Errorf("pubkey is not BLS: %T", key)
+	}
+	if len(msg) == 0 {
+		return fmt.Errorf("empty message")
+	}
+	// the signatures are in G1 and the pubkeys in G2
+	var g1 herumi.G1
+	if len(sig) == 0 || g1.Deserialize(sig) != nil {
+		return fmt.Errorf("invalid signature %X", sig)
+	}
+	b.keys = append(b.keys, pubKey)
+	b.msgs = append(b.msgs, msg)
+	b.sigs = append(b.sigs, g1)
+	return nil
+}
+
+// Verify implements crypto.BatchVerifier.
+func (b *BatchVerifier) Verify() (bool, []bool) {
+	if len(b.keys) == 0 {
+		return false, nil
+	}
+	byLength := make(map[int][]int)
+	for i, msg := range b.msgs {
+		byLength[len(msg)] = append(byLength[len(msg)], i)
+	}
+
+	valid := make([]bool, len(b.keys))
+	allValid := true
+	for _, indexes := range byLength {
+		if b.verifyAggregate(indexes) {
+			for _, i := range indexes {
+				valid[i] = true
+			}
+			continue
+		}
+		for _, i := range indexes {
+			var sig herumi.Sign
+			valid[i] = sig.Deserialize(b.sigs[i].Serialize()) == nil &&
+				sig.VerifyHash(&b.keys[i].PublicKey, b.msgs[i])
+			allValid = allValid && valid[i]
+		}
+	}
+	return allValid, valid
+}
+
+// verifyAggregate returns true if the randomized aggregate of the signatures
+// of indexes, whose messages have the same length, is valid.
+func (b *BatchVerifier) verifyAggregate(indexes []int) bool {
+	var aggregate herumi.G1
+	pubKeys := make([]herumi.PublicKey, len(indexes))
+	msgs := make([][]byte, len(indexes))
+	for j, i := range indexes {
+		var r herumi.Fr
+		r.SetByCSPRNG()
+
+		var sig herumi.G1
+		herumi.G1Mul(&sig, &b.sigs[i], &r)
+		if j == 0 {
+			aggregate = sig
+		} else {
+			herumi.G1Add(&aggregate, &aggregate, &sig)
+		}
+
+		var pubKey herumi.G2
+		if err := pubKey.Deserialize(b.keys[i].Serialize()); err != nil {
+			return false
+		}
+		herumi.G2Mul(&pubKey, &pubKey, &r)
+		if err := pubKeys[j].Deserialize(pubKey.Serialize()); err != nil {
+			return false
+		}
+		msgs[j] = b.msgs[i]
+	}
+
+	var sig herumi.Sign
+	if err := sig.Deserialize(aggregate.Serialize()); err != nil {
+		return false
+	}
+	return sig.VerifyAggregateHashes(pubKeys, msgs)
+}
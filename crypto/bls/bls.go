@@ -89,6 +89,10 @@ func (pubKey PubKeyBls) Bytes() []byte {
 	return data
 }
 func (pubKey PubKeyBls) VerifyBytes(msg []byte, sig []byte) bool {
+	// herumi panics deserializing an empty signature
+	if len(sig) == 0 {
+		return false
+	}
 	var herumiSign herumi.Sign
 	if err := herumiSign.Deserialize(sig); err != nil {
 		return false
@@ -0,0 +1,17 @@
+// Code generated by scripts/codecgen from its manifest. DO NOT EDIT.
+
+package bls
+
+import (
+	"github.com/hdac-io/tendermint/crypto"
+	amino "github.com/tendermint/go-amino"
+)
+
+var cdc = amino.NewCodec()
+
+func init() {
+	cdc.RegisterInterface((*crypto.PubKey)(nil), nil)
+	cdc.RegisterConcrete(PubKeyBls{}, PubKeyAminoName, nil)
+	cdc.RegisterInterface((*crypto.PrivKey)(nil), nil)
+	cdc.RegisterConcrete(PrivKeyBls{}, PrivKeyAminoName, nil)
+}
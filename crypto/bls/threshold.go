@@ -0,0 +1,101 @@
+package bls
+
+import (
+	"fmt"
+	"strconv"
+
+	herumi "github.com/hdac-io/bls-go-binary/bls"
+)
+
+// SplitPrivKey splits privKey into n shares, any threshold of which recover
+// its signatures (Shamir's secret sharing). The i-th share has the index i+1,
+// which is the one to pass to RecoverSignature and RecoverPubKey.
+func SplitPrivKey(privKey PrivKeyBls, threshold, n int) ([]PrivKeyBls, error) {
+	if threshold < 1 || threshold > n {
+		return nil, fmt.Errorf("invalid threshold %d of %d shares", threshold, n)
+	}
+	msk := privKey.GetMasterSecretKey(threshold)
+	shares := make([]PrivKeyBls, n)
+	for i := range shares {
+		id, err := shareID(i + 1)
+		if err != nil {
+			return nil, err
+		}
+		if err := shares[i].Set(msk, id); err != nil {
+			return nil, err
+		}
+	}
+	return shares, nil
+}
+
+// RecoverSignature recovers the signature of the split key from the
+// signatures of the same message by the shares of the given indexes. It needs
+// as many shares as the threshold the key was split with, and recovers a
+// wrong signature otherwise.
+func RecoverSignature(indexes []int, sigs [][]byte) ([]byte, error) {
+	if len(indexes) != len(sigs) || len(sigs) == 0 {
+		return nil, fmt.Errorf("%d indexes for %d signatures", len(indexes), len(sigs))
+	}
+	ids, err := shareIDs(indexes)
+	if err != nil {
+		return nil, err
+	}
+	sigVec := make([]herumi.Sign, len(sigs))
+	for i, sig := range sigs {
+		if err := sigVec[i].Deserialize(sig); err != nil {
+			return nil, fmt.Errorf("invalid signature of share %d: %v", indexes[i], err)
+		}
+	}
+	var recovered herumi.Sign
+	if err := recovered.Recover(sigVec, ids); err != nil {
+		return nil, err
+	}
+	return recovered.Serialize(), nil
+}
+
+// RecoverPubKey recovers the public key of the split key from the public keys
+// of the shares of the given indexes, under the same conditions as
+// RecoverSignature.
+func RecoverPubKey(indexes []int, pubKeys []PubKeyBls) (PubKeyBls, error) {
+	if len(indexes) != len(pubKeys) || len(pubKeys) == 0 {
+		return PubKeyBls{}, fmt.Errorf("%d indexes for %d public keys", len(indexes), len(pubKeys))
+	}
+	ids, err := shareIDs(indexes)
+	if err != nil {
+		return PubKeyBls{}, err
+	}
+	pubVec := make([]herumi.PublicKey, len(pubKeys))
+	for i, pubKey := range pubKeys {
+		pubVec[i] = pubKey.PublicKey
+	}
+	var recovered PubKeyBls
+	if err := recovered.Recover(pubVec, ids); err != nil {
+		return PubKeyBls{}, err
+	}
+	return recovered, nil
+}
+
+func shareIDs(indexes []int) ([]herumi.ID, error) {
+	ids := make([]herumi.ID, len(indexes))
+	for i, index := range indexes {
+		id, err := shareID(index)
+		if err != nil {
+			return nil, err
+		}
+		ids[i] = *id
+	}
+	return ids, nil
+}
+
+// shareID returns the ID of the share of the index, which can't be 0: the
+// share of ID 0 is the split key itself.
+func shareID(index int) (*herumi.ID, error) {
+	if index < 1 {
+		return nil, fmt.Errorf("invalid share index %d", index)
+	}
+	var id herumi.ID
+	if err := id.SetDecString(strconv.Itoa(index)); err != nil {
+		return nil, err
+	}
+	return &id, nil
+}
@@ -38,3 +38,15 @@ type Symmetric interface {
 	Encrypt(plaintext []byte, secret []byte) (ciphertext []byte)
 	Decrypt(ciphertext []byte, secret []byte) (plaintext []byte, err error)
 }
+
+// BatchVerifier verifies several signatures at once, with less work than
+// verifying them one by one.
+type BatchVerifier interface {
+	// Add adds the signature sig of msg by key to the batch. It returns an
+	// error if the key or the signature can't be verified by the batch.
+	Add(key PubKey, msg, sig []byte) error
+	// Verify returns true if all the signatures of the batch are valid, and
+	// whether each one is valid in the order they were added. An empty batch
+	// is not valid.
+	Verify() (bool, []bool)
+}
@@ -0,0 +1,69 @@
+package ed25519
+
+import (
+	"fmt"
+	"runtime"
+	"sync"
+
+	"github.com/hdac-io/tendermint/crypto"
+)
+
+var _ crypto.BatchVerifier = &BatchVerifier{}
+
+// BatchVerifier verifies ed25519 signatures on all the CPUs.
+type BatchVerifier struct {
+	keys []PubKeyEd25519
+	msgs [][]byte
+	sigs [][]byte
+}
+
+// NewBatchVerifier returns an empty BatchVerifier.
+func NewBatchVerifier() *BatchVerifier {
+	return &BatchVerifier{}
+}
+
+// Add implements crypto.BatchVerifier.
+func (b *BatchVerifier) Add(key crypto.PubKey, msg, sig []byte) error {
+	pubKey, ok := key.(PubKeyEd25519)
+	if !ok {
+		return fmt.Errorf("pubkey is not ed25519: %T", key)
+	}
+	if len(sig) != SignatureSize {
+		return fmt.Errorf("invalid signature size %d", len(sig))
+	}
+	b.keys = append(b.keys, pubKey)
+	b.msgs = append(b.msgs, msg)
+	b.sigs = append(b.sigs, sig)
+	return nil
+}
+
+// Verify implements crypto.BatchVerifier.
+func (b *BatchVerifier) Verify() (bool, []bool) {
+	n := len(b.keys)
+	if n == 0 {
+		return false, nil
+	}
+	valid := make([]bool, n)
+	workers := runtime.NumCPU()
+	if workers > n {
+		workers = n
+	}
+	var wg sync.WaitGroup
+	wg.Add(workers)
+	for w := 0; w < workers; w++ {
+		go func(w int) {
+			defer wg.Done()
+			for i := w; i < n; i += workers {
+				valid[i] = b.keys[i].VerifyBytes(b.msgs[i], b.sigs[i])
+			}
+		}(w)
+	}
+	wg.Wait()
+
+	for _, ok := range valid {
+		if !ok {
+			return false, valid
+		}
+	}
+	return true, valid
+}
@@ -12,8 +12,6 @@ import (
 	"github.com/hdac-io/tendermint/crypto/secp256k1"
 )
 
-var cdc = amino.NewCodec()
-
 // nameTable is used to map public key concrete types back
 // to their registered amino names. This should eventually be handled
 // by amino. Example usage:
@@ -21,14 +19,6 @@ var cdc = amino.NewCodec()
 var nameTable = make(map[reflect.Type]string, 4)
 
 func init() {
-	// NOTE: It's important that there be no conflicts here,
-	// as that would change the canonical representations,
-	// and therefore change the address.
-	// TODO: Remove above note when
-	// https://github.com/tendermint/go-amino/issues/9
-	// is resolved
-	RegisterAmino(cdc)
-
 	// TODO: Have amino provide a way to go from concrete struct to route directly.
 	// Its currently a private API
 	nameTable[reflect.TypeOf(ed25519.PubKeyEd25519{})] = ed25519.PubKeyAminoName
@@ -45,28 +35,6 @@ func PubkeyAminoName(cdc *amino.Codec, key crypto.PubKey) (string, bool) {
 	return route, found
 }
 
-// RegisterAmino registers all crypto related types in the given (amino) codec.
-func RegisterAmino(cdc *amino.Codec) {
-	// These are all written here instead of
-	cdc.RegisterInterface((*crypto.PubKey)(nil), nil)
-	cdc.RegisterConcrete(ed25519.PubKeyEd25519{},
-		ed25519.PubKeyAminoName, nil)
-	cdc.RegisterConcrete(secp256k1.PubKeySecp256k1{},
-		secp256k1.PubKeyAminoName, nil)
-	cdc.RegisterConcrete(multisig.PubKeyMultisigThreshold{},
-		multisig.PubKeyMultisigThresholdAminoRoute, nil)
-	cdc.RegisterConcrete(bls.PubKeyBls{},
-		bls.PubKeyAminoName, nil)
-
-	cdc.RegisterInterface((*crypto.PrivKey)(nil), nil)
-	cdc.RegisterConcrete(ed25519.PrivKeyEd25519{},
-		ed25519.PrivKeyAminoName, nil)
-	cdc.RegisterConcrete(secp256k1.PrivKeySecp256k1{},
-		secp256k1.PrivKeyAminoName, nil)
-	cdc.RegisterConcrete(bls.PrivKeyBls{},
-		bls.PrivKeyAminoName, nil)
-}
-
 func PrivKeyFromBytes(privKeyBytes []byte) (privKey crypto.PrivKey, err error) {
 	err = cdc.UnmarshalBinaryBare(privKeyBytes, &privKey)
 	return
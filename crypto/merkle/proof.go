@@ -2,6 +2,8 @@ package merkle
 
 import (
 	"bytes"
+	"sort"
+	"sync"
 
 	"github.com/pkg/errors"
 )
@@ -127,12 +129,66 @@ func (prt *ProofRuntime) Verify(proof *Proof, root []byte, keypath string, args
 	return poz.Verify(root, keypath, args)
 }
 
-// DefaultProofRuntime only knows about Simple value
-// proofs.
+// VerifyValue verifies with DefaultProofRuntime that the proof proves value
+// at keypath against root.
+func VerifyValue(proof *Proof, root []byte, keypath string, value []byte) error {
+	return DefaultProofRuntime().VerifyValue(proof, root, keypath, value)
+}
+
+// VerifyAbsence verifies with DefaultProofRuntime that the proof proves that
+// there is no value at keypath against root.
+func VerifyAbsence(proof *Proof, root []byte, keypath string) error {
+	return DefaultProofRuntime().VerifyAbsence(proof, root, keypath)
+}
+
+// DefaultProofRuntime knows about Simple value and range
+// proofs, and the op-decoders registered with RegisterOpDecoder.
 // To use e.g. IAVL proofs, register op-decoders as
 // defined in the IAVL package.
 func DefaultProofRuntime() (prt *ProofRuntime) {
 	prt = NewProofRuntime()
-	prt.RegisterOpDecoder(ProofOpSimpleValue, SimpleValueOpDecoder)
+	opDecodersMtx.RLock()
+	defer opDecodersMtx.RUnlock()
+	for typ, dec := range opDecoders {
+		prt.RegisterOpDecoder(typ, dec)
+	}
 	return
 }
+
+//----------------------------------------
+// Registry of the op-decoders of DefaultProofRuntime
+
+var (
+	opDecodersMtx sync.RWMutex
+	opDecoders    = map[string]OpDecoder{
+		ProofOpSimpleValue: SimpleValueOpDecoder,
+		ProofOpSimpleRange: SimpleRangeOpDecoder,
+	}
+)
+
+// RegisterOpDecoder registers the op-decoder of a custom ProofOp type, which
+// the ProofRuntimes created by DefaultProofRuntime afterwards know about.
+// Apps use it to plug their proof ops into the nodes and light clients
+// verifying the /abci_query proofs. It panics if the type is already
+// registered.
+func RegisterOpDecoder(typ string, dec OpDecoder) {
+	opDecodersMtx.Lock()
+	defer opDecodersMtx.Unlock()
+	if _, ok := opDecoders[typ]; ok {
+		panic("already registered for type " + typ)
+	}
+	opDecoders[typ] = dec
+}
+
+// RegisteredOpTypes returns the sorted ProofOp types registered with
+// RegisterOpDecoder, the Simple ones included.
+func RegisteredOpTypes() []string {
+	opDecodersMtx.RLock()
+	defer opDecodersMtx.RUnlock()
+	types := make([]string, 0, len(opDecoders))
+	for typ := range opDecoders {
+		types = append(types, typ)
+	}
+	sort.Strings(types)
+	return types
+}
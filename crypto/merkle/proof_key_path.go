@@ -83,6 +83,16 @@ func (pth KeyPath) String() string {
 	return res
 }
 
+// KeysToKeyPath returns the path of the keys, URL-encoded, the outermost
+// first. It is the inverse of KeyPathToKeys.
+func KeysToKeyPath(keys ...[]byte) string {
+	kp := make(KeyPath, 0, len(keys))
+	for _, key := range keys {
+		kp = kp.AppendKey(key, KeyEncodingURL)
+	}
+	return kp.String()
+}
+
 // Decode a path to a list of keys. Path must begin with `/`.
 // Each key must use a known encoding.
 func KeyPathToKeys(path string) (keys [][]byte, err error) {
@@ -41,3 +41,11 @@ func TestKeyPath(t *testing.T) {
 		}
 	}
 }
+
+func TestKeysToKeyPath(t *testing.T) {
+	keys := [][]byte{[]byte("store"), []byte("a/b c"), {0x00, 0xff}}
+	res, err := KeyPathToKeys(KeysToKeyPath(keys...))
+	require.NoError(t, err)
+	require.Equal(t, keys, res)
+	require.Equal(t, "/store", KeysToKeyPath([]byte("store")))
+}
@@ -0,0 +1,98 @@
+package merkle
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/pkg/errors"
+
+	"github.com/hdac-io/tendermint/crypto/tmhash"
+)
+
+const ProofOpSimpleRange = "simple:r"
+
+// SimpleRangeOp takes the values of a range of contiguous keys as argument
+// and produces the root hash, like SimpleValueOp does for a single key. The
+// corresponding tree structure is the SimpleMap tree. A query iterating over
+// keys is thus proven by one SimpleRangeOp instead of one SimpleValueOp per
+// key.
+//
+// As the keys are many, they are not part of the key path: GetKey returns
+// nil, and the verifier must check Keys against the keys it queried.
+//
+// If the produced root hash matches the expected hash, the
+// proof is good.
+type SimpleRangeOp struct {
+	// To encode in ProofOp.Data
+	Keys  [][]byte          `json:"keys"`
+	Proof *SimpleRangeProof `json:"simple_range_proof"`
+}
+
+var _ ProofOperator = SimpleRangeOp{}
+
+func NewSimpleRangeOp(keys [][]byte, proof *SimpleRangeProof) SimpleRangeOp {
+	return SimpleRangeOp{
+		Keys:  keys,
+		Proof: proof,
+	}
+}
+
+func SimpleRangeOpDecoder(pop ProofOp) (ProofOperator, error) {
+	if pop.Type != ProofOpSimpleRange {
+		return nil, errors.Errorf("unexpected ProofOp.Type; got %v, want %v", pop.Type, ProofOpSimpleRange)
+	}
+	var op SimpleRangeOp
+	err := cdc.UnmarshalBinaryLengthPrefixed(pop.Data, &op)
+	if err != nil {
+		return nil, errors.Wrap(err, "decoding ProofOp.Data into SimpleRangeOp")
+	}
+	if op.Proof == nil {
+		return nil, errors.New("SimpleRangeOp has no proof")
+	}
+	return op, nil
+}
+
+func (op SimpleRangeOp) ProofOp() ProofOp {
+	bz := cdc.MustMarshalBinaryLengthPrefixed(op)
+	return ProofOp{
+		Type: ProofOpSimpleRange,
+		Data: bz,
+	}
+}
+
+func (op SimpleRangeOp) String() string {
+	return fmt.Sprintf("SimpleRangeOp{%d keys}", len(op.Keys))
+}
+
+func (op SimpleRangeOp) Run(args [][]byte) ([][]byte, error) {
+	if len(args) != len(op.Keys) {
+		return nil, errors.Errorf("expected %v args, got %v", len(op.Keys), len(args))
+	}
+	if len(args) != len(op.Proof.LeafHashes) {
+		return nil, errors.Errorf("expected %v leaf hashes, got %v", len(args), len(op.Proof.LeafHashes))
+	}
+	for i, value := range args {
+		vhash := tmhash.Sum(value)
+
+		bz := new(bytes.Buffer)
+		// Wrap <op.Keys[i], vhash> to hash the KVPair.
+		encodeByteSlice(bz, op.Keys[i]) // does not error
+		encodeByteSlice(bz, vhash)      // does not error
+		kvhash := leafHash(bz.Bytes())
+
+		if !bytes.Equal(kvhash, op.Proof.LeafHashes[i]) {
+			return nil, errors.Errorf("leaf hash #%d mismatch: want %X got %X", i, op.Proof.LeafHashes[i], kvhash)
+		}
+	}
+
+	rootHash := op.Proof.ComputeRootHash()
+	if rootHash == nil {
+		return nil, errors.New("malformed range proof")
+	}
+	return [][]byte{rootHash}, nil
+}
+
+// GetKey returns nil, see SimpleRangeOp.
+func (op SimpleRangeOp) GetKey() []byte {
+	return nil
+}
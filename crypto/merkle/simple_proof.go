@@ -88,6 +88,33 @@ func (sp *SimpleProof) Verify(rootHash []byte, leaf []byte) error {
 	return nil
 }
 
+// VerifySimpleProofs verifies in a batch that proofs[i] proves items[i] in the
+// tree of rootHash, like the proofs of SimpleProofsFromByteSlices. The proofs
+// must be of the same tree size and of distinct indexes; the error of an
+// invalid proof tells its position.
+func VerifySimpleProofs(rootHash []byte, proofs []*SimpleProof, items [][]byte) error {
+	if len(proofs) != len(items) {
+		return errors.Errorf("expected a proof per item, got %d proofs for %d items", len(proofs), len(items))
+	}
+	indexes := make(map[int]bool, len(proofs))
+	for i, sp := range proofs {
+		if sp == nil {
+			return errors.Errorf("proof #%d is nil", i)
+		}
+		if sp.Total != proofs[0].Total {
+			return errors.Errorf("proof #%d has a total of %d, proof #0 of %d", i, sp.Total, proofs[0].Total)
+		}
+		if indexes[sp.Index] {
+			return errors.Errorf("proof #%d has the index %d of a previous proof", i, sp.Index)
+		}
+		indexes[sp.Index] = true
+		if err := sp.Verify(rootHash, items[i]); err != nil {
+			return errors.Wrapf(err, "proof #%d", i)
+		}
+	}
+	return nil
+}
+
 // Compute the root hash given a leaf hash.  Does not verify the result.
 func (sp *SimpleProof) ComputeRootHash() []byte {
 	return computeHashFromAunts(
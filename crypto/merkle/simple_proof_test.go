@@ -4,6 +4,7 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestSimpleProofValidateBasic(t *testing.T) {
@@ -36,3 +37,24 @@ func TestSimpleProofValidateBasic(t *testing.T) {
 		})
 	}
 }
+
+func TestVerifySimpleProofs(t *testing.T) {
+	items := [][]byte{[]byte("a"), []byte("b"), []byte("c"), []byte("d"), []byte("e")}
+	rootHash, proofs := SimpleProofsFromByteSlices(items)
+	assert.NoError(t, VerifySimpleProofs(rootHash, proofs, items))
+	// a subset, in any order
+	assert.NoError(t, VerifySimpleProofs(rootHash, []*SimpleProof{proofs[3], proofs[1]}, [][]byte{items[3], items[1]}))
+	assert.NoError(t, VerifySimpleProofs(rootHash, nil, nil))
+
+	err := VerifySimpleProofs(rootHash, []*SimpleProof{proofs[0], proofs[1]}, [][]byte{items[0], items[2]})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "proof #1")
+	assert.Error(t, VerifySimpleProofs([]byte("wrong"), proofs, items))
+	assert.Error(t, VerifySimpleProofs(rootHash, proofs, items[1:]))
+	assert.Error(t, VerifySimpleProofs(rootHash, []*SimpleProof{proofs[0], nil}, items[:2]))
+	assert.Error(t, VerifySimpleProofs(rootHash, []*SimpleProof{proofs[2], proofs[2]}, [][]byte{items[2], items[2]}))
+
+	// the proofs of another tree size
+	_, others := SimpleProofsFromByteSlices(items[:4])
+	assert.Error(t, VerifySimpleProofs(rootHash, []*SimpleProof{proofs[0], others[1]}, items[:2]))
+}
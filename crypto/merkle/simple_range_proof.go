@@ -0,0 +1,200 @@
+package merkle
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+
+	"github.com/pkg/errors"
+
+	"github.com/hdac-io/tendermint/crypto/tmhash"
+)
+
+// SimpleRangeProof represents a simple Merkle proof of a range of contiguous
+// items, e.g. the key-value pairs of an iteration over a SimpleMap. Only the
+// hashes of the subtrees outside the range are included, the ones inside are
+// computed from the items, so it is smaller than a SimpleProof per item.
+type SimpleRangeProof struct {
+	Total      int      `json:"total"`       // Total number of items.
+	Index      int      `json:"index"`       // Index of the first item to prove.
+	LeafHashes [][]byte `json:"leaf_hashes"` // Hashes of the items to prove.
+	Aunts      [][]byte `json:"aunts"`       // Hashes of the subtrees outside the range, from left to right.
+}
+
+// SimpleRangeProofFromByteSlices computes the range proof of items[start:end].
+// It panics if the range is empty or out of bounds.
+func SimpleRangeProofFromByteSlices(items [][]byte, start, end int) (rootHash []byte, proof *SimpleRangeProof) {
+	if start < 0 || end > len(items) || start >= end {
+		panic(fmt.Sprintf("invalid range [%d, %d) of %d items", start, end, len(items)))
+	}
+	proof = &SimpleRangeProof{
+		Total:      len(items),
+		Index:      start,
+		LeafHashes: make([][]byte, 0, end-start),
+		Aunts:      [][]byte{},
+	}
+	for _, item := range items[start:end] {
+		proof.LeafHashes = append(proof.LeafHashes, leafHash(item))
+	}
+	rootHash = rangeAunts(items, 0, start, end, &proof.Aunts)
+	return
+}
+
+// SimpleRangeProofFromMap computes the range proof of the key-value pairs of
+// the map whose keys are in [start, end), with the root hash of the map as
+// computed by SimpleProofsFromMap, and returns their keys sorted. An empty end
+// means no upper bound. The proof is nil if no key is in the range.
+func SimpleRangeProofFromMap(m map[string][]byte, start, end string) (
+	rootHash []byte, proof *SimpleRangeProof, keys []string) {
+
+	sm := newSimpleMap()
+	for k, v := range m {
+		sm.Set(k, v)
+	}
+	sm.Sort()
+	kvs := sm.kvs
+	kvsBytes := make([][]byte, len(kvs))
+	for i, kvp := range kvs {
+		kvsBytes[i] = KVPair(kvp).Bytes()
+	}
+
+	first := sort.Search(len(kvs), func(i int) bool { return string(kvs[i].Key) >= start })
+	last := len(kvs)
+	if end != "" {
+		last = sort.Search(len(kvs), func(i int) bool { return string(kvs[i].Key) >= end })
+	}
+	if first >= last {
+		return SimpleHashFromByteSlices(kvsBytes), nil, nil
+	}
+
+	rootHash, proof = SimpleRangeProofFromByteSlices(kvsBytes, first, last)
+	keys = make([]string, 0, last-first)
+	for _, kvp := range kvs[first:last] {
+		keys = append(keys, string(kvp.Key))
+	}
+	return
+}
+
+// rangeAunts returns the hash of the subtree of items, whose first item has
+// the given offset, and appends the hashes of its subtrees outside
+// [start, end) to aunts.
+func rangeAunts(items [][]byte, offset, start, end int, aunts *[][]byte) []byte {
+	switch {
+	case offset >= end || offset+len(items) <= start:
+		hash := SimpleHashFromByteSlices(items)
+		*aunts = append(*aunts, hash)
+		return hash
+	case len(items) == 1:
+		return leafHash(items[0])
+	default:
+		k := getSplitPoint(len(items))
+		left := rangeAunts(items[:k], offset, start, end, aunts)
+		right := rangeAunts(items[k:], offset+k, start, end, aunts)
+		return innerHash(left, right)
+	}
+}
+
+// Verify that the SimpleRangeProof proves the root hash of the leaves.
+func (rp *SimpleRangeProof) Verify(rootHash []byte, leaves [][]byte) error {
+	if rp.Total < 0 {
+		return errors.New("Proof total must be positive")
+	}
+	if rp.Index < 0 {
+		return errors.New("Proof index cannot be negative")
+	}
+	if len(leaves) != len(rp.LeafHashes) {
+		return errors.Errorf("expected %d leaves, got %d", len(rp.LeafHashes), len(leaves))
+	}
+	for i, leaf := range leaves {
+		if hash := leafHash(leaf); !bytes.Equal(rp.LeafHashes[i], hash) {
+			return errors.Errorf("invalid leaf hash #%d: wanted %X got %X", i, hash, rp.LeafHashes[i])
+		}
+	}
+	computedHash := rp.ComputeRootHash()
+	if !bytes.Equal(computedHash, rootHash) {
+		return errors.Errorf("invalid root hash: wanted %X got %X", rootHash, computedHash)
+	}
+	return nil
+}
+
+// Compute the root hash given the leaf hashes. Does not verify the result.
+// If the proof is malformed, the result is nil.
+func (rp *SimpleRangeProof) ComputeRootHash() []byte {
+	if rp.Total <= 0 || rp.Index < 0 || len(rp.LeafHashes) == 0 || rp.Index+len(rp.LeafHashes) > rp.Total {
+		return nil
+	}
+	aunts := rp.Aunts
+	hash := computeHashFromRange(0, rp.Total, rp.Index, rp.LeafHashes, &aunts)
+	if len(aunts) != 0 {
+		return nil
+	}
+	return hash
+}
+
+// ValidateBasic performs basic validation.
+// NOTE: it expects LeafHashes and Aunts of tmhash.Size size
+func (rp *SimpleRangeProof) ValidateBasic() error {
+	if rp.Total < 0 {
+		return errors.New("negative Total")
+	}
+	if rp.Index < 0 {
+		return errors.New("negative Index")
+	}
+	if len(rp.LeafHashes) == 0 {
+		return errors.New("no LeafHashes")
+	}
+	if rp.Index+len(rp.LeafHashes) > rp.Total {
+		return errors.Errorf("range [%d, %d) exceeds Total %d", rp.Index, rp.Index+len(rp.LeafHashes), rp.Total)
+	}
+	for i, hash := range rp.LeafHashes {
+		if len(hash) != tmhash.Size {
+			return errors.Errorf("expected LeafHashes#%d size to be %d, got %d", i, tmhash.Size, len(hash))
+		}
+	}
+	// at most one aunt per level on each side of the range
+	if len(rp.Aunts) > 2*maxAunts {
+		return errors.Errorf("expected no more than %d aunts, got %d", 2*maxAunts, len(rp.Aunts))
+	}
+	for i, auntHash := range rp.Aunts {
+		if len(auntHash) != tmhash.Size {
+			return errors.Errorf("expected Aunts#%d size to be %d, got %d", i, tmhash.Size, len(auntHash))
+		}
+	}
+	return nil
+}
+
+// String implements the stringer interface for SimpleRangeProof.
+func (rp *SimpleRangeProof) String() string {
+	return fmt.Sprintf("SimpleRangeProof{%d..%d/%d Aunts: %X}",
+		rp.Index, rp.Index+len(rp.LeafHashes), rp.Total, rp.Aunts)
+}
+
+// Use the leafHashes of [start, start+len(leafHashes)) and the aunts to get
+// the hash of the subtree of total items beginning at offset, consuming the
+// aunts. If there aren't enough aunts, the result is nil.
+// Recursive impl.
+func computeHashFromRange(offset, total, start int, leafHashes [][]byte, aunts *[][]byte) []byte {
+	end := start + len(leafHashes)
+	switch {
+	case offset >= end || offset+total <= start:
+		if len(*aunts) == 0 {
+			return nil
+		}
+		hash := (*aunts)[0]
+		*aunts = (*aunts)[1:]
+		return hash
+	case total == 1:
+		return leafHashes[offset-start]
+	default:
+		k := getSplitPoint(total)
+		left := computeHashFromRange(offset, k, start, leafHashes, aunts)
+		if left == nil {
+			return nil
+		}
+		right := computeHashFromRange(offset+k, total-k, start, leafHashes, aunts)
+		if right == nil {
+			return nil
+		}
+		return innerHash(left, right)
+	}
+}
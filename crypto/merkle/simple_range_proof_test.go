@@ -0,0 +1,137 @@
+package merkle
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hdac-io/tendermint/crypto/tmhash"
+	cmn "github.com/hdac-io/tendermint/libs/common"
+)
+
+func TestSimpleRangeProof(t *testing.T) {
+	for total := 1; total <= 20; total++ {
+		items := make([][]byte, total)
+		for i := range items {
+			items[i] = cmn.RandBytes(tmhash.Size)
+		}
+		rootHash := SimpleHashFromByteSlices(items)
+
+		for start := 0; start < total; start++ {
+			for end := start + 1; end <= total; end++ {
+				root, proof := SimpleRangeProofFromByteSlices(items, start, end)
+				require.Equal(t, rootHash, root, "total %d range [%d, %d)", total, start, end)
+				require.NoError(t, proof.ValidateBasic())
+				require.NoError(t, proof.Verify(rootHash, items[start:end]),
+					"total %d range [%d, %d)", total, start, end)
+
+				// wrong leaves
+				leaves := append([][]byte{}, items[start:end]...)
+				leaves[0] = cmn.RandBytes(tmhash.Size)
+				assert.Error(t, proof.Verify(rootHash, leaves))
+				if end-start > 1 {
+					assert.Error(t, proof.Verify(rootHash, items[start:end-1]))
+				}
+
+				// wrong position
+				proof.Index++
+				assert.Error(t, proof.Verify(rootHash, items[start:end]))
+				proof.Index--
+
+				// missing or extra aunts
+				if len(proof.Aunts) > 0 {
+					aunts := proof.Aunts
+					proof.Aunts = aunts[:len(aunts)-1]
+					assert.Nil(t, proof.ComputeRootHash())
+					proof.Aunts = append(append([][]byte{}, aunts...), aunts[0])
+					assert.Nil(t, proof.ComputeRootHash())
+					proof.Aunts = aunts
+				}
+			}
+		}
+	}
+
+	assert.Panics(t, func() { SimpleRangeProofFromByteSlices([][]byte{{1}}, 0, 0) })
+	assert.Panics(t, func() { SimpleRangeProofFromByteSlices([][]byte{{1}}, 0, 2) })
+}
+
+func TestSimpleRangeOp(t *testing.T) {
+	m := make(map[string][]byte)
+	for i := 0; i < 10; i++ {
+		m[fmt.Sprintf("key%d", i)] = []byte(fmt.Sprintf("value%d", i))
+	}
+	rootHash, _, _ := SimpleProofsFromMap(m)
+
+	root, proof, keys := SimpleRangeProofFromMap(m, "key3", "key7")
+	require.Equal(t, rootHash, root)
+	require.Equal(t, []string{"key3", "key4", "key5", "key6"}, keys)
+
+	var (
+		bkeys  [][]byte
+		values [][]byte
+	)
+	for _, key := range keys {
+		bkeys = append(bkeys, []byte(key))
+		values = append(values, m[key])
+	}
+	op := NewSimpleRangeOp(bkeys, proof)
+
+	// the op is decoded by the default runtime
+	prt := DefaultProofRuntime()
+	pop := op.ProofOp()
+	decoded, err := prt.Decode(pop)
+	require.NoError(t, err)
+	assert.Equal(t, op, decoded)
+
+	// the range is chained under a store op, as the keys of a range are not
+	// part of the key path
+	store := NewDominoOp("store", string(rootHash), "APPHASH")
+	verify := func(op ProofOperator, values [][]byte) error {
+		return ProofOperators{op, store}.Verify([]byte("APPHASH"), "/store", values)
+	}
+	assert.NoError(t, verify(decoded, values))
+
+	// wrong values
+	wrong := append([][]byte{}, values...)
+	wrong[1] = []byte("wrong")
+	assert.Error(t, verify(decoded, wrong))
+	assert.Error(t, verify(decoded, values[1:]))
+
+	// wrong keys
+	op.Keys[0] = []byte("key2")
+	assert.Error(t, verify(op, values))
+
+	// no upper bound, and empty range
+	_, proof, keys = SimpleRangeProofFromMap(m, "key8", "")
+	assert.Equal(t, []string{"key8", "key9"}, keys)
+	assert.NotNil(t, proof)
+	root, proof, keys = SimpleRangeProofFromMap(m, "key7", "key7")
+	assert.Equal(t, rootHash, root)
+	assert.Nil(t, proof)
+	assert.Nil(t, keys)
+}
+
+func TestRegisterOpDecoder(t *testing.T) {
+	assert.Equal(t, []string{ProofOpSimpleRange, ProofOpSimpleValue}, RegisteredOpTypes())
+
+	pop := NewDominoOp("KEY", "INPUT", "OUTPUT").ProofOp()
+	_, err := DefaultProofRuntime().Decode(pop)
+	assert.Error(t, err)
+
+	RegisterOpDecoder(ProofOpDomino, DominoOpDecoder)
+	defer func() {
+		opDecodersMtx.Lock()
+		delete(opDecoders, ProofOpDomino)
+		opDecodersMtx.Unlock()
+	}()
+	assert.Equal(t, []string{ProofOpSimpleRange, ProofOpSimpleValue, ProofOpDomino}, RegisteredOpTypes())
+
+	op, err := DefaultProofRuntime().Decode(pop)
+	require.NoError(t, err)
+	assert.Equal(t, NewDominoOp("KEY", "INPUT", "OUTPUT"), op)
+
+	assert.Panics(t, func() { RegisterOpDecoder(ProofOpDomino, DominoOpDecoder) })
+	assert.Panics(t, func() { RegisterOpDecoder(ProofOpSimpleValue, SimpleValueOpDecoder) })
+}
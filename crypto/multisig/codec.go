@@ -1,26 +1,7 @@
 package multisig
 
-import (
-	amino "github.com/tendermint/go-amino"
-	"github.com/hdac-io/tendermint/crypto"
-	"github.com/hdac-io/tendermint/crypto/ed25519"
-	"github.com/hdac-io/tendermint/crypto/secp256k1"
-)
-
 // TODO: Figure out API for others to either add their own pubkey types, or
 // to make verify / marshal accept a cdc.
 const (
 	PubKeyMultisigThresholdAminoRoute = "tendermint/PubKeyMultisigThreshold"
 )
-
-var cdc = amino.NewCodec()
-
-func init() {
-	cdc.RegisterInterface((*crypto.PubKey)(nil), nil)
-	cdc.RegisterConcrete(PubKeyMultisigThreshold{},
-		PubKeyMultisigThresholdAminoRoute, nil)
-	cdc.RegisterConcrete(ed25519.PubKeyEd25519{},
-		ed25519.PubKeyAminoName, nil)
-	cdc.RegisterConcrete(secp256k1.PubKeySecp256k1{},
-		secp256k1.PubKeyAminoName, nil)
-}
@@ -12,8 +12,6 @@ import (
 
 	secp256k1 "github.com/btcsuite/btcd/btcec"
 
-	amino "github.com/tendermint/go-amino"
-
 	"github.com/hdac-io/tendermint/crypto"
 )
 
@@ -23,18 +21,6 @@ const (
 	PubKeyAminoName  = "tendermint/PubKeySecp256k1"
 )
 
-var cdc = amino.NewCodec()
-
-func init() {
-	cdc.RegisterInterface((*crypto.PubKey)(nil), nil)
-	cdc.RegisterConcrete(PubKeySecp256k1{},
-		PubKeyAminoName, nil)
-
-	cdc.RegisterInterface((*crypto.PrivKey)(nil), nil)
-	cdc.RegisterConcrete(PrivKeySecp256k1{},
-		PrivKeyAminoName, nil)
-}
-
 //-------------------------------------
 
 var _ crypto.PrivKey = PrivKeySecp256k1{}
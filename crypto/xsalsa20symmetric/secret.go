@@ -0,0 +1,44 @@
+package xsalsa20symmetric
+
+import (
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"strings"
+)
+
+// LoadSecret loads a 32 byte secret from source, which is either
+// "env:NAME" to read it from the environment variable NAME, or "file:PATH"
+// to read it from a file (e.g. one provisioned by a KMS agent).
+// The secret must be hex or base64 encoded.
+func LoadSecret(source string) ([]byte, error) {
+	var encoded string
+	switch {
+	case strings.HasPrefix(source, "env:"):
+		name := strings.TrimPrefix(source, "env:")
+		value, ok := os.LookupEnv(name)
+		if !ok {
+			return nil, fmt.Errorf("environment variable %s is not set", name)
+		}
+		encoded = value
+	case strings.HasPrefix(source, "file:"):
+		bz, err := ioutil.ReadFile(strings.TrimPrefix(source, "file:"))
+		if err != nil {
+			return nil, err
+		}
+		encoded = string(bz)
+	default:
+		return nil, fmt.Errorf("unknown secret source %q, expected env:NAME or file:PATH", source)
+	}
+
+	encoded = strings.TrimSpace(encoded)
+	if secret, err := hex.DecodeString(encoded); err == nil && len(secret) == secretLen {
+		return secret, nil
+	}
+	if secret, err := base64.StdEncoding.DecodeString(encoded); err == nil && len(secret) == secretLen {
+		return secret, nil
+	}
+	return nil, fmt.Errorf("secret from %s must be %d bytes, hex or base64 encoded", source, secretLen)
+}
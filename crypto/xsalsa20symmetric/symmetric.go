@@ -9,11 +9,29 @@ import (
 	"github.com/hdac-io/tendermint/crypto"
 )
 
-// TODO, make this into a struct that implements crypto.Symmetric.
-
 const nonceLen = 24
 const secretLen = 32
 
+// Symmetric implements crypto.Symmetric with EncryptSymmetric and DecryptSymmetric.
+type Symmetric struct{}
+
+var _ crypto.Symmetric = Symmetric{}
+
+// Keygen returns a new random 32 byte secret.
+func (Symmetric) Keygen() []byte {
+	return crypto.CRandBytes(secretLen)
+}
+
+// Encrypt calls EncryptSymmetric.
+func (Symmetric) Encrypt(plaintext []byte, secret []byte) (ciphertext []byte) {
+	return EncryptSymmetric(plaintext, secret)
+}
+
+// Decrypt calls DecryptSymmetric.
+func (Symmetric) Decrypt(ciphertext []byte, secret []byte) (plaintext []byte, err error) {
+	return DecryptSymmetric(ciphertext, secret)
+}
+
 // secret must be 32 bytes long. Use something like Sha256(Bcrypt(passphrase))
 // The ciphertext is (secretbox.Overhead + 24) bytes longer than the plaintext.
 func EncryptSymmetric(plaintext []byte, secret []byte) (ciphertext []byte) {
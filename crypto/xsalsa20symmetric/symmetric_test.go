@@ -1,6 +1,10 @@
 package xsalsa20symmetric
 
 import (
+	"encoding/base64"
+	"encoding/hex"
+	"io/ioutil"
+	"os"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -38,3 +42,49 @@ func TestSimpleWithKDF(t *testing.T) {
 	require.Nil(t, err, "%+v", err)
 	assert.Equal(t, plaintext, plaintext2)
 }
+
+func TestSymmetric(t *testing.T) {
+	var sym crypto.Symmetric = Symmetric{}
+
+	plaintext := []byte("sometext")
+	secret := sym.Keygen()
+	ciphertext := sym.Encrypt(plaintext, secret)
+	plaintext2, err := sym.Decrypt(ciphertext, secret)
+
+	require.Nil(t, err, "%+v", err)
+	assert.Equal(t, plaintext, plaintext2)
+
+	_, err = sym.Decrypt(ciphertext, sym.Keygen())
+	assert.Error(t, err)
+}
+
+func TestLoadSecret(t *testing.T) {
+	secret := []byte("somesecretoflengththirtytwo===32")
+
+	os.Setenv("TM_TEST_SECRET_HEX", hex.EncodeToString(secret))
+	defer os.Unsetenv("TM_TEST_SECRET_HEX")
+	loaded, err := LoadSecret("env:TM_TEST_SECRET_HEX")
+	require.Nil(t, err, "%+v", err)
+	assert.Equal(t, secret, loaded)
+
+	file, err := ioutil.TempFile("", "secret")
+	require.Nil(t, err)
+	defer os.Remove(file.Name())
+	_, err = file.WriteString(base64.StdEncoding.EncodeToString(secret) + "\n")
+	require.Nil(t, err)
+	file.Close()
+	loaded, err = LoadSecret("file:" + file.Name())
+	require.Nil(t, err, "%+v", err)
+	assert.Equal(t, secret, loaded)
+
+	os.Setenv("TM_TEST_SECRET_SHORT", "0123abcd")
+	defer os.Unsetenv("TM_TEST_SECRET_SHORT")
+	_, err = LoadSecret("env:TM_TEST_SECRET_SHORT")
+	assert.Error(t, err)
+
+	_, err = LoadSecret("env:TM_TEST_SECRET_NOT_SET")
+	assert.Error(t, err)
+
+	_, err = LoadSecret(hex.EncodeToString(secret))
+	assert.Error(t, err)
+}
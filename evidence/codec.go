@@ -1,19 +1,9 @@
 package evidence
 
 import (
-	amino "github.com/tendermint/go-amino"
-	cryptoAmino "github.com/hdac-io/tendermint/crypto/encoding/amino"
 	"github.com/hdac-io/tendermint/types"
 )
 
-var cdc = amino.NewCodec()
-
-func init() {
-	RegisterEvidenceMessages(cdc)
-	cryptoAmino.RegisterAmino(cdc)
-	types.RegisterEvidences(cdc)
-}
-
 // For testing purposes only
 func RegisterMockEvidences() {
 	types.RegisterMockEvidences(cdc)
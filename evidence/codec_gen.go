@@ -0,0 +1,23 @@
+// Code generated by scripts/codecgen from its manifest. DO NOT EDIT.
+
+package evidence
+
+import (
+	cryptoAmino "github.com/hdac-io/tendermint/crypto/encoding/amino"
+	"github.com/hdac-io/tendermint/types"
+	amino "github.com/tendermint/go-amino"
+)
+
+var cdc = amino.NewCodec()
+
+func init() {
+	RegisterEvidenceMessages(cdc)
+	cryptoAmino.RegisterAmino(cdc)
+	types.RegisterEvidences(cdc)
+}
+
+// RegisterEvidenceMessages registers the messages of the evidence reactor.
+func RegisterEvidenceMessages(cdc *amino.Codec) {
+	cdc.RegisterInterface((*EvidenceMessage)(nil), nil)
+	cdc.RegisterConcrete(&EvidenceListMessage{}, "tendermint/evidence/EvidenceListMessage", nil)
+}
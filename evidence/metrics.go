@@ -0,0 +1,54 @@
+package evidence
+
+import (
+	"github.com/go-kit/kit/metrics"
+	"github.com/go-kit/kit/metrics/discard"
+	"github.com/go-kit/kit/metrics/prometheus"
+	stdprometheus "github.com/prometheus/client_golang/prometheus"
+)
+
+const (
+	// MetricsSubsystem is a subsystem shared by all metrics exposed by this
+	// package.
+	MetricsSubsystem = "evidence"
+)
+
+// Metrics contains metrics exposed by this package.
+type Metrics struct {
+	// Number of pieces of evidence pruned from the store.
+	PrunedEvidence metrics.Counter
+	// Lowest height of the evidence kept in the store.
+	RetainHeight metrics.Gauge
+}
+
+// PrometheusMetrics returns Metrics build using Prometheus client library.
+// Optionally, labels can be provided along with their values ("foo",
+// "fooValue").
+func PrometheusMetrics(namespace string, labelsAndValues ...string) *Metrics {
+	labels := []string{}
+	for i := 0; i < len(labelsAndValues); i += 2 {
+		labels = append(labels, labelsAndValues[i])
+	}
+	return &Metrics{
+		PrunedEvidence: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "pruned_evidence",
+			Help:      "Number of pieces of evidence pruned after MaxAge.",
+		}, labels).With(labelsAndValues...),
+		RetainHeight: prometheus.NewGaugeFrom(stdprometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "retain_height",
+			Help:      "Lowest height of the evidence kept in the store.",
+		}, labels).With(labelsAndValues...),
+	}
+}
+
+// NopMetrics returns no-op Metrics.
+func NopMetrics() *Metrics {
+	return &Metrics{
+		PrunedEvidence: discard.NewCounter(),
+		RetainHeight:   discard.NewGauge(),
+	}
+}
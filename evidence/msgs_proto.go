@@ -0,0 +1,68 @@
+package evidence
+
+import (
+	"fmt"
+
+	"github.com/hdac-io/tendermint/p2p"
+	"github.com/hdac-io/tendermint/types"
+	"github.com/hdac-io/tendermint/types/msgs"
+)
+
+// encodeMsg encodes msg for peer, with protobuf if the peer uses it, see
+// p2p.Switch.ProtoMsgs, else amino.
+func (evR *EvidenceReactor) encodeMsg(peer p2p.Peer, msg EvidenceMessage) []byte {
+	if !evR.Switch.ProtoMsgs(peer) {
+		return cdc.MustMarshalBinaryBare(msg)
+	}
+	bz, err := msgToProto(msg).Marshal()
+	if err != nil {
+		panic(err)
+	}
+	return bz
+}
+
+// decodePeerMsg decodes a message encoded with protobuf if proto is true,
+// else amino.
+func decodePeerMsg(bz []byte, proto bool) (EvidenceMessage, error) {
+	if !proto {
+		return decodeMsg(bz)
+	}
+	if len(bz) > maxMsgSize {
+		return nil, fmt.Errorf("Msg exceeds max size (%d > %d)", len(bz), maxMsgSize)
+	}
+	var pb msgs.EvidenceMessage
+	if err := pb.Unmarshal(bz); err != nil {
+		return nil, err
+	}
+	return msgFromProto(&pb)
+}
+
+func msgToProto(msg EvidenceMessage) *msgs.EvidenceMessage {
+	pb := &msgs.EvidenceMessage{}
+	switch msg := msg.(type) {
+	case *EvidenceListMessage:
+		list := &msgs.EvidenceListMessage{Evidence: make([][]byte, len(msg.Evidence))}
+		for i, ev := range msg.Evidence {
+			list.Evidence[i] = cdc.MustMarshalBinaryBare(ev)
+		}
+		pb.Sum = &msgs.EvidenceMessage_EvidenceList{EvidenceList: list}
+	default:
+		panic(fmt.Sprintf("Unknown message type %T", msg))
+	}
+	return pb
+}
+
+func msgFromProto(pb *msgs.EvidenceMessage) (EvidenceMessage, error) {
+	switch sum := pb.Sum.(type) {
+	case *msgs.EvidenceMessage_EvidenceList:
+		msg := &EvidenceListMessage{Evidence: make([]types.Evidence, len(sum.EvidenceList.Evidence))}
+		for i, bz := range sum.EvidenceList.Evidence {
+			if err := cdc.UnmarshalBinaryBare(bz, &msg.Evidence[i]); err != nil {
+				return nil, err
+			}
+		}
+		return msg, nil
+	default:
+		return nil, fmt.Errorf("unknown message %T", pb.Sum)
+	}
+}
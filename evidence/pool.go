@@ -3,6 +3,7 @@ package evidence
 import (
 	"fmt"
 	"sync"
+	"time"
 
 	clist "github.com/hdac-io/tendermint/libs/clist"
 	"github.com/hdac-io/tendermint/libs/log"
@@ -22,24 +23,42 @@ type EvidencePool struct {
 
 	// needed to load validators to verify evidence
 	stateDB dbm.DB
+	// needed to load the times of the blocks to age evidence
+	blockStore sm.BlockStore
 
 	// latest state
 	mtx   sync.Mutex
 	state sm.State
+
+	metrics *Metrics
 }
 
-func NewEvidencePool(stateDB, evidenceDB dbm.DB) *EvidencePool {
+// EvidencePoolOption sets an optional parameter on the EvidencePool.
+type EvidencePoolOption func(*EvidencePool)
+
+func NewEvidencePool(stateDB, evidenceDB dbm.DB, blockStore sm.BlockStore,
+	options ...EvidencePoolOption) *EvidencePool {
 	evidenceStore := NewEvidenceStore(evidenceDB)
 	evpool := &EvidencePool{
 		stateDB:       stateDB,
+		blockStore:    blockStore,
 		state:         sm.LoadState(stateDB),
 		logger:        log.NewNopLogger(),
 		evidenceStore: evidenceStore,
 		evidenceList:  clist.New(),
+		metrics:       NopMetrics(),
+	}
+	for _, option := range options {
+		option(evpool)
 	}
 	return evpool
 }
 
+// WithMetrics sets the metrics.
+func WithMetrics(metrics *Metrics) EvidencePoolOption {
+	return func(evpool *EvidencePool) { evpool.metrics = metrics }
+}
+
 func (evpool *EvidencePool) EvidenceFront() *clist.CElement {
 	return evpool.evidenceList.Front()
 }
@@ -58,10 +77,23 @@ func (evpool *EvidencePool) PriorityEvidence() []types.Evidence {
 	return evpool.evidenceStore.PriorityEvidence()
 }
 
-// PendingEvidence returns up to maxNum uncommitted evidence.
+// PendingEvidence returns up to maxNum uncommitted evidence, skipping the
+// evidence expired at the last block of the state (see
+// EvidenceParams.IsExpired), which can't be committed anymore.
 // If maxNum is -1, all evidence is returned.
 func (evpool *EvidencePool) PendingEvidence(maxNum int64) []types.Evidence {
-	return evpool.evidenceStore.PendingEvidence(maxNum)
+	state := evpool.State()
+	var evidence []types.Evidence
+	for _, ev := range evpool.evidenceStore.PendingEvidence(-1) {
+		if int64(len(evidence)) == maxNum {
+			break
+		}
+		if evpool.isExpired(state.ConsensusParams.Evidence, ev, state.LastBlockHeight, state.LastBlockTime) {
+			continue
+		}
+		evidence = append(evidence, ev)
+	}
+	return evidence
 }
 
 // State returns the current state of the evpool.
@@ -94,7 +126,7 @@ func (evpool *EvidencePool) AddEvidence(evidence types.Evidence) (err error) {
 	// TODO: check if we already have evidence for this
 	// validator at this height so we dont get spammed
 
-	if err := sm.VerifyEvidence(evpool.stateDB, evpool.State(), evidence); err != nil {
+	if err := sm.VerifyEvidence(evpool.blockStore, evpool.stateDB, evpool.State(), evidence); err != nil {
 		return err
 	}
 
@@ -128,8 +160,8 @@ func (evpool *EvidencePool) MarkEvidenceAsCommitted(height int64, evidence []typ
 	}
 
 	// remove committed evidence from the clist
-	maxAge := evpool.State().ConsensusParams.Evidence.MaxAge
-	evpool.removeEvidence(height, maxAge, blockEvidenceMap)
+	state := evpool.State()
+	evpool.removeEvidence(height, state.LastBlockTime, state.ConsensusParams.Evidence, blockEvidenceMap)
 
 }
 
@@ -139,14 +171,45 @@ func (evpool *EvidencePool) IsCommitted(evidence types.Evidence) bool {
 	return ei.Evidence != nil && ei.Committed
 }
 
-func (evpool *EvidencePool) removeEvidence(height, maxAge int64, blockEvidenceMap map[string]struct{}) {
+// PruneExpiredEvidence deletes the evidence older than
+// ConsensusParams.Evidence.MaxAge from the store: it can't be committed
+// anymore, so there's no need to remember it.
+func (evpool *EvidencePool) PruneExpiredEvidence() {
+	state := evpool.State()
+	retainHeight := state.LastBlockHeight - state.ConsensusParams.Evidence.MaxAge
+	if retainHeight <= 1 {
+		return
+	}
+
+	pruned := evpool.evidenceStore.PruneEvidence(retainHeight)
+	evpool.metrics.RetainHeight.Set(float64(retainHeight))
+	if pruned > 0 {
+		evpool.metrics.PrunedEvidence.Add(float64(pruned))
+		evpool.logger.Info("Pruned expired evidence", "pruned", pruned, "retainHeight", retainHeight)
+	}
+}
+
+// isExpired returns true if ev is too old to be committed after the block of
+// height and time t (see sm.IsEvidenceExpired). Evidence which can't be aged
+// is treated as expired, since it can't be verified either.
+func (evpool *EvidencePool) isExpired(params types.EvidenceParams, ev types.Evidence, height int64, t time.Time) bool {
+	expired, err := sm.IsEvidenceExpired(evpool.blockStore, params, ev, height, t)
+	if err != nil {
+		evpool.logger.Error("Can't age the evidence", "evidence", ev, "err", err)
+		return true
+	}
+	return expired
+}
+
+func (evpool *EvidencePool) removeEvidence(height int64, t time.Time, params types.EvidenceParams,
+	blockEvidenceMap map[string]struct{}) {
 	for e := evpool.evidenceList.Front(); e != nil; e = e.Next() {
 		ev := e.Value.(types.Evidence)
 
 		// Remove the evidence if it's already in a block
 		// or if it's now too old.
 		if _, ok := blockEvidenceMap[evMapKey(ev)]; ok ||
-			ev.Height() < height-maxAge {
+			evpool.isExpired(params, ev, height, t) {
 
 			// remove from clist
 			evpool.evidenceList.Remove(e)
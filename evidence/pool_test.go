@@ -4,10 +4,12 @@ import (
 	"os"
 	"sync"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 
 	sm "github.com/hdac-io/tendermint/state"
+	"github.com/hdac-io/tendermint/store"
 	"github.com/hdac-io/tendermint/types"
 	tmtime "github.com/hdac-io/tendermint/types/time"
 	dbm "github.com/tendermint/tm-db"
@@ -57,7 +59,7 @@ func TestEvidencePool(t *testing.T) {
 	height := int64(5)
 	stateDB := initializeValidatorState(valAddr, height)
 	evidenceDB := dbm.NewMemDB()
-	pool := NewEvidencePool(stateDB, evidenceDB)
+	pool := NewEvidencePool(stateDB, evidenceDB, store.NewBlockStore(dbm.NewMemDB()))
 
 	goodEvidence := types.NewMockGoodEvidence(height, 0, valAddr)
 	badEvidence := types.MockBadEvidence{MockGoodEvidence: goodEvidence}
@@ -91,7 +93,7 @@ func TestEvidencePoolIsCommitted(t *testing.T) {
 	height := int64(42)
 	stateDB := initializeValidatorState(valAddr, height)
 	evidenceDB := dbm.NewMemDB()
-	pool := NewEvidencePool(stateDB, evidenceDB)
+	pool := NewEvidencePool(stateDB, evidenceDB, store.NewBlockStore(dbm.NewMemDB()))
 
 	// evidence not seen yet:
 	evidence := types.NewMockGoodEvidence(height, 0, valAddr)
@@ -105,3 +107,47 @@ func TestEvidencePoolIsCommitted(t *testing.T) {
 	pool.MarkEvidenceAsCommitted(height, []types.Evidence{evidence})
 	assert.True(t, pool.IsCommitted(evidence))
 }
+
+func TestEvidencePoolPendingEvidenceExpired(t *testing.T) {
+	valAddr := []byte("validator_address")
+	height := int64(10)
+	stateDB := initializeValidatorState(valAddr, height)
+	// the last block height is 9, and the block of each height a minute older
+	// than the next one
+	state := sm.LoadState(stateDB)
+	now := state.LastBlockTime
+	blockStore := store.NewBlockStore(dbm.NewMemDB())
+	for h := int64(1); h < height; h++ {
+		block := types.MakeBlock(h, nil, new(types.Commit), nil)
+		block.Time = now.Add(-time.Duration(height-1-h) * time.Minute)
+		blockStore.SaveBlock(block, block.MakePartSet(types.BlockPartSizeBytes), new(types.Commit), 1)
+		state.LastBlockHeight = h
+		state.LastBlockTime = block.Time
+		sm.SaveState(stateDB, state)
+	}
+	pool := NewEvidencePool(stateDB, dbm.NewMemDB(), blockStore)
+	pool.state.ConsensusParams.Evidence = types.EvidenceParams{MaxAge: 5, MaxAgeDurationMs: 90000}
+
+	evidence := func(height int64, t time.Time) types.Evidence {
+		return &types.DuplicateVoteEvidence{
+			VoteA: &types.Vote{Height: height, Timestamp: t},
+			VoteB: &types.Vote{Height: height, Timestamp: t},
+		}
+	}
+	// the times of the votes, signed by the equivocator, don't matter
+	fresh := evidence(8, now.Add(-time.Hour))
+	for _, ev := range []types.Evidence{
+		evidence(3, now), // older than MaxAge
+		evidence(7, now), // older than MaxAgeDurationMs, from the time of block 7
+		fresh,
+	} {
+		assert.True(t, pool.evidenceStore.AddNewEvidence(ev, 1))
+	}
+
+	pending := pool.PendingEvidence(-1)
+	if assert.Len(t, pending, 1) {
+		assert.Equal(t, fresh.Hash(), pending[0].Hash())
+	}
+	assert.Len(t, pool.PendingEvidence(1), 1)
+	assert.Len(t, pool.PendingEvidence(0), 0)
+}
@@ -5,8 +5,6 @@ import (
 	"reflect"
 	"time"
 
-	amino "github.com/tendermint/go-amino"
-
 	clist "github.com/hdac-io/tendermint/libs/clist"
 	"github.com/hdac-io/tendermint/libs/log"
 	"github.com/hdac-io/tendermint/p2p"
@@ -20,6 +18,7 @@ const (
 
 	broadcastEvidenceIntervalS = 60  // broadcast uncommitted evidence this often
 	peerCatchupSleepIntervalMS = 100 // If peer is behind, sleep this amount
+	pruneEvidenceIntervalS     = 60  // prune expired evidence this often
 )
 
 // EvidenceReactor handles evpool evidence broadcasting amongst peers.
@@ -44,6 +43,13 @@ func (evR *EvidenceReactor) SetLogger(l log.Logger) {
 	evR.evpool.SetLogger(l)
 }
 
+// OnStart implements Service.
+// It starts pruning the expired evidence in the background.
+func (evR *EvidenceReactor) OnStart() error {
+	go evR.pruneEvidenceRoutine()
+	return nil
+}
+
 // GetChannels implements Reactor.
 // It returns the list of channels for this reactor.
 func (evR *EvidenceReactor) GetChannels() []*p2p.ChannelDescriptor {
@@ -63,7 +69,7 @@ func (evR *EvidenceReactor) AddPeer(peer p2p.Peer) {
 // Receive implements Reactor.
 // It adds any received evidence to the evpool.
 func (evR *EvidenceReactor) Receive(chID byte, src p2p.Peer, msgBytes []byte) {
-	msg, err := decodeMsg(msgBytes)
+	msg, err := decodePeerMsg(msgBytes, evR.Switch.ProtoMsgs(src))
 	if err != nil {
 		evR.Logger.Error("Error decoding message", "src", src, "chId", chID, "msg", msg, "err", err, "bytes", msgBytes)
 		evR.Switch.StopPeerForError(src, err)
@@ -126,7 +132,7 @@ func (evR *EvidenceReactor) broadcastEvidenceRoutine(peer p2p.Peer) {
 		ev := next.Value.(types.Evidence)
 		msg, retry := evR.checkSendEvidenceMessage(peer, ev)
 		if msg != nil {
-			success := peer.Send(EvidenceChannel, cdc.MustMarshalBinaryBare(msg))
+			success := peer.Send(EvidenceChannel, evR.encodeMsg(peer, msg))
 			retry = !success
 		}
 
@@ -152,6 +158,21 @@ func (evR *EvidenceReactor) broadcastEvidenceRoutine(peer p2p.Peer) {
 	}
 }
 
+// pruneEvidenceRoutine prunes the expired evidence from the evpool every
+// pruneEvidenceIntervalS.
+func (evR *EvidenceReactor) pruneEvidenceRoutine() {
+	ticker := time.NewTicker(time.Second * pruneEvidenceIntervalS)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			evR.evpool.PruneExpiredEvidence()
+		case <-evR.Quit():
+			return
+		}
+	}
+}
+
 // Returns the message to send the peer, or nil if the evidence is invalid for the peer.
 // If message is nil, return true if we should sleep and try again.
 func (evR EvidenceReactor) checkSendEvidenceMessage(peer p2p.Peer, ev types.Evidence) (msg EvidenceMessage, retry bool) {
@@ -169,16 +190,19 @@ func (evR EvidenceReactor) checkSendEvidenceMessage(peer p2p.Peer, ev types.Evid
 
 	// NOTE: We only send evidence to peers where
 	// peerHeight - maxAge < evidenceHeight < peerHeight
-	maxAge := evR.evpool.State().ConsensusParams.Evidence.MaxAge
+	// and which isn't older than MaxAgeDurationMs at our last block
+	state := evR.evpool.State()
+	params := state.ConsensusParams.Evidence
 	peerHeight := peerState.GetHeight()
 	if peerHeight < evHeight {
 		// peer is behind. sleep while he catches up
 		return nil, true
-	} else if peerHeight > evHeight+maxAge {
+	} else if evR.evpool.isExpired(params, ev, peerHeight, state.LastBlockTime) {
 		// evidence is too old, skip
 		// NOTE: if evidence is too old for an honest peer,
 		// then we're behind and either it already got committed or it never will!
-		evR.Logger.Info("Not sending peer old evidence", "peerHeight", peerHeight, "evHeight", evHeight, "maxAge", maxAge, "peer", peer)
+		evR.Logger.Info("Not sending peer old evidence", "peerHeight", peerHeight, "evHeight", evHeight,
+			"maxAge", params.MaxAge, "maxAgeDurationMs", params.MaxAgeDurationMs, "peer", peer)
 		return nil, false
 	}
 
@@ -200,12 +224,6 @@ type EvidenceMessage interface {
 	ValidateBasic() error
 }
 
-func RegisterEvidenceMessages(cdc *amino.Codec) {
-	cdc.RegisterInterface((*EvidenceMessage)(nil), nil)
-	cdc.RegisterConcrete(&EvidenceListMessage{},
-		"tendermint/evidence/EvidenceListMessage", nil)
-}
-
 func decodeMsg(bz []byte) (msg EvidenceMessage, err error) {
 	if len(bz) > maxMsgSize {
 		return msg, fmt.Errorf("Msg exceeds max size (%d > %d)", len(bz), maxMsgSize)
@@ -13,6 +13,7 @@ import (
 	"github.com/hdac-io/tendermint/crypto/secp256k1"
 	"github.com/hdac-io/tendermint/libs/log"
 	"github.com/hdac-io/tendermint/p2p"
+	"github.com/hdac-io/tendermint/store"
 	"github.com/hdac-io/tendermint/types"
 	dbm "github.com/tendermint/tm-db"
 )
@@ -38,7 +39,7 @@ func makeAndConnectEvidenceReactors(config *cfg.Config, stateDBs []dbm.DB) []*Ev
 	for i := 0; i < N; i++ {
 
 		evidenceDB := dbm.NewMemDB()
-		pool := NewEvidencePool(stateDBs[i], evidenceDB)
+		pool := NewEvidencePool(stateDBs[i], evidenceDB, store.NewBlockStore(dbm.NewMemDB()))
 		reactors[i] = NewEvidenceReactor(pool)
 		reactors[i].SetLogger(logger.With("validator", i))
 	}
@@ -9,7 +9,7 @@ import (
 
 /*
 Requirements:
-	- Valid new evidence must be persisted immediately and never forgotten
+	- Valid new evidence must be persisted immediately and never forgotten until it expires (MaxAge)
 	- Uncommitted evidence must be continuously broadcast
 	- Uncommitted evidence has a partial order, the evidence's priority
 
@@ -17,6 +17,7 @@ Impl:
 	- First commit atomically in outqueue, pending, lookup.
 	- Once broadcast, remove from outqueue. No need to sync
 	- Once committed, atomically remove from pending and update lookup.
+	- Once older than MaxAge, remove from outqueue, pending and lookup.
 
 Schema for indexing evidence (note you need both height and hash to find a piece of evidence):
 
@@ -194,6 +195,41 @@ func (store *EvidenceStore) MarkEvidenceAsCommitted(evidence types.Evidence) {
 	store.db.SetSync(lookupKey, cdc.MustMarshalBinaryBare(ei))
 }
 
+// PruneEvidence deletes all the evidence of the heights below retainHeight,
+// committed or not, and returns how many pieces of evidence it deleted.
+func (store *EvidenceStore) PruneEvidence(retainHeight int64) int {
+	var infos []EvidenceInfo
+	iter := dbm.IteratePrefix(store.db, []byte(baseKeyLookup))
+	for ; iter.Valid(); iter.Next() {
+		var ei EvidenceInfo
+		err := cdc.UnmarshalBinaryBare(iter.Value(), &ei)
+		if err != nil {
+			panic(err)
+		}
+		// the lookup keys are sorted by height
+		if ei.Evidence.Height() >= retainHeight {
+			break
+		}
+		infos = append(infos, ei)
+	}
+	iter.Close()
+	if len(infos) == 0 {
+		return 0
+	}
+
+	batch := store.db.NewBatch()
+	defer batch.Close()
+	for _, ei := range infos {
+		if !ei.Committed {
+			batch.Delete(keyOutqueue(ei.Evidence, ei.Priority))
+			batch.Delete(keyPending(ei.Evidence))
+		}
+		batch.Delete(keyLookup(ei.Evidence))
+	}
+	batch.WriteSync()
+	return len(infos)
+}
+
 //---------------------------------------------------
 // utils
 
@@ -123,3 +123,30 @@ func TestStorePriority(t *testing.T) {
 		assert.Equal(ev, cases[i].ev)
 	}
 }
+
+func TestStorePruneEvidence(t *testing.T) {
+	assert := assert.New(t)
+
+	db := dbm.NewMemDB()
+	store := NewEvidenceStore(db)
+
+	priority := int64(10)
+	committed := types.NewMockGoodEvidence(2, 1, []byte("val1"))
+	pending := types.NewMockGoodEvidence(3, 1, []byte("val1"))
+	retained := types.NewMockGoodEvidence(5, 1, []byte("val1"))
+	for _, ev := range []types.Evidence{committed, pending, retained} {
+		assert.True(store.AddNewEvidence(ev, priority))
+	}
+	store.MarkEvidenceAsCommitted(committed)
+
+	assert.Equal(0, store.PruneEvidence(2))
+	assert.Equal(2, store.PruneEvidence(5))
+	assert.Equal(0, store.PruneEvidence(5))
+
+	// only the evidence of height 5 is left
+	assert.Nil(store.GetEvidenceInfo(committed.Height(), committed.Hash()).Evidence)
+	assert.Nil(store.GetEvidenceInfo(pending.Height(), pending.Hash()).Evidence)
+	assert.Equal(retained, store.GetEvidenceInfo(retained.Height(), retained.Hash()).Evidence)
+	assert.Equal([]types.Evidence{retained}, store.PriorityEvidence())
+	assert.Equal([]types.Evidence{retained}, store.PendingEvidence(-1))
+}
@@ -117,6 +117,21 @@ func (af *AutoFile) closeFile() (err error) {
 	return file.Close()
 }
 
+// Rename closes the file and moves it to newPath. The next write opens a new
+// file at Path.
+func (af *AutoFile) Rename(newPath string) error {
+	af.mtx.Lock()
+	defer af.mtx.Unlock()
+
+	if af.file != nil {
+		if err := af.file.Close(); err != nil {
+			return err
+		}
+		af.file = nil
+	}
+	return os.Rename(af.Path, newPath)
+}
+
 // Write writes len(b) bytes to the AutoFile. It returns the number of bytes
 // written and an error, if any. Write returns a non-nil error when n !=
 // len(b).
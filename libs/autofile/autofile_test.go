@@ -120,3 +120,34 @@ func TestAutoFileSize(t *testing.T) {
 	// Cleanup
 	_ = os.Remove(f.Name())
 }
+
+func TestAutoFileRename(t *testing.T) {
+	f, err := ioutil.TempFile("", "rename_test")
+	require.NoError(t, err)
+	err = f.Close()
+	require.NoError(t, err)
+	rotated := f.Name() + ".1"
+
+	af, err := OpenAutoFile(f.Name())
+	require.NoError(t, err)
+	defer af.Close()
+
+	_, err = af.Write([]byte("Line 1\n"))
+	require.NoError(t, err)
+	err = af.Rename(rotated)
+	require.NoError(t, err)
+	_, err = af.Write([]byte("Line 2\n"))
+	require.NoError(t, err)
+
+	// The written lines are in the renamed file and the new file
+	if read := cmn.MustReadFile(rotated); string(read) != "Line 1\n" {
+		t.Errorf("Unexpected renamed file contents: %v", string(read))
+	}
+	if read := cmn.MustReadFile(f.Name()); string(read) != "Line 2\n" {
+		t.Errorf("Unexpected file contents: %v", string(read))
+	}
+
+	// Cleanup
+	_ = os.Remove(f.Name())
+	_ = os.Remove(rotated)
+}
@@ -0,0 +1,206 @@
+// Package erasure implements a systematic Reed-Solomon erasure code over
+// GF(2^8): data shards are extended with parity shards, and any data shards
+// count of the data and parity shards rebuild the data shards.
+package erasure
+
+import (
+	"errors"
+	"fmt"
+)
+
+// MaxShards is the maximum number of data and parity shards.
+const MaxShards = 256
+
+var (
+	// ErrTooFewShards is returned when less shards than the data shards are
+	// known.
+	ErrTooFewShards = errors.New("too few shards to reconstruct")
+	// ErrShardSize is returned when the shards don't have the same size.
+	ErrShardSize = errors.New("shards of different sizes")
+)
+
+// The parity shard i is the sum over the data shards j of cauchy(i, j) times
+// the data shard j, with the Cauchy matrix 1/(x_i + y_j), x_i = dataShards+i
+// and y_j = j. As any square submatrix of a Cauchy matrix is invertible, any
+// dataShards rows of the identity matrix extended with it are too.
+func cauchy(dataShards, i, j int) byte {
+	return gfInv(byte(dataShards+i) ^ byte(j))
+}
+
+// Encode returns parityShards parity shards of the data shards, which must
+// have the same size.
+func Encode(data [][]byte, parityShards int) ([][]byte, error) {
+	if err := checkShardCounts(len(data), parityShards); err != nil {
+		return nil, err
+	}
+	size := len(data[0])
+	for _, shard := range data {
+		if len(shard) != size {
+			return nil, ErrShardSize
+		}
+	}
+
+	parity := make([][]byte, parityShards)
+	for i := range parity {
+		parity[i] = make([]byte, size)
+		for j, shard := range data {
+			gfMulAdd(cauchy(len(data), i, j), shard, parity[i])
+		}
+	}
+	return parity, nil
+}
+
+// Reconstruct rebuilds the nil data shards of shards, the dataShards data
+// shards followed by the parity shards, from any dataShards of the non nil
+// shards. The parity shards are not rebuilt.
+func Reconstruct(shards [][]byte, dataShards int) error {
+	if err := checkShardCounts(dataShards, len(shards)-dataShards); err != nil {
+		return err
+	}
+
+	// the rows of the encoding matrix of the first dataShards known shards
+	size := -1
+	var rows []int
+	missing := false
+	for i, shard := range shards {
+		if shard == nil {
+			missing = missing || i < dataShards
+			continue
+		}
+		if size == -1 {
+			size = len(shard)
+		} else if len(shard) != size {
+			return ErrShardSize
+		}
+		if len(rows) < dataShards {
+			rows = append(rows, i)
+		}
+	}
+	if !missing {
+		return nil
+	}
+	if len(rows) < dataShards {
+		return ErrTooFewShards
+	}
+
+	matrix := make([][]byte, dataShards)
+	for r, i := range rows {
+		matrix[r] = make([]byte, dataShards)
+		if i < dataShards {
+			matrix[r][i] = 1
+			continue
+		}
+		for j := range matrix[r] {
+			matrix[r][j] = cauchy(dataShards, i-dataShards, j)
+		}
+	}
+	inverse, err := invert(matrix)
+	if err != nil {
+		return err
+	}
+
+	// data shard j is the row j of the inverse times the known shards
+	for j := 0; j < dataShards; j++ {
+		if shards[j] != nil {
+			continue
+		}
+		shard := make([]byte, size)
+		for r, i := range rows {
+			gfMulAdd(inverse[j][r], shards[i], shard)
+		}
+		shards[j] = shard
+	}
+	return nil
+}
+
+func checkShardCounts(dataShards, parityShards int) error {
+	if dataShards <= 0 || parityShards < 0 || dataShards+parityShards > MaxShards {
+		return fmt.Errorf("invalid %d data shards and %d parity shards", dataShards, parityShards)
+	}
+	return nil
+}
+
+// invert returns the inverse of the square matrix, by Gauss-Jordan
+// elimination.
+func invert(matrix [][]byte) ([][]byte, error) {
+	n := len(matrix)
+	work := make([][]byte, n)
+	for i := range work {
+		work[i] = make([]byte, 2*n)
+		copy(work[i], matrix[i])
+		work[i][n+i] = 1
+	}
+
+	for col := 0; col < n; col++ {
+		pivot := col
+		for pivot < n && work[pivot][col] == 0 {
+			pivot++
+		}
+		if pivot == n {
+			return nil, errors.New("singular matrix")
+		}
+		work[col], work[pivot] = work[pivot], work[col]
+
+		scale := gfInv(work[col][col])
+		for k := range work[col] {
+			work[col][k] = gfMul(work[col][k], scale)
+		}
+		for row := 0; row < n; row++ {
+			if row != col && work[row][col] != 0 {
+				gfMulAdd(work[row][col], work[col], work[row])
+			}
+		}
+	}
+
+	inverse := make([][]byte, n)
+	for i := range inverse {
+		inverse[i] = work[i][n:]
+	}
+	return inverse, nil
+}
+
+//-----------------------------------------------------------------------------
+// GF(2^8) with the polynomial x^8 + x^4 + x^3 + x^2 + 1
+
+var (
+	gfExp [510]byte
+	gfLog [256]int
+)
+
+func init() {
+	x := 1
+	for i := 0; i < 255; i++ {
+		gfExp[i] = byte(x)
+		gfExp[i+255] = byte(x)
+		gfLog[x] = i
+		x <<= 1
+		if x&0x100 != 0 {
+			x ^= 0x11d
+		}
+	}
+}
+
+func gfMul(a, b byte) byte {
+	if a == 0 || b == 0 {
+		return 0
+	}
+	return gfExp[gfLog[a]+gfLog[b]]
+}
+
+// gfInv returns the multiplicative inverse of a, which must not be 0.
+func gfInv(a byte) byte {
+	return gfExp[255-gfLog[a]]
+}
+
+// gfMulAdd adds c times in to out.
+func gfMulAdd(c byte, in, out []byte) {
+	if c == 0 {
+		return
+	}
+	logC := gfLog[c]
+	for i, b := range in {
+		if b != 0 {
+			out[i] ^= gfExp[logC+gfLog[b]]
+		}
+	}
+}
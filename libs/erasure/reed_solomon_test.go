@@ -0,0 +1,67 @@
+package erasure
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func randomShards(n, size int) [][]byte {
+	shards := make([][]byte, n)
+	for i := range shards {
+		shards[i] = make([]byte, size)
+		rand.Read(shards[i])
+	}
+	return shards
+}
+
+func TestReconstruct(t *testing.T) {
+	for _, tc := range []struct {
+		dataShards, parityShards int
+	}{
+		{1, 1},
+		{4, 2},
+		{10, 5},
+		{16, 16},
+		{200, 56},
+	} {
+		data := randomShards(tc.dataShards, 100)
+		parity, err := Encode(data, tc.parityShards)
+		require.NoError(t, err)
+		require.Len(t, parity, tc.parityShards)
+
+		// lose parityShards random shards
+		shards := append(append([][]byte{}, data...), parity...)
+		for _, i := range rand.Perm(len(shards))[:tc.parityShards] {
+			shards[i] = nil
+		}
+		require.NoError(t, Reconstruct(shards, tc.dataShards))
+		assert.Equal(t, data, shards[:tc.dataShards], "%d data shards, %d parity shards", tc.dataShards, tc.parityShards)
+
+		// one more is too many
+		if tc.parityShards < len(shards) {
+			shards = append(append([][]byte{}, data...), parity...)
+			for _, i := range rand.Perm(len(shards))[:tc.parityShards+1] {
+				shards[i] = nil
+			}
+			hasDataShards := true
+			for _, shard := range shards[:tc.dataShards] {
+				hasDataShards = hasDataShards && shard != nil
+			}
+			if !hasDataShards {
+				assert.Equal(t, ErrTooFewShards, Reconstruct(shards, tc.dataShards))
+			}
+		}
+	}
+}
+
+func TestEncodeInvalid(t *testing.T) {
+	_, err := Encode(randomShards(200, 10), 57)
+	assert.Error(t, err)
+	_, err = Encode(nil, 1)
+	assert.Error(t, err)
+	_, err = Encode([][]byte{make([]byte, 10), make([]byte, 9)}, 1)
+	assert.Equal(t, ErrShardSize, err)
+}
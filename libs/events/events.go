@@ -74,6 +74,11 @@ func (evsw *eventSwitch) OnStart() error {
 
 func (evsw *eventSwitch) OnStop() {}
 
+// OnReset implements cmn.Service. The listeners are kept.
+func (evsw *eventSwitch) OnReset() error {
+	return nil
+}
+
 func (evsw *eventSwitch) AddListenerForEvent(listenerID, event string, cb EventCallback) error {
 	// Get/Create eventCell and listener.
 	evsw.mtx.Lock()
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"os"
 	"strconv"
+	"sync"
 )
 
 func envSet() int {
@@ -22,14 +23,22 @@ func envSet() int {
 }
 
 // Fail when FAIL_TEST_INDEX == callIndex
-var callIndex int //indexes Fail calls
+var (
+	callIndex int //indexes Fail calls
+	mtx       sync.Mutex
+)
 
+// Fail exits the process on the FAIL_TEST_INDEX-th call, counting from 0.
+// It is safe to call concurrently, e.g. from the handlers of several heights
+// of the friday consensus: the calls are then counted in no particular order.
 func Fail() {
 	callIndexToFail := envSet()
 	if callIndexToFail < 0 {
 		return
 	}
 
+	mtx.Lock()
+	defer mtx.Unlock()
 	if callIndex == callIndexToFail {
 		Exit()
 	}
@@ -0,0 +1,73 @@
+package log
+
+import (
+	"sync"
+)
+
+// SwitchLogger is a logger whose next logger can be replaced while it's used,
+// by the SwitchLogger and all the loggers derived from it with With, e.g. to
+// change the log level of a running node.
+type SwitchLogger struct {
+	root    *switchRoot
+	keyvals []interface{}
+
+	mtx        sync.Mutex
+	generation uint64
+	next       Logger // root.next with keyvals
+}
+
+type switchRoot struct {
+	mtx        sync.RWMutex
+	generation uint64
+	next       Logger
+}
+
+// NewSwitchLogger returns a SwitchLogger logging to next until Set.
+func NewSwitchLogger(next Logger) *SwitchLogger {
+	return &SwitchLogger{root: &switchRoot{next: next}}
+}
+
+// Set makes the SwitchLogger and the loggers derived from it log to next.
+func (l *SwitchLogger) Set(next Logger) {
+	l.root.mtx.Lock()
+	defer l.root.mtx.Unlock()
+	l.root.next = next
+	l.root.generation++
+}
+
+func (l *SwitchLogger) current() Logger {
+	l.root.mtx.RLock()
+	generation, next := l.root.generation, l.root.next
+	l.root.mtx.RUnlock()
+
+	l.mtx.Lock()
+	defer l.mtx.Unlock()
+	if l.next == nil || l.generation != generation {
+		l.next = next
+		if len(l.keyvals) > 0 {
+			l.next = next.With(l.keyvals...)
+		}
+		l.generation = generation
+	}
+	return l.next
+}
+
+func (l *SwitchLogger) Info(msg string, keyvals ...interface{}) {
+	l.current().Info(msg, keyvals...)
+}
+
+func (l *SwitchLogger) Debug(msg string, keyvals ...interface{}) {
+	l.current().Debug(msg, keyvals...)
+}
+
+func (l *SwitchLogger) Error(msg string, keyvals ...interface{}) {
+	l.current().Error(msg, keyvals...)
+}
+
+// With implements Logger by returning a logger logging to the next logger of
+// l with keyvals appended, even after Set.
+func (l *SwitchLogger) With(keyvals ...interface{}) Logger {
+	allKeyvals := make([]interface{}, 0, len(l.keyvals)+len(keyvals))
+	allKeyvals = append(append(allKeyvals, l.keyvals...), keyvals...)
+	return &SwitchLogger{root: l.root, keyvals: allKeyvals}
+}
@@ -0,0 +1,29 @@
+package log_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/hdac-io/tendermint/libs/log"
+)
+
+func TestSwitchLogger(t *testing.T) {
+	var buf bytes.Buffer
+	base := log.NewTMJSONLogger(&buf)
+	logger := log.NewSwitchLogger(log.NewFilter(base, log.AllowInfo()))
+	derived := logger.With("module", "consensus")
+
+	derived.Debug("hidden")
+	derived.Info("shown")
+	if have := buf.String(); strings.Contains(have, "hidden") || !strings.Contains(have, `"module":"consensus"`) {
+		t.Errorf("unexpected output before Set: %s", have)
+	}
+
+	buf.Reset()
+	logger.Set(log.NewFilter(base, log.AllowDebug()))
+	derived.Debug("debug")
+	if have := buf.String(); !strings.Contains(have, "debug") || !strings.Contains(have, `"module":"consensus"`) {
+		t.Errorf("the derived logger doesn't log to the new logger: %s", have)
+	}
+}
@@ -0,0 +1,80 @@
+package log
+
+import (
+	"io"
+	"reflect"
+
+	kitlog "github.com/go-kit/kit/log"
+)
+
+const (
+	timestampKey = "ts"
+	peerKey      = "peer"
+)
+
+// structuredKeyAliases maps the keys the reactors use for a peer to peerKey,
+// so the peer of a log line is always found under the same field.
+var structuredKeyAliases = map[string]string{
+	"src":     peerKey,
+	"peerID":  peerKey,
+	"peer_id": peerKey,
+}
+
+// NewTMStructuredJSONLogger returns a Logger that encodes keyvals to the
+// Writer as a single JSON object, like NewTMJSONLogger, with stable field
+// names meant to be indexed by log pipelines:
+//
+//   - ts: the UTC time of the log line
+//   - level, _msg and module, as in the other loggers
+//   - height, round and step of the consensus, the step as its name
+//   - peer: the ID of the peer, whatever key ("src", "peerID", ...) it was
+//     logged with and whether it was logged as a Peer or as an ID
+//
+// The passed Writer must be safe for concurrent use by multiple goroutines if
+// the returned Logger will be used concurrently.
+func NewTMStructuredJSONLogger(w io.Writer) Logger {
+	return &tmLogger{kitlog.With(structuredLogger{kitlog.NewJSONLogger(w)}, timestampKey, kitlog.DefaultTimestampUTC)}
+}
+
+// structuredLogger renames the aliases of the stable keys, and replaces the
+// peers by their IDs, before passing the keyvals to the next logger.
+type structuredLogger struct {
+	next kitlog.Logger
+}
+
+func (l structuredLogger) Log(keyvals ...interface{}) error {
+	kvs := make([]interface{}, len(keyvals))
+	copy(kvs, keyvals)
+	for i := 0; i+1 < len(kvs); i += 2 {
+		key, ok := kvs[i].(string)
+		if !ok {
+			continue
+		}
+		if alias, ok := structuredKeyAliases[key]; ok {
+			key = alias
+			kvs[i] = alias
+		}
+		if key == peerKey {
+			kvs[i+1] = peerID(kvs[i+1])
+		}
+	}
+	return l.next.Log(kvs...)
+}
+
+// peerID returns the ID of v if v has an ID method returning a string, as
+// p2p.Peer does, and v otherwise. It uses reflection because libs/log can't
+// depend on p2p.
+func peerID(v interface{}) interface{} {
+	rv := reflect.ValueOf(v)
+	if !rv.IsValid() || (rv.Kind() == reflect.Ptr && rv.IsNil()) {
+		return v
+	}
+	m := rv.MethodByName("ID")
+	if !m.IsValid() {
+		return v
+	}
+	if mt := m.Type(); mt.NumIn() != 0 || mt.NumOut() != 1 || mt.Out(0).Kind() != reflect.String {
+		return v
+	}
+	return m.Call(nil)[0].String()
+}
@@ -0,0 +1,59 @@
+package log_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hdac-io/tendermint/libs/log"
+)
+
+type testPeer struct{ id string }
+
+func (p *testPeer) ID() string     { return p.id }
+func (p *testPeer) String() string { return "Peer{" + p.id + "}" }
+
+func TestStructuredJSONLogger(t *testing.T) {
+	var buf bytes.Buffer
+	logger := log.NewTMStructuredJSONLogger(&buf).With("module", "consensus", "height", int64(3))
+
+	decode := func() map[string]interface{} {
+		var fields map[string]interface{}
+		require.NoError(t, json.Unmarshal(buf.Bytes(), &fields), buf.String())
+		buf.Reset()
+		return fields
+	}
+
+	logger.Info("foo", "round", 1, "src", &testPeer{"abcd"}, "err", errors.New("bar"))
+	fields := decode()
+	assert.NotEmpty(t, fields["ts"])
+	delete(fields, "ts")
+	assert.Equal(t, map[string]interface{}{
+		"level":  "info",
+		"_msg":   "foo",
+		"module": "consensus",
+		"height": float64(3),
+		"round":  float64(1),
+		"peer":   "abcd",
+		"err":    "bar",
+	}, fields)
+
+	// the aliases of peer, and an ID instead of a peer
+	logger.Error("foo", "peerID", "abcd")
+	fields = decode()
+	assert.Equal(t, "abcd", fields["peer"])
+	assert.NotContains(t, fields, "peerID")
+
+	// the height can be overridden
+	logger.Debug("foo", "height", 4)
+	assert.Equal(t, float64(4), decode()["height"])
+
+	// a nil peer
+	var peer *testPeer
+	logger.Info("foo", "peer", peer)
+	assert.Contains(t, decode(), "peer")
+}
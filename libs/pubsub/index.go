@@ -0,0 +1,216 @@
+package pubsub
+
+import (
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/hdac-io/tendermint/libs/pubsub/query"
+)
+
+// queryIndex narrows down the queries to match against the events of a
+// message, using the conditions of the queries on the indexed tags.
+//
+// A query is indexed by its first condition on an indexed tag: an equality
+// with a string, or the integer range made of all its integer conditions on
+// that tag. Since every condition of a query must hold for the query to match,
+// the queries found in the index may match, the others can't. The queries
+// without such condition (or which don't expose their conditions) are always
+// matched.
+//
+// NOTE: not goroutine safe
+type queryIndex struct {
+	tags map[string]struct{}
+
+	// tag -> value -> query string, for the equality conditions
+	equal map[string]map[string]map[string]struct{}
+	// tag -> query ranges sorted by lower bound, for the integer conditions
+	ranges map[string][]queryRange
+	// query strings without condition on the indexed tags
+	unindexed map[string]struct{}
+}
+
+type queryRange struct {
+	lo, hi int64
+	qStr   string
+}
+
+// indexEntry is where a query is stored in the index.
+type indexEntry struct {
+	tag     string
+	isRange bool
+	value   string     // if !isRange
+	r       queryRange // if isRange
+}
+
+type conditionsQuery interface {
+	Conditions() []query.Condition
+}
+
+func newQueryIndex(tags []string) *queryIndex {
+	idx := &queryIndex{
+		tags:      make(map[string]struct{}, len(tags)),
+		equal:     make(map[string]map[string]map[string]struct{}),
+		ranges:    make(map[string][]queryRange),
+		unindexed: make(map[string]struct{}),
+	}
+	for _, tag := range tags {
+		idx.tags[tag] = struct{}{}
+	}
+	return idx
+}
+
+// entry returns where the query is stored in the index, if it is indexed.
+func (idx *queryIndex) entry(qStr string, q Query) (indexEntry, bool) {
+	cq, isConditionsQuery := q.(conditionsQuery)
+	if !isConditionsQuery || len(idx.tags) == 0 {
+		return indexEntry{}, false
+	}
+	conditions := cq.Conditions()
+	for _, c := range conditions {
+		if _, indexed := idx.tags[c.Tag]; !indexed {
+			continue
+		}
+		if s, isString := c.Operand.(string); isString && c.Op == query.OpEqual {
+			return indexEntry{tag: c.Tag, value: s}, true
+		}
+		if _, isInt := c.Operand.(int64); isInt {
+			r := integerRange(c.Tag, conditions)
+			r.qStr = qStr
+			return indexEntry{tag: c.Tag, isRange: true, r: r}, true
+		}
+	}
+	return indexEntry{}, false
+}
+
+// integerRange returns the range allowed by the integer conditions on tag.
+func integerRange(tag string, conditions []query.Condition) queryRange {
+	r := queryRange{lo: math.MinInt64, hi: math.MaxInt64}
+	for _, c := range conditions {
+		v, isInt := c.Operand.(int64)
+		if c.Tag != tag || !isInt {
+			continue
+		}
+		lo, hi := r.lo, r.hi
+		switch c.Op {
+		case query.OpEqual:
+			lo, hi = v, v
+		case query.OpGreaterEqual:
+			lo = v
+		case query.OpGreater:
+			if v == math.MaxInt64 {
+				// matches nothing
+				return queryRange{lo: math.MaxInt64, hi: math.MinInt64}
+			}
+			lo = v + 1
+		case query.OpLessEqual:
+			hi = v
+		case query.OpLess:
+			if v == math.MinInt64 {
+				return queryRange{lo: math.MaxInt64, hi: math.MinInt64}
+			}
+			hi = v - 1
+		}
+		if lo > r.lo {
+			r.lo = lo
+		}
+		if hi < r.hi {
+			r.hi = hi
+		}
+	}
+	return r
+}
+
+func (idx *queryIndex) add(qStr string, q Query) {
+	e, ok := idx.entry(qStr, q)
+	switch {
+	case !ok:
+		idx.unindexed[qStr] = struct{}{}
+	case !e.isRange:
+		if _, ok := idx.equal[e.tag]; !ok {
+			idx.equal[e.tag] = make(map[string]map[string]struct{})
+		}
+		if _, ok := idx.equal[e.tag][e.value]; !ok {
+			idx.equal[e.tag][e.value] = make(map[string]struct{})
+		}
+		idx.equal[e.tag][e.value][qStr] = struct{}{}
+	default:
+		ranges := idx.ranges[e.tag]
+		i := sort.Search(len(ranges), func(i int) bool { return ranges[i].lo > e.r.lo })
+		ranges = append(ranges, queryRange{})
+		copy(ranges[i+1:], ranges[i:])
+		ranges[i] = e.r
+		idx.ranges[e.tag] = ranges
+	}
+}
+
+func (idx *queryIndex) remove(qStr string, q Query) {
+	e, ok := idx.entry(qStr, q)
+	tag, value := e.tag, e.value
+	switch {
+	case !ok:
+		delete(idx.unindexed, qStr)
+	case !e.isRange:
+		delete(idx.equal[tag][value], qStr)
+		if len(idx.equal[tag][value]) == 0 {
+			delete(idx.equal[tag], value)
+		}
+		if len(idx.equal[tag]) == 0 {
+			delete(idx.equal, tag)
+		}
+	default:
+		ranges := idx.ranges[tag]
+		for i := range ranges {
+			if ranges[i].qStr == qStr {
+				idx.ranges[tag] = append(ranges[:i], ranges[i+1:]...)
+				break
+			}
+		}
+		if len(idx.ranges[tag]) == 0 {
+			delete(idx.ranges, tag)
+		}
+	}
+}
+
+// candidates returns the query strings which may match events.
+func (idx *queryIndex) candidates(events map[string][]string) map[string]struct{} {
+	candidates := make(map[string]struct{}, len(idx.unindexed))
+	for qStr := range idx.unindexed {
+		candidates[qStr] = struct{}{}
+	}
+	for tag, byValue := range idx.equal {
+		for _, value := range events[tag] {
+			for qStr := range byValue[value] {
+				candidates[qStr] = struct{}{}
+			}
+		}
+	}
+	for tag, ranges := range idx.ranges {
+		for _, value := range events[tag] {
+			v, ok := parseInt(value)
+			if !ok {
+				continue
+			}
+			// ranges are sorted by lower bound, skip the ones starting above v
+			n := sort.Search(len(ranges), func(i int) bool { return ranges[i].lo > v })
+			for _, r := range ranges[:n] {
+				if v <= r.hi {
+					candidates[r.qStr] = struct{}{}
+				}
+			}
+		}
+	}
+	return candidates
+}
+
+// parseInt converts the value of an event like query.Query does,
+// floats being truncated.
+func parseInt(value string) (int64, bool) {
+	if strings.ContainsAny(value, ".") {
+		f, err := strconv.ParseFloat(value, 64)
+		return int64(f), err == nil
+	}
+	v, err := strconv.ParseInt(value, 10, 64)
+	return v, err == nil
+}
@@ -0,0 +1,60 @@
+package pubsub
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/hdac-io/tendermint/libs/pubsub/query"
+)
+
+func TestQueryIndexCandidates(t *testing.T) {
+	idx := newQueryIndex([]string{"tm.event", "block.height"})
+
+	queries := []string{
+		"tm.event='NewBlock'",
+		"tm.event='Vote' AND block.height=7",
+		"block.height >= 5 AND block.height <= 10",
+		"block.height > 8",
+		"block.height < 3",
+		"abci.account.name='John'",
+	}
+	for _, qStr := range queries {
+		idx.add(qStr, query.MustParse(qStr))
+	}
+	idx.add("empty", query.Empty{})
+
+	testCases := []struct {
+		events map[string][]string
+		want   []string
+	}{
+		{
+			map[string][]string{"tm.event": {"NewBlock"}, "block.height": {"2"}},
+			[]string{"tm.event='NewBlock'", "block.height < 3", "abci.account.name='John'", "empty"},
+		},
+		{
+			map[string][]string{"tm.event": {"Vote"}, "block.height": {"9"}},
+			[]string{"tm.event='Vote' AND block.height=7", "block.height >= 5 AND block.height <= 10",
+				"block.height > 8", "abci.account.name='John'", "empty"},
+		},
+		{
+			map[string][]string{"block.height": {"11", "4"}},
+			[]string{"block.height > 8", "abci.account.name='John'", "empty"},
+		},
+	}
+	for i, tc := range testCases {
+		var got []string
+		for qStr := range idx.candidates(tc.events) {
+			got = append(got, qStr)
+		}
+		assert.ElementsMatch(t, tc.want, got, "#%d", i)
+	}
+
+	for _, qStr := range queries {
+		idx.remove(qStr, query.MustParse(qStr))
+	}
+	idx.remove("empty", query.Empty{})
+	assert.Empty(t, idx.equal)
+	assert.Empty(t, idx.ranges)
+	assert.Empty(t, idx.unindexed)
+}
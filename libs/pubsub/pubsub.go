@@ -6,8 +6,9 @@
 // that channel (fan-in).
 //
 // Clients subscribe for messages, which could be of any type, using a query.
-// When some message is published, we match it with all queries. If there is a
-// match, this message will be pushed to all clients, subscribed to that query.
+// When some message is published, we match it with all queries (or the ones
+// found in the index, see IndexTags). If there is a match, this message will be
+// pushed to all clients, subscribed to that query.
 // See query subpackage for our implementation.
 //
 // Example:
@@ -93,6 +94,9 @@ type Server struct {
 	cmds    chan cmd
 	cmdsCap int
 
+	// tags of the conditions used to find the queries matching a message
+	indexedTags []string
+
 	// check if we have subscription before
 	// subscribing or unsubscribing
 	mtx           sync.RWMutex
@@ -133,6 +137,18 @@ func BufferCapacity(cap int) Option {
 	}
 }
 
+// IndexTags makes the server index the queries by their conditions on the
+// given tags, so a published message is only matched against the queries
+// which can match it. It pays off for the tags most queries have a condition
+// on, with a different value, e.g. the event type or the block height: the
+// string equalities and the integer ranges (e.g. "h >= 5 AND h <= 10") are
+// indexed.
+func IndexTags(tags ...string) Option {
+	return func(s *Server) {
+		s.indexedTags = tags
+	}
+}
+
 // BufferCapacity returns capacity of the internal server's queue.
 func (s *Server) BufferCapacity() int {
 	return s.cmdsCap
@@ -291,6 +307,8 @@ type state struct {
 	subscriptions map[string]map[string]*Subscription
 	// query string -> queryPlusRefCount
 	queries map[string]*queryPlusRefCount
+	// finds the queries which may match a message
+	index *queryIndex
 }
 
 // queryPlusRefCount holds a pointer to a query and reference counter. When
@@ -305,6 +323,7 @@ func (s *Server) OnStart() error {
 	go s.loop(state{
 		subscriptions: make(map[string]map[string]*Subscription),
 		queries:       make(map[string]*queryPlusRefCount),
+		index:         newQueryIndex(s.indexedTags),
 	})
 	return nil
 }
@@ -348,6 +367,7 @@ func (state *state) add(clientID string, q Query, subscription *Subscription) {
 	// initialize query if needed
 	if _, ok := state.queries[qStr]; !ok {
 		state.queries[qStr] = &queryPlusRefCount{q: q, refCount: 0}
+		state.index.add(qStr, q)
 	}
 	// increment reference counter
 	state.queries[qStr].refCount++
@@ -377,6 +397,7 @@ func (state *state) remove(clientID string, qStr string, reason error) {
 	state.queries[qStr].refCount--
 	// remove the query if nobody else is using it
 	if state.queries[qStr].refCount == 0 {
+		state.index.remove(qStr, state.queries[qStr].q)
 		delete(state.queries, qStr)
 	}
 }
@@ -398,7 +419,12 @@ func (state *state) removeAll(reason error) {
 }
 
 func (state *state) send(msg interface{}, events map[string][]string) {
-	for qStr, clientSubscriptions := range state.subscriptions {
+	for qStr := range state.index.candidates(events) {
+		clientSubscriptions, ok := state.subscriptions[qStr]
+		if !ok {
+			// removed while sending to a previous query
+			continue
+		}
 		q := state.queries[qStr].q
 		if q.Matches(events) {
 			for clientID, subscription := range clientSubscriptions {
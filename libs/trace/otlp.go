@@ -0,0 +1,301 @@
+package trace
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	cmn "github.com/hdac-io/tendermint/libs/common"
+)
+
+const (
+	// how often the ended spans are exported
+	otlpExportInterval = time.Second
+
+	// how many spans are exported in a single request at most
+	otlpMaxBatchSize = 512
+
+	// how many ended spans can wait for the export before new ones are dropped
+	otlpQueueSize = 4 * otlpMaxBatchSize
+
+	otlpRequestTimeout = 10 * time.Second
+
+	// SPAN_KIND_INTERNAL
+	otlpSpanKindInternal = 1
+)
+
+// OTLPTracer is a Tracer exporting the spans to an OpenTelemetry collector
+// with OTLP/HTTP, in the JSON encoding. The spans are exported in batches by a
+// background routine: it must be started, and stopped to export the last ones.
+type OTLPTracer struct {
+	cmn.BaseService
+
+	url         string
+	serviceName string
+	chainID     string
+	instanceID  string
+
+	client  *http.Client
+	spansCh chan *otlpSpan
+}
+
+var _ Tracer = (*OTLPTracer)(nil)
+
+// NewOTLPTracer returns a new OTLPTracer posting the spans to
+// endpoint/v1/traces, e.g. http://localhost:4318/v1/traces.
+// serviceName and instanceID identify the node among the ones of chainID.
+func NewOTLPTracer(endpoint, serviceName, chainID, instanceID string) *OTLPTracer {
+	t := &OTLPTracer{
+		url:         strings.TrimSuffix(endpoint, "/") + "/v1/traces",
+		serviceName: serviceName,
+		chainID:     chainID,
+		instanceID:  instanceID,
+		client:      &http.Client{Timeout: otlpRequestTimeout},
+		spansCh:     make(chan *otlpSpan, otlpQueueSize),
+	}
+	t.BaseService = *cmn.NewBaseService(nil, "OTLPTracer", t)
+	return t
+}
+
+// OnStart implements cmn.Service by starting the export routine.
+func (t *OTLPTracer) OnStart() error {
+	go t.exportRoutine()
+	return nil
+}
+
+// StartHeight implements Tracer.
+func (t *OTLPTracer) StartHeight(height int64) Span {
+	traceID := t.traceID(height)
+	return t.startSpan(traceID, t.rootSpanID(traceID), nil, "height", []interface{}{"height", height})
+}
+
+// StartSpan implements Tracer.
+func (t *OTLPTracer) StartSpan(height int64, name string, kv ...interface{}) Span {
+	traceID := t.traceID(height)
+	return t.startSpan(traceID, cmn.RandBytes(8), t.rootSpanID(traceID), name, kv)
+}
+
+func (t *OTLPTracer) startSpan(traceID, spanID, parentSpanID []byte, name string, kv []interface{}) *otlpSpan {
+	return &otlpSpan{
+		tracer:       t,
+		traceID:      traceID,
+		spanID:       spanID,
+		parentSpanID: parentSpanID,
+		name:         name,
+		start:        time.Now(),
+		attributes:   append([]interface{}{}, kv...),
+	}
+}
+
+// traceID is the same on every node, so the collector can merge their traces.
+func (t *OTLPTracer) traceID(height int64) []byte {
+	hash := sha256.Sum256([]byte(t.chainID + "/" + strconv.FormatInt(height, 10)))
+	return hash[:16]
+}
+
+// rootSpanID is the same for every component of the node, so they don't need
+// to share the root span of a height to start its children.
+func (t *OTLPTracer) rootSpanID(traceID []byte) []byte {
+	hash := sha256.Sum256(append(append([]byte{}, traceID...), t.instanceID...))
+	return hash[:8]
+}
+
+func (t *OTLPTracer) exportRoutine() {
+	ticker := time.NewTicker(otlpExportInterval)
+	defer ticker.Stop()
+
+	batch := make([]*otlpSpan, 0, otlpMaxBatchSize)
+	for {
+		select {
+		case span := <-t.spansCh:
+			batch = append(batch, span)
+			if len(batch) < otlpMaxBatchSize {
+				continue
+			}
+		case <-ticker.C:
+		case <-t.Quit():
+			for len(t.spansCh) > 0 && len(batch) < otlpMaxBatchSize {
+				batch = append(batch, <-t.spansCh)
+			}
+			t.export(batch)
+			return
+		}
+		t.export(batch)
+		batch = batch[:0]
+	}
+}
+
+func (t *OTLPTracer) export(spans []*otlpSpan) {
+	if len(spans) == 0 {
+		return
+	}
+	body, err := json.Marshal(t.makeRequest(spans))
+	if err != nil {
+		panic(fmt.Sprintf("Could not marshal spans: %v", err))
+	}
+	res, err := t.client.Post(t.url, "application/json", bytes.NewReader(body))
+	if err != nil {
+		t.Logger.Error("Failed to export spans", "spans", len(spans), "err", err)
+		return
+	}
+	defer res.Body.Close() // nolint: errcheck
+	if res.StatusCode != http.StatusOK {
+		resBody, _ := ioutil.ReadAll(res.Body)
+		t.Logger.Error("Failed to export spans", "spans", len(spans), "status", res.Status, "body", string(resBody))
+	}
+}
+
+//-----------------------------------------------------------------------------
+
+type otlpSpan struct {
+	tracer       *OTLPTracer
+	traceID      []byte
+	spanID       []byte
+	parentSpanID []byte
+	name         string
+	start        time.Time
+
+	mtx        sync.Mutex
+	attributes []interface{}
+	end        time.Time
+}
+
+// SetAttributes implements Span.
+func (s *otlpSpan) SetAttributes(kv ...interface{}) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	s.attributes = append(s.attributes, kv...)
+}
+
+// End implements Span.
+func (s *otlpSpan) End() {
+	s.mtx.Lock()
+	if !s.end.IsZero() {
+		s.mtx.Unlock()
+		return
+	}
+	s.end = time.Now()
+	s.mtx.Unlock()
+
+	select {
+	case s.tracer.spansCh <- s:
+	default:
+		s.tracer.Logger.Debug("Dropped span, the export is too slow", "span", s.name)
+	}
+}
+
+//-----------------------------------------------------------------------------
+// OTLP/HTTP JSON encoding of ExportTraceServiceRequest.
+// See https://github.com/open-telemetry/opentelemetry-proto
+
+type otlpRequest struct {
+	ResourceSpans []otlpResourceSpans `json:"resourceSpans"`
+}
+
+type otlpResourceSpans struct {
+	Resource   otlpResource     `json:"resource"`
+	ScopeSpans []otlpScopeSpans `json:"scopeSpans"`
+}
+
+type otlpResource struct {
+	Attributes []otlpKeyValue `json:"attributes"`
+}
+
+type otlpScopeSpans struct {
+	Scope otlpScope      `json:"scope"`
+	Spans []otlpSpanJSON `json:"spans"`
+}
+
+type otlpScope struct {
+	Name string `json:"name"`
+}
+
+type otlpSpanJSON struct {
+	TraceID           string         `json:"traceId"`
+	SpanID            string         `json:"spanId"`
+	ParentSpanID      string         `json:"parentSpanId,omitempty"`
+	Name              string         `json:"name"`
+	Kind              int            `json:"kind"`
+	StartTimeUnixNano string         `json:"startTimeUnixNano"`
+	EndTimeUnixNano   string         `json:"endTimeUnixNano"`
+	Attributes        []otlpKeyValue `json:"attributes,omitempty"`
+}
+
+type otlpKeyValue struct {
+	Key   string                 `json:"key"`
+	Value map[string]interface{} `json:"value"`
+}
+
+func (t *OTLPTracer) makeRequest(spans []*otlpSpan) otlpRequest {
+	jsonSpans := make([]otlpSpanJSON, len(spans))
+	for i, s := range spans {
+		s.mtx.Lock()
+		jsonSpans[i] = otlpSpanJSON{
+			TraceID:           hex.EncodeToString(s.traceID),
+			SpanID:            hex.EncodeToString(s.spanID),
+			ParentSpanID:      hex.EncodeToString(s.parentSpanID),
+			Name:              s.name,
+			Kind:              otlpSpanKindInternal,
+			StartTimeUnixNano: strconv.FormatInt(s.start.UnixNano(), 10),
+			EndTimeUnixNano:   strconv.FormatInt(s.end.UnixNano(), 10),
+			Attributes:        otlpAttributes(s.attributes),
+		}
+		s.mtx.Unlock()
+	}
+	return otlpRequest{ResourceSpans: []otlpResourceSpans{{
+		Resource: otlpResource{Attributes: otlpAttributes([]interface{}{
+			"service.name", t.serviceName,
+			"service.instance.id", t.instanceID,
+			"chain_id", t.chainID,
+		})},
+		ScopeSpans: []otlpScopeSpans{{
+			Scope: otlpScope{Name: "github.com/hdac-io/tendermint"},
+			Spans: jsonSpans,
+		}},
+	}}}
+}
+
+func otlpAttributes(kv []interface{}) []otlpKeyValue {
+	attributes := make([]otlpKeyValue, 0, (len(kv)+1)/2)
+	for i := 0; i < len(kv); i += 2 {
+		var value interface{} = "(MISSING)"
+		if i+1 < len(kv) {
+			value = kv[i+1]
+		}
+		attributes = append(attributes, otlpKeyValue{Key: fmt.Sprint(kv[i]), Value: otlpValue(value)})
+	}
+	return attributes
+}
+
+// otlpValue returns the AnyValue of v. Integers are strings in the JSON
+// encoding of the 64 bits integers of protobuf.
+func otlpValue(v interface{}) map[string]interface{} {
+	switch v := v.(type) {
+	case string:
+		return map[string]interface{}{"stringValue": v}
+	case bool:
+		return map[string]interface{}{"boolValue": v}
+	case int:
+		return map[string]interface{}{"intValue": strconv.FormatInt(int64(v), 10)}
+	case int32:
+		return map[string]interface{}{"intValue": strconv.FormatInt(int64(v), 10)}
+	case int64:
+		return map[string]interface{}{"intValue": strconv.FormatInt(v, 10)}
+	case uint32:
+		return map[string]interface{}{"intValue": strconv.FormatUint(uint64(v), 10)}
+	case float64:
+		return map[string]interface{}{"doubleValue": v}
+	case []byte:
+		return map[string]interface{}{"stringValue": fmt.Sprintf("%X", v)}
+	default:
+		return map[string]interface{}{"stringValue": fmt.Sprint(v)}
+	}
+}
@@ -0,0 +1,71 @@
+package trace
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOTLPTracerExport(t *testing.T) {
+	requestsCh := make(chan otlpRequest, 1)
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/traces" || r.Header.Get("Content-Type") != "application/json" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		var req otlpRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		requestsCh <- req
+	}))
+	defer ts.Close()
+
+	tracer := NewOTLPTracer(ts.URL+"/", "tendermint", "test-chain", "node0")
+	require.NoError(t, tracer.Start())
+
+	root := tracer.StartHeight(5)
+	span := tracer.StartSpan(5, "abci.BeginBlock", "txs", 3)
+	span.SetAttributes("ok", true)
+	span.End()
+	span.End() // ignored
+	root.End()
+	tracer.StartSpan(6, "never ended")
+	require.NoError(t, tracer.Stop())
+
+	var req otlpRequest
+	select {
+	case req = <-requestsCh:
+	case <-time.After(5 * time.Second):
+		t.Fatal("spans were not exported")
+	}
+	require.Len(t, req.ResourceSpans, 1)
+	assert.Contains(t, req.ResourceSpans[0].Resource.Attributes,
+		otlpKeyValue{Key: "service.instance.id", Value: map[string]interface{}{"stringValue": "node0"}})
+	require.Len(t, req.ResourceSpans[0].ScopeSpans, 1)
+	spans := req.ResourceSpans[0].ScopeSpans[0].Spans
+	require.Len(t, spans, 2)
+
+	child, parent := spans[0], spans[1]
+	assert.Equal(t, "abci.BeginBlock", child.Name)
+	assert.Equal(t, "height", parent.Name)
+	assert.Equal(t, parent.TraceID, child.TraceID)
+	assert.Equal(t, parent.SpanID, child.ParentSpanID)
+	assert.Empty(t, parent.ParentSpanID)
+	assert.Equal(t, []otlpKeyValue{
+		{Key: "txs", Value: map[string]interface{}{"intValue": "3"}},
+		{Key: "ok", Value: map[string]interface{}{"boolValue": true}},
+	}, child.Attributes)
+
+	// the trace of a height is the same on every node, not the root span
+	other := NewOTLPTracer(ts.URL, "tendermint", "test-chain", "node1")
+	assert.Equal(t, tracer.traceID(5), other.traceID(5))
+	assert.NotEqual(t, tracer.rootSpanID(tracer.traceID(5)), other.rootSpanID(other.traceID(5)))
+	assert.NotEqual(t, tracer.traceID(5), tracer.traceID(6))
+}
@@ -0,0 +1,45 @@
+// Package trace records spans of the work done for each height (consensus
+// steps, block execution, ABCI calls, ...) so the time spent on a height can
+// be broken down, and exports them with the OpenTelemetry protocol (OTLP).
+//
+// All the spans of a height belong to the same trace, whose id only depends on
+// the chain id and the height: the traces of the nodes of a network can be
+// merged by the collector. The spans are children of the root span of their
+// height, so the components don't need to pass spans around.
+package trace
+
+// Tracer starts the spans of the traces of the heights.
+// Implementations must be safe for concurrent use.
+type Tracer interface {
+	// StartHeight starts the root span of the trace of height.
+	StartHeight(height int64) Span
+
+	// StartSpan starts a span of the trace of height, child of its root span.
+	// kv are alternating attribute keys and values, like for log.Logger.
+	StartSpan(height int64, name string, kv ...interface{}) Span
+}
+
+// Span is a timed operation. It is exported once ended.
+// Implementations must be safe for concurrent use.
+type Span interface {
+	// SetAttributes adds the alternating attribute keys and values kv.
+	SetAttributes(kv ...interface{})
+
+	// End ends the span. The following calls are ignored.
+	End()
+}
+
+type nopTracer struct{}
+
+// NopTracer returns a Tracer which doesn't record anything.
+func NopTracer() Tracer {
+	return nopTracer{}
+}
+
+func (nopTracer) StartHeight(int64) Span                       { return nopSpan{} }
+func (nopTracer) StartSpan(int64, string, ...interface{}) Span { return nopSpan{} }
+
+type nopSpan struct{}
+
+func (nopSpan) SetAttributes(...interface{}) {}
+func (nopSpan) End()                         {}
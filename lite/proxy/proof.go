@@ -5,10 +5,5 @@ import (
 )
 
 func defaultProofRuntime() *merkle.ProofRuntime {
-	prt := merkle.NewProofRuntime()
-	prt.RegisterOpDecoder(
-		merkle.ProofOpSimpleValue,
-		merkle.SimpleValueOpDecoder,
-	)
-	return prt
+	return merkle.DefaultProofRuntime()
 }
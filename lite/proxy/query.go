@@ -79,10 +79,7 @@ func GetWithProofOptions(prt *merkle.ProofRuntime, path string, key []byte, opts
 		if err != nil {
 			return nil, err
 		}
-		kp := merkle.KeyPath{}
-		kp = kp.AppendKey([]byte(storeName), merkle.KeyEncodingURL)
-		kp = kp.AppendKey(resp.Key, merkle.KeyEncodingURL)
-		err = prt.VerifyValue(resp.Proof, signedHeader.AppHash, kp.String(), resp.Value)
+		err = rpcclient.VerifyABCIQuery(prt, resp, signedHeader.AppHash, []byte(storeName))
 		if err != nil {
 			return nil, errors.Wrap(err, "Couldn't verify value proof")
 		}
@@ -51,13 +51,22 @@ type CListMempool struct {
 	// txsMap: txKey -> CElement
 	txsMap sync.Map
 
+	// Map for replacing the txs with the dedup key set by the app in CheckTx.
+	// txsDedupMap: string(dedupKey) -> CElement
+	txsDedupMap sync.Map
+
 	// Map for Reservation
 	reserveTxsMap sync.Map
 
+	// Txs of each sender set by the app in CheckTx, with SenderNonceOrder
+	senderQueues *senderQueues
+
 	// Atomic integers
-	height     int64 // the last block Update()'d to
-	txsBytes   int64 // total size of mempool, in bytes
-	rechecking int32 // for re-checking filtered txs on Update()
+	height      int64 // the last block Update()'d to
+	txsBytes    int64 // total size of mempool, in bytes
+	rechecking  int32 // for re-checking filtered txs on Update()
+	maxSize     int64 // max number of txs, config.Size unless SetLimits
+	maxTxsBytes int64 // max txsBytes, config.MaxTxsBytes unless SetLimits
 
 	// Keep a cache of already-seen txs.
 	// This reduces the pressure on the proxyApp.
@@ -89,8 +98,11 @@ func NewCListMempool(
 		txs:           clist.New(),
 		height:        height,
 		rechecking:    0,
+		maxSize:       int64(config.Size),
+		maxTxsBytes:   config.MaxTxsBytes,
 		recheckCursor: nil,
 		recheckEnd:    nil,
+		senderQueues:  newSenderQueues(),
 		logger:        log.NewNopLogger(),
 		metrics:       NopMetrics(),
 	}
@@ -106,6 +118,19 @@ func NewCListMempool(
 	return mempool
 }
 
+// SetLimits sets the max number of txs and total size in bytes of the
+// mempool, e.g. when the config is reloaded. It is safe to call while the
+// mempool is running.
+func (mem *CListMempool) SetLimits(size int, maxTxsBytes int64) {
+	atomic.StoreInt64(&mem.maxSize, int64(size))
+	atomic.StoreInt64(&mem.maxTxsBytes, maxTxsBytes)
+}
+
+// limits returns the max number of txs and total size in bytes of the mempool.
+func (mem *CListMempool) limits() (size int, maxTxsBytes int64) {
+	return int(atomic.LoadInt64(&mem.maxSize)), atomic.LoadInt64(&mem.maxTxsBytes)
+}
+
 // NOTE: not thread safe - should only be called once, on startup
 func (mem *CListMempool) EnableTxsAvailable() {
 	mem.txsAvailable = make(chan struct{}, 1)
@@ -190,6 +215,8 @@ func (mem *CListMempool) Flush() {
 	}
 
 	mem.txsMap = sync.Map{}
+	mem.txsDedupMap = sync.Map{}
+	mem.senderQueues.reset()
 	_ = atomic.SwapInt64(&mem.txsBytes, 0)
 }
 
@@ -200,6 +227,26 @@ func (mem *CListMempool) TxsFront() *clist.CElement {
 	return mem.txs.Front()
 }
 
+// txByKey returns the tx of the given key, if it is in the mempool.
+func (mem *CListMempool) txByKey(key [sha256.Size]byte) (*mempoolTx, bool) {
+	e, ok := mem.txsMap.Load(key)
+	if !ok {
+		return nil, false
+	}
+	return e.(*clist.CElement).Value.(*mempoolTx), true
+}
+
+// seen returns true if the tx of the given key is in the mempool or in the
+// cache. If it is in the mempool, the peer is added to its senders, so it is
+// not gossiped back to the peer.
+func (mem *CListMempool) seen(key [sha256.Size]byte, peerID uint16) bool {
+	if memTx, ok := mem.txByKey(key); ok {
+		memTx.senders.LoadOrStore(peerID, true)
+		return true
+	}
+	return mem.cache.Has(key)
+}
+
 // TxsWaitChan returns a channel to wait on transactions. It will be closed
 // once the mempool is not empty (ie. the internal `mem.txs` has at least one
 // element)
@@ -220,16 +267,23 @@ func (mem *CListMempool) CheckTxWithInfo(tx types.Tx, cb func(*abci.Response), t
 	// use defer to unlock mutex because application (*local client*) might panic
 	defer mem.proxyMtx.Unlock()
 
+	if mem.config.ValidatorLocal() {
+		return ErrValidatorLocal
+	}
+
 	var (
-		memSize  = mem.Size()
-		txsBytes = mem.TxsBytes()
-		txSize   = len(tx)
+		memSize              = mem.Size()
+		txsBytes             = mem.TxsBytes()
+		txSize               = len(tx)
+		maxSize, maxTxsBytes = mem.limits()
 	)
-	if memSize >= mem.config.Size ||
-		int64(txSize)+txsBytes > mem.config.MaxTxsBytes {
+	// with an eviction policy, txs are evicted for the tx once checked
+	if (memSize >= maxSize ||
+		int64(txSize)+txsBytes > maxTxsBytes) && !mem.evicts() {
+		mem.metrics.RejectedTxs.Add(1)
 		return ErrMempoolIsFull{
-			memSize, mem.config.Size,
-			txsBytes, mem.config.MaxTxsBytes}
+			memSize, maxSize,
+			txsBytes, maxTxsBytes}
 	}
 
 	// The size of the corresponding amino-encoded TxMessage
@@ -247,6 +301,7 @@ func (mem *CListMempool) CheckTxWithInfo(tx types.Tx, cb func(*abci.Response), t
 
 	// CACHE
 	if !mem.cache.Push(tx) {
+		mem.metrics.CacheHits.Add(1)
 		// Record a new sender for a tx we've already seen.
 		// Note it's possible a tx is still in the cache but no longer in the mempool
 		// (eg. after committing a block, txs are removed from mempool but not cache),
@@ -262,6 +317,7 @@ func (mem *CListMempool) CheckTxWithInfo(tx types.Tx, cb func(*abci.Response), t
 
 		return ErrTxInCache
 	}
+	mem.metrics.CacheMisses.Add(1)
 	// END CACHE
 
 	// WAL
@@ -341,6 +397,12 @@ func (mem *CListMempool) reqResCb(tx []byte, peerID uint16, externalCb func(*abc
 func (mem *CListMempool) addTx(memTx *mempoolTx) {
 	e := mem.txs.PushBack(memTx)
 	mem.txsMap.Store(txKey(memTx.tx), e)
+	if len(memTx.dedupKey) > 0 {
+		mem.txsDedupMap.Store(string(memTx.dedupKey), e)
+	}
+	if mem.config.SenderNonceOrder && memTx.sender != "" {
+		mem.senderQueues.add(memTx)
+	}
 	atomic.AddInt64(&mem.txsBytes, int64(len(memTx.tx)))
 	mem.metrics.TxSizeBytes.Observe(float64(len(memTx.tx)))
 }
@@ -348,10 +410,21 @@ func (mem *CListMempool) addTx(memTx *mempoolTx) {
 // Called from:
 //  - Update (lock held) if tx was committed
 // 	- resCbRecheck (lock not held) if tx was invalidated
+//  - replaceTx (lock not held) if tx was replaced
 func (mem *CListMempool) removeTx(tx types.Tx, elem *clist.CElement, removeFromCache bool) {
 	mem.txs.Remove(elem)
 	elem.DetachPrev()
 	mem.txsMap.Delete(txKey(tx))
+	memTx := elem.Value.(*mempoolTx)
+	if memTx.sender != "" {
+		mem.senderQueues.remove(memTx)
+	}
+	if dedupKey := memTx.dedupKey; len(dedupKey) > 0 {
+		// the tx may have been replaced already
+		if e, ok := mem.txsDedupMap.Load(string(dedupKey)); ok && e.(*clist.CElement) == elem {
+			mem.txsDedupMap.Delete(string(dedupKey))
+		}
+	}
 	atomic.AddInt64(&mem.txsBytes, int64(-len(tx)))
 
 	if removeFromCache {
@@ -370,12 +443,25 @@ func (mem *CListMempool) resCbFirstTime(tx []byte, peerID uint16, res *abci.Resp
 		if mem.postCheck != nil {
 			postCheckErr = mem.postCheck(tx, r.CheckTx)
 		}
+		memTx := &mempoolTx{
+			height:    mem.height,
+			gasWanted: r.CheckTx.GasWanted,
+			tx:        tx,
+			dedupKey:  r.CheckTx.DedupKey,
+			sender:    r.CheckTx.Sender,
+			nonce:     r.CheckTx.Nonce,
+			priority:  r.CheckTx.Priority,
+
+			conflictKeys: r.CheckTx.ConflictKeys,
+		}
+		var replaceErr, evictErr error
 		if (r.CheckTx.Code == abci.CodeTypeOK) && postCheckErr == nil {
-			memTx := &mempoolTx{
-				height:    mem.height,
-				gasWanted: r.CheckTx.GasWanted,
-				tx:        tx,
-			}
+			replaceErr = mem.replaceTx(tx, r.CheckTx.DedupKey)
+		}
+		if (r.CheckTx.Code == abci.CodeTypeOK) && postCheckErr == nil && replaceErr == nil {
+			evictErr = mem.evict(memTx)
+		}
+		if (r.CheckTx.Code == abci.CodeTypeOK) && postCheckErr == nil && replaceErr == nil && evictErr == nil {
 			memTx.senders.Store(peerID, true)
 			mem.addTx(memTx)
 			mem.logger.Info("Added good transaction",
@@ -385,9 +471,15 @@ func (mem *CListMempool) resCbFirstTime(tx []byte, peerID uint16, res *abci.Resp
 				"total", mem.Size(),
 			)
 			mem.notifyTxsAvailable()
+		} else if evictErr != nil {
+			mem.logger.Info("Rejected transaction, the mempool is full", "tx", txID(tx), "err", evictErr)
+			mem.metrics.RejectedTxs.Add(1)
+			// remove from cache (there may be room later)
+			mem.cache.Remove(tx)
 		} else {
 			// ignore bad transaction
-			mem.logger.Info("Rejected bad transaction", "tx", txID(tx), "res", r, "err", postCheckErr)
+			mem.logger.Info("Rejected bad transaction", "tx", txID(tx), "res", r, "err", postCheckErr,
+				"replaceErr", replaceErr)
 			mem.metrics.FailedTxs.Add(1)
 			// remove from cache (it might be good later)
 			mem.cache.Remove(tx)
@@ -397,6 +489,31 @@ func (mem *CListMempool) resCbFirstTime(tx []byte, peerID uint16, res *abci.Resp
 	}
 }
 
+// replaceTx removes the tx in the mempool with the given dedup key, which the
+// tx replaces. The replaced tx is kept in the cache, so it is not added back
+// when peers gossip it again. It returns an error if the replaced tx is
+// reserved by a proposal block, which may still commit it.
+func (mem *CListMempool) replaceTx(tx types.Tx, dedupKey []byte) error {
+	if len(dedupKey) == 0 {
+		return nil
+	}
+	e, ok := mem.txsDedupMap.Load(string(dedupKey))
+	if !ok {
+		return nil
+	}
+	elem := e.(*clist.CElement)
+	replaced := elem.Value.(*mempoolTx).tx
+	if blockHeight, reserved := mem.ReservedHeight(replaced); reserved {
+		return fmt.Errorf("tx %s with the same dedup key is reserved by the proposal block of height %d",
+			txID(replaced), blockHeight)
+	}
+	mem.removeTx(replaced, elem, false)
+	mem.logger.Info("Replaced transaction", "tx", txID(replaced), "by", txID(tx),
+		"dedupKey", cmn.HexBytes(dedupKey))
+	mem.metrics.ReplacedTxs.Add(1)
+	return nil
+}
+
 // callback, which is called after the app rechecked the tx.
 //
 // The case where the app checks the tx for the first time is handled by the
@@ -477,18 +594,11 @@ func (mem *CListMempool) ReapMaxBytesMaxGas(maxBytes, maxGas int64) types.Txs {
 	// size per tx, and set the initial capacity based off of that.
 	// txs := make([]types.Tx, 0, cmn.MinInt(mem.txs.Len(), max/mem.avgTxSize))
 	txs := make([]types.Tx, 0, mem.txs.Len())
-	for e := mem.txs.Front(); e != nil; e = e.Next() {
-		memTx := e.Value.(*mempoolTx)
-		txHash := txKey(memTx.tx)
-		if blockHeight, reserved := mem.reserveTxsMap.Load(txHash); reserved {
-			mem.logger.Info("skip reserved tx", "height", blockHeight.(int64), "hash", cmn.HexBytes(txHash[:]))
-			continue
-		}
-
+	mem.reapable(func(memTx *mempoolTx) bool {
 		// Check total size requirement
 		aminoOverhead := types.ComputeAminoOverhead(memTx.tx, 1)
 		if maxBytes > -1 && totalBytes+int64(len(memTx.tx))+aminoOverhead > maxBytes {
-			return txs
+			return false
 		}
 		totalBytes += int64(len(memTx.tx)) + aminoOverhead
 		// Check total gas requirement.
@@ -497,11 +607,12 @@ func (mem *CListMempool) ReapMaxBytesMaxGas(maxBytes, maxGas int64) types.Txs {
 		// must be non-negative, it follows that this won't overflow.
 		newTotalGas := totalGas + memTx.gasWanted
 		if maxGas > -1 && newTotalGas > maxGas {
-			return txs
+			return false
 		}
 		totalGas = newTotalGas
 		txs = append(txs, memTx.tx)
-	}
+		return true
+	})
 	return txs
 }
 
@@ -519,17 +630,59 @@ func (mem *CListMempool) ReapMaxTxs(max int) types.Txs {
 	}
 
 	txs := make([]types.Tx, 0, cmn.MinInt(mem.txs.Len(), max))
-	for e := mem.txs.Front(); e != nil && len(txs) <= max; e = e.Next() {
+	mem.reapable(func(memTx *mempoolTx) bool {
+		txs = append(txs, memTx.tx)
+		return len(txs) <= max
+	})
+	return txs
+}
+
+// reapable calls f with the txs to reap, in order, until f returns false. The
+// txs reserved by a proposal block are skipped. With SenderNonceOrder, the txs
+// of a sender are reaped in nonce order where the first of them is, up to a
+// nonce gap.
+func (mem *CListMempool) reapable(f func(memTx *mempoolTx) bool) {
+	var (
+		reapedSenders map[string]bool
+		gapTxs        int
+	)
+	if mem.config.SenderNonceOrder {
+		reapedSenders = make(map[string]bool)
+		defer func() { mem.metrics.NonceGapTxs.Set(float64(gapTxs)) }()
+	}
+	minNonceHeight := mem.height - mem.config.MaxNonceGapBlocks
+
+	for e := mem.txs.Front(); e != nil; e = e.Next() {
 		memTx := e.Value.(*mempoolTx)
-		txHash := txKey(memTx.tx)
-		if blockHeight, reserved := mem.reserveTxsMap.Load(txHash); reserved {
-			mem.logger.Info("skip reserved tx", "height", blockHeight.(int64), "hash", cmn.HexBytes(txHash[:]))
+		if reapedSenders == nil || memTx.sender == "" {
+			if !mem.reserved(memTx) && !f(memTx) {
+				return
+			}
 			continue
 		}
 
-		txs = append(txs, memTx.tx)
+		if reapedSenders[memTx.sender] {
+			continue
+		}
+		reapedSenders[memTx.sender] = true
+		senderTxs, held := mem.senderQueues.releasable(memTx.sender, minNonceHeight)
+		gapTxs += held
+		for _, senderTx := range senderTxs {
+			if !mem.reserved(senderTx) && !f(senderTx) {
+				return
+			}
+		}
 	}
-	return txs
+}
+
+// reserved returns true if memTx is reserved by a proposal block.
+func (mem *CListMempool) reserved(memTx *mempoolTx) bool {
+	txHash := txKey(memTx.tx)
+	if blockHeight, reserved := mem.reserveTxsMap.Load(txHash); reserved {
+		mem.logger.Info("skip reserved tx", "height", blockHeight.(int64), "hash", cmn.HexBytes(txHash[:]))
+		return true
+	}
+	return false
 }
 
 // Reserve marking reserve the mempool that the given txs were received proposal block.
@@ -551,6 +704,26 @@ func (mem *CListMempool) Unreserve(blockTxs types.Txs) {
 	}
 }
 
+// ReservedHeight returns the height of the proposal block which reserved the
+// given tx, if any.
+func (mem *CListMempool) ReservedHeight(tx types.Tx) (int64, bool) {
+	blockHeight, reserved := mem.reserveTxsMap.Load(txKey(tx))
+	if !reserved {
+		return 0, false
+	}
+	return blockHeight.(int64), true
+}
+
+// ConflictKeys returns the conflict keys the app set in the CheckTx of the
+// given tx, if it is in the mempool.
+func (mem *CListMempool) ConflictKeys(tx types.Tx) ([][]byte, bool) {
+	memTx, ok := mem.txByKey(txKey(tx))
+	if !ok {
+		return nil, false
+	}
+	return memTx.conflictKeys, true
+}
+
 func (mem *CListMempool) Update(
 	height int64,
 	txs types.Txs,
@@ -589,11 +762,19 @@ func (mem *CListMempool) Update(
 		//   100
 		// https://github.com/tendermint/tendermint/issues/3322.
 		if e, ok := mem.txsMap.Load(txKey(tx)); ok {
+			// the nonce is passed, even if the tx failed
+			if memTx := e.(*clist.CElement).Value.(*mempoolTx); memTx.sender != "" {
+				mem.senderQueues.committed(memTx.sender, memTx.nonce, height)
+			}
 			mem.removeTx(tx, e.(*clist.CElement), false)
 		}
 
 		mem.reserveTxsMap.Delete(txKey(tx))
 	}
+	mem.senderQueues.prune(height - mem.config.MaxNonceGapBlocks)
+	if mem.config.TTLNumBlocks > 0 {
+		mem.removeExpiredTxs(height)
+	}
 
 	// Either recheck non-committed txs to see if they became invalid
 	// or just notify there're some txs left.
@@ -615,6 +796,24 @@ func (mem *CListMempool) Update(
 	return nil
 }
 
+// removeExpiredTxs removes the txs checked TTLNumBlocks blocks or more before
+// height, unless reserved by a proposal block. They are removed from the cache
+// too, so they can be resubmitted.
+func (mem *CListMempool) removeExpiredTxs(height int64) {
+	for e := mem.txs.Front(); e != nil; e = e.Next() {
+		memTx := e.Value.(*mempoolTx)
+		if height-memTx.Height() < mem.config.TTLNumBlocks {
+			continue
+		}
+		if _, reserved := mem.ReservedHeight(memTx.tx); reserved {
+			continue
+		}
+		mem.removeTx(memTx.tx, e, true)
+		mem.logger.Info("Expired transaction", "tx", txID(memTx.tx), "height", memTx.Height())
+		mem.metrics.ExpiredTxs.Add(1)
+	}
+}
+
 func (mem *CListMempool) recheckTxs() {
 	if mem.Size() == 0 {
 		panic("recheckTxs is called, but the mempool is empty")
@@ -644,6 +843,12 @@ type mempoolTx struct {
 	height    int64    // height that this tx had been validated in
 	gasWanted int64    // amount of gas this tx states it will require
 	tx        types.Tx //
+	dedupKey  []byte   // key set by the app in CheckTx, under which the tx replaces another
+	sender    string   // sender set by the app in CheckTx
+	nonce     uint64   // nonce of the tx of the sender
+	priority  int64    // priority set by the app in CheckTx, for the eviction policy
+
+	conflictKeys [][]byte // keys set by the app in CheckTx, for the parallel DeliverTx
 
 	// ids of peers who've sent us this tx (as a map for quick lookups).
 	// senders: PeerID -> bool
@@ -661,6 +866,7 @@ type txCache interface {
 	Reset()
 	Push(tx types.Tx) bool
 	Remove(tx types.Tx)
+	Has(key [sha256.Size]byte) bool
 }
 
 // mapTxCache maintains a LRU cache of transactions. This only stores the hash
@@ -730,13 +936,22 @@ func (cache *mapTxCache) Remove(tx types.Tx) {
 	cache.mtx.Unlock()
 }
 
+// Has returns true if the tx of the given key is in the cache.
+func (cache *mapTxCache) Has(key [sha256.Size]byte) bool {
+	cache.mtx.Lock()
+	defer cache.mtx.Unlock()
+	_, exists := cache.map_[key]
+	return exists
+}
+
 type nopTxCache struct{}
 
 var _ txCache = (*nopTxCache)(nil)
 
-func (nopTxCache) Reset()             {}
-func (nopTxCache) Push(types.Tx) bool { return true }
-func (nopTxCache) Remove(types.Tx)    {}
+func (nopTxCache) Reset()                    {}
+func (nopTxCache) Push(types.Tx) bool        { return true }
+func (nopTxCache) Remove(types.Tx)           {}
+func (nopTxCache) Has([sha256.Size]byte) bool { return false }
 
 //--------------------------------------------------------------------------------
 
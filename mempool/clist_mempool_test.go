@@ -101,16 +101,213 @@ func TestReserve(t *testing.T) {
 	prevTxs := mempool.ReapMaxTxs(10)
 	assert.NotEqual(t, len(prevTxs), 0)
 
-	mempool.Reserve([]types.Tx{tx0.tx})
+	_, reserved := mempool.ReservedHeight(tx0.tx)
+	assert.False(t, reserved)
+
+	mempool.Reserve(5, []types.Tx{tx0.tx})
 	ReservedTxs := mempool.ReapMaxTxs(10)
 	assert.Equal(t, len(ReservedTxs), len(prevTxs)-1)
 	for _, tx := range ReservedTxs {
 		assert.NotEqual(t, tx, tx0.tx)
 	}
+	height, reserved := mempool.ReservedHeight(tx0.tx)
+	assert.True(t, reserved)
+	assert.EqualValues(t, 5, height)
 
 	mempool.Unreserve([]types.Tx{tx0.tx})
 	unreservedTxs := mempool.ReapMaxTxs(10)
 	assert.Equal(t, len(unreservedTxs), len(prevTxs))
+	_, reserved = mempool.ReservedHeight(tx0.tx)
+	assert.False(t, reserved)
+}
+
+// dedupApp sets the first byte of the tx, like a sender, as its dedup key.
+type dedupApp struct {
+	abci.BaseApplication
+}
+
+func (dedupApp) CheckTx(req abci.RequestCheckTx) abci.ResponseCheckTx {
+	return abci.ResponseCheckTx{Code: abci.CodeTypeOK, DedupKey: req.Tx[:1]}
+}
+
+func TestMempoolReplaceTx(t *testing.T) {
+	cc := proxy.NewLocalClientCreator(dedupApp{})
+	mempool, cleanup := newMempoolWithApp(cc)
+	defer cleanup()
+
+	// a tx replaces the one with the same dedup key
+	for _, tx := range []types.Tx{{0x01, 0x00}, {0x02, 0x00}, {0x01, 0x01}} {
+		require.NoError(t, mempool.CheckTx(tx, nil))
+	}
+	assert.Equal(t, types.Txs{{0x02, 0x00}, {0x01, 0x01}}, mempool.ReapMaxTxs(-1))
+	assert.EqualValues(t, 4, mempool.TxsBytes())
+
+	// the replaced tx stays in the cache
+	assert.Equal(t, ErrTxInCache, mempool.CheckTx([]byte{0x01, 0x00}, nil))
+
+	// a tx reserved by a proposal block is not replaced
+	mempool.Reserve(1, types.Txs{{0x02, 0x00}})
+	require.NoError(t, mempool.CheckTx([]byte{0x02, 0x01}, nil))
+	mempool.Unreserve(types.Txs{{0x02, 0x00}})
+	assert.Equal(t, types.Txs{{0x02, 0x00}, {0x01, 0x01}}, mempool.ReapMaxTxs(-1))
+
+	// a committed tx frees its dedup key
+	err := mempool.Update(1, types.Txs{{0x02, 0x00}}, abciResponses(1, abci.CodeTypeOK), nil, nil)
+	require.NoError(t, err)
+	require.NoError(t, mempool.CheckTx([]byte{0x02, 0x01}, nil))
+	assert.Equal(t, types.Txs{{0x01, 0x01}, {0x02, 0x01}}, mempool.ReapMaxTxs(-1))
+}
+
+// conflictApp sets the bytes of the tx as its conflict keys.
+type conflictApp struct {
+	abci.BaseApplication
+}
+
+func (conflictApp) CheckTx(req abci.RequestCheckTx) abci.ResponseCheckTx {
+	keys := make([][]byte, len(req.Tx))
+	for i := range req.Tx {
+		keys[i] = req.Tx[i : i+1]
+	}
+	return abci.ResponseCheckTx{Code: abci.CodeTypeOK, ConflictKeys: keys}
+}
+
+func TestMempoolConflictKeys(t *testing.T) {
+	cc := proxy.NewLocalClientCreator(conflictApp{})
+	mempool, cleanup := newMempoolWithApp(cc)
+	defer cleanup()
+
+	require.NoError(t, mempool.CheckTx(types.Tx{0x01, 0x02}, nil))
+	keys, ok := mempool.ConflictKeys(types.Tx{0x01, 0x02})
+	require.True(t, ok)
+	assert.Equal(t, [][]byte{{0x01}, {0x02}}, keys)
+
+	_, ok = mempool.ConflictKeys(types.Tx{0x03})
+	assert.False(t, ok)
+
+	// the committed txs are forgotten
+	err := mempool.Update(1, types.Txs{{0x01, 0x02}}, abciResponses(1, abci.CodeTypeOK), nil, nil)
+	require.NoError(t, err)
+	_, ok = mempool.ConflictKeys(types.Tx{0x01, 0x02})
+	assert.False(t, ok)
+}
+
+// senderApp sets the first byte of the tx as its sender, and the second one as
+// its nonce.
+type senderApp struct {
+	abci.BaseApplication
+}
+
+func (senderApp) CheckTx(req abci.RequestCheckTx) abci.ResponseCheckTx {
+	return abci.ResponseCheckTx{Code: abci.CodeTypeOK, Sender: string(req.Tx[:1]), Nonce: uint64(req.Tx[1])}
+}
+
+func TestMempoolSenderNonceOrder(t *testing.T) {
+	config := cfg.ResetTestRoot("mempool_test")
+	config.Mempool.SenderNonceOrder = true
+	config.Mempool.MaxNonceGapBlocks = 2
+	mempool, cleanup := newMempoolWithAppAndConfig(proxy.NewLocalClientCreator(senderApp{}), config)
+	defer cleanup()
+
+	// the txs of a sender are reaped in nonce order, up to the gap at nonce 3
+	for _, tx := range []types.Tx{{0x01, 2}, {0x02, 7}, {0x01, 1}, {0x01, 4}, {0x01, 0}} {
+		require.NoError(t, mempool.CheckTx(tx, nil))
+	}
+	assert.Equal(t, types.Txs{{0x01, 0}, {0x01, 1}, {0x01, 2}, {0x02, 7}}, mempool.ReapMaxTxs(-1))
+	assert.Equal(t, types.Txs{{0x01, 0}, {0x01, 1}}, mempool.ReapMaxBytesMaxGas(2*(2+2), -1))
+
+	// the txs reserved by a proposal block are skipped, but fill the gaps
+	mempool.Reserve(1, types.Txs{{0x01, 0}, {0x01, 1}})
+	require.NoError(t, mempool.CheckTx([]byte{0x01, 3}, nil))
+	assert.Equal(t, types.Txs{{0x01, 2}, {0x01, 3}, {0x01, 4}, {0x02, 7}}, mempool.ReapMaxTxs(-1))
+
+	// after a committed tx, the next nonce is expected
+	err := mempool.Update(1, types.Txs{{0x01, 0}, {0x01, 1}, {0x01, 2}, {0x01, 3}}, abciResponses(4, abci.CodeTypeOK), nil, nil)
+	require.NoError(t, err)
+	require.NoError(t, mempool.CheckTx([]byte{0x01, 6}, nil))
+	assert.Equal(t, types.Txs{{0x02, 7}, {0x01, 4}}, mempool.ReapMaxTxs(-1))
+	err = mempool.Update(2, types.Txs{{0x01, 4}}, abciResponses(1, abci.CodeTypeOK), nil, nil)
+	require.NoError(t, err)
+	assert.Equal(t, types.Txs{{0x02, 7}}, mempool.ReapMaxTxs(-1))
+
+	// until the gap lasts more than MaxNonceGapBlocks blocks
+	for height := int64(3); height <= 5; height++ {
+		require.NoError(t, mempool.Update(height, types.Txs{}, nil, nil, nil))
+	}
+	assert.Equal(t, types.Txs{{0x02, 7}, {0x01, 6}}, mempool.ReapMaxTxs(-1))
+}
+
+// priorityApp sets the first byte of the tx as its sender, and the second one
+// as its priority.
+type priorityApp struct {
+	abci.BaseApplication
+}
+
+func (priorityApp) CheckTx(req abci.RequestCheckTx) abci.ResponseCheckTx {
+	return abci.ResponseCheckTx{Code: abci.CodeTypeOK, Sender: string(req.Tx[:1]), Priority: int64(req.Tx[1])}
+}
+
+func TestMempoolEvictionPolicy(t *testing.T) {
+	txs := types.Txs{{0x01, 5}, {0x02, 3}, {0x01, 1}, {0x01, 4}}
+	testCases := []struct {
+		policy string
+		tx     types.Tx
+		reaped types.Txs
+	}{
+		{"none", types.Tx{0x02, 9}, txs[1:]},
+		// the lowest priority below the one of the tx
+		{"lowest_priority", types.Tx{0x02, 9}, types.Txs{{0x02, 3}, {0x01, 4}, {0x02, 9}}},
+		{"lowest_priority", types.Tx{0x02, 0}, txs[1:]},
+		// the oldest tx not reserved
+		{"oldest", types.Tx{0x02, 0}, types.Txs{{0x02, 3}, {0x01, 4}, {0x02, 0}}},
+		// the last tx of the sender above its budget, counting the reserved txs
+		{"sender_budget", types.Tx{0x03, 0}, types.Txs{{0x02, 3}, {0x01, 1}, {0x03, 0}}},
+		{"sender_budget", types.Tx{0x02, 0}, txs[1:]},
+	}
+	for _, tc := range testCases {
+		config := cfg.ResetTestRoot("mempool_test")
+		config.Mempool.Size = 3
+		config.Mempool.EvictionPolicy = tc.policy
+		config.Mempool.SenderBudget = 1
+		mempool, cleanup := newMempoolWithAppAndConfig(proxy.NewLocalClientCreator(priorityApp{}), config)
+
+		for _, tx := range txs[:3] {
+			require.NoError(t, mempool.CheckTx(tx, nil))
+		}
+		mempool.Reserve(1, types.Txs{txs[0]})
+		require.NoError(t, mempool.Update(1, types.Txs{txs[0]}, abciResponses(1, abci.CodeTypeOK), nil, nil))
+		require.NoError(t, mempool.CheckTx(txs[3], nil))
+		mempool.Reserve(2, types.Txs{txs[1]})
+
+		err := mempool.CheckTx(tc.tx, nil)
+		if tc.policy == "none" {
+			assert.IsType(t, ErrMempoolIsFull{}, err)
+		} else {
+			assert.NoError(t, err)
+		}
+		mempool.Unreserve(types.Txs{txs[1]})
+		assert.Equal(t, tc.reaped, mempool.ReapMaxTxs(-1), "%v %X", tc.policy, tc.tx)
+		cleanup()
+	}
+}
+
+func TestMempoolTTLNumBlocks(t *testing.T) {
+	config := cfg.ResetTestRoot("mempool_test")
+	config.Mempool.TTLNumBlocks = 2
+	mempool, cleanup := newMempoolWithAppAndConfig(proxy.NewLocalClientCreator(priorityApp{}), config)
+	defer cleanup()
+
+	require.NoError(t, mempool.CheckTx([]byte{0x01, 0}, nil))
+	require.NoError(t, mempool.Update(1, types.Txs{}, nil, nil, nil))
+	require.NoError(t, mempool.CheckTx([]byte{0x02, 0}, nil))
+	require.NoError(t, mempool.Update(2, types.Txs{}, nil, nil, nil))
+	assert.Equal(t, types.Txs{{0x02, 0}}, mempool.ReapMaxTxs(-1))
+
+	// the expired tx can be resubmitted, but not a reserved one
+	mempool.Reserve(3, types.Txs{{0x02, 0}})
+	require.NoError(t, mempool.CheckTx([]byte{0x01, 0}, nil))
+	require.NoError(t, mempool.Update(3, types.Txs{}, nil, nil, nil))
+	mempool.Unreserve(types.Txs{{0x02, 0}})
+	assert.Equal(t, types.Txs{{0x02, 0}, {0x01, 0}}, mempool.ReapMaxTxs(-1))
 }
 
 func TestReapMaxBytesMaxGas(t *testing.T) {
@@ -192,6 +389,9 @@ func TestMempoolFilters(t *testing.T) {
 		{10, PreCheckAminoMaxBytes(30), PostCheckMaxGas(20), 10},
 		{10, PreCheckAminoMaxBytes(22), PostCheckMaxGas(1), 10},
 		{10, PreCheckAminoMaxBytes(22), PostCheckMaxGas(0), 0},
+		{10, PreCheckMaxTxBytes(0), nopPostFilter, 10},
+		{10, PreCheckMaxTxBytes(19), nopPostFilter, 0},
+		{10, PreCheckMaxTxBytes(20), nopPostFilter, 10},
 	}
 	for tcIndex, tt := range tests {
 		mempool.Update(1, emptyTxArr, abciResponses(len(emptyTxArr), abci.CodeTypeOK), tt.preFilter, tt.postFilter)
@@ -531,7 +731,17 @@ func TestMempoolTxsBytes(t *testing.T) {
 		assert.IsType(t, ErrMempoolIsFull{}, err)
 	}
 
-	// 6. zero after tx is rechecked and removed due to not being valid anymore
+	// 6. the limits set when the config is reloaded apply to the next txs
+	mempool.SetLimits(config.Mempool.Size, 11)
+	err = mempool.CheckTx([]byte{0x05}, nil)
+	require.NoError(t, err)
+	mempool.SetLimits(1, 100)
+	err = mempool.CheckTx([]byte{0x06}, nil)
+	if assert.Error(t, err) {
+		assert.IsType(t, ErrMempoolIsFull{}, err)
+	}
+
+	// 7. zero after tx is rechecked and removed due to not being valid anymore
 	app2 := counter.NewCounterApplication(true)
 	cc = proxy.NewLocalClientCreator(app2)
 	mempool, cleanup = newMempoolWithApp(cc)
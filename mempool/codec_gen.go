@@ -0,0 +1,23 @@
+// Code generated by scripts/codecgen from its manifest. DO NOT EDIT.
+
+package mempool
+
+import (
+	amino "github.com/tendermint/go-amino"
+)
+
+var cdc = amino.NewCodec()
+
+func init() {
+	RegisterMempoolMessages(cdc)
+}
+
+// RegisterMempoolMessages registers the messages of the mempool reactor.
+func RegisterMempoolMessages(cdc *amino.Codec) {
+	cdc.RegisterInterface((*MempoolMessage)(nil), nil)
+	cdc.RegisterConcrete(&TxMessage{}, "tendermint/mempool/TxMessage", nil)
+	cdc.RegisterConcrete(&TxHashesMessage{}, "tendermint/mempool/TxHashesMessage", nil)
+	cdc.RegisterConcrete(&WantTxsMessage{}, "tendermint/mempool/WantTxsMessage", nil)
+	cdc.RegisterConcrete(&PullTxsMessage{}, "tendermint/mempool/PullTxsMessage", nil)
+	cdc.RegisterConcrete(&PulledTxsMessage{}, "tendermint/mempool/PulledTxsMessage", nil)
+}
@@ -9,6 +9,10 @@ import (
 var (
 	// ErrTxInCache is returned to the client if we saw tx earlier
 	ErrTxInCache = errors.New("Tx already exists in cache")
+
+	// ErrValidatorLocal is returned to the client if the mempool runs the
+	// validator_local mode, which accepts no tx
+	ErrValidatorLocal = errors.New("Mempool accepts no tx in the validator_local mode, send it to an entry node")
 )
 
 // ErrTxTooLarge means the tx is too big to be sent in a message to other peers
@@ -0,0 +1,132 @@
+package mempool
+
+import (
+	"sort"
+
+	"github.com/hdac-io/tendermint/libs/clist"
+)
+
+// evicts returns true if the mempool evicts txs for a new tx when it is full,
+// instead of rejecting the new tx.
+func (mem *CListMempool) evicts() bool {
+	switch mem.config.EvictionPolicy {
+	case "lowest_priority", "oldest", "sender_budget":
+		return true
+	default:
+		return false
+	}
+}
+
+// evict evicts txs by the eviction policy until the mempool has room for
+// memTx. If the policy can't make room, nothing is evicted and
+// ErrMempoolIsFull is returned. The evicted txs stay in the cache, so they are
+// not added back when peers gossip them again.
+func (mem *CListMempool) evict(memTx *mempoolTx) error {
+	if !mem.evicts() {
+		return nil
+	}
+	maxSize, maxTxsBytes := mem.limits()
+	needTxs := int64(mem.Size() + 1 - maxSize)
+	needBytes := mem.TxsBytes() + int64(len(memTx.tx)) - maxTxsBytes
+	if needTxs <= 0 && needBytes <= 0 {
+		return nil
+	}
+
+	var evicted []*clist.CElement
+	for _, e := range mem.evictionCandidates(memTx) {
+		if needTxs <= 0 && needBytes <= 0 {
+			break
+		}
+		evicted = append(evicted, e)
+		needTxs--
+		needBytes -= int64(len(e.Value.(*mempoolTx).tx))
+	}
+	if needTxs > 0 || needBytes > 0 {
+		return ErrMempoolIsFull{
+			mem.Size(), maxSize,
+			mem.TxsBytes(), maxTxsBytes}
+	}
+
+	for _, e := range evicted {
+		evictedTx := e.Value.(*mempoolTx)
+		mem.removeTx(evictedTx.tx, e, false)
+		mem.logger.Info("Evicted transaction", "tx", txID(evictedTx.tx), "by", txID(memTx.tx),
+			"policy", mem.config.EvictionPolicy)
+		mem.metrics.EvictedTxs.Add(1)
+	}
+	return nil
+}
+
+// evictionCandidates returns the txs the eviction policy may evict for memTx,
+// in eviction order. The txs reserved by a proposal block, which may still
+// commit them, are not candidates.
+func (mem *CListMempool) evictionCandidates(memTx *mempoolTx) []*clist.CElement {
+	var elems []*clist.CElement
+	for e := mem.txs.Front(); e != nil; e = e.Next() {
+		elems = append(elems, e)
+	}
+	switch mem.config.EvictionPolicy {
+	case "oldest":
+	case "lowest_priority":
+		elems = lowestPriorityCandidates(elems, memTx.priority)
+	case "sender_budget":
+		elems = senderBudgetCandidates(elems, memTx.sender, mem.config.SenderBudget)
+	default:
+		return nil
+	}
+
+	candidates := elems[:0]
+	for _, e := range elems {
+		if _, reserved := mem.ReservedHeight(e.Value.(*mempoolTx).tx); !reserved {
+			candidates = append(candidates, e)
+		}
+	}
+	return candidates
+}
+
+// lowestPriorityCandidates returns the txs of elems of a priority below
+// priority, the lowest first, and the oldest first among the same priority.
+func lowestPriorityCandidates(elems []*clist.CElement, priority int64) []*clist.CElement {
+	var candidates []*clist.CElement
+	for _, e := range elems {
+		if e.Value.(*mempoolTx).priority < priority {
+			candidates = append(candidates, e)
+		}
+	}
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return candidates[i].Value.(*mempoolTx).priority < candidates[j].Value.(*mempoolTx).priority
+	})
+	return candidates
+}
+
+// senderBudgetCandidates returns the txs of elems above the budget of their
+// sender: the last tx of the sender with the most txs first, until every
+// sender is within budget. A new tx of sender is itself above the budget if
+// the sender has budget txs already, and nothing is evicted for it.
+func senderBudgetCandidates(elems []*clist.CElement, sender string, budget int) []*clist.CElement {
+	senderTxs := make(map[string][]*clist.CElement)
+	for _, e := range elems {
+		if s := e.Value.(*mempoolTx).sender; s != "" {
+			senderTxs[s] = append(senderTxs[s], e)
+		}
+	}
+	if sender != "" && len(senderTxs[sender]) >= budget {
+		return nil
+	}
+
+	var candidates []*clist.CElement
+	for {
+		var most string
+		for s, txs := range senderTxs {
+			if len(txs) > budget && (len(txs) > len(senderTxs[most]) || (len(txs) == len(senderTxs[most]) && s < most)) {
+				most = s
+			}
+		}
+		if most == "" {
+			return candidates
+		}
+		txs := senderTxs[most]
+		candidates = append(candidates, txs[len(txs)-1])
+		senderTxs[most] = txs[:len(txs)-1]
+	}
+}
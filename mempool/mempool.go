@@ -46,6 +46,15 @@ type Mempool interface {
 	// Unreserve Update unmarking reserve the mempool that the given txs were previous failed round proposal block.
 	Unreserve(blockTxs types.Txs)
 
+	// ReservedHeight returns the height of the proposal block which reserved
+	// the given tx, if any.
+	ReservedHeight(tx types.Tx) (height int64, reserved bool)
+
+	// ConflictKeys returns the keys of the state the given tx reads or writes,
+	// set by the app in CheckTx (see abci.ResponseCheckTx.ConflictKeys), if the
+	// tx is in the mempool.
+	ConflictKeys(tx types.Tx) (keys [][]byte, ok bool)
+
 	// Update informs the mempool that the given txs were committed and can be discarded.
 	// NOTE: this should be called *after* block is committed by consensus.
 	// NOTE: unsafe; Lock/Unlock must be managed by caller
@@ -122,6 +131,17 @@ func PreCheckAminoMaxBytes(maxBytes int64) PreCheckFunc {
 	}
 }
 
+// PreCheckMaxTxBytes checks that the size of the transaction is smaller or
+// equal to maxTxBytes. Returns nil if maxTxBytes is 0.
+func PreCheckMaxTxBytes(maxTxBytes int64) PreCheckFunc {
+	return func(tx types.Tx) error {
+		if maxTxBytes > 0 && int64(len(tx)) > maxTxBytes {
+			return fmt.Errorf("Tx size is too big: %d, max: %d", len(tx), maxTxBytes)
+		}
+		return nil
+	}
+}
+
 // PostCheckMaxGas checks that the wanted gas is smaller or equal to the passed
 // maxGas. Returns nil if maxGas is -1.
 func PostCheckMaxGas(maxGas int64) PostCheckFunc {
@@ -24,6 +24,20 @@ type Metrics struct {
 	FailedTxs metrics.Counter
 	// Number of times transactions are rechecked in the mempool.
 	RecheckTimes metrics.Counter
+	// Number of transactions replaced by one with the same dedup key.
+	ReplacedTxs metrics.Counter
+	// Number of transactions held back by a nonce gap of their sender at the
+	// last reap.
+	NonceGapTxs metrics.Gauge
+	// Number of transactions evicted to make room for a new one.
+	EvictedTxs metrics.Counter
+	// Number of transactions rejected because the mempool is full.
+	RejectedTxs metrics.Counter
+	// Number of transactions removed after TTLNumBlocks blocks.
+	ExpiredTxs metrics.Counter
+	// Number of transactions found, or not, in the cache of the seen txs.
+	CacheHits   metrics.Counter
+	CacheMisses metrics.Counter
 }
 
 // PrometheusMetrics returns Metrics build using Prometheus client library.
@@ -60,6 +74,48 @@ func PrometheusMetrics(namespace string, labelsAndValues ...string) *Metrics {
 			Name:      "recheck_times",
 			Help:      "Number of times transactions are rechecked in the mempool.",
 		}, labels).With(labelsAndValues...),
+		ReplacedTxs: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "replaced_txs",
+			Help:      "Number of transactions replaced by one with the same dedup key.",
+		}, labels).With(labelsAndValues...),
+		NonceGapTxs: prometheus.NewGaugeFrom(stdprometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "nonce_gap_txs",
+			Help:      "Number of transactions held back by a nonce gap of their sender at the last reap.",
+		}, labels).With(labelsAndValues...),
+		EvictedTxs: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "evicted_txs",
+			Help:      "Number of transactions evicted to make room for a new one.",
+		}, labels).With(labelsAndValues...),
+		RejectedTxs: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "rejected_txs",
+			Help:      "Number of transactions rejected because the mempool is full.",
+		}, labels).With(labelsAndValues...),
+		ExpiredTxs: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "expired_txs",
+			Help:      "Number of transactions removed after ttl_num_blocks blocks.",
+		}, labels).With(labelsAndValues...),
+		CacheHits: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "cache_hits",
+			Help:      "Number of transactions found in the cache of the seen txs.",
+		}, labels).With(labelsAndValues...),
+		CacheMisses: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "cache_misses",
+			Help:      "Number of transactions not found in the cache of the seen txs.",
+		}, labels).With(labelsAndValues...),
 	}
 }
 
@@ -70,5 +126,12 @@ func NopMetrics() *Metrics {
 		TxSizeBytes:  discard.NewHistogram(),
 		FailedTxs:    discard.NewCounter(),
 		RecheckTimes: discard.NewCounter(),
+		ReplacedTxs:  discard.NewCounter(),
+		NonceGapTxs:  discard.NewGauge(),
+		EvictedTxs:   discard.NewCounter(),
+		RejectedTxs:  discard.NewCounter(),
+		ExpiredTxs:   discard.NewCounter(),
+		CacheHits:    discard.NewCounter(),
+		CacheMisses:  discard.NewCounter(),
 	}
 }
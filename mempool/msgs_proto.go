@@ -0,0 +1,85 @@
+package mempool
+
+import (
+	"fmt"
+
+	"github.com/hdac-io/tendermint/p2p"
+	"github.com/hdac-io/tendermint/types"
+	"github.com/hdac-io/tendermint/types/msgs"
+)
+
+// encodeMsg encodes msg for peer, with protobuf if the peer uses it, see
+// p2p.Switch.ProtoMsgs, else amino.
+func (memR *Reactor) encodeMsg(peer p2p.Peer, msg MempoolMessage) []byte {
+	if !memR.Switch.ProtoMsgs(peer) {
+		return cdc.MustMarshalBinaryBare(msg)
+	}
+	bz, err := msgToProto(msg).Marshal()
+	if err != nil {
+		panic(err)
+	}
+	return bz
+}
+
+// decodePeerMsg decodes a message encoded with protobuf if proto is true,
+// else amino.
+func (memR *Reactor) decodePeerMsg(chID byte, bz []byte, proto bool) (MempoolMessage, error) {
+	if !proto {
+		return memR.decodeMsg(chID, bz)
+	}
+	maxMsgSize := memR.maxMsgSize(chID)
+	if l := len(bz); l > maxMsgSize {
+		return nil, ErrTxTooLarge{maxMsgSize, l}
+	}
+	var pb msgs.MempoolMessage
+	if err := pb.Unmarshal(bz); err != nil {
+		return nil, err
+	}
+	return msgFromProto(&pb)
+}
+
+func msgToProto(msg MempoolMessage) *msgs.MempoolMessage {
+	pb := &msgs.MempoolMessage{}
+	switch msg := msg.(type) {
+	case *TxMessage:
+		pb.Sum = &msgs.MempoolMessage_Tx{Tx: &msgs.TxMessage{Tx: msg.Tx}}
+	case *TxHashesMessage:
+		pb.Sum = &msgs.MempoolMessage_TxHashes{TxHashes: &msgs.TxHashesMessage{Hashes: msg.Hashes}}
+	case *WantTxsMessage:
+		pb.Sum = &msgs.MempoolMessage_WantTxs{WantTxs: &msgs.WantTxsMessage{Hashes: msg.Hashes}}
+	case *PullTxsMessage:
+		pb.Sum = &msgs.MempoolMessage_PullTxs{PullTxs: &msgs.PullTxsMessage{
+			RequestId: msg.RequestID, MaxBytes: msg.MaxBytes, MaxGas: msg.MaxGas}}
+	case *PulledTxsMessage:
+		txs := make([][]byte, len(msg.Txs))
+		for i, tx := range msg.Txs {
+			txs[i] = tx
+		}
+		pb.Sum = &msgs.MempoolMessage_PulledTxs{PulledTxs: &msgs.PulledTxsMessage{RequestId: msg.RequestID, Txs: txs}}
+	default:
+		panic(fmt.Sprintf("Unknown message type %T", msg))
+	}
+	return pb
+}
+
+func msgFromProto(pb *msgs.MempoolMessage) (MempoolMessage, error) {
+	switch sum := pb.Sum.(type) {
+	case *msgs.MempoolMessage_Tx:
+		return &TxMessage{Tx: sum.Tx.Tx}, nil
+	case *msgs.MempoolMessage_TxHashes:
+		return &TxHashesMessage{Hashes: sum.TxHashes.Hashes}, nil
+	case *msgs.MempoolMessage_WantTxs:
+		return &WantTxsMessage{Hashes: sum.WantTxs.Hashes}, nil
+	case *msgs.MempoolMessage_PullTxs:
+		return &PullTxsMessage{
+			RequestID: sum.PullTxs.RequestId, MaxBytes: sum.PullTxs.MaxBytes, MaxGas: sum.PullTxs.MaxGas}, nil
+	case *msgs.MempoolMessage_PulledTxs:
+		txs := make(types.Txs, len(sum.PulledTxs.Txs))
+		for i, tx := range sum.PulledTxs.Txs {
+			txs[i] = tx
+		}
+		return &PulledTxsMessage{RequestID: sum.PulledTxs.RequestId, Txs: txs}, nil
+	default:
+		return nil, fmt.Errorf("unknown message %T", pb.Sum)
+	}
+}
@@ -0,0 +1,268 @@
+package mempool
+
+import (
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"time"
+
+	abcicli "github.com/hdac-io/tendermint/abci/client"
+	abci "github.com/hdac-io/tendermint/abci/types"
+	"github.com/hdac-io/tendermint/p2p"
+	"github.com/hdac-io/tendermint/types"
+)
+
+// maxPulledTxsMsgSize is the max size of a PulledTxsMessage, which carries
+// the txs of a block.
+const maxPulledTxsMsgSize = types.MaxBlockSizeBytes + 1024
+
+// pulledTx is a tx pulled from the peers, which passed CheckTx.
+type pulledTx struct {
+	tx        types.Tx
+	gasWanted int64
+}
+
+// pull is a PullTxsMessage waiting for the txs of the peers it was sent to.
+type pull struct {
+	peers  map[p2p.ID]bool
+	pulled chan types.Txs
+}
+
+// PullTxs implements state.TxPuller, for the validators running the
+// validator_local mode: it returns, without waiting, the txs pulled from the
+// peers in the background (see pullTxsRoutine) up to maxBytes and maxGas,
+// which are the limits of the next pulls. The txs returned are not returned
+// again, and the txs committed since they were pulled are dropped.
+func (memR *Reactor) PullTxs(maxBytes, maxGas int64) types.Txs {
+	memR.pulledMtx.Lock()
+	defer memR.pulledMtx.Unlock()
+	memR.pullMaxBytes, memR.pullMaxGas = maxBytes, maxGas
+
+	var (
+		txs                  types.Txs
+		totalBytes, totalGas int64
+		rest                 []pulledTx
+	)
+	for _, ptx := range memR.pulled {
+		if memR.mempool.cache.Has(txKey(ptx.tx)) {
+			continue
+		}
+		txBytes := int64(len(ptx.tx)) + types.ComputeAminoOverhead(ptx.tx, 1)
+		if totalBytes+txBytes > maxBytes || (maxGas > -1 && totalGas+ptx.gasWanted > maxGas) {
+			rest = append(rest, ptx)
+			continue
+		}
+		totalBytes += txBytes
+		totalGas += ptx.gasWanted
+		txs = append(txs, ptx.tx)
+	}
+	memR.pulled = rest
+	return txs
+}
+
+// pullTxsRoutine pulls the txs of the next proposals from the peers every
+// PullTxsInterval, once the limits of the proposals are known, for PullTxs not
+// to wait for the peers.
+func (memR *Reactor) pullTxsRoutine() {
+	ticker := time.NewTicker(memR.config.PullTxsInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+		case <-memR.Quit():
+			return
+		}
+
+		memR.pulledMtx.Lock()
+		maxBytes, maxGas := memR.pullMaxBytes, memR.pullMaxGas
+		memR.pulledMtx.Unlock()
+		if maxBytes == 0 {
+			// no proposal yet
+			continue
+		}
+		txs := memR.mempool.checkPulledTxs(memR.pullTxs(maxBytes, maxGas), maxBytes, maxGas)
+		memR.pulledMtx.Lock()
+		memR.pulled = txs
+		memR.pulledMtx.Unlock()
+	}
+}
+
+// pullTxs requests txs up to maxBytes and maxGas from the peers of
+// PullTxsPeerIDs, and returns the first txs received. It returns no tx after
+// PullTxsTimeout, or once every peer answered without txs.
+func (memR *Reactor) pullTxs(maxBytes, maxGas int64) types.Txs {
+	var peers []p2p.Peer
+	memR.pullMtx.Lock()
+	for _, peer := range memR.Switch.Peers().List() {
+		if memR.pullTxsPeers[peer.ID()] && peerHasChannel(peer, PullTxsChannel) {
+			peers = append(peers, peer)
+		}
+	}
+	if len(peers) == 0 {
+		memR.pullMtx.Unlock()
+		return nil
+	}
+	memR.pullID++
+	msg := &PullTxsMessage{RequestID: memR.pullID, MaxBytes: maxBytes, MaxGas: maxGas}
+	p := &pull{peers: make(map[p2p.ID]bool), pulled: make(chan types.Txs, len(peers))}
+	memR.pulls[msg.RequestID] = p
+	memR.pullMtx.Unlock()
+	defer func() {
+		memR.pullMtx.Lock()
+		delete(memR.pulls, msg.RequestID)
+		memR.pullMtx.Unlock()
+	}()
+
+	sent := 0
+	for _, peer := range peers {
+		memR.pullMtx.Lock()
+		p.peers[peer.ID()] = true
+		memR.pullMtx.Unlock()
+		if peer.Send(PullTxsChannel, memR.encodeMsg(peer, msg)) {
+			sent++
+		}
+	}
+	timeout := time.NewTimer(memR.config.PullTxsTimeout)
+	defer timeout.Stop()
+	for ; sent > 0; sent-- {
+		select {
+		case txs := <-p.pulled:
+			if len(txs) > 0 {
+				memR.Logger.Debug("Pulled txs", "txs", len(txs), "requestID", msg.RequestID)
+				return txs
+			}
+		case <-timeout.C:
+			memR.Logger.Info("No txs pulled from the peers", "timeout", memR.config.PullTxsTimeout)
+			return nil
+		case <-memR.Quit():
+			return nil
+		}
+	}
+	return nil
+}
+
+// sendPulledTxs sends the txs of the mempool requested by the peer, if it is
+// one of PullTxsPeerIDs and its last pull was served at least half
+// PullTxsInterval ago.
+func (memR *Reactor) sendPulledTxs(peer p2p.Peer, msg *PullTxsMessage) {
+	now := time.Now()
+	memR.pullMtx.Lock()
+	if !memR.pullTxsPeers[peer.ID()] {
+		memR.pullMtx.Unlock()
+		memR.Logger.Debug("Ignoring the txs pull of a peer not in pull_txs_peer_ids", "peer", peer)
+		return
+	}
+	last, ok := memR.servedPulls[peer.ID()]
+	if ok && now.Sub(last) < memR.config.PullTxsInterval/2 {
+		memR.pullMtx.Unlock()
+		memR.Logger.Debug("Ignoring a txs pull over the rate limit", "peer", peer)
+		return
+	}
+	memR.servedPulls[peer.ID()] = now
+	memR.pullMtx.Unlock()
+
+	txs := memR.mempool.ReapMaxBytesMaxGas(msg.MaxBytes, msg.MaxGas)
+	peer.TrySend(PullTxsChannel, memR.encodeMsg(peer, &PulledTxsMessage{RequestID: msg.RequestID, Txs: txs}))
+}
+
+// receivePulledTxs hands the txs pulled from the peer to the request waiting
+// for them, if any was sent to the peer.
+func (memR *Reactor) receivePulledTxs(peer p2p.Peer, msg *PulledTxsMessage) {
+	memR.pullMtx.Lock()
+	p, ok := memR.pulls[msg.RequestID]
+	if ok && !p.peers[peer.ID()] {
+		ok = false
+	}
+	if ok {
+		// the peer answers once
+		delete(p.peers, peer.ID())
+	}
+	memR.pullMtx.Unlock()
+	if !ok {
+		return
+	}
+	p.pulled <- msg.Txs
+}
+
+// checkPulledTxs returns the txs of txs which pass CheckTx and are not in the
+// cache, e.g. committed, up to maxBytes and maxGas like ReapMaxBytesMaxGas.
+// They are not added to the mempool.
+func (mem *CListMempool) checkPulledTxs(txs types.Txs, maxBytes, maxGas int64) []pulledTx {
+	mem.proxyMtx.Lock()
+	defer mem.proxyMtx.Unlock()
+
+	reqs := make([]*abcicli.ReqRes, 0, len(txs))
+	seen := make(map[[sha256.Size]byte]bool)
+	for _, tx := range txs {
+		key := txKey(tx)
+		if seen[key] || mem.cache.Has(key) {
+			continue
+		}
+		seen[key] = true
+		reqs = append(reqs, mem.proxyAppConn.CheckTxAsync(abci.RequestCheckTx{Tx: tx}))
+	}
+	if err := mem.proxyAppConn.FlushSync(); err != nil {
+		mem.logger.Error("Failed to check the pulled txs", "err", err)
+		return nil
+	}
+
+	var (
+		checked              []pulledTx
+		totalBytes, totalGas int64
+	)
+	// FlushSync returns once the responses of the requests before it are in
+	for _, reqRes := range reqs {
+		tx := types.Tx(reqRes.Request.GetCheckTx().Tx)
+		res := reqRes.Response.GetCheckTx()
+		if res == nil || res.Code != abci.CodeTypeOK {
+			mem.logger.Debug("Dropping a pulled tx rejected by CheckTx", "tx", txID(tx))
+			continue
+		}
+		totalBytes += int64(len(tx)) + types.ComputeAminoOverhead(tx, 1)
+		totalGas += res.GasWanted
+		if totalBytes > maxBytes || (maxGas > -1 && totalGas > maxGas) {
+			break
+		}
+		checked = append(checked, pulledTx{tx: tx, gasWanted: res.GasWanted})
+	}
+	return checked
+}
+
+//-------------------------------------
+
+// PullTxsMessage is a MempoolMessage requesting txs of the receiver's mempool
+// up to MaxBytes and MaxGas, for a proposal of the sender.
+type PullTxsMessage struct {
+	RequestID uint64
+	MaxBytes  int64
+	MaxGas    int64
+}
+
+// ValidateBasic performs basic validation.
+func (m *PullTxsMessage) ValidateBasic() error {
+	if m.MaxBytes <= 0 || m.MaxBytes > types.MaxBlockSizeBytes {
+		return fmt.Errorf("max bytes must be in (0, %d], got %d", types.MaxBlockSizeBytes, m.MaxBytes)
+	}
+	if m.MaxGas < -1 {
+		return errors.New("max gas must be -1 or more")
+	}
+	return nil
+}
+
+// String returns a string representation of the PullTxsMessage.
+func (m *PullTxsMessage) String() string {
+	return fmt.Sprintf("[PullTxsMessage %d %d %d]", m.RequestID, m.MaxBytes, m.MaxGas)
+}
+
+//-------------------------------------
+
+// PulledTxsMessage is a MempoolMessage answering a PullTxsMessage.
+type PulledTxsMessage struct {
+	RequestID uint64
+	Txs       types.Txs
+}
+
+// String returns a string representation of the PulledTxsMessage.
+func (m *PulledTxsMessage) String() string {
+	return fmt.Sprintf("[PulledTxsMessage %d %d]", m.RequestID, len(m.Txs))
+}
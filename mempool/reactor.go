@@ -1,16 +1,18 @@
 package mempool
 
 import (
+	"bytes"
+	"crypto/sha256"
+	"errors"
 	"fmt"
 	"math"
 	"reflect"
 	"sync"
 	"time"
 
-	amino "github.com/tendermint/go-amino"
-
 	cfg "github.com/hdac-io/tendermint/config"
 	"github.com/hdac-io/tendermint/libs/clist"
+	cmn "github.com/hdac-io/tendermint/libs/common"
 	"github.com/hdac-io/tendermint/libs/log"
 	"github.com/hdac-io/tendermint/p2p"
 	"github.com/hdac-io/tendermint/types"
@@ -18,6 +20,13 @@ import (
 
 const (
 	MempoolChannel = byte(0x30)
+	// TxHashesChannel carries the TxHashesMessage and WantTxsMessage of the
+	// two-phase gossip. The nodes which don't advertise it are sent full txs.
+	TxHashesChannel = byte(0x31)
+	// PullTxsChannel carries the PullTxsMessage and PulledTxsMessage of the
+	// validators running the validator_local mode, pulling the txs of their
+	// proposals from the peers.
+	PullTxsChannel = byte(0x32)
 
 	aminoOverheadForTxMessage = 8
 
@@ -28,16 +37,73 @@ const (
 	UnknownPeerID uint16 = 0
 
 	maxActiveIDs = math.MaxUint16
+
+	// maxTxHashesPerMessage is the maximum number of hashes of a
+	// TxHashesMessage or a WantTxsMessage.
+	maxTxHashesPerMessage = 1000
+
+	// wantTxTimeout is how long a tx requested from a peer is not requested
+	// from the other peers advertising it.
+	wantTxTimeout = 2 * time.Second
 )
 
 // Reactor handles mempool tx broadcasting amongst peers.
 // It maintains a map from peer ID to counter, to prevent gossiping txs to the
 // peers you received it from.
+//
+// If config.BroadcastTxHashes, the txs are gossiped in two phases to the peers
+// supporting it: the hashes of the txs are advertised (TxHashesMessage), and
+// the peers request the txs they haven't seen yet (WantTxsMessage), from one
+// peer at a time: a tx not sent within wantTxTimeout is requested from the next
+// peer which advertised it.
 type Reactor struct {
 	p2p.BaseReactor
 	config  *cfg.MempoolConfig
 	mempool *CListMempool
 	ids     *mempoolIDs
+
+	// hashes of the txs requested from a peer -> request
+	wantedMtx sync.Mutex
+	wanted    map[[sha256.Size]byte]*wantedTx
+
+	// the peers to pull txs from or to serve the pulls of, id of the last
+	// PullTxsMessage, the requests waiting for txs, and the time of the last
+	// pull served to each peer
+	pullMtx      sync.Mutex
+	pullTxsPeers map[p2p.ID]bool
+	pullID       uint64
+	pulls        map[uint64]*pull
+	servedPulls  map[p2p.ID]time.Time
+	// the txs pulled for the next proposals, and the limits of the proposals
+	pulledMtx    sync.Mutex
+	pulled       []pulledTx
+	pullMaxBytes int64
+	pullMaxGas   int64
+}
+
+// wantedTx is a tx requested from a peer, with the other peers which
+// advertised it, to request it from if it's not sent within wantTxTimeout.
+type wantedTx struct {
+	requested   time.Time
+	advertisers []p2p.Peer
+}
+
+func (w *wantedTx) addAdvertiser(peer p2p.Peer) {
+	for _, p := range w.advertisers {
+		if p.ID() == peer.ID() {
+			return
+		}
+	}
+	w.advertisers = append(w.advertisers, peer)
+}
+
+func (w *wantedTx) removeAdvertiser(peer p2p.Peer) {
+	for i, p := range w.advertisers {
+		if p.ID() == peer.ID() {
+			w.advertisers = append(w.advertisers[:i], w.advertisers[i+1:]...)
+			return
+		}
+	}
 }
 
 type mempoolIDs struct {
@@ -106,9 +172,16 @@ func newMempoolIDs() *mempoolIDs {
 // NewReactor returns a new Reactor with the given config and mempool.
 func NewReactor(config *cfg.MempoolConfig, mempool *CListMempool) *Reactor {
 	memR := &Reactor{
-		config:  config,
-		mempool: mempool,
-		ids:     newMempoolIDs(),
+		config:       config,
+		mempool:      mempool,
+		ids:          newMempoolIDs(),
+		wanted:       make(map[[sha256.Size]byte]*wantedTx),
+		pullTxsPeers: make(map[p2p.ID]bool),
+		pulls:        make(map[uint64]*pull),
+		servedPulls:  make(map[p2p.ID]time.Time),
+	}
+	for _, id := range cmn.SplitAndTrim(config.PullTxsPeerIDs, ",", " ") {
+		memR.pullTxsPeers[p2p.ID(id)] = true
 	}
 	memR.BaseReactor = *p2p.NewBaseReactor("Reactor", memR)
 	return memR
@@ -122,20 +195,40 @@ func (memR *Reactor) SetLogger(l log.Logger) {
 
 // OnStart implements p2p.BaseReactor.
 func (memR *Reactor) OnStart() error {
+	if memR.config.ValidatorLocal() {
+		memR.Logger.Info("Mempool runs the validator_local mode: no tx is accepted nor gossiped")
+		go memR.pullTxsRoutine()
+		return nil
+	}
 	if !memR.config.Broadcast {
 		memR.Logger.Info("Tx broadcasting is disabled")
 	}
+	go memR.rerequestTxsRoutine()
 	return nil
 }
 
 // GetChannels implements Reactor.
-// It returns the list of channels for this reactor.
+// It returns the list of channels for this reactor. With the validator_local
+// mode, the node only advertises PullTxsChannel, so the peers send it no tx.
 func (memR *Reactor) GetChannels() []*p2p.ChannelDescriptor {
+	pullTxs := &p2p.ChannelDescriptor{
+		ID:                  PullTxsChannel,
+		Priority:            5,
+		RecvMessageCapacity: maxPulledTxsMsgSize,
+	}
+	if memR.config.ValidatorLocal() {
+		return []*p2p.ChannelDescriptor{pullTxs}
+	}
 	return []*p2p.ChannelDescriptor{
 		{
 			ID:       MempoolChannel,
 			Priority: 5,
 		},
+		{
+			ID:       TxHashesChannel,
+			Priority: 5,
+		},
+		pullTxs,
 	}
 }
 
@@ -143,7 +236,9 @@ func (memR *Reactor) GetChannels() []*p2p.ChannelDescriptor {
 // It starts a broadcast routine ensuring all txs are forwarded to the given peer.
 func (memR *Reactor) AddPeer(peer p2p.Peer) {
 	memR.ids.ReserveForPeer(peer)
-	go memR.broadcastTxRoutine(peer)
+	if !memR.config.ValidatorLocal() {
+		go memR.broadcastTxRoutine(peer)
+	}
 }
 
 // RemovePeer implements Reactor.
@@ -155,7 +250,7 @@ func (memR *Reactor) RemovePeer(peer p2p.Peer, reason interface{}) {
 // Receive implements Reactor.
 // It adds any received transactions to the mempool.
 func (memR *Reactor) Receive(chID byte, src p2p.Peer, msgBytes []byte) {
-	msg, err := memR.decodeMsg(msgBytes)
+	msg, err := memR.decodePeerMsg(chID, msgBytes, memR.Switch.ProtoMsgs(src))
 	if err != nil {
 		memR.Logger.Error("Error decoding message", "src", src, "chId", chID, "msg", msg, "err", err, "bytes", msgBytes)
 		memR.Switch.StopPeerForError(src, err)
@@ -165,17 +260,176 @@ func (memR *Reactor) Receive(chID byte, src p2p.Peer, msgBytes []byte) {
 
 	switch msg := msg.(type) {
 	case *TxMessage:
+		memR.unwant(txKey(msg.Tx))
 		peerID := memR.ids.GetForPeer(src)
 		err := memR.mempool.CheckTxWithInfo(msg.Tx, nil, TxInfo{SenderID: peerID})
 		if err != nil {
 			memR.Logger.Info("Could not check tx", "tx", txID(msg.Tx), "err", err)
 		}
 		// broadcasting happens from go routines per peer
+	case *TxHashesMessage:
+		if err := msg.ValidateBasic(); err != nil {
+			memR.Switch.StopPeerForError(src, err)
+			return
+		}
+		memR.requestTxs(src, msg.Hashes)
+	case *WantTxsMessage:
+		if err := msg.ValidateBasic(); err != nil {
+			memR.Switch.StopPeerForError(src, err)
+			return
+		}
+		memR.sendTxs(src, msg.Hashes)
+	case *PullTxsMessage:
+		if err := msg.ValidateBasic(); err != nil {
+			memR.Switch.StopPeerForError(src, err)
+			return
+		}
+		memR.sendPulledTxs(src, msg)
+	case *PulledTxsMessage:
+		memR.receivePulledTxs(src, msg)
 	default:
 		memR.Logger.Error(fmt.Sprintf("Unknown message type %v", reflect.TypeOf(msg)))
 	}
 }
 
+// requestTxs requests from the peer the txs of the advertised hashes which
+// haven't been seen, nor requested from another peer for wantTxTimeout. The
+// peer is kept as an advertiser of the txs requested from another peer, to
+// request them from it if they are not sent in time.
+func (memR *Reactor) requestTxs(peer p2p.Peer, hashes [][]byte) {
+	peerID := memR.ids.GetForPeer(peer)
+	now := time.Now()
+
+	var want [][]byte
+	memR.wantedMtx.Lock()
+	for _, hash := range hashes {
+		var key [sha256.Size]byte
+		copy(key[:], hash)
+		if memR.mempool.seen(key, peerID) {
+			continue
+		}
+		w, ok := memR.wanted[key]
+		if !ok {
+			w = &wantedTx{}
+			memR.wanted[key] = w
+		}
+		if ok && now.Sub(w.requested) < wantTxTimeout {
+			w.addAdvertiser(peer)
+			continue
+		}
+		w.requested = now
+		w.removeAdvertiser(peer)
+		want = append(want, hash)
+	}
+	memR.wantedMtx.Unlock()
+
+	memR.sendWantTxs(peer, want)
+}
+
+// rerequestTxs requests the txs which were not sent within wantTxTimeout from
+// the next peers advertising them, and forgets the ones which were seen since,
+// or which no other peer advertised.
+func (memR *Reactor) rerequestTxs(now time.Time) {
+	wants := make(map[p2p.ID][][]byte)
+	peers := make(map[p2p.ID]p2p.Peer)
+	memR.wantedMtx.Lock()
+	for key, w := range memR.wanted {
+		if now.Sub(w.requested) < wantTxTimeout {
+			continue
+		}
+		if _, ok := memR.mempool.txByKey(key); ok || memR.mempool.cache.Has(key) {
+			delete(memR.wanted, key)
+			continue
+		}
+		var next p2p.Peer
+		for next == nil && len(w.advertisers) > 0 {
+			if peer := w.advertisers[0]; peer.IsRunning() {
+				next = peer
+			}
+			w.advertisers = w.advertisers[1:]
+		}
+		if next == nil {
+			delete(memR.wanted, key)
+			continue
+		}
+		w.requested = now
+		hash := key
+		wants[next.ID()] = append(wants[next.ID()], hash[:])
+		peers[next.ID()] = next
+	}
+	memR.wantedMtx.Unlock()
+
+	for id, hashes := range wants {
+		memR.sendWantTxs(peers[id], hashes)
+	}
+}
+
+// sendWantTxs sends to the peer the WantTxsMessages of the hashes. If they
+// can't be sent, the txs are requested from the next peers advertising them.
+func (memR *Reactor) sendWantTxs(peer p2p.Peer, hashes [][]byte) {
+	for len(hashes) > 0 {
+		n := cmn.MinInt(len(hashes), maxTxHashesPerMessage)
+		msg := &WantTxsMessage{Hashes: hashes[:n]}
+		if !peer.Send(TxHashesChannel, memR.encodeMsg(peer, msg)) {
+			memR.wantedMtx.Lock()
+			for _, hash := range hashes {
+				var key [sha256.Size]byte
+				copy(key[:], hash)
+				if w, ok := memR.wanted[key]; ok {
+					w.requested = time.Time{}
+				}
+			}
+			memR.wantedMtx.Unlock()
+			return
+		}
+		hashes = hashes[n:]
+	}
+}
+
+// rerequestTxsRoutine requests the txs which were not sent in time from the
+// next peers advertising them.
+func (memR *Reactor) rerequestTxsRoutine() {
+	ticker := time.NewTicker(wantTxTimeout / 2)
+	defer ticker.Stop()
+	for {
+		select {
+		case now := <-ticker.C:
+			memR.rerequestTxs(now)
+		case <-memR.Quit():
+			return
+		}
+	}
+}
+
+// unwant forgets the request of the tx of the given key.
+func (memR *Reactor) unwant(key [sha256.Size]byte) {
+	memR.wantedMtx.Lock()
+	delete(memR.wanted, key)
+	memR.wantedMtx.Unlock()
+}
+
+// sendTxs sends to the peer the requested txs which are in the mempool.
+func (memR *Reactor) sendTxs(peer p2p.Peer, hashes [][]byte) {
+	for _, hash := range hashes {
+		var key [sha256.Size]byte
+		copy(key[:], hash)
+		memTx, ok := memR.mempool.txByKey(key)
+		if !ok {
+			continue
+		}
+		msg := &TxMessage{Tx: memTx.tx}
+		if !peer.Send(MempoolChannel, memR.encodeMsg(peer, msg)) {
+			return
+		}
+	}
+}
+
+// peerHasChannel returns true if the peer advertised the channel.
+func peerHasChannel(peer p2p.Peer, chID byte) bool {
+	nodeInfo, ok := peer.NodeInfo().(p2p.DefaultNodeInfo)
+	return ok && bytes.IndexByte(nodeInfo.Channels, chID) >= 0
+}
+
 // PeerState describes the state of a peer.
 type PeerState interface {
 	GetHeight() int64
@@ -186,6 +440,16 @@ func (memR *Reactor) broadcastTxRoutine(peer p2p.Peer) {
 	if !memR.config.Broadcast {
 		return
 	}
+	// the peers running the validator_local mode accept no tx
+	if nodeInfo, ok := peer.NodeInfo().(p2p.DefaultNodeInfo); ok &&
+		bytes.IndexByte(nodeInfo.Channels, MempoolChannel) < 0 {
+		return
+	}
+
+	if memR.config.BroadcastTxHashes && peerHasChannel(peer, TxHashesChannel) {
+		memR.broadcastTxHashesRoutine(peer)
+		return
+	}
 
 	peerID := memR.ids.GetForPeer(peer)
 	var next *clist.CElement
@@ -232,7 +496,7 @@ func (memR *Reactor) broadcastTxRoutine(peer p2p.Peer) {
 		if _, ok := memTx.senders.Load(peerID); !ok {
 			// send memTx
 			msg := &TxMessage{Tx: memTx.tx}
-			success := peer.Send(MempoolChannel, cdc.MustMarshalBinaryBare(msg))
+			success := peer.Send(MempoolChannel, memR.encodeMsg(peer, msg))
 			if !success {
 				time.Sleep(peerCatchupSleepIntervalMS * time.Millisecond)
 				continue
@@ -251,19 +515,98 @@ func (memR *Reactor) broadcastTxRoutine(peer p2p.Peer) {
 	}
 }
 
+// Advertise the hashes of new mempool txs to peer, batching the txs which are
+// already available.
+func (memR *Reactor) broadcastTxHashesRoutine(peer p2p.Peer) {
+	peerID := memR.ids.GetForPeer(peer)
+	var (
+		next   *clist.CElement
+		hashes [][]byte
+	)
+	for {
+		// In case of both next.NextWaitChan() and peer.Quit() are variable at the same time
+		if !memR.IsRunning() || !peer.IsRunning() {
+			return
+		}
+		// This happens because the CElement we were looking at got garbage
+		// collected (removed). That is, .NextWait() returned nil. Go ahead and
+		// start from the beginning.
+		if next == nil {
+			select {
+			case <-memR.mempool.TxsWaitChan(): // Wait until a tx is available
+				if next = memR.mempool.TxsFront(); next == nil {
+					continue
+				}
+			case <-peer.Quit():
+				return
+			case <-memR.Quit():
+				return
+			}
+		}
+
+		memTx := next.Value.(*mempoolTx)
+
+		// make sure the peer is up to date
+		peerState, ok := peer.Get(types.PeerStateKey).(PeerState)
+		if !ok {
+			// Peer does not have a state yet. See broadcastTxRoutine.
+			time.Sleep(peerCatchupSleepIntervalMS * time.Millisecond)
+			continue
+		}
+		if peerState.GetHeight() < memTx.Height()-1 { // Allow for a lag of 1 block
+			time.Sleep(peerCatchupSleepIntervalMS * time.Millisecond)
+			continue
+		}
+
+		// ensure peer hasn't already sent or advertised us this tx
+		if _, ok := memTx.senders.Load(peerID); !ok {
+			key := txKey(memTx.tx)
+			hashes = append(hashes, key[:])
+		}
+
+		// send the hashes before waiting for the next tx
+		if len(hashes) > 0 && (len(hashes) == maxTxHashesPerMessage || next.Next() == nil) {
+			msg := &TxHashesMessage{Hashes: hashes}
+			for !peer.Send(TxHashesChannel, memR.encodeMsg(peer, msg)) {
+				select {
+				case <-time.After(peerCatchupSleepIntervalMS * time.Millisecond):
+				case <-peer.Quit():
+					return
+				case <-memR.Quit():
+					return
+				}
+			}
+			hashes = nil
+		}
+
+		select {
+		case <-next.NextWaitChan():
+			// see the start of the for loop for nil check
+			next = next.Next()
+		case <-peer.Quit():
+			return
+		case <-memR.Quit():
+			return
+		}
+	}
+}
+
 //-----------------------------------------------------------------------------
 // Messages
 
 // MempoolMessage is a message sent or received by the Reactor.
 type MempoolMessage interface{}
 
-func RegisterMempoolMessages(cdc *amino.Codec) {
-	cdc.RegisterInterface((*MempoolMessage)(nil), nil)
-	cdc.RegisterConcrete(&TxMessage{}, "tendermint/mempool/TxMessage", nil)
+// maxMsgSize returns the max size of the messages of the channel.
+func (memR *Reactor) maxMsgSize(chID byte) int {
+	if chID == PullTxsChannel {
+		return maxPulledTxsMsgSize
+	}
+	return calcMaxMsgSize(memR.config.MaxTxBytes)
 }
 
-func (memR *Reactor) decodeMsg(bz []byte) (msg MempoolMessage, err error) {
-	maxMsgSize := calcMaxMsgSize(memR.config.MaxTxBytes)
+func (memR *Reactor) decodeMsg(chID byte, bz []byte) (msg MempoolMessage, err error) {
+	maxMsgSize := memR.maxMsgSize(chID)
 	if l := len(bz); l > maxMsgSize {
 		return msg, ErrTxTooLarge{maxMsgSize, l}
 	}
@@ -283,6 +626,57 @@ func (m *TxMessage) String() string {
 	return fmt.Sprintf("[TxMessage %v]", m.Tx)
 }
 
+//-------------------------------------
+
+// TxHashesMessage is a MempoolMessage advertising the hashes of txs of the
+// sender's mempool.
+type TxHashesMessage struct {
+	Hashes [][]byte
+}
+
+// ValidateBasic performs basic validation.
+func (m *TxHashesMessage) ValidateBasic() error {
+	return validateTxHashes(m.Hashes)
+}
+
+// String returns a string representation of the TxHashesMessage.
+func (m *TxHashesMessage) String() string {
+	return fmt.Sprintf("[TxHashesMessage %d]", len(m.Hashes))
+}
+
+//-------------------------------------
+
+// WantTxsMessage is a MempoolMessage requesting the txs of the given hashes,
+// which were advertised by the receiver.
+type WantTxsMessage struct {
+	Hashes [][]byte
+}
+
+// ValidateBasic performs basic validation.
+func (m *WantTxsMessage) ValidateBasic() error {
+	return validateTxHashes(m.Hashes)
+}
+
+// String returns a string representation of the WantTxsMessage.
+func (m *WantTxsMessage) String() string {
+	return fmt.Sprintf("[WantTxsMessage %d]", len(m.Hashes))
+}
+
+func validateTxHashes(hashes [][]byte) error {
+	if len(hashes) == 0 {
+		return errors.New("no hashes")
+	}
+	if len(hashes) > maxTxHashesPerMessage {
+		return fmt.Errorf("too many hashes: %d > %d", len(hashes), maxTxHashesPerMessage)
+	}
+	for i, hash := range hashes {
+		if len(hash) != sha256.Size {
+			return fmt.Errorf("expected hash #%d size to be %d, got %d", i, sha256.Size, len(hash))
+		}
+	}
+	return nil
+}
+
 // calcMaxMsgSize returns the max size of TxMessage
 // account for amino overhead of TxMessage
 func calcMaxMsgSize(maxTxSize int) int {
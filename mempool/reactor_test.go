@@ -1,6 +1,7 @@
 package mempool
 
 import (
+	"crypto/sha256"
 	"net"
 	"sync"
 	"testing"
@@ -10,8 +11,10 @@ import (
 	"github.com/go-kit/kit/log/term"
 	"github.com/pkg/errors"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	"github.com/hdac-io/tendermint/abci/example/kvstore"
+	abci "github.com/hdac-io/tendermint/abci/types"
 	cfg "github.com/hdac-io/tendermint/config"
 	"github.com/hdac-io/tendermint/libs/log"
 	"github.com/hdac-io/tendermint/p2p"
@@ -113,24 +116,156 @@ const (
 )
 
 func TestReactorBroadcastTxMessage(t *testing.T) {
-	config := cfg.TestConfig()
-	const N = 4
-	reactors := makeAndConnectReactors(config, N)
-	defer func() {
+	for _, broadcastTxHashes := range []bool{true, false} {
+		config := cfg.TestConfig()
+		config.Mempool.BroadcastTxHashes = broadcastTxHashes
+		const N = 4
+		reactors := makeAndConnectReactors(config, N)
 		for _, r := range reactors {
-			r.Stop()
+			for _, peer := range r.Switch.Peers().List() {
+				peer.Set(types.PeerStateKey, peerState{1})
+			}
 		}
-	}()
-	for _, r := range reactors {
-		for _, peer := range r.Switch.Peers().List() {
-			peer.Set(types.PeerStateKey, peerState{1})
+
+		// send a bunch of txs to the first reactor's mempool
+		// and wait for them all to be received in the others
+		txs := checkTxs(t, reactors[0].mempool, NUM_TXS, UnknownPeerID)
+		waitForTxsOnReactors(t, txs, reactors)
+
+		for _, r := range reactors {
+			r.Stop()
 		}
 	}
+}
+
+// recordingPeer records the messages sent to it.
+type recordingPeer struct {
+	*mock.Peer
+	mtx  sync.Mutex
+	sent []MempoolMessage
+}
+
+func (p *recordingPeer) Send(chID byte, msgBytes []byte) bool {
+	var msg MempoolMessage
+	if err := cdc.UnmarshalBinaryBare(msgBytes, &msg); err != nil {
+		panic(err)
+	}
+	p.mtx.Lock()
+	p.sent = append(p.sent, msg)
+	p.mtx.Unlock()
+	return true
+}
+
+func TestReactorRequestTxsOnce(t *testing.T) {
+	app := kvstore.NewKVStoreApplication()
+	cc := proxy.NewLocalClientCreator(app)
+	mempool, cleanup := newMempoolWithApp(cc)
+	defer cleanup()
+	memR := NewReactor(cfg.TestConfig().Mempool, mempool)
+
+	peer1 := &recordingPeer{Peer: mock.NewPeer(net.IP{127, 0, 0, 1})}
+	peer2 := &recordingPeer{Peer: mock.NewPeer(net.IP{127, 0, 0, 2})}
+	memR.ids.ReserveForPeer(peer1)
+	memR.ids.ReserveForPeer(peer2)
+
+	seen := checkTxs(t, mempool, 1, UnknownPeerID)[0]
+	seenKey, unseenKey := txKey(seen), txKey(types.Tx("unseen"))
+
+	// only the unseen tx is requested, and the peer won't be advertised the seen tx
+	memR.requestTxs(peer1, [][]byte{seenKey[:], unseenKey[:]})
+	assert.Equal(t, []MempoolMessage{&WantTxsMessage{Hashes: [][]byte{unseenKey[:]}}}, peer1.sent)
+	memTx, ok := mempool.txByKey(seenKey)
+	require.True(t, ok)
+	_, sent := memTx.senders.Load(memR.ids.GetForPeer(peer1))
+	assert.True(t, sent)
+
+	// the tx isn't requested again from another peer, until it times out
+	memR.requestTxs(peer2, [][]byte{unseenKey[:]})
+	assert.Empty(t, peer2.sent)
+	memR.wanted[unseenKey].requested = time.Now().Add(-wantTxTimeout)
+	memR.requestTxs(peer2, [][]byte{unseenKey[:]})
+	assert.Len(t, peer2.sent, 1)
+	assert.Empty(t, memR.wanted[unseenKey].advertisers)
+
+	// the requested txs in the mempool are sent
+	memR.sendTxs(peer1, [][]byte{seenKey[:], unseenKey[:]})
+	assert.Equal(t, &TxMessage{Tx: seen}, peer1.sent[len(peer1.sent)-1])
+	assert.Len(t, peer1.sent, 2)
+}
+
+func TestReactorRerequestTxs(t *testing.T) {
+	app := kvstore.NewKVStoreApplication()
+	cc := proxy.NewLocalClientCreator(app)
+	mempool, cleanup := newMempoolWithApp(cc)
+	defer cleanup()
+	memR := NewReactor(cfg.TestConfig().Mempool, mempool)
+
+	peers := make([]*recordingPeer, 3)
+	for i := range peers {
+		peers[i] = &recordingPeer{Peer: mock.NewPeer(net.IP{127, 0, 0, byte(i + 1)})}
+		memR.ids.ReserveForPeer(peers[i])
+	}
+	key := txKey(types.Tx("unseen"))
+	want := []MempoolMessage{&WantTxsMessage{Hashes: [][]byte{key[:]}}}
+
+	// the tx is requested from the first peer advertising it only
+	for _, peer := range peers {
+		memR.requestTxs(peer, [][]byte{key[:]})
+	}
+	assert.Equal(t, want, peers[0].sent)
+	assert.Empty(t, peers[1].sent)
+	assert.Empty(t, peers[2].sent)
+
+	// it's not requested again before it times out
+	now := time.Now()
+	memR.rerequestTxs(now)
+	assert.Empty(t, peers[1].sent)
+
+	// the first peer never sends it: it's requested from the next advertiser
+	// which is still running
+	peers[1].Stop()
+	now = now.Add(wantTxTimeout)
+	memR.rerequestTxs(now)
+	assert.Empty(t, peers[1].sent)
+	assert.Equal(t, want, peers[2].sent)
+
+	// no other peer advertised it: it's forgotten
+	memR.rerequestTxs(now.Add(wantTxTimeout))
+	assert.Len(t, peers[2].sent, 1)
+	assert.NotContains(t, memR.wanted, key)
+
+	// and so is a tx seen since its request
+	seen := checkTxs(t, mempool, 1, UnknownPeerID)[0]
+	seenKey := txKey(seen)
+	memR.wanted[seenKey] = &wantedTx{advertisers: []p2p.Peer{peers[2]}}
+	memR.rerequestTxs(now.Add(2 * wantTxTimeout))
+	assert.NotContains(t, memR.wanted, seenKey)
+	assert.Len(t, peers[2].sent, 1)
+}
+
+func TestTxHashesMessageValidateBasic(t *testing.T) {
+	hash := make([]byte, sha256.Size)
+	tooMany := make([][]byte, maxTxHashesPerMessage+1)
+	for i := range tooMany {
+		tooMany[i] = hash
+	}
 
-	// send a bunch of txs to the first reactor's mempool
-	// and wait for them all to be received in the others
-	txs := checkTxs(t, reactors[0].mempool, NUM_TXS, UnknownPeerID)
-	waitForTxsOnReactors(t, txs, reactors)
+	cases := []struct {
+		hashes [][]byte
+		valid  bool
+	}{
+		{[][]byte{hash}, true},
+		{tooMany[1:], true},
+		{nil, false},
+		{tooMany, false},
+		{[][]byte{hash, hash[1:]}, false},
+	}
+	for i, c := range cases {
+		err := (&TxHashesMessage{Hashes: c.hashes}).ValidateBasic()
+		assert.Equal(t, c.valid, err == nil, "#%d: %v", i, err)
+		err = (&WantTxsMessage{Hashes: c.hashes}).ValidateBasic()
+		assert.Equal(t, c.valid, err == nil, "#%d: %v", i, err)
+	}
 }
 
 func TestReactorNoBroadcastToSender(t *testing.T) {
@@ -223,3 +358,117 @@ func TestMempoolIDsPanicsIfNodeRequestsOvermaxActiveIDs(t *testing.T) {
 		ids.ReserveForPeer(peer)
 	})
 }
+
+func TestReactorValidatorLocal(t *testing.T) {
+	entryConfig := cfg.ResetTestRoot("mempool_entry")
+	localConfig := cfg.ResetTestRoot("mempool_validator_local")
+	localConfig.Mempool.Mode = "validator_local"
+	for _, config := range []*cfg.Config{entryConfig, localConfig} {
+		config.Mempool.PullTxsInterval = 50 * time.Millisecond
+		config.Mempool.PullTxsTimeout = 50 * time.Millisecond
+	}
+
+	reactors := make([]*Reactor, 2)
+	for i, config := range []*cfg.Config{entryConfig, localConfig} {
+		cc := proxy.NewLocalClientCreator(kvstore.NewKVStoreApplication())
+		mempool, cleanup := newMempoolWithAppAndConfig(cc, config)
+		defer cleanup()
+		reactors[i] = NewReactor(config.Mempool, mempool)
+		reactors[i].SetLogger(mempoolLogger().With("validator", i))
+	}
+	p2p.MakeConnectedSwitches(entryConfig.P2P, 2, func(i int, s *p2p.Switch) *p2p.Switch {
+		s.AddReactor("MEMPOOL", reactors[i])
+		return s
+	}, p2p.Connect2Switches)
+	for _, r := range reactors {
+		defer r.Stop()
+		for _, peer := range r.Switch.Peers().List() {
+			peer.Set(types.PeerStateKey, peerState{1})
+		}
+	}
+	entry, local := reactors[0], reactors[1]
+	entryID, localID := entry.Switch.NodeInfo().ID(), local.Switch.NodeInfo().ID()
+
+	// the validator_local node accepts no tx, and is not sent any
+	assert.Equal(t, ErrValidatorLocal, local.mempool.CheckTx(types.Tx("tx"), nil))
+	txs := checkTxs(t, entry.mempool, 10, UnknownPeerID)
+	ensureNoTxs(t, local, 100*time.Millisecond)
+
+	// it pulls the txs of its proposals in the background, once their limits
+	// are known, from the peers of pull_txs_peer_ids allowing it
+	pullTxs := func(maxBytes, maxGas int64) types.Txs {
+		assert.Empty(t, local.PullTxs(maxBytes, maxGas), "no tx pulled yet")
+		time.Sleep(200 * time.Millisecond)
+		return local.PullTxs(maxBytes, maxGas)
+	}
+	assert.Empty(t, pullTxs(1024*1024, -1), "no peer to pull from")
+	allowPull := func(memR *Reactor, id p2p.ID) {
+		memR.pullMtx.Lock()
+		memR.pullTxsPeers[id] = true
+		memR.pullMtx.Unlock()
+	}
+	allowPull(local, entryID)
+	assert.Empty(t, pullTxs(1024*1024, -1), "not allowed by the entry node")
+	allowPull(entry, localID)
+	assert.Equal(t, txs, pullTxs(1024*1024, -1))
+	// the txs are returned once
+	assert.Empty(t, local.PullTxs(1024*1024, -1))
+
+	// up to the max bytes and gas, the kvstore txs wanting 1 gas each
+	txBytes := int64(len(txs[0])) + types.ComputeAminoOverhead(txs[0], 1)
+	local.pulledMtx.Lock()
+	local.pulled, local.pullMaxBytes = nil, 0
+	local.pulledMtx.Unlock()
+	assert.Equal(t, txs[:3], pullTxs(3*txBytes, -1))
+	local.pulledMtx.Lock()
+	local.pulled, local.pullMaxBytes = nil, 0
+	local.pulledMtx.Unlock()
+	assert.Equal(t, txs[:2], pullTxs(1024*1024, 2))
+
+	// the committed txs are dropped
+	local.pulledMtx.Lock()
+	local.pulled, local.pullMaxBytes = nil, 0
+	local.pulledMtx.Unlock()
+	local.mempool.Lock()
+	require.NoError(t, local.mempool.Update(1, txs[:5], abciResponses(5, abci.CodeTypeOK), nil, nil))
+	local.mempool.Unlock()
+	assert.Equal(t, txs[5:], pullTxs(1024*1024, -1))
+}
+
+func TestReactorServePullTxs(t *testing.T) {
+	config := cfg.ResetTestRoot("mempool_test")
+	config.Mempool.PullTxsInterval = time.Hour
+	cc := proxy.NewLocalClientCreator(kvstore.NewKVStoreApplication())
+	mempool, cleanup := newMempoolWithAppAndConfig(cc, config)
+	defer cleanup()
+	memR := NewReactor(config.Mempool, mempool)
+	memR.SetLogger(log.TestingLogger())
+	checkTxs(t, mempool, 3, UnknownPeerID)
+
+	peer := mock.NewPeer(net.IP{127, 0, 0, 1})
+	msg := &PullTxsMessage{RequestID: 1, MaxBytes: 1024, MaxGas: -1}
+	// the pulls of the peers not in pull_txs_peer_ids are ignored
+	memR.sendPulledTxs(peer, msg)
+	memR.pullMtx.Lock()
+	assert.Empty(t, memR.servedPulls)
+	memR.pullMtx.Unlock()
+
+	// one pull per half pull_txs_interval is served
+	memR.pullMtx.Lock()
+	memR.pullTxsPeers[peer.ID()] = true
+	memR.pullMtx.Unlock()
+	memR.sendPulledTxs(peer, msg)
+	memR.pullMtx.Lock()
+	served := memR.servedPulls[peer.ID()]
+	memR.pullMtx.Unlock()
+	assert.False(t, served.IsZero())
+	memR.sendPulledTxs(peer, msg)
+	memR.pullMtx.Lock()
+	assert.Equal(t, served, memR.servedPulls[peer.ID()])
+	memR.pullMtx.Unlock()
+
+	// the txs pulled from a peer the request wasn't sent to are dropped
+	memR.pulls[2] = &pull{peers: map[p2p.ID]bool{"other": true}, pulled: make(chan types.Txs, 1)}
+	memR.receivePulledTxs(peer, &PulledTxsMessage{RequestID: 2, Txs: types.Txs{types.Tx("tx")}})
+	assert.Len(t, memR.pulls[2].pulled, 0)
+}
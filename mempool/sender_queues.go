@@ -0,0 +1,127 @@
+package mempool
+
+import (
+	"sort"
+	"sync"
+)
+
+// senderQueues keeps the txs of each sender, set by the app in CheckTx, in
+// nonce order, for the mempool to release them in that order.
+type senderQueues struct {
+	mtx sync.Mutex
+	// sender -> txs of the sender, sorted by nonce
+	queues map[string][]*mempoolTx
+	// sender -> nonce following the last committed tx of the sender
+	next map[string]committedNonce
+}
+
+// committedNonce is the nonce following the last committed tx of a sender,
+// and the height it was committed at.
+type committedNonce struct {
+	nonce  uint64
+	height int64
+}
+
+func newSenderQueues() *senderQueues {
+	return &senderQueues{
+		queues: make(map[string][]*mempoolTx),
+		next:   make(map[string]committedNonce),
+	}
+}
+
+// add adds memTx to the queue of its sender, after the txs with the same
+// nonce.
+func (sq *senderQueues) add(memTx *mempoolTx) {
+	sq.mtx.Lock()
+	defer sq.mtx.Unlock()
+
+	queue := sq.queues[memTx.sender]
+	i := sort.Search(len(queue), func(i int) bool { return queue[i].nonce > memTx.nonce })
+	queue = append(queue, nil)
+	copy(queue[i+1:], queue[i:])
+	queue[i] = memTx
+	sq.queues[memTx.sender] = queue
+}
+
+// remove removes memTx from the queue of its sender.
+func (sq *senderQueues) remove(memTx *mempoolTx) {
+	sq.mtx.Lock()
+	defer sq.mtx.Unlock()
+
+	queue := sq.queues[memTx.sender]
+	for i, tx := range queue {
+		if tx == memTx {
+			queue = append(queue[:i], queue[i+1:]...)
+			break
+		}
+	}
+	if len(queue) == 0 {
+		delete(sq.queues, memTx.sender)
+	} else {
+		sq.queues[memTx.sender] = queue
+	}
+}
+
+// committed records that the tx of sender with nonce was committed at height.
+func (sq *senderQueues) committed(sender string, nonce uint64, height int64) {
+	sq.mtx.Lock()
+	defer sq.mtx.Unlock()
+
+	if next, ok := sq.next[sender]; !ok || nonce >= next.nonce {
+		sq.next[sender] = committedNonce{nonce: nonce + 1, height: height}
+	}
+}
+
+// prune forgets the nonces committed before minHeight.
+func (sq *senderQueues) prune(minHeight int64) {
+	sq.mtx.Lock()
+	defer sq.mtx.Unlock()
+
+	for sender, next := range sq.next {
+		if next.height < minHeight {
+			delete(sq.next, sender)
+		}
+	}
+}
+
+// releasable returns the txs of sender to release, in nonce order: the txs
+// with consecutive nonces, starting from the nonce following the last
+// committed tx of the sender, or from the lowest nonce if none was committed
+// since minHeight. It also returns the number of txs held back by a nonce gap.
+// The txs with a nonce already passed are neither released nor held back.
+func (sq *senderQueues) releasable(sender string, minHeight int64) ([]*mempoolTx, int) {
+	sq.mtx.Lock()
+	defer sq.mtx.Unlock()
+
+	queue := sq.queues[sender]
+	if len(queue) == 0 {
+		return nil, 0
+	}
+	expected := queue[0].nonce
+	if next, ok := sq.next[sender]; ok && next.height >= minHeight {
+		expected = next.nonce
+	}
+
+	txs := make([]*mempoolTx, 0, len(queue))
+	for i, memTx := range queue {
+		switch {
+		case memTx.nonce < expected:
+			// a duplicate nonce, or a nonce committed already
+		case memTx.nonce == expected:
+			txs = append(txs, memTx)
+			expected++
+		default:
+			return txs, len(queue) - i
+		}
+	}
+	return txs, 0
+}
+
+// reset removes all the txs and the committed nonces.
+func (sq *senderQueues) reset() {
+	sq.mtx.Lock()
+	defer sq.mtx.Unlock()
+
+	sq.queues = make(map[string][]*mempoolTx)
+	sq.next = make(map[string]committedNonce)
+}
@@ -26,6 +26,8 @@ func (Mempool) ReapMaxBytesMaxGas(_, _ int64) types.Txs       { return types.Txs
 func (Mempool) ReapMaxTxs(n int) types.Txs                    { return types.Txs{} }
 func (Mempool) Reserve(blockHeight int64, blockTxs types.Txs) {}
 func (Mempool) Unreserve(blockTxs types.Txs)                  {}
+func (Mempool) ReservedHeight(tx types.Tx) (int64, bool)      { return 0, false }
+func (Mempool) ConflictKeys(tx types.Tx) ([][]byte, bool)     { return nil, false }
 func (Mempool) Update(
 	_ int64,
 	_ types.Txs,
@@ -0,0 +1,141 @@
+package node
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/hdac-io/tendermint/control"
+	"github.com/hdac-io/tendermint/types"
+)
+
+const controlSubscriber = "ControlServer"
+
+// LogRotator moves the log file away, for the node to write to a new one.
+type LogRotator func() error
+
+// SetLogRotator sets the rotator of the log file, if the node logs to a file.
+func (n *Node) SetLogRotator(rotator LogRotator) {
+	n.controlMtx.Lock()
+	defer n.controlMtx.Unlock()
+	n.logRotator = rotator
+}
+
+// drainer is implemented by the consensus modules which can stop proposing.
+type drainer interface {
+	SetDraining(draining bool)
+	IsDraining() bool
+}
+
+// standbyer is implemented by the consensus modules which can keep a
+// validator in standby.
+type standbyer interface {
+	IsStandby() bool
+}
+
+// pipeliner is implemented by the consensus modules deciding several heights
+// at once.
+type pipeliner interface {
+	GetPipelineHeight() int64
+}
+
+var _ control.Handler = (*Node)(nil)
+
+// ControlStatus implements control.Handler.
+func (n *Node) ControlStatus() control.Status {
+	n.controlMtx.Lock()
+	defer n.controlMtx.Unlock()
+
+	status := control.Status{
+		LastHeight:     n.blockStore.Height(),
+		PipelineHeight: n.consensusState.GetLastHeight() + 1,
+		StopHeight:     n.stopHeight,
+	}
+	if p, ok := n.consensusState.(pipeliner); ok {
+		status.PipelineHeight = p.GetPipelineHeight()
+	}
+	if d, ok := n.consensusState.(drainer); ok {
+		status.Draining = d.IsDraining()
+	}
+	if s, ok := n.consensusState.(standbyer); ok {
+		status.Standby = s.IsStandby()
+	}
+	return status
+}
+
+// Drain implements control.Handler. A drained validator keeps voting, so the
+// heights in progress are decided without its proposals.
+func (n *Node) Drain(drain bool) error {
+	d, ok := n.consensusState.(drainer)
+	if !ok {
+		return errors.New("the consensus module can't be drained")
+	}
+	d.SetDraining(drain)
+	return nil
+}
+
+// StopAfterHeight implements control.Handler. To miss none of the heights in
+// progress, drain the node and stop it after the pipeline height of its
+// status.
+func (n *Node) StopAfterHeight(height int64) error {
+	n.controlMtx.Lock()
+	defer n.controlMtx.Unlock()
+
+	if lastHeight := n.blockStore.Height(); height <= lastHeight {
+		return fmt.Errorf("height %d is not above the last height %d", height, lastHeight)
+	}
+	n.stopHeight = height
+	n.Logger.Info("The node will stop after height", "height", height)
+	return nil
+}
+
+// RotateLogs implements control.Handler.
+func (n *Node) RotateLogs() error {
+	n.controlMtx.Lock()
+	rotator := n.logRotator
+	n.controlMtx.Unlock()
+
+	if rotator == nil {
+		return errors.New("the node doesn't log to a file (log_file)")
+	}
+	if err := rotator(); err != nil {
+		return fmt.Errorf("failed to rotate the logs: %v", err)
+	}
+	n.Logger.Info("Rotated the logs")
+	return nil
+}
+
+// startControlServer starts the control API server, and the routine stopping
+// the node after the stop height.
+func (n *Node) startControlServer() error {
+	sub, err := n.eventBus.Subscribe(context.Background(), controlSubscriber, types.EventQueryNewBlock)
+	if err != nil {
+		return err
+	}
+	n.controlServer = control.NewServer(n.config.Control, n, n.Logger.With("module", "control"))
+	if err := n.controlServer.Start(); err != nil {
+		return err
+	}
+
+	go func() {
+		for {
+			select {
+			case msg := <-sub.Out():
+				height := msg.Data().(types.EventDataNewBlock).Block.Height
+				n.controlMtx.Lock()
+				stop := n.stopHeight > 0 && height >= n.stopHeight
+				n.controlMtx.Unlock()
+				if stop {
+					n.Logger.Info("Stopping the node after height", "height", height)
+					if err := n.Stop(); err != nil {
+						n.Logger.Error("Failed to stop the node", "err", err)
+					}
+					return
+				}
+			case <-sub.Cancelled():
+				return
+			}
+		}
+	}()
+	return nil
+}
@@ -0,0 +1,172 @@
+package node
+
+import (
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+
+	cmn "github.com/hdac-io/tendermint/libs/common"
+	"github.com/hdac-io/tendermint/libs/log"
+	"github.com/hdac-io/tendermint/p2p/upnp"
+)
+
+const (
+	// the lease of the port mapping, renewed every half lease
+	portMappingLease = 20 * time.Minute
+
+	portMappingDescription = "tendermint"
+)
+
+// The sources of the external address of the node.
+const (
+	externalAddressConfig = "config"
+	externalAddressPeers  = "peers"
+)
+
+// portMapping maps the p2p port of the node on its NAT gateway, with UPnP or
+// NAT-PMP, for the node to be dialable without configuring the router, and
+// renews the mapping until stopped.
+type portMapping struct {
+	cmn.BaseService
+
+	nat          upnp.NAT
+	source       string // "upnp" or "nat-pmp"
+	internalPort int
+	externalIP   net.IP
+
+	mtx          sync.Mutex
+	externalPort int
+}
+
+// newPortMapping maps the port of listenAddr on the gateway found with UPnP,
+// else NAT-PMP.
+func newPortMapping(listenAddr string, logger log.Logger) (*portMapping, error) {
+	port, err := listenPort(listenAddr)
+	if err != nil {
+		return nil, errors.Wrap(err, "p2p.laddr is incorrect")
+	}
+
+	pm := &portMapping{internalPort: port}
+	pm.BaseService = *cmn.NewBaseService(logger, "PortMapping", pm)
+	if pm.nat, err = upnp.Discover(); err == nil {
+		pm.source = "upnp"
+	} else {
+		logger.Debug("No UPnP gateway, trying NAT-PMP", "err", err)
+		if pm.nat, err = upnp.DiscoverNATPMP(nil); err != nil {
+			return nil, errors.Wrap(err, "no UPnP nor NAT-PMP gateway")
+		}
+		pm.source = "nat-pmp"
+	}
+
+	if pm.externalIP, err = pm.nat.GetExternalAddress(); err != nil {
+		return nil, errors.Wrap(err, "could not get the external IP")
+	}
+	if err := pm.renew(); err != nil {
+		return nil, errors.Wrap(err, "could not map the port")
+	}
+	return pm, nil
+}
+
+// renew (re)creates the mapping, asking for the same external port as the
+// internal one the first time.
+func (pm *portMapping) renew() error {
+	externalPort := pm.getExternalPort()
+	if externalPort == 0 {
+		externalPort = pm.internalPort
+	}
+	mapped, err := pm.nat.AddPortMapping("tcp", externalPort, pm.internalPort, portMappingDescription,
+		int(portMappingLease/time.Second))
+	if err != nil {
+		return err
+	}
+	pm.mtx.Lock()
+	pm.externalPort = mapped
+	pm.mtx.Unlock()
+	return nil
+}
+
+func (pm *portMapping) getExternalPort() int {
+	pm.mtx.Lock()
+	defer pm.mtx.Unlock()
+	return pm.externalPort
+}
+
+// Address returns the external address of the mapping, as host:port.
+func (pm *portMapping) Address() string {
+	return net.JoinHostPort(pm.externalIP.String(), strconv.Itoa(pm.getExternalPort()))
+}
+
+// OnStart implements cmn.Service.
+func (pm *portMapping) OnStart() error {
+	go pm.renewRoutine()
+	return nil
+}
+
+// OnStop implements cmn.Service by deleting the mapping.
+func (pm *portMapping) OnStop() {
+	if err := pm.nat.DeletePortMapping("tcp", pm.getExternalPort(), pm.internalPort); err != nil {
+		pm.Logger.Error("Failed to delete the port mapping", "err", err)
+	}
+}
+
+func (pm *portMapping) renewRoutine() {
+	ticker := time.NewTicker(portMappingLease / 2)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			previous := pm.getExternalPort()
+			if err := pm.renew(); err != nil {
+				pm.Logger.Error("Failed to renew the port mapping", "err", err)
+			} else if port := pm.getExternalPort(); port != previous {
+				pm.Logger.Error("The gateway mapped another external port, the peers know the previous one",
+					"previous", previous, "port", port)
+			}
+		case <-pm.Quit():
+			return
+		}
+	}
+}
+
+// ExternalAddress returns the address the node is dialable at from outside
+// its network, as host:port, and where it comes from: the config
+// (p2p.external_address), the port mapping of the gateway ("upnp" or
+// "nat-pmp"), or the IP most peers see the node from with the p2p port
+// ("peers"). It returns empty strings if it's not known.
+func (n *Node) ExternalAddress() (address, source string) {
+	if n.config.P2P.ExternalAddress != "" {
+		return n.config.P2P.ExternalAddress, externalAddressConfig
+	}
+	if n.portMapping != nil {
+		return n.portMapping.Address(), n.portMapping.source
+	}
+	if n.pexReactor == nil {
+		return "", ""
+	}
+	ip := n.pexReactor.ObservedExternalIP()
+	if ip == nil {
+		return "", ""
+	}
+	port, err := listenPort(n.config.P2P.ListenAddress)
+	if err != nil {
+		return "", ""
+	}
+	return net.JoinHostPort(ip.String(), strconv.Itoa(port)), externalAddressPeers
+}
+
+// listenPort returns the port of the p2p listen address, e.g.
+// tcp://0.0.0.0:26656.
+func listenPort(listenAddr string) (int, error) {
+	if i := strings.Index(listenAddr, "://"); i >= 0 {
+		listenAddr = listenAddr[i+3:]
+	}
+	_, port, err := net.SplitHostPort(listenAddr)
+	if err != nil {
+		return 0, err
+	}
+	return strconv.Atoi(port)
+}
@@ -3,12 +3,17 @@ package node
 import (
 	"bytes"
 	"context"
+	"crypto/x509"
+	"encoding/hex"
 	"fmt"
+	"io/ioutil"
 	"net"
 	"net/http"
-	_ "net/http/pprof"
+	"net/http/pprof"
 	"os"
+	"runtime"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/pkg/errors"
@@ -23,15 +28,22 @@ import (
 	cfg "github.com/hdac-io/tendermint/config"
 	"github.com/hdac-io/tendermint/consensus"
 	cs "github.com/hdac-io/tendermint/consensus"
+	"github.com/hdac-io/tendermint/consensus/eventlog"
 	fridaycs "github.com/hdac-io/tendermint/consensus/friday"
+	"github.com/hdac-io/tendermint/control"
 	"github.com/hdac-io/tendermint/crypto"
+	cryptoAmino "github.com/hdac-io/tendermint/crypto/encoding/amino"
+	"github.com/hdac-io/tendermint/crypto/xsalsa20symmetric"
 	"github.com/hdac-io/tendermint/evidence"
 	cmn "github.com/hdac-io/tendermint/libs/common"
 	"github.com/hdac-io/tendermint/libs/log"
+	"github.com/hdac-io/tendermint/libs/trace"
 	tmpubsub "github.com/hdac-io/tendermint/libs/pubsub"
 	mempl "github.com/hdac-io/tendermint/mempool"
 	"github.com/hdac-io/tendermint/p2p"
+	"github.com/hdac-io/tendermint/p2p/conn"
 	"github.com/hdac-io/tendermint/p2p/pex"
+	"github.com/hdac-io/tendermint/p2p/trust"
 	"github.com/hdac-io/tendermint/privval"
 	"github.com/hdac-io/tendermint/proxy"
 	rpccore "github.com/hdac-io/tendermint/rpc/core"
@@ -42,6 +54,7 @@ import (
 	"github.com/hdac-io/tendermint/state/txindex"
 	"github.com/hdac-io/tendermint/state/txindex/kv"
 	"github.com/hdac-io/tendermint/state/txindex/null"
+	"github.com/hdac-io/tendermint/state/txindex/psql"
 	"github.com/hdac-io/tendermint/store"
 	"github.com/hdac-io/tendermint/types"
 	tmtime "github.com/hdac-io/tendermint/types/time"
@@ -110,12 +123,30 @@ func DefaultNewNode(config *cfg.Config, logger log.Logger) (*Node, error) {
 		oldPV.Upgrade(newPrivValKey, newPrivValState)
 	}
 
-	var privVal types.PrivValidator
+	var (
+		privVal       types.PrivValidator
+		clientCreator proxy.ClientCreator
+	)
 	switch config.Consensus.Module {
 	case "tendermint":
 		privVal = privval.LoadOrGenFilePV(newPrivValKey, newPrivValState)
+		if config.ParallelDeliverTx {
+			// the block executor orders the txs the app runs concurrently
+			clientCreator = proxy.DefaultFridayClientCreator(config.ProxyApp, config.ABCI, config.DBDir())
+		} else {
+			clientCreator = proxy.DefaultClientCreator(config.ProxyApp, config.ABCI, config.DBDir())
+		}
 	case "friday":
-		privVal = privval.LoadOrGenFridayFilePV(newPrivValKey, newPrivValState)
+		clientCreator = proxy.DefaultFridayClientCreator(config.ProxyApp, config.ABCI, config.DBDir())
+		secret, err := loadDiskEncryptionSecret(config)
+		if err != nil {
+			return nil, err
+		}
+		if secret != nil {
+			privVal = privval.LoadOrGenEncryptedFridayFilePV(newPrivValKey, newPrivValState, xsalsa20symmetric.Symmetric{}, secret)
+		} else {
+			privVal = privval.LoadOrGenFridayFilePV(newPrivValKey, newPrivValState)
+		}
 	default:
 		return nil, fmt.Errorf("invalid consensus module %s", config.Consensus.Module)
 	}
@@ -123,7 +154,7 @@ func DefaultNewNode(config *cfg.Config, logger log.Logger) (*Node, error) {
 	return NewNode(config,
 		privVal,
 		nodeKey,
-		proxy.DefaultClientCreator(config.ProxyApp, config.ABCI, config.DBDir()),
+		clientCreator,
 		DefaultGenesisDocProviderFunc(config),
 		DefaultDBProvider,
 		DefaultMetricsProvider(config.Instrumentation),
@@ -131,20 +162,23 @@ func DefaultNewNode(config *cfg.Config, logger log.Logger) (*Node, error) {
 	)
 }
 
-// MetricsProvider returns a consensus, p2p and mempool Metrics.
-type MetricsProvider func(chainID string) (*cs.Metrics, *p2p.Metrics, *mempl.Metrics, *sm.Metrics)
+// MetricsProvider returns a consensus, p2p, mempool, state, evidence and RPC
+// server Metrics.
+type MetricsProvider func(chainID string) (*cs.Metrics, *p2p.Metrics, *mempl.Metrics, *sm.Metrics, *evidence.Metrics, *rpcserver.Metrics)
 
 // DefaultMetricsProvider returns Metrics build using Prometheus client library
 // if Prometheus is enabled. Otherwise, it returns no-op Metrics.
 func DefaultMetricsProvider(config *cfg.InstrumentationConfig) MetricsProvider {
-	return func(chainID string) (*cs.Metrics, *p2p.Metrics, *mempl.Metrics, *sm.Metrics) {
+	return func(chainID string) (*cs.Metrics, *p2p.Metrics, *mempl.Metrics, *sm.Metrics, *evidence.Metrics, *rpcserver.Metrics) {
 		if config.Prometheus {
 			return cs.PrometheusMetrics(config.Namespace, "chain_id", chainID),
 				p2p.PrometheusMetrics(config.Namespace, "chain_id", chainID),
 				mempl.PrometheusMetrics(config.Namespace, "chain_id", chainID),
-				sm.PrometheusMetrics(config.Namespace, "chain_id", chainID)
+				sm.PrometheusMetrics(config.Namespace, "chain_id", chainID),
+				evidence.PrometheusMetrics(config.Namespace, "chain_id", chainID),
+				rpcserver.PrometheusMetrics(config.Namespace, "chain_id", chainID)
 		}
-		return cs.NopMetrics(), p2p.NopMetrics(), mempl.NopMetrics(), sm.NopMetrics()
+		return cs.NopMetrics(), p2p.NopMetrics(), mempl.NopMetrics(), sm.NopMetrics(), evidence.NopMetrics(), rpcserver.NopMetrics()
 	}
 }
 
@@ -175,6 +209,25 @@ func CustomReactors(reactors map[string]p2p.Reactor) Option {
 	}
 }
 
+// TxGas makes the node account the gas of the txs with txGas, enforcing the
+// Block.MaxGas consensus param: the proposal blocks are filled with txs up to
+// it, and the blocks exceeding it are invalid. All the nodes must use the same
+// txGas.
+func TxGas(txGas sm.TxGasFunc) Option {
+	return func(n *Node) {
+		n.blockExec.SetTxGas(txGas)
+	}
+}
+
+// ProposalTxsPreparer makes the node hand the txs reaped for its proposal
+// blocks to txsPreparer, e.g. for the app to reorder them, within the
+// consensus.timeout_prepare_proposal budget.
+func ProposalTxsPreparer(txsPreparer sm.ProposalTxsPreparer) Option {
+	return func(n *Node) {
+		n.blockExec.SetProposalTxsPreparer(txsPreparer)
+	}
+}
+
 //------------------------------------------------------------------------------
 
 // Node is the highest level interface to a full Tendermint node.
@@ -187,6 +240,16 @@ type Node struct {
 	genesisDoc    *types.GenesisDoc   // initial validator set
 	privValidator types.PrivValidator // local node's validator key
 
+	configMtx      sync.Mutex
+	configLoader   ConfigLoader // loads the config to reload
+	reloadedConfig *cfg.Config  // config with the reloaded fields, if reloaded
+
+	// control API
+	controlServer *control.Server
+	controlMtx    sync.Mutex
+	stopHeight    int64      // stop after this height if above 0
+	logRotator    LogRotator // rotates the log file
+
 	// network
 	transport   *p2p.MultiplexTransport
 	sw          *p2p.Switch  // p2p connections
@@ -194,32 +257,48 @@ type Node struct {
 	nodeInfo    p2p.NodeInfo
 	nodeKey     *p2p.NodeKey // our node privkey
 	isListening bool
+	trustStore  *trust.TrustMetricStore // trust metrics of the peers
+	portMapping *portMapping            // p2p port mapped on the NAT gateway, if any
+
+	bandwidthGroups p2p.BandwidthGroups // bandwidth budgets of the reactors
 
 	// services
 	eventBus         *types.EventBus // pub/sub for services
 	stateDB          dbm.DB
-	blockStore       *store.BlockStore // store the blockchain to disk
-	bcReactor        p2p.Reactor       // for fast-syncing
-	mempoolReactor   *mempl.Reactor    // for gossipping transactions
+	blockStore       sm.BlockStore  // store the blockchain to disk
+	headerStore      *store.HeaderStore // store the headers only (fastsync version "headers")
+	bcReactor        p2p.Reactor    // for fast-syncing
+	mempoolReactor   *mempl.Reactor // for gossipping transactions
 	mempool          mempl.Mempool
 	consensusState   cs.IConsensusState     // latest consensus state
 	consensusReactor cs.IConsensusReactor   // for participating in the consensus
 	pexReactor       *pex.PEXReactor        // for exchanging peer addresses
 	evidencePool     *evidence.EvidencePool // tracking evidence
+	blockExec        *sm.BlockExecutor      // executing the blocks
 	proxyApp         proxy.AppConns         // connection to the application
 	rpcListeners     []net.Listener         // rpc servers
+	rpcMetrics       *rpcserver.Metrics     // rate limits of the rpc servers
 	txIndexer        txindex.TxIndexer
 	indexerService   *txindex.IndexerService
+	eventRecorder    *eventlog.Recorder // records the events, if enabled
 	prometheusSrv    *http.Server
+	debugSrv         *http.Server
+	pprofSrv         *http.Server
+	tracer           trace.Tracer
 }
 
-func initDBs(config *cfg.Config, dbProvider DBProvider) (blockStore *store.BlockStore, stateDB dbm.DB, err error) {
+// InitDBs opens the block store, with the configured backend, and the state DB.
+// It is exported for the commands working on the data of a stopped node.
+func InitDBs(config *cfg.Config, dbProvider DBProvider, logger log.Logger) (blockStore sm.BlockStore, stateDB dbm.DB, err error) {
 	var blockStoreDB dbm.DB
 	blockStoreDB, err = dbProvider(&DBContext{"blockstore", config})
 	if err != nil {
 		return
 	}
-	blockStore = store.NewBlockStore(blockStoreDB)
+	blockStore, err = createBlockStore(config.BlockStore, blockStoreDB, logger)
+	if err != nil {
+		return
+	}
 
 	stateDB, err = dbProvider(&DBContext{"state", config})
 	if err != nil {
@@ -229,6 +308,30 @@ func initDBs(config *cfg.Config, dbProvider DBProvider) (blockStore *store.Block
 	return
 }
 
+func createBlockStore(config *cfg.BlockStoreConfig, db dbm.DB, logger log.Logger) (sm.BlockStore, error) {
+	switch config.Backend {
+	case "local":
+		return store.NewBlockStore(db), nil
+	case "s3":
+		storage, err := store.NewS3Storage(
+			config.S3Endpoint,
+			config.S3Region,
+			config.S3Bucket,
+			config.S3Prefix,
+			os.Getenv("AWS_ACCESS_KEY_ID"),
+			os.Getenv("AWS_SECRET_ACCESS_KEY"),
+		)
+		if err != nil {
+			return nil, errors.Wrap(err, "could not create the block store")
+		}
+		archive := store.NewArchiveBlockStore(db, storage, config.KeepRecent)
+		archive.SetLogger(logger.With("module", "blockstore"))
+		return archive, nil
+	default:
+		return nil, fmt.Errorf("unknown block store backend %s", config.Backend)
+	}
+}
+
 func createAndStartProxyAppConns(clientCreator proxy.ClientCreator, logger log.Logger) (proxy.AppConns, error) {
 	proxyApp := proxy.NewAppConns(clientCreator)
 	proxyApp.SetLogger(logger.With("module", "proxy"))
@@ -247,7 +350,26 @@ func createAndStartEventBus(logger log.Logger) (*types.EventBus, error) {
 	return eventBus, nil
 }
 
-func createAndStartIndexerService(config *cfg.Config, dbProvider DBProvider,
+// createAndStartEventRecorder returns the recorder of the events of the
+// EventBus, or nil if disabled.
+func createAndStartEventRecorder(config *cfg.ConsensusConfig, eventBus *types.EventBus,
+	logger log.Logger) (*eventlog.Recorder, error) {
+
+	if !config.EventLogEnabled() {
+		return nil, nil
+	}
+	recorder, err := eventlog.NewRecorder(config.EventLogFile(), eventBus)
+	if err != nil {
+		return nil, err
+	}
+	recorder.SetLogger(logger.With("module", "eventlog"))
+	if err := recorder.Start(); err != nil {
+		return nil, err
+	}
+	return recorder, nil
+}
+
+func createAndStartIndexerService(config *cfg.Config, dbProvider DBProvider, state sm.State,
 	eventBus *types.EventBus, logger log.Logger) (*txindex.IndexerService, txindex.TxIndexer, error) {
 
 	var txIndexer txindex.TxIndexer
@@ -269,7 +391,22 @@ func createAndStartIndexerService(config *cfg.Config, dbProvider DBProvider,
 		txIndexer = &null.TxIndex{}
 	}
 
-	indexerService := txindex.NewIndexerService(txIndexer, eventBus)
+	var sinks []txindex.Sink
+	if config.TxIndex.PsqlConn != "" {
+		// the results of a block are in the LastResultsHash of the next one,
+		// or of the one LenULB heights above with the friday consensus
+		resultsDelay := int64(1)
+		if config.Consensus.Module == "friday" {
+			resultsDelay = state.ConsensusParams.Block.LenULB
+		}
+		sink, err := psql.NewSink(config.TxIndex.PsqlConn, state.ChainID, resultsDelay)
+		if err != nil {
+			return nil, nil, err
+		}
+		sinks = append(sinks, sink)
+	}
+
+	indexerService := txindex.NewIndexerService(txIndexer, eventBus, sinks...)
 	indexerService.SetLogger(logger.With("module", "txindex"))
 	if err := indexerService.Start(); err != nil {
 		return nil, nil, err
@@ -279,8 +416,11 @@ func createAndStartIndexerService(config *cfg.Config, dbProvider DBProvider,
 
 func doHandshake(config *cfg.Config, stateDB dbm.DB, state sm.State, blockStore sm.BlockStore,
 	genDoc *types.GenesisDoc, eventBus *types.EventBus, proxyApp proxy.AppConns, consensusLogger log.Logger) error {
-	if config.Consensus.Module != genDoc.ConsensusModule {
-		return fmt.Errorf("unmatched between Consensus.Module in config.toml and consensus_module in genesis.json")
+	// the module of the state is the one of genesis.json, unless the chain
+	// was migrated to another one by migrate-chain
+	if config.Consensus.Module != state.Version.Consensus.Module {
+		return fmt.Errorf("unmatched between Consensus.Module in config.toml and the consensus module %s of the state",
+			state.Version.Consensus.Module)
 	}
 
 	// Handshaker it's only used here. don't abstract.
@@ -289,6 +429,9 @@ func doHandshake(config *cfg.Config, stateDB dbm.DB, state sm.State, blockStore
 		handshaker := cs.NewHandshaker(stateDB, state, blockStore, genDoc)
 		handshaker.SetLogger(consensusLogger)
 		handshaker.SetEventBus(eventBus)
+		if config.ParallelDeliverTx {
+			handshaker.SetParallelDeliverTx()
+		}
 		if err := handshaker.Handshake(proxyApp); err != nil {
 			return fmt.Errorf("error during handshake: %v", err)
 		}
@@ -296,6 +439,9 @@ func doHandshake(config *cfg.Config, stateDB dbm.DB, state sm.State, blockStore
 		handshaker := fridaycs.NewHandshaker(stateDB, state, blockStore, genDoc)
 		handshaker.SetLogger(consensusLogger)
 		handshaker.SetEventBus(eventBus)
+		if config.ParallelDeliverTx {
+			handshaker.SetParallelDeliverTx()
+		}
 		if err := handshaker.Handshake(proxyApp); err != nil {
 			return fmt.Errorf("error during handshake: %v", err)
 		}
@@ -361,14 +507,15 @@ func createMempoolAndMempoolReactor(config *cfg.Config, proxyApp proxy.AppConns,
 }
 
 func createEvidenceReactor(config *cfg.Config, dbProvider DBProvider,
-	stateDB dbm.DB, logger log.Logger) (*evidence.EvidenceReactor, *evidence.EvidencePool, error) {
+	stateDB dbm.DB, blockStore sm.BlockStore, evidenceMetrics *evidence.Metrics, logger log.Logger) (*evidence.EvidenceReactor, *evidence.EvidencePool, error) {
 
 	evidenceDB, err := dbProvider(&DBContext{"evidence", config})
 	if err != nil {
 		return nil, nil, err
 	}
 	evidenceLogger := logger.With("module", "evidence")
-	evidencePool := evidence.NewEvidencePool(stateDB, evidenceDB)
+	evidencePool := evidence.NewEvidencePool(stateDB, evidenceDB, blockStore,
+		evidence.WithMetrics(evidenceMetrics))
 	evidencePool.SetLogger(evidenceLogger)
 	evidenceReactor := evidence.NewEvidenceReactor(evidencePool)
 	evidenceReactor.SetLogger(evidenceLogger)
@@ -378,7 +525,7 @@ func createEvidenceReactor(config *cfg.Config, dbProvider DBProvider,
 func createBlockchainReactor(config *cfg.Config,
 	state sm.State,
 	blockExec *sm.BlockExecutor,
-	blockStore *store.BlockStore,
+	blockStore sm.BlockStore,
 	fastSync bool,
 	logger log.Logger) (bcReactor p2p.Reactor, err error) {
 
@@ -395,6 +542,37 @@ func createBlockchainReactor(config *cfg.Config,
 	return bcReactor, nil
 }
 
+// createHeaderSyncReactor opens the header store and returns the reactor
+// syncing it, for the nodes which only sync the headers.
+func createHeaderSyncReactor(config *cfg.Config, dbProvider DBProvider, state sm.State,
+	logger log.Logger) (*store.HeaderStore, p2p.Reactor, error) {
+
+	headerStoreDB, err := dbProvider(&DBContext{"headerstore", config})
+	if err != nil {
+		return nil, nil, err
+	}
+	headerStore := store.NewHeaderStore(headerStoreDB)
+	hsReactor := bcv0.NewHeaderSyncReactor(state.Copy(), headerStore)
+	hsReactor.SetLogger(logger.With("module", "blockchain"))
+	return headerStore, hsReactor, nil
+}
+
+// createTracer returns the tracer of the heights configured in
+// [instrumentation], or a no-op one if tracing is disabled.
+func createTracer(config *cfg.Config, chainID string, nodeID p2p.ID, logger log.Logger) trace.Tracer {
+	if !config.Instrumentation.Tracing {
+		return trace.NopTracer()
+	}
+	tracer := trace.NewOTLPTracer(
+		config.Instrumentation.TracingOTLPEndpoint,
+		config.Instrumentation.Namespace,
+		chainID,
+		string(nodeID),
+	)
+	tracer.SetLogger(logger.With("module", "trace"))
+	return tracer
+}
+
 func createConsensusReactor(config *cfg.Config,
 	state sm.State,
 	blockExec *sm.BlockExecutor,
@@ -403,8 +581,10 @@ func createConsensusReactor(config *cfg.Config,
 	evidencePool *evidence.EvidencePool,
 	privValidator types.PrivValidator,
 	csMetrics *cs.Metrics,
+	tracer trace.Tracer,
 	fastSync bool,
 	eventBus *types.EventBus,
+	diskSecret []byte,
 	consensusLogger log.Logger) (consensus.IConsensusReactor, consensus.IConsensusState) {
 
 	var consensusState consensus.IConsensusState
@@ -426,6 +606,10 @@ func createConsensusReactor(config *cfg.Config,
 		consensusReactor = tmConsensusReactor
 
 	case "friday":
+		stateOptions := []fridaycs.StateOption{fridaycs.StateMetrics(csMetrics), fridaycs.StateTracer(tracer)}
+		if diskSecret != nil {
+			stateOptions = append(stateOptions, fridaycs.WALEncryption(xsalsa20symmetric.Symmetric{}, diskSecret))
+		}
 		fridayConsensusState := fridaycs.NewConsensusState(
 			config.Consensus,
 			state.Copy(),
@@ -433,7 +617,7 @@ func createConsensusReactor(config *cfg.Config,
 			blockStore,
 			mempool,
 			evidencePool,
-			fridaycs.StateMetrics(csMetrics),
+			stateOptions...,
 		)
 
 		fridayConsensusReactor := fridaycs.NewConsensusReactor(fridayConsensusState, fastSync, fridaycs.ReactorMetrics(csMetrics))
@@ -455,14 +639,43 @@ func createConsensusReactor(config *cfg.Config,
 	return consensusReactor, consensusState
 }
 
-func createTransport(config *cfg.Config, nodeInfo p2p.NodeInfo, nodeKey *p2p.NodeKey, proxyApp proxy.AppConns) (*p2p.MultiplexTransport, []p2p.PeerFilterFunc) {
+// loadDiskEncryptionSecret loads the secret of disk_encryption_key.
+// It returns nil if the encryption at rest is disabled.
+func loadDiskEncryptionSecret(config *cfg.Config) ([]byte, error) {
+	if config.DiskEncryptionKey == "" {
+		return nil, nil
+	}
+	secret, err := xsalsa20symmetric.LoadSecret(config.DiskEncryptionKey)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not load disk_encryption_key")
+	}
+	return secret, nil
+}
+
+func createTransport(config *cfg.Config, nodeInfo p2p.NodeInfo, nodeKey *p2p.NodeKey,
+	proxyApp proxy.AppConns, bandwidthGroups p2p.BandwidthGroups,
+	logger log.Logger) (*p2p.MultiplexTransport, []p2p.PeerFilterFunc, error) {
+	mConnConfig := p2p.MConnConfig(config.P2P)
+	mConnConfig.ChannelBudgets = bandwidthGroups.ChannelBudgets()
 	var (
-		mConnConfig = p2p.MConnConfig(config.P2P)
 		transport   = p2p.NewMultiplexTransport(nodeInfo, *nodeKey, mConnConfig)
 		connFilters = []p2p.ConnFilterFunc{}
 		peerFilters = []p2p.PeerFilterFunc{}
 	)
 
+	pinnedKeys, err := persistentPeersPubKeys(config.P2P)
+	if err != nil {
+		return nil, nil, err
+	}
+	p2p.MultiplexTransportPinnedKeys(pinnedKeys)(transport)
+	p2p.MultiplexTransportSecretConnConfig(conn.SecretConnConfig{
+		RekeyBytes:    config.P2P.SecretConnRekeyBytes,
+		RekeyInterval: config.P2P.SecretConnRekeyInterval,
+	})(transport)
+	if config.P2P.SecretConnAudit {
+		p2p.MultiplexTransportSecretConnAudit(logger.With("module", "p2p"))(transport)
+	}
+
 	if !config.P2P.AllowDuplicateIP {
 		connFilters = append(connFilters, p2p.ConnDuplicateIPFilter())
 	}
@@ -508,7 +721,68 @@ func createTransport(config *cfg.Config, nodeInfo p2p.NodeInfo, nodeKey *p2p.Nod
 	}
 
 	p2p.MultiplexTransportConnFilters(connFilters...)(transport)
-	return transport, peerFilters
+	return transport, peerFilters, nil
+}
+
+// createBandwidthGroups returns the bandwidth budgets of the channels of the
+// reactors, see the rates of the P2PConfig.
+func createBandwidthGroups(config *cfg.P2PConfig) p2p.BandwidthGroups {
+	// the blockchain reactors v0 and v1 share their channel
+	return p2p.BandwidthGroups{
+		{
+			Name: "consensus",
+			Channels: []byte{
+				cs.StateChannel, cs.DataChannel, cs.VoteChannel, cs.VoteSetBitsChannel,
+				fridaycs.DataParityChannel, fridaycs.CommitAggregateChannel,
+			},
+			Budget: conn.NewBandwidthBudget(config.ConsensusSendRate, config.ConsensusRecvRate),
+		},
+		{
+			Name:     "blockchain",
+			Channels: []byte{bcv0.BlockchainChannel},
+			Budget:   conn.NewBandwidthBudget(config.BlockchainSendRate, config.BlockchainRecvRate),
+		},
+		{
+			Name:     "mempool",
+			Channels: []byte{mempl.MempoolChannel, mempl.TxHashesChannel, mempl.PullTxsChannel},
+			Budget:   conn.NewBandwidthBudget(config.MempoolSendRate, config.MempoolRecvRate),
+		},
+		{
+			Name:     "evidence",
+			Channels: []byte{evidence.EvidenceChannel},
+			Budget:   conn.NewBandwidthBudget(config.EvidenceSendRate, config.EvidenceRecvRate),
+		},
+	}
+}
+
+// persistentPeersPubKeys returns the public keys of persistent_peers_pubkeys
+// by ID, checking that each is the key of a persistent peer.
+func persistentPeersPubKeys(config *cfg.P2PConfig) (map[p2p.ID]crypto.PubKey, error) {
+	persistentIDs := make(map[p2p.ID]bool)
+	for _, addr := range splitAndTrimEmpty(config.PersistentPeers, ",", " ") {
+		if i := strings.Index(addr, "://"); i >= 0 {
+			addr = addr[i+3:]
+		}
+		persistentIDs[p2p.ID(strings.Split(addr, "@")[0])] = true
+	}
+
+	keys := make(map[p2p.ID]crypto.PubKey)
+	for _, hexKey := range splitAndTrimEmpty(config.PersistentPeersPubKeys, ",", " ") {
+		bz, err := hex.DecodeString(hexKey)
+		if err != nil {
+			return nil, fmt.Errorf("persistent_peers_pubkeys: %s is not hex encoded", hexKey)
+		}
+		pubKey, err := cryptoAmino.PubKeyFromBytes(bz)
+		if err != nil {
+			return nil, errors.Wrapf(err, "persistent_peers_pubkeys: %s is not a public key", hexKey)
+		}
+		id := p2p.PubKeyToID(pubKey)
+		if !persistentIDs[id] {
+			return nil, fmt.Errorf("persistent_peers_pubkeys: the ID %s of %s is not the ID of a persistent peer", id, hexKey)
+		}
+		keys[id] = pubKey
+	}
+	return keys, nil
 }
 
 func createSwitch(config *cfg.Config,
@@ -521,6 +795,8 @@ func createSwitch(config *cfg.Config,
 	evidenceReactor *evidence.EvidenceReactor,
 	nodeInfo p2p.NodeInfo,
 	nodeKey *p2p.NodeKey,
+	trustStore *trust.TrustMetricStore,
+	banList *p2p.BanList,
 	p2pLogger log.Logger) *p2p.Switch {
 
 	sw := p2p.NewSwitch(
@@ -528,6 +804,8 @@ func createSwitch(config *cfg.Config,
 		transport,
 		p2p.WithMetrics(p2pMetrics),
 		p2p.SwitchPeerFilters(peerFilters...),
+		p2p.SwitchTrustMetricStore(trustStore),
+		p2p.SwitchBanList(banList),
 	)
 	sw.SetLogger(p2pLogger)
 	sw.AddReactor("MEMPOOL", mempoolReactor)
@@ -542,8 +820,26 @@ func createSwitch(config *cfg.Config,
 	return sw
 }
 
+// createPeerStore opens the trust metrics of the peers and the list of the
+// banned peers, both saved in the peerstore DB so they last across restarts.
+func createPeerStore(config *cfg.Config, dbProvider DBProvider,
+	p2pLogger log.Logger) (*trust.TrustMetricStore, *p2p.BanList, error) {
+
+	peerStoreDB, err := dbProvider(&DBContext{"peerstore", config})
+	if err != nil {
+		return nil, nil, err
+	}
+	trustStore := trust.NewTrustMetricStore(peerStoreDB, trust.DefaultConfig())
+	trustStore.SetLogger(p2pLogger)
+	banList, err := p2p.NewBanList(peerStoreDB)
+	if err != nil {
+		return nil, nil, err
+	}
+	return trustStore, banList, nil
+}
+
 func createAddrBookAndSetOnSwitch(config *cfg.Config, sw *p2p.Switch,
-	p2pLogger log.Logger, nodeKey *p2p.NodeKey) (pex.AddrBook, error) {
+	p2pLogger log.Logger, nodeKey *p2p.NodeKey, natMapping *portMapping) (pex.AddrBook, error) {
 
 	addrBook := pex.NewAddrBook(config.P2P.AddrBookFile(), config.P2P.AddrBookStrict)
 	addrBook.SetLogger(p2pLogger.With("book", config.P2P.AddrBookFile()))
@@ -556,6 +852,13 @@ func createAddrBookAndSetOnSwitch(config *cfg.Config, sw *p2p.Switch,
 		}
 		addrBook.AddOurAddress(addr)
 	}
+	if natMapping != nil {
+		addr, err := p2p.NewNetAddressString(p2p.IDAddressString(nodeKey.ID(), natMapping.Address()))
+		if err != nil {
+			return nil, errors.Wrap(err, "the address mapped on the NAT gateway is incorrect")
+		}
+		addrBook.AddOurAddress(addr)
+	}
 	if config.P2P.ListenAddress != "" {
 		addr, err := p2p.NewNetAddressString(p2p.IDAddressString(nodeKey.ID(), config.P2P.ListenAddress))
 		if err != nil {
@@ -600,10 +903,11 @@ func NewNode(config *cfg.Config,
 	logger log.Logger,
 	options ...Option) (*Node, error) {
 
-	blockStore, stateDB, err := initDBs(config, dbProvider)
+	blockStore, stateDB, err := InitDBs(config, dbProvider, logger)
 	if err != nil {
 		return nil, err
 	}
+	sm.SetCacheSize(config.StateCacheSize)
 
 	state, genDoc, err := LoadStateFromDBOrGenesisDocProvider(stateDB, genesisDocProvider)
 	if err != nil {
@@ -625,8 +929,13 @@ func NewNode(config *cfg.Config,
 		return nil, err
 	}
 
+	eventRecorder, err := createAndStartEventRecorder(config.Consensus, eventBus, logger)
+	if err != nil {
+		return nil, err
+	}
+
 	// Transaction indexing
-	indexerService, txIndexer, err := createAndStartIndexerService(config, dbProvider, eventBus, logger)
+	indexerService, txIndexer, err := createAndStartIndexerService(config, dbProvider, state, eventBus, logger)
 	if err != nil {
 		return nil, err
 	}
@@ -647,7 +956,16 @@ func NewNode(config *cfg.Config,
 	// external signing process.
 	if config.PrivValidatorListenAddr != "" {
 		// FIXME: we should start services inside OnStart
-		privValidator, err = createAndStartPrivValidatorSocketClient(config.PrivValidatorListenAddr, logger)
+		switch {
+		case config.PrivValidatorThreshold > 0:
+			privValidator, err = createAndStartPrivValidatorThresholdClient(
+				splitAndTrimEmpty(config.PrivValidatorListenAddr, ",", " "), config.PrivValidatorThreshold, logger)
+		case config.PrivValidatorFailover:
+			privValidator, err = createAndStartPrivValidatorFailoverClient(
+				splitAndTrimEmpty(config.PrivValidatorListenAddr, ",", " "), logger)
+		default:
+			privValidator, err = createAndStartPrivValidatorSocketClient(config.PrivValidatorListenAddr, logger)
+		}
 		if err != nil {
 			return nil, errors.Wrap(err, "error with private validator socket client")
 		}
@@ -659,24 +977,45 @@ func NewNode(config *cfg.Config,
 		return nil, errors.New("could not retrieve public key from private validator")
 	}
 
+	if config.Consensus.SimulateMisbehavior != "" {
+		if _, ok := privValidator.(types.ConflictingVoteSigner); !ok {
+			return nil, errors.New("the simulated misbehaviors require a node built with " +
+				"-tags misbehavior and a local priv_validator")
+		}
+		logger.Error("SIMULATING MISBEHAVIOR: the node is byzantine on purpose, only run it in a private testnet",
+			"misbehavior", config.Consensus.SimulateMisbehavior)
+	}
+
 	logNodeStartupInfo(state, pubKey, logger, consensusLogger)
 
 	// Decide whether to fast-sync or not
 	// We don't fast-sync when the only validator is us.
 	fastSync := config.FastSyncMode && !onlyValidatorIsUs(state, privValidator)
 
-	csMetrics, p2pMetrics, memplMetrics, smMetrics := metricsProvider(genDoc.ChainID)
+	csMetrics, p2pMetrics, memplMetrics, smMetrics, evidenceMetrics, rpcMetrics := metricsProvider(genDoc.ChainID)
+	tracer := createTracer(config, genDoc.ChainID, nodeKey.ID(), logger)
 
 	// Make MempoolReactor
 	mempoolReactor, mempool := createMempoolAndMempoolReactor(config, proxyApp, state, memplMetrics, logger)
 
 	// Make Evidence Reactor
-	evidenceReactor, evidencePool, err := createEvidenceReactor(config, dbProvider, stateDB, logger)
+	evidenceReactor, evidencePool, err := createEvidenceReactor(config, dbProvider, stateDB, blockStore, evidenceMetrics, logger)
 	if err != nil {
 		return nil, err
 	}
 
 	// make block executor for consensus and blockchain reactors to execute blocks
+	blockExecOptions := []sm.BlockExecutorOption{
+		sm.BlockExecutorWithMetrics(smMetrics),
+		sm.BlockExecutorWithTracer(tracer),
+	}
+	if config.Mempool.ValidatorLocal() {
+		// the mempool accepts no tx: the proposals pull theirs from the peers
+		blockExecOptions = append(blockExecOptions, sm.BlockExecutorWithTxPuller(mempoolReactor))
+	}
+	if config.ParallelDeliverTx {
+		blockExecOptions = append(blockExecOptions, sm.BlockExecutorWithParallelDeliverTx())
+	}
 	blockExec := sm.NewBlockExecutor(
 		blockStore,
 		stateDB,
@@ -684,34 +1023,70 @@ func NewNode(config *cfg.Config,
 		proxyApp.Consensus(),
 		mempool,
 		evidencePool,
-		sm.BlockExecutorWithMetrics(smMetrics),
+		blockExecOptions...,
 	)
 
 	// Make BlockchainReactor
-	bcReactor, err := createBlockchainReactor(config, state, blockExec, blockStore, fastSync, logger)
+	var (
+		bcReactor   p2p.Reactor
+		headerStore *store.HeaderStore
+	)
+	if config.FastSync.HeadersOnly() {
+		headerStore, bcReactor, err = createHeaderSyncReactor(config, dbProvider, state, logger)
+		// consensus waits for a switch which never comes
+		fastSync = true
+	} else {
+		bcReactor, err = createBlockchainReactor(config, state, blockExec, blockStore, fastSync, logger)
+	}
 	if err != nil {
 		return nil, errors.Wrap(err, "could not create blockchain reactor")
 	}
 
+	diskSecret, err := loadDiskEncryptionSecret(config)
+	if err != nil {
+		return nil, err
+	}
+
 	// Make ConsensusReactor
 	consensusReactor, consensusState := createConsensusReactor(
 		config, state, blockExec, blockStore, mempool, evidencePool,
-		privValidator, csMetrics, fastSync, eventBus, consensusLogger,
+		privValidator, csMetrics, tracer, fastSync, eventBus, diskSecret, consensusLogger,
 	)
 
-	nodeInfo, err := makeNodeInfo(config, nodeKey, txIndexer, genDoc, state)
+	// Map the p2p port on the NAT gateway, if it's not configured already.
+	var natMapping *portMapping
+	if config.P2P.UPNP && config.P2P.ExternalAddress == "" {
+		natLogger := logger.With("module", "nat")
+		natMapping, err = newPortMapping(config.P2P.ListenAddress, natLogger)
+		if err != nil {
+			natLogger.Error("Failed to map the p2p port on the NAT gateway", "err", err)
+		} else {
+			natLogger.Info("Mapped the p2p port on the NAT gateway", "source", natMapping.source,
+				"address", natMapping.Address())
+		}
+	}
+
+	nodeInfo, err := makeNodeInfo(config, nodeKey, txIndexer, genDoc, state, natMapping)
 	if err != nil {
 		return nil, err
 	}
 
 	// Setup Transport.
-	transport, peerFilters := createTransport(config, nodeInfo, nodeKey, proxyApp)
+	bandwidthGroups := createBandwidthGroups(config.P2P)
+	transport, peerFilters, err := createTransport(config, nodeInfo, nodeKey, proxyApp, bandwidthGroups, logger)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not create transport")
+	}
 
 	// Setup Switch.
 	p2pLogger := logger.With("module", "p2p")
+	trustStore, banList, err := createPeerStore(config, dbProvider, p2pLogger)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not create peer store")
+	}
 	sw := createSwitch(
 		config, transport, p2pMetrics, peerFilters, mempoolReactor, bcReactor,
-		consensusReactor, evidenceReactor, nodeInfo, nodeKey, p2pLogger,
+		consensusReactor, evidenceReactor, nodeInfo, nodeKey, trustStore, banList, p2pLogger,
 	)
 
 	err = sw.AddPersistentPeers(splitAndTrimEmpty(config.P2P.PersistentPeers, ",", " "))
@@ -719,7 +1094,12 @@ func NewNode(config *cfg.Config,
 		return nil, errors.Wrap(err, "could not add peers from persistent_peers field")
 	}
 
-	addrBook, err := createAddrBookAndSetOnSwitch(config, sw, p2pLogger, nodeKey)
+	err = sw.AddUnconditionalPeerIDs(splitAndTrimEmpty(config.P2P.UnconditionalPeerIDs, ",", " "))
+	if err != nil {
+		return nil, errors.Wrap(err, "could not add peer ids from unconditional_peer_ids field")
+	}
+
+	addrBook, err := createAddrBookAndSetOnSwitch(config, sw, p2pLogger, nodeKey, natMapping)
 	if err != nil {
 		return nil, errors.Wrap(err, "could not create addrbook")
 	}
@@ -752,14 +1132,19 @@ func NewNode(config *cfg.Config,
 		genesisDoc:    genDoc,
 		privValidator: privValidator,
 
-		transport: transport,
-		sw:        sw,
-		addrBook:  addrBook,
-		nodeInfo:  nodeInfo,
-		nodeKey:   nodeKey,
+		transport:   transport,
+		sw:          sw,
+		addrBook:    addrBook,
+		nodeInfo:    nodeInfo,
+		nodeKey:     nodeKey,
+		trustStore:  trustStore,
+		portMapping: natMapping,
+
+		bandwidthGroups: bandwidthGroups,
 
 		stateDB:          stateDB,
 		blockStore:       blockStore,
+		headerStore:      headerStore,
 		bcReactor:        bcReactor,
 		mempoolReactor:   mempoolReactor,
 		mempool:          mempool,
@@ -767,10 +1152,14 @@ func NewNode(config *cfg.Config,
 		consensusReactor: consensusReactor,
 		pexReactor:       pexReactor,
 		evidencePool:     evidencePool,
+		blockExec:        blockExec,
 		proxyApp:         proxyApp,
 		txIndexer:        txIndexer,
 		indexerService:   indexerService,
+		eventRecorder:    eventRecorder,
 		eventBus:         eventBus,
+		tracer:           tracer,
+		rpcMetrics:       rpcMetrics,
 	}
 	node.BaseService = *cmn.NewBaseService(logger, "Node", node)
 
@@ -808,6 +1197,30 @@ func (n *Node) OnStart() error {
 		n.prometheusSrv = n.startPrometheusServer(n.config.Instrumentation.PrometheusListenAddr)
 	}
 
+	if n.config.Instrumentation.DebugListenAddr != "" {
+		if fcs, ok := n.consensusState.(*fridaycs.ConsensusState); ok {
+			n.debugSrv = n.startDebugServer(n.config.Instrumentation.DebugListenAddr, fcs)
+		} else {
+			n.Logger.Info("The debug server is only available with the friday consensus")
+		}
+	}
+
+	if n.config.Instrumentation.Pprof {
+		n.pprofSrv = n.startPprofServer(n.config.Instrumentation.PprofListenAddr)
+	}
+
+	if t, ok := n.tracer.(cmn.Service); ok {
+		if err := t.Start(); err != nil {
+			return err
+		}
+	}
+
+	if n.config.Control.ListenAddress != "" {
+		if err := n.startControlServer(); err != nil {
+			return errors.Wrap(err, "could not start the control server")
+		}
+	}
+
 	// Start the transport.
 	addr, err := p2p.NewNetAddressString(p2p.IDAddressString(n.nodeKey.ID(), n.config.P2P.ListenAddress))
 	if err != nil {
@@ -819,10 +1232,29 @@ func (n *Node) OnStart() error {
 
 	n.isListening = true
 
+	// Keep the p2p port mapped on the NAT gateway.
+	if n.portMapping != nil {
+		if err := n.portMapping.Start(); err != nil {
+			return err
+		}
+	}
+
+	// Start moving the old blocks to the object storage, if any.
+	if bs, ok := n.blockStore.(cmn.Service); ok {
+		if err := bs.Start(); err != nil {
+			return err
+		}
+	}
+
 	if n.config.Mempool.WalEnabled() {
 		n.mempool.InitWAL() // no need to have the mempool wal during tests
 	}
 
+	// Start recording the trust metrics of the peers.
+	if err := n.trustStore.Start(); err != nil {
+		return err
+	}
+
 	// Start the switch (the P2P server).
 	err = n.sw.Start()
 	if err != nil {
@@ -847,9 +1279,16 @@ func (n *Node) OnStop() {
 	// first stop the non-reactor services
 	n.eventBus.Stop()
 	n.indexerService.Stop()
+	if n.eventRecorder != nil {
+		n.eventRecorder.Stop()
+	}
+	if n.controlServer != nil {
+		n.controlServer.Stop()
+	}
 
 	// now stop the reactors
 	n.sw.Stop()
+	n.trustStore.Stop()
 
 	// stop mempool WAL
 	if n.config.Mempool.WalEnabled() {
@@ -862,6 +1301,10 @@ func (n *Node) OnStop() {
 
 	n.isListening = false
 
+	if n.portMapping != nil {
+		n.portMapping.Stop()
+	}
+
 	// finally stop the listeners / external services
 	for _, l := range n.rpcListeners {
 		n.Logger.Info("Closing rpc listener", "listener", l)
@@ -874,12 +1317,81 @@ func (n *Node) OnStop() {
 		pvsc.Stop()
 	}
 
+	if bs, ok := n.blockStore.(cmn.Service); ok {
+		bs.Stop()
+	}
+
 	if n.prometheusSrv != nil {
 		if err := n.prometheusSrv.Shutdown(context.Background()); err != nil {
 			// Error from closing listeners, or context timeout:
 			n.Logger.Error("Prometheus HTTP server Shutdown", "err", err)
 		}
 	}
+
+	if n.debugSrv != nil {
+		if err := n.debugSrv.Shutdown(context.Background()); err != nil {
+			n.Logger.Error("Debug HTTP server Shutdown", "err", err)
+		}
+	}
+
+	if n.pprofSrv != nil {
+		if err := n.pprofSrv.Shutdown(context.Background()); err != nil {
+			n.Logger.Error("Pprof HTTP server Shutdown", "err", err)
+		}
+	}
+
+	// stopped last, so the spans of the stopped services are exported
+	if t, ok := n.tracer.(cmn.Service); ok {
+		t.Stop()
+	}
+}
+
+// ConfigLoader loads the config again, e.g. from the config file, for the node
+// to reload it.
+type ConfigLoader func() (*cfg.Config, error)
+
+// SetConfigLoader sets the loader of the config ReloadConfig reloads.
+func (n *Node) SetConfigLoader(loader ConfigLoader) {
+	n.configMtx.Lock()
+	defer n.configMtx.Unlock()
+	n.configLoader = loader
+}
+
+// ReloadConfig loads the config with the config loader and applies its
+// reloadable fields (see cfg.ReloadableKeys) to the running node. It returns
+// the keys of the reloaded fields, and of the other changed fields, which need
+// a restart.
+func (n *Node) ReloadConfig() (reloaded, ignored []string, err error) {
+	n.configMtx.Lock()
+	defer n.configMtx.Unlock()
+
+	if n.configLoader == nil {
+		return nil, nil, errors.New("no config loader")
+	}
+	newConfig, err := n.configLoader()
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to load the config: %v", err)
+	}
+	runningConfig := n.reloadedConfig
+	if runningConfig == nil {
+		runningConfig = n.config
+	}
+	reloadedConfig, reloaded, ignored, err := runningConfig.Reload(newConfig)
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid config: %v", err)
+	}
+	n.reloadedConfig = reloadedConfig
+
+	// the services read their config concurrently, so the reloaded fields are
+	// set with their setters
+	rpccore.SetConfig(*reloadedConfig.RPC)
+	if mempool, ok := n.mempool.(*mempl.CListMempool); ok {
+		mempool.SetLimits(reloadedConfig.Mempool.Size, reloadedConfig.Mempool.MaxTxsBytes)
+	}
+	n.consensusState.SetConfig(reloadedConfig.Consensus)
+
+	n.Logger.Info("Reloaded the config", "reloaded", reloaded, "ignored", ignored)
+	return reloaded, ignored, nil
 }
 
 // ConfigureRPC sets all variables in rpccore so they will serve
@@ -887,21 +1399,29 @@ func (n *Node) OnStop() {
 func (n *Node) ConfigureRPC() {
 	rpccore.SetStateDB(n.stateDB)
 	rpccore.SetBlockStore(n.blockStore)
+	if n.headerStore != nil {
+		rpccore.SetHeaderStore(n.headerStore)
+	}
 	rpccore.SetConsensusState(n.consensusState)
 	rpccore.SetMempool(n.mempool)
 	rpccore.SetEvidencePool(n.evidencePool)
 	rpccore.SetP2PPeers(n.sw)
 	rpccore.SetP2PTransport(n)
+	rpccore.SetBandwidthGroups(n.bandwidthGroups)
 	pubKey := n.privValidator.GetPubKey()
 	rpccore.SetPubKey(pubKey)
 	rpccore.SetGenesisDoc(n.genesisDoc)
 	rpccore.SetAddrBook(n.addrBook)
+	if n.pexReactor != nil {
+		rpccore.SetPeerCrawler(n.pexReactor)
+	}
 	rpccore.SetProxyAppQuery(n.proxyApp.Query())
 	rpccore.SetTxIndexer(n.txIndexer)
 	rpccore.SetConsensusReactor(n.consensusReactor)
 	rpccore.SetEventBus(n.eventBus)
 	rpccore.SetLogger(n.Logger.With("module", "rpc"))
 	rpccore.SetConfig(*n.config.RPC)
+	rpccore.SetConfigReloader(n)
 }
 
 func (n *Node) startRPC() ([]net.Listener, error) {
@@ -925,6 +1445,16 @@ func (n *Node) startRPC() ([]net.Listener, error) {
 		config.WriteTimeout = n.config.RPC.TimeoutBroadcastTxCommit + 1*time.Second
 	}
 
+	auth, err := n.rpcAuth(config)
+	if err != nil {
+		return nil, err
+	}
+	var rateLimiter *rpcserver.RateLimiter
+	if n.config.RPC.RateLimit > 0 {
+		// shared by the listeners, for a remote IP to have the same rate on all
+		rateLimiter = rpcserver.NewRateLimiter(n.config.RPC.RateLimit, n.config.RPC.RateLimitBurst, n.rpcMetrics)
+	}
+
 	// we may expose the rpc over both a unix and tcp socket
 	listeners := make([]net.Listener, len(listenAddrs))
 	for i, listenAddr := range listenAddrs {
@@ -939,10 +1469,15 @@ func (n *Node) startRPC() ([]net.Listener, error) {
 				}
 			}),
 			rpcserver.ReadLimit(config.MaxBodyBytes),
+			rpcserver.CallRateLimit(n.config.RPC.WSRateLimit, n.config.RPC.RateLimitBurst),
+			rpcserver.EventRateLimit(n.config.RPC.WSMaxEventsPerSecond, n.config.RPC.RateLimitBurst),
+			rpcserver.RateLimitMetrics(n.rpcMetrics),
 		)
 		wm.SetLogger(wmLogger)
 		mux.HandleFunc("/websocket", wm.WebsocketHandler)
 		rpcserver.RegisterRPCFuncs(mux, rpccore.Routes, coreCodec, rpcLogger)
+		rpcserver.RegisterRESTRoutes(mux, "/v1", rpccore.RESTRoutes, rpccore.Routes, coreCodec,
+			rpccore.RESTInfo, rpcLogger.With("module", "rest"))
 		listener, err := rpcserver.Listen(
 			listenAddr,
 			config,
@@ -958,7 +1493,15 @@ func (n *Node) startRPC() ([]net.Listener, error) {
 				AllowedMethods: n.config.RPC.CORSAllowedMethods,
 				AllowedHeaders: n.config.RPC.CORSAllowedHeaders,
 			})
-			rootHandler = corsMiddleware.Handler(mux)
+			rootHandler = corsMiddleware.Handler(rootHandler)
+		}
+		if rateLimiter != nil {
+			rootHandler = rateLimiter.Handler(rootHandler)
+		}
+		if auth != nil {
+			// authenticate before limiting the rate, for the admin clients not
+			// to be limited, and after CORS, as the preflight requests have no credentials
+			rootHandler = auth.Handler(rootHandler)
 		}
 		if n.config.RPC.IsTLSEnabled() {
 			go rpcserver.StartHTTPAndTLSServer(
@@ -997,6 +1540,42 @@ func (n *Node) startRPC() ([]net.Listener, error) {
 	return listeners, nil
 }
 
+// rpcAuth returns the authentication of the RPC clients, or nil if it is not
+// enabled, and sets the certificate authorities of the TLS clients in config.
+func (n *Node) rpcAuth(config *rpcserver.Config) (*rpcserver.Auth, error) {
+	if !n.config.RPC.IsAuthEnabled() {
+		return nil, nil
+	}
+
+	var tokens []string
+	if n.config.RPC.AdminTokensFile != "" {
+		var err error
+		tokens, err = rpcserver.ReadTokensFile(n.config.RPC.AdminTokensFilePath())
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to read the RPC admin tokens")
+		}
+	}
+	if n.config.RPC.TLSClientCAFile != "" {
+		bz, err := ioutil.ReadFile(n.config.RPC.ClientCAFile())
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to read the RPC client certificate authorities")
+		}
+		config.ClientCAs = x509.NewCertPool()
+		if !config.ClientCAs.AppendCertsFromPEM(bz) {
+			return nil, fmt.Errorf("no certificate in %s", n.config.RPC.ClientCAFile())
+		}
+	}
+
+	adminFuncs := rpccore.UnsafeRouteNames()
+	for _, name := range n.config.RPC.AdminEndpoints {
+		if _, ok := rpccore.Routes[name]; !ok {
+			return nil, fmt.Errorf("unknown RPC admin endpoint %s", name)
+		}
+		adminFuncs = append(adminFuncs, name)
+	}
+	return rpcserver.NewAuth(adminFuncs, tokens), nil
+}
+
 // startPrometheusServer starts a Prometheus HTTP server, listening for metrics
 // collectors on addr.
 func (n *Node) startPrometheusServer(addr string) *http.Server {
@@ -1018,13 +1597,48 @@ func (n *Node) startPrometheusServer(addr string) *http.Server {
 	return srv
 }
 
+// startDebugServer starts an HTTP server serving the internals of the friday
+// consensus state on addr.
+func (n *Node) startDebugServer(addr string, consensusState *fridaycs.ConsensusState) *http.Server {
+	mux := http.NewServeMux()
+	mux.Handle("/debug/consensus", consensusState.DebugHandler())
+	srv := &http.Server{Addr: addr, Handler: mux}
+	go func() {
+		if err := srv.ListenAndServe(); err != http.ErrServerClosed {
+			n.Logger.Error("Debug HTTP server ListenAndServe", "err", err)
+		}
+	}()
+	return srv
+}
+
+// startPprofServer starts an HTTP server serving the pprof profiles on addr,
+// and turns on the sampling of the block and mutex profiles.
+func (n *Node) startPprofServer(addr string) *http.Server {
+	runtime.SetBlockProfileRate(n.config.Instrumentation.PprofBlockProfileRate)
+	runtime.SetMutexProfileFraction(n.config.Instrumentation.PprofMutexProfileFraction)
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/debug/pprof/", pprof.Index)
+	mux.HandleFunc("/debug/pprof/cmdline", pprof.Cmdline)
+	mux.HandleFunc("/debug/pprof/profile", pprof.Profile)
+	mux.HandleFunc("/debug/pprof/symbol", pprof.Symbol)
+	mux.HandleFunc("/debug/pprof/trace", pprof.Trace)
+	srv := &http.Server{Addr: addr, Handler: mux}
+	go func() {
+		if err := srv.ListenAndServe(); err != http.ErrServerClosed {
+			n.Logger.Error("Pprof HTTP server ListenAndServe", "err", err)
+		}
+	}()
+	return srv
+}
+
 // Switch returns the Node's Switch.
 func (n *Node) Switch() *p2p.Switch {
 	return n.sw
 }
 
 // BlockStore returns the Node's BlockStore.
-func (n *Node) BlockStore() *store.BlockStore {
+func (n *Node) BlockStore() sm.BlockStore {
 	return n.blockStore
 }
 
@@ -1079,7 +1693,8 @@ func (n *Node) ProxyApp() proxy.AppConns {
 	return n.proxyApp
 }
 
-// Config returns the Node's config.
+// Config returns the Node's config. The fields reloaded by ReloadConfig are
+// not set in it.
 func (n *Node) Config() *cfg.Config {
 	return n.config
 }
@@ -1107,6 +1722,7 @@ func makeNodeInfo(
 	txIndexer txindex.TxIndexer,
 	genDoc *types.GenesisDoc,
 	state sm.State,
+	natMapping *portMapping,
 ) (p2p.NodeInfo, error) {
 	txIndexerStatus := "on"
 	if _, ok := txIndexer.(*null.TxIndex); ok {
@@ -1135,7 +1751,7 @@ func makeNodeInfo(
 		Channels: []byte{
 			bcChannel,
 			cs.StateChannel, cs.DataChannel, cs.VoteChannel, cs.VoteSetBitsChannel,
-			mempl.MempoolChannel,
+			mempl.MempoolChannel, mempl.TxHashesChannel,
 			evidence.EvidenceChannel,
 		},
 		Moniker: config.Moniker,
@@ -1144,13 +1760,20 @@ func makeNodeInfo(
 			RPCAddress: config.RPC.ListenAddress,
 		},
 	}
+	if config.P2P.ProtoMsgs {
+		nodeInfo.Other.MsgEncodings = p2p.MsgEncodingProto
+	}
 
 	if config.P2P.PexReactor {
-		nodeInfo.Channels = append(nodeInfo.Channels, pex.PexChannel)
+		nodeInfo.Channels = append(nodeInfo.Channels, pex.PexChannel, pex.ObservedAddrChannel)
 	}
 
 	lAddr := config.P2P.ExternalAddress
 
+	if lAddr == "" && natMapping != nil {
+		lAddr = natMapping.Address()
+	}
+
 	if lAddr == "" {
 		lAddr = config.P2P.ListenAddress
 	}
@@ -1230,6 +1853,54 @@ func createAndStartPrivValidatorSocketClient(
 	return pvsc, nil
 }
 
+// createAndStartPrivValidatorThresholdClient listens for a cosigner on each of
+// the listenAddrs, and returns a client signing with threshold of them.
+func createAndStartPrivValidatorThresholdClient(
+	listenAddrs []string,
+	threshold int,
+	logger log.Logger,
+) (types.PrivValidator, error) {
+	cosigners := make([]types.PrivValidator, len(listenAddrs))
+	for i, listenAddr := range listenAddrs {
+		cosigner, err := createAndStartPrivValidatorSocketClient(listenAddr, logger.With("cosigner", i+1))
+		if err != nil {
+			return nil, err
+		}
+		cosigners[i] = cosigner
+	}
+
+	tsc, err := privval.NewThresholdSignerClient(threshold, cosigners)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to start private validator")
+	}
+
+	return tsc, nil
+}
+
+// createAndStartPrivValidatorFailoverClient listens for a signer on each of the
+// listenAddrs, and returns a client signing with the first of them, failing
+// over to the next ones.
+func createAndStartPrivValidatorFailoverClient(
+	listenAddrs []string,
+	logger log.Logger,
+) (types.PrivValidator, error) {
+	signers := make([]types.PrivValidator, len(listenAddrs))
+	for i, listenAddr := range listenAddrs {
+		signer, err := createAndStartPrivValidatorSocketClient(listenAddr, logger.With("signer", i+1))
+		if err != nil {
+			return nil, err
+		}
+		signers[i] = signer
+	}
+
+	fsc, err := privval.NewFailoverSignerClient(signers, logger.With("module", "privval"))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to start private validator")
+	}
+
+	return fsc, nil
+}
+
 // splitAndTrimEmpty slices s into all subslices separated by sep and returns a
 // slice of the string s with all leading and trailing Unicode code points
 // contained in cutset removed. If sep is empty, SplitAndTrim splits after each
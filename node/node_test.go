@@ -249,7 +249,7 @@ func TestCreateProposalBlock(t *testing.T) {
 	types.RegisterMockEvidencesGlobal() // XXX!
 	evidence.RegisterMockEvidences()
 	evidenceDB := dbm.NewMemDB()
-	evidencePool := evidence.NewEvidencePool(stateDB, evidenceDB)
+	evidencePool := evidence.NewEvidencePool(stateDB, evidenceDB, store.NewBlockStore(dbm.NewMemDB()))
 	evidencePool.SetLogger(logger)
 
 	// fill the evidence pool with more evidence
@@ -284,6 +284,7 @@ func TestCreateProposalBlock(t *testing.T) {
 		height,
 		state, commit,
 		proposerAddr,
+		time.Time{},
 	)
 
 	err = blockExec.ValidateBlock(state, block)
@@ -0,0 +1,102 @@
+package p2p
+
+import (
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	dbm "github.com/tendermint/tm-db"
+)
+
+var banListKey = []byte("banList")
+
+// ErrPeerBanned is returned when connecting to a banned peer.
+type ErrPeerBanned struct {
+	ID    ID
+	Until time.Time
+}
+
+func (e ErrPeerBanned) Error() string {
+	return fmt.Sprintf("peer %v is banned until %v", e.ID, e.Until)
+}
+
+// BanList is the set of the banned peers and the time their ban expires,
+// saved in a DB so the bans last across restarts.
+type BanList struct {
+	mtx  sync.Mutex
+	db   dbm.DB
+	bans map[ID]time.Time
+}
+
+// NewBanList returns the BanList saved in db, or an empty one.
+func NewBanList(db dbm.DB) (*BanList, error) {
+	bl := &BanList{db: db, bans: make(map[ID]time.Time)}
+	if bz := db.Get(banListKey); bz != nil {
+		if err := json.Unmarshal(bz, &bl.bans); err != nil {
+			return nil, fmt.Errorf("failed to read the ban list: %v", err)
+		}
+	}
+	return bl, nil
+}
+
+// Ban bans the peer until the given time, replacing its previous ban.
+func (bl *BanList) Ban(id ID, until time.Time) {
+	bl.mtx.Lock()
+	defer bl.mtx.Unlock()
+
+	bl.bans[id] = until
+	bl.save()
+}
+
+// Unban lifts the ban of the peer. It returns false if it wasn't banned.
+func (bl *BanList) Unban(id ID) bool {
+	bl.mtx.Lock()
+	defer bl.mtx.Unlock()
+
+	if _, ok := bl.bans[id]; !ok {
+		return false
+	}
+	delete(bl.bans, id)
+	bl.save()
+	return true
+}
+
+// BannedUntil returns the time the ban of the peer expires, and false if it
+// isn't banned.
+func (bl *BanList) BannedUntil(id ID) (time.Time, bool) {
+	bl.mtx.Lock()
+	defer bl.mtx.Unlock()
+
+	until, ok := bl.bans[id]
+	if ok && !time.Now().Before(until) {
+		delete(bl.bans, id)
+		bl.save()
+		return time.Time{}, false
+	}
+	return until, ok
+}
+
+// Bans returns the bans which haven't expired.
+func (bl *BanList) Bans() map[ID]time.Time {
+	bl.mtx.Lock()
+	defer bl.mtx.Unlock()
+
+	now := time.Now()
+	bans := make(map[ID]time.Time, len(bl.bans))
+	for id, until := range bl.bans {
+		if now.Before(until) {
+			bans[id] = until
+		}
+	}
+	return bans
+}
+
+// save assumes the mutex is acquired.
+func (bl *BanList) save() {
+	bz, err := json.Marshal(bl.bans)
+	if err != nil {
+		panic(err)
+	}
+	bl.db.SetSync(banListKey, bz)
+}
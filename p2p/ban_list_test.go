@@ -0,0 +1,43 @@
+package p2p
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	dbm "github.com/tendermint/tm-db"
+)
+
+func TestBanListPersistence(t *testing.T) {
+	db := dbm.NewMemDB()
+	bl, err := NewBanList(db)
+	require.NoError(t, err)
+
+	until := time.Now().Add(time.Hour).Round(0)
+	bl.Ban("peer1", until)
+	bl.Ban("peer2", until)
+	assert.True(t, bl.Unban("peer2"))
+	assert.False(t, bl.Unban("peer2"))
+
+	// the bans are loaded back from the DB
+	bl, err = NewBanList(db)
+	require.NoError(t, err)
+	got, ok := bl.BannedUntil("peer1")
+	require.True(t, ok)
+	assert.True(t, until.Equal(got))
+	_, ok = bl.BannedUntil("peer2")
+	assert.False(t, ok)
+	assert.Len(t, bl.Bans(), 1)
+}
+
+func TestBanListExpiry(t *testing.T) {
+	bl, err := NewBanList(dbm.NewMemDB())
+	require.NoError(t, err)
+
+	bl.Ban("peer1", time.Now().Add(-time.Second))
+	assert.Empty(t, bl.Bans())
+	_, ok := bl.BannedUntil("peer1")
+	assert.False(t, ok)
+	assert.False(t, bl.Unban("peer1"), "expired ban should be removed")
+}
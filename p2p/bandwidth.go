@@ -0,0 +1,38 @@
+package p2p
+
+import (
+	"github.com/hdac-io/tendermint/p2p/conn"
+)
+
+// BandwidthGroup is a group of channels, e.g. the channels of a reactor,
+// sharing a bandwidth budget on each connection.
+type BandwidthGroup struct {
+	Name     string
+	Channels []byte
+	Budget   *conn.BandwidthBudget
+}
+
+// BandwidthGroups are the groups of channels with a bandwidth budget.
+type BandwidthGroups []*BandwidthGroup
+
+// Get returns the group with the given name, or nil.
+func (groups BandwidthGroups) Get(name string) *BandwidthGroup {
+	for _, group := range groups {
+		if group.Name == name {
+			return group
+		}
+	}
+	return nil
+}
+
+// ChannelBudgets returns the budgets of the groups by channel ID, for
+// conn.MConnConfig.
+func (groups BandwidthGroups) ChannelBudgets() map[byte]*conn.BandwidthBudget {
+	budgets := make(map[byte]*conn.BandwidthBudget)
+	for _, group := range groups {
+		for _, chID := range group.Channels {
+			budgets[chID] = group.Budget
+		}
+	}
+	return budgets
+}
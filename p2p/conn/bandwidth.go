@@ -0,0 +1,59 @@
+package conn
+
+import (
+	"sync/atomic"
+	"time"
+)
+
+// budgetRetryInterval is the time after which the MConnection tries again to
+// send the messages of the channels over their budget.
+const budgetRetryInterval = 10 * time.Millisecond
+
+// BandwidthBudget is the send and receive rates, in bytes/second, allowed to a
+// group of channels on each connection, e.g. the channels of a reactor, so a
+// reactor can't starve the others. It's shared by the connections, so its
+// rates can be changed at runtime. A zero rate doesn't restrict anything
+// besides the rates of the connection.
+type BandwidthBudget struct {
+	sendRate int64 // atomic
+	recvRate int64 // atomic
+}
+
+// NewBandwidthBudget returns a BandwidthBudget with the given rates.
+func NewBandwidthBudget(sendRate, recvRate int64) *BandwidthBudget {
+	return &BandwidthBudget{sendRate: sendRate, recvRate: recvRate}
+}
+
+// SetRates changes the rates of the budget, for all the connections.
+func (b *BandwidthBudget) SetRates(sendRate, recvRate int64) {
+	atomic.StoreInt64(&b.sendRate, sendRate)
+	atomic.StoreInt64(&b.recvRate, recvRate)
+}
+
+// Rates returns the rates of the budget.
+func (b *BandwidthBudget) Rates() (sendRate, recvRate int64) {
+	return atomic.LoadInt64(&b.sendRate), atomic.LoadInt64(&b.recvRate)
+}
+
+// canSendWithinBudget returns whether the channel may send a packet without
+// exceeding its budget.
+func (ch *Channel) canSendWithinBudget() bool {
+	if ch.budget == nil {
+		return true
+	}
+	sendRate, _ := ch.budget.Rates()
+	return ch.sendMonitor.Limit(1, sendRate, false) > 0
+}
+
+// limitRecv blocks until the channel may receive the next packet without
+// exceeding its budget, after n bytes were received. As the channels share the
+// connection, the packets of the other channels wait too: the budget slows
+// down the peer rather than dropping its messages.
+func (ch *Channel) limitRecv(n int) {
+	if ch.budget == nil {
+		return
+	}
+	ch.recvMonitor.Update(n)
+	_, recvRate := ch.budget.Rates()
+	ch.recvMonitor.Limit(ch.conn._maxPacketMsgSize, recvRate, true)
+}
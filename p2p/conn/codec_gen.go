@@ -0,0 +1,23 @@
+// Code generated by scripts/codecgen from its manifest. DO NOT EDIT.
+
+package conn
+
+import (
+	cryptoAmino "github.com/hdac-io/tendermint/crypto/encoding/amino"
+	amino "github.com/tendermint/go-amino"
+)
+
+var cdc = amino.NewCodec()
+
+func init() {
+	cryptoAmino.RegisterAmino(cdc)
+	RegisterPacket(cdc)
+}
+
+// RegisterPacket registers the packets of the connections.
+func RegisterPacket(cdc *amino.Codec) {
+	cdc.RegisterInterface((*Packet)(nil), nil)
+	cdc.RegisterConcrete(PacketPing{}, "tendermint/p2p/PacketPing", nil)
+	cdc.RegisterConcrete(PacketPong{}, "tendermint/p2p/PacketPong", nil)
+	cdc.RegisterConcrete(PacketMsg{}, "tendermint/p2p/PacketMsg", nil)
+}
@@ -15,7 +15,6 @@ import (
 
 	"github.com/pkg/errors"
 
-	amino "github.com/tendermint/go-amino"
 	cmn "github.com/hdac-io/tendermint/libs/common"
 	flow "github.com/hdac-io/tendermint/libs/flowrate"
 	"github.com/hdac-io/tendermint/libs/log"
@@ -58,9 +57,10 @@ Each `MConnection` handles message transmission on multiple abstract communicati
 The byte id and the relative priorities of each `Channel` are configured upon
 initialization of the connection.
 
-There are two methods for sending messages:
+There are three methods for sending messages:
 	func (m MConnection) Send(chID byte, msgBytes []byte) bool {}
 	func (m MConnection) TrySend(chID byte, msgBytes []byte}) bool {}
+	func (m MConnection) SendUrgent(chID byte, msgBytes []byte) bool {}
 
 `Send(chID, msgBytes)` is a blocking call that waits until `msg` is
 successfully queued for the channel with the given id byte `chID`, or until the
@@ -69,6 +69,11 @@ request times out.  The message `msg` is serialized using Go-Amino.
 `TrySend(chID, msgBytes)` is a nonblocking call that returns false if the
 channel's queue is full.
 
+`SendUrgent(chID, msgBytes)` is like `Send`, but the message jumps ahead of the
+ones queued with `Send` and `TrySend`: channels with urgent messages are served
+first (still weighted by their priorities), and within a channel urgent
+messages are sent before the others, once the message being sent is complete.
+
 Inbound message bytes are handled with an onReceive callback function.
 */
 type MConnection struct {
@@ -130,6 +135,9 @@ type MConnConfig struct {
 
 	// Maximum wait time for pongs
 	PongTimeout time.Duration `mapstructure:"pong_timeout"`
+
+	// Bandwidth budgets of the channels, by channel ID (see BandwidthBudget)
+	ChannelBudgets map[byte]*BandwidthBudget `mapstructure:"-"`
 }
 
 // DefaultMConnConfig returns the default config.
@@ -358,6 +366,35 @@ func (c *MConnection) Send(chID byte, msgBytes []byte) bool {
 	return success
 }
 
+// Queues a message to be sent to channel ahead of the messages queued with
+// Send and TrySend.
+func (c *MConnection) SendUrgent(chID byte, msgBytes []byte) bool {
+	if !c.IsRunning() {
+		return false
+	}
+
+	c.Logger.Debug("SendUrgent", "channel", chID, "conn", c, "msgBytes", fmt.Sprintf("%X", msgBytes))
+
+	// Send message to channel.
+	channel, ok := c.channelsIdx[chID]
+	if !ok {
+		c.Logger.Error(fmt.Sprintf("Cannot send bytes, unknown channel %X", chID))
+		return false
+	}
+
+	success := channel.sendUrgentBytes(msgBytes)
+	if success {
+		// Wake up sendRoutine if necessary
+		select {
+		case c.send <- struct{}{}:
+		default:
+		}
+	} else {
+		c.Logger.Debug("SendUrgent failed", "channel", chID, "conn", c, "msgBytes", fmt.Sprintf("%X", msgBytes))
+	}
+	return success
+}
+
 // Queues a message to be sent to channel.
 // Nonblocking, returns true if successful.
 func (c *MConnection) TrySend(chID byte, msgBytes []byte) bool {
@@ -498,24 +535,46 @@ func (c *MConnection) sendSomePacketMsgs() bool {
 // Returns true if messages from channels were exhausted.
 func (c *MConnection) sendPacketMsg() bool {
 	// Choose a channel to create a PacketMsg from.
-	// The chosen channel will be the one whose recentlySent/priority is the least.
+	// The chosen channel will be the one whose recentlySent/priority is the least,
+	// among the channels with urgent messages if there are any.
 	var leastRatio float32 = math.MaxFloat32
 	var leastChannel *Channel
+	leastUrgent := false
+	overBudget := false
 	for _, channel := range c.channels {
 		// If nothing to send, skip this channel
 		if !channel.isSendPending() {
 			continue
 		}
+		// If the channel exceeds its bandwidth budget, skip it for now
+		if !channel.canSendWithinBudget() {
+			overBudget = true
+			continue
+		}
+		urgent := channel.isSendingUrgent()
+		if leastUrgent && !urgent {
+			continue
+		}
 		// Get ratio, and keep track of lowest ratio.
 		ratio := float32(channel.recentlySent) / float32(channel.desc.Priority)
-		if ratio < leastRatio {
+		if ratio < leastRatio || (urgent && !leastUrgent) {
 			leastRatio = ratio
 			leastChannel = channel
+			leastUrgent = urgent
 		}
 	}
 
 	// Nothing to send?
 	if leastChannel == nil {
+		if overBudget {
+			// try again once the budgets allow some bytes
+			time.AfterFunc(budgetRetryInterval, func() {
+				select {
+				case c.send <- struct{}{}:
+				default:
+				}
+			})
+		}
 		return true
 	}
 	// c.Logger.Info("Found a msgPacket to send")
@@ -528,6 +587,9 @@ func (c *MConnection) sendPacketMsg() bool {
 		return true
 	}
 	c.sendMonitor.Update(int(_n))
+	if leastChannel.budget != nil {
+		leastChannel.sendMonitor.Update(int(_n))
+	}
 	c.flushTimer.Set()
 	return false
 }
@@ -620,6 +682,7 @@ FOR_LOOP:
 				}
 				break FOR_LOOP
 			}
+			channel.limitRecv(int(_n))
 			if msgBytes != nil {
 				c.Logger.Debug("Received bytes", "chID", pkt.ChannelID, "msgBytes", fmt.Sprintf("%X", msgBytes))
 				// NOTE: This means the reactor.Receive runs in the same thread as the p2p recv routine
@@ -669,6 +732,7 @@ type ChannelStatus struct {
 	ID                byte
 	SendQueueCapacity int
 	SendQueueSize     int
+	UrgentQueueSize   int
 	Priority          int
 	RecentlySent      int64
 }
@@ -684,6 +748,7 @@ func (c *MConnection) Status() ConnectionStatus {
 			ID:                channel.desc.ID,
 			SendQueueCapacity: cap(channel.sendQueue),
 			SendQueueSize:     int(atomic.LoadInt32(&channel.sendQueueSize)),
+			UrgentQueueSize:   len(channel.urgentQueue),
 			Priority:          channel.desc.Priority,
 			RecentlySent:      atomic.LoadInt64(&channel.recentlySent),
 		}
@@ -721,11 +786,18 @@ type Channel struct {
 	conn          *MConnection
 	desc          ChannelDescriptor
 	sendQueue     chan []byte
-	sendQueueSize int32 // atomic.
+	sendQueueSize int32 // atomic. includes the urgent messages
+	urgentQueue   chan []byte
 	recving       []byte
 	sending       []byte
+	sendingUrgent bool  // whether sending comes from urgentQueue
 	recentlySent  int64 // exponential moving average
 
+	// nil if the channel has no bandwidth budget
+	budget      *BandwidthBudget
+	sendMonitor *flow.Monitor
+	recvMonitor *flow.Monitor
+
 	maxPacketMsgPayloadSize int
 
 	Logger log.Logger
@@ -736,13 +808,20 @@ func newChannel(conn *MConnection, desc ChannelDescriptor) *Channel {
 	if desc.Priority <= 0 {
 		panic("Channel default priority must be a positive integer")
 	}
-	return &Channel{
+	ch := &Channel{
 		conn:                    conn,
 		desc:                    desc,
 		sendQueue:               make(chan []byte, desc.SendQueueCapacity),
+		urgentQueue:             make(chan []byte, desc.SendQueueCapacity),
 		recving:                 make([]byte, 0, desc.RecvBufferCapacity),
 		maxPacketMsgPayloadSize: conn.config.MaxPacketMsgPayloadSize,
 	}
+	if budget, ok := conn.config.ChannelBudgets[desc.ID]; ok && budget != nil {
+		ch.budget = budget
+		ch.sendMonitor = flow.New(0, 0)
+		ch.recvMonitor = flow.New(0, 0)
+	}
+	return ch
 }
 
 func (ch *Channel) SetLogger(l log.Logger) {
@@ -762,6 +841,19 @@ func (ch *Channel) sendBytes(bytes []byte) bool {
 	}
 }
 
+// Queues urgent message to send to this channel.
+// Goroutine-safe
+// Times out (and returns false) after defaultSendTimeout
+func (ch *Channel) sendUrgentBytes(bytes []byte) bool {
+	select {
+	case ch.urgentQueue <- bytes:
+		atomic.AddInt32(&ch.sendQueueSize, 1)
+		return true
+	case <-time.After(defaultSendTimeout):
+		return false
+	}
+}
+
 // Queues message to send to this channel.
 // Nonblocking, returns true if successful.
 // Goroutine-safe
@@ -791,14 +883,27 @@ func (ch *Channel) canSend() bool {
 // Goroutine-safe
 func (ch *Channel) isSendPending() bool {
 	if len(ch.sending) == 0 {
+		if len(ch.urgentQueue) != 0 {
+			ch.sending = <-ch.urgentQueue
+			ch.sendingUrgent = true
+			return true
+		}
 		if len(ch.sendQueue) == 0 {
 			return false
 		}
 		ch.sending = <-ch.sendQueue
+		ch.sendingUrgent = false
 	}
 	return true
 }
 
+// Returns true if the message being sent was queued with SendUrgent.
+// Call after isSendPending().
+// Not goroutine-safe
+func (ch *Channel) isSendingUrgent() bool {
+	return ch.sendingUrgent
+}
+
 // Creates a new PacketMsg to send.
 // Not goroutine-safe
 func (ch *Channel) nextPacketMsg() PacketMsg {
@@ -864,13 +969,6 @@ type Packet interface {
 	AssertIsPacket()
 }
 
-func RegisterPacket(cdc *amino.Codec) {
-	cdc.RegisterInterface((*Packet)(nil), nil)
-	cdc.RegisterConcrete(PacketPing{}, "tendermint/p2p/PacketPing", nil)
-	cdc.RegisterConcrete(PacketPong{}, "tendermint/p2p/PacketPong", nil)
-	cdc.RegisterConcrete(PacketMsg{}, "tendermint/p2p/PacketMsg", nil)
-}
-
 func (_ PacketPing) AssertIsPacket() {}
 func (_ PacketPong) AssertIsPacket() {}
 func (_ PacketMsg) AssertIsPacket()  {}
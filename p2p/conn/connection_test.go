@@ -141,6 +141,107 @@ func TestMConnectionReceive(t *testing.T) {
 	}
 }
 
+func TestMConnectionSendUrgent(t *testing.T) {
+	server, client := NetPipe()
+	defer server.Close() // nolint: errcheck
+	defer client.Close() // nolint: errcheck
+
+	chDescs := []*ChannelDescriptor{
+		{ID: 0x01, Priority: 10, SendQueueCapacity: 2},
+		{ID: 0x02, Priority: 1, SendQueueCapacity: 2},
+	}
+	receivedCh := make(chan string, 4)
+	onReceive := func(chID byte, msgBytes []byte) {
+		receivedCh <- string(msgBytes)
+	}
+	onError := func(r interface{}) {}
+	mconn1 := NewMConnection(client, chDescs, onReceive, onError)
+	mconn1.SetLogger(log.TestingLogger())
+	err := mconn1.Start()
+	require.Nil(t, err)
+	defer mconn1.Stop()
+
+	// queue the messages before the send routine runs, so their order only
+	// depends on how sendPacketMsg picks them
+	mconn2 := NewMConnection(server, chDescs, func(byte, []byte) {}, onError)
+	mconn2.SetLogger(log.TestingLogger())
+	require.True(t, mconn2.channelsIdx[0x01].sendBytes([]byte("gossip")))
+	require.True(t, mconn2.channelsIdx[0x01].sendBytes([]byte("gossip")))
+	require.True(t, mconn2.channelsIdx[0x02].sendUrgentBytes([]byte("vote")))
+	require.True(t, mconn2.channelsIdx[0x02].sendBytes([]byte("lazy")))
+	assert.Equal(t, 1, mconn2.Status().Channels[1].UrgentQueueSize)
+	err = mconn2.Start()
+	require.Nil(t, err)
+	defer mconn2.Stop()
+	mconn2.send <- struct{}{}
+
+	// the urgent message preempts the channel with the higher priority
+	for _, want := range []string{"vote", "gossip", "gossip", "lazy"} {
+		select {
+		case got := <-receivedCh:
+			assert.Equal(t, want, got)
+		case <-time.After(500 * time.Millisecond):
+			t.Fatalf("Did not receive %s message in 500ms", want)
+		}
+	}
+}
+
+func TestMConnectionBandwidthBudget(t *testing.T) {
+	server, client := NetPipe()
+	defer server.Close() // nolint: errcheck
+	defer client.Close() // nolint: errcheck
+
+	chDescs := []*ChannelDescriptor{
+		{ID: 0x01, Priority: 10, SendQueueCapacity: 5},
+		{ID: 0x02, Priority: 1, SendQueueCapacity: 1},
+	}
+	receivedCh := make(chan byte, 6)
+	onReceive := func(chID byte, msgBytes []byte) {
+		receivedCh <- chID
+	}
+	onError := func(r interface{}) {}
+	mconn1 := NewMConnection(client, chDescs, onReceive, onError)
+	mconn1.SetLogger(log.TestingLogger())
+	require.Nil(t, mconn1.Start())
+	defer mconn1.Stop()
+
+	// the channel 0x01 may send about one packet per sample
+	budget := NewBandwidthBudget(1, 0)
+	cfg := DefaultMConnConfig()
+	cfg.ChannelBudgets = map[byte]*BandwidthBudget{0x01: budget}
+	mconn2 := NewMConnectionWithConfig(server, chDescs, func(byte, []byte) {}, onError, cfg)
+	mconn2.SetLogger(log.TestingLogger())
+	require.Nil(t, mconn2.Start())
+	defer mconn2.Stop()
+
+	for i := 0; i < 5; i++ {
+		require.True(t, mconn2.Send(0x01, make([]byte, 2*defaultMaxPacketMsgPayloadSize)))
+	}
+	require.True(t, mconn2.Send(0x02, []byte("vote")))
+
+	// the channel over its budget doesn't delay the other one
+	received := 0
+	for chID := byte(0); chID != 0x02; received++ {
+		select {
+		case chID = <-receivedCh:
+		case <-time.After(time.Second):
+			t.Fatal("Did not receive the message of the channel without budget in 1s")
+		}
+	}
+	assert.True(t, received < 5, "the channel over its budget sent its messages first")
+
+	// the budget changes at runtime
+	budget.SetRates(0, 0)
+	for ; received < 6; received++ {
+		select {
+		case chID := <-receivedCh:
+			assert.EqualValues(t, 0x01, chID)
+		case <-time.After(time.Second):
+			t.Fatal("Did not receive the messages of the channel 0x01 in 1s")
+		}
+	}
+}
+
 func TestMConnectionStatus(t *testing.T) {
 	server, client := NetPipe()
 	defer server.Close() // nolint: errcheck
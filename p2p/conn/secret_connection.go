@@ -33,6 +33,10 @@ const aeadSizeOverhead = 16 // overhead of poly 1305 authentication tag
 const aeadKeySize = chacha20poly1305.KeySize
 const aeadNonceSize = chacha20poly1305.NonceSize
 
+// rekeyFrameLength is the length of the frame announcing that the sender
+// rotates its key: the next frames are sealed with the next key.
+const rekeyFrameLength = math.MaxUint32
+
 var (
 	ErrSmallOrderRemotePubKey = errors.New("detected low order point from remote peer")
 	ErrSharedSecretIsZero     = errors.New("shared secret is all zeroes")
@@ -50,11 +54,10 @@ var (
 type SecretConnection struct {
 
 	// immutable
-	recvAead cipher.AEAD
-	sendAead cipher.AEAD
-
 	remPubKey crypto.PubKey
 	conn      io.ReadWriteCloser
+	config    SecretConnConfig
+	onRekey   func(direction string, age time.Duration, bytes int64)
 
 	// net.Conn must be thread safe:
 	// https://golang.org/pkg/net/#Conn.
@@ -66,9 +69,71 @@ type SecretConnection struct {
 	recvMtx    sync.Mutex
 	recvBuffer []byte
 	recvNonce  *[aeadNonceSize]byte
+	recvKey    secretConnKey
 
 	sendMtx   sync.Mutex
 	sendNonce *[aeadNonceSize]byte
+	sendKey   secretConnKey
+}
+
+// SecretConnConfig is the key rotation config of a SecretConnection. The
+// keys received are rotated whenever the peer says so, but a peer running a
+// version without key rotation drops the connection at the first rotation of
+// our send key.
+type SecretConnConfig struct {
+	// The send key is rotated after sealing RekeyBytes bytes or after
+	// RekeyInterval, at the next write, whichever comes first (0 for never).
+	RekeyBytes    int64
+	RekeyInterval time.Duration
+}
+
+// SecretConnStatus describes the ciphers and the keys of a SecretConnection.
+type SecretConnStatus struct {
+	KeyExchange  string
+	Cipher       string
+	SendKeyAge   time.Duration
+	SendKeyBytes int64
+	SendRekeys   int
+	RecvKeyAge   time.Duration
+	RecvKeyBytes int64
+	RecvRekeys   int
+}
+
+// secretConnKey is the key of one direction of a SecretConnection.
+type secretConnKey struct {
+	secret  *[aeadKeySize]byte
+	aead    cipher.AEAD
+	created time.Time
+	bytes   int64 // sealed or opened with the key
+	rekeys  int   // number of rotations so far
+}
+
+func newSecretConnKey(secret *[aeadKeySize]byte) (secretConnKey, error) {
+	aead, err := chacha20poly1305.New(secret[:])
+	if err != nil {
+		return secretConnKey{}, err
+	}
+	return secretConnKey{secret: secret, aead: aead, created: time.Now()}, nil
+}
+
+// next returns the key replacing k, derived with HKDF, and wipes k. The
+// previous keys can't be derived from the next ones, so the data sealed with
+// them stays secret if a key leaks.
+func (k secretConnKey) next() secretConnKey {
+	hkdf := hkdf.New(sha256.New, k.secret[:], nil, []byte("TENDERMINT_SECRET_CONNECTION_REKEY"))
+	secret := new([aeadKeySize]byte)
+	if _, err := io.ReadFull(hkdf, secret[:]); err != nil {
+		panic(err)
+	}
+	for i := range k.secret {
+		k.secret[i] = 0
+	}
+	next, err := newSecretConnKey(secret)
+	if err != nil {
+		panic(err)
+	}
+	next.rekeys = k.rekeys + 1
+	return next
 }
 
 // MakeSecretConnection performs handshake and returns a new authenticated
@@ -77,6 +142,13 @@ type SecretConnection struct {
 // Caller should call conn.Close()
 // See docs/sts-final.pdf for more information.
 func MakeSecretConnection(conn io.ReadWriteCloser, locPrivKey crypto.PrivKey) (*SecretConnection, error) {
+	return MakeSecretConnectionWithConfig(conn, locPrivKey, SecretConnConfig{})
+}
+
+// MakeSecretConnectionWithConfig is like MakeSecretConnection, rotating the
+// send key as configured.
+func MakeSecretConnectionWithConfig(conn io.ReadWriteCloser, locPrivKey crypto.PrivKey,
+	config SecretConnConfig) (*SecretConnection, error) {
 	locPubKey := locPrivKey.PubKey()
 
 	// Generate ephemeral keys for perfect forward secrecy.
@@ -106,22 +178,23 @@ func MakeSecretConnection(conn io.ReadWriteCloser, locPrivKey crypto.PrivKey) (*
 	// generate the secret used for receiving, sending, challenge via hkdf-sha2 on dhSecret
 	recvSecret, sendSecret, challenge := deriveSecretAndChallenge(dhSecret, locIsLeast)
 
-	sendAead, err := chacha20poly1305.New(sendSecret[:])
+	sendKey, err := newSecretConnKey(sendSecret)
 	if err != nil {
 		return nil, errors.New("invalid send SecretConnection Key")
 	}
-	recvAead, err := chacha20poly1305.New(recvSecret[:])
+	recvKey, err := newSecretConnKey(recvSecret)
 	if err != nil {
 		return nil, errors.New("invalid receive SecretConnection Key")
 	}
 	// Construct SecretConnection.
 	sc := &SecretConnection{
 		conn:       conn,
+		config:     config,
 		recvBuffer: nil,
 		recvNonce:  new([aeadNonceSize]byte),
 		sendNonce:  new([aeadNonceSize]byte),
-		recvAead:   recvAead,
-		sendAead:   sendAead,
+		recvKey:    recvKey,
+		sendKey:    sendKey,
 	}
 
 	// Sign the challenge bytes for authentication.
@@ -153,6 +226,78 @@ func (sc *SecretConnection) RemotePubKey() crypto.PubKey {
 	return sc.remPubKey
 }
 
+// SetOnRekey sets a function called after each rotation of a key, with the
+// direction ("send" or "recv"), the age of the key replaced and the number
+// of bytes it sealed or opened, e.g. to audit the connection. It must be set
+// before the connection is used.
+func (sc *SecretConnection) SetOnRekey(fn func(direction string, age time.Duration, bytes int64)) {
+	sc.onRekey = fn
+}
+
+// Status returns the ciphers and the state of the keys of the connection.
+func (sc *SecretConnection) Status() SecretConnStatus {
+	status := SecretConnStatus{KeyExchange: "x25519", Cipher: "chacha20poly1305"}
+	now := time.Now()
+	sc.sendMtx.Lock()
+	status.SendKeyAge = now.Sub(sc.sendKey.created)
+	status.SendKeyBytes = sc.sendKey.bytes
+	status.SendRekeys = sc.sendKey.rekeys
+	sc.sendMtx.Unlock()
+	sc.recvMtx.Lock()
+	status.RecvKeyAge = now.Sub(sc.recvKey.created)
+	status.RecvKeyBytes = sc.recvKey.bytes
+	status.RecvRekeys = sc.recvKey.rekeys
+	sc.recvMtx.Unlock()
+	return status
+}
+
+// sendRekeyDue returns whether the send key must be rotated.
+// NOTE: sc.sendMtx must be locked
+func (sc *SecretConnection) sendRekeyDue() bool {
+	return (sc.config.RekeyBytes > 0 && sc.sendKey.bytes >= sc.config.RekeyBytes) ||
+		(sc.config.RekeyInterval > 0 && time.Since(sc.sendKey.created) >= sc.config.RekeyInterval)
+}
+
+// rekeySend announces the rotation of the send key to the peer, in a frame
+// sealed with the current key, and rotates it.
+// NOTE: sc.sendMtx must be locked
+func (sc *SecretConnection) rekeySend() error {
+	var sealedFrame = pool.Get(aeadSizeOverhead + totalFrameSize)
+	var frame = pool.Get(totalFrameSize)
+	defer func() {
+		pool.Put(sealedFrame)
+		pool.Put(frame)
+	}()
+	binary.LittleEndian.PutUint32(frame, rekeyFrameLength)
+	for i := dataLenSize; i < len(frame); i++ {
+		frame[i] = 0
+	}
+	sc.sendKey.aead.Seal(sealedFrame[:0], sc.sendNonce[:], frame, nil)
+	incrNonce(sc.sendNonce)
+	if _, err := sc.conn.Write(sealedFrame); err != nil {
+		return err
+	}
+
+	age, bytes := time.Since(sc.sendKey.created), sc.sendKey.bytes
+	sc.sendKey = sc.sendKey.next()
+	sc.sendNonce = new([aeadNonceSize]byte)
+	if sc.onRekey != nil {
+		sc.onRekey("send", age, bytes)
+	}
+	return nil
+}
+
+// rekeyRecv rotates the receive key, as announced by the peer.
+// NOTE: sc.recvMtx must be locked
+func (sc *SecretConnection) rekeyRecv() {
+	age, bytes := time.Since(sc.recvKey.created), sc.recvKey.bytes
+	sc.recvKey = sc.recvKey.next()
+	sc.recvNonce = new([aeadNonceSize]byte)
+	if sc.onRekey != nil {
+		sc.onRekey("recv", age, bytes)
+	}
+}
+
 // Writes encrypted frames of `totalFrameSize + aeadSizeOverhead`.
 // CONTRACT: data smaller than dataMaxSize is written atomically.
 func (sc *SecretConnection) Write(data []byte) (n int, err error) {
@@ -160,6 +305,11 @@ func (sc *SecretConnection) Write(data []byte) (n int, err error) {
 	defer sc.sendMtx.Unlock()
 
 	for 0 < len(data) {
+		if sc.sendRekeyDue() {
+			if err := sc.rekeySend(); err != nil {
+				return n, err
+			}
+		}
 		if err := func() error {
 			var sealedFrame = pool.Get(aeadSizeOverhead + totalFrameSize)
 			var frame = pool.Get(totalFrameSize)
@@ -180,8 +330,9 @@ func (sc *SecretConnection) Write(data []byte) (n int, err error) {
 			copy(frame[dataLenSize:], chunk)
 
 			// encrypt the frame
-			sc.sendAead.Seal(sealedFrame[:0], sc.sendNonce[:], frame, nil)
+			sc.sendKey.aead.Seal(sealedFrame[:0], sc.sendNonce[:], frame, nil)
 			incrNonce(sc.sendNonce)
+			sc.sendKey.bytes += int64(chunkLength)
 			// end encryption
 
 			_, err = sc.conn.Write(sealedFrame)
@@ -212,28 +363,38 @@ func (sc *SecretConnection) Read(data []byte) (n int, err error) {
 	// read off the conn
 	var sealedFrame = pool.Get(aeadSizeOverhead + totalFrameSize)
 	defer pool.Put(sealedFrame)
-	_, err = io.ReadFull(sc.conn, sealedFrame)
-	if err != nil {
-		return
-	}
-
-	// decrypt the frame.
-	// reads and updates the sc.recvNonce
 	var frame = pool.Get(totalFrameSize)
 	defer pool.Put(frame)
-	_, err = sc.recvAead.Open(frame[:0], sc.recvNonce[:], sealedFrame, nil)
-	if err != nil {
-		return n, errors.New("failed to decrypt SecretConnection")
+	var chunkLength uint32
+	for {
+		_, err = io.ReadFull(sc.conn, sealedFrame)
+		if err != nil {
+			return
+		}
+
+		// decrypt the frame.
+		// reads and updates the sc.recvNonce
+		_, err = sc.recvKey.aead.Open(frame[:0], sc.recvNonce[:], sealedFrame, nil)
+		if err != nil {
+			return n, errors.New("failed to decrypt SecretConnection")
+		}
+		incrNonce(sc.recvNonce)
+		// end decryption
+
+		chunkLength = binary.LittleEndian.Uint32(frame) // read the first four bytes
+		if chunkLength != rekeyFrameLength {
+			break
+		}
+		// the next frames are sealed with the next key
+		sc.rekeyRecv()
 	}
-	incrNonce(sc.recvNonce)
-	// end decryption
 
 	// copy checkLength worth into data,
 	// set recvBuffer to the rest.
-	var chunkLength = binary.LittleEndian.Uint32(frame) // read the first four bytes
 	if chunkLength > dataMaxSize {
 		return 0, errors.New("chunkLength is greater than dataMaxSize")
 	}
+	sc.recvKey.bytes += int64(chunkLength)
 	var chunk = frame[dataLenSize : dataLenSize+chunkLength]
 	n = copy(data, chunk)
 	if n < len(chunk) {
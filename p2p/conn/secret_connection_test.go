@@ -14,10 +14,12 @@ import (
 	"strings"
 	"sync"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"github.com/hdac-io/tendermint/crypto"
+	"github.com/hdac-io/tendermint/crypto/bls"
 	"github.com/hdac-io/tendermint/crypto/ed25519"
 	"github.com/hdac-io/tendermint/crypto/secp256k1"
 	cmn "github.com/hdac-io/tendermint/libs/common"
@@ -522,3 +524,46 @@ func BenchmarkReadSecretConnection(b *testing.B) {
 	}
 	b.StopTimer()
 }
+
+func TestSecretConnectionRekey(t *testing.T) {
+	fooConn, barConn := makeKVStoreConnPair()
+	config := SecretConnConfig{RekeyBytes: 2 * dataMaxSize}
+	var fooSecConn, barSecConn *SecretConnection
+	trs, ok := cmn.Parallel(
+		func(_ int) (val interface{}, err error, abort bool) {
+			fooSecConn, err = MakeSecretConnectionWithConfig(fooConn, bls.GenPrivKey(), config)
+			return nil, err, err != nil
+		},
+		func(_ int) (val interface{}, err error, abort bool) {
+			barSecConn, err = MakeSecretConnection(barConn, bls.GenPrivKey())
+			return nil, err, err != nil
+		},
+	)
+	require.True(t, ok)
+	require.NoError(t, trs.FirstError())
+
+	var fooRekeys []int64
+	fooSecConn.SetOnRekey(func(direction string, _ time.Duration, bytes int64) {
+		assert.Equal(t, "send", direction)
+		fooRekeys = append(fooRekeys, bytes)
+	})
+
+	data := cmn.RandBytes(10 * dataMaxSize)
+	go func() {
+		_, err := fooSecConn.Write(data)
+		assert.NoError(t, err)
+	}()
+	read := make([]byte, len(data))
+	_, err := io.ReadFull(barSecConn, read)
+	require.NoError(t, err)
+	assert.Equal(t, data, read)
+
+	// the first key also sealed the handshake
+	if assert.Len(t, fooRekeys, 4) {
+		assert.True(t, fooRekeys[0] > 2*dataMaxSize)
+		assert.Equal(t, []int64{2 * dataMaxSize, 2 * dataMaxSize, 2 * dataMaxSize}, fooRekeys[1:])
+	}
+	assert.EqualValues(t, 4, fooSecConn.Status().SendRekeys)
+	assert.EqualValues(t, 4, barSecConn.Status().RecvRekeys)
+	assert.EqualValues(t, 0, barSecConn.Status().SendRekeys, "only the send key of foo is rotated")
+}
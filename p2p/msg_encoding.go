@@ -0,0 +1,73 @@
+package p2p
+
+import (
+	"strings"
+	"sync"
+)
+
+// MsgEncodingProto is advertised in DefaultNodeInfoOther.MsgEncodings by the
+// nodes decoding the reactor messages encoded with protobuf, see the
+// types/msgs package.
+const MsgEncodingProto = "proto"
+
+// HasMsgEncoding returns true if the node of nodeInfo advertises encoding.
+func HasMsgEncoding(nodeInfo NodeInfo, encoding string) bool {
+	ni, ok := nodeInfo.(DefaultNodeInfo)
+	if !ok {
+		return false
+	}
+	for _, e := range strings.Split(ni.Other.MsgEncodings, ",") {
+		if strings.TrimSpace(e) == encoding {
+			return true
+		}
+	}
+	return false
+}
+
+// ProtoMsgs returns true if the reactor messages exchanged with peer are
+// encoded with protobuf, i.e. if both the node and peer advertise
+// MsgEncodingProto, else they're encoded with amino.
+func (sw *Switch) ProtoMsgs(peer Peer) bool {
+	return sw != nil && HasMsgEncoding(sw.NodeInfo(), MsgEncodingProto) &&
+		HasMsgEncoding(peer.NodeInfo(), MsgEncodingProto)
+}
+
+// BroadcastMsg is like Broadcast, with the message encoded for each peer by
+// encode, with protobuf if proto is true, see ProtoMsgs. The message is
+// encoded at most once with each encoding.
+func (sw *Switch) BroadcastMsg(chID byte, encode func(proto bool) []byte) chan bool {
+	var (
+		mtx     sync.Mutex
+		encoded = make(map[bool][]byte, 2)
+	)
+	msgBytes := func(proto bool) []byte {
+		mtx.Lock()
+		defer mtx.Unlock()
+		bz, ok := encoded[proto]
+		if !ok {
+			bz = encode(proto)
+			encoded[proto] = bz
+		}
+		return bz
+	}
+
+	peers := sw.peers.List()
+	var wg sync.WaitGroup
+	wg.Add(len(peers))
+	successChan := make(chan bool, len(peers))
+
+	for _, peer := range peers {
+		go func(p Peer) {
+			defer wg.Done()
+			success := p.Send(chID, msgBytes(sw.ProtoMsgs(p)))
+			successChan <- success
+		}(peer)
+	}
+
+	go func() {
+		wg.Wait()
+		close(successChan)
+	}()
+
+	return successChan
+}
@@ -0,0 +1,53 @@
+package p2p
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHasMsgEncoding(t *testing.T) {
+	testCases := []struct {
+		encodings string
+		has       bool
+	}{
+		{"", false},
+		{"proto", true},
+		{"json, proto", true},
+		{"protobuf", false},
+	}
+	for _, tc := range testCases {
+		ni := DefaultNodeInfo{Other: DefaultNodeInfoOther{MsgEncodings: tc.encodings}}
+		assert.Equal(t, tc.has, HasMsgEncoding(ni, MsgEncodingProto), tc.encodings)
+	}
+	assert.False(t, HasMsgEncoding(mockNodeInfo{}, MsgEncodingProto))
+}
+
+func TestSwitchBroadcastMsg(t *testing.T) {
+	s1, s2 := MakeSwitchPair(t, initSwitchFunc)
+	defer s1.Stop()
+	defer s2.Stop()
+
+	// s2 doesn't advertise protobuf
+	ni := s1.NodeInfo().(DefaultNodeInfo)
+	ni.Other.MsgEncodings = MsgEncodingProto
+	s1.SetNodeInfo(ni)
+	peer := s1.Peers().List()[0]
+	assert.False(t, s1.ProtoMsgs(peer))
+
+	encodings := 0
+	successChan := s1.BroadcastMsg(byte(0x00), func(proto bool) []byte {
+		encodings++
+		if proto {
+			return []byte("proto")
+		}
+		return []byte("amino")
+	})
+	for success := range successChan {
+		assert.True(t, success)
+	}
+	assert.Equal(t, 1, encodings)
+	assertMsgReceivedWithTimeout(t, []byte("amino"), byte(0x00), s2.Reactor("foo").(*TestReactor),
+		10*time.Millisecond, 5*time.Second)
+}
@@ -95,6 +95,9 @@ type DefaultNodeInfo struct {
 type DefaultNodeInfoOther struct {
 	TxIndex    string `json:"tx_index"`
 	RPCAddress string `json:"rpc_address"`
+	// comma separated encodings of the reactor messages the node decodes
+	// besides amino, see MsgEncodingProto
+	MsgEncodings string `json:"msg_encodings"`
 }
 
 // ID returns the node's peer ID.
@@ -165,6 +168,9 @@ func (info DefaultNodeInfo) Validate() error {
 	if len(rpcAddr) > 0 && (!cmn.IsASCIIText(rpcAddr) || cmn.ASCIITrim(rpcAddr) == "") {
 		return fmt.Errorf("info.Other.RPCAddress=%v must be valid ASCII text without tabs", rpcAddr)
 	}
+	if encodings := other.MsgEncodings; len(encodings) > 0 && !cmn.IsASCIIText(encodings) {
+		return fmt.Errorf("info.Other.MsgEncodings=%v must be valid ASCII text without tabs", encodings)
+	}
 
 	return nil
 }
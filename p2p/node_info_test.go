@@ -60,6 +60,10 @@ func TestNodeInfoValidate(t *testing.T) {
 		{"Empty space RPCAddress", func(ni *DefaultNodeInfo) { ni.Other.RPCAddress = emptySpace }, true},
 		{"Empty RPCAddress", func(ni *DefaultNodeInfo) { ni.Other.RPCAddress = "" }, false},
 		{"Good RPCAddress", func(ni *DefaultNodeInfo) { ni.Other.RPCAddress = "0.0.0.0:26657" }, false},
+
+		{"Non-ASCII MsgEncodings", func(ni *DefaultNodeInfo) { ni.Other.MsgEncodings = nonAscii }, true},
+		{"Empty MsgEncodings", func(ni *DefaultNodeInfo) { ni.Other.MsgEncodings = "" }, false},
+		{"Good MsgEncodings", func(ni *DefaultNodeInfo) { ni.Other.MsgEncodings = MsgEncodingProto }, false},
 	}
 
 	nodeKey := NodeKey{PrivKey: ed25519.GenPrivKey()}
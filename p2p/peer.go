@@ -33,6 +33,7 @@ type Peer interface {
 
 	Send(byte, []byte) bool
 	TrySend(byte, []byte) bool
+	SendUrgent(byte, []byte) bool
 
 	Set(string, interface{})
 	Get(string) interface{}
@@ -257,6 +258,26 @@ func (p *peer) Send(chID byte, msgBytes []byte) bool {
 	return res
 }
 
+// SendUrgent msg bytes to the channel identified by chID byte, ahead of the
+// messages queued with Send and TrySend. Returns false if the send queue is
+// full after timeout, specified by MConnection.
+func (p *peer) SendUrgent(chID byte, msgBytes []byte) bool {
+	if !p.IsRunning() {
+		return false
+	} else if !p.hasChannel(chID) {
+		return false
+	}
+	res := p.mconn.SendUrgent(chID, msgBytes)
+	if res {
+		labels := []string{
+			"peer_id", string(p.ID()),
+			"chID", fmt.Sprintf("%#x", chID),
+		}
+		p.metrics.PeerSendBytesTotal.With(labels...).Add(float64(len(msgBytes)))
+	}
+	return res
+}
+
 // TrySend msg bytes to the channel identified by chID byte. Immediately returns
 // false if the send queue is full.
 func (p *peer) TrySend(chID byte, msgBytes []byte) bool {
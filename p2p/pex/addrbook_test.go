@@ -2,10 +2,12 @@ package pex
 
 import (
 	"encoding/hex"
+	"encoding/json"
 	"fmt"
 	"io/ioutil"
 	"math"
 	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -623,3 +625,47 @@ func analyseSelectionLayout(book *addrBook, addrs []*p2p.NetAddress) (seqLens, s
 
 	return
 }
+
+func TestAddrBookExportImport(t *testing.T) {
+	dir, err := ioutil.TempDir("", "addrbook_test")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	fname := filepath.Join(dir, "addrbook.json")
+
+	book, bookFile := createAddrBookWithMOldAndNNewAddrs(t, 3, 2)
+	defer deleteTempFile(bookFile)
+	exported := book.Export()
+	require.Len(t, exported, 5)
+	for i, addr := range exported {
+		assert.Equal(t, i < 3, addr.Vetted, "the vetted addresses come first")
+	}
+
+	bz, err := json.Marshal(exported)
+	require.NoError(t, err)
+	addrs, err := ParseAddrList(bz)
+	require.NoError(t, err)
+	require.Len(t, addrs, 5)
+
+	// a list of seeds
+	extra := []*p2p.NetAddress{randIPv4Address(t), randIPv4Address(t), randIPv4Address(t)}
+	list := fmt.Sprintf("# seeds\n%v,%v\n\n  %v\n", extra[0], extra[1], extra[2])
+	extraAddrs, err := ParseAddrList([]byte(list))
+	require.NoError(t, err)
+	require.Len(t, extraAddrs, 3)
+	for i, addr := range extraAddrs {
+		assert.True(t, extra[i].Equals(addr))
+	}
+	_, err = ParseAddrList([]byte("127.0.0.1:26656"))
+	assert.Error(t, err, "no ID")
+
+	// the imported addresses are unverified
+	other := LoadAddrBook(fname, true)
+	other.SetLogger(log.TestingLogger())
+	assert.Equal(t, 8, other.Import(append(addrs, extraAddrs...)))
+	assert.Equal(t, 0, other.Import(addrs), "already in the book")
+	for _, addr := range other.Export() {
+		assert.False(t, addr.Vetted)
+	}
+	other.Save()
+	assert.Equal(t, 8, LoadAddrBook(fname, true).Size())
+}
@@ -1,11 +0,0 @@
-package pex
-
-import (
-	amino "github.com/tendermint/go-amino"
-)
-
-var cdc *amino.Codec = amino.NewCodec()
-
-func init() {
-	RegisterPexMessage(cdc)
-}
@@ -0,0 +1,21 @@
+// Code generated by scripts/codecgen from its manifest. DO NOT EDIT.
+
+package pex
+
+import (
+	amino "github.com/tendermint/go-amino"
+)
+
+var cdc = amino.NewCodec()
+
+func init() {
+	RegisterPexMessage(cdc)
+}
+
+// RegisterPexMessage registers the messages of the PEX reactor.
+func RegisterPexMessage(cdc *amino.Codec) {
+	cdc.RegisterInterface((*PexMessage)(nil), nil)
+	cdc.RegisterConcrete(&pexRequestMessage{}, "tendermint/p2p/PexRequestMessage", nil)
+	cdc.RegisterConcrete(&pexAddrsMessage{}, "tendermint/p2p/PexAddrsMessage", nil)
+	cdc.RegisterConcrete(&pexObservedAddrMessage{}, "tendermint/p2p/PexObservedAddrMessage", nil)
+}
@@ -1,11 +1,17 @@
 package pex
 
 import (
+	"bufio"
+	"bytes"
 	"encoding/json"
 	"fmt"
 	"os"
+	"sort"
+	"strings"
+	"time"
 
 	cmn "github.com/hdac-io/tendermint/libs/common"
+	"github.com/hdac-io/tendermint/p2p"
 )
 
 /* Loading & Saving */
@@ -81,3 +87,104 @@ func (a *addrBook) loadFromFile(filePath string) bool {
 	}
 	return true
 }
+
+/* Exporting & Importing */
+
+// LoadAddrBook returns the address book saved in filePath, or an empty one if
+// the file doesn't exist, without starting it, e.g. to export or import
+// addresses while the node is stopped.
+func LoadAddrBook(filePath string, routabilityStrict bool) *addrBook {
+	book := NewAddrBook(filePath, routabilityStrict)
+	book.loadFromFile(filePath)
+	return book
+}
+
+// ExportedAddr is an address of the book in an export, which other nodes can
+// import: unlike the book file, it doesn't depend on the key of the book.
+type ExportedAddr struct {
+	Addr        string    `json:"addr"` // ID@host:port
+	Vetted      bool      `json:"vetted"`
+	LastSuccess time.Time `json:"last_success,omitempty"`
+}
+
+// Export returns the addresses of the book, the vetted ones first.
+func (a *addrBook) Export() []ExportedAddr {
+	a.mtx.Lock()
+	defer a.mtx.Unlock()
+
+	addrs := make([]ExportedAddr, 0, len(a.addrLookup))
+	for _, ka := range a.addrLookup {
+		addrs = append(addrs, ExportedAddr{
+			Addr:        ka.Addr.String(),
+			Vetted:      ka.isOld(),
+			LastSuccess: ka.LastSuccess,
+		})
+	}
+	sort.Slice(addrs, func(i, j int) bool {
+		if addrs[i].Vetted != addrs[j].Vetted {
+			return addrs[i].Vetted
+		}
+		return addrs[i].Addr < addrs[j].Addr
+	})
+	return addrs
+}
+
+// Import adds the addresses which are not in the book yet as unverified
+// addresses, with themselves as source, whether or not they were vetted by
+// the node they come from: they are vetted once this node connects to them.
+// It returns the number of addresses added.
+func (a *addrBook) Import(addrs []*p2p.NetAddress) int {
+	a.mtx.Lock()
+	defer a.mtx.Unlock()
+
+	added := 0
+	for _, addr := range addrs {
+		if _, ok := a.addrLookup[addr.ID]; ok {
+			continue
+		}
+		if err := a.addAddress(addr, addr); err != nil {
+			a.Logger.Info("Skipped imported address", "addr", addr, "err", err)
+			continue
+		}
+		added++
+	}
+	return added
+}
+
+// ParseAddrList parses a list of addresses to import in an address book: an
+// export of an address book, or ID@host:port addresses separated by commas or
+// new lines, with the lines starting with # ignored, e.g. a list of seeds.
+func ParseAddrList(bz []byte) ([]*p2p.NetAddress, error) {
+	var strs []string
+	if trimmed := bytes.TrimSpace(bz); len(trimmed) > 0 && trimmed[0] == '[' {
+		var exported []ExportedAddr
+		if err := json.Unmarshal(trimmed, &exported); err != nil {
+			return nil, err
+		}
+		for _, addr := range exported {
+			strs = append(strs, addr.Addr)
+		}
+	} else {
+		scanner := bufio.NewScanner(bytes.NewReader(bz))
+		for scanner.Scan() {
+			line := strings.TrimSpace(scanner.Text())
+			if line == "" || strings.HasPrefix(line, "#") {
+				continue
+			}
+			for _, str := range strings.Split(line, ",") {
+				if str = strings.TrimSpace(str); str != "" {
+					strs = append(strs, str)
+				}
+			}
+		}
+		if err := scanner.Err(); err != nil {
+			return nil, err
+		}
+	}
+
+	addrs, errs := p2p.NewNetAddressStrings(strs)
+	if len(errs) > 0 {
+		return nil, errs[0]
+	}
+	return addrs, nil
+}
@@ -0,0 +1,67 @@
+package pex
+
+import (
+	"net"
+	"sync"
+
+	"github.com/hdac-io/tendermint/p2p"
+)
+
+// minObservedIPPeers is the minimum number of peers reporting the same IP for
+// it to be taken as our external IP.
+const minObservedIPPeers = 2
+
+// observedIPs keeps the IP each connected peer sees us connecting from, to
+// detect our external IP when we're behind a NAT.
+type observedIPs struct {
+	mtx sync.Mutex
+	ips map[p2p.ID]net.IP
+}
+
+func newObservedIPs() *observedIPs {
+	return &observedIPs{ips: make(map[p2p.ID]net.IP)}
+}
+
+// observe records that the peer id sees us from ip. The non routable IPs, e.g.
+// reported by a peer in the same private network, are ignored.
+func (o *observedIPs) observe(id p2p.ID, ip net.IP) {
+	if !(&p2p.NetAddress{ID: id, IP: ip}).Routable() {
+		return
+	}
+	o.mtx.Lock()
+	defer o.mtx.Unlock()
+	o.ips[id] = ip
+}
+
+// remove forgets the IP reported by the peer id.
+func (o *observedIPs) remove(id p2p.ID) {
+	o.mtx.Lock()
+	defer o.mtx.Unlock()
+	delete(o.ips, id)
+}
+
+// externalIP returns the IP reported by the most peers, if at least
+// minObservedIPPeers of them reported it, else nil.
+func (o *observedIPs) externalIP() net.IP {
+	o.mtx.Lock()
+	defer o.mtx.Unlock()
+
+	counts := make(map[string]int)
+	var (
+		best      net.IP
+		bestCount int
+	)
+	for _, ip := range o.ips {
+		key := ip.String()
+		counts[key]++
+		count := counts[key]
+		// ties go to the lowest IP, for the result not to depend on the map order
+		if count > bestCount || (count == bestCount && key < best.String()) {
+			best, bestCount = ip, count
+		}
+	}
+	if bestCount < minObservedIPPeers {
+		return nil
+	}
+	return best
+}
@@ -2,13 +2,13 @@ package pex
 
 import (
 	"fmt"
+	"net"
 	"reflect"
 	"sync"
 	"time"
 
 	"github.com/pkg/errors"
 
-	amino "github.com/tendermint/go-amino"
 	cmn "github.com/hdac-io/tendermint/libs/common"
 	"github.com/hdac-io/tendermint/p2p"
 	"github.com/hdac-io/tendermint/p2p/conn"
@@ -20,6 +20,10 @@ const (
 	// PexChannel is a channel for PEX messages
 	PexChannel = byte(0x00)
 
+	// ObservedAddrChannel is a channel for the peers to tell us the IP they
+	// see us from, to detect our external IP behind a NAT
+	ObservedAddrChannel = byte(0x01)
+
 	// over-estimate of max NetAddress size
 	// hexID (40) + IP (16) + Port (2) + Name (100) ...
 	// NOTE: dont use massive DNS name ..
@@ -92,6 +96,14 @@ type PEXReactor struct {
 
 	// seed/crawled mode fields
 	crawlPeerInfos map[p2p.ID]crawlPeerInfo
+
+	// the crawls requested with Crawl
+	crawlRequests chan struct{}
+	crawlMtx      sync.Mutex
+	lastCrawl     time.Time
+
+	// the IPs the peers see us from
+	observedIPs *observedIPs
 }
 
 func (r *PEXReactor) minReceiveRequestInterval() time.Duration {
@@ -129,6 +141,8 @@ func NewPEXReactor(b AddrBook, config *PEXReactorConfig) *PEXReactor {
 		requestsSent:         cmn.NewCMap(),
 		lastReceivedRequests: cmn.NewCMap(),
 		crawlPeerInfos:       make(map[p2p.ID]crawlPeerInfo),
+		crawlRequests:        make(chan struct{}, 1),
+		observedIPs:          newObservedIPs(),
 	}
 	r.BaseReactor = *p2p.NewBaseReactor("PEXReactor", r)
 	return r
@@ -173,12 +187,20 @@ func (r *PEXReactor) GetChannels() []*conn.ChannelDescriptor {
 			Priority:          1,
 			SendQueueCapacity: 10,
 		},
+		{
+			ID:                ObservedAddrChannel,
+			Priority:          1,
+			SendQueueCapacity: 1,
+		},
 	}
 }
 
 // AddPeer implements Reactor by adding peer to the address book (if inbound)
-// or by requesting more addresses (if outbound).
+// or by requesting more addresses (if outbound). The peer is told the IP we
+// see it from.
 func (r *PEXReactor) AddPeer(p Peer) {
+	p.Send(ObservedAddrChannel, cdc.MustMarshalBinaryBare(&pexObservedAddrMessage{IP: p.RemoteIP()}))
+
 	if p.IsOutbound() {
 		// For outbound peers, the address is already in the books -
 		// either via DialPeersAsync or r.Receive.
@@ -209,6 +231,7 @@ func (r *PEXReactor) RemovePeer(p Peer, reason interface{}) {
 	id := string(p.ID())
 	r.requestsSent.Delete(id)
 	r.lastReceivedRequests.Delete(id)
+	r.observedIPs.remove(p.ID())
 }
 
 func (r *PEXReactor) logErrAddrBook(err error) {
@@ -276,6 +299,12 @@ func (r *PEXReactor) Receive(chID byte, src Peer, msgBytes []byte) {
 			r.Switch.StopPeerForError(src, err)
 			return
 		}
+	case *pexObservedAddrMessage:
+		if len(msg.IP) != net.IPv4len && len(msg.IP) != net.IPv6len {
+			r.Switch.StopPeerForError(src, fmt.Errorf("invalid observed IP %v", msg.IP))
+			return
+		}
+		r.observedIPs.observe(src.ID(), msg.IP)
 	default:
 		r.Logger.Error(fmt.Sprintf("Unknown message type %v", reflect.TypeOf(msg)))
 	}
@@ -314,6 +343,12 @@ func (r *PEXReactor) receiveRequest(src Peer) error {
 	return nil
 }
 
+// ObservedExternalIP returns the IP the most peers see us from, if at least
+// two of them do, else nil.
+func (r *PEXReactor) ObservedExternalIP() net.IP {
+	return r.observedIPs.externalIP()
+}
+
 // RequestAddrs asks peer for more addresses if we do not already have a
 // request out for this peer.
 func (r *PEXReactor) RequestAddrs(p Peer) {
@@ -414,6 +449,11 @@ func (r *PEXReactor) ensurePeersRoutine() {
 		select {
 		case <-ticker.C:
 			r.ensurePeers()
+		case <-r.crawlRequests:
+			for _, peer := range r.Switch.Peers().List() {
+				r.RequestAddrs(peer)
+			}
+			r.ensurePeers()
 		case <-r.Quit():
 			ticker.Stop()
 			return
@@ -612,6 +652,25 @@ func (r *PEXReactor) AttemptsToDial(addr *p2p.NetAddress) int {
 
 //----------------------------------------------------------
 
+// Crawl makes the reactor ask its peers for addresses and dial the addresses
+// of the book at once, instead of waiting for the next period, e.g. to expand
+// the book of a node bootstrapping a new region. As the peers drop the nodes
+// asking them for addresses too often, it fails if the last crawl was less
+// than a third of the ensure peers period ago.
+func (r *PEXReactor) Crawl() error {
+	r.crawlMtx.Lock()
+	defer r.crawlMtx.Unlock()
+	if since := time.Since(r.lastCrawl); since < r.minReceiveRequestInterval() {
+		return fmt.Errorf("the last crawl was %v ago, wait %v", since.Round(time.Second), r.minReceiveRequestInterval())
+	}
+	r.lastCrawl = time.Now()
+	select {
+	case r.crawlRequests <- struct{}{}:
+	default: // a crawl is pending already
+	}
+	return nil
+}
+
 // Explores the network searching for more peers. (continuous)
 // Seed/Crawler Mode causes this node to quickly disconnect
 // from peers, except other seed nodes.
@@ -633,6 +692,8 @@ func (r *PEXReactor) crawlPeersRoutine() {
 			r.attemptDisconnects()
 			r.crawlPeers(r.book.GetSelection())
 			r.cleanupCrawlPeerInfos()
+		case <-r.crawlRequests:
+			r.crawlPeers(r.book.GetSelection())
 		case <-r.Quit():
 			return
 		}
@@ -731,15 +792,9 @@ func markAddrInBookBasedOnErr(addr *p2p.NetAddress, book AddrBook, err error) {
 // Messages
 
 // PexMessage is a primary type for PEX messages. Underneath, it could contain
-// either pexRequestMessage, or pexAddrsMessage messages.
+// either pexRequestMessage, pexAddrsMessage or pexObservedAddrMessage messages.
 type PexMessage interface{}
 
-func RegisterPexMessage(cdc *amino.Codec) {
-	cdc.RegisterInterface((*PexMessage)(nil), nil)
-	cdc.RegisterConcrete(&pexRequestMessage{}, "tendermint/p2p/PexRequestMessage", nil)
-	cdc.RegisterConcrete(&pexAddrsMessage{}, "tendermint/p2p/PexAddrsMessage", nil)
-}
-
 func decodeMsg(bz []byte) (msg PexMessage, err error) {
 	if len(bz) > maxMsgSize {
 		return msg, fmt.Errorf("Msg exceeds max size (%d > %d)", len(bz), maxMsgSize)
@@ -768,3 +823,14 @@ type pexAddrsMessage struct {
 func (m *pexAddrsMessage) String() string {
 	return fmt.Sprintf("[pexAddrs %v]", m.Addrs)
 }
+
+/*
+A message with the IP the sender sees the receiver from.
+*/
+type pexObservedAddrMessage struct {
+	IP net.IP
+}
+
+func (m *pexObservedAddrMessage) String() string {
+	return fmt.Sprintf("[pexObservedAddr %v]", m.IP)
+}
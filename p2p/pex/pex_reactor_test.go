@@ -3,6 +3,7 @@ package pex
 import (
 	"fmt"
 	"io/ioutil"
+	"net"
 	"os"
 	"path/filepath"
 	"testing"
@@ -197,6 +198,49 @@ func TestPEXReactorAddrsMessageAbuse(t *testing.T) {
 	assert.False(t, sw.Peers().Has(peer.ID()))
 }
 
+func TestPEXReactorObservedExternalIP(t *testing.T) {
+	r, book := createReactor(&PEXReactorConfig{})
+	defer teardownReactor(book)
+
+	sw := createSwitchAndAddReactors(r)
+	sw.SetAddrBook(book)
+
+	observe := func(peer p2p.Peer, ip string) {
+		msg := cdc.MustMarshalBinaryBare(&pexObservedAddrMessage{IP: net.ParseIP(ip)})
+		r.Receive(ObservedAddrChannel, peer, msg)
+	}
+	peers := make([]p2p.Peer, 4)
+	for i := range peers {
+		peers[i] = mock.NewPeer(net.IPv4(10, 0, 0, byte(i+1)))
+		p2p.AddPeerToSwitchPeerSet(sw, peers[i])
+	}
+
+	// a single peer is not enough
+	observe(peers[0], "8.8.8.8")
+	assert.Nil(t, r.ObservedExternalIP())
+
+	// private IPs are ignored
+	observe(peers[1], "192.168.0.1")
+	observe(peers[2], "192.168.0.1")
+	assert.Nil(t, r.ObservedExternalIP())
+
+	// the IP reported by the most peers
+	observe(peers[1], "8.8.8.8")
+	observe(peers[2], "1.2.3.4")
+	observe(peers[3], "1.2.3.4")
+	assert.True(t, net.ParseIP("1.2.3.4").Equal(r.ObservedExternalIP()))
+
+	// the observations of the removed peers are dropped
+	r.RemovePeer(peers[3], nil)
+	assert.True(t, net.ParseIP("8.8.8.8").Equal(r.ObservedExternalIP()))
+	r.RemovePeer(peers[0], nil)
+	assert.Nil(t, r.ObservedExternalIP())
+
+	// an invalid IP causes a disconnect
+	r.Receive(ObservedAddrChannel, peers[1], cdc.MustMarshalBinaryBare(&pexObservedAddrMessage{IP: []byte{1, 2}}))
+	assert.False(t, sw.Peers().Has(peers[1].ID()))
+}
+
 func TestCheckSeeds(t *testing.T) {
 	// directory to store address books
 	dir, err := ioutil.TempDir("", "pex_reactor")
@@ -661,3 +705,10 @@ func createSwitchAndAddReactors(reactors ...p2p.Reactor) *p2p.Switch {
 	}
 	return sw
 }
+
+func TestPEXReactorCrawl(t *testing.T) {
+	r := NewPEXReactor(NewAddrBook("", false), &PEXReactorConfig{})
+	require.NoError(t, r.Crawl())
+	assert.Len(t, r.crawlRequests, 1)
+	assert.Error(t, r.Crawl(), "too soon for the peers")
+}
@@ -11,22 +11,13 @@ import (
 	"github.com/hdac-io/tendermint/config"
 	cmn "github.com/hdac-io/tendermint/libs/common"
 	"github.com/hdac-io/tendermint/p2p/conn"
+	"github.com/hdac-io/tendermint/p2p/trust"
 )
 
 const (
 	// wait a random amount of time from this interval
-	// before dialing peers or reconnecting to help prevent DoS
+	// before dialing peers to help prevent DoS
 	dialRandomizerIntervalMilliseconds = 3000
-
-	// repeatedly try to reconnect for a few minutes
-	// ie. 5 * 20 = 100s
-	reconnectAttempts = 20
-	reconnectInterval = 5 * time.Second
-
-	// then move into exponential backoff mode for ~1day
-	// ie. 3**10 = 16hrs
-	reconnectBackOffAttempts    = 10
-	reconnectBackOffBaseSeconds = 3
 )
 
 // MConnConfig returns an MConnConfig with fields updated
@@ -77,8 +68,12 @@ type Switch struct {
 	nodeInfo     NodeInfo // our node info
 	nodeKey      *NodeKey // our node privkey
 	addrBook     AddrBook
+
+	peersMtx sync.RWMutex
 	// peers addresses with whom we'll maintain constant connection
 	persistentPeersAddrs []*NetAddress
+	// peers IDs accepted even if we have enough inbound peers
+	unconditionalPeerIDs map[ID]struct{}
 
 	transport Transport
 
@@ -88,6 +83,10 @@ type Switch struct {
 	rng *cmn.Rand // seed for randomizing dial times and orders
 
 	metrics *Metrics
+
+	// optional trust metrics of the peers, and list of the banned peers
+	trustStore *trust.TrustMetricStore
+	banList    *BanList
 }
 
 // NetAddress returns the address the switch is listening on.
@@ -117,6 +116,7 @@ func NewSwitch(
 		transport:            transport,
 		filterTimeout:        defaultFilterTimeout,
 		persistentPeersAddrs: make([]*NetAddress, 0),
+		unconditionalPeerIDs: make(map[ID]struct{}),
 	}
 
 	// Ensure we have a completely undeterministic PRNG.
@@ -141,6 +141,18 @@ func SwitchPeerFilters(filters ...PeerFilterFunc) SwitchOption {
 	return func(sw *Switch) { sw.peerFilters = filters }
 }
 
+// SwitchTrustMetricStore makes the switch record the good and bad events of
+// the peers in tms, which the caller starts and stops.
+func SwitchTrustMetricStore(tms *trust.TrustMetricStore) SwitchOption {
+	return func(sw *Switch) { sw.trustStore = tms }
+}
+
+// SwitchBanList makes the switch reject the peers banned in bl, and ban peers
+// in it with BanPeer.
+func SwitchBanList(bl *BanList) SwitchOption {
+	return func(sw *Switch) { sw.banList = bl }
+}
+
 // WithMetrics sets the metrics.
 func WithMetrics(metrics *Metrics) SwitchOption {
 	return func(sw *Switch) { sw.metrics = metrics }
@@ -308,6 +320,7 @@ func (sw *Switch) Peers() IPeerSet {
 // TODO: make record depending on reason.
 func (sw *Switch) StopPeerForError(peer Peer, reason interface{}) {
 	sw.Logger.Error("Stopping peer for error", "peer", peer, "err", reason)
+	sw.MarkPeerAsBad(peer)
 	sw.stopAndRemovePeer(peer, reason)
 
 	if peer.IsPersistent() {
@@ -349,10 +362,15 @@ func (sw *Switch) stopAndRemovePeer(peer Peer, reason interface{}) {
 	if sw.peers.Remove(peer) {
 		sw.metrics.Peers.Add(float64(-1))
 	}
+
+	if sw.trustStore != nil {
+		sw.trustStore.PeerDisconnected(string(peer.ID()))
+	}
 }
 
 // reconnectToPeer tries to reconnect to the addr, first repeatedly
-// with a fixed interval, then with exponential backoff.
+// with a fixed interval, then with exponential backoff, as configured by the
+// Reconnect* fields of the P2PConfig.
 // If no success after all that, it stops trying, and leaves it
 // to the PEX/Addrbook to find the peer with the addr again
 // NOTE: this will keep trying even if the handshake or auth fails.
@@ -368,46 +386,62 @@ func (sw *Switch) reconnectToPeer(addr *NetAddress) {
 
 	start := time.Now()
 	sw.Logger.Info("Reconnecting to peer", "addr", addr)
-	for i := 0; i < reconnectAttempts; i++ {
-		if !sw.IsRunning() {
-			return
+	attempts := sw.config.ReconnectAttempts + sw.config.ReconnectBackoffAttempts
+	for i := 0; i < attempts; i++ {
+		if i == sw.config.ReconnectAttempts {
+			sw.Logger.Error("Failed to reconnect to peer. Beginning exponential backoff",
+				"addr", addr, "elapsed", time.Since(start))
 		}
-
-		err := sw.DialPeerWithAddress(addr)
-		if err == nil {
-			return // success
-		} else if _, ok := err.(ErrCurrentlyDialingOrExistingAddress); ok {
+		if i > 0 && !sw.reconnectSleep(reconnectDelay(sw.config, i)) {
 			return
 		}
-
-		sw.Logger.Info("Error reconnecting to peer. Trying again", "tries", i, "err", err, "addr", addr)
-		// sleep a set amount
-		sw.randomSleep(reconnectInterval)
-		continue
-	}
-
-	sw.Logger.Error("Failed to reconnect to peer. Beginning exponential backoff",
-		"addr", addr, "elapsed", time.Since(start))
-	for i := 0; i < reconnectBackOffAttempts; i++ {
 		if !sw.IsRunning() {
 			return
 		}
 
-		// sleep an exponentially increasing amount
-		sleepIntervalSeconds := math.Pow(reconnectBackOffBaseSeconds, float64(i))
-		sw.randomSleep(time.Duration(sleepIntervalSeconds) * time.Second)
-
 		err := sw.DialPeerWithAddress(addr)
 		if err == nil {
 			return // success
 		} else if _, ok := err.(ErrCurrentlyDialingOrExistingAddress); ok {
 			return
+		} else if _, ok := err.(ErrPeerBanned); ok {
+			sw.Logger.Info("Not reconnecting to banned peer", "addr", addr, "err", err)
+			return
 		}
 		sw.Logger.Info("Error reconnecting to peer. Trying again", "tries", i, "err", err, "addr", addr)
 	}
 	sw.Logger.Error("Failed to reconnect to peer. Giving up", "addr", addr, "elapsed", time.Since(start))
 }
 
+// reconnectDelay returns the time to wait before the given reconnection
+// attempt (the first one being 0), without the jitter.
+func reconnectDelay(cfg *config.P2PConfig, attempt int) time.Duration {
+	if attempt < cfg.ReconnectAttempts {
+		return cfg.ReconnectInterval
+	}
+	backoff := math.Pow(cfg.ReconnectBackoffFactor, float64(attempt-cfg.ReconnectAttempts)) * float64(time.Second)
+	if cfg.ReconnectMaxBackoff > 0 && backoff > float64(cfg.ReconnectMaxBackoff) {
+		return cfg.ReconnectMaxBackoff
+	}
+	return time.Duration(backoff)
+}
+
+// reconnectSleep sleeps for delay plus a random amount of up to the
+// configured jitter. It returns false if the switch was stopped meanwhile.
+func (sw *Switch) reconnectSleep(delay time.Duration) bool {
+	if jitter := sw.config.ReconnectJitter; jitter > 0 {
+		delay += time.Duration(sw.rng.Int63n(int64(jitter)))
+	}
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return true
+	case <-sw.Quit():
+		return false
+	}
+}
+
 // SetAddrBook allows to set address book on Switch.
 func (sw *Switch) SetAddrBook(addrBook AddrBook) {
 	sw.addrBook = addrBook
@@ -419,6 +453,84 @@ func (sw *Switch) MarkPeerAsGood(peer Peer) {
 	if sw.addrBook != nil {
 		sw.addrBook.MarkGood(peer.ID())
 	}
+	if sw.trustStore != nil {
+		sw.trustStore.GetPeerTrustMetric(string(peer.ID())).GoodEvents(1)
+	}
+}
+
+// MarkPeerAsBad lowers the trust score of the given peer when it did something
+// useless or harmful, like sending messages of rounds we have passed.
+func (sw *Switch) MarkPeerAsBad(peer Peer) {
+	if sw.trustStore != nil {
+		sw.trustStore.GetPeerTrustMetric(string(peer.ID())).BadEvents(1)
+	}
+}
+
+// PeerTrustScores returns the trust score, between 0 and 100, of each peer
+// the switch recorded events of, connected or not. It returns nil if the
+// switch has no TrustMetricStore.
+func (sw *Switch) PeerTrustScores() map[ID]int {
+	if sw.trustStore == nil {
+		return nil
+	}
+	scores := make(map[ID]int)
+	for key, score := range sw.trustStore.TrustScores() {
+		scores[ID(key)] = score
+	}
+	return scores
+}
+
+// BanPeer bans the peer for the given duration, and disconnects from it. The
+// switch neither dials a banned peer nor accepts its connections.
+func (sw *Switch) BanPeer(id ID, duration time.Duration) error {
+	if sw.banList == nil {
+		return errors.New("no ban list")
+	}
+	if err := validateID(id); err != nil {
+		return err
+	}
+	if duration <= 0 {
+		return fmt.Errorf("invalid ban duration %v", duration)
+	}
+	until := time.Now().Add(duration)
+	sw.banList.Ban(id, until)
+	sw.Logger.Info("Banned peer", "peer", id, "until", until)
+
+	if peer := sw.peers.Get(id); peer != nil {
+		sw.stopAndRemovePeer(peer, ErrPeerBanned{ID: id, Until: until})
+	}
+	return nil
+}
+
+// UnbanPeer lifts the ban of the peer.
+func (sw *Switch) UnbanPeer(id ID) error {
+	if sw.banList == nil {
+		return errors.New("no ban list")
+	}
+	if !sw.banList.Unban(id) {
+		return fmt.Errorf("peer %v is not banned", id)
+	}
+	sw.Logger.Info("Unbanned peer", "peer", id)
+	return nil
+}
+
+// BannedPeers returns the time the ban of each banned peer expires.
+func (sw *Switch) BannedPeers() map[ID]time.Time {
+	if sw.banList == nil {
+		return nil
+	}
+	return sw.banList.Bans()
+}
+
+// checkBanned returns ErrPeerBanned if the peer is banned.
+func (sw *Switch) checkBanned(id ID) error {
+	if sw.banList == nil {
+		return nil
+	}
+	if until, ok := sw.banList.BannedUntil(id); ok {
+		return ErrPeerBanned{ID: id, Until: until}
+	}
+	return nil
 }
 
 //---------------------------------------------------------------------
@@ -537,9 +649,9 @@ func (sw *Switch) IsDialingOrExistingAddress(addr *NetAddress) bool {
 		(!sw.config.AllowDuplicateIP && sw.peers.HasIP(addr.IP))
 }
 
-// AddPersistentPeers allows you to set persistent peers. It ignores
-// ErrNetAddressLookup. However, if there are other errors, first encounter is
-// returned.
+// AddPersistentPeers allows you to add persistent peers, in addition to the
+// ones already added. It ignores ErrNetAddressLookup. However, if there are
+// other errors, first encounter is returned.
 func (sw *Switch) AddPersistentPeers(addrs []string) error {
 	sw.Logger.Info("Adding persistent peers", "addrs", addrs)
 	netAddrs, errs := NewNetAddressStrings(addrs)
@@ -554,18 +666,76 @@ func (sw *Switch) AddPersistentPeers(addrs []string) error {
 		}
 		return err
 	}
-	sw.persistentPeersAddrs = netAddrs
+
+	sw.peersMtx.Lock()
+	defer sw.peersMtx.Unlock()
+	for _, na := range netAddrs {
+		if !sw.isPeerPersistent(na) {
+			sw.persistentPeersAddrs = append(sw.persistentPeersAddrs, na)
+		}
+	}
+	return nil
+}
+
+// AddUnconditionalPeerIDs allows you to add the IDs of the peers which are
+// accepted even if we already have MaxNumInboundPeers inbound peers. If an ID
+// is invalid, an error is returned and none is added.
+func (sw *Switch) AddUnconditionalPeerIDs(ids []string) error {
+	sw.Logger.Info("Adding unconditional peer ids", "ids", ids)
+	for _, id := range ids {
+		if err := validateID(ID(id)); err != nil {
+			return errors.Wrapf(err, "wrong ID %q", id)
+		}
+	}
+
+	sw.peersMtx.Lock()
+	defer sw.peersMtx.Unlock()
+	for _, id := range ids {
+		sw.unconditionalPeerIDs[ID(id)] = struct{}{}
+	}
 	return nil
 }
 
+// IsPeerUnconditional returns true if the peer with the given ID is accepted
+// regardless of the number of inbound peers.
+func (sw *Switch) IsPeerUnconditional(id ID) bool {
+	sw.peersMtx.RLock()
+	defer sw.peersMtx.RUnlock()
+	_, ok := sw.unconditionalPeerIDs[id]
+	return ok
+}
+
+// NOTE: sw.peersMtx must be locked
+func (sw *Switch) isPeerPersistent(na *NetAddress) bool {
+	for _, pa := range sw.persistentPeersAddrs {
+		if pa.Equals(na) {
+			return true
+		}
+	}
+	return false
+}
+
+// isPeerAllowed returns true if the peer with the given ID is a persistent or
+// an unconditional peer, the only ones accepted if config.AllowListOnly.
+func (sw *Switch) isPeerAllowed(id ID) bool {
+	sw.peersMtx.RLock()
+	defer sw.peersMtx.RUnlock()
+	if _, ok := sw.unconditionalPeerIDs[id]; ok {
+		return true
+	}
+	for _, pa := range sw.persistentPeersAddrs {
+		if pa.ID == id {
+			return true
+		}
+	}
+	return false
+}
+
 func (sw *Switch) isPeerPersistentFn() func(*NetAddress) bool {
 	return func(na *NetAddress) bool {
-		for _, pa := range sw.persistentPeersAddrs {
-			if pa.Equals(na) {
-				return true
-			}
-		}
-		return false
+		sw.peersMtx.RLock()
+		defer sw.peersMtx.RUnlock()
+		return sw.isPeerPersistent(na)
 	}
 }
 
@@ -625,9 +795,10 @@ func (sw *Switch) acceptRoutine() {
 			break
 		}
 
-		// Ignore connection if we already have enough peers.
+		// Ignore connection if we already have enough peers, unless it's
+		// unconditional.
 		_, in, _ := sw.NumPeers()
-		if in >= sw.config.MaxNumInboundPeers {
+		if in >= sw.config.MaxNumInboundPeers && !sw.IsPeerUnconditional(p.ID()) {
 			sw.Logger.Info(
 				"Ignoring inbound connection: already have enough inbound peers",
 				"address", p.SocketAddr(),
@@ -663,6 +834,10 @@ func (sw *Switch) addOutboundPeerWithConfig(
 	addr *NetAddress,
 	cfg *config.P2PConfig,
 ) error {
+	if err := sw.checkBanned(addr.ID); err != nil {
+		return err
+	}
+
 	sw.Logger.Info("Dialing peer", "address", addr)
 
 	// XXX(xla): Remove the leakage of test concerns in implementation.
@@ -716,6 +891,14 @@ func (sw *Switch) filterPeer(p Peer) error {
 		return ErrRejected{id: p.ID(), isDuplicate: true}
 	}
 
+	if err := sw.checkBanned(p.ID()); err != nil {
+		return ErrRejected{id: p.ID(), err: err, isFiltered: true}
+	}
+
+	if sw.config.AllowListOnly && !sw.isPeerAllowed(p.ID()) {
+		return ErrRejected{id: p.ID(), err: errors.New("not a persistent or unconditional peer"), isFiltered: true}
+	}
+
 	errc := make(chan error, len(sw.peerFilters))
 
 	for _, f := range sw.peerFilters {
@@ -19,8 +19,10 @@ import (
 	"github.com/prometheus/client_golang/prometheus/promhttp"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	dbm "github.com/tendermint/tm-db"
 
 	"github.com/hdac-io/tendermint/config"
+	"github.com/hdac-io/tendermint/crypto/bls"
 	"github.com/hdac-io/tendermint/crypto/ed25519"
 	"github.com/hdac-io/tendermint/libs/log"
 	"github.com/hdac-io/tendermint/p2p/conn"
@@ -312,6 +314,55 @@ func assertNoPeersAfterTimeout(t *testing.T, sw *Switch, timeout time.Duration)
 	}
 }
 
+func TestSwitchBanPeer(t *testing.T) {
+	banList, err := NewBanList(dbm.NewMemDB())
+	require.NoError(t, err)
+	sw := MakeSwitch(cfg, 1, "testing", "123.123.123", initSwitchFunc, SwitchBanList(banList))
+	err = sw.Start()
+	require.NoError(t, err)
+	defer sw.Stop()
+
+	// simulate remote peer
+	rp := &remotePeer{PrivKey: bls.GenPrivKey(), Config: cfg}
+	rp.Start()
+	defer rp.Stop()
+
+	err = sw.DialPeerWithAddress(rp.Addr())
+	require.NoError(t, err)
+	require.NotNil(t, sw.Peers().Get(rp.ID()))
+
+	// banning disconnects the peer, and rejects it afterwards
+	require.NoError(t, sw.BanPeer(rp.ID(), time.Hour))
+	assert.Nil(t, sw.Peers().Get(rp.ID()))
+	assert.Contains(t, sw.BannedPeers(), rp.ID())
+
+	err = sw.DialPeerWithAddress(rp.Addr())
+	if assert.Error(t, err) {
+		_, ok := err.(ErrPeerBanned)
+		assert.True(t, ok, "expected ErrPeerBanned, got %v", err)
+	}
+
+	p, err := sw.transport.Dial(*rp.Addr(), peerConfig{
+		chDescs:      sw.chDescs,
+		onPeerError:  sw.StopPeerForError,
+		isPersistent: sw.isPeerPersistentFn(),
+		reactorsByCh: sw.reactorsByCh,
+	})
+	require.NoError(t, err)
+	err = sw.addPeer(p)
+	if err, ok := err.(ErrRejected); assert.True(t, ok, "expected ErrRejected") {
+		assert.True(t, err.IsFiltered())
+	}
+	sw.transport.Cleanup(p)
+
+	// unbanning accepts it again
+	require.NoError(t, sw.UnbanPeer(rp.ID()))
+	assert.Error(t, sw.UnbanPeer(rp.ID()))
+	err = sw.DialPeerWithAddress(rp.Addr())
+	require.NoError(t, err)
+	assert.NotNil(t, sw.Peers().Get(rp.ID()))
+}
+
 func TestSwitchStopsNonPersistentPeerOnError(t *testing.T) {
 	assert, require := assert.New(t), require.New(t)
 
@@ -485,6 +536,81 @@ func TestSwitchDialPeersAsync(t *testing.T) {
 	require.NotNil(t, sw.Peers().Get(rp.ID()))
 }
 
+func TestSwitchReconnectDelay(t *testing.T) {
+	conf := config.DefaultP2PConfig()
+	conf.ReconnectAttempts = 2
+	conf.ReconnectInterval = 5 * time.Second
+	conf.ReconnectBackoffFactor = 2
+	conf.ReconnectMaxBackoff = 10 * time.Second
+
+	delays := []time.Duration{
+		5 * time.Second, 5 * time.Second, // fixed interval
+		1 * time.Second, 2 * time.Second, 4 * time.Second, 8 * time.Second, // backoff
+		10 * time.Second, 10 * time.Second, // capped
+	}
+	for i, delay := range delays {
+		assert.Equal(t, delay, reconnectDelay(conf, i), "attempt %d", i)
+	}
+
+	conf.ReconnectMaxBackoff = 0
+	assert.Equal(t, 1024*time.Second, reconnectDelay(conf, 12))
+}
+
+func TestSwitchAddPersistentAndUnconditionalPeers(t *testing.T) {
+	sw := MakeSwitch(cfg, 1, "testing", "123.123.123", initSwitchFunc)
+
+	addr1 := "d51fb70907db1c6c2d5237e78379b25cf1a37ab4d51fb70907db1c6c2d5237e7@127.0.0.1:41198"
+	addr2 := "a51fb70907db1c6c2d5237e78379b25cf1a37ab4d51fb70907db1c6c2d5237e7@127.0.0.1:41199"
+	require.NoError(t, sw.AddPersistentPeers([]string{addr1}))
+	// the persistent peers are added to the previous ones
+	require.NoError(t, sw.AddPersistentPeers([]string{addr1, addr2}))
+	isPersistent := sw.isPeerPersistentFn()
+	for _, addr := range []string{addr1, addr2} {
+		na, err := NewNetAddressString(addr)
+		require.NoError(t, err)
+		assert.True(t, isPersistent(na), addr)
+	}
+	assert.Len(t, sw.persistentPeersAddrs, 2)
+
+	id := "d51fb70907db1c6c2d5237e78379b25cf1a37ab4d51fb70907db1c6c2d5237e7"
+	assert.False(t, sw.IsPeerUnconditional(ID(id)))
+	assert.Error(t, sw.AddUnconditionalPeerIDs([]string{id, "invalid"}))
+	assert.False(t, sw.IsPeerUnconditional(ID(id)))
+	require.NoError(t, sw.AddUnconditionalPeerIDs([]string{id}))
+	assert.True(t, sw.IsPeerUnconditional(ID(id)))
+}
+
+func TestSwitchAllowListOnly(t *testing.T) {
+	sw := MakeSwitch(cfg, 1, "testing", "123.123.123", initSwitchFunc)
+	sw.config.AllowListOnly = true
+	err := sw.Start()
+	require.NoError(t, err)
+	defer sw.Stop()
+
+	rp := &remotePeer{PrivKey: bls.GenPrivKey(), Config: cfg}
+	rp.Start()
+	defer rp.Stop()
+
+	// a stranger is refused
+	err = sw.DialPeerWithAddress(rp.Addr())
+	if err, ok := err.(ErrRejected); assert.True(t, ok, "expected ErrRejected, got %v", err) {
+		assert.True(t, err.IsFiltered())
+	}
+	assert.Nil(t, sw.Peers().Get(rp.ID()))
+
+	// a persistent peer is accepted, as an unconditional one
+	require.NoError(t, sw.AddPersistentPeers([]string{rp.Addr().String()}))
+	require.NoError(t, sw.DialPeerWithAddress(rp.Addr()))
+	assert.NotNil(t, sw.Peers().Get(rp.ID()))
+
+	rp2 := &remotePeer{PrivKey: bls.GenPrivKey(), Config: cfg}
+	rp2.Start()
+	defer rp2.Stop()
+	require.NoError(t, sw.AddUnconditionalPeerIDs([]string{string(rp2.ID())}))
+	require.NoError(t, sw.DialPeerWithAddress(rp2.Addr()))
+	assert.NotNil(t, sw.Peers().Get(rp2.ID()))
+}
+
 func waitUntilSwitchHasAtLeastNPeers(sw *Switch, n int) {
 	for i := 0; i < 20; i++ {
 		time.Sleep(250 * time.Millisecond)
@@ -556,6 +682,19 @@ func TestSwitchAcceptRoutine(t *testing.T) {
 	assert.Equal(t, cfg.MaxNumInboundPeers, sw.Peers().Size())
 	rp.Stop()
 
+	// 3. check we accept unconditional peers even if we already have
+	// MaxNumInboundPeers peers
+	rp = &remotePeer{PrivKey: ed25519.GenPrivKey(), Config: cfg}
+	remotePeers = append(remotePeers, rp)
+	rp.Start()
+	err = sw.AddUnconditionalPeerIDs([]string{string(rp.ID())})
+	require.NoError(t, err)
+	conn, err = rp.Dial(sw.NetAddress())
+	require.NoError(t, err)
+	defer conn.Close()
+	waitUntilSwitchHasAtLeastNPeers(sw, cfg.MaxNumInboundPeers+1)
+	assert.Equal(t, cfg.MaxNumInboundPeers+1, sw.Peers().Size())
+
 	// stop remote peers
 	for _, rp := range remotePeers {
 		rp.Stop()
@@ -224,22 +224,22 @@ func testPeerConn(
 	ourNodePrivKey crypto.PrivKey,
 	socketAddr *NetAddress,
 ) (pc peerConn, err error) {
-	conn := rawConn
+	c := rawConn
 
 	// Fuzz connection
 	if cfg.TestFuzz {
 		// so we have time to do peer handshakes and get set up
-		conn = FuzzConnAfterFromConfig(conn, 10*time.Second, cfg.TestFuzzConfig)
+		c = FuzzConnAfterFromConfig(c, 10*time.Second, cfg.TestFuzzConfig)
 	}
 
 	// Encrypt connection
-	conn, err = upgradeSecretConn(conn, cfg.HandshakeTimeout, ourNodePrivKey)
+	c, err = upgradeSecretConn(c, cfg.HandshakeTimeout, ourNodePrivKey, conn.SecretConnConfig{})
 	if err != nil {
 		return pc, errors.Wrap(err, "Error creating peer")
 	}
 
 	// Only the information we already have
-	return newPeerConn(outbound, persistent, conn, socketAddr), nil
+	return newPeerConn(outbound, persistent, c, socketAddr), nil
 }
 
 //----------------------------------------------------------------
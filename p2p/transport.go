@@ -9,6 +9,7 @@ import (
 	"github.com/pkg/errors"
 
 	"github.com/hdac-io/tendermint/crypto"
+	"github.com/hdac-io/tendermint/libs/log"
 	"github.com/hdac-io/tendermint/p2p/conn"
 )
 
@@ -122,6 +123,26 @@ func MultiplexTransportResolver(resolver IPResolver) MultiplexTransportOption {
 	return func(mt *MultiplexTransport) { mt.resolver = resolver }
 }
 
+// MultiplexTransportPinnedKeys pins the public keys of peers: the connections
+// of a peer whose ID has a pinned key, inbound or outbound, are rejected if it
+// authenticates with another key.
+func MultiplexTransportPinnedKeys(keys map[ID]crypto.PubKey) MultiplexTransportOption {
+	return func(mt *MultiplexTransport) { mt.pinnedKeys = keys }
+}
+
+// MultiplexTransportSecretConnConfig sets the key rotation of the secret
+// connections.
+func MultiplexTransportSecretConnConfig(config conn.SecretConnConfig) MultiplexTransportOption {
+	return func(mt *MultiplexTransport) { mt.secretConnConfig = config }
+}
+
+// MultiplexTransportSecretConnAudit logs the ciphers of each secret connection
+// and the age of its keys at each rotation with logger, for the security
+// reviews of the long-lived links, e.g. between a validator and its sentries.
+func MultiplexTransportSecretConnAudit(logger log.Logger) MultiplexTransportOption {
+	return func(mt *MultiplexTransport) { mt.auditLogger = logger }
+}
+
 // MultiplexTransport accepts and dials tcp connections and upgrades them to
 // multiplexed peers.
 type MultiplexTransport struct {
@@ -142,6 +163,13 @@ type MultiplexTransport struct {
 	nodeKey          NodeKey
 	resolver         IPResolver
 
+	// public keys of the peers, by ID, see MultiplexTransportPinnedKeys
+	pinnedKeys map[ID]crypto.PubKey
+
+	secretConnConfig conn.SecretConnConfig
+	// nil unless the secret connections are audited
+	auditLogger log.Logger
+
 	// TODO(xla): This config is still needed as we parameterise peerConn and
 	// peer currently. All relevant configuration should be refactored into options
 	// with sane defaults.
@@ -383,7 +411,7 @@ func (mt *MultiplexTransport) upgrade(
 		}
 	}()
 
-	secretConn, err = upgradeSecretConn(c, mt.handshakeTimeout, mt.nodeKey.PrivKey)
+	secretConn, err = upgradeSecretConn(c, mt.handshakeTimeout, mt.nodeKey.PrivKey, mt.secretConnConfig)
 	if err != nil {
 		return nil, nil, ErrRejected{
 			conn:          c,
@@ -409,6 +437,24 @@ func (mt *MultiplexTransport) upgrade(
 		}
 	}
 
+	// Ensure connection key matches pinned key.
+	if pinnedKey, ok := mt.pinnedKeys[connID]; ok && !pinnedKey.Equals(secretConn.RemotePubKey()) {
+		return nil, nil, ErrRejected{
+			conn: c,
+			id:   connID,
+			err: fmt.Errorf(
+				"conn pubkey (%X) pinned pubkey (%X) mismatch",
+				secretConn.RemotePubKey().Bytes(),
+				pinnedKey.Bytes(),
+			),
+			isAuthFailure: true,
+		}
+	}
+
+	if mt.auditLogger != nil {
+		mt.auditSecretConn(secretConn, connID)
+	}
+
 	nodeInfo, err = handshake(secretConn, mt.handshakeTimeout, mt.nodeInfo)
 	if err != nil {
 		return nil, nil, ErrRejected{
@@ -540,16 +586,30 @@ func handshake(
 	return peerNodeInfo, c.SetDeadline(time.Time{})
 }
 
+// auditSecretConn logs the ciphers of sc, and the age of its keys at each
+// rotation.
+func (mt *MultiplexTransport) auditSecretConn(sc *conn.SecretConnection, id ID) {
+	logger := mt.auditLogger.With("peer", id, "addr", sc.RemoteAddr())
+	status := sc.Status()
+	logger.Info("Secret connection established", "keyExchange", status.KeyExchange, "cipher", status.Cipher,
+		"auth", fmt.Sprintf("%T", sc.RemotePubKey()), "rekeyBytes", mt.secretConnConfig.RekeyBytes,
+		"rekeyInterval", mt.secretConnConfig.RekeyInterval)
+	sc.SetOnRekey(func(direction string, age time.Duration, bytes int64) {
+		logger.Info("Rotated the secret connection key", "direction", direction, "keyAge", age, "keyBytes", bytes)
+	})
+}
+
 func upgradeSecretConn(
 	c net.Conn,
 	timeout time.Duration,
 	privKey crypto.PrivKey,
+	config conn.SecretConnConfig,
 ) (*conn.SecretConnection, error) {
 	if err := c.SetDeadline(time.Now().Add(timeout)); err != nil {
 		return nil, err
 	}
 
-	sc, err := conn.MakeSecretConnection(c, privKey)
+	sc, err := conn.MakeSecretConnectionWithConfig(c, privKey, config)
 	if err != nil {
 		return nil, err
 	}
@@ -8,6 +8,8 @@ import (
 	"testing"
 	"time"
 
+	"github.com/hdac-io/tendermint/crypto"
+	"github.com/hdac-io/tendermint/crypto/bls"
 	"github.com/hdac-io/tendermint/crypto/ed25519"
 	"github.com/hdac-io/tendermint/p2p/conn"
 )
@@ -240,7 +242,7 @@ func TestTransportMultiplexAcceptNonBlocking(t *testing.T) {
 			errc <- fmt.Errorf("Fast peer timed out")
 		}
 
-		sc, err := upgradeSecretConn(c, 20*time.Millisecond, ed25519.GenPrivKey())
+		sc, err := upgradeSecretConn(c, 20*time.Millisecond, ed25519.GenPrivKey(), conn.SecretConnConfig{})
 		if err != nil {
 			errc <- err
 			return
@@ -375,6 +377,48 @@ func TestTransportMultiplexRejectMissmatchID(t *testing.T) {
 	}
 }
 
+func TestTransportMultiplexRejectPinnedKeyMismatch(t *testing.T) {
+	var (
+		pv       = bls.GenPrivKey()
+		dialerID = PubKeyToID(pv.PubKey())
+	)
+	for _, pinned := range []bool{true, false} {
+		// the secret connection needs BLS keys
+		lpv := bls.GenPrivKey()
+		mt := newMultiplexTransport(testNodeInfo(PubKeyToID(lpv.PubKey()), "transport"), NodeKey{PrivKey: lpv})
+		addr, err := NewNetAddressString(IDAddressString(mt.nodeKey.ID(), "127.0.0.1:0"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := mt.Listen(*addr); err != nil {
+			t.Fatal(err)
+		}
+
+		// a key of the ID of the dialer which is not its key
+		keys := map[ID]crypto.PubKey{dialerID: bls.GenPrivKey().PubKey()}
+		if pinned {
+			keys[dialerID] = pv.PubKey()
+		}
+		MultiplexTransportPinnedKeys(keys)(mt)
+
+		go func() {
+			dialer := newMultiplexTransport(testNodeInfo(dialerID, "dialer"), NodeKey{PrivKey: pv})
+			addr := NewNetAddress(mt.nodeKey.ID(), mt.listener.Addr())
+			_, _ = dialer.Dial(*addr, peerConfig{})
+		}()
+
+		_, err = mt.Accept(peerConfig{})
+		if pinned {
+			if err != nil {
+				t.Errorf("expected the pinned key to be accepted, got %v", err)
+			}
+		} else if err, ok := err.(ErrRejected); !ok || !err.IsAuthFailure() {
+			t.Errorf("expected auth failure, got %v", err)
+		}
+		mt.Close()
+	}
+}
+
 func TestTransportMultiplexDialRejectWrongID(t *testing.T) {
 	mt := testSetupMultiplexTransport(t)
 
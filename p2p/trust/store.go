@@ -114,6 +114,18 @@ func (tms *TrustMetricStore) GetPeerTrustMetric(key string) *TrustMetric {
 	return tm
 }
 
+// TrustScores returns the trust score of each peer of the store, by peer key.
+func (tms *TrustMetricStore) TrustScores() map[string]int {
+	tms.mtx.Lock()
+	defer tms.mtx.Unlock()
+
+	scores := make(map[string]int, len(tms.peerMetrics))
+	for key, tm := range tms.peerMetrics {
+		scores[key] = tm.TrustScore()
+	}
+	return scores
+}
+
 // PeerDisconnected pauses the trust metric associated with the peer identified by the key
 func (tms *TrustMetricStore) PeerDisconnected(key string) {
 	tms.mtx.Lock()
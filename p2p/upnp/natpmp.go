@@ -0,0 +1,165 @@
+package upnp
+
+// NAT-PMP, RFC 6886, for the gateways without UPnP, e.g. Apple's.
+
+import (
+	"bufio"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"strings"
+	"time"
+)
+
+const (
+	natpmpPort = 5351
+
+	natpmpOpExternalAddress = 0
+	natpmpOpMapUDP          = 1
+	natpmpOpMapTCP          = 2
+
+	// the lease of a mapping with a timeout of 0, which deletes the mapping
+	// with NAT-PMP
+	natpmpDefaultLifetime = 7200
+
+	// the request is sent again after 250ms, 500ms, ... up to natpmpMaxTries
+	// times, as recommended by the RFC but shorter
+	natpmpFirstTimeout = 250 * time.Millisecond
+	natpmpMaxTries     = 4
+)
+
+type natpmpNAT struct {
+	gateway *net.UDPAddr
+}
+
+// DiscoverNATPMP returns the NAT-PMP NAT of gateway, or of the default
+// gateway if it's nil, after checking it answers.
+func DiscoverNATPMP(gateway net.IP) (nat NAT, err error) {
+	if gateway == nil {
+		if gateway, err = defaultGateway(); err != nil {
+			return nil, err
+		}
+	}
+	n := &natpmpNAT{gateway: &net.UDPAddr{IP: gateway, Port: natpmpPort}}
+	if _, err := n.GetExternalAddress(); err != nil {
+		return nil, err
+	}
+	return n, nil
+}
+
+func (n *natpmpNAT) GetExternalAddress() (addr net.IP, err error) {
+	resp, err := n.request([]byte{0, natpmpOpExternalAddress}, 12)
+	if err != nil {
+		return nil, err
+	}
+	return net.IPv4(resp[8], resp[9], resp[10], resp[11]), nil
+}
+
+func (n *natpmpNAT) AddPortMapping(protocol string, externalPort, internalPort int, description string, timeout int) (mappedExternalPort int, err error) {
+	if timeout == 0 {
+		timeout = natpmpDefaultLifetime
+	}
+	resp, err := n.mapPort(protocol, externalPort, internalPort, timeout)
+	if err != nil {
+		return 0, err
+	}
+	return int(binary.BigEndian.Uint16(resp[10:12])), nil
+}
+
+func (n *natpmpNAT) DeletePortMapping(protocol string, externalPort, internalPort int) (err error) {
+	// a lifetime of 0 deletes the mapping of the internal port
+	_, err = n.mapPort(protocol, externalPort, internalPort, 0)
+	return
+}
+
+func (n *natpmpNAT) mapPort(protocol string, externalPort, internalPort, lifetime int) ([]byte, error) {
+	var op byte
+	switch protocol {
+	case "udp", "UDP":
+		op = natpmpOpMapUDP
+	case "tcp", "TCP":
+		op = natpmpOpMapTCP
+	default:
+		return nil, fmt.Errorf("unknown protocol %v", protocol)
+	}
+	msg := make([]byte, 12)
+	msg[1] = op
+	binary.BigEndian.PutUint16(msg[4:6], uint16(internalPort))
+	binary.BigEndian.PutUint16(msg[6:8], uint16(externalPort))
+	binary.BigEndian.PutUint32(msg[8:12], uint32(lifetime))
+	return n.request(msg, 16)
+}
+
+// request sends msg to the gateway and returns its response of respLen
+// bytes, after checking it answers msg and succeeded.
+func (n *natpmpNAT) request(msg []byte, respLen int) ([]byte, error) {
+	conn, err := net.DialUDP("udp4", nil, n.gateway)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close() // nolint: errcheck
+
+	resp := make([]byte, 16)
+	timeout := natpmpFirstTimeout
+	for i := 0; i < natpmpMaxTries; i++ {
+		if _, err = conn.Write(msg); err != nil {
+			return nil, err
+		}
+		if err = conn.SetReadDeadline(time.Now().Add(timeout)); err != nil {
+			return nil, err
+		}
+		timeout *= 2
+
+		var size int
+		size, err = conn.Read(resp)
+		if err != nil {
+			if netErr, ok := err.(net.Error); ok && netErr.Timeout() {
+				continue
+			}
+			return nil, err
+		}
+		// a response to an earlier request of another op is skipped
+		if size < respLen || resp[1] != msg[1]+128 {
+			continue
+		}
+		if resp[0] != 0 {
+			return nil, fmt.Errorf("unsupported NAT-PMP version %d", resp[0])
+		}
+		if code := binary.BigEndian.Uint16(resp[2:4]); code != 0 {
+			return nil, fmt.Errorf("NAT-PMP request failed with result code %d", code)
+		}
+		return resp[:respLen], nil
+	}
+	return nil, errors.New("no response from the NAT-PMP gateway")
+}
+
+// defaultGateway returns the gateway of the default IPv4 route, from
+// /proc/net/route on Linux.
+func defaultGateway() (net.IP, error) {
+	f, err := os.Open("/proc/net/route")
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close() // nolint: errcheck
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		// Iface Destination Gateway Flags ..., in little-endian hex
+		fields := strings.Fields(scanner.Text())
+		if len(fields) < 3 || fields[1] != "00000000" {
+			continue
+		}
+		gw, err := hex.DecodeString(fields[2])
+		if err != nil || len(gw) != net.IPv4len {
+			continue
+		}
+		return net.IPv4(gw[3], gw[2], gw[1], gw[0]), nil
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return nil, errors.New("no default gateway")
+}
@@ -0,0 +1,36 @@
+// Code generated by scripts/codecgen from its manifest. DO NOT EDIT.
+
+package privval
+
+import (
+	cryptoAmino "github.com/hdac-io/tendermint/crypto/encoding/amino"
+	amino "github.com/tendermint/go-amino"
+)
+
+var cdc = amino.NewCodec()
+
+func init() {
+	cryptoAmino.RegisterAmino(cdc)
+	RegisterRemoteSignerMsg(cdc)
+	RegisterFridaySignState(cdc)
+}
+
+// RegisterRemoteSignerMsg registers the messages between the signer clients and servers.
+func RegisterRemoteSignerMsg(cdc *amino.Codec) {
+	cdc.RegisterInterface((*SignerMessage)(nil), nil)
+	cdc.RegisterConcrete(&PubKeyRequest{}, "tendermint/remotesigner/PubKeyRequest", nil)
+	cdc.RegisterConcrete(&PubKeyResponse{}, "tendermint/remotesigner/PubKeyResponse", nil)
+	cdc.RegisterConcrete(&SignVoteRequest{}, "tendermint/remotesigner/SignVoteRequest", nil)
+	cdc.RegisterConcrete(&SignedVoteResponse{}, "tendermint/remotesigner/SignedVoteResponse", nil)
+	cdc.RegisterConcrete(&SignProposalRequest{}, "tendermint/remotesigner/SignProposalRequest", nil)
+	cdc.RegisterConcrete(&SignedProposalResponse{}, "tendermint/remotesigner/SignedProposalResponse", nil)
+	cdc.RegisterConcrete(&SetImmutableHeightRequest{}, "tendermint/remotesigner/SetImmutableHeightRequest", nil)
+	cdc.RegisterConcrete(&SetImmutableHeightResponse{}, "tendermint/remotesigner/SetImmutableHeightResponse", nil)
+	cdc.RegisterConcrete(&PingRequest{}, "tendermint/remotesigner/PingRequest", nil)
+	cdc.RegisterConcrete(&PingResponse{}, "tendermint/remotesigner/PingResponse", nil)
+}
+
+// RegisterFridaySignState registers the sign state of FridayFilePV.
+func RegisterFridaySignState(cdc *amino.Codec) {
+	cdc.RegisterConcrete(&FridayFilePVSignState{}, fridaySignStateAminoName, nil)
+}
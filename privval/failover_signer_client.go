@@ -0,0 +1,267 @@
+package privval
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/pkg/errors"
+
+	"github.com/hdac-io/tendermint/crypto"
+	"github.com/hdac-io/tendermint/libs/log"
+	"github.com/hdac-io/tendermint/types"
+)
+
+// FailoverSignerClient implements PrivValidator.
+// It signs with a primary signer, e.g. a SignerClient connected to a remote
+// signer, and fails over to the standby signers, in order, while it is
+// disconnected or fails to respond, so a dead signer doesn't miss more than
+// the step it was signing.
+//
+// The signers share a watermark: the highest round and step requested at each
+// height, and from which signer. A signer is not requested to sign at or below
+// the watermark of another signer, which may have signed it without
+// responding, so two signers never sign the same height, round and step.
+type FailoverSignerClient struct {
+	pubKey  crypto.PubKey
+	signers []types.PrivValidator
+	logger  log.Logger
+
+	mtx        sync.Mutex
+	verified   []bool
+	watermarks map[int64]signWatermark
+	maxHeight  int64
+}
+
+var _ types.PrivValidator = (*FailoverSignerClient)(nil)
+
+// signWatermark is the highest round and step requested at a height, from the
+// signer of index signer.
+type signWatermark struct {
+	round  int
+	step   int8
+	signer int
+}
+
+// ErrWatermark is returned when a signer is requested to sign at or below the
+// watermark of another signer.
+type ErrWatermark struct {
+	Height int64
+	Round  int
+	Step   int8
+	Signer int
+}
+
+func (e ErrWatermark) Error() string {
+	return fmt.Sprintf("height %d round %d step %d may have been signed by signer %d",
+		e.Height, e.Round, e.Step, e.Signer+1)
+}
+
+// NewFailoverSignerClient returns a FailoverSignerClient signing with the
+// first of signers, or the next ones when it fails. The first signer must be
+// connected to get the public key; the others are checked to have the same
+// key before signing with them.
+func NewFailoverSignerClient(signers []types.PrivValidator, logger log.Logger) (*FailoverSignerClient, error) {
+	if len(signers) == 0 {
+		return nil, errors.New("no signers")
+	}
+	pubKey := signers[0].GetPubKey()
+	if pubKey == nil {
+		return nil, errors.New("failed to get the public key of the primary signer")
+	}
+	verified := make([]bool, len(signers))
+	verified[0] = true
+	return &FailoverSignerClient{
+		pubKey:     pubKey,
+		signers:    signers,
+		logger:     logger,
+		verified:   verified,
+		watermarks: make(map[int64]signWatermark),
+	}, nil
+}
+
+// GetPubKey returns the public key of the primary signer.
+// Implements PrivValidator.
+func (fc *FailoverSignerClient) GetPubKey() crypto.PubKey {
+	return fc.pubKey
+}
+
+// SignVote requests the first available signer to sign the vote.
+// Implements PrivValidator.
+func (fc *FailoverSignerClient) SignVote(chainID string, vote *types.Vote) error {
+	return fc.sign(vote.Height, vote.Round, voteToStep(vote), func(signer types.PrivValidator) error {
+		return signer.SignVote(chainID, vote)
+	})
+}
+
+// SignProposal requests the first available signer to sign the proposal.
+// Implements PrivValidator.
+func (fc *FailoverSignerClient) SignProposal(chainID string, proposal *types.Proposal) error {
+	return fc.sign(proposal.Height, proposal.Round, stepPropose, func(signer types.PrivValidator) error {
+		return signer.SignProposal(chainID, proposal)
+	})
+}
+
+// SetImmutableHeight sets the immutable height of the sign state of the
+// signers, and forgets the watermarks below it. It fails if no signer
+// succeeds.
+// Implements ParallelProgressablePV
+func (fc *FailoverSignerClient) SetImmutableHeight(height int64) error {
+	var failures []string
+	for i, signer := range fc.signers {
+		pv := signer.GetParallelProgressablePV()
+		if pv == nil {
+			failures = append(failures, fmt.Sprintf("signer %d: parallel progress not supported", i+1))
+			continue
+		}
+		if err := pv.SetImmutableHeight(height); err != nil {
+			failures = append(failures, fmt.Sprintf("signer %d: %v", i+1, err))
+		}
+	}
+
+	fc.mtx.Lock()
+	for h := range fc.watermarks {
+		if h < height {
+			delete(fc.watermarks, h)
+		}
+	}
+	fc.mtx.Unlock()
+
+	if len(failures) == len(fc.signers) {
+		return fmt.Errorf("failed to set the immutable height of the signers: %s", strings.Join(failures, "; "))
+	}
+	return nil
+}
+
+// GetParallelProgressablePV implements PrivValidator.
+func (fc *FailoverSignerClient) GetParallelProgressablePV() types.ParallelProgressablePV {
+	return fc
+}
+
+// sign signs with the first connected signer, failing over to the next ones
+// if it fails. If no signer is connected, the primary is tried first, as it
+// may be reconnecting. An error of the remote signer, e.g. refusing to double
+// sign, is returned without failing over.
+func (fc *FailoverSignerClient) sign(height int64, round int, step int8, signOne func(types.PrivValidator) error) error {
+	var failures []string
+	for _, i := range fc.signerOrder() {
+		signer := fc.signers[i]
+		if err := fc.verifyPubKey(i); err != nil {
+			failures = append(failures, fmt.Sprintf("signer %d: %v", i+1, err))
+			continue
+		}
+		prev, err := fc.raiseWatermark(height, round, step, i)
+		if err != nil {
+			failures = append(failures, fmt.Sprintf("signer %d: %v", i+1, err))
+			continue
+		}
+
+		err = signOne(signer)
+		if err == nil {
+			if i > 0 {
+				fc.logger.Info("Signed with standby signer", "signer", i+1, "height", height, "round", round, "step", step)
+			}
+			return nil
+		}
+		if _, ok := err.(*RemoteSignerError); ok {
+			return err
+		}
+		if err == ErrConnectionTimeout || err == ErrNoConnection {
+			// the request wasn't sent, the next signer may sign
+			fc.restoreWatermark(height, round, step, i, prev)
+		}
+		fc.logger.Error("Signer failed, failing over", "signer", i+1, "height", height, "round", round,
+			"step", step, "err", err)
+		failures = append(failures, fmt.Sprintf("signer %d: %v", i+1, err))
+	}
+	return fmt.Errorf("no signer could sign: %s", strings.Join(failures, "; "))
+}
+
+// signerOrder returns the indexes of the connected signers first, in order,
+// then the others.
+func (fc *FailoverSignerClient) signerOrder() []int {
+	var connected, disconnected []int
+	for i, signer := range fc.signers {
+		if sc, ok := signer.(interface{ IsConnected() bool }); ok && !sc.IsConnected() {
+			disconnected = append(disconnected, i)
+		} else {
+			connected = append(connected, i)
+		}
+	}
+	return append(connected, disconnected...)
+}
+
+// verifyPubKey checks that the signer of index i has the key of the primary,
+// the first time it signs.
+func (fc *FailoverSignerClient) verifyPubKey(i int) error {
+	fc.mtx.Lock()
+	verified := fc.verified[i]
+	fc.mtx.Unlock()
+	if verified {
+		return nil
+	}
+
+	pubKey := fc.signers[i].GetPubKey()
+	if pubKey == nil {
+		return errors.New("failed to get the public key")
+	}
+	if !pubKey.Equals(fc.pubKey) {
+		return fmt.Errorf("public key %v is not the one of the primary signer", pubKey)
+	}
+	fc.mtx.Lock()
+	fc.verified[i] = true
+	fc.mtx.Unlock()
+	return nil
+}
+
+// raiseWatermark records that the signer of index i is requested to sign the
+// height, round and step, unless another signer was requested to sign them or
+// a higher round and step at the height. It returns the previous watermark of
+// the height, if any.
+func (fc *FailoverSignerClient) raiseWatermark(height int64, round int, step int8, i int) (*signWatermark, error) {
+	fc.mtx.Lock()
+	defer fc.mtx.Unlock()
+
+	wm, ok := fc.watermarks[height]
+	above := !ok || round > wm.round || (round == wm.round && step > wm.step)
+	if !above && wm.signer != i {
+		return nil, ErrWatermark{height, wm.round, wm.step, wm.signer}
+	}
+	var prev *signWatermark
+	if ok {
+		prev = &wm
+	}
+	if above || (round == wm.round && step == wm.step) {
+		fc.watermarks[height] = signWatermark{round: round, step: step, signer: i}
+	}
+
+	// without SetImmutableHeight, forget the heights far below the last one
+	if height > fc.maxHeight {
+		fc.maxHeight = height
+	}
+	if len(fc.watermarks) > 2*types.MaxLenULB {
+		for h := range fc.watermarks {
+			if h < fc.maxHeight-types.MaxLenULB {
+				delete(fc.watermarks, h)
+			}
+		}
+	}
+	return prev, nil
+}
+
+// restoreWatermark restores the watermark prev of the height, raised by
+// raiseWatermark for the signer of index i to the round and step, if it wasn't
+// raised again since.
+func (fc *FailoverSignerClient) restoreWatermark(height int64, round int, step int8, i int, prev *signWatermark) {
+	fc.mtx.Lock()
+	defer fc.mtx.Unlock()
+
+	if fc.watermarks[height] != (signWatermark{round: round, step: step, signer: i}) {
+		return
+	}
+	if prev == nil {
+		delete(fc.watermarks, height)
+	} else {
+		fc.watermarks[height] = *prev
+	}
+}
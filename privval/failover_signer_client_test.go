@@ -0,0 +1,134 @@
+package privval
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hdac-io/tendermint/crypto/bls"
+	"github.com/hdac-io/tendermint/libs/log"
+	"github.com/hdac-io/tendermint/types"
+)
+
+// failoverSigner is a signer which may be disconnected, or fail after or
+// before sending the request, counting the requests it signed.
+type failoverSigner struct {
+	*types.MockPV
+	connected bool
+	err       error
+	signed    int
+}
+
+func (fs *failoverSigner) IsConnected() bool { return fs.connected }
+
+func (fs *failoverSigner) SignVote(chainID string, vote *types.Vote) error {
+	if fs.err != nil {
+		return fs.err
+	}
+	fs.signed++
+	return fs.MockPV.SignVote(chainID, vote)
+}
+
+func (fs *failoverSigner) SignProposal(chainID string, proposal *types.Proposal) error {
+	if fs.err != nil {
+		return fs.err
+	}
+	fs.signed++
+	return fs.MockPV.SignProposal(chainID, proposal)
+}
+
+func (fs *failoverSigner) SetImmutableHeight(height int64) error { return nil }
+
+func (fs *failoverSigner) GetParallelProgressablePV() types.ParallelProgressablePV { return fs }
+
+const (
+	voteTypePrevote   = byte(types.PrevoteType)
+	voteTypePrecommit = byte(types.PrecommitType)
+)
+
+func newFailoverSigners() (*failoverSigner, *failoverSigner) {
+	privKey := bls.GenPrivKey()
+	primary := &failoverSigner{MockPV: types.NewMockPVWithParams(privKey, false, false), connected: true}
+	standby := &failoverSigner{MockPV: types.NewMockPVWithParams(privKey, false, false), connected: true}
+	return primary, standby
+}
+
+func TestFailoverSignerClient(t *testing.T) {
+	primary, standby := newFailoverSigners()
+	fc, err := NewFailoverSignerClient([]types.PrivValidator{primary, standby}, log.TestingLogger())
+	require.NoError(t, err)
+	assert.Equal(t, primary.GetPubKey(), fc.GetPubKey())
+
+	block := types.BlockID{Hash: []byte{1, 2, 3}, PartsHeader: types.PartSetHeader{}}
+	addr := primary.GetPubKey().Address()
+	height := int64(10)
+
+	// the primary signs while it is up
+	require.NoError(t, fc.SignProposal("mychainid", newProposal(height, 0, block)))
+	require.NoError(t, fc.SignVote("mychainid", newVote(addr, 0, height, 0, voteTypePrevote, block)))
+	assert.Equal(t, 2, primary.signed)
+	assert.Equal(t, 0, standby.signed)
+
+	// the primary times out after the request was sent: the standby mustn't
+	// sign the same step, which the primary may have signed
+	primary.err = ErrReadTimeout
+	vote := newVote(addr, 0, height, 0, voteTypePrecommit, block)
+	err = fc.SignVote("mychainid", vote)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), ErrWatermark{height, 0, stepPrecommit, 0}.Error())
+	assert.Equal(t, 0, standby.signed)
+
+	// but signs the next round, and the other heights, once the primary
+	// dropped the connection which timed out
+	primary.connected = false
+	require.NoError(t, fc.SignVote("mychainid", newVote(addr, 0, height, 1, voteTypePrevote, block)))
+	require.NoError(t, fc.SignVote("mychainid", newVote(addr, 0, height+1, 0, voteTypePrevote, block)))
+	assert.Equal(t, 2, standby.signed)
+
+	// the primary is still disconnected: the standby signs first
+	primary.err = ErrConnectionTimeout
+	require.NoError(t, fc.SignVote("mychainid", newVote(addr, 0, height+1, 0, voteTypePrecommit, block)))
+	assert.Equal(t, 3, standby.signed)
+
+	// the primary is back and signs the next steps, but only the standby is
+	// requested to sign below its watermark, its own sign state deciding
+	primary.err = nil
+	primary.connected = true
+	require.NoError(t, fc.SignVote("mychainid", newVote(addr, 0, height+1, 0, voteTypePrevote, block)))
+	assert.Equal(t, 2, primary.signed)
+	assert.Equal(t, 4, standby.signed)
+	require.NoError(t, fc.SignVote("mychainid", newVote(addr, 0, height+1, 1, voteTypePrevote, block)))
+	assert.Equal(t, 3, primary.signed)
+
+	// a request which wasn't sent doesn't raise the watermark
+	primary.err = ErrConnectionTimeout
+	require.NoError(t, fc.SignVote("mychainid", newVote(addr, 0, height+2, 0, voteTypePrevote, block)))
+	assert.Equal(t, 5, standby.signed)
+	primary.err = nil
+	require.NoError(t, fc.SignVote("mychainid", newVote(addr, 0, height+2, 0, voteTypePrecommit, block)))
+	assert.Equal(t, 4, primary.signed)
+
+	// a signer refusing to sign doesn't fail over
+	primary.err = &RemoteSignerError{Code: 500, Description: "double sign"}
+	err = fc.SignVote("mychainid", newVote(addr, 0, height+3, 0, voteTypePrevote, block))
+	assert.Equal(t, primary.err, err)
+	assert.Equal(t, 5, standby.signed)
+
+	// the watermarks below the immutable height are forgotten
+	require.NoError(t, fc.SetImmutableHeight(height+2))
+	assert.Len(t, fc.watermarks, 2)
+}
+
+func TestFailoverSignerClientPubKey(t *testing.T) {
+	primary, _ := newFailoverSigners()
+	other := &failoverSigner{MockPV: types.NewMockPVWithParams(bls.GenPrivKey(), false, false), connected: true}
+	fc, err := NewFailoverSignerClient([]types.PrivValidator{primary, other}, log.TestingLogger())
+	require.NoError(t, err)
+
+	// a standby with another key never signs
+	primary.err = ErrConnectionTimeout
+	block := types.BlockID{Hash: []byte{1, 2, 3}, PartsHeader: types.PartSetHeader{}}
+	assert.Error(t, fc.SignVote("mychainid", newVote(primary.GetPubKey().Address(), 0, 1, 0, voteTypePrevote, block)))
+	assert.Equal(t, 0, other.signed)
+}
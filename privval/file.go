@@ -63,6 +63,15 @@ func (pvKey FilePVKey) Save() {
 
 }
 
+// ProofOfPossession returns the proof of possession of the BLS private key,
+// to register the validator with, or nil if it isn't a BLS key.
+func (pvKey FilePVKey) ProofOfPossession() []byte {
+	if privKey, ok := pvKey.PrivKey.(bls.PrivKeyBls); ok {
+		return privKey.ProofOfPossession()
+	}
+	return nil
+}
+
 //-------------------------------------------------------------------------------
 
 // FilePVLastSignState stores the mutable part of PrivValidator.
@@ -274,6 +283,42 @@ func (pv *FilePV) Reset() {
 	pv.Save()
 }
 
+// RotateKey replaces the key of the FilePV with privKey. The last height,
+// round and step are kept, but not the last signature: it was made with the
+// previous key, so the new key won't sign again at the last HRS.
+// It does not call Save().
+func (pv *FilePV) RotateKey(privKey crypto.PrivKey) {
+	pv.Key = FilePVKey{
+		Address:  privKey.PubKey().Address(),
+		PubKey:   privKey.PubKey(),
+		PrivKey:  privKey,
+		filePath: pv.Key.filePath,
+	}
+	pv.LastSignState.Signature = nil
+	pv.LastSignState.SignBytes = nil
+}
+
+// ToFridayFilePV converts the FilePV to a FridayFilePV with the same key and
+// file paths, for a node moving to the friday consensus. The heights below the
+// last height become immutable, and the last HRS is kept as the sign state of
+// the last height. It does not call Save().
+func (pv *FilePV) ToFridayFilePV() *FridayFilePV {
+	fpv := &FridayFilePV{
+		Key: pv.Key,
+		SignState: FridayFilePVSignState{
+			filePath: pv.LastSignState.filePath,
+		},
+	}
+	lss := pv.LastSignState
+	if lss.Height > 0 {
+		fpv.SignState.ImmutableHeight = lss.Height - 1
+		if lss.Step != stepNone {
+			fpv.SignState.storeSignState(lss.Height, lss.Round, lss.Step, lss.SignBytes, lss.Signature)
+		}
+	}
+	return fpv
+}
+
 // String returns a string representation of the FilePV.
 func (pv *FilePV) String() string {
 	return fmt.Sprintf("PrivValidator{%v LH:%v, LR:%v, LS:%v}", pv.GetAddress(), pv.LastSignState.Height, pv.LastSignState.Round, pv.LastSignState.Step)
@@ -2,6 +2,7 @@ package privval
 
 import (
 	"encoding/base64"
+	"encoding/json"
 	"fmt"
 	"io/ioutil"
 	"os"
@@ -10,7 +11,9 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"github.com/hdac-io/tendermint/crypto/bls"
 	"github.com/hdac-io/tendermint/crypto/ed25519"
+	"github.com/hdac-io/tendermint/crypto/xsalsa20symmetric"
 	"github.com/hdac-io/tendermint/types"
 	tmtime "github.com/hdac-io/tendermint/types/time"
 )
@@ -300,6 +303,118 @@ func TestDifferByTimestamp(t *testing.T) {
 	}
 }
 
+func TestRotateKey(t *testing.T) {
+	tempKeyFile, err := ioutil.TempFile("", "priv_validator_key_")
+	require.Nil(t, err)
+	tempStateFile, err := ioutil.TempFile("", "priv_validator_state_")
+	require.Nil(t, err)
+
+	block1 := types.BlockID{Hash: []byte{1, 2, 3}, PartsHeader: types.PartSetHeader{}}
+	height, round := int64(10), 1
+	voteType := byte(types.PrevoteType)
+
+	// FilePV: the new key can't sign again at the last HRS
+	{
+		privVal := GenFilePV(tempKeyFile.Name(), tempStateFile.Name())
+		err := privVal.SignVote("mychainid", newVote(privVal.Key.Address, 0, height, round, voteType, block1))
+		require.NoError(t, err)
+
+		oldAddr := privVal.GetAddress()
+		privVal.RotateKey(bls.GenPrivKey())
+		assert.NotEqual(t, oldAddr, privVal.GetAddress())
+		assert.Equal(t, height, privVal.LastSignState.Height)
+
+		err = privVal.SignVote("mychainid", newVote(privVal.Key.Address, 0, height, round, voteType, block1))
+		assert.Error(t, err, "expected error on signing at the last HRS of the previous key")
+		err = privVal.SignVote("mychainid", newVote(privVal.Key.Address, 0, height+1, round, voteType, block1))
+		assert.NoError(t, err)
+	}
+
+	// FridayFilePV: the new key can't sign at the heights of the previous one
+	{
+		privVal := GenFridayFilePV(tempKeyFile.Name(), tempStateFile.Name())
+		require.NoError(t, privVal.SetImmutableHeight(height-5))
+		for h := height; h < height+3; h++ {
+			err := privVal.SignVote("mychainid", newVote(privVal.Key.Address, 0, h, round, voteType, block1))
+			require.NoError(t, err)
+		}
+
+		privVal.RotateKey(bls.GenPrivKey())
+		privVal.Save()
+		privVal = LoadFridayFilePV(tempKeyFile.Name(), tempStateFile.Name())
+		assert.Equal(t, height+2, privVal.SignState.ImmutableHeight)
+
+		for h := height; h < height+3; h++ {
+			err := privVal.SignVote("mychainid", newVote(privVal.Key.Address, 0, h, round, voteType, block1))
+			assert.Error(t, err, "expected error on signing at a height of the previous key")
+		}
+		err := privVal.SignVote("mychainid", newVote(privVal.Key.Address, 0, height+3, round, voteType, block1))
+		assert.NoError(t, err)
+	}
+}
+
+func TestMigrateFilePV(t *testing.T) {
+	tempKeyFile, err := ioutil.TempFile("", "priv_validator_key_")
+	require.Nil(t, err)
+	tempStateFile, err := ioutil.TempFile("", "priv_validator_state_")
+	require.Nil(t, err)
+
+	block1 := types.BlockID{Hash: []byte{1, 2, 3}, PartsHeader: types.PartSetHeader{}}
+	block2 := types.BlockID{Hash: []byte{3, 2, 1}, PartsHeader: types.PartSetHeader{}}
+	height, round := int64(10), 1
+	voteType := byte(types.PrevoteType)
+
+	// FilePV to FridayFilePV: the last HRS and the lower heights are kept
+	{
+		privVal := GenFilePV(tempKeyFile.Name(), tempStateFile.Name())
+		vote := newVote(privVal.Key.Address, 0, height, round, voteType, block1)
+		require.NoError(t, privVal.SignVote("mychainid", vote))
+
+		fpv := privVal.ToFridayFilePV()
+		assert.Equal(t, privVal.GetPubKey(), fpv.GetPubKey())
+		fpv.Save()
+		fpv = LoadFridayFilePV(tempKeyFile.Name(), tempStateFile.Name())
+		assert.Equal(t, height-1, fpv.SignState.ImmutableHeight)
+
+		// the last signature is reused, but can't be replaced
+		resigned := newVote(privVal.Key.Address, 0, height, round, voteType, block1)
+		require.NoError(t, fpv.SignVote("mychainid", resigned))
+		assert.Equal(t, vote.Signature, resigned.Signature)
+		err := fpv.SignVote("mychainid", newVote(privVal.Key.Address, 0, height, round, voteType, block2))
+		assert.Error(t, err, "expected error on signing conflicting vote")
+		err = fpv.SignVote("mychainid", newVote(privVal.Key.Address, 0, height-1, round, voteType, block1))
+		assert.Error(t, err, "expected error on signing below the last height")
+		err = fpv.SignVote("mychainid", newVote(privVal.Key.Address, 0, height+1, round, voteType, block1))
+		assert.NoError(t, err)
+	}
+
+	// FridayFilePV to FilePV: the highest signed HRS is kept
+	{
+		privVal := GenFridayFilePV(tempKeyFile.Name(), tempStateFile.Name())
+		require.NoError(t, privVal.SetImmutableHeight(height-5))
+		assert.Equal(t, height-4, privVal.ToFilePV().LastSignState.Height)
+
+		for h := height; h < height+3; h++ {
+			err := privVal.SignVote("mychainid", newVote(privVal.Key.Address, 0, h, round, voteType, block1))
+			require.NoError(t, err)
+		}
+
+		pv := privVal.ToFilePV()
+		pv.Save()
+		pv = LoadFilePV(tempKeyFile.Name(), tempStateFile.Name())
+		assert.Equal(t, height+2, pv.LastSignState.Height)
+		assert.Equal(t, round, pv.LastSignState.Round)
+		assert.Equal(t, stepPrevote, pv.LastSignState.Step)
+
+		err := pv.SignVote("mychainid", newVote(privVal.Key.Address, 0, height+2, round, voteType, block2))
+		assert.Error(t, err, "expected error on signing conflicting vote")
+		err = pv.SignVote("mychainid", newVote(privVal.Key.Address, 0, height+1, round, voteType, block1))
+		assert.Error(t, err, "expected error on signing below the highest signed height")
+		err = pv.SignVote("mychainid", newVote(privVal.Key.Address, 0, height+3, round, voteType, block1))
+		assert.NoError(t, err)
+	}
+}
+
 func newVote(addr types.Address, idx int, height int64, round int, typ byte, blockID types.BlockID) *types.Vote {
 	return &types.Vote{
 		ValidatorAddress: addr,
@@ -320,3 +435,167 @@ func newProposal(height int64, round int, blockID types.BlockID) *types.Proposal
 		Timestamp: tmtime.Now(),
 	}
 }
+
+func TestLoadFridayFilePVSignState(t *testing.T) {
+	tempKeyFile, err := ioutil.TempFile("", "priv_validator_key_")
+	require.Nil(t, err)
+	tempStateFile, err := ioutil.TempFile("", "priv_validator_state_")
+	require.Nil(t, err)
+
+	block := types.BlockID{Hash: []byte{1, 2, 3}, PartsHeader: types.PartSetHeader{}}
+	privVal := GenFridayFilePV(tempKeyFile.Name(), tempStateFile.Name())
+	require.NoError(t, privVal.SetImmutableHeight(4))
+	for _, h := range []int64{7, 5, 6} {
+		err := privVal.SignVote("mychainid", newVote(privVal.Key.Address, 0, h, 1, byte(types.PrecommitType), block))
+		require.NoError(t, err)
+	}
+	privVal.Save()
+
+	ss, err := LoadFridayFilePVSignState(tempStateFile.Name(), nil, nil)
+	require.NoError(t, err)
+	assert.Equal(t, int64(4), ss.ImmutableHeight)
+	signStates := ss.SignStates()
+	require.Len(t, signStates, 3)
+	for i, s := range signStates {
+		assert.Equal(t, int64(5+i), s.Height)
+		assert.Equal(t, 1, s.Round)
+		assert.Equal(t, "precommit", s.StepString())
+		assert.NotEmpty(t, s.Signature)
+	}
+
+	_, err = LoadFridayFilePVSignState(tempStateFile.Name()+"_missing", nil, nil)
+	assert.Error(t, err)
+}
+
+func TestFridayFilePVSignStateVersions(t *testing.T) {
+	tempStateFile, err := ioutil.TempFile("", "priv_validator_state_")
+	require.Nil(t, err)
+	defer os.Remove(tempStateFile.Name())
+
+	// the unversioned amino JSON of the first releases
+	v0 := `{
+  "type": "tendermint/fridayFilePVState",
+  "value": {
+    "height_sign_states": {
+      "6": {"round": 0, "step": 1, "signature": "AwQ=", "signbytes": "0102"},
+      "5": {"round": 1, "step": 2, "signature": "AwQ=", "signbytes": "0102"}
+    },
+    "immutable_height": 4
+  }
+}`
+	require.NoError(t, ioutil.WriteFile(tempStateFile.Name(), []byte(v0), 0600))
+	ss, err := LoadFridayFilePVSignState(tempStateFile.Name(), nil, nil)
+	require.NoError(t, err)
+	assert.Equal(t, 0, ss.Version())
+	assert.Equal(t, int64(4), ss.ImmutableHeight)
+	require.Len(t, ss.SignStates(), 2)
+
+	// migrated to the current version on save, always saved the same
+	ss.Save()
+	assert.Equal(t, FridaySignStateVersion, ss.Version())
+	saved, err := ioutil.ReadFile(tempStateFile.Name())
+	require.NoError(t, err)
+	assert.Equal(t, `{
+  "version": 1,
+  "immutable_height": "4",
+  "sign_states": [
+    {
+      "height": "5",
+      "round": 1,
+      "step": 2,
+      "signature": "AwQ=",
+      "signbytes": "0102"
+    },
+    {
+      "height": "6",
+      "round": 0,
+      "step": 1,
+      "signature": "AwQ=",
+      "signbytes": "0102"
+    }
+  ]
+}`, string(saved))
+
+	loaded, err := LoadFridayFilePVSignState(tempStateFile.Name(), nil, nil)
+	require.NoError(t, err)
+	assert.Equal(t, 1, loaded.Version())
+	assert.Equal(t, ss.ImmutableHeight, loaded.ImmutableHeight)
+	assert.Equal(t, ss.SignStates(), loaded.SignStates())
+	loaded.Save()
+	resaved, err := ioutil.ReadFile(tempStateFile.Name())
+	require.NoError(t, err)
+	assert.Equal(t, saved, resaved)
+
+	// newer versions, and invalid sign states, are rejected
+	for _, invalid := range []string{
+		`{"version": 2, "immutable_height": "4", "sign_states": []}`,
+		`{"version": 1, "immutable_height": "4", "sign_states": [{"height": "5"}, {"height": "5"}]}`,
+		`{"type": "tendermint/other", "value": {}}`,
+	} {
+		require.NoError(t, ioutil.WriteFile(tempStateFile.Name(), []byte(invalid), 0600))
+		_, err = LoadFridayFilePVSignState(tempStateFile.Name(), nil, nil)
+		assert.Error(t, err, invalid)
+	}
+}
+
+func TestFridayFilePVStateEncryption(t *testing.T) {
+	tempKeyFile, err := ioutil.TempFile("", "priv_validator_key_")
+	require.Nil(t, err)
+	defer os.Remove(tempKeyFile.Name())
+	tempStateFile, err := ioutil.TempFile("", "priv_validator_state_")
+	require.Nil(t, err)
+	defer os.Remove(tempStateFile.Name())
+	sym := xsalsa20symmetric.Symmetric{}
+	secret := sym.Keygen()
+
+	privVal := GenFridayFilePV(tempKeyFile.Name(), tempStateFile.Name())
+	privVal.SetStateEncryption(sym, secret)
+	privVal.Save()
+	blockID := types.BlockID{Hash: []byte{1, 2, 3}, PartsHeader: types.PartSetHeader{}}
+	vote := newVote(privVal.Key.Address, 0, 5, 1, byte(types.PrevoteType), blockID)
+	require.NoError(t, privVal.SignVote("mychainid", vote))
+
+	bz, err := ioutil.ReadFile(tempStateFile.Name())
+	require.NoError(t, err)
+	assert.False(t, json.Valid(bz), "the sign state is written in plaintext")
+
+	// reloaded with the key, it still refuses to sign a conflicting vote
+	privVal = LoadEncryptedFridayFilePV(tempKeyFile.Name(), tempStateFile.Name(), sym, secret)
+	conflicting := newVote(privVal.Key.Address, 0, 5, 1, byte(types.PrevoteType), types.BlockID{})
+	assert.Error(t, privVal.SignVote("mychainid", conflicting))
+	assert.NoError(t, privVal.SignVote("mychainid", vote))
+
+	// with another key, or none
+	_, err = LoadFridayFilePVSignState(tempStateFile.Name(), sym, sym.Keygen())
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "Error decrypting PrivValidator state")
+	}
+	_, err = LoadFridayFilePVSignState(tempStateFile.Name(), nil, nil)
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "is encrypted but no secret was given")
+	}
+}
+
+func TestFridayFilePVStateEncryptionTurnedOn(t *testing.T) {
+	tempKeyFile, err := ioutil.TempFile("", "priv_validator_key_")
+	require.Nil(t, err)
+	defer os.Remove(tempKeyFile.Name())
+	tempStateFile, err := ioutil.TempFile("", "priv_validator_state_")
+	require.Nil(t, err)
+	defer os.Remove(tempStateFile.Name())
+	sym := xsalsa20symmetric.Symmetric{}
+	secret := sym.Keygen()
+
+	// an unencrypted state is loaded, and saved encrypted from then on
+	privVal := GenFridayFilePV(tempKeyFile.Name(), tempStateFile.Name())
+	privVal.Save()
+	privVal = LoadEncryptedFridayFilePV(tempKeyFile.Name(), tempStateFile.Name(), sym, secret)
+	blockID := types.BlockID{Hash: []byte{1, 2, 3}, PartsHeader: types.PartSetHeader{}}
+	require.NoError(t, privVal.SignVote("mychainid", newVote(privVal.Key.Address, 0, 5, 1, byte(types.PrevoteType), blockID)))
+
+	bz, err := ioutil.ReadFile(tempStateFile.Name())
+	require.NoError(t, err)
+	assert.False(t, json.Valid(bz), "the sign state is written in plaintext")
+	_, err = LoadFridayFilePVSignState(tempStateFile.Name(), sym, secret)
+	assert.NoError(t, err)
+}
@@ -6,18 +6,16 @@ import (
 	"errors"
 	"fmt"
 	"io/ioutil"
+	"sort"
 	"sync"
 
 	"github.com/hdac-io/tendermint/crypto"
 	"github.com/hdac-io/tendermint/crypto/bls"
 	cmn "github.com/hdac-io/tendermint/libs/common"
 	"github.com/hdac-io/tendermint/types"
-	amino "github.com/tendermint/go-amino"
 )
 
-func RegisterFridaySignState(cdc *amino.Codec) {
-	cdc.RegisterConcrete(&FridayFilePVSignState{}, "tendermint/fridayFilePVState", nil)
-}
+const fridaySignStateAminoName = "tendermint/fridayFilePVState"
 
 //-------------------------------------------------------------------------------
 // FridayFilePVSignState stores the mutable part of PrivValidator.
@@ -26,6 +24,12 @@ type FridayFilePVSignState struct {
 	ImmutableHeight    int64    `json:"immutable_height"`
 
 	filePath string
+	// the schema version of the file it was read from
+	version int
+
+	// optional encryption of the file at rest
+	sym    crypto.Symmetric
+	secret []byte
 }
 
 // SignState stores sign info state per height
@@ -36,6 +40,23 @@ type SignState struct {
 	SignBytes cmn.HexBytes `json:"signbytes,omitempty"`
 }
 
+// StepString returns the name of the step of the SignState: "propose",
+// "prevote" or "precommit", or "none" if nothing was signed.
+func (s SignState) StepString() string {
+	switch s.Step {
+	case stepPropose:
+		return "propose"
+	case stepPrevote:
+		return "prevote"
+	case stepPrecommit:
+		return "precommit"
+	case stepNone:
+		return "none"
+	default:
+		return fmt.Sprintf("unknown(%d)", s.Step)
+	}
+}
+
 // CheckHRS checks the given height, round, step (HRS) against that of the
 // FilePVLastSignState. It returns an error if the arguments constitute a regression,
 // or if they match but the SignBytes are empty.
@@ -83,57 +104,150 @@ func (ss *FridayFilePVSignState) storeSignState(height int64, round int, step in
 		})
 }
 
-//marshalSpecializedState is for marshaling the sync.Map field.
-type marshalSpecializedState struct {
+// FridaySignStateVersion is the version of the on-disk schema of the
+// FridayFilePVSignState. Version 0 is the unversioned amino JSON of the first
+// releases, read and migrated on the next save.
+//
+// Version 1 is the canonical JSON
+//
+//	{
+//	  "version": 1,
+//	  "immutable_height": "<int64>",
+//	  "sign_states": [
+//	    {"height": "<int64>", "round": <int>, "step": <int8>,
+//	     "signature": "<base64>", "signbytes": "<hex>"},
+//	    ...
+//	  ]
+//	}
+//
+// with the sign states sorted by height, so a same sign state is always saved
+// the same. A change of the schema must increase the version and migrate the
+// previous versions in UnmarshalJSON: a sign state of a version above
+// FridaySignStateVersion is rejected rather than read partially.
+const FridaySignStateVersion = 1
+
+// fridaySignStateV1 is the version 1 of the schema.
+type fridaySignStateV1 struct {
+	Version         int                 `json:"version"`
+	ImmutableHeight int64               `json:"immutable_height,string"`
+	SignStates      []heightSignStateV1 `json:"sign_states"`
+}
+
+type heightSignStateV1 struct {
+	Height    int64        `json:"height,string"`
+	Round     int          `json:"round"`
+	Step      int8         `json:"step"`
+	Signature []byte       `json:"signature,omitempty"`
+	SignBytes cmn.HexBytes `json:"signbytes,omitempty"`
+}
+
+// fridaySignStateV0 is the version 0 of the schema, wrapped in the amino JSON
+// {"type": "tendermint/fridayFilePVState", "value": ...}.
+type fridaySignStateV0 struct {
 	HeightSignStateMap map[int64]SignState `json:"height_sign_states"`
 	ImmutableHeight    int64               `json:"immutable_height"`
 }
 
-//MarshalJSON override purpose is for using builtin json marshaler
+// MarshalJSON encodes the sign state in the schema of FridaySignStateVersion.
 func (ss *FridayFilePVSignState) MarshalJSON() ([]byte, error) {
-	tmpMap := make(map[int64]SignState)
-	ss.HeightSignStateMap.Range(func(k, v interface{}) bool {
-		tmpMap[k.(int64)] = v.(SignState)
-		return true
-	})
-
-	//using builtin json marshaler, because Amino does not support the map type.
-	encoded, err := json.Marshal(marshalSpecializedState{
-		HeightSignStateMap: tmpMap,
-		ImmutableHeight:    ss.ImmutableHeight,
-	})
-	return encoded, err
+	state := fridaySignStateV1{
+		Version:         FridaySignStateVersion,
+		ImmutableHeight: ss.ImmutableHeight,
+		SignStates:      []heightSignStateV1{},
+	}
+	for _, s := range ss.SignStates() {
+		state.SignStates = append(state.SignStates, heightSignStateV1{
+			Height:    s.Height,
+			Round:     s.Round,
+			Step:      s.Step,
+			Signature: s.Signature,
+			SignBytes: s.SignBytes,
+		})
+	}
+	return json.MarshalIndent(state, "", "  ")
 }
 
-//UnmarshalJSON override purpose is for using builtin json marshaler
+// UnmarshalJSON decodes a sign state of any version up to
+// FridaySignStateVersion.
 func (ss *FridayFilePVSignState) UnmarshalJSON(marshaled []byte) error {
-	var tmpState marshalSpecializedState
-	if err := json.Unmarshal(marshaled, &tmpState); err != nil {
+	var probe struct {
+		Version *int            `json:"version"`
+		Type    string          `json:"type"`
+		Value   json.RawMessage `json:"value"`
+	}
+	if err := json.Unmarshal(marshaled, &probe); err != nil {
 		return err
 	}
 
-	for height, state := range tmpState.HeightSignStateMap {
-		ss.HeightSignStateMap.Store(height, state)
+	switch {
+	case probe.Version == nil:
+		// version 0, with or without its amino wrapper
+		if probe.Type != "" {
+			if probe.Type != fridaySignStateAminoName {
+				return fmt.Errorf("unknown sign state type %q", probe.Type)
+			}
+			marshaled = probe.Value
+		}
+		var state fridaySignStateV0
+		if err := json.Unmarshal(marshaled, &state); err != nil {
+			return err
+		}
+		ss.ImmutableHeight = state.ImmutableHeight
+		for height, signState := range state.HeightSignStateMap {
+			ss.HeightSignStateMap.Store(height, signState)
+		}
+		ss.version = 0
+
+	case *probe.Version == 1:
+		var state fridaySignStateV1
+		if err := json.Unmarshal(marshaled, &state); err != nil {
+			return err
+		}
+		for _, s := range state.SignStates {
+			if _, loaded := ss.HeightSignStateMap.LoadOrStore(s.Height, SignState{
+				Round:     s.Round,
+				Step:      s.Step,
+				Signature: s.Signature,
+				SignBytes: s.SignBytes,
+			}); loaded {
+				return fmt.Errorf("duplicate sign state of height %d", s.Height)
+			}
+		}
+		ss.ImmutableHeight = state.ImmutableHeight
+		ss.version = 1
+
+	default:
+		return fmt.Errorf("sign state version %d is newer than the version %d supported by this binary",
+			*probe.Version, FridaySignStateVersion)
 	}
-	ss.ImmutableHeight = tmpState.ImmutableHeight
 	return nil
 }
 
-// Save persists the FridayFilePVLastSignState to its filePath.
-// NOTE: change amino to builtin json marshaler, amino cannot support to map struct
+// Version returns the schema version the sign state was read in, which is
+// FridaySignStateVersion once it is saved.
+func (ss *FridayFilePVSignState) Version() int {
+	return ss.version
+}
+
+// Save persists the FridayFilePVLastSignState to its filePath, in the schema
+// of FridaySignStateVersion.
 func (ss *FridayFilePVSignState) Save() {
 	outFile := ss.filePath
 	if outFile == "" {
 		panic("cannot save FridayFilePVLastSignState: filePath not set")
 	}
-	jsonBytes, err := cdc.MarshalJSONIndent(ss, "", "  ")
+	jsonBytes, err := ss.MarshalJSON()
 	if err != nil {
 		panic(err)
 	}
+	if ss.sym != nil {
+		jsonBytes = ss.sym.Encrypt(jsonBytes, ss.secret)
+	}
 	err = cmn.WriteFileAtomic(outFile, jsonBytes, 0600)
 	if err != nil {
 		panic(err)
 	}
+	ss.version = FridaySignStateVersion
 }
 
 // Reset resets all Sign State
@@ -163,6 +277,42 @@ func (ss *FridayFilePVSignState) setImmutableHeight(height int64) error {
 	return nil
 }
 
+// migrate makes the sign state safe to use with a new key: the heights signed
+// with the previous key can't be signed again, so the immutable height is
+// raised to the highest of them and their sign states, whose signatures are
+// of the previous key, are dropped.
+func (ss *FridayFilePVSignState) migrate() {
+	immutableHeight := ss.ImmutableHeight
+	ss.HeightSignStateMap.Range(func(key interface{}, value interface{}) bool {
+		if signedHeight := key.(int64); signedHeight > immutableHeight {
+			immutableHeight = signedHeight
+		}
+		ss.HeightSignStateMap.Delete(key)
+		return true
+	})
+	ss.ImmutableHeight = immutableHeight
+}
+
+// HeightSignState is the SignState of a height.
+type HeightSignState struct {
+	Height int64
+	SignState
+}
+
+// SignStates returns the sign states of the FridayFilePVSignState, sorted by
+// height.
+func (ss *FridayFilePVSignState) SignStates() []HeightSignState {
+	var signStates []HeightSignState
+	ss.HeightSignStateMap.Range(func(key interface{}, value interface{}) bool {
+		signStates = append(signStates, HeightSignState{Height: key.(int64), SignState: value.(SignState)})
+		return true
+	})
+	sort.Slice(signStates, func(i, j int) bool {
+		return signStates[i].Height < signStates[j].Height
+	})
+	return signStates
+}
+
 // String returns a string representation of the FridayFilePVLastSignState.
 func (ss *FridayFilePVSignState) String() string {
 	var result string
@@ -214,17 +364,24 @@ func GenFridayFilePV(keyFilePath, stateFilePath string) *FridayFilePV {
 // signing prevention by persisting data to the stateFilePath.  If either file path
 // does not exist, the program will exit.
 func LoadFridayFilePV(keyFilePath, stateFilePath string) *FridayFilePV {
-	return loadFridayFilePV(keyFilePath, stateFilePath, true)
+	return loadFridayFilePV(keyFilePath, stateFilePath, true, nil, nil)
 }
 
 // LoadFilePVEmptyState loads a FilePV from the given keyFilePath, with an empty SignState.
 // If the keyFilePath does not exist, the program will exit.
 func LoadFridayFilePVEmptyState(keyFilePath, stateFilePath string) *FridayFilePV {
-	return loadFridayFilePV(keyFilePath, stateFilePath, false)
+	return loadFridayFilePV(keyFilePath, stateFilePath, false, nil, nil)
+}
+
+// LoadEncryptedFridayFilePV is like LoadFridayFilePV, but the state file is
+// decrypted with sym and secret, and saved encrypted from then on.
+// An unencrypted state file is still accepted, so the encryption can be turned on.
+func LoadEncryptedFridayFilePV(keyFilePath, stateFilePath string, sym crypto.Symmetric, secret []byte) *FridayFilePV {
+	return loadFridayFilePV(keyFilePath, stateFilePath, true, sym, secret)
 }
 
 // If loadState is true, we load from the stateFilePath. Otherwise, we use an empty SignState.
-func loadFridayFilePV(keyFilePath, stateFilePath string, loadState bool) *FridayFilePV {
+func loadFridayFilePV(keyFilePath, stateFilePath string, loadState bool, sym crypto.Symmetric, secret []byte) *FridayFilePV {
 	keyJSONBytes, err := ioutil.ReadFile(keyFilePath)
 	if err != nil {
 		cmn.Exit(err.Error())
@@ -242,17 +399,14 @@ func loadFridayFilePV(keyFilePath, stateFilePath string, loadState bool) *Friday
 
 	pvState := FridayFilePVSignState{}
 	if loadState {
-		stateJSONBytes, err := ioutil.ReadFile(stateFilePath)
-		if err != nil {
+		if err := readFridayFilePVSignState(stateFilePath, sym, secret, &pvState); err != nil {
 			cmn.Exit(err.Error())
 		}
-		err = cdc.UnmarshalJSON(stateJSONBytes, &pvState)
-		if err != nil {
-			cmn.Exit(fmt.Sprintf("Error reading PrivValidator state from %v: %v\n", stateFilePath, err))
-		}
 	}
 
 	pvState.filePath = stateFilePath
+	pvState.sym = sym
+	pvState.secret = secret
 
 	return &FridayFilePV{
 		Key:       pvKey,
@@ -260,6 +414,41 @@ func loadFridayFilePV(keyFilePath, stateFilePath string, loadState bool) *Friday
 	}
 }
 
+// LoadFridayFilePVSignState loads the FridayFilePVSignState of stateFilePath
+// alone, decrypting it with sym and secret if it is encrypted, e.g. to inspect
+// it without the private key.
+func LoadFridayFilePVSignState(stateFilePath string, sym crypto.Symmetric, secret []byte) (*FridayFilePVSignState, error) {
+	ss := &FridayFilePVSignState{}
+	if err := readFridayFilePVSignState(stateFilePath, sym, secret, ss); err != nil {
+		return nil, err
+	}
+	ss.filePath = stateFilePath
+	ss.sym = sym
+	ss.secret = secret
+	return ss, nil
+}
+
+func readFridayFilePVSignState(stateFilePath string, sym crypto.Symmetric, secret []byte, ss *FridayFilePVSignState) error {
+	stateJSONBytes, err := ioutil.ReadFile(stateFilePath)
+	if err != nil {
+		return err
+	}
+	if !json.Valid(stateJSONBytes) {
+		if sym == nil {
+			return fmt.Errorf("PrivValidator state %v is encrypted but no secret was given", stateFilePath)
+		}
+		stateJSONBytes, err = sym.Decrypt(stateJSONBytes, secret)
+		if err != nil {
+			return fmt.Errorf("Error decrypting PrivValidator state from %v: %v", stateFilePath, err)
+		}
+	}
+	err = ss.UnmarshalJSON(stateJSONBytes)
+	if err != nil {
+		return fmt.Errorf("Error reading PrivValidator state from %v: %v", stateFilePath, err)
+	}
+	return nil
+}
+
 // LoadOrGenFridayFilePV loads a FilePV from the given filePaths
 // or else generates a new one and saves it to the filePaths.
 func LoadOrGenFridayFilePV(keyFilePath, stateFilePath string) *FridayFilePV {
@@ -273,6 +462,26 @@ func LoadOrGenFridayFilePV(keyFilePath, stateFilePath string) *FridayFilePV {
 	return pv
 }
 
+// LoadOrGenEncryptedFridayFilePV is like LoadOrGenFridayFilePV, but the state
+// file is encrypted with sym and secret. See LoadEncryptedFridayFilePV.
+func LoadOrGenEncryptedFridayFilePV(keyFilePath, stateFilePath string, sym crypto.Symmetric, secret []byte) *FridayFilePV {
+	var pv *FridayFilePV
+	if cmn.FileExists(keyFilePath) {
+		pv = LoadEncryptedFridayFilePV(keyFilePath, stateFilePath, sym, secret)
+	} else {
+		pv = GenFridayFilePV(keyFilePath, stateFilePath)
+		pv.SetStateEncryption(sym, secret)
+		pv.Save()
+	}
+	return pv
+}
+
+// SetStateEncryption makes the next saves of the sign state encrypted with sym and secret.
+func (pv *FridayFilePV) SetStateEncryption(sym crypto.Symmetric, secret []byte) {
+	pv.SignState.sym = sym
+	pv.SignState.secret = secret
+}
+
 // GetAddress returns the address of the validator.
 // Implements PrivValidator.
 func (pv *FridayFilePV) GetAddress() types.Address {
@@ -327,6 +536,95 @@ func (pv *FridayFilePV) SetImmutableHeight(height int64) error {
 	return pv.SignState.setImmutableHeight(height)
 }
 
+// RotateKey replaces the key of the FridayFilePV with privKey, and migrates
+// the sign state so the new key never signs at or below a height signed with
+// the previous one. It does not call Save().
+func (pv *FridayFilePV) RotateKey(privKey crypto.PrivKey) {
+	pv.Key = FilePVKey{
+		Address:  privKey.PubKey().Address(),
+		PubKey:   privKey.PubKey(),
+		PrivKey:  privKey,
+		filePath: pv.Key.filePath,
+	}
+	pv.SignState.migrate()
+}
+
+// SplitKey splits the BLS key of the FridayFilePV into one share per key
+// file path, any threshold of which sign with it through a
+// ThresholdSignerClient. It returns the FridayFilePVs of the shares, the i-th
+// of which holds the share of index i+1 and is saved at the i-th file paths.
+// Their sign states never sign at or below a height signed with the key, as
+// after RotateKey, and are not encrypted. It does not call Save().
+func (pv *FridayFilePV) SplitKey(threshold int, keyFilePaths, stateFilePaths []string) ([]*FridayFilePV, error) {
+	privKey, ok := pv.Key.PrivKey.(bls.PrivKeyBls)
+	if !ok {
+		return nil, fmt.Errorf("can't split a %T private key", pv.Key.PrivKey)
+	}
+	if len(keyFilePaths) != len(stateFilePaths) {
+		return nil, fmt.Errorf("%d key files for %d state files", len(keyFilePaths), len(stateFilePaths))
+	}
+	shares, err := bls.SplitPrivKey(privKey, threshold, len(keyFilePaths))
+	if err != nil {
+		return nil, err
+	}
+
+	immutableHeight := pv.SignState.ImmutableHeight
+	pv.SignState.HeightSignStateMap.Range(func(key interface{}, value interface{}) bool {
+		if signedHeight := key.(int64); signedHeight > immutableHeight {
+			immutableHeight = signedHeight
+		}
+		return true
+	})
+
+	sharePVs := make([]*FridayFilePV, len(shares))
+	for i, share := range shares {
+		sharePVs[i] = &FridayFilePV{
+			Key: FilePVKey{
+				Address:  share.PubKey().Address(),
+				PubKey:   share.PubKey(),
+				PrivKey:  share,
+				filePath: keyFilePaths[i],
+			},
+			SignState: FridayFilePVSignState{
+				ImmutableHeight: immutableHeight,
+				filePath:        stateFilePaths[i],
+			},
+		}
+	}
+	return sharePVs, nil
+}
+
+// ToFilePV converts the FridayFilePV to a FilePV with the same key and file
+// paths, for a node going back to the tendermint consensus. The FilePV can't
+// sign below the highest signed height, as it only keeps the last HRS, nor at
+// or below the immutable height. It does not call Save().
+func (pv *FridayFilePV) ToFilePV() *FilePV {
+	lastSignState := FilePVLastSignState{
+		Step:     stepNone,
+		filePath: pv.SignState.filePath,
+	}
+	if pv.SignState.ImmutableHeight > 0 {
+		// nothing signed yet at the height after the immutable height
+		lastSignState.Height = pv.SignState.ImmutableHeight + 1
+	}
+	pv.SignState.HeightSignStateMap.Range(func(key interface{}, value interface{}) bool {
+		if signedHeight := key.(int64); signedHeight >= lastSignState.Height {
+			signState := value.(SignState)
+			lastSignState.Height = signedHeight
+			lastSignState.Round = signState.Round
+			lastSignState.Step = signState.Step
+			lastSignState.Signature = signState.Signature
+			lastSignState.SignBytes = signState.SignBytes
+		}
+		return true
+	})
+
+	return &FilePV{
+		Key:           pv.Key,
+		LastSignState: lastSignState,
+	}
+}
+
 // String returns a string representation of the FridayFilePV.
 func (pv *FridayFilePV) String() string {
 	return fmt.Sprintf("PrivValidator{%v SignState:%s}", pv.GetAddress(), pv.SignState.String())
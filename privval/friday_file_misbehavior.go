@@ -0,0 +1,28 @@
+// +build misbehavior
+
+package privval
+
+import (
+	"fmt"
+
+	"github.com/hdac-io/tendermint/types"
+)
+
+// Only the binaries built with the misbehavior tag can sign conflicting votes:
+//
+//	go build -tags misbehavior ./cmd/tendermint
+
+var _ types.ConflictingVoteSigner = (*FridayFilePV)(nil)
+
+// SignConflictingVote signs the vote without checking nor saving the sign
+// state, so it may conflict with a vote signed at the same HRS. Only for the
+// misbehaviors simulated in private testnets.
+// Implements ConflictingVoteSigner.
+func (pv *FridayFilePV) SignConflictingVote(chainID string, vote *types.Vote) error {
+	sig, err := pv.Key.PrivKey.Sign(vote.SignBytes(chainID))
+	if err != nil {
+		return fmt.Errorf("error signing vote: %v", err)
+	}
+	vote.Signature = sig
+	return nil
+}
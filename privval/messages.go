@@ -3,27 +3,11 @@ package privval
 import (
 	"github.com/hdac-io/tendermint/crypto"
 	"github.com/hdac-io/tendermint/types"
-	amino "github.com/tendermint/go-amino"
 )
 
 // SignerMessage is sent between Signer Clients and Servers.
 type SignerMessage interface{}
 
-func RegisterRemoteSignerMsg(cdc *amino.Codec) {
-	cdc.RegisterInterface((*SignerMessage)(nil), nil)
-	cdc.RegisterConcrete(&PubKeyRequest{}, "tendermint/remotesigner/PubKeyRequest", nil)
-	cdc.RegisterConcrete(&PubKeyResponse{}, "tendermint/remotesigner/PubKeyResponse", nil)
-	cdc.RegisterConcrete(&SignVoteRequest{}, "tendermint/remotesigner/SignVoteRequest", nil)
-	cdc.RegisterConcrete(&SignedVoteResponse{}, "tendermint/remotesigner/SignedVoteResponse", nil)
-	cdc.RegisterConcrete(&SignProposalRequest{}, "tendermint/remotesigner/SignProposalRequest", nil)
-	cdc.RegisterConcrete(&SignedProposalResponse{}, "tendermint/remotesigner/SignedProposalResponse", nil)
-	cdc.RegisterConcrete(&SetImmutableHeightRequest{}, "tendermint/remotesigner/SetImmutableHeightRequest", nil)
-	cdc.RegisterConcrete(&SetImmutableHeightResponse{}, "tendermint/remotesigner/SetImmutableHeightResponse", nil)
-
-	cdc.RegisterConcrete(&PingRequest{}, "tendermint/remotesigner/PingRequest", nil)
-	cdc.RegisterConcrete(&PingResponse{}, "tendermint/remotesigner/PingResponse", nil)
-}
-
 // TODO: Add ChainIDRequest
 
 // PubKeyRequest requests the consensus public key from the remote signer.
@@ -0,0 +1,120 @@
+package privval
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/hdac-io/tendermint/crypto"
+	"github.com/hdac-io/tendermint/crypto/bls"
+	"github.com/hdac-io/tendermint/crypto/ed25519"
+	"github.com/hdac-io/tendermint/crypto/secp256k1"
+	"github.com/hdac-io/tendermint/types"
+)
+
+// SignerHealth reports whether a SignerServer serves its validator.
+type SignerHealth struct {
+	Running   bool `json:"running"`
+	Connected bool `json:"connected"`
+	// the ABCI type of the key, e.g. bls12_381
+	KeyType string `json:"key_type"`
+	// the times of the last response to the validator, including the pings,
+	// and of the last successful vote or proposal signature
+	LastResponseTime time.Time `json:"last_response_time"`
+	LastSignTime     time.Time `json:"last_sign_time"`
+}
+
+// Health returns the health of the server.
+func (ss *SignerServer) Health() SignerHealth {
+	ss.healthMtx.Lock()
+	defer ss.healthMtx.Unlock()
+	return SignerHealth{
+		Running:          ss.IsRunning(),
+		Connected:        ss.endpoint.IsConnected(),
+		KeyType:          pubKeyType(ss.privVal.GetPubKey()),
+		LastResponseTime: ss.lastResponseTime,
+		LastSignTime:     ss.lastSignTime,
+	}
+}
+
+// recordResponse records that res was sent to the validator.
+func (ss *SignerServer) recordResponse(res SignerMessage) {
+	ss.healthMtx.Lock()
+	defer ss.healthMtx.Unlock()
+
+	now := time.Now()
+	ss.lastResponseTime = now
+	switch r := res.(type) {
+	case *SignedVoteResponse:
+		if r.Error == nil {
+			ss.lastSignTime = now
+		}
+	case *SignedProposalResponse:
+		if r.Error == nil {
+			ss.lastSignTime = now
+		}
+	}
+}
+
+// idle returns how long the server has not responded to the validator, since
+// it started.
+func (ss *SignerServer) idle(now time.Time) time.Duration {
+	ss.healthMtx.Lock()
+	defer ss.healthMtx.Unlock()
+	if ss.lastResponseTime.After(ss.startTime) {
+		return now.Sub(ss.lastResponseTime)
+	}
+	return now.Sub(ss.startTime)
+}
+
+// SignerHealthHandler returns the HTTP handler of the probes of ss:
+//
+//   - /health reports its SignerHealth, with the status 503 if it stopped or
+//     didn't respond to the validator for maxIdle: the validator pings it
+//     while connected, so it is disconnected or wedged. To restart it.
+//   - /ready has the status 200 while it is connected to the validator, or
+//     503 otherwise.
+func SignerHealthHandler(ss *SignerServer, maxIdle time.Duration) http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/health", func(w http.ResponseWriter, r *http.Request) {
+		health := ss.Health()
+		status := http.StatusOK
+		if !health.Running || ss.idle(time.Now()) > maxIdle {
+			status = http.StatusServiceUnavailable
+		}
+		writeSignerHealth(w, status, health)
+	})
+	mux.HandleFunc("/ready", func(w http.ResponseWriter, r *http.Request) {
+		health := ss.Health()
+		status := http.StatusOK
+		if !health.Running || !health.Connected {
+			status = http.StatusServiceUnavailable
+		}
+		writeSignerHealth(w, status, health)
+	})
+	return mux
+}
+
+func writeSignerHealth(w http.ResponseWriter, status int, health SignerHealth) {
+	bz, err := cdc.MarshalJSON(health)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	w.Write(bz) // nolint: errcheck
+}
+
+func pubKeyType(pubKey crypto.PubKey) string {
+	switch pubKey.(type) {
+	case bls.PubKeyBls:
+		return types.ABCIPubKeyTypeBLS
+	case ed25519.PubKeyEd25519:
+		return types.ABCIPubKeyTypeEd25519
+	case secp256k1.PubKeySecp256k1:
+		return types.ABCIPubKeyTypeSecp256k1
+	default:
+		return fmt.Sprintf("%T", pubKey)
+	}
+}
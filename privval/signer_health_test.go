@@ -0,0 +1,44 @@
+package privval
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hdac-io/tendermint/types"
+)
+
+func TestSignerHealth(t *testing.T) {
+	for _, tc := range getSignerTestCases(t) {
+		defer tc.signerClient.Close()
+
+		health := tc.signerServer.Health()
+		assert.True(t, health.Running)
+		assert.Equal(t, pubKeyType(tc.mockPV.GetPubKey()), health.KeyType)
+
+		vote := &types.Vote{Timestamp: time.Now(), Type: types.PrecommitType}
+		require.NoError(t, tc.signerClient.SignVote(tc.chainID, vote))
+		health = tc.signerServer.Health()
+		assert.True(t, health.Connected)
+		assert.False(t, health.LastSignTime.IsZero())
+		assert.False(t, health.LastResponseTime.Before(health.LastSignTime))
+
+		handler := SignerHealthHandler(tc.signerServer, time.Minute)
+		assert.Equal(t, http.StatusOK, probe(handler, "/health"))
+		assert.Equal(t, http.StatusOK, probe(handler, "/ready"))
+
+		require.NoError(t, tc.signerServer.Stop())
+		assert.Equal(t, http.StatusServiceUnavailable, probe(handler, "/health"))
+		assert.Equal(t, http.StatusServiceUnavailable, probe(handler, "/ready"))
+	}
+}
+
+func probe(handler http.Handler, path string) int {
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, path, nil))
+	return rec.Code
+}
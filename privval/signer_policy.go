@@ -0,0 +1,156 @@
+package privval
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/hdac-io/tendermint/types"
+)
+
+// signerPolicyTypes are the names of the message types in a SignerPolicy.
+var signerPolicyTypes = map[string]types.SignedMsgType{
+	"prevote":   types.PrevoteType,
+	"precommit": types.PrecommitType,
+	"proposal":  types.ProposalType,
+}
+
+// SignerPolicy restricts what a SignerServer signs with its key, e.g. to lend
+// the key of a testnet validator without risking a signature for the
+// mainnet. The empty fields don't restrict anything.
+type SignerPolicy struct {
+	// the chain IDs allowed
+	ChainIDs []string `json:"chain_ids"`
+
+	// the heights allowed, both included (0 means no bound)
+	MinHeight int64 `json:"min_height"`
+	MaxHeight int64 `json:"max_height"`
+
+	// the types of the messages allowed: "prevote", "precommit" or "proposal"
+	Types []string `json:"types"`
+
+	// the times of the votes and proposals allowed, both included
+	MinTime time.Time `json:"min_time"`
+	MaxTime time.Time `json:"max_time"`
+}
+
+// LoadSignerPolicy reads a SignerPolicy from a JSON file, e.g.
+//
+//	{
+//	  "chain_ids": ["testnet-1"],
+//	  "min_height": 1000,
+//	  "types": ["prevote", "precommit"],
+//	  "max_time": "2021-01-01T00:00:00Z"
+//	}
+func LoadSignerPolicy(file string) (*SignerPolicy, error) {
+	bz, err := ioutil.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+	policy := new(SignerPolicy)
+	if err := json.Unmarshal(bz, policy); err != nil {
+		return nil, errors.Wrapf(err, "could not read the signer policy %s", file)
+	}
+	if err := policy.ValidateBasic(); err != nil {
+		return nil, errors.Wrapf(err, "invalid signer policy %s", file)
+	}
+	return policy, nil
+}
+
+// ValidateBasic performs basic validation.
+func (p *SignerPolicy) ValidateBasic() error {
+	if p.MinHeight < 0 || p.MaxHeight < 0 {
+		return errors.New("heights can't be negative")
+	}
+	if p.MaxHeight > 0 && p.MinHeight > p.MaxHeight {
+		return fmt.Errorf("min_height %d is above max_height %d", p.MinHeight, p.MaxHeight)
+	}
+	for _, name := range p.Types {
+		if _, ok := signerPolicyTypes[name]; !ok {
+			return fmt.Errorf("unknown type %q, expected prevote, precommit or proposal", name)
+		}
+	}
+	if !p.MinTime.IsZero() && !p.MaxTime.IsZero() && p.MinTime.After(p.MaxTime) {
+		return fmt.Errorf("min_time %v is after max_time %v", p.MinTime, p.MaxTime)
+	}
+	return nil
+}
+
+// AllowsChainID returns whether the policy allows signing for chainID.
+func (p *SignerPolicy) AllowsChainID(chainID string) bool {
+	return len(p.ChainIDs) == 0 || containsString(p.ChainIDs, chainID)
+}
+
+// CheckVote returns an error if the policy doesn't allow signing vote for
+// chainID.
+func (p *SignerPolicy) CheckVote(chainID string, vote *types.Vote) error {
+	return p.check(chainID, vote.Type, vote.Height, vote.Timestamp)
+}
+
+// CheckProposal returns an error if the policy doesn't allow signing
+// proposal for chainID.
+func (p *SignerPolicy) CheckProposal(chainID string, proposal *types.Proposal) error {
+	return p.check(chainID, types.ProposalType, proposal.Height, proposal.Timestamp)
+}
+
+func (p *SignerPolicy) check(chainID string, msgType types.SignedMsgType, height int64, t time.Time) error {
+	if !p.AllowsChainID(chainID) {
+		return fmt.Errorf("signer policy: chain ID %s is not allowed", chainID)
+	}
+	if height < p.MinHeight {
+		return fmt.Errorf("signer policy: height %d is below min_height %d", height, p.MinHeight)
+	}
+	if p.MaxHeight > 0 && height > p.MaxHeight {
+		return fmt.Errorf("signer policy: height %d is above max_height %d", height, p.MaxHeight)
+	}
+	if len(p.Types) > 0 {
+		name := fmt.Sprintf("%#x", byte(msgType))
+		for n, t := range signerPolicyTypes {
+			if t == msgType {
+				name = n
+			}
+		}
+		if !containsString(p.Types, name) {
+			return fmt.Errorf("signer policy: type %s is not allowed", name)
+		}
+	}
+	if !p.MinTime.IsZero() && t.Before(p.MinTime) {
+		return fmt.Errorf("signer policy: time %v is before min_time %v", t, p.MinTime)
+	}
+	if !p.MaxTime.IsZero() && t.After(p.MaxTime) {
+		return fmt.Errorf("signer policy: time %v is after max_time %v", t, p.MaxTime)
+	}
+	return nil
+}
+
+func containsString(strs []string, str string) bool {
+	for _, s := range strs {
+		if s == str {
+			return true
+		}
+	}
+	return false
+}
+
+// checkPolicy returns the response refusing req if the policy of the server
+// doesn't allow signing it, or nil.
+// NOTE: ss.handlerMtx must be locked
+func (ss *SignerServer) checkPolicy(req SignerMessage) (SignerMessage, error) {
+	if ss.policy == nil {
+		return nil, nil
+	}
+	switch r := req.(type) {
+	case *SignVoteRequest:
+		if err := ss.policy.CheckVote(ss.chainID, r.Vote); err != nil {
+			return &SignedVoteResponse{nil, &RemoteSignerError{0, err.Error()}}, err
+		}
+	case *SignProposalRequest:
+		if err := ss.policy.CheckProposal(ss.chainID, r.Proposal); err != nil {
+			return &SignedProposalResponse{nil, &RemoteSignerError{0, err.Error()}}, err
+		}
+	}
+	return nil, nil
+}
@@ -0,0 +1,104 @@
+package privval
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hdac-io/tendermint/types"
+)
+
+func TestLoadSignerPolicy(t *testing.T) {
+	dir, err := ioutil.TempDir("", "signer_policy")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	file := filepath.Join(dir, "policy.json")
+
+	require.NoError(t, ioutil.WriteFile(file, []byte(`{
+  "chain_ids": ["testnet-1"],
+  "min_height": 10,
+  "max_height": 20,
+  "types": ["prevote", "precommit"],
+  "max_time": "2021-01-01T00:00:00Z"
+}`), 0600))
+	policy, err := LoadSignerPolicy(file)
+	require.NoError(t, err)
+	assert.Equal(t, &SignerPolicy{
+		ChainIDs:  []string{"testnet-1"},
+		MinHeight: 10,
+		MaxHeight: 20,
+		Types:     []string{"prevote", "precommit"},
+		MaxTime:   time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+	}, policy)
+
+	for _, invalid := range []string{
+		`{"min_height": 20, "max_height": 10}`,
+		`{"min_height": -1}`,
+		`{"types": ["vote"]}`,
+		`{"min_time": "2021-01-02T00:00:00Z", "max_time": "2021-01-01T00:00:00Z"}`,
+		`{"chain_ids": "testnet-1"}`,
+	} {
+		require.NoError(t, ioutil.WriteFile(file, []byte(invalid), 0600))
+		_, err = LoadSignerPolicy(file)
+		assert.Error(t, err, invalid)
+	}
+}
+
+func TestSignerPolicy(t *testing.T) {
+	now := time.Now()
+	policy := &SignerPolicy{
+		ChainIDs:  []string{"testnet-1", "testnet-2"},
+		MinHeight: 10,
+		MaxHeight: 20,
+		Types:     []string{"precommit", "proposal"},
+		MinTime:   now.Add(-time.Hour),
+		MaxTime:   now.Add(time.Hour),
+	}
+	vote := func(msgType types.SignedMsgType, height int64, t time.Time) *types.Vote {
+		return &types.Vote{Type: msgType, Height: height, Timestamp: t}
+	}
+
+	assert.NoError(t, policy.CheckVote("testnet-2", vote(types.PrecommitType, 10, now)))
+	assert.NoError(t, policy.CheckVote("testnet-1", vote(types.PrecommitType, 20, now.Add(time.Hour))))
+	assert.Error(t, policy.CheckVote("mainnet", vote(types.PrecommitType, 15, now)))
+	assert.Error(t, policy.CheckVote("testnet-1", vote(types.PrecommitType, 9, now)))
+	assert.Error(t, policy.CheckVote("testnet-1", vote(types.PrecommitType, 21, now)))
+	assert.Error(t, policy.CheckVote("testnet-1", vote(types.PrevoteType, 15, now)))
+	assert.Error(t, policy.CheckVote("testnet-1", vote(types.PrecommitType, 15, now.Add(-2*time.Hour))))
+	assert.Error(t, policy.CheckVote("testnet-1", vote(types.PrecommitType, 15, now.Add(2*time.Hour))))
+
+	assert.NoError(t, policy.CheckProposal("testnet-1", &types.Proposal{Height: 15, Timestamp: now}))
+	assert.Error(t, policy.CheckProposal("testnet-1", &types.Proposal{Height: 30, Timestamp: now}))
+
+	// no restriction
+	assert.NoError(t, (&SignerPolicy{}).CheckVote("mainnet", vote(types.PrevoteType, 1, time.Time{})))
+}
+
+func TestSignerServerPolicy(t *testing.T) {
+	ss := &SignerServer{chainID: "mainnet", privVal: types.NewMockPV()}
+	req := &SignVoteRequest{Vote: &types.Vote{Type: types.PrecommitType, Height: 1, Timestamp: time.Now()}}
+
+	res, err := ss.checkPolicy(req)
+	assert.NoError(t, err)
+	assert.Nil(t, res, "no policy")
+
+	ss.SetPolicy(&SignerPolicy{ChainIDs: []string{"testnet-1"}})
+	res, err = ss.checkPolicy(req)
+	assert.Error(t, err)
+	if assert.IsType(t, &SignedVoteResponse{}, res) {
+		assert.Nil(t, res.(*SignedVoteResponse).Vote)
+		assert.NotNil(t, res.(*SignedVoteResponse).Error)
+	}
+	res, err = ss.checkPolicy(&SignProposalRequest{Proposal: &types.Proposal{Height: 1}})
+	assert.Error(t, err)
+	assert.IsType(t, &SignedProposalResponse{}, res)
+
+	res, err = ss.checkPolicy(&PingRequest{})
+	assert.NoError(t, err)
+	assert.Nil(t, res, "only the signatures are restricted")
+}
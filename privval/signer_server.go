@@ -3,6 +3,7 @@ package privval
 import (
 	"io"
 	"sync"
+	"time"
 
 	cmn "github.com/hdac-io/tendermint/libs/common"
 	"github.com/hdac-io/tendermint/types"
@@ -23,6 +24,13 @@ type SignerServer struct {
 
 	handlerMtx               sync.Mutex
 	validationRequestHandler ValidationRequestHandlerFunc
+	policy                   *SignerPolicy
+
+	// for Health
+	healthMtx        sync.Mutex
+	startTime        time.Time
+	lastResponseTime time.Time
+	lastSignTime     time.Time
 }
 
 func NewSignerServer(endpoint *SignerDialerEndpoint, chainID string, privVal types.PrivValidator) *SignerServer {
@@ -40,6 +48,9 @@ func NewSignerServer(endpoint *SignerDialerEndpoint, chainID string, privVal typ
 
 // OnStart implements cmn.Service.
 func (ss *SignerServer) OnStart() error {
+	ss.healthMtx.Lock()
+	ss.startTime = time.Now()
+	ss.healthMtx.Unlock()
 	go ss.serviceLoop()
 	return nil
 }
@@ -57,6 +68,14 @@ func (ss *SignerServer) SetRequestHandler(validationRequestHandler ValidationReq
 	ss.validationRequestHandler = validationRequestHandler
 }
 
+// SetPolicy restricts what the server signs, before the requests reach the
+// request handler. nil removes the restrictions.
+func (ss *SignerServer) SetPolicy(policy *SignerPolicy) {
+	ss.handlerMtx.Lock()
+	defer ss.handlerMtx.Unlock()
+	ss.policy = policy
+}
+
 func (ss *SignerServer) servicePendingRequest() {
 	if !ss.IsRunning() {
 		return // Ignore error from closing.
@@ -75,7 +94,10 @@ func (ss *SignerServer) servicePendingRequest() {
 		// limit the scope of the lock
 		ss.handlerMtx.Lock()
 		defer ss.handlerMtx.Unlock()
-		res, err = ss.validationRequestHandler(ss.privVal, req, ss.chainID)
+		res, err = ss.checkPolicy(req)
+		if res == nil {
+			res, err = ss.validationRequestHandler(ss.privVal, req, ss.chainID)
+		}
 		if err != nil {
 			// only log the error; we'll reply with an error in res
 			ss.Logger.Error("SignerServer: handleMessage", "err", err)
@@ -86,6 +108,8 @@ func (ss *SignerServer) servicePendingRequest() {
 		err = ss.endpoint.WriteMessage(res)
 		if err != nil {
 			ss.Logger.Error("SignerServer: writeMessage", "err", err)
+		} else {
+			ss.recordResponse(res)
 		}
 	}
 }
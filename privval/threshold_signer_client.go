@@ -0,0 +1,246 @@
+package privval
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/hdac-io/tendermint/crypto"
+	"github.com/hdac-io/tendermint/crypto/bls"
+	"github.com/hdac-io/tendermint/types"
+)
+
+// ThresholdSignerClient implements PrivValidator.
+// It signs with a BLS key split among cosigners (see bls.SplitPrivKey): the
+// i-th cosigner signs with the share of index i+1, and the signature of the
+// key is recovered once threshold cosigners signed the same sign bytes.
+//
+// Each cosigner checks the height, round and step of what it signs against
+// its own sign state, like any PrivValidator, so the key can't double sign
+// unless threshold cosigners lose their sign state.
+type ThresholdSignerClient struct {
+	pubKey       bls.PubKeyBls
+	threshold    int
+	cosigners    []types.PrivValidator
+	sharePubKeys []bls.PubKeyBls
+}
+
+var _ types.PrivValidator = (*ThresholdSignerClient)(nil)
+
+// NewThresholdSignerClient returns a ThresholdSignerClient signing with
+// threshold of the cosigners. It returns an error if the public keys of the
+// cosigners are not the shares of a same BLS key split with threshold.
+func NewThresholdSignerClient(threshold int, cosigners []types.PrivValidator) (*ThresholdSignerClient, error) {
+	if threshold < 1 || threshold > len(cosigners) {
+		return nil, fmt.Errorf("invalid threshold %d of %d cosigners", threshold, len(cosigners))
+	}
+
+	sharePubKeys := make([]bls.PubKeyBls, len(cosigners))
+	indexes := make([]int, len(cosigners))
+	for i, cosigner := range cosigners {
+		pubKey, ok := cosigner.GetPubKey().(bls.PubKeyBls)
+		if !ok {
+			return nil, fmt.Errorf("cosigner %d has no BLS public key", i+1)
+		}
+		sharePubKeys[i] = pubKey
+		indexes[i] = i + 1
+	}
+
+	// recover the public key from the first threshold shares, then check that
+	// each other share recovers it too, together with threshold-1 of them
+	pubKey, err := bls.RecoverPubKey(indexes[:threshold], sharePubKeys[:threshold])
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to recover the public key")
+	}
+	for i := threshold; i < len(cosigners); i++ {
+		subsetIndexes := append(append([]int{}, indexes[:threshold-1]...), indexes[i])
+		subset := append(append([]bls.PubKeyBls{}, sharePubKeys[:threshold-1]...), sharePubKeys[i])
+		recovered, err := bls.RecoverPubKey(subsetIndexes, subset)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to recover the public key")
+		}
+		if !recovered.Equals(pubKey) {
+			return nil, fmt.Errorf("cosigner %d has no share of the key of the first %d cosigners", i+1, threshold)
+		}
+	}
+
+	return &ThresholdSignerClient{
+		pubKey:       pubKey,
+		threshold:    threshold,
+		cosigners:    cosigners,
+		sharePubKeys: sharePubKeys,
+	}, nil
+}
+
+// Threshold returns the number of cosigners required to sign.
+func (tc *ThresholdSignerClient) Threshold() int {
+	return tc.threshold
+}
+
+// GetPubKey returns the public key of the split key.
+// Implements PrivValidator.
+func (tc *ThresholdSignerClient) GetPubKey() crypto.PubKey {
+	return tc.pubKey
+}
+
+// SignVote requests the cosigners to sign the vote, and sets the signature
+// recovered from the first threshold of them signing the same sign bytes.
+// Implements PrivValidator.
+func (tc *ThresholdSignerClient) SignVote(chainID string, vote *types.Vote) error {
+	signed, sig, err := tc.sign(chainID, func(cosigner types.PrivValidator) (signable, error) {
+		share := vote.Copy()
+		if err := cosigner.SignVote(chainID, share); err != nil {
+			return nil, err
+		}
+		return share, nil
+	})
+	if err != nil {
+		return errors.Wrap(err, "error signing vote")
+	}
+	*vote = *signed.(*types.Vote)
+	vote.Signature = sig
+	return nil
+}
+
+// SignProposal requests the cosigners to sign the proposal, and sets the
+// signature recovered from the first threshold of them signing the same sign
+// bytes. Implements PrivValidator.
+func (tc *ThresholdSignerClient) SignProposal(chainID string, proposal *types.Proposal) error {
+	signed, sig, err := tc.sign(chainID, func(cosigner types.PrivValidator) (signable, error) {
+		share := *proposal
+		if err := cosigner.SignProposal(chainID, &share); err != nil {
+			return nil, err
+		}
+		return &share, nil
+	})
+	if err != nil {
+		return errors.Wrap(err, "error signing proposal")
+	}
+	*proposal = *signed.(*types.Proposal)
+	proposal.Signature = sig
+	return nil
+}
+
+// SetImmutableHeight sets the immutable height of the sign state of the
+// cosigners. It fails if less than threshold of them succeed, as the key
+// can't sign anymore.
+// Implements ParallelProgressablePV
+func (tc *ThresholdSignerClient) SetImmutableHeight(height int64) error {
+	errs := make(chan error, len(tc.cosigners))
+	for i, cosigner := range tc.cosigners {
+		go func(i int, cosigner types.PrivValidator) {
+			pv := cosigner.GetParallelProgressablePV()
+			if pv == nil {
+				errs <- fmt.Errorf("cosigner %d: parallel progress not supported", i+1)
+				return
+			}
+			if err := pv.SetImmutableHeight(height); err != nil {
+				errs <- fmt.Errorf("cosigner %d: %v", i+1, err)
+				return
+			}
+			errs <- nil
+		}(i, cosigner)
+	}
+
+	var failures []string
+	for range tc.cosigners {
+		if err := <-errs; err != nil {
+			failures = append(failures, err.Error())
+		}
+	}
+	if len(tc.cosigners)-len(failures) < tc.threshold {
+		return fmt.Errorf("failed to set the immutable height of %d of %d cosigners: %s",
+			len(failures), len(tc.cosigners), strings.Join(failures, "; "))
+	}
+	return nil
+}
+
+// GetParallelProgressablePV implements PrivValidator.
+func (tc *ThresholdSignerClient) GetParallelProgressablePV() types.ParallelProgressablePV {
+	return tc
+}
+
+// signable is a vote or a proposal signed by a cosigner.
+type signable interface {
+	SignBytes(chainID string) []byte
+}
+
+// cosignerShare is what a cosigner signed, or the error it failed with.
+type cosignerShare struct {
+	index     int
+	signed    signable
+	signBytes []byte
+	err       error
+}
+
+// sign requests all the cosigners to sign concurrently, and returns what the
+// first threshold of them to sign the same sign bytes signed, with the
+// signature recovered from theirs. The shares of the cosigners which signed
+// other sign bytes, e.g. with another timestamp after a crash, don't count.
+func (tc *ThresholdSignerClient) sign(
+	chainID string,
+	signOne func(cosigner types.PrivValidator) (signable, error),
+) (signable, []byte, error) {
+	shares := make(chan cosignerShare, len(tc.cosigners))
+	for i, cosigner := range tc.cosigners {
+		go func(i int, cosigner types.PrivValidator) {
+			signed, err := signOne(cosigner)
+			if err != nil {
+				shares <- cosignerShare{index: i + 1, err: err}
+				return
+			}
+			share := cosignerShare{index: i + 1, signed: signed, signBytes: signed.SignBytes(chainID)}
+			if !tc.sharePubKeys[i].VerifyBytes(share.signBytes, signature(signed)) {
+				share.err = errors.New("invalid signature share")
+			}
+			shares <- share
+		}(i, cosigner)
+	}
+
+	// the shares of each sign bytes
+	bySignBytes := make(map[string][]cosignerShare)
+	var failures []string
+	for range tc.cosigners {
+		share := <-shares
+		if share.err != nil {
+			failures = append(failures, fmt.Sprintf("cosigner %d: %v", share.index, share.err))
+			continue
+		}
+		key := string(share.signBytes)
+		bySignBytes[key] = append(bySignBytes[key], share)
+		if len(bySignBytes[key]) < tc.threshold {
+			continue
+		}
+
+		agreed := bySignBytes[key]
+		indexes := make([]int, len(agreed))
+		sigs := make([][]byte, len(agreed))
+		for i, s := range agreed {
+			indexes[i] = s.index
+			sigs[i] = signature(s.signed)
+		}
+		sig, err := bls.RecoverSignature(indexes, sigs)
+		if err != nil {
+			return nil, nil, err
+		}
+		if !tc.pubKey.VerifyBytes(share.signBytes, sig) {
+			return nil, nil, errors.New("invalid recovered signature")
+		}
+		return share.signed, sig, nil
+	}
+
+	return nil, nil, fmt.Errorf("less than %d of %d cosigners signed the same sign bytes: %s",
+		tc.threshold, len(tc.cosigners), strings.Join(failures, "; "))
+}
+
+func signature(signed signable) []byte {
+	switch signed := signed.(type) {
+	case *types.Vote:
+		return signed.Signature
+	case *types.Proposal:
+		return signed.Signature
+	default:
+		panic(fmt.Sprintf("unexpected signable %T", signed))
+	}
+}
@@ -0,0 +1,132 @@
+package privval
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hdac-io/tendermint/crypto/bls"
+	"github.com/hdac-io/tendermint/types"
+)
+
+// newThresholdCosigners splits the key of privVal among n cosigners, saved in
+// dir.
+func newThresholdCosigners(t *testing.T, privVal *FridayFilePV, threshold, n int, dir string) []*FridayFilePV {
+	keyFiles := make([]string, n)
+	stateFiles := make([]string, n)
+	for i := range keyFiles {
+		keyFiles[i] = filepath.Join(dir, fmt.Sprintf("priv_validator_key_%d", i+1))
+		stateFiles[i] = filepath.Join(dir, fmt.Sprintf("priv_validator_state_%d", i+1))
+	}
+	sharePVs, err := privVal.SplitKey(threshold, keyFiles, stateFiles)
+	require.NoError(t, err)
+	for _, sharePV := range sharePVs {
+		sharePV.Save()
+	}
+	return sharePVs
+}
+
+func toPrivValidators(pvs []*FridayFilePV) []types.PrivValidator {
+	privVals := make([]types.PrivValidator, len(pvs))
+	for i, pv := range pvs {
+		privVals[i] = pv
+	}
+	return privVals
+}
+
+func TestThresholdSignerClient(t *testing.T) {
+	dir, err := ioutil.TempDir("", "threshold_signer")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	privVal := GenFridayFilePV(filepath.Join(dir, "priv_validator_key"), filepath.Join(dir, "priv_validator_state"))
+	block1 := types.BlockID{Hash: []byte{1, 2, 3}, PartsHeader: types.PartSetHeader{}}
+	block2 := types.BlockID{Hash: []byte{3, 2, 1}, PartsHeader: types.PartSetHeader{}}
+	height, round := int64(10), 1
+	voteType := byte(types.PrecommitType)
+
+	// the cosigners can't sign at or below the heights signed with the key
+	require.NoError(t, privVal.SignVote("mychainid", newVote(privVal.Key.Address, 0, height, round, voteType, block1)))
+	cosigners := newThresholdCosigners(t, privVal, 2, 3, dir)
+	for _, cosigner := range cosigners {
+		assert.Equal(t, height, cosigner.SignState.ImmutableHeight)
+	}
+
+	tc, err := NewThresholdSignerClient(2, toPrivValidators(cosigners))
+	require.NoError(t, err)
+	assert.True(t, privVal.GetPubKey().Equals(tc.GetPubKey()))
+
+	err = tc.SignVote("mychainid", newVote(privVal.Key.Address, 0, height, round, voteType, block1))
+	assert.Error(t, err, "expected error on signing at a height signed with the key")
+
+	// the recovered signature is the one of the key
+	height++
+	vote := newVote(privVal.Key.Address, 0, height, round, voteType, block1)
+	require.NoError(t, tc.SignVote("mychainid", vote))
+	assert.True(t, tc.GetPubKey().VerifyBytes(vote.SignBytes("mychainid"), vote.Signature))
+	expected := vote.Copy()
+	require.NoError(t, privVal.SignVote("mychainid", expected))
+	assert.Equal(t, expected.Signature, vote.Signature)
+
+	proposal := newProposal(height+1, round, block1)
+	require.NoError(t, tc.SignProposal("mychainid", proposal))
+	assert.True(t, tc.GetPubKey().VerifyBytes(proposal.SignBytes("mychainid"), proposal.Signature))
+
+	// signing the same vote again returns the same signature, and a
+	// conflicting vote is refused by the share-level sign states
+	same := newVote(privVal.Key.Address, 0, height, round, voteType, block1)
+	require.NoError(t, tc.SignVote("mychainid", same))
+	assert.Equal(t, vote.Signature, same.Signature)
+	assert.Equal(t, vote.Timestamp, same.Timestamp)
+	err = tc.SignVote("mychainid", newVote(privVal.Key.Address, 0, height, round, voteType, block2))
+	assert.Error(t, err, "expected error on signing a conflicting vote")
+
+	// a cosigner which signed the conflicting vote alone doesn't make the key
+	// sign it
+	conflicting := newVote(privVal.Key.Address, 0, height+2, round, voteType, block2)
+	require.NoError(t, cosigners[0].SignVote("mychainid", conflicting.Copy()))
+	vote = newVote(privVal.Key.Address, 0, height+2, round, voteType, block1)
+	require.NoError(t, tc.SignVote("mychainid", vote))
+	assert.True(t, tc.GetPubKey().VerifyBytes(vote.SignBytes("mychainid"), vote.Signature))
+
+	// the immutable height is set as long as threshold cosigners set it
+	require.NoError(t, cosigners[2].SetImmutableHeight(height+5))
+	require.NoError(t, tc.SetImmutableHeight(height+1))
+	assert.Equal(t, height+1, cosigners[0].SignState.ImmutableHeight)
+	require.NoError(t, cosigners[1].SetImmutableHeight(height+5))
+	assert.Error(t, tc.SetImmutableHeight(height+2))
+}
+
+func TestThresholdSignerClientInvalidShares(t *testing.T) {
+	dir, err := ioutil.TempDir("", "threshold_signer")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	privVal := GenFridayFilePV(filepath.Join(dir, "priv_validator_key"), filepath.Join(dir, "priv_validator_state"))
+	cosigners := toPrivValidators(newThresholdCosigners(t, privVal, 2, 3, dir))
+
+	_, err = NewThresholdSignerClient(0, cosigners)
+	assert.Error(t, err)
+	_, err = NewThresholdSignerClient(4, cosigners)
+	assert.Error(t, err)
+
+	// a cosigner out of order, or with a share of another key
+	_, err = NewThresholdSignerClient(2, []types.PrivValidator{cosigners[1], cosigners[0], cosigners[2]})
+	assert.Error(t, err)
+	other := GenFridayFilePV(filepath.Join(dir, "other_key"), filepath.Join(dir, "other_state"))
+	_, err = NewThresholdSignerClient(2, []types.PrivValidator{cosigners[0], cosigners[1], other})
+	assert.Error(t, err)
+
+	// a key split with a lower threshold
+	lower, err := NewThresholdSignerClient(1, cosigners)
+	assert.Error(t, err)
+	assert.Nil(t, lower)
+
+	_, err = bls.SplitPrivKey(bls.GenPrivKey(), 3, 2)
+	assert.Error(t, err)
+}
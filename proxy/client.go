@@ -20,8 +20,9 @@ type ClientCreator interface {
 // local proxy uses a mutex on an in-proc app
 
 type localClientCreator struct {
-	mtx *sync.Mutex
-	app types.Application
+	mtx    *sync.Mutex
+	app    types.Application
+	friday bool
 }
 
 func NewLocalClientCreator(app types.Application) ClientCreator {
@@ -31,7 +32,21 @@ func NewLocalClientCreator(app types.Application) ClientCreator {
 	}
 }
 
+// NewFridayLocalClientCreator is like NewLocalClientCreator, for the apps
+// running the txs of a block concurrently: they are delivered asynchronously,
+// without the mutex (see abcicli.NewFridayLocalClient).
+func NewFridayLocalClientCreator(app types.Application) ClientCreator {
+	return &localClientCreator{
+		mtx:    new(sync.Mutex),
+		app:    app,
+		friday: true,
+	}
+}
+
 func (l *localClientCreator) NewABCIClient() (abcicli.Client, error) {
+	if l.friday {
+		return abcicli.NewFridayLocalClient(l.mtx, l.app), nil
+	}
 	return abcicli.NewLocalClient(l.mtx, l.app), nil
 }
 
@@ -42,6 +57,7 @@ type remoteClientCreator struct {
 	addr        string
 	transport   string
 	mustConnect bool
+	friday      bool
 }
 
 func NewRemoteClientCreator(addr, transport string, mustConnect bool) ClientCreator {
@@ -52,8 +68,24 @@ func NewRemoteClientCreator(addr, transport string, mustConnect bool) ClientCrea
 	}
 }
 
+// NewFridayRemoteClientCreator is like NewRemoteClientCreator, for the friday
+// consensus: the txs of a block are delivered asynchronously, so the app may
+// run them concurrently (see abcicli.NewFridayClient).
+func NewFridayRemoteClientCreator(addr, transport string, mustConnect bool) ClientCreator {
+	return &remoteClientCreator{
+		addr:        addr,
+		transport:   transport,
+		mustConnect: mustConnect,
+		friday:      true,
+	}
+}
+
 func (r *remoteClientCreator) NewABCIClient() (abcicli.Client, error) {
-	remoteApp, err := abcicli.NewClient(r.addr, r.transport, r.mustConnect)
+	newClient := abcicli.NewClient
+	if r.friday {
+		newClient = abcicli.NewFridayClient
+	}
+	remoteApp, err := newClient(r.addr, r.transport, r.mustConnect)
 	if err != nil {
 		return nil, errors.Wrap(err, "Failed to connect to proxy")
 	}
@@ -64,6 +96,17 @@ func (r *remoteClientCreator) NewABCIClient() (abcicli.Client, error) {
 // default
 
 func DefaultClientCreator(addr, transport, dbDir string) ClientCreator {
+	return defaultClientCreator(addr, transport, dbDir, NewRemoteClientCreator)
+}
+
+// DefaultFridayClientCreator is like DefaultClientCreator, but the external
+// apps are connected to with NewFridayRemoteClientCreator.
+func DefaultFridayClientCreator(addr, transport, dbDir string) ClientCreator {
+	return defaultClientCreator(addr, transport, dbDir, NewFridayRemoteClientCreator)
+}
+
+func defaultClientCreator(addr, transport, dbDir string,
+	newRemoteClientCreator func(addr, transport string, mustConnect bool) ClientCreator) ClientCreator {
 	switch addr {
 	case "counter":
 		return NewLocalClientCreator(counter.NewCounterApplication(false))
@@ -73,10 +116,12 @@ func DefaultClientCreator(addr, transport, dbDir string) ClientCreator {
 		return NewLocalClientCreator(kvstore.NewKVStoreApplication())
 	case "persistent_kvstore":
 		return NewLocalClientCreator(kvstore.NewPersistentKVStoreApplication(dbDir))
+	case "friday_kvstore":
+		return NewFridayLocalClientCreator(kvstore.NewFridayKVStoreApplication())
 	case "noop":
 		return NewLocalClientCreator(types.NewBaseApplication())
 	default:
 		mustConnect := false // loop retrying
-		return NewRemoteClientCreator(addr, transport, mustConnect)
+		return newRemoteClientCreator(addr, transport, mustConnect)
 	}
 }
@@ -0,0 +1,45 @@
+package client
+
+import (
+	"github.com/pkg/errors"
+
+	ctypes "github.com/hdac-io/tendermint/rpc/core/types"
+	"github.com/hdac-io/tendermint/types"
+)
+
+// EvidenceSubmitClient is the part of a Client used to submit evidence built
+// from votes, e.g. captured by an external monitor.
+type EvidenceSubmitClient interface {
+	StatusClient
+	EvidenceClient
+	Validators(height *int64) (*ctypes.ResultValidators, error)
+}
+
+// SubmitDuplicateVoteEvidence builds the DuplicateVoteEvidence of the
+// conflicting votes voteA and voteB with the chain ID and the validator set at
+// their height of the node c is connected to, and broadcasts it once verified.
+// Evidence which fails to verify isn't broadcast, so the monitor doesn't
+// submit evidence the node would reject.
+func SubmitDuplicateVoteEvidence(c EvidenceSubmitClient, voteA, voteB *types.Vote) (*ctypes.ResultBroadcastEvidence, error) {
+	if voteA == nil || voteB == nil {
+		return nil, errors.New("one or both of the votes are empty")
+	}
+	status, err := c.Status()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get the status of the node")
+	}
+	if voteA.Height > status.SyncInfo.LatestBlockHeight {
+		return nil, errors.Errorf("height %d of the votes is above the latest height %d of the node",
+			voteA.Height, status.SyncInfo.LatestBlockHeight)
+	}
+	height := voteA.Height
+	vals, err := c.Validators(&height)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get the validators at height %d", height)
+	}
+	ev, err := types.NewDuplicateVoteEvidence(status.NodeInfo.Network, types.NewValidatorSet(vals.Validators), voteA, voteB)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid evidence")
+	}
+	return c.BroadcastEvidence(ev)
+}
@@ -5,6 +5,9 @@ import (
 	"time"
 
 	"github.com/pkg/errors"
+
+	abci "github.com/hdac-io/tendermint/abci/types"
+	"github.com/hdac-io/tendermint/crypto/merkle"
 	"github.com/hdac-io/tendermint/types"
 )
 
@@ -75,3 +78,22 @@ func WaitForOneEvent(c EventsClient, evtTyp string, timeout time.Duration) (type
 		return nil, errors.New("timed out waiting for event")
 	}
 }
+
+// VerifyABCIQuery verifies the proof of the response of an ABCIQuery with
+// Prove against appHash, the app hash of the header of the height after
+// resp.Height. The proof must prove resp.Value, or that there is no value if
+// it is nil, at the keys followed by resp.Key, e.g. the name of a store. prt
+// decodes the proof ops, merkle.DefaultProofRuntime() if nil.
+func VerifyABCIQuery(prt *merkle.ProofRuntime, resp abci.ResponseQuery, appHash []byte, keys ...[]byte) error {
+	if resp.Proof == nil {
+		return errors.New("no proof in the response")
+	}
+	if prt == nil {
+		prt = merkle.DefaultProofRuntime()
+	}
+	keypath := merkle.KeysToKeyPath(append(keys[:len(keys):len(keys)], resp.Key)...)
+	if resp.Value == nil {
+		return errors.Wrap(prt.VerifyAbsence(resp.Proof, appHash, keypath), "invalid absence proof")
+	}
+	return errors.Wrap(prt.VerifyValue(resp.Proof, appHash, keypath, resp.Value), "invalid value proof")
+}
@@ -7,6 +7,9 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+
+	abci "github.com/hdac-io/tendermint/abci/types"
+	"github.com/hdac-io/tendermint/crypto/merkle"
 	"github.com/hdac-io/tendermint/rpc/client"
 	"github.com/hdac-io/tendermint/rpc/client/mock"
 	ctypes "github.com/hdac-io/tendermint/rpc/core/types"
@@ -74,3 +77,32 @@ func TestWaitForHeight(t *testing.T) {
 	require.True(ok)
 	assert.Equal(int64(15), postr.SyncInfo.LatestBlockHeight)
 }
+
+func TestVerifyABCIQuery(t *testing.T) {
+	// the value of key in store, in the app hash
+	storeRoot, storeProofs, _ := merkle.SimpleProofsFromMap(map[string][]byte{
+		"key":   []byte("value"),
+		"other": []byte("other value"),
+	})
+	appHash, appProofs, _ := merkle.SimpleProofsFromMap(map[string][]byte{"store": storeRoot})
+	proof := &merkle.Proof{Ops: []merkle.ProofOp{
+		merkle.NewSimpleValueOp([]byte("key"), storeProofs["key"]).ProofOp(),
+		merkle.NewSimpleValueOp([]byte("store"), appProofs["store"]).ProofOp(),
+	}}
+	resp := abci.ResponseQuery{Key: []byte("key"), Value: []byte("value"), Proof: proof, Height: 3}
+
+	require.NoError(t, client.VerifyABCIQuery(nil, resp, appHash, []byte("store")))
+	require.NoError(t, client.VerifyABCIQuery(merkle.DefaultProofRuntime(), resp, appHash, []byte("store")))
+	assert.Error(t, client.VerifyABCIQuery(nil, resp, []byte("wrong"), []byte("store")))
+	assert.Error(t, client.VerifyABCIQuery(nil, resp, appHash, []byte("other store")))
+	assert.Error(t, client.VerifyABCIQuery(nil, resp, appHash))
+
+	wrong := resp
+	wrong.Value = []byte("wrong")
+	assert.Error(t, client.VerifyABCIQuery(nil, wrong, appHash, []byte("store")))
+	wrong.Value = nil
+	assert.Error(t, client.VerifyABCIQuery(nil, wrong, appHash, []byte("store")))
+	wrong = resp
+	wrong.Proof = nil
+	assert.Error(t, client.VerifyABCIQuery(nil, wrong, appHash, []byte("store")))
+}
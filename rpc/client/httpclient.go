@@ -308,6 +308,33 @@ func (c *baseRPCClient) Commit(height *int64) (*ctypes.ResultCommit, error) {
 	return result, nil
 }
 
+func (c *baseRPCClient) Header(height *int64) (*ctypes.ResultHeader, error) {
+	result := new(ctypes.ResultHeader)
+	_, err := c.caller.Call("header", map[string]interface{}{"height": height}, result)
+	if err != nil {
+		return nil, errors.Wrap(err, "Header")
+	}
+	return result, nil
+}
+
+func (c *baseRPCClient) ULBCommit(height *int64) (*ctypes.ResultCommit, error) {
+	result := new(ctypes.ResultCommit)
+	_, err := c.caller.Call("ulb_commit", map[string]interface{}{"height": height}, result)
+	if err != nil {
+		return nil, errors.Wrap(err, "ULBCommit")
+	}
+	return result, nil
+}
+
+func (c *baseRPCClient) QuorumCert(height *int64) (*ctypes.ResultQuorumCert, error) {
+	result := new(ctypes.ResultQuorumCert)
+	_, err := c.caller.Call("quorum_cert", map[string]interface{}{"height": height}, result)
+	if err != nil {
+		return nil, errors.Wrap(err, "QuorumCert")
+	}
+	return result, nil
+}
+
 func (c *baseRPCClient) Tx(hash []byte, prove bool) (*ctypes.ResultTx, error) {
 	result := new(ctypes.ResultTx)
 	params := map[string]interface{}{
@@ -66,6 +66,9 @@ type SignClient interface {
 	Block(height *int64) (*ctypes.ResultBlock, error)
 	BlockResults(height *int64) (*ctypes.ResultBlockResults, error)
 	Commit(height *int64) (*ctypes.ResultCommit, error)
+	Header(height *int64) (*ctypes.ResultHeader, error)
+	ULBCommit(height *int64) (*ctypes.ResultCommit, error)
+	QuorumCert(height *int64) (*ctypes.ResultQuorumCert, error)
 	Validators(height *int64) (*ctypes.ResultValidators, error)
 	Tx(hash []byte, prove bool) (*ctypes.ResultTx, error)
 	TxSearch(query string, prove bool, page, perPage int) (*ctypes.ResultTxSearch, error)
@@ -121,8 +121,8 @@ func (c *Local) DialSeeds(seeds []string) (*ctypes.ResultDialSeeds, error) {
 	return core.UnsafeDialSeeds(c.ctx, seeds)
 }
 
-func (c *Local) DialPeers(peers []string, persistent bool) (*ctypes.ResultDialPeers, error) {
-	return core.UnsafeDialPeers(c.ctx, peers, persistent)
+func (c *Local) DialPeers(peers []string, persistent, unconditional bool) (*ctypes.ResultDialPeers, error) {
+	return core.UnsafeDialPeers(c.ctx, peers, persistent, unconditional)
 }
 
 func (c *Local) BlockchainInfo(minHeight, maxHeight int64) (*ctypes.ResultBlockchainInfo, error) {
@@ -138,13 +138,25 @@ func (c *Local) Block(height *int64) (*ctypes.ResultBlock, error) {
 }
 
 func (c *Local) BlockResults(height *int64) (*ctypes.ResultBlockResults, error) {
-	return core.BlockResults(c.ctx, height)
+	return core.BlockResults(c.ctx, height, "", 0, 0)
 }
 
 func (c *Local) Commit(height *int64) (*ctypes.ResultCommit, error) {
 	return core.Commit(c.ctx, height)
 }
 
+func (c *Local) Header(height *int64) (*ctypes.ResultHeader, error) {
+	return core.Header(c.ctx, height)
+}
+
+func (c *Local) ULBCommit(height *int64) (*ctypes.ResultCommit, error) {
+	return core.ULBCommit(c.ctx, height)
+}
+
+func (c *Local) QuorumCert(height *int64) (*ctypes.ResultQuorumCert, error) {
+	return core.QuorumCert(c.ctx, height)
+}
+
 func (c *Local) Validators(height *int64) (*ctypes.ResultValidators, error) {
 	return core.Validators(c.ctx, height)
 }
@@ -126,8 +126,8 @@ func (c Client) DialSeeds(seeds []string) (*ctypes.ResultDialSeeds, error) {
 	return core.UnsafeDialSeeds(&rpctypes.Context{}, seeds)
 }
 
-func (c Client) DialPeers(peers []string, persistent bool) (*ctypes.ResultDialPeers, error) {
-	return core.UnsafeDialPeers(&rpctypes.Context{}, peers, persistent)
+func (c Client) DialPeers(peers []string, persistent, unconditional bool) (*ctypes.ResultDialPeers, error) {
+	return core.UnsafeDialPeers(&rpctypes.Context{}, peers, persistent, unconditional)
 }
 
 func (c Client) BlockchainInfo(minHeight, maxHeight int64) (*ctypes.ResultBlockchainInfo, error) {
@@ -146,6 +146,18 @@ func (c Client) Commit(height *int64) (*ctypes.ResultCommit, error) {
 	return core.Commit(&rpctypes.Context{}, height)
 }
 
+func (c Client) Header(height *int64) (*ctypes.ResultHeader, error) {
+	return core.Header(&rpctypes.Context{}, height)
+}
+
+func (c Client) ULBCommit(height *int64) (*ctypes.ResultCommit, error) {
+	return core.ULBCommit(&rpctypes.Context{}, height)
+}
+
+func (c Client) QuorumCert(height *int64) (*ctypes.ResultQuorumCert, error) {
+	return core.QuorumCert(&rpctypes.Context{}, height)
+}
+
 func (c Client) Validators(height *int64) (*ctypes.ResultValidators, error) {
 	return core.Validators(&rpctypes.Context{}, height)
 }
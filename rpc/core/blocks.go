@@ -3,6 +3,7 @@ package core
 import (
 	"fmt"
 
+	abci "github.com/hdac-io/tendermint/abci/types"
 	cmn "github.com/hdac-io/tendermint/libs/common"
 	ctypes "github.com/hdac-io/tendermint/rpc/core/types"
 	rpctypes "github.com/hdac-io/tendermint/rpc/lib/types"
@@ -234,13 +235,22 @@ func Block(ctx *rpctypes.Context, heightPtr *int64) (*ctypes.ResultBlock, error)
 		return nil, err
 	}
 
-	blockMeta := blockStore.LoadBlockMeta(height)
-	block := blockStore.LoadBlock(height)
-	return &ctypes.ResultBlock{BlockMeta: blockMeta, Block: block}, nil
+	result, err := cachedResult("block", height, func() (interface{}, error) {
+		blockMeta := blockStore.LoadBlockMeta(height)
+		block := blockStore.LoadBlock(height)
+		return &ctypes.ResultBlock{BlockMeta: blockMeta, Block: block}, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result.(*ctypes.ResultBlock), nil
 }
 
 // Get block commit at a given height.
 // If no height is provided, it will fetch the commit for the latest block.
+// The commit is the canonical one (commit_source "block_commit") if the block
+// carrying it, at carrying_height, was committed, else the one seen by the
+// node (commit_source "seen_commit"). See /ulb_commit.
 //
 // ```shell
 // curl 'localhost:26657/commit?height=11'
@@ -321,18 +331,232 @@ func Commit(ctx *rpctypes.Context, heightPtr *int64) (*ctypes.ResultCommit, erro
 		return nil, err
 	}
 
-	header := blockStore.LoadBlockMeta(height).Header
+	result, err := cachedResult("commit", height, func() (interface{}, error) {
+		header := blockStore.LoadBlockMeta(height).Header
 
-	// If the next block has not been committed yet,
-	// use a non-canonical commit
-	if height == storeHeight {
+		// Return the canonical commit if the block carrying it was committed
+		if commit := blockStore.LoadBlockCommit(height); commit != nil {
+			result := ctypes.NewResultCommit(&header, commit, true)
+			result.CarryingHeight = height + commitDistance(sm.LoadState(stateDB))
+			return result, nil
+		}
+
+		// Else use the non-canonical commit seen by the node
 		commit := blockStore.LoadSeenCommit(height)
 		return ctypes.NewResultCommit(&header, commit, false), nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result.(*ctypes.ResultCommit), nil
+}
+
+// ULBCommit gets the canonical commit of a given height, which is embedded in
+// the block of the height LenULB heights above (the next height with the
+// tendermint consensus module), with the height of that block. If no height is
+// provided, it will fetch the commit of the last height with a canonical
+// commit.
+//
+// Unlike /commit, it never returns the commit seen by the node, whose
+// signatures may differ from the ones of the canonical commit.
+//
+// ```shell
+// curl 'localhost:26657/ulb_commit?height=10'
+// ```
+//
+// ```go
+// client := client.NewHTTP("tcp://0.0.0.0:26657", "/websocket")
+// err := client.Start()
+// if err != nil {
+//   // handle error
+// }
+// defer client.Stop()
+// info, err := client.ULBCommit(10)
+// ```
+//
+// > The above command returns JSON structured like this:
+//
+// ```json
+// {
+//   "jsonrpc": "2.0",
+//   "id": "",
+//   "result": {
+//     "signed_header": {
+//       "header": {...},
+//       "commit": {...}
+//     },
+//     "canonical": true,
+//     "commit_source": "block_commit",
+//     "carrying_height": "14"
+//   }
+// }
+// ```
+func ULBCommit(ctx *rpctypes.Context, heightPtr *int64) (*ctypes.ResultCommit, error) {
+	storeHeight := blockStore.Height()
+	distance := commitDistance(sm.LoadState(stateDB))
+	height, err := getHeight(storeHeight, heightPtr)
+	if err != nil {
+		return nil, err
+	}
+	if heightPtr == nil {
+		height = storeHeight - distance
+	}
+	if height < 1 || height+distance > storeHeight {
+		return nil, fmt.Errorf("no canonical commit at height %d: the block carrying it, %d heights above, is not committed yet", height, distance)
 	}
 
-	// Return the canonical commit (comes from the block at height+1)
 	commit := blockStore.LoadBlockCommit(height)
-	return ctypes.NewResultCommit(&header, commit, true), nil
+	if commit == nil {
+		return nil, fmt.Errorf("no canonical commit at height %d", height)
+	}
+	header := blockStore.LoadBlockMeta(height).Header
+	result := ctypes.NewResultCommit(&header, commit, true)
+	result.CarryingHeight = height + distance
+	return result, nil
+}
+
+// QuorumCert gets the quorum certificate of a given height, saved when the
+// node finalized it: the precommits for its block with their signatures
+// aggregated, to verify it was finalized with the validator set of the height
+// only, without waiting for the block carrying its commit. If no height is
+// provided, it will fetch the one of the latest height.
+//
+// The heights the node didn't finalize itself, e.g. fast synced, have none.
+//
+// ```shell
+// curl 'localhost:26657/quorum_cert?height=10'
+// ```
+//
+// ```go
+// client := client.NewHTTP("tcp://0.0.0.0:26657", "/websocket")
+// err := client.Start()
+// if err != nil {
+//   // handle error
+// }
+// defer client.Stop()
+// info, err := client.QuorumCert(10)
+// ```
+//
+// > The above command returns JSON structured like this:
+//
+// ```json
+// {
+//   "jsonrpc": "2.0",
+//   "id": "",
+//   "result": {
+//     "quorum_cert": {
+//       "height": "10",
+//       "round": "0",
+//       "block_id": {
+//         "hash": "C3DE8D2C7A3F5E1A5D1B1F0A8E1B9F9E5D23D1A0F6B7B1E9E7C6A4D1C3E2B1A0",
+//         "parts": {
+//           "total": "1",
+//           "hash": "0E3D5C7B2F1A9E8D7C6B5A4F3E2D1C0B9A8F7E6D5C4B3A2F1E0D9C8B7A6F5E4D"
+//         }
+//       },
+//       "signers": "xx_x",
+//       "timestamps": [
+//         "2019-12-20T07:03:47.563Z",
+//         "2019-12-20T07:03:47.561Z",
+//         "2019-12-20T07:03:47.566Z"
+//       ],
+//       "signature": "F4uYvJ0fWQpH0CjZ5kV1uB3r..."
+//     }
+//   }
+// }
+// ```
+func QuorumCert(ctx *rpctypes.Context, heightPtr *int64) (*ctypes.ResultQuorumCert, error) {
+	height, err := getHeight(blockStore.Height(), heightPtr)
+	if err != nil {
+		return nil, err
+	}
+	qc, err := sm.LoadQuorumCert(stateDB, height)
+	if err != nil {
+		return nil, err
+	}
+	return &ctypes.ResultQuorumCert{QuorumCert: qc}, nil
+}
+
+// commitDistance returns the distance between the height of a block and the
+// height of the block carrying its canonical commit.
+func commitDistance(state sm.State) int64 {
+	if state.Version.Consensus.Module == "friday" {
+		return state.ConsensusParams.Block.LenULB
+	}
+	return 1
+}
+
+// Header gets the header at a given height, with its commit and validator set.
+// If no height is provided, it will fetch the latest header.
+// Unlike /commit, it is also served by the nodes which only sync the headers
+// (fastsync version "headers"). The commit is the canonical one if the block
+// of the next ULB height was committed, else the one seen by the node.
+//
+// ```shell
+// curl 'localhost:26657/header?height=10'
+// ```
+//
+// ```go
+// client := client.NewHTTP("tcp://0.0.0.0:26657", "/websocket")
+// err := client.Start()
+// if err != nil {
+//   // handle error
+// }
+// defer client.Stop()
+// info, err := client.Header(10)
+// ```
+//
+// > The above command returns JSON structured like this:
+//
+// ```json
+// {
+//   "jsonrpc": "2.0",
+//   "id": "",
+//   "result": {
+//     "signed_header": {
+//       "header": {...},
+//       "commit": {...}
+//     },
+//     "validators": {
+//       "validators": [...],
+//       "proposer": {...}
+//     }
+//   }
+// }
+// ```
+func Header(ctx *rpctypes.Context, heightPtr *int64) (*ctypes.ResultHeader, error) {
+	if headerStore != nil {
+		height, err := getHeight(headerStore.Height(), heightPtr)
+		if err != nil {
+			return nil, err
+		}
+		sh := headerStore.LoadSignedHeader(height)
+		if sh == nil {
+			return nil, fmt.Errorf("no header at height %d", height)
+		}
+		return &ctypes.ResultHeader{SignedHeader: *sh, Validators: headerStore.LoadValidators(height)}, nil
+	}
+
+	height, err := getHeight(blockStore.Height(), heightPtr)
+	if err != nil {
+		return nil, err
+	}
+	meta := blockStore.LoadBlockMeta(height)
+	if meta == nil {
+		return nil, fmt.Errorf("no header at height %d", height)
+	}
+	commit := blockStore.LoadBlockCommit(height)
+	if commit == nil {
+		commit = blockStore.LoadSeenCommit(height)
+	}
+	validators, err := sm.LoadValidators(stateDB, height)
+	if err != nil {
+		return nil, err
+	}
+	return &ctypes.ResultHeader{
+		SignedHeader: types.SignedHeader{Header: &meta.Header, Commit: commit},
+		Validators:   validators,
+	}, nil
 }
 
 // BlockResults gets ABCIResults at a given height.
@@ -342,6 +566,16 @@ func Commit(ctx *rpctypes.Context, heightPtr *int64) (*ctypes.ResultCommit, erro
 // Thus response.results.deliver_tx[5] is the results of executing
 // getBlock(h).Txs[5]
 //
+// The results of the txs can be filtered by event type and paginated, e.g. by
+// explorers pulling large blocks: `deliver_tx` then only has the results of
+// the page, and `tx_indexes` their indexes in the block. `total_count` is the
+// number of results matching the filter. Without `event_type`, `page` and
+// `per_page`, all the results are returned.
+//
+// `results_carrying_height` is the height of the block whose header carries
+// the app hash and results hash of the execution of the block: LenULB heights
+// above with the friday consensus, else the next height.
+//
 // ```shell
 // curl 'localhost:26657/block_results?height=10'
 // ```
@@ -356,6 +590,9 @@ func Commit(ctx *rpctypes.Context, heightPtr *int64) (*ctypes.ResultCommit, erro
 // info, err := client.BlockResults(10)
 // ```
 //
+// ```shell
+// curl 'localhost:26657/block_results?height=39&event_type="transfer"&page=2&per_page=1'
+// ```
 //
 // > The above command returns JSON structured like this:
 //
@@ -368,10 +605,15 @@ func Commit(ctx *rpctypes.Context, heightPtr *int64) (*ctypes.ResultCommit, erro
 //     "results": {
 //       "deliver_tx": [
 //         {
-//           "tags": [
+//           "events": [
 //             {
-//               "key": "YXBwLmNyZWF0b3I=",
-//               "value": "Q29zbW9zaGkgTmV0b3dva28="
+//               "type": "transfer",
+//               "attributes": [
+//                 {
+//                   "key": "YXBwLmNyZWF0b3I=",
+//                   "value": "Q29zbW9zaGkgTmV0b3dva28="
+//                 }
+//               ]
 //             }
 //           ]
 //         }
@@ -380,27 +622,97 @@ func Commit(ctx *rpctypes.Context, heightPtr *int64) (*ctypes.ResultCommit, erro
 //         "validator_updates": null
 //       },
 //       "begin_block": {}
-//     }
+//     },
+//     "total_count": "2",
+//     "tx_indexes": ["5"],
+//     "results_carrying_height": "43"
 //   }
 // }
 // ```
-func BlockResults(ctx *rpctypes.Context, heightPtr *int64) (*ctypes.ResultBlockResults, error) {
+//
+// ### Query Parameters
+//
+// | Parameter  | Type   | Default | Required | Description                                             |
+// |------------+--------+---------+----------+---------------------------------------------------------|
+// | height     | int64  | 0       | false    | Height to return. If no height is provided, the latest |
+// | event_type | string | ""      | false    | Only the results of the txs with an event of this type  |
+// | page       | int    | 1       | false    | Page number (1-based)                                   |
+// | per_page   | int    | 30      | false    | Number of entries per page (max: 100)                   |
+func BlockResults(ctx *rpctypes.Context, heightPtr *int64, eventType string, page, perPage int) (*ctypes.ResultBlockResults, error) {
 	storeHeight := blockStore.Height()
 	height, err := getHeight(storeHeight, heightPtr)
 	if err != nil {
 		return nil, err
 	}
 
-	results, err := sm.LoadABCIResponses(stateDB, height)
+	res, err := cachedResult("block_results", height, func() (interface{}, error) {
+		results, err := sm.LoadABCIResponses(stateDB, height)
+		if err != nil {
+			return nil, err
+		}
+		return &ctypes.ResultBlockResults{
+			Height:  height,
+			Results: results,
+		}, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	all := res.(*ctypes.ResultBlockResults)
+	result := &ctypes.ResultBlockResults{
+		Height:                height,
+		Results:               all.Results,
+		TotalCount:            len(all.Results.DeliverTx),
+		ResultsCarryingHeight: height + commitDistance(sm.LoadState(stateDB)),
+	}
+	if eventType == "" && page == 0 && perPage == 0 {
+		return result, nil
+	}
+
+	indexes, totalCount, err := filterDeliverTxs(all.Results.DeliverTx, eventType, page, perPage)
 	if err != nil {
 		return nil, err
 	}
+	// the cached results are shared, copy them
+	results := *all.Results
+	results.DeliverTx = make([]*abci.ResponseDeliverTx, len(indexes))
+	for i, index := range indexes {
+		results.DeliverTx[i] = all.Results.DeliverTx[index]
+	}
+	result.Results = &results
+	result.TotalCount = totalCount
+	result.TxIndexes = indexes
+	return result, nil
+}
 
-	res := &ctypes.ResultBlockResults{
-		Height:  height,
-		Results: results,
+// filterDeliverTxs returns the indexes of the results of the page among the
+// ones with an event of the given type (any type if empty), and the number of
+// these.
+func filterDeliverTxs(deliverTxs []*abci.ResponseDeliverTx, eventType string,
+	page, perPage int) (indexes []int, totalCount int, err error) {
+	indexes = []int{}
+	for i, deliverTx := range deliverTxs {
+		if eventType == "" || hasEventType(deliverTx.Events, eventType) {
+			indexes = append(indexes, i)
+		}
+	}
+	totalCount = len(indexes)
+	perPage = validatePerPage(perPage)
+	page, err = validatePage(page, perPage, totalCount)
+	if err != nil {
+		return nil, 0, err
+	}
+	skipCount := validateSkipCount(page, perPage)
+	return indexes[skipCount : skipCount+cmn.MinInt(perPage, totalCount-skipCount)], totalCount, nil
+}
+
+func hasEventType(events []abci.Event, eventType string) bool {
+	for _, event := range events {
+		if event.Type == eventType {
+			return true
+		}
 	}
-	return res, nil
+	return false
 }
 
 func getHeight(currentHeight int64, heightPtr *int64) (int64, error) {
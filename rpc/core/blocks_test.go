@@ -4,7 +4,15 @@ import (
 	"fmt"
 	"testing"
 
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	dbm "github.com/tendermint/tm-db"
+
+	abci "github.com/hdac-io/tendermint/abci/types"
+	ctypes "github.com/hdac-io/tendermint/rpc/core/types"
+	rpctypes "github.com/hdac-io/tendermint/rpc/lib/types"
+	sm "github.com/hdac-io/tendermint/state"
+	"github.com/hdac-io/tendermint/types"
 )
 
 func TestBlockchainInfo(t *testing.T) {
@@ -56,3 +64,125 @@ func TestBlockchainInfo(t *testing.T) {
 	}
 
 }
+
+// commitStore is a block store with the canonical commits of the heights up to
+// lastCanonical.
+type commitStore struct {
+	sm.BlockStore
+	height, lastCanonical int64
+}
+
+func (s commitStore) Height() int64 {
+	return s.height
+}
+
+func (s commitStore) LoadBlockMeta(height int64) *types.BlockMeta {
+	return &types.BlockMeta{Header: types.Header{Height: height}}
+}
+
+func (s commitStore) LoadBlockCommit(height int64) *types.Commit {
+	if height > s.lastCanonical {
+		return nil
+	}
+	return &types.Commit{}
+}
+
+func (s commitStore) LoadSeenCommit(height int64) *types.Commit {
+	return &types.Commit{}
+}
+
+func TestCommitSource(t *testing.T) {
+	genDoc := &types.GenesisDoc{ChainID: "commit-source", ConsensusModule: "friday"}
+	state, err := sm.MakeGenesisState(genDoc)
+	require.NoError(t, err)
+	lenULB := state.ConsensusParams.Block.LenULB
+	require.True(t, lenULB > 1)
+	stateDB = dbm.NewMemDB()
+	sm.SaveState(stateDB, state)
+
+	height := 10 + lenULB
+	blockStore = commitStore{height: height, lastCanonical: height - lenULB}
+	defer func() {
+		stateDB, blockStore = nil, nil
+	}()
+
+	// the last heights only have the commit seen by the node
+	for h := height - lenULB + 1; h <= height; h++ {
+		res, err := Commit(&rpctypes.Context{}, &h)
+		require.NoError(t, err)
+		assert.False(t, res.CanonicalCommit)
+		assert.Equal(t, ctypes.CommitSourceSeen, res.CommitSource)
+		assert.Zero(t, res.CarryingHeight)
+
+		_, err = ULBCommit(&rpctypes.Context{}, &h)
+		assert.Error(t, err, "height %d", h)
+	}
+
+	h := int64(1)
+	res, err := Commit(&rpctypes.Context{}, &h)
+	require.NoError(t, err)
+	assert.True(t, res.CanonicalCommit)
+	assert.Equal(t, ctypes.CommitSourceBlock, res.CommitSource)
+	assert.Equal(t, 1+lenULB, res.CarryingHeight)
+
+	// the last height with a canonical commit by default
+	res, err = ULBCommit(&rpctypes.Context{}, nil)
+	require.NoError(t, err)
+	assert.Equal(t, int64(10), res.Header.Height)
+	assert.Equal(t, ctypes.CommitSourceBlock, res.CommitSource)
+	assert.Equal(t, height, res.CarryingHeight)
+}
+
+func TestQuorumCert(t *testing.T) {
+	stateDB = dbm.NewMemDB()
+	blockStore = commitStore{height: 10}
+	defer func() {
+		stateDB, blockStore = nil, nil
+	}()
+	qc := &types.QuorumCert{Height: 9, BlockID: types.BlockID{Hash: []byte("hash")}, Signature: []byte("sig")}
+	sm.SaveQuorumCert(stateDB, qc)
+
+	h := int64(9)
+	res, err := QuorumCert(&rpctypes.Context{}, &h)
+	require.NoError(t, err)
+	assert.Equal(t, qc, res.QuorumCert)
+
+	// none at the latest height
+	_, err = QuorumCert(&rpctypes.Context{}, nil)
+	assert.Equal(t, sm.ErrNoQuorumCertForHeight{Height: 10}, err)
+}
+
+func TestFilterDeliverTxs(t *testing.T) {
+	deliverTxs := make([]*abci.ResponseDeliverTx, 5)
+	for i := range deliverTxs {
+		deliverTxs[i] = &abci.ResponseDeliverTx{}
+		if i%2 == 0 {
+			deliverTxs[i].Events = []abci.Event{{Type: "other"}, {Type: "transfer"}}
+		}
+	}
+
+	cases := []struct {
+		eventType     string
+		page, perPage int
+		indexes       []int
+		totalCount    int
+		wantErr       bool
+	}{
+		{"", 1, 2, []int{0, 1}, 5, false},
+		{"", 3, 2, []int{4}, 5, false},
+		{"", 4, 2, nil, 0, true},
+		{"transfer", 0, 0, []int{0, 2, 4}, 3, false},
+		{"transfer", 2, 2, []int{4}, 3, false},
+		{"none", 1, 2, []int{}, 0, false},
+	}
+	for i, c := range cases {
+		indexes, totalCount, err := filterDeliverTxs(deliverTxs, c.eventType, c.page, c.perPage)
+		if c.wantErr {
+			assert.Error(t, err, "case %d", i)
+			continue
+		}
+		require.NoError(t, err, "case %d", i)
+		assert.Equal(t, c.indexes, indexes, "case %d", i)
+		assert.Equal(t, c.totalCount, totalCount, "case %d", i)
+	}
+}
@@ -0,0 +1,139 @@
+package core
+
+import (
+	"container/list"
+	"sync"
+	"time"
+)
+
+// The explorers query the same historical heights again and again, so the
+// results of the queries by height are kept in memory (see cachedResult).
+var resultCache = newHeightResultCache(0, 0)
+
+type heightResultKey struct {
+	route  string
+	height int64
+}
+
+type heightResultEntry struct {
+	key   heightResultKey
+	value interface{}
+	// zero for the results of the finalized heights, which never change
+	expires time.Time
+}
+
+// heightResultCache is a LRU cache of the results of the RPC routes by
+// height.
+type heightResultCache struct {
+	mtx  sync.Mutex
+	size int
+	ttl  time.Duration
+	map_ map[heightResultKey]*list.Element
+	list *list.List // *heightResultEntry, the most recently used last
+}
+
+func newHeightResultCache(size int, ttl time.Duration) *heightResultCache {
+	return &heightResultCache{
+		size: size,
+		ttl:  ttl,
+		map_: make(map[heightResultKey]*list.Element, size),
+		list: list.New(),
+	}
+}
+
+// SetLimits sets the number of results cached, evicting the least recently
+// used ones above it, and the TTL of the results of the heights which are
+// not finalized.
+func (cache *heightResultCache) SetLimits(size int, ttl time.Duration) {
+	cache.mtx.Lock()
+	defer cache.mtx.Unlock()
+
+	cache.size = size
+	cache.ttl = ttl
+	for cache.list.Len() > size {
+		cache.remove(cache.list.Front())
+	}
+}
+
+// Enabled returns whether the cache keeps results.
+func (cache *heightResultCache) Enabled() bool {
+	cache.mtx.Lock()
+	defer cache.mtx.Unlock()
+	return cache.size > 0
+}
+
+// Get returns the result of route at height, if it is cached and not
+// expired.
+func (cache *heightResultCache) Get(route string, height int64, now time.Time) (interface{}, bool) {
+	cache.mtx.Lock()
+	defer cache.mtx.Unlock()
+
+	e, ok := cache.map_[heightResultKey{route, height}]
+	if !ok {
+		return nil, false
+	}
+	entry := e.Value.(*heightResultEntry)
+	if !entry.expires.IsZero() && !now.Before(entry.expires) {
+		cache.remove(e)
+		return nil, false
+	}
+	cache.list.MoveToBack(e)
+	return entry.value, true
+}
+
+// Set caches the result of route at height, forever if finalized, else for
+// the TTL, evicting the least recently used result if the cache is full.
+func (cache *heightResultCache) Set(route string, height int64, value interface{}, finalized bool, now time.Time) {
+	cache.mtx.Lock()
+	defer cache.mtx.Unlock()
+
+	if cache.size == 0 || (!finalized && cache.ttl == 0) {
+		return
+	}
+	entry := &heightResultEntry{key: heightResultKey{route, height}, value: value}
+	if !finalized {
+		entry.expires = now.Add(cache.ttl)
+	}
+	if e, ok := cache.map_[entry.key]; ok {
+		e.Value = entry
+		cache.list.MoveToBack(e)
+		return
+	}
+	if cache.list.Len() >= cache.size {
+		cache.remove(cache.list.Front())
+	}
+	cache.map_[entry.key] = cache.list.PushBack(entry)
+}
+
+// NOTE: cache.mtx must be locked
+func (cache *heightResultCache) remove(e *list.Element) {
+	delete(cache.map_, e.Value.(*heightResultEntry).key)
+	cache.list.Remove(e)
+}
+
+// cachedResult returns the result of route at height from the cache, or
+// loads it and caches it. The results of the heights whose canonical commit
+// was committed (see finalizedHeight) never change, so they are cached until
+// evicted; the ones of the heights above, e.g. the commit seen by the node,
+// only for rpc.result_cache_ttl. The errors are not cached.
+func cachedResult(route string, height int64, load func() (interface{}, error)) (interface{}, error) {
+	if !resultCache.Enabled() {
+		return load()
+	}
+	now := time.Now()
+	if result, ok := resultCache.Get(route, height, now); ok {
+		return result, nil
+	}
+	result, err := load()
+	if err != nil {
+		return nil, err
+	}
+	resultCache.Set(route, height, result, height <= finalizedHeight(), now)
+	return result, nil
+}
+
+// finalizedHeight returns the last height whose canonical commit was
+// committed: the data of the heights up to it never changes.
+func finalizedHeight() int64 {
+	return blockStore.Height() - commitDistance(consensusState.GetState())
+}
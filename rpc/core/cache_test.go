@@ -0,0 +1,54 @@
+package core
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHeightResultCache(t *testing.T) {
+	now := time.Now()
+	cache := newHeightResultCache(2, time.Second)
+
+	cache.Set("block", 1, "finalized", true, now)
+	cache.Set("block", 2, "mutable", false, now)
+	result, ok := cache.Get("block", 1, now.Add(time.Hour))
+	assert.True(t, ok)
+	assert.Equal(t, "finalized", result)
+	_, ok = cache.Get("commit", 1, now)
+	assert.False(t, ok, "cached by route")
+
+	// the results of the heights not finalized expire after the TTL
+	result, ok = cache.Get("block", 2, now.Add(time.Second-1))
+	assert.True(t, ok)
+	assert.Equal(t, "mutable", result)
+	_, ok = cache.Get("block", 2, now.Add(time.Second))
+	assert.False(t, ok)
+
+	// the least recently used result is evicted
+	cache.Set("block", 2, "finalized", true, now)
+	cache.Get("block", 1, now)
+	cache.Set("block", 3, "finalized", true, now)
+	_, ok = cache.Get("block", 2, now)
+	assert.False(t, ok)
+	_, ok = cache.Get("block", 1, now)
+	assert.True(t, ok)
+
+	// without TTL, the results of the heights not finalized are not cached
+	cache.SetLimits(2, 0)
+	cache.Set("block", 4, "mutable", false, now)
+	_, ok = cache.Get("block", 4, now)
+	assert.False(t, ok)
+
+	cache.SetLimits(1, time.Second)
+	_, ok = cache.Get("block", 3, now)
+	assert.False(t, ok)
+	_, ok = cache.Get("block", 1, now)
+	assert.True(t, ok)
+
+	cache.SetLimits(0, time.Second)
+	cache.Set("block", 5, "finalized", true, now)
+	_, ok = cache.Get("block", 5, now)
+	assert.False(t, ok, "disabled")
+}
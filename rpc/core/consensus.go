@@ -1,7 +1,14 @@
 package core
 
 import (
+	"fmt"
+
+	"github.com/pkg/errors"
+
+	dbm "github.com/tendermint/tm-db"
+
 	cm "github.com/hdac-io/tendermint/consensus"
+	cmn "github.com/hdac-io/tendermint/libs/common"
 	ctypes "github.com/hdac-io/tendermint/rpc/core/types"
 	rpctypes "github.com/hdac-io/tendermint/rpc/lib/types"
 	sm "github.com/hdac-io/tendermint/state"
@@ -59,13 +66,141 @@ func Validators(ctx *rpctypes.Context, heightPtr *int64) (*ctypes.ResultValidato
 		return nil, err
 	}
 
-	validators, err := sm.LoadValidators(stateDB, height)
+	result, err := cachedResult("validators", height, func() (interface{}, error) {
+		validators, err := sm.LoadValidators(stateDB, height)
+		if err != nil {
+			return nil, err
+		}
+		return &ctypes.ResultValidators{
+			BlockHeight: height,
+			Validators:  validators.Validators}, nil
+	})
 	if err != nil {
 		return nil, err
 	}
-	return &ctypes.ResultValidators{
-		BlockHeight: height,
-		Validators:  validators.Validators}, nil
+	return result.(*ctypes.ResultValidators), nil
+}
+
+// ProposerSchedule returns the expected proposers of the rounds of the heights
+// from `from` to `to` (both included), so operators can plan a maintenance
+// without missing their proposals. The first heights default to the next
+// height and the ones in the ULB pipeline after it, and rounds to 1 (the
+// proposers of round 0 only).
+//
+// The proposers are computed from the validator sets and their proposer
+// priorities. The validator sets of the heights after the last one saved are
+// estimated from it, assuming no validator changes: the proposers of these
+// slots are marked as estimated.
+//
+// ```shell
+// curl 'localhost:26657/proposer_schedule?from=10&to=12&rounds=2'
+// ```
+//
+// The above command returns JSON structured like this:
+//
+// ```json
+// {
+// 	"error": "",
+// 	"result": {
+// 		"last_height": "8",
+// 		"proposers": [
+// 			{
+// 				"height": "10",
+// 				"round": "0",
+// 				"address": "E89A51D60F68385E09E716D353373B11F8FACD62",
+// 				"estimated": false
+// 			},
+// 			{
+// 				"height": "10",
+// 				"round": "1",
+// 				"address": "3E3A8D6C9F6E74F9D8B1A4C4A9A7E3A5D7D2F6B1",
+// 				"estimated": false
+// 			},
+// 			...
+// 		]
+// 	},
+// 	"id": "",
+// 	"jsonrpc": "2.0"
+// }
+// ```
+func ProposerSchedule(ctx *rpctypes.Context, fromPtr, toPtr *int64, roundsPtr *int) (*ctypes.ResultProposerSchedule, error) {
+	state := consensusState.GetState()
+	from := state.LastBlockHeight + 1
+	if fromPtr != nil {
+		from = *fromPtr
+	}
+	to := from + state.ConsensusParams.Block.LenULB
+	if toPtr != nil {
+		to = *toPtr
+	}
+	rounds := 1
+	if roundsPtr != nil {
+		rounds = *roundsPtr
+	}
+
+	proposers, err := proposerSchedule(stateDB, state.LastBlockHeight, from, to, rounds)
+	if err != nil {
+		return nil, err
+	}
+	return &ctypes.ResultProposerSchedule{
+		LastHeight: state.LastBlockHeight,
+		Proposers:  proposers}, nil
+}
+
+const (
+	maxProposerScheduleHeights = 1000
+	maxProposerScheduleRounds  = 10
+)
+
+func proposerSchedule(db dbm.DB, lastHeight, from, to int64, rounds int) ([]ctypes.ProposerSlot, error) {
+	switch {
+	case from <= 0:
+		return nil, fmt.Errorf("from must be greater than 0")
+	case to < from:
+		return nil, fmt.Errorf("to must be greater than or equal to from")
+	case to-from >= maxProposerScheduleHeights:
+		return nil, fmt.Errorf("at most %d heights can be scheduled", maxProposerScheduleHeights)
+	case from > lastHeight+maxProposerScheduleHeights:
+		return nil, fmt.Errorf("from must be less than or equal to %d", lastHeight+maxProposerScheduleHeights)
+	case rounds <= 0 || rounds > maxProposerScheduleRounds:
+		return nil, fmt.Errorf("rounds must be between 1 and %d", maxProposerScheduleRounds)
+	}
+
+	// the validators of the next height are always saved
+	var (
+		known       *types.ValidatorSet
+		knownHeight int64
+	)
+	start := cmn.MinInt64(from, lastHeight+1)
+	proposers := make([]ctypes.ProposerSlot, 0, int(to-from+1)*rounds)
+	for height := start; height <= to; height++ {
+		validators, err := sm.LoadValidators(db, height)
+		switch {
+		case err == nil:
+			known, knownHeight = validators, height
+		case height <= lastHeight+1 || known == nil:
+			return nil, err
+		}
+		if height < from {
+			continue
+		}
+
+		estimated := knownHeight != height
+		for round := 0; round < rounds; round++ {
+			times := int(height-knownHeight) + round
+			proposer := known.GetProposer()
+			if times > 0 {
+				proposer = known.CopyIncrementProposerPriority(times).GetProposer()
+			}
+			proposers = append(proposers, ctypes.ProposerSlot{
+				Height:    height,
+				Round:     round,
+				Address:   proposer.Address,
+				Estimated: estimated,
+			})
+		}
+	}
+	return proposers, nil
 }
 
 // DumpConsensusState dumps consensus state.
@@ -286,6 +421,183 @@ func ConsensusState(ctx *rpctypes.Context) (*ctypes.ResultConsensusState, error)
 	return &ctypes.ResultConsensusState{RoundState: bz}, err
 }
 
+// consensusFailures is implemented by the consensus modules which keep a
+// diagnostic bundle of their last failure.
+type consensusFailures interface {
+	GetLastFailureJSON() ([]byte, error)
+}
+
+// ConsensusFailure returns the diagnostic bundle captured by the friday
+// consensus the last time it failed: the error and its stack, the round
+// states, the last WAL records and the stacks of all the goroutines. The
+// failure is null if the consensus never failed. As the bundle exposes the
+// internals of the node, it's only served with rpc.unsafe, to the admin role
+// when the RPC authentication is enabled.
+// UNSTABLE
+//
+// ```shell
+// curl 'localhost:26657/consensus_failure'
+// ```
+//
+// The above command returns JSON structured like this:
+//
+// ```json
+//{
+//  "jsonrpc": "2.0",
+//  "id": "",
+//  "result": {
+//    "failure": {
+//      "time": "2019-11-05T08:14:57.213417Z",
+//      "error": "runtime error: invalid memory address or nil pointer dereference",
+//      "stack": "goroutine 112 [running]:...",
+//      "failures": 1,
+//      "halted": true,
+//      "round_states": [...],
+//      "debug": {...},
+//      "wal_records": [...],
+//      "goroutines": "goroutine 1 [select]:..."
+//    }
+//  }
+//}
+//```
+func ConsensusFailure(ctx *rpctypes.Context) (*ctypes.ResultConsensusFailure, error) {
+	cf, ok := consensusState.(consensusFailures)
+	if !ok {
+		return nil, errors.New("the consensus module doesn't keep its failures")
+	}
+	bz, err := cf.GetLastFailureJSON()
+	return &ctypes.ResultConsensusFailure{Failure: bz}, err
+}
+
+// voteDelays is implemented by the consensus modules which record the vote
+// delays of the validators.
+type voteDelays interface {
+	GetVoteDelaysJSON() ([]byte, error)
+}
+
+// VoteDelays returns, by validator address, the delays between the entry of
+// the friday consensus in the prevote and precommit steps of the heights and
+// the arrival of the votes of the validators: their count, and their mean,
+// max and last delay in nanoseconds. A vote received before the step was
+// entered has no delay. The validators are sorted by mean precommit delay,
+// slowest first.
+// UNSTABLE
+//
+// ```shell
+// curl 'localhost:26657/vote_delays'
+// ```
+//
+// The above command returns JSON structured like this:
+//
+// ```json
+//{
+//  "jsonrpc": "2.0",
+//  "id": "",
+//  "result": {
+//    "vote_delays": [
+//      {
+//        "address": "B5B3D40BE53982AD294EF99FF5A34C0C3E5A3244",
+//        "prevote": {
+//          "count": 152,
+//          "mean": 84211345,
+//          "max": 410239127,
+//          "last": 61023841
+//        },
+//        "precommit": {
+//          "count": 150,
+//          "mean": 102345901,
+//          "max": 523410239,
+//          "last": 90234123
+//        }
+//      }
+//    ]
+//  }
+//}
+//```
+func VoteDelays(ctx *rpctypes.Context) (*ctypes.ResultVoteDelays, error) {
+	vd, ok := consensusState.(voteDelays)
+	if !ok {
+		return nil, errors.New("the consensus module doesn't record vote delays")
+	}
+	bz, err := vd.GetVoteDelaysJSON()
+	return &ctypes.ResultVoteDelays{VoteDelays: bz}, err
+}
+
+// voteTally is implemented by the consensus modules which tally the votes of
+// the heights in progress.
+type voteTally interface {
+	GetVoteTallyJSON(height int64, round int) ([]byte, error)
+}
+
+// VoteTally returns the voting power of the prevotes and precommits received
+// for the current round of each height in progress, the validators who voted,
+// as bit arrays by index in the validator set, and the block which got +2/3 of
+// the votes, if any. With a height, only the height is tallied, and with a
+// round, the round instead of the current one.
+// UNSTABLE
+//
+// ```shell
+// curl 'localhost:26657/vote_tally?height=11&round=0'
+// ```
+//
+// The above command returns JSON structured like this:
+//
+// ```json
+//{
+//  "jsonrpc": "2.0",
+//  "id": "",
+//  "result": {
+//    "vote_tally": [
+//      {
+//        "height": 11,
+//        "round": 0,
+//        "step": "RoundStepPrecommit",
+//        "total_voting_power": 40,
+//        "prevotes": {
+//          "voting_power": 30,
+//          "fraction": 0.75,
+//          "bit_array": "xxx_",
+//          "maj23": {
+//            "hash": "9D2D6CB9D3A6C7D3B9A1D8B5C6F7A8B9C0D1E2F3A4B5C6D7E8F9A0B1C2D3E4F5",
+//            "parts": {
+//              "total": "1",
+//              "hash": "3F3F7D9A1B2C3D4E5F60718293A4B5C6D7E8F90A1B2C3D4E5F60718293A4B5C6"
+//            }
+//          }
+//        },
+//        "precommits": {
+//          "voting_power": 20,
+//          "fraction": 0.5,
+//          "bit_array": "xx__"
+//        }
+//      }
+//    ]
+//  }
+//}
+//```
+func VoteTally(ctx *rpctypes.Context, heightPtr *int64, roundPtr *int) (*ctypes.ResultVoteTally, error) {
+	vt, ok := consensusState.(voteTally)
+	if !ok {
+		return nil, errors.New("the consensus module doesn't tally the votes of the heights in progress")
+	}
+	var height int64
+	if heightPtr != nil {
+		if *heightPtr <= 0 {
+			return nil, fmt.Errorf("height must be greater than 0, but got %d", *heightPtr)
+		}
+		height = *heightPtr
+	}
+	round := -1
+	if roundPtr != nil {
+		if *roundPtr < 0 {
+			return nil, fmt.Errorf("round must not be negative, but got %d", *roundPtr)
+		}
+		round = *roundPtr
+	}
+	bz, err := vt.GetVoteTallyJSON(height, round)
+	return &ctypes.ResultVoteTally{VoteTally: bz}, err
+}
+
 // Get the consensus parameters  at the given block height.
 // If no height is provided, it will fetch the current consensus params.
 //
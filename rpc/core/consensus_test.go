@@ -0,0 +1,74 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	dbm "github.com/tendermint/tm-db"
+
+	sm "github.com/hdac-io/tendermint/state"
+	"github.com/hdac-io/tendermint/types"
+)
+
+func TestProposerSchedule(t *testing.T) {
+	valSet, _ := types.RandValidatorSet(4, 10)
+	genDoc := &types.GenesisDoc{ChainID: "proposer-schedule", ConsensusModule: "friday"}
+	for i, val := range valSet.Validators {
+		genDoc.Validators = append(genDoc.Validators, types.GenesisValidator{
+			Address: val.Address,
+			PubKey:  val.PubKey,
+			Power:   val.VotingPower + int64(i), // different priorities
+		})
+	}
+	state, err := sm.MakeGenesisState(genDoc)
+	require.NoError(t, err)
+	db := dbm.NewMemDB()
+	sm.SaveState(db, state)
+
+	// the validators of the heights of the ULB window are saved
+	lastSaved := 1 + state.ConsensusParams.Block.LenULB
+	to := lastSaved + 3
+	proposers, err := proposerSchedule(db, 0, 1, to, 3)
+	require.NoError(t, err)
+	require.Len(t, proposers, int(to)*3)
+	for i, slot := range proposers {
+		height, round := int64(i/3+1), i%3
+		assert.Equal(t, height, slot.Height)
+		assert.Equal(t, round, slot.Round)
+		assert.Equal(t, height > lastSaved, slot.Estimated, "height %d", height)
+
+		proposer := state.Validators.Copy()
+		if times := int(height-1) + round; times > 0 {
+			proposer.IncrementProposerPriority(times)
+		}
+		assert.Equal(t, proposer.GetProposer().Address, slot.Address, "height %d round %d", height, round)
+	}
+
+	// the estimated heights don't depend on the first height
+	later, err := proposerSchedule(db, 0, lastSaved+2, to, 3)
+	require.NoError(t, err)
+	assert.Equal(t, proposers[(lastSaved+1)*3:], later)
+
+	cases := []struct {
+		from, to int64
+		rounds   int
+	}{
+		{0, 1, 1},
+		{2, 1, 1},
+		{1, 1 + maxProposerScheduleHeights, 1},
+		{2 + maxProposerScheduleHeights, 2 + maxProposerScheduleHeights, 1},
+		{1, 1, 0},
+		{1, 1, maxProposerScheduleRounds + 1},
+	}
+	for i, c := range cases {
+		_, err := proposerSchedule(db, 0, c.from, c.to, c.rounds)
+		assert.Error(t, err, "#%d", i)
+	}
+}
+
+func TestConsensusFailureIsUnsafe(t *testing.T) {
+	// the failure bundle exposes the stacks and the WAL records of the node
+	assert.NotContains(t, Routes, "consensus_failure")
+	assert.Contains(t, UnsafeRouteNames(), "consensus_failure")
+}
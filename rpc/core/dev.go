@@ -1,6 +1,7 @@
 package core
 
 import (
+	"errors"
 	"os"
 	"runtime/pprof"
 
@@ -14,6 +15,38 @@ func UnsafeFlushMempool(ctx *rpctypes.Context) (*ctypes.ResultUnsafeFlushMempool
 	return &ctypes.ResultUnsafeFlushMempool{}, nil
 }
 
+// Reload the config file of the node, applying the fields which can be changed
+// on a running node: the log level, the consensus timeouts, the mempool size and
+// the RPC subscription limits. The other changed fields are reported as ignored,
+// and need a restart. Sending the node SIGHUP reloads it too.
+//
+// ```shell
+// curl 'localhost:26657/reload_config'
+// ```
+//
+// > The above command returns JSON structured like this:
+//
+// ```json
+// {
+//   "jsonrpc": "2.0",
+//   "id": "",
+//   "result": {
+//     "reloaded": ["consensus.timeout_propose"],
+//     "ignored": ["p2p.laddr"]
+//   }
+// }
+// ```
+func UnsafeReloadConfig(ctx *rpctypes.Context) (*ctypes.ResultReloadConfig, error) {
+	if configLoader == nil {
+		return nil, errors.New("the config can't be reloaded")
+	}
+	reloaded, ignored, err := configLoader.ReloadConfig()
+	if err != nil {
+		return nil, err
+	}
+	return &ctypes.ResultReloadConfig{Reloaded: reloaded, Ignored: ignored}, nil
+}
+
 var profFile *os.File
 
 // UnsafeStartCPUProfiler starts a pprof profiler using the given filename.
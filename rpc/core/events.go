@@ -27,8 +27,16 @@ import (
 //		tm.event = 'Tx' AND tx.hash = 'XYZ' # single transaction
 //		tm.event = 'Tx' AND tx.height = 5   # all txs of the fifth block
 //		tx.height = 5                       # all txs of the fifth block
-//
-// Tendermint provides a few predefined keys: tm.event, tx.hash and tx.height.
+//		block.height >= 5 AND block.height <= 9 AND consensus.pipeline_stage = 'finalized'
+//		                                    # blocks and txs of heights 5 to 9
+//
+// Tendermint provides a few predefined keys: tm.event, tx.hash, tx.height,
+// block.height and consensus.pipeline_stage. block.height is the height the
+// block, tx and consensus events are about, and consensus.pipeline_stage is
+// the stage of that height when the event occurred: 'propose', 'vote',
+// 'commit' or 'finalized'. Queries on tm.event, block.height and
+// consensus.pipeline_stage are indexed, so prefer them to filtering
+// client-side.
 // Note for transactions, you can define additional keys by providing events with
 // DeliverTx response.
 //
@@ -150,6 +158,7 @@ import (
 // <aside class="notice">WebSocket only</aside>
 func Subscribe(ctx *rpctypes.Context, query string) (*ctypes.ResultSubscribe, error) {
 	addr := ctx.RemoteAddr()
+	config := rpcConfig()
 
 	if eventBus.NumClients() >= config.MaxSubscriptionClients {
 		return nil, fmt.Errorf("max_subscription_clients %d reached", config.MaxSubscriptionClients)
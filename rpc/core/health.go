@@ -1,12 +1,32 @@
 package core
 
 import (
+	"time"
+
+	fridaycs "github.com/hdac-io/tendermint/consensus/friday"
 	ctypes "github.com/hdac-io/tendermint/rpc/core/types"
 	rpctypes "github.com/hdac-io/tendermint/rpc/lib/types"
+	tmtime "github.com/hdac-io/tendermint/types/time"
 )
 
-// Get node health. Returns empty result (200 OK) on success, no response - in
-// case of an error.
+// pipelineHealth is implemented by the consensus modules which track the
+// conditions stalling their heights.
+type pipelineHealth interface {
+	GetHealth() fridaycs.Health
+}
+
+// Get node health. Reports, each in its own field, the conditions stalling
+// the heights, so that load balancers and alerting can act on a specific one:
+//
+// - finalize_stalled: no height was finalized for rpc.health_finalize_timeout
+// (not checked while catching up)
+// - wait_finalize_stalled: a committed height has been waiting for
+// rpc.health_wait_finalize_timeout for a lower height to be finalized
+// - wal_write_failing: the last writes of the consensus WAL failed
+// - priv_validator_unreachable: the privValidator failed to sign the last votes
+//
+// healthy is false if any of them is detected. Only the finalize stall is
+// detected with the tendermint consensus, from the time of the last block.
 //
 // ```shell
 // curl 'localhost:26657/health'
@@ -27,11 +47,60 @@ import (
 // ```json
 // {
 // 	"error": "",
-// 	"result": {},
+// 	"result": {
+// 		"healthy": false,
+// 		"catching_up": false,
+// 		"last_height": "1262",
+// 		"last_finalized_time": "2019-11-05T08:14:57.213417Z",
+// 		"finalize_stalled": true,
+// 		"wait_finalize": "0",
+// 		"wait_finalize_stalled": false,
+// 		"wal_write_failing": false,
+// 		"priv_validator_unreachable": true,
+// 		"priv_validator_error": "after 1s: Error timed out waiting for the privValidator to sign the vote"
+// 	},
 // 	"id": "",
 // 	"jsonrpc": "2.0"
 // }
 // ```
 func Health(ctx *rpctypes.Context) (*ctypes.ResultHealth, error) {
-	return &ctypes.ResultHealth{}, nil
+	now := tmtime.Now()
+	config := rpcConfig()
+	result := &ctypes.ResultHealth{CatchingUp: consensusReactor.FastSync()}
+
+	if ph, ok := consensusState.(pipelineHealth); ok && !result.CatchingUp {
+		health := ph.GetHealth()
+		result.LastHeight = health.LastHeight
+		result.LastFinalizedTime = health.LastFinalizedTime
+		if !health.WaitFinalizeSince.IsZero() {
+			result.WaitFinalize = now.Sub(health.WaitFinalizeSince)
+		}
+		if health.WALWriteFailures > 0 {
+			result.WALWriteFailing = true
+			result.WALWriteError = health.WALWriteError.Error()
+		}
+		if health.SignVoteFailures > 0 {
+			result.PrivValidatorUnreachable = true
+			result.PrivValidatorError = health.SignVoteError.Error()
+		}
+	} else {
+		result.LastHeight = blockStore.Height()
+		if genDoc != nil {
+			result.LastFinalizedTime = genDoc.GenesisTime
+		}
+		if meta := blockStore.LoadBlockMeta(result.LastHeight); meta != nil {
+			result.LastFinalizedTime = meta.Header.Time
+		}
+	}
+
+	result.FinalizeStalled = !result.CatchingUp && exceeds(now.Sub(result.LastFinalizedTime), config.HealthFinalizeTimeout)
+	result.WaitFinalizeStalled = exceeds(result.WaitFinalize, config.HealthWaitFinalizeTimeout)
+	result.Healthy = !result.FinalizeStalled && !result.WaitFinalizeStalled &&
+		!result.WALWriteFailing && !result.PrivValidatorUnreachable
+	return result, nil
+}
+
+// exceeds returns whether d exceeds the timeout, if the timeout is enabled.
+func exceeds(d, timeout time.Duration) bool {
+	return timeout > 0 && d > timeout
 }
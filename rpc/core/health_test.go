@@ -0,0 +1,76 @@
+package core
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	cfg "github.com/hdac-io/tendermint/config"
+	fridaycs "github.com/hdac-io/tendermint/consensus/friday"
+	"github.com/hdac-io/tendermint/p2p"
+	rpctypes "github.com/hdac-io/tendermint/rpc/lib/types"
+	"github.com/hdac-io/tendermint/types"
+	tmtime "github.com/hdac-io/tendermint/types/time"
+)
+
+type healthReactor struct {
+	p2p.BaseReactor
+	fastSync bool
+}
+
+func (r *healthReactor) SetEventBus(*types.EventBus) {}
+func (r *healthReactor) FastSync() bool              { return r.fastSync }
+
+type healthConsensus struct {
+	Consensus
+	health fridaycs.Health
+}
+
+func (cs *healthConsensus) GetHealth() fridaycs.Health { return cs.health }
+
+func TestHealth(t *testing.T) {
+	reactor := &healthReactor{}
+	cs := &healthConsensus{}
+	SetConsensusReactor(reactor)
+	SetConsensusState(cs)
+	SetConfig(*cfg.DefaultRPCConfig())
+
+	now := tmtime.Now()
+	cs.health = fridaycs.Health{LastHeight: 10, LastFinalizedTime: now}
+	res, err := Health(&rpctypes.Context{})
+	require.NoError(t, err)
+	assert.True(t, res.Healthy)
+	assert.EqualValues(t, 10, res.LastHeight)
+
+	// no height finalized for too long, while a height waits
+	cs.health.LastFinalizedTime = now.Add(-2 * time.Minute)
+	cs.health.WaitFinalizeSince = now.Add(-time.Minute)
+	res, err = Health(&rpctypes.Context{})
+	require.NoError(t, err)
+	assert.False(t, res.Healthy)
+	assert.True(t, res.FinalizeStalled)
+	assert.True(t, res.WaitFinalizeStalled)
+	assert.True(t, res.WaitFinalize >= time.Minute)
+	assert.False(t, res.WALWriteFailing)
+	assert.False(t, res.PrivValidatorUnreachable)
+
+	cs.health = fridaycs.Health{
+		LastHeight:        10,
+		LastFinalizedTime: now,
+		WALWriteFailures:  1,
+		WALWriteError:     errors.New("disk full"),
+		SignVoteFailures:  2,
+		SignVoteError:     errors.New("connection refused"),
+	}
+	res, err = Health(&rpctypes.Context{})
+	require.NoError(t, err)
+	assert.False(t, res.Healthy)
+	assert.False(t, res.FinalizeStalled)
+	assert.True(t, res.WALWriteFailing)
+	assert.Equal(t, "disk full", res.WALWriteError)
+	assert.True(t, res.PrivValidatorUnreachable)
+	assert.Equal(t, "connection refused", res.PrivValidatorError)
+}
@@ -82,6 +82,16 @@ func BroadcastTxAsync(ctx *rpctypes.Context, tx types.Tx) (*ctypes.ResultBroadca
 
 // Returns with the response from CheckTx. Does not wait for DeliverTx result.
 //
+// If CheckTx passed, the response also tells where the tx is in the pipeline:
+// `proposal_height` is the height of the in-flight proposal which already
+// included the tx (e.g. a tx received by another node first), if any, and
+// `estimated_finalized_height` the height at which the block including the tx
+// is expected to be finalized: the proposal height, or else the first height
+// after the LenULB heights which may already be in flight, as their proposals
+// may have been created without the tx. Wallets can show the tx as pending
+// until the `Tx` event, then included until the block of that height is
+// finalized.
+//
 // If you want to be sure that the transaction is included in a block, you can
 // subscribe for the result using JSONRPC via a websocket. See
 // https://tendermint.com/docs/app-dev/subscribing-to-events-via-websocket.html
@@ -122,7 +132,9 @@ func BroadcastTxAsync(ctx *rpctypes.Context, tx types.Tx) (*ctypes.ResultBroadca
 // 		"code": "0",
 // 		"data": "",
 // 		"log": "",
-// 		"hash": "0D33F2F03A5234F38706E43004489E061AC40A2E"
+// 		"hash": "0D33F2F03A5234F38706E43004489E061AC40A2E",
+// 		"proposal_height": "12",
+// 		"estimated_finalized_height": "12"
 // 	},
 // 	"error": ""
 // }
@@ -143,12 +155,27 @@ func BroadcastTxSync(ctx *rpctypes.Context, tx types.Tx) (*ctypes.ResultBroadcas
 	}
 	res := <-resCh
 	r := res.GetCheckTx()
-	return &ctypes.ResultBroadcastTx{
+	result := &ctypes.ResultBroadcastTx{
 		Code: r.Code,
 		Data: r.Data,
 		Log:  r.Log,
 		Hash: tx.Hash(),
-	}, nil
+	}
+	if r.Code == abci.CodeTypeOK {
+		result.ProposalHeight, result.EstimatedFinalizedHeight = txPlacement(tx)
+	}
+	return result, nil
+}
+
+// txPlacement returns the height of the proposal which reserved the tx, if
+// any, and the height at which the block including the tx is expected to be
+// finalized.
+func txPlacement(tx types.Tx) (proposalHeight, finalizedHeight int64) {
+	if height, reserved := mempool.ReservedHeight(tx); reserved {
+		return height, height
+	}
+	state := consensusState.GetState()
+	return 0, state.LastBlockHeight + state.ConsensusParams.Block.LenULB + 1
 }
 
 // Returns with the responses from CheckTx and DeliverTx.
@@ -214,6 +241,7 @@ func BroadcastTxSync(ctx *rpctypes.Context, tx types.Tx) (*ctypes.ResultBroadcas
 // | tx        | Tx   | nil     | true     | The transaction |
 func BroadcastTxCommit(ctx *rpctypes.Context, tx types.Tx) (*ctypes.ResultBroadcastTxCommit, error) {
 	subscriber := ctx.RemoteAddr()
+	config := rpcConfig()
 
 	if eventBus.NumClients() >= config.MaxSubscriptionClients {
 		return nil, fmt.Errorf("max_subscription_clients %d reached", config.MaxSubscriptionClients)
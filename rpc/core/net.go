@@ -2,6 +2,9 @@ package core
 
 import (
 	"fmt"
+	"sort"
+	"strings"
+	"time"
 
 	"github.com/pkg/errors"
 
@@ -191,11 +194,20 @@ func UnsafeDialSeeds(ctx *rpctypes.Context, seeds []string) (*ctypes.ResultDialS
 	return &ctypes.ResultDialSeeds{Log: "Dialing seeds in progress. See /net_info for details"}, nil
 }
 
-func UnsafeDialPeers(ctx *rpctypes.Context, peers []string, persistent bool) (*ctypes.ResultDialPeers, error) {
+func UnsafeDialPeers(ctx *rpctypes.Context, peers []string, persistent, unconditional bool) (*ctypes.ResultDialPeers, error) {
 	if len(peers) == 0 {
 		return &ctypes.ResultDialPeers{}, errors.New("No peers provided")
 	}
-	logger.Info("DialPeers", "peers", peers, "persistent", persistent)
+	logger.Info("DialPeers", "peers", peers, "persistent", persistent, "unconditional", unconditional)
+	if unconditional {
+		ids, err := getIDs(peers)
+		if err != nil {
+			return &ctypes.ResultDialPeers{}, err
+		}
+		if err := p2pPeers.AddUnconditionalPeerIDs(ids); err != nil {
+			return &ctypes.ResultDialPeers{}, err
+		}
+	}
 	if persistent {
 		if err := p2pPeers.AddPersistentPeers(peers); err != nil {
 			return &ctypes.ResultDialPeers{}, err
@@ -207,6 +219,231 @@ func UnsafeDialPeers(ctx *rpctypes.Context, peers []string, persistent bool) (*c
 	return &ctypes.ResultDialPeers{Log: "Dialing peers in progress. See /net_info for details"}, nil
 }
 
+// Get the trust scores of the peers.
+//
+// The score of a peer, between 0 and 100, drops when it misbehaves, e.g. sends
+// block parts of rounds we have passed, and rises when it's useful. The peers
+// banned with /ban_peer are listed with the time their ban expires.
+//
+// ```shell
+// curl 'localhost:26657/peer_scores'
+// ```
+//
+// > The above command returns JSON structured like this:
+//
+// ```json
+// {
+//   "jsonrpc": "2.0",
+//   "id": "",
+//   "result": {
+//     "peers": [
+//       {
+//         "node_id": "93529da3435c090d02251a050342b6a488d4ab5693529da3435c090d02251a05",
+//         "score": 87,
+//         "connected": true
+//       },
+//       {
+//         "node_id": "3ed8e1c3e4b8a1d6f7b1b4c0b7a44b5eb3e3e59c3ed8e1c3e4b8a1d6f7b1b4c0",
+//         "score": 12,
+//         "connected": false,
+//         "banned_until": "2019-11-05T10:20:30.123456789Z"
+//       }
+//     ]
+//   }
+// }
+// ```
+func PeerScores(ctx *rpctypes.Context) (*ctypes.ResultPeerScores, error) {
+	scores := p2pPeers.PeerTrustScores()
+	bans := p2pPeers.BannedPeers()
+
+	ids := make(map[p2p.ID]struct{}, len(scores)+len(bans))
+	for id := range scores {
+		ids[id] = struct{}{}
+	}
+	for id := range bans {
+		ids[id] = struct{}{}
+	}
+	peers := make([]ctypes.PeerScore, 0, len(ids))
+	for id := range ids {
+		peer := ctypes.PeerScore{
+			NodeID:    id,
+			Score:     scores[id],
+			Connected: p2pPeers.Peers().Has(id),
+		}
+		if until, ok := bans[id]; ok {
+			peer.BannedUntil = &until
+		}
+		peers = append(peers, peer)
+	}
+	sort.Slice(peers, func(i, j int) bool { return peers[i].NodeID < peers[j].NodeID })
+	return &ctypes.ResultPeerScores{Peers: peers}, nil
+}
+
+// Ban a peer for the given duration, like "1h" or "30m", and disconnect from
+// it. The node neither dials a banned peer nor accepts its connections, even
+// after a restart, until its ban expires.
+//
+// ```shell
+// curl 'localhost:26657/ban_peer?peer_id="93529da3435c090d02251a050342b6a488d4ab5693529da3435c090d02251a05"&duration="24h"'
+// ```
+//
+// > The above command returns JSON structured like this:
+//
+// ```json
+// {
+//   "jsonrpc": "2.0",
+//   "id": "",
+//   "result": {
+//     "banned_until": "2019-11-05T10:20:30.123456789Z"
+//   }
+// }
+// ```
+func UnsafeBanPeer(ctx *rpctypes.Context, peerID string, duration string) (*ctypes.ResultBanPeer, error) {
+	id := p2p.ID(peerID)
+	d, err := time.ParseDuration(duration)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid duration")
+	}
+	logger.Info("BanPeer", "peer", id, "duration", d)
+	if err := p2pPeers.BanPeer(id, d); err != nil {
+		return nil, err
+	}
+	until, ok := p2pPeers.BannedPeers()[id]
+	if !ok {
+		return nil, fmt.Errorf("peer %v is not banned", id)
+	}
+	return &ctypes.ResultBanPeer{BannedUntil: until}, nil
+}
+
+// Lift the ban of a peer.
+//
+// ```shell
+// curl 'localhost:26657/unban_peer?peer_id="93529da3435c090d02251a050342b6a488d4ab5693529da3435c090d02251a05"'
+// ```
+func UnsafeUnbanPeer(ctx *rpctypes.Context, peerID string) (*ctypes.ResultUnbanPeer, error) {
+	id := p2p.ID(peerID)
+	logger.Info("UnbanPeer", "peer", id)
+	if err := p2pPeers.UnbanPeer(id); err != nil {
+		return nil, err
+	}
+	return &ctypes.ResultUnbanPeer{}, nil
+}
+
+// Ask the peers for addresses and dial the addresses of the address book at
+// once, instead of waiting for the next period of the PEX reactor, e.g. after
+// importing addresses with import-addrbook. It fails if the PEX reactor is
+// disabled, or if the last crawl was too recent for the peers to answer.
+//
+// ```shell
+// curl 'localhost:26657/crawl_peers'
+// ```
+//
+// > The above command returns JSON structured like this:
+//
+// ```json
+// {
+//   "jsonrpc": "2.0",
+//   "id": "",
+//   "result": {
+//     "log": "Crawling peers in progress. See /net_info for details"
+//   }
+// }
+// ```
+func UnsafeCrawlPeers(ctx *rpctypes.Context) (*ctypes.ResultCrawlPeers, error) {
+	if crawler == nil {
+		return nil, errors.New("the PEX reactor is disabled")
+	}
+	logger.Info("CrawlPeers")
+	if err := crawler.Crawl(); err != nil {
+		return nil, err
+	}
+	return &ctypes.ResultCrawlPeers{Log: "Crawling peers in progress. See /net_info for details"}, nil
+}
+
+// Get the bandwidth budgets of the channels of the reactors, in bytes/second
+// on each connection (0 for no limit besides the rates of the connection).
+//
+// ```shell
+// curl 'localhost:26657/bandwidth_budgets'
+// ```
+//
+// > The above command returns JSON structured like this:
+//
+// ```json
+// {
+//   "jsonrpc": "2.0",
+//   "id": "",
+//   "result": {
+//     "budgets": [
+//       {
+//         "group": "consensus",
+//         "channels": "202122232425",
+//         "send_rate": "0",
+//         "recv_rate": "0"
+//       },
+//       {
+//         "group": "blockchain",
+//         "channels": "40",
+//         "send_rate": "1024000",
+//         "recv_rate": "0"
+//       }
+//     ]
+//   }
+// }
+// ```
+func BandwidthBudgets(ctx *rpctypes.Context) (*ctypes.ResultBandwidthBudgets, error) {
+	budgets := make([]ctypes.BandwidthBudget, 0, len(bandwidthGroups))
+	for _, group := range bandwidthGroups {
+		budgets = append(budgets, bandwidthBudget(group))
+	}
+	return &ctypes.ResultBandwidthBudgets{Budgets: budgets}, nil
+}
+
+// Set the bandwidth budget of the channels of a reactor: consensus,
+// blockchain, mempool or evidence. The rates, in bytes/second on each
+// connection, apply at once to all the peers until the node restarts.
+//
+// ```shell
+// curl 'localhost:26657/set_bandwidth_budget?group="blockchain"&send_rate=1024000&recv_rate=0'
+// ```
+func UnsafeSetBandwidthBudget(ctx *rpctypes.Context, group string, sendRate, recvRate int64) (
+	*ctypes.BandwidthBudget, error) {
+	g := bandwidthGroups.Get(group)
+	if g == nil {
+		return nil, fmt.Errorf("unknown bandwidth group %q", group)
+	}
+	if sendRate < 0 || recvRate < 0 {
+		return nil, errors.New("rates can't be negative")
+	}
+	logger.Info("SetBandwidthBudget", "group", group, "sendRate", sendRate, "recvRate", recvRate)
+	g.Budget.SetRates(sendRate, recvRate)
+	budget := bandwidthBudget(g)
+	return &budget, nil
+}
+
+func bandwidthBudget(group *p2p.BandwidthGroup) ctypes.BandwidthBudget {
+	sendRate, recvRate := group.Budget.Rates()
+	return ctypes.BandwidthBudget{
+		Group:    group.Name,
+		Channels: group.Channels,
+		SendRate: sendRate,
+		RecvRate: recvRate,
+	}
+}
+
+// getIDs returns the IDs of the ID@host:port peer addresses.
+func getIDs(peers []string) ([]string, error) {
+	ids := make([]string, 0, len(peers))
+	for _, peer := range peers {
+		spl := strings.Split(peer, "@")
+		if len(spl) != 2 {
+			return nil, p2p.ErrNetAddressNoID{Addr: peer}
+		}
+		ids = append(ids, spl[0])
+	}
+	return ids, nil
+}
+
 // Get genesis file.
 //
 // ```shell
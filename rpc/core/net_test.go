@@ -10,6 +10,7 @@ import (
 	"github.com/hdac-io/tendermint/libs/log"
 	"github.com/hdac-io/tendermint/p2p"
 	rpctypes "github.com/hdac-io/tendermint/rpc/lib/types"
+	dbm "github.com/tendermint/tm-db"
 )
 
 func TestUnsafeDialSeeds(t *testing.T) {
@@ -27,7 +28,7 @@ func TestUnsafeDialSeeds(t *testing.T) {
 		isErr bool
 	}{
 		{[]string{}, true},
-		{[]string{"d51fb70907db1c6c2d5237e78379b25cf1a37ab4@127.0.0.1:41198"}, false},
+		{[]string{"d51fb70907db1c6c2d5237e78379b25cf1a37ab4d51fb70907db1c6c2d5237e7@127.0.0.1:41198"}, false},
 		{[]string{"127.0.0.1:41198"}, true},
 	}
 
@@ -53,16 +54,55 @@ func TestUnsafeDialPeers(t *testing.T) {
 	p2pPeers = sw
 
 	testCases := []struct {
-		peers []string
-		isErr bool
+		peers                     []string
+		persistent, unconditional bool
+		isErr                     bool
 	}{
-		{[]string{}, true},
-		{[]string{"d51fb70907db1c6c2d5237e78379b25cf1a37ab4@127.0.0.1:41198"}, false},
-		{[]string{"127.0.0.1:41198"}, true},
+		{[]string{}, false, false, true},
+		{[]string{"d51fb70907db1c6c2d5237e78379b25cf1a37ab4d51fb70907db1c6c2d5237e7@127.0.0.1:41198"}, false, false, false},
+		{[]string{"d51fb70907db1c6c2d5237e78379b25cf1a37ab4d51fb70907db1c6c2d5237e7@127.0.0.1:41198"}, true, true, false},
+		{[]string{"127.0.0.1:41198"}, false, false, true},
+		{[]string{"127.0.0.1:41198"}, false, true, true},
+		{[]string{"invalid@127.0.0.1:41198"}, false, true, true},
+	}
+
+	for _, tc := range testCases {
+		res, err := UnsafeDialPeers(&rpctypes.Context{}, tc.peers, tc.persistent, tc.unconditional)
+		if tc.isErr {
+			assert.Error(t, err)
+		} else {
+			assert.NoError(t, err)
+			assert.NotNil(t, res)
+		}
+	}
+	assert.True(t, sw.IsPeerUnconditional("d51fb70907db1c6c2d5237e78379b25cf1a37ab4d51fb70907db1c6c2d5237e7"))
+}
+
+func TestUnsafeBanPeer(t *testing.T) {
+	banList, err := p2p.NewBanList(dbm.NewMemDB())
+	require.NoError(t, err)
+	sw := p2p.MakeSwitch(cfg.DefaultP2PConfig(), 1, "testing", "123.123.123",
+		func(n int, sw *p2p.Switch) *p2p.Switch { return sw }, p2p.SwitchBanList(banList))
+	err = sw.Start()
+	require.NoError(t, err)
+	defer sw.Stop()
+
+	logger = log.TestingLogger()
+	p2pPeers = sw
+
+	id := "d51fb70907db1c6c2d5237e78379b25cf1a37ab4d51fb70907db1c6c2d5237e7"
+	testCases := []struct {
+		peerID, duration string
+		isErr            bool
+	}{
+		{id, "1h", false},
+		{id, "-1h", true},
+		{id, "forever", true},
+		{"not-an-id", "1h", true},
 	}
 
 	for _, tc := range testCases {
-		res, err := UnsafeDialPeers(&rpctypes.Context{}, tc.peers, false)
+		res, err := UnsafeBanPeer(&rpctypes.Context{}, tc.peerID, tc.duration)
 		if tc.isErr {
 			assert.Error(t, err)
 		} else {
@@ -70,4 +110,15 @@ func TestUnsafeDialPeers(t *testing.T) {
 			assert.NotNil(t, res)
 		}
 	}
+
+	scores, err := PeerScores(&rpctypes.Context{})
+	require.NoError(t, err)
+	require.Len(t, scores.Peers, 1)
+	assert.EqualValues(t, id, scores.Peers[0].NodeID)
+	assert.NotNil(t, scores.Peers[0].BannedUntil)
+
+	_, err = UnsafeUnbanPeer(&rpctypes.Context{}, id)
+	assert.NoError(t, err)
+	_, err = UnsafeUnbanPeer(&rpctypes.Context{}, id)
+	assert.Error(t, err)
 }
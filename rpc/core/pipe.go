@@ -2,6 +2,7 @@ package core
 
 import (
 	"fmt"
+	"sync"
 	"time"
 
 	cfg "github.com/hdac-io/tendermint/config"
@@ -13,6 +14,7 @@ import (
 	"github.com/hdac-io/tendermint/proxy"
 	sm "github.com/hdac-io/tendermint/state"
 	"github.com/hdac-io/tendermint/state/txindex"
+	"github.com/hdac-io/tendermint/store"
 	"github.com/hdac-io/tendermint/types"
 	dbm "github.com/tendermint/tm-db"
 )
@@ -42,13 +44,27 @@ type transport interface {
 	Listeners() []string
 	IsListening() bool
 	NodeInfo() p2p.NodeInfo
+	ExternalAddress() (address, source string)
 }
 
 type peers interface {
 	AddPersistentPeers([]string) error
+	AddUnconditionalPeerIDs([]string) error
 	DialPeersAsync([]string) error
 	NumPeers() (outbound, inbound, dialig int)
 	Peers() p2p.IPeerSet
+	PeerTrustScores() map[p2p.ID]int
+	BanPeer(p2p.ID, time.Duration) error
+	UnbanPeer(p2p.ID) error
+	BannedPeers() map[p2p.ID]time.Time
+}
+
+type configReloader interface {
+	ReloadConfig() (reloaded, ignored []string, err error)
+}
+
+type peerCrawler interface {
+	Crawl() error
 }
 
 //----------------------------------------------
@@ -62,15 +78,19 @@ var (
 	// interfaces defined in types and above
 	stateDB        dbm.DB
 	blockStore     sm.BlockStore
+	headerStore    *store.HeaderStore
 	evidencePool   sm.EvidencePool
 	consensusState Consensus
 	p2pPeers       peers
 	p2pTransport   transport
+	configLoader   configReloader
+	crawler        peerCrawler
 
 	// objects
 	pubKey           crypto.PubKey
 	genDoc           *types.GenesisDoc // cache the genesis structure
 	addrBook         p2p.AddrBook
+	bandwidthGroups  p2p.BandwidthGroups
 	txIndexer        txindex.TxIndexer
 	consensusReactor consensus.IConsensusReactor
 	eventBus         *types.EventBus // thread safe
@@ -78,7 +98,9 @@ var (
 
 	logger log.Logger
 
-	config cfg.RPCConfig
+	// set again when the config is reloaded, while serving
+	configMtx sync.RWMutex
+	config    cfg.RPCConfig
 )
 
 func SetStateDB(db dbm.DB) {
@@ -89,6 +111,12 @@ func SetBlockStore(bs sm.BlockStore) {
 	blockStore = bs
 }
 
+// SetHeaderStore makes the headers be served from hs instead of the block
+// store, on the nodes which only sync the headers.
+func SetHeaderStore(hs *store.HeaderStore) {
+	headerStore = hs
+}
+
 func SetMempool(mem mempl.Mempool) {
 	mempool = mem
 }
@@ -109,6 +137,10 @@ func SetP2PTransport(t transport) {
 	p2pTransport = t
 }
 
+func SetBandwidthGroups(groups p2p.BandwidthGroups) {
+	bandwidthGroups = groups
+}
+
 func SetPubKey(pk crypto.PubKey) {
 	pubKey = pk
 }
@@ -121,6 +153,11 @@ func SetAddrBook(book p2p.AddrBook) {
 	addrBook = book
 }
 
+// SetPeerCrawler sets the PEX reactor crawling the peers, if enabled.
+func SetPeerCrawler(c peerCrawler) {
+	crawler = c
+}
+
 func SetProxyAppQuery(appConn proxy.AppConnQuery) {
 	proxyAppQuery = appConn
 }
@@ -141,9 +178,25 @@ func SetEventBus(b *types.EventBus) {
 	eventBus = b
 }
 
-// SetConfig sets an RPCConfig.
+// SetConfigReloader sets the node reloading its config.
+func SetConfigReloader(r configReloader) {
+	configLoader = r
+}
+
+// SetConfig sets an RPCConfig. It is safe to call while serving, e.g. when
+// the config is reloaded.
 func SetConfig(c cfg.RPCConfig) {
+	configMtx.Lock()
 	config = c
+	configMtx.Unlock()
+	resultCache.SetLimits(c.ResultCacheSize, c.ResultCacheTTL)
+}
+
+// rpcConfig returns the RPCConfig set by SetConfig.
+func rpcConfig() cfg.RPCConfig {
+	configMtx.RLock()
+	defer configMtx.RUnlock()
+	return config
 }
 
 func validatePage(page, perPage, totalCount int) (int, error) {
@@ -2,6 +2,7 @@ package core
 
 import (
 	rpc "github.com/hdac-io/tendermint/rpc/lib/server"
+	"github.com/hdac-io/tendermint/version"
 )
 
 // TODO: better system than "unsafe" prefix
@@ -16,16 +17,24 @@ var Routes = map[string]*rpc.RPCFunc{
 	"health":               rpc.NewRPCFunc(Health, ""),
 	"status":               rpc.NewRPCFunc(Status, ""),
 	"net_info":             rpc.NewRPCFunc(NetInfo, ""),
+	"peer_scores":          rpc.NewRPCFunc(PeerScores, ""),
+	"bandwidth_budgets":    rpc.NewRPCFunc(BandwidthBudgets, ""),
 	"blockchain":           rpc.NewRPCFunc(BlockchainInfo, "minHeight,maxHeight"),
 	"genesis":              rpc.NewRPCFunc(Genesis, ""),
 	"block":                rpc.NewRPCFunc(Block, "height"),
-	"block_results":        rpc.NewRPCFunc(BlockResults, "height"),
+	"block_results":        rpc.NewRPCFunc(BlockResults, "height,event_type,page,per_page"),
 	"commit":               rpc.NewRPCFunc(Commit, "height"),
+	"header":               rpc.NewRPCFunc(Header, "height"),
+	"ulb_commit":           rpc.NewRPCFunc(ULBCommit, "height"),
+	"quorum_cert":          rpc.NewRPCFunc(QuorumCert, "height"),
 	"tx":                   rpc.NewRPCFunc(Tx, "hash,prove"),
 	"tx_search":            rpc.NewRPCFunc(TxSearch, "query,prove,page,per_page"),
 	"validators":           rpc.NewRPCFunc(Validators, "height"),
+	"proposer_schedule":    rpc.NewRPCFunc(ProposerSchedule, "from,to,rounds"),
 	"dump_consensus_state": rpc.NewRPCFunc(DumpConsensusState, ""),
 	"consensus_state":      rpc.NewRPCFunc(ConsensusState, ""),
+	"vote_delays":          rpc.NewRPCFunc(VoteDelays, ""),
+	"vote_tally":           rpc.NewRPCFunc(VoteTally, "height,round"),
 	"consensus_params":     rpc.NewRPCFunc(ConsensusParams, "height"),
 	"unconfirmed_txs":      rpc.NewRPCFunc(UnconfirmedTxs, "limit"),
 	"num_unconfirmed_txs":  rpc.NewRPCFunc(NumUnconfirmedTxs, ""),
@@ -43,14 +52,68 @@ var Routes = map[string]*rpc.RPCFunc{
 	"broadcast_evidence": rpc.NewRPCFunc(BroadcastEvidence, "evidence"),
 }
 
+// RESTRoutes are the routes of the REST gateway, served under /v1 along with
+// their OpenAPI document at /v1/openapi.json. The routes with fixed segments
+// come before the ones with params there.
+var RESTRoutes = []rpc.RESTRoute{
+	{Path: "/status", Func: "status", Summary: "Node status"},
+	{Path: "/health", Func: "health", Summary: "Node health"},
+	{Path: "/net_info", Func: "net_info", Summary: "Network info"},
+	{Path: "/genesis", Func: "genesis", Summary: "Genesis file"},
+	{Path: "/blocks", Func: "blockchain", Summary: "Block metas in a height range"},
+	{Path: "/blocks/latest", Func: "block", Summary: "Latest block"},
+	{Path: "/blocks/{height}", Func: "block", Summary: "Block at a height"},
+	{Path: "/blocks/{height}/results", Func: "block_results", Summary: "Results of the txs of a block"},
+	{Path: "/blocks/{height}/commit", Func: "commit", Summary: "Commit of a block"},
+	{Path: "/blocks/{height}/header", Func: "header", Summary: "Header of a block"},
+	{Path: "/validators", Func: "validators", Summary: "Validator set at a height, the latest by default"},
+	{Path: "/validators/{height}", Func: "validators", Summary: "Validator set at a height"},
+	{Path: "/consensus_params", Func: "consensus_params", Summary: "Consensus params at a height, the latest by default"},
+	{Path: "/txs", Func: "tx_search", Summary: "Search the txs"},
+	{Path: "/txs/{hash}", Func: "tx", Summary: "Tx by hash (0x prefixed hex)"},
+	{Path: "/unconfirmed_txs", Func: "unconfirmed_txs", Summary: "Txs in the mempool"},
+}
+
+// RESTInfo describes the REST gateway in its OpenAPI document.
+var RESTInfo = rpc.RESTInfo{
+	Title:       "Tendermint RPC",
+	Description: "REST gateway over the Tendermint RPC",
+	Version:     version.TMCoreSemVer,
+}
+
 func AddUnsafeRoutes() {
+	for name, f := range unsafeRoutes {
+		Routes[name] = f
+	}
+}
+
+// UnsafeRouteNames returns the names of the routes added by AddUnsafeRoutes.
+// They are restricted to the admin role when the RPC authentication is
+// enabled.
+func UnsafeRouteNames() []string {
+	names := make([]string, 0, len(unsafeRoutes))
+	for name := range unsafeRoutes {
+		names = append(names, name)
+	}
+	return names
+}
+
+var unsafeRoutes = map[string]*rpc.RPCFunc{
 	// control API
-	Routes["dial_seeds"] = rpc.NewRPCFunc(UnsafeDialSeeds, "seeds")
-	Routes["dial_peers"] = rpc.NewRPCFunc(UnsafeDialPeers, "peers,persistent")
-	Routes["unsafe_flush_mempool"] = rpc.NewRPCFunc(UnsafeFlushMempool, "")
+	"dial_seeds":           rpc.NewRPCFunc(UnsafeDialSeeds, "seeds"),
+	"dial_peers":           rpc.NewRPCFunc(UnsafeDialPeers, "peers,persistent,unconditional"),
+	"ban_peer":             rpc.NewRPCFunc(UnsafeBanPeer, "peer_id,duration"),
+	"unban_peer":           rpc.NewRPCFunc(UnsafeUnbanPeer, "peer_id"),
+	"crawl_peers":          rpc.NewRPCFunc(UnsafeCrawlPeers, ""),
+	"set_bandwidth_budget": rpc.NewRPCFunc(UnsafeSetBandwidthBudget, "group,send_rate,recv_rate"),
+	"unsafe_flush_mempool": rpc.NewRPCFunc(UnsafeFlushMempool, ""),
+	"reload_config":        rpc.NewRPCFunc(UnsafeReloadConfig, ""),
+
+	// the stacks and WAL records of a consensus failure are for the operator
+	"consensus_failure": rpc.NewRPCFunc(ConsensusFailure, ""),
 
 	// profiler API
-	Routes["unsafe_start_cpu_profiler"] = rpc.NewRPCFunc(UnsafeStartCPUProfiler, "filename")
-	Routes["unsafe_stop_cpu_profiler"] = rpc.NewRPCFunc(UnsafeStopCPUProfiler, "")
-	Routes["unsafe_write_heap_profile"] = rpc.NewRPCFunc(UnsafeWriteHeapProfile, "filename")
+	"unsafe_start_cpu_profiler": rpc.NewRPCFunc(UnsafeStartCPUProfiler, "filename"),
+	"unsafe_stop_cpu_profiler":  rpc.NewRPCFunc(UnsafeStopCPUProfiler, ""),
+	"unsafe_write_heap_profile": rpc.NewRPCFunc(UnsafeWriteHeapProfile, "filename"),
 }
@@ -67,6 +67,10 @@ import (
 //   			"value": "wVxKNtEsJmR4vvh651LrVoRguPs+6yJJ9Bz174gw9DM="
 //   		},
 //   		"voting_power": "10"
+//   	},
+//   	"external_address": {
+//   		"address": "203.0.113.7:26656",
+//   		"source": "upnp"
 //   	}
 //   }
 // }
@@ -98,6 +102,8 @@ func Status(ctx *rpctypes.Context) (*ctypes.ResultStatus, error) {
 		votingPower = val.VotingPower
 	}
 
+	externalAddress, externalAddressSource := p2pTransport.ExternalAddress()
+
 	result := &ctypes.ResultStatus{
 		NodeInfo: p2pTransport.NodeInfo().(p2p.DefaultNodeInfo),
 		SyncInfo: ctypes.SyncInfo{
@@ -112,6 +118,10 @@ func Status(ctx *rpctypes.Context) (*ctypes.ResultStatus, error) {
 			PubKey:      pubKey,
 			VotingPower: votingPower,
 		},
+		ExternalAddress: ctypes.ExternalAddressInfo{
+			Address: externalAddress,
+			Source:  externalAddressSource,
+		},
 	}
 
 	return result, nil
@@ -30,16 +30,48 @@ type ResultBlock struct {
 	Block     *types.Block     `json:"block"`
 }
 
+// Where the commit of a ResultCommit comes from
+const (
+	// CommitSourceBlock is the canonical commit, embedded in a later block
+	CommitSourceBlock = "block_commit"
+	// CommitSourceSeen is the commit seen by the node, as the block carrying
+	// the canonical one is not committed yet
+	CommitSourceSeen = "seen_commit"
+)
+
 // Commit and Header
 type ResultCommit struct {
 	types.SignedHeader `json:"signed_header"`
-	CanonicalCommit    bool `json:"canonical"`
+	CanonicalCommit    bool   `json:"canonical"`
+	CommitSource       string `json:"commit_source"`
+	// height of the block embedding the canonical commit
+	CarryingHeight int64 `json:"carrying_height,omitempty"`
+}
+
+// Header with its commit and validator set
+type ResultHeader struct {
+	types.SignedHeader `json:"signed_header"`
+	Validators         *types.ValidatorSet `json:"validators"`
+}
+
+// Quorum certificate of a finalized height
+type ResultQuorumCert struct {
+	QuorumCert *types.QuorumCert `json:"quorum_cert"`
 }
 
 // ABCI results from a block
 type ResultBlockResults struct {
 	Height  int64                `json:"height"`
 	Results *state.ABCIResponses `json:"results"`
+
+	// Number of the results of the txs matching the filter, and the indexes
+	// in the block of the ones returned, if filtered or paginated
+	TotalCount int   `json:"total_count"`
+	TxIndexes  []int `json:"tx_indexes,omitempty"`
+
+	// Height of the block carrying the app hash and results hash of the
+	// execution of the block
+	ResultsCarryingHeight int64 `json:"results_carrying_height"`
 }
 
 // NewResultCommit is a helper to initialize the ResultCommit with
@@ -47,12 +79,17 @@ type ResultBlockResults struct {
 func NewResultCommit(header *types.Header, commit *types.Commit,
 	canonical bool) *ResultCommit {
 
+	source := CommitSourceSeen
+	if canonical {
+		source = CommitSourceBlock
+	}
 	return &ResultCommit{
 		SignedHeader: types.SignedHeader{
 			Header: header,
 			Commit: commit,
 		},
 		CanonicalCommit: canonical,
+		CommitSource:    source,
 	}
 }
 
@@ -65,6 +102,14 @@ type SyncInfo struct {
 	CatchingUp        bool         `json:"catching_up"`
 }
 
+// Info about the node's external address, empty if it's not known. Source is
+// "config" for p2p.external_address, "upnp" or "nat-pmp" for the port mapped
+// on the NAT gateway, or "peers" for the IP most peers see the node from.
+type ExternalAddressInfo struct {
+	Address string `json:"address"`
+	Source  string `json:"source"`
+}
+
 // Info about the node's validator
 type ValidatorInfo struct {
 	Address     cmn.HexBytes  `json:"address"`
@@ -77,6 +122,8 @@ type ResultStatus struct {
 	NodeInfo      p2p.DefaultNodeInfo `json:"node_info"`
 	SyncInfo      SyncInfo            `json:"sync_info"`
 	ValidatorInfo ValidatorInfo       `json:"validator_info"`
+	// the address the node is dialable at from outside its network
+	ExternalAddress ExternalAddressInfo `json:"external_address"`
 }
 
 // Is TxIndexing enabled
@@ -105,6 +152,11 @@ type ResultDialPeers struct {
 	Log string `json:"log"`
 }
 
+// Log from crawling the peers
+type ResultCrawlPeers struct {
+	Log string `json:"log"`
+}
+
 // A peer
 type Peer struct {
 	NodeInfo         p2p.DefaultNodeInfo  `json:"node_info"`
@@ -113,12 +165,70 @@ type Peer struct {
 	RemoteIP         string               `json:"remote_ip"`
 }
 
+// Trust scores and bans of the peers
+type ResultPeerScores struct {
+	Peers []PeerScore `json:"peers"`
+}
+
+// The trust score of a peer, between 0 and 100, and the time its ban expires
+// if it's banned
+type PeerScore struct {
+	NodeID      p2p.ID     `json:"node_id"`
+	Score       int        `json:"score"`
+	Connected   bool       `json:"connected"`
+	BannedUntil *time.Time `json:"banned_until,omitempty"`
+}
+
+// Bandwidth budgets of the channels of the reactors
+type ResultBandwidthBudgets struct {
+	Budgets []BandwidthBudget `json:"budgets"`
+}
+
+// The rates allowed to a group of channels on each connection, in bytes/second
+type BandwidthBudget struct {
+	Group    string       `json:"group"`
+	Channels cmn.HexBytes `json:"channels"`
+	SendRate int64        `json:"send_rate"`
+	RecvRate int64        `json:"recv_rate"`
+}
+
+// Time the ban of a peer expires
+type ResultBanPeer struct {
+	BannedUntil time.Time `json:"banned_until"`
+}
+
+// Unban a peer
+type ResultUnbanPeer struct{}
+
+// Keys of the config fields reloaded, and of the changed ones which need a
+// restart
+type ResultReloadConfig struct {
+	Reloaded []string `json:"reloaded"`
+	Ignored  []string `json:"ignored"`
+}
+
 // Validators for a height
 type ResultValidators struct {
 	BlockHeight int64              `json:"block_height"`
 	Validators  []*types.Validator `json:"validators"`
 }
 
+// Expected proposers of the upcoming heights and rounds
+type ResultProposerSchedule struct {
+	LastHeight int64          `json:"last_height"`
+	Proposers  []ProposerSlot `json:"proposers"`
+}
+
+// Expected proposer of a height and round. Estimated is true if the validator
+// set of the height isn't known yet, and a validator change could make
+// another validator propose.
+type ProposerSlot struct {
+	Height    int64         `json:"height"`
+	Round     int           `json:"round"`
+	Address   types.Address `json:"address"`
+	Estimated bool          `json:"estimated"`
+}
+
 // ConsensusParams for given height
 type ResultConsensusParams struct {
 	BlockHeight     int64                 `json:"block_height"`
@@ -143,6 +253,21 @@ type ResultConsensusState struct {
 	RoundState json.RawMessage `json:"round_state"`
 }
 
+// UNSTABLE
+type ResultConsensusFailure struct {
+	Failure json.RawMessage `json:"failure"`
+}
+
+// UNSTABLE
+type ResultVoteDelays struct {
+	VoteDelays json.RawMessage `json:"vote_delays"`
+}
+
+// UNSTABLE
+type ResultVoteTally struct {
+	VoteTally json.RawMessage `json:"vote_tally"`
+}
+
 // CheckTx result
 type ResultBroadcastTx struct {
 	Code uint32       `json:"code"`
@@ -150,6 +275,14 @@ type ResultBroadcastTx struct {
 	Log  string       `json:"log"`
 
 	Hash cmn.HexBytes `json:"hash"`
+
+	// Placement of the tx in the pipeline (sync only, if CheckTx passed):
+	// the height of the in-flight proposal which included the tx, if any, and
+	// the height at which the block including the tx is expected to be
+	// finalized. Without a proposal, that is the first height after the LenULB
+	// heights which may already be in flight.
+	ProposalHeight           int64 `json:"proposal_height,omitempty"`
+	EstimatedFinalizedHeight int64 `json:"estimated_finalized_height,omitempty"`
 }
 
 // CheckTx and DeliverTx results
@@ -205,9 +338,34 @@ type (
 	ResultUnsafeProfile      struct{}
 	ResultSubscribe          struct{}
 	ResultUnsubscribe        struct{}
-	ResultHealth             struct{}
 )
 
+// Health of the node: each condition stalling the heights is reported in its
+// own field, and Healthy is false if any is detected.
+type ResultHealth struct {
+	Healthy    bool `json:"healthy"`
+	CatchingUp bool `json:"catching_up"`
+
+	// the last height finalized, and when
+	LastHeight        int64     `json:"last_height"`
+	LastFinalizedTime time.Time `json:"last_finalized_time"`
+	// no height was finalized for rpc.health_finalize_timeout
+	FinalizeStalled bool `json:"finalize_stalled"`
+
+	// how long a committed height has been waiting for a lower height to be
+	// finalized, and whether it is more than rpc.health_wait_finalize_timeout
+	WaitFinalize        time.Duration `json:"wait_finalize"`
+	WaitFinalizeStalled bool          `json:"wait_finalize_stalled"`
+
+	// the last WAL writes failed
+	WALWriteFailing bool   `json:"wal_write_failing"`
+	WALWriteError   string `json:"wal_write_error,omitempty"`
+
+	// the privValidator failed to sign the last votes
+	PrivValidatorUnreachable bool   `json:"priv_validator_unreachable"`
+	PrivValidatorError       string `json:"priv_validator_error,omitempty"`
+}
+
 // Event data from a subscription
 type ResultEvent struct {
 	Query  string              `json:"query"`
@@ -14,23 +14,34 @@ type Config struct {
 	MaxOpenConnections int
 }
 
-// StartGRPCServer starts a new gRPC BroadcastAPIServer using the given
-// net.Listener.
+// StartGRPCServer starts a new gRPC BroadcastAPIServer and StreamAPIServer
+// using the given net.Listener.
 // NOTE: This function blocks - you may want to call it in a go-routine.
 func StartGRPCServer(ln net.Listener) error {
 	grpcServer := grpc.NewServer()
 	RegisterBroadcastAPIServer(grpcServer, &broadcastAPI{})
+	RegisterStreamAPIServer(grpcServer, &streamAPI{})
 	return grpcServer.Serve(ln)
 }
 
 // StartGRPCClient dials the gRPC server using protoAddr and returns a new
 // BroadcastAPIClient.
 func StartGRPCClient(protoAddr string) BroadcastAPIClient {
+	return NewBroadcastAPIClient(dialGRPC(protoAddr))
+}
+
+// StartGRPCStreamClient dials the gRPC server using protoAddr and returns a
+// new StreamAPIClient.
+func StartGRPCStreamClient(protoAddr string) StreamAPIClient {
+	return NewStreamAPIClient(dialGRPC(protoAddr))
+}
+
+func dialGRPC(protoAddr string) *grpc.ClientConn {
 	conn, err := grpc.Dial(protoAddr, grpc.WithInsecure(), grpc.WithContextDialer(dialerFunc))
 	if err != nil {
 		panic(err)
 	}
-	return NewBroadcastAPIClient(conn)
+	return conn
 }
 
 func dialerFunc(ctx context.Context, addr string) (net.Conn, error) {
@@ -30,3 +30,39 @@ func TestBroadcastTx(t *testing.T) {
 	require.EqualValues(t, 0, res.CheckTx.Code)
 	require.EqualValues(t, 0, res.DeliverTx.Code)
 }
+
+func TestStreamTxs(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	stream, err := rpctest.GetGRPCStreamClient().StreamTxs(ctx, &core_grpc.RequestStreamTxs{
+		FromHeight: 1,
+		Query:      "app.key='streamed'",
+	})
+	require.NoError(t, err)
+
+	_, err = rpctest.GetGRPCClient().BroadcastTx(ctx, &core_grpc.RequestBroadcastTx{Tx: []byte("skipped=1")})
+	require.NoError(t, err)
+	_, err = rpctest.GetGRPCClient().BroadcastTx(ctx, &core_grpc.RequestBroadcastTx{Tx: []byte("streamed=1")})
+	require.NoError(t, err)
+
+	res, err := stream.Recv()
+	require.NoError(t, err)
+	require.Equal(t, []byte("streamed=1"), res.Tx)
+	require.EqualValues(t, 0, res.DeliverTx.Code)
+	require.True(t, res.Height > 0)
+}
+
+func TestStreamBlocks(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	stream, err := rpctest.GetGRPCStreamClient().StreamBlocks(ctx, &core_grpc.RequestStreamBlocks{FromHeight: 1})
+	require.NoError(t, err)
+
+	for height := int64(1); height <= 3; height++ {
+		res, err := stream.Recv()
+		require.NoError(t, err)
+		require.Equal(t, height, res.Header.Height)
+		require.Equal(t, len(res.Txs), int(res.Header.NumTxs))
+		require.NotNil(t, res.EndBlock)
+	}
+}
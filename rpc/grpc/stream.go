@@ -0,0 +1,199 @@
+package core_grpc
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	abci "github.com/hdac-io/tendermint/abci/types"
+	tmquery "github.com/hdac-io/tendermint/libs/pubsub/query"
+	core "github.com/hdac-io/tendermint/rpc/core"
+	rpctypes "github.com/hdac-io/tendermint/rpc/lib/types"
+	sm "github.com/hdac-io/tendermint/state"
+	"github.com/hdac-io/tendermint/types"
+)
+
+// streamPollInterval is how often the streams check for newly finalized
+// heights once they caught up.
+const streamPollInterval = 100 * time.Millisecond
+
+// streamAPI streams the finalized heights from the block store and the saved
+// ABCI responses, at the pace of the client: unlike a websocket subscription,
+// a slow client doesn't lose events, and a client reconnecting with the next
+// height it expects misses none.
+type streamAPI struct {
+}
+
+// StreamBlocks streams the finalized blocks from req.FromHeight (the next
+// finalized height if 0), in height order, with the results of their
+// BeginBlock and EndBlock. With req.Query, only the blocks with events
+// matching it are streamed, as with a NewBlock subscription.
+func (sapi *streamAPI) StreamBlocks(req *RequestStreamBlocks, stream StreamAPI_StreamBlocksServer) error {
+	query, err := parseStreamQuery(req.Query)
+	if err != nil {
+		return err
+	}
+
+	return streamHeights(stream.Context(), req.FromHeight, func(height int64, results *sm.ABCIResponses) error {
+		var resultEvents []abci.Event
+		if results.BeginBlock != nil {
+			resultEvents = append(resultEvents, results.BeginBlock.Events...)
+		}
+		if results.EndBlock != nil {
+			resultEvents = append(resultEvents, results.EndBlock.Events...)
+		}
+		events := stringifyEvents(resultEvents)
+		events[types.EventTypeKey] = append(events[types.EventTypeKey], types.EventNewBlock)
+		events[types.BlockHeightKey] = append(events[types.BlockHeightKey], fmt.Sprintf("%d", height))
+		events[types.PipelineStageKey] = append(events[types.PipelineStageKey], types.PipelineStageFinalized)
+		if query != nil && !query.Matches(events) {
+			return nil
+		}
+
+		res, err := core.Block(&rpctypes.Context{}, &height)
+		if err != nil {
+			return err
+		}
+		if res.Block == nil {
+			return fmt.Errorf("no block at height %d", height)
+		}
+		header := types.TM2PB.Header(&res.Block.Header)
+		blockID := types.TM2PB.BlockID(res.BlockMeta.BlockID)
+		txs := make([][]byte, len(res.Block.Txs))
+		for i, tx := range res.Block.Txs {
+			txs[i] = tx
+		}
+		return stream.Send(&ResponseStreamBlocks{
+			Header:     &header,
+			BlockId:    &blockID,
+			Txs:        txs,
+			BeginBlock: results.BeginBlock,
+			EndBlock:   results.EndBlock,
+		})
+	})
+}
+
+// StreamTxs streams the results of the txs of the finalized blocks from
+// req.FromHeight (the next finalized height if 0), in height and index order.
+// With req.Query, only the txs with events matching it are streamed, as with
+// a Tx subscription.
+func (sapi *streamAPI) StreamTxs(req *RequestStreamTxs, stream StreamAPI_StreamTxsServer) error {
+	query, err := parseStreamQuery(req.Query)
+	if err != nil {
+		return err
+	}
+
+	return streamHeights(stream.Context(), req.FromHeight, func(height int64, results *sm.ABCIResponses) error {
+		if len(results.DeliverTx) == 0 {
+			return nil
+		}
+		res, err := core.Block(&rpctypes.Context{}, &height)
+		if err != nil {
+			return err
+		}
+		if res.Block == nil {
+			return fmt.Errorf("no block at height %d", height)
+		}
+		if len(res.Block.Txs) != len(results.DeliverTx) {
+			return fmt.Errorf("%d txs at height %d, but %d results", len(res.Block.Txs), height, len(results.DeliverTx))
+		}
+
+		for i, tx := range res.Block.Txs {
+			result := results.DeliverTx[i]
+			if query != nil {
+				events := stringifyEvents(result.Events)
+				events[types.EventTypeKey] = append(events[types.EventTypeKey], types.EventTx)
+				events[types.TxHashKey] = append(events[types.TxHashKey], fmt.Sprintf("%X", tx.Hash()))
+				events[types.TxHeightKey] = append(events[types.TxHeightKey], fmt.Sprintf("%d", height))
+				events[types.BlockHeightKey] = append(events[types.BlockHeightKey], fmt.Sprintf("%d", height))
+				events[types.PipelineStageKey] = append(events[types.PipelineStageKey], types.PipelineStageFinalized)
+				if !query.Matches(events) {
+					continue
+				}
+			}
+			if err := stream.Send(&ResponseStreamTxs{
+				Height:    height,
+				Index:     uint32(i),
+				Tx:        tx,
+				DeliverTx: result,
+			}); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// streamHeights calls send with the ABCI responses of each finalized height
+// from fromHeight (the next finalized height if 0), until ctx is done or send
+// fails.
+func streamHeights(ctx context.Context, fromHeight int64, send func(height int64, results *sm.ABCIResponses) error) error {
+	if fromHeight < 0 {
+		return fmt.Errorf("negative from_height %d", fromHeight)
+	}
+
+	ticker := time.NewTicker(streamPollInterval)
+	defer ticker.Stop()
+	height := fromHeight
+	for {
+		status, err := core.Status(&rpctypes.Context{})
+		if err != nil {
+			return err
+		}
+		lastHeight := status.SyncInfo.LatestBlockHeight
+		if height == 0 {
+			height = lastHeight + 1
+		}
+
+		for ; height <= lastHeight; height++ {
+			res, err := core.BlockResults(&rpctypes.Context{}, &height, "", 0, 0)
+			if err != nil {
+				// the ABCI responses of the last block height may not be
+				// saved yet while fast syncing
+				if height == lastHeight {
+					break
+				}
+				return err
+			}
+			if err := send(height, res.Results); err != nil {
+				return err
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+func parseStreamQuery(query string) (*tmquery.Query, error) {
+	if query == "" {
+		return nil, nil
+	}
+	q, err := tmquery.New(query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse query: %v", err)
+	}
+	return q, nil
+}
+
+// stringifyEvents returns the events by composite "type.key" tag, like the
+// event bus publishes them.
+func stringifyEvents(events []abci.Event) map[string][]string {
+	result := make(map[string][]string)
+	for _, event := range events {
+		if len(event.Type) == 0 {
+			continue
+		}
+		for _, attr := range event.Attributes {
+			if len(attr.Key) == 0 {
+				continue
+			}
+			compositeTag := fmt.Sprintf("%s.%s", event.Type, string(attr.Key))
+			result[compositeTag] = append(result[compositeTag], string(attr.Value))
+		}
+	}
+	return result
+}
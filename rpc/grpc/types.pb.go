@@ -117,6 +117,116 @@ func (m *RequestBroadcastTx) GetTx() []byte {
 	return nil
 }
 
+type RequestStreamBlocks struct {
+	FromHeight           int64    `protobuf:"varint,1,opt,name=from_height,json=fromHeight,proto3" json:"from_height,omitempty"`
+	Query                string   `protobuf:"bytes,2,opt,name=query,proto3" json:"query,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RequestStreamBlocks) Reset()         { *m = RequestStreamBlocks{} }
+func (m *RequestStreamBlocks) String() string { return proto.CompactTextString(m) }
+func (*RequestStreamBlocks) ProtoMessage()    {}
+func (*RequestStreamBlocks) Descriptor() ([]byte, []int) {
+	return fileDescriptor_15f63baabf91876a, []int{2}
+}
+func (m *RequestStreamBlocks) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *RequestStreamBlocks) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_RequestStreamBlocks.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *RequestStreamBlocks) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RequestStreamBlocks.Merge(m, src)
+}
+func (m *RequestStreamBlocks) XXX_Size() int {
+	return m.Size()
+}
+func (m *RequestStreamBlocks) XXX_DiscardUnknown() {
+	xxx_messageInfo_RequestStreamBlocks.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RequestStreamBlocks proto.InternalMessageInfo
+
+func (m *RequestStreamBlocks) GetFromHeight() int64 {
+	if m != nil {
+		return m.FromHeight
+	}
+	return 0
+}
+
+func (m *RequestStreamBlocks) GetQuery() string {
+	if m != nil {
+		return m.Query
+	}
+	return ""
+}
+
+type RequestStreamTxs struct {
+	FromHeight           int64    `protobuf:"varint,1,opt,name=from_height,json=fromHeight,proto3" json:"from_height,omitempty"`
+	Query                string   `protobuf:"bytes,2,opt,name=query,proto3" json:"query,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RequestStreamTxs) Reset()         { *m = RequestStreamTxs{} }
+func (m *RequestStreamTxs) String() string { return proto.CompactTextString(m) }
+func (*RequestStreamTxs) ProtoMessage()    {}
+func (*RequestStreamTxs) Descriptor() ([]byte, []int) {
+	return fileDescriptor_15f63baabf91876a, []int{3}
+}
+func (m *RequestStreamTxs) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *RequestStreamTxs) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_RequestStreamTxs.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *RequestStreamTxs) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RequestStreamTxs.Merge(m, src)
+}
+func (m *RequestStreamTxs) XXX_Size() int {
+	return m.Size()
+}
+func (m *RequestStreamTxs) XXX_DiscardUnknown() {
+	xxx_messageInfo_RequestStreamTxs.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RequestStreamTxs proto.InternalMessageInfo
+
+func (m *RequestStreamTxs) GetFromHeight() int64 {
+	if m != nil {
+		return m.FromHeight
+	}
+	return 0
+}
+
+func (m *RequestStreamTxs) GetQuery() string {
+	if m != nil {
+		return m.Query
+	}
+	return ""
+}
+
 type ResponsePing struct {
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
@@ -127,7 +237,7 @@ func (m *ResponsePing) Reset()         { *m = ResponsePing{} }
 func (m *ResponsePing) String() string { return proto.CompactTextString(m) }
 func (*ResponsePing) ProtoMessage()    {}
 func (*ResponsePing) Descriptor() ([]byte, []int) {
-	return fileDescriptor_15f63baabf91876a, []int{2}
+	return fileDescriptor_15f63baabf91876a, []int{4}
 }
 func (m *ResponsePing) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -168,7 +278,7 @@ func (m *ResponseBroadcastTx) Reset()         { *m = ResponseBroadcastTx{} }
 func (m *ResponseBroadcastTx) String() string { return proto.CompactTextString(m) }
 func (*ResponseBroadcastTx) ProtoMessage()    {}
 func (*ResponseBroadcastTx) Descriptor() ([]byte, []int) {
-	return fileDescriptor_15f63baabf91876a, []int{3}
+	return fileDescriptor_15f63baabf91876a, []int{5}
 }
 func (m *ResponseBroadcastTx) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -211,43 +321,217 @@ func (m *ResponseBroadcastTx) GetDeliverTx() *types.ResponseDeliverTx {
 	return nil
 }
 
+type ResponseStreamBlocks struct {
+	Header               *types.Header             `protobuf:"bytes,1,opt,name=header,proto3" json:"header,omitempty"`
+	BlockId              *types.BlockID            `protobuf:"bytes,2,opt,name=block_id,json=blockId,proto3" json:"block_id,omitempty"`
+	Txs                  [][]byte                  `protobuf:"bytes,3,rep,name=txs,proto3" json:"txs,omitempty"`
+	BeginBlock           *types.ResponseBeginBlock `protobuf:"bytes,4,opt,name=begin_block,json=beginBlock,proto3" json:"begin_block,omitempty"`
+	EndBlock             *types.ResponseEndBlock   `protobuf:"bytes,5,opt,name=end_block,json=endBlock,proto3" json:"end_block,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                  `json:"-"`
+	XXX_unrecognized     []byte                    `json:"-"`
+	XXX_sizecache        int32                     `json:"-"`
+}
+
+func (m *ResponseStreamBlocks) Reset()         { *m = ResponseStreamBlocks{} }
+func (m *ResponseStreamBlocks) String() string { return proto.CompactTextString(m) }
+func (*ResponseStreamBlocks) ProtoMessage()    {}
+func (*ResponseStreamBlocks) Descriptor() ([]byte, []int) {
+	return fileDescriptor_15f63baabf91876a, []int{6}
+}
+func (m *ResponseStreamBlocks) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ResponseStreamBlocks) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ResponseStreamBlocks.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *ResponseStreamBlocks) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ResponseStreamBlocks.Merge(m, src)
+}
+func (m *ResponseStreamBlocks) XXX_Size() int {
+	return m.Size()
+}
+func (m *ResponseStreamBlocks) XXX_DiscardUnknown() {
+	xxx_messageInfo_ResponseStreamBlocks.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ResponseStreamBlocks proto.InternalMessageInfo
+
+func (m *ResponseStreamBlocks) GetHeader() *types.Header {
+	if m != nil {
+		return m.Header
+	}
+	return nil
+}
+
+func (m *ResponseStreamBlocks) GetBlockId() *types.BlockID {
+	if m != nil {
+		return m.BlockId
+	}
+	return nil
+}
+
+func (m *ResponseStreamBlocks) GetTxs() [][]byte {
+	if m != nil {
+		return m.Txs
+	}
+	return nil
+}
+
+func (m *ResponseStreamBlocks) GetBeginBlock() *types.ResponseBeginBlock {
+	if m != nil {
+		return m.BeginBlock
+	}
+	return nil
+}
+
+func (m *ResponseStreamBlocks) GetEndBlock() *types.ResponseEndBlock {
+	if m != nil {
+		return m.EndBlock
+	}
+	return nil
+}
+
+type ResponseStreamTxs struct {
+	Height               int64                    `protobuf:"varint,1,opt,name=height,proto3" json:"height,omitempty"`
+	Index                uint32                   `protobuf:"varint,2,opt,name=index,proto3" json:"index,omitempty"`
+	Tx                   []byte                   `protobuf:"bytes,3,opt,name=tx,proto3" json:"tx,omitempty"`
+	DeliverTx            *types.ResponseDeliverTx `protobuf:"bytes,4,opt,name=deliver_tx,json=deliverTx,proto3" json:"deliver_tx,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                 `json:"-"`
+	XXX_unrecognized     []byte                   `json:"-"`
+	XXX_sizecache        int32                    `json:"-"`
+}
+
+func (m *ResponseStreamTxs) Reset()         { *m = ResponseStreamTxs{} }
+func (m *ResponseStreamTxs) String() string { return proto.CompactTextString(m) }
+func (*ResponseStreamTxs) ProtoMessage()    {}
+func (*ResponseStreamTxs) Descriptor() ([]byte, []int) {
+	return fileDescriptor_15f63baabf91876a, []int{7}
+}
+func (m *ResponseStreamTxs) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ResponseStreamTxs) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ResponseStreamTxs.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *ResponseStreamTxs) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ResponseStreamTxs.Merge(m, src)
+}
+func (m *ResponseStreamTxs) XXX_Size() int {
+	return m.Size()
+}
+func (m *ResponseStreamTxs) XXX_DiscardUnknown() {
+	xxx_messageInfo_ResponseStreamTxs.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ResponseStreamTxs proto.InternalMessageInfo
+
+func (m *ResponseStreamTxs) GetHeight() int64 {
+	if m != nil {
+		return m.Height
+	}
+	return 0
+}
+
+func (m *ResponseStreamTxs) GetIndex() uint32 {
+	if m != nil {
+		return m.Index
+	}
+	return 0
+}
+
+func (m *ResponseStreamTxs) GetTx() []byte {
+	if m != nil {
+		return m.Tx
+	}
+	return nil
+}
+
+func (m *ResponseStreamTxs) GetDeliverTx() *types.ResponseDeliverTx {
+	if m != nil {
+		return m.DeliverTx
+	}
+	return nil
+}
+
 func init() {
 	proto.RegisterType((*RequestPing)(nil), "core_grpc.RequestPing")
 	golang_proto.RegisterType((*RequestPing)(nil), "core_grpc.RequestPing")
 	proto.RegisterType((*RequestBroadcastTx)(nil), "core_grpc.RequestBroadcastTx")
 	golang_proto.RegisterType((*RequestBroadcastTx)(nil), "core_grpc.RequestBroadcastTx")
+	proto.RegisterType((*RequestStreamBlocks)(nil), "core_grpc.RequestStreamBlocks")
+	golang_proto.RegisterType((*RequestStreamBlocks)(nil), "core_grpc.RequestStreamBlocks")
+	proto.RegisterType((*RequestStreamTxs)(nil), "core_grpc.RequestStreamTxs")
+	golang_proto.RegisterType((*RequestStreamTxs)(nil), "core_grpc.RequestStreamTxs")
 	proto.RegisterType((*ResponsePing)(nil), "core_grpc.ResponsePing")
 	golang_proto.RegisterType((*ResponsePing)(nil), "core_grpc.ResponsePing")
 	proto.RegisterType((*ResponseBroadcastTx)(nil), "core_grpc.ResponseBroadcastTx")
 	golang_proto.RegisterType((*ResponseBroadcastTx)(nil), "core_grpc.ResponseBroadcastTx")
+	proto.RegisterType((*ResponseStreamBlocks)(nil), "core_grpc.ResponseStreamBlocks")
+	golang_proto.RegisterType((*ResponseStreamBlocks)(nil), "core_grpc.ResponseStreamBlocks")
+	proto.RegisterType((*ResponseStreamTxs)(nil), "core_grpc.ResponseStreamTxs")
+	golang_proto.RegisterType((*ResponseStreamTxs)(nil), "core_grpc.ResponseStreamTxs")
 }
 
 func init() { proto.RegisterFile("rpc/grpc/types.proto", fileDescriptor_15f63baabf91876a) }
 func init() { golang_proto.RegisterFile("rpc/grpc/types.proto", fileDescriptor_15f63baabf91876a) }
 
 var fileDescriptor_15f63baabf91876a = []byte{
-	// 321 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xe2, 0x12, 0x29, 0x2a, 0x48, 0xd6,
-	0x4f, 0x07, 0x11, 0x25, 0x95, 0x05, 0xa9, 0xc5, 0x7a, 0x05, 0x45, 0xf9, 0x25, 0xf9, 0x42, 0x9c,
-	0xc9, 0xf9, 0x45, 0xa9, 0xf1, 0x20, 0x61, 0x29, 0xdd, 0xf4, 0xcc, 0x92, 0x8c, 0xd2, 0x24, 0xbd,
-	0xe4, 0xfc, 0x5c, 0xfd, 0xf4, 0xfc, 0xf4, 0x7c, 0x7d, 0xb0, 0x8a, 0xa4, 0xd2, 0x34, 0x30, 0x0f,
-	0xcc, 0x01, 0xb3, 0x20, 0x3a, 0xa5, 0xcc, 0x91, 0x94, 0x97, 0xa4, 0xe6, 0xa5, 0xa4, 0x16, 0xe5,
-	0x66, 0xe6, 0x95, 0x20, 0x33, 0x13, 0x93, 0x92, 0x33, 0x21, 0x96, 0x21, 0x5b, 0xa9, 0xc4, 0xcb,
-	0xc5, 0x1d, 0x94, 0x5a, 0x58, 0x9a, 0x5a, 0x5c, 0x12, 0x90, 0x99, 0x97, 0xae, 0xa4, 0xc2, 0x25,
-	0x04, 0xe5, 0x3a, 0x15, 0xe5, 0x27, 0xa6, 0x24, 0x27, 0x16, 0x97, 0x84, 0x54, 0x08, 0xf1, 0x71,
-	0x31, 0x95, 0x54, 0x48, 0x30, 0x2a, 0x30, 0x6a, 0xf0, 0x04, 0x31, 0x95, 0x54, 0x28, 0xf1, 0x71,
-	0xf1, 0x04, 0xa5, 0x16, 0x17, 0xe4, 0xe7, 0x15, 0xa7, 0x82, 0x75, 0x35, 0x32, 0x72, 0x09, 0xc3,
-	0x04, 0x90, 0xf5, 0x19, 0x72, 0x71, 0x24, 0x67, 0xa4, 0x26, 0x67, 0xc7, 0x43, 0x75, 0x73, 0x1b,
-	0x89, 0xe9, 0x41, 0x2c, 0x87, 0xa9, 0x76, 0x06, 0x49, 0x87, 0x54, 0x04, 0xb1, 0x27, 0x43, 0x18,
-	0x42, 0xe6, 0x5c, 0x5c, 0x29, 0xa9, 0x39, 0x99, 0x65, 0xa9, 0x45, 0x20, 0x4d, 0x4c, 0x60, 0x4d,
-	0x12, 0x68, 0x9a, 0x5c, 0x20, 0x0a, 0x42, 0x2a, 0x82, 0x38, 0x53, 0x60, 0x4c, 0xa3, 0xa9, 0x8c,
-	0x5c, 0x3c, 0x70, 0xbb, 0x1d, 0x03, 0x3c, 0x85, 0xcc, 0xb9, 0x58, 0x40, 0x8e, 0x13, 0x12, 0xd3,
-	0x83, 0x87, 0xaa, 0x1e, 0x92, 0x57, 0xa5, 0xc4, 0x51, 0xc4, 0x11, 0xbe, 0x11, 0xf2, 0xe1, 0xe2,
-	0x46, 0xf6, 0x84, 0x2c, 0xa6, 0x7e, 0x24, 0x69, 0x29, 0x39, 0x2c, 0xc6, 0x20, 0xc9, 0x3b, 0xc9,
-	0xfc, 0x78, 0x28, 0xc7, 0xb8, 0xe2, 0x91, 0x1c, 0xe3, 0x8e, 0x47, 0x72, 0x8c, 0x27, 0x1e, 0xc9,
-	0x31, 0x5e, 0x78, 0x24, 0xc7, 0xf8, 0xe0, 0x91, 0x1c, 0xe3, 0x81, 0xc7, 0x72, 0x8c, 0x49, 0x6c,
-	0xe0, 0x58, 0x30, 0x06, 0x04, 0x00, 0x00, 0xff, 0xff, 0xd5, 0xa8, 0xe4, 0xd9, 0x10, 0x02, 0x00,
-	0x00,
+	// 584 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x9c, 0x54, 0xcd, 0x6e, 0xd3, 0x4c,
+	0x14, 0xd5, 0x24, 0x6d, 0x1a, 0xdf, 0xfc, 0xa8, 0xdf, 0x34, 0x4a, 0xf3, 0x99, 0xe2, 0x46, 0x16,
+	0x48, 0x61, 0xd1, 0xa4, 0x84, 0x4a, 0x95, 0xd8, 0x11, 0x8a, 0x94, 0x48, 0x5d, 0x94, 0xc1, 0xfb,
+	0xc8, 0x3f, 0x53, 0xdb, 0x6a, 0xe3, 0x49, 0xed, 0x09, 0x72, 0x97, 0x6c, 0xd9, 0xf3, 0x0e, 0x7d,
+	0x04, 0x96, 0x2c, 0x59, 0xf2, 0x08, 0x10, 0xb6, 0x3c, 0x00, 0x4b, 0x34, 0xe3, 0x49, 0xea, 0xa4,
+	0xad, 0x84, 0xd8, 0x44, 0xf7, 0xe7, 0x9c, 0x33, 0x73, 0xef, 0x9c, 0x18, 0x1a, 0xf1, 0xd4, 0xed,
+	0xf9, 0xe2, 0x87, 0x5f, 0x4f, 0x69, 0xd2, 0x9d, 0xc6, 0x8c, 0x33, 0xac, 0xb9, 0x2c, 0xa6, 0x63,
+	0x51, 0xd6, 0x0f, 0xfc, 0x90, 0x07, 0x33, 0xa7, 0xeb, 0xb2, 0x49, 0xcf, 0x67, 0x3e, 0xeb, 0x49,
+	0x84, 0x33, 0x3b, 0x97, 0x99, 0x4c, 0x64, 0x94, 0x31, 0xf5, 0xa3, 0x1c, 0x3c, 0xf0, 0x6c, 0xf7,
+	0x20, 0x64, 0x3d, 0x4e, 0x23, 0x8f, 0xc6, 0x93, 0x30, 0xe2, 0x3d, 0xdb, 0x71, 0xc3, 0xec, 0xa4,
+	0xfc, 0x79, 0x66, 0x0d, 0x2a, 0x84, 0x5e, 0xcd, 0x68, 0xc2, 0xcf, 0xc2, 0xc8, 0x37, 0x9f, 0x00,
+	0x56, 0xe9, 0x20, 0x66, 0xb6, 0xe7, 0xda, 0x09, 0xb7, 0x52, 0x5c, 0x87, 0x02, 0x4f, 0x5b, 0xa8,
+	0x8d, 0x3a, 0x55, 0x52, 0xe0, 0xa9, 0x79, 0x0a, 0x3b, 0x0a, 0xf5, 0x8e, 0xc7, 0xd4, 0x9e, 0x0c,
+	0x2e, 0x99, 0x7b, 0x91, 0xe0, 0x7d, 0xa8, 0x9c, 0xc7, 0x6c, 0x32, 0x0e, 0x68, 0xe8, 0x07, 0x5c,
+	0xe2, 0x8b, 0x04, 0x44, 0x69, 0x28, 0x2b, 0xb8, 0x01, 0x9b, 0x57, 0x33, 0x1a, 0x5f, 0xb7, 0x0a,
+	0x6d, 0xd4, 0xd1, 0x48, 0x96, 0x98, 0x23, 0xd8, 0x5e, 0x51, 0xb3, 0xd2, 0x7f, 0x96, 0xaa, 0x43,
+	0x95, 0xd0, 0x64, 0xca, 0xa2, 0x84, 0xca, 0x71, 0x3e, 0x20, 0xd8, 0x59, 0x14, 0xf2, 0x03, 0x3d,
+	0x87, 0xb2, 0x1b, 0x50, 0xf7, 0x62, 0xac, 0xc6, 0xaa, 0xf4, 0x9b, 0xdd, 0x6c, 0x2b, 0x0b, 0xf4,
+	0x6b, 0xd1, 0xb6, 0x52, 0xb2, 0xe5, 0x66, 0x01, 0x3e, 0x06, 0xf0, 0xe8, 0x65, 0xf8, 0x9e, 0xc6,
+	0x82, 0x54, 0x90, 0xa4, 0xd6, 0x1a, 0xe9, 0x24, 0x03, 0x58, 0x29, 0xd1, 0xbc, 0x45, 0x68, 0xfe,
+	0x42, 0xd0, 0x58, 0x00, 0x56, 0xd6, 0xf5, 0x14, 0x4a, 0x01, 0xb5, 0x3d, 0x1a, 0xab, 0x2b, 0xd4,
+	0x94, 0xda, 0x50, 0x16, 0x89, 0x6a, 0xe2, 0x67, 0x50, 0x76, 0x04, 0x61, 0x1c, 0x7a, 0xea, 0xd8,
+	0xba, 0x02, 0x4a, 0x9d, 0xd1, 0x09, 0xd9, 0x92, 0xfd, 0x91, 0x87, 0xb7, 0xa1, 0xc8, 0xd3, 0xa4,
+	0x55, 0x6c, 0x17, 0x3b, 0x55, 0x22, 0x42, 0xfc, 0x12, 0x2a, 0x0e, 0xf5, 0xc3, 0x68, 0x2c, 0x21,
+	0xad, 0x0d, 0xc9, 0xff, 0x7f, 0xed, 0xda, 0x03, 0x81, 0x90, 0x62, 0x04, 0x9c, 0x65, 0x8c, 0x8f,
+	0x40, 0xa3, 0x91, 0xa7, 0x98, 0x9b, 0x92, 0xb9, 0xbb, 0xc6, 0x7c, 0x13, 0x79, 0x19, 0xaf, 0x4c,
+	0x55, 0x64, 0x7e, 0x44, 0xf0, 0xdf, 0xea, 0xb8, 0xe2, 0x3d, 0x9b, 0x62, 0xd6, 0xdc, 0x53, 0x96,
+	0x82, 0xe5, 0x33, 0x86, 0x91, 0x47, 0xb3, 0x85, 0xd6, 0x48, 0x96, 0x28, 0xbf, 0x15, 0x17, 0x7e,
+	0x5b, 0xdb, 0xfd, 0xc6, 0x5f, 0xef, 0xbe, 0xff, 0x09, 0x41, 0x75, 0xf9, 0xee, 0xaf, 0xce, 0x46,
+	0xf8, 0x18, 0x36, 0x84, 0x31, 0x70, 0xb3, 0xbb, 0xfc, 0x9f, 0x75, 0x73, 0xfe, 0xd7, 0x77, 0x57,
+	0xea, 0xb7, 0x4e, 0xc2, 0xa7, 0x50, 0xc9, 0x1b, 0xe8, 0xf1, 0x5d, 0x7e, 0xae, 0xad, 0x1b, 0xf7,
+	0xc8, 0xe4, 0xfa, 0xfd, 0x1b, 0x04, 0x5a, 0xb6, 0x1c, 0x71, 0xa9, 0xb7, 0x50, 0x5d, 0x31, 0x86,
+	0x71, 0x57, 0x3c, 0xdf, 0xd7, 0xf7, 0xef, 0x51, 0xcf, 0x03, 0x0e, 0x11, 0x1e, 0x82, 0x76, 0xbb,
+	0xfc, 0x47, 0x0f, 0xe9, 0x59, 0x69, 0xa2, 0xef, 0x3d, 0x28, 0x66, 0xa5, 0xc9, 0x21, 0x1a, 0xec,
+	0xfd, 0xfe, 0x61, 0xa0, 0x9b, 0xb9, 0x81, 0x3e, 0xcf, 0x0d, 0xf4, 0x75, 0x6e, 0xa0, 0x6f, 0x73,
+	0x03, 0x7d, 0x9f, 0x1b, 0xe8, 0xcb, 0x4f, 0x03, 0x39, 0x25, 0xf9, 0x15, 0x79, 0xf1, 0x67, 0x00,
+	0x70, 0xb1, 0x86, 0x36, 0xcd, 0x04, 0x00, 0x00,
 }
 
 func (this *RequestPing) Equal(that interface{}) bool {
@@ -301,6 +585,66 @@ func (this *RequestBroadcastTx) Equal(that interface{}) bool {
 	}
 	return true
 }
+func (this *RequestStreamBlocks) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
+
+	that1, ok := that.(*RequestStreamBlocks)
+	if !ok {
+		that2, ok := that.(RequestStreamBlocks)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
+	}
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
+	}
+	if this.FromHeight != that1.FromHeight {
+		return false
+	}
+	if this.Query != that1.Query {
+		return false
+	}
+	if !bytes.Equal(this.XXX_unrecognized, that1.XXX_unrecognized) {
+		return false
+	}
+	return true
+}
+func (this *RequestStreamTxs) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
+
+	that1, ok := that.(*RequestStreamTxs)
+	if !ok {
+		that2, ok := that.(RequestStreamTxs)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
+	}
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
+	}
+	if this.FromHeight != that1.FromHeight {
+		return false
+	}
+	if this.Query != that1.Query {
+		return false
+	}
+	if !bytes.Equal(this.XXX_unrecognized, that1.XXX_unrecognized) {
+		return false
+	}
+	return true
+}
 func (this *ResponsePing) Equal(that interface{}) bool {
 	if that == nil {
 		return this == nil
@@ -355,49 +699,129 @@ func (this *ResponseBroadcastTx) Equal(that interface{}) bool {
 	}
 	return true
 }
-
-// Reference imports to suppress errors if they are not otherwise used.
-var _ context.Context
-var _ grpc.ClientConn
-
-// This is a compile-time assertion to ensure that this generated file
-// is compatible with the grpc package it is being compiled against.
-const _ = grpc.SupportPackageIsVersion4
-
-// BroadcastAPIClient is the client API for BroadcastAPI service.
-//
-// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
-type BroadcastAPIClient interface {
-	Ping(ctx context.Context, in *RequestPing, opts ...grpc.CallOption) (*ResponsePing, error)
-	BroadcastTx(ctx context.Context, in *RequestBroadcastTx, opts ...grpc.CallOption) (*ResponseBroadcastTx, error)
-}
-
-type broadcastAPIClient struct {
-	cc *grpc.ClientConn
-}
-
-func NewBroadcastAPIClient(cc *grpc.ClientConn) BroadcastAPIClient {
-	return &broadcastAPIClient{cc}
-}
-
-func (c *broadcastAPIClient) Ping(ctx context.Context, in *RequestPing, opts ...grpc.CallOption) (*ResponsePing, error) {
-	out := new(ResponsePing)
-	err := c.cc.Invoke(ctx, "/core_grpc.BroadcastAPI/Ping", in, out, opts...)
-	if err != nil {
-		return nil, err
+func (this *ResponseStreamBlocks) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
 	}
-	return out, nil
-}
 
-func (c *broadcastAPIClient) BroadcastTx(ctx context.Context, in *RequestBroadcastTx, opts ...grpc.CallOption) (*ResponseBroadcastTx, error) {
-	out := new(ResponseBroadcastTx)
-	err := c.cc.Invoke(ctx, "/core_grpc.BroadcastAPI/BroadcastTx", in, out, opts...)
-	if err != nil {
-		return nil, err
+	that1, ok := that.(*ResponseStreamBlocks)
+	if !ok {
+		that2, ok := that.(ResponseStreamBlocks)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
 	}
-	return out, nil
-}
-
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
+	}
+	if !this.Header.Equal(that1.Header) {
+		return false
+	}
+	if !this.BlockId.Equal(that1.BlockId) {
+		return false
+	}
+	if len(this.Txs) != len(that1.Txs) {
+		return false
+	}
+	for i := range this.Txs {
+		if !bytes.Equal(this.Txs[i], that1.Txs[i]) {
+			return false
+		}
+	}
+	if !this.BeginBlock.Equal(that1.BeginBlock) {
+		return false
+	}
+	if !this.EndBlock.Equal(that1.EndBlock) {
+		return false
+	}
+	if !bytes.Equal(this.XXX_unrecognized, that1.XXX_unrecognized) {
+		return false
+	}
+	return true
+}
+func (this *ResponseStreamTxs) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
+
+	that1, ok := that.(*ResponseStreamTxs)
+	if !ok {
+		that2, ok := that.(ResponseStreamTxs)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
+	}
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
+	}
+	if this.Height != that1.Height {
+		return false
+	}
+	if this.Index != that1.Index {
+		return false
+	}
+	if !bytes.Equal(this.Tx, that1.Tx) {
+		return false
+	}
+	if !this.DeliverTx.Equal(that1.DeliverTx) {
+		return false
+	}
+	if !bytes.Equal(this.XXX_unrecognized, that1.XXX_unrecognized) {
+		return false
+	}
+	return true
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConn
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion4
+
+// BroadcastAPIClient is the client API for BroadcastAPI service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type BroadcastAPIClient interface {
+	Ping(ctx context.Context, in *RequestPing, opts ...grpc.CallOption) (*ResponsePing, error)
+	BroadcastTx(ctx context.Context, in *RequestBroadcastTx, opts ...grpc.CallOption) (*ResponseBroadcastTx, error)
+}
+
+type broadcastAPIClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewBroadcastAPIClient(cc *grpc.ClientConn) BroadcastAPIClient {
+	return &broadcastAPIClient{cc}
+}
+
+func (c *broadcastAPIClient) Ping(ctx context.Context, in *RequestPing, opts ...grpc.CallOption) (*ResponsePing, error) {
+	out := new(ResponsePing)
+	err := c.cc.Invoke(ctx, "/core_grpc.BroadcastAPI/Ping", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *broadcastAPIClient) BroadcastTx(ctx context.Context, in *RequestBroadcastTx, opts ...grpc.CallOption) (*ResponseBroadcastTx, error) {
+	out := new(ResponseBroadcastTx)
+	err := c.cc.Invoke(ctx, "/core_grpc.BroadcastAPI/BroadcastTx", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // BroadcastAPIServer is the server API for BroadcastAPI service.
 type BroadcastAPIServer interface {
 	Ping(context.Context, *RequestPing) (*ResponsePing, error)
@@ -472,6 +896,168 @@ var _BroadcastAPI_serviceDesc = grpc.ServiceDesc{
 	Metadata: "rpc/grpc/types.proto",
 }
 
+// StreamAPIClient is the client API for StreamAPI service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type StreamAPIClient interface {
+	StreamBlocks(ctx context.Context, in *RequestStreamBlocks, opts ...grpc.CallOption) (StreamAPI_StreamBlocksClient, error)
+	StreamTxs(ctx context.Context, in *RequestStreamTxs, opts ...grpc.CallOption) (StreamAPI_StreamTxsClient, error)
+}
+
+type streamAPIClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewStreamAPIClient(cc *grpc.ClientConn) StreamAPIClient {
+	return &streamAPIClient{cc}
+}
+
+func (c *streamAPIClient) StreamBlocks(ctx context.Context, in *RequestStreamBlocks, opts ...grpc.CallOption) (StreamAPI_StreamBlocksClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_StreamAPI_serviceDesc.Streams[0], "/core_grpc.StreamAPI/StreamBlocks", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &streamAPIStreamBlocksClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type StreamAPI_StreamBlocksClient interface {
+	Recv() (*ResponseStreamBlocks, error)
+	grpc.ClientStream
+}
+
+type streamAPIStreamBlocksClient struct {
+	grpc.ClientStream
+}
+
+func (x *streamAPIStreamBlocksClient) Recv() (*ResponseStreamBlocks, error) {
+	m := new(ResponseStreamBlocks)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *streamAPIClient) StreamTxs(ctx context.Context, in *RequestStreamTxs, opts ...grpc.CallOption) (StreamAPI_StreamTxsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_StreamAPI_serviceDesc.Streams[1], "/core_grpc.StreamAPI/StreamTxs", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &streamAPIStreamTxsClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type StreamAPI_StreamTxsClient interface {
+	Recv() (*ResponseStreamTxs, error)
+	grpc.ClientStream
+}
+
+type streamAPIStreamTxsClient struct {
+	grpc.ClientStream
+}
+
+func (x *streamAPIStreamTxsClient) Recv() (*ResponseStreamTxs, error) {
+	m := new(ResponseStreamTxs)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// StreamAPIServer is the server API for StreamAPI service.
+type StreamAPIServer interface {
+	StreamBlocks(*RequestStreamBlocks, StreamAPI_StreamBlocksServer) error
+	StreamTxs(*RequestStreamTxs, StreamAPI_StreamTxsServer) error
+}
+
+// UnimplementedStreamAPIServer can be embedded to have forward compatible implementations.
+type UnimplementedStreamAPIServer struct {
+}
+
+func (*UnimplementedStreamAPIServer) StreamBlocks(req *RequestStreamBlocks, srv StreamAPI_StreamBlocksServer) error {
+	return status.Errorf(codes.Unimplemented, "method StreamBlocks not implemented")
+}
+func (*UnimplementedStreamAPIServer) StreamTxs(req *RequestStreamTxs, srv StreamAPI_StreamTxsServer) error {
+	return status.Errorf(codes.Unimplemented, "method StreamTxs not implemented")
+}
+
+func RegisterStreamAPIServer(s *grpc.Server, srv StreamAPIServer) {
+	s.RegisterService(&_StreamAPI_serviceDesc, srv)
+}
+
+func _StreamAPI_StreamBlocks_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(RequestStreamBlocks)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(StreamAPIServer).StreamBlocks(m, &streamAPIStreamBlocksServer{stream})
+}
+
+type StreamAPI_StreamBlocksServer interface {
+	Send(*ResponseStreamBlocks) error
+	grpc.ServerStream
+}
+
+type streamAPIStreamBlocksServer struct {
+	grpc.ServerStream
+}
+
+func (x *streamAPIStreamBlocksServer) Send(m *ResponseStreamBlocks) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _StreamAPI_StreamTxs_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(RequestStreamTxs)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(StreamAPIServer).StreamTxs(m, &streamAPIStreamTxsServer{stream})
+}
+
+type StreamAPI_StreamTxsServer interface {
+	Send(*ResponseStreamTxs) error
+	grpc.ServerStream
+}
+
+type streamAPIStreamTxsServer struct {
+	grpc.ServerStream
+}
+
+func (x *streamAPIStreamTxsServer) Send(m *ResponseStreamTxs) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+var _StreamAPI_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "core_grpc.StreamAPI",
+	HandlerType: (*StreamAPIServer)(nil),
+	Methods:     []grpc.MethodDesc{},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "StreamBlocks",
+			Handler:       _StreamAPI_StreamBlocks_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "StreamTxs",
+			Handler:       _StreamAPI_StreamTxs_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "rpc/grpc/types.proto",
+}
+
 func (m *RequestPing) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
@@ -533,6 +1119,84 @@ func (m *RequestBroadcastTx) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	return len(dAtA) - i, nil
 }
 
+func (m *RequestStreamBlocks) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *RequestStreamBlocks) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *RequestStreamBlocks) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if len(m.Query) > 0 {
+		i -= len(m.Query)
+		copy(dAtA[i:], m.Query)
+		i = encodeVarintTypes(dAtA, i, uint64(len(m.Query)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if m.FromHeight != 0 {
+		i = encodeVarintTypes(dAtA, i, uint64(m.FromHeight))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *RequestStreamTxs) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *RequestStreamTxs) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *RequestStreamTxs) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if len(m.Query) > 0 {
+		i -= len(m.Query)
+		copy(dAtA[i:], m.Query)
+		i = encodeVarintTypes(dAtA, i, uint64(len(m.Query)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if m.FromHeight != 0 {
+		i = encodeVarintTypes(dAtA, i, uint64(m.FromHeight))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
 func (m *ResponsePing) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
@@ -611,26 +1275,166 @@ func (m *ResponseBroadcastTx) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	return len(dAtA) - i, nil
 }
 
-func encodeVarintTypes(dAtA []byte, offset int, v uint64) int {
-	offset -= sovTypes(v)
-	base := offset
-	for v >= 1<<7 {
-		dAtA[offset] = uint8(v&0x7f | 0x80)
-		v >>= 7
-		offset++
-	}
-	dAtA[offset] = uint8(v)
-	return base
-}
-func NewPopulatedRequestPing(r randyTypes, easy bool) *RequestPing {
-	this := &RequestPing{}
-	if !easy && r.Intn(10) != 0 {
-		this.XXX_unrecognized = randUnrecognizedTypes(r, 1)
+func (m *ResponseStreamBlocks) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
 	}
-	return this
+	return dAtA[:n], nil
 }
 
-func NewPopulatedRequestBroadcastTx(r randyTypes, easy bool) *RequestBroadcastTx {
+func (m *ResponseStreamBlocks) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ResponseStreamBlocks) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if m.EndBlock != nil {
+		{
+			size, err := m.EndBlock.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintTypes(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x2a
+	}
+	if m.BeginBlock != nil {
+		{
+			size, err := m.BeginBlock.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintTypes(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x22
+	}
+	if len(m.Txs) > 0 {
+		for iNdEx := len(m.Txs) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.Txs[iNdEx])
+			copy(dAtA[i:], m.Txs[iNdEx])
+			i = encodeVarintTypes(dAtA, i, uint64(len(m.Txs[iNdEx])))
+			i--
+			dAtA[i] = 0x1a
+		}
+	}
+	if m.BlockId != nil {
+		{
+			size, err := m.BlockId.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintTypes(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x12
+	}
+	if m.Header != nil {
+		{
+			size, err := m.Header.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintTypes(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *ResponseStreamTxs) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ResponseStreamTxs) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ResponseStreamTxs) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if m.DeliverTx != nil {
+		{
+			size, err := m.DeliverTx.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintTypes(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x22
+	}
+	if len(m.Tx) > 0 {
+		i -= len(m.Tx)
+		copy(dAtA[i:], m.Tx)
+		i = encodeVarintTypes(dAtA, i, uint64(len(m.Tx)))
+		i--
+		dAtA[i] = 0x1a
+	}
+	if m.Index != 0 {
+		i = encodeVarintTypes(dAtA, i, uint64(m.Index))
+		i--
+		dAtA[i] = 0x10
+	}
+	if m.Height != 0 {
+		i = encodeVarintTypes(dAtA, i, uint64(m.Height))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func encodeVarintTypes(dAtA []byte, offset int, v uint64) int {
+	offset -= sovTypes(v)
+	base := offset
+	for v >= 1<<7 {
+		dAtA[offset] = uint8(v&0x7f | 0x80)
+		v >>= 7
+		offset++
+	}
+	dAtA[offset] = uint8(v)
+	return base
+}
+func NewPopulatedRequestPing(r randyTypes, easy bool) *RequestPing {
+	this := &RequestPing{}
+	if !easy && r.Intn(10) != 0 {
+		this.XXX_unrecognized = randUnrecognizedTypes(r, 1)
+	}
+	return this
+}
+
+func NewPopulatedRequestBroadcastTx(r randyTypes, easy bool) *RequestBroadcastTx {
 	this := &RequestBroadcastTx{}
 	v1 := r.Intn(100)
 	this.Tx = make([]byte, v1)
@@ -643,6 +1447,32 @@ func NewPopulatedRequestBroadcastTx(r randyTypes, easy bool) *RequestBroadcastTx
 	return this
 }
 
+func NewPopulatedRequestStreamBlocks(r randyTypes, easy bool) *RequestStreamBlocks {
+	this := &RequestStreamBlocks{}
+	this.FromHeight = int64(r.Int63())
+	if r.Intn(2) == 0 {
+		this.FromHeight *= -1
+	}
+	this.Query = string(randStringTypes(r))
+	if !easy && r.Intn(10) != 0 {
+		this.XXX_unrecognized = randUnrecognizedTypes(r, 3)
+	}
+	return this
+}
+
+func NewPopulatedRequestStreamTxs(r randyTypes, easy bool) *RequestStreamTxs {
+	this := &RequestStreamTxs{}
+	this.FromHeight = int64(r.Int63())
+	if r.Intn(2) == 0 {
+		this.FromHeight *= -1
+	}
+	this.Query = string(randStringTypes(r))
+	if !easy && r.Intn(10) != 0 {
+		this.XXX_unrecognized = randUnrecognizedTypes(r, 3)
+	}
+	return this
+}
+
 func NewPopulatedResponsePing(r randyTypes, easy bool) *ResponsePing {
 	this := &ResponsePing{}
 	if !easy && r.Intn(10) != 0 {
@@ -665,6 +1495,56 @@ func NewPopulatedResponseBroadcastTx(r randyTypes, easy bool) *ResponseBroadcast
 	return this
 }
 
+func NewPopulatedResponseStreamBlocks(r randyTypes, easy bool) *ResponseStreamBlocks {
+	this := &ResponseStreamBlocks{}
+	if r.Intn(5) != 0 {
+		this.Header = types.NewPopulatedHeader(r, easy)
+	}
+	if r.Intn(5) != 0 {
+		this.BlockId = types.NewPopulatedBlockID(r, easy)
+	}
+	v2 := r.Intn(10)
+	this.Txs = make([][]byte, v2)
+	for i := 0; i < v2; i++ {
+		v3 := r.Intn(100)
+		this.Txs[i] = make([]byte, v3)
+		for j := 0; j < v3; j++ {
+			this.Txs[i][j] = byte(r.Intn(256))
+		}
+	}
+	if r.Intn(5) != 0 {
+		this.BeginBlock = types.NewPopulatedResponseBeginBlock(r, easy)
+	}
+	if r.Intn(5) != 0 {
+		this.EndBlock = types.NewPopulatedResponseEndBlock(r, easy)
+	}
+	if !easy && r.Intn(10) != 0 {
+		this.XXX_unrecognized = randUnrecognizedTypes(r, 6)
+	}
+	return this
+}
+
+func NewPopulatedResponseStreamTxs(r randyTypes, easy bool) *ResponseStreamTxs {
+	this := &ResponseStreamTxs{}
+	this.Height = int64(r.Int63())
+	if r.Intn(2) == 0 {
+		this.Height *= -1
+	}
+	this.Index = uint32(r.Uint32())
+	v4 := r.Intn(100)
+	this.Tx = make([]byte, v4)
+	for i := 0; i < v4; i++ {
+		this.Tx[i] = byte(r.Intn(256))
+	}
+	if r.Intn(5) != 0 {
+		this.DeliverTx = types.NewPopulatedResponseDeliverTx(r, easy)
+	}
+	if !easy && r.Intn(10) != 0 {
+		this.XXX_unrecognized = randUnrecognizedTypes(r, 5)
+	}
+	return this
+}
+
 type randyTypes interface {
 	Float32() float32
 	Float64() float64
@@ -684,9 +1564,9 @@ func randUTF8RuneTypes(r randyTypes) rune {
 	return rune(ru + 61)
 }
 func randStringTypes(r randyTypes) string {
-	v2 := r.Intn(100)
-	tmps := make([]rune, v2)
-	for i := 0; i < v2; i++ {
+	v5 := r.Intn(100)
+	tmps := make([]rune, v5)
+	for i := 0; i < v5; i++ {
 		tmps[i] = randUTF8RuneTypes(r)
 	}
 	return string(tmps)
@@ -708,11 +1588,11 @@ func randFieldTypes(dAtA []byte, r randyTypes, fieldNumber int, wire int) []byte
 	switch wire {
 	case 0:
 		dAtA = encodeVarintPopulateTypes(dAtA, uint64(key))
-		v3 := r.Int63()
+		v6 := r.Int63()
 		if r.Intn(2) == 0 {
-			v3 *= -1
+			v6 *= -1
 		}
-		dAtA = encodeVarintPopulateTypes(dAtA, uint64(v3))
+		dAtA = encodeVarintPopulateTypes(dAtA, uint64(v6))
 	case 1:
 		dAtA = encodeVarintPopulateTypes(dAtA, uint64(key))
 		dAtA = append(dAtA, byte(r.Intn(256)), byte(r.Intn(256)), byte(r.Intn(256)), byte(r.Intn(256)), byte(r.Intn(256)), byte(r.Intn(256)), byte(r.Intn(256)), byte(r.Intn(256)))
@@ -765,6 +1645,44 @@ func (m *RequestBroadcastTx) Size() (n int) {
 	return n
 }
 
+func (m *RequestStreamBlocks) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.FromHeight != 0 {
+		n += 1 + sovTypes(uint64(m.FromHeight))
+	}
+	l = len(m.Query)
+	if l > 0 {
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *RequestStreamTxs) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.FromHeight != 0 {
+		n += 1 + sovTypes(uint64(m.FromHeight))
+	}
+	l = len(m.Query)
+	if l > 0 {
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
 func (m *ResponsePing) Size() (n int) {
 	if m == nil {
 		return 0
@@ -797,6 +1715,66 @@ func (m *ResponseBroadcastTx) Size() (n int) {
 	return n
 }
 
+func (m *ResponseStreamBlocks) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Header != nil {
+		l = m.Header.Size()
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	if m.BlockId != nil {
+		l = m.BlockId.Size()
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	if len(m.Txs) > 0 {
+		for _, b := range m.Txs {
+			l = len(b)
+			n += 1 + l + sovTypes(uint64(l))
+		}
+	}
+	if m.BeginBlock != nil {
+		l = m.BeginBlock.Size()
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	if m.EndBlock != nil {
+		l = m.EndBlock.Size()
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *ResponseStreamTxs) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Height != 0 {
+		n += 1 + sovTypes(uint64(m.Height))
+	}
+	if m.Index != 0 {
+		n += 1 + sovTypes(uint64(m.Index))
+	}
+	l = len(m.Tx)
+	if l > 0 {
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	if m.DeliverTx != nil {
+		l = m.DeliverTx.Size()
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
 func sovTypes(x uint64) (n int) {
 	return (math_bits.Len64(x|1) + 6) / 7
 }
@@ -945,7 +1923,7 @@ func (m *RequestBroadcastTx) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *ResponsePing) Unmarshal(dAtA []byte) error {
+func (m *RequestStreamBlocks) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -968,20 +1946,230 @@ func (m *ResponsePing) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: ResponsePing: wiretype end group for non-group")
+			return fmt.Errorf("proto: RequestStreamBlocks: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: ResponsePing: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: RequestStreamBlocks: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
-		default:
-			iNdEx = preIndex
-			skippy, err := skipTypes(dAtA[iNdEx:])
-			if err != nil {
-				return err
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field FromHeight", wireType)
 			}
-			if skippy < 0 {
-				return ErrInvalidLengthTypes
+			m.FromHeight = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.FromHeight |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Query", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Query = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipTypes(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *RequestStreamTxs) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowTypes
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: RequestStreamTxs: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: RequestStreamTxs: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field FromHeight", wireType)
+			}
+			m.FromHeight = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.FromHeight |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Query", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Query = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipTypes(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ResponsePing) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowTypes
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ResponsePing: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ResponsePing: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		default:
+			iNdEx = preIndex
+			skippy, err := skipTypes(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthTypes
 			}
 			if (iNdEx + skippy) < 0 {
 				return ErrInvalidLengthTypes
@@ -1125,6 +2313,398 @@ func (m *ResponseBroadcastTx) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
+func (m *ResponseStreamBlocks) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowTypes
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ResponseStreamBlocks: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ResponseStreamBlocks: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Header", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Header == nil {
+				m.Header = &types.Header{}
+			}
+			if err := m.Header.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field BlockId", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.BlockId == nil {
+				m.BlockId = &types.BlockID{}
+			}
+			if err := m.BlockId.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Txs", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Txs = append(m.Txs, make([]byte, postIndex-iNdEx))
+			copy(m.Txs[len(m.Txs)-1], dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field BeginBlock", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.BeginBlock == nil {
+				m.BeginBlock = &types.ResponseBeginBlock{}
+			}
+			if err := m.BeginBlock.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field EndBlock", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.EndBlock == nil {
+				m.EndBlock = &types.ResponseEndBlock{}
+			}
+			if err := m.EndBlock.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipTypes(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ResponseStreamTxs) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowTypes
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ResponseStreamTxs: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ResponseStreamTxs: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Height", wireType)
+			}
+			m.Height = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Height |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Index", wireType)
+			}
+			m.Index = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Index |= uint32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Tx", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Tx = append(m.Tx[:0], dAtA[iNdEx:postIndex]...)
+			if m.Tx == nil {
+				m.Tx = []byte{}
+			}
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field DeliverTx", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.DeliverTx == nil {
+				m.DeliverTx = &types.ResponseDeliverTx{}
+			}
+			if err := m.DeliverTx.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipTypes(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
 func skipTypes(dAtA []byte) (n int, err error) {
 	l := len(dAtA)
 	iNdEx := 0
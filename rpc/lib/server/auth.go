@@ -0,0 +1,152 @@
+package rpcserver
+
+import (
+	"bufio"
+	"context"
+	"crypto/subtle"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// Role is the role of a RPC client, which decides the RPC functions it can
+// call.
+type Role string
+
+const (
+	// RolePublic clients can call all the RPC functions but the admin ones.
+	RolePublic Role = "public"
+	// RoleAdmin clients can call all the RPC functions.
+	RoleAdmin Role = "admin"
+)
+
+// ErrUnauthorized is returned when a client calls a RPC function its role
+// doesn't allow.
+var ErrUnauthorized = errors.New("unauthorized: the admin role is required")
+
+// Auth authenticates the RPC clients, and restricts the admin RPC functions to
+// the clients with the admin role. A client has the admin role if it presents
+// one of the admin tokens in the Authorization header ("Bearer <token>"), or a
+// TLS client certificate verified by the server (see Config.ClientCAs), and
+// the public role otherwise.
+type Auth struct {
+	adminFuncs  map[string]struct{}
+	adminTokens [][]byte
+}
+
+// NewAuth returns an Auth restricting the adminFuncs to the clients with one
+// of the adminTokens or a verified client certificate.
+func NewAuth(adminFuncs []string, adminTokens []string) *Auth {
+	a := &Auth{adminFuncs: make(map[string]struct{}, len(adminFuncs))}
+	for _, name := range adminFuncs {
+		a.adminFuncs[name] = struct{}{}
+	}
+	for _, token := range adminTokens {
+		a.adminTokens = append(a.adminTokens, []byte(token))
+	}
+	return a
+}
+
+// Role returns the role of the client of the request.
+func (a *Auth) Role(r *http.Request) Role {
+	if r.TLS != nil && len(r.TLS.VerifiedChains) > 0 {
+		return RoleAdmin
+	}
+	header := r.Header.Get("Authorization")
+	if !strings.HasPrefix(header, "Bearer ") {
+		return RolePublic
+	}
+	token := []byte(strings.TrimSpace(strings.TrimPrefix(header, "Bearer ")))
+	// compare with all the tokens, in constant time
+	matched := 0
+	for _, adminToken := range a.adminTokens {
+		matched |= subtle.ConstantTimeCompare(token, adminToken)
+	}
+	if matched == 1 {
+		return RoleAdmin
+	}
+	return RolePublic
+}
+
+// Authorize returns ErrUnauthorized if the role can't call the RPC function.
+func (a *Auth) Authorize(role Role, funcName string) error {
+	if _, ok := a.adminFuncs[funcName]; ok && role != RoleAdmin {
+		return ErrUnauthorized
+	}
+	return nil
+}
+
+// Handler returns a handler authenticating the client of each request before
+// passing it to next. The RPC handlers then authorize the calls of the
+// request, including the ones of a websocket connection, with the role of its
+// client.
+func (a *Auth) Handler(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ca := &clientAuth{auth: a, role: a.Role(r)}
+		next.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), clientAuthKey{}, ca)))
+	})
+}
+
+type clientAuthKey struct{}
+
+// clientAuth is the role of the client of a request, stored in its context by
+// Auth.Handler.
+type clientAuth struct {
+	auth *Auth
+	role Role
+}
+
+// clientAuthFromContext returns the clientAuth of the request context, or nil
+// if the RPC server has no Auth.
+func clientAuthFromContext(ctx context.Context) *clientAuth {
+	ca, _ := ctx.Value(clientAuthKey{}).(*clientAuth)
+	return ca
+}
+
+// isAdmin returns true if the client has the admin role. It is false if the
+// RPC server has no Auth.
+func (ca *clientAuth) isAdmin() bool {
+	return ca != nil && ca.role == RoleAdmin
+}
+
+// authorize returns ErrUnauthorized if the client can't call the RPC
+// function. All the calls are authorized if the RPC server has no Auth.
+func (ca *clientAuth) authorize(funcName string) error {
+	if ca == nil {
+		return nil
+	}
+	return ca.auth.Authorize(ca.role, funcName)
+}
+
+// ReadTokensFile reads the tokens of a file, one per line. The blank lines and
+// the lines starting with '#' are ignored.
+func ReadTokensFile(path string) ([]string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var tokens []string
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		if strings.ContainsAny(line, " \t") {
+			return nil, fmt.Errorf("invalid token in %s: tokens can't contain spaces", path)
+		}
+		tokens = append(tokens, line)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	if len(tokens) == 0 {
+		return nil, fmt.Errorf("no token in %s", path)
+	}
+	return tokens, nil
+}
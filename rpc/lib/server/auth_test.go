@@ -0,0 +1,171 @@
+package rpcserver_test
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	amino "github.com/tendermint/go-amino"
+	"github.com/hdac-io/tendermint/libs/log"
+	rs "github.com/hdac-io/tendermint/rpc/lib/server"
+	types "github.com/hdac-io/tendermint/rpc/lib/types"
+)
+
+const testAdminToken = "s3cr3t"
+
+// newAuthServer returns a server with a public function "pub" and an admin
+// function "adm", both callable over HTTP and websocket.
+func newAuthServer() *httptest.Server {
+	f := func(ctx *types.Context) (string, error) { return "foo", nil }
+	funcMap := map[string]*rs.RPCFunc{
+		"pub": rs.NewRPCFunc(f, ""),
+		"adm": rs.NewRPCFunc(f, ""),
+	}
+	cdc := amino.NewCodec()
+	mux := http.NewServeMux()
+	wm := rs.NewWebsocketManager(funcMap, cdc)
+	wm.SetLogger(log.TestingLogger())
+	mux.HandleFunc("/websocket", wm.WebsocketHandler)
+	rs.RegisterRPCFuncs(mux, funcMap, cdc, log.TestingLogger())
+
+	auth := rs.NewAuth([]string{"adm"}, []string{testAdminToken})
+	return httptest.NewServer(auth.Handler(mux))
+}
+
+func TestAuthRole(t *testing.T) {
+	auth := rs.NewAuth([]string{"adm"}, []string{testAdminToken, "other"})
+
+	tests := []struct {
+		header string
+		role   rs.Role
+	}{
+		{"", rs.RolePublic},
+		{"Bearer wrong", rs.RolePublic},
+		{"Bearer ", rs.RolePublic},
+		{"Basic " + testAdminToken, rs.RolePublic},
+		{"Bearer " + testAdminToken, rs.RoleAdmin},
+		{"Bearer other", rs.RoleAdmin},
+	}
+	for i, tt := range tests {
+		req := httptest.NewRequest("GET", "/pub", nil)
+		if tt.header != "" {
+			req.Header.Set("Authorization", tt.header)
+		}
+		role := auth.Role(req)
+		assert.Equal(t, tt.role, role, "#%d", i)
+
+		assert.NoError(t, auth.Authorize(role, "pub"), "#%d", i)
+		if role == rs.RoleAdmin {
+			assert.NoError(t, auth.Authorize(role, "adm"), "#%d", i)
+		} else {
+			assert.Equal(t, rs.ErrUnauthorized, auth.Authorize(role, "adm"), "#%d", i)
+		}
+	}
+}
+
+func TestAuthHTTP(t *testing.T) {
+	s := newAuthServer()
+	defer s.Close()
+
+	call := func(path, body, token string) (int, string) {
+		method := "GET"
+		if body != "" {
+			method = "POST"
+		}
+		req, err := http.NewRequest(method, s.URL+path, strings.NewReader(body))
+		require.NoError(t, err)
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+		res, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		defer res.Body.Close()
+		bz, err := ioutil.ReadAll(res.Body)
+		require.NoError(t, err)
+		return res.StatusCode, string(bz)
+	}
+
+	// URI
+	code, _ := call("/pub", "", "")
+	assert.Equal(t, http.StatusOK, code)
+	code, body := call("/adm", "", "")
+	assert.Equal(t, http.StatusForbidden, code)
+	assert.Contains(t, body, "unauthorized")
+	code, _ = call("/adm", "", testAdminToken)
+	assert.Equal(t, http.StatusOK, code)
+
+	// JSON-RPC, each request of a batch is authorized
+	batch := `[{"jsonrpc":"2.0","id":"0","method":"pub"},{"jsonrpc":"2.0","id":"1","method":"adm"}]`
+	for _, token := range []string{"", testAdminToken} {
+		_, body = call("/", batch, token)
+		var resps []types.RPCResponse
+		require.NoError(t, json.Unmarshal([]byte(body), &resps))
+		require.Len(t, resps, 2)
+		assert.Nil(t, resps[0].Error)
+		if token == "" {
+			require.NotNil(t, resps[1].Error)
+			assert.Contains(t, resps[1].Error.Data, "unauthorized")
+		} else {
+			assert.Nil(t, resps[1].Error)
+		}
+	}
+}
+
+func TestAuthWebsocket(t *testing.T) {
+	s := newAuthServer()
+	defer s.Close()
+
+	for _, token := range []string{"", testAdminToken} {
+		header := http.Header{}
+		if token != "" {
+			header.Set("Authorization", "Bearer "+token)
+		}
+		c, _, err := websocket.DefaultDialer.Dial("ws://"+s.Listener.Addr().String()+"/websocket", header)
+		require.NoError(t, err)
+
+		for _, method := range []string{"pub", "adm"} {
+			require.NoError(t, c.WriteJSON(types.RPCRequest{JSONRPC: "2.0", ID: types.JSONRPCStringID(method), Method: method}))
+			var resp types.RPCResponse
+			require.NoError(t, c.ReadJSON(&resp))
+			if method == "adm" && token == "" {
+				require.NotNil(t, resp.Error)
+				assert.Contains(t, resp.Error.Data, "unauthorized")
+			} else {
+				assert.Nil(t, resp.Error, "%s with token %q", method, token)
+			}
+		}
+		c.Close()
+	}
+}
+
+func TestReadTokensFile(t *testing.T) {
+	dir, err := ioutil.TempDir("", "rpc-tokens")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "tokens")
+
+	require.NoError(t, ioutil.WriteFile(path, []byte("# admins\n\n  token1 \ntoken2\n"), 0600))
+	tokens, err := rs.ReadTokensFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"token1", "token2"}, tokens)
+
+	require.NoError(t, ioutil.WriteFile(path, []byte("# no token\n"), 0600))
+	_, err = rs.ReadTokensFile(path)
+	assert.Error(t, err)
+
+	require.NoError(t, ioutil.WriteFile(path, []byte("two tokens\n"), 0600))
+	_, err = rs.ReadTokensFile(path)
+	assert.Error(t, err)
+
+	_, err = rs.ReadTokensFile(filepath.Join(dir, "missing"))
+	assert.Error(t, err)
+}
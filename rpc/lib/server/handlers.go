@@ -12,6 +12,7 @@ import (
 	"runtime/debug"
 	"sort"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/gorilla/websocket"
@@ -28,7 +29,7 @@ import (
 func RegisterRPCFuncs(mux *http.ServeMux, funcMap map[string]*RPCFunc, cdc *amino.Codec, logger log.Logger) {
 	// HTTP endpoints
 	for funcName, rpcFunc := range funcMap {
-		mux.HandleFunc("/"+funcName, makeHTTPHandler(rpcFunc, cdc, logger))
+		mux.HandleFunc("/"+funcName, makeHTTPHandler(funcName, rpcFunc, cdc, logger))
 	}
 
 	// JSONRPC endpoints
@@ -114,10 +115,7 @@ func makeJSONRPCHandler(funcMap map[string]*RPCFunc, cdc *amino.Codec, logger lo
 		}
 
 		// first try to unmarshal the incoming request as an array of RPC requests
-		var (
-			requests  []types.RPCRequest
-			responses []types.RPCResponse
-		)
+		var requests []types.RPCRequest
 		if err := json.Unmarshal(b, &requests); err != nil {
 			// next, try to unmarshal as a single request
 			var request types.RPCRequest
@@ -126,32 +124,39 @@ func makeJSONRPCHandler(funcMap map[string]*RPCFunc, cdc *amino.Codec, logger lo
 				return
 			}
 			requests = []types.RPCRequest{request}
+		} else if len(requests) == 0 {
+			WriteRPCResponseHTTP(w, types.RPCInvalidRequestError(types.JSONRPCStringID(""), errors.New("empty batch")))
+			return
 		}
 
-		for _, request := range requests {
-			request := request
+		responses := handleBatch(requests, func(request *types.RPCRequest) *types.RPCResponse {
 			// A Notification is a Request object without an "id" member.
 			// The Server MUST NOT reply to a Notification, including those that are within a batch request.
 			if request.ID == types.JSONRPCStringID("") {
 				logger.Debug("HTTPJSONRPC received a notification, skipping... (please send a non-empty ID if you want to call a method)")
-				continue
+				return nil
 			}
+			var resp types.RPCResponse
 			if len(r.URL.Path) > 1 {
-				responses = append(responses, types.RPCInvalidRequestError(request.ID, errors.Errorf("path %s is invalid", r.URL.Path)))
-				continue
+				resp = types.RPCInvalidRequestError(request.ID, errors.Errorf("path %s is invalid", r.URL.Path))
+				return &resp
 			}
 			rpcFunc, ok := funcMap[request.Method]
 			if !ok || rpcFunc.ws {
-				responses = append(responses, types.RPCMethodNotFoundError(request.ID))
-				continue
+				resp = types.RPCMethodNotFoundError(request.ID)
+				return &resp
 			}
-			ctx := &types.Context{JSONReq: &request, HTTPReq: r}
+			if err := clientAuthFromContext(r.Context()).authorize(request.Method); err != nil {
+				resp = types.RPCInvalidRequestError(request.ID, err)
+				return &resp
+			}
+			ctx := &types.Context{JSONReq: request, HTTPReq: r}
 			args := []reflect.Value{reflect.ValueOf(ctx)}
 			if len(request.Params) > 0 {
 				fnArgs, err := jsonParamsToArgs(rpcFunc, cdc, request.Params)
 				if err != nil {
-					responses = append(responses, types.RPCInvalidParamsError(request.ID, errors.Wrap(err, "error converting json params to arguments")))
-					continue
+					resp = types.RPCInvalidParamsError(request.ID, errors.Wrap(err, "error converting json params to arguments"))
+					return &resp
 				}
 				args = append(args, fnArgs...)
 			}
@@ -159,17 +164,62 @@ func makeJSONRPCHandler(funcMap map[string]*RPCFunc, cdc *amino.Codec, logger lo
 			logger.Info("HTTPJSONRPC", "method", request.Method, "args", args, "returns", returns)
 			result, err := unreflectResult(returns)
 			if err != nil {
-				responses = append(responses, types.RPCInternalError(request.ID, err))
-				continue
+				resp = types.RPCInternalError(request.ID, err)
+				return &resp
 			}
-			responses = append(responses, types.NewRPCSuccessResponse(cdc, request.ID, result))
-		}
+			resp = types.NewRPCSuccessResponse(cdc, request.ID, result)
+			return &resp
+		})
 		if len(responses) > 0 {
 			WriteRPCResponseArrayHTTP(w, responses)
 		}
 	}
 }
 
+// maximum number of the requests of a batch handled at the same time
+const maxConcurrentBatchRequests = 16
+
+// handleBatch calls handle for the requests of a batch concurrently, and
+// returns their responses in the order of the requests. handle returns nil for
+// the requests which must not be responded to (notifications). A panic while
+// handling a request of a batch of more than one request is turned into an
+// internal error response, as the panic can't reach the recover of the caller.
+func handleBatch(requests []types.RPCRequest, handle func(*types.RPCRequest) *types.RPCResponse) []types.RPCResponse {
+	results := make([]*types.RPCResponse, len(requests))
+	if len(requests) == 1 {
+		results[0] = handle(&requests[0])
+	} else {
+		var (
+			wg  sync.WaitGroup
+			sem = make(chan struct{}, maxConcurrentBatchRequests)
+		)
+		for i := range requests {
+			wg.Add(1)
+			sem <- struct{}{}
+			go func(i int) {
+				defer func() {
+					if r := recover(); r != nil {
+						resp := types.RPCInternalError(requests[i].ID, fmt.Errorf("panic in handler: %v", r))
+						results[i] = &resp
+					}
+					<-sem
+					wg.Done()
+				}()
+				results[i] = handle(&requests[i])
+			}(i)
+		}
+		wg.Wait()
+	}
+
+	responses := make([]types.RPCResponse, 0, len(results))
+	for _, resp := range results {
+		if resp != nil {
+			responses = append(responses, *resp)
+		}
+	}
+	return responses
+}
+
 func handleInvalidJSONRPCPaths(next http.HandlerFunc) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		// Since the pattern "/" matches all paths not matched by other registered patterns we check whether the path is indeed
@@ -255,7 +305,7 @@ func jsonParamsToArgs(rpcFunc *RPCFunc, cdc *amino.Codec, raw []byte) ([]reflect
 // rpc.http
 
 // convert from a function name to the http handler
-func makeHTTPHandler(rpcFunc *RPCFunc, cdc *amino.Codec, logger log.Logger) func(http.ResponseWriter, *http.Request) {
+func makeHTTPHandler(funcName string, rpcFunc *RPCFunc, cdc *amino.Codec, logger log.Logger) func(http.ResponseWriter, *http.Request) {
 	// Exception for websocket endpoints
 	if rpcFunc.ws {
 		return func(w http.ResponseWriter, r *http.Request) {
@@ -267,6 +317,11 @@ func makeHTTPHandler(rpcFunc *RPCFunc, cdc *amino.Codec, logger log.Logger) func
 	return func(w http.ResponseWriter, r *http.Request) {
 		logger.Debug("HTTP HANDLER", "req", r)
 
+		if err := clientAuthFromContext(r.Context()).authorize(funcName); err != nil {
+			WriteRPCResponseHTTPError(w, http.StatusForbidden, types.RPCInvalidRequestError(types.JSONRPCStringID(""), err))
+			return
+		}
+
 		ctx := &types.Context{HTTPReq: r}
 		args := []reflect.Value{reflect.ValueOf(ctx)}
 
@@ -292,6 +347,12 @@ func makeHTTPHandler(rpcFunc *RPCFunc, cdc *amino.Codec, logger log.Logger) func
 // Covert an http query to a list of properly typed values.
 // To be properly decoded the arg must be a concrete type from tendermint (if its an interface).
 func httpParamsToArgs(rpcFunc *RPCFunc, cdc *amino.Codec, r *http.Request) ([]reflect.Value, error) {
+	return stringParamsToArgs(rpcFunc, cdc, func(name string) string { return GetParam(r, name) })
+}
+
+// stringParamsToArgs converts the string params returned by getParam, by arg
+// name, to a list of properly typed values.
+func stringParamsToArgs(rpcFunc *RPCFunc, cdc *amino.Codec, getParam func(string) string) ([]reflect.Value, error) {
 	// skip types.Context
 	const argsOffset = 1
 
@@ -302,7 +363,7 @@ func httpParamsToArgs(rpcFunc *RPCFunc, cdc *amino.Codec, r *http.Request) ([]re
 
 		values[i] = reflect.Zero(argType) // set default for that type
 
-		arg := GetParam(r, name)
+		arg := getParam(name)
 		// log.Notice("param to arg", "argType", argType, "name", name, "arg", arg)
 
 		if "" == arg {
@@ -433,7 +494,7 @@ type wsConnection struct {
 
 	remoteAddr string
 	baseConn   *websocket.Conn
-	writeChan  chan types.RPCResponse
+	writeChan  chan interface{} // types.RPCResponse or []types.RPCResponse (batch)
 
 	funcMap map[string]*RPCFunc
 	cdc     *amino.Codec
@@ -456,6 +517,14 @@ type wsConnection struct {
 	// callback which is called upon disconnect
 	onDisconnect func(remoteAddr string)
 
+	// role of the client, nil if the server has no Auth
+	clientAuth *clientAuth
+
+	// rate limits of the calls and of the events, nil if unlimited
+	callRateLimit  *connRateLimit
+	eventRateLimit *connRateLimit
+	metrics        *Metrics
+
 	ctx    context.Context
 	cancel context.CancelFunc
 }
@@ -481,6 +550,7 @@ func NewWSConnection(
 		writeChanCapacity: defaultWSWriteChanCapacity,
 		readWait:          defaultWSReadWait,
 		pingPeriod:        defaultWSPingPeriod,
+		metrics:           NopMetrics(),
 	}
 	for _, option := range options {
 		option(wsc)
@@ -538,10 +608,44 @@ func ReadLimit(readLimit int64) func(*wsConnection) {
 	}
 }
 
+// CallRateLimit limits the calls of the connection to rate per second, and
+// burst at once (0 - unlimited). The calls above the rate get an error
+// response. The admin clients are not limited.
+// It should only be used in the constructor - not Goroutine-safe.
+func CallRateLimit(rate float64, burst int) func(*wsConnection) {
+	return func(wsc *wsConnection) {
+		if rate > 0 {
+			wsc.callRateLimit = newConnRateLimit(rate, burst)
+		}
+	}
+}
+
+// EventRateLimit limits the responses written with TryWriteRPCResponse, i.e.
+// the subscription events, to rate per second, and burst at once (0 -
+// unlimited). The ones above the rate are dropped. The admin clients are not
+// limited.
+// It should only be used in the constructor - not Goroutine-safe.
+func EventRateLimit(rate float64, burst int) func(*wsConnection) {
+	return func(wsc *wsConnection) {
+		if rate > 0 {
+			wsc.eventRateLimit = newConnRateLimit(rate, burst)
+		}
+	}
+}
+
+// RateLimitMetrics sets the metrics counting the calls and events above the
+// rate limits. No-op by default.
+// It should only be used in the constructor - not Goroutine-safe.
+func RateLimitMetrics(metrics *Metrics) func(*wsConnection) {
+	return func(wsc *wsConnection) {
+		wsc.metrics = metrics
+	}
+}
+
 // OnStart implements cmn.Service by starting the read and write routines. It
 // blocks until the connection closes.
 func (wsc *wsConnection) OnStart() error {
-	wsc.writeChan = make(chan types.RPCResponse, wsc.writeChanCapacity)
+	wsc.writeChan = make(chan interface{}, wsc.writeChanCapacity)
 
 	// Read subscriptions/unsubscriptions to events
 	go wsc.readRoutine()
@@ -581,9 +685,24 @@ func (wsc *wsConnection) WriteRPCResponse(resp types.RPCResponse) {
 	}
 }
 
+// writeRPCResponses pushes the responses of a batch to the writeChan, to be
+// written as an array in one message, and blocks until it is accepted.
+func (wsc *wsConnection) writeRPCResponses(resps []types.RPCResponse) {
+	select {
+	case <-wsc.Quit():
+		return
+	case wsc.writeChan <- resps:
+	}
+}
+
 // TryWriteRPCResponse attempts to push a response to the writeChan, but does not block.
+// The response is dropped if the connection is above its EventRateLimit.
 // It implements WSRPCConnection. It is Goroutine-safe
 func (wsc *wsConnection) TryWriteRPCResponse(resp types.RPCResponse) bool {
+	if !wsc.clientAuth.isAdmin() && !wsc.eventRateLimit.allow() {
+		wsc.metrics.RateLimited.With("limit", "ws_event").Add(1)
+		return false
+	}
 	select {
 	case <-wsc.Quit():
 		return false
@@ -651,52 +770,88 @@ func (wsc *wsConnection) readRoutine() {
 				return
 			}
 
+			// a batch is responded to with an array of responses, in one message
+			var requests []types.RPCRequest
+			if err = json.Unmarshal(in, &requests); err == nil {
+				if len(requests) == 0 {
+					wsc.WriteRPCResponse(types.RPCInvalidRequestError(types.JSONRPCStringID(""), errors.New("empty batch")))
+					continue
+				}
+				if responses := handleBatch(requests, wsc.handleRequest); len(responses) > 0 {
+					wsc.writeRPCResponses(responses)
+				}
+				continue
+			}
+
 			var request types.RPCRequest
 			err = json.Unmarshal(in, &request)
 			if err != nil {
 				wsc.WriteRPCResponse(types.RPCParseError(types.JSONRPCStringID(""), errors.Wrap(err, "error unmarshaling request")))
 				continue
 			}
-
-			// A Notification is a Request object without an "id" member.
-			// The Server MUST NOT reply to a Notification, including those that are within a batch request.
-			if request.ID == types.JSONRPCStringID("") {
-				wsc.Logger.Debug("WSJSONRPC received a notification, skipping... (please send a non-empty ID if you want to call a method)")
-				continue
+			if resp := wsc.handleRequest(&request); resp != nil {
+				wsc.WriteRPCResponse(*resp)
 			}
+		}
+	}
+}
 
-			// Now, fetch the RPCFunc and execute it.
-			rpcFunc := wsc.funcMap[request.Method]
-			if rpcFunc == nil {
-				wsc.WriteRPCResponse(types.RPCMethodNotFoundError(request.ID))
-				continue
-			}
+// handleRequest calls the RPCFunc of the request, and returns its response,
+// or nil for a notification.
+func (wsc *wsConnection) handleRequest(request *types.RPCRequest) *types.RPCResponse {
+	var resp types.RPCResponse
 
-			ctx := &types.Context{JSONReq: &request, WSConn: wsc}
-			args := []reflect.Value{reflect.ValueOf(ctx)}
-			if len(request.Params) > 0 {
-				fnArgs, err := jsonParamsToArgs(rpcFunc, wsc.cdc, request.Params)
-				if err != nil {
-					wsc.WriteRPCResponse(types.RPCInternalError(request.ID, errors.Wrap(err, "error converting json params to arguments")))
-					continue
-				}
-				args = append(args, fnArgs...)
-			}
-
-			returns := rpcFunc.f.Call(args)
+	if !wsc.clientAuth.isAdmin() && !wsc.callRateLimit.allow() {
+		wsc.metrics.RateLimited.With("limit", "ws_call").Add(1)
+		if request.ID == types.JSONRPCStringID("") {
+			return nil
+		}
+		resp = types.RPCServerError(request.ID, ErrRateLimited)
+		return &resp
+	}
 
-			// TODO: Need to encode args/returns to string if we want to log them
-			wsc.Logger.Info("WSJSONRPC", "method", request.Method)
+	// A Notification is a Request object without an "id" member.
+	// The Server MUST NOT reply to a Notification, including those that are within a batch request.
+	if request.ID == types.JSONRPCStringID("") {
+		wsc.Logger.Debug("WSJSONRPC received a notification, skipping... (please send a non-empty ID if you want to call a method)")
+		return nil
+	}
 
-			result, err := unreflectResult(returns)
-			if err != nil {
-				wsc.WriteRPCResponse(types.RPCInternalError(request.ID, err))
-				continue
-			}
+	// Now, fetch the RPCFunc and execute it.
+	rpcFunc := wsc.funcMap[request.Method]
+	if rpcFunc == nil {
+		resp = types.RPCMethodNotFoundError(request.ID)
+		return &resp
+	}
+	if err := wsc.clientAuth.authorize(request.Method); err != nil {
+		resp = types.RPCInvalidRequestError(request.ID, err)
+		return &resp
+	}
 
-			wsc.WriteRPCResponse(types.NewRPCSuccessResponse(wsc.cdc, request.ID, result))
+	ctx := &types.Context{JSONReq: request, WSConn: wsc}
+	args := []reflect.Value{reflect.ValueOf(ctx)}
+	if len(request.Params) > 0 {
+		fnArgs, err := jsonParamsToArgs(rpcFunc, wsc.cdc, request.Params)
+		if err != nil {
+			resp = types.RPCInternalError(request.ID, errors.Wrap(err, "error converting json params to arguments"))
+			return &resp
 		}
+		args = append(args, fnArgs...)
 	}
+
+	returns := rpcFunc.f.Call(args)
+
+	// TODO: Need to encode args/returns to string if we want to log them
+	wsc.Logger.Info("WSJSONRPC", "method", request.Method)
+
+	result, err := unreflectResult(returns)
+	if err != nil {
+		resp = types.RPCInternalError(request.ID, err)
+		return &resp
+	}
+
+	resp = types.NewRPCSuccessResponse(wsc.cdc, request.ID, result)
+	return &resp
 }
 
 // receives on a write channel and writes out on the socket
@@ -805,6 +960,7 @@ func (wm *WebsocketManager) WebsocketHandler(w http.ResponseWriter, r *http.Requ
 
 	// register connection
 	con := NewWSConnection(wsConn, wm.funcMap, wm.cdc, wm.wsConnOptions...)
+	con.clientAuth = clientAuthFromContext(r.Context())
 	con.SetLogger(wm.logger.With("remote", wsConn.RemoteAddr()))
 	wm.logger.Info("New websocket connection", "remote", con.remoteAddr)
 	err = con.Start() // Blocking
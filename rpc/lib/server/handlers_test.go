@@ -3,6 +3,7 @@ package rpcserver_test
 import (
 	"bytes"
 	"encoding/json"
+	"fmt"
 	"io/ioutil"
 	"net/http"
 	"net/http/httptest"
@@ -33,7 +34,7 @@ func testMux() *http.ServeMux {
 	cdc := amino.NewCodec()
 	mux := http.NewServeMux()
 	buf := new(bytes.Buffer)
-	logger := log.NewTMLogger(buf)
+	logger := log.NewTMLogger(log.NewSyncWriter(buf))
 	rs.RegisterRPCFuncs(mux, funcMap, cdc, logger)
 
 	return mux
@@ -220,6 +221,42 @@ func TestRPCNotificationInBatch(t *testing.T) {
 	}
 }
 
+func TestRPCBatchOrder(t *testing.T) {
+	mux := testMux()
+	var reqs []string
+	for i := 0; i < 50; i++ {
+		reqs = append(reqs, fmt.Sprintf(`{"jsonrpc": "2.0","method":"c","id":"%d","params":["a","10"]}`, i))
+	}
+	reqs = append(reqs, `{"jsonrpc": "2.0","method":"y","id":"50"}`)
+	req, _ := http.NewRequest("POST", "http://localhost/", strings.NewReader("["+strings.Join(reqs, ",")+"]"))
+	rec := httptest.NewRecorder()
+	mux.ServeHTTP(rec, req)
+	res := rec.Result()
+	require.True(t, statusOK(res.StatusCode), "should always return 2XX")
+	blob, err := ioutil.ReadAll(res.Body)
+	require.NoError(t, err)
+
+	var responses []types.RPCResponse
+	require.NoError(t, json.Unmarshal(blob, &responses))
+	require.Len(t, responses, 51)
+	for i, response := range responses {
+		assert.Equal(t, types.JSONRPCStringID(fmt.Sprintf("%d", i)), response.ID)
+		if i < 50 {
+			assert.Nil(t, response.Error, "#%d", i)
+		} else {
+			assert.NotNil(t, response.Error)
+		}
+	}
+
+	// an empty batch is an invalid request
+	req, _ = http.NewRequest("POST", "http://localhost/", strings.NewReader("[]"))
+	rec = httptest.NewRecorder()
+	mux.ServeHTTP(rec, req)
+	var response types.RPCResponse
+	require.NoError(t, json.NewDecoder(rec.Result().Body).Decode(&response))
+	assert.NotNil(t, response.Error)
+}
+
 func TestUnknownRPCPath(t *testing.T) {
 	mux := testMux()
 	req, _ := http.NewRequest("GET", "http://localhost/unknownrpcpath", nil)
@@ -259,6 +296,34 @@ func TestWebsocketManagerHandler(t *testing.T) {
 	require.Nil(t, resp.Error)
 }
 
+func TestWebsocketManagerBatch(t *testing.T) {
+	s := newWSServer()
+	defer s.Close()
+
+	d := websocket.Dialer{}
+	c, _, err := d.Dial("ws://"+s.Listener.Addr().String()+"/websocket", nil)
+	require.NoError(t, err)
+
+	var reqs []types.RPCRequest
+	for i := 0; i < 20; i++ {
+		req, err := types.MapToRequest(amino.NewCodec(), types.JSONRPCStringID(fmt.Sprintf("%d", i)), "c",
+			map[string]interface{}{"s": "a", "i": 10})
+		require.NoError(t, err)
+		reqs = append(reqs, req)
+	}
+	// notifications aren't responded to
+	reqs = append(reqs, types.RPCRequest{JSONRPC: "2.0", ID: types.JSONRPCStringID(""), Method: "c"})
+	require.NoError(t, c.WriteJSON(reqs))
+
+	var resps []types.RPCResponse
+	require.NoError(t, c.ReadJSON(&resps))
+	require.Len(t, resps, 20)
+	for i, resp := range resps {
+		assert.Equal(t, types.JSONRPCStringID(fmt.Sprintf("%d", i)), resp.ID)
+		assert.Nil(t, resp.Error, "#%d", i)
+	}
+}
+
 func newWSServer() *httptest.Server {
 	funcMap := map[string]*rs.RPCFunc{
 		"c": rs.NewWSRPCFunc(func(ctx *types.Context, s string, i int) (string, error) { return "foo", nil }, "s,i"),
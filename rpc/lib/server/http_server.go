@@ -3,6 +3,8 @@ package rpcserver
 
 import (
 	"bufio"
+	"crypto/tls"
+	"crypto/x509"
 	"encoding/json"
 	"fmt"
 	"net"
@@ -31,6 +33,9 @@ type Config struct {
 	MaxBodyBytes int64
 	// mirrors http.Server#MaxHeaderBytes
 	MaxHeaderBytes int
+	// ClientCAs verify the certificates of the TLS clients which present one.
+	// See Auth.
+	ClientCAs *x509.CertPool
 }
 
 // DefaultConfig returns a default configuration.
@@ -78,6 +83,12 @@ func StartHTTPAndTLSServer(
 		WriteTimeout:   config.WriteTimeout,
 		MaxHeaderBytes: config.MaxHeaderBytes,
 	}
+	if config.ClientCAs != nil {
+		s.TLSConfig = &tls.Config{
+			ClientCAs:  config.ClientCAs,
+			ClientAuth: tls.VerifyClientCertIfGiven,
+		}
+	}
 	err := s.ServeTLS(listener, certFile, keyFile)
 
 	logger.Error("RPC HTTPS server stopped", "err", err)
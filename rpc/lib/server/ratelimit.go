@@ -0,0 +1,159 @@
+package rpcserver
+
+import (
+	"net"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// ErrRateLimited is returned for the websocket calls above the rate limit of
+// the connection.
+var ErrRateLimited = errors.New("rate limit exceeded")
+
+// rateLimiterSweepInterval is the interval at which the RateLimiter forgets
+// the remote IPs with a full bucket.
+const rateLimiterSweepInterval = time.Minute
+
+// tokenBucket allows rate events per second on average, and burst events at
+// once. It is not Goroutine-safe.
+type tokenBucket struct {
+	rate   float64
+	burst  float64
+	tokens float64
+	last   time.Time
+}
+
+func newTokenBucket(rate float64, burst int, now time.Time) *tokenBucket {
+	return &tokenBucket{rate: rate, burst: float64(burst), tokens: float64(burst), last: now}
+}
+
+// refill adds the tokens accumulated since the last refill, up to burst.
+func (tb *tokenBucket) refill(now time.Time) {
+	if now.After(tb.last) {
+		tb.tokens += now.Sub(tb.last).Seconds() * tb.rate
+		if tb.tokens > tb.burst {
+			tb.tokens = tb.burst
+		}
+		tb.last = now
+	}
+}
+
+// allow takes a token and returns true if there is one left at now.
+func (tb *tokenBucket) allow(now time.Time) bool {
+	tb.refill(now)
+	if tb.tokens < 1 {
+		return false
+	}
+	tb.tokens--
+	return true
+}
+
+// full returns true if no token was taken since burst/rate seconds, which
+// makes the bucket the same as a new one.
+func (tb *tokenBucket) full(now time.Time) bool {
+	tb.refill(now)
+	return tb.tokens >= tb.burst
+}
+
+// connRateLimit limits the rate of the calls or events of a websocket
+// connection. It is Goroutine-safe.
+type connRateLimit struct {
+	mtx    sync.Mutex
+	bucket *tokenBucket
+}
+
+func newConnRateLimit(rate float64, burst int) *connRateLimit {
+	return &connRateLimit{bucket: newTokenBucket(rate, burst, time.Now())}
+}
+
+// allow returns true if the call or event is under the rate. All of them are
+// allowed if the limit is nil.
+func (crl *connRateLimit) allow() bool {
+	if crl == nil {
+		return true
+	}
+	crl.mtx.Lock()
+	defer crl.mtx.Unlock()
+	return crl.bucket.allow(time.Now())
+}
+
+// RateLimiter limits the rate of the HTTP requests of each remote IP with a
+// token bucket: a remote IP can send rate requests per second on average, and
+// burst requests at once. The admin clients (see Auth) are not limited.
+type RateLimiter struct {
+	rate    float64
+	burst   int
+	metrics *Metrics
+
+	mtx       sync.Mutex
+	buckets   map[string]*tokenBucket // remote IP -> bucket
+	lastSweep time.Time
+}
+
+// NewRateLimiter returns a RateLimiter allowing rate requests per second and
+// burst requests at once to each remote IP.
+func NewRateLimiter(rate float64, burst int, metrics *Metrics) *RateLimiter {
+	return &RateLimiter{
+		rate:      rate,
+		burst:     burst,
+		metrics:   metrics,
+		buckets:   make(map[string]*tokenBucket),
+		lastSweep: time.Now(),
+	}
+}
+
+// Allow returns true if the remote IP is under the rate at now.
+func (rl *RateLimiter) Allow(ip string, now time.Time) bool {
+	rl.mtx.Lock()
+	defer rl.mtx.Unlock()
+
+	if now.Sub(rl.lastSweep) >= rateLimiterSweepInterval {
+		rl.sweep(now)
+	}
+	bucket, ok := rl.buckets[ip]
+	if !ok {
+		bucket = newTokenBucket(rl.rate, rl.burst, now)
+		rl.buckets[ip] = bucket
+	}
+	return bucket.allow(now)
+}
+
+// sweep forgets the remote IPs with a full bucket, so that the IPs which
+// stopped sending requests don't accumulate.
+func (rl *RateLimiter) sweep(now time.Time) {
+	for ip, bucket := range rl.buckets {
+		if bucket.full(now) {
+			delete(rl.buckets, ip)
+		}
+	}
+	rl.lastSweep = now
+}
+
+// Handler returns a handler responding 429 Too Many Requests to the requests
+// of the remote IPs above the rate, and passing the others to next. It must
+// be wrapped by Auth.Handler, if any, for the admin clients not to be
+// limited.
+func (rl *RateLimiter) Handler(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !clientAuthFromContext(r.Context()).isAdmin() && !rl.Allow(remoteIP(r), time.Now()) {
+			rl.metrics.RateLimited.With("limit", "ip").Add(1)
+			w.Header().Set("Retry-After", "1")
+			http.Error(w, ErrRateLimited.Error(), http.StatusTooManyRequests)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// remoteIP returns the IP of the remote address of the request, or the
+// remote address itself if it has no port (e.g. unix socket).
+func remoteIP(r *http.Request) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
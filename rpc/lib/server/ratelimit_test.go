@@ -0,0 +1,124 @@
+package rpcserver_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	amino "github.com/tendermint/go-amino"
+	"github.com/hdac-io/tendermint/libs/log"
+	rs "github.com/hdac-io/tendermint/rpc/lib/server"
+	types "github.com/hdac-io/tendermint/rpc/lib/types"
+)
+
+func TestRateLimiterAllow(t *testing.T) {
+	rl := rs.NewRateLimiter(2, 3, rs.NopMetrics())
+	now := time.Now()
+
+	for i := 0; i < 3; i++ {
+		assert.True(t, rl.Allow("1.2.3.4", now), "#%d", i)
+	}
+	assert.False(t, rl.Allow("1.2.3.4", now))
+	// the other IPs have their own bucket
+	assert.True(t, rl.Allow("5.6.7.8", now))
+
+	// 2 tokens per second
+	now = now.Add(500 * time.Millisecond)
+	assert.True(t, rl.Allow("1.2.3.4", now))
+	assert.False(t, rl.Allow("1.2.3.4", now))
+
+	// up to the burst, after the IPs are forgotten
+	now = now.Add(2 * time.Minute)
+	for i := 0; i < 3; i++ {
+		assert.True(t, rl.Allow("1.2.3.4", now), "#%d", i)
+	}
+	assert.False(t, rl.Allow("1.2.3.4", now))
+}
+
+func TestRateLimiterHTTP(t *testing.T) {
+	f := func(ctx *types.Context) (string, error) { return "foo", nil }
+	funcMap := map[string]*rs.RPCFunc{"pub": rs.NewRPCFunc(f, "")}
+	mux := http.NewServeMux()
+	rs.RegisterRPCFuncs(mux, funcMap, amino.NewCodec(), log.TestingLogger())
+	auth := rs.NewAuth(nil, []string{testAdminToken})
+	rl := rs.NewRateLimiter(0.001, 2, rs.NopMetrics())
+	s := httptest.NewServer(auth.Handler(rl.Handler(mux)))
+	defer s.Close()
+
+	call := func(token string) int {
+		req, err := http.NewRequest("GET", s.URL+"/pub", nil)
+		require.NoError(t, err)
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+		res, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		res.Body.Close()
+		return res.StatusCode
+	}
+
+	assert.Equal(t, http.StatusOK, call(""))
+	assert.Equal(t, http.StatusOK, call(""))
+	assert.Equal(t, http.StatusTooManyRequests, call(""))
+	// the admin clients are not limited
+	for i := 0; i < 3; i++ {
+		assert.Equal(t, http.StatusOK, call(testAdminToken), "#%d", i)
+	}
+}
+
+func TestRateLimitWebsocket(t *testing.T) {
+	f := func(ctx *types.Context) (string, error) { return "foo", nil }
+	// events writes 5 events, and returns the number written
+	events := func(ctx *types.Context) (int, error) {
+		n := 0
+		for i := 0; i < 5; i++ {
+			if ctx.WSConn.TryWriteRPCResponse(types.NewRPCSuccessResponse(ctx.WSConn.Codec(),
+				types.JSONRPCStringID("events#event"), "bar")) {
+				n++
+			}
+		}
+		return n, nil
+	}
+	funcMap := map[string]*rs.RPCFunc{
+		"pub":    rs.NewRPCFunc(f, ""),
+		"events": rs.NewRPCFunc(events, ""),
+	}
+	mux := http.NewServeMux()
+	wm := rs.NewWebsocketManager(funcMap, amino.NewCodec(),
+		rs.CallRateLimit(0.001, 2),
+		rs.EventRateLimit(0.001, 3),
+	)
+	wm.SetLogger(log.TestingLogger())
+	mux.HandleFunc("/websocket", wm.WebsocketHandler)
+	s := httptest.NewServer(mux)
+	defer s.Close()
+
+	c, _, err := websocket.DefaultDialer.Dial("ws://"+s.Listener.Addr().String()+"/websocket", nil)
+	require.NoError(t, err)
+	defer c.Close()
+
+	// 3 of the 5 events are written
+	require.NoError(t, c.WriteJSON(types.RPCRequest{JSONRPC: "2.0", ID: types.JSONRPCStringID("events"), Method: "events"}))
+	var resp types.RPCResponse
+	for i := 0; i < 3; i++ {
+		require.NoError(t, c.ReadJSON(&resp))
+		assert.Equal(t, types.JSONRPCStringID("events#event"), resp.ID)
+	}
+	require.NoError(t, c.ReadJSON(&resp))
+	require.Nil(t, resp.Error)
+	assert.Equal(t, `"3"`, string(resp.Result)) // amino encodes ints as strings
+
+	// the first call spent one of the 2 calls
+	require.NoError(t, c.WriteJSON(types.RPCRequest{JSONRPC: "2.0", ID: types.JSONRPCStringID("1"), Method: "pub"}))
+	require.NoError(t, c.ReadJSON(&resp))
+	assert.Nil(t, resp.Error)
+	require.NoError(t, c.WriteJSON(types.RPCRequest{JSONRPC: "2.0", ID: types.JSONRPCStringID("2"), Method: "pub"}))
+	require.NoError(t, c.ReadJSON(&resp))
+	require.NotNil(t, resp.Error)
+	assert.Contains(t, resp.Error.Data, rs.ErrRateLimited.Error())
+}
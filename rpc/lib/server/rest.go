@@ -0,0 +1,361 @@
+package rpcserver
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strings"
+	"time"
+
+	amino "github.com/tendermint/go-amino"
+
+	"github.com/hdac-io/tendermint/libs/log"
+	types "github.com/hdac-io/tendermint/rpc/lib/types"
+)
+
+// RESTRoute maps the GET requests of a path of the REST gateway to an RPC
+// function. The segments of the path in braces, e.g. {height} in
+// /blocks/{height}, are args of the function, and the other args are read from
+// the query.
+type RESTRoute struct {
+	Path    string
+	Func    string
+	Summary string
+}
+
+// RESTInfo describes the REST gateway in its OpenAPI document.
+type RESTInfo struct {
+	Title       string
+	Description string
+	Version     string
+}
+
+type restRoute struct {
+	RESTRoute
+	segments []string
+	rpcFunc  *RPCFunc
+}
+
+// match returns the path params of the route if it matches the path segments.
+func (rr *restRoute) match(segments []string) (map[string]string, bool) {
+	if len(segments) != len(rr.segments) {
+		return nil, false
+	}
+	params := make(map[string]string)
+	for i, segment := range rr.segments {
+		if name, ok := pathParam(segment); ok {
+			params[name] = segments[i]
+		} else if segment != segments[i] {
+			return nil, false
+		}
+	}
+	return params, true
+}
+
+func pathParam(segment string) (string, bool) {
+	if strings.HasPrefix(segment, "{") && strings.HasSuffix(segment, "}") {
+		return segment[1 : len(segment)-1], true
+	}
+	return "", false
+}
+
+func splitPath(path string) []string {
+	return strings.Split(strings.Trim(path, "/"), "/")
+}
+
+// RegisterRESTRoutes serves the routes under prefix (e.g. "/v1"), with the
+// OpenAPI document describing them at prefix + "/openapi.json". A route
+// responds with the result of its RPC function, without the JSON-RPC
+// envelope, or with {"error": "..."} and a 4xx or 5xx status. The first route
+// matching a path is used, so the routes with fixed segments (e.g.
+// /blocks/latest) must come before the ones with params there (e.g.
+// /blocks/{height}).
+//
+// It panics if a route names an unknown or websocket function, or a path
+// param which isn't an arg of the function.
+func RegisterRESTRoutes(mux *http.ServeMux, prefix string, routes []RESTRoute, funcMap map[string]*RPCFunc,
+	cdc *amino.Codec, info RESTInfo, logger log.Logger) {
+
+	compiled := make([]*restRoute, len(routes))
+	for i, route := range routes {
+		rpcFunc, ok := funcMap[route.Func]
+		if !ok || rpcFunc.ws {
+			panic(fmt.Sprintf("REST route %s: no RPC function %q", route.Path, route.Func))
+		}
+		rr := &restRoute{RESTRoute: route, segments: splitPath(route.Path), rpcFunc: rpcFunc}
+		for _, segment := range rr.segments {
+			if name, ok := pathParam(segment); ok && rpcFunc.argIndex(name) < 0 {
+				panic(fmt.Sprintf("REST route %s: %q is not an arg of %s", route.Path, name, route.Func))
+			}
+		}
+		compiled[i] = rr
+	}
+
+	doc, err := json.MarshalIndent(makeOpenAPIDoc(prefix, compiled, info), "", "  ")
+	if err != nil {
+		panic(err)
+	}
+	mux.HandleFunc(prefix+"/openapi.json", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write(doc) // nolint: errcheck
+	})
+	mux.HandleFunc(prefix+"/", makeRESTHandler(prefix, compiled, cdc, logger))
+}
+
+func (f *RPCFunc) argIndex(name string) int {
+	for i, argName := range f.argNames {
+		if argName == name {
+			return i
+		}
+	}
+	return -1
+}
+
+func makeRESTHandler(prefix string, routes []*restRoute, cdc *amino.Codec, logger log.Logger) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			writeRESTError(w, http.StatusMethodNotAllowed, fmt.Errorf("method %s not allowed", r.Method))
+			return
+		}
+		segments := splitPath(strings.TrimPrefix(r.URL.Path, prefix))
+		for _, route := range routes {
+			pathParams, ok := route.match(segments)
+			if !ok {
+				continue
+			}
+			if err := clientAuthFromContext(r.Context()).authorize(route.Func); err != nil {
+				writeRESTError(w, http.StatusForbidden, err)
+				return
+			}
+			fnArgs, err := stringParamsToArgs(route.rpcFunc, cdc, func(name string) string {
+				if value, ok := pathParams[name]; ok {
+					return value
+				}
+				return GetParam(r, name)
+			})
+			if err != nil {
+				writeRESTError(w, http.StatusBadRequest, err)
+				return
+			}
+			ctx := &types.Context{HTTPReq: r}
+			args := append([]reflect.Value{reflect.ValueOf(ctx)}, fnArgs...)
+			returns := route.rpcFunc.f.Call(args)
+			logger.Info("HTTPRest", "path", r.URL.Path, "func", route.Func)
+			result, err := unreflectResult(returns)
+			if err != nil {
+				writeRESTError(w, http.StatusInternalServerError, err)
+				return
+			}
+			bz, err := cdc.MarshalJSON(result)
+			if err != nil {
+				writeRESTError(w, http.StatusInternalServerError, err)
+				return
+			}
+			w.Header().Set("Content-Type", "application/json")
+			w.Write(bz) // nolint: errcheck
+			return
+		}
+		writeRESTError(w, http.StatusNotFound, fmt.Errorf("no route %s", r.URL.Path))
+	}
+}
+
+func writeRESTError(w http.ResponseWriter, code int, err error) {
+	bz, _ := json.Marshal(map[string]string{"error": err.Error()})
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	w.Write(bz) // nolint: errcheck
+}
+
+//-----------------------------------------------------------------------------
+// OpenAPI
+
+func makeOpenAPIDoc(prefix string, routes []*restRoute, info RESTInfo) map[string]interface{} {
+	schemas := openAPISchemas{
+		components: make(map[string]interface{}),
+		names:      make(map[reflect.Type]string),
+	}
+	paths := make(map[string]interface{})
+	for _, route := range routes {
+		pathParams := make(map[string]bool)
+		for _, segment := range route.segments {
+			if name, ok := pathParam(segment); ok {
+				pathParams[name] = true
+			}
+		}
+		params := make([]interface{}, len(route.rpcFunc.argNames))
+		for i, name := range route.rpcFunc.argNames {
+			in := "query"
+			if pathParams[name] {
+				in = "path"
+			}
+			params[i] = map[string]interface{}{
+				"name":     name,
+				"in":       in,
+				"required": pathParams[name],
+				"schema":   paramSchema(route.rpcFunc.args[i+1]),
+			}
+		}
+		paths[prefix+route.Path] = map[string]interface{}{
+			"get": map[string]interface{}{
+				"summary":     route.Summary,
+				"operationId": route.Func,
+				"parameters":  params,
+				"responses": map[string]interface{}{
+					"200": map[string]interface{}{
+						"description": "The result of " + route.Func,
+						"content": map[string]interface{}{
+							"application/json": map[string]interface{}{
+								"schema": schemas.schema(route.rpcFunc.returns[0]),
+							},
+						},
+					},
+					"default": map[string]interface{}{
+						"description": "The error",
+						"content": map[string]interface{}{
+							"application/json": map[string]interface{}{
+								"schema": map[string]interface{}{
+									"type": "object",
+									"properties": map[string]interface{}{
+										"error": map[string]interface{}{"type": "string"},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		}
+	}
+	return map[string]interface{}{
+		"openapi": "3.0.0",
+		"info": map[string]interface{}{
+			"title":       info.Title,
+			"description": info.Description,
+			"version":     info.Version,
+		},
+		"paths":      paths,
+		"components": map[string]interface{}{"schemas": schemas.components},
+	}
+}
+
+// paramSchema returns the schema of a param, as parsed by stringParamsToArgs.
+func paramSchema(rt reflect.Type) map[string]interface{} {
+	for rt.Kind() == reflect.Ptr {
+		rt = rt.Elem()
+	}
+	switch rt.Kind() {
+	case reflect.Bool:
+		return map[string]interface{}{"type": "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return map[string]interface{}{"type": "integer"}
+	case reflect.Slice:
+		if rt.Elem().Kind() == reflect.Uint8 {
+			return map[string]interface{}{
+				"type":        "string",
+				"description": `0x prefixed hex, or a quoted string`,
+			}
+		}
+	}
+	return map[string]interface{}{"type": "string"}
+}
+
+var (
+	timeType          = reflect.TypeOf(time.Time{})
+	jsonMarshalerType = reflect.TypeOf((*json.Marshaler)(nil)).Elem()
+)
+
+// openAPISchemas makes the schemas of the results, as encoded by amino, the
+// named structs going in the components.
+type openAPISchemas struct {
+	components map[string]interface{}
+	names      map[reflect.Type]string
+}
+
+// componentName returns the name of a struct in the components, e.g.
+// types.Header, or its full path if a struct of another package has the
+// same name.
+func (s openAPISchemas) componentName(rt reflect.Type) string {
+	name := rt.String()
+	if _, ok := s.components[name]; ok {
+		name = strings.Replace(rt.PkgPath(), "/", ".", -1) + "." + rt.Name()
+	}
+	return name
+}
+
+func (s openAPISchemas) schema(rt reflect.Type) map[string]interface{} {
+	for rt.Kind() == reflect.Ptr {
+		rt = rt.Elem()
+	}
+	switch {
+	case rt == timeType:
+		return map[string]interface{}{"type": "string", "format": "date-time"}
+	case rt.Implements(jsonMarshalerType) || reflect.PtrTo(rt).Implements(jsonMarshalerType):
+		// custom encoding
+		return map[string]interface{}{}
+	}
+
+	switch rt.Kind() {
+	case reflect.Bool:
+		return map[string]interface{}{"type": "boolean"}
+	case reflect.Int8, reflect.Int16, reflect.Int32, reflect.Uint8, reflect.Uint16, reflect.Uint32:
+		return map[string]interface{}{"type": "integer"}
+	case reflect.Int, reflect.Int64, reflect.Uint, reflect.Uint64:
+		// amino encodes the 64 bits integers as strings
+		return map[string]interface{}{"type": "string", "format": "int64"}
+	case reflect.Float32, reflect.Float64:
+		return map[string]interface{}{"type": "number"}
+	case reflect.String:
+		return map[string]interface{}{"type": "string"}
+	case reflect.Slice, reflect.Array:
+		if rt.Elem().Kind() == reflect.Uint8 {
+			return map[string]interface{}{"type": "string", "format": "byte"}
+		}
+		return map[string]interface{}{"type": "array", "items": s.schema(rt.Elem())}
+	case reflect.Map:
+		return map[string]interface{}{"type": "object", "additionalProperties": s.schema(rt.Elem())}
+	case reflect.Interface:
+		// registered concrete types
+		return map[string]interface{}{
+			"type": "object",
+			"properties": map[string]interface{}{
+				"type":  map[string]interface{}{"type": "string"},
+				"value": map[string]interface{}{},
+			},
+		}
+	case reflect.Struct:
+		if rt.Name() == "" {
+			return s.structSchema(rt)
+		}
+		name, ok := s.names[rt]
+		if !ok {
+			name = s.componentName(rt)
+			s.names[rt] = name
+			s.components[name] = s.structSchema(rt)
+		}
+		return map[string]interface{}{"$ref": "#/components/schemas/" + name}
+	}
+	return map[string]interface{}{}
+}
+
+func (s openAPISchemas) structSchema(rt reflect.Type) map[string]interface{} {
+	properties := make(map[string]interface{})
+	for i := 0; i < rt.NumField(); i++ {
+		field := rt.Field(i)
+		if field.PkgPath != "" {
+			continue // unexported
+		}
+		name := field.Name
+		if tag := field.Tag.Get("json"); tag != "" {
+			if tag == "-" {
+				continue
+			}
+			if tagName := strings.Split(tag, ",")[0]; tagName != "" {
+				name = tagName
+			}
+		}
+		properties[name] = s.schema(field.Type)
+	}
+	return map[string]interface{}{"type": "object", "properties": properties}
+}
@@ -0,0 +1,156 @@
+package rpcserver_test
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	amino "github.com/tendermint/go-amino"
+
+	"github.com/hdac-io/tendermint/libs/log"
+	rs "github.com/hdac-io/tendermint/rpc/lib/server"
+	types "github.com/hdac-io/tendermint/rpc/lib/types"
+)
+
+type restResult struct {
+	Height int64  `json:"height"`
+	Hash   []byte `json:"hash"`
+	Name   string `json:"name"`
+}
+
+func testRESTMux() *http.ServeMux {
+	block := func(ctx *types.Context, height *int64, name string) (*restResult, error) {
+		if height == nil {
+			return &restResult{Height: 100, Name: name}, nil
+		}
+		if *height > 100 {
+			return nil, errors.New("height must be less than or equal to 100")
+		}
+		return &restResult{Height: *height, Hash: []byte{1, 2}, Name: name}, nil
+	}
+	funcMap := map[string]*rs.RPCFunc{
+		"block": rs.NewRPCFunc(block, "height,name"),
+	}
+	routes := []rs.RESTRoute{
+		{Path: "/blocks/latest", Func: "block", Summary: "Latest block"},
+		{Path: "/blocks/{height}", Func: "block", Summary: "Block at a height"},
+	}
+	mux := http.NewServeMux()
+	rs.RegisterRESTRoutes(mux, "/v1", routes, funcMap, amino.NewCodec(),
+		rs.RESTInfo{Title: "test", Version: "1.0"}, log.TestingLogger())
+	return mux
+}
+
+func TestRESTRoutes(t *testing.T) {
+	mux := testRESTMux()
+
+	tests := []struct {
+		method, path string
+		code         int
+		body         string
+	}{
+		{"GET", "/v1/blocks/latest", 200, `{"height":"100","hash":null,"name":""}`},
+		{"GET", "/v1/blocks/5?name=%22x%22", 200, `{"height":"5","hash":"AQI=","name":"x"}`},
+		{"GET", "/v1/blocks/5/", 200, `{"height":"5","hash":"AQI=","name":""}`},
+		{"GET", "/v1/blocks/abc", 400, ""},
+		{"GET", "/v1/blocks/101", 500, `{"error":"height must be less than or equal to 100"}`},
+		{"GET", "/v1/blocks", 404, ""},
+		{"GET", "/v1/blocks/5/commit", 404, ""},
+		{"POST", "/v1/blocks/5", 405, ""},
+	}
+	for i, tt := range tests {
+		req := httptest.NewRequest(tt.method, tt.path, nil)
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, req)
+		res := rec.Result()
+		assert.Equal(t, tt.code, res.StatusCode, "#%d %s", i, tt.path)
+		body, err := ioutil.ReadAll(res.Body)
+		require.NoError(t, err)
+		if tt.body != "" {
+			assert.Equal(t, tt.body, string(body), "#%d %s", i, tt.path)
+		} else if tt.code != 200 {
+			var resErr map[string]string
+			require.NoError(t, json.Unmarshal(body, &resErr), "#%d %s", i, tt.path)
+			assert.NotEmpty(t, resErr["error"], "#%d %s", i, tt.path)
+		}
+	}
+}
+
+func TestRESTRoutesInvalid(t *testing.T) {
+	funcMap := map[string]*rs.RPCFunc{
+		"c":  rs.NewRPCFunc(func(ctx *types.Context, s string) (string, error) { return s, nil }, "s"),
+		"ws": rs.NewWSRPCFunc(func(ctx *types.Context) (string, error) { return "", nil }, ""),
+	}
+	for _, route := range []rs.RESTRoute{
+		{Path: "/c", Func: "d"},
+		{Path: "/c", Func: "ws"},
+		{Path: "/c/{t}", Func: "c"},
+	} {
+		assert.Panics(t, func() {
+			rs.RegisterRESTRoutes(http.NewServeMux(), "/v1", []rs.RESTRoute{route}, funcMap,
+				amino.NewCodec(), rs.RESTInfo{}, log.TestingLogger())
+		}, route.Path+" "+route.Func)
+	}
+}
+
+func TestRESTOpenAPI(t *testing.T) {
+	mux := testRESTMux()
+
+	req := httptest.NewRequest("GET", "/v1/openapi.json", nil)
+	rec := httptest.NewRecorder()
+	mux.ServeHTTP(rec, req)
+	require.Equal(t, 200, rec.Code)
+
+	var doc struct {
+		OpenAPI string
+		Info    struct{ Title, Version string }
+		Paths   map[string]struct {
+			Get struct {
+				OperationID string
+				Parameters  []struct {
+					Name, In string
+					Required bool
+					Schema   map[string]interface{}
+				}
+				Responses map[string]struct {
+					Content map[string]struct {
+						Schema map[string]interface{}
+					}
+				}
+			}
+		}
+		Components struct {
+			Schemas map[string]struct {
+				Properties map[string]map[string]interface{}
+			}
+		}
+	}
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &doc))
+	assert.Equal(t, "3.0.0", doc.OpenAPI)
+	assert.Equal(t, "test", doc.Info.Title)
+	require.Len(t, doc.Paths, 2)
+
+	get := doc.Paths["/v1/blocks/{height}"].Get
+	assert.Equal(t, "block", get.OperationID)
+	require.Len(t, get.Parameters, 2)
+	assert.Equal(t, "path", get.Parameters[0].In)
+	assert.True(t, get.Parameters[0].Required)
+	assert.Equal(t, "integer", get.Parameters[0].Schema["type"])
+	assert.Equal(t, "query", get.Parameters[1].In)
+	assert.False(t, get.Parameters[1].Required)
+	assert.Equal(t, "query", doc.Paths["/v1/blocks/latest"].Get.Parameters[0].In)
+
+	schema := get.Responses["200"].Content["application/json"].Schema
+	assert.Equal(t, "#/components/schemas/rpcserver_test.restResult", schema["$ref"])
+	properties := doc.Components.Schemas["rpcserver_test.restResult"].Properties
+	assert.Equal(t, "string", properties["height"]["type"])
+	assert.Equal(t, "int64", properties["height"]["format"])
+	assert.Equal(t, "byte", properties["hash"]["format"])
+	assert.Equal(t, "string", properties["name"]["type"])
+}
@@ -114,6 +114,11 @@ func GetGRPCClient() core_grpc.BroadcastAPIClient {
 	return core_grpc.StartGRPCClient(grpcAddr)
 }
 
+func GetGRPCStreamClient() core_grpc.StreamAPIClient {
+	grpcAddr := globalConfig.RPC.GRPCListenAddress
+	return core_grpc.StartGRPCStreamClient(grpcAddr)
+}
+
 // StartTendermint starts a test tendermint server in a go routine and returns when it is initialized
 func StartTendermint(app abci.Application, opts ...func(*Options)) *nm.Node {
 	nodeOpts := defaultOptions
@@ -0,0 +1,621 @@
+// Command codecgen generates the amino codec files of the packages of the
+// module (codec_gen.go) from its manifest (manifest.go): the package level
+// codecs, and the exported funcs registering the types of a package on a
+// codec. Run it from the root of the repository after editing the manifest:
+//
+//	go run ./scripts/codecgen
+//
+// It fails, without writing anything, if a codec would register a type or an
+// amino name twice, or if a type implementing a registered interface is
+// missing from its group (see Group).
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"io/ioutil"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const (
+	modulePath = "github.com/hdac-io/tendermint"
+	aminoPath  = "github.com/tendermint/go-amino"
+	genFile    = "codec_gen.go"
+
+	header = "// Code generated by scripts/codecgen from its manifest. DO NOT EDIT.\n\n"
+)
+
+// importNames are the names the generated files import the packages with,
+// if not the last element of their path.
+var importNames = map[string]string{
+	"crypto/encoding/amino": "cryptoAmino",
+	"rpc/core/types":        "ctypes",
+}
+
+func main() {
+	root := flag.String("root", ".", "root of the repository")
+	flag.Parse()
+
+	files, err := generate(*root, manifest)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	for name, src := range files {
+		if err := ioutil.WriteFile(filepath.Join(*root, name), src, 0644); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+	}
+	stale, err := staleFiles(*root, files)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	for _, name := range stale {
+		if err := os.Remove(filepath.Join(*root, name)); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+	}
+}
+
+// generate returns the source of the codec files of the manifest m, by path
+// relative to root.
+func generate(root string, m Manifest) (map[string][]byte, error) {
+	g := &generator{
+		root:   root,
+		fset:   token.NewFileSet(),
+		pkgs:   make(map[string]*pkgInfo),
+		groups: make(map[string]*Group),
+		funcs:  make(map[string]*Func),
+	}
+	if err := g.index(m); err != nil {
+		return nil, err
+	}
+	if err := g.check(m); err != nil {
+		return nil, err
+	}
+
+	files := make(map[string]*file)
+	var paths []string
+	fileOf := func(pkg string) (*file, error) {
+		if f, ok := files[pkg]; ok {
+			return f, nil
+		}
+		info, err := g.pkg(pkg)
+		if err != nil {
+			return nil, err
+		}
+		f := &file{pkg: pkg, name: info.name, imports: map[string]string{aminoPath: "amino"}}
+		files[pkg] = f
+		paths = append(paths, pkg)
+		return f, nil
+	}
+	for i := range m.Codecs {
+		c := &m.Codecs[i]
+		f, err := fileOf(c.Package)
+		if err != nil {
+			return nil, err
+		}
+		var doc string
+		if c.Doc != "" {
+			doc = comment(c.Doc)
+		}
+		f.vars = append(f.vars, fmt.Sprintf("%s%s = amino.NewCodec()\n", doc, c.Var))
+		if f.init.Len() > 0 {
+			f.init.WriteString("\n")
+		}
+		if err := g.writeRegistrations(&f.init, f, c.Var, c.Calls, c.Groups, c.Local); err != nil {
+			return nil, fmt.Errorf("codec %s.%s: %v", c.Package, c.Var, err)
+		}
+	}
+	for i := range m.Funcs {
+		fn := &m.Funcs[i]
+		f, err := fileOf(fn.Package)
+		if err != nil {
+			return nil, err
+		}
+		fmt.Fprintf(&f.funcs, "\n%sfunc %s(cdc *amino.Codec) {\n", comment(fn.Doc), fn.Name)
+		if err := g.writeRegistrations(&f.funcs, f, "cdc", fn.Calls, fn.Groups, false); err != nil {
+			return nil, fmt.Errorf("func %s.%s: %v", fn.Package, fn.Name, err)
+		}
+		f.funcs.WriteString("}\n")
+	}
+
+	res := make(map[string][]byte, len(files))
+	for _, pkg := range paths {
+		src, err := files[pkg].source()
+		if err != nil {
+			return nil, fmt.Errorf("%s: %v", pkg, err)
+		}
+		res[path.Join(pkg, genFile)] = src
+	}
+	return res, nil
+}
+
+// staleFiles returns the codec files under root which are not in files.
+func staleFiles(root string, files map[string][]byte) ([]string, error) {
+	var stale []string
+	err := filepath.Walk(root, func(p string, fi os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if fi.IsDir() && (fi.Name() == "vendor" || fi.Name() == "node_modules" || strings.HasPrefix(fi.Name(), ".")) && p != root {
+			return filepath.SkipDir
+		}
+		if fi.Name() != genFile {
+			return nil
+		}
+		rel, err := filepath.Rel(root, p)
+		if err != nil {
+			return err
+		}
+		if _, ok := files[filepath.ToSlash(rel)]; !ok {
+			stale = append(stale, rel)
+		}
+		return nil
+	})
+	return stale, err
+}
+
+//----------------------------------------
+
+// ref is a reference to a declaration of the module, see Manifest.
+type ref struct {
+	pkg     string
+	name    string
+	pointer bool
+}
+
+func parseRef(s string) (ref, error) {
+	var r ref
+	if strings.HasPrefix(s, "*") {
+		r.pointer = true
+		s = s[1:]
+	}
+	i := strings.LastIndex(s, ".")
+	if i <= 0 || i == len(s)-1 {
+		return r, fmt.Errorf("invalid reference %q", s)
+	}
+	r.pkg, r.name = s[:i], s[i+1:]
+	return r, nil
+}
+
+func (r ref) String() string {
+	return r.pkg + "." + r.name
+}
+
+// pkgInfo is the declarations of a package.
+type pkgInfo struct {
+	name    string
+	types   map[string]ast.Expr        // type name -> type
+	methods map[string]map[string]bool // type name -> methods, with pointer receiver or not
+}
+
+type generator struct {
+	root   string
+	fset   *token.FileSet
+	pkgs   map[string]*pkgInfo
+	groups map[string]*Group
+	funcs  map[string]*Func // by reference
+}
+
+// pkg parses the package at the path pkg relative to the module.
+func (g *generator) pkg(pkg string) (*pkgInfo, error) {
+	if info, ok := g.pkgs[pkg]; ok {
+		return info, nil
+	}
+	notTest := func(fi os.FileInfo) bool { return !strings.HasSuffix(fi.Name(), "_test.go") }
+	parsed, err := parser.ParseDir(g.fset, filepath.Join(g.root, filepath.FromSlash(pkg)), notTest, 0)
+	if err != nil {
+		return nil, err
+	}
+	if len(parsed) != 1 {
+		return nil, fmt.Errorf("%d packages in %s", len(parsed), pkg)
+	}
+
+	info := &pkgInfo{types: make(map[string]ast.Expr), methods: make(map[string]map[string]bool)}
+	for name, p := range parsed {
+		info.name = name
+		for _, f := range p.Files {
+			for _, decl := range f.Decls {
+				switch decl := decl.(type) {
+				case *ast.GenDecl:
+					for _, spec := range decl.Specs {
+						if spec, ok := spec.(*ast.TypeSpec); ok {
+							info.types[spec.Name.Name] = spec.Type
+						}
+					}
+				case *ast.FuncDecl:
+					if decl.Recv == nil || len(decl.Recv.List) == 0 {
+						continue
+					}
+					recv := decl.Recv.List[0].Type
+					if star, ok := recv.(*ast.StarExpr); ok {
+						recv = star.X
+					}
+					if ident, ok := recv.(*ast.Ident); ok {
+						if info.methods[ident.Name] == nil {
+							info.methods[ident.Name] = make(map[string]bool)
+						}
+						info.methods[ident.Name][decl.Name.Name] = true
+					}
+				}
+			}
+		}
+	}
+	g.pkgs[pkg] = info
+	return info, nil
+}
+
+// index indexes the groups and the funcs of m, checking they are unique.
+func (g *generator) index(m Manifest) error {
+	ifaces := make(map[string]string)
+	for i := range m.Groups {
+		grp := &m.Groups[i]
+		if _, ok := g.groups[grp.Name]; ok {
+			return fmt.Errorf("duplicate group %q", grp.Name)
+		}
+		g.groups[grp.Name] = grp
+		if grp.Interface == "" {
+			continue
+		}
+		if other, ok := ifaces[grp.Interface]; ok {
+			return fmt.Errorf("%s is the interface of the groups %q and %q", grp.Interface, other, grp.Name)
+		}
+		ifaces[grp.Interface] = grp.Name
+	}
+	for i := range m.Funcs {
+		fn := &m.Funcs[i]
+		name := fn.Package + "." + fn.Name
+		if _, ok := g.funcs[name]; ok {
+			return fmt.Errorf("duplicate func %s", name)
+		}
+		g.funcs[name] = fn
+	}
+	return nil
+}
+
+// check checks that the groups are complete and registered, and that the
+// funcs and the codecs register each type and amino name once.
+func (g *generator) check(m Manifest) error {
+	var errs []string
+	used := make(map[string][]string) // group -> packages registering it
+	for _, fn := range m.Funcs {
+		for _, grp := range fn.Groups {
+			used[grp] = append(used[grp], fn.Package)
+		}
+		if err := g.checkUnique(fn.Calls, fn.Groups, false, fn.Package); err != nil {
+			errs = append(errs, fmt.Sprintf("func %s.%s: %v", fn.Package, fn.Name, err))
+		}
+	}
+	for _, c := range m.Codecs {
+		for _, grp := range c.Groups {
+			used[grp] = append(used[grp], c.Package)
+		}
+		if err := g.checkUnique(c.Calls, c.Groups, c.Local, c.Package); err != nil {
+			errs = append(errs, fmt.Sprintf("codec %s.%s: %v", c.Package, c.Var, err))
+		}
+	}
+	for _, grp := range m.Groups {
+		if len(used[grp.Name]) == 0 {
+			errs = append(errs, fmt.Sprintf("group %q is registered by no func nor codec", grp.Name))
+		}
+		missing, err := g.missing(grp, used[grp.Name])
+		if err != nil {
+			return fmt.Errorf("group %q: %v", grp.Name, err)
+		}
+		for _, r := range missing {
+			errs = append(errs, fmt.Sprintf("%s implements %s but is missing from the group %q", r, grp.Interface, grp.Name))
+		}
+	}
+	if len(errs) > 0 {
+		return fmt.Errorf("invalid manifest:\n\t%s", strings.Join(errs, "\n\t"))
+	}
+	return nil
+}
+
+// checkUnique checks that the calls and the groups register each type and
+// amino name once.
+func (g *generator) checkUnique(calls, groups []string, local bool, pkg string) error {
+	seen := make(map[string]bool)
+	var visit func(calls, groups []string, local bool, pkg string, depth int) error
+	visit = func(calls, groups []string, local bool, pkg string, depth int) error {
+		if depth > len(g.funcs) {
+			return fmt.Errorf("the funcs call themselves")
+		}
+		for _, call := range calls {
+			fn, ok := g.funcs[call]
+			if !ok {
+				return fmt.Errorf("unknown func %s", call)
+			}
+			if err := visit(fn.Calls, fn.Groups, false, fn.Package, depth+1); err != nil {
+				return err
+			}
+		}
+		for _, name := range groups {
+			grp, ok := g.groups[name]
+			if !ok {
+				return fmt.Errorf("unknown group %q", name)
+			}
+			concretes, err := localConcretes(grp, local, pkg)
+			if err != nil {
+				return err
+			}
+			if len(concretes) > 0 && grp.Interface != "" {
+				if seen["interface "+grp.Interface] {
+					return fmt.Errorf("%s registered twice", grp.Interface)
+				}
+				seen["interface "+grp.Interface] = true
+			}
+			for _, c := range concretes {
+				r, _ := parseRef(c.Type)
+				name := c.Name
+				if name == "" {
+					name = c.NameConst
+				}
+				for _, key := range []string{"type " + r.String(), "name " + name} {
+					if seen[key] {
+						return fmt.Errorf("%s registered twice", strings.TrimPrefix(strings.TrimPrefix(key, "type "), "name "))
+					}
+					seen[key] = true
+				}
+			}
+		}
+		return nil
+	}
+	return visit(calls, groups, local, pkg, 0)
+}
+
+// localConcretes returns the concrete types of grp, only the ones of pkg if
+// local.
+func localConcretes(grp *Group, local bool, pkg string) ([]Concrete, error) {
+	var res []Concrete
+	for _, c := range grp.Concretes {
+		r, err := parseRef(c.Type)
+		if err != nil {
+			return nil, err
+		}
+		if (c.Name == "") == (c.NameConst == "") {
+			return nil, fmt.Errorf("%s needs one of Name and NameConst", c.Type)
+		}
+		if !local || r.pkg == pkg {
+			res = append(res, c)
+		}
+	}
+	return res, nil
+}
+
+// missing returns the types of the packages of the interface of grp, of its
+// concrete types, and of pkgs, which implement the interface, or match
+// grp.Match, but are not in grp.
+func (g *generator) missing(grp Group, pkgs []string) ([]ref, error) {
+	if grp.Interface == "" {
+		return nil, nil
+	}
+	iface, err := parseRef(grp.Interface)
+	if err != nil {
+		return nil, err
+	}
+	info, err := g.pkg(iface.pkg)
+	if err != nil {
+		return nil, err
+	}
+	typ, ok := info.types[iface.name].(*ast.InterfaceType)
+	if !ok {
+		return nil, fmt.Errorf("%s is not an interface", iface)
+	}
+	var methods []string
+	for _, field := range typ.Methods.List {
+		for _, name := range field.Names {
+			methods = append(methods, name.Name)
+		}
+	}
+	var match *regexp.Regexp
+	if grp.Match != "" {
+		if match, err = regexp.Compile(grp.Match); err != nil {
+			return nil, err
+		}
+	} else if len(methods) == 0 {
+		// any type implements an empty interface
+		return nil, nil
+	}
+
+	known := make(map[string]bool)
+	scanned := map[string]bool{iface.pkg: true}
+	for _, pkg := range pkgs {
+		scanned[pkg] = true
+	}
+	for _, c := range grp.Concretes {
+		r, err := parseRef(c.Type)
+		if err != nil {
+			return nil, err
+		}
+		known[r.String()] = true
+		scanned[r.pkg] = true
+	}
+	for _, name := range grp.Ignore {
+		known[name] = true
+	}
+
+	var missing []ref
+	for pkg := range scanned {
+		info, err := g.pkg(pkg)
+		if err != nil {
+			return nil, err
+		}
+		for name, typ := range info.types {
+			if _, ok := typ.(*ast.InterfaceType); ok {
+				continue
+			}
+			implements := len(methods) > 0
+			for _, method := range methods {
+				implements = implements && info.methods[name][method]
+			}
+			matches := match != nil && pkg == iface.pkg && match.MatchString(name)
+			r := ref{pkg: pkg, name: name}
+			if (implements || matches) && !known[r.String()] {
+				missing = append(missing, r)
+			}
+		}
+	}
+	sort.Slice(missing, func(i, j int) bool { return missing[i].String() < missing[j].String() })
+	return missing, nil
+}
+
+// writeRegistrations writes the registrations of the calls and the groups on
+// the codec cdc to b, the code of f.
+func (g *generator) writeRegistrations(b *bytes.Buffer, f *file, cdc string, calls, groups []string, local bool) error {
+	for _, call := range calls {
+		r, err := parseRef(call)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(b, "\t%s(%s)\n", f.qualify(r), cdc)
+	}
+	for _, name := range groups {
+		grp := g.groups[name]
+		concretes, err := localConcretes(grp, local, f.pkg)
+		if err != nil {
+			return err
+		}
+		if len(concretes) == 0 {
+			continue
+		}
+		if grp.Interface != "" {
+			r, err := parseRef(grp.Interface)
+			if err != nil {
+				return err
+			}
+			fmt.Fprintf(b, "\t%s.RegisterInterface((*%s)(nil), nil)\n", cdc, f.qualify(r))
+		}
+		for _, c := range concretes {
+			value, err := g.value(f, c.Type)
+			if err != nil {
+				return err
+			}
+			name := strconv.Quote(c.Name)
+			if c.NameConst != "" {
+				r, err := parseRef(c.NameConst)
+				if err != nil {
+					return err
+				}
+				name = f.qualify(r)
+			}
+			fmt.Fprintf(b, "\t%s.RegisterConcrete(%s, %s, nil)\n", cdc, value, name)
+		}
+	}
+	return nil
+}
+
+// value returns the zero value of the type typ in the code of f, or a
+// pointer to it.
+func (g *generator) value(f *file, typ string) (string, error) {
+	r, err := parseRef(typ)
+	if err != nil {
+		return "", err
+	}
+	info, err := g.pkg(r.pkg)
+	if err != nil {
+		return "", err
+	}
+	if r.pkg != f.pkg && !ast.IsExported(r.name) {
+		return "", fmt.Errorf("%s is not exported", r)
+	}
+	name := f.qualify(r)
+	switch t := info.types[r.name].(type) {
+	case *ast.StructType:
+		if r.pointer {
+			return "&" + name + "{}", nil
+		}
+		return name + "{}", nil
+	case *ast.ArrayType, *ast.MapType:
+		if !r.pointer {
+			return name + "{}", nil
+		}
+	case *ast.Ident:
+		if r.pointer {
+			break
+		}
+		if t.Name == "string" {
+			return name + `("")`, nil
+		}
+		return name + "(0)", nil
+	case nil:
+		return "", fmt.Errorf("unknown type %s", r)
+	}
+	return "", fmt.Errorf("can't register %s", typ)
+}
+
+// file is a codec file being generated.
+type file struct {
+	pkg     string
+	name    string
+	imports map[string]string // path -> name
+
+	vars  []string
+	init  bytes.Buffer
+	funcs bytes.Buffer
+}
+
+// qualify returns the name of the declaration r in the code of f, importing
+// its package if needed.
+func (f *file) qualify(r ref) string {
+	if r.pkg == f.pkg {
+		return r.name
+	}
+	name, ok := importNames[r.pkg]
+	if !ok {
+		name = path.Base(r.pkg)
+	}
+	f.imports[path.Join(modulePath, r.pkg)] = name
+	return name + "." + r.name
+}
+
+func (f *file) source() ([]byte, error) {
+	var b bytes.Buffer
+	b.WriteString(header)
+	fmt.Fprintf(&b, "package %s\n\nimport (\n", f.name)
+	paths := make([]string, 0, len(f.imports))
+	for p := range f.imports {
+		paths = append(paths, p)
+	}
+	sort.Strings(paths)
+	for _, p := range paths {
+		if name := f.imports[p]; name != path.Base(p) {
+			fmt.Fprintf(&b, "\t%s %q\n", name, p)
+		} else {
+			fmt.Fprintf(&b, "\t%q\n", p)
+		}
+	}
+	b.WriteString(")\n")
+	switch len(f.vars) {
+	case 0:
+	case 1:
+		fmt.Fprintf(&b, "\nvar %s", f.vars[0])
+	default:
+		fmt.Fprintf(&b, "\nvar (\n%s)\n", strings.Join(f.vars, ""))
+	}
+	if len(f.vars) > 0 {
+		fmt.Fprintf(&b, "\nfunc init() {\n%s}\n", f.init.Bytes())
+	}
+	b.Write(f.funcs.Bytes())
+	return format.Source(b.Bytes())
+}
+
+// comment returns text as line comments.
+func comment(text string) string {
+	return "// " + strings.Replace(text, "\n", "\n// ", -1) + "\n"
+}
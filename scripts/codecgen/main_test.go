@@ -0,0 +1,91 @@
+package main
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const root = "../.."
+
+func TestGeneratedFilesUpToDate(t *testing.T) {
+	files, err := generate(root, manifest)
+	require.NoError(t, err)
+	for name, src := range files {
+		bz, err := ioutil.ReadFile(filepath.Join(root, name))
+		require.NoError(t, err, "run make codecgen")
+		assert.Equal(t, string(src), string(bz), "%s is not up to date: run make codecgen", name)
+	}
+	stale, err := staleFiles(root, files)
+	require.NoError(t, err)
+	assert.Empty(t, stale, "run make codecgen")
+}
+
+// withGroup returns a copy of manifest with the group name changed by f.
+func withGroup(name string, f func(grp *Group)) Manifest {
+	m := manifest
+	m.Groups = make([]Group, len(manifest.Groups))
+	copy(m.Groups, manifest.Groups)
+	for i := range m.Groups {
+		if m.Groups[i].Name == name {
+			f(&m.Groups[i])
+		}
+	}
+	return m
+}
+
+func TestGenerateInvalidManifest(t *testing.T) {
+	testCases := []struct {
+		name     string
+		manifest Manifest
+		err      string
+	}{
+		{
+			"missing key",
+			withGroup("crypto/pubkeys", func(grp *Group) { grp.Concretes = grp.Concretes[:3] }),
+			"crypto/bls.PubKeyBls implements crypto.PubKey but is missing from the group",
+		},
+		{
+			"missing message",
+			withGroup("consensus/messages", func(grp *Group) { grp.Concretes = grp.Concretes[1:] }),
+			"consensus.NewRoundStepMessage implements consensus.ConsensusMessage but is missing from the group",
+		},
+		{
+			"missing message of an empty interface",
+			withGroup("mempool/messages", func(grp *Group) { grp.Concretes = grp.Concretes[1:] }),
+			"mempool.TxMessage implements mempool.MempoolMessage but is missing from the group",
+		},
+		{
+			"duplicate name",
+			withGroup("types/events", func(grp *Group) {
+				grp.Concretes = append([]Concrete{}, grp.Concretes...)
+				grp.Concretes[1].Name = grp.Concretes[0].Name
+			}),
+			"tendermint/event/NewBlock registered twice",
+		},
+		{
+			"unexported type out of its package",
+			withGroup("consensus/wal", func(grp *Group) {
+				grp.Concretes = append(grp.Concretes, Concrete{Type: "p2p/pex.pexRequestMessage", Name: "tendermint/wal/Pex"})
+			}),
+			"p2p/pex.pexRequestMessage is not exported",
+		},
+	}
+	for _, tc := range testCases {
+		_, err := generate(root, tc.manifest)
+		if assert.Error(t, err, tc.name) {
+			assert.Contains(t, err.Error(), tc.err, tc.name)
+		}
+	}
+
+	m := manifest
+	m.Codecs = append(append([]Codec{}, manifest.Codecs...),
+		Codec{Package: "state", Var: "dupCdc", Calls: []string{"types.RegisterBlockAmino", "crypto/encoding/amino.RegisterAmino"}})
+	_, err := generate(root, m)
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "codec state.dupCdc: crypto.PubKey registered twice")
+	}
+}
@@ -0,0 +1,430 @@
+package main
+
+// Manifest lists the types registered on the amino codecs of the module. The
+// types and the funcs are referenced by their package, relative to the
+// module, and their name, e.g. "crypto/ed25519.PubKeyEd25519", prefixed with
+// "*" for the types registered as pointers.
+type Manifest struct {
+	Groups []Group
+	Funcs  []Func
+	Codecs []Codec
+}
+
+// Group is an interface and the concrete types registered for it. The group
+// of an interface must list all the types implementing it, or whose name
+// matches Match, in the packages of the interface and of its concrete types,
+// but the ones in Ignore: the generator fails on a type missing from the
+// group.
+type Group struct {
+	Name      string
+	Interface string // none for concrete types registered without interface
+	Match     string // for an empty interface, regexp of the names of the types of its package to register
+	Ignore    []string
+	Concretes []Concrete
+}
+
+// Concrete is a concrete type and its amino name, given as a string or as
+// the constant holding it.
+type Concrete struct {
+	Type      string
+	Name      string
+	NameConst string
+}
+
+// Func is an exported func of Package registering the types of Groups, after
+// calling the funcs of Calls, on the codec it is given.
+type Func struct {
+	Package string
+	Name    string
+	Doc     string
+	Calls   []string
+	Groups  []string
+}
+
+// Codec is a package level codec of Package, registering the types of Groups
+// after calling the funcs of Calls. With Local, only the concrete types of
+// Package are registered, for the packages the others import.
+type Codec struct {
+	Package string
+	Var     string
+	Doc     string
+	Calls   []string
+	Groups  []string
+	Local   bool
+}
+
+var manifest = Manifest{
+	Groups: []Group{
+		{
+			Name:      "crypto/pubkeys",
+			Interface: "crypto.PubKey",
+			Concretes: []Concrete{
+				{Type: "crypto/ed25519.PubKeyEd25519", NameConst: "crypto/ed25519.PubKeyAminoName"},
+				{Type: "crypto/secp256k1.PubKeySecp256k1", NameConst: "crypto/secp256k1.PubKeyAminoName"},
+				{Type: "crypto/multisig.PubKeyMultisigThreshold", NameConst: "crypto/multisig.PubKeyMultisigThresholdAminoRoute"},
+				{Type: "crypto/bls.PubKeyBls", NameConst: "crypto/bls.PubKeyAminoName"},
+			},
+		},
+		{
+			Name:      "crypto/privkeys",
+			Interface: "crypto.PrivKey",
+			Concretes: []Concrete{
+				{Type: "crypto/ed25519.PrivKeyEd25519", NameConst: "crypto/ed25519.PrivKeyAminoName"},
+				{Type: "crypto/secp256k1.PrivKeySecp256k1", NameConst: "crypto/secp256k1.PrivKeyAminoName"},
+				{Type: "crypto/bls.PrivKeyBls", NameConst: "crypto/bls.PrivKeyAminoName"},
+			},
+		},
+		{
+			Name:      "types/evidences",
+			Interface: "types.Evidence",
+			Ignore: []string{
+				// test evidences, see types/mock-evidences
+				"types.MockGoodEvidence",
+				"types.MockRandomGoodEvidence",
+				"types.MockBadEvidence",
+			},
+			Concretes: []Concrete{
+				{Type: "*types.DuplicateVoteEvidence", Name: "tendermint/DuplicateVoteEvidence"},
+				{Type: "*types.DuplicateProposalEvidence", Name: "tendermint/DuplicateProposalEvidence"},
+			},
+		},
+		{
+			Name: "types/mock-evidences",
+			Concretes: []Concrete{
+				{Type: "types.MockGoodEvidence", Name: "tendermint/MockGoodEvidence"},
+				{Type: "types.MockRandomGoodEvidence", Name: "tendermint/MockRandomGoodEvidence"},
+				{Type: "types.MockBadEvidence", Name: "tendermint/MockBadEvidence"},
+			},
+		},
+		{
+			Name:      "types/events",
+			Interface: "types.TMEventData",
+			Match:     "^EventData",
+			Concretes: []Concrete{
+				{Type: "types.EventDataNewBlock", Name: "tendermint/event/NewBlock"},
+				{Type: "types.EventDataNewBlockHeader", Name: "tendermint/event/NewBlockHeader"},
+				{Type: "types.EventDataTx", Name: "tendermint/event/Tx"},
+				{Type: "types.EventDataRoundState", Name: "tendermint/event/RoundState"},
+				{Type: "types.EventDataNewRound", Name: "tendermint/event/NewRound"},
+				{Type: "types.EventDataCompleteProposal", Name: "tendermint/event/CompleteProposal"},
+				{Type: "types.EventDataVote", Name: "tendermint/event/Vote"},
+				{Type: "types.EventDataValidatorSetUpdates", Name: "tendermint/event/ValidatorSetUpdates"},
+				{Type: "types.EventDataFinalizeStall", Name: "tendermint/event/FinalizeStall"},
+				{Type: "types.EventDataInvariantViolation", Name: "tendermint/event/InvariantViolation"},
+				{Type: "types.EventDataString", Name: "tendermint/event/ProposalString"},
+			},
+		},
+		{
+			Name:      "consensus/messages",
+			Interface: "consensus.ConsensusMessage",
+			Concretes: []Concrete{
+				{Type: "*consensus.NewRoundStepMessage", Name: "tendermint/NewRoundStepMessage"},
+				{Type: "*consensus.NewValidBlockMessage", Name: "tendermint/NewValidBlockMessage"},
+				{Type: "*consensus.ProposalMessage", Name: "tendermint/Proposal"},
+				{Type: "*consensus.ProposalPOLMessage", Name: "tendermint/ProposalPOL"},
+				{Type: "*consensus.BlockPartMessage", Name: "tendermint/BlockPart"},
+				{Type: "*consensus.VoteMessage", Name: "tendermint/Vote"},
+				{Type: "*consensus.HasVoteMessage", Name: "tendermint/HasVote"},
+				{Type: "*consensus.VoteSetMaj23Message", Name: "tendermint/VoteSetMaj23"},
+				{Type: "*consensus.VoteSetBitsMessage", Name: "tendermint/VoteSetBits"},
+			},
+		},
+		{
+			Name:      "consensus/wal",
+			Interface: "consensus.WALMessage",
+			Concretes: []Concrete{
+				{Type: "types.EventDataRoundState", Name: "tendermint/wal/EventDataRoundState"},
+				{Type: "consensus.msgInfo", Name: "tendermint/wal/MsgInfo"},
+				{Type: "consensus.timeoutInfo", Name: "tendermint/wal/TimeoutInfo"},
+				{Type: "consensus.EndHeightMessage", Name: "tendermint/wal/EndHeightMessage"},
+			},
+		},
+		{
+			Name:      "consensus/friday/messages",
+			Interface: "consensus/friday.ConsensusMessage",
+			Concretes: []Concrete{
+				{Type: "*consensus/friday.NewRoundStepMessage", Name: "tendermint/NewRoundStepMessage"},
+				{Type: "*consensus/friday.NewValidBlockMessage", Name: "tendermint/NewValidBlockMessage"},
+				{Type: "*consensus/friday.ProposalMessage", Name: "tendermint/Proposal"},
+				{Type: "*consensus/friday.ProposalPOLMessage", Name: "tendermint/ProposalPOL"},
+				{Type: "*consensus/friday.BlockPartMessage", Name: "tendermint/BlockPart"},
+				{Type: "*consensus/friday.BlockPartParityMessage", Name: "tendermint/BlockPartParity"},
+				{Type: "*consensus/friday.VoteMessage", Name: "tendermint/Vote"},
+				{Type: "*consensus/friday.HasVoteMessage", Name: "tendermint/HasVote"},
+				{Type: "*consensus/friday.VoteSetMaj23Message", Name: "tendermint/VoteSetMaj23"},
+				{Type: "*consensus/friday.VoteSetBitsMessage", Name: "tendermint/VoteSetBits"},
+				{Type: "*consensus/friday.SignWatermarkRequestMessage", Name: "tendermint/SignWatermarkRequest"},
+				{Type: "*consensus/friday.SignWatermarkMessage", Name: "tendermint/SignWatermark"},
+				{Type: "*consensus/friday.CommitAggregateMessage", Name: "tendermint/CommitAggregate"},
+			},
+		},
+		{
+			Name:      "consensus/friday/wal",
+			Interface: "consensus/friday.WALMessage",
+			Concretes: []Concrete{
+				{Type: "types.EventDataRoundState", Name: "tendermint/wal/EventDataRoundState"},
+				{Type: "consensus/friday.msgInfo", Name: "tendermint/wal/MsgInfo"},
+				{Type: "consensus/friday.timeoutInfo", Name: "tendermint/wal/TimeoutInfo"},
+				{Type: "consensus/friday.EndHeightMessage", Name: "tendermint/wal/EndHeightMessage"},
+				{Type: "consensus/friday.CheckpointMessage", Name: "tendermint/wal/CheckpointMessage"},
+			},
+		},
+		{
+			Name:      "blockchain/v0/messages",
+			Interface: "blockchain/v0.BlockchainMessage",
+			Concretes: []Concrete{
+				{Type: "*blockchain/v0.bcBlockRequestMessage", Name: "tendermint/blockchain/BlockRequest"},
+				{Type: "*blockchain/v0.bcBlockResponseMessage", Name: "tendermint/blockchain/BlockResponse"},
+				{Type: "*blockchain/v0.bcNoBlockResponseMessage", Name: "tendermint/blockchain/NoBlockResponse"},
+				{Type: "*blockchain/v0.bcStatusResponseMessage", Name: "tendermint/blockchain/StatusResponse"},
+				{Type: "*blockchain/v0.bcStatusRequestMessage", Name: "tendermint/blockchain/StatusRequest"},
+				{Type: "*blockchain/v0.bcHeaderRequestMessage", Name: "tendermint/blockchain/HeaderRequest"},
+				{Type: "*blockchain/v0.bcHeaderResponseMessage", Name: "tendermint/blockchain/HeaderResponse"},
+			},
+		},
+		{
+			Name:      "blockchain/v1/messages",
+			Interface: "blockchain/v1.BlockchainMessage",
+			Concretes: []Concrete{
+				{Type: "*blockchain/v1.bcBlockRequestMessage", Name: "tendermint/blockchain/BlockRequest"},
+				{Type: "*blockchain/v1.bcBlockResponseMessage", Name: "tendermint/blockchain/BlockResponse"},
+				{Type: "*blockchain/v1.bcNoBlockResponseMessage", Name: "tendermint/blockchain/NoBlockResponse"},
+				{Type: "*blockchain/v1.bcStatusResponseMessage", Name: "tendermint/blockchain/StatusResponse"},
+				{Type: "*blockchain/v1.bcStatusRequestMessage", Name: "tendermint/blockchain/StatusRequest"},
+			},
+		},
+		{
+			Name:      "mempool/messages",
+			Interface: "mempool.MempoolMessage",
+			Match:     "Message$",
+			Concretes: []Concrete{
+				{Type: "*mempool.TxMessage", Name: "tendermint/mempool/TxMessage"},
+				{Type: "*mempool.TxHashesMessage", Name: "tendermint/mempool/TxHashesMessage"},
+				{Type: "*mempool.WantTxsMessage", Name: "tendermint/mempool/WantTxsMessage"},
+				{Type: "*mempool.PullTxsMessage", Name: "tendermint/mempool/PullTxsMessage"},
+				{Type: "*mempool.PulledTxsMessage", Name: "tendermint/mempool/PulledTxsMessage"},
+			},
+		},
+		{
+			Name:      "evidence/messages",
+			Interface: "evidence.EvidenceMessage",
+			Concretes: []Concrete{
+				{Type: "*evidence.EvidenceListMessage", Name: "tendermint/evidence/EvidenceListMessage"},
+			},
+		},
+		{
+			Name:      "p2p/conn/packets",
+			Interface: "p2p/conn.Packet",
+			Concretes: []Concrete{
+				{Type: "p2p/conn.PacketPing", Name: "tendermint/p2p/PacketPing"},
+				{Type: "p2p/conn.PacketPong", Name: "tendermint/p2p/PacketPong"},
+				{Type: "p2p/conn.PacketMsg", Name: "tendermint/p2p/PacketMsg"},
+			},
+		},
+		{
+			Name:      "p2p/pex/messages",
+			Interface: "p2p/pex.PexMessage",
+			Match:     "Message$",
+			Concretes: []Concrete{
+				{Type: "*p2p/pex.pexRequestMessage", Name: "tendermint/p2p/PexRequestMessage"},
+				{Type: "*p2p/pex.pexAddrsMessage", Name: "tendermint/p2p/PexAddrsMessage"},
+				{Type: "*p2p/pex.pexObservedAddrMessage", Name: "tendermint/p2p/PexObservedAddrMessage"},
+			},
+		},
+		{
+			Name:      "privval/messages",
+			Interface: "privval.SignerMessage",
+			Match:     "(Request|Response)$",
+			Concretes: []Concrete{
+				{Type: "*privval.PubKeyRequest", Name: "tendermint/remotesigner/PubKeyRequest"},
+				{Type: "*privval.PubKeyResponse", Name: "tendermint/remotesigner/PubKeyResponse"},
+				{Type: "*privval.SignVoteRequest", Name: "tendermint/remotesigner/SignVoteRequest"},
+				{Type: "*privval.SignedVoteResponse", Name: "tendermint/remotesigner/SignedVoteResponse"},
+				{Type: "*privval.SignProposalRequest", Name: "tendermint/remotesigner/SignProposalRequest"},
+				{Type: "*privval.SignedProposalResponse", Name: "tendermint/remotesigner/SignedProposalResponse"},
+				{Type: "*privval.SetImmutableHeightRequest", Name: "tendermint/remotesigner/SetImmutableHeightRequest"},
+				{Type: "*privval.SetImmutableHeightResponse", Name: "tendermint/remotesigner/SetImmutableHeightResponse"},
+				{Type: "*privval.PingRequest", Name: "tendermint/remotesigner/PingRequest"},
+				{Type: "*privval.PingResponse", Name: "tendermint/remotesigner/PingResponse"},
+			},
+		},
+		{
+			Name: "privval/friday-sign-state",
+			Concretes: []Concrete{
+				{Type: "*privval.FridayFilePVSignState", NameConst: "privval.fridaySignStateAminoName"},
+			},
+		},
+	},
+
+	Funcs: []Func{
+		{
+			Package: "crypto/encoding/amino",
+			Name:    "RegisterAmino",
+			Doc:     "RegisterAmino registers all crypto related types in the given (amino) codec.",
+			Groups:  []string{"crypto/pubkeys", "crypto/privkeys"},
+		},
+		{
+			Package: "types",
+			Name:    "RegisterBlockAmino",
+			Doc:     "RegisterBlockAmino registers the types of the blocks: the keys and the evidences.",
+			Calls:   []string{"crypto/encoding/amino.RegisterAmino", "types.RegisterEvidences"},
+		},
+		{
+			Package: "types",
+			Name:    "RegisterEvidences",
+			Doc:     "RegisterEvidences registers the evidences.",
+			Groups:  []string{"types/evidences"},
+		},
+		{
+			Package: "types",
+			Name:    "RegisterMockEvidences",
+			Doc:     "RegisterMockEvidences registers the test evidences.",
+			Groups:  []string{"types/mock-evidences"},
+		},
+		{
+			Package: "types",
+			Name:    "RegisterEventDatas",
+			Doc:     "RegisterEventDatas registers the data of the events.",
+			Groups:  []string{"types/events"},
+		},
+		{
+			Package: "consensus",
+			Name:    "RegisterConsensusMessages",
+			Doc:     "RegisterConsensusMessages registers the messages of the consensus reactor.",
+			Groups:  []string{"consensus/messages"},
+		},
+		{
+			Package: "consensus",
+			Name:    "RegisterWALMessages",
+			Doc:     "RegisterWALMessages registers the messages of the WAL.",
+			Groups:  []string{"consensus/wal"},
+		},
+		{
+			Package: "consensus/friday",
+			Name:    "RegisterConsensusMessages",
+			Doc:     "RegisterConsensusMessages registers the messages of the consensus reactor.",
+			Groups:  []string{"consensus/friday/messages"},
+		},
+		{
+			Package: "consensus/friday",
+			Name:    "RegisterWALMessages",
+			Doc:     "RegisterWALMessages registers the messages of the WAL.",
+			Groups:  []string{"consensus/friday/wal"},
+		},
+		{
+			Package: "blockchain/v0",
+			Name:    "RegisterBlockchainMessages",
+			Doc:     "RegisterBlockchainMessages registers the fast sync messages for amino encoding.",
+			Groups:  []string{"blockchain/v0/messages"},
+		},
+		{
+			Package: "blockchain/v1",
+			Name:    "RegisterBlockchainMessages",
+			Doc:     "RegisterBlockchainMessages registers the fast sync messages for amino encoding.",
+			Groups:  []string{"blockchain/v1/messages"},
+		},
+		{
+			Package: "mempool",
+			Name:    "RegisterMempoolMessages",
+			Doc:     "RegisterMempoolMessages registers the messages of the mempool reactor.",
+			Groups:  []string{"mempool/messages"},
+		},
+		{
+			Package: "evidence",
+			Name:    "RegisterEvidenceMessages",
+			Doc:     "RegisterEvidenceMessages registers the messages of the evidence reactor.",
+			Groups:  []string{"evidence/messages"},
+		},
+		{
+			Package: "p2p/conn",
+			Name:    "RegisterPacket",
+			Doc:     "RegisterPacket registers the packets of the connections.",
+			Groups:  []string{"p2p/conn/packets"},
+		},
+		{
+			Package: "p2p/pex",
+			Name:    "RegisterPexMessage",
+			Doc:     "RegisterPexMessage registers the messages of the PEX reactor.",
+			Groups:  []string{"p2p/pex/messages"},
+		},
+		{
+			Package: "privval",
+			Name:    "RegisterRemoteSignerMsg",
+			Doc:     "RegisterRemoteSignerMsg registers the messages between the signer clients and servers.",
+			Groups:  []string{"privval/messages"},
+		},
+		{
+			Package: "privval",
+			Name:    "RegisterFridaySignState",
+			Doc:     "RegisterFridaySignState registers the sign state of FridayFilePV.",
+			Groups:  []string{"privval/friday-sign-state"},
+		},
+		{
+			Package: "rpc/core/types",
+			Name:    "RegisterAmino",
+			Doc:     "RegisterAmino registers the types of the RPC responses.",
+			Calls:   []string{"types.RegisterEventDatas", "types.RegisterBlockAmino"},
+		},
+	},
+
+	Codecs: []Codec{
+		// the keys encode themselves with their own codec, the other keys
+		// import them
+		{Package: "crypto/ed25519", Var: "cdc", Groups: []string{"crypto/pubkeys", "crypto/privkeys"}, Local: true},
+		{Package: "crypto/secp256k1", Var: "cdc", Groups: []string{"crypto/pubkeys", "crypto/privkeys"}, Local: true},
+		{Package: "crypto/bls", Var: "cdc", Groups: []string{"crypto/pubkeys", "crypto/privkeys"}, Local: true},
+		// the multisig keys are made of the other keys
+		{Package: "crypto/multisig", Var: "cdc", Groups: []string{"crypto/pubkeys"}},
+		{Package: "crypto/encoding/amino", Var: "cdc", Calls: []string{"crypto/encoding/amino.RegisterAmino"}},
+
+		{Package: "types", Var: "cdc", Calls: []string{"types.RegisterBlockAmino"}},
+		{Package: "abci/example/kvstore", Var: "cdc", Calls: []string{"crypto/encoding/amino.RegisterAmino"}},
+		{Package: "blockchain/v0", Var: "cdc", Calls: []string{"blockchain/v0.RegisterBlockchainMessages", "types.RegisterBlockAmino"}},
+		{Package: "blockchain/v1", Var: "cdc", Calls: []string{"blockchain/v1.RegisterBlockchainMessages", "types.RegisterBlockAmino"}},
+		{Package: "cmd/tendermint/commands", Var: "cdc", Calls: []string{"crypto/encoding/amino.RegisterAmino"}},
+		{
+			Package: "consensus",
+			Var:     "cdc",
+			Calls:   []string{"consensus.RegisterConsensusMessages", "consensus.RegisterWALMessages", "types.RegisterBlockAmino"},
+		},
+		{Package: "consensus/eventlog", Var: "cdc", Calls: []string{"types.RegisterBlockAmino", "types.RegisterEventDatas"}},
+		{
+			Package: "consensus/friday",
+			Var:     "cdc",
+			Calls:   []string{"consensus/friday.RegisterConsensusMessages", "consensus/friday.RegisterWALMessages", "types.RegisterBlockAmino"},
+		},
+		{Package: "consensus/types", Var: "cdc", Calls: []string{"types.RegisterBlockAmino"}},
+		{
+			Package: "consensus/wal",
+			Var:     "tendermintCdc",
+			Doc:     "the messages of the two consensus modules are registered under the same\nnames, so each one has its codec",
+			Calls:   []string{"consensus.RegisterConsensusMessages", "consensus.RegisterWALMessages", "types.RegisterBlockAmino"},
+		},
+		{
+			Package: "consensus/wal",
+			Var:     "fridayCdc",
+			Calls:   []string{"consensus/friday.RegisterConsensusMessages", "consensus/friday.RegisterWALMessages", "types.RegisterBlockAmino"},
+		},
+		{Package: "control", Var: "cdc", Calls: []string{"crypto/encoding/amino.RegisterAmino"}},
+		{
+			Package: "evidence",
+			Var:     "cdc",
+			Calls:   []string{"evidence.RegisterEvidenceMessages", "crypto/encoding/amino.RegisterAmino", "types.RegisterEvidences"},
+		},
+		{Package: "mempool", Var: "cdc", Calls: []string{"mempool.RegisterMempoolMessages"}},
+		{Package: "node", Var: "cdc", Calls: []string{"crypto/encoding/amino.RegisterAmino"}},
+		{Package: "p2p", Var: "cdc", Calls: []string{"crypto/encoding/amino.RegisterAmino"}},
+		{Package: "p2p/conn", Var: "cdc", Calls: []string{"crypto/encoding/amino.RegisterAmino", "p2p/conn.RegisterPacket"}},
+		{Package: "p2p/pex", Var: "cdc", Calls: []string{"p2p/pex.RegisterPexMessage"}},
+		{
+			Package: "privval",
+			Var:     "cdc",
+			Calls:   []string{"crypto/encoding/amino.RegisterAmino", "privval.RegisterRemoteSignerMsg", "privval.RegisterFridaySignState"},
+		},
+		{Package: "rpc/client", Var: "cdc", Calls: []string{"types.RegisterEvidences"}},
+		{Package: "state", Var: "cdc", Calls: []string{"crypto/encoding/amino.RegisterAmino"}},
+		{Package: "store", Var: "cdc", Calls: []string{"types.RegisterBlockAmino"}},
+		{Package: "tools/tm-monitor", Var: "cdc", Calls: []string{"rpc/core/types.RegisterAmino"}},
+		{Package: "tools/tm-monitor/monitor", Var: "cdc", Calls: []string{"rpc/core/types.RegisterAmino"}},
+	},
+}
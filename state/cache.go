@@ -0,0 +1,159 @@
+package state
+
+import (
+	"container/list"
+	"sync"
+
+	dbm "github.com/tendermint/tm-db"
+)
+
+// The proposals and the validation of the heights of the ULB window load the
+// validators, app hash and results hash of the same few heights again and
+// again, so the last ones loaded or saved are kept in memory.
+const DefaultCacheSize = 256
+
+var (
+	validatorsCache  = newHeightCache("validators", DefaultCacheSize)
+	appHashCache     = newHeightCache("app_hash", DefaultCacheSize)
+	resultsHashCache = newHeightCache("results_hash", DefaultCacheSize)
+
+	heightCaches = []*heightCache{validatorsCache, appHashCache, resultsHashCache}
+)
+
+// SetCacheSize sets the number of heights of which the validators, app hash
+// and results hash are kept in memory, for all the DBs of the process. 0
+// disables the caches.
+func SetCacheSize(size int) {
+	for _, cache := range heightCaches {
+		cache.SetSize(size)
+	}
+}
+
+// reportCacheMetrics adds the hits and misses of the caches since the last
+// report to the metrics.
+func reportCacheMetrics(metrics *Metrics) {
+	for _, cache := range heightCaches {
+		hits, misses := cache.TakeStats()
+		metrics.CacheHits.With("cache", cache.name).Add(float64(hits))
+		metrics.CacheMisses.With("cache", cache.name).Add(float64(misses))
+	}
+}
+
+// heightCacheKey is keyed by DB as well, so the nodes of a process (e.g. in
+// tests) don't share their values.
+type heightCacheKey struct {
+	db     dbm.DB
+	height int64
+}
+
+type heightCacheEntry struct {
+	key   heightCacheKey
+	value interface{}
+}
+
+// heightCache is a LRU cache of values by DB and height.
+type heightCache struct {
+	name string
+
+	mtx  sync.Mutex
+	size int
+	map_ map[heightCacheKey]*list.Element
+	list *list.List // *heightCacheEntry, the most recently used last
+
+	// since the last TakeStats
+	hits   int64
+	misses int64
+}
+
+func newHeightCache(name string, size int) *heightCache {
+	return &heightCache{
+		name: name,
+		size: size,
+		map_: make(map[heightCacheKey]*list.Element, size),
+		list: list.New(),
+	}
+}
+
+// SetSize sets the number of values cached, evicting the least recently used
+// ones above it.
+func (cache *heightCache) SetSize(size int) {
+	cache.mtx.Lock()
+	defer cache.mtx.Unlock()
+
+	cache.size = size
+	for cache.list.Len() > size {
+		cache.removeFront()
+	}
+}
+
+// Get returns the value of the height of db, if it is cached.
+func (cache *heightCache) Get(db dbm.DB, height int64) (interface{}, bool) {
+	cache.mtx.Lock()
+	defer cache.mtx.Unlock()
+
+	if cache.size == 0 {
+		return nil, false
+	}
+	e, ok := cache.map_[heightCacheKey{db, height}]
+	if !ok {
+		cache.misses++
+		return nil, false
+	}
+	cache.hits++
+	cache.list.MoveToBack(e)
+	return e.Value.(*heightCacheEntry).value, true
+}
+
+// TakeStats returns the number of the hits and misses of Get since the last
+// call.
+func (cache *heightCache) TakeStats() (hits, misses int64) {
+	cache.mtx.Lock()
+	defer cache.mtx.Unlock()
+
+	hits, misses = cache.hits, cache.misses
+	cache.hits, cache.misses = 0, 0
+	return hits, misses
+}
+
+// Set caches the value of the height of db, evicting the least recently used
+// value if the cache is full.
+func (cache *heightCache) Set(db dbm.DB, height int64, value interface{}) {
+	cache.mtx.Lock()
+	defer cache.mtx.Unlock()
+
+	key := heightCacheKey{db, height}
+	if e, ok := cache.map_[key]; ok {
+		e.Value.(*heightCacheEntry).value = value
+		cache.list.MoveToBack(e)
+		return
+	}
+	if cache.size == 0 {
+		return
+	}
+	if cache.list.Len() >= cache.size {
+		cache.removeFront()
+	}
+	cache.map_[key] = cache.list.PushBack(&heightCacheEntry{key, value})
+}
+
+// NOTE: cache.mtx must be locked
+func (cache *heightCache) removeFront() {
+	front := cache.list.Front()
+	delete(cache.map_, front.Value.(*heightCacheEntry).key)
+	cache.list.Remove(front)
+}
+
+// RemoveFrom removes the values of db at height and above.
+func (cache *heightCache) RemoveFrom(db dbm.DB, height int64) {
+	cache.mtx.Lock()
+	defer cache.mtx.Unlock()
+
+	for e := cache.list.Front(); e != nil; {
+		next := e.Next()
+		if entry := e.Value.(*heightCacheEntry); entry.key.db == db && entry.key.height >= height {
+			delete(cache.map_, entry.key)
+			cache.list.Remove(e)
+		}
+		e = next
+	}
+}
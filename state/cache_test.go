@@ -0,0 +1,53 @@
+package state
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	dbm "github.com/tendermint/tm-db"
+)
+
+func TestHeightCache(t *testing.T) {
+	db := dbm.NewMemDB()
+	cache := newHeightCache("test", 2)
+
+	cache.Set(db, 1, "a")
+	cache.Set(db, 2, "b")
+	_, ok := cache.Get(db, 1)
+	assert.True(t, ok)
+	// 2 is the least recently used
+	cache.Set(db, 3, "c")
+	_, ok = cache.Get(db, 2)
+	assert.False(t, ok)
+	hits, misses := cache.TakeStats()
+	assert.EqualValues(t, 1, hits)
+	assert.EqualValues(t, 1, misses)
+	hits, misses = cache.TakeStats()
+	assert.Zero(t, hits)
+	assert.Zero(t, misses)
+
+	// shrinking evicts the least recently used
+	cache.SetSize(1)
+	_, ok = cache.Get(db, 1)
+	assert.False(t, ok)
+	value, ok := cache.Get(db, 3)
+	assert.True(t, ok)
+	assert.Equal(t, "c", value)
+
+	// 0 disables the cache
+	cache.SetSize(0)
+	cache.Set(db, 4, "d")
+	_, ok = cache.Get(db, 4)
+	assert.False(t, ok)
+}
+
+func TestSaveCaches(t *testing.T) {
+	db := dbm.NewMemDB()
+	saveAppHash(db, 1, []byte("hash"))
+	cached, ok := appHashCache.Get(db, 1)
+	assert.True(t, ok)
+	assert.Equal(t, []byte("hash"), cached)
+	appHash, err := LoadAppHash(db, 1)
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("hash"), appHash)
+}
@@ -0,0 +1,111 @@
+package state
+
+import (
+	"time"
+
+	abci "github.com/hdac-io/tendermint/abci/types"
+	"github.com/hdac-io/tendermint/proxy"
+	"github.com/hdac-io/tendermint/types"
+)
+
+// deliverTxErrorCheckInterval is the interval at which deliverTxs checks the
+// connection to the app while waiting for the responses.
+const deliverTxErrorCheckInterval = 100 * time.Millisecond
+
+// ConflictKeysFunc returns the conflict keys of a tx (see
+// abci.ResponseCheckTx.ConflictKeys), if they are known.
+type ConflictKeysFunc func(tx types.Tx) (keys [][]byte, ok bool)
+
+// noConflictKeys knows no conflict key, so that all the txs conflict.
+func noConflictKeys(types.Tx) ([][]byte, bool) {
+	return nil, false
+}
+
+// deliverTxDeps returns, for each tx of txs, the indexes of the txs before it
+// which must be answered before it is delivered: the last one with each of
+// its conflict keys, and the last one without keys. A tx without keys, or
+// whose keys are unknown, waits for all the txs before it, and all the txs
+// after it wait for it. The txs a tx conflicts with are thus answered before
+// it is delivered, directly or through the txs in between.
+func deliverTxDeps(txs types.Txs, conflictKeys ConflictKeysFunc) [][]int {
+	deps := make([][]int, len(txs))
+	lastByKey := make(map[string]int)
+	lastBarrier := -1
+	var sinceBarrier []int
+	for i, tx := range txs {
+		keys, ok := conflictKeys(tx)
+		if !ok || len(keys) == 0 {
+			if len(sinceBarrier) > 0 {
+				deps[i] = sinceBarrier
+			} else if lastBarrier >= 0 {
+				deps[i] = []int{lastBarrier}
+			}
+			lastBarrier, sinceBarrier = i, nil
+			lastByKey = make(map[string]int)
+			continue
+		}
+
+		seen := make(map[int]bool)
+		if lastBarrier >= 0 {
+			deps[i] = append(deps[i], lastBarrier)
+			seen[lastBarrier] = true
+		}
+		for _, key := range keys {
+			if j, ok := lastByKey[string(key)]; ok && !seen[j] {
+				deps[i] = append(deps[i], j)
+				seen[j] = true
+			}
+			lastByKey[string(key)] = i
+		}
+		sinceBarrier = append(sinceBarrier, i)
+	}
+	return deps
+}
+
+// deliverTxs delivers each tx of txs once the txs it depends on (see
+// deliverTxDeps) were answered, as signalled by their index on delivered.
+// The txs ready at the same time are delivered in the order of the block. It
+// returns once all the txs were answered, or the connection failed.
+func deliverTxs(proxyAppConn proxy.AppConnConsensus, txs types.Txs, deps [][]int, delivered <-chan int) error {
+	waiting := make([]int, len(txs))
+	dependents := make([][]int, len(txs))
+	var ready []int
+	for i := range txs {
+		waiting[i] = len(deps[i])
+		for _, j := range deps[i] {
+			dependents[j] = append(dependents[j], i)
+		}
+		if waiting[i] == 0 {
+			ready = append(ready, i)
+		}
+	}
+
+	ticker := time.NewTicker(deliverTxErrorCheckInterval)
+	defer ticker.Stop()
+	for answered := 0; answered < len(txs); {
+		for _, i := range ready {
+			proxyAppConn.DeliverTxAsync(abci.RequestDeliverTx{Tx: txs[i], Index: int32(i)})
+			if err := proxyAppConn.Error(); err != nil {
+				return err
+			}
+		}
+		ready = ready[:0]
+
+		select {
+		case j := <-delivered:
+			answered++
+			// the dependents are in the order of the block
+			for _, i := range dependents[j] {
+				waiting[i]--
+				if waiting[i] == 0 {
+					ready = append(ready, i)
+				}
+			}
+		case <-ticker.C:
+			if err := proxyAppConn.Error(); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,138 @@
+package state_test
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	abci "github.com/hdac-io/tendermint/abci/types"
+	"github.com/hdac-io/tendermint/libs/log"
+	"github.com/hdac-io/tendermint/mock"
+	"github.com/hdac-io/tendermint/proxy"
+	sm "github.com/hdac-io/tendermint/state"
+	"github.com/hdac-io/tendermint/store"
+	"github.com/hdac-io/tendermint/types"
+	dbm "github.com/tendermint/tm-db"
+)
+
+// conflictKeysMempool knows the conflict keys of the txs in keys.
+type conflictKeysMempool struct {
+	mock.Mempool
+	keys map[string][]string
+}
+
+func (mem conflictKeysMempool) ConflictKeys(tx types.Tx) ([][]byte, bool) {
+	keys, ok := mem.keys[string(tx)]
+	if !ok {
+		return nil, false
+	}
+	res := make([][]byte, len(keys))
+	for i, key := range keys {
+		res[i] = []byte(key)
+	}
+	return res, true
+}
+
+func TestDeliverTxDeps(t *testing.T) {
+	testCases := []struct {
+		name     string
+		keys     map[string][]string
+		expected [][]int
+	}{
+		{"independent", map[string][]string{"a": {"1"}, "b": {"2"}, "c": {"3"}}, [][]int{nil, nil, nil}},
+		{"chain", map[string][]string{"a": {"1"}, "b": {"1", "2"}, "c": {"2"}}, [][]int{nil, {0}, {1}}},
+		{"both keys", map[string][]string{"a": {"1"}, "b": {"2"}, "c": {"1", "2"}}, [][]int{nil, nil, {0, 1}}},
+		{"unknown keys", map[string][]string{"a": {"1"}, "c": {"1"}}, [][]int{nil, {0}, {1}}},
+		{"no keys", map[string][]string{"a": {"1"}, "b": {}, "c": {"2"}}, [][]int{nil, {0}, {1}}},
+		{"first barrier", map[string][]string{"b": {"1"}, "c": {"1"}}, [][]int{nil, {0}, {0, 1}}},
+	}
+	txs := types.Txs{types.Tx("a"), types.Tx("b"), types.Tx("c")}
+	for _, tc := range testCases {
+		mem := conflictKeysMempool{keys: tc.keys}
+		assert.Equal(t, tc.expected, sm.DeliverTxDeps(txs, mem.ConflictKeys), tc.name)
+	}
+}
+
+// conflictsApp delivers the txs slowly, and records the txs with a key in
+// common delivered at the same time.
+type conflictsApp struct {
+	abci.BaseApplication
+	keys map[string][]string
+
+	mtx           sync.Mutex
+	running       map[string]bool // key -> delivering
+	concurrent    int
+	maxConcurrent int
+	conflicts     []string
+}
+
+func (app *conflictsApp) DeliverTx(req abci.RequestDeliverTx) abci.ResponseDeliverTx {
+	keys := app.keys[string(req.Tx)]
+	app.mtx.Lock()
+	for _, key := range keys {
+		if app.running[key] {
+			app.conflicts = append(app.conflicts, string(req.Tx))
+		}
+		app.running[key] = true
+	}
+	app.concurrent++
+	if app.concurrent > app.maxConcurrent {
+		app.maxConcurrent = app.concurrent
+	}
+	app.mtx.Unlock()
+
+	time.Sleep(20 * time.Millisecond)
+
+	app.mtx.Lock()
+	for _, key := range keys {
+		delete(app.running, key)
+	}
+	app.concurrent--
+	app.mtx.Unlock()
+	return abci.ResponseDeliverTx{Index: req.Index, Data: req.Tx}
+}
+
+func TestApplyBlockParallelDeliverTx(t *testing.T) {
+	keys := map[string][]string{
+		"a": {"1"},
+		"b": {"2"},
+		"c": {"1", "3"},
+		"d": {"3"},
+		"e": {"4"},
+	}
+	app := &conflictsApp{keys: keys, running: make(map[string]bool)}
+	proxyApp := proxy.NewAppConns(proxy.NewFridayLocalClientCreator(app))
+	require.NoError(t, proxyApp.Start())
+	defer proxyApp.Stop()
+
+	val, _ := types.RandValidator(false, 10)
+	state, err := sm.MakeGenesisState(&types.GenesisDoc{
+		ChainID:         chainID,
+		ConsensusModule: "tendermint",
+		Validators:      []types.GenesisValidator{{PubKey: val.PubKey, Power: val.VotingPower}},
+	})
+	require.NoError(t, err)
+	stateDB := dbm.NewMemDB()
+	sm.SaveState(stateDB, state)
+	blockExec := sm.NewBlockExecutor(store.NewBlockStore(stateDB), stateDB, log.TestingLogger(), proxyApp.Consensus(),
+		conflictKeysMempool{keys: keys}, sm.MockEvidencePool{}, sm.BlockExecutorWithParallelDeliverTx())
+
+	// "f" is not in the mempool: it conflicts with all the others
+	txs := types.Txs{types.Tx("a"), types.Tx("b"), types.Tx("c"), types.Tx("d"), types.Tx("f"), types.Tx("e")}
+	block, _ := state.MakeBlock(1, txs, new(types.Commit), nil, state.Validators.GetProposer().Address)
+	blockID := types.BlockID{Hash: block.Hash(), PartsHeader: types.PartSetHeader{}}
+	_, err = blockExec.ApplyBlock(state, blockID, block)
+	require.NoError(t, err)
+
+	assert.Empty(t, app.conflicts)
+	assert.True(t, app.maxConcurrent > 1, "no txs delivered concurrently")
+	abciResponses, err := sm.LoadABCIResponses(stateDB, 1)
+	require.NoError(t, err)
+	require.Len(t, abciResponses.DeliverTx, len(txs))
+	for i, tx := range txs {
+		assert.EqualValues(t, tx, abciResponses.DeliverTx[i].Data, "#%d", i)
+	}
+}
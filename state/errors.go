@@ -25,6 +25,12 @@ type (
 		AppHeight  int64
 	}
 
+	ErrAppLenULBUnsupported struct {
+		LenULB int64
+		Min    int64
+		Max    int64
+	}
+
 	ErrLastStateMismatch struct {
 		Height int64
 		Core   []byte
@@ -48,6 +54,10 @@ type (
 		Height int64
 	}
 
+	ErrNoQuorumCertForHeight struct {
+		Height int64
+	}
+
 	// ErrLastBlockIDMismatch requires from check to ValidateBlock error type
 	ErrLastBlockIDMismatch struct {
 		Expected types.BlockID
@@ -66,6 +76,11 @@ func (e ErrBlockHashMismatch) Error() string {
 func (e ErrAppBlockHeightTooHigh) Error() string {
 	return fmt.Sprintf("App block height (%d) is higher than core (%d)", e.AppHeight, e.CoreHeight)
 }
+
+func (e ErrAppLenULBUnsupported) Error() string {
+	return fmt.Sprintf("App supports LenULB from %d to %d (0 is unbounded), but the consensus params have %d", e.Min, e.Max, e.LenULB)
+}
+
 func (e ErrLastStateMismatch) Error() string {
 	return fmt.Sprintf("Latest tendermint block (%d) LastAppHash (%X) does not match app's AppHash (%X)", e.Height, e.Core, e.App)
 }
@@ -86,6 +101,10 @@ func (e ErrNoABCIResponsesForHeight) Error() string {
 	return fmt.Sprintf("Could not find results for height #%d", e.Height)
 }
 
+func (e ErrNoQuorumCertForHeight) Error() string {
+	return fmt.Sprintf("Could not find quorum cert for height #%d", e.Height)
+}
+
 func (e ErrLastBlockIDMismatch) Error() string {
 	return fmt.Sprintf("Wrong Block.Header.LastBlockID.  Expected %v, got %v",
 		e.Expected,
@@ -5,8 +5,11 @@ import (
 	"time"
 
 	abci "github.com/hdac-io/tendermint/abci/types"
+	"github.com/hdac-io/tendermint/crypto/bls"
+	cmn "github.com/hdac-io/tendermint/libs/common"
 	"github.com/hdac-io/tendermint/libs/fail"
 	"github.com/hdac-io/tendermint/libs/log"
+	"github.com/hdac-io/tendermint/libs/trace"
 	mempl "github.com/hdac-io/tendermint/mempool"
 	"github.com/hdac-io/tendermint/proxy"
 	"github.com/hdac-io/tendermint/types"
@@ -39,6 +42,19 @@ type BlockExecutor struct {
 	logger log.Logger
 
 	metrics *Metrics
+	tracer  trace.Tracer
+
+	// account the gas of the txs, if set
+	txGas TxGasFunc
+
+	// pull the txs of the proposals when the mempool has none, if set
+	txPuller TxPuller
+
+	// prepare the txs reaped for the proposals, if set
+	txsPreparer ProposalTxsPreparer
+
+	// deliver the txs without conflict keys in common concurrently
+	parallelDeliverTx bool
 }
 
 type BlockExecutorOption func(executor *BlockExecutor)
@@ -49,6 +65,34 @@ func BlockExecutorWithMetrics(metrics *Metrics) BlockExecutorOption {
 	}
 }
 
+func BlockExecutorWithTracer(tracer trace.Tracer) BlockExecutorOption {
+	return func(blockExec *BlockExecutor) {
+		blockExec.tracer = tracer
+	}
+}
+
+// BlockExecutorWithTxPuller makes the proposal blocks pull their txs with
+// txPuller when the mempool has none.
+func BlockExecutorWithTxPuller(txPuller TxPuller) BlockExecutorOption {
+	return func(blockExec *BlockExecutor) {
+		blockExec.txPuller = txPuller
+	}
+}
+
+// BlockExecutorWithParallelDeliverTx makes the blocks deliver each tx once the
+// txs before it that it conflicts with were answered, instead of all the txs
+// at once, so that the app can run the txs without conflict keys in common
+// concurrently (see abci.ResponseCheckTx.ConflictKeys). The conflict keys are
+// the ones of the txs in the mempool: the other txs conflict with all the
+// others. The responses are in the order of the block whatever the order they
+// arrive in. It requires a client delivering the txs concurrently (e.g.
+// abcicli.NewFridayLocalClient), the other ones deliver them one at a time.
+func BlockExecutorWithParallelDeliverTx() BlockExecutorOption {
+	return func(blockExec *BlockExecutor) {
+		blockExec.parallelDeliverTx = true
+	}
+}
+
 // NewBlockExecutor returns a new BlockExecutor with a NopEventBus.
 // Call SetEventBus to provide one.
 func NewBlockExecutor(store BlockStore, db dbm.DB, logger log.Logger, proxyApp proxy.AppConnConsensus, mempool mempl.Mempool, evpool EvidencePool, options ...BlockExecutorOption) *BlockExecutor {
@@ -61,6 +105,7 @@ func NewBlockExecutor(store BlockStore, db dbm.DB, logger log.Logger, proxyApp p
 		evpool:   evpool,
 		logger:   logger,
 		metrics:  NopMetrics(),
+		tracer:   trace.NopTracer(),
 	}
 
 	for _, option := range options {
@@ -80,14 +125,30 @@ func (blockExec *BlockExecutor) SetEventBus(eventBus types.BlockEventPublisher)
 	blockExec.eventBus = eventBus
 }
 
+// SetTxGas sets the gas of the txs enforcing the Block.MaxGas consensus
+// param: the proposal blocks are filled with txs up to it, and the blocks
+// exceeding it are invalid. If not called, the proposal blocks are filled up
+// to the gas wanted by the CheckTx of the txs, and the blocks aren't checked.
+func (blockExec *BlockExecutor) SetTxGas(txGas TxGasFunc) {
+	blockExec.txGas = txGas
+}
+
+// SetProposalTxsPreparer sets the preparer of the txs reaped for the proposal
+// blocks. If not called, the txs are proposed as reaped.
+func (blockExec *BlockExecutor) SetProposalTxsPreparer(txsPreparer ProposalTxsPreparer) {
+	blockExec.txsPreparer = txsPreparer
+}
+
 // CreateProposalBlock calls state.MakeBlock with evidence from the evpool
 // and txs from the mempool. The max bytes must be big enough to fit the commit.
 // Up to 1/10th of the block space is allcoated for maximum sized evidence.
-// The rest is given to txs, up to the max gas.
+// The rest is given to txs, up to the max gas. The txs are prepared before
+// deadline, if not zero (see prepareTxs).
 func (blockExec *BlockExecutor) CreateProposalBlock(
 	height int64,
 	state State, commit *types.Commit,
 	proposerAddr []byte,
+	deadline time.Time,
 ) (*types.Block, *types.PartSet) {
 
 	maxBytes := state.ConsensusParams.Block.MaxBytes
@@ -99,7 +160,7 @@ func (blockExec *BlockExecutor) CreateProposalBlock(
 
 	// Fetch a limited amount of valid txs
 	maxDataBytes := types.MaxDataBytes(maxBytes, state.Validators.Size(), len(evidence))
-	txs := blockExec.mempool.ReapMaxBytesMaxGas(maxDataBytes, maxGas)
+	txs := blockExec.prepareTxs(height, maxDataBytes, maxGas, state.ConsensusParams.Block.MaxTxBytes, deadline)
 
 	return state.MakeBlock(height, txs, commit, evidence, proposerAddr)
 }
@@ -107,7 +168,8 @@ func (blockExec *BlockExecutor) CreateProposalBlock(
 // CreateProposalBlockFromArgs calls state.MakeBlockFromArgs with evidence from the evpool
 // and txs from the mempool. The max bytes must be big enough to fit the commit.
 // Up to 1/10th of the block space is allcoated for maximum sized evidence.
-// The rest is given to txs, up to the max gas.
+// The rest is given to txs, up to the max gas. The txs are prepared before
+// deadline, if not zero (see prepareTxs).
 func (blockExec *BlockExecutor) CreateProposalBlockFromArgs(
 	height int64,
 	prevBlockID types.BlockID,
@@ -116,10 +178,12 @@ func (blockExec *BlockExecutor) CreateProposalBlockFromArgs(
 	ulbCommit *types.Commit, ulbValidators *types.ValidatorSet,
 	validatorsHash []byte, ulbNextValidatorsHash []byte, appHash []byte, resultsHash []byte,
 	proposerAddr []byte,
+	deadline time.Time,
 ) (*types.Block, *types.PartSet) {
 
-	maxBytes := state.ConsensusParams.Block.MaxBytes
-	maxGas := state.ConsensusParams.Block.MaxGas
+	params := state.ConsensusParamsAt(height)
+	maxBytes := params.Block.MaxBytes
+	maxGas := params.Block.MaxGas
 
 	// Fetch a limited amount of valid evidence
 	maxNumEvidence, _ := types.MaxEvidencePerBlock(maxBytes)
@@ -127,7 +191,7 @@ func (blockExec *BlockExecutor) CreateProposalBlockFromArgs(
 
 	// Fetch a limited amount of valid txs
 	maxDataBytes := types.MaxDataBytes(maxBytes, state.Validators.Size(), len(evidence))
-	txs := blockExec.mempool.ReapMaxBytesMaxGas(maxDataBytes, maxGas)
+	txs := blockExec.prepareTxs(height, maxDataBytes, maxGas, params.Block.MaxTxBytes, deadline)
 
 	return state.MakeBlockFromArgs(
 		height,
@@ -139,12 +203,126 @@ func (blockExec *BlockExecutor) CreateProposalBlockFromArgs(
 		validatorsHash, ulbNextValidatorsHash, appHash, resultsHash)
 }
 
+// prepareTxs reaps the txs of a proposal block at height, and hands them to
+// the txsPreparer, if set. With a deadline, a slow mempool, peer or app can't
+// delay the proposal past it: the block has no txs if they are not reaped in
+// time, and the reaped txs if the txsPreparer doesn't return in time, which
+// is counted by the ProposalBudgetExceeded metric.
+func (blockExec *BlockExecutor) prepareTxs(height, maxBytes, maxGas, maxTxBytes int64, deadline time.Time) types.Txs {
+	start := time.Now()
+	defer func() {
+		blockExec.metrics.ProposalPreparationTime.Observe(float64(time.Since(start)) / float64(time.Millisecond))
+	}()
+
+	var expired <-chan time.Time
+	if !deadline.IsZero() {
+		timer := time.NewTimer(time.Until(deadline))
+		defer timer.Stop()
+		expired = timer.C
+	}
+
+	reaped := make(chan types.Txs, 1)
+	go func() {
+		span := blockExec.tracer.StartSpan(height, "mempool.ReapMaxBytesMaxGas")
+		txs := blockExec.reapMaxBytesMaxGas(maxBytes, maxGas, maxTxBytes)
+		span.SetAttributes("txs", len(txs))
+		span.End()
+		reaped <- txs
+	}()
+	var txs types.Txs
+	select {
+	case txs = <-reaped:
+	case <-expired:
+		blockExec.metrics.ProposalBudgetExceeded.With("step", "reap").Add(1)
+		blockExec.logger.Error("The txs of the proposal block were not reaped in time, proposing none",
+			"height", height, "deadline", deadline)
+		return nil
+	}
+	if blockExec.txsPreparer == nil || len(txs) == 0 {
+		return txs
+	}
+
+	// the txsPreparer gets a copy, as it may still be reordering it when the
+	// reaped txs are proposed
+	prepared := make(chan types.Txs, 1)
+	go func(txs types.Txs) {
+		prepared <- blockExec.txsPreparer.PrepareProposalTxs(deadline, txs)
+	}(append(types.Txs(nil), txs...))
+	select {
+	case preparedTxs := <-prepared:
+		if txsBytes(preparedTxs) > txsBytes(txs) {
+			blockExec.logger.Error("The app prepared more bytes of txs than reaped, proposing the reaped txs",
+				"height", height, "reaped", txsBytes(txs), "prepared", txsBytes(preparedTxs))
+			return txs
+		}
+		return preparedTxs
+	case <-expired:
+		blockExec.metrics.ProposalBudgetExceeded.With("step", "app").Add(1)
+		blockExec.logger.Error("The app did not prepare the txs of the proposal block in time, proposing the reaped txs",
+			"height", height, "deadline", deadline)
+		return txs
+	}
+}
+
+// reapMaxBytesMaxGas reaps the txs from the mempool up to maxBytes and
+// maxGas, accounting the gas with txGas if set. It skips the txs larger than
+// maxTxBytes, if set, which the mempool admitted before it decreased.
+func (blockExec *BlockExecutor) reapMaxBytesMaxGas(maxBytes, maxGas, maxTxBytes int64) types.Txs {
+	if blockExec.txGas == nil || maxGas < 0 {
+		return dropLargeTxs(blockExec.reapTxs(maxBytes, maxGas), maxTxBytes)
+	}
+
+	txs := dropLargeTxs(blockExec.reapTxs(maxBytes, -1), maxTxBytes)
+	var totalGas int64
+	for i, tx := range txs {
+		totalGas += blockExec.txGas(tx)
+		if totalGas > maxGas {
+			return txs[:i]
+		}
+	}
+	return txs
+}
+
+// reapTxs reaps the txs from the mempool up to maxBytes and maxGas, or pulls
+// them with the txPuller, if set, when the mempool has none.
+func (blockExec *BlockExecutor) reapTxs(maxBytes, maxGas int64) types.Txs {
+	txs := blockExec.mempool.ReapMaxBytesMaxGas(maxBytes, maxGas)
+	if len(txs) == 0 && blockExec.txPuller != nil {
+		txs = blockExec.txPuller.PullTxs(maxBytes, maxGas)
+	}
+	return txs
+}
+
+// txsBytes returns the size of txs.
+func txsBytes(txs types.Txs) int64 {
+	var size int64
+	for _, tx := range txs {
+		size += int64(len(tx))
+	}
+	return size
+}
+
+// dropLargeTxs returns the txs not larger than maxTxBytes. 0 keeps all the
+// txs.
+func dropLargeTxs(txs types.Txs, maxTxBytes int64) types.Txs {
+	if maxTxBytes == 0 {
+		return txs
+	}
+	kept := txs[:0]
+	for _, tx := range txs {
+		if int64(len(tx)) <= maxTxBytes {
+			kept = append(kept, tx)
+		}
+	}
+	return kept
+}
+
 // ValidateBlock validates the given block against the given state.
 // If the block is invalid, it returns an error.
 // Validation does not mutate state, but does require historical information from the stateDB,
 // ie. to verify evidence from a validator at an old height.
 func (blockExec *BlockExecutor) ValidateBlock(state State, block *types.Block) error {
-	return validateBlock(blockExec.store, blockExec.evpool, blockExec.db, state, block)
+	return validateBlock(blockExec.store, blockExec.evpool, blockExec.db, state, block, blockExec.txGas)
 }
 
 // ReserveBlock marking txs to 'reserved' into mempool from received proposal
@@ -177,12 +355,15 @@ func (blockExec *BlockExecutor) ApplyBlock(state State, blockID types.BlockID, b
 		return blockExec.ApplyFridayBlock(state, blockID, block)
 	}
 
+	span := blockExec.tracer.StartSpan(block.Height, "state.ApplyBlock")
+	defer span.End()
+
 	if err := blockExec.ValidateBlock(state, block); err != nil {
 		return state, ErrInvalidBlock(err)
 	}
 
 	startTime := time.Now().UnixNano()
-	abciResponses, err := execBlockOnProxyApp(blockExec.logger, blockExec.proxyApp, block, blockExec.db, 1)
+	abciResponses, err := execBlockOnProxyApp(blockExec.logger, blockExec.tracer, blockExec.proxyApp, block, blockExec.db, 1, blockExec.conflictKeys())
 	endTime := time.Now().UnixNano()
 	blockExec.metrics.BlockProcessingTime.Observe(float64(endTime-startTime) / 1000000)
 	if err != nil {
@@ -230,6 +411,7 @@ func (blockExec *BlockExecutor) ApplyBlock(state State, blockID types.BlockID, b
 	// Update the app hash and save the state.
 	state.AppHash = appHash
 	SaveState(blockExec.db, state)
+	reportCacheMetrics(blockExec.metrics)
 
 	fail.Fail() // XXX
 
@@ -248,12 +430,16 @@ func (blockExec *BlockExecutor) ApplyBlock(state State, blockID types.BlockID, b
 // NOTE: changed updateState to specialized for friday
 func (blockExec *BlockExecutor) ApplyFridayBlock(state State, blockID types.BlockID, block *types.Block) (State, error) {
 
+	span := blockExec.tracer.StartSpan(block.Height, "state.ApplyBlock")
+	defer span.End()
+
 	if err := blockExec.ValidateBlock(state, block); err != nil {
 		return state, ErrInvalidBlock(err)
 	}
 
 	startTime := time.Now().UnixNano()
-	abciResponses, err := execBlockOnProxyApp(blockExec.logger, blockExec.proxyApp, block, blockExec.db, state.ConsensusParams.Block.LenULB)
+	abciResponses, err := execBlockOnProxyApp(blockExec.logger, blockExec.tracer, blockExec.proxyApp, block, blockExec.db, state.ConsensusParams.Block.LenULB,
+		blockExec.conflictKeys())
 	endTime := time.Now().UnixNano()
 	blockExec.metrics.BlockProcessingTime.Observe(float64(endTime-startTime) / 1000000)
 	if err != nil {
@@ -262,6 +448,10 @@ func (blockExec *BlockExecutor) ApplyFridayBlock(state State, blockID types.Bloc
 
 	fail.Fail() // XXX
 
+	// Reject the consensus params update before saving the results, so the
+	// rejection is replayed with them.
+	rejectConsensusParamUpdates(blockExec.logger, block.Height, state.ConsensusParams, abciResponses)
+
 	// Save the results before we commit.
 	saveABCIResponses(blockExec.db, block.Height, abciResponses)
 
@@ -280,7 +470,6 @@ func (blockExec *BlockExecutor) ApplyFridayBlock(state State, blockID types.Bloc
 	if len(validatorUpdates) > 0 {
 		blockExec.logger.Info("Updates to validators", "updates", types.ValidatorListString(validatorUpdates))
 	}
-
 	// Update the state with the block and responses.
 	state, err = updateFridayState(state, blockID, &block.Header, abciResponses, validatorUpdates)
 	if err != nil {
@@ -301,6 +490,7 @@ func (blockExec *BlockExecutor) ApplyFridayBlock(state State, blockID types.Bloc
 	// Update the app hash and save the state.
 	state.AppHash = appHash
 	SaveState(blockExec.db, state)
+	reportCacheMetrics(blockExec.metrics)
 
 	fail.Fail() // XXX
 
@@ -327,14 +517,18 @@ func (blockExec *BlockExecutor) Commit(
 
 	// while mempool is Locked, flush to ensure all async requests have completed
 	// in the ABCI app before Commit.
+	span := blockExec.tracer.StartSpan(block.Height, "mempool.FlushAppConn")
 	err := blockExec.mempool.FlushAppConn()
+	span.End()
 	if err != nil {
 		blockExec.logger.Error("Client error during mempool.FlushAppConn", "err", err)
 		return nil, err
 	}
 
 	// Commit block, get hash back
+	span = blockExec.tracer.StartSpan(block.Height, "abci.Commit")
 	res, err := blockExec.proxyApp.CommitSync()
+	span.End()
 	if err != nil {
 		blockExec.logger.Error(
 			"Client error during proxyAppConn.CommitSync",
@@ -352,6 +546,7 @@ func (blockExec *BlockExecutor) Commit(
 	)
 
 	// Update mempool.
+	span = blockExec.tracer.StartSpan(block.Height, "mempool.Update", "txs", len(block.Txs))
 	err = blockExec.mempool.Update(
 		block.Height,
 		block.Txs,
@@ -359,6 +554,7 @@ func (blockExec *BlockExecutor) Commit(
 		TxPreCheck(state),
 		TxPostCheck(state),
 	)
+	span.End()
 
 	return res.Data, err
 }
@@ -366,19 +562,41 @@ func (blockExec *BlockExecutor) Commit(
 //---------------------------------------------------------
 // Helper functions for executing blocks and updating state
 
+// conflictKeys returns the conflict keys of the txs to deliver them in
+// parallel, or nil to deliver them all at once.
+func (blockExec *BlockExecutor) conflictKeys() ConflictKeysFunc {
+	if !blockExec.parallelDeliverTx {
+		return nil
+	}
+	return blockExec.mempool.ConflictKeys
+}
+
 // Executes block's transactions on proxyAppConn.
 // Returns a list of transaction results and updates to the validator set
+// The txs are delivered all at once if conflictKeys is nil, and each tx once
+// the txs it conflicts with were answered otherwise (see deliverTxDeps).
 func execBlockOnProxyApp(
 	logger log.Logger,
+	tracer trace.Tracer,
 	proxyAppConn proxy.AppConnConsensus,
 	block *types.Block,
 	stateDB dbm.DB,
 	commitDistance int64,
+	conflictKeys ConflictKeysFunc,
 ) (*ABCIResponses, error) {
 	var validTxs, invalidTxs = 0, 0
 
 	abciResponses := NewABCIResponses(block)
 
+	// The txs are delivered asynchronously, the span ends with the last response.
+	var deliverTxSpan trace.Span
+
+	// The indexes of the answered txs, to deliver the ones waiting for them.
+	var delivered chan int
+	if conflictKeys != nil {
+		delivered = make(chan int, len(block.Txs))
+	}
+
 	// Execute transactions and get hash.
 	proxyCb := func(req *abci.Request, res *abci.Response) {
 		if r, ok := res.Value.(*abci.Response_DeliverTx); ok {
@@ -393,6 +611,13 @@ func execBlockOnProxyApp(
 				invalidTxs++
 			}
 			abciResponses.DeliverTx[r.DeliverTx.Index] = txRes
+			if validTxs+invalidTxs == len(block.Txs) {
+				deliverTxSpan.SetAttributes("validTxs", validTxs, "invalidTxs", invalidTxs)
+				deliverTxSpan.End()
+			}
+			if delivered != nil {
+				delivered <- int(req.GetDeliverTx().Index)
+			}
 		}
 	}
 	proxyAppConn.SetResponseCallback(proxyCb)
@@ -401,27 +626,41 @@ func execBlockOnProxyApp(
 
 	// Begin block
 	var err error
+	span := tracer.StartSpan(block.Height, "abci.BeginBlock")
 	abciResponses.BeginBlock, err = proxyAppConn.BeginBlockSync(abci.RequestBeginBlock{
 		Hash:                block.Hash(),
 		Header:              types.TM2PB.Header(&block.Header),
 		LastCommitInfo:      commitInfo,
 		ByzantineValidators: byzVals,
 	})
+	span.End()
 	if err != nil {
 		logger.Error("Error in proxyAppConn.BeginBlock", "err", err)
 		return nil, err
 	}
 
 	// Run txs of block.
-	for index, tx := range block.Txs {
-		proxyAppConn.DeliverTxAsync(abci.RequestDeliverTx{Tx: tx, Index: int32(index)})
-		if err := proxyAppConn.Error(); err != nil {
+	deliverTxSpan = tracer.StartSpan(block.Height, "abci.DeliverTx", "txs", len(block.Txs))
+	if len(block.Txs) == 0 {
+		deliverTxSpan.End()
+	}
+	if conflictKeys != nil {
+		if err := deliverTxs(proxyAppConn, block.Txs, deliverTxDeps(block.Txs, conflictKeys), delivered); err != nil {
 			return nil, err
 		}
+	} else {
+		for index, tx := range block.Txs {
+			proxyAppConn.DeliverTxAsync(abci.RequestDeliverTx{Tx: tx, Index: int32(index)})
+			if err := proxyAppConn.Error(); err != nil {
+				return nil, err
+			}
+		}
 	}
 
 	// End block.
+	span = tracer.StartSpan(block.Height, "abci.EndBlock")
 	abciResponses.EndBlock, err = proxyAppConn.EndBlockSync(abci.RequestEndBlock{Height: block.Height})
+	span.End()
 	if err != nil {
 		logger.Error("Error in proxyAppConn.EndBlock", "err", err)
 		return nil, err
@@ -466,6 +705,9 @@ func getBeginBlockValidatorInfo(block *types.Block, stateDB dbm.DB, commitDistan
 			Validator:       types.TM2PB.Validator(val),
 			SignedLastBlock: vote != nil,
 		}
+		if vote != nil {
+			voteInfo.Timestamp = vote.Timestamp
+		}
 		voteInfos[i] = voteInfo
 	}
 
@@ -480,9 +722,15 @@ func getBeginBlockValidatorInfo(block *types.Block, stateDB dbm.DB, commitDistan
 		byzVals[i] = types.TM2PB.Evidence(ev, valset, block.Time)
 	}
 
+	// the commit is the one carried by the block, of the height commitDistance
+	// below it, signed by the validators of that height
 	commitInfo := abci.LastCommitInfo{
-		Round: int32(block.LastCommit.Round()),
-		Votes: voteInfos,
+		Round:  int32(block.LastCommit.Round()),
+		Votes:  voteInfos,
+		Height: block.LastCommit.Height(),
+	}
+	if signers := block.LastCommit.BitArray(); signers != nil {
+		commitInfo.Signers = signers.Bytes()
 	}
 	return commitInfo, byzVals
 
@@ -505,6 +753,17 @@ func validateValidatorUpdates(abciUpdates []abci.ValidatorUpdate,
 			return fmt.Errorf("Validator %v is using pubkey %s, which is unsupported for consensus",
 				valUpdate, thisKeyType)
 		}
+
+		// Check the proof of possession of a BLS key, if any
+		if len(valUpdate.ProofOfPossession) > 0 {
+			pubKey, err := types.PB2TM.PubKey(valUpdate.PubKey)
+			if err != nil {
+				return err
+			}
+			if blsKey, ok := pubKey.(bls.PubKeyBls); !ok || !blsKey.VerifyProofOfPossession(valUpdate.ProofOfPossession) {
+				return fmt.Errorf("Validator %v has an invalid proof of possession of its BLS key", valUpdate)
+			}
+		}
 	}
 	return nil
 }
@@ -533,6 +792,10 @@ func updateState(
 		if err != nil {
 			return state, fmt.Errorf("Error changing validator set: %v", err)
 		}
+		err = state.ConsensusParams.Validator.CheckPowerShare(nValSet.Validators)
+		if err != nil {
+			return state, fmt.Errorf("Error changing validator set: %v", err)
+		}
 		// Change results from this height but only applies to the next next height.
 		lastHeightValsChanged = header.Height + 1 + 1
 	}
@@ -577,6 +840,25 @@ func updateState(
 	}, nil
 }
 
+// rejectConsensusParamUpdates rejects the consensus params update returned by
+// EndBlock if it changes the params which can't be updated once the chain has
+// started (see ConsensusParams.ValidateUpdate): none of its fields are applied,
+// and a "consensus_params" event with the reason as its "rejected" attribute
+// (types.ConsensusParamsRejectedKey) is added to the EndBlock events, for the
+// app to see it in the block results and the NewBlock events.
+func rejectConsensusParamUpdates(logger log.Logger, height int64, params types.ConsensusParams, abciResponses *ABCIResponses) {
+	err := params.ValidateUpdate(abciResponses.EndBlock.ConsensusParamUpdates)
+	if err == nil {
+		return
+	}
+	logger.Error("Rejected the consensus params update", "height", height, "err", err)
+	abciResponses.EndBlock.ConsensusParamUpdates = nil
+	abciResponses.EndBlock.Events = append(abciResponses.EndBlock.Events, abci.Event{
+		Type:       "consensus_params",
+		Attributes: []cmn.KVPair{{Key: []byte("rejected"), Value: []byte(err.Error())}},
+	})
+}
+
 // updateFridayState returns a new State updated according to the header and responses.
 // NOTE: change NextValidators delay distance to after ULB distance
 func updateFridayState(
@@ -598,6 +880,10 @@ func updateFridayState(
 		if err != nil {
 			return state, fmt.Errorf("Error changing validator set: %v", err)
 		}
+		err = state.ConsensusParams.Validator.CheckPowerShare(nValSet.Validators)
+		if err != nil {
+			return state, fmt.Errorf("Error changing validator set: %v", err)
+		}
 		// Change results from this height but only applies to the next next height.
 		lastHeightValsChanged = header.Height + 1 + state.ConsensusParams.Block.LenULB
 	}
@@ -605,18 +891,34 @@ func updateFridayState(
 	// Update validator proposer priority and set state variables.
 	nValSet.IncrementProposerPriority(1)
 
-	// Update the params with the latest abciResponses.
-	nextParams := state.ConsensusParams
-	lastHeightParamsChanged := state.LastHeightConsensusParamsChanged
+	// Schedule the params update of the latest abciResponses.
+	scheduledParams := state.ScheduledConsensusParams
 	if abciResponses.EndBlock.ConsensusParamUpdates != nil {
-		// NOTE: must not mutate s.ConsensusParams
-		nextParams = state.ConsensusParams.Update(abciResponses.EndBlock.ConsensusParamUpdates)
-		err := nextParams.Validate()
+		// NOTE: must not mutate s.ConsensusParams nor s.ScheduledConsensusParams
+		params := state.ConsensusParams
+		if n := len(scheduledParams); n > 0 {
+			params = scheduledParams[n-1].Params
+		}
+		// an update changing the LenULB was rejected, see rejectConsensusParamUpdates
+		params = params.Update(abciResponses.EndBlock.ConsensusParamUpdates)
+		err := params.Validate()
 		if err != nil {
 			return state, fmt.Errorf("Error updating consensus params: %v", err)
 		}
-		// Change results from this height but only applies to the next height.
+		// Change results from this height but only applies after the ULB distance.
+		scheduledParams = append(append([]ConsensusParamsChange{}, scheduledParams...), ConsensusParamsChange{
+			Height: header.Height + 1 + state.ConsensusParams.Block.LenULB,
+			Params: params,
+		})
+	}
+
+	// Apply the params scheduled for the next height.
+	nextParams := state.ConsensusParams
+	lastHeightParamsChanged := state.LastHeightConsensusParamsChanged
+	for len(scheduledParams) > 0 && scheduledParams[0].Height <= header.Height+1 {
+		nextParams = scheduledParams[0].Params
 		lastHeightParamsChanged = header.Height + 1
+		scheduledParams = scheduledParams[1:]
 	}
 
 	// TODO: allow app to upgrade version
@@ -639,6 +941,7 @@ func updateFridayState(
 		LastHeightConsensusParamsChanged: lastHeightParamsChanged,
 		LastResultsHash:                  abciResponses.ResultsHash(),
 		AppHash:                          nil,
+		ScheduledConsensusParams:         scheduledParams,
 	}, nil
 }
 
@@ -677,14 +980,21 @@ func fireEvents(logger log.Logger, eventBus types.BlockEventPublisher, block *ty
 
 // ExecCommitBlock executes and commits a block on the proxyApp without validating or mutating the state.
 // It returns the application root hash (result of abci.Commit).
+// With parallelDeliverTx, the txs are delivered one at a time, as their
+// conflict keys are unknown (see BlockExecutorWithParallelDeliverTx).
 func ExecCommitBlock(
 	appConnConsensus proxy.AppConnConsensus,
 	block *types.Block,
 	logger log.Logger,
 	stateDB dbm.DB,
 	commitDistance int64,
+	parallelDeliverTx bool,
 ) ([]byte, error) {
-	_, err := execBlockOnProxyApp(logger, appConnConsensus, block, stateDB, commitDistance)
+	var conflictKeys ConflictKeysFunc
+	if parallelDeliverTx {
+		conflictKeys = noConflictKeys
+	}
+	_, err := execBlockOnProxyApp(logger, trace.NopTracer(), appConnConsensus, block, stateDB, commitDistance, conflictKeys)
 	if err != nil {
 		logger.Error("Error executing block on proxy app", "height", block.Height, "err", err)
 		return nil, err
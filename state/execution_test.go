@@ -9,14 +9,17 @@ import (
 	"github.com/stretchr/testify/require"
 	"github.com/hdac-io/tendermint/abci/example/kvstore"
 	abci "github.com/hdac-io/tendermint/abci/types"
+	"github.com/hdac-io/tendermint/crypto/bls"
 	"github.com/hdac-io/tendermint/crypto/ed25519"
 	"github.com/hdac-io/tendermint/crypto/secp256k1"
 	"github.com/hdac-io/tendermint/libs/log"
 	"github.com/hdac-io/tendermint/mock"
 	"github.com/hdac-io/tendermint/proxy"
 	sm "github.com/hdac-io/tendermint/state"
+	"github.com/hdac-io/tendermint/store"
 	"github.com/hdac-io/tendermint/types"
 	tmtime "github.com/hdac-io/tendermint/types/time"
+	dbm "github.com/tendermint/tm-db"
 )
 
 var (
@@ -34,7 +37,7 @@ func TestApplyBlock(t *testing.T) {
 
 	state, stateDB, _ := makeState(1, 1)
 
-	blockExec := sm.NewBlockExecutor(stateDB, log.TestingLogger(), proxyApp.Consensus(),
+	blockExec := sm.NewBlockExecutor(store.NewBlockStore(dbm.NewMemDB()), stateDB, log.TestingLogger(), proxyApp.Consensus(),
 		mock.Mempool{}, sm.MockEvidencePool{})
 
 	block := makeBlock(state, 1)
@@ -63,17 +66,18 @@ func TestBeginBlockValidators(t *testing.T) {
 	prevBlockID := types.BlockID{Hash: prevHash, PartsHeader: prevParts}
 
 	now := tmtime.Now()
-	commitSig0 := (&types.Vote{ValidatorIndex: 0, Timestamp: now, Type: types.PrecommitType}).CommitSig()
-	commitSig1 := (&types.Vote{ValidatorIndex: 1, Timestamp: now}).CommitSig()
+	commitSig0 := (&types.Vote{Height: 1, ValidatorIndex: 0, Timestamp: now, Type: types.PrecommitType}).CommitSig()
+	commitSig1 := (&types.Vote{Height: 1, ValidatorIndex: 1, Timestamp: now}).CommitSig()
 
 	testCases := []struct {
 		desc                     string
 		lastCommitPrecommits     []*types.CommitSig
 		expectedAbsentValidators []int
+		expectedSigners          []byte
 	}{
-		{"none absent", []*types.CommitSig{commitSig0, commitSig1}, []int{}},
-		{"one absent", []*types.CommitSig{commitSig0, nil}, []int{1}},
-		{"multiple absent", []*types.CommitSig{nil, nil}, []int{0, 1}},
+		{"none absent", []*types.CommitSig{commitSig0, commitSig1}, []int{}, []byte{0x03}},
+		{"one absent", []*types.CommitSig{commitSig0, nil}, []int{1}, []byte{0x01}},
+		{"multiple absent", []*types.CommitSig{nil, nil}, []int{0, 1}, []byte{0x00}},
 	}
 
 	for _, tc := range testCases {
@@ -82,7 +86,7 @@ func TestBeginBlockValidators(t *testing.T) {
 		// block for height 2
 		block, _ := state.MakeBlock(2, makeTxs(2), lastCommit, nil, state.Validators.GetProposer().Address)
 
-		_, err = sm.ExecCommitBlock(proxyApp.Consensus(), block, log.TestingLogger(), stateDB)
+		_, err = sm.ExecCommitBlock(proxyApp.Consensus(), block, log.TestingLogger(), stateDB, 1, false)
 		require.Nil(t, err, tc.desc)
 
 		// -> app receives a list of validators with a bool indicating if they signed
@@ -92,11 +96,18 @@ func TestBeginBlockValidators(t *testing.T) {
 				tc.expectedAbsentValidators[ctr] == i {
 
 				assert.False(t, v.SignedLastBlock)
+				assert.True(t, v.Timestamp.IsZero())
 				ctr++
 			} else {
 				assert.True(t, v.SignedLastBlock)
+				assert.True(t, v.Timestamp.Equal(now))
 			}
 		}
+		// -> and the height and bitmap of the signers of the commit
+		if len(tc.expectedAbsentValidators) < len(tc.lastCommitPrecommits) {
+			assert.EqualValues(t, 1, app.CommitHeight, tc.desc)
+		}
+		assert.Equal(t, tc.expectedSigners, app.CommitSigners, tc.desc)
 	}
 }
 
@@ -143,7 +154,7 @@ func TestBeginBlockByzantineValidators(t *testing.T) {
 		block, _ := state.MakeBlock(10, makeTxs(2), lastCommit, nil, state.Validators.GetProposer().Address)
 		block.Time = now
 		block.Evidence.Evidence = tc.evidence
-		_, err = sm.ExecCommitBlock(proxyApp.Consensus(), block, log.TestingLogger(), stateDB)
+		_, err = sm.ExecCommitBlock(proxyApp.Consensus(), block, log.TestingLogger(), stateDB, 1, false)
 		require.Nil(t, err, tc.desc)
 
 		// -> app must receive an index of the byzantine validator
@@ -156,8 +167,11 @@ func TestValidateValidatorUpdates(t *testing.T) {
 	pubkey2 := ed25519.GenPrivKey().PubKey()
 
 	secpKey := secp256k1.GenPrivKey().PubKey()
+	blsPrivKey := bls.GenPrivKey()
+	blsKey := blsPrivKey.PubKey()
 
 	defaultValidatorParams := types.ValidatorParams{PubKeyTypes: []string{types.ABCIPubKeyTypeEd25519}}
+	blsValidatorParams := types.ValidatorParams{PubKeyTypes: []string{types.ABCIPubKeyTypeBLS, types.ABCIPubKeyTypeEd25519}}
 
 	testCases := []struct {
 		name string
@@ -205,6 +219,33 @@ func TestValidateValidatorUpdates(t *testing.T) {
 			[]abci.ValidatorUpdate{{PubKey: types.TM2PB.PubKey(secpKey), Power: -100}},
 			defaultValidatorParams,
 
+			true,
+		},
+		{
+			"adding a BLS validator with its proof of possession is OK",
+
+			[]abci.ValidatorUpdate{{PubKey: types.TM2PB.PubKey(blsKey), Power: 20,
+				ProofOfPossession: blsPrivKey.ProofOfPossession()}},
+			blsValidatorParams,
+
+			false,
+		},
+		{
+			"adding a BLS validator with the proof of possession of another key results in error",
+
+			[]abci.ValidatorUpdate{{PubKey: types.TM2PB.PubKey(blsKey), Power: 20,
+				ProofOfPossession: bls.GenPrivKey().ProofOfPossession()}},
+			blsValidatorParams,
+
+			true,
+		},
+		{
+			"adding a validator with a proof of possession but no BLS key results in error",
+
+			[]abci.ValidatorUpdate{{PubKey: types.TM2PB.PubKey(pubkey2), Power: 20,
+				ProofOfPossession: blsPrivKey.ProofOfPossession()}},
+			blsValidatorParams,
+
 			true,
 		},
 	}
@@ -309,7 +350,7 @@ func TestEndBlockValidatorUpdates(t *testing.T) {
 
 	state, stateDB, _ := makeState(1, 1)
 
-	blockExec := sm.NewBlockExecutor(stateDB, log.TestingLogger(), proxyApp.Consensus(), mock.Mempool{}, sm.MockEvidencePool{})
+	blockExec := sm.NewBlockExecutor(store.NewBlockStore(dbm.NewMemDB()), stateDB, log.TestingLogger(), proxyApp.Consensus(), mock.Mempool{}, sm.MockEvidencePool{})
 
 	eventBus := types.NewEventBus()
 	err = eventBus.Start()
@@ -323,7 +364,7 @@ func TestEndBlockValidatorUpdates(t *testing.T) {
 	block := makeBlock(state, 1)
 	blockID := types.BlockID{Hash: block.Hash(), PartsHeader: block.MakePartSet(testPartSize).Header()}
 
-	pubkey := ed25519.GenPrivKey().PubKey()
+	pubkey := bls.GenPrivKey().PubKey()
 	app.ValidatorUpdates = []abci.ValidatorUpdate{
 		{PubKey: types.TM2PB.PubKey(pubkey), Power: 10},
 	}
@@ -345,7 +386,7 @@ func TestEndBlockValidatorUpdates(t *testing.T) {
 		event, ok := msg.Data().(types.EventDataValidatorSetUpdates)
 		require.True(t, ok, "Expected event of type EventDataValidatorSetUpdates, got %T", msg.Data())
 		if assert.NotEmpty(t, event.ValidatorUpdates) {
-			assert.Equal(t, pubkey, event.ValidatorUpdates[0].PubKey)
+			assert.True(t, pubkey.Equals(event.ValidatorUpdates[0].PubKey))
 			assert.EqualValues(t, 10, event.ValidatorUpdates[0].VotingPower)
 		}
 	case <-updatesSub.Cancelled():
@@ -366,7 +407,7 @@ func TestEndBlockValidatorUpdatesResultingInEmptySet(t *testing.T) {
 	defer proxyApp.Stop()
 
 	state, stateDB, _ := makeState(1, 1)
-	blockExec := sm.NewBlockExecutor(stateDB, log.TestingLogger(), proxyApp.Consensus(), mock.Mempool{}, sm.MockEvidencePool{})
+	blockExec := sm.NewBlockExecutor(store.NewBlockStore(dbm.NewMemDB()), stateDB, log.TestingLogger(), proxyApp.Consensus(), mock.Mempool{}, sm.MockEvidencePool{})
 
 	block := makeBlock(state, 1)
 	blockID := types.BlockID{Hash: block.Hash(), PartsHeader: block.MakePartSet(testPartSize).Header()}
@@ -381,3 +422,147 @@ func TestEndBlockValidatorUpdatesResultingInEmptySet(t *testing.T) {
 	assert.NotEmpty(t, state.NextValidators.Validators)
 
 }
+
+func TestCreateProposalBlockDropsLargeTxs(t *testing.T) {
+	val, _ := types.RandValidator(false, 10)
+	params := types.DefaultConsensusParams()
+	params.Block.MaxTxBytes = 8
+	state, err := sm.MakeGenesisState(&types.GenesisDoc{
+		ChainID:         chainID,
+		ConsensusModule: "tendermint",
+		ConsensusParams: params,
+		Validators:      []types.GenesisValidator{{PubKey: val.PubKey, Power: val.VotingPower}},
+	})
+	require.NoError(t, err)
+	stateDB := dbm.NewMemDB()
+	sm.SaveState(stateDB, state)
+
+	// admitted by the mempool before MaxTxBytes decreased
+	txs := types.Txs{types.Tx("12345678"), types.Tx("123456789"), types.Tx("1")}
+	blockExec := sm.NewBlockExecutor(store.NewBlockStore(dbm.NewMemDB()), stateDB, log.TestingLogger(), nil,
+		slowMempool{txs: txs}, sm.MockEvidencePool{})
+	block, _ := blockExec.CreateProposalBlock(1, state, types.NewCommit(types.BlockID{}, nil),
+		state.Validators.GetProposer().Address, time.Time{})
+	assert.Equal(t, types.Txs{txs[0], txs[2]}, block.Txs)
+	assert.NoError(t, blockExec.ValidateBlock(state, block))
+}
+
+func TestCreateProposalBlockMaxGas(t *testing.T) {
+	txGas := func(tx types.Tx) int64 { return int64(len(tx)) }
+	// the mempool reaps them all, whatever the max gas
+	txs := types.Txs{types.Tx("12345"), types.Tx("12345"), types.Tx("1")}
+	testCases := []struct {
+		maxGas int64
+		txGas  sm.TxGasFunc
+		reaped types.Txs
+	}{
+		{-1, txGas, txs},
+		{0, txGas, types.Txs{}},
+		{10, txGas, txs[:2]},
+		// stops at the first tx over the limit
+		{9, txGas, txs[:1]},
+		{11, txGas, txs},
+		// the mempool limits the gas
+		{1, nil, txs},
+	}
+	for i, tc := range testCases {
+		val, _ := types.RandValidator(false, 10)
+		params := types.DefaultConsensusParams()
+		params.Block.MaxGas = tc.maxGas
+		state, err := sm.MakeGenesisState(&types.GenesisDoc{
+			ChainID:         chainID,
+			ConsensusModule: "tendermint",
+			ConsensusParams: params,
+			Validators:      []types.GenesisValidator{{PubKey: val.PubKey, Power: val.VotingPower}},
+		})
+		require.NoError(t, err)
+		stateDB := dbm.NewMemDB()
+		sm.SaveState(stateDB, state)
+
+		blockExec := sm.NewBlockExecutor(store.NewBlockStore(dbm.NewMemDB()), stateDB, log.TestingLogger(), nil,
+			slowMempool{txs: txs}, sm.MockEvidencePool{})
+		if tc.txGas != nil {
+			blockExec.SetTxGas(tc.txGas)
+		}
+		block, _ := blockExec.CreateProposalBlock(1, state, types.NewCommit(types.BlockID{}, nil),
+			state.Validators.GetProposer().Address, time.Time{})
+		assert.Equal(t, tc.reaped, block.Txs, "#%d", i)
+		assert.NoError(t, blockExec.ValidateBlock(state, block), "#%d", i)
+	}
+}
+
+// TestEndBlockParamUpdatesLenULB ensures a consensus params update changing the
+// LenULB is rejected as a whole, without failing the block, and the app sees it
+// in the EndBlock events.
+func TestEndBlockParamUpdatesLenULB(t *testing.T) {
+	app := &testApp{}
+	cc := proxy.NewLocalClientCreator(app)
+	proxyApp := proxy.NewAppConns(cc)
+	err := proxyApp.Start()
+	require.Nil(t, err)
+	defer proxyApp.Stop()
+
+	val, _ := types.RandValidator(false, 10)
+	state, err := sm.MakeGenesisState(&types.GenesisDoc{
+		ChainID:         chainID,
+		ConsensusModule: "friday",
+		ConsensusParams: types.DefaultFridayConsensusParams(),
+		Validators:      []types.GenesisValidator{{PubKey: val.PubKey, Power: val.VotingPower}},
+	})
+	require.NoError(t, err)
+	stateDB := dbm.NewMemDB()
+	sm.SaveState(stateDB, state)
+
+	blockStore := store.NewBlockStore(dbm.NewMemDB())
+	blockExec := sm.NewBlockExecutor(blockStore, stateDB, log.TestingLogger(), proxyApp.Consensus(), mock.Mempool{}, sm.MockEvidencePool{})
+	eventBus := types.NewEventBus()
+	err = eventBus.Start()
+	require.NoError(t, err)
+	defer eventBus.Stop()
+	blockExec.SetEventBus(eventBus)
+
+	rejectedSub, err := eventBus.Subscribe(context.Background(), "TestEndBlockParamUpdatesLenULB", types.EventQueryNewBlock)
+	require.NoError(t, err)
+
+	// the other fields of the update aren't applied either
+	params := state.ConsensusParams
+	params.Block.LenULB++
+	params.Block.MaxBytes++
+	app.ParamUpdates = types.TM2PB.ConsensusParams(&params)
+
+	block := makeBlock(state, 1)
+	parts := block.MakePartSet(testPartSize)
+	blockID := types.BlockID{Hash: block.Hash(), PartsHeader: parts.Header()}
+	blockStore.SaveBlock(block, parts, new(types.Commit), 1)
+	updated, err := blockExec.ApplyFridayBlock(state, blockID, block)
+	require.NoError(t, err)
+	assert.Empty(t, updated.ScheduledConsensusParams)
+	assert.Equal(t, state.ConsensusParams, updated.ConsensusParams)
+
+	responses, err := sm.LoadABCIResponses(stateDB, 1)
+	require.NoError(t, err)
+	assert.Nil(t, responses.EndBlock.ConsensusParamUpdates)
+
+	select {
+	case msg := <-rejectedSub.Out():
+		rejected := msg.Events()[types.ConsensusParamsRejectedKey]
+		if assert.Len(t, rejected, 1) {
+			assert.Contains(t, rejected[0], "LenULB")
+		}
+	case <-rejectedSub.Cancelled():
+		t.Fatalf("rejectedSub was cancelled (reason: %v)", rejectedSub.Err())
+	case <-time.After(1 * time.Second):
+		t.Fatal("Did not receive the rejection within 1 sec.")
+	}
+
+	// an update keeping the LenULB is scheduled
+	params.Block.LenULB--
+	app.ParamUpdates = types.TM2PB.ConsensusParams(&params)
+	block = makeBlock(updated, 2)
+	blockID = types.BlockID{Hash: block.Hash(), PartsHeader: block.MakePartSet(testPartSize).Header()}
+	updated, err = blockExec.ApplyFridayBlock(updated, blockID, block)
+	require.NoError(t, err)
+	if assert.Len(t, updated.ScheduledConsensusParams, 1) {
+		assert.Equal(t, params, updated.ScheduledConsensusParams[0].Params)
+	}
+}
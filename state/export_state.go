@@ -0,0 +1,156 @@
+package state
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"time"
+
+	"github.com/pkg/errors"
+
+	dbm "github.com/tendermint/tm-db"
+
+	cmn "github.com/hdac-io/tendermint/libs/common"
+	"github.com/hdac-io/tendermint/types"
+)
+
+// StateExport is the state of a chain at a height, exported to boot a new
+// chain continuing from it, e.g. to restart a chain after a consensus failure:
+// the app hash and results hash of the height, and the validators and
+// consensus params (including the LenULB) of the next height.
+type StateExport struct {
+	ChainID         string                   `json:"chain_id"`
+	Height          int64                    `json:"height"`
+	BlockID         types.BlockID            `json:"block_id"`
+	Time            time.Time                `json:"time"`
+	ConsensusModule string                   `json:"consensus_module"`
+	ConsensusParams types.ConsensusParams    `json:"consensus_params"`
+	Validators      []types.GenesisValidator `json:"validators"`
+	AppHash         cmn.HexBytes             `json:"app_hash"`
+	LastResultsHash cmn.HexBytes             `json:"last_results_hash"`
+}
+
+// ExportState exports the state of the chain at height, which the app must
+// have committed.
+//
+// The validator updates returned by the app before the height apply to the
+// heights above it, up to LenULB heights with the friday consensus: as the
+// new chain starts with a single validator set, the export fails if one is
+// still pending.
+func ExportState(db dbm.DB, blockStore BlockStore, height int64) (*StateExport, error) {
+	state := LoadState(db)
+	if state.IsEmpty() {
+		return nil, errors.New("no state to export")
+	}
+	if height < 1 || height > state.LastBlockHeight {
+		return nil, fmt.Errorf("height %d must be between 1 and the last height of the state %d",
+			height, state.LastBlockHeight)
+	}
+	meta := blockStore.LoadBlockMeta(height)
+	if meta == nil {
+		return nil, fmt.Errorf("no block at height %d", height)
+	}
+
+	// the distance of the block carrying the app hash of a height, and of the
+	// height the validator updates of a height apply to
+	delay := int64(1)
+	if state.Version.Consensus.Module == "friday" {
+		delay = state.ConsensusParams.Block.LenULB
+	}
+
+	appHash, _ := LoadAppHash(db, height)
+	if appHash == nil {
+		// not saved by the older versions
+		if height == state.LastBlockHeight {
+			appHash = state.AppHash
+		} else if carrier := blockStore.LoadBlockMeta(height + delay); carrier != nil {
+			appHash = carrier.Header.AppHash
+		} else {
+			return nil, fmt.Errorf("no app hash at height %d", height)
+		}
+	}
+	resultsHash, err := LoadResultsHash(db, height)
+	if err != nil {
+		return nil, err
+	}
+	params, err := LoadConsensusParams(db, height+1)
+	if err != nil {
+		return nil, err
+	}
+
+	vals, err := LoadValidators(db, height+1)
+	if err != nil {
+		return nil, err
+	}
+	for h := height + 2; h <= height+1+delay; h++ {
+		nextVals, err := LoadValidators(db, h)
+		if err != nil {
+			return nil, err
+		}
+		if !bytes.Equal(nextVals.Hash(), vals.Hash()) {
+			return nil, fmt.Errorf("the validators change at height %d: export a height without "+
+				"a pending validator update", h)
+		}
+	}
+	genVals := make([]types.GenesisValidator, len(vals.Validators))
+	for i, val := range vals.Validators {
+		genVals[i] = types.GenesisValidator{Address: val.Address, PubKey: val.PubKey, Power: val.VotingPower,
+			ProofOfPossession: val.ProofOfPossession}
+	}
+
+	return &StateExport{
+		ChainID:         state.ChainID,
+		Height:          height,
+		BlockID:         meta.BlockID,
+		Time:            meta.Header.Time,
+		ConsensusModule: state.Version.Consensus.Module,
+		ConsensusParams: params,
+		Validators:      genVals,
+		AppHash:         appHash,
+		LastResultsHash: resultsHash,
+	}, nil
+}
+
+// SaveAs saves the StateExport as a JSON file.
+func (export *StateExport) SaveAs(file string) error {
+	bz, err := cdc.MarshalJSONIndent(export, "", "  ")
+	if err != nil {
+		return err
+	}
+	return cmn.WriteFile(file, bz, 0644)
+}
+
+// StateExportFromFile reads a StateExport saved by SaveAs.
+func StateExportFromFile(file string) (*StateExport, error) {
+	bz, err := ioutil.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+	export := new(StateExport)
+	if err := cdc.UnmarshalJSON(bz, export); err != nil {
+		return nil, errors.Wrapf(err, "could not read the state export %s", file)
+	}
+	return export, nil
+}
+
+// GenesisDoc returns the genesis of a new chain with chainID, continuing from
+// the exported state. Its genesis time is the time of the exported block, so
+// all the validators make the same genesis from the same export.
+func (export *StateExport) GenesisDoc(chainID string) (*types.GenesisDoc, error) {
+	if chainID == export.ChainID {
+		return nil, fmt.Errorf("the new chain must have another chain ID than %s", export.ChainID)
+	}
+	params := export.ConsensusParams
+	genDoc := &types.GenesisDoc{
+		GenesisTime:     export.Time,
+		ChainID:         chainID,
+		ConsensusModule: export.ConsensusModule,
+		ConsensusParams: &params,
+		Validators:      export.Validators,
+		AppHash:         export.AppHash,
+	}
+	if err := genDoc.ValidateAndComplete(); err != nil {
+		return nil, err
+	}
+	return genDoc, nil
+}
@@ -0,0 +1,104 @@
+package state_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	abci "github.com/hdac-io/tendermint/abci/types"
+	sm "github.com/hdac-io/tendermint/state"
+	"github.com/hdac-io/tendermint/store"
+	"github.com/hdac-io/tendermint/types"
+	dbm "github.com/tendermint/tm-db"
+)
+
+func TestExportState(t *testing.T) {
+	genVals := make([]types.GenesisValidator, 4)
+	for i := range genVals {
+		val, _ := types.RandValidator(false, 10)
+		genVals[i] = types.GenesisValidator{PubKey: val.PubKey, Power: val.VotingPower}
+	}
+	state, err := sm.MakeGenesisState(&types.GenesisDoc{
+		ChainID:         chainID,
+		ConsensusModule: "tendermint",
+		Validators:      genVals,
+	})
+	require.NoError(t, err)
+	stateDB := dbm.NewMemDB()
+	sm.SaveState(stateDB, state)
+	blockStore := store.NewBlockStore(dbm.NewMemDB())
+
+	// a chain of 5 blocks, the last one changing the power of a validator
+	var blocks []*types.Block
+	for height := int64(1); height <= 5; height++ {
+		block := makeBlock(state, height)
+		block.Time = time.Unix(height, 0).UTC()
+		blocks = append(blocks, block)
+		parts := block.MakePartSet(types.BlockPartSizeBytes)
+		blockID := types.BlockID{Hash: block.Hash(), PartsHeader: parts.Header()}
+		blockStore.SaveBlock(block, parts, new(types.Commit), 1)
+
+		abciResponses := &sm.ABCIResponses{
+			DeliverTx:  []*abci.ResponseDeliverTx{{Data: []byte{byte(height)}}},
+			BeginBlock: &abci.ResponseBeginBlock{},
+			EndBlock:   &abci.ResponseEndBlock{},
+		}
+		var updates []*types.Validator
+		if height == 5 {
+			val := state.NextValidators.Validators[0]
+			updates = []*types.Validator{types.NewValidator(val.PubKey, val.VotingPower+10)}
+		}
+		sm.SaveABCIResponses(stateDB, height, abciResponses)
+		state, err = sm.UpdateState(state, blockID, &block.Header, abciResponses, updates)
+		require.NoError(t, err)
+		state.AppHash = []byte{byte(height)}
+		sm.SaveState(stateDB, state)
+	}
+
+	_, err = sm.ExportState(stateDB, blockStore, 5)
+	assert.Error(t, err, "the validator update of the last block is pending")
+	_, err = sm.ExportState(stateDB, blockStore, 6)
+	assert.Error(t, err, "above the last height")
+
+	export, err := sm.ExportState(stateDB, blockStore, 3)
+	require.NoError(t, err)
+	assert.Equal(t, chainID, export.ChainID)
+	assert.EqualValues(t, 3, export.Height)
+	assert.Equal(t, blocks[2].Hash(), export.BlockID.Hash)
+	assert.Equal(t, blocks[2].Time, export.Time)
+	assert.Equal(t, "tendermint", export.ConsensusModule)
+	assert.Equal(t, state.ConsensusParams, export.ConsensusParams)
+	assert.EqualValues(t, []byte{3}, export.AppHash)
+	resultsHash, err := sm.LoadResultsHash(stateDB, 3)
+	require.NoError(t, err)
+	assert.EqualValues(t, resultsHash, export.LastResultsHash)
+	require.Len(t, export.Validators, len(genVals))
+
+	dir, err := ioutil.TempDir("", "export_state")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	file := filepath.Join(dir, "state.json")
+	require.NoError(t, export.SaveAs(file))
+	loaded, err := sm.StateExportFromFile(file)
+	require.NoError(t, err)
+	assert.Equal(t, export, loaded)
+
+	// the genesis of the new chain
+	_, err = export.GenesisDoc(chainID)
+	assert.Error(t, err, "same chain ID")
+	genDoc, err := export.GenesisDoc("restarted-chain")
+	require.NoError(t, err)
+	genState, err := sm.MakeGenesisState(genDoc)
+	require.NoError(t, err)
+	assert.Equal(t, "restarted-chain", genState.ChainID)
+	assert.Equal(t, export.Time, genState.LastBlockTime)
+	assert.EqualValues(t, []byte{3}, genState.AppHash)
+	vals, err := sm.LoadValidators(stateDB, 4)
+	require.NoError(t, err)
+	assert.Equal(t, vals.Hash(), genState.Validators.Hash())
+}
@@ -60,3 +60,9 @@ func SaveConsensusParamsInfo(db dbm.DB, nextHeight, changeHeight int64, params t
 func SaveValidatorsInfo(db dbm.DB, height, lastHeightChanged int64, valSet *types.ValidatorSet) {
 	saveValidatorsInfo(db, height, lastHeightChanged, valSet)
 }
+
+// DeliverTxDeps is an alias for the private deliverTxDeps function in
+// deliver_txs.go, exported exclusively and explicitly for testing.
+func DeliverTxDeps(txs types.Txs, conflictKeys ConflictKeysFunc) [][]int {
+	return deliverTxDeps(txs, conflictKeys)
+}
@@ -106,9 +106,10 @@ func makeState(nVals, height int) (sm.State, dbm.DB, map[string]types.PrivValida
 		privVals[valAddr.String()] = types.NewMockPVWithParams(pk, false, false)
 	}
 	s, _ := sm.MakeGenesisState(&types.GenesisDoc{
-		ChainID:    chainID,
-		Validators: vals,
-		AppHash:    nil,
+		ChainID:         chainID,
+		ConsensusModule: "tendermint",
+		Validators:      vals,
+		AppHash:         nil,
 	})
 
 	stateDB := dbm.NewMemDB()
@@ -205,8 +206,9 @@ func makeHeaderPartsResponsesParams(state sm.State, params types.ConsensusParams
 func randomGenesisDoc() *types.GenesisDoc {
 	pubkey := ed25519.GenPrivKey().PubKey()
 	return &types.GenesisDoc{
-		GenesisTime: tmtime.Now(),
-		ChainID:     "abc",
+		GenesisTime:     tmtime.Now(),
+		ChainID:         "abc",
+		ConsensusModule: "tendermint",
 		Validators: []types.GenesisValidator{
 			{
 				Address: pubkey.Address(),
@@ -225,8 +227,11 @@ type testApp struct {
 	abci.BaseApplication
 
 	CommitVotes         []abci.VoteInfo
+	CommitHeight        int64
+	CommitSigners       []byte
 	ByzantineValidators []abci.Evidence
 	ValidatorUpdates    []abci.ValidatorUpdate
+	ParamUpdates        *abci.ConsensusParams
 }
 
 var _ abci.Application = (*testApp)(nil)
@@ -237,16 +242,18 @@ func (app *testApp) Info(req abci.RequestInfo) (resInfo abci.ResponseInfo) {
 
 func (app *testApp) BeginBlock(req abci.RequestBeginBlock) abci.ResponseBeginBlock {
 	app.CommitVotes = req.LastCommitInfo.Votes
+	app.CommitHeight = req.LastCommitInfo.Height
+	app.CommitSigners = req.LastCommitInfo.Signers
 	app.ByzantineValidators = req.ByzantineValidators
 	return abci.ResponseBeginBlock{}
 }
 
 func (app *testApp) EndBlock(req abci.RequestEndBlock) abci.ResponseEndBlock {
-	return abci.ResponseEndBlock{ValidatorUpdates: app.ValidatorUpdates}
+	return abci.ResponseEndBlock{ValidatorUpdates: app.ValidatorUpdates, ConsensusParamUpdates: app.ParamUpdates}
 }
 
 func (app *testApp) DeliverTx(req abci.RequestDeliverTx) abci.ResponseDeliverTx {
-	return abci.ResponseDeliverTx{Events: []abci.Event{}}
+	return abci.ResponseDeliverTx{Events: []abci.Event{}, Index: req.Index}
 }
 
 func (app *testApp) CheckTx(req abci.RequestCheckTx) abci.ResponseCheckTx {
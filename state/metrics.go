@@ -17,6 +17,19 @@ const (
 type Metrics struct {
 	// Time between BeginBlock and EndBlock.
 	BlockProcessingTime metrics.Histogram
+
+	// Number of the loads of the validators, app hash or results hash of a
+	// height served from memory, by cache.
+	CacheHits metrics.Counter
+	// Number of the loads of the validators, app hash or results hash of a
+	// height read from the DB, by cache.
+	CacheMisses metrics.Counter
+
+	// Time to prepare the txs of a proposal block of the node.
+	ProposalPreparationTime metrics.Histogram
+	// Number of the proposal blocks of the node whose txs were not prepared
+	// within the budget, by step ("reap" or "app").
+	ProposalBudgetExceeded metrics.Counter
 }
 
 // PrometheusMetrics returns Metrics build using Prometheus client library.
@@ -35,6 +48,31 @@ func PrometheusMetrics(namespace string, labelsAndValues ...string) *Metrics {
 			Help:      "Time between BeginBlock and EndBlock in ms.",
 			Buckets:   stdprometheus.LinearBuckets(1, 10, 10),
 		}, labels).With(labelsAndValues...),
+		CacheHits: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "cache_hits",
+			Help:      "Number of the loads of the validators, app hash or results hash served from memory.",
+		}, append(labels, "cache")).With(labelsAndValues...),
+		CacheMisses: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "cache_misses",
+			Help:      "Number of the loads of the validators, app hash or results hash read from the DB.",
+		}, append(labels, "cache")).With(labelsAndValues...),
+		ProposalPreparationTime: prometheus.NewHistogramFrom(stdprometheus.HistogramOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "proposal_preparation_time",
+			Help:      "Time to prepare the txs of a proposal block in ms.",
+			Buckets:   stdprometheus.ExponentialBuckets(1, 2, 12),
+		}, labels).With(labelsAndValues...),
+		ProposalBudgetExceeded: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "proposal_budget_exceeded",
+			Help:      "Number of the proposal blocks whose txs were not prepared within the budget.",
+		}, append(labels, "step")).With(labelsAndValues...),
 	}
 }
 
@@ -42,5 +80,10 @@ func PrometheusMetrics(namespace string, labelsAndValues ...string) *Metrics {
 func NopMetrics() *Metrics {
 	return &Metrics{
 		BlockProcessingTime: discard.NewHistogram(),
+		CacheHits:           discard.NewCounter(),
+		CacheMisses:         discard.NewCounter(),
+
+		ProposalPreparationTime: discard.NewHistogram(),
+		ProposalBudgetExceeded:  discard.NewCounter(),
 	}
 }
@@ -0,0 +1,101 @@
+package state
+
+import (
+	"fmt"
+
+	"github.com/pkg/errors"
+
+	dbm "github.com/tendermint/tm-db"
+
+	"github.com/hdac-io/tendermint/types"
+)
+
+// MigrateToFriday converts the state of a chain run by the tendermint
+// consensus up to its last block into the state of the friday consensus with
+// lenULB, which runs the heights above it: the transition height is
+// LastBlockHeight+1. The blocks below it stay tendermint blocks; as the friday
+// blocks of the first lenULB heights refer to them for their LastCommit,
+// AppHash and LastResultsHash, their app hashes are saved if missing, and the
+// validators of the heights up to LastBlockHeight+1+lenULB are saved, as the
+// friday consensus delays the validator updates by lenULB heights instead of 2.
+// The commits of the block store are saved at the height they commit with
+// both consensus modules, so they are kept as they are.
+//
+// The app must have committed the last block, and the chain must have at
+// least lenULB blocks. The converted state is saved and returned.
+func MigrateToFriday(db dbm.DB, blockStore BlockStore, lenULB int64) (State, error) {
+	state := LoadState(db)
+	if state.IsEmpty() {
+		return state, errors.New("no state to migrate")
+	}
+	if state.Version.Consensus.Module == "friday" {
+		return state, errors.New("the state is already a friday state")
+	}
+	lastHeight := state.LastBlockHeight
+	if lenULB < 1 {
+		return state, fmt.Errorf("LenULB must be at least 1. Got %d", lenULB)
+	}
+	if lastHeight < lenULB {
+		return state, fmt.Errorf("the chain must have at least LenULB (%d) blocks to migrate. Got %d",
+			lenULB, lastHeight)
+	}
+	if storeHeight := blockStore.Height(); storeHeight != lastHeight {
+		return state, fmt.Errorf("the block store height %d differs from the state height %d: start the node "+
+			"once to replay the last block before migrating", storeHeight, lastHeight)
+	}
+
+	params := state.ConsensusParams
+	params.Block.LenULB = lenULB
+	if err := params.Validate(); err != nil {
+		return state, err
+	}
+
+	// the tendermint blocks the first friday heights refer to
+	for height := lastHeight - lenULB + 1; height <= lastHeight; height++ {
+		if _, err := LoadABCIResponses(db, height); err != nil {
+			return state, err
+		}
+		if appHash, _ := LoadAppHash(db, height); appHash != nil {
+			continue
+		}
+		appHash := state.AppHash
+		if height < lastHeight {
+			meta := blockStore.LoadBlockMeta(height + 1)
+			if meta == nil {
+				return state, fmt.Errorf("no block at height %d", height+1)
+			}
+			appHash = meta.Header.AppHash
+		}
+		saveAppHash(db, height, appHash)
+	}
+
+	// validatorsAt returns the validators of height, from LastBlockHeight to
+	// the heights of the tendermint NextValidators
+	validatorsAt := func(height int64) *types.ValidatorSet {
+		switch {
+		case height <= lastHeight:
+			return state.LastValidators.Copy()
+		case height == lastHeight+1:
+			return state.Validators.Copy()
+		case height == lastHeight+2:
+			return state.NextValidators.Copy()
+		default:
+			return state.NextValidators.CopyIncrementProposerPriority(int(height - lastHeight - 2))
+		}
+	}
+	// the next validators of the state are saved with it
+	for height := lastHeight + 3; height <= lastHeight+lenULB; height++ {
+		saveValidatorsInfo(db, height, state.LastHeightValidatorsChanged, validatorsAt(height))
+	}
+
+	fridayState := state.Copy()
+	fridayState.Version.Consensus.Module = "friday"
+	fridayState.LastValidators = validatorsAt(lastHeight + lenULB - 1)
+	fridayState.Validators = validatorsAt(lastHeight + lenULB)
+	fridayState.NextValidators = validatorsAt(lastHeight + lenULB + 1)
+	fridayState.ConsensusParams = params
+	fridayState.LastHeightConsensusParamsChanged = lastHeight + 1
+	fridayState.ScheduledConsensusParams = nil
+	SaveState(db, fridayState)
+	return fridayState, nil
+}
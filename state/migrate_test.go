@@ -0,0 +1,92 @@
+package state_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	abci "github.com/hdac-io/tendermint/abci/types"
+	sm "github.com/hdac-io/tendermint/state"
+	"github.com/hdac-io/tendermint/store"
+	"github.com/hdac-io/tendermint/types"
+	dbm "github.com/tendermint/tm-db"
+)
+
+func TestMigrateToFriday(t *testing.T) {
+	genVals := make([]types.GenesisValidator, 4)
+	for i := range genVals {
+		val, _ := types.RandValidator(false, 10)
+		genVals[i] = types.GenesisValidator{PubKey: val.PubKey, Power: val.VotingPower}
+	}
+	state, err := sm.MakeGenesisState(&types.GenesisDoc{
+		ChainID:         chainID,
+		ConsensusModule: "tendermint",
+		Validators:      genVals,
+	})
+	require.NoError(t, err)
+	stateDB := dbm.NewMemDB()
+	sm.SaveState(stateDB, state)
+	blockStore := store.NewBlockStore(dbm.NewMemDB())
+
+	// a tendermint chain of 5 blocks, the last one changing the power of a
+	// validator
+	for height := int64(1); height <= 5; height++ {
+		block := makeBlock(state, height)
+		parts := block.MakePartSet(types.BlockPartSizeBytes)
+		blockID := types.BlockID{Hash: block.Hash(), PartsHeader: parts.Header()}
+		blockStore.SaveBlock(block, parts, new(types.Commit), 1)
+
+		abciResponses := &sm.ABCIResponses{
+			BeginBlock: &abci.ResponseBeginBlock{},
+			EndBlock:   &abci.ResponseEndBlock{},
+		}
+		var updates []*types.Validator
+		if height == 5 {
+			val := state.NextValidators.Validators[0]
+			updates = []*types.Validator{types.NewValidator(val.PubKey, val.VotingPower+10)}
+		}
+		sm.SaveABCIResponses(stateDB, height, abciResponses)
+		state, err = sm.UpdateState(state, blockID, &block.Header, abciResponses, updates)
+		require.NoError(t, err)
+		state.AppHash = []byte{byte(height)}
+		sm.SaveState(stateDB, state)
+	}
+	require.EqualValues(t, 7, state.LastHeightValidatorsChanged)
+
+	_, err = sm.MigrateToFriday(stateDB, blockStore, 6)
+	assert.Error(t, err, "the chain is shorter than LenULB")
+
+	const lenULB = 3
+	fridayState, err := sm.MigrateToFriday(stateDB, blockStore, lenULB)
+	require.NoError(t, err)
+	assert.Equal(t, "friday", fridayState.Version.Consensus.Module)
+	assert.EqualValues(t, lenULB, fridayState.ConsensusParams.Block.LenULB)
+	assert.Equal(t, fridayState.Bytes(), sm.LoadState(stateDB).Bytes())
+
+	params, err := sm.LoadConsensusParams(stateDB, 6)
+	require.NoError(t, err)
+	assert.EqualValues(t, lenULB, params.Block.LenULB)
+	appHash, err := sm.LoadAppHash(stateDB, 5)
+	require.NoError(t, err)
+	assert.Equal(t, []byte{5}, appHash)
+
+	// the validators of the next heights: the update of the last block
+	// applies from height 7, as with the tendermint consensus
+	expected := map[int64]*types.ValidatorSet{
+		6: state.Validators,
+		7: state.NextValidators,
+		8: state.NextValidators.CopyIncrementProposerPriority(1),
+		9: state.NextValidators.CopyIncrementProposerPriority(2),
+	}
+	for height, vals := range expected {
+		loaded, err := sm.LoadValidators(stateDB, height)
+		require.NoError(t, err)
+		assert.Equal(t, vals.Hash(), loaded.Hash(), "height %d", height)
+		assert.Equal(t, vals.GetProposer().Address, loaded.GetProposer().Address, "height %d", height)
+	}
+	assert.Equal(t, expected[9].Hash(), fridayState.NextValidators.Hash())
+
+	_, err = sm.MigrateToFriday(stateDB, blockStore, lenULB)
+	assert.Error(t, err, "the state is migrated already")
+}
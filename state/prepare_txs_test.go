@@ -0,0 +1,96 @@
+package state_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hdac-io/tendermint/libs/log"
+	"github.com/hdac-io/tendermint/mock"
+	sm "github.com/hdac-io/tendermint/state"
+	"github.com/hdac-io/tendermint/store"
+	"github.com/hdac-io/tendermint/types"
+	dbm "github.com/tendermint/tm-db"
+)
+
+// slowMempool reaps txs after delay.
+type slowMempool struct {
+	mock.Mempool
+	txs   types.Txs
+	delay time.Duration
+}
+
+func (mem slowMempool) ReapMaxBytesMaxGas(maxBytes, maxGas int64) types.Txs {
+	time.Sleep(mem.delay)
+	return mem.txs
+}
+
+type txsPreparerFunc func(deadline time.Time, txs types.Txs) types.Txs
+
+func (f txsPreparerFunc) PrepareProposalTxs(deadline time.Time, txs types.Txs) types.Txs {
+	return f(deadline, txs)
+}
+
+func TestCreateProposalBlockDeadline(t *testing.T) {
+	val, _ := types.RandValidator(false, 10)
+	state, err := sm.MakeGenesisState(&types.GenesisDoc{
+		ChainID:         chainID,
+		ConsensusModule: "tendermint",
+		Validators:      []types.GenesisValidator{{PubKey: val.PubKey, Power: val.VotingPower}},
+	})
+	require.NoError(t, err)
+	stateDB := dbm.NewMemDB()
+	sm.SaveState(stateDB, state)
+	commit := types.NewCommit(types.BlockID{}, nil)
+	proposerAddr := state.Validators.GetProposer().Address
+	txs := types.Txs{types.Tx("a"), types.Tx("b"), types.Tx("c")}
+
+	reverse := txsPreparerFunc(func(deadline time.Time, txs types.Txs) types.Txs {
+		reversed := make(types.Txs, len(txs))
+		for i, tx := range txs {
+			reversed[len(txs)-1-i] = tx
+		}
+		return reversed
+	})
+	late := txsPreparerFunc(func(deadline time.Time, txs types.Txs) types.Txs {
+		time.Sleep(time.Until(deadline) + 100*time.Millisecond)
+		return nil
+	})
+	grow := txsPreparerFunc(func(deadline time.Time, txs types.Txs) types.Txs {
+		return append(txs, types.Tx("d"))
+	})
+
+	testCases := []struct {
+		name      string
+		reapDelay time.Duration
+		preparer  sm.ProposalTxsPreparer
+		budget    time.Duration // 0 for no deadline
+		expected  types.Txs
+	}{
+		{"reaped", 0, nil, time.Hour, txs},
+		{"prepared", 0, reverse, time.Hour, types.Txs{txs[2], txs[1], txs[0]}},
+		{"no deadline", 50 * time.Millisecond, reverse, 0, types.Txs{txs[2], txs[1], txs[0]}},
+		{"late app", 0, late, 50 * time.Millisecond, txs},
+		{"app adding txs", 0, grow, time.Hour, txs},
+		{"late reap", time.Second, reverse, 50 * time.Millisecond, nil},
+	}
+	for _, tc := range testCases {
+		blockExec := sm.NewBlockExecutor(store.NewBlockStore(dbm.NewMemDB()), stateDB, log.TestingLogger(), nil,
+			slowMempool{txs: txs, delay: tc.reapDelay}, sm.MockEvidencePool{})
+		if tc.preparer != nil {
+			blockExec.SetProposalTxsPreparer(tc.preparer)
+		}
+
+		var deadline time.Time
+		if tc.budget > 0 {
+			deadline = time.Now().Add(tc.budget)
+		}
+		block, _ := blockExec.CreateProposalBlock(1, state, commit, proposerAddr, deadline)
+		assert.Equal(t, tc.expected, block.Txs, tc.name)
+		if tc.budget > 0 {
+			assert.True(t, time.Now().Before(deadline.Add(20*time.Millisecond)), "%s: past the deadline", tc.name)
+		}
+	}
+}
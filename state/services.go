@@ -1,6 +1,8 @@
 package state
 
 import (
+	"time"
+
 	"github.com/hdac-io/tendermint/types"
 )
 
@@ -13,23 +15,62 @@ import (
 // blockstore
 
 // BlockStoreRPC is the block store interface used by the RPC.
+//
+// The Load methods return nil if nothing is stored for the given height,
+// and panic if the stored data can't be decoded (i.e. it's corrupted).
 type BlockStoreRPC interface {
+	// Height returns the last contiguous height saved.
 	Height() int64
 
 	LoadBlockMeta(height int64) *types.BlockMeta
 	LoadBlock(height int64) *types.Block
 	LoadBlockPart(height int64, index int) *types.Part
 
+	// LoadBlockCommit returns the commit for height, which is only saved
+	// with the block at height+blockCommitLength (see SaveBlock).
 	LoadBlockCommit(height int64) *types.Commit
+	// LoadSeenCommit returns the commit seen locally when height was committed.
 	LoadSeenCommit(height int64) *types.Commit
 }
 
-// BlockStore defines the BlockStore interface used by the ConsensusState.
+// BlockStore defines the BlockStore interface used by the ConsensusState,
+// the blockchain reactors and the node. store.BlockStore is the default
+// implementation, store.ArchiveBlockStore keeps the old blocks in an object storage.
 type BlockStore interface {
 	BlockStoreRPC
+
+	// SaveBlock saves the block, its parts and seenCommit at block.Height,
+	// which must be Height()+1, and block.LastCommit at
+	// block.Height-blockCommitLength. blockCommitLength is the friday LenULB
+	// (the number of heights in flight), or 1 for the tendermint consensus.
 	SaveBlock(block *types.Block, blockParts *types.PartSet, seenCommit *types.Commit, blockCommitLength int64)
 }
 
+//-----------------------------------------------------------------------------------------------------
+// tx puller
+
+// TxPuller pulls the txs of a proposal block from other nodes, when the
+// mempool has none, e.g. for the validators running the validator_local
+// mempool mode. mempool.Reactor is the default implementation.
+type TxPuller interface {
+	// PullTxs returns txs up to maxBytes and maxGas which passed CheckTx. It
+	// must not block the proposal, e.g. by returning the txs pulled in the
+	// background, or none.
+	PullTxs(maxBytes, maxGas int64) types.Txs
+}
+
+//-----------------------------------------------------------------------------------------------------
+// proposal txs preparer
+
+// ProposalTxsPreparer prepares the txs reaped for a proposal block of the
+// node, e.g. an app reordering them by priority.
+type ProposalTxsPreparer interface {
+	// PrepareProposalTxs returns the txs to propose, a subset of txs in any
+	// order, before deadline: the txs are proposed as reaped if it returns
+	// later, or if it returns more bytes than txs.
+	PrepareProposalTxs(deadline time.Time, txs types.Txs) types.Txs
+}
+
 //-----------------------------------------------------------------------------------------------------
 // evidence pool
 
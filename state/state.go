@@ -81,6 +81,19 @@ type State struct {
 
 	// the latest AppHash we've received from calling abci.Commit()
 	AppHash []byte
+
+	// Changes of the consensus parameters returned by EndBlock with the friday
+	// consensus, ordered by height. A change applies LenULB heights after the
+	// block which returned it, as the heights in between may already be
+	// progressing with the previous parameters.
+	ScheduledConsensusParams []ConsensusParamsChange
+}
+
+// ConsensusParamsChange is a change of the consensus parameters, which applies
+// from Height.
+type ConsensusParamsChange struct {
+	Height int64
+	Params types.ConsensusParams
 }
 
 // Copy makes a copy of the State for mutating.
@@ -105,7 +118,29 @@ func (state State) Copy() State {
 		AppHash: state.AppHash,
 
 		LastResultsHash: state.LastResultsHash,
+
+		ScheduledConsensusParams: state.ScheduledConsensusParams,
+	}
+}
+
+// ConsensusParamsAt returns the consensus parameters of the given height,
+// which must be at most LastBlockHeight + LenULB: the changes returned by the
+// next blocks apply above it.
+func (state State) ConsensusParamsAt(height int64) types.ConsensusParams {
+	params := state.ConsensusParams
+	for _, change := range state.ScheduledConsensusParams {
+		if change.Height > height {
+			break
+		}
+		params = change.Params
 	}
+	return params
+}
+
+// AggregatesLastCommits returns true if the friday blocks of the chain can
+// carry the QuorumCert of the height LenULB below them as their LastCommit.
+func (state State) AggregatesLastCommits() bool {
+	return state.Version.Consensus.Block >= version.AggregateCommitBlockProtocol
 }
 
 // Equals returns true if the States are identical.
@@ -186,13 +221,13 @@ func (state State) MakeBlockFromArgs(
 	} else {
 		timestamp = MedianTime(ulbCommit, ulbValidators)
 	}
-	//TODO : fix state.Validators, .NextValidators, .ConsensusParams -> getting from persistant db
+	params := state.ConsensusParamsAt(height)
 	// Fill rest of header with state data.
 	block.Header.Populate(
 		state.Version.Consensus, state.ChainID,
 		timestamp, prevBlockID, prevBlockTotalTxs+block.NumTxs,
 		validatorsHash, ulbNextValidatorsHash,
-		state.ConsensusParams.Hash(), appHash, resultsHash,
+		params.Hash(), appHash, resultsHash,
 		proposerAddress,
 	)
 
@@ -262,6 +297,7 @@ func MakeGenesisState(genDoc *types.GenesisDoc) (State, error) {
 		validators := make([]*types.Validator, len(genDoc.Validators))
 		for i, val := range genDoc.Validators {
 			validators[i] = types.NewValidator(val.PubKey, val.Power)
+			validators[i].ProofOfPossession = val.ProofOfPossession
 		}
 		validatorSet = types.NewValidatorSet(validators)
 		nextValidatorSet = types.NewValidatorSet(validators).CopyIncrementProposerPriority(1)
@@ -2,7 +2,6 @@ package state
 
 import (
 	"fmt"
-	"sync"
 
 	abci "github.com/hdac-io/tendermint/abci/types"
 	cmn "github.com/hdac-io/tendermint/libs/common"
@@ -16,7 +15,6 @@ const (
 	// https://github.com/tendermint/tendermint/pull/3438
 	// 100000 results in ~ 100ms to get 100 validators (see BenchmarkLoadValidators)
 	valSetCheckpointInterval = 100000
-	valSetCacheSize          = 4
 )
 
 //------------------------------------------------------------------------
@@ -37,6 +35,10 @@ func calcAppHashKey(height int64) []byte {
 	return []byte(fmt.Sprintf("appHashKey:%v", height))
 }
 
+func calcQuorumCertKey(height int64) []byte {
+	return []byte(fmt.Sprintf("quorumCertKey:%v", height))
+}
+
 // LoadStateFromDBOrGenesisFile loads the most recent state from the database,
 // or creates a new one from the given genesisFilePath and persists the result
 // to the database.
@@ -139,7 +141,8 @@ func saveFridayState(db dbm.DB, state State, key []byte) {
 	// Save next validators.
 	saveValidatorsInfo(db, nextHeight+state.ConsensusParams.Block.LenULB, state.LastHeightValidatorsChanged, state.NextValidators)
 	// Save next consensus params.
-	// TODO: change delay distance to after ULB distance
+	// NOTE: the params changes are delayed by the ULB distance in the
+	// ScheduledConsensusParams, so the params of the next height are final.
 	saveConsensusParamsInfo(db, nextHeight, state.LastHeightConsensusParamsChanged, state.ConsensusParams)
 	// Save current app hash
 	saveAppHash(db, state.LastBlockHeight, state.AppHash)
@@ -180,6 +183,21 @@ func (arz *ABCIResponses) ResultsHash() []byte {
 	return results.Hash()
 }
 
+// LoadResultsHash returns the ResultsHash of the ABCIResponses for the given
+// height, without loading them again if it was loaded recently.
+func LoadResultsHash(db dbm.DB, height int64) ([]byte, error) {
+	if cached, ok := resultsHashCache.Get(db, height); ok {
+		return cached.([]byte), nil
+	}
+	abciResponses, err := LoadABCIResponses(db, height)
+	if err != nil {
+		return nil, err
+	}
+	resultsHash := abciResponses.ResultsHash()
+	resultsHashCache.Set(db, height, resultsHash)
+	return resultsHash, nil
+}
+
 // LoadABCIResponses loads the ABCIResponses for the given height from the database.
 // This is useful for recovering from crashes where we called app.Commit and before we called
 // s.Save(). It can also be used to produce Merkle proofs of the result of txs.
@@ -206,6 +224,8 @@ func LoadABCIResponses(db dbm.DB, height int64) (*ABCIResponses, error) {
 // Responses are indexed by height so they can also be loaded later to produce Merkle proofs.
 func saveABCIResponses(db dbm.DB, height int64, abciResponses *ABCIResponses) {
 	db.SetSync(calcABCIResponsesKey(height), abciResponses.Bytes())
+	// the results hash goes in the header of a later height
+	resultsHashCache.Set(db, height, abciResponses.ResultsHash())
 }
 
 //-----------------------------------------------------------------------------
@@ -221,13 +241,13 @@ func (valInfo *ValidatorsInfo) Bytes() []byte {
 	return cdc.MustMarshalBinaryBare(valInfo)
 }
 
-var cachedValidators sync.Map
-
 // LoadValidators loads the ValidatorSet for a given height.
 // Returns ErrNoValSetForHeight if the validator set can't be found for this height.
+// The returned ValidatorSet is a copy, which the caller may mutate (e.g. with
+// IncrementProposerPriority) without changing the cached one.
 func LoadValidators(db dbm.DB, height int64) (*types.ValidatorSet, error) {
-	if cached, exist := cachedValidators.Load(height); exist {
-		return cached.(*ValidatorsInfo).ValidatorSet, nil
+	if cached, ok := validatorsCache.Get(db, height); ok {
+		return cached.(*types.ValidatorSet).Copy(), nil
 	}
 
 	valInfo := loadValidatorsInfo(db, height)
@@ -258,10 +278,9 @@ func LoadValidators(db dbm.DB, height int64) (*types.ValidatorSet, error) {
 		valInfo = valInfo2
 	}
 
-	cachedValidators.Store(height, valInfo)
-	cachedValidators.Delete(height - valSetCacheSize)
+	validatorsCache.Set(db, height, valInfo.ValidatorSet)
 
-	return valInfo.ValidatorSet, nil
+	return valInfo.ValidatorSet.Copy(), nil
 }
 
 func lastStoredHeightFor(height, lastHeightChanged int64) int64 {
@@ -306,6 +325,11 @@ func saveValidatorsInfo(db dbm.DB, height, lastHeightChanged int64, valSet *type
 		valInfo.ValidatorSet = valSet
 	}
 	db.Set(calcValidatorsKey(height), valInfo.Bytes())
+	// the validators of the heights above may be loaded from this one
+	validatorsCache.RemoveFrom(db, height)
+	if valInfo.ValidatorSet != nil {
+		validatorsCache.Set(db, height, valSet.Copy())
+	}
 }
 
 //-----------------------------------------------------------------------------
@@ -383,11 +407,45 @@ func saveConsensusParamsInfo(db dbm.DB, nextHeight, changeHeight int64, params t
 // saveAppHash persists the app result hash.
 func saveAppHash(db dbm.DB, height int64, appHash []byte) {
 	db.SetSync(calcAppHashKey(height), appHash)
+	appHashCache.Set(db, height, appHash)
 }
 
 // LoadAppHash for save the db, get from CreateProposalBlcok
 // it's useful seperate to using state into block making logic
 func LoadAppHash(db dbm.DB, height int64) ([]byte, error) {
+	if cached, ok := appHashCache.Get(db, height); ok {
+		return cached.([]byte), nil
+	}
 	appHash := db.Get(calcAppHashKey(height))
+	if appHash != nil {
+		// not saved yet otherwise
+		appHashCache.Set(db, height, appHash)
+	}
 	return appHash, nil
 }
+
+//-----------------------------------------------------------------------------
+
+// SaveQuorumCert persists the QuorumCert of a finalized height.
+func SaveQuorumCert(db dbm.DB, qc *types.QuorumCert) {
+	db.SetSync(calcQuorumCertKey(qc.Height), cdc.MustMarshalBinaryBare(qc))
+}
+
+// LoadQuorumCert loads the QuorumCert for the given height.
+// Returns ErrNoQuorumCertForHeight if it wasn't saved, e.g. the height was
+// finalized before the node started to save them.
+func LoadQuorumCert(db dbm.DB, height int64) (*types.QuorumCert, error) {
+	buf := db.Get(calcQuorumCertKey(height))
+	if len(buf) == 0 {
+		return nil, ErrNoQuorumCertForHeight{height}
+	}
+
+	qc := new(types.QuorumCert)
+	err := cdc.UnmarshalBinaryBare(buf, qc)
+	if err != nil {
+		// DATA HAS BEEN CORRUPTED OR THE SPEC HAS CHANGED
+		cmn.Exit(fmt.Sprintf(`LoadQuorumCert: Data has been corrupted or its spec has
+                changed: %v\n`, err))
+	}
+	return qc, nil
+}
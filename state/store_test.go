@@ -8,6 +8,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	abci "github.com/hdac-io/tendermint/abci/types"
 	cfg "github.com/hdac-io/tendermint/config"
 	sm "github.com/hdac-io/tendermint/state"
 	"github.com/hdac-io/tendermint/types"
@@ -54,6 +55,78 @@ func TestStoreLoadValidators(t *testing.T) {
 	assert.NotZero(t, loadedVals.Size())
 }
 
+func TestStoreCachedByDBAndHeight(t *testing.T) {
+	db1, db2 := dbm.NewMemDB(), dbm.NewMemDB()
+	vals1, _ := types.RandValidatorSet(1, 10)
+	vals2, _ := types.RandValidatorSet(2, 10)
+
+	// the validators of a height are not shared by the DBs
+	sm.SaveValidatorsInfo(db1, 1, 1, vals1)
+	sm.SaveValidatorsInfo(db2, 1, 1, vals2)
+	loaded1, err := sm.LoadValidators(db1, 1)
+	require.NoError(t, err)
+	loaded2, err := sm.LoadValidators(db2, 1)
+	require.NoError(t, err)
+	assert.Equal(t, vals1.Hash(), loaded1.Hash())
+	assert.Equal(t, vals2.Hash(), loaded2.Hash())
+
+	// saving the validators of a height drops them, and the ones loaded from them
+	sm.SaveValidatorsInfo(db1, 2, 1, nil)
+	_, err = sm.LoadValidators(db1, 2)
+	require.NoError(t, err)
+	sm.SaveValidatorsInfo(db1, 1, 1, vals2)
+	for h := int64(1); h <= 2; h++ {
+		loaded, err := sm.LoadValidators(db1, h)
+		require.NoError(t, err)
+		assert.Equal(t, vals2.Hash(), loaded.Hash(), "height %d", h)
+	}
+
+	// the results hash is memoized until the responses are saved again
+	_, err = sm.LoadResultsHash(db1, 1)
+	assert.Error(t, err)
+	responses := &sm.ABCIResponses{
+		DeliverTx: []*abci.ResponseDeliverTx{{Code: 1}},
+		EndBlock:  &abci.ResponseEndBlock{},
+	}
+	sm.SaveABCIResponses(db1, 1, responses)
+	resultsHash, err := sm.LoadResultsHash(db1, 1)
+	require.NoError(t, err)
+	assert.Equal(t, responses.ResultsHash(), resultsHash)
+	responses.DeliverTx[0].Code = 2
+	sm.SaveABCIResponses(db1, 1, responses)
+	resultsHash, err = sm.LoadResultsHash(db1, 1)
+	require.NoError(t, err)
+	assert.Equal(t, responses.ResultsHash(), resultsHash)
+}
+
+func TestLoadValidatorsReturnsCopy(t *testing.T) {
+	stateDB := dbm.NewMemDB()
+	vals, _ := types.RandValidatorSet(4, 10)
+	sm.SaveValidatorsInfo(stateDB, 1, 1, vals)
+	sm.SaveValidatorsInfo(stateDB, 5, 1, nil)
+
+	for _, height := range []int64{1, 5} {
+		// the first load misses the cache, the second one hits it
+		loaded, err := sm.LoadValidators(stateDB, height)
+		require.NoError(t, err)
+		proposer := loaded.GetProposer().Address
+
+		// e.g. the proposer of a round > 0
+		loaded.IncrementProposerPriority(1)
+		loaded.Validators[0].VotingPower++
+
+		again, err := sm.LoadValidators(stateDB, height)
+		require.NoError(t, err)
+		assert.False(t, loaded == again, "height %d", height)
+		assert.Equal(t, proposer, again.GetProposer().Address, "height %d", height)
+		assert.Equal(t, vals.TotalVotingPower(), again.TotalVotingPower(), "height %d", height)
+		again.IncrementProposerPriority(2)
+		again, err = sm.LoadValidators(stateDB, height)
+		require.NoError(t, err)
+		assert.Equal(t, proposer, again.GetProposer().Address, "height %d", height)
+	}
+}
+
 func BenchmarkLoadValidators(b *testing.B) {
 	const valSetSize = 100
 
@@ -6,13 +6,21 @@ import (
 )
 
 // TxPreCheck returns a function to filter transactions before processing.
-// The function limits the size of a transaction to the block's maximum data size.
+// The function limits the size of a transaction to the block's maximum data
+// size, and to the maximum tx size.
 func TxPreCheck(state State) mempl.PreCheckFunc {
 	maxDataBytes := types.MaxDataBytesUnknownEvidence(
 		state.ConsensusParams.Block.MaxBytes,
 		state.Validators.Size(),
 	)
-	return mempl.PreCheckAminoMaxBytes(maxDataBytes)
+	maxBytesCheck := mempl.PreCheckAminoMaxBytes(maxDataBytes)
+	maxTxBytesCheck := mempl.PreCheckMaxTxBytes(state.ConsensusParams.Block.MaxTxBytes)
+	return func(tx types.Tx) error {
+		if err := maxTxBytesCheck(tx); err != nil {
+			return err
+		}
+		return maxBytesCheck(tx)
+	}
 }
 
 // TxPostCheck returns a function to filter transactions after processing.
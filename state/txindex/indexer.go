@@ -24,6 +24,19 @@ type TxIndexer interface {
 	Search(q *query.Query) ([]*types.TxResult, error)
 }
 
+// Sink is a store the IndexerService writes the blocks and their transactions
+// to along with the TxIndexer, e.g. a database for analytics. Unlike the
+// TxIndexer, it isn't queried by the node.
+type Sink interface {
+
+	// IndexBlock stores the block with the results of its BeginBlock and
+	// EndBlock, and the results of its transactions, in the order of the block.
+	IndexBlock(block types.EventDataNewBlockHeader, txs []*types.TxResult) error
+
+	// Stop releases the resources of the Sink.
+	Stop() error
+}
+
 //----------------------------------------------------
 // Txs are written as a batch
 
@@ -2,6 +2,7 @@ package txindex
 
 import (
 	"context"
+	"time"
 
 	cmn "github.com/hdac-io/tendermint/libs/common"
 
@@ -10,6 +11,13 @@ import (
 
 const (
 	subscriber = "IndexerService"
+
+	// sinkQueueSize is the number of blocks queued for a sink while it
+	// retries a failed write. The indexing waits when the queue is full.
+	sinkQueueSize = 100
+
+	sinkRetryMinInterval = 100 * time.Millisecond
+	sinkRetryMaxInterval = 10 * time.Second
 )
 
 // IndexerService connects event bus and transaction indexer together in order
@@ -17,13 +25,23 @@ const (
 type IndexerService struct {
 	cmn.BaseService
 
-	idr      TxIndexer
-	eventBus *types.EventBus
+	idr        TxIndexer
+	sinks      []Sink
+	sinkQueues []chan sinkBlock
+	eventBus   *types.EventBus
 }
 
-// NewIndexerService returns a new service instance.
-func NewIndexerService(idr TxIndexer, eventBus *types.EventBus) *IndexerService {
-	is := &IndexerService{idr: idr, eventBus: eventBus}
+// sinkBlock is a block to write to a sink.
+type sinkBlock struct {
+	block types.EventDataNewBlockHeader
+	txs   []*types.TxResult
+}
+
+// NewIndexerService returns a new service instance, which also writes the
+// indexed blocks to the sinks. Each sink is written in the background, and
+// retries a failed write until it succeeds.
+func NewIndexerService(idr TxIndexer, eventBus *types.EventBus, sinks ...Sink) *IndexerService {
+	is := &IndexerService{idr: idr, sinks: sinks, eventBus: eventBus}
 	is.BaseService = *cmn.NewBaseService(nil, "IndexerService", is)
 	return is
 }
@@ -45,10 +63,17 @@ func (is *IndexerService) OnStart() error {
 		return err
 	}
 
+	is.sinkQueues = make([]chan sinkBlock, len(is.sinks))
+	for i, sink := range is.sinks {
+		is.sinkQueues[i] = make(chan sinkBlock, sinkQueueSize)
+		go is.writeSink(sink, is.sinkQueues[i])
+	}
+
 	go func() {
 		for {
 			msg := <-blockHeadersSub.Out()
-			header := msg.Data().(types.EventDataNewBlockHeader).Header
+			block := msg.Data().(types.EventDataNewBlockHeader)
+			header := block.Header
 			batch := NewBatch(header.NumTxs)
 			for i := int64(0); i < header.NumTxs; i++ {
 				msg2 := <-txsSub.Out()
@@ -65,14 +90,61 @@ func (is *IndexerService) OnStart() error {
 			} else {
 				is.Logger.Info("Indexed block", "height", header.Height)
 			}
+			for _, queue := range is.sinkQueues {
+				select {
+				case queue <- sinkBlock{block, batch.Ops}:
+				default:
+					is.Logger.Error("Sink queue is full, waiting", "height", header.Height)
+					select {
+					case queue <- sinkBlock{block, batch.Ops}:
+					case <-is.Quit():
+						return
+					}
+				}
+			}
 		}
 	}()
 	return nil
 }
 
+// writeSink writes the queued blocks to sink, retrying a failed write with a
+// backoff until it succeeds or the service stops.
+func (is *IndexerService) writeSink(sink Sink, queue <-chan sinkBlock) {
+	for {
+		var b sinkBlock
+		select {
+		case b = <-queue:
+		case <-is.Quit():
+			return
+		}
+		retry := sinkRetryMinInterval
+		for {
+			err := sink.IndexBlock(b.block, b.txs)
+			if err == nil {
+				break
+			}
+			is.Logger.Error("Failed to write block to sink, retrying",
+				"height", b.block.Header.Height, "retryIn", retry, "err", err)
+			select {
+			case <-time.After(retry):
+			case <-is.Quit():
+				return
+			}
+			if retry *= 2; retry > sinkRetryMaxInterval {
+				retry = sinkRetryMaxInterval
+			}
+		}
+	}
+}
+
 // OnStop implements cmn.Service by unsubscribing from all transactions.
 func (is *IndexerService) OnStop() {
 	if is.eventBus.IsRunning() {
 		_ = is.eventBus.UnsubscribeAll(context.Background(), subscriber)
 	}
+	for _, sink := range is.sinks {
+		if err := sink.Stop(); err != nil {
+			is.Logger.Error("Failed to stop sink", "err", err)
+		}
+	}
 }
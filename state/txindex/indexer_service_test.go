@@ -1,6 +1,8 @@
 package txindex_test
 
 import (
+	"errors"
+	"sync"
 	"testing"
 	"time"
 
@@ -62,3 +64,105 @@ func TestIndexerServiceIndexesBlocks(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(t, txResult2, res)
 }
+
+type recordingSink struct {
+	mtx    sync.Mutex
+	blocks []types.EventDataNewBlockHeader
+	txs    [][]*types.TxResult
+}
+
+func (s *recordingSink) IndexBlock(block types.EventDataNewBlockHeader, txs []*types.TxResult) error {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	s.blocks = append(s.blocks, block)
+	s.txs = append(s.txs, txs)
+	return nil
+}
+
+func (s *recordingSink) Stop() error { return nil }
+
+func TestIndexerServiceWritesSinks(t *testing.T) {
+	eventBus := types.NewEventBus()
+	eventBus.SetLogger(log.TestingLogger())
+	err := eventBus.Start()
+	require.NoError(t, err)
+	defer eventBus.Stop()
+
+	sink := &recordingSink{}
+	service := txindex.NewIndexerService(kv.NewTxIndex(db.NewMemDB()), eventBus, sink)
+	service.SetLogger(log.TestingLogger())
+	err = service.Start()
+	require.NoError(t, err)
+	defer service.Stop()
+
+	endBlock := abci.ResponseEndBlock{Events: []abci.Event{{Type: "reward"}}}
+	eventBus.PublishEventNewBlockHeader(types.EventDataNewBlockHeader{
+		Header:         types.Header{Height: 1, NumTxs: 1},
+		ResultEndBlock: endBlock,
+	})
+	txResult := &types.TxResult{Height: 1, Index: 0, Tx: types.Tx("foo")}
+	eventBus.PublishEventTx(types.EventDataTx{TxResult: *txResult})
+	eventBus.PublishEventNewBlockHeader(types.EventDataNewBlockHeader{
+		Header: types.Header{Height: 2},
+	})
+
+	time.Sleep(100 * time.Millisecond)
+
+	sink.mtx.Lock()
+	defer sink.mtx.Unlock()
+	require.Len(t, sink.blocks, 2)
+	assert.EqualValues(t, 1, sink.blocks[0].Header.Height)
+	assert.Equal(t, endBlock, sink.blocks[0].ResultEndBlock)
+	assert.Equal(t, []*types.TxResult{txResult}, sink.txs[0])
+	assert.EqualValues(t, 2, sink.blocks[1].Header.Height)
+	assert.Empty(t, sink.txs[1])
+}
+
+// failingSink fails the first writes, then records the blocks.
+type failingSink struct {
+	recordingSink
+	failures int
+}
+
+func (s *failingSink) IndexBlock(block types.EventDataNewBlockHeader, txs []*types.TxResult) error {
+	s.mtx.Lock()
+	if s.failures > 0 {
+		s.failures--
+		s.mtx.Unlock()
+		return errors.New("database is down")
+	}
+	s.mtx.Unlock()
+	return s.recordingSink.IndexBlock(block, txs)
+}
+
+func TestIndexerServiceRetriesSinks(t *testing.T) {
+	eventBus := types.NewEventBus()
+	eventBus.SetLogger(log.TestingLogger())
+	err := eventBus.Start()
+	require.NoError(t, err)
+	defer eventBus.Stop()
+
+	sink := &failingSink{failures: 2}
+	service := txindex.NewIndexerService(kv.NewTxIndex(db.NewMemDB()), eventBus, sink)
+	service.SetLogger(log.TestingLogger())
+	err = service.Start()
+	require.NoError(t, err)
+	defer service.Stop()
+
+	for h := int64(1); h <= 3; h++ {
+		eventBus.PublishEventNewBlockHeader(types.EventDataNewBlockHeader{
+			Header: types.Header{Height: h},
+		})
+	}
+
+	// retried after 100ms and 200ms
+	time.Sleep(600 * time.Millisecond)
+
+	sink.mtx.Lock()
+	defer sink.mtx.Unlock()
+	assert.Zero(t, sink.failures)
+	require.Len(t, sink.blocks, 3)
+	for i, block := range sink.blocks {
+		assert.EqualValues(t, i+1, block.Header.Height)
+	}
+}
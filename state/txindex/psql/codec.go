@@ -1,11 +1,7 @@
-package mempool
+package psql
 
 import (
 	amino "github.com/tendermint/go-amino"
 )
 
 var cdc = amino.NewCodec()
-
-func init() {
-	RegisterMempoolMessages(cdc)
-}
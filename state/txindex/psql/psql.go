@@ -0,0 +1,219 @@
+// Package psql implements a txindex.Sink writing the blocks, the results of
+// their transactions and their events to a PostgreSQL database, to query them
+// with SQL.
+package psql
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/pkg/errors"
+
+	abci "github.com/hdac-io/tendermint/abci/types"
+	"github.com/hdac-io/tendermint/state/txindex"
+	"github.com/hdac-io/tendermint/types"
+)
+
+// DriverName is the database/sql driver the Sink is opened with. The stock
+// tendermint binary links none, so a node writing to a Sink must be built with
+// a PostgreSQL driver registered under this name, e.g. with
+//
+//	import _ "github.com/lib/pq"
+const DriverName = "postgres"
+
+// Schema creates the tables of the Sink, if they don't exist.
+//
+// The blocks are identified by their height and chain ID, and the results of
+// their transactions by their block and index. The events of BeginBlock and
+// EndBlock have no tx_id. The results_height of a transaction is the height of
+// the block whose LastResultsHash includes its result: LenULB heights above
+// its block with the friday consensus, where the blocks are executed after the
+// LenULB heights in progress, and the next height with the tendermint
+// consensus.
+const Schema = `
+CREATE TABLE IF NOT EXISTS blocks (
+  rowid      BIGSERIAL PRIMARY KEY,
+  height     BIGINT NOT NULL,
+  chain_id   VARCHAR NOT NULL,
+  hash       VARCHAR NOT NULL,
+  time       TIMESTAMPTZ NOT NULL,
+  num_txs    BIGINT NOT NULL,
+  created_at TIMESTAMPTZ NOT NULL,
+  UNIQUE (height, chain_id)
+);
+
+CREATE TABLE IF NOT EXISTS tx_results (
+  rowid          BIGSERIAL PRIMARY KEY,
+  block_id       BIGINT NOT NULL REFERENCES blocks(rowid),
+  index          INTEGER NOT NULL,
+  tx_hash        VARCHAR NOT NULL,
+  code           INTEGER NOT NULL,
+  tx             BYTEA NOT NULL,
+  tx_result      BYTEA NOT NULL,
+  results_height BIGINT NOT NULL,
+  created_at     TIMESTAMPTZ NOT NULL,
+  UNIQUE (block_id, index)
+);
+
+CREATE TABLE IF NOT EXISTS events (
+  rowid    BIGSERIAL PRIMARY KEY,
+  block_id BIGINT NOT NULL REFERENCES blocks(rowid),
+  tx_id    BIGINT NULL REFERENCES tx_results(rowid),
+  type     VARCHAR NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS attributes (
+  event_id      BIGINT NOT NULL REFERENCES events(rowid),
+  key           VARCHAR NOT NULL,
+  composite_key VARCHAR NOT NULL,
+  value         VARCHAR NULL,
+  UNIQUE (event_id, key)
+);
+
+CREATE INDEX IF NOT EXISTS tx_results_tx_hash ON tx_results (tx_hash);
+CREATE INDEX IF NOT EXISTS attributes_composite_key ON attributes (composite_key, value);
+`
+
+var _ txindex.Sink = (*Sink)(nil)
+
+// Sink writes the indexed blocks to a PostgreSQL database.
+type Sink struct {
+	db           *sql.DB
+	chainID      string
+	resultsDelay int64
+}
+
+// NewSink returns a Sink writing the blocks of the chain to the database
+// opened with the connection string conn, creating its tables if needed. The
+// results of the transactions of a block are included in the block
+// resultsDelay heights above it (see Schema). It fails if no driver is
+// registered as DriverName.
+func NewSink(conn, chainID string, resultsDelay int64) (*Sink, error) {
+	if !driverRegistered(DriverName) {
+		return nil, fmt.Errorf("no database/sql driver %q is linked in this binary; "+
+			"build the node with a PostgreSQL driver (e.g. github.com/lib/pq) to use psql_conn", DriverName)
+	}
+	db, err := sql.Open(DriverName, conn)
+	if err != nil {
+		return nil, errors.Wrap(err, "couldn't open the tx index database")
+	}
+	sink, err := NewSinkFromDB(db, chainID, resultsDelay)
+	if err != nil {
+		db.Close()
+		return nil, err
+	}
+	return sink, nil
+}
+
+func driverRegistered(name string) bool {
+	for _, driver := range sql.Drivers() {
+		if driver == name {
+			return true
+		}
+	}
+	return false
+}
+
+// NewSinkFromDB is like NewSink with an open database.
+func NewSinkFromDB(db *sql.DB, chainID string, resultsDelay int64) (*Sink, error) {
+	if _, err := db.Exec(Schema); err != nil {
+		return nil, errors.Wrap(err, "couldn't create the tx index tables")
+	}
+	return &Sink{db: db, chainID: chainID, resultsDelay: resultsDelay}, nil
+}
+
+// IndexBlock implements txindex.Sink. The block and its transactions are
+// written in a single database transaction. A block already written, e.g. when
+// the node replays it after a restart, is skipped.
+func (s *Sink) IndexBlock(block types.EventDataNewBlockHeader, txs []*types.TxResult) (err error) {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			tx.Rollback() // nolint: errcheck
+		}
+	}()
+
+	now := time.Now().UTC()
+	header := block.Header
+	var blockID int64
+	err = tx.QueryRow(`INSERT INTO blocks (height, chain_id, hash, time, num_txs, created_at)
+VALUES ($1, $2, $3, $4, $5, $6) ON CONFLICT DO NOTHING RETURNING rowid`,
+		header.Height, s.chainID, fmt.Sprintf("%X", header.Hash()), header.Time, header.NumTxs, now,
+	).Scan(&blockID)
+	if err == sql.ErrNoRows {
+		return tx.Rollback()
+	}
+	if err != nil {
+		return errors.Wrap(err, "couldn't insert the block")
+	}
+
+	if err = s.insertEvents(tx, blockID, nil, block.ResultBeginBlock.Events); err != nil {
+		return err
+	}
+	if err = s.insertEvents(tx, blockID, nil, block.ResultEndBlock.Events); err != nil {
+		return err
+	}
+
+	for _, txResult := range txs {
+		if txResult == nil {
+			continue
+		}
+		var bz []byte
+		if bz, err = cdc.MarshalBinaryBare(txResult); err != nil {
+			return err
+		}
+		var txID int64
+		err = tx.QueryRow(`INSERT INTO tx_results (block_id, index, tx_hash, code, tx, tx_result, results_height, created_at)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8) RETURNING rowid`,
+			blockID, txResult.Index, fmt.Sprintf("%X", txResult.Tx.Hash()), txResult.Result.Code,
+			[]byte(txResult.Tx), bz, txResult.Height+s.resultsDelay, now,
+		).Scan(&txID)
+		if err != nil {
+			return errors.Wrap(err, fmt.Sprintf("couldn't insert the result of tx %d", txResult.Index))
+		}
+		if err = s.insertEvents(tx, blockID, &txID, txResult.Result.Events); err != nil {
+			return err
+		}
+	}
+	return tx.Commit()
+}
+
+// insertEvents inserts the events of the transaction txID of the block, or of
+// the block itself if txID is nil. Like with the kv indexer, the events with
+// an empty type are skipped.
+func (s *Sink) insertEvents(tx *sql.Tx, blockID int64, txID *int64, events []abci.Event) error {
+	for _, event := range events {
+		if len(event.Type) == 0 {
+			continue
+		}
+		var eventID int64
+		err := tx.QueryRow(`INSERT INTO events (block_id, tx_id, type) VALUES ($1, $2, $3) RETURNING rowid`,
+			blockID, txID, event.Type,
+		).Scan(&eventID)
+		if err != nil {
+			return errors.Wrap(err, fmt.Sprintf("couldn't insert the event %s", event.Type))
+		}
+		for _, attr := range event.Attributes {
+			if len(attr.Key) == 0 {
+				continue
+			}
+			_, err := tx.Exec(`INSERT INTO attributes (event_id, key, composite_key, value)
+VALUES ($1, $2, $3, $4) ON CONFLICT DO NOTHING`,
+				eventID, string(attr.Key), fmt.Sprintf("%s.%s", event.Type, attr.Key), string(attr.Value),
+			)
+			if err != nil {
+				return errors.Wrap(err, fmt.Sprintf("couldn't insert the attribute %s.%s", event.Type, attr.Key))
+			}
+		}
+	}
+	return nil
+}
+
+// Stop implements txindex.Sink by closing the database.
+func (s *Sink) Stop() error {
+	return s.db.Close()
+}
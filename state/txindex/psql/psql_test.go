@@ -0,0 +1,181 @@
+package psql
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"io"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	abci "github.com/hdac-io/tendermint/abci/types"
+	cmn "github.com/hdac-io/tendermint/libs/common"
+	"github.com/hdac-io/tendermint/types"
+)
+
+// recordingDriver is a database/sql driver recording the statements of the
+// committed transactions. The INSERTs RETURNING a rowid return increasing
+// rowids, but the ones into blocks, which return none for a height already
+// inserted, like ON CONFLICT DO NOTHING.
+type recordingDriver struct {
+	mtx       sync.Mutex
+	committed []statement
+	pending   []statement
+	rowid     int64
+	heights   map[int64]bool
+}
+
+type statement struct {
+	query string
+	args  []driver.Value
+}
+
+func (d *recordingDriver) Open(name string) (driver.Conn, error) { return d, nil }
+func (d *recordingDriver) Prepare(query string) (driver.Stmt, error) {
+	return &recordingStmt{d, query}, nil
+}
+func (d *recordingDriver) Close() error              { return nil }
+func (d *recordingDriver) Begin() (driver.Tx, error) { return d, nil }
+func (d *recordingDriver) Commit() error {
+	d.mtx.Lock()
+	defer d.mtx.Unlock()
+	d.committed = append(d.committed, d.pending...)
+	d.pending = nil
+	return nil
+}
+func (d *recordingDriver) Rollback() error {
+	d.mtx.Lock()
+	defer d.mtx.Unlock()
+	d.pending = nil
+	return nil
+}
+
+// statements returns the committed statements into table.
+func (d *recordingDriver) statements(table string) []statement {
+	d.mtx.Lock()
+	defer d.mtx.Unlock()
+	var stmts []statement
+	for _, stmt := range d.committed {
+		if strings.HasPrefix(stmt.query, "INSERT INTO "+table+" ") {
+			stmts = append(stmts, stmt)
+		}
+	}
+	return stmts
+}
+
+type recordingStmt struct {
+	d     *recordingDriver
+	query string
+}
+
+func (s *recordingStmt) Close() error  { return nil }
+func (s *recordingStmt) NumInput() int { return -1 }
+func (s *recordingStmt) Exec(args []driver.Value) (driver.Result, error) {
+	s.d.mtx.Lock()
+	defer s.d.mtx.Unlock()
+	if strings.HasPrefix(s.query, "INSERT") {
+		s.d.pending = append(s.d.pending, statement{s.query, args})
+	}
+	return driver.RowsAffected(1), nil
+}
+func (s *recordingStmt) Query(args []driver.Value) (driver.Rows, error) {
+	s.d.mtx.Lock()
+	defer s.d.mtx.Unlock()
+	if strings.HasPrefix(s.query, "INSERT INTO blocks ") {
+		height := args[0].(int64)
+		if s.d.heights[height] {
+			return &recordingRows{}, nil
+		}
+		s.d.heights[height] = true
+	}
+	s.d.pending = append(s.d.pending, statement{s.query, args})
+	s.d.rowid++
+	return &recordingRows{rowid: s.d.rowid}, nil
+}
+
+type recordingRows struct {
+	rowid int64
+}
+
+func (r *recordingRows) Columns() []string { return []string{"rowid"} }
+func (r *recordingRows) Close() error      { return nil }
+func (r *recordingRows) Next(dest []driver.Value) error {
+	if r.rowid == 0 {
+		return io.EOF
+	}
+	dest[0] = r.rowid
+	r.rowid = 0
+	return nil
+}
+
+var (
+	registerOnce sync.Once
+	testDriver   = &recordingDriver{heights: make(map[int64]bool)}
+)
+
+func TestSinkIndexBlock(t *testing.T) {
+	registerOnce.Do(func() { sql.Register("psql_test", testDriver) })
+	db, err := sql.Open("psql_test", "")
+	require.NoError(t, err)
+	sink, err := NewSinkFromDB(db, "test-chain", 3)
+	require.NoError(t, err)
+	defer sink.Stop()
+
+	block := types.EventDataNewBlockHeader{
+		Header: types.Header{ChainID: "test-chain", Height: 5, NumTxs: 2},
+		ResultEndBlock: abci.ResponseEndBlock{Events: []abci.Event{
+			{Type: "reward", Attributes: []cmn.KVPair{{Key: []byte("amount"), Value: []byte("10")}}},
+		}},
+	}
+	txs := []*types.TxResult{
+		{Height: 5, Index: 0, Tx: types.Tx("foo"), Result: abci.ResponseDeliverTx{Events: []abci.Event{
+			{Type: "account", Attributes: []cmn.KVPair{
+				{Key: []byte("number"), Value: []byte("1")},
+				{Key: []byte(""), Value: []byte("skipped")},
+			}},
+			{Type: "", Attributes: []cmn.KVPair{{Key: []byte("skipped"), Value: []byte("1")}}},
+		}}},
+		{Height: 5, Index: 1, Tx: types.Tx("bar"), Result: abci.ResponseDeliverTx{Code: 1}},
+	}
+	require.NoError(t, sink.IndexBlock(block, txs))
+	// replayed
+	require.NoError(t, sink.IndexBlock(block, txs))
+
+	blocks := testDriver.statements("blocks")
+	require.Len(t, blocks, 1)
+	assert.Equal(t, []driver.Value{int64(5), "test-chain"}, blocks[0].args[:2])
+	blockID := int64(1)
+
+	results := testDriver.statements("tx_results")
+	require.Len(t, results, 2)
+	for i, res := range results {
+		assert.Equal(t, blockID, res.args[0])
+		assert.EqualValues(t, i, res.args[1])
+		assert.Equal(t, []byte(txs[i].Tx), res.args[4])
+		// included in the LastResultsHash of height 8
+		assert.Equal(t, int64(8), res.args[6])
+	}
+	assert.EqualValues(t, 1, results[1].args[3], "code")
+
+	events := testDriver.statements("events")
+	require.Len(t, events, 2)
+	assert.Equal(t, []driver.Value{blockID, nil, "reward"}, events[0].args)
+	assert.Equal(t, blockID, events[1].args[0])
+	assert.NotNil(t, events[1].args[1], "tx_id")
+	assert.Equal(t, "account", events[1].args[2])
+
+	attrs := testDriver.statements("attributes")
+	require.Len(t, attrs, 2)
+	assert.Equal(t, []driver.Value{"amount", "reward.amount", "10"}, attrs[0].args[1:])
+	assert.Equal(t, []driver.Value{"number", "account.number", "1"}, attrs[1].args[1:])
+}
+
+func TestNewSinkWithoutDriver(t *testing.T) {
+	// the tests link no driver registered as DriverName
+	_, err := NewSink("postgresql://localhost/txindex", "test-chain", 1)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "no database/sql driver")
+}
@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"errors"
 	"fmt"
+	"time"
 
 	"github.com/hdac-io/tendermint/crypto"
 	"github.com/hdac-io/tendermint/types"
@@ -13,21 +14,26 @@ import (
 //-----------------------------------------------------
 // Validate block
 
-func validateBlock(store BlockStore, evidencePool EvidencePool, stateDB dbm.DB, state State, block *types.Block) error {
+// TxGasFunc returns the gas of a tx. It must be deterministic: all the nodes
+// must get the same gas for a tx.
+type TxGasFunc func(tx types.Tx) int64
+
+func validateBlock(store BlockStore, evidencePool EvidencePool, stateDB dbm.DB, state State, block *types.Block, txGas TxGasFunc) error {
 	if state.Version.Consensus.Module == "friday" {
-		return fridayValidateBlock(store, evidencePool, stateDB, state, block)
+		return fridayValidateBlock(store, evidencePool, stateDB, state, block, txGas)
 	} else {
-		return tmValidateBlock(evidencePool, stateDB, state, block)
+		return tmValidateBlock(store, evidencePool, stateDB, state, block, txGas)
 	}
 }
 
-func fridayValidateBlock(store BlockStore, evidencePool EvidencePool, stateDB dbm.DB, state State, block *types.Block) error {
+func fridayValidateBlock(store BlockStore, evidencePool EvidencePool, stateDB dbm.DB, state State, block *types.Block, txGas TxGasFunc) error {
 	// Validate internal consistency.
 	if err := block.ValidateFridayBasic(); err != nil {
 		return err
 	}
 
 	lenULB := state.ConsensusParams.Block.LenULB
+	params := state.ConsensusParamsAt(block.Height)
 
 	// Validate basic info.
 	if block.Version != state.Version.Consensus {
@@ -72,11 +78,10 @@ func fridayValidateBlock(store BlockStore, evidencePool EvidencePool, stateDB db
 			)
 		}
 
-		ulbABCIResponses, err := LoadABCIResponses(stateDB, ulbHeight)
+		ulbResultsHash, err := LoadResultsHash(stateDB, ulbHeight)
 		if err != nil {
 			panic(fmt.Sprintf("Cannot load ulb ABCI responses. ulbHeight=%v, error=%v", ulbHeight, err.Error()))
 		}
-		ulbResultsHash := ulbABCIResponses.ResultsHash()
 		if !bytes.Equal(block.LastResultsHash, ulbResultsHash) {
 			return fmt.Errorf("Wrong Block.Header.LastResultsHash.  Expected %X, got %v",
 				ulbResultsHash,
@@ -85,9 +90,9 @@ func fridayValidateBlock(store BlockStore, evidencePool EvidencePool, stateDB db
 		}
 	}
 
-	if !bytes.Equal(block.ConsensusHash, state.ConsensusParams.Hash()) {
+	if !bytes.Equal(block.ConsensusHash, params.Hash()) {
 		return fmt.Errorf("Wrong Block.Header.ConsensusHash.  Expected %X, got %v",
-			state.ConsensusParams.Hash(),
+			params.Hash(),
 			block.ConsensusHash,
 		)
 	}
@@ -136,6 +141,9 @@ func fridayValidateBlock(store BlockStore, evidencePool EvidencePool, stateDB db
 		if len(block.LastCommit.Precommits) != ulbValidators.Size() {
 			return types.NewErrInvalidCommitPrecommits(ulbValidators.Size(), len(block.LastCommit.Precommits))
 		}
+		if block.LastCommit.IsAggregate() && !state.AggregatesLastCommits() {
+			return fmt.Errorf("Block protocol %v can't have an aggregate LastCommit", state.Version.Consensus.Block)
+		}
 		ulbHeight := block.Height - lenULB
 		ulbBlockMeta := store.LoadBlockMeta(ulbHeight)
 		err = ulbValidators.VerifyCommit(
@@ -177,8 +185,16 @@ func fridayValidateBlock(store BlockStore, evidencePool EvidencePool, stateDB db
 		}
 	}
 
+	// Limit the gas and the sizes of the txs
+	if err := validateBlockGas(txGas, params.Block.MaxGas, block.Txs); err != nil {
+		return err
+	}
+	if err := validateBlockTxSizes(params.Block.MaxTxBytes, block.Txs); err != nil {
+		return err
+	}
+
 	// Limit the amount of evidence
-	maxNumEvidence, _ := types.MaxEvidencePerBlock(state.ConsensusParams.Block.MaxBytes)
+	maxNumEvidence, _ := types.MaxEvidencePerBlock(params.Block.MaxBytes)
 	numEvidence := int64(len(block.Evidence.Evidence))
 	if numEvidence > maxNumEvidence {
 		return types.NewErrEvidenceOverflow(maxNumEvidence, numEvidence)
@@ -187,7 +203,7 @@ func fridayValidateBlock(store BlockStore, evidencePool EvidencePool, stateDB db
 
 	// Validate all evidence.
 	for _, ev := range block.Evidence.Evidence {
-		if err := VerifyEvidence(stateDB, state, ev); err != nil {
+		if err := VerifyEvidence(store, stateDB, state, ev); err != nil {
 			return types.NewErrEvidenceInvalid(ev, err)
 		}
 		if evidencePool != nil && evidencePool.IsCommitted(ev) {
@@ -208,7 +224,7 @@ func fridayValidateBlock(store BlockStore, evidencePool EvidencePool, stateDB db
 	return nil
 }
 
-func tmValidateBlock(evidencePool EvidencePool, stateDB dbm.DB, state State, block *types.Block) error {
+func tmValidateBlock(store BlockStore, evidencePool EvidencePool, stateDB dbm.DB, state State, block *types.Block, txGas TxGasFunc) error {
 	// Validate internal consistency.
 	if err := block.ValidateBasic(); err != nil {
 		return err
@@ -292,6 +308,9 @@ func tmValidateBlock(evidencePool EvidencePool, stateDB dbm.DB, state State, blo
 		if len(block.LastCommit.Precommits) != state.LastValidators.Size() {
 			return types.NewErrInvalidCommitPrecommits(state.LastValidators.Size(), len(block.LastCommit.Precommits))
 		}
+		if block.LastCommit.IsAggregate() {
+			return errors.New("Block LastCommit can't be aggregate")
+		}
 		err := state.LastValidators.VerifyCommit(
 			state.ChainID, state.LastBlockID, block.Height-1, block.LastCommit)
 		if err != nil {
@@ -325,6 +344,14 @@ func tmValidateBlock(evidencePool EvidencePool, stateDB dbm.DB, state State, blo
 		}
 	}
 
+	// Limit the gas and the sizes of the txs
+	if err := validateBlockGas(txGas, state.ConsensusParams.Block.MaxGas, block.Txs); err != nil {
+		return err
+	}
+	if err := validateBlockTxSizes(state.ConsensusParams.Block.MaxTxBytes, block.Txs); err != nil {
+		return err
+	}
+
 	// Limit the amount of evidence
 	maxNumEvidence, _ := types.MaxEvidencePerBlock(state.ConsensusParams.Block.MaxBytes)
 	numEvidence := int64(len(block.Evidence.Evidence))
@@ -335,7 +362,7 @@ func tmValidateBlock(evidencePool EvidencePool, stateDB dbm.DB, state State, blo
 
 	// Validate all evidence.
 	for _, ev := range block.Evidence.Evidence {
-		if err := VerifyEvidence(stateDB, state, ev); err != nil {
+		if err := VerifyEvidence(store, stateDB, state, ev); err != nil {
 			return types.NewErrEvidenceInvalid(ev, err)
 		}
 		if evidencePool != nil && evidencePool.IsCommitted(ev) {
@@ -356,19 +383,52 @@ func tmValidateBlock(evidencePool EvidencePool, stateDB dbm.DB, state State, blo
 	return nil
 }
 
+// validateBlockGas returns an error if the gas of the txs, accounted with
+// txGas, exceeds maxGas. Without txGas or maxGas, the gas isn't limited.
+func validateBlockGas(txGas TxGasFunc, maxGas int64, txs types.Txs) error {
+	if txGas == nil || maxGas < 0 {
+		return nil
+	}
+	var totalGas int64
+	for _, tx := range txs {
+		totalGas += txGas(tx)
+		if totalGas > maxGas {
+			return fmt.Errorf("Block gas exceeds the max gas %d", maxGas)
+		}
+	}
+	return nil
+}
+
+// validateBlockTxSizes returns an error if a tx is larger than maxTxBytes.
+// Without maxTxBytes, the size of the txs isn't limited.
+func validateBlockTxSizes(maxTxBytes int64, txs types.Txs) error {
+	if maxTxBytes == 0 {
+		return nil
+	}
+	for i, tx := range txs {
+		if int64(len(tx)) > maxTxBytes {
+			return fmt.Errorf("Block tx %d size %d exceeds the max tx size %d", i, len(tx), maxTxBytes)
+		}
+	}
+	return nil
+}
+
 // VerifyEvidence verifies the evidence fully by checking:
-// - it is sufficiently recent (MaxAge)
+// - it is sufficiently recent (MaxAge and MaxAgeDurationMs)
 // - it is from a key who was a validator at the given height
 // - it is internally consistent
 // - it was properly signed by the alleged equivocator
-func VerifyEvidence(stateDB dbm.DB, state State, evidence types.Evidence) error {
+func VerifyEvidence(store BlockStoreRPC, stateDB dbm.DB, state State, evidence types.Evidence) error {
 	height := state.LastBlockHeight
 
-	evidenceAge := height - evidence.Height()
-	maxAge := state.ConsensusParams.Evidence.MaxAge
-	if evidenceAge > maxAge {
-		return fmt.Errorf("Evidence from height %d is too old. Min height is %d",
-			evidence.Height(), height-maxAge)
+	params := state.ConsensusParams.Evidence
+	expired, err := IsEvidenceExpired(store, params, evidence, height, state.LastBlockTime)
+	if err != nil {
+		return err
+	}
+	if expired {
+		return fmt.Errorf("Evidence from height %d is too old. Min height is %d, max age is %dms",
+			evidence.Height(), height-params.MaxAge, params.MaxAgeDurationMs)
 	}
 
 	valset, err := LoadValidators(stateDB, evidence.Height())
@@ -394,5 +454,37 @@ func VerifyEvidence(stateDB dbm.DB, state State, evidence types.Evidence) error
 		return err
 	}
 
+	// The address must have been the proposer of the round.
+	if ev, ok := evidence.(*types.DuplicateProposalEvidence); ok {
+		proposers := valset.Copy()
+		if ev.Round() > 0 {
+			proposers.IncrementProposerPriority(ev.Round())
+		}
+		if proposer := proposers.GetProposer(); !bytes.Equal(proposer.Address, addr) {
+			return fmt.Errorf("Address %X was not the proposer at height %d round %d", addr, height, ev.Round())
+		}
+	}
+
 	return nil
 }
+
+// IsEvidenceExpired returns true if the evidence is too old to be committed
+// after the block of height and time t (see EvidenceParams.IsExpired), its age
+// in time measured from the header time of the block at its height, which
+// every node agrees on. It fails closed with an error if the block meta of
+// the evidence height isn't in the store.
+func IsEvidenceExpired(store BlockStoreRPC, params types.EvidenceParams, evidence types.Evidence, height int64, t time.Time) (bool, error) {
+	evHeight := evidence.Height()
+	if height-evHeight > params.MaxAge {
+		return true, nil
+	}
+	if params.MaxAgeDurationMs == 0 || evHeight > height {
+		return false, nil
+	}
+
+	meta := store.LoadBlockMeta(evHeight)
+	if meta == nil {
+		return false, fmt.Errorf("No block meta for the evidence height %d to age it", evHeight)
+	}
+	return params.IsExpired(evHeight, meta.Header.Time, height, t), nil
+}
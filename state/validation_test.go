@@ -1,9 +1,11 @@
 package state_test
 
 import (
+	"fmt"
 	"testing"
 	"time"
 
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"github.com/hdac-io/tendermint/mock"
 
@@ -11,8 +13,10 @@ import (
 	"github.com/hdac-io/tendermint/crypto/tmhash"
 	"github.com/hdac-io/tendermint/libs/log"
 	sm "github.com/hdac-io/tendermint/state"
+	"github.com/hdac-io/tendermint/store"
 	"github.com/hdac-io/tendermint/types"
 	tmtime "github.com/hdac-io/tendermint/types/time"
+	dbm "github.com/tendermint/tm-db"
 )
 
 const validationTestsStopHeight int64 = 10
@@ -23,7 +27,7 @@ func TestValidateBlockHeader(t *testing.T) {
 	defer proxyApp.Stop()
 
 	state, stateDB, privVals := makeState(3, 1)
-	blockExec := sm.NewBlockExecutor(stateDB, log.TestingLogger(), proxyApp.Consensus(), mock.Mempool{}, sm.MockEvidencePool{})
+	blockExec := sm.NewBlockExecutor(store.NewBlockStore(dbm.NewMemDB()), stateDB, log.TestingLogger(), proxyApp.Consensus(), mock.Mempool{}, sm.MockEvidencePool{})
 	lastCommit := types.NewCommit(types.BlockID{}, nil)
 
 	// some bad values
@@ -89,7 +93,7 @@ func TestValidateBlockCommit(t *testing.T) {
 	defer proxyApp.Stop()
 
 	state, stateDB, privVals := makeState(1, 1)
-	blockExec := sm.NewBlockExecutor(stateDB, log.TestingLogger(), proxyApp.Consensus(), mock.Mempool{}, sm.MockEvidencePool{})
+	blockExec := sm.NewBlockExecutor(store.NewBlockStore(dbm.NewMemDB()), stateDB, log.TestingLogger(), proxyApp.Consensus(), mock.Mempool{}, sm.MockEvidencePool{})
 	lastCommit := types.NewCommit(types.BlockID{}, nil)
 	wrongPrecommitsCommit := types.NewCommit(types.BlockID{}, nil)
 	badPrivVal := types.NewMockPV()
@@ -116,6 +120,21 @@ func TestValidateBlockCommit(t *testing.T) {
 			err = blockExec.ValidateBlock(state, block)
 			_, isErrInvalidCommitPrecommits := err.(types.ErrInvalidCommitPrecommits)
 			require.True(t, isErrInvalidCommitPrecommits, "expected ErrInvalidCommitPrecommits at height %d but got: %v", height, err)
+
+			/*
+				only the friday blocks can carry an aggregate commit
+			*/
+			aggPrecommits := make([]*types.CommitSig, len(lastCommit.Precommits))
+			for i, precommit := range lastCommit.Precommits {
+				aggPrecommit := *precommit
+				aggPrecommit.Signature = nil
+				aggPrecommits[i] = &aggPrecommit
+			}
+			aggCommit := types.NewCommit(lastCommit.BlockID, aggPrecommits)
+			aggCommit.AggregateSignature = lastCommit.Precommits[0].Signature
+			block, _ = state.MakeBlock(height, makeTxs(height), aggCommit, nil, proposerAddr)
+			err = blockExec.ValidateBlock(state, block)
+			require.EqualError(t, err, "Block LastCommit can't be aggregate", "height %d", height)
 		}
 
 		/*
@@ -152,7 +171,7 @@ func TestValidateBlockEvidence(t *testing.T) {
 	defer proxyApp.Stop()
 
 	state, stateDB, privVals := makeState(3, 1)
-	blockExec := sm.NewBlockExecutor(stateDB, log.TestingLogger(), proxyApp.Consensus(), mock.Mempool{}, sm.MockEvidencePool{})
+	blockExec := sm.NewBlockExecutor(store.NewBlockStore(dbm.NewMemDB()), stateDB, log.TestingLogger(), proxyApp.Consensus(), mock.Mempool{}, sm.MockEvidencePool{})
 	lastCommit := types.NewCommit(types.BlockID{}, nil)
 
 	for height := int64(1); height < validationTestsStopHeight; height++ {
@@ -199,7 +218,7 @@ func TestValidateFailBlockOnCommittedEvidence(t *testing.T) {
 	var height int64 = 1
 	state, stateDB, _ := makeState(1, int(height))
 
-	blockExec := sm.NewBlockExecutor(stateDB, log.TestingLogger(), nil, nil, mockEvPoolAlwaysCommitted{})
+	blockExec := sm.NewBlockExecutor(store.NewBlockStore(dbm.NewMemDB()), stateDB, log.TestingLogger(), nil, nil, mockEvPoolAlwaysCommitted{})
 	// A block with a couple pieces of evidence passes.
 	block := makeBlock(state, height)
 	addr, _ := state.Validators.GetByIndex(0)
@@ -211,3 +230,151 @@ func TestValidateFailBlockOnCommittedEvidence(t *testing.T) {
 	require.Error(t, err)
 	require.IsType(t, err, &types.ErrEvidenceInvalid{})
 }
+
+func TestValidateBlockMaxTxBytes(t *testing.T) {
+	val, _ := types.RandValidator(false, 10)
+	params := types.DefaultConsensusParams()
+	params.Block.MaxTxBytes = 8
+	state, err := sm.MakeGenesisState(&types.GenesisDoc{
+		ChainID:         chainID,
+		ConsensusModule: "tendermint",
+		ConsensusParams: params,
+		Validators:      []types.GenesisValidator{{PubKey: val.PubKey, Power: val.VotingPower}},
+	})
+	require.NoError(t, err)
+	stateDB := dbm.NewMemDB()
+	sm.SaveState(stateDB, state)
+	blockExec := sm.NewBlockExecutor(store.NewBlockStore(dbm.NewMemDB()), stateDB, log.TestingLogger(), nil,
+		mock.Mempool{}, sm.MockEvidencePool{})
+	commit := types.NewCommit(types.BlockID{}, nil)
+	proposerAddr := state.Validators.GetProposer().Address
+
+	testCases := []struct {
+		name  string
+		txs   types.Txs
+		valid bool
+	}{
+		{"no txs", nil, true},
+		{"txs of the max size", types.Txs{types.Tx("12345678"), types.Tx("1")}, true},
+		{"tx over the max size", types.Txs{types.Tx("1"), types.Tx("123456789")}, false},
+	}
+	for _, tc := range testCases {
+		block, _ := state.MakeBlock(1, tc.txs, commit, nil, proposerAddr)
+		err := blockExec.ValidateBlock(state, block)
+		if tc.valid {
+			assert.NoError(t, err, tc.name)
+		} else {
+			assert.EqualError(t, err, "Block tx 1 size 9 exceeds the max tx size 8", tc.name)
+		}
+	}
+}
+
+func TestValidateBlockGas(t *testing.T) {
+	txGas := func(tx types.Tx) int64 { return int64(len(tx)) }
+	testCases := []struct {
+		name   string
+		maxGas int64
+		txGas  sm.TxGasFunc
+		txs    types.Txs
+		valid  bool
+	}{
+		{"unlimited", -1, txGas, types.Txs{types.Tx("12345678901")}, true},
+		{"no txs under 0", 0, txGas, nil, true},
+		{"tx over 0", 0, txGas, types.Txs{types.Tx("1")}, false},
+		{"exact fit", 10, txGas, types.Txs{types.Tx("12345"), types.Tx("12345")}, true},
+		{"over the max gas", 10, txGas, types.Txs{types.Tx("12345"), types.Tx("123456")}, false},
+		{"no tx gas", 10, nil, types.Txs{types.Tx("12345678901")}, true},
+	}
+	for _, module := range []string{"tendermint", "friday"} {
+		for _, tc := range testCases {
+			val, _ := types.RandValidator(false, 10)
+			params := types.DefaultConsensusParams()
+			if module == "friday" {
+				params = types.DefaultFridayConsensusParams()
+			}
+			params.Block.MaxGas = tc.maxGas
+			state, err := sm.MakeGenesisState(&types.GenesisDoc{
+				ChainID:         chainID,
+				ConsensusModule: module,
+				ConsensusParams: params,
+				Validators:      []types.GenesisValidator{{PubKey: val.PubKey, Power: val.VotingPower}},
+			})
+			require.NoError(t, err)
+			stateDB := dbm.NewMemDB()
+			sm.SaveState(stateDB, state)
+			blockExec := sm.NewBlockExecutor(store.NewBlockStore(dbm.NewMemDB()), stateDB, log.TestingLogger(), nil,
+				mock.Mempool{}, sm.MockEvidencePool{})
+			if tc.txGas != nil {
+				blockExec.SetTxGas(tc.txGas)
+			}
+
+			commit := types.NewCommit(types.BlockID{}, nil)
+			proposerAddr := state.Validators.GetProposer().Address
+			var block *types.Block
+			if module == "friday" {
+				block, _ = state.MakeBlockFromArgs(1, tc.txs, types.BlockID{}, 0, commit, nil, nil, proposerAddr,
+					state.Validators.Hash(), state.NextValidators.Hash(), nil, nil)
+			} else {
+				block, _ = state.MakeBlock(1, tc.txs, commit, nil, proposerAddr)
+			}
+			err = blockExec.ValidateBlock(state, block)
+			if tc.valid {
+				assert.NoError(t, err, "%s: %s", module, tc.name)
+			} else {
+				assert.EqualError(t, err, fmt.Sprintf("Block gas exceeds the max gas %d", tc.maxGas),
+					"%s: %s", module, tc.name)
+			}
+		}
+	}
+}
+
+func TestVerifyEvidenceAge(t *testing.T) {
+	now := tmtime.Now()
+	blockStore := store.NewBlockStore(dbm.NewMemDB())
+	for height := int64(1); height <= 2; height++ {
+		state, _, _ := makeState(1, int(height))
+		block := makeBlock(state, height)
+		block.Time = now.Add(time.Duration(height-3) * time.Minute)
+		blockStore.SaveBlock(block, block.MakePartSet(types.BlockPartSizeBytes), new(types.Commit), 1)
+	}
+
+	// a node upgraded from a version saving the block times in the state DB,
+	// claiming block 1 is fresh, and one synced from genesis without them
+	legacyState, legacyDB, _ := makeState(1, 3)
+	legacyDB.Set([]byte("blockTimeKey:1"), types.GetCodec().MustMarshalBinaryBare(now))
+	state, stateDB, _ := makeState(1, 3)
+	for _, s := range []*sm.State{&legacyState, &state} {
+		s.LastBlockTime = now
+		s.ConsensusParams.Evidence = types.EvidenceParams{MaxAge: 10, MaxAgeDurationMs: 90000}
+	}
+	addr, _ := state.Validators.GetByIndex(0)
+
+	testCases := []struct {
+		height  int64
+		expired bool
+	}{
+		{1, true},  // 2 minutes old
+		{2, false}, // 1 minute old
+	}
+	for _, tc := range testCases {
+		evidence := types.NewMockGoodEvidence(tc.height, 0, addr)
+		expired, err := sm.IsEvidenceExpired(blockStore, state.ConsensusParams.Evidence, evidence,
+			state.LastBlockHeight, state.LastBlockTime)
+		require.NoError(t, err)
+		assert.Equal(t, tc.expired, expired, "height %d", tc.height)
+
+		errLegacy := sm.VerifyEvidence(blockStore, legacyDB, legacyState, evidence)
+		err = sm.VerifyEvidence(blockStore, stateDB, state, evidence)
+		assert.Equal(t, tc.expired, err != nil, "height %d: %v", tc.height, err)
+		assert.Equal(t, err, errLegacy, "height %d", tc.height)
+	}
+
+	// without the block meta of the evidence height, fails closed
+	evidence := types.NewMockGoodEvidence(2, 0, addr)
+	emptyStore := store.NewBlockStore(dbm.NewMemDB())
+	_, err := sm.IsEvidenceExpired(emptyStore, state.ConsensusParams.Evidence, evidence,
+		state.LastBlockHeight, state.LastBlockTime)
+	assert.Error(t, err)
+	assert.Error(t, sm.VerifyEvidence(emptyStore, legacyDB, legacyState, evidence))
+	assert.Error(t, sm.VerifyEvidence(emptyStore, stateDB, state, evidence))
+}
@@ -0,0 +1,345 @@
+package store
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/pkg/errors"
+
+	dbm "github.com/tendermint/tm-db"
+
+	cmn "github.com/hdac-io/tendermint/libs/common"
+	sm "github.com/hdac-io/tendermint/state"
+	"github.com/hdac-io/tendermint/types"
+)
+
+const (
+	// how many archived heights are kept in memory after being fetched
+	archiveCacheSize = 8
+
+	// how many heights can wait for the upload before SaveBlock drops the notification
+	archiveQueueSize = 100
+)
+
+// ObjectStorage is a store of immutable objects, such as an S3 bucket.
+// Implementations must be safe for concurrent use.
+type ObjectStorage interface {
+	// Get returns the object stored under key, or an error if there is none.
+	Get(key string) ([]byte, error)
+	// Put stores value under key, replacing any existing object.
+	Put(key string, value []byte) error
+}
+
+// archivedBlock is everything the BlockStore knows about a height,
+// stored as a single object.
+type archivedBlock struct {
+	Meta       *types.BlockMeta `json:"meta"`
+	Parts      []*types.Part    `json:"parts"`
+	Commit     *types.Commit    `json:"commit"`
+	SeenCommit *types.Commit    `json:"seen_commit"`
+}
+
+/*
+ArchiveBlockStore is a BlockStore for archival nodes which keeps only the
+recent heights in the local DB and moves the older ones to an ObjectStorage,
+from which they are fetched lazily when loaded.
+
+A height is moved once it is more than keepRecent heights below the last
+height whose commit was saved, i.e. the friday ULB window (the commitDistance
+of SaveBlock) always stays local. The block metas are never moved, since the
+evidence is aged from the time of their headers whatever the ObjectStorage
+answers. The uploads happen in the background and the progress is persisted,
+so they resume after a restart.
+*/
+type ArchiveBlockStore struct {
+	cmn.BaseService
+
+	*BlockStore
+	storage    ObjectStorage
+	keepRecent int64
+
+	// protects archivedHeight, and the local data of the heights being archived
+	mtx            sync.RWMutex
+	archivedHeight int64
+
+	// highest height that can be archived, sent by SaveBlock
+	archivableCh chan int64
+
+	cacheMtx   sync.Mutex
+	cache      map[int64]*archivedBlock
+	cacheOrder []int64
+}
+
+var _ sm.BlockStore = (*ArchiveBlockStore)(nil)
+
+// NewArchiveBlockStore returns a new ArchiveBlockStore which keeps keepRecent
+// heights (on top of the ULB window) in db and the older ones in storage.
+func NewArchiveBlockStore(db dbm.DB, storage ObjectStorage, keepRecent int64) *ArchiveBlockStore {
+	as := &ArchiveBlockStore{
+		BlockStore:     NewBlockStore(db),
+		storage:        storage,
+		keepRecent:     keepRecent,
+		archivedHeight: LoadArchiveStateJSON(db).Height,
+		archivableCh:   make(chan int64, archiveQueueSize),
+		cache:          make(map[int64]*archivedBlock),
+	}
+	as.BaseService = *cmn.NewBaseService(nil, "ArchiveBlockStore", as)
+	return as
+}
+
+// OnStart implements cmn.Service by starting the upload routine.
+func (as *ArchiveBlockStore) OnStart() error {
+	go as.archiveRoutine()
+	return nil
+}
+
+// ArchivedHeight returns the highest height moved to the ObjectStorage.
+func (as *ArchiveBlockStore) ArchivedHeight() int64 {
+	as.mtx.RLock()
+	defer as.mtx.RUnlock()
+	return as.archivedHeight
+}
+
+// LoadBlock implements sm.BlockStore.
+func (as *ArchiveBlockStore) LoadBlock(height int64) *types.Block {
+	as.mtx.RLock()
+	if height > as.archivedHeight {
+		defer as.mtx.RUnlock()
+		return as.BlockStore.LoadBlock(height)
+	}
+	as.mtx.RUnlock()
+
+	ab := as.loadArchived(height)
+	if ab == nil {
+		return nil
+	}
+	buf := []byte{}
+	for _, part := range ab.Parts {
+		buf = append(buf, part.Bytes...)
+	}
+	var block = new(types.Block)
+	err := cdc.UnmarshalBinaryLengthPrefixed(buf, block)
+	if err != nil {
+		panic(errors.Wrap(err, "Error reading archived block"))
+	}
+	return block
+}
+
+// LoadBlockPart implements sm.BlockStore.
+func (as *ArchiveBlockStore) LoadBlockPart(height int64, index int) *types.Part {
+	as.mtx.RLock()
+	if height > as.archivedHeight {
+		defer as.mtx.RUnlock()
+		return as.BlockStore.LoadBlockPart(height, index)
+	}
+	as.mtx.RUnlock()
+
+	ab := as.loadArchived(height)
+	if ab == nil || index < 0 || index >= len(ab.Parts) {
+		return nil
+	}
+	return ab.Parts[index]
+}
+
+// LoadBlockMeta implements sm.BlockStore.
+func (as *ArchiveBlockStore) LoadBlockMeta(height int64) *types.BlockMeta {
+	// the heights archived before the metas were kept are fetched
+	if meta := as.BlockStore.LoadBlockMeta(height); meta != nil {
+		return meta
+	}
+	as.mtx.RLock()
+	if height > as.archivedHeight {
+		defer as.mtx.RUnlock()
+		return nil
+	}
+	as.mtx.RUnlock()
+
+	ab := as.loadArchived(height)
+	if ab == nil {
+		return nil
+	}
+	return ab.Meta
+}
+
+// LoadBlockCommit implements sm.BlockStore.
+func (as *ArchiveBlockStore) LoadBlockCommit(height int64) *types.Commit {
+	as.mtx.RLock()
+	if height > as.archivedHeight {
+		defer as.mtx.RUnlock()
+		return as.BlockStore.LoadBlockCommit(height)
+	}
+	as.mtx.RUnlock()
+
+	ab := as.loadArchived(height)
+	if ab == nil {
+		return nil
+	}
+	return ab.Commit
+}
+
+// LoadSeenCommit implements sm.BlockStore.
+func (as *ArchiveBlockStore) LoadSeenCommit(height int64) *types.Commit {
+	as.mtx.RLock()
+	if height > as.archivedHeight {
+		defer as.mtx.RUnlock()
+		return as.BlockStore.LoadSeenCommit(height)
+	}
+	as.mtx.RUnlock()
+
+	ab := as.loadArchived(height)
+	if ab == nil {
+		return nil
+	}
+	return ab.SeenCommit
+}
+
+// SaveBlock implements sm.BlockStore. The block is saved locally and
+// the heights which left the window are queued for the upload.
+func (as *ArchiveBlockStore) SaveBlock(block *types.Block, blockParts *types.PartSet, seenCommit *types.Commit, commitDistance int64) {
+	as.BlockStore.SaveBlock(block, blockParts, seenCommit, commitDistance)
+
+	// the commit of heights above height-commitDistance isn't saved yet
+	archivable := block.Height - commitDistance - as.keepRecent
+	if archivable <= 0 {
+		return
+	}
+	select {
+	case as.archivableCh <- archivable:
+	default:
+		// the routine is busy, it will catch up with the next block
+	}
+}
+
+func (as *ArchiveBlockStore) archiveRoutine() {
+	for {
+		select {
+		case archivable := <-as.archivableCh:
+			for height := as.ArchivedHeight() + 1; height <= archivable; height++ {
+				if err := as.archive(height); err != nil {
+					as.Logger.Error("Failed to archive block, will retry with the next block", "height", height, "err", err)
+					break
+				}
+			}
+		case <-as.Quit():
+			return
+		}
+	}
+}
+
+// archive uploads everything stored for height, then removes it from the local DB.
+// If anything is missing locally, nothing is uploaded and the local data is kept.
+func (as *ArchiveBlockStore) archive(height int64) error {
+	meta := as.BlockStore.LoadBlockMeta(height)
+	if meta == nil {
+		return fmt.Errorf("no block meta for height %d", height)
+	}
+	ab := &archivedBlock{
+		Meta:       meta,
+		Parts:      make([]*types.Part, meta.BlockID.PartsHeader.Total),
+		Commit:     as.BlockStore.LoadBlockCommit(height),
+		SeenCommit: as.BlockStore.LoadSeenCommit(height),
+	}
+	if ab.Commit == nil {
+		return fmt.Errorf("no block commit for height %d", height)
+	}
+	if ab.SeenCommit == nil {
+		return fmt.Errorf("no seen commit for height %d", height)
+	}
+	for i := range ab.Parts {
+		ab.Parts[i] = as.BlockStore.LoadBlockPart(height, i)
+		if ab.Parts[i] == nil {
+			return fmt.Errorf("no block part %d for height %d", i, height)
+		}
+	}
+
+	if err := as.storage.Put(calcArchivedBlockKey(height), cdc.MustMarshalBinaryBare(ab)); err != nil {
+		return err
+	}
+
+	as.mtx.Lock()
+	defer as.mtx.Unlock()
+	as.archivedHeight = height
+	ArchiveStateJSON{Height: height}.Save(as.db)
+
+	batch := as.db.NewBatch()
+	defer batch.Close()
+	for i := range ab.Parts {
+		batch.Delete(calcBlockPartKey(height, i))
+	}
+	batch.Delete(calcBlockCommitKey(height))
+	batch.Delete(calcSeenCommitKey(height))
+	batch.WriteSync()
+
+	as.Logger.Debug("Archived block", "height", height)
+	return nil
+}
+
+// loadArchived fetches the archived height from the cache or the ObjectStorage.
+func (as *ArchiveBlockStore) loadArchived(height int64) *archivedBlock {
+	as.cacheMtx.Lock()
+	if ab, ok := as.cache[height]; ok {
+		as.cacheMtx.Unlock()
+		return ab
+	}
+	as.cacheMtx.Unlock()
+
+	bz, err := as.storage.Get(calcArchivedBlockKey(height))
+	if err != nil {
+		as.Logger.Error("Failed to fetch archived block", "height", height, "err", err)
+		return nil
+	}
+	ab := new(archivedBlock)
+	if err := cdc.UnmarshalBinaryBare(bz, ab); err != nil {
+		panic(errors.Wrap(err, "Error reading archived block"))
+	}
+
+	as.cacheMtx.Lock()
+	defer as.cacheMtx.Unlock()
+	if _, ok := as.cache[height]; !ok {
+		if len(as.cacheOrder) >= archiveCacheSize {
+			delete(as.cache, as.cacheOrder[0])
+			as.cacheOrder = as.cacheOrder[1:]
+		}
+		as.cache[height] = ab
+		as.cacheOrder = append(as.cacheOrder, height)
+	}
+	return ab
+}
+
+func calcArchivedBlockKey(height int64) string {
+	// zero padded, so the objects are listed in order
+	return fmt.Sprintf("blocks/%020d", height)
+}
+
+//-----------------------------------------------------------------------------
+
+var archiveStateKey = []byte("blockStoreArchive")
+
+// ArchiveStateJSON is the ArchiveBlockStore state JSON structure.
+type ArchiveStateJSON struct {
+	Height int64 `json:"height"`
+}
+
+// Save persists the archive state to the database as JSON.
+func (asj ArchiveStateJSON) Save(db dbm.DB) {
+	bytes, err := cdc.MarshalJSON(asj)
+	if err != nil {
+		panic(fmt.Sprintf("Could not marshal state bytes: %v", err))
+	}
+	db.SetSync(archiveStateKey, bytes)
+}
+
+// LoadArchiveStateJSON returns the ArchiveStateJSON as loaded from disk.
+// If no ArchiveStateJSON was previously persisted, it returns the zero value.
+func LoadArchiveStateJSON(db dbm.DB) ArchiveStateJSON {
+	bytes := db.Get(archiveStateKey)
+	if len(bytes) == 0 {
+		return ArchiveStateJSON{}
+	}
+	asj := ArchiveStateJSON{}
+	err := cdc.UnmarshalJSON(bytes, &asj)
+	if err != nil {
+		panic(fmt.Sprintf("Could not unmarshal bytes: %X", bytes))
+	}
+	return asj
+}
@@ -0,0 +1,154 @@
+package store
+
+import (
+	"bytes"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	dbm "github.com/tendermint/tm-db"
+
+	sm "github.com/hdac-io/tendermint/state"
+	"github.com/hdac-io/tendermint/types"
+	tmtime "github.com/hdac-io/tendermint/types/time"
+)
+
+type memObjectStorage struct {
+	mtx     sync.Mutex
+	objects map[string][]byte
+}
+
+func newMemObjectStorage() *memObjectStorage {
+	return &memObjectStorage{objects: make(map[string][]byte)}
+}
+
+func (s *memObjectStorage) Get(key string) ([]byte, error) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	value, ok := s.objects[key]
+	if !ok {
+		return nil, fmt.Errorf("no object %s", key)
+	}
+	return value, nil
+}
+
+func (s *memObjectStorage) Put(key string, value []byte) error {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	s.objects[key] = value
+	return nil
+}
+
+func makeArchiveTestState(t *testing.T) sm.State {
+	genDoc := &types.GenesisDoc{
+		ChainID:         "archive_test",
+		ConsensusModule: "friday",
+		Validators:      []types.GenesisValidator{{PubKey: types.NewMockPV().GetPubKey(), Power: 10}},
+	}
+	require.NoError(t, genDoc.ValidateAndComplete())
+	state, err := sm.MakeGenesisState(genDoc)
+	require.NoError(t, err)
+	return state
+}
+
+// saveArchiveTestBlocks saves numBlocks blocks, the LastCommit of each being
+// the commit of the height commitDistance below, and returns them by height.
+func saveArchiveTestBlocks(as *ArchiveBlockStore, state sm.State, numBlocks, commitDistance int64) []*types.Block {
+	blocks := make([]*types.Block, numBlocks+1)
+	for height := int64(1); height <= numBlocks; height++ {
+		blocks[height] = makeBlock(height, state, new(types.Commit))
+		if height > commitDistance {
+			blocks[height].LastCommit = makeTestCommit(height-commitDistance, tmtime.Now())
+		}
+		as.SaveBlock(blocks[height], blocks[height].MakePartSet(2), makeTestCommit(height, tmtime.Now()), commitDistance)
+	}
+	return blocks
+}
+
+func TestArchiveBlockStore(t *testing.T) {
+	state := makeArchiveTestState(t)
+
+	const (
+		numBlocks      = 10
+		commitDistance = 2
+		keepRecent     = 3
+	)
+
+	db := dbm.NewMemDB()
+	storage := newMemObjectStorage()
+	as := NewArchiveBlockStore(db, storage, keepRecent)
+	require.NoError(t, as.Start())
+	defer as.Stop()
+
+	blocks := saveArchiveTestBlocks(as, state, numBlocks, commitDistance)
+
+	// only the heights whose commit is saved and that are out of keepRecent are moved
+	wantArchived := int64(numBlocks - commitDistance - keepRecent)
+	require.Eventually(t, func() bool {
+		return as.ArchivedHeight() == wantArchived
+	}, 5*time.Second, 10*time.Millisecond)
+	assert.Len(t, storage.objects, int(wantArchived))
+
+	for height := int64(1); height <= numBlocks; height++ {
+		local := db.Get(calcBlockPartKey(height, 0)) != nil
+		assert.Equal(t, height > wantArchived, local, "height %d", height)
+		assert.NotNil(t, db.Get(calcBlockMetaKey(height)), "the block metas stay local, height %d", height)
+
+		block := as.LoadBlock(height)
+		require.NotNil(t, block, "height %d", height)
+		assert.Equal(t, blocks[height].Hash(), block.Hash(), "height %d", height)
+		assert.NotNil(t, as.LoadBlockMeta(height), "height %d", height)
+		assert.NotNil(t, as.LoadBlockPart(height, 0), "height %d", height)
+		assert.NotNil(t, as.LoadSeenCommit(height), "height %d", height)
+	}
+	assert.Nil(t, as.LoadBlock(numBlocks+1))
+
+	// the progress survives a restart
+	assert.Equal(t, wantArchived, NewArchiveBlockStore(db, storage, keepRecent).ArchivedHeight())
+}
+
+func TestArchiveBlockStoreMissingData(t *testing.T) {
+	state := makeArchiveTestState(t)
+	const height = 2
+
+	testCases := []struct {
+		name string
+		key  []byte
+		err  string
+	}{
+		{"block commit", calcBlockCommitKey(height), "no block commit for height 2"},
+		{"seen commit", calcSeenCommitKey(height), "no seen commit for height 2"},
+		{"block part", calcBlockPartKey(height, 1), "no block part 1 for height 2"},
+		{"block meta", calcBlockMetaKey(height), "no block meta for height 2"},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			db := dbm.NewMemDB()
+			storage := newMemObjectStorage()
+			// not started, the heights are archived by the test
+			as := NewArchiveBlockStore(db, storage, 0)
+			saveArchiveTestBlocks(as, state, 5, 1)
+			require.NoError(t, as.archive(1))
+			db.Delete(tc.key)
+
+			err := as.archive(height)
+			require.Error(t, err)
+			assert.Equal(t, tc.err, err.Error())
+
+			// nothing is uploaded, and the local data is kept
+			assert.Len(t, storage.objects, 1)
+			assert.EqualValues(t, 1, as.ArchivedHeight())
+			assert.EqualValues(t, 1, LoadArchiveStateJSON(db).Height)
+			for _, key := range [][]byte{calcBlockMetaKey(height), calcBlockCommitKey(height),
+				calcSeenCommitKey(height), calcBlockPartKey(height, 0), calcBlockPartKey(height, 1)} {
+				if !bytes.Equal(key, tc.key) {
+					assert.NotNil(t, db.Get(key), "%s", key)
+				}
+			}
+		})
+	}
+}
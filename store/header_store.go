@@ -0,0 +1,212 @@
+package store
+
+import (
+	"bytes"
+	"fmt"
+	"sync"
+
+	"github.com/pkg/errors"
+
+	dbm "github.com/tendermint/tm-db"
+
+	sm "github.com/hdac-io/tendermint/state"
+	"github.com/hdac-io/tendermint/types"
+)
+
+/*
+HeaderStore is a simple low level store for the headers of a chain, with
+their commits and validator sets, but without the block bodies. It is used by
+the nodes which only sync the headers (fastsync version "headers"), e.g.
+relayers and light client proxies which never need the transactions.
+
+The contiguous height of the headers is kept, like BlockStore does. The
+validator set of a height is only saved when it differs from the one of the
+previous height.
+*/
+type HeaderStore struct {
+	db dbm.DB
+
+	mtx    sync.RWMutex
+	height int64
+}
+
+// NewHeaderStore returns a new HeaderStore with the given DB,
+// initialized to the last height that was committed to the DB.
+func NewHeaderStore(db dbm.DB) *HeaderStore {
+	hsjson := LoadHeaderStoreStateJSON(db)
+	return &HeaderStore{
+		height: hsjson.Height,
+		db:     db,
+	}
+}
+
+// Height returns the last known contiguous header height.
+func (hs *HeaderStore) Height() int64 {
+	hs.mtx.RLock()
+	defer hs.mtx.RUnlock()
+	return hs.height
+}
+
+// LoadSignedHeader returns the header at the given height with its commit.
+// If no header is found for that height, it returns nil.
+func (hs *HeaderStore) LoadSignedHeader(height int64) *types.SignedHeader {
+	bz := hs.db.Get(calcHeaderKey(height))
+	if len(bz) == 0 {
+		return nil
+	}
+	var sh = new(types.SignedHeader)
+	err := cdc.UnmarshalBinaryBare(bz, sh)
+	if err != nil {
+		panic(errors.Wrap(err, "Error reading signed header"))
+	}
+	return sh
+}
+
+// headerValidatorsInfo is the validator set of a height, or the height it
+// was last saved at.
+type headerValidatorsInfo struct {
+	ValidatorSet      *types.ValidatorSet
+	LastHeightChanged int64
+}
+
+// LoadValidators returns the validator set of the given height.
+// If no header is found for that height, it returns nil.
+func (hs *HeaderStore) LoadValidators(height int64) *types.ValidatorSet {
+	info := hs.loadValidatorsInfo(height)
+	if info == nil {
+		return nil
+	}
+	if info.ValidatorSet == nil {
+		info = hs.loadValidatorsInfo(info.LastHeightChanged)
+		if info == nil || info.ValidatorSet == nil {
+			panic(fmt.Sprintf("Couldn't find the validators of height %d", height))
+		}
+	}
+	return info.ValidatorSet
+}
+
+func (hs *HeaderStore) loadValidatorsInfo(height int64) *headerValidatorsInfo {
+	bz := hs.db.Get(calcHeaderValidatorsKey(height))
+	if len(bz) == 0 {
+		return nil
+	}
+	var info = new(headerValidatorsInfo)
+	err := cdc.UnmarshalBinaryBare(bz, info)
+	if err != nil {
+		panic(errors.Wrap(err, "Error reading header validators"))
+	}
+	return info
+}
+
+// SaveSignedHeader persists the given header, its commit and its validator
+// set. The header must be at the height after Height(), and verified with
+// VerifySignedHeader.
+func (hs *HeaderStore) SaveSignedHeader(sh *types.SignedHeader, validators *types.ValidatorSet) {
+	if sh == nil || sh.Header == nil || sh.Commit == nil {
+		panic("HeaderStore can only save a complete signed header")
+	}
+	height := sh.Height
+	if g, w := height, hs.Height()+1; g != w {
+		panic(fmt.Sprintf("HeaderStore can only save contiguous headers. Wanted %v, got %v", w, g))
+	}
+
+	info := &headerValidatorsInfo{LastHeightChanged: height}
+	if prev := hs.loadValidatorsInfo(height - 1); prev != nil &&
+		bytes.Equal(hs.LoadValidators(height-1).Hash(), validators.Hash()) {
+		info.LastHeightChanged = prev.LastHeightChanged
+	} else {
+		info.ValidatorSet = validators
+	}
+
+	hs.db.Set(calcHeaderKey(height), cdc.MustMarshalBinaryBare(sh))
+	hs.db.Set(calcHeaderValidatorsKey(height), cdc.MustMarshalBinaryBare(info))
+	HeaderStoreStateJSON{Height: height}.Save(hs.db)
+
+	hs.mtx.Lock()
+	hs.height = height
+	hs.mtx.Unlock()
+}
+
+// VerifySignedHeader verifies the header at the height after Height() against
+// the previous one and the genesis state of the chain: it must link to the
+// previous header, validators must be its validator set (the validator
+// changes apply after the ULB window, see verifyValidators), and its commit
+// must be signed by +2/3 of them.
+func (hs *HeaderStore) VerifySignedHeader(genState sm.State, sh *types.SignedHeader,
+	validators *types.ValidatorSet) error {
+
+	if sh == nil || validators == nil {
+		return errors.New("incomplete signed header")
+	}
+	if err := sh.ValidateBasic(genState.ChainID); err != nil {
+		return err
+	}
+	height := sh.Height
+	if height != hs.Height()+1 {
+		return fmt.Errorf("expected height %d, got %d", hs.Height()+1, height)
+	}
+
+	var (
+		prevHeader     *types.Header
+		prevValidators *types.ValidatorSet
+	)
+	if height > 1 {
+		prev := hs.LoadSignedHeader(height - 1)
+		if prev == nil {
+			return fmt.Errorf("the header at height %d is missing", height-1)
+		}
+		if !sh.LastBlockID.Equals(prev.Commit.BlockID) {
+			return fmt.Errorf("wrong LastBlockID, expected %v, got %v", prev.Commit.BlockID, sh.LastBlockID)
+		}
+		prevHeader, prevValidators = prev.Header, hs.LoadValidators(height-1)
+	}
+	if err := verifyValidators(sh.Header, validators, genState, commitDistance(genState),
+		prevHeader, prevValidators); err != nil {
+		return err
+	}
+	if err := validators.VerifyCommit(genState.ChainID, sh.Commit.BlockID, height, sh.Commit); err != nil {
+		return errors.Wrap(err, "invalid commit")
+	}
+	return nil
+}
+
+//-----------------------------------------------------------------------------
+
+func calcHeaderKey(height int64) []byte {
+	return []byte(fmt.Sprintf("HH:%v", height))
+}
+
+func calcHeaderValidatorsKey(height int64) []byte {
+	return []byte(fmt.Sprintf("HV:%v", height))
+}
+
+var headerStoreKey = []byte("headerStore")
+
+// HeaderStoreStateJSON is the header store state JSON structure.
+type HeaderStoreStateJSON struct {
+	Height int64 `json:"height"`
+}
+
+// Save persists the header store state to the database as JSON.
+func (hsj HeaderStoreStateJSON) Save(db dbm.DB) {
+	bz, err := cdc.MarshalJSON(hsj)
+	if err != nil {
+		panic(fmt.Sprintf("Could not marshal state bytes: %v", err))
+	}
+	db.SetSync(headerStoreKey, bz)
+}
+
+// LoadHeaderStoreStateJSON returns the HeaderStoreStateJSON as loaded from disk.
+// If no HeaderStoreStateJSON was previously persisted, it returns the zero value.
+func LoadHeaderStoreStateJSON(db dbm.DB) HeaderStoreStateJSON {
+	bz := db.Get(headerStoreKey)
+	if len(bz) == 0 {
+		return HeaderStoreStateJSON{}
+	}
+	hsj := HeaderStoreStateJSON{}
+	err := cdc.UnmarshalJSON(bz, &hsj)
+	if err != nil {
+		panic(fmt.Sprintf("Could not unmarshal bytes: %X", bz))
+	}
+	return hsj
+}
@@ -0,0 +1,54 @@
+package store
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	dbm "github.com/tendermint/tm-db"
+
+	sm "github.com/hdac-io/tendermint/state"
+	"github.com/hdac-io/tendermint/types"
+)
+
+func TestHeaderStoreSyncHeaders(t *testing.T) {
+	genState, bs, stateDB := makeSignedChain(t, numBlocks, lenULB)
+
+	signedHeader := func(height int64) (*types.SignedHeader, *types.ValidatorSet) {
+		validators, err := sm.LoadValidators(stateDB, height)
+		require.NoError(t, err)
+		meta := bs.LoadBlockMeta(height)
+		return &types.SignedHeader{Header: &meta.Header, Commit: bs.LoadSeenCommit(height)}, validators
+	}
+
+	db := dbm.NewMemDB()
+	hs := NewHeaderStore(db)
+	for h := int64(1); h <= numBlocks; h++ {
+		sh, validators := signedHeader(h)
+		require.NoError(t, hs.VerifySignedHeader(genState, sh, validators), "height %d", h)
+		hs.SaveSignedHeader(sh, validators)
+	}
+	assert.EqualValues(t, numBlocks, NewHeaderStore(db).Height())
+	for h := int64(1); h <= numBlocks; h++ {
+		sh, validators := signedHeader(h)
+		assert.Equal(t, sh.Hash(), hs.LoadSignedHeader(h).Hash(), "height %d", h)
+		assert.Equal(t, validators.Hash(), hs.LoadValidators(h).Hash(), "height %d", h)
+	}
+	assert.Nil(t, hs.LoadSignedHeader(numBlocks+1))
+	assert.Nil(t, hs.LoadValidators(numBlocks+1))
+
+	// the headers must be contiguous, linked, and signed by their validators
+	hs = NewHeaderStore(dbm.NewMemDB())
+	sh, validators := signedHeader(2)
+	assert.Error(t, hs.VerifySignedHeader(genState, sh, validators))
+	sh, validators = signedHeader(1)
+	otherValidators, _ := types.RandValidatorSet(4, 10)
+	assert.Error(t, hs.VerifySignedHeader(genState, sh, otherValidators))
+	otherCommit := *sh
+	otherCommit.Commit = bs.LoadSeenCommit(2)
+	assert.Error(t, hs.VerifySignedHeader(genState, &otherCommit, validators))
+	require.NoError(t, hs.VerifySignedHeader(genState, sh, validators))
+	hs.SaveSignedHeader(sh, validators)
+	sh, validators = signedHeader(3)
+	assert.Error(t, hs.VerifySignedHeader(genState, sh, validators))
+}
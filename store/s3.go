@@ -0,0 +1,154 @@
+package store
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+const s3RequestTimeout = 30 * time.Second
+
+// S3Storage is an ObjectStorage backed by a bucket of S3 or of any
+// S3 compatible object storage. Requests are signed with AWS Signature
+// Version 4 and use path-style addressing.
+type S3Storage struct {
+	endpoint  *url.URL
+	region    string
+	bucket    string
+	prefix    string
+	accessKey string
+	secretKey string
+
+	client *http.Client
+}
+
+var _ ObjectStorage = (*S3Storage)(nil)
+
+// NewS3Storage returns a new S3Storage storing the objects in bucket under prefix.
+// endpoint is the base URL of the service, e.g. https://s3.us-east-1.amazonaws.com.
+func NewS3Storage(endpoint, region, bucket, prefix, accessKey, secretKey string) (*S3Storage, error) {
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return nil, fmt.Errorf("invalid S3 endpoint %q: %v", endpoint, err)
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return nil, fmt.Errorf("invalid S3 endpoint %q: scheme must be http or https", endpoint)
+	}
+	if bucket == "" {
+		return nil, fmt.Errorf("S3 bucket is empty")
+	}
+	return &S3Storage{
+		endpoint:  u,
+		region:    region,
+		bucket:    bucket,
+		prefix:    prefix,
+		accessKey: accessKey,
+		secretKey: secretKey,
+		client:    &http.Client{Timeout: s3RequestTimeout},
+	}, nil
+}
+
+// Get implements ObjectStorage.
+func (s *S3Storage) Get(key string) ([]byte, error) {
+	res, err := s.do(http.MethodGet, key, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close() // nolint: errcheck
+
+	body, err := ioutil.ReadAll(res.Body)
+	if err != nil {
+		return nil, err
+	}
+	if res.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("GET %s: %s: %s", key, res.Status, body)
+	}
+	return body, nil
+}
+
+// Put implements ObjectStorage.
+func (s *S3Storage) Put(key string, value []byte) error {
+	res, err := s.do(http.MethodPut, key, value)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close() // nolint: errcheck
+
+	if res.StatusCode != http.StatusOK {
+		body, _ := ioutil.ReadAll(res.Body)
+		return fmt.Errorf("PUT %s: %s: %s", key, res.Status, body)
+	}
+	return nil
+}
+
+func (s *S3Storage) do(method, key string, body []byte) (*http.Response, error) {
+	u := *s.endpoint
+	u.Path = strings.TrimSuffix(u.Path, "/") + "/" + s.bucket + "/" + s.prefix + key
+
+	req, err := http.NewRequest(method, u.String(), bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	s.sign(req, body, time.Now().UTC())
+	return s.client.Do(req)
+}
+
+// sign adds the AWS Signature Version 4 headers to req.
+// See https://docs.aws.amazon.com/general/latest/gr/sigv4_signing.html
+func (s *S3Storage) sign(req *http.Request, body []byte, now time.Time) {
+	amzDate := now.Format("20060102T150405Z")
+	date := now.Format("20060102")
+	payloadHash := sha256Hex(body)
+
+	req.Header.Set("x-amz-date", amzDate)
+	req.Header.Set("x-amz-content-sha256", payloadHash)
+
+	const signedHeaders = "host;x-amz-content-sha256;x-amz-date"
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		req.URL.EscapedPath(),
+		req.URL.RawQuery,
+		"host:" + req.URL.Host,
+		"x-amz-content-sha256:" + payloadHash,
+		"x-amz-date:" + amzDate,
+		"",
+		signedHeaders,
+		payloadHash,
+	}, "\n")
+
+	scope := date + "/" + s.region + "/s3/aws4_request"
+	stringToSign := strings.Join([]string{
+		"AWS4-HMAC-SHA256",
+		amzDate,
+		scope,
+		sha256Hex([]byte(canonicalRequest)),
+	}, "\n")
+
+	signingKey := hmacSHA256([]byte("AWS4"+s.secretKey), []byte(date))
+	signingKey = hmacSHA256(signingKey, []byte(s.region))
+	signingKey = hmacSHA256(signingKey, []byte("s3"))
+	signingKey = hmacSHA256(signingKey, []byte("aws4_request"))
+	signature := hex.EncodeToString(hmacSHA256(signingKey, []byte(stringToSign)))
+
+	req.Header.Set("Authorization", fmt.Sprintf(
+		"AWS4-HMAC-SHA256 Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		s.accessKey, scope, signedHeaders, signature))
+}
+
+func sha256Hex(data []byte) string {
+	hash := sha256.Sum256(data)
+	return hex.EncodeToString(hash[:])
+}
+
+func hmacSHA256(key, data []byte) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write(data) // nolint: errcheck
+	return mac.Sum(nil)
+}
@@ -0,0 +1,65 @@
+package store
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestS3StorageGetPut(t *testing.T) {
+	var (
+		mtx     sync.Mutex
+		objects = make(map[string][]byte)
+	)
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		auth := r.Header.Get("Authorization")
+		if !strings.HasPrefix(auth, "AWS4-HMAC-SHA256 Credential=access/") ||
+			!strings.Contains(auth, "/eu-west-1/s3/aws4_request") ||
+			r.Header.Get("x-amz-date") == "" || r.Header.Get("x-amz-content-sha256") == "" {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+
+		mtx.Lock()
+		defer mtx.Unlock()
+		switch r.Method {
+		case http.MethodPut:
+			body, _ := ioutil.ReadAll(r.Body)
+			if r.Header.Get("x-amz-content-sha256") != sha256Hex(body) {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			objects[r.URL.Path] = body
+		case http.MethodGet:
+			body, ok := objects[r.URL.Path]
+			if !ok {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			w.Write(body) // nolint: errcheck
+		}
+	}))
+	defer ts.Close()
+
+	s, err := NewS3Storage(ts.URL, "eu-west-1", "bucket", "chain/", "access", "secret")
+	require.NoError(t, err)
+
+	require.NoError(t, s.Put("blocks/1", []byte("block")))
+	assert.Contains(t, objects, "/bucket/chain/blocks/1")
+
+	value, err := s.Get("blocks/1")
+	require.NoError(t, err)
+	assert.Equal(t, []byte("block"), value)
+
+	_, err = s.Get("blocks/2")
+	assert.Error(t, err)
+
+	_, err = NewS3Storage("s3.amazonaws.com", "eu-west-1", "bucket", "", "access", "secret")
+	assert.Error(t, err)
+}
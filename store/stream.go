@@ -0,0 +1,274 @@
+package store
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+
+	"github.com/pkg/errors"
+
+	dbm "github.com/tendermint/tm-db"
+
+	"github.com/hdac-io/tendermint/libs/log"
+	sm "github.com/hdac-io/tendermint/state"
+	"github.com/hdac-io/tendermint/types"
+)
+
+// a record holds a block, its seen commit and a validator set
+const maxBlockStreamRecordBytes = 2 * types.MaxBlockSizeBytes
+
+/*
+A block stream is a height range of a chain, as written by ExportBlocks and
+read by ImportBlocks: a BlockStreamHeader followed by a BlockStreamRecord for
+every height of the range, each of them amino encoded and length prefixed.
+
+It is self-contained so it can be imported in any sm.BlockStore, e.g. to move
+a chain to another block store backend.
+*/
+
+// BlockStreamHeader is the first record of a block stream.
+type BlockStreamHeader struct {
+	ChainID         string `json:"chain_id"`
+	ConsensusModule string `json:"consensus_module"`
+	// the commit of a height is in the LastCommit of the block CommitDistance
+	// heights above, see sm.BlockStore.SaveBlock
+	CommitDistance int64 `json:"commit_distance"`
+	FromHeight     int64 `json:"from_height"`
+	ToHeight       int64 `json:"to_height"`
+}
+
+// BlockStreamRecord is a height of a block stream.
+type BlockStreamRecord struct {
+	Block      *types.Block        `json:"block"`
+	SeenCommit *types.Commit       `json:"seen_commit"`
+	Validators *types.ValidatorSet `json:"validators"`
+}
+
+// commitDistance returns the commit distance of the blocks of the chain.
+func commitDistance(state sm.State) int64 {
+	if state.Version.Consensus.Module == "friday" {
+		return state.ConsensusParams.Block.LenULB
+	}
+	return 1
+}
+
+// ExportBlocks writes the heights from to to of bs to w as a block stream.
+// The validators are loaded from stateDB, which must be the state DB of the
+// node bs belongs to.
+func ExportBlocks(w io.Writer, bs sm.BlockStoreRPC, stateDB dbm.DB, from, to int64) error {
+	state := sm.LoadState(stateDB)
+	if state.IsEmpty() {
+		return errors.New("no state found")
+	}
+	if from < 1 || from > to || to > bs.Height() {
+		return fmt.Errorf("invalid height range %d-%d, the block store has the heights 1-%d", from, to, bs.Height())
+	}
+
+	bw := bufio.NewWriter(w)
+	header := BlockStreamHeader{
+		ChainID:         state.ChainID,
+		ConsensusModule: state.Version.Consensus.Module,
+		CommitDistance:  commitDistance(state),
+		FromHeight:      from,
+		ToHeight:        to,
+	}
+	if _, err := cdc.MarshalBinaryLengthPrefixedWriter(bw, header); err != nil {
+		return errors.Wrap(err, "could not write the header")
+	}
+	for height := from; height <= to; height++ {
+		record := BlockStreamRecord{
+			Block:      bs.LoadBlock(height),
+			SeenCommit: bs.LoadSeenCommit(height),
+		}
+		if record.Block == nil || record.SeenCommit == nil {
+			return fmt.Errorf("block or seen commit at height %d not found", height)
+		}
+		validators, err := sm.LoadValidators(stateDB, height)
+		if err != nil {
+			return errors.Wrapf(err, "could not load the validators of height %d", height)
+		}
+		record.Validators = validators
+		if _, err := cdc.MarshalBinaryLengthPrefixedWriter(bw, record); err != nil {
+			return errors.Wrapf(err, "could not write height %d", height)
+		}
+	}
+	return bw.Flush()
+}
+
+// importedHeight is what ImportBlocks remembers of the heights imported last,
+// to verify the commits of the following ones.
+type importedHeight struct {
+	header     types.Header
+	blockID    types.BlockID
+	validators *types.ValidatorSet
+}
+
+// ImportBlocks reads a block stream from r and saves its blocks in bs, after
+// Height(). The heights already in bs are skipped, so an interrupted import
+// can be resumed. It returns the last height saved.
+//
+// genState is the genesis state of the chain: the stream must be of the same
+// chain, and the validator sets are verified starting from the genesis ones.
+// Before a block is saved, it is verified that it links to the previous one,
+// that its validator set is the one of the previous header, that its seen
+// commit is signed by +2/3 of its validators and that its LastCommit, the
+// commit of the ULB height (CommitDistance heights below), is signed by +2/3
+// of the validators of that height. The validators of the heights imported
+// before an interrupted import are not known, so only the block ID of the
+// LastCommit of the first blocks imported after it is verified.
+func ImportBlocks(r io.Reader, bs sm.BlockStore, genState sm.State, logger log.Logger) (int64, error) {
+	br := bufio.NewReader(r)
+	var header BlockStreamHeader
+	if _, err := cdc.UnmarshalBinaryLengthPrefixedReader(br, &header, maxBlockStreamRecordBytes); err != nil {
+		return bs.Height(), errors.Wrap(err, "could not read the header")
+	}
+	distance := commitDistance(genState)
+	switch {
+	case header.ChainID != genState.ChainID:
+		return bs.Height(), fmt.Errorf("the blocks are of chain %s, not %s", header.ChainID, genState.ChainID)
+	case header.ConsensusModule != genState.Version.Consensus.Module:
+		return bs.Height(), fmt.Errorf("the blocks are of consensus module %s, not %s",
+			header.ConsensusModule, genState.Version.Consensus.Module)
+	case header.CommitDistance != distance:
+		return bs.Height(), fmt.Errorf("the commit distance of the blocks is %d, not %d", header.CommitDistance, distance)
+	case header.FromHeight > bs.Height()+1:
+		return bs.Height(), fmt.Errorf("the blocks start at height %d, but the block store ends at height %d",
+			header.FromHeight, bs.Height())
+	}
+	logger.Info("Importing blocks", "from", header.FromHeight, "to", header.ToHeight, "storeHeight", bs.Height())
+
+	recent := make(map[int64]importedHeight)
+	for {
+		var record BlockStreamRecord
+		_, err := cdc.UnmarshalBinaryLengthPrefixedReader(br, &record, maxBlockStreamRecordBytes)
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return bs.Height(), errors.Wrapf(err, "could not read height %d", bs.Height()+1)
+		}
+		if record.Block == nil || record.SeenCommit == nil || record.Validators == nil {
+			return bs.Height(), fmt.Errorf("incomplete record after height %d", bs.Height())
+		}
+		height := record.Block.Height
+		if height <= bs.Height() {
+			continue
+		}
+		if height != bs.Height()+1 {
+			return bs.Height(), fmt.Errorf("expected height %d, got %d", bs.Height()+1, height)
+		}
+
+		imported, err := verifyBlockStreamRecord(record, bs, genState, distance, recent)
+		if err != nil {
+			return bs.Height(), errors.Wrapf(err, "invalid height %d", height)
+		}
+		parts := record.Block.MakePartSet(types.BlockPartSizeBytes)
+		bs.SaveBlock(record.Block, parts, record.SeenCommit, distance)
+
+		recent[height] = imported
+		delete(recent, height-distance)
+		if height%1000 == 0 {
+			logger.Info("Imported blocks", "height", height)
+		}
+	}
+	return bs.Height(), nil
+}
+
+// verifyBlockStreamRecord verifies the record of the height after bs.Height(),
+// given the recent heights imported before it.
+func verifyBlockStreamRecord(record BlockStreamRecord, bs sm.BlockStoreRPC, genState sm.State,
+	distance int64, recent map[int64]importedHeight) (importedHeight, error) {
+
+	block, validators := record.Block, record.Validators
+	height := block.Height
+	chainID := genState.ChainID
+
+	var err error
+	if genState.Version.Consensus.Module == "friday" {
+		err = block.ValidateFridayBasic()
+	} else {
+		err = block.ValidateBasic()
+	}
+	if err != nil {
+		return importedHeight{}, err
+	}
+	if block.ChainID != chainID {
+		return importedHeight{}, fmt.Errorf("wrong chain id %s", block.ChainID)
+	}
+
+	// the previous block, from the stream or else from the block store
+	var (
+		prevHeader  *types.Header
+		prevBlockID types.BlockID
+	)
+	if prev, ok := recent[height-1]; ok {
+		prevHeader, prevBlockID = &prev.header, prev.blockID
+	} else if meta := bs.LoadBlockMeta(height - 1); meta != nil {
+		prevHeader, prevBlockID = &meta.Header, meta.BlockID
+	}
+	if height > 1 && !block.LastBlockID.Equals(prevBlockID) {
+		return importedHeight{}, fmt.Errorf("wrong LastBlockID, expected %v, got %v", prevBlockID, block.LastBlockID)
+	}
+
+	var prevValidators *types.ValidatorSet
+	if prev, ok := recent[height-1]; ok {
+		prevValidators = prev.validators
+	}
+	if err := verifyValidators(&block.Header, validators, genState, distance, prevHeader, prevValidators); err != nil {
+		return importedHeight{}, err
+	}
+
+	parts := block.MakePartSet(types.BlockPartSizeBytes)
+	blockID := types.BlockID{Hash: block.Hash(), PartsHeader: parts.Header()}
+	if err := validators.VerifyCommit(chainID, blockID, height, record.SeenCommit); err != nil {
+		return importedHeight{}, errors.Wrap(err, "invalid seen commit")
+	}
+
+	// the ULB commit chain
+	if height > distance {
+		ulbHeight := height - distance
+		if ulb, ok := recent[ulbHeight]; ok {
+			if err := ulb.validators.VerifyCommit(chainID, ulb.blockID, ulbHeight, block.LastCommit); err != nil {
+				return importedHeight{}, errors.Wrap(err, "invalid LastCommit")
+			}
+		} else if meta := bs.LoadBlockMeta(ulbHeight); meta == nil || !block.LastCommit.BlockID.Equals(meta.BlockID) {
+			return importedHeight{}, fmt.Errorf("the LastCommit is not for the block at height %d", ulbHeight)
+		}
+	} else if block.LastCommit != nil && len(block.LastCommit.Precommits) != 0 {
+		return importedHeight{}, errors.New("the blocks of the first ULB window can't have LastCommit precommits")
+	}
+
+	return importedHeight{header: block.Header, blockID: blockID, validators: validators}, nil
+}
+
+// verifyValidators verifies that validators are the validator set of header,
+// as trusted from the genesis state or the previous header. prevHeader and
+// prevValidators may be nil if the previous height is unknown.
+func verifyValidators(header *types.Header, validators *types.ValidatorSet, genState sm.State,
+	distance int64, prevHeader *types.Header, prevValidators *types.ValidatorSet) error {
+
+	if !bytes.Equal(validators.Hash(), header.ValidatorsHash) {
+		return fmt.Errorf("the validators don't match ValidatorsHash %X", header.ValidatorsHash)
+	}
+	switch {
+	case header.Height <= distance+1 && genState.Validators.Size() > 0:
+		// the validator changes apply after the ULB window
+		if !bytes.Equal(validators.Hash(), genState.Validators.Hash()) {
+			return errors.New("the validators are not the genesis ones")
+		}
+	case header.Height <= distance+1:
+		// the genesis validators come from InitChain, trust the first ones
+		if prevValidators != nil && !bytes.Equal(validators.Hash(), prevValidators.Hash()) {
+			return errors.New("the validators changed within the ULB window")
+		}
+	case prevHeader == nil:
+		return errors.New("the previous header is missing")
+	default:
+		if !bytes.Equal(validators.Hash(), prevHeader.NextValidatorsHash) {
+			return fmt.Errorf("the validators don't match the previous NextValidatorsHash %X",
+				prevHeader.NextValidatorsHash)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,152 @@
+package store
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	dbm "github.com/tendermint/tm-db"
+
+	"github.com/hdac-io/tendermint/libs/log"
+	sm "github.com/hdac-io/tendermint/state"
+	"github.com/hdac-io/tendermint/types"
+)
+
+// makeSignedChain saves numBlocks friday blocks with valid commits in a block
+// store, and the validators of their heights in a state DB.
+func makeSignedChain(t *testing.T, numBlocks, lenULB int64) (sm.State, *BlockStore, dbm.DB) {
+	valSet, privVals := types.RandValidatorSet(4, 10)
+	genDoc := &types.GenesisDoc{
+		ChainID:         "stream_test",
+		ConsensusModule: "friday",
+		ConsensusParams: types.DefaultFridayConsensusParams(),
+	}
+	genDoc.ConsensusParams.Block.LenULB = lenULB
+	for _, val := range valSet.Validators {
+		genDoc.Validators = append(genDoc.Validators, types.GenesisValidator{PubKey: val.PubKey, Power: val.VotingPower})
+	}
+	require.NoError(t, genDoc.ValidateAndComplete())
+	genState, err := sm.MakeGenesisState(genDoc)
+	require.NoError(t, err)
+
+	stateDB := dbm.NewMemDB()
+	bs := NewBlockStore(dbm.NewMemDB())
+	state := genState.Copy()
+	sm.SaveState(stateDB, state)
+
+	commits := make(map[int64]*types.Commit)
+	for height := int64(1); height <= numBlocks; height++ {
+		lastCommit := new(types.Commit)
+		if height > lenULB {
+			lastCommit = commits[height-lenULB]
+		}
+		block, parts := state.MakeBlockFromArgs(height, makeTxs(height), state.LastBlockID, state.LastBlockTotalTx,
+			lastCommit, state.Validators, nil, state.Validators.GetProposer().Address,
+			state.Validators.Hash(), state.NextValidators.Hash(), nil, nil)
+		blockID := types.BlockID{Hash: block.Hash(), PartsHeader: parts.Header()}
+		voteSet := types.NewVoteSet(genDoc.ChainID, height, 0, types.PrecommitType, state.Validators)
+		commits[height], err = types.MakeCommit(blockID, height, 0, voteSet, privVals)
+		require.NoError(t, err)
+		bs.SaveBlock(block, parts, commits[height], lenULB)
+
+		state.LastBlockHeight = height
+		state.LastBlockID = blockID
+		state.LastBlockTotalTx = block.TotalTxs
+		sm.SaveState(stateDB, state)
+	}
+	return genState, bs, stateDB
+}
+
+const numBlocks, lenULB = 8, 2
+
+func TestExportImportBlocks(t *testing.T) {
+	genState, bs, stateDB := makeSignedChain(t, numBlocks, lenULB)
+
+	// the whole chain
+	buf := new(bytes.Buffer)
+	require.NoError(t, ExportBlocks(buf, bs, stateDB, 1, numBlocks))
+	imported := NewBlockStore(dbm.NewMemDB())
+	height, err := ImportBlocks(buf, imported, genState, log.TestingLogger())
+	require.NoError(t, err)
+	assert.EqualValues(t, numBlocks, height)
+	for h := int64(1); h <= numBlocks; h++ {
+		assert.Equal(t, bs.LoadBlockMeta(h), imported.LoadBlockMeta(h), "height %d", h)
+		assert.Equal(t, bs.LoadSeenCommit(h), imported.LoadSeenCommit(h), "height %d", h)
+	}
+	for h := int64(1); h <= numBlocks-lenULB; h++ {
+		assert.Equal(t, bs.LoadBlockCommit(h), imported.LoadBlockCommit(h), "height %d", h)
+	}
+
+	// resume an interrupted import
+	buf.Reset()
+	require.NoError(t, ExportBlocks(buf, bs, stateDB, 1, 5))
+	resumed := NewBlockStore(dbm.NewMemDB())
+	height, err = ImportBlocks(buf, resumed, genState, log.TestingLogger())
+	require.NoError(t, err)
+	assert.EqualValues(t, 5, height)
+	buf.Reset()
+	require.NoError(t, ExportBlocks(buf, bs, stateDB, 3, numBlocks))
+	height, err = ImportBlocks(buf, resumed, genState, log.TestingLogger())
+	require.NoError(t, err)
+	assert.EqualValues(t, numBlocks, height)
+
+	// the heights can't be skipped
+	buf.Reset()
+	require.NoError(t, ExportBlocks(buf, bs, stateDB, 3, numBlocks))
+	_, err = ImportBlocks(buf, NewBlockStore(dbm.NewMemDB()), genState, log.TestingLogger())
+	assert.Error(t, err)
+
+	// nor imported in another chain
+	otherState := genState.Copy()
+	otherState.ChainID = "other_chain"
+	buf.Reset()
+	require.NoError(t, ExportBlocks(buf, bs, stateDB, 1, numBlocks))
+	_, err = ImportBlocks(buf, NewBlockStore(dbm.NewMemDB()), otherState, log.TestingLogger())
+	assert.Error(t, err)
+}
+
+func TestImportBlocksInvalidCommit(t *testing.T) {
+	genState, bs, stateDB := makeSignedChain(t, numBlocks, lenULB)
+
+	writeStream := func(tamper func(height int64, record *BlockStreamRecord)) *bytes.Buffer {
+		buf := new(bytes.Buffer)
+		header := BlockStreamHeader{genState.ChainID, "friday", lenULB, 1, numBlocks}
+		_, err := cdc.MarshalBinaryLengthPrefixedWriter(buf, header)
+		require.NoError(t, err)
+		for h := int64(1); h <= numBlocks; h++ {
+			validators, err := sm.LoadValidators(stateDB, h)
+			require.NoError(t, err)
+			record := BlockStreamRecord{bs.LoadBlock(h), bs.LoadSeenCommit(h), validators}
+			tamper(h, &record)
+			_, err = cdc.MarshalBinaryLengthPrefixedWriter(buf, record)
+			require.NoError(t, err)
+		}
+		return buf
+	}
+
+	testCases := map[string]func(height int64, record *BlockStreamRecord){
+		"seen commit of another height": func(height int64, record *BlockStreamRecord) {
+			if height == 5 {
+				record.SeenCommit = bs.LoadSeenCommit(4)
+			}
+		},
+		"other validators": func(height int64, record *BlockStreamRecord) {
+			if height == 5 {
+				record.Validators, _ = types.RandValidatorSet(4, 10)
+			}
+		},
+		"LastCommit of another height": func(height int64, record *BlockStreamRecord) {
+			if height == 5 {
+				record.Block.LastCommit = bs.LoadSeenCommit(2)
+				record.Block.LastCommitHash = record.Block.LastCommit.Hash()
+			}
+		},
+	}
+	for name, tamper := range testCases {
+		imported := NewBlockStore(dbm.NewMemDB())
+		height, err := ImportBlocks(writeStream(tamper), imported, genState, log.TestingLogger())
+		assert.Error(t, err, name)
+		assert.EqualValues(t, 4, height, name)
+	}
+}
@@ -0,0 +1,248 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	metrics "github.com/rcrowley/go-metrics"
+
+	tmrpc "github.com/hdac-io/tendermint/rpc/client"
+	"github.com/hdac-io/tendermint/types"
+)
+
+const latencySubscriber = "tm-bench"
+
+// heightLatency is the time a height took from its complete proposal to its
+// finalization, as seen by the node, with friday the time it was in the ULB
+// window with the other heights in flight.
+type heightLatency struct {
+	Height       int64
+	Round        int
+	NumTxs       int64
+	ProposalTime time.Time
+	FinalizeTime time.Time
+	// the heights with a complete proposal not finalized yet, including this
+	// one, when it was finalized
+	InFlight int
+}
+
+// Latency returns the time from the complete proposal to the finalization.
+func (hl heightLatency) Latency() time.Duration {
+	return hl.FinalizeTime.Sub(hl.ProposalTime)
+}
+
+// completeProposal is a proposal whose block the node received entirely.
+type completeProposal struct {
+	round     int
+	blockHash []byte
+	time      time.Time
+}
+
+// latencyRecorder records the latency of the heights finalized by a node,
+// from its CompleteProposal and NewBlockHeader events.
+type latencyRecorder struct {
+	mtx sync.Mutex
+	// the complete proposals of the heights not finalized yet
+	proposals map[int64][]completeProposal
+	latencies []heightLatency
+}
+
+func newLatencyRecorder() *latencyRecorder {
+	return &latencyRecorder{proposals: make(map[int64][]completeProposal)}
+}
+
+// proposed records that the proposal of height and round with the block
+// blockHash was complete at t.
+func (lr *latencyRecorder) proposed(height int64, round int, blockHash []byte, t time.Time) {
+	lr.mtx.Lock()
+	defer lr.mtx.Unlock()
+	lr.proposals[height] = append(lr.proposals[height], completeProposal{round, blockHash, t})
+}
+
+// finalized records that the block blockHash of height with numTxs txs was
+// finalized at t. The heights finalized without a complete proposal seen,
+// e.g. before the recorder started, are skipped.
+func (lr *latencyRecorder) finalized(height int64, blockHash []byte, numTxs int64, t time.Time) {
+	lr.mtx.Lock()
+	defer lr.mtx.Unlock()
+
+	proposals, ok := lr.proposals[height]
+	if !ok {
+		return
+	}
+	// the proposal of the finalized block, else the last one
+	proposal := proposals[len(proposals)-1]
+	for _, p := range proposals {
+		if bytes.Equal(p.blockHash, blockHash) {
+			proposal = p
+		}
+	}
+	inFlight := 0
+	for h := range lr.proposals {
+		if h >= height {
+			inFlight++
+		}
+	}
+	for h := range lr.proposals {
+		if h <= height {
+			delete(lr.proposals, h)
+		}
+	}
+
+	lr.latencies = append(lr.latencies, heightLatency{
+		Height:       height,
+		Round:        proposal.round,
+		NumTxs:       numTxs,
+		ProposalTime: proposal.time,
+		FinalizeTime: t,
+		InFlight:     inFlight,
+	})
+}
+
+// Latencies returns the latencies of the heights finalized in [timeStart,
+// timeEnd], in height order.
+func (lr *latencyRecorder) Latencies(timeStart, timeEnd time.Time) []heightLatency {
+	lr.mtx.Lock()
+	defer lr.mtx.Unlock()
+
+	var latencies []heightLatency
+	for _, hl := range lr.latencies {
+		if !hl.FinalizeTime.Before(timeStart) && !hl.FinalizeTime.After(timeEnd) {
+			latencies = append(latencies, hl)
+		}
+	}
+	sort.Slice(latencies, func(i, j int) bool { return latencies[i].Height < latencies[j].Height })
+	return latencies
+}
+
+// start subscribes to the events of the node of client, and records them
+// until ctx is done.
+func (lr *latencyRecorder) start(ctx context.Context, client *tmrpc.HTTP) error {
+	if err := client.Start(); err != nil {
+		return err
+	}
+	proposals, err := client.Subscribe(ctx, latencySubscriber, types.EventQueryCompleteProposal.String(), 1000)
+	if err != nil {
+		return err
+	}
+	headers, err := client.Subscribe(ctx, latencySubscriber, types.EventQueryNewBlockHeader.String(), 1000)
+	if err != nil {
+		return err
+	}
+
+	go func() {
+		defer client.Stop() // nolint: errcheck
+		for {
+			select {
+			case ev := <-proposals:
+				if data, ok := ev.Data.(types.EventDataCompleteProposal); ok {
+					lr.proposed(data.Height, data.Round, data.BlockID.Hash, time.Now())
+				}
+			case ev := <-headers:
+				if data, ok := ev.Data.(types.EventDataNewBlockHeader); ok {
+					lr.finalized(data.Header.Height, data.Header.Hash(), data.Header.NumTxs, time.Now())
+				}
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return nil
+}
+
+// latencyStatistics summarizes the latencies of the heights.
+type latencyStatistics struct {
+	Latency  metrics.Histogram // in milliseconds
+	InFlight metrics.Histogram
+}
+
+func calculateLatencyStatistics(latencies []heightLatency) *latencyStatistics {
+	stats := &latencyStatistics{
+		Latency:  metrics.NewHistogram(metrics.NewUniformSample(10000)),
+		InFlight: metrics.NewHistogram(metrics.NewUniformSample(10000)),
+	}
+	for _, hl := range latencies {
+		stats.Latency.Update(int64(hl.Latency() / time.Millisecond))
+		stats.InFlight.Update(int64(hl.InFlight))
+	}
+	return stats
+}
+
+// writeLatencyCSV writes the latency of each height to w, as CSV.
+func writeLatencyCSV(w io.Writer, latencies []heightLatency) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write([]string{"height", "round", "num_txs", "proposal_time", "finalize_time",
+		"latency_ms", "in_flight"}); err != nil {
+		return err
+	}
+	for _, hl := range latencies {
+		err := cw.Write([]string{
+			strconv.FormatInt(hl.Height, 10),
+			strconv.Itoa(hl.Round),
+			strconv.FormatInt(hl.NumTxs, 10),
+			hl.ProposalTime.UTC().Format(time.RFC3339Nano),
+			hl.FinalizeTime.UTC().Format(time.RFC3339Nano),
+			strconv.FormatInt(int64(hl.Latency()/time.Millisecond), 10),
+			strconv.Itoa(hl.InFlight),
+		})
+		if err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// latencyQuantiles are the quantiles of the latency summary.
+var latencyQuantiles = []float64{0.5, 0.9, 0.99}
+
+// writePrometheus writes the statistics to w in the Prometheus text format,
+// e.g. for the textfile collector of the node exporter.
+func writePrometheus(w io.Writer, stats *statistics, latencies []heightLatency) error {
+	var buf bytes.Buffer
+	gauge := func(name, help string, value float64) {
+		fmt.Fprintf(&buf, "# HELP %s %s\n# TYPE %s gauge\n%s %g\n", name, help, name, name, value)
+	}
+	gauge("tm_bench_txs_per_sec", "Average number of txs finalized per second.", stats.TxsThroughput.Mean())
+	gauge("tm_bench_blocks_per_sec", "Average number of blocks finalized per second.", stats.BlocksThroughput.Mean())
+
+	if latencies != nil {
+		lstats := calculateLatencyStatistics(latencies)
+		name := "tm_bench_finalization_latency_seconds"
+		fmt.Fprintf(&buf, "# HELP %s Time from the complete proposal of a height to its finalization.\n", name)
+		fmt.Fprintf(&buf, "# TYPE %s summary\n", name)
+		for i, q := range lstats.Latency.Percentiles(latencyQuantiles) {
+			fmt.Fprintf(&buf, "%s{quantile=\"%g\"} %g\n", name, latencyQuantiles[i], q/1000)
+		}
+		fmt.Fprintf(&buf, "%s_sum %g\n", name, float64(lstats.Latency.Sum())/1000)
+		fmt.Fprintf(&buf, "%s_count %d\n", name, lstats.Latency.Count())
+		gauge("tm_bench_heights_in_flight", "Average number of heights in flight when a height was finalized.",
+			lstats.InFlight.Mean())
+		gauge("tm_bench_heights_in_flight_max", "Maximum number of heights in flight when a height was finalized.",
+			float64(lstats.InFlight.Max()))
+	}
+
+	_, err := w.Write(buf.Bytes())
+	return err
+}
+
+// writeFile writes a file with write.
+func writeFile(path string, write func(io.Writer) error) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if err := write(f); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
@@ -0,0 +1,99 @@
+package main
+
+import (
+	"bytes"
+	"encoding/csv"
+	"strings"
+	"testing"
+	"time"
+
+	metrics "github.com/rcrowley/go-metrics"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLatencyRecorder(t *testing.T) {
+	lr := newLatencyRecorder()
+	t0 := time.Now()
+	at := func(ms int) time.Time { return t0.Add(time.Duration(ms) * time.Millisecond) }
+
+	// finalized before the recorder started
+	lr.finalized(1, []byte("b1"), 1, at(0))
+	// heights 2 to 4 in flight, height 3 in 2 rounds
+	lr.proposed(2, 0, []byte("b2"), at(10))
+	lr.proposed(3, 0, []byte("b3"), at(20))
+	lr.proposed(3, 1, []byte("b3'"), at(30))
+	lr.proposed(4, 0, []byte("b4"), at(40))
+	lr.finalized(2, []byte("b2"), 5, at(100))
+	lr.finalized(3, []byte("b3"), 6, at(200))
+	lr.finalized(4, []byte("b4"), 7, at(300))
+	// finalized after the end
+	lr.proposed(5, 0, []byte("b5"), at(310))
+	lr.finalized(5, []byte("b5"), 8, at(1000))
+
+	latencies := lr.Latencies(at(0), at(500))
+	require.Len(t, latencies, 3)
+	assert.Equal(t, heightLatency{2, 0, 5, at(10), at(100), 3}, latencies[0])
+	// the proposal of the finalized block
+	assert.Equal(t, heightLatency{3, 0, 6, at(20), at(200), 2}, latencies[1])
+	assert.Equal(t, heightLatency{4, 0, 7, at(40), at(300), 1}, latencies[2])
+	assert.Equal(t, 90*time.Millisecond, latencies[0].Latency())
+
+	lstats := calculateLatencyStatistics(latencies)
+	assert.EqualValues(t, 260, lstats.Latency.Max())
+	assert.Equal(t, 2.0, lstats.InFlight.Mean())
+}
+
+func TestWriteLatencyCSV(t *testing.T) {
+	t0 := time.Date(2019, 1, 2, 3, 4, 5, 0, time.UTC)
+	latencies := []heightLatency{
+		{2, 0, 5, t0, t0.Add(1500 * time.Millisecond), 3},
+		{3, 1, 0, t0.Add(time.Second), t0.Add(2 * time.Second), 2},
+	}
+	var buf bytes.Buffer
+	require.NoError(t, writeLatencyCSV(&buf, latencies))
+
+	records, err := csv.NewReader(&buf).ReadAll()
+	require.NoError(t, err)
+	assert.Equal(t, [][]string{
+		{"height", "round", "num_txs", "proposal_time", "finalize_time", "latency_ms", "in_flight"},
+		{"2", "0", "5", "2019-01-02T03:04:05Z", "2019-01-02T03:04:06.5Z", "1500", "3"},
+		{"3", "1", "0", "2019-01-02T03:04:06Z", "2019-01-02T03:04:07Z", "1000", "2"},
+	}, records)
+}
+
+func TestWritePrometheus(t *testing.T) {
+	stats := &statistics{
+		TxsThroughput:    metrics.NewHistogram(metrics.NewUniformSample(1000)),
+		BlocksThroughput: metrics.NewHistogram(metrics.NewUniformSample(1000)),
+	}
+	stats.TxsThroughput.Update(100)
+	stats.BlocksThroughput.Update(2)
+
+	// without the latencies
+	var buf bytes.Buffer
+	require.NoError(t, writePrometheus(&buf, stats, nil))
+	assert.Contains(t, buf.String(), "# TYPE tm_bench_txs_per_sec gauge\ntm_bench_txs_per_sec 100\n")
+	assert.Contains(t, buf.String(), "tm_bench_blocks_per_sec 2\n")
+	assert.NotContains(t, buf.String(), "latency")
+
+	t0 := time.Now()
+	latencies := []heightLatency{
+		{2, 0, 5, t0, t0.Add(time.Second), 3},
+		{3, 0, 5, t0, t0.Add(2 * time.Second), 1},
+	}
+	buf.Reset()
+	require.NoError(t, writePrometheus(&buf, stats, latencies))
+	out := buf.String()
+	assert.Contains(t, out, "# TYPE tm_bench_finalization_latency_seconds summary\n")
+	assert.Contains(t, out, `tm_bench_finalization_latency_seconds{quantile="0.5"} 1.5`+"\n")
+	assert.Contains(t, out, "tm_bench_finalization_latency_seconds_sum 3\n")
+	assert.Contains(t, out, "tm_bench_finalization_latency_seconds_count 2\n")
+	assert.Contains(t, out, "tm_bench_heights_in_flight 2\n")
+	assert.Contains(t, out, "tm_bench_heights_in_flight_max 3\n")
+	for _, line := range strings.Split(strings.TrimSpace(out), "\n") {
+		if !strings.HasPrefix(line, "#") {
+			assert.Len(t, strings.Fields(line), 2, line)
+		}
+	}
+}
@@ -1,8 +1,10 @@
 package main
 
 import (
+	"context"
 	"flag"
 	"fmt"
+	"io"
 	"os"
 	"strings"
 	"sync"
@@ -19,8 +21,8 @@ var logger = log.NewNopLogger()
 
 func main() {
 	var durationInt, txsRate, connections, txSize int
-	var verbose bool
-	var outputFormat, broadcastTxMethod string
+	var verbose, latency bool
+	var outputFormat, broadcastTxMethod, csvFile, prometheusFile string
 
 	flagSet := flag.NewFlagSet("tm-bench", flag.ExitOnError)
 	flagSet.IntVar(&connections, "c", 1, "Connections to keep open per endpoint")
@@ -30,15 +32,22 @@ func main() {
 	flagSet.StringVar(&outputFormat, "output-format", "plain", "Output format: plain or json")
 	flagSet.StringVar(&broadcastTxMethod, "broadcast-tx-method", "async", "Broadcast method: async (no guarantees; fastest), sync (ensures tx is checked) or commit (ensures tx is checked and committed; slowest)")
 	flagSet.BoolVar(&verbose, "v", false, "Verbose output")
+	flagSet.BoolVar(&latency, "latency", false,
+		"Measure the time from the complete proposal of each height to its finalization, on the first endpoint")
+	flagSet.StringVar(&csvFile, "csv", "", "Write the latency of each height to this CSV file (requires -latency)")
+	flagSet.StringVar(&prometheusFile, "prometheus", "",
+		"Write the statistics to this file in the Prometheus text format, e.g. for the node exporter textfile collector")
 
 	flagSet.Usage = func() {
 		fmt.Println(`Tendermint blockchain benchmarking tool.
 
 Usage:
 	tm-bench [-c 1] [-T 10] [-r 1000] [-s 250] [endpoints] [-output-format <plain|json> [-broadcast-tx-method <async|sync|commit>]]
+		[-latency [-csv latency.csv]] [-prometheus tm-bench.prom]
 
 Examples:
-	tm-bench localhost:26657`)
+	tm-bench localhost:26657
+	tm-bench -latency -csv latency.csv -prometheus tm-bench.prom localhost:26657`)
 		fmt.Println("Flags:")
 		flagSet.PrintDefaults()
 	}
@@ -78,6 +87,10 @@ Examples:
 		printErrorAndExit("broadcast-tx-method should be either 'sync', 'async' or 'commit'.")
 	}
 
+	if csvFile != "" && !latency {
+		printErrorAndExit("-csv requires -latency.")
+	}
+
 	var (
 		endpoints     = strings.Split(flagSet.Arg(0), ",")
 		client        = tmrpc.NewHTTP(endpoints[0], "/websocket")
@@ -85,6 +98,16 @@ Examples:
 	)
 	logger.Info("Latest block height", "h", initialHeight)
 
+	var recorder *latencyRecorder
+	if latency {
+		recorder = newLatencyRecorder()
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		if err := recorder.start(ctx, tmrpc.NewHTTP(endpoints[0], "/websocket")); err != nil {
+			printErrorAndExit(fmt.Sprintf("Failed to subscribe to the events: %v", err))
+		}
+	}
+
 	transacters := startTransacters(
 		endpoints,
 		connections,
@@ -130,7 +153,28 @@ Examples:
 		printErrorAndExit(err.Error())
 	}
 
-	printStatistics(stats, outputFormat)
+	var latencies []heightLatency
+	if recorder != nil {
+		// the heights in flight at the end finalize in the next seconds
+		latencies = recorder.Latencies(timeStart, timeEnd)
+		if latencies == nil {
+			latencies = []heightLatency{}
+		}
+	}
+	if csvFile != "" {
+		err := writeFile(csvFile, func(w io.Writer) error { return writeLatencyCSV(w, latencies) })
+		if err != nil {
+			printErrorAndExit(err.Error())
+		}
+	}
+	if prometheusFile != "" {
+		err := writeFile(prometheusFile, func(w io.Writer) error { return writePrometheus(w, stats, latencies) })
+		if err != nil {
+			printErrorAndExit(err.Error())
+		}
+	}
+
+	printStatistics(stats, latencies, outputFormat)
 }
 
 func latestBlockHeight(client tmrpc.Client) int64 {
@@ -8,9 +8,9 @@ import (
 	"text/tabwriter"
 	"time"
 
-	metrics "github.com/rcrowley/go-metrics"
 	tmrpc "github.com/hdac-io/tendermint/rpc/client"
 	"github.com/hdac-io/tendermint/types"
+	metrics "github.com/rcrowley/go-metrics"
 )
 
 type statistics struct {
@@ -111,12 +111,24 @@ func secondsSinceTimeStart(timeStart, timePassed time.Time) int64 {
 	return int64(math.Round(timePassed.Sub(timeStart).Seconds()))
 }
 
-func printStatistics(stats *statistics, outputFormat string) {
+func printStatistics(stats *statistics, latencies []heightLatency, outputFormat string) {
+	var lstats *latencyStatistics
+	if latencies != nil {
+		lstats = calculateLatencyStatistics(latencies)
+	}
 	if outputFormat == "json" {
-		result, err := json.Marshal(struct {
-			TxsThroughput    float64 `json:"txs_per_sec_avg"`
-			BlocksThroughput float64 `json:"blocks_per_sec_avg"`
-		}{stats.TxsThroughput.Mean(), stats.BlocksThroughput.Mean()})
+		res := struct {
+			TxsThroughput    float64  `json:"txs_per_sec_avg"`
+			BlocksThroughput float64  `json:"blocks_per_sec_avg"`
+			LatencyAvg       *float64 `json:"latency_ms_avg,omitempty"`
+			LatencyP99       *float64 `json:"latency_ms_p99,omitempty"`
+			InFlightAvg      *float64 `json:"heights_in_flight_avg,omitempty"`
+		}{TxsThroughput: stats.TxsThroughput.Mean(), BlocksThroughput: stats.BlocksThroughput.Mean()}
+		if lstats != nil {
+			avg, p99, inFlight := lstats.Latency.Mean(), lstats.Latency.Percentile(0.99), lstats.InFlight.Mean()
+			res.LatencyAvg, res.LatencyP99, res.InFlightAvg = &avg, &p99, &inFlight
+		}
+		result, err := json.Marshal(res)
 
 		if err != nil {
 			fmt.Fprintln(os.Stderr, err)
@@ -145,6 +157,27 @@ func printStatistics(stats *statistics, outputFormat string) {
 				stats.BlocksThroughput.Sum(),
 			),
 		)
+		if lstats != nil {
+			// the totals are the numbers of heights measured
+			fmt.Fprintln(
+				w,
+				fmt.Sprintf("Latency(ms)\t%.0f\t%.0f\t%d\t%d\t",
+					lstats.Latency.Mean(),
+					lstats.Latency.StdDev(),
+					lstats.Latency.Max(),
+					lstats.Latency.Count(),
+				),
+			)
+			fmt.Fprintln(
+				w,
+				fmt.Sprintf("InFlight\t%.2f\t%.2f\t%d\t%d\t",
+					lstats.InFlight.Mean(),
+					lstats.InFlight.StdDev(),
+					lstats.InFlight.Max(),
+					lstats.InFlight.Count(),
+				),
+			)
+		}
 		w.Flush()
 	}
 }
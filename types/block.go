@@ -600,6 +600,10 @@ type Commit struct {
 	// active ValidatorSet.
 	BlockID    BlockID      `json:"block_id"`
 	Precommits []*CommitSig `json:"precommits"`
+	// AggregateSignature, if set, is the BLS aggregate of the signatures of
+	// the precommits for BlockID, which then have no signature of their own:
+	// the commit is the QuorumCert of the height, see QuorumCert.Commit.
+	AggregateSignature []byte `json:"aggregate_signature,omitempty"`
 
 	// memoized in first call to corresponding method
 	// NOTE: can't memoize in constructor because constructor
@@ -736,6 +740,33 @@ func (commit *Commit) GetByIndex(valIdx int) *Vote {
 	return commit.GetVote(valIdx)
 }
 
+// IsAggregate returns true if the commit is the QuorumCert of its height,
+// with the signatures of its precommits aggregated into AggregateSignature.
+func (commit *Commit) IsAggregate() bool {
+	return commit != nil && len(commit.AggregateSignature) != 0
+}
+
+// QuorumCert returns the QuorumCert of an aggregate commit, nil otherwise.
+func (commit *Commit) QuorumCert() *QuorumCert {
+	if !commit.IsAggregate() {
+		return nil
+	}
+	qc := &QuorumCert{
+		Height:     commit.Height(),
+		Round:      commit.Round(),
+		BlockID:    commit.BlockID,
+		Signers:    commit.BitArray(),
+		Timestamps: []time.Time{},
+		Signature:  commit.AggregateSignature,
+	}
+	for _, precommit := range commit.Precommits {
+		if precommit != nil {
+			qc.Timestamps = append(qc.Timestamps, precommit.Timestamp)
+		}
+	}
+	return qc
+}
+
 // IsCommit returns true if there is at least one vote.
 func (commit *Commit) IsCommit() bool {
 	return len(commit.Precommits) != 0
@@ -773,6 +804,17 @@ func (commit *Commit) ValidateBasic() error {
 			return fmt.Errorf("Invalid commit precommit round. Expected %v, got %v",
 				round, precommit.Round)
 		}
+		// Ensure that the precommits of an aggregate commit are the signed
+		// ones for its block.
+		if commit.IsAggregate() {
+			if !commit.BlockID.Equals(precommit.BlockID) {
+				return fmt.Errorf("Invalid aggregate commit precommit block. Expected %v, got %v",
+					commit.BlockID, precommit.BlockID)
+			}
+			if len(precommit.Signature) != 0 {
+				return errors.New("Invalid aggregate commit precommit: signature not aggregated")
+			}
+		}
 	}
 	return nil
 }
@@ -787,6 +829,9 @@ func (commit *Commit) Hash() cmn.HexBytes {
 		for i, precommit := range commit.Precommits {
 			bs[i] = cdcEncode(precommit)
 		}
+		if commit.IsAggregate() {
+			bs = append(bs, commit.AggregateSignature)
+		}
 		commit.hash = merkle.SimpleHashFromByteSlices(bs)
 	}
 	return commit.hash
@@ -805,10 +850,12 @@ func (commit *Commit) StringIndented(indent string) string {
 %s  BlockID:    %v
 %s  Precommits:
 %s    %v
+%s  AggregateSignature: %X
 %s}#%v`,
 		indent, commit.BlockID,
 		indent,
 		indent, strings.Join(precommitStrings, "\n"+indent+"    "),
+		indent, cmn.Fingerprint(commit.AggregateSignature),
 		indent, commit.hash)
 }
 
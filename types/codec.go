@@ -2,20 +2,8 @@ package types
 
 import (
 	amino "github.com/tendermint/go-amino"
-	cryptoAmino "github.com/hdac-io/tendermint/crypto/encoding/amino"
 )
 
-var cdc = amino.NewCodec()
-
-func init() {
-	RegisterBlockAmino(cdc)
-}
-
-func RegisterBlockAmino(cdc *amino.Codec) {
-	cryptoAmino.RegisterAmino(cdc)
-	RegisterEvidences(cdc)
-}
-
 // GetCodec returns a codec used by the package. For testing purposes only.
 func GetCodec() *amino.Codec {
 	return cdc
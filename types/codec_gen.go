@@ -0,0 +1,50 @@
+// Code generated by scripts/codecgen from its manifest. DO NOT EDIT.
+
+package types
+
+import (
+	cryptoAmino "github.com/hdac-io/tendermint/crypto/encoding/amino"
+	amino "github.com/tendermint/go-amino"
+)
+
+var cdc = amino.NewCodec()
+
+func init() {
+	RegisterBlockAmino(cdc)
+}
+
+// RegisterBlockAmino registers the types of the blocks: the keys and the evidences.
+func RegisterBlockAmino(cdc *amino.Codec) {
+	cryptoAmino.RegisterAmino(cdc)
+	RegisterEvidences(cdc)
+}
+
+// RegisterEvidences registers the evidences.
+func RegisterEvidences(cdc *amino.Codec) {
+	cdc.RegisterInterface((*Evidence)(nil), nil)
+	cdc.RegisterConcrete(&DuplicateVoteEvidence{}, "tendermint/DuplicateVoteEvidence", nil)
+	cdc.RegisterConcrete(&DuplicateProposalEvidence{}, "tendermint/DuplicateProposalEvidence", nil)
+}
+
+// RegisterMockEvidences registers the test evidences.
+func RegisterMockEvidences(cdc *amino.Codec) {
+	cdc.RegisterConcrete(MockGoodEvidence{}, "tendermint/MockGoodEvidence", nil)
+	cdc.RegisterConcrete(MockRandomGoodEvidence{}, "tendermint/MockRandomGoodEvidence", nil)
+	cdc.RegisterConcrete(MockBadEvidence{}, "tendermint/MockBadEvidence", nil)
+}
+
+// RegisterEventDatas registers the data of the events.
+func RegisterEventDatas(cdc *amino.Codec) {
+	cdc.RegisterInterface((*TMEventData)(nil), nil)
+	cdc.RegisterConcrete(EventDataNewBlock{}, "tendermint/event/NewBlock", nil)
+	cdc.RegisterConcrete(EventDataNewBlockHeader{}, "tendermint/event/NewBlockHeader", nil)
+	cdc.RegisterConcrete(EventDataTx{}, "tendermint/event/Tx", nil)
+	cdc.RegisterConcrete(EventDataRoundState{}, "tendermint/event/RoundState", nil)
+	cdc.RegisterConcrete(EventDataNewRound{}, "tendermint/event/NewRound", nil)
+	cdc.RegisterConcrete(EventDataCompleteProposal{}, "tendermint/event/CompleteProposal", nil)
+	cdc.RegisterConcrete(EventDataVote{}, "tendermint/event/Vote", nil)
+	cdc.RegisterConcrete(EventDataValidatorSetUpdates{}, "tendermint/event/ValidatorSetUpdates", nil)
+	cdc.RegisterConcrete(EventDataFinalizeStall{}, "tendermint/event/FinalizeStall", nil)
+	cdc.RegisterConcrete(EventDataInvariantViolation{}, "tendermint/event/InvariantViolation", nil)
+	cdc.RegisterConcrete(EventDataString(""), "tendermint/event/ProposalString", nil)
+}
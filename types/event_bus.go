@@ -43,7 +43,10 @@ func NewEventBus() *EventBus {
 // NewEventBusWithBufferCapacity returns a new event bus with the given buffer capacity.
 func NewEventBusWithBufferCapacity(cap int) *EventBus {
 	// capacity could be exposed later if needed
-	pubsub := tmpubsub.NewServer(tmpubsub.BufferCapacity(cap))
+	pubsub := tmpubsub.NewServer(
+		tmpubsub.BufferCapacity(cap),
+		tmpubsub.IndexTags(EventTypeKey, BlockHeightKey, PipelineStageKey),
+	)
 	b := &EventBus{pubsub: pubsub}
 	b.BaseService = *cmn.NewBaseService(nil, "EventBus", b)
 	return b
@@ -94,6 +97,26 @@ func (b *EventBus) Publish(eventType string, eventData TMEventData) error {
 	return b.pubsub.PublishWithEvents(ctx, eventData, map[string][]string{EventTypeKey: {eventType}})
 }
 
+// PublishWithEvents publishes eventData with the events it was published
+// with, e.g. when replaying recorded events.
+func (b *EventBus) PublishWithEvents(eventData TMEventData, events map[string][]string) error {
+	// no explicit deadline for publishing events
+	ctx := context.Background()
+	return b.pubsub.PublishWithEvents(ctx, eventData, events)
+}
+
+// publishAtHeight publishes eventData with the height and the pipeline stage
+// of the height it is about.
+func (b *EventBus) publishAtHeight(eventType string, height int64, stage string, eventData TMEventData) error {
+	// no explicit deadline for publishing events
+	ctx := context.Background()
+	return b.pubsub.PublishWithEvents(ctx, eventData, map[string][]string{
+		EventTypeKey:     {eventType},
+		BlockHeightKey:   {fmt.Sprintf("%d", height)},
+		PipelineStageKey: {stage},
+	})
+}
+
 // validateAndStringifyEvents takes a slice of event objects and creates a
 // map of stringified events where each key is composed of the event
 // type and each of the event's attributes keys in the form of
@@ -129,6 +152,10 @@ func (b *EventBus) PublishEventNewBlock(data EventDataNewBlock) error {
 
 	// add predefined new block event
 	events[EventTypeKey] = append(events[EventTypeKey], EventNewBlock)
+	if data.Block != nil {
+		events[BlockHeightKey] = append(events[BlockHeightKey], fmt.Sprintf("%d", data.Block.Height))
+		events[PipelineStageKey] = append(events[PipelineStageKey], PipelineStageFinalized)
+	}
 
 	return b.pubsub.PublishWithEvents(ctx, data, events)
 }
@@ -143,16 +170,21 @@ func (b *EventBus) PublishEventNewBlockHeader(data EventDataNewBlockHeader) erro
 
 	// add predefined new block header event
 	events[EventTypeKey] = append(events[EventTypeKey], EventNewBlockHeader)
+	events[BlockHeightKey] = append(events[BlockHeightKey], fmt.Sprintf("%d", data.Header.Height))
+	events[PipelineStageKey] = append(events[PipelineStageKey], PipelineStageFinalized)
 
 	return b.pubsub.PublishWithEvents(ctx, data, events)
 }
 
 func (b *EventBus) PublishEventVote(data EventDataVote) error {
-	return b.Publish(EventVote, data)
+	if data.Vote == nil {
+		return b.Publish(EventVote, data)
+	}
+	return b.publishAtHeight(EventVote, data.Vote.Height, PipelineStageVote, data)
 }
 
 func (b *EventBus) PublishEventValidBlock(data EventDataRoundState) error {
-	return b.Publish(EventValidBlock, data)
+	return b.publishAtHeight(EventValidBlock, data.Height, PipelineStageOfStep(data.Step), data)
 }
 
 // PublishEventTx publishes tx event with tags from Result. Note it will add
@@ -168,50 +200,60 @@ func (b *EventBus) PublishEventTx(data EventDataTx) error {
 	events[EventTypeKey] = append(events[EventTypeKey], EventTx)
 	events[TxHashKey] = append(events[TxHashKey], fmt.Sprintf("%X", data.Tx.Hash()))
 	events[TxHeightKey] = append(events[TxHeightKey], fmt.Sprintf("%d", data.Height))
+	events[BlockHeightKey] = append(events[BlockHeightKey], fmt.Sprintf("%d", data.Height))
+	events[PipelineStageKey] = append(events[PipelineStageKey], PipelineStageFinalized)
 
 	return b.pubsub.PublishWithEvents(ctx, data, events)
 }
 
 func (b *EventBus) PublishEventNewRoundStep(data EventDataRoundState) error {
-	return b.Publish(EventNewRoundStep, data)
+	return b.publishAtHeight(EventNewRoundStep, data.Height, PipelineStageOfStep(data.Step), data)
 }
 
 func (b *EventBus) PublishEventTimeoutPropose(data EventDataRoundState) error {
-	return b.Publish(EventTimeoutPropose, data)
+	return b.publishAtHeight(EventTimeoutPropose, data.Height, PipelineStageOfStep(data.Step), data)
 }
 
 func (b *EventBus) PublishEventTimeoutWait(data EventDataRoundState) error {
-	return b.Publish(EventTimeoutWait, data)
+	return b.publishAtHeight(EventTimeoutWait, data.Height, PipelineStageOfStep(data.Step), data)
 }
 
 func (b *EventBus) PublishEventNewRound(data EventDataNewRound) error {
-	return b.Publish(EventNewRound, data)
+	return b.publishAtHeight(EventNewRound, data.Height, PipelineStageOfStep(data.Step), data)
 }
 
 func (b *EventBus) PublishEventCompleteProposal(data EventDataCompleteProposal) error {
-	return b.Publish(EventCompleteProposal, data)
+	return b.publishAtHeight(EventCompleteProposal, data.Height, PipelineStageOfStep(data.Step), data)
 }
 
 func (b *EventBus) PublishEventPolka(data EventDataRoundState) error {
-	return b.Publish(EventPolka, data)
+	return b.publishAtHeight(EventPolka, data.Height, PipelineStageOfStep(data.Step), data)
 }
 
 func (b *EventBus) PublishEventUnlock(data EventDataRoundState) error {
-	return b.Publish(EventUnlock, data)
+	return b.publishAtHeight(EventUnlock, data.Height, PipelineStageOfStep(data.Step), data)
 }
 
 func (b *EventBus) PublishEventRelock(data EventDataRoundState) error {
-	return b.Publish(EventRelock, data)
+	return b.publishAtHeight(EventRelock, data.Height, PipelineStageOfStep(data.Step), data)
 }
 
 func (b *EventBus) PublishEventLock(data EventDataRoundState) error {
-	return b.Publish(EventLock, data)
+	return b.publishAtHeight(EventLock, data.Height, PipelineStageOfStep(data.Step), data)
 }
 
 func (b *EventBus) PublishEventValidatorSetUpdates(data EventDataValidatorSetUpdates) error {
 	return b.Publish(EventValidatorSetUpdates, data)
 }
 
+func (b *EventBus) PublishEventFinalizeStall(data EventDataFinalizeStall) error {
+	return b.publishAtHeight(EventFinalizeStall, data.Height, PipelineStageCommit, data)
+}
+
+func (b *EventBus) PublishEventInvariantViolation(data EventDataInvariantViolation) error {
+	return b.Publish(EventInvariantViolation, data)
+}
+
 //-----------------------------------------------------------------------------
 type NopEventBus struct{}
 
@@ -282,3 +324,11 @@ func (NopEventBus) PublishEventLock(data EventDataRoundState) error {
 func (NopEventBus) PublishEventValidatorSetUpdates(data EventDataValidatorSetUpdates) error {
 	return nil
 }
+
+func (NopEventBus) PublishEventFinalizeStall(data EventDataFinalizeStall) error {
+	return nil
+}
+
+func (NopEventBus) PublishEventInvariantViolation(data EventDataInvariantViolation) error {
+	return nil
+}
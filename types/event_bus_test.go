@@ -207,6 +207,48 @@ func TestEventBusPublishEventTxDuplicateKeys(t *testing.T) {
 	}
 }
 
+func TestEventBusHeightRangeAndPipelineStage(t *testing.T) {
+	eventBus := NewEventBus()
+	err := eventBus.Start()
+	require.NoError(t, err)
+	defer eventBus.Stop()
+
+	query := "block.height >= 2 AND block.height <= 3 AND consensus.pipeline_stage='vote'"
+	sub, err := eventBus.Subscribe(context.Background(), "test", tmquery.MustParse(query), 10)
+	require.NoError(t, err)
+
+	for height := int64(1); height <= 4; height++ {
+		err = eventBus.PublishEventNewRoundStep(EventDataRoundState{Height: height, Step: "RoundStepPropose"})
+		require.NoError(t, err)
+		err = eventBus.PublishEventVote(EventDataVote{Vote: &Vote{Height: height}})
+		require.NoError(t, err)
+		err = eventBus.PublishEventPolka(EventDataRoundState{Height: height, Step: "RoundStepPrevote"})
+		require.NoError(t, err)
+	}
+
+	var got []string
+	for len(got) < 4 {
+		select {
+		case msg := <-sub.Out():
+			switch data := msg.Data().(type) {
+			case EventDataVote:
+				got = append(got, fmt.Sprintf("Vote/%d", data.Vote.Height))
+			case EventDataRoundState:
+				got = append(got, fmt.Sprintf("%s/%d", data.Step, data.Height))
+			}
+		case <-time.After(1 * time.Second):
+			t.Fatalf("received %v only", got)
+		}
+	}
+	assert.Equal(t, []string{"Vote/2", "RoundStepPrevote/2", "Vote/3", "RoundStepPrevote/3"}, got)
+
+	select {
+	case msg := <-sub.Out():
+		t.Fatalf("unexpected event %v", msg.Data())
+	case <-time.After(100 * time.Millisecond):
+	}
+}
+
 func TestEventBusPublishEventNewBlockHeader(t *testing.T) {
 	eventBus := NewEventBus()
 	err := eventBus.Start()
@@ -260,7 +302,7 @@ func TestEventBusPublish(t *testing.T) {
 	require.NoError(t, err)
 	defer eventBus.Stop()
 
-	const numEventsExpected = 14
+	const numEventsExpected = 16
 
 	sub, err := eventBus.Subscribe(context.Background(), "test", tmquery.Empty{}, numEventsExpected)
 	require.NoError(t, err)
@@ -305,6 +347,10 @@ func TestEventBusPublish(t *testing.T) {
 	require.NoError(t, err)
 	err = eventBus.PublishEventValidatorSetUpdates(EventDataValidatorSetUpdates{})
 	require.NoError(t, err)
+	err = eventBus.PublishEventFinalizeStall(EventDataFinalizeStall{})
+	require.NoError(t, err)
+	err = eventBus.PublishEventInvariantViolation(EventDataInvariantViolation{})
+	require.NoError(t, err)
 
 	select {
 	case <-done:
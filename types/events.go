@@ -2,8 +2,8 @@ package types
 
 import (
 	"fmt"
+	"time"
 
-	amino "github.com/tendermint/go-amino"
 	abci "github.com/hdac-io/tendermint/abci/types"
 	tmpubsub "github.com/hdac-io/tendermint/libs/pubsub"
 	tmquery "github.com/hdac-io/tendermint/libs/pubsub/query"
@@ -24,17 +24,19 @@ const (
 	// Internal consensus events.
 	// These are used for testing the consensus state machine.
 	// They can also be used to build real-time consensus visualizers.
-	EventCompleteProposal = "CompleteProposal"
-	EventLock             = "Lock"
-	EventNewRound         = "NewRound"
-	EventNewRoundStep     = "NewRoundStep"
-	EventPolka            = "Polka"
-	EventRelock           = "Relock"
-	EventTimeoutPropose   = "TimeoutPropose"
-	EventTimeoutWait      = "TimeoutWait"
-	EventUnlock           = "Unlock"
-	EventValidBlock       = "ValidBlock"
-	EventVote             = "Vote"
+	EventCompleteProposal   = "CompleteProposal"
+	EventFinalizeStall      = "FinalizeStall"
+	EventInvariantViolation = "InvariantViolation"
+	EventLock               = "Lock"
+	EventNewRound           = "NewRound"
+	EventNewRoundStep       = "NewRoundStep"
+	EventPolka              = "Polka"
+	EventRelock             = "Relock"
+	EventTimeoutPropose     = "TimeoutPropose"
+	EventTimeoutWait        = "TimeoutWait"
+	EventUnlock             = "Unlock"
+	EventValidBlock         = "ValidBlock"
+	EventVote               = "Vote"
 )
 
 ///////////////////////////////////////////////////////////////////////////////
@@ -46,19 +48,6 @@ type TMEventData interface {
 	// empty interface
 }
 
-func RegisterEventDatas(cdc *amino.Codec) {
-	cdc.RegisterInterface((*TMEventData)(nil), nil)
-	cdc.RegisterConcrete(EventDataNewBlock{}, "tendermint/event/NewBlock", nil)
-	cdc.RegisterConcrete(EventDataNewBlockHeader{}, "tendermint/event/NewBlockHeader", nil)
-	cdc.RegisterConcrete(EventDataTx{}, "tendermint/event/Tx", nil)
-	cdc.RegisterConcrete(EventDataRoundState{}, "tendermint/event/RoundState", nil)
-	cdc.RegisterConcrete(EventDataNewRound{}, "tendermint/event/NewRound", nil)
-	cdc.RegisterConcrete(EventDataCompleteProposal{}, "tendermint/event/CompleteProposal", nil)
-	cdc.RegisterConcrete(EventDataVote{}, "tendermint/event/Vote", nil)
-	cdc.RegisterConcrete(EventDataValidatorSetUpdates{}, "tendermint/event/ValidatorSetUpdates", nil)
-	cdc.RegisterConcrete(EventDataString(""), "tendermint/event/ProposalString", nil)
-}
-
 // Most event messages are basic types (a block, a transaction)
 // but some (an input to a call tx or a receive) are more exotic
 
@@ -120,6 +109,27 @@ type EventDataValidatorSetUpdates struct {
 	ValidatorUpdates []*Validator `json:"validator_updates"`
 }
 
+// EventDataFinalizeStall is fired (friday) when the committed Height has been
+// waiting for too long for the lower BlockingHeight to be finalized.
+type EventDataFinalizeStall struct {
+	Height         int64         `json:"height"`
+	BlockingHeight int64         `json:"blocking_height"`
+	Waited         time.Duration `json:"waited"`
+
+	// the round state of BlockingHeight, if known
+	BlockingRoundState EventDataRoundState `json:"blocking_round_state"`
+}
+
+// EventDataInvariantViolation is fired (friday) when the background checks
+// find an Invariant of the consensus state violated, about Height if it
+// concerns a single height, at LastHeight.
+type EventDataInvariantViolation struct {
+	Invariant  string `json:"invariant"`
+	Height     int64  `json:"height"`
+	LastHeight int64  `json:"last_height"`
+	Details    string `json:"details"`
+}
+
 ///////////////////////////////////////////////////////////////////////////////
 // PUBSUB
 ///////////////////////////////////////////////////////////////////////////////
@@ -133,10 +143,47 @@ const (
 	// TxHeightKey is a reserved key, used to specify transaction block's height.
 	// see EventBus#PublishEventTx
 	TxHeightKey = "tx.height"
+	// BlockHeightKey is a reserved key, used to specify the height the block,
+	// tx and consensus events are about.
+	BlockHeightKey = "block.height"
+	// PipelineStageKey is a reserved key, used to specify the stage of the
+	// height in the consensus pipeline when the event occurred.
+	PipelineStageKey = "consensus.pipeline_stage"
+	// ConsensusParamsRejectedKey is a reserved key, used to specify why the
+	// consensus params update returned by EndBlock was rejected.
+	// see state.BlockExecutor#ApplyFridayBlock
+	ConsensusParamsRejectedKey = "consensus_params.rejected"
+)
+
+// Values of PipelineStageKey.
+const (
+	// the height is waiting for or receiving its proposal
+	PipelineStagePropose = "propose"
+	// the validators are voting on the proposal of the height
+	PipelineStageVote = "vote"
+	// +2/3 precommitted the block of the height
+	PipelineStageCommit = "commit"
+	// the block of the height was executed and saved
+	PipelineStageFinalized = "finalized"
 )
 
+// PipelineStageOfStep returns the pipeline stage of a height at the given
+// consensus round step, e.g. EventDataRoundState.Step.
+func PipelineStageOfStep(step string) string {
+	switch step {
+	case "RoundStepPrevote", "RoundStepPrevoteWait", "RoundStepPrecommit", "RoundStepPrecommitWait":
+		return PipelineStageVote
+	case "RoundStepCommit":
+		return PipelineStageCommit
+	default:
+		return PipelineStagePropose
+	}
+}
+
 var (
 	EventQueryCompleteProposal    = QueryForEvent(EventCompleteProposal)
+	EventQueryFinalizeStall       = QueryForEvent(EventFinalizeStall)
+	EventQueryInvariantViolation  = QueryForEvent(EventInvariantViolation)
 	EventQueryLock                = QueryForEvent(EventLock)
 	EventQueryNewBlock            = QueryForEvent(EventNewBlock)
 	EventQueryNewBlockHeader      = QueryForEvent(EventNewBlockHeader)
@@ -7,8 +7,6 @@ import (
 	"github.com/pkg/errors"
 	"github.com/hdac-io/tendermint/crypto/tmhash"
 
-	amino "github.com/tendermint/go-amino"
-
 	"github.com/hdac-io/tendermint/crypto"
 	"github.com/hdac-io/tendermint/crypto/merkle"
 )
@@ -65,17 +63,6 @@ type Evidence interface {
 	String() string
 }
 
-func RegisterEvidences(cdc *amino.Codec) {
-	cdc.RegisterInterface((*Evidence)(nil), nil)
-	cdc.RegisterConcrete(&DuplicateVoteEvidence{}, "tendermint/DuplicateVoteEvidence", nil)
-}
-
-func RegisterMockEvidences(cdc *amino.Codec) {
-	cdc.RegisterConcrete(MockGoodEvidence{}, "tendermint/MockGoodEvidence", nil)
-	cdc.RegisterConcrete(MockRandomGoodEvidence{}, "tendermint/MockRandomGoodEvidence", nil)
-	cdc.RegisterConcrete(MockBadEvidence{}, "tendermint/MockBadEvidence", nil)
-}
-
 const (
 	MaxEvidenceBytesDenominator = 10
 )
@@ -201,6 +188,146 @@ func (dve *DuplicateVoteEvidence) ValidateBasic() error {
 	return nil
 }
 
+// NewDuplicateVoteEvidence returns the DuplicateVoteEvidence of the
+// conflicting votes voteA and voteB, signed by a validator of valSet, the
+// validator set at their height. The evidence is verified like by the
+// evidence pool, but for its age.
+func NewDuplicateVoteEvidence(chainID string, valSet *ValidatorSet, voteA, voteB *Vote) (*DuplicateVoteEvidence, error) {
+	if voteA == nil || voteB == nil {
+		return nil, errors.New("One or both of the votes are empty")
+	}
+	idx, val := valSet.GetByAddress(voteA.ValidatorAddress)
+	if val == nil {
+		return nil, fmt.Errorf("Address %X was not a validator at height %d", voteA.ValidatorAddress, voteA.Height)
+	}
+	if idx != voteA.ValidatorIndex {
+		return nil, fmt.Errorf("Validator %X has index %d at height %d, not %d",
+			voteA.ValidatorAddress, idx, voteA.Height, voteA.ValidatorIndex)
+	}
+	dve := &DuplicateVoteEvidence{PubKey: val.PubKey, VoteA: voteA, VoteB: voteB}
+	if err := dve.ValidateBasic(); err != nil {
+		return nil, err
+	}
+	if err := dve.Verify(chainID, val.PubKey); err != nil {
+		return nil, err
+	}
+	return dve, nil
+}
+
+//-----------------------------------------------------------------
+
+// DuplicateProposalEvidence contains evidence a proposer signed two
+// conflicting proposals.
+type DuplicateProposalEvidence struct {
+	PubKey    crypto.PubKey
+	ProposalA *Proposal
+	ProposalB *Proposal
+}
+
+var _ Evidence = &DuplicateProposalEvidence{}
+
+// NewDuplicateProposalEvidence returns the evidence the proposer with pubKey
+// signed the proposals.
+func NewDuplicateProposalEvidence(pubKey crypto.PubKey, proposalA, proposalB *Proposal) *DuplicateProposalEvidence {
+	return &DuplicateProposalEvidence{
+		PubKey:    pubKey,
+		ProposalA: proposalA,
+		ProposalB: proposalB,
+	}
+}
+
+// String returns a string representation of the evidence.
+func (dpe *DuplicateProposalEvidence) String() string {
+	return fmt.Sprintf("ProposalA: %v; ProposalB: %v", dpe.ProposalA, dpe.ProposalB)
+}
+
+// Height returns the height this evidence refers to.
+func (dpe *DuplicateProposalEvidence) Height() int64 {
+	return dpe.ProposalA.Height
+}
+
+// Round returns the round this evidence refers to.
+func (dpe *DuplicateProposalEvidence) Round() int {
+	return dpe.ProposalA.Round
+}
+
+// Address returns the address of the proposer.
+func (dpe *DuplicateProposalEvidence) Address() []byte {
+	return dpe.PubKey.Address()
+}
+
+// Bytes returns the bytes of the evidence.
+func (dpe *DuplicateProposalEvidence) Bytes() []byte {
+	return cdcEncode(dpe)
+}
+
+// Hash returns the hash of the evidence.
+func (dpe *DuplicateProposalEvidence) Hash() []byte {
+	return tmhash.Sum(cdcEncode(dpe))
+}
+
+// Verify returns an error if the two proposals aren't conflicting.
+// To be conflicting, they must be signed by pubKey for the same H/R, but for
+// different blocks. That pubKey is the proposer of the round is checked by
+// the caller.
+func (dpe *DuplicateProposalEvidence) Verify(chainID string, pubKey crypto.PubKey) error {
+	// H/R must be the same
+	if dpe.ProposalA.Height != dpe.ProposalB.Height ||
+		dpe.ProposalA.Round != dpe.ProposalB.Round {
+		return fmt.Errorf("DuplicateProposalEvidence Error: H/R does not match. Got %v and %v", dpe.ProposalA, dpe.ProposalB)
+	}
+
+	// BlockIDs must be different
+	if dpe.ProposalA.BlockID.Equals(dpe.ProposalB.BlockID) {
+		return fmt.Errorf("DuplicateProposalEvidence Error: BlockIDs are the same (%v) - not a real duplicate proposal", dpe.ProposalA.BlockID)
+	}
+
+	// pubkey must match the evidence pubkey (this should already be true, sanity check)
+	if !bytes.Equal(pubKey.Address(), dpe.PubKey.Address()) {
+		return fmt.Errorf("DuplicateProposalEvidence FAILED SANITY CHECK - address (%X) doesn't match pubkey (%v - %X)",
+			dpe.PubKey.Address(), pubKey, pubKey.Address())
+	}
+
+	// Signatures must be valid
+	if !pubKey.VerifyBytes(dpe.ProposalA.SignBytes(chainID), dpe.ProposalA.Signature) {
+		return errors.New("DuplicateProposalEvidence Error verifying ProposalA: invalid signature")
+	}
+	if !pubKey.VerifyBytes(dpe.ProposalB.SignBytes(chainID), dpe.ProposalB.Signature) {
+		return errors.New("DuplicateProposalEvidence Error verifying ProposalB: invalid signature")
+	}
+
+	return nil
+}
+
+// Equal checks if two pieces of evidence are equal.
+func (dpe *DuplicateProposalEvidence) Equal(ev Evidence) bool {
+	if _, ok := ev.(*DuplicateProposalEvidence); !ok {
+		return false
+	}
+
+	// just check their hashes
+	dpeHash := tmhash.Sum(cdcEncode(dpe))
+	evHash := tmhash.Sum(cdcEncode(ev))
+	return bytes.Equal(dpeHash, evHash)
+}
+
+// ValidateBasic performs basic validation.
+func (dpe *DuplicateProposalEvidence) ValidateBasic() error {
+	if dpe.PubKey == nil || len(dpe.PubKey.Bytes()) == 0 {
+		return errors.New("Empty PubKey")
+	}
+	if dpe.ProposalA == nil || dpe.ProposalB == nil {
+		return fmt.Errorf("One or both of the proposals are empty %v, %v", dpe.ProposalA, dpe.ProposalB)
+	}
+	if err := dpe.ProposalA.ValidateBasic(); err != nil {
+		return fmt.Errorf("Invalid ProposalA: %v", err)
+	}
+	if err := dpe.ProposalB.ValidateBasic(); err != nil {
+		return fmt.Errorf("Invalid ProposalB: %v", err)
+	}
+	return nil
+}
+
 //-----------------------------------------------------------------
 
 // UNSTABLE
@@ -159,6 +159,42 @@ func TestDuplicateVoteEvidenceValidation(t *testing.T) {
 	}
 }
 
+func TestNewDuplicateVoteEvidence(t *testing.T) {
+	valSet, privVals := RandValidatorSet(2, 10)
+	val, other := privVals[0], privVals[1]
+	idx, _ := valSet.GetByAddress(val.GetPubKey().Address())
+	blockID := makeBlockID(tmhash.Sum([]byte("blockhash")), 1000, tmhash.Sum([]byte("partshash")))
+	blockID2 := makeBlockID(tmhash.Sum([]byte("blockhash2")), 1000, tmhash.Sum([]byte("partshash")))
+	const chainID = "mychain"
+
+	voteA := makeVote(val, chainID, idx, 10, 2, 1, blockID)
+	ev, err := NewDuplicateVoteEvidence(chainID, valSet, voteA, makeVote(val, chainID, idx, 10, 2, 1, blockID2))
+	require.NoError(t, err)
+	assert.True(t, ev.PubKey.Equals(val.GetPubKey()))
+	assert.NoError(t, ev.Verify(chainID, val.GetPubKey()))
+
+	cases := []struct {
+		testName     string
+		chainID      string
+		valSet       *ValidatorSet
+		voteA, voteB *Vote
+	}{
+		{"Nil vote", chainID, valSet, voteA, nil},
+		{"Same block", chainID, valSet, voteA, makeVote(val, chainID, idx, 10, 2, 1, blockID)},
+		{"Other round", chainID, valSet, voteA, makeVote(val, chainID, idx, 10, 3, 1, blockID2)},
+		{"Other validator", chainID, valSet, voteA, makeVote(other, chainID, 1-idx, 10, 2, 1, blockID2)},
+		{"Other chain", "mychain2", valSet, voteA, makeVote(val, chainID, idx, 10, 2, 1, blockID2)},
+		{"Wrong index", chainID, valSet, makeVote(val, chainID, 1-idx, 10, 2, 1, blockID),
+			makeVote(val, chainID, 1-idx, 10, 2, 1, blockID2)},
+		{"Not a validator", chainID, NewValidatorSet([]*Validator{valSet.Validators[1-idx]}), voteA,
+			makeVote(val, chainID, idx, 10, 2, 1, blockID2)},
+	}
+	for _, tc := range cases {
+		_, err := NewDuplicateVoteEvidence(tc.chainID, tc.valSet, tc.voteA, tc.voteB)
+		assert.Error(t, err, tc.testName)
+	}
+}
+
 func TestMockGoodEvidenceValidateBasic(t *testing.T) {
 	goodEvidence := NewMockGoodEvidence(int64(1), 1, []byte{1})
 	assert.Nil(t, goodEvidence.ValidateBasic())
@@ -168,3 +204,61 @@ func TestMockBadEvidenceValidateBasic(t *testing.T) {
 	badEvidence := MockBadEvidence{MockGoodEvidence: NewMockGoodEvidence(int64(1), 1, []byte{1})}
 	assert.Nil(t, badEvidence.ValidateBasic())
 }
+
+func makeProposal(val PrivValidator, chainID string, height int64, round int, blockID BlockID) *Proposal {
+	p := NewProposal(height, round, -1, blockID)
+	if err := val.SignProposal(chainID, p); err != nil {
+		panic(err)
+	}
+	return p
+}
+
+func TestDuplicateProposalEvidence(t *testing.T) {
+	val := NewMockPV()
+	val2 := NewMockPV()
+	blockID := makeBlockID(tmhash.Sum([]byte("blockhash")), 1000, tmhash.Sum([]byte("partshash")))
+	blockID2 := makeBlockID(tmhash.Sum([]byte("blockhash2")), 1000, tmhash.Sum([]byte("partshash")))
+	const chainID = "mychain"
+
+	proposal := makeProposal(val, chainID, 10, 2, blockID)
+	testCases := []struct {
+		testName  string
+		proposal  *Proposal
+		expectErr bool
+	}{
+		{"Different block ids", makeProposal(val, chainID, 10, 2, blockID2), false},
+		{"Same block id", makeProposal(val, chainID, 10, 2, blockID), true},
+		{"Wrong height", makeProposal(val, chainID, 11, 2, blockID2), true},
+		{"Wrong round", makeProposal(val, chainID, 10, 3, blockID2), true},
+		{"Wrong chain id", makeProposal(val, "mychain2", 10, 2, blockID2), true},
+		{"Wrong signer", makeProposal(val2, chainID, 10, 2, blockID2), true},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.testName, func(t *testing.T) {
+			ev := NewDuplicateProposalEvidence(val.GetPubKey(), proposal, tc.proposal)
+			assert.NoError(t, ev.ValidateBasic())
+			assert.Equal(t, tc.expectErr, ev.Verify(chainID, val.GetPubKey()) != nil)
+		})
+	}
+
+	ev := NewDuplicateProposalEvidence(val.GetPubKey(), proposal, nil)
+	assert.Error(t, ev.ValidateBasic())
+}
+
+func TestMaxDuplicateProposalEvidenceBytes(t *testing.T) {
+	val := NewMockPV()
+	blockID := makeBlockID(tmhash.Sum([]byte("blockhash")), math.MaxInt64, tmhash.Sum([]byte("partshash")))
+	blockID2 := makeBlockID(tmhash.Sum([]byte("blockhash2")), math.MaxInt64, tmhash.Sum([]byte("partshash")))
+	const chainID = "mychain"
+	ev := NewDuplicateProposalEvidence(
+		secp256k1.GenPrivKey().PubKey(), // use secp because it's pubkey is longer
+		makeProposal(val, chainID, math.MaxInt64, math.MaxInt64, blockID),
+		makeProposal(val, chainID, math.MaxInt64, math.MaxInt64, blockID2),
+	)
+
+	bz, err := cdc.MarshalBinaryLengthPrefixed(ev)
+	require.NoError(t, err)
+
+	assert.True(t, int64(len(bz)) <= MaxEvidenceBytes, "%d bytes", len(bz))
+}
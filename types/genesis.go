@@ -10,6 +10,7 @@ import (
 	"github.com/pkg/errors"
 
 	"github.com/hdac-io/tendermint/crypto"
+	"github.com/hdac-io/tendermint/crypto/bls"
 	cmn "github.com/hdac-io/tendermint/libs/common"
 	tmtime "github.com/hdac-io/tendermint/types/time"
 )
@@ -31,6 +32,9 @@ type GenesisValidator struct {
 	PubKey  crypto.PubKey `json:"pub_key"`
 	Power   int64         `json:"power"`
 	Name    string        `json:"name"`
+
+	// ProofOfPossession of a BLS key, see Validator.
+	ProofOfPossession []byte `json:"proof_of_possession,omitempty"`
 }
 
 // GenesisDoc defines the initial conditions for a tendermint blockchain, in particular its validator set.
@@ -93,6 +97,9 @@ func (genDoc *GenesisDoc) ValidateAndComplete() error {
 	}
 
 	for i, v := range genDoc.Validators {
+		if v.PubKey == nil {
+			return errors.Errorf("The genesis file cannot contain validators with no pub_key: %v", v)
+		}
 		if v.Power == 0 {
 			return errors.Errorf("The genesis file cannot contain validators with no voting power: %v", v)
 		}
@@ -103,6 +110,13 @@ func (genDoc *GenesisDoc) ValidateAndComplete() error {
 			genDoc.Validators[i].Address = v.PubKey.Address()
 		}
 	}
+	vals := make([]*Validator, len(genDoc.Validators))
+	for i, v := range genDoc.Validators {
+		vals[i] = NewValidator(v.PubKey, v.Power)
+	}
+	if err := genDoc.ConsensusParams.Validator.CheckPowerShare(vals); err != nil {
+		return errors.Wrap(err, "Genesis validators exceed the max power share")
+	}
 
 	if genDoc.GenesisTime.IsZero() {
 		genDoc.GenesisTime = tmtime.Now()
@@ -111,6 +125,130 @@ func (genDoc *GenesisDoc) ValidateAndComplete() error {
 	return nil
 }
 
+// ValidateForModule runs ValidateAndComplete and the checks of the
+// validate-genesis command against genDoc and returns all the problems it
+// found. module is the consensus module of the local config.
+func (genDoc *GenesisDoc) ValidateForModule(module string) []error {
+	var problems []error
+
+	// ValidateAndComplete fills in the defaults the node would use,
+	// so the remaining checks see the same document the node sees.
+	if err := genDoc.ValidateAndComplete(); err != nil {
+		problems = append(problems, err)
+		// without a known module or valid params the other checks are meaningless
+		if genDoc.ConsensusParams == nil {
+			return problems
+		}
+	}
+
+	if genDoc.ConsensusModule != module {
+		problems = append(problems, fmt.Errorf(
+			"consensus_module of the genesis is %q but the node is configured with %q; "+
+				"set [consensus] module = %q in config.toml or fix the genesis",
+			genDoc.ConsensusModule, module, genDoc.ConsensusModule))
+	}
+
+	problems = append(problems, validateGenesisLenULB(genDoc)...)
+	problems = append(problems, validateGenesisValidators(genDoc)...)
+
+	if len(genDoc.AppState) > 0 {
+		var appState map[string]json.RawMessage
+		if err := json.Unmarshal(genDoc.AppState, &appState); err != nil {
+			problems = append(problems, fmt.Errorf(
+				"app_state must be a JSON object, the application won't be able to initialize its state: %v", err))
+		}
+	}
+
+	return problems
+}
+
+func validateGenesisLenULB(genDoc *GenesisDoc) []error {
+	lenULB := genDoc.ConsensusParams.Block.LenULB
+
+	switch genDoc.ConsensusModule {
+	case "friday":
+		if lenULB <= 0 {
+			return []error{fmt.Errorf(
+				"consensus_params.block.len_ulb must be greater than 0 for friday consensus. Got %d", lenULB)}
+		}
+		if lenULB > MaxLenULB {
+			return []error{fmt.Errorf(
+				"consensus_params.block.len_ulb is too big. %d > %d", lenULB, MaxLenULB)}
+		}
+	case "tendermint":
+		if lenULB != 0 {
+			return []error{fmt.Errorf(
+				"consensus_params.block.len_ulb is only used by friday consensus, "+
+					"remove it or set consensus_module to \"friday\". Got %d", lenULB)}
+		}
+	}
+	return nil
+}
+
+func validateGenesisValidators(genDoc *GenesisDoc) []error {
+	var problems []error
+
+	if len(genDoc.Validators) == 0 {
+		// the application is expected to provide them in InitChain
+		return nil
+	}
+
+	var zeroBLSKey bls.PubKeyBls
+	seen := make(map[string]int, len(genDoc.Validators))
+	totalPower := int64(0)
+	for i, v := range genDoc.Validators {
+		if v.PubKey == nil {
+			problems = append(problems, fmt.Errorf("validators[%d] (%s) has no pub_key", i, v.Name))
+			continue
+		}
+
+		if j, ok := seen[string(v.PubKey.Address())]; ok {
+			problems = append(problems, fmt.Errorf(
+				"validators[%d] and validators[%d] have the same address %v", j, i, v.PubKey.Address()))
+		}
+		seen[string(v.PubKey.Address())] = i
+
+		keyType := TM2PB.PubKey(v.PubKey).Type
+		if !genDoc.ConsensusParams.Validator.IsValidPubkeyType(keyType) {
+			problems = append(problems, fmt.Errorf(
+				"validators[%d] (%s) has a %q key but consensus_params.validator.pub_key_types only allows %v",
+				i, v.Name, keyType, genDoc.ConsensusParams.Validator.PubKeyTypes))
+		}
+
+		switch pubKey := v.PubKey.(type) {
+		case bls.PubKeyBls:
+			if pubKey.Equals(zeroBLSKey) {
+				problems = append(problems, fmt.Errorf("validators[%d] (%s) has an invalid (zero) BLS pub_key", i, v.Name))
+			} else if len(v.ProofOfPossession) > 0 && !pubKey.VerifyProofOfPossession(v.ProofOfPossession) {
+				problems = append(problems, fmt.Errorf("validators[%d] (%s) has an invalid proof_of_possession", i, v.Name))
+			}
+		default:
+			if len(v.ProofOfPossession) > 0 {
+				problems = append(problems, fmt.Errorf(
+					"validators[%d] (%s) has a proof_of_possession but not a BLS pub_key", i, v.Name))
+			}
+			if genDoc.ConsensusModule == "friday" {
+				problems = append(problems, fmt.Errorf(
+					"validators[%d] (%s) must have a BLS pub_key for friday consensus; "+
+						"regenerate the validator key with `tendermint init`", i, v.Name))
+			}
+		}
+
+		if v.Power < 0 {
+			problems = append(problems, fmt.Errorf("validators[%d] (%s) has negative voting power %d", i, v.Name, v.Power))
+			continue
+		}
+		if v.Power > MaxTotalVotingPower-totalPower {
+			problems = append(problems, fmt.Errorf(
+				"total voting power of the validators exceeds the maximum %d", MaxTotalVotingPower))
+			break
+		}
+		totalPower += v.Power
+	}
+
+	return problems
+}
+
 //------------------------------------------------------------
 // Make genesis state from file
 
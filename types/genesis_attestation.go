@@ -0,0 +1,182 @@
+package types
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+
+	"github.com/pkg/errors"
+
+	"github.com/hdac-io/tendermint/crypto"
+	"github.com/hdac-io/tendermint/crypto/tmhash"
+	cmn "github.com/hdac-io/tendermint/libs/common"
+)
+
+// GenesisAttestation is a detached attestation of a genesis file, distributed
+// with it: the signatures of the genesis validators over the hash of the file,
+// so each node can check it has the genesis file the validators agreed on
+// before the chain starts.
+type GenesisAttestation struct {
+	ChainID     string             `json:"chain_id"`
+	GenesisHash cmn.HexBytes       `json:"genesis_hash"`
+	Signatures  []GenesisSignature `json:"signatures"`
+}
+
+// GenesisSignature is the signature of a genesis validator in a
+// GenesisAttestation.
+type GenesisSignature struct {
+	Address   Address       `json:"address"`
+	PubKey    crypto.PubKey `json:"pub_key"`
+	Signature []byte        `json:"signature"`
+}
+
+// NewGenesisAttestation returns an attestation without signatures of the
+// genesis file jsonBlob, whose document is genDoc.
+func NewGenesisAttestation(jsonBlob []byte, genDoc *GenesisDoc) *GenesisAttestation {
+	return &GenesisAttestation{
+		ChainID:     genDoc.ChainID,
+		GenesisHash: tmhash.Sum(jsonBlob),
+		Signatures:  []GenesisSignature{},
+	}
+}
+
+type canonicalGenesisAttestation struct {
+	Type        string
+	ChainID     string
+	GenesisHash []byte
+}
+
+// GenesisAttestationSignBytes returns the bytes the validators sign to attest
+// the genesis file with the hash genesisHash. They are a hash themselves, so
+// they are signed whole by every key type.
+func GenesisAttestationSignBytes(chainID string, genesisHash []byte) []byte {
+	bz := cdc.MustMarshalBinaryLengthPrefixed(canonicalGenesisAttestation{
+		Type:        "genesis_attestation",
+		ChainID:     chainID,
+		GenesisHash: genesisHash,
+	})
+	return tmhash.Sum(bz)
+}
+
+// SignBytes returns the bytes the validators sign.
+func (ga *GenesisAttestation) SignBytes() []byte {
+	return GenesisAttestationSignBytes(ga.ChainID, ga.GenesisHash)
+}
+
+// Sign adds the signature of privKey, the key of a validator of genDoc, to the
+// attestation, replacing its previous signature if any.
+func (ga *GenesisAttestation) Sign(genDoc *GenesisDoc, privKey crypto.PrivKey) error {
+	if ga.ChainID != genDoc.ChainID {
+		return fmt.Errorf("attestation of chain %s, not %s", ga.ChainID, genDoc.ChainID)
+	}
+	pubKey := privKey.PubKey()
+	if genesisValidator(genDoc, pubKey) == nil {
+		return fmt.Errorf("%v is not a genesis validator", pubKey.Address())
+	}
+	sig, err := privKey.Sign(ga.SignBytes())
+	if err != nil {
+		return err
+	}
+
+	gs := GenesisSignature{Address: pubKey.Address(), PubKey: pubKey, Signature: sig}
+	for i, other := range ga.Signatures {
+		if bytes.Equal(other.Address, gs.Address) {
+			ga.Signatures[i] = gs
+			return nil
+		}
+	}
+	ga.Signatures = append(ga.Signatures, gs)
+	return nil
+}
+
+// Signed returns true if the validator with the address signed the
+// attestation. The signature is not verified.
+func (ga *GenesisAttestation) Signed(address Address) bool {
+	for _, gs := range ga.Signatures {
+		if bytes.Equal(gs.Address, address) {
+			return true
+		}
+	}
+	return false
+}
+
+// Verify verifies that the attestation is about the genesis file jsonBlob,
+// whose document is genDoc, and that it has valid signatures of validators of
+// genDoc holding more than 2/3 of their voting power. Any invalid signature,
+// or signature of another key than a genesis validator's, is an error.
+func (ga *GenesisAttestation) Verify(jsonBlob []byte, genDoc *GenesisDoc) error {
+	if ga.ChainID != genDoc.ChainID {
+		return fmt.Errorf("attestation of chain %s, not %s", ga.ChainID, genDoc.ChainID)
+	}
+	if hash := tmhash.Sum(jsonBlob); !bytes.Equal(ga.GenesisHash, hash) {
+		return fmt.Errorf("attestation of the genesis file with the hash %v, not %v (stale genesis file?)",
+			ga.GenesisHash, cmn.HexBytes(hash))
+	}
+	if len(genDoc.Validators) == 0 {
+		return errors.New("the genesis has no validators to attest it")
+	}
+
+	signBytes := ga.SignBytes()
+	signed := make(map[string]bool)
+	signedPower := int64(0)
+	for i, gs := range ga.Signatures {
+		if gs.PubKey == nil {
+			return fmt.Errorf("signatures[%d] has no pub_key", i)
+		}
+		if !bytes.Equal(gs.Address, gs.PubKey.Address()) {
+			return fmt.Errorf("signatures[%d] address %v doesn't match its pub_key", i, gs.Address)
+		}
+		val := genesisValidator(genDoc, gs.PubKey)
+		if val == nil {
+			return fmt.Errorf("signatures[%d] by %v, which is not a genesis validator", i, gs.Address)
+		}
+		if !gs.PubKey.VerifyBytes(signBytes, gs.Signature) {
+			return fmt.Errorf("invalid signatures[%d] by %v", i, gs.Address)
+		}
+		if !signed[string(gs.Address)] {
+			signed[string(gs.Address)] = true
+			signedPower += val.Power
+		}
+	}
+
+	totalPower := int64(0)
+	for _, val := range genDoc.Validators {
+		totalPower += val.Power
+	}
+	if signedPower <= totalPower*2/3 {
+		return fmt.Errorf("attestation signed by validators with %d of the %d voting power, need more than 2/3",
+			signedPower, totalPower)
+	}
+	return nil
+}
+
+// SaveAs saves the attestation as a JSON file.
+func (ga *GenesisAttestation) SaveAs(file string) error {
+	bz, err := cdc.MarshalJSONIndent(ga, "", "  ")
+	if err != nil {
+		return err
+	}
+	return cmn.WriteFile(file, bz, 0644)
+}
+
+// GenesisAttestationFromFile reads a GenesisAttestation from a JSON file.
+func GenesisAttestationFromFile(file string) (*GenesisAttestation, error) {
+	bz, err := ioutil.ReadFile(file)
+	if err != nil {
+		return nil, errors.Wrap(err, "couldn't read genesis attestation file")
+	}
+	ga := new(GenesisAttestation)
+	if err := cdc.UnmarshalJSON(bz, ga); err != nil {
+		return nil, errors.Wrap(err, fmt.Sprintf("error reading genesis attestation at %v", file))
+	}
+	return ga, nil
+}
+
+func genesisValidator(genDoc *GenesisDoc, pubKey crypto.PubKey) *GenesisValidator {
+	for i, val := range genDoc.Validators {
+		if val.PubKey != nil && val.PubKey.Equals(pubKey) {
+			return &genDoc.Validators[i]
+		}
+	}
+	return nil
+}
@@ -0,0 +1,109 @@
+package types
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hdac-io/tendermint/crypto"
+	"github.com/hdac-io/tendermint/crypto/bls"
+	tmtime "github.com/hdac-io/tendermint/types/time"
+)
+
+func attestedGenesisDoc(t *testing.T, powers ...int64) (*GenesisDoc, []byte, []crypto.PrivKey) {
+	genDoc := &GenesisDoc{
+		GenesisTime:     tmtime.Now(),
+		ChainID:         "abc",
+		ConsensusModule: "friday",
+		ConsensusParams: DefaultFridayConsensusParams(),
+	}
+	var privKeys []crypto.PrivKey
+	for _, power := range powers {
+		privKey := bls.GenPrivKey()
+		privKeys = append(privKeys, privKey)
+		genDoc.Validators = append(genDoc.Validators,
+			GenesisValidator{privKey.PubKey().Address(), privKey.PubKey(), power, "", nil})
+	}
+	jsonBlob, err := cdc.MarshalJSONIndent(genDoc, "", "  ")
+	require.NoError(t, err)
+	genDoc, err = GenesisDocFromJSON(jsonBlob)
+	require.NoError(t, err)
+	return genDoc, jsonBlob, privKeys
+}
+
+func TestGenesisAttestation(t *testing.T) {
+	genDoc, jsonBlob, privKeys := attestedGenesisDoc(t, 10, 10, 10, 10)
+	ga := NewGenesisAttestation(jsonBlob, genDoc)
+	assert.Error(t, ga.Verify(jsonBlob, genDoc), "no signatures")
+
+	require.NoError(t, ga.Sign(genDoc, privKeys[0]))
+	require.NoError(t, ga.Sign(genDoc, privKeys[1]))
+	require.NoError(t, ga.Sign(genDoc, privKeys[1]))
+	assert.Len(t, ga.Signatures, 2, "a validator signs once")
+	assert.Error(t, ga.Verify(jsonBlob, genDoc), "1/2 of the voting power")
+
+	require.NoError(t, ga.Sign(genDoc, privKeys[2]))
+	assert.NoError(t, ga.Verify(jsonBlob, genDoc))
+	assert.True(t, ga.Signed(genDoc.Validators[2].Address))
+	assert.False(t, ga.Signed(genDoc.Validators[3].Address))
+
+	// not a genesis validator
+	assert.Error(t, ga.Sign(genDoc, bls.GenPrivKey()))
+
+	// stale genesis file
+	staleDoc, staleBlob, _ := attestedGenesisDoc(t, 10)
+	staleDoc.ChainID = genDoc.ChainID
+	assert.Error(t, ga.Verify(staleBlob, staleDoc))
+	assert.Error(t, ga.Verify(append(jsonBlob, '\n'), genDoc))
+
+	// save and load
+	dir, err := ioutil.TempDir("", "genesis_attestation")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	file := filepath.Join(dir, "genesis_attestation.json")
+	require.NoError(t, ga.SaveAs(file))
+	loaded, err := GenesisAttestationFromFile(file)
+	require.NoError(t, err)
+	assert.Equal(t, ga.ChainID, loaded.ChainID)
+	assert.Equal(t, ga.GenesisHash, loaded.GenesisHash)
+	require.Len(t, loaded.Signatures, len(ga.Signatures))
+	for i, gs := range loaded.Signatures {
+		assert.Equal(t, ga.Signatures[i].Address, gs.Address)
+		assert.True(t, ga.Signatures[i].PubKey.Equals(gs.PubKey))
+		assert.Equal(t, ga.Signatures[i].Signature, gs.Signature)
+	}
+	assert.NoError(t, loaded.Verify(jsonBlob, genDoc))
+}
+
+func TestGenesisAttestationInvalid(t *testing.T) {
+	genDoc, jsonBlob, privKeys := attestedGenesisDoc(t, 10, 10, 10)
+	other := bls.GenPrivKey()
+
+	testCases := []struct {
+		name     string
+		malleate func(ga *GenesisAttestation)
+	}{
+		{"wrong chain ID", func(ga *GenesisAttestation) { ga.ChainID = "other" }},
+		{"wrong signature", func(ga *GenesisAttestation) { ga.Signatures[0].Signature = ga.Signatures[1].Signature }},
+		{"wrong address", func(ga *GenesisAttestation) { ga.Signatures[0].Address = ga.Signatures[1].Address }},
+		{"no pub key", func(ga *GenesisAttestation) { ga.Signatures[0].PubKey = nil }},
+		{"not a validator", func(ga *GenesisAttestation) {
+			sig, err := other.Sign(ga.SignBytes())
+			require.NoError(t, err)
+			ga.Signatures = append(ga.Signatures, GenesisSignature{other.PubKey().Address(), other.PubKey(), sig})
+		}},
+	}
+	for _, tc := range testCases {
+		ga := NewGenesisAttestation(jsonBlob, genDoc)
+		for _, privKey := range privKeys {
+			require.NoError(t, ga.Sign(genDoc, privKey))
+		}
+		require.NoError(t, ga.Verify(jsonBlob, genDoc), tc.name)
+		tc.malleate(ga)
+		assert.Error(t, ga.Verify(jsonBlob, genDoc), tc.name)
+	}
+}
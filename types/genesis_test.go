@@ -3,11 +3,13 @@ package types
 import (
 	"io/ioutil"
 	"os"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"github.com/hdac-io/tendermint/crypto/bls"
 	"github.com/hdac-io/tendermint/crypto/ed25519"
 	tmtime "github.com/hdac-io/tendermint/types/time"
 )
@@ -45,7 +47,7 @@ func TestGenesisGood(t *testing.T) {
 	// create a base gendoc from struct
 	baseGenDoc := &GenesisDoc{
 		ChainID:    "abc",
-		Validators: []GenesisValidator{{pubkey.Address(), pubkey, 10, "myval"}},
+		Validators: []GenesisValidator{{pubkey.Address(), pubkey, 10, "myval", nil}},
 	}
 	genDocBytes, err = cdc.MarshalJSON(baseGenDoc)
 	assert.NoError(t, err, "error marshalling genDoc")
@@ -85,6 +87,93 @@ func TestGenesisGood(t *testing.T) {
 	}
 }
 
+func TestGenesisValidateForModule(t *testing.T) {
+	blsPrivKey := bls.GenPrivKey()
+	blsKey := blsPrivKey.PubKey()
+	edKey := ed25519.GenPrivKey().PubKey()
+	validGenDoc := func() *GenesisDoc {
+		return &GenesisDoc{
+			ChainID:         "abc",
+			ConsensusModule: "friday",
+			Validators: []GenesisValidator{
+				{PubKey: blsKey, Power: 10, Name: "bls", ProofOfPossession: blsPrivKey.ProofOfPossession()},
+				{PubKey: bls.GenPrivKey().PubKey(), Power: 10, Name: "bls2"},
+			},
+			AppState: []byte(`{"account_owner": "Bob"}`),
+		}
+	}
+
+	testCases := []struct {
+		name     string
+		module   string
+		malleate func(*GenesisDoc)
+		problem  string
+	}{
+		{"valid friday", "friday", func(*GenesisDoc) {}, ""},
+		{"valid without validators", "friday", func(g *GenesisDoc) { g.Validators = nil }, ""},
+		{"valid tendermint", "tendermint", func(g *GenesisDoc) { g.ConsensusModule = "tendermint" }, ""},
+		{"unknown module", "friday", func(g *GenesisDoc) { g.ConsensusModule = "monday" }, "invalid consenssus module"},
+		{"module mismatch", "tendermint", func(*GenesisDoc) {}, "but the node is configured with \"tendermint\""},
+		{"friday without LenULB", "friday", func(g *GenesisDoc) {
+			g.ConsensusParams = DefaultFridayConsensusParams()
+			g.ConsensusParams.Block.LenULB = 0
+		}, "len_ulb must be greater than 0"},
+		{"friday LenULB too big", "friday", func(g *GenesisDoc) {
+			g.ConsensusParams = DefaultFridayConsensusParams()
+			g.ConsensusParams.Block.LenULB = MaxLenULB + 1
+		}, "len_ulb is too big"},
+		{"tendermint with LenULB", "tendermint", func(g *GenesisDoc) {
+			g.ConsensusModule = "tendermint"
+			g.ConsensusParams = DefaultFridayConsensusParams()
+		}, "len_ulb is only used by friday consensus"},
+		{"no pub_key", "friday", func(g *GenesisDoc) { g.Validators[1].PubKey = nil }, "has no pub_key"},
+		{"duplicate validator", "friday", func(g *GenesisDoc) { g.Validators[1].PubKey = blsKey }, "have the same address"},
+		{"key type not allowed", "tendermint", func(g *GenesisDoc) {
+			g.ConsensusModule = "tendermint"
+			g.Validators[1].PubKey = edKey
+		}, "pub_key_types only allows"},
+		{"zero BLS key", "friday", func(g *GenesisDoc) { g.Validators[1].PubKey = bls.PubKeyBls{} }, "invalid (zero) BLS pub_key"},
+		{"proof of possession of another key", "friday", func(g *GenesisDoc) {
+			g.Validators[1].ProofOfPossession = g.Validators[0].ProofOfPossession
+		}, "invalid proof_of_possession"},
+		{"proof of possession without BLS key", "tendermint", func(g *GenesisDoc) {
+			g.ConsensusModule = "tendermint"
+			g.ConsensusParams = DefaultConsensusParams()
+			g.ConsensusParams.Validator.PubKeyTypes = []string{ABCIPubKeyTypeBLS, ABCIPubKeyTypeEd25519}
+			g.Validators[1].PubKey = edKey
+			g.Validators[1].ProofOfPossession = g.Validators[0].ProofOfPossession
+		}, "has a proof_of_possession but not a BLS pub_key"},
+		{"friday without BLS key", "friday", func(g *GenesisDoc) {
+			g.ConsensusParams = DefaultFridayConsensusParams()
+			g.ConsensusParams.Validator.PubKeyTypes = []string{ABCIPubKeyTypeBLS, ABCIPubKeyTypeEd25519}
+			g.Validators[1].PubKey = edKey
+		}, "must have a BLS pub_key for friday consensus"},
+		{"negative power", "friday", func(g *GenesisDoc) { g.Validators[1].Power = -1 }, "negative voting power"},
+		{"total power too big", "friday", func(g *GenesisDoc) {
+			g.Validators[0].Power = MaxTotalVotingPower
+		}, "exceeds the maximum"},
+		{"app_state not an object", "friday", func(g *GenesisDoc) { g.AppState = []byte(`["Bob"]`) }, "app_state must be a JSON object"},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			genDoc := validGenDoc()
+			tc.malleate(genDoc)
+			problems := genDoc.ValidateForModule(tc.module)
+			if tc.problem == "" {
+				assert.Empty(t, problems)
+				return
+			}
+			found := false
+			for _, problem := range problems {
+				found = found || strings.Contains(problem.Error(), tc.problem)
+			}
+			assert.True(t, found, "expected %q in %v", tc.problem, problems)
+		})
+	}
+}
+
 func TestGenesisSaveAs(t *testing.T) {
 	tmpfile, err := ioutil.TempFile("", "genesis")
 	require.NoError(t, err)
@@ -122,7 +211,7 @@ func randomGenesisDoc() *GenesisDoc {
 	return &GenesisDoc{
 		GenesisTime:     tmtime.Now(),
 		ChainID:         "abc",
-		Validators:      []GenesisValidator{{pubkey.Address(), pubkey, 10, "myval"}},
+		Validators:      []GenesisValidator{{pubkey.Address(), pubkey, 10, "myval", nil}},
 		ConsensusParams: DefaultConsensusParams(),
 	}
 }
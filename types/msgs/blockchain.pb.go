@@ -0,0 +1,2187 @@
+// Code generated by protoc-gen-gogo. DO NOT EDIT.
+// source: types/msgs/blockchain.proto
+
+package msgs
+
+import (
+	fmt "fmt"
+	_ "github.com/gogo/protobuf/gogoproto"
+	proto "github.com/gogo/protobuf/proto"
+	io "io"
+	math "math"
+	math_bits "math/bits"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.GoGoProtoPackageIsVersion3 // please upgrade the proto package
+
+type BlockRequestMessage struct {
+	Height               int64    `protobuf:"varint,1,opt,name=height,proto3" json:"height,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *BlockRequestMessage) Reset()         { *m = BlockRequestMessage{} }
+func (m *BlockRequestMessage) String() string { return proto.CompactTextString(m) }
+func (*BlockRequestMessage) ProtoMessage()    {}
+func (*BlockRequestMessage) Descriptor() ([]byte, []int) {
+	return fileDescriptor_8922a7d82d7de808, []int{0}
+}
+func (m *BlockRequestMessage) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *BlockRequestMessage) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_BlockRequestMessage.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *BlockRequestMessage) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BlockRequestMessage.Merge(m, src)
+}
+func (m *BlockRequestMessage) XXX_Size() int {
+	return m.Size()
+}
+func (m *BlockRequestMessage) XXX_DiscardUnknown() {
+	xxx_messageInfo_BlockRequestMessage.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BlockRequestMessage proto.InternalMessageInfo
+
+func (m *BlockRequestMessage) GetHeight() int64 {
+	if m != nil {
+		return m.Height
+	}
+	return 0
+}
+
+type NoBlockResponseMessage struct {
+	Height               int64    `protobuf:"varint,1,opt,name=height,proto3" json:"height,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *NoBlockResponseMessage) Reset()         { *m = NoBlockResponseMessage{} }
+func (m *NoBlockResponseMessage) String() string { return proto.CompactTextString(m) }
+func (*NoBlockResponseMessage) ProtoMessage()    {}
+func (*NoBlockResponseMessage) Descriptor() ([]byte, []int) {
+	return fileDescriptor_8922a7d82d7de808, []int{1}
+}
+func (m *NoBlockResponseMessage) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *NoBlockResponseMessage) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_NoBlockResponseMessage.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *NoBlockResponseMessage) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_NoBlockResponseMessage.Merge(m, src)
+}
+func (m *NoBlockResponseMessage) XXX_Size() int {
+	return m.Size()
+}
+func (m *NoBlockResponseMessage) XXX_DiscardUnknown() {
+	xxx_messageInfo_NoBlockResponseMessage.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_NoBlockResponseMessage proto.InternalMessageInfo
+
+func (m *NoBlockResponseMessage) GetHeight() int64 {
+	if m != nil {
+		return m.Height
+	}
+	return 0
+}
+
+type BlockResponseMessage struct {
+	// amino encoded types.Block
+	Block                []byte   `protobuf:"bytes,1,opt,name=block,proto3" json:"block,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *BlockResponseMessage) Reset()         { *m = BlockResponseMessage{} }
+func (m *BlockResponseMessage) String() string { return proto.CompactTextString(m) }
+func (*BlockResponseMessage) ProtoMessage()    {}
+func (*BlockResponseMessage) Descriptor() ([]byte, []int) {
+	return fileDescriptor_8922a7d82d7de808, []int{2}
+}
+func (m *BlockResponseMessage) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *BlockResponseMessage) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_BlockResponseMessage.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *BlockResponseMessage) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BlockResponseMessage.Merge(m, src)
+}
+func (m *BlockResponseMessage) XXX_Size() int {
+	return m.Size()
+}
+func (m *BlockResponseMessage) XXX_DiscardUnknown() {
+	xxx_messageInfo_BlockResponseMessage.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BlockResponseMessage proto.InternalMessageInfo
+
+func (m *BlockResponseMessage) GetBlock() []byte {
+	if m != nil {
+		return m.Block
+	}
+	return nil
+}
+
+type StatusRequestMessage struct {
+	Height               int64    `protobuf:"varint,1,opt,name=height,proto3" json:"height,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *StatusRequestMessage) Reset()         { *m = StatusRequestMessage{} }
+func (m *StatusRequestMessage) String() string { return proto.CompactTextString(m) }
+func (*StatusRequestMessage) ProtoMessage()    {}
+func (*StatusRequestMessage) Descriptor() ([]byte, []int) {
+	return fileDescriptor_8922a7d82d7de808, []int{3}
+}
+func (m *StatusRequestMessage) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *StatusRequestMessage) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_StatusRequestMessage.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *StatusRequestMessage) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_StatusRequestMessage.Merge(m, src)
+}
+func (m *StatusRequestMessage) XXX_Size() int {
+	return m.Size()
+}
+func (m *StatusRequestMessage) XXX_DiscardUnknown() {
+	xxx_messageInfo_StatusRequestMessage.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_StatusRequestMessage proto.InternalMessageInfo
+
+func (m *StatusRequestMessage) GetHeight() int64 {
+	if m != nil {
+		return m.Height
+	}
+	return 0
+}
+
+type StatusResponseMessage struct {
+	Height               int64    `protobuf:"varint,1,opt,name=height,proto3" json:"height,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *StatusResponseMessage) Reset()         { *m = StatusResponseMessage{} }
+func (m *StatusResponseMessage) String() string { return proto.CompactTextString(m) }
+func (*StatusResponseMessage) ProtoMessage()    {}
+func (*StatusResponseMessage) Descriptor() ([]byte, []int) {
+	return fileDescriptor_8922a7d82d7de808, []int{4}
+}
+func (m *StatusResponseMessage) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *StatusResponseMessage) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_StatusResponseMessage.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *StatusResponseMessage) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_StatusResponseMessage.Merge(m, src)
+}
+func (m *StatusResponseMessage) XXX_Size() int {
+	return m.Size()
+}
+func (m *StatusResponseMessage) XXX_DiscardUnknown() {
+	xxx_messageInfo_StatusResponseMessage.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_StatusResponseMessage proto.InternalMessageInfo
+
+func (m *StatusResponseMessage) GetHeight() int64 {
+	if m != nil {
+		return m.Height
+	}
+	return 0
+}
+
+// HeaderRequestMessage is only sent by the blockchain/v0 reactor.
+type HeaderRequestMessage struct {
+	Height               int64    `protobuf:"varint,1,opt,name=height,proto3" json:"height,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *HeaderRequestMessage) Reset()         { *m = HeaderRequestMessage{} }
+func (m *HeaderRequestMessage) String() string { return proto.CompactTextString(m) }
+func (*HeaderRequestMessage) ProtoMessage()    {}
+func (*HeaderRequestMessage) Descriptor() ([]byte, []int) {
+	return fileDescriptor_8922a7d82d7de808, []int{5}
+}
+func (m *HeaderRequestMessage) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *HeaderRequestMessage) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_HeaderRequestMessage.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *HeaderRequestMessage) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_HeaderRequestMessage.Merge(m, src)
+}
+func (m *HeaderRequestMessage) XXX_Size() int {
+	return m.Size()
+}
+func (m *HeaderRequestMessage) XXX_DiscardUnknown() {
+	xxx_messageInfo_HeaderRequestMessage.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_HeaderRequestMessage proto.InternalMessageInfo
+
+func (m *HeaderRequestMessage) GetHeight() int64 {
+	if m != nil {
+		return m.Height
+	}
+	return 0
+}
+
+// HeaderResponseMessage is only sent by the blockchain/v0 reactor.
+type HeaderResponseMessage struct {
+	// amino encoded types.SignedHeader
+	SignedHeader []byte `protobuf:"bytes,1,opt,name=signed_header,json=signedHeader,proto3" json:"signed_header,omitempty"`
+	// amino encoded types.ValidatorSet
+	Validators           []byte   `protobuf:"bytes,2,opt,name=validators,proto3" json:"validators,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *HeaderResponseMessage) Reset()         { *m = HeaderResponseMessage{} }
+func (m *HeaderResponseMessage) String() string { return proto.CompactTextString(m) }
+func (*HeaderResponseMessage) ProtoMessage()    {}
+func (*HeaderResponseMessage) Descriptor() ([]byte, []int) {
+	return fileDescriptor_8922a7d82d7de808, []int{6}
+}
+func (m *HeaderResponseMessage) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *HeaderResponseMessage) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_HeaderResponseMessage.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *HeaderResponseMessage) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_HeaderResponseMessage.Merge(m, src)
+}
+func (m *HeaderResponseMessage) XXX_Size() int {
+	return m.Size()
+}
+func (m *HeaderResponseMessage) XXX_DiscardUnknown() {
+	xxx_messageInfo_HeaderResponseMessage.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_HeaderResponseMessage proto.InternalMessageInfo
+
+func (m *HeaderResponseMessage) GetSignedHeader() []byte {
+	if m != nil {
+		return m.SignedHeader
+	}
+	return nil
+}
+
+func (m *HeaderResponseMessage) GetValidators() []byte {
+	if m != nil {
+		return m.Validators
+	}
+	return nil
+}
+
+// BlockchainMessage is the message sent on the blockchain channel.
+type BlockchainMessage struct {
+	// Types that are valid to be assigned to Sum:
+	//	*BlockchainMessage_BlockRequest
+	//	*BlockchainMessage_NoBlockResponse
+	//	*BlockchainMessage_BlockResponse
+	//	*BlockchainMessage_StatusRequest
+	//	*BlockchainMessage_StatusResponse
+	//	*BlockchainMessage_HeaderRequest
+	//	*BlockchainMessage_HeaderResponse
+	Sum                  isBlockchainMessage_Sum `protobuf_oneof:"sum"`
+	XXX_NoUnkeyedLiteral struct{}                `json:"-"`
+	XXX_unrecognized     []byte                  `json:"-"`
+	XXX_sizecache        int32                   `json:"-"`
+}
+
+func (m *BlockchainMessage) Reset()         { *m = BlockchainMessage{} }
+func (m *BlockchainMessage) String() string { return proto.CompactTextString(m) }
+func (*BlockchainMessage) ProtoMessage()    {}
+func (*BlockchainMessage) Descriptor() ([]byte, []int) {
+	return fileDescriptor_8922a7d82d7de808, []int{7}
+}
+func (m *BlockchainMessage) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *BlockchainMessage) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_BlockchainMessage.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *BlockchainMessage) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BlockchainMessage.Merge(m, src)
+}
+func (m *BlockchainMessage) XXX_Size() int {
+	return m.Size()
+}
+func (m *BlockchainMessage) XXX_DiscardUnknown() {
+	xxx_messageInfo_BlockchainMessage.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BlockchainMessage proto.InternalMessageInfo
+
+type isBlockchainMessage_Sum interface {
+	isBlockchainMessage_Sum()
+	MarshalTo([]byte) (int, error)
+	Size() int
+}
+
+type BlockchainMessage_BlockRequest struct {
+	BlockRequest *BlockRequestMessage `protobuf:"bytes,1,opt,name=block_request,json=blockRequest,proto3,oneof"`
+}
+type BlockchainMessage_NoBlockResponse struct {
+	NoBlockResponse *NoBlockResponseMessage `protobuf:"bytes,2,opt,name=no_block_response,json=noBlockResponse,proto3,oneof"`
+}
+type BlockchainMessage_BlockResponse struct {
+	BlockResponse *BlockResponseMessage `protobuf:"bytes,3,opt,name=block_response,json=blockResponse,proto3,oneof"`
+}
+type BlockchainMessage_StatusRequest struct {
+	StatusRequest *StatusRequestMessage `protobuf:"bytes,4,opt,name=status_request,json=statusRequest,proto3,oneof"`
+}
+type BlockchainMessage_StatusResponse struct {
+	StatusResponse *StatusResponseMessage `protobuf:"bytes,5,opt,name=status_response,json=statusResponse,proto3,oneof"`
+}
+type BlockchainMessage_HeaderRequest struct {
+	HeaderRequest *HeaderRequestMessage `protobuf:"bytes,6,opt,name=header_request,json=headerRequest,proto3,oneof"`
+}
+type BlockchainMessage_HeaderResponse struct {
+	HeaderResponse *HeaderResponseMessage `protobuf:"bytes,7,opt,name=header_response,json=headerResponse,proto3,oneof"`
+}
+
+func (*BlockchainMessage_BlockRequest) isBlockchainMessage_Sum()    {}
+func (*BlockchainMessage_NoBlockResponse) isBlockchainMessage_Sum() {}
+func (*BlockchainMessage_BlockResponse) isBlockchainMessage_Sum()   {}
+func (*BlockchainMessage_StatusRequest) isBlockchainMessage_Sum()   {}
+func (*BlockchainMessage_StatusResponse) isBlockchainMessage_Sum()  {}
+func (*BlockchainMessage_HeaderRequest) isBlockchainMessage_Sum()   {}
+func (*BlockchainMessage_HeaderResponse) isBlockchainMessage_Sum()  {}
+
+func (m *BlockchainMessage) GetSum() isBlockchainMessage_Sum {
+	if m != nil {
+		return m.Sum
+	}
+	return nil
+}
+
+func (m *BlockchainMessage) GetBlockRequest() *BlockRequestMessage {
+	if x, ok := m.GetSum().(*BlockchainMessage_BlockRequest); ok {
+		return x.BlockRequest
+	}
+	return nil
+}
+
+func (m *BlockchainMessage) GetNoBlockResponse() *NoBlockResponseMessage {
+	if x, ok := m.GetSum().(*BlockchainMessage_NoBlockResponse); ok {
+		return x.NoBlockResponse
+	}
+	return nil
+}
+
+func (m *BlockchainMessage) GetBlockResponse() *BlockResponseMessage {
+	if x, ok := m.GetSum().(*BlockchainMessage_BlockResponse); ok {
+		return x.BlockResponse
+	}
+	return nil
+}
+
+func (m *BlockchainMessage) GetStatusRequest() *StatusRequestMessage {
+	if x, ok := m.GetSum().(*BlockchainMessage_StatusRequest); ok {
+		return x.StatusRequest
+	}
+	return nil
+}
+
+func (m *BlockchainMessage) GetStatusResponse() *StatusResponseMessage {
+	if x, ok := m.GetSum().(*BlockchainMessage_StatusResponse); ok {
+		return x.StatusResponse
+	}
+	return nil
+}
+
+func (m *BlockchainMessage) GetHeaderRequest() *HeaderRequestMessage {
+	if x, ok := m.GetSum().(*BlockchainMessage_HeaderRequest); ok {
+		return x.HeaderRequest
+	}
+	return nil
+}
+
+func (m *BlockchainMessage) GetHeaderResponse() *HeaderResponseMessage {
+	if x, ok := m.GetSum().(*BlockchainMessage_HeaderResponse); ok {
+		return x.HeaderResponse
+	}
+	return nil
+}
+
+// XXX_OneofWrappers is for the internal use of the proto package.
+func (*BlockchainMessage) XXX_OneofWrappers() []interface{} {
+	return []interface{}{
+		(*BlockchainMessage_BlockRequest)(nil),
+		(*BlockchainMessage_NoBlockResponse)(nil),
+		(*BlockchainMessage_BlockResponse)(nil),
+		(*BlockchainMessage_StatusRequest)(nil),
+		(*BlockchainMessage_StatusResponse)(nil),
+		(*BlockchainMessage_HeaderRequest)(nil),
+		(*BlockchainMessage_HeaderResponse)(nil),
+	}
+}
+
+func init() {
+	proto.RegisterType((*BlockRequestMessage)(nil), "msgs.BlockRequestMessage")
+	proto.RegisterType((*NoBlockResponseMessage)(nil), "msgs.NoBlockResponseMessage")
+	proto.RegisterType((*BlockResponseMessage)(nil), "msgs.BlockResponseMessage")
+	proto.RegisterType((*StatusRequestMessage)(nil), "msgs.StatusRequestMessage")
+	proto.RegisterType((*StatusResponseMessage)(nil), "msgs.StatusResponseMessage")
+	proto.RegisterType((*HeaderRequestMessage)(nil), "msgs.HeaderRequestMessage")
+	proto.RegisterType((*HeaderResponseMessage)(nil), "msgs.HeaderResponseMessage")
+	proto.RegisterType((*BlockchainMessage)(nil), "msgs.BlockchainMessage")
+}
+
+func init() { proto.RegisterFile("types/msgs/blockchain.proto", fileDescriptor_8922a7d82d7de808) }
+
+var fileDescriptor_8922a7d82d7de808 = []byte{
+	// 406 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x8c, 0x93, 0xdd, 0x6a, 0xa3, 0x40,
+	0x14, 0x80, 0xe3, 0xe6, 0x67, 0xe1, 0xc4, 0x24, 0xc4, 0x35, 0x21, 0x9b, 0x2c, 0xb2, 0xb8, 0x37,
+	0x7b, 0xb1, 0xd1, 0x25, 0xfb, 0x02, 0x8b, 0x85, 0x12, 0x0a, 0xed, 0x85, 0xbd, 0x2d, 0x04, 0x4d,
+	0xa6, 0x2a, 0x4d, 0x9c, 0xd4, 0x19, 0x0b, 0x7d, 0x82, 0xbe, 0x5a, 0x2f, 0xfb, 0x08, 0x25, 0x4f,
+	0x52, 0xe6, 0x8c, 0x8a, 0x8a, 0xd0, 0xdc, 0xe5, 0xfc, 0x7d, 0xf3, 0x4d, 0xce, 0x08, 0x0b, 0xfe,
+	0x7c, 0x24, 0xcc, 0x3e, 0xb0, 0x80, 0xd9, 0xfe, 0x9e, 0x6e, 0x1f, 0xb6, 0xa1, 0x17, 0xc5, 0xd6,
+	0x31, 0xa1, 0x9c, 0x6a, 0x1d, 0x91, 0x9e, 0x2f, 0x83, 0x88, 0x87, 0xa9, 0x6f, 0x6d, 0xe9, 0xc1,
+	0x0e, 0x68, 0x40, 0x6d, 0x2c, 0xfa, 0xe9, 0x3d, 0x46, 0x18, 0xe0, 0x2f, 0x39, 0x64, 0x2e, 0xe1,
+	0x9b, 0x23, 0x40, 0x2e, 0x79, 0x4c, 0x09, 0xe3, 0xd7, 0x84, 0x31, 0x2f, 0x20, 0xda, 0x14, 0x7a,
+	0x21, 0x89, 0x82, 0x90, 0xcf, 0x94, 0x9f, 0xca, 0xef, 0xb6, 0x9b, 0x45, 0xe6, 0x5f, 0x98, 0xde,
+	0xd0, 0x6c, 0x80, 0x1d, 0x69, 0xcc, 0xc8, 0x67, 0x13, 0x7f, 0x40, 0x6f, 0xec, 0xd7, 0xa1, 0x8b,
+	0x37, 0xc0, 0x76, 0xd5, 0x95, 0x81, 0x69, 0x81, 0x7e, 0xcb, 0x3d, 0x9e, 0xb2, 0x33, 0x7d, 0x6c,
+	0x98, 0xe4, 0xfd, 0xe7, 0xe9, 0x58, 0xa0, 0xaf, 0x89, 0xb7, 0x23, 0xc9, 0x99, 0x07, 0xdc, 0xc1,
+	0x24, 0xef, 0xaf, 0x1e, 0xf0, 0x0b, 0x06, 0x2c, 0x0a, 0x62, 0xb2, 0xdb, 0x84, 0x58, 0xcf, 0xee,
+	0xa1, 0xca, 0xa4, 0x9c, 0xd1, 0x0c, 0x80, 0x27, 0x6f, 0x1f, 0xed, 0x3c, 0x4e, 0x13, 0x36, 0xfb,
+	0x82, 0x1d, 0xa5, 0x8c, 0xf9, 0xd2, 0x81, 0xb1, 0x53, 0xec, 0x31, 0x47, 0xff, 0x87, 0x01, 0xfe,
+	0x1b, 0x9b, 0x44, 0x3a, 0x22, 0xba, 0xbf, 0xfa, 0x6e, 0x89, 0x05, 0x5b, 0x0d, 0xeb, 0x5a, 0xb7,
+	0x5c, 0xd5, 0x2f, 0xa5, 0xb5, 0x2b, 0x18, 0xc7, 0x74, 0x93, 0x43, 0xa4, 0x38, 0x1e, 0xdf, 0x5f,
+	0xfd, 0x90, 0x94, 0xe6, 0x2d, 0xae, 0x5b, 0xee, 0x28, 0xae, 0x56, 0xb4, 0x0b, 0x18, 0xd6, 0x40,
+	0x6d, 0x04, 0xcd, 0x2b, 0x3a, 0x75, 0xcc, 0xc0, 0xaf, 0x43, 0x18, 0xee, 0xa9, 0xb8, 0x53, 0xa7,
+	0x0c, 0x69, 0xda, 0xb9, 0x80, 0xb0, 0x72, 0x5e, 0xbb, 0x84, 0x51, 0x01, 0xc9, 0x54, 0xba, 0x48,
+	0x59, 0x54, 0x29, 0x75, 0x97, 0x21, 0xab, 0x14, 0x84, 0x8c, 0xdc, 0x59, 0x21, 0xd3, 0x2b, 0xcb,
+	0x34, 0xbd, 0x0f, 0x21, 0x13, 0x96, 0xf3, 0x42, 0xa6, 0x80, 0x64, 0x32, 0x5f, 0xcb, 0x32, 0x8d,
+	0xaf, 0x46, 0xc8, 0x84, 0x95, 0x82, 0xd3, 0x85, 0x36, 0x4b, 0x0f, 0x8e, 0xfa, 0x7a, 0x32, 0x94,
+	0xb7, 0x93, 0xa1, 0xbc, 0x9f, 0x0c, 0xc5, 0xef, 0xe1, 0xc7, 0xf9, 0xef, 0x63, 0x00, 0x08, 0xa5,
+	0x4d, 0xaf, 0xf0, 0x03, 0x00, 0x00,
+}
+
+func (m *BlockRequestMessage) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *BlockRequestMessage) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *BlockRequestMessage) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if m.Height != 0 {
+		i = encodeVarintBlockchain(dAtA, i, uint64(m.Height))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *NoBlockResponseMessage) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *NoBlockResponseMessage) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *NoBlockResponseMessage) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if m.Height != 0 {
+		i = encodeVarintBlockchain(dAtA, i, uint64(m.Height))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *BlockResponseMessage) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *BlockResponseMessage) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *BlockResponseMessage) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if len(m.Block) > 0 {
+		i -= len(m.Block)
+		copy(dAtA[i:], m.Block)
+		i = encodeVarintBlockchain(dAtA, i, uint64(len(m.Block)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *StatusRequestMessage) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *StatusRequestMessage) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *StatusRequestMessage) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if m.Height != 0 {
+		i = encodeVarintBlockchain(dAtA, i, uint64(m.Height))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *StatusResponseMessage) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *StatusResponseMessage) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *StatusResponseMessage) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if m.Height != 0 {
+		i = encodeVarintBlockchain(dAtA, i, uint64(m.Height))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *HeaderRequestMessage) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *HeaderRequestMessage) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *HeaderRequestMessage) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if m.Height != 0 {
+		i = encodeVarintBlockchain(dAtA, i, uint64(m.Height))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *HeaderResponseMessage) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *HeaderResponseMessage) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *HeaderResponseMessage) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if len(m.Validators) > 0 {
+		i -= len(m.Validators)
+		copy(dAtA[i:], m.Validators)
+		i = encodeVarintBlockchain(dAtA, i, uint64(len(m.Validators)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.SignedHeader) > 0 {
+		i -= len(m.SignedHeader)
+		copy(dAtA[i:], m.SignedHeader)
+		i = encodeVarintBlockchain(dAtA, i, uint64(len(m.SignedHeader)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *BlockchainMessage) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *BlockchainMessage) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *BlockchainMessage) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if m.Sum != nil {
+		{
+			size := m.Sum.Size()
+			i -= size
+			if _, err := m.Sum.MarshalTo(dAtA[i:]); err != nil {
+				return 0, err
+			}
+		}
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *BlockchainMessage_BlockRequest) MarshalTo(dAtA []byte) (int, error) {
+	return m.MarshalToSizedBuffer(dAtA[:m.Size()])
+}
+
+func (m *BlockchainMessage_BlockRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	if m.BlockRequest != nil {
+		{
+			size, err := m.BlockRequest.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintBlockchain(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+func (m *BlockchainMessage_NoBlockResponse) MarshalTo(dAtA []byte) (int, error) {
+	return m.MarshalToSizedBuffer(dAtA[:m.Size()])
+}
+
+func (m *BlockchainMessage_NoBlockResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	if m.NoBlockResponse != nil {
+		{
+			size, err := m.NoBlockResponse.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintBlockchain(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x12
+	}
+	return len(dAtA) - i, nil
+}
+func (m *BlockchainMessage_BlockResponse) MarshalTo(dAtA []byte) (int, error) {
+	return m.MarshalToSizedBuffer(dAtA[:m.Size()])
+}
+
+func (m *BlockchainMessage_BlockResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	if m.BlockResponse != nil {
+		{
+			size, err := m.BlockResponse.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintBlockchain(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x1a
+	}
+	return len(dAtA) - i, nil
+}
+func (m *BlockchainMessage_StatusRequest) MarshalTo(dAtA []byte) (int, error) {
+	return m.MarshalToSizedBuffer(dAtA[:m.Size()])
+}
+
+func (m *BlockchainMessage_StatusRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	if m.StatusRequest != nil {
+		{
+			size, err := m.StatusRequest.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintBlockchain(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x22
+	}
+	return len(dAtA) - i, nil
+}
+func (m *BlockchainMessage_StatusResponse) MarshalTo(dAtA []byte) (int, error) {
+	return m.MarshalToSizedBuffer(dAtA[:m.Size()])
+}
+
+func (m *BlockchainMessage_StatusResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	if m.StatusResponse != nil {
+		{
+			size, err := m.StatusResponse.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintBlockchain(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x2a
+	}
+	return len(dAtA) - i, nil
+}
+func (m *BlockchainMessage_HeaderRequest) MarshalTo(dAtA []byte) (int, error) {
+	return m.MarshalToSizedBuffer(dAtA[:m.Size()])
+}
+
+func (m *BlockchainMessage_HeaderRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	if m.HeaderRequest != nil {
+		{
+			size, err := m.HeaderRequest.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintBlockchain(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x32
+	}
+	return len(dAtA) - i, nil
+}
+func (m *BlockchainMessage_HeaderResponse) MarshalTo(dAtA []byte) (int, error) {
+	return m.MarshalToSizedBuffer(dAtA[:m.Size()])
+}
+
+func (m *BlockchainMessage_HeaderResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	if m.HeaderResponse != nil {
+		{
+			size, err := m.HeaderResponse.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintBlockchain(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x3a
+	}
+	return len(dAtA) - i, nil
+}
+func encodeVarintBlockchain(dAtA []byte, offset int, v uint64) int {
+	offset -= sovBlockchain(v)
+	base := offset
+	for v >= 1<<7 {
+		dAtA[offset] = uint8(v&0x7f | 0x80)
+		v >>= 7
+		offset++
+	}
+	dAtA[offset] = uint8(v)
+	return base
+}
+func (m *BlockRequestMessage) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Height != 0 {
+		n += 1 + sovBlockchain(uint64(m.Height))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *NoBlockResponseMessage) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Height != 0 {
+		n += 1 + sovBlockchain(uint64(m.Height))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *BlockResponseMessage) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Block)
+	if l > 0 {
+		n += 1 + l + sovBlockchain(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *StatusRequestMessage) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Height != 0 {
+		n += 1 + sovBlockchain(uint64(m.Height))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *StatusResponseMessage) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Height != 0 {
+		n += 1 + sovBlockchain(uint64(m.Height))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *HeaderRequestMessage) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Height != 0 {
+		n += 1 + sovBlockchain(uint64(m.Height))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *HeaderResponseMessage) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.SignedHeader)
+	if l > 0 {
+		n += 1 + l + sovBlockchain(uint64(l))
+	}
+	l = len(m.Validators)
+	if l > 0 {
+		n += 1 + l + sovBlockchain(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *BlockchainMessage) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Sum != nil {
+		n += m.Sum.Size()
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *BlockchainMessage_BlockRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.BlockRequest != nil {
+		l = m.BlockRequest.Size()
+		n += 1 + l + sovBlockchain(uint64(l))
+	}
+	return n
+}
+func (m *BlockchainMessage_NoBlockResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.NoBlockResponse != nil {
+		l = m.NoBlockResponse.Size()
+		n += 1 + l + sovBlockchain(uint64(l))
+	}
+	return n
+}
+func (m *BlockchainMessage_BlockResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.BlockResponse != nil {
+		l = m.BlockResponse.Size()
+		n += 1 + l + sovBlockchain(uint64(l))
+	}
+	return n
+}
+func (m *BlockchainMessage_StatusRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.StatusRequest != nil {
+		l = m.StatusRequest.Size()
+		n += 1 + l + sovBlockchain(uint64(l))
+	}
+	return n
+}
+func (m *BlockchainMessage_StatusResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.StatusResponse != nil {
+		l = m.StatusResponse.Size()
+		n += 1 + l + sovBlockchain(uint64(l))
+	}
+	return n
+}
+func (m *BlockchainMessage_HeaderRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.HeaderRequest != nil {
+		l = m.HeaderRequest.Size()
+		n += 1 + l + sovBlockchain(uint64(l))
+	}
+	return n
+}
+func (m *BlockchainMessage_HeaderResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.HeaderResponse != nil {
+		l = m.HeaderResponse.Size()
+		n += 1 + l + sovBlockchain(uint64(l))
+	}
+	return n
+}
+
+func sovBlockchain(x uint64) (n int) {
+	return (math_bits.Len64(x|1) + 6) / 7
+}
+func sozBlockchain(x uint64) (n int) {
+	return sovBlockchain(uint64((x << 1) ^ uint64((int64(x) >> 63))))
+}
+func (m *BlockRequestMessage) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowBlockchain
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: BlockRequestMessage: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: BlockRequestMessage: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Height", wireType)
+			}
+			m.Height = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowBlockchain
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Height |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipBlockchain(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthBlockchain
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthBlockchain
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *NoBlockResponseMessage) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowBlockchain
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: NoBlockResponseMessage: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: NoBlockResponseMessage: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Height", wireType)
+			}
+			m.Height = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowBlockchain
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Height |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipBlockchain(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthBlockchain
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthBlockchain
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *BlockResponseMessage) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowBlockchain
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: BlockResponseMessage: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: BlockResponseMessage: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Block", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowBlockchain
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthBlockchain
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthBlockchain
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Block = append(m.Block[:0], dAtA[iNdEx:postIndex]...)
+			if m.Block == nil {
+				m.Block = []byte{}
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipBlockchain(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthBlockchain
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthBlockchain
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *StatusRequestMessage) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowBlockchain
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: StatusRequestMessage: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: StatusRequestMessage: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Height", wireType)
+			}
+			m.Height = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowBlockchain
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Height |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipBlockchain(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthBlockchain
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthBlockchain
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *StatusResponseMessage) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowBlockchain
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: StatusResponseMessage: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: StatusResponseMessage: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Height", wireType)
+			}
+			m.Height = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowBlockchain
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Height |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipBlockchain(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthBlockchain
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthBlockchain
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *HeaderRequestMessage) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowBlockchain
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: HeaderRequestMessage: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: HeaderRequestMessage: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Height", wireType)
+			}
+			m.Height = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowBlockchain
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Height |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipBlockchain(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthBlockchain
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthBlockchain
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *HeaderResponseMessage) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowBlockchain
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: HeaderResponseMessage: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: HeaderResponseMessage: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SignedHeader", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowBlockchain
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthBlockchain
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthBlockchain
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.SignedHeader = append(m.SignedHeader[:0], dAtA[iNdEx:postIndex]...)
+			if m.SignedHeader == nil {
+				m.SignedHeader = []byte{}
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Validators", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowBlockchain
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthBlockchain
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthBlockchain
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Validators = append(m.Validators[:0], dAtA[iNdEx:postIndex]...)
+			if m.Validators == nil {
+				m.Validators = []byte{}
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipBlockchain(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthBlockchain
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthBlockchain
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *BlockchainMessage) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowBlockchain
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: BlockchainMessage: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: BlockchainMessage: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field BlockRequest", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowBlockchain
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthBlockchain
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthBlockchain
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			v := &BlockRequestMessage{}
+			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			m.Sum = &BlockchainMessage_BlockRequest{v}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field NoBlockResponse", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowBlockchain
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthBlockchain
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthBlockchain
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			v := &NoBlockResponseMessage{}
+			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			m.Sum = &BlockchainMessage_NoBlockResponse{v}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field BlockResponse", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowBlockchain
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthBlockchain
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthBlockchain
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			v := &BlockResponseMessage{}
+			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			m.Sum = &BlockchainMessage_BlockResponse{v}
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field StatusRequest", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowBlockchain
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthBlockchain
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthBlockchain
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			v := &StatusRequestMessage{}
+			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			m.Sum = &BlockchainMessage_StatusRequest{v}
+			iNdEx = postIndex
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field StatusResponse", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowBlockchain
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthBlockchain
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthBlockchain
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			v := &StatusResponseMessage{}
+			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			m.Sum = &BlockchainMessage_StatusResponse{v}
+			iNdEx = postIndex
+		case 6:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field HeaderRequest", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowBlockchain
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthBlockchain
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthBlockchain
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			v := &HeaderRequestMessage{}
+			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			m.Sum = &BlockchainMessage_HeaderRequest{v}
+			iNdEx = postIndex
+		case 7:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field HeaderResponse", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowBlockchain
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthBlockchain
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthBlockchain
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			v := &HeaderResponseMessage{}
+			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			m.Sum = &BlockchainMessage_HeaderResponse{v}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipBlockchain(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthBlockchain
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthBlockchain
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func skipBlockchain(dAtA []byte) (n int, err error) {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return 0, ErrIntOverflowBlockchain
+			}
+			if iNdEx >= l {
+				return 0, io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		wireType := int(wire & 0x7)
+		switch wireType {
+		case 0:
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return 0, ErrIntOverflowBlockchain
+				}
+				if iNdEx >= l {
+					return 0, io.ErrUnexpectedEOF
+				}
+				iNdEx++
+				if dAtA[iNdEx-1] < 0x80 {
+					break
+				}
+			}
+			return iNdEx, nil
+		case 1:
+			iNdEx += 8
+			return iNdEx, nil
+		case 2:
+			var length int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return 0, ErrIntOverflowBlockchain
+				}
+				if iNdEx >= l {
+					return 0, io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				length |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if length < 0 {
+				return 0, ErrInvalidLengthBlockchain
+			}
+			iNdEx += length
+			if iNdEx < 0 {
+				return 0, ErrInvalidLengthBlockchain
+			}
+			return iNdEx, nil
+		case 3:
+			for {
+				var innerWire uint64
+				var start int = iNdEx
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return 0, ErrIntOverflowBlockchain
+					}
+					if iNdEx >= l {
+						return 0, io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					innerWire |= (uint64(b) & 0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				innerWireType := int(innerWire & 0x7)
+				if innerWireType == 4 {
+					break
+				}
+				next, err := skipBlockchain(dAtA[start:])
+				if err != nil {
+					return 0, err
+				}
+				iNdEx = start + next
+				if iNdEx < 0 {
+					return 0, ErrInvalidLengthBlockchain
+				}
+			}
+			return iNdEx, nil
+		case 4:
+			return iNdEx, nil
+		case 5:
+			iNdEx += 4
+			return iNdEx, nil
+		default:
+			return 0, fmt.Errorf("proto: illegal wireType %d", wireType)
+		}
+	}
+	panic("unreachable")
+}
+
+var (
+	ErrInvalidLengthBlockchain = fmt.Errorf("proto: negative length found during unmarshaling")
+	ErrIntOverflowBlockchain   = fmt.Errorf("proto: integer overflow")
+)
@@ -0,0 +1,140 @@
+package msgs
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/hdac-io/tendermint/crypto/merkle"
+	cmn "github.com/hdac-io/tendermint/libs/common"
+	"github.com/hdac-io/tendermint/types"
+)
+
+// BitArrayToProto converts a BitArray, nil if ba is nil.
+func BitArrayToProto(ba *cmn.BitArray) *BitArray {
+	if ba == nil {
+		return nil
+	}
+	return &BitArray{Bits: int64(ba.Bits), Elems: ba.Elems}
+}
+
+// BitArrayFromProto converts a BitArray, nil if ba is nil.
+func BitArrayFromProto(ba *BitArray) (*cmn.BitArray, error) {
+	if ba == nil {
+		return nil, nil
+	}
+	if ba.Bits < 0 || int64(len(ba.Elems)) != (ba.Bits+63)/64 {
+		return nil, fmt.Errorf("%d elems for %d bits", len(ba.Elems), ba.Bits)
+	}
+	return &cmn.BitArray{Bits: int(ba.Bits), Elems: ba.Elems}, nil
+}
+
+// PartSetHeaderToProto converts a PartSetHeader.
+func PartSetHeaderToProto(psh types.PartSetHeader) PartSetHeader {
+	return PartSetHeader{Total: int64(psh.Total), Hash: psh.Hash}
+}
+
+// PartSetHeaderFromProto converts a PartSetHeader.
+func PartSetHeaderFromProto(psh PartSetHeader) types.PartSetHeader {
+	return types.PartSetHeader{Total: int(psh.Total), Hash: psh.Hash}
+}
+
+// BlockIDToProto converts a BlockID.
+func BlockIDToProto(blockID types.BlockID) BlockID {
+	return BlockID{Hash: blockID.Hash, PartsHeader: PartSetHeaderToProto(blockID.PartsHeader)}
+}
+
+// BlockIDFromProto converts a BlockID.
+func BlockIDFromProto(blockID BlockID) types.BlockID {
+	return types.BlockID{Hash: blockID.Hash, PartsHeader: PartSetHeaderFromProto(blockID.PartsHeader)}
+}
+
+// VoteToProto converts a Vote.
+func VoteToProto(vote *types.Vote) *Vote {
+	return &Vote{
+		Type:             uint32(vote.Type),
+		Height:           vote.Height,
+		Round:            int64(vote.Round),
+		BlockID:          BlockIDToProto(vote.BlockID),
+		Timestamp:        vote.Timestamp,
+		ValidatorAddress: vote.ValidatorAddress,
+		ValidatorIndex:   int64(vote.ValidatorIndex),
+		Signature:        vote.Signature,
+	}
+}
+
+// VoteFromProto converts a Vote, which can't be nil.
+func VoteFromProto(vote *Vote) (*types.Vote, error) {
+	if vote == nil {
+		return nil, errors.New("nil vote")
+	}
+	return &types.Vote{
+		Type:             types.SignedMsgType(vote.Type),
+		Height:           vote.Height,
+		Round:            int(vote.Round),
+		BlockID:          BlockIDFromProto(vote.BlockID),
+		Timestamp:        vote.Timestamp,
+		ValidatorAddress: vote.ValidatorAddress,
+		ValidatorIndex:   int(vote.ValidatorIndex),
+		Signature:        vote.Signature,
+	}, nil
+}
+
+// ProposalToProto converts a Proposal.
+func ProposalToProto(proposal *types.Proposal) *Proposal {
+	return &Proposal{
+		Type:      uint32(proposal.Type),
+		Height:    proposal.Height,
+		Round:     int64(proposal.Round),
+		POLRound:  int64(proposal.POLRound),
+		BlockID:   BlockIDToProto(proposal.BlockID),
+		Timestamp: proposal.Timestamp,
+		Signature: proposal.Signature,
+	}
+}
+
+// ProposalFromProto converts a Proposal, which can't be nil.
+func ProposalFromProto(proposal *Proposal) (*types.Proposal, error) {
+	if proposal == nil {
+		return nil, errors.New("nil proposal")
+	}
+	return &types.Proposal{
+		Type:      types.SignedMsgType(proposal.Type),
+		Height:    proposal.Height,
+		Round:     int(proposal.Round),
+		POLRound:  int(proposal.POLRound),
+		BlockID:   BlockIDFromProto(proposal.BlockID),
+		Timestamp: proposal.Timestamp,
+		Signature: proposal.Signature,
+	}, nil
+}
+
+// PartToProto converts a Part.
+func PartToProto(part *types.Part) *Part {
+	return &Part{
+		Index: int64(part.Index),
+		Bytes: part.Bytes,
+		Proof: SimpleProof{
+			Total:    int64(part.Proof.Total),
+			Index:    int64(part.Proof.Index),
+			LeafHash: part.Proof.LeafHash,
+			Aunts:    part.Proof.Aunts,
+		},
+	}
+}
+
+// PartFromProto converts a Part, which can't be nil.
+func PartFromProto(part *Part) (*types.Part, error) {
+	if part == nil {
+		return nil, errors.New("nil part")
+	}
+	return &types.Part{
+		Index: int(part.Index),
+		Bytes: part.Bytes,
+		Proof: merkle.SimpleProof{
+			Total:    int(part.Proof.Total),
+			Index:    int(part.Proof.Index),
+			LeafHash: part.Proof.LeafHash,
+			Aunts:    part.Proof.Aunts,
+		},
+	}, nil
+}
@@ -0,0 +1,10 @@
+/*
+Package msgs defines the protobuf encoding of the messages of the consensus,
+blockchain, mempool and evidence reactors, for the peers advertising
+p2p.MsgEncodingProto, with the conversions of the types the messages carry.
+The other peers keep exchanging the messages encoded with amino.
+
+The messages are defined in the .proto files of the package, and generated
+with `make protoc_msgs`.
+*/
+package msgs
@@ -1,6 +1,9 @@
 package types
 
 import (
+	"math/bits"
+	"time"
+
 	"github.com/pkg/errors"
 
 	abci "github.com/hdac-io/tendermint/abci/types"
@@ -17,6 +20,9 @@ const (
 
 	// MaxBlockPartsCount is the maximum count of block parts.
 	MaxBlockPartsCount = (MaxBlockSizeBytes / BlockPartSizeBytes) + 1
+
+	// MaxLenULB is the maximum number of heights friday consensus may progress in parallel.
+	MaxLenULB = 100
 )
 
 // ConsensusParams contains consensus critical parameters that determine the
@@ -46,17 +52,56 @@ type BlockParams struct {
 
 	// LenULB is optional field, it's just using friday consensus
 	LenULB int64 `json:"len_ulb",omitempty`
+
+	// Base timeouts of the friday consensus steps (in milliseconds), which
+	// replace the ones of the node's config. 0 keeps the node's config.
+	TimeoutProposeMs   int64 `json:"timeout_propose_ms,omitempty"`
+	TimeoutPrevoteMs   int64 `json:"timeout_prevote_ms,omitempty"`
+	TimeoutPrecommitMs int64 `json:"timeout_precommit_ms,omitempty"`
+	TimeoutCommitMs    int64 `json:"timeout_commit_ms,omitempty"`
+
+	// Maximum size of a tx (in bytes), checked by the mempool and the block
+	// validation. 0 only limits it by MaxBytes.
+	MaxTxBytes int64 `json:"max_tx_bytes,omitempty"`
 }
 
 // EvidenceParams determine how we handle evidence of malfeasance.
 type EvidenceParams struct {
 	MaxAge int64 `json:"max_age"` // only accept new evidence more recent than this
+
+	// Maximum age of the evidence in time (in milliseconds), from the time of
+	// the block at the height of the evidence to the time of the last block,
+	// in addition to MaxAge in heights: the block times of the friday
+	// consensus vary, so the heights only roughly match the slashing window of
+	// the app. 0 disables it.
+	MaxAgeDurationMs int64 `json:"max_age_duration_ms,omitempty"`
+}
+
+// IsExpired returns true if the evidence of height evHeight is too old to be
+// committed after the last block of height and time t: more than MaxAge
+// heights, or MaxAgeDurationMs if set, older than it. The age in time is
+// measured from evTime, the header time of the block at evHeight, not from
+// the times signed by the equivocator.
+func (params EvidenceParams) IsExpired(evHeight int64, evTime time.Time, height int64, t time.Time) bool {
+	if height-evHeight > params.MaxAge {
+		return true
+	}
+	maxAgeDuration := time.Duration(params.MaxAgeDurationMs) * time.Millisecond
+	if maxAgeDuration == 0 || evHeight > height {
+		return false
+	}
+	return t.Sub(evTime) > maxAgeDuration
 }
 
 // ValidatorParams restrict the public key types validators can use.
 // NOTE: uses ABCI pubkey naming, not Amino names.
 type ValidatorParams struct {
 	PubKeyTypes []string `json:"pub_key_types"`
+
+	// Maximum share of the total voting power a single validator may have, in
+	// percent. 0 disables the cap.
+	// Not exposed to the application.
+	MaxPowerShare int64 `json:"max_power_share,omitempty"`
 }
 
 // DefaultConsensusParams returns a default ConsensusParams.
@@ -103,7 +148,7 @@ func DefaultEvidenceParams() EvidenceParams {
 // DefaultValidatorParams returns a default ValidatorParams, which allows
 // only bls pubkeys.
 func DefaultValidatorParams() ValidatorParams {
-	return ValidatorParams{[]string{ABCIPubKeyTypeBLS}}
+	return ValidatorParams{PubKeyTypes: []string{ABCIPubKeyTypeBLS}}
 }
 
 func (params *ValidatorParams) IsValidPubkeyType(pubkeyType string) bool {
@@ -115,6 +160,28 @@ func (params *ValidatorParams) IsValidPubkeyType(pubkeyType string) bool {
 	return false
 }
 
+// CheckPowerShare returns an error if a validator has more than MaxPowerShare
+// percent of the total voting power of vals.
+func (params *ValidatorParams) CheckPowerShare(vals []*Validator) error {
+	if params.MaxPowerShare == 0 || len(vals) == 0 {
+		return nil
+	}
+	var total uint64
+	for _, val := range vals {
+		total += uint64(val.VotingPower)
+	}
+	// power*100 > MaxPowerShare*total, on 128 bits
+	maxHi, maxLo := bits.Mul64(uint64(params.MaxPowerShare), total)
+	for _, val := range vals {
+		hi, lo := bits.Mul64(uint64(val.VotingPower), 100)
+		if hi > maxHi || (hi == maxHi && lo > maxLo) {
+			return errors.Errorf("validator %v has %d of the total voting power %d, above the max share of %d%%",
+				val.Address, val.VotingPower, total, params.MaxPowerShare)
+		}
+	}
+	return nil
+}
+
 // Validate validates the ConsensusParams to ensure all values are within their
 // allowed limits, and returns an error if they are not.
 func (params *ConsensusParams) Validate() error {
@@ -137,11 +204,42 @@ func (params *ConsensusParams) Validate() error {
 			params.Block.TimeIotaMs)
 	}
 
+	if params.Block.LenULB < 0 {
+		return errors.Errorf("Block.LenULB must be greater or equal to 0. Got %d",
+			params.Block.LenULB)
+	}
+	if params.Block.LenULB > MaxLenULB {
+		return errors.Errorf("Block.LenULB is too big. %d > %d",
+			params.Block.LenULB, MaxLenULB)
+	}
+
+	if params.Block.TimeoutProposeMs < 0 || params.Block.TimeoutPrevoteMs < 0 ||
+		params.Block.TimeoutPrecommitMs < 0 || params.Block.TimeoutCommitMs < 0 {
+		return errors.Errorf("Block timeouts must be greater or equal to 0. Got %d, %d, %d and %d",
+			params.Block.TimeoutProposeMs, params.Block.TimeoutPrevoteMs,
+			params.Block.TimeoutPrecommitMs, params.Block.TimeoutCommitMs)
+	}
+
+	if params.Block.MaxTxBytes < 0 || params.Block.MaxTxBytes > params.Block.MaxBytes {
+		return errors.Errorf("Block.MaxTxBytes must be between 0 and Block.MaxBytes %d. Got %d",
+			params.Block.MaxBytes, params.Block.MaxTxBytes)
+	}
+
 	if params.Evidence.MaxAge <= 0 {
 		return errors.Errorf("EvidenceParams.MaxAge must be greater than 0. Got %d",
 			params.Evidence.MaxAge)
 	}
 
+	if params.Evidence.MaxAgeDurationMs < 0 {
+		return errors.Errorf("EvidenceParams.MaxAgeDurationMs must be greater or equal to 0. Got %d",
+			params.Evidence.MaxAgeDurationMs)
+	}
+
+	if params.Validator.MaxPowerShare < 0 || params.Validator.MaxPowerShare > 100 {
+		return errors.Errorf("Validator.MaxPowerShare must be between 0 and 100. Got %d",
+			params.Validator.MaxPowerShare)
+	}
+
 	if len(params.Validator.PubKeyTypes) == 0 {
 		return errors.New("len(Validator.PubKeyTypes) must be greater than 0")
 	}
@@ -178,10 +276,27 @@ func (params *ConsensusParams) Hash() []byte {
 func (params *ConsensusParams) Equals(params2 *ConsensusParams) bool {
 	return params.Block == params2.Block &&
 		params.Evidence == params2.Evidence &&
-		cmn.StringSliceEqual(params.Validator.PubKeyTypes, params2.Validator.PubKeyTypes)
+		cmn.StringSliceEqual(params.Validator.PubKeyTypes, params2.Validator.PubKeyTypes) &&
+		params.Validator.MaxPowerShare == params2.Validator.MaxPowerShare
+}
+
+// ValidateUpdate returns an error if p2 changes the params which can't be
+// updated once the chain has started: the LenULB, as the LastCommit of each
+// friday block is the commit LenULB heights below, which can't skip or repeat
+// heights. A zero LenULB leaves it unchanged. The friday nodes reject such an
+// update as a whole, and Update ignores the LenULB anyway.
+func (params ConsensusParams) ValidateUpdate(params2 *abci.ConsensusParams) error {
+	if params2 == nil || params2.Block == nil {
+		return nil
+	}
+	if lenULB := params2.Block.LenUlb; lenULB != 0 && lenULB != params.Block.LenULB {
+		return errors.Errorf("Block.LenULB can't be updated from %d to %d", params.Block.LenULB, lenULB)
+	}
+	return nil
 }
 
 // Update returns a copy of the params with updates from the non-zero fields of p2.
+// The LenULB of p2 isn't applied, see ValidateUpdate.
 // NOTE: note: must not modify the original
 func (params ConsensusParams) Update(params2 *abci.ConsensusParams) ConsensusParams {
 	res := params // explicit copy
@@ -194,9 +309,15 @@ func (params ConsensusParams) Update(params2 *abci.ConsensusParams) ConsensusPar
 	if params2.Block != nil {
 		res.Block.MaxBytes = params2.Block.MaxBytes
 		res.Block.MaxGas = params2.Block.MaxGas
+		res.Block.TimeoutProposeMs = params2.Block.TimeoutProposeMs
+		res.Block.TimeoutPrevoteMs = params2.Block.TimeoutPrevoteMs
+		res.Block.TimeoutPrecommitMs = params2.Block.TimeoutPrecommitMs
+		res.Block.TimeoutCommitMs = params2.Block.TimeoutCommitMs
+		res.Block.MaxTxBytes = params2.Block.MaxTxBytes
 	}
 	if params2.Evidence != nil {
 		res.Evidence.MaxAge = params2.Evidence.MaxAge
+		res.Evidence.MaxAgeDurationMs = params2.Evidence.MaxAgeDurationMs
 	}
 	if params2.Validator != nil {
 		// Copy params2.Validator.PubkeyTypes, and set result's value to the copy.
@@ -4,9 +4,11 @@ import (
 	"bytes"
 	"sort"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	abci "github.com/hdac-io/tendermint/abci/types"
+	"github.com/hdac-io/tendermint/crypto/ed25519"
 )
 
 var (
@@ -67,6 +69,94 @@ func makeParams(
 	}
 }
 
+func TestConsensusParamsMaxTxBytesValidation(t *testing.T) {
+	testCases := []struct {
+		maxTxBytes int64
+		valid      bool
+	}{
+		0: {0, true},
+		1: {1, true},
+		2: {1024, true},
+		3: {-1, false},
+		4: {1025, false},
+	}
+	for i, tc := range testCases {
+		params := makeParams(1024, 0, 10, 1, valEd25519)
+		params.Block.MaxTxBytes = tc.maxTxBytes
+		if tc.valid {
+			assert.NoErrorf(t, params.Validate(), "expected no error for valid params (#%d)", i)
+		} else {
+			assert.Errorf(t, params.Validate(), "expected error for non valid params (#%d)", i)
+		}
+	}
+}
+
+func TestConsensusParamsLenULBValidation(t *testing.T) {
+	testCases := []struct {
+		lenULB int64
+		valid  bool
+	}{
+		0: {0, true},
+		1: {3, true},
+		2: {MaxLenULB, true},
+		3: {-1, false},
+		4: {MaxLenULB + 1, false},
+	}
+	for i, tc := range testCases {
+		params := makeParams(1, 0, 10, 1, valEd25519)
+		params.Block.LenULB = tc.lenULB
+		if tc.valid {
+			assert.NoErrorf(t, params.Validate(), "expected no error for valid params (#%d)", i)
+		} else {
+			assert.Errorf(t, params.Validate(), "expected error for non valid params (#%d)", i)
+		}
+	}
+}
+
+func TestValidatorParamsMaxPowerShare(t *testing.T) {
+	testCases := []struct {
+		maxPowerShare int64
+		valid         bool
+	}{
+		0: {0, true},
+		1: {10, true},
+		2: {100, true},
+		3: {-1, false},
+		4: {101, false},
+	}
+	for i, tc := range testCases {
+		params := makeParams(1, 0, 10, 1, valEd25519)
+		params.Validator.MaxPowerShare = tc.maxPowerShare
+		if tc.valid {
+			assert.NoErrorf(t, params.Validate(), "expected no error for valid params (#%d)", i)
+		} else {
+			assert.Errorf(t, params.Validate(), "expected error for non valid params (#%d)", i)
+		}
+	}
+
+	vals := func(powers ...int64) []*Validator {
+		vals := make([]*Validator, len(powers))
+		for i, power := range powers {
+			vals[i] = NewValidator(ed25519.GenPrivKey().PubKey(), power)
+		}
+		return vals
+	}
+	params := ValidatorParams{PubKeyTypes: valEd25519, MaxPowerShare: 25}
+	assert.NoError(t, params.CheckPowerShare(vals(1, 1, 1, 1)))
+	assert.NoError(t, params.CheckPowerShare(vals(25, 25, 25, 25)), "the share may reach the cap")
+	assert.Error(t, params.CheckPowerShare(vals(26, 25, 25, 24)))
+	assert.Error(t, params.CheckPowerShare(vals(1)))
+	assert.NoError(t, params.CheckPowerShare(nil))
+	// no overflow with the max voting power
+	assert.Error(t, params.CheckPowerShare(vals(MaxTotalVotingPower-3, 1, 1, 1)))
+	assert.NoError(t, params.CheckPowerShare(vals(MaxTotalVotingPower/4, MaxTotalVotingPower/4,
+		MaxTotalVotingPower/4, MaxTotalVotingPower/4)))
+
+	// no cap
+	params.MaxPowerShare = 0
+	assert.NoError(t, params.CheckPowerShare(vals(1)))
+}
+
 func TestConsensusParamsHash(t *testing.T) {
 	params := []ConsensusParams{
 		makeParams(4, 2, 10, 3, valEd25519),
@@ -128,3 +218,61 @@ func TestConsensusParamsUpdate(t *testing.T) {
 		assert.Equal(t, tc.updatedParams, tc.params.Update(tc.updates))
 	}
 }
+
+func TestConsensusParamsUpdateFriday(t *testing.T) {
+	params := *DefaultFridayConsensusParams()
+	updated := params.Update(&abci.ConsensusParams{
+		Block: &abci.BlockParams{
+			MaxBytes:           100,
+			MaxGas:             200,
+			LenUlb:             params.Block.LenULB + 1,
+			TimeoutProposeMs:   1000,
+			TimeoutPrevoteMs:   2000,
+			TimeoutPrecommitMs: 3000,
+			TimeoutCommitMs:    4000,
+			MaxTxBytes:         50,
+		},
+	})
+
+	// the LenULB isn't applied, an update changing it is rejected
+	assert.Equal(t, params.Block.LenULB, updated.Block.LenULB)
+	assert.Error(t, params.ValidateUpdate(&abci.ConsensusParams{Block: &abci.BlockParams{LenUlb: params.Block.LenULB + 1}}))
+	assert.NoError(t, params.ValidateUpdate(&abci.ConsensusParams{Block: &abci.BlockParams{LenUlb: params.Block.LenULB}}))
+	assert.NoError(t, params.ValidateUpdate(&abci.ConsensusParams{Block: &abci.BlockParams{MaxBytes: 100}}))
+	assert.NoError(t, params.ValidateUpdate(nil))
+	assert.EqualValues(t, 1000, updated.Block.TimeoutProposeMs)
+	assert.EqualValues(t, 2000, updated.Block.TimeoutPrevoteMs)
+	assert.EqualValues(t, 3000, updated.Block.TimeoutPrecommitMs)
+	assert.EqualValues(t, 4000, updated.Block.TimeoutCommitMs)
+	assert.EqualValues(t, 50, updated.Block.MaxTxBytes)
+	assert.NoError(t, updated.Validate())
+	assert.Equal(t, updated, params.Update(TM2PB.ConsensusParams(&updated)))
+
+	updated.Block.TimeoutCommitMs = -1
+	assert.Error(t, updated.Validate())
+}
+
+func TestEvidenceParamsMaxAgeDuration(t *testing.T) {
+	params := *DefaultConsensusParams()
+	params.Evidence.MaxAgeDurationMs = -1
+	assert.Error(t, params.Validate())
+
+	params = DefaultConsensusParams().Update(&abci.ConsensusParams{
+		Evidence: &abci.EvidenceParams{MaxAge: 10, MaxAgeDurationMs: 60000},
+	})
+	assert.NoError(t, params.Validate())
+	assert.EqualValues(t, 60000, params.Evidence.MaxAgeDurationMs)
+
+	now := time.Now()
+	// expired by either bound
+	assert.False(t, params.Evidence.IsExpired(90, now.Add(-time.Minute), 100, now))
+	assert.True(t, params.Evidence.IsExpired(89, now, 100, now))
+	assert.True(t, params.Evidence.IsExpired(95, now.Add(-time.Minute-time.Millisecond), 100, now))
+
+	// a height not committed yet has no time
+	assert.False(t, params.Evidence.IsExpired(101, time.Time{}, 100, now))
+
+	// 0 only bounds the heights
+	params.Evidence.MaxAgeDurationMs = 0
+	assert.False(t, params.Evidence.IsExpired(95, now.Add(-time.Hour), 100, now))
+}
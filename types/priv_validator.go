@@ -25,6 +25,15 @@ type ParallelProgressablePV interface {
 	SetImmutableHeight(height int64) error
 }
 
+// ConflictingVoteSigner is implemented by the PrivValidators able to sign a
+// vote conflicting with one they signed, bypassing their protection against
+// double signing. Only for the misbehaviors simulated in private testnets, see
+// ConsensusConfig.SimulateMisbehavior: the FridayFilePV implements it only in
+// the binaries built with the misbehavior tag.
+type ConflictingVoteSigner interface {
+	SignConflictingVote(chainID string, vote *Vote) error
+}
+
 //----------------------------------------
 // Misc.
 
@@ -71,6 +80,15 @@ func (pv *MockPV) GetPubKey() crypto.PubKey {
 	return pv.privKey.PubKey()
 }
 
+// ProofOfPossession returns the proof of possession of the BLS key of the
+// MockPV, or nil if it isn't a BLS key.
+func (pv *MockPV) ProofOfPossession() []byte {
+	if privKey, ok := pv.privKey.(bls.PrivKeyBls); ok {
+		return privKey.ProofOfPossession()
+	}
+	return nil
+}
+
 // Implements PrivValidator.
 func (pv *MockPV) SignVote(chainID string, vote *Vote) error {
 	useChainID := chainID
@@ -16,8 +16,9 @@ import (
 // Use strings to distinguish types in ABCI messages
 
 const (
-	ABCIEvidenceTypeDuplicateVote = "duplicate/vote"
-	ABCIEvidenceTypeMockGood      = "mock/good"
+	ABCIEvidenceTypeDuplicateVote     = "duplicate/vote"
+	ABCIEvidenceTypeDuplicateProposal = "duplicate/proposal"
+	ABCIEvidenceTypeMockGood          = "mock/good"
 )
 
 const (
@@ -93,8 +94,9 @@ func (tm2pb) PartSetHeader(header PartSetHeader) abci.PartSetHeader {
 // XXX: panics on unknown pubkey type
 func (tm2pb) ValidatorUpdate(val *Validator) abci.ValidatorUpdate {
 	return abci.ValidatorUpdate{
-		PubKey: TM2PB.PubKey(val.PubKey),
-		Power:  val.VotingPower,
+		PubKey:            TM2PB.PubKey(val.PubKey),
+		Power:             val.VotingPower,
+		ProofOfPossession: val.ProofOfPossession,
 	}
 }
 
@@ -138,9 +140,17 @@ func (tm2pb) ConsensusParams(params *ConsensusParams) *abci.ConsensusParams {
 			MaxBytes: params.Block.MaxBytes,
 			MaxGas:   params.Block.MaxGas,
 			LenUlb:   params.Block.LenULB,
+
+			TimeoutProposeMs:   params.Block.TimeoutProposeMs,
+			TimeoutPrevoteMs:   params.Block.TimeoutPrevoteMs,
+			TimeoutPrecommitMs: params.Block.TimeoutPrecommitMs,
+			TimeoutCommitMs:    params.Block.TimeoutCommitMs,
+
+			MaxTxBytes: params.Block.MaxTxBytes,
 		},
 		Evidence: &abci.EvidenceParams{
-			MaxAge: params.Evidence.MaxAge,
+			MaxAge:           params.Evidence.MaxAge,
+			MaxAgeDurationMs: params.Evidence.MaxAgeDurationMs,
 		},
 		Validator: &abci.ValidatorParams{
 			PubKeyTypes: params.Validator.PubKeyTypes,
@@ -163,6 +173,8 @@ func (tm2pb) Evidence(ev Evidence, valSet *ValidatorSet, evTime time.Time) abci.
 	switch ev.(type) {
 	case *DuplicateVoteEvidence:
 		evType = ABCIEvidenceTypeDuplicateVote
+	case *DuplicateProposalEvidence:
+		evType = ABCIEvidenceTypeDuplicateProposal
 	case MockGoodEvidence:
 		// XXX: not great to have test types in production paths ...
 		evType = ABCIEvidenceTypeMockGood
@@ -234,6 +246,7 @@ func (pb2tm) ValidatorUpdates(vals []abci.ValidatorUpdate) ([]*Validator, error)
 			return nil, err
 		}
 		tmVals[i] = NewValidator(pub, v.Power)
+		tmVals[i].ProofOfPossession = v.ProofOfPossession
 	}
 	return tmVals, nil
 }
@@ -0,0 +1,198 @@
+package types
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/hdac-io/tendermint/crypto/bls"
+	cmn "github.com/hdac-io/tendermint/libs/common"
+)
+
+// QuorumCert is a compact proof that a block was finalized: the precommits
+// for it of more than 2/3 of the voting power of the validators of its
+// height, with their signatures aggregated into one. Unlike the commit of the
+// block, carried in the block LenULB heights above it, it can be verified
+// with the validator set of the height only.
+//
+// The validators of the height who signed are the true bits of Signers, in
+// the order of the validator set, and Timestamps are the timestamps of their
+// precommits, in the same order, to rebuild the bytes they signed.
+type QuorumCert struct {
+	Height     int64         `json:"height"`
+	Round      int           `json:"round"`
+	BlockID    BlockID       `json:"block_id"`
+	Signers    *cmn.BitArray `json:"signers"`
+	Timestamps []time.Time   `json:"timestamps"`
+	Signature  []byte        `json:"signature"`
+}
+
+// NewQuorumCert returns the QuorumCert of the commit by the validator set of
+// its height, aggregating the signatures of the precommits for its block. The
+// validators must have BLS keys with a proof of possession.
+func NewQuorumCert(commit *Commit, valSet *ValidatorSet) (*QuorumCert, error) {
+	if err := commit.ValidateBasic(); err != nil {
+		return nil, err
+	}
+	if valSet.Size() != len(commit.Precommits) {
+		return nil, NewErrInvalidCommitPrecommits(valSet.Size(), len(commit.Precommits))
+	}
+
+	qc := &QuorumCert{
+		Height:     commit.Height(),
+		Round:      commit.Round(),
+		BlockID:    commit.BlockID,
+		Signers:    cmn.NewBitArray(valSet.Size()),
+		Timestamps: []time.Time{},
+	}
+	var sigs [][]byte
+	for idx, precommit := range commit.Precommits {
+		if precommit == nil || !commit.BlockID.Equals(precommit.BlockID) {
+			continue
+		}
+		if _, val := valSet.GetByIndex(idx); !isBLS(val) {
+			return nil, fmt.Errorf("validator %v doesn't have a BLS key", val.Address)
+		} else if len(val.ProofOfPossession) == 0 {
+			return nil, fmt.Errorf("validator %v doesn't have a proof of possession of its BLS key", val.Address)
+		}
+		qc.Signers.SetIndex(idx, true)
+		qc.Timestamps = append(qc.Timestamps, precommit.Timestamp)
+		sigs = append(sigs, precommit.Signature)
+	}
+	signature, err := bls.AggregateSignatures(sigs)
+	if err != nil {
+		return nil, errors.Wrap(err, "couldn't aggregate the precommits")
+	}
+	qc.Signature = signature
+	return qc, nil
+}
+
+// ValidateBasic performs basic validation that doesn't involve the validator
+// set. Does not verify the signature.
+func (qc *QuorumCert) ValidateBasic() error {
+	if qc.Height <= 0 {
+		return errors.New("non-positive Height")
+	}
+	if qc.Round < 0 {
+		return errors.New("negative Round")
+	}
+	if qc.BlockID.IsZero() {
+		return errors.New("quorum cert cannot be for nil block")
+	}
+	if err := qc.BlockID.ValidateBasic(); err != nil {
+		return fmt.Errorf("wrong BlockID: %v", err)
+	}
+	if qc.Signers == nil {
+		return errors.New("no signers")
+	}
+	signers := 0
+	for idx := 0; idx < qc.Signers.Size(); idx++ {
+		if qc.Signers.GetIndex(idx) {
+			signers++
+		}
+	}
+	if len(qc.Timestamps) != signers {
+		return fmt.Errorf("expected %d timestamps, one per signer, got %d", signers, len(qc.Timestamps))
+	}
+	if len(qc.Signature) == 0 {
+		return errors.New("signature is missing")
+	}
+	return nil
+}
+
+// Verify verifies that the validators of valSet, the validator set of the
+// height of the QuorumCert, holding more than 2/3 of its voting power signed
+// precommits for the block. All the signers must prove the possession of
+// their BLS keys: the BLS keys only sign the first bytes of the precommits,
+// the same for all of them, and the aggregate of the signatures of the same
+// message could be forged with a rogue key made from the others.
+func (qc *QuorumCert) Verify(chainID string, valSet *ValidatorSet) error {
+	if err := qc.ValidateBasic(); err != nil {
+		return err
+	}
+	if valSet.Size() != qc.Signers.Size() {
+		return NewErrInvalidCommitPrecommits(valSet.Size(), qc.Signers.Size())
+	}
+
+	talliedVotingPower := int64(0)
+	pubKeys := make([]bls.PubKeyBls, 0, len(qc.Timestamps))
+	msgs := make([][]byte, 0, len(qc.Timestamps))
+	for idx := 0; idx < qc.Signers.Size(); idx++ {
+		if !qc.Signers.GetIndex(idx) {
+			continue
+		}
+		address, val := valSet.GetByIndex(idx)
+		if !isBLS(val) {
+			return fmt.Errorf("validator %v doesn't have a BLS key", address)
+		}
+		if !val.PubKey.(bls.PubKeyBls).VerifyProofOfPossession(val.ProofOfPossession) {
+			return fmt.Errorf("validator %v doesn't have a valid proof of possession of its BLS key", address)
+		}
+		precommit := &Vote{
+			Type:             PrecommitType,
+			Height:           qc.Height,
+			Round:            qc.Round,
+			BlockID:          qc.BlockID,
+			Timestamp:        qc.Timestamps[len(msgs)],
+			ValidatorAddress: address,
+			ValidatorIndex:   idx,
+		}
+		pubKeys = append(pubKeys, val.PubKey.(bls.PubKeyBls))
+		msgs = append(msgs, precommit.SignBytes(chainID))
+		talliedVotingPower += val.VotingPower
+	}
+
+	if !bls.VerifyAggregateSignature(pubKeys, msgs, qc.Signature) {
+		return ErrVoteInvalidSignature
+	}
+	if talliedVotingPower > valSet.TotalVotingPower()*2/3 {
+		return nil
+	}
+	return errTooMuchChange{talliedVotingPower, valSet.TotalVotingPower()*2/3 + 1}
+}
+
+// Commit returns the QuorumCert as an aggregate commit, for the LastCommit of
+// the block LenULB heights above, with the precommits of the signers without
+// their signatures. valSet is the validator set of the height of the
+// QuorumCert. See Commit.QuorumCert for the inverse.
+func (qc *QuorumCert) Commit(valSet *ValidatorSet) *Commit {
+	precommits := make([]*CommitSig, valSet.Size())
+	signed := 0
+	for idx := range precommits {
+		if !qc.Signers.GetIndex(idx) {
+			continue
+		}
+		address, _ := valSet.GetByIndex(idx)
+		precommits[idx] = &CommitSig{
+			Type:             PrecommitType,
+			Height:           qc.Height,
+			Round:            qc.Round,
+			BlockID:          qc.BlockID,
+			Timestamp:        qc.Timestamps[signed],
+			ValidatorAddress: address,
+			ValidatorIndex:   idx,
+		}
+		signed++
+	}
+	commit := NewCommit(qc.BlockID, precommits)
+	commit.AggregateSignature = qc.Signature
+	return commit
+}
+
+// String returns a string representation of the QuorumCert.
+func (qc *QuorumCert) String() string {
+	if qc == nil {
+		return "nil-QuorumCert"
+	}
+	return fmt.Sprintf("QuorumCert{%v/%02d %v %v %X}",
+		qc.Height, qc.Round, qc.BlockID, qc.Signers, cmn.Fingerprint(qc.Signature))
+}
+
+func isBLS(val *Validator) bool {
+	if val == nil {
+		return false
+	}
+	_, ok := val.PubKey.(bls.PubKeyBls)
+	return ok
+}
@@ -0,0 +1,178 @@
+package types
+
+import (
+	"testing"
+	"time"
+
+	herumi "github.com/hdac-io/bls-go-binary/bls"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hdac-io/tendermint/crypto/bls"
+	cmn "github.com/hdac-io/tendermint/libs/common"
+)
+
+func TestQuorumCert(t *testing.T) {
+	blockID := makeBlockIDRandom()
+	h := int64(3)
+
+	voteSet, valSet, vals := randVoteSet(h, 1, PrecommitType, 4, 1)
+	commit, err := MakeCommit(blockID, h, 1, voteSet, vals)
+	require.NoError(t, err)
+	commit.Precommits[2] = nil
+
+	qc, err := NewQuorumCert(commit, valSet)
+	require.NoError(t, err)
+	assert.Equal(t, h, qc.Height)
+	assert.Equal(t, 1, qc.Round)
+	assert.Equal(t, "BA{4:xx_x}", qc.Signers.String())
+	assert.Len(t, qc.Timestamps, 3)
+	require.NoError(t, qc.Verify(voteSet.ChainID(), valSet))
+
+	// survives a round trip
+	bz, err := cdc.MarshalJSON(qc)
+	require.NoError(t, err)
+	qc2 := new(QuorumCert)
+	require.NoError(t, cdc.UnmarshalJSON(bz, qc2))
+	require.NoError(t, qc2.Verify(voteSet.ChainID(), valSet))
+
+	// another block, chain or validator set
+	qc2.BlockID = makeBlockIDRandom()
+	assert.Error(t, qc2.Verify(voteSet.ChainID(), valSet))
+	assert.Error(t, qc.Verify("other-chain", valSet))
+	_, otherValSet, _ := randVoteSet(h, 1, PrecommitType, 4, 1)
+	assert.Error(t, qc.Verify(voteSet.ChainID(), otherValSet))
+
+	// a signer without its timestamp
+	qc2 = &QuorumCert{}
+	*qc2 = *qc
+	qc2.Timestamps = qc.Timestamps[1:]
+	assert.Error(t, qc2.Verify(voteSet.ChainID(), valSet))
+
+	// not enough voting power
+	commit.Precommits[3] = nil
+	qc, err = NewQuorumCert(commit, valSet)
+	require.NoError(t, err)
+	assert.IsType(t, errTooMuchChange{}, qc.Verify(voteSet.ChainID(), valSet))
+}
+
+func TestQuorumCertRogueKey(t *testing.T) {
+	blockID := makeBlockIDRandom()
+	h := int64(3)
+	_, valSet, _ := randVoteSet(h, 1, PrecommitType, 3, 10)
+
+	// the rogue pubkey is the pubkey of the attacker minus the pubkeys of the
+	// others, so the pubkeys of all the validators add up to the attacker's
+	attacker := bls.GenPrivKey()
+	attackerKey := attacker.PubKey().(bls.PubKeyBls)
+	var rogue herumi.G2
+	require.NoError(t, rogue.Deserialize(attackerKey.Serialize()))
+	for _, val := range valSet.Validators {
+		valKey := val.PubKey.(bls.PubKeyBls)
+		var pubKey herumi.G2
+		require.NoError(t, pubKey.Deserialize(valKey.Serialize()))
+		herumi.G2Sub(&rogue, &rogue, &pubKey)
+	}
+	var rogueKey bls.PubKeyBls
+	require.NoError(t, rogueKey.Deserialize(rogue.Serialize()))
+	// the attacker can't prove the possession of the rogue key
+	rogueVal := NewValidator(rogueKey, 10)
+	rogueVal.ProofOfPossession = attacker.ProofOfPossession()
+	assert.False(t, rogueKey.VerifyProofOfPossession(rogueVal.ProofOfPossession))
+	require.NoError(t, valSet.UpdateWithChangeSet([]*Validator{rogueVal}))
+
+	// the attacker signs a precommit alone for all the validators: the BLS
+	// keys sign the same first bytes of their precommits, so the aggregate
+	// verifies against their pubkeys
+	timestamp := time.Now()
+	precommit := &Vote{Type: PrecommitType, Height: h, Round: 1, BlockID: blockID, Timestamp: timestamp}
+	msg := precommit.SignBytes("test_chain_id")
+	sig, err := attacker.Sign(msg)
+	require.NoError(t, err)
+	pubKeys := make([]bls.PubKeyBls, 0, valSet.Size())
+	msgs := make([][]byte, 0, valSet.Size())
+	for _, val := range valSet.Validators {
+		pubKeys = append(pubKeys, val.PubKey.(bls.PubKeyBls))
+		msgs = append(msgs, msg)
+	}
+	require.True(t, bls.VerifyAggregateSignature(pubKeys, msgs, sig))
+
+	// but not without the proof of possession of all the signers
+	qc := &QuorumCert{
+		Height:     h,
+		Round:      1,
+		BlockID:    blockID,
+		Signers:    cmn.NewBitArray(valSet.Size()),
+		Timestamps: []time.Time{timestamp, timestamp, timestamp, timestamp},
+		Signature:  sig,
+	}
+	for idx := 0; idx < valSet.Size(); idx++ {
+		qc.Signers.SetIndex(idx, true)
+	}
+	err = qc.Verify("test_chain_id", valSet)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "proof of possession")
+
+	// the validator keeps its proof of possession when its power changes
+	_, val := valSet.GetByIndex(0)
+	pop := val.ProofOfPossession
+	require.NotEmpty(t, pop)
+	require.NoError(t, valSet.UpdateWithChangeSet([]*Validator{NewValidator(val.PubKey, 20)}))
+	_, val = valSet.GetByAddress(val.Address)
+	assert.Equal(t, pop, val.ProofOfPossession)
+}
+
+func TestQuorumCertCommit(t *testing.T) {
+	blockID := makeBlockIDRandom()
+	h := int64(3)
+
+	voteSet, valSet, vals := randVoteSet(h, 1, PrecommitType, 4, 1)
+	commit, err := MakeCommit(blockID, h, 1, voteSet, vals)
+	require.NoError(t, err)
+	commit.Precommits[2] = nil
+	qc, err := NewQuorumCert(commit, valSet)
+	require.NoError(t, err)
+
+	// the aggregate commit verifies like the commit, and is the QuorumCert
+	aggCommit := qc.Commit(valSet)
+	require.True(t, aggCommit.IsAggregate())
+	require.NoError(t, aggCommit.ValidateBasic())
+	assert.Nil(t, aggCommit.Precommits[2])
+	assert.Equal(t, commit.BitArray(), aggCommit.BitArray())
+	assert.Equal(t, commit.Precommits[1].Timestamp, aggCommit.Precommits[1].Timestamp)
+	assert.Empty(t, aggCommit.Precommits[1].Signature)
+	assert.Equal(t, qc, aggCommit.QuorumCert())
+	assert.NotEqual(t, commit.Hash(), aggCommit.Hash())
+	require.NoError(t, valSet.VerifyCommit(voteSet.ChainID(), blockID, h, aggCommit))
+	require.NoError(t, valSet.VerifyFutureCommit(valSet, voteSet.ChainID(), blockID, h, aggCommit))
+	assert.False(t, commit.IsAggregate())
+	assert.Nil(t, commit.QuorumCert())
+
+	// survives a round trip
+	bz, err := cdc.MarshalBinaryBare(aggCommit)
+	require.NoError(t, err)
+	aggCommit2 := new(Commit)
+	require.NoError(t, cdc.UnmarshalBinaryBare(bz, aggCommit2))
+	assert.Equal(t, aggCommit.Hash(), aggCommit2.Hash())
+	require.NoError(t, valSet.VerifyCommit(voteSet.ChainID(), blockID, h, aggCommit2))
+
+	// another block, or a signer dropped
+	assert.Error(t, valSet.VerifyCommit(voteSet.ChainID(), makeBlockIDRandom(), h, aggCommit2))
+	aggCommit2 = qc.Commit(valSet)
+	aggCommit2.Precommits[0] = nil
+	assert.Error(t, valSet.VerifyCommit(voteSet.ChainID(), blockID, h, aggCommit2))
+
+	// a precommit with its signature or for another block
+	aggCommit2 = qc.Commit(valSet)
+	aggCommit2.Precommits[0].Signature = commit.Precommits[0].Signature
+	assert.Error(t, aggCommit2.ValidateBasic())
+	aggCommit2 = qc.Commit(valSet)
+	aggCommit2.Precommits[0].BlockID = BlockID{}
+	assert.Error(t, aggCommit2.ValidateBasic())
+
+	// a non signer claimed to have signed
+	aggCommit2 = qc.Commit(valSet)
+	aggCommit2.Precommits[2] = aggCommit2.Precommits[3]
+	aggCommit2.Precommits[2].ValidatorIndex = 2
+	assert.Error(t, valSet.VerifyCommit(voteSet.ChainID(), blockID, h, aggCommit2))
+}
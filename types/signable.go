@@ -1,6 +1,8 @@
 package types
 
 import (
+	"github.com/hdac-io/tendermint/crypto"
+	"github.com/hdac-io/tendermint/crypto/batch"
 	"github.com/hdac-io/tendermint/crypto/ed25519"
 	cmn "github.com/hdac-io/tendermint/libs/common"
 )
@@ -21,3 +23,30 @@ var (
 type Signable interface {
 	SignBytes(chainID string) []byte
 }
+
+// verifySignatures returns whether each signature of msgs by pubKeys is
+// valid. The signatures are verified in a batch if the keys are all of a type
+// supporting batch verification, and one by one otherwise.
+func verifySignatures(pubKeys []crypto.PubKey, msgs, sigs [][]byte) []bool {
+	if len(pubKeys) > 1 {
+		if bv, ok := batch.CreateBatchVerifier(pubKeys[0]); ok {
+			added := true
+			for i, pubKey := range pubKeys {
+				if err := bv.Add(pubKey, msgs[i], sigs[i]); err != nil {
+					added = false
+					break
+				}
+			}
+			if added {
+				_, valid := bv.Verify()
+				return valid
+			}
+		}
+	}
+
+	valid := make([]bool, len(pubKeys))
+	for i, pubKey := range pubKeys {
+		valid[i] = pubKey.VerifyBytes(msgs[i], sigs[i])
+	}
+	return valid
+}
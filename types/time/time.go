@@ -56,3 +56,19 @@ func WeightedMedian(weightedTimes []*WeightedTime, totalVotingPower int64) (res
 	}
 	return
 }
+
+// Clock is a source of the current time, so it can be replaced in tests or
+// synchronized from another source than the system clock.
+type Clock interface {
+	Now() time.Time
+}
+
+// SystemClock is the Clock of Now.
+type SystemClock struct{}
+
+var _ Clock = SystemClock{}
+
+// Now implements Clock.
+func (SystemClock) Now() time.Time {
+	return Now()
+}
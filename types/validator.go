@@ -18,6 +18,11 @@ type Validator struct {
 	VotingPower int64         `json:"voting_power"`
 
 	ProposerPriority int64 `json:"proposer_priority"`
+
+	// ProofOfPossession of the private key of a BLS PubKey, required to
+	// aggregate its signatures with the others (see bls.VerifyProofOfPossession).
+	// Like ProposerPriority, it isn't included in Validator.Hash().
+	ProofOfPossession []byte `json:"proof_of_possession,omitempty"`
 }
 
 func NewValidator(pubKey crypto.PubKey, votingPower int64) *Validator {
@@ -107,5 +112,6 @@ func RandValidator(randPower bool, minPower int64) (*Validator, PrivValidator) {
 	}
 	pubKey := privVal.GetPubKey()
 	val := NewValidator(pubKey, votePower)
+	val.ProofOfPossession = privVal.ProofOfPossession()
 	return val, privVal
 }
@@ -10,6 +10,7 @@ import (
 
 	"github.com/pkg/errors"
 
+	"github.com/hdac-io/tendermint/crypto"
 	"github.com/hdac-io/tendermint/crypto/merkle"
 )
 
@@ -452,6 +453,10 @@ func (vals *ValidatorSet) applyUpdates(updates []*Validator) {
 			merged[i] = updates[0]
 			if bytes.Equal(existing[0].Address, updates[0].Address) {
 				// Validator is present in both, advance existing.
+				// Keep its proof of possession if the update has none.
+				if len(updates[0].ProofOfPossession) == 0 {
+					updates[0].ProofOfPossession = existing[0].ProofOfPossession
+				}
 				existing = existing[1:]
 			}
 			updates = updates[1:]
@@ -588,6 +593,7 @@ func (vals *ValidatorSet) UpdateWithChangeSet(changes []*Validator) error {
 }
 
 // Verify that +2/3 of the set had signed the given signBytes.
+// An aggregate commit is verified as the QuorumCert it is.
 func (vals *ValidatorSet) VerifyCommit(chainID string, blockID BlockID, height int64, commit *Commit) error {
 
 	if err := commit.ValidateBasic(); err != nil {
@@ -603,20 +609,19 @@ func (vals *ValidatorSet) VerifyCommit(chainID string, blockID BlockID, height i
 		return fmt.Errorf("Invalid commit -- wrong block id: want %v got %v",
 			blockID, commit.BlockID)
 	}
+	if commit.IsAggregate() {
+		return commit.QuorumCert().Verify(chainID, vals)
+	}
 
 	talliedVotingPower := int64(0)
+	sigs := newCommitSigs(len(commit.Precommits))
 
 	for idx, precommit := range commit.Precommits {
 		if precommit == nil {
 			continue // OK, some precommits can be missing.
 		}
 		_, val := vals.GetByIndex(idx)
-		// Validate signature.
-		precommitSignBytes := commit.VoteSignBytes(chainID, idx)
-		if !val.PubKey.VerifyBytes(precommitSignBytes, precommit.Signature) {
-			return fmt.Errorf("Invalid commit -- invalid signature: %v", precommit)
-		}
-		// Good precommit!
+		sigs.add(chainID, commit, idx, val.PubKey)
 		if blockID.Equals(precommit.BlockID) {
 			talliedVotingPower += val.VotingPower
 		}
@@ -625,6 +630,10 @@ func (vals *ValidatorSet) VerifyCommit(chainID string, blockID BlockID, height i
 		// precommits to measure validator availability.
 		// }
 	}
+	// Validate the signatures, in a batch if possible.
+	if err := sigs.verify(commit); err != nil {
+		return err
+	}
 
 	if talliedVotingPower > vals.TotalVotingPower()*2/3 {
 		return nil
@@ -674,6 +683,7 @@ func (vals *ValidatorSet) VerifyFutureCommit(newSet *ValidatorSet, chainID strin
 	// Check old voting power.
 	oldVotingPower := int64(0)
 	seen := map[int]bool{}
+	sigs := newCommitSigs(len(commit.Precommits))
 	round := commit.Round()
 
 	for idx, precommit := range commit.Precommits {
@@ -695,13 +705,10 @@ func (vals *ValidatorSet) VerifyFutureCommit(newSet *ValidatorSet, chainID strin
 			continue // missing or double vote...
 		}
 		seen[oldIdx] = true
-
-		// Validate signature.
-		precommitSignBytes := commit.VoteSignBytes(chainID, idx)
-		if !val.PubKey.VerifyBytes(precommitSignBytes, precommit.Signature) {
-			return errors.Errorf("Invalid commit -- invalid signature: %v", precommit)
+		// the aggregate signature was verified with the same keys by newSet
+		if !commit.IsAggregate() {
+			sigs.add(chainID, commit, idx, val.PubKey)
 		}
-		// Good precommit!
 		if blockID.Equals(precommit.BlockID) {
 			oldVotingPower += val.VotingPower
 		}
@@ -710,6 +717,10 @@ func (vals *ValidatorSet) VerifyFutureCommit(newSet *ValidatorSet, chainID strin
 		// precommits to measure validator availability.
 		// }
 	}
+	// Validate the signatures, in a batch if possible.
+	if err := sigs.verify(commit); err != nil {
+		return err
+	}
 
 	if oldVotingPower <= oldVals.TotalVotingPower()*2/3 {
 		return errTooMuchChange{oldVotingPower, oldVals.TotalVotingPower()*2/3 + 1}
@@ -717,6 +728,41 @@ func (vals *ValidatorSet) VerifyFutureCommit(newSet *ValidatorSet, chainID strin
 	return nil
 }
 
+// commitSigs are the signatures of the precommits of a commit to verify.
+type commitSigs struct {
+	idxs    []int
+	pubKeys []crypto.PubKey
+	msgs    [][]byte
+	sigs    [][]byte
+}
+
+func newCommitSigs(size int) *commitSigs {
+	return &commitSigs{
+		idxs:    make([]int, 0, size),
+		pubKeys: make([]crypto.PubKey, 0, size),
+		msgs:    make([][]byte, 0, size),
+		sigs:    make([][]byte, 0, size),
+	}
+}
+
+// add adds the signature of the precommit at idx by pubKey.
+func (cs *commitSigs) add(chainID string, commit *Commit, idx int, pubKey crypto.PubKey) {
+	cs.idxs = append(cs.idxs, idx)
+	cs.pubKeys = append(cs.pubKeys, pubKey)
+	cs.msgs = append(cs.msgs, commit.VoteSignBytes(chainID, idx))
+	cs.sigs = append(cs.sigs, commit.Precommits[idx].Signature)
+}
+
+// verify returns an error for the first invalid signature.
+func (cs *commitSigs) verify(commit *Commit) error {
+	for i, ok := range verifySignatures(cs.pubKeys, cs.msgs, cs.sigs) {
+		if !ok {
+			return errors.Errorf("Invalid commit -- invalid signature: %v", commit.Precommits[cs.idxs[i]])
+		}
+	}
+	return nil
+}
+
 //-----------------
 // ErrTooMuchChange
 
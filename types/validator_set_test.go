@@ -10,6 +10,7 @@ import (
 	"testing/quick"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	"github.com/hdac-io/tendermint/crypto"
 	"github.com/hdac-io/tendermint/crypto/ed25519"
@@ -587,6 +588,28 @@ func TestValidatorSetVerifyCommit(t *testing.T) {
 	assert.Nil(t, err)
 }
 
+func TestValidatorSetVerifyCommitBatch(t *testing.T) {
+	height := int64(3)
+	blockID := makeBlockIDRandom()
+	voteSet, vset, vals := randVoteSet(height, 1, PrecommitType, 10, 1)
+	commit, err := MakeCommit(blockID, height, 1, voteSet, vals)
+	require.NoError(t, err)
+	chainID := voteSet.ChainID()
+
+	assert.NoError(t, vset.VerifyCommit(chainID, blockID, height, commit))
+	assert.NoError(t, vset.VerifyFutureCommit(vset, chainID, blockID, height, commit))
+
+	// the invalid signature is reported, even if the others are enough
+	commit.Precommits[3].Signature[0] ^= 0x01
+	err = vset.VerifyCommit(chainID, blockID, height, commit)
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "invalid signature")
+		assert.Contains(t, err.Error(), commit.Precommits[3].String())
+	}
+	err = vset.VerifyFutureCommit(vset, chainID, blockID, height, commit)
+	assert.Error(t, err)
+}
+
 func TestEmptySet(t *testing.T) {
 
 	var valList []*Validator
@@ -58,6 +58,16 @@ type Vote struct {
 	ValidatorAddress Address       `json:"validator_address"`
 	ValidatorIndex   int           `json:"validator_index"`
 	Signature        []byte        `json:"signature"`
+
+	// set by BatchVerifyVotes, for Verify not to verify the signature again
+	verified *voteVerification
+}
+
+// voteVerification is a signature of a vote verified in a batch.
+type voteVerification struct {
+	pubKey    crypto.PubKey
+	signBytes []byte
+	signature []byte
 }
 
 // CommitSig converts the Vote to a CommitSig.
@@ -67,6 +77,7 @@ func (vote *Vote) CommitSig() *CommitSig {
 		return nil
 	}
 	cs := CommitSig(*vote)
+	cs.verified = nil
 	return &cs
 }
 
@@ -115,12 +126,40 @@ func (vote *Vote) Verify(chainID string, pubKey crypto.PubKey) error {
 		return ErrVoteInvalidValidatorAddress
 	}
 
-	if !pubKey.VerifyBytes(vote.SignBytes(chainID), vote.Signature) {
+	signBytes := vote.SignBytes(chainID)
+	if v := vote.verified; v != nil && v.pubKey.Equals(pubKey) &&
+		bytes.Equal(v.signBytes, signBytes) && bytes.Equal(v.signature, vote.Signature) {
+		return nil
+	}
+	if !pubKey.VerifyBytes(signBytes, vote.Signature) {
 		return ErrVoteInvalidSignature
 	}
 	return nil
 }
 
+// BatchVerifyVotes verifies the signatures of votes by pubKeys in a batch,
+// and marks the valid ones for Verify not to verify them again. It returns
+// whether each signature is valid.
+func BatchVerifyVotes(chainID string, votes []*Vote, pubKeys []crypto.PubKey) []bool {
+	signBytes := make([][]byte, len(votes))
+	sigs := make([][]byte, len(votes))
+	for i, vote := range votes {
+		signBytes[i] = vote.SignBytes(chainID)
+		sigs[i] = vote.Signature
+	}
+	valid := verifySignatures(pubKeys, signBytes, sigs)
+	for i, vote := range votes {
+		if valid[i] {
+			vote.verified = &voteVerification{
+				pubKey:    pubKeys[i],
+				signBytes: signBytes[i],
+				signature: append([]byte(nil), vote.Signature...),
+			}
+		}
+	}
+	return valid
+}
+
 // ValidateBasic performs basic validation.
 func (vote *Vote) ValidateBasic() error {
 	if !IsVoteTypeValid(vote.Type) {
@@ -222,6 +222,42 @@ func TestVoteVerify(t *testing.T) {
 	}
 }
 
+func TestBatchVerifyVotes(t *testing.T) {
+	const chainID = "test_chain_id"
+	var (
+		votes   []*Vote
+		pubKeys []crypto.PubKey
+	)
+	for i := 0; i < 4; i++ {
+		privVal := NewMockPV()
+		vote := examplePrecommit()
+		vote.ValidatorAddress = privVal.GetPubKey().Address()
+		vote.ValidatorIndex = i
+		require.NoError(t, privVal.SignVote(chainID, vote))
+		votes = append(votes, vote)
+		pubKeys = append(pubKeys, privVal.GetPubKey())
+	}
+	votes[2].Signature[0] ^= 0x01
+
+	assert.Equal(t, []bool{true, true, false, true}, BatchVerifyVotes(chainID, votes, pubKeys))
+	for i, vote := range votes {
+		if i == 2 {
+			assert.Equal(t, ErrVoteInvalidSignature, vote.Verify(chainID, pubKeys[i]))
+		} else {
+			assert.NoError(t, vote.Verify(chainID, pubKeys[i]))
+		}
+	}
+
+	// a vote changed after its verification is verified again
+	votes[0].Round++
+	assert.Equal(t, ErrVoteInvalidSignature, votes[0].Verify(chainID, pubKeys[0]))
+	// and so is a signature changed in place
+	votes[3].Signature[0] ^= 0x01
+	assert.Equal(t, ErrVoteInvalidSignature, votes[3].Verify(chainID, pubKeys[3]))
+	// and a verified vote isn't carried into commits
+	assert.Nil(t, votes[1].CommitSig().verified)
+}
+
 func TestMaxVoteBytes(t *testing.T) {
 	// time is varint encoded so need to pick the max.
 	// year int, month Month, day, hour, min, sec, nsec int, loc *Location
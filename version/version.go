@@ -43,7 +43,12 @@ var (
 
 	// BlockProtocol versions all block data structures and processing.
 	// This includes validity of blocks and state updates.
-	BlockProtocol Protocol = 10
+	BlockProtocol Protocol = 11
+
+	// AggregateCommitBlockProtocol is the first BlockProtocol whose friday
+	// blocks can carry the QuorumCert of the height LenULB below them as
+	// their LastCommit, instead of its precommits.
+	AggregateCommitBlockProtocol Protocol = 11
 )
 
 //------------------------------------------------------------------------